@@ -0,0 +1,10 @@
+// Package api contains the OpenAPI specification of the Yunikorn History Server REST API.
+// The Go client in pkg/client is generated from this specification.
+package api
+
+import _ "embed"
+
+// Spec is the OpenAPI specification in YAML format.
+//
+//go:embed openapi.yaml
+var Spec []byte
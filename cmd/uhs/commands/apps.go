@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/yunikorn-history-server/pkg/client"
+)
+
+const defaultPartition = "default"
+
+// applicationsQuery contains the flags for querying the applications of a queue.
+type applicationsQuery struct {
+	Partition string
+	Queue     string
+	User      string
+	Since     time.Duration
+}
+
+// params returns the API parameters of the query, resolving the since duration relative to now.
+func (q *applicationsQuery) params(now time.Time) *client.GetAppsPerPartitionPerQueueParams {
+	params := &client.GetAppsPerPartitionPerQueueParams{}
+	if q.User != "" {
+		params.User = &q.User
+	}
+	if q.Since > 0 {
+		submissionStartTime := now.Add(-q.Since).UTC().Format(time.RFC3339)
+		params.SubmissionStartTime = &submissionStartTime
+	}
+	return params
+}
+
+func newAppsCmd() *cobra.Command {
+	appsCmd := &cobra.Command{
+		Use:   "apps",
+		Short: "Query applications.",
+	}
+	appsCmd.AddCommand(newAppsListCmd())
+	return appsCmd
+}
+
+func newAppsListCmd() *cobra.Command {
+	q := applicationsQuery{Partition: defaultPartition}
+	var limit, offset int
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the applications of a queue.",
+		Long: `List the applications of a queue, ordered by submission time in descending order.
+Example: uhs apps list --queue root.default --since 24h -o csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if q.Queue == "" {
+				return errors.New("queue is required")
+			}
+			c, err := newClientFromFlags()
+			if err != nil {
+				return err
+			}
+			params := q.params(time.Now())
+			if limit > 0 {
+				params.Limit = &limit
+			}
+			if offset > 0 {
+				params.Offset = &offset
+			}
+			apps, err := c.ListApplications(cmd.Context(), q.Partition, q.Queue, params)
+			if err != nil {
+				return err
+			}
+			return writeApplications(cmd.OutOrStdout(), Output, apps)
+		},
+	}
+	addApplicationsQueryFlags(listCmd, &q)
+	listCmd.Flags().IntVar(&limit, "limit", limit, "maximum number of applications to list")
+	listCmd.Flags().IntVar(&offset, "offset", offset, "number of applications to skip")
+	return listCmd
+}
+
+func addApplicationsQueryFlags(cmd *cobra.Command, q *applicationsQuery) {
+	cmd.Flags().StringVarP(&q.Partition, "partition", "p", q.Partition, "partition of the queue")
+	cmd.Flags().StringVarP(&q.Queue, "queue", "q", q.Queue, "fully qualified name of the queue, e.g. root.default")
+	cmd.Flags().StringVarP(&q.User, "user", "u", q.User, "only include applications submitted by this user")
+	cmd.Flags().DurationVar(&q.Since, "since", q.Since, "only include applications submitted within this duration, e.g. 24h")
+}
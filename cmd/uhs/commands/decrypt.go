@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/yunikorn-history-server/internal/envelope"
+)
+
+func newDecryptCmd() *cobra.Command {
+	var identityFile, file string
+	var kms bool
+	decryptCmd := &cobra.Command{
+		Use:   "decrypt [encrypted export]",
+		Short: "Decrypt an encrypted export.",
+		Long: `Decrypt an export encrypted in the age format, read from the file or stdin.
+Exports are decrypted with the age identities of the --identity file, or with AWS KMS with --kms, using the
+credentials of the default AWS credential chain. Exports encrypted for age recipients can also be decrypted with age.
+Example: uhs decrypt --kms --file apps.ndjson 0c8f3a2e-5d1b-4b9e-9f6e-2f1a7c4d8e90.ndjson.age`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var identities []envelope.Identity
+			if identityFile != "" {
+				f, err := os.Open(identityFile)
+				if err != nil {
+					return fmt.Errorf("could not open identity file: %v", err)
+				}
+				parsed, err := envelope.ParseIdentities(f)
+				_ = f.Close()
+				if err != nil {
+					return fmt.Errorf("could not read identity file: %v", err)
+				}
+				identities = append(identities, parsed...)
+			}
+			if kms {
+				identity, err := envelope.NewKMSIdentity(cmd.Context())
+				if err != nil {
+					return err
+				}
+				identities = append(identities, identity)
+			}
+			if len(identities) == 0 {
+				return errors.New("an identity file or --kms is required")
+			}
+
+			var r io.Reader = cmd.InOrStdin()
+			if len(args) == 1 {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("could not open encrypted export: %v", err)
+				}
+				defer func() { _ = f.Close() }()
+				r = f
+			}
+			var w io.Writer = cmd.OutOrStdout()
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return fmt.Errorf("could not create decrypted export file: %v", err)
+				}
+				defer func() { _ = f.Close() }()
+				w = f
+			}
+
+			decrypted, err := envelope.Decrypt(cmd.Context(), r, identities...)
+			if err != nil {
+				return fmt.Errorf("could not decrypt export: %w", err)
+			}
+			if _, err := io.Copy(w, decrypted); err != nil {
+				return fmt.Errorf("could not decrypt export: %w", err)
+			}
+			return nil
+		},
+	}
+	decryptCmd.Flags().StringVarP(&identityFile, "identity", "i", identityFile, "file of the age identities to decrypt with")
+	decryptCmd.Flags().BoolVar(&kms, "kms", kms, "decrypt with the AWS KMS keys the export is encrypted with")
+	decryptCmd.Flags().StringVarP(&file, "file", "f", file, "file to write the decrypted export to, defaults to stdout")
+	return decryptCmd
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// exportPageSize is the number of applications fetched per request when exporting.
+const exportPageSize = 500
+
+func newExportCmd() *cobra.Command {
+	q := applicationsQuery{Partition: defaultPartition}
+	var file string
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all applications of a queue.",
+		Long: `Export all applications of a queue, fetching them page by page.
+The output format is controlled by the --output flag, use json or csv for machine-readable exports.
+Example: uhs export --queue root.default --since 168h -o csv --file apps.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if q.Queue == "" {
+				return errors.New("queue is required")
+			}
+			c, err := newClientFromFlags()
+			if err != nil {
+				return err
+			}
+
+			apps, err := c.IterateApplications(q.Partition, q.Queue, q.params(time.Now()), exportPageSize).All(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return fmt.Errorf("could not create export file: %v", err)
+				}
+				defer func() { _ = f.Close() }()
+				w = f
+			}
+			if err := writeApplications(w, Output, apps); err != nil {
+				return fmt.Errorf("could not write export: %v", err)
+			}
+			if file != "" {
+				cmd.PrintErrf("exported %d applications to %s\n", len(apps), file)
+			}
+			return nil
+		},
+	}
+	addApplicationsQueryFlags(exportCmd, &q)
+	exportCmd.Flags().StringVarP(&file, "file", "f", file, "file to write the export to, defaults to stdout")
+	return exportCmd
+}
@@ -0,0 +1,14 @@
+package commands
+
+import "time"
+
+var (
+	// Server is the base URL of the Yunikorn History Server
+	Server = "http://localhost:8989"
+	// APIKey is the API key used to authenticate against the Yunikorn History Server
+	APIKey string
+	// Timeout is the timeout of a single request to the Yunikorn History Server
+	Timeout = 30 * time.Second
+	// Output is the output format, one of table, json or csv
+	Output = outputTable
+)
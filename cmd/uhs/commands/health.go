@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+func newHealthCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "health",
+		Short: "Check the health of the Yunikorn History Server.",
+		Long: `Check the liveness and readiness of the Yunikorn History Server.
+The command exits with a non-zero status if the server is not healthy.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClientFromFlags()
+			if err != nil {
+				return err
+			}
+			liveness, err := c.Liveness(cmd.Context())
+			if err != nil {
+				return err
+			}
+			readiness, err := c.Readiness(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := writeHealth(cmd.OutOrStdout(), Output, &healthReport{Liveness: liveness, Readiness: readiness}); err != nil {
+				return err
+			}
+			if !liveness.Healthy || !readiness.Healthy {
+				return errors.New("yunikorn history server is not healthy")
+			}
+			return nil
+		},
+	}
+}
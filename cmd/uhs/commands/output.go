@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/pkg/client"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+// write writes the data to w in the given format.
+// The JSON format encodes data as is, while the table and CSV formats use the header and rows.
+func write(w io.Writer, format string, data any, header []string, rows [][]string) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case outputCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case outputTable:
+		writer := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		if _, err := fmt.Fprintln(writer, strings.Join(header, "\t")); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(writer, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+var applicationsHeader = []string{"APPLICATION ID", "PARTITION", "QUEUE", "USER", "STATE", "SUBMITTED", "FINISHED"}
+
+func applicationRows(apps []client.Application) [][]string {
+	rows := make([][]string, 0, len(apps))
+	for _, app := range apps {
+		rows = append(rows, []string{
+			app.ApplicationID,
+			app.Partition,
+			app.QueueName,
+			value(app.User),
+			value(app.ApplicationState),
+			formatUnixNano(value(app.SubmissionTime)),
+			formatUnixNano(value(app.FinishedTime)),
+		})
+	}
+	return rows
+}
+
+func writeApplications(w io.Writer, format string, apps []client.Application) error {
+	return write(w, format, apps, applicationsHeader, applicationRows(apps))
+}
+
+// healthReport combines the liveness and readiness reports of the Yunikorn History Server.
+type healthReport struct {
+	Liveness  *client.LivenessStatus  `json:"liveness"`
+	Readiness *client.ReadinessStatus `json:"readiness"`
+}
+
+func writeHealth(w io.Writer, format string, report *healthReport) error {
+	header := []string{"CHECK", "HEALTHY", "ERROR"}
+	rows := [][]string{
+		{"liveness", strconv.FormatBool(report.Liveness.Healthy), ""},
+		{"readiness", strconv.FormatBool(report.Readiness.Healthy), ""},
+	}
+	for _, component := range report.Readiness.ComponentStatuses {
+		rows = append(rows, []string{"readiness/" + component.Identifier, strconv.FormatBool(component.Healthy), value(component.Error)})
+	}
+	return write(w, format, report, header, rows)
+}
+
+// formatUnixNano formats a Unix timestamp in nanoseconds, as reported by Yunikorn, in RFC3339 format.
+func formatUnixNano(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(0, ts).UTC().Format(time.RFC3339)
+}
+
+// value returns the value of an optional field, or its zero value if not set.
+func value[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
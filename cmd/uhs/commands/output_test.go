@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/pkg/client"
+)
+
+func TestWriteApplications(t *testing.T) {
+	apps := []client.Application{
+		{
+			ApplicationID:    "app-1",
+			Partition:        "default",
+			QueueName:        "root.test",
+			User:             util.ToPtr("alice"),
+			ApplicationState: util.ToPtr("Completed"),
+			SubmissionTime:   util.ToPtr(int64(1700000000000000000)),
+			FinishedTime:     util.ToPtr(int64(1700000060000000000)),
+		},
+	}
+
+	tests := map[string]struct {
+		format string
+		want   string
+	}{
+		"table": {
+			format: outputTable,
+			want: "APPLICATION ID   PARTITION   QUEUE       USER    STATE       SUBMITTED              FINISHED\n" +
+				"app-1            default     root.test   alice   Completed   2023-11-14T22:13:20Z   2023-11-14T22:14:20Z\n",
+		},
+		"csv": {
+			format: outputCSV,
+			want: "APPLICATION ID,PARTITION,QUEUE,USER,STATE,SUBMITTED,FINISHED\n" +
+				"app-1,default,root.test,alice,Completed,2023-11-14T22:13:20Z,2023-11-14T22:14:20Z\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeApplications(&buf, tc.format, apps))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeApplications(&buf, outputJSON, apps))
+		assert.Contains(t, buf.String(), `"applicationID": "app-1"`)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		assert.Error(t, writeApplications(&bytes.Buffer{}, "yaml", apps))
+	})
+}
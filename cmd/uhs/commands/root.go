@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/yunikorn-history-server/pkg/client"
+)
+
+const (
+	envServer = "UHS_SERVER"
+	envAPIKey = "UHS_API_KEY"
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "uhs",
+	Short: "uhs is a command line client for the Yunikorn History Server.",
+	Long: `uhs is a command line client for the Yunikorn History Server API.
+The server URL and API key can be provided using flags or the ` + envServer + ` and ` + envAPIKey + ` environment variables.`,
+	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		switch Output {
+		case outputTable, outputJSON, outputCSV:
+			return nil
+		default:
+			return fmt.Errorf("invalid output format %q, must be one of %s, %s or %s", Output, outputTable, outputJSON, outputCSV)
+		}
+	},
+}
+
+func New() *cobra.Command {
+	if server := os.Getenv(envServer); server != "" {
+		Server = server
+	}
+	APIKey = os.Getenv(envAPIKey)
+
+	rootCmd.PersistentFlags().StringVarP(&Server, "server", "s", Server, "base URL of the Yunikorn History Server")
+	rootCmd.PersistentFlags().StringVar(&APIKey, "api-key", APIKey, "API key used to authenticate against the Yunikorn History Server")
+	rootCmd.PersistentFlags().DurationVar(&Timeout, "timeout", Timeout, "timeout of a single request")
+	rootCmd.PersistentFlags().StringVarP(&Output, "output", "o", Output, "output format, one of table, json or csv")
+	rootCmd.AddCommand(newAppsCmd())
+	rootCmd.AddCommand(newExportCmd())
+	rootCmd.AddCommand(newDecryptCmd())
+	rootCmd.AddCommand(newHealthCmd())
+	return rootCmd
+}
+
+func newClientFromFlags() (*client.Client, error) {
+	return client.New(Server, client.WithAPIKey(APIKey), client.WithTimeout(Timeout), client.WithUserAgent("uhs"))
+}
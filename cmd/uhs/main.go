@@ -0,0 +1,15 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/G-Research/yunikorn-history-server/cmd/uhs/commands"
+)
+
+func main() {
+	ctx := context.Background()
+	if err := commands.New().ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
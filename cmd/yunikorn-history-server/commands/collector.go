@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/oklog/run"
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/yunikorn-history-server/cmd/yunikorn-history-server/info"
+	"github.com/G-Research/yunikorn-history-server/internal/collector"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/egress"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/secrets"
+	"github.com/G-Research/yunikorn-history-server/internal/transform"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
+)
+
+// collectorCmd represents the collector command which forwards the data of Yunikorn to a central server
+var collectorCmd = &cobra.Command{
+	Use:   "collector",
+	Short: "Forward the data of Yunikorn to a central history server.",
+	Long: `Run a collector next to Yunikorn which syncs its data and event stream, and forwards them in batches to the
+central history server configured with collector.server_url, which must have ingestion enabled.
+The collector does not connect to the database.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.New(ConfigFile)
+		if err != nil {
+			return err
+		}
+
+		return RunCollector(context.Background(), cfg)
+	},
+}
+
+// RunCollector is the main entry point for the collector.
+func RunCollector(ctx context.Context, cfg *config.Config) error {
+	if cfg.CollectorConfig.ServerURL == "" {
+		return errors.New("the collector requires collector.server_url")
+	}
+	if err := cfg.YunikornConfig.Validate(); err != nil {
+		return err
+	}
+
+	log.Init(&cfg.LogConfig)
+
+	log.Logger.Infow(
+		"starting yunikorn history collector",
+		"version", info.Version, "buildTime", info.BuildTime, "commit", info.Commit,
+	)
+
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
+	defer cancel()
+
+	// the outbound requests, including those of the secret store, are sent as the egress configuration specifies
+	if err := egress.Install(&cfg.EgressConfig); err != nil {
+		return fmt.Errorf("invalid egress config: %w", err)
+	}
+	provider, err := secrets.New(ctx, &cfg.SecretsConfig)
+	if err != nil {
+		return fmt.Errorf("could not create secret provider: %w", err)
+	}
+	if err := secrets.NewResolver(provider, 0).ResolveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("could not resolve secrets: %w", err)
+	}
+
+	transformer, err := transform.New(&cfg.TransformConfig)
+	if err != nil {
+		return fmt.Errorf("invalid transform config: %w", err)
+	}
+	forwarder, err := collector.NewForwarder(&cfg.CollectorConfig)
+	if err != nil {
+		return err
+	}
+	service := yunikorn.NewService(
+		forwarder,
+		forwarder,
+		yunikorn.NewRESTClient(&cfg.YunikornConfig),
+		yunikorn.WithSyncInterval(cfg.YHSConfig.DataSyncInterval),
+		yunikorn.WithTransformer(transformer),
+		yunikorn.WithCluster(cfg.YunikornConfig.ClusterID),
+	)
+
+	g := run.Group{}
+	g.Add(
+		func() error {
+			return service.Run(ctx)
+		},
+		func(err error) {},
+	)
+	g.Add(
+		func() error {
+			return forwarder.Run(ctx)
+		},
+		func(err error) {},
+	)
+
+	if err = g.Run(); err != nil {
+		log.Logger.Warnf("group stopped because of an error: %v", err)
+	}
+
+	return nil
+}
+
+func newCollectorCmd() *cobra.Command {
+	return collectorCmd
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// configCmd groups the commands which inspect the configuration
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration.",
+}
+
+// configSchemaCmd represents the command which prints the JSON Schema of the configuration file
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the configuration file.",
+	Long: `Print the JSON Schema of the configuration file, which can be used to validate configuration files
+in Helm charts, CI pipelines and IDEs.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema, err := config.JSONSchema()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(append(schema, '\n'))
+		return err
+	},
+}
+
+func newConfigCmd() *cobra.Command {
+	configCmd.AddCommand(configSchemaCmd)
+	return configCmd
+}
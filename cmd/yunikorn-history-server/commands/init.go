@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/bootstrap"
+	"github.com/G-Research/yunikorn-history-server/internal/database/migrations"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+var (
+	// writerUsers are the login roles granted the uhs_writer role
+	writerUsers []string
+	// readerUsers are the login roles granted the uhs_reader role
+	readerUsers []string
+)
+
+// initCmd represents the init command which is used to bootstrap the database schema
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create the database schema, roles and grants, and run the migrations.",
+	Long: `Create the database schema with the uhs_writer and uhs_reader roles and their grants, and run the migrations.
+The configured database user must be allowed to create roles. The server itself can then connect with a user
+granted uhs_writer, or with a user granted uhs_reader in read-only mode.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.New(ConfigFile)
+		if err != nil {
+			return err
+		}
+
+		log.Init(&cfg.LogConfig)
+
+		ctx := context.Background()
+		if err := resolveDatabaseCredentials(ctx, cfg); err != nil {
+			return err
+		}
+
+		pool, err := postgres.NewConnectionPool(ctx, &cfg.PostgresConfig)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+		err = bootstrap.Bootstrap(
+			ctx,
+			pool,
+			cfg.PostgresConfig.Schema,
+			bootstrap.WithWriters(writerUsers...),
+			bootstrap.WithReaders(readerUsers...),
+		)
+		if err != nil {
+			return err
+		}
+
+		m, err := migrations.New(&cfg.PostgresConfig, MigrationsDir)
+		if err != nil {
+			return err
+		}
+		_, err = m.Up()
+		return err
+	},
+}
+
+func newInitCmd() *cobra.Command {
+	initCmd.Flags().StringVarP(
+		&MigrationsDir,
+		"migrations-dir",
+		"m",
+		MigrationsDir,
+		"path to the folder containing the database migrations",
+	)
+	initCmd.Flags().StringSliceVar(&writerUsers, "writer", nil, "existing database users granted the uhs_writer role")
+	initCmd.Flags().StringSliceVar(&readerUsers, "reader", nil, "existing database users granted the uhs_reader role")
+	return initCmd
+}
@@ -1,12 +1,18 @@
 package commands
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/G-Research/yunikorn-history-server/internal/database/migrations"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
 
 	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/egress"
 	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/secrets"
 )
 
 // migrateCmd represents the migrate command which is used to run database migrations
@@ -24,6 +30,10 @@ var migrateCmd = &cobra.Command{
 
 		log.Init(&cfg.LogConfig)
 
+		if err := resolveDatabaseCredentials(context.Background(), cfg); err != nil {
+			return err
+		}
+
 		m, err := migrations.New(&cfg.PostgresConfig, MigrationsDir)
 		if err != nil {
 			return err
@@ -39,6 +49,29 @@ var migrateCmd = &cobra.Command{
 	},
 }
 
+// resolveDatabaseCredentials resolves the secret references of the configuration and, for IAM database
+// authentication, sets a token as password. The token is only valid for a few minutes, which suffices for
+// commands connecting once.
+func resolveDatabaseCredentials(ctx context.Context, cfg *config.Config) error {
+	// the outbound requests, including those of the secret store, are sent as the egress configuration specifies
+	if err := egress.Install(&cfg.EgressConfig); err != nil {
+		return fmt.Errorf("invalid egress config: %w", err)
+	}
+	provider, err := secrets.New(ctx, &cfg.SecretsConfig)
+	if err != nil {
+		return err
+	}
+	if err := secrets.NewResolver(provider, 0).ResolveConfig(ctx, cfg); err != nil {
+		return err
+	}
+	tokenSource, err := postgres.NewIAMTokenSource(ctx, &cfg.PostgresConfig)
+	if err != nil || tokenSource == nil {
+		return err
+	}
+	cfg.PostgresConfig.Password, err = tokenSource.Token(ctx, cfg.PostgresConfig.Username)
+	return err
+}
+
 func newMigrateCmd() *cobra.Command {
 	migrateCmd.Flags().StringVarP(
 		&MigrationsDir,
@@ -2,21 +2,15 @@ package commands
 
 import (
 	"context"
-	"fmt"
 	"os/signal"
 	"syscall"
 
-	"github.com/oklog/run"
 	"github.com/spf13/cobra"
 
 	"github.com/G-Research/yunikorn-history-server/cmd/yunikorn-history-server/info"
 	"github.com/G-Research/yunikorn-history-server/internal/config"
-	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
-	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
-	"github.com/G-Research/yunikorn-history-server/internal/health"
 	"github.com/G-Research/yunikorn-history-server/internal/log"
-	"github.com/G-Research/yunikorn-history-server/internal/webservice"
-	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
+	"github.com/G-Research/yunikorn-history-server/pkg/server"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -46,51 +40,14 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
 	defer cancel()
 
-	log.ToContext(ctx, log.Logger)
-
-	pool, err := postgres.NewConnectionPool(ctx, &cfg.PostgresConfig)
-	if err != nil {
-		return fmt.Errorf("cannot parse Postgres connection config: %w", err)
-	}
-	mainRepository, err := repository.NewPostgresRepository(pool)
-	if err != nil {
-		log.Logger.Error("could not create db repository")
-		panic(err)
-	}
-	eventRepository := repository.NewInMemoryEventRepository()
-
-	g := run.Group{}
-
-	client := yunikorn.NewRESTClient(&cfg.YunikornConfig)
-	service := yunikorn.NewService(mainRepository, eventRepository, client, yunikorn.WithSyncInterval(cfg.YHSConfig.DataSyncInterval))
-	g.Add(
-		func() error {
-			return service.Run(ctx)
-		},
-		func(err error) {},
-	)
-
-	healthService := health.New(info.Version, health.NewYunikornComponent(client), health.NewPostgresComponent(pool))
-
-	ws := webservice.NewWebService(&cfg.YHSConfig, mainRepository, eventRepository, healthService)
-	g.Add(
-		func() error {
-			return ws.Start(ctx)
-		},
-		func(err error) {
-			_ = ws.Shutdown(ctx)
-		},
-	)
-
-	if err = g.Run(); err != nil {
-		log.Logger.Warnf("group stopped because of an error: %v", err)
-	}
-
-	return nil
+	return server.New(cfg, server.WithLogger(log.Logger), server.WithVersion(info.Version)).Run(ctx)
 }
 
 func New() *cobra.Command {
 	rootCmd.PersistentFlags().StringVarP(&ConfigFile, "config", "c", ConfigFile, "path to the configuration file")
 	rootCmd.AddCommand(newMigrateCmd())
+	rootCmd.AddCommand(newInitCmd())
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newCollectorCmd())
 	return rootCmd
 }
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"os"
+	// timezones of the tz query parameter are available without the system timezone database
+	_ "time/tzdata"
 
 	_ "go.uber.org/mock/mockgen/model"
 
@@ -0,0 +1,189 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+// State is the evaluation state of an alert rule.
+type State string
+
+const (
+	StatePending State = "pending"
+	StateOK      State = "ok"
+	StateFiring  State = "firing"
+	StateError   State = "error"
+)
+
+// RuleStatus describes an alert rule and the result of its last evaluation.
+type RuleStatus struct {
+	policy.AlertRule
+	// Evaluation is the result of the last evaluation.
+	Evaluation State `json:"evaluation"`
+	// Value is the number of matching applications counted in the last evaluation.
+	Value int `json:"value"`
+	// Error is the error of the last evaluation, if any.
+	Error string `json:"error,omitempty"`
+	// LastEvaluation is the time of the last evaluation.
+	LastEvaluation *time.Time `json:"lastEvaluation,omitempty"`
+	// FiringSince is the time the rule started firing.
+	FiringSince *time.Time `json:"firingSince,omitempty"`
+}
+
+// Notification is sent to the webhook when a rule starts or stops firing.
+type Notification struct {
+	Rule      policy.AlertRule `json:"rule"`
+	State     State            `json:"state"`
+	Value     int              `json:"value"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// Service evaluates the alert rules from the policy store against the stored applications. It is run by the
+// scheduler.
+type Service struct {
+	repo       repository.Repository
+	store      *policy.Store
+	webhookURL string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mutex    sync.RWMutex
+	statuses map[string]*RuleStatus
+}
+
+type Option func(*Service)
+
+// WithWebhook sets the URL notifications are posted to when a rule starts or stops firing.
+func WithWebhook(url string) Option {
+	return func(s *Service) {
+		s.webhookURL = url
+	}
+}
+
+func NewService(repo repository.Repository, store *policy.Store, opts ...Option) *Service {
+	s := &Service{
+		repo:       repo,
+		store:      store,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+		statuses:   make(map[string]*RuleStatus),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Statuses returns the status of all alert rules in the policy store ordered by name.
+func (s *Service) Statuses() []*RuleStatus {
+	rules := s.store.AlertRules()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	statuses := make([]*RuleStatus, 0, len(rules))
+	for _, rule := range rules {
+		status := RuleStatus{AlertRule: rule, Evaluation: StatePending}
+		if previous, ok := s.statuses[rule.Name]; ok && previous.AlertRule == rule {
+			status = *previous
+		}
+		statuses = append(statuses, &status)
+	}
+	return statuses
+}
+
+// Evaluate evaluates all alert rules once and notifies about rules which started or stopped firing. A rule which
+// cannot be evaluated does not prevent the others from being evaluated, but fails the run.
+func (s *Service) Evaluate(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	now := s.now()
+
+	rules := s.store.AlertRules()
+	statuses := make(map[string]*RuleStatus, len(rules))
+	failed := 0
+	for _, rule := range rules {
+		status := &RuleStatus{AlertRule: rule, LastEvaluation: &now}
+		s.mutex.RLock()
+		previous, ok := s.statuses[rule.Name]
+		s.mutex.RUnlock()
+		// a changed rule is evaluated from scratch
+		if !ok || previous.AlertRule != rule {
+			previous = &RuleStatus{Evaluation: StatePending}
+		}
+
+		count, err := s.repo.CountFinishedApplications(ctx, rule.Partition, rule.Queue, rule.State, now.Add(-rule.Window))
+		switch {
+		case err != nil:
+			logger.Errorw("could not evaluate alert rule", "rule", rule.Name, "error", err)
+			status.Evaluation = StateError
+			status.Error = err.Error()
+			failed++
+			// keep the firing state of the rule on transient errors
+			status.FiringSince = previous.FiringSince
+		case count >= rule.Threshold:
+			status.Evaluation = StateFiring
+			status.Value = count
+			status.FiringSince = previous.FiringSince
+			if status.FiringSince == nil {
+				status.FiringSince = &now
+				logger.Warnw("alert rule is firing", "rule", rule.Name, "severity", rule.Severity, "value", count)
+				s.notify(ctx, status)
+			}
+		default:
+			status.Evaluation = StateOK
+			status.Value = count
+			if previous.FiringSince != nil {
+				logger.Infow("alert rule resolved", "rule", rule.Name, "value", count)
+				s.notify(ctx, status)
+			}
+		}
+		statuses[rule.Name] = status
+	}
+
+	s.mutex.Lock()
+	s.statuses = statuses
+	s.mutex.Unlock()
+	if failed > 0 {
+		return fmt.Errorf("could not evaluate %d of %d alert rules", failed, len(rules))
+	}
+	return nil
+}
+
+// notify posts a notification about the rule to the configured webhook, if any.
+func (s *Service) notify(ctx context.Context, status *RuleStatus) {
+	if s.webhookURL == "" {
+		return
+	}
+	notification := Notification{Rule: status.AlertRule, State: status.Evaluation, Value: status.Value, Timestamp: s.now()}
+	if err := s.post(ctx, notification); err != nil {
+		log.FromContext(ctx).Errorw("could not send alert notification", "rule", status.Name, "error", err)
+	}
+}
+
+func (s *Service) post(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
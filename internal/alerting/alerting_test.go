@@ -0,0 +1,95 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+func TestService_Evaluate(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	rule := policy.AlertRule{Name: "failures", Queue: "root.batch", State: "Failed", Threshold: 3, Window: time.Hour}
+
+	var notifications []Notification
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		notifications = append(notifications, n)
+	}))
+	defer webhook.Close()
+
+	store := policy.NewStore()
+	require.NoError(t, store.ApplyAlertRule(rule))
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	gomock.InOrder(
+		repo.EXPECT().CountFinishedApplications(gomock.Any(), "", "root.batch", "Failed", now.Add(-time.Hour)).Return(3, nil),
+		repo.EXPECT().CountFinishedApplications(gomock.Any(), "", "root.batch", "Failed", now.Add(-time.Hour)).Return(4, nil),
+		repo.EXPECT().CountFinishedApplications(gomock.Any(), "", "root.batch", "Failed", now.Add(-time.Hour)).
+			Return(0, errors.New("connection refused")),
+		repo.EXPECT().CountFinishedApplications(gomock.Any(), "", "root.batch", "Failed", now.Add(-time.Hour)).Return(1, nil),
+	)
+
+	s := NewService(repo, store, WithWebhook(webhook.URL))
+	s.now = func() time.Time { return now }
+
+	statuses := s.Statuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StatePending, statuses[0].Evaluation)
+
+	require.NoError(t, s.Evaluate(context.Background()))
+	status := s.Statuses()[0]
+	assert.Equal(t, StateFiring, status.Evaluation)
+	assert.Equal(t, 3, status.Value)
+	assert.Equal(t, &now, status.FiringSince)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, StateFiring, notifications[0].State)
+
+	// a rule which keeps firing is not notified again
+	require.NoError(t, s.Evaluate(context.Background()))
+	assert.Equal(t, StateFiring, s.Statuses()[0].Evaluation)
+	assert.Len(t, notifications, 1)
+
+	assert.EqualError(t, s.Evaluate(context.Background()), "could not evaluate 1 of 1 alert rules")
+	status = s.Statuses()[0]
+	assert.Equal(t, StateError, status.Evaluation)
+	assert.Equal(t, "connection refused", status.Error)
+	assert.NotNil(t, status.FiringSince)
+
+	require.NoError(t, s.Evaluate(context.Background()))
+	status = s.Statuses()[0]
+	assert.Equal(t, StateOK, status.Evaluation)
+	assert.Nil(t, status.FiringSince)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, StateOK, notifications[1].State)
+}
+
+func TestService_StatusesResetOnRuleChange(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	store := policy.NewStore()
+	require.NoError(t, store.ApplyAlertRule(policy.AlertRule{Name: "r", State: "Failed", Threshold: 1, Window: time.Hour}))
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().CountFinishedApplications(gomock.Any(), "", "", "Failed", now.Add(-time.Hour)).Return(1, nil)
+
+	s := NewService(repo, store)
+	s.now = func() time.Time { return now }
+	require.NoError(t, s.Evaluate(context.Background()))
+	assert.Equal(t, StateFiring, s.Statuses()[0].Evaluation)
+
+	require.NoError(t, store.ApplyAlertRule(policy.AlertRule{Name: "r", State: "Failed", Threshold: 10, Window: time.Hour}))
+	assert.Equal(t, StatePending, s.Statuses()[0].Evaluation)
+}
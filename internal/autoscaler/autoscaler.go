@@ -0,0 +1,111 @@
+// Package autoscaler converts the Kubernetes events of the cluster-autoscaler, forwarded to the server by an event
+// exporter, to the events stored on the timeline of the pending resources of the partitions.
+package autoscaler
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// directions classifies the reasons of the events of the cluster-autoscaler.
+var directions = map[string]model.AutoscalerDirection{
+	"TriggeredScaleUp":     model.AutoscalerScaleUp,
+	"ScaledUpGroup":        model.AutoscalerScaleUp,
+	"NotTriggerScaleUp":    model.AutoscalerNoScaleUp,
+	"FailedToScaleUpGroup": model.AutoscalerScaleUpFailed,
+	"ScaleDown":            model.AutoscalerScaleDown,
+	"ScaleDownEmpty":       model.AutoscalerScaleDown,
+	"ScaleDownFailed":      model.AutoscalerScaleDownFailed,
+}
+
+// ErrMissingUID is returned for the events without UID nor name, whose occurrences cannot be told apart.
+var ErrMissingUID = errors.New("event has neither uid nor name")
+
+// Direction returns the direction of the events of the cluster-autoscaler with the reason.
+func Direction(reason string) model.AutoscalerDirection {
+	if direction, ok := directions[reason]; ok {
+		return direction
+	}
+	return model.AutoscalerOther
+}
+
+// Converter converts the Kubernetes events of the cluster-autoscaler.
+type Converter struct {
+	sourceComponents []string
+	now              func() time.Time
+}
+
+// NewConverter creates a converter of the events reported by the source components.
+func NewConverter(sourceComponents []string) *Converter {
+	return &Converter{
+		sourceComponents: sourceComponents,
+		now:              time.Now,
+	}
+}
+
+// Convert converts the Kubernetes event to the occurrence of an event of the cluster-autoscaler. It returns nil
+// if the event is not reported by any of the source components. The occurrence is at the last time the event was
+// seen, and events are identified by their UID, or by their namespace and name if they have none.
+func (c *Converter) Convert(event *corev1.Event) (*model.AutoscalerEvent, error) {
+	if !slices.Contains(c.sourceComponents, sourceComponent(event)) {
+		return nil, nil
+	}
+	uid := string(event.UID)
+	if uid == "" {
+		if event.Name == "" {
+			return nil, ErrMissingUID
+		}
+		uid = event.Namespace + "/" + event.Name
+	}
+	converted := &model.AutoscalerEvent{
+		UID:             uid,
+		Time:            c.occurrenceTime(event).UnixNano(),
+		Reason:          event.Reason,
+		Direction:       Direction(event.Reason),
+		ObjectKind:      nonEmpty(event.InvolvedObject.Kind),
+		ObjectNamespace: nonEmpty(event.InvolvedObject.Namespace),
+		ObjectName:      nonEmpty(event.InvolvedObject.Name),
+		Message:         nonEmpty(event.Message),
+		Count:           max(event.Count, 1),
+	}
+	if event.Series != nil {
+		converted.Count = max(event.Series.Count, 1)
+	}
+	return converted, nil
+}
+
+// sourceComponent returns the component which reported the event, in the format of the core or events API.
+func sourceComponent(event *corev1.Event) string {
+	if event.Source.Component != "" {
+		return event.Source.Component
+	}
+	return event.ReportingController
+}
+
+// occurrenceTime returns the last time the event was seen, the time it is converted if it has none.
+func (c *Converter) occurrenceTime(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return c.now()
+	}
+}
+
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
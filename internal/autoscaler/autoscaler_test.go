@@ -0,0 +1,97 @@
+package autoscaler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestConverterConvert(t *testing.T) {
+	converter := NewConverter([]string{"cluster-autoscaler"})
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	converter.now = func() time.Time { return now }
+	last := time.Date(2024, 7, 1, 11, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		event *corev1.Event
+		want  *model.AutoscalerEvent
+	}{
+		"scale-up": {
+			event: &corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{UID: "uid-1", Name: "pod-1.17e", Namespace: "batch"},
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "batch", Name: "pod-1"},
+				Reason:         "TriggeredScaleUp",
+				Message:        "pod triggered scale-up: [{pool-a 1->3 (max: 10)}]",
+				Source:         corev1.EventSource{Component: "cluster-autoscaler"},
+				FirstTimestamp: metav1.NewTime(last.Add(-time.Minute)),
+				LastTimestamp:  metav1.NewTime(last),
+				Count:          2,
+			},
+			want: &model.AutoscalerEvent{
+				UID:             "uid-1",
+				Time:            last.UnixNano(),
+				Reason:          "TriggeredScaleUp",
+				Direction:       model.AutoscalerScaleUp,
+				ObjectKind:      util.ToPtr("Pod"),
+				ObjectNamespace: util.ToPtr("batch"),
+				ObjectName:      util.ToPtr("pod-1"),
+				Message:         util.ToPtr("pod triggered scale-up: [{pool-a 1->3 (max: 10)}]"),
+				Count:           2,
+			},
+		},
+		"events API": {
+			event: &corev1.Event{
+				ObjectMeta:          metav1.ObjectMeta{Name: "node-1.17e", Namespace: "default"},
+				Reason:              "ScaleDown",
+				ReportingController: "cluster-autoscaler",
+				EventTime:           metav1.NewMicroTime(last),
+				Series:              &corev1.EventSeries{Count: 3},
+			},
+			want: &model.AutoscalerEvent{
+				UID:       "default/node-1.17e",
+				Time:      last.UnixNano(),
+				Reason:    "ScaleDown",
+				Direction: model.AutoscalerScaleDown,
+				Count:     3,
+			},
+		},
+		"without time": {
+			event: &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{UID: "uid-2"},
+				Reason:     "Unknown",
+				Source:     corev1.EventSource{Component: "cluster-autoscaler"},
+			},
+			want: &model.AutoscalerEvent{
+				UID:       "uid-2",
+				Time:      now.UnixNano(),
+				Reason:    "Unknown",
+				Direction: model.AutoscalerOther,
+				Count:     1,
+			},
+		},
+		"other component": {
+			event: &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{UID: "uid-3"},
+				Reason:     "Scheduled",
+				Source:     corev1.EventSource{Component: "default-scheduler"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := converter.Convert(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := converter.Convert(&corev1.Event{Source: corev1.EventSource{Component: "cluster-autoscaler"}})
+	assert.ErrorIs(t, err, ErrMissingUID)
+}
@@ -0,0 +1,195 @@
+// Package breaker protects the database with a circuit breaker: once the operations of the repository failed
+// a number of times in a row because the database is unavailable or too slow, the circuit opens and operations
+// fail fast with an UnavailableError instead of piling up on the database, until a probe operation succeeds.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// ErrUnavailable matches the errors returned while the circuit is open.
+var ErrUnavailable = errors.New("history store unavailable")
+
+// UnavailableError is returned by the operations rejected while the circuit is open.
+type UnavailableError struct {
+	// RetryAfter is the time until the circuit lets a probe operation through.
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	return ErrUnavailable.Error()
+}
+
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+// State is the state of the circuit.
+type State int
+
+const (
+	// Closed lets all operations through.
+	Closed State = iota
+	// HalfOpen lets one probe operation through, which closes the circuit if it succeeds.
+	HalfOpen
+	// Open rejects all operations.
+	Open
+)
+
+func (s State) String() string {
+	switch s {
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+var (
+	circuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Subsystem: "repository",
+		Name:      "circuit_state",
+		Help:      "State of the circuit breaker of the repository: 0 closed, 1 half-open, 2 open.",
+	})
+	rejectedOperationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "repository",
+		Name:      "circuit_rejected_operations_total",
+		Help:      "Number of operations of the repository rejected while the circuit was open.",
+	})
+)
+
+// Breaker counts the consecutive failures of the operations, and opens the circuit when they reach the threshold.
+// After the open duration, it lets one probe operation through, which closes the circuit if it succeeds, and opens
+// it again if it fails.
+type Breaker struct {
+	threshold    int
+	openDuration time.Duration
+	now          func() time.Time
+
+	mutex    sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+// New returns the breaker configured by cfg, or nil if it is disabled.
+func New(cfg *config.CircuitBreakerConfig) *Breaker {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewBreaker(cfg.FailureThreshold, cfg.OpenDuration)
+}
+
+func NewBreaker(threshold int, openDuration time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, openDuration: openDuration, now: time.Now}
+}
+
+// State returns the state of the circuit.
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+// Allow returns an UnavailableError if the operation is rejected. Otherwise, the result of the operation must be
+// recorded with Record.
+func (b *Breaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case HalfOpen:
+		// the probe operation did not finish yet
+		rejectedOperationsTotal.Inc()
+		return &UnavailableError{RetryAfter: time.Second}
+	case Open:
+		if wait := b.openedAt.Add(b.openDuration).Sub(b.now()); wait > 0 {
+			rejectedOperationsTotal.Inc()
+			return &UnavailableError{RetryAfter: wait}
+		}
+		b.setState(HalfOpen)
+	}
+	return nil
+}
+
+// Record records the result of an allowed operation. Only the errors showing that the database is unavailable or
+// too slow count as failures, and the operations cancelled by their callers are ignored.
+func (b *Breaker) Record(ctx context.Context, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		if b.state == HalfOpen {
+			// let the next operation probe the database
+			b.openedAt = b.now().Add(-b.openDuration)
+			b.setState(Open)
+		}
+		return
+	}
+	failed := IsFailure(err)
+	switch {
+	case !failed:
+		if b.state != Closed {
+			log.FromContext(ctx).Info("closing the circuit of the repository, as the database is available again")
+		}
+		b.failures = 0
+		b.setState(Closed)
+	case b.state == HalfOpen:
+		log.FromContext(ctx).Warnw("opening the circuit of the repository again, as the probe operation failed", "error", err)
+		b.open()
+	default:
+		b.failures++
+		if b.failures >= b.threshold && b.state == Closed {
+			log.FromContext(ctx).Errorw("opening the circuit of the repository after consecutive failures",
+				"failures", b.failures, "error", err)
+			b.open()
+		}
+	}
+}
+
+// open opens the circuit. The breaker must be locked.
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.setState(Open)
+}
+
+// setState sets the state of the circuit. The breaker must be locked.
+func (b *Breaker) setState(state State) {
+	b.state = state
+	circuitState.Set(float64(state))
+}
+
+// failureClasses are the classes of the Postgres errors showing that the database is unavailable or too slow.
+var failureClasses = []string{"08", "53", "57", "58"}
+
+// IsFailure reports whether the error shows that the database is unavailable or too slow: connection errors,
+// timeouts, and the errors of the Postgres classes connection exception (08), insufficient resources (53),
+// operator intervention (57), which includes cancelled statements, and system error (58). Other errors, e.g.
+// constraint violations or records which are not found, show that the database is available.
+func IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return len(pgErr.Code) == 5 && slices.Contains(failureClasses, pgErr.Code[:2])
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) || errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) || pgconn.SafeToRetry(err)
+}
@@ -0,0 +1,132 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+var errConnectionRefused = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func TestBreaker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBreaker(3, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	fail := func(err error) {
+		t.Helper()
+		require.NoError(t, b.Allow())
+		b.Record(ctx, err)
+	}
+
+	// errors showing that the database is available do not count, and successes reset the failures
+	fail(errConnectionRefused)
+	fail(errConnectionRefused)
+	fail(pgx.ErrNoRows)
+	fail(errConnectionRefused)
+	fail(errConnectionRefused)
+	assert.Equal(t, Closed, b.State())
+
+	// operations cancelled by their callers are ignored
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.NoError(t, b.Allow())
+	b.Record(cancelled, context.Canceled)
+	assert.Equal(t, Closed, b.State())
+
+	fail(&pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"})
+	assert.Equal(t, Open, b.State())
+	err := b.Allow()
+	require.ErrorIs(t, err, ErrUnavailable)
+	var unavailable *UnavailableError
+	require.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, 30*time.Second, unavailable.RetryAfter)
+	assert.EqualError(t, err, "history store unavailable")
+
+	// one probe operation is let through after the open duration
+	now = now.Add(30 * time.Second)
+	require.NoError(t, b.Allow())
+	assert.Equal(t, HalfOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrUnavailable, "only one probe operation is let through")
+	b.Record(ctx, errConnectionRefused)
+	assert.Equal(t, Open, b.State())
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, b.Allow())
+	b.Record(ctx, nil)
+	assert.Equal(t, Closed, b.State())
+	require.NoError(t, b.Allow())
+}
+
+func TestIsFailure(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"success":            {err: nil, want: false},
+		"not found":          {err: fmt.Errorf("%w: 42", repository.ErrLegalHoldNotFound), want: false},
+		"no rows":            {err: pgx.ErrNoRows, want: false},
+		"unique violation":   {err: &pgconn.PgError{Code: "23505"}, want: false},
+		"connection refused": {err: fmt.Errorf("could not get partitions: %w", errConnectionRefused), want: true},
+		"connect":            {err: &pgconn.ConnectError{}, want: true},
+		"deadline exceeded":  {err: context.DeadlineExceeded, want: true},
+		"statement timeout":  {err: &pgconn.PgError{Code: "57014"}, want: true},
+		"admin shutdown":     {err: &pgconn.PgError{Code: "57P01"}, want: true},
+		"too many clients":   {err: &pgconn.PgError{Code: "53300"}, want: true},
+		"connection failure": {err: &pgconn.PgError{Code: "08006"}, want: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsFailure(tt.err))
+		})
+	}
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	b := NewBreaker(1, time.Minute)
+	wrapped := NewRepository(repo, b)
+	assert.Same(t, repo, NewRepository(repo, nil))
+
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, errConnectionRefused)
+	_, err := wrapped.GetAllPartitions(ctx)
+	assert.ErrorIs(t, err, errConnectionRefused)
+
+	// operations are rejected without reaching the database while the circuit is open
+	_, err = wrapped.GetAllPartitions(ctx)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorIs(t, wrapped.UpsertPartitions(ctx, nil), ErrUnavailable)
+}
+
+func TestRepository_ApplyIngestBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	tx := repository.NewMockRepository(gomock.NewController(t))
+	wrapped := NewRepository(repo, NewBreaker(1, time.Minute))
+
+	// the writes of the batch are part of the operation of the batch
+	repo.EXPECT().ApplyIngestBatch(gomock.Any(), "collector-1", int64(3), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string, _ int64, fn func(context.Context, repository.Repository) error) (int64, error) {
+			return 3, fn(ctx, tx)
+		})
+	tx.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, nil)
+	sequence, err := wrapped.ApplyIngestBatch(ctx, "collector-1", 3, func(ctx context.Context, tx repository.Repository) error {
+		_, err := tx.GetAllPartitions(ctx)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), sequence)
+}
@@ -0,0 +1,940 @@
+package breaker
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Repository lets the operations of the wrapped repository through the breaker, which rejects them with
+// an UnavailableError while the circuit is open.
+type Repository struct {
+	repo    repository.Repository
+	breaker *Breaker
+}
+
+// NewRepository wraps the repository, so that its operations fail fast while the database is unavailable.
+// It returns the repository itself if the breaker is nil.
+func NewRepository(repo repository.Repository, breaker *Breaker) repository.Repository {
+	if breaker == nil {
+		return repo
+	}
+	return &Repository{repo: repo, breaker: breaker}
+}
+
+var _ repository.Repository = &Repository{}
+
+func (r *Repository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpsertApplications(ctx, apps)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetAllApplications(ctx context.Context, filters repository.ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAllApplications(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAppsPerPartitionPerQueue(ctx, partition, queue, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) StreamAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+	fn func(*model.ApplicationDAOInfo) error,
+) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.StreamAppsPerPartitionPerQueue(ctx, partition, queue, filters, fn)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueuePage(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, repository.PageInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	result, info, err := r.repo.GetAppsPerPartitionPerQueuePage(ctx, partition, queue, filters)
+	r.breaker.Record(ctx, err)
+	return result, info, err
+}
+
+func (r *Repository) GetApplicationSummaries(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationSummary, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetApplicationSummaries(ctx, partition, queue, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) CountFinishedApplications(ctx context.Context, partition, queue, state string, since time.Time) (int, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.CountFinishedApplications(ctx, partition, queue, state, since)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) DeleteApplicationsFinishedBefore(
+	ctx context.Context,
+	partition, queue string,
+	before time.Time,
+	limit int,
+) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.DeleteApplicationsFinishedBefore(ctx, partition, queue, before, limit)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) CountBulkDeleteApplications(
+	ctx context.Context,
+	filters repository.BulkDeleteFilters,
+) (int64, int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, 0, err
+	}
+	deletable, held, err := r.repo.CountBulkDeleteApplications(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return deletable, held, err
+}
+
+func (r *Repository) BulkDeleteApplications(ctx context.Context, filters repository.BulkDeleteFilters, limit int) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.BulkDeleteApplications(ctx, filters, limit)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) MoveApplicationsToColdTier(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.MoveApplicationsToColdTier(ctx, before, limit)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) UpdateHistory(
+	ctx context.Context,
+	apps []*dao.ApplicationHistoryDAOInfo,
+	containers []*dao.ContainerHistoryDAOInfo,
+) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpdateHistory(ctx, apps, containers)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) DeleteHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.DeleteHistoryBefore(ctx, before, limit)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetApplicationsHistory(ctx context.Context) ([]*dao.ApplicationHistoryDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetApplicationsHistory(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetContainersHistory(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetApplicationsHistoryPage(
+	ctx context.Context, page repository.Page) ([]*dao.ApplicationHistoryDAOInfo, repository.PageInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	result, info, err := r.repo.GetApplicationsHistoryPage(ctx, page)
+	r.breaker.Record(ctx, err)
+	return result, info, err
+}
+
+func (r *Repository) GetContainersHistoryPage(
+	ctx context.Context, page repository.Page) ([]*dao.ContainerHistoryDAOInfo, repository.PageInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	result, info, err := r.repo.GetContainersHistoryPage(ctx, page)
+	r.breaker.Record(ctx, err)
+	return result, info, err
+}
+
+func (r *Repository) StreamApplicationsHistory(ctx context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.StreamApplicationsHistory(ctx, fn)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) StreamContainersHistory(ctx context.Context, fn func(*dao.ContainerHistoryDAOInfo) error) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.StreamContainersHistory(ctx, fn)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpsertNodes(ctx, nodes, partition)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) InsertNodeUtilizations(
+	ctx context.Context,
+	uuid uuid.UUID,
+	partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo,
+) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.InsertNodeUtilizations(ctx, uuid, partitionNodesUtil)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetNodeUtilizations(ctx context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetNodeUtilizations(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetNodesPerPartition(ctx context.Context, partition string) ([]*dao.NodeDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetNodesPerPartition(ctx, partition)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetNodesPerPartitionPage(
+	ctx context.Context, partition string, page repository.Page) ([]*dao.NodeDAOInfo, repository.PageInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	result, info, err := r.repo.GetNodesPerPartitionPage(ctx, partition, page)
+	r.breaker.Record(ctx, err)
+	return result, info, err
+}
+
+func (r *Repository) GetNode(ctx context.Context, nodeID string) (*model.NodeDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetNode(ctx, nodeID)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetNodeTopologies(ctx context.Context, partition string) ([]*model.NodeTopology, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetNodeTopologies(ctx, partition)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) RecordNodeTermination(
+	ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+) (*model.NodeTermination, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.RecordNodeTermination(ctx, nodeID, terminatedAt, spotSelectors)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetNodeTerminations(
+	ctx context.Context,
+	filters repository.NodeTerminationFilters,
+) ([]*model.NodeTermination, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetNodeTerminations(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) AddAutoscalerEvents(ctx context.Context, events []*model.AutoscalerEvent) (int, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.AddAutoscalerEvents(ctx, events)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetAutoscalerEvents(
+	ctx context.Context,
+	filters repository.AutoscalerEventFilters,
+) ([]*model.AutoscalerEvent, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAutoscalerEvents(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetPendingBacklog(
+	ctx context.Context,
+	filters repository.PendingBacklogFilters,
+) ([]*model.PendingBacklogSample, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetPendingBacklog(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpsertPartitions(ctx, partitions)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetAllPartitions(ctx context.Context) ([]*dao.PartitionInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAllPartitions(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) AddQueues(ctx context.Context, parentId *string, queues []*dao.PartitionQueueDAOInfo) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.AddQueues(ctx, parentId, queues)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpsertQueues(ctx, queues)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetAllQueues(ctx context.Context) ([]*model.PartitionQueueDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAllQueues(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetQueuesPerPartition(ctx context.Context, partition string) ([]*model.PartitionQueueDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetQueuesPerPartition(ctx, partition)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetQueue(ctx context.Context, partition, queueName string) (*model.PartitionQueueDAOInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetQueue(ctx, partition, queueName)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) DeleteQueues(ctx context.Context, queues []*model.PartitionQueueDAOInfo) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.DeleteQueues(ctx, queues)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) CreateLegalHold(ctx context.Context, hold *model.LegalHold) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.CreateLegalHold(ctx, hold)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetLegalHolds(ctx context.Context, filters repository.LegalHoldFilters) ([]*model.LegalHold, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetLegalHolds(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetLegalHold(ctx context.Context, id string) (*model.LegalHold, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetLegalHold(ctx, id)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) ReleaseLegalHold(ctx context.Context, id, releasedBy, reason string, ifVersion *int64) (*model.LegalHold, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.ReleaseLegalHold(ctx, id, releasedBy, reason, ifVersion)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) AddQueueLineage(ctx context.Context, link *model.QueueLineage) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.AddQueueLineage(ctx, link)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetQueueLineage(ctx context.Context, partition *string) ([]*model.QueueLineage, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetQueueLineage(ctx, partition)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) DeleteQueueLineage(ctx context.Context, id string) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.DeleteQueueLineage(ctx, id)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) StartUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.StartUserErasure(ctx, erasure)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) UpdateUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpdateUserErasure(ctx, erasure)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) EraseUserApplications(ctx context.Context, user, pseudonym, mode string, limit int) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.EraseUserApplications(ctx, user, pseudonym, mode, limit)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) CountUserApplications(ctx context.Context, user string) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.CountUserApplications(ctx, user)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) PseudonymizeAuditRecords(ctx context.Context, user, pseudonym string) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.PseudonymizeAuditRecords(ctx, user, pseudonym)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.UpdateQueueACLs(ctx, acls, at)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetQueueACLs(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.QueueACLFilters,
+) ([]*model.QueueACL, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetQueueACLs(ctx, partition, queue, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetAllocationConstraints(
+	ctx context.Context,
+	partition, queue, appID string,
+) ([]*model.AllocationConstraints, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAllocationConstraints(ctx, partition, queue, appID)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) AddTraceEvent(ctx context.Context, event *model.TraceEvent, maxEvents int) (bool, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return false, err
+	}
+	result, err := r.repo.AddTraceEvent(ctx, event, maxEvents)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetTraceEvents(
+	ctx context.Context,
+	appID string,
+	filters repository.TraceEventFilters,
+) ([]*model.TraceEvent, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetTraceEvents(ctx, appID, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) DeleteTraceEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.DeleteTraceEventsBefore(ctx, before)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return false, err
+	}
+	result, err := r.repo.RecordSchedulerEpoch(ctx, epoch)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetSchedulerEpochs(
+	ctx context.Context,
+	filters repository.SchedulerEpochFilters,
+) ([]*model.SchedulerEpoch, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetSchedulerEpochs(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetNamespaceQueues(
+	ctx context.Context,
+	partition string,
+	filters repository.NamespaceQueueFilters,
+) ([]*model.NamespaceQueue, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetNamespaceQueues(ctx, partition, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) QueryAnalytics(ctx context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.QueryAnalytics(ctx, query)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) EstimateAnalytics(ctx context.Context, query repository.AnalyticsQuery) (*model.AnalyticsEstimate, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.EstimateAnalytics(ctx, query)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) StartAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.StartAnalyticsSink(ctx, name)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) StopAnalyticsSink(ctx context.Context, name string) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.StopAnalyticsSink(ctx, name)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetAnalyticsSink(ctx, name)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) BackfillAnalyticsSink(
+	ctx context.Context,
+	name string,
+	limit int,
+	fn func([]*model.AnalyticsApplication) error,
+) (bool, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return false, err
+	}
+	result, err := r.repo.BackfillAnalyticsSink(ctx, name, limit, fn)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) ConsumeAnalyticsChanges(
+	ctx context.Context,
+	limit int,
+	fn func([]*model.AnalyticsApplication) error,
+) (int, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.ConsumeAnalyticsChanges(ctx, limit, fn)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetQueueThroughput(
+	ctx context.Context,
+	filters repository.ThroughputFilters,
+) ([]*model.ThroughputBucket, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetQueueThroughput(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetDuplicateApplications(
+	ctx context.Context,
+	filters repository.DuplicateApplicationFilters,
+) ([]*model.DuplicateApplication, repository.PageInfo, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	result, info, err := r.repo.GetDuplicateApplications(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, info, err
+}
+
+func (r *Repository) GetTopUsage(ctx context.Context, filters repository.TopUsageFilters) ([]*model.TopUsage, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetTopUsage(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetHourlyUsage(
+	ctx context.Context,
+	filters repository.HourlyUsageFilters,
+) ([]*model.HourlyUsage, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetHourlyUsage(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetPrincipals(
+	ctx context.Context,
+	kind repository.PrincipalKind,
+	filters repository.PrincipalFilters,
+) ([]*model.Principal, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetPrincipals(ctx, kind, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetQueueWaitPhases(
+	ctx context.Context, filters repository.WaitPhaseFilters) ([]*model.QueueWaitPhases, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetQueueWaitPhases(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) CheckDataQuality(ctx context.Context, check repository.DataQualityCheck, at time.Time) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.CheckDataQuality(ctx, check, at)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetDataQualityChecks(ctx context.Context) ([]*model.DataQualityCheck, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetDataQualityChecks(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetDataQualityViolations(
+	ctx context.Context,
+	filters repository.DataQualityFilters,
+) ([]*model.DataQualityViolation, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetDataQualityViolations(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) SyncConfigSLOs(ctx context.Context, slos []*model.QueueSLO) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.SyncConfigSLOs(ctx, slos)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) PutSLO(ctx context.Context, slo *model.QueueSLO, ifVersion *int64) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.PutSLO(ctx, slo, ifVersion)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) DeleteSLO(ctx context.Context, name string, ifVersion *int64) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.DeleteSLO(ctx, name, ifVersion)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetSLOs(ctx context.Context) ([]*model.QueueSLO, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetSLOs(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) EvaluateSLO(ctx context.Context, slo *model.QueueSLO, at time.Time) (*model.SLOEvaluation, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.EvaluateSLO(ctx, slo, at)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetSLOEvaluations(
+	ctx context.Context,
+	filters repository.SLOEvaluationFilters,
+) ([]*model.SLOEvaluation, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetSLOEvaluations(ctx, filters)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) DeleteSLOEvaluationsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.DeleteSLOEvaluationsBefore(ctx, before)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) RegisterCluster(ctx context.Context, cluster *model.Cluster) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.RegisterCluster(ctx, cluster)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) HeartbeatCluster(ctx context.Context, name string, ingestedUntil *time.Time) (*model.Cluster, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.HeartbeatCluster(ctx, name, ingestedUntil)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) GetClusters(ctx context.Context) ([]*model.Cluster, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetClusters(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+func (r *Repository) PauseOperation(ctx context.Context, op *model.PausedOperation) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.PauseOperation(ctx, op)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) ResumeOperation(ctx context.Context, name string) error {
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+	err := r.repo.ResumeOperation(ctx, name)
+	r.breaker.Record(ctx, err)
+	return err
+}
+
+func (r *Repository) GetPausedOperations(ctx context.Context) ([]*model.PausedOperation, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := r.repo.GetPausedOperations(ctx)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
+
+// ApplyIngestBatch lets the batch through the breaker as one operation, whose writes are not rejected.
+func (r *Repository) ApplyIngestBatch(
+	ctx context.Context,
+	source string,
+	sequence int64,
+	fn func(ctx context.Context, tx repository.Repository) error,
+) (int64, error) {
+	if err := r.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	result, err := r.repo.ApplyIngestBatch(ctx, source, sequence, fn)
+	r.breaker.Record(ctx, err)
+	return result, err
+}
@@ -0,0 +1,140 @@
+// Package broadcast pushes the events of the event stream of Yunikorn to the subscribers of the live event stream of
+// the API as they are ingested. Events are delivered on a best effort basis: a subscriber which does not keep up
+// loses the events which overflow its buffer, so that slow clients never hold up the ingestion.
+package broadcast
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// DefaultBufferSize is the number of events buffered for each subscriber by default.
+const DefaultBufferSize = 256
+
+var (
+	subscribersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Subsystem: "event_stream",
+		Name:      "subscribers",
+		Help:      "Number of clients subscribed to the live event stream.",
+	})
+	droppedEventsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "event_stream",
+		Name:      "dropped_events_total",
+		Help:      "Number of events not delivered to subscribers of the live event stream whose buffer was full.",
+	})
+)
+
+// Filter selects the events delivered to a subscriber. Empty fields match all events.
+type Filter struct {
+	// Partition matches the events of the partition, and the events whose partition is unknown, such as those of
+	// queues and nodes.
+	Partition string
+	// Queue matches the events of the applications of the queue and of its child queues, and the events of the queue
+	// and of its child queues.
+	Queue string
+}
+
+// Matches returns whether the event is selected by the filter.
+func (f Filter) Matches(event *model.StreamEvent) bool {
+	if f.Partition != "" && event.Partition != "" && event.Partition != f.Partition {
+		return false
+	}
+	if f.Queue != "" && event.QueueName != f.Queue && !strings.HasPrefix(event.QueueName, f.Queue+".") {
+		return false
+	}
+	return true
+}
+
+// Hub delivers the published events to its subscribers.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+}
+
+// NewHub creates a hub which buffers up to bufferSize events for each subscriber, or DefaultBufferSize if not
+// positive.
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe subscribes to the events selected by the filter. The subscription must be closed once no longer read.
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	s := &Subscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan *model.StreamEvent, h.bufferSize),
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[s] = struct{}{}
+	subscribersGauge.Inc()
+	return s
+}
+
+// Publish delivers the event to the subscribers whose filter selects it. It never blocks: the event is dropped for
+// the subscribers whose buffer is full.
+func (h *Hub) Publish(event *model.StreamEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subscribers {
+		if !s.filter.Matches(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			s.mu.Lock()
+			s.dropped++
+			s.mu.Unlock()
+			droppedEventsTotal.Inc()
+		}
+	}
+}
+
+// Subscription is the subscription of a client to the events of a hub.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	events chan *model.StreamEvent
+	mu     sync.Mutex
+	// dropped is the number of events dropped since it was last taken
+	dropped int
+}
+
+// Events returns the channel of the delivered events.
+func (s *Subscription) Events() <-chan *model.StreamEvent {
+	return s.events
+}
+
+// TakeDropped returns the number of events dropped since the last call, as the buffer of the subscription was full.
+func (s *Subscription) TakeDropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dropped := s.dropped
+	s.dropped = 0
+	return dropped
+}
+
+// Close unsubscribes from the hub. The events still buffered are not read anymore.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subscribers[s]; ok {
+		delete(s.hub.subscribers, s)
+		subscribersGauge.Dec()
+	}
+}
@@ -0,0 +1,72 @@
+package broadcast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	app := &model.StreamEvent{Type: "APP", Partition: "default", QueueName: "root.batch.etl"}
+	node := &model.StreamEvent{Type: "NODE"}
+	queue := &model.StreamEvent{Type: "QUEUE", QueueName: "root.batch"}
+
+	tests := map[string]struct {
+		filter Filter
+		want   []*model.StreamEvent
+	}{
+		"no filter":                {filter: Filter{}, want: []*model.StreamEvent{app, node, queue}},
+		"partition":                {filter: Filter{Partition: "default"}, want: []*model.StreamEvent{app, node, queue}},
+		"other partition":          {filter: Filter{Partition: "gpu"}, want: []*model.StreamEvent{node, queue}},
+		"queue":                    {filter: Filter{Queue: "root.batch"}, want: []*model.StreamEvent{app, queue}},
+		"child queue":              {filter: Filter{Queue: "root.batch.etl"}, want: []*model.StreamEvent{app}},
+		"queue with common prefix": {filter: Filter{Queue: "root.bat"}, want: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []*model.StreamEvent
+			for _, event := range []*model.StreamEvent{app, node, queue} {
+				if tt.filter.Matches(event) {
+					got = append(got, event)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	hub := NewHub(2)
+	all := hub.Subscribe(Filter{})
+	defer all.Close()
+	batch := hub.Subscribe(Filter{Queue: "root.batch"})
+
+	first := &model.StreamEvent{ObjectID: "app-1", QueueName: "root.batch"}
+	second := &model.StreamEvent{ObjectID: "app-2", QueueName: "root.default"}
+	third := &model.StreamEvent{ObjectID: "app-3", QueueName: "root.batch"}
+	hub.Publish(first)
+	hub.Publish(second)
+	// the buffer of the first subscriber is full, so that the event is dropped for it only
+	hub.Publish(third)
+
+	require.Len(t, all.Events(), 2)
+	assert.Equal(t, first, <-all.Events())
+	assert.Equal(t, second, <-all.Events())
+	assert.Equal(t, 1, all.TakeDropped())
+	assert.Equal(t, 0, all.TakeDropped())
+
+	require.Len(t, batch.Events(), 2)
+	assert.Equal(t, first, <-batch.Events())
+	assert.Equal(t, third, <-batch.Events())
+	assert.Equal(t, 0, batch.TakeDropped())
+
+	// closed subscriptions receive no further events
+	batch.Close()
+	batch.Close()
+	hub.Publish(third)
+	assert.Empty(t, batch.Events())
+	assert.Len(t, all.Events(), 1)
+}
@@ -0,0 +1,185 @@
+// Package cache caches the responses of the read endpoints, so that repeated requests do not query the database.
+// The server writing the data invalidates the cache when it changes the data. With Redis, the cache is shared by
+// all replicas and the invalidations are published to all of them, so that they serve consistent data.
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// Backend stores the cached entries. Entries are stored with the generation they were read in,
+// which changes when the entries are invalidated, so that entries read before an invalidation are not stored.
+type Backend interface {
+	// Get returns the entry of the key and the current generation. The generation is negative if it is unknown,
+	// in which case entries cannot be stored.
+	Get(ctx context.Context, key string) (value []byte, ok bool, generation int64, err error)
+	// Set stores the entry of the key for the duration of the TTL, unless the generation is outdated.
+	Set(ctx context.Context, generation int64, key string, value []byte, ttl time.Duration) error
+	// Invalidate removes all entries, including the entries of the other replicas sharing the backend.
+	Invalidate(ctx context.Context) error
+	// Run maintains the backend until the context is cancelled, e.g. receives the invalidations of other replicas.
+	Run(ctx context.Context) error
+}
+
+// Cache caches entries in a backend. Invalidations are debounced: they are applied at the configured interval,
+// so that a sync writing many records invalidates the cache once. A nil Cache caches nothing.
+type Cache struct {
+	backend  Backend
+	ttl      time.Duration
+	interval time.Duration
+	dirty    atomic.Bool
+}
+
+// New returns the cache configured by cfg, or nil if caching is disabled. Entries are cached in Redis
+// if a Redis address is configured, else in memory.
+func New(cfg *config.CacheConfig) *Cache {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewWithBackend(newBackend(cfg, cfg.Redis.KeyPrefix), cfg.TTL, cfg.InvalidationInterval)
+}
+
+// NewResults returns the cache of the results of the analytics and report endpoints configured by cfg, or nil if
+// caching is disabled. Its entries are stored with the TTL of their time window, and are kept apart from the
+// responses of the other endpoints, so that they are not invalidated when the data changes.
+func NewResults(cfg *config.CacheConfig) *Cache {
+	if !cfg.Enabled {
+		return nil
+	}
+	return NewWithBackend(newBackend(cfg, cfg.Redis.KeyPrefix+":results"), cfg.Results.RecentTTL, cfg.InvalidationInterval)
+}
+
+// newBackend returns the Redis backend with the key prefix if a Redis address is configured, else a memory backend.
+func newBackend(cfg *config.CacheConfig, keyPrefix string) Backend {
+	if cfg.Redis.Address == "" {
+		return NewMemoryBackend(cfg.MaxEntries)
+	}
+	opts := &redis.Options{
+		Addr:     cfg.Redis.Address,
+		Username: cfg.Redis.Username,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	}
+	if cfg.Redis.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return NewRedisBackend(redis.NewClient(opts), keyPrefix)
+}
+
+// NewWithBackend returns a cache storing entries in the backend for the duration of the TTL.
+// Invalidations are applied at the interval.
+func NewWithBackend(backend Backend, ttl, interval time.Duration) *Cache {
+	return &Cache{backend: backend, ttl: ttl, interval: interval}
+}
+
+// Entry is the result of a lookup. If the entry was not found, its value can be stored with Store.
+type Entry struct {
+	cache      *Cache
+	key        string
+	generation int64
+}
+
+// Get returns the value of the key. The returned entry stores the value if it was not found.
+// Errors of the backend are logged and treated as a miss, so that requests are served from the database.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, *Entry) {
+	if c == nil {
+		return nil, false, nil
+	}
+	value, ok, generation, err := c.backend.Get(ctx, key)
+	if err != nil {
+		log.FromContext(ctx).Warnf("could not get cache entry: %v", err)
+		return nil, false, nil
+	}
+	if ok {
+		return value, true, nil
+	}
+	if generation < 0 {
+		return nil, false, nil
+	}
+	return nil, false, &Entry{cache: c, key: key, generation: generation}
+}
+
+// Store stores the value of the entry for the TTL of the cache, unless the cache was invalidated since the entry
+// was looked up.
+func (e *Entry) Store(ctx context.Context, value []byte) {
+	if e == nil {
+		return
+	}
+	e.StoreFor(ctx, value, e.cache.ttl)
+}
+
+// StoreFor stores the value of the entry for the duration of the TTL, unless the cache was invalidated since
+// the entry was looked up.
+func (e *Entry) StoreFor(ctx context.Context, value []byte, ttl time.Duration) {
+	if e == nil {
+		return
+	}
+	if err := e.cache.backend.Set(ctx, e.generation, e.key, value, ttl); err != nil {
+		log.FromContext(ctx).Warnf("could not set cache entry: %v", err)
+	}
+}
+
+// Invalidate marks the cache to be invalidated at the next interval.
+func (c *Cache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.dirty.Store(true)
+}
+
+// Run runs the backend and applies the invalidations at the configured interval until the context is cancelled.
+func (c *Cache) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "cache")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Info("starting cache")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.backend.Run(ctx)
+	}()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warn("shutting down cache")
+			if errs == nil {
+				return nil
+			}
+			return <-errs
+		case err := <-errs:
+			if err != nil {
+				return err
+			}
+			// the backend has nothing to maintain
+			errs = nil
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+// flush applies the pending invalidation, if any. The invalidation is retried at the next interval if it fails.
+func (c *Cache) flush(ctx context.Context) {
+	if !c.dirty.Swap(false) {
+		return
+	}
+	if err := c.backend.Invalidate(ctx); err != nil {
+		log.FromContext(ctx).Errorf("could not invalidate cache: %v", err)
+		c.dirty.Store(true)
+	}
+}
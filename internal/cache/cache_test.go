@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	b := NewMemoryBackend(2)
+	b.now = func() time.Time { return now }
+
+	_, ok, generation, err := b.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, b.Set(ctx, generation, "a", []byte("1"), time.Minute))
+	require.NoError(t, b.Set(ctx, generation, "b", []byte("2"), time.Second))
+
+	value, ok, _, err := b.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	// b is the least recently used entry
+	require.NoError(t, b.Set(ctx, generation, "c", []byte("3"), time.Minute))
+	_, ok, _, _ = b.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok, _, _ = b.Get(ctx, "c")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok, _, _ = b.Get(ctx, "a")
+	assert.False(t, ok, "entry should have expired")
+}
+
+func TestMemoryBackend_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBackend(10)
+
+	_, _, before, _ := b.Get(ctx, "a")
+	require.NoError(t, b.Set(ctx, before, "a", []byte("1"), time.Minute))
+	require.NoError(t, b.Invalidate(ctx))
+	_, ok, after, _ := b.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.NotEqual(t, before, after)
+
+	// the value was read before the invalidation and may be stale
+	require.NoError(t, b.Set(ctx, before, "a", []byte("1"), time.Minute))
+	_, ok, _, _ = b.Get(ctx, "a")
+	assert.False(t, ok)
+}
+
+type failingBackend struct {
+	*MemoryBackend
+	err error
+}
+
+func (b *failingBackend) Invalidate(ctx context.Context) error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.MemoryBackend.Invalidate(ctx)
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	backend := &failingBackend{MemoryBackend: NewMemoryBackend(10)}
+	c := NewWithBackend(backend, time.Minute, time.Second)
+
+	_, ok, entry := c.Get(ctx, "a")
+	assert.False(t, ok)
+	entry.Store(ctx, []byte("1"))
+
+	// invalidations are applied at the next interval
+	c.Invalidate()
+	c.Invalidate()
+	value, ok, _ := c.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	// failed invalidations are retried
+	backend.err = errors.New("connection refused")
+	c.flush(ctx)
+	assert.True(t, c.dirty.Load())
+	backend.err = nil
+	c.flush(ctx)
+	assert.False(t, c.dirty.Load())
+	_, ok, _ = c.Get(ctx, "a")
+	assert.False(t, ok)
+}
+
+func TestEntry_StoreFor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	backend := NewMemoryBackend(10)
+	backend.now = func() time.Time { return now }
+	c := NewWithBackend(backend, time.Minute, time.Second)
+
+	_, _, entry := c.Get(ctx, "recent")
+	entry.Store(ctx, []byte("1"))
+	_, _, entry = c.Get(ctx, "past")
+	entry.StoreFor(ctx, []byte("2"), 24*time.Hour)
+
+	now = now.Add(time.Hour)
+	_, ok, _ := c.Get(ctx, "recent")
+	assert.False(t, ok)
+	value, ok, _ := c.Get(ctx, "past")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), value)
+}
+
+func TestCache_Nil(t *testing.T) {
+	var c *Cache
+	ctx := context.Background()
+	_, ok, entry := c.Get(ctx, "a")
+	assert.False(t, ok)
+	entry.Store(ctx, []byte("1"))
+	entry.StoreFor(ctx, []byte("1"), time.Hour)
+	c.Invalidate()
+}
+
+func TestStale(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	s := NewStale(2, time.Hour)
+	s.backend.now = func() time.Time { return now }
+
+	_, _, ok := s.Get("a")
+	assert.False(t, ok)
+	s.Store(ctx, "a", []byte("1"))
+	now = now.Add(time.Minute)
+	s.Store(ctx, "b", []byte("2"))
+
+	value, stored, ok := s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, now.Add(-time.Minute), stored)
+
+	// responses are replaced, and older ones are not served
+	s.Store(ctx, "b", []byte("3"))
+	value, stored, ok = s.Get("b")
+	require.True(t, ok)
+	assert.Equal(t, []byte("3"), value)
+	assert.Equal(t, now, stored)
+	now = now.Add(time.Hour)
+	_, _, ok = s.Get("a")
+	assert.False(t, ok, "response should be too old")
+
+	var disabled *Stale
+	disabled.Store(ctx, "a", []byte("1"))
+	_, _, ok = disabled.Get("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryBackend stores the entries in memory, evicting the least recently used entries beyond a maximum number
+// of entries. It is not shared by replicas, whose entries expire after the TTL.
+type MemoryBackend struct {
+	mu         sync.Mutex
+	maxEntries int
+	generation int64
+	entries    map[string]*list.Element
+	// lru holds the entries, most recently used first
+	lru *list.List
+	now func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+var _ Backend = &MemoryBackend{}
+
+func NewMemoryBackend(maxEntries int) *MemoryBackend {
+	return &MemoryBackend{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+func (b *MemoryBackend) Get(_ context.Context, key string) ([]byte, bool, int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.get(key)
+	if !ok {
+		return nil, false, b.generation, nil
+	}
+	return entry.value, true, b.generation, nil
+}
+
+func (b *MemoryBackend) Set(_ context.Context, generation int64, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation != b.generation {
+		return nil
+	}
+	entry := &memoryEntry{key: key, value: value, expires: b.now().Add(ttl)}
+	if element, ok := b.entries[key]; ok {
+		element.Value = entry
+		b.lru.MoveToFront(element)
+		return nil
+	}
+	b.entries[key] = b.lru.PushFront(entry)
+	for b.lru.Len() > b.maxEntries {
+		b.remove(b.lru.Back())
+	}
+	return nil
+}
+
+func (b *MemoryBackend) Invalidate(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.generation++
+	b.entries = make(map[string]*list.Element)
+	b.lru.Init()
+	return nil
+}
+
+// Run returns immediately, as there is nothing to maintain.
+func (b *MemoryBackend) Run(_ context.Context) error {
+	return nil
+}
+
+// get returns the entry of the key unless it expired. The backend must be locked.
+func (b *MemoryBackend) get(key string) (*memoryEntry, bool) {
+	element, ok := b.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !b.now().Before(entry.expires) {
+		b.remove(element)
+		return nil, false
+	}
+	b.lru.MoveToFront(element)
+	return entry, true
+}
+
+func (b *MemoryBackend) remove(element *list.Element) {
+	b.lru.Remove(element)
+	delete(b.entries, element.Value.(*memoryEntry).key)
+}
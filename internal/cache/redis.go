@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// redisRetryInterval is the interval at which the subscription to the invalidations is retried after an error.
+const redisRetryInterval = time.Second
+
+// RedisBackend stores the entries in Redis, shared by all replicas. The keys of the entries contain a generation,
+// which is incremented to invalidate all entries at once; the entries of previous generations expire after the TTL.
+// The new generation is published to all replicas, which keep the current generation in memory.
+type RedisBackend struct {
+	client     *redis.Client
+	prefix     string
+	generation atomic.Int64
+}
+
+var _ Backend = &RedisBackend{}
+
+// NewRedisBackend returns a backend storing entries in Redis under keys with the prefix. The generation is
+// unknown, so that no entries are cached, until the backend is run.
+func NewRedisBackend(client *redis.Client, prefix string) *RedisBackend {
+	b := &RedisBackend{client: client, prefix: prefix}
+	b.generation.Store(-1)
+	return b
+}
+
+func (b *RedisBackend) Get(ctx context.Context, key string) ([]byte, bool, int64, error) {
+	generation := b.generation.Load()
+	if generation < 0 {
+		return nil, false, generation, nil
+	}
+	value, err := b.client.Get(ctx, b.entryKey(generation, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, generation, nil
+	}
+	if err != nil {
+		return nil, false, generation, err
+	}
+	return value, true, generation, nil
+}
+
+func (b *RedisBackend) Set(ctx context.Context, generation int64, key string, value []byte, ttl time.Duration) error {
+	// entries of outdated generations are never read and expire
+	if generation != b.generation.Load() {
+		return nil
+	}
+	return b.client.Set(ctx, b.entryKey(generation, key), value, ttl).Err()
+}
+
+// Invalidate increments the generation and publishes it to the replicas.
+func (b *RedisBackend) Invalidate(ctx context.Context) error {
+	generation, err := b.client.Incr(ctx, b.generationKey()).Result()
+	if err != nil {
+		return fmt.Errorf("could not increment cache generation: %w", err)
+	}
+	b.generation.Store(generation)
+	if err := b.client.Publish(ctx, b.channel(), generation).Err(); err != nil {
+		return fmt.Errorf("could not publish cache generation: %w", err)
+	}
+	return nil
+}
+
+// Run receives the generations published by the replica invalidating the cache until the context is cancelled.
+// The current generation is read whenever the subscription is (re)established, as invalidations may have been
+// missed, and is unknown while the subscription is broken.
+func (b *RedisBackend) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	pubsub := b.client.Subscribe(ctx, b.channel())
+	defer func() {
+		_ = pubsub.Close()
+		_ = b.client.Close()
+	}()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			logger.Warnf("could not receive cache invalidations: %v", err)
+			b.generation.Store(-1)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(redisRetryInterval):
+			}
+			continue
+		}
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			if err := b.loadGeneration(ctx); err != nil {
+				logger.Warnf("could not read cache generation: %v", err)
+				b.generation.Store(-1)
+			}
+		case *redis.Message:
+			generation, err := strconv.ParseInt(msg.Payload, 10, 64)
+			if err != nil {
+				logger.Warnf("invalid cache generation %q: %v", msg.Payload, err)
+				continue
+			}
+			b.generation.Store(generation)
+		}
+	}
+}
+
+// loadGeneration reads the current generation, which is 0 until the cache is invalidated for the first time.
+func (b *RedisBackend) loadGeneration(ctx context.Context) error {
+	generation, err := b.client.Get(ctx, b.generationKey()).Int64()
+	if errors.Is(err, redis.Nil) {
+		generation, err = 0, nil
+	}
+	if err != nil {
+		return err
+	}
+	b.generation.Store(generation)
+	return nil
+}
+
+// entryKey hashes the key of the entry, as request URLs may be long.
+func (b *RedisBackend) entryKey(generation int64, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s:cache:%d:%s", b.prefix, generation, hex.EncodeToString(sum[:]))
+}
+
+func (b *RedisBackend) generationKey() string {
+	return b.prefix + ":cache:generation"
+}
+
+func (b *RedisBackend) channel() string {
+	return b.prefix + ":cache:invalidations"
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisBackend_Invalidations(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newBackend := func() *RedisBackend {
+		b := NewRedisBackend(redis.NewClient(&redis.Options{Addr: server.Addr()}), "yhs")
+		// nothing is cached until the generation is known
+		_, _, generation, err := b.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.Negative(t, generation)
+		go func() {
+			_ = b.Run(ctx)
+		}()
+		require.Eventually(t, func() bool { return b.generation.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+		return b
+	}
+	leader := newBackend()
+	replica := newBackend()
+
+	_, ok, generation, err := replica.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, replica.Set(ctx, generation, "a", []byte("1"), time.Minute))
+	value, ok, _, err := leader.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok, "entries should be shared by the replicas")
+	assert.Equal(t, []byte("1"), value)
+
+	require.NoError(t, leader.Invalidate(ctx))
+	require.Eventually(t, func() bool { return replica.generation.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	_, ok, _, err = replica.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok, "entries should have been invalidated")
+
+	// the value was read before the invalidation and may be stale
+	require.NoError(t, replica.Set(ctx, generation, "a", []byte("1"), time.Minute))
+	_, ok, _, _ = leader.Get(ctx, "a")
+	assert.False(t, ok)
+
+	// a replica starting after the invalidation reads the current generation
+	late := NewRedisBackend(redis.NewClient(&redis.Options{Addr: server.Addr()}), "yhs")
+	go func() {
+		_ = late.Run(ctx)
+	}()
+	require.Eventually(t, func() bool { return late.generation.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+}
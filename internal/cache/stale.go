@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// Stale keeps the last successful responses of the key read endpoints in memory, so that they can be served while
+// the database is unavailable. Unlike the cache, it is never invalidated: its responses are served knowing that
+// they are stale, until they are older than the maximum age. A nil Stale keeps nothing.
+type Stale struct {
+	backend *MemoryBackend
+	maxAge  time.Duration
+}
+
+func NewStale(maxEntries int, maxAge time.Duration) *Stale {
+	return &Stale{backend: NewMemoryBackend(maxEntries), maxAge: maxAge}
+}
+
+// Store keeps the response of the key, replacing the previous one.
+func (s *Stale) Store(ctx context.Context, key string, value []byte) {
+	if s == nil {
+		return
+	}
+	if err := s.backend.Set(ctx, 0, key, value, s.maxAge); err != nil {
+		log.FromContext(ctx).Warnf("could not keep stale response: %v", err)
+	}
+}
+
+// Get returns the last response of the key and the time it was stored, unless it is older than the maximum age.
+func (s *Stale) Get(key string) ([]byte, time.Time, bool) {
+	if s == nil {
+		return nil, time.Time{}, false
+	}
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+	entry, ok := s.backend.get(key)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	return entry.value, entry.expires.Add(-s.maxAge), true
+}
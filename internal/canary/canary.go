@@ -0,0 +1,233 @@
+// Package canary evaluates candidate implementations of the read methods of the repository, e.g. an optimized
+// query, against real traffic before they replace the current implementation. A configurable percentage of the
+// calls of a method runs the candidate next to the current implementation, the baseline, whose result is returned:
+// the latencies of both and whether their results match are exposed as metrics. As the percentage can be changed at
+// runtime, a candidate can be rolled out gradually and rolled back immediately.
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// Methods are the read methods of the repository which candidates can implement.
+var Methods = []string{
+	"CountFinishedApplications",
+	"GetAllApplications",
+	"GetAllQueues",
+	"GetApplicationSummaries",
+	"GetAppsPerPartitionPerQueue",
+	"GetHourlyUsage",
+	"GetNamespaceQueues",
+	"GetNodesPerPartition",
+	"GetPendingBacklog",
+	"GetQueueThroughput",
+	"GetQueuesPerPartition",
+	"GetTopUsage",
+	"QueryAnalytics",
+}
+
+// ErrUnknownCandidate is returned for methods without a registered candidate.
+var ErrUnknownCandidate = errors.New("unknown canary candidate")
+
+var (
+	durationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "yhs",
+		Subsystem: "canary",
+		Name:      "duration_seconds",
+		Help:      "Duration of the calls running a candidate by method and implementation: baseline or candidate.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "implementation"})
+	comparisonsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "canary",
+		Name:      "comparisons_total",
+		Help: "Number of results of candidates compared to those of the baseline by method and outcome: match, " +
+			"mismatch, or error if only the candidate failed.",
+	}, []string{"method", "outcome"})
+)
+
+// Candidate is a candidate implementation of a read method of the repository.
+type Candidate struct {
+	// Method is the name of the method of the repository, one of Methods.
+	Method string
+	// Percentage is the initial percentage of the calls of the method which run the candidate, between 0 and 100.
+	Percentage float64
+	// New returns the repository implementing the candidate, e.g. by embedding the baseline repository and
+	// overriding the method. Only the method is called on the returned repository.
+	New func(baseline repository.Repository) repository.Repository
+}
+
+// Status describes the rollout of a candidate.
+type Status struct {
+	Method string `json:"method"`
+	// Percentage is the percentage of the calls of the method which run the candidate.
+	Percentage float64 `json:"percentage"`
+}
+
+// ValidateCandidates returns an error if a candidate implements an unknown method, or a method implemented by
+// another candidate, or if its percentage is not between 0 and 100.
+func ValidateCandidates(candidates []Candidate) error {
+	var errs []error
+	methods := make(map[string]bool)
+	for i, c := range candidates {
+		switch {
+		case !slices.Contains(Methods, c.Method):
+			errs = append(errs, fmt.Errorf("candidate %d: method %q cannot have candidates", i, c.Method))
+		case methods[c.Method]:
+			errs = append(errs, fmt.Errorf("candidate %d: duplicate candidate of %s", i, c.Method))
+		}
+		methods[c.Method] = true
+		if err := validatePercentage(c.Percentage); err != nil {
+			errs = append(errs, fmt.Errorf("candidate %d: %w", i, err))
+		}
+		if c.New == nil {
+			errs = append(errs, fmt.Errorf("candidate %d: new is required", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validatePercentage(percentage float64) error {
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("percentage must be between 0 and 100, got %v", percentage)
+	}
+	return nil
+}
+
+// Canary runs the candidates for their percentage of the calls.
+//
+// A nil Canary never runs candidates.
+type Canary struct {
+	mutex      sync.RWMutex
+	candidates map[string]repository.Repository
+	percentage map[string]float64
+	// random returns a number in [0, 100), it is replaced in tests.
+	random func() float64
+}
+
+// New returns a canary running the candidates, which must be valid, against the baseline repository, or nil if there
+// are no candidates.
+func New(baseline repository.Repository, candidates []Candidate) *Canary {
+	if len(candidates) == 0 {
+		return nil
+	}
+	c := &Canary{
+		candidates: make(map[string]repository.Repository),
+		percentage: make(map[string]float64),
+		random: func() float64 {
+			return rand.Float64() * 100
+		},
+	}
+	for _, candidate := range candidates {
+		c.candidates[candidate.Method] = candidate.New(baseline)
+		c.percentage[candidate.Method] = candidate.Percentage
+	}
+	return c
+}
+
+// List returns the rollout of all candidates ordered by method.
+func (c *Canary) List() []Status {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	statuses := make([]Status, 0, len(c.percentage))
+	for method, percentage := range c.percentage {
+		statuses = append(statuses, Status{Method: method, Percentage: percentage})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Method < statuses[j].Method })
+	return statuses
+}
+
+// SetPercentage sets the percentage of the calls of the method which run its candidate, 0 stops running it.
+func (c *Canary) SetPercentage(method string, percentage float64) (Status, error) {
+	if err := validatePercentage(percentage); err != nil {
+		return Status{}, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.percentage[method]; !ok {
+		return Status{}, fmt.Errorf("%w: %s", ErrUnknownCandidate, method)
+	}
+	c.percentage[method] = percentage
+	return Status{Method: method, Percentage: percentage}, nil
+}
+
+// candidate returns the candidate of the method if the call runs it.
+func (c *Canary) candidate(method string) (repository.Repository, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mutex.RLock()
+	candidate, ok := c.candidates[method]
+	percentage := c.percentage[method]
+	c.mutex.RUnlock()
+	if !ok || percentage <= 0 || c.random() >= percentage {
+		return nil, false
+	}
+	return candidate, true
+}
+
+// run calls the method on the baseline repository, and on the candidate concurrently if the call runs it. The result
+// of the baseline is returned once both completed, after their results were compared. The errors of the candidate
+// are never returned.
+func run[T any](
+	ctx context.Context,
+	c *Canary,
+	method string,
+	baseline repository.Repository,
+	call func(repository.Repository) (T, error),
+) (T, error) {
+	candidate, ok := c.candidate(method)
+	if !ok {
+		return call(baseline)
+	}
+
+	var candidateResult T
+	var candidateErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				candidateErr = fmt.Errorf("candidate panicked: %v", r)
+			}
+		}()
+		start := time.Now()
+		candidateResult, candidateErr = call(candidate)
+		durationSeconds.WithLabelValues(method, "candidate").Observe(time.Since(start).Seconds())
+	}()
+	start := time.Now()
+	result, err := call(baseline)
+	durationSeconds.WithLabelValues(method, "baseline").Observe(time.Since(start).Seconds())
+	<-done
+
+	// the results are only compared if the baseline succeeded, as it failing does not tell about the candidate
+	if err != nil {
+		return result, err
+	}
+	logger := log.FromContext(ctx)
+	switch {
+	case candidateErr != nil:
+		comparisonsTotal.WithLabelValues(method, "error").Inc()
+		logger.Warnf("candidate of %s failed: %v", method, candidateErr)
+	case !reflect.DeepEqual(result, candidateResult):
+		comparisonsTotal.WithLabelValues(method, "mismatch").Inc()
+		logger.Warnf("result of the candidate of %s does not match the baseline", method)
+	default:
+		comparisonsTotal.WithLabelValues(method, "match").Inc()
+	}
+	return result, err
+}
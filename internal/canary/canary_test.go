@@ -0,0 +1,131 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestValidateCandidates(t *testing.T) {
+	newCandidate := func(baseline repository.Repository) repository.Repository { return baseline }
+	tests := map[string]struct {
+		candidates []Candidate
+		wantErr    string
+	}{
+		"no candidates": {},
+		"valid": {
+			candidates: []Candidate{
+				{Method: "QueryAnalytics", Percentage: 5, New: newCandidate},
+				{Method: "GetAllQueues", New: newCandidate},
+			},
+		},
+		"unsupported method": {
+			candidates: []Candidate{{Method: "UpsertApplications", New: newCandidate}},
+			wantErr:    `candidate 0: method "UpsertApplications" cannot have candidates`,
+		},
+		"duplicate method": {
+			candidates: []Candidate{
+				{Method: "QueryAnalytics", New: newCandidate},
+				{Method: "QueryAnalytics", New: newCandidate},
+			},
+			wantErr: "candidate 1: duplicate candidate of QueryAnalytics",
+		},
+		"invalid candidate": {
+			candidates: []Candidate{{Method: "QueryAnalytics", Percentage: 101}},
+			wantErr:    "candidate 0: percentage must be between 0 and 100, got 101\ncandidate 0: new is required",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCandidates(tt.candidates)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetPercentage(t *testing.T) {
+	assert.Nil(t, New(nil, nil))
+
+	c := New(nil, []Candidate{
+		{Method: "QueryAnalytics", Percentage: 5, New: func(repository.Repository) repository.Repository { return nil }},
+		{Method: "GetAllQueues", New: func(repository.Repository) repository.Repository { return nil }},
+	})
+	assert.Equal(t, []Status{{Method: "GetAllQueues"}, {Method: "QueryAnalytics", Percentage: 5}}, c.List())
+
+	status, err := c.SetPercentage("GetAllQueues", 50)
+	require.NoError(t, err)
+	assert.Equal(t, Status{Method: "GetAllQueues", Percentage: 50}, status)
+	assert.Equal(t, []Status{{Method: "GetAllQueues", Percentage: 50}, {Method: "QueryAnalytics", Percentage: 5}}, c.List())
+
+	_, err = c.SetPercentage("GetTopUsage", 50)
+	assert.ErrorIs(t, err, ErrUnknownCandidate)
+	_, err = c.SetPercentage("GetAllQueues", -1)
+	assert.EqualError(t, err, "percentage must be between 0 and 100, got -1")
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	since := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	mockCtrl := gomock.NewController(t)
+	baseline := repository.NewMockRepository(mockCtrl)
+	candidate := repository.NewMockRepository(mockCtrl)
+	c := New(baseline, []Candidate{{
+		Method:     "CountFinishedApplications",
+		Percentage: 10,
+		New:        func(repository.Repository) repository.Repository { return candidate },
+	}})
+	random := 50.0
+	c.random = func() float64 { return random }
+	repo := NewRepository(baseline, c)
+	comparisons := func(outcome string) float64 {
+		return testutil.ToFloat64(comparisonsTotal.WithLabelValues("CountFinishedApplications", outcome))
+	}
+
+	// the candidate does not run for the calls outside of its percentage
+	baseline.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(3, nil)
+	count, err := repo.CountFinishedApplications(ctx, "default", "root.a", "Completed", since)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// the results of the candidate are compared to those of the baseline, which are returned
+	random = 5
+	baseline.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(3, nil).Times(3)
+	candidate.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(3, nil)
+	candidate.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(4, nil)
+	candidate.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(0, errors.New("boom"))
+	for _, outcome := range []string{"match", "mismatch", "error"} {
+		before := comparisons(outcome)
+		count, err = repo.CountFinishedApplications(ctx, "default", "root.a", "Completed", since)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Equal(t, before+1, comparisons(outcome), outcome)
+	}
+
+	// the results are not compared if the baseline fails
+	baseline.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(0, errors.New("boom"))
+	candidate.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(3, nil)
+	before := comparisons("error")
+	_, err = repo.CountFinishedApplications(ctx, "default", "root.a", "Completed", since)
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, before, comparisons("error"))
+
+	// the candidate stops running once its percentage is 0
+	_, err = c.SetPercentage("CountFinishedApplications", 0)
+	require.NoError(t, err)
+	random = 0
+	baseline.EXPECT().CountFinishedApplications(ctx, "default", "root.a", "Completed", since).Return(3, nil)
+	_, err = repo.CountFinishedApplications(ctx, "default", "root.a", "Completed", since)
+	require.NoError(t, err)
+}
@@ -0,0 +1,123 @@
+package canary
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Repository runs the candidates of the canary for the methods of Methods, and passes the other calls to the wrapped
+// repository.
+type Repository struct {
+	repository.Repository
+	canary *Canary
+}
+
+var _ repository.Repository = &Repository{}
+
+// NewRepository wraps the repository, so that the candidates of the canary run next to its methods.
+// It returns the repository itself if the canary is nil.
+func NewRepository(repo repository.Repository, canary *Canary) repository.Repository {
+	if canary == nil {
+		return repo
+	}
+	return &Repository{Repository: repo, canary: canary}
+}
+
+func (r *Repository) GetAllApplications(ctx context.Context, filters repository.ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+	return run(ctx, r.canary, "GetAllApplications", r.Repository, func(repo repository.Repository) ([]*model.ApplicationDAOInfo, error) {
+		return repo.GetAllApplications(ctx, filters)
+	})
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, error) {
+	call := func(repo repository.Repository) ([]*model.ApplicationDAOInfo, error) {
+		return repo.GetAppsPerPartitionPerQueue(ctx, partition, queue, filters)
+	}
+	return run(ctx, r.canary, "GetAppsPerPartitionPerQueue", r.Repository, call)
+}
+
+func (r *Repository) GetApplicationSummaries(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationSummary, error) {
+	return run(ctx, r.canary, "GetApplicationSummaries", r.Repository, func(repo repository.Repository) ([]*model.ApplicationSummary, error) {
+		return repo.GetApplicationSummaries(ctx, partition, queue, filters)
+	})
+}
+
+func (r *Repository) CountFinishedApplications(ctx context.Context, partition, queue, state string, since time.Time) (int, error) {
+	return run(ctx, r.canary, "CountFinishedApplications", r.Repository, func(repo repository.Repository) (int, error) {
+		return repo.CountFinishedApplications(ctx, partition, queue, state, since)
+	})
+}
+
+func (r *Repository) GetNodesPerPartition(ctx context.Context, partition string) ([]*dao.NodeDAOInfo, error) {
+	return run(ctx, r.canary, "GetNodesPerPartition", r.Repository, func(repo repository.Repository) ([]*dao.NodeDAOInfo, error) {
+		return repo.GetNodesPerPartition(ctx, partition)
+	})
+}
+
+func (r *Repository) GetPendingBacklog(
+	ctx context.Context,
+	filters repository.PendingBacklogFilters,
+) ([]*model.PendingBacklogSample, error) {
+	return run(ctx, r.canary, "GetPendingBacklog", r.Repository, func(repo repository.Repository) ([]*model.PendingBacklogSample, error) {
+		return repo.GetPendingBacklog(ctx, filters)
+	})
+}
+
+func (r *Repository) GetAllQueues(ctx context.Context) ([]*model.PartitionQueueDAOInfo, error) {
+	return run(ctx, r.canary, "GetAllQueues", r.Repository, func(repo repository.Repository) ([]*model.PartitionQueueDAOInfo, error) {
+		return repo.GetAllQueues(ctx)
+	})
+}
+
+func (r *Repository) GetQueuesPerPartition(ctx context.Context, partition string) ([]*model.PartitionQueueDAOInfo, error) {
+	return run(ctx, r.canary, "GetQueuesPerPartition", r.Repository, func(repo repository.Repository) ([]*model.PartitionQueueDAOInfo, error) {
+		return repo.GetQueuesPerPartition(ctx, partition)
+	})
+}
+
+func (r *Repository) GetNamespaceQueues(
+	ctx context.Context,
+	partition string,
+	filters repository.NamespaceQueueFilters,
+) ([]*model.NamespaceQueue, error) {
+	return run(ctx, r.canary, "GetNamespaceQueues", r.Repository, func(repo repository.Repository) ([]*model.NamespaceQueue, error) {
+		return repo.GetNamespaceQueues(ctx, partition, filters)
+	})
+}
+
+func (r *Repository) QueryAnalytics(ctx context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	return run(ctx, r.canary, "QueryAnalytics", r.Repository, func(repo repository.Repository) ([]*model.AnalyticsRow, error) {
+		return repo.QueryAnalytics(ctx, query)
+	})
+}
+
+func (r *Repository) GetQueueThroughput(ctx context.Context, filters repository.ThroughputFilters) ([]*model.ThroughputBucket, error) {
+	return run(ctx, r.canary, "GetQueueThroughput", r.Repository, func(repo repository.Repository) ([]*model.ThroughputBucket, error) {
+		return repo.GetQueueThroughput(ctx, filters)
+	})
+}
+
+func (r *Repository) GetTopUsage(ctx context.Context, filters repository.TopUsageFilters) ([]*model.TopUsage, error) {
+	return run(ctx, r.canary, "GetTopUsage", r.Repository, func(repo repository.Repository) ([]*model.TopUsage, error) {
+		return repo.GetTopUsage(ctx, filters)
+	})
+}
+
+func (r *Repository) GetHourlyUsage(ctx context.Context, filters repository.HourlyUsageFilters) ([]*model.HourlyUsage, error) {
+	return run(ctx, r.canary, "GetHourlyUsage", r.Repository, func(repo repository.Repository) ([]*model.HourlyUsage, error) {
+		return repo.GetHourlyUsage(ctx, filters)
+	})
+}
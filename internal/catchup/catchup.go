@@ -0,0 +1,232 @@
+// Package catchup tracks the lag of the ingestion of the event stream of Yunikorn, and switches the ingestion to the
+// catch-up mode while the lag exceeds its threshold. In catch-up mode, the ingestion stores finished applications in
+// batches and defers looking up new applications in Yunikorn, and the configured periodic jobs are paused, until
+// the lag fell below the recovery lag.
+package catchup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// PausedBy is the principal of the pauses of the jobs paused in catch-up mode.
+const PausedBy = "catch-up"
+
+// State is the mode of the ingestion reported in notifications.
+type State string
+
+const (
+	StateCatchingUp State = "catching-up"
+	StateCaughtUp   State = "caught-up"
+)
+
+var (
+	ingestionLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Name:      "ingestion_lag_seconds",
+		Help:      "Lag of the last ingested event of the event stream behind its time in Yunikorn.",
+	})
+	catchUpActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Name:      "ingestion_catch_up_active",
+		Help:      "Whether the ingestion is in catch-up mode (1) or not (0).",
+	})
+	catchUpsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Name:      "ingestion_catch_ups_total",
+		Help:      "Number of times the ingestion entered the catch-up mode.",
+	})
+)
+
+// Notification is sent to the webhook when the catch-up mode is entered and left.
+type Notification struct {
+	State State `json:"state"`
+	// LagSeconds is the lag of the event which changed the mode.
+	LagSeconds float64 `json:"lagSeconds"`
+	// Since is the time the catch-up mode was entered.
+	Since time.Time `json:"since"`
+	// MaxLagSeconds is the maximum lag observed in catch-up mode.
+	MaxLagSeconds float64   `json:"maxLagSeconds"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Pauses returns the pauses of the jobs, e.g. the pauses made via the admin API.
+type Pauses interface {
+	// Paused returns the pause of the job, or nil if it is not paused.
+	Paused(name string) *model.PausedOperation
+}
+
+// Monitor tracks the lag of the ingested events and the catch-up mode. A nil Monitor never enters the catch-up mode.
+type Monitor struct {
+	lagThreshold time.Duration
+	recoveryLag  time.Duration
+	batchSize    int
+	pausedJobs   []string
+	pauses       Pauses
+	webhookURL   string
+	httpClient   *http.Client
+	now          func() time.Time
+
+	mutex  sync.RWMutex
+	active bool
+	since  time.Time
+	maxLag time.Duration
+}
+
+type Option func(*Monitor)
+
+// WithPauses sets the pauses of the jobs which apply in addition to those of the catch-up mode.
+func WithPauses(pauses Pauses) Option {
+	return func(m *Monitor) {
+		m.pauses = pauses
+	}
+}
+
+// NewMonitor creates the monitor of the configured thresholds. It returns nil if the catch-up mode is disabled.
+func NewMonitor(cfg *config.CatchUpConfig, opts ...Option) *Monitor {
+	if !cfg.Enabled() {
+		return nil
+	}
+	m := &Monitor{
+		lagThreshold: cfg.LagThreshold,
+		recoveryLag:  cfg.RecoveryLag,
+		batchSize:    cfg.BatchSize,
+		pausedJobs:   cfg.PausedJobs,
+		webhookURL:   cfg.WebhookURL,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Observe records the lag of an event ingested at the time it occurred in Yunikorn, enters the catch-up mode if
+// the lag exceeds the threshold, and leaves it once the lag fell below the recovery lag. It returns whether the
+// ingestion is in catch-up mode.
+func (m *Monitor) Observe(ctx context.Context, eventTime time.Time) bool {
+	if m == nil {
+		return false
+	}
+	now := m.now()
+	lag := max(now.Sub(eventTime), 0)
+	ingestionLag.Set(lag.Seconds())
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	switch {
+	case !m.active && lag > m.lagThreshold:
+		m.active, m.since, m.maxLag = true, now, lag
+		catchUpActive.Set(1)
+		catchUpsTotal.Inc()
+		log.FromContext(ctx).Warnw("ingestion is lagging behind, entering catch-up mode", "lag", lag.String(),
+			"lagThreshold", m.lagThreshold.String(), "pausedJobs", m.pausedJobs)
+		m.notify(ctx, StateCatchingUp, lag)
+	case m.active && lag < m.recoveryLag:
+		m.active = false
+		catchUpActive.Set(0)
+		log.FromContext(ctx).Infow("ingestion caught up, leaving catch-up mode", "lag", lag.String(),
+			"duration", now.Sub(m.since).String(), "maxLag", m.maxLag.String())
+		m.notify(ctx, StateCaughtUp, lag)
+	case m.active:
+		m.maxLag = max(m.maxLag, lag)
+	}
+	return m.active
+}
+
+// Active returns whether the ingestion is in catch-up mode.
+func (m *Monitor) Active() bool {
+	if m == nil {
+		return false
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.active
+}
+
+// BatchSize returns the number of finished applications stored at once in catch-up mode.
+func (m *Monitor) BatchSize() int {
+	if m == nil {
+		return 1
+	}
+	return m.batchSize
+}
+
+// Paused returns the pause of the job: its pause of the other pauses if any, or a pause of the catch-up mode if the
+// job is paused in catch-up mode, or nil if the job is not paused.
+func (m *Monitor) Paused(name string) *model.PausedOperation {
+	if m == nil {
+		return nil
+	}
+	if m.pauses != nil {
+		if op := m.pauses.Paused(name); op != nil {
+			return op
+		}
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if !m.active || !slices.Contains(m.pausedJobs, name) {
+		return nil
+	}
+	return &model.PausedOperation{
+		Name:     name,
+		Reason:   "the ingestion is catching up",
+		PausedBy: PausedBy,
+		PausedAt: m.since.Unix(),
+	}
+}
+
+// notify posts a notification about the change of the mode to the configured webhook, if any. The notification is
+// posted in the background, so that it does not delay the ingestion. The monitor must be locked.
+func (m *Monitor) notify(ctx context.Context, state State, lag time.Duration) {
+	if m.webhookURL == "" {
+		return
+	}
+	notification := Notification{
+		State:         state,
+		LagSeconds:    lag.Seconds(),
+		Since:         m.since,
+		MaxLagSeconds: m.maxLag.Seconds(),
+		Timestamp:     m.now(),
+	}
+	go func() {
+		if err := m.post(ctx, notification); err != nil {
+			log.FromContext(ctx).Errorw("could not send catch-up notification", "state", state, "error", err)
+		}
+	}()
+}
+
+func (m *Monitor) post(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
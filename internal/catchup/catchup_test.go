@@ -0,0 +1,81 @@
+package catchup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+type fakePauses map[string]*model.PausedOperation
+
+func (p fakePauses) Paused(name string) *model.PausedOperation {
+	return p[name]
+}
+
+func TestMonitor(t *testing.T) {
+	notifications := make(chan Notification, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		notifications <- n
+	}))
+	defer server.Close()
+
+	adminPause := &model.PausedOperation{Name: "retention", PausedBy: "ops"}
+	m := NewMonitor(&config.CatchUpConfig{
+		LagThreshold: 5 * time.Minute,
+		RecoveryLag:  time.Minute,
+		BatchSize:    100,
+		PausedJobs:   []string{"data-quality", "slo"},
+		WebhookURL:   server.URL,
+	}, WithPauses(fakePauses{"retention": adminPause}))
+	now := time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.False(t, m.Observe(ctx, now.Add(-time.Minute)))
+	assert.Nil(t, m.Paused("slo"))
+	assert.Equal(t, adminPause, m.Paused("retention"))
+
+	// the catch-up mode is entered beyond the threshold and pauses the configured jobs
+	assert.True(t, m.Observe(ctx, now.Add(-10*time.Minute)))
+	n := <-notifications
+	assert.Equal(t, StateCatchingUp, n.State)
+	assert.Equal(t, 600.0, n.LagSeconds)
+	pause := m.Paused("slo")
+	require.NotNil(t, pause)
+	assert.Equal(t, PausedBy, pause.PausedBy)
+	assert.Equal(t, now.Unix(), pause.PausedAt)
+	assert.Nil(t, m.Paused("alerting"))
+	assert.Equal(t, adminPause, m.Paused("retention"))
+
+	// the catch-up mode is kept until the lag falls below the recovery lag
+	now = now.Add(20 * time.Minute)
+	assert.True(t, m.Observe(ctx, now.Add(-15*time.Minute)))
+	assert.True(t, m.Observe(ctx, now.Add(-2*time.Minute)))
+	assert.True(t, m.Active())
+	assert.False(t, m.Observe(ctx, now.Add(-30*time.Second)))
+	n = <-notifications
+	assert.Equal(t, StateCaughtUp, n.State)
+	assert.Equal(t, 900.0, n.MaxLagSeconds)
+	assert.Equal(t, now.Add(-20*time.Minute), n.Since.UTC())
+	assert.Nil(t, m.Paused("slo"))
+}
+
+func TestMonitorDisabled(t *testing.T) {
+	m := NewMonitor(&config.CatchUpConfig{})
+	assert.Nil(t, m)
+	assert.False(t, m.Observe(context.Background(), time.Now().Add(-time.Hour)))
+	assert.False(t, m.Active())
+	assert.Equal(t, 1, m.BatchSize())
+	assert.Nil(t, m.Paused("slo"))
+}
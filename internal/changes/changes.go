@@ -0,0 +1,162 @@
+// Package changes notifies clients of new data. The server writing the data notifies the feed of the topics
+// it changed, and clients wait on the feed for changes of the topics they are interested in since a cursor.
+package changes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Topic is a kind of data which changes.
+type Topic string
+
+const (
+	TopicApplications Topic = "applications"
+	TopicHistory      Topic = "history"
+	TopicNodes        Topic = "nodes"
+	TopicPartitions   Topic = "partitions"
+	TopicQueues       Topic = "queues"
+	TopicLegalHolds   Topic = "legal-holds"
+	TopicEpochs       Topic = "epochs"
+	TopicErasures     Topic = "erasures"
+)
+
+// Topics lists all topics.
+var Topics = []Topic{
+	TopicApplications, TopicHistory, TopicNodes, TopicPartitions, TopicQueues, TopicLegalHolds, TopicEpochs,
+	TopicErasures,
+}
+
+// ParseTopic returns the topic of the name.
+func ParseTopic(name string) (Topic, error) {
+	for _, t := range Topics {
+		if string(t) == name {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown topic %q", name)
+}
+
+// Feed numbers the changes notified to it, so that clients can wait for the changes since a cursor.
+// Cursors are only valid for the feed which issued them, as the changes are numbered in memory.
+type Feed struct {
+	mu sync.Mutex
+	// id identifies the feed in its cursors
+	id  string
+	seq uint64
+	// last holds the number of the last change of each topic
+	last map[Topic]uint64
+	// wake is closed and replaced when a change is notified
+	wake        chan struct{}
+	subscribers []func(topics []Topic)
+}
+
+func NewFeed() *Feed {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return &Feed{
+		id:   hex.EncodeToString(id),
+		last: make(map[Topic]uint64),
+		wake: make(chan struct{}),
+	}
+}
+
+// Subscribe registers fn to be called with the topics of every change. fn must not block.
+func (f *Feed) Subscribe(fn func(topics []Topic)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribers = append(f.subscribers, fn)
+}
+
+// Notify records a change of the topics and wakes the waiting clients.
+func (f *Feed) Notify(topics ...Topic) {
+	if len(topics) == 0 {
+		return
+	}
+	f.mu.Lock()
+	f.seq++
+	for _, t := range topics {
+		f.last[t] = f.seq
+	}
+	close(f.wake)
+	f.wake = make(chan struct{})
+	subscribers := f.subscribers
+	f.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(topics)
+	}
+}
+
+// Changes are the changes since a cursor.
+type Changes struct {
+	// Cursor is the cursor to wait for the next changes from.
+	Cursor string `json:"cursor"`
+	// Topics are the changed topics, empty if nothing changed.
+	Topics []Topic `json:"topics"`
+	// Reset is set if the cursor was not issued by the feed, e.g. before the server restarted,
+	// so that the changes since the cursor are unknown and clients must reload the data.
+	Reset bool `json:"reset"`
+}
+
+// Wait waits until any of the topics, or any topic if none are given, changed since the cursor,
+// or until the context is done. Without cursor, it returns the current cursor immediately.
+func (f *Feed) Wait(ctx context.Context, cursor string, topics []Topic) Changes {
+	if len(topics) == 0 {
+		topics = Topics
+	}
+	f.mu.Lock()
+	current := f.cursor()
+	if cursor == "" {
+		f.mu.Unlock()
+		return Changes{Cursor: current, Topics: []Topic{}}
+	}
+	since, ok := f.parseCursor(cursor)
+	if !ok {
+		f.mu.Unlock()
+		return Changes{Cursor: current, Topics: []Topic{}, Reset: true}
+	}
+	for {
+		changed := []Topic{}
+		for _, t := range topics {
+			if f.last[t] > since {
+				changed = append(changed, t)
+			}
+		}
+		current = f.cursor()
+		wake := f.wake
+		f.mu.Unlock()
+		if len(changed) > 0 {
+			return Changes{Cursor: current, Topics: changed}
+		}
+		select {
+		case <-ctx.Done():
+			return Changes{Cursor: current, Topics: changed}
+		case <-wake:
+		}
+		f.mu.Lock()
+	}
+}
+
+// cursor returns the cursor of the last change. The feed must be locked.
+func (f *Feed) cursor() string {
+	return f.id + "." + strconv.FormatUint(f.seq, 10)
+}
+
+// parseCursor returns the number of the change of the cursor, if it was issued by the feed. The feed must be locked.
+func (f *Feed) parseCursor(cursor string) (uint64, bool) {
+	id, seq, ok := strings.Cut(cursor, ".")
+	if !ok || id != f.id {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil || n > f.seq {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,75 @@
+package changes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed_Wait(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed()
+
+	initial := feed.Wait(ctx, "", nil)
+	assert.Empty(t, initial.Topics)
+	assert.False(t, initial.Reset)
+
+	feed.Notify(TopicNodes)
+	changes := feed.Wait(ctx, initial.Cursor, []Topic{TopicNodes, TopicQueues})
+	assert.Equal(t, []Topic{TopicNodes}, changes.Topics)
+	assert.NotEqual(t, initial.Cursor, changes.Cursor)
+
+	// changes of other topics do not wake the client, but advance the cursor
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	feed.Notify(TopicApplications)
+	unchanged := feed.Wait(timeoutCtx, changes.Cursor, []Topic{TopicQueues})
+	assert.Empty(t, unchanged.Topics)
+	assert.NotEqual(t, changes.Cursor, unchanged.Cursor)
+
+	done := make(chan Changes)
+	go func() {
+		done <- feed.Wait(ctx, unchanged.Cursor, nil)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	feed.Notify(TopicQueues, TopicHistory)
+	select {
+	case changes := <-done:
+		assert.ElementsMatch(t, []Topic{TopicQueues, TopicHistory}, changes.Topics)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "client was not woken")
+	}
+}
+
+func TestFeed_WaitReset(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed()
+	other := NewFeed()
+	other.Notify(TopicNodes)
+
+	tests := map[string]string{
+		"other feed":    other.Wait(ctx, "", nil).Cursor,
+		"future change": feed.id + ".1",
+		"malformed":     "cursor",
+	}
+	for name, cursor := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes := feed.Wait(ctx, cursor, nil)
+			assert.True(t, changes.Reset)
+			assert.Equal(t, feed.Wait(ctx, "", nil).Cursor, changes.Cursor)
+		})
+	}
+}
+
+func TestParseTopic(t *testing.T) {
+	for _, topic := range Topics {
+		parsed, err := ParseTopic(string(topic))
+		require.NoError(t, err)
+		assert.Equal(t, topic, parsed)
+	}
+	_, err := ParseTopic("events")
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package changes
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+const (
+	// channel is the Postgres notification channel the changes are published on.
+	channel = "yhs_changes"
+	// defaultPublishInterval is the default interval at which the changes are published.
+	defaultPublishInterval = time.Second
+	// listenRetryInterval is the interval at which listening is retried after an error.
+	listenRetryInterval = 5 * time.Second
+)
+
+// Publisher publishes the changes notified to the feed of the server writing the data to the read-only servers,
+// with Postgres notifications. The changes are published at an interval, so that a sync changing many records
+// publishes them once.
+type Publisher struct {
+	pool     *pgxpool.Pool
+	interval time.Duration
+	mu       sync.Mutex
+	pending  map[Topic]bool
+}
+
+// NewPublisher returns a publisher of the changes notified to the feed.
+func NewPublisher(pool *pgxpool.Pool, feed *Feed) *Publisher {
+	p := &Publisher{pool: pool, interval: defaultPublishInterval, pending: make(map[Topic]bool)}
+	feed.Subscribe(func(topics []Topic) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, t := range topics {
+			p.pending[t] = true
+		}
+	})
+	return p
+}
+
+// Run publishes the pending changes at the interval until the context is cancelled.
+func (p *Publisher) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "changes_publisher")
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.publish(ctx); err != nil {
+				logger.Errorf("could not publish changes: %v", err)
+			}
+		}
+	}
+}
+
+// publish publishes the pending changes, which are published again at the next interval if it fails.
+func (p *Publisher) publish(ctx context.Context) error {
+	p.mu.Lock()
+	topics := make([]string, 0, len(p.pending))
+	for t := range p.pending {
+		topics = append(topics, string(t))
+	}
+	p.pending = make(map[Topic]bool)
+	p.mu.Unlock()
+	if len(topics) == 0 {
+		return nil
+	}
+
+	if _, err := p.pool.Exec(ctx, "SELECT pg_notify($1, $2)", channel, strings.Join(topics, ",")); err != nil {
+		p.mu.Lock()
+		for _, t := range topics {
+			p.pending[Topic(t)] = true
+		}
+		p.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Listen notifies the feed of a read-only server of the changes published by the server writing the data,
+// until the context is cancelled. It holds a connection of the pool. Changes may be missed while the connection
+// is broken, so all topics are notified when it is re-established.
+func Listen(ctx context.Context, pool *pgxpool.Pool, feed *Feed) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "changes_listener")
+
+	reconnected := false
+	for {
+		err := listen(ctx, pool, feed, reconnected)
+		if ctx.Err() != nil {
+			return nil
+		}
+		logger.Errorf("could not listen for changes: %v", err)
+		reconnected = true
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+func listen(ctx context.Context, pool *pgxpool.Pool, feed *Feed, reconnected bool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	if reconnected {
+		feed.Notify(Topics...)
+	}
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			// the connection is in an unknown state
+			_ = conn.Conn().Close(context.Background())
+			return err
+		}
+		var topics []Topic
+		for _, name := range strings.Split(notification.Payload, ",") {
+			if t, err := ParseTopic(name); err == nil {
+				topics = append(topics, t)
+			}
+		}
+		feed.Notify(topics...)
+	}
+}
@@ -0,0 +1,47 @@
+package changes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestPublishListen_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	writer := NewFeed()
+	publisher := NewPublisher(connPool, writer)
+	publisher.interval = 10 * time.Millisecond
+	go func() {
+		_ = publisher.Run(ctx)
+	}()
+
+	replica := NewFeed()
+	go func() {
+		_ = Listen(ctx, connPool, replica)
+	}()
+	cursor := replica.Wait(ctx, "", nil).Cursor
+
+	// the listener may not be listening yet, so the change is notified until it is received
+	var changes Changes
+	require.Eventually(t, func() bool {
+		writer.Notify(TopicQueues)
+		waitCtx, waitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer waitCancel()
+		changes = replica.Wait(waitCtx, cursor, nil)
+		return len(changes.Topics) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []Topic{TopicQueues}, changes.Topics)
+}
@@ -0,0 +1,161 @@
+package changes
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Repository notifies the feed of the topics changed by every write of the wrapped repository. Writes which fail
+// are notified too, as they may have written some of the records. The progress of user erasures is not
+// a topic, as it is only visible to administrators, and neither are cluster registrations and heartbeats,
+// as the status of clusters changes with time rather than with writes, nor the events of the traces of applications,
+// which change with every event of the event stream and are not cached.
+type Repository struct {
+	repository.Repository
+	feed *Feed
+}
+
+// NewRepository wraps the repository, so that its writes notify the feed.
+func NewRepository(repo repository.Repository, feed *Feed) repository.Repository {
+	return &Repository{Repository: repo, feed: feed}
+}
+
+var _ repository.Repository = &Repository{}
+
+func (r *Repository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
+	defer r.feed.Notify(TopicApplications)
+	return r.Repository.UpsertApplications(ctx, apps)
+}
+
+func (r *Repository) DeleteApplicationsFinishedBefore(
+	ctx context.Context, partition, queue string, before time.Time, limit int) (int64, error) {
+	defer r.feed.Notify(TopicApplications)
+	return r.Repository.DeleteApplicationsFinishedBefore(ctx, partition, queue, before, limit)
+}
+
+func (r *Repository) BulkDeleteApplications(ctx context.Context, filters repository.BulkDeleteFilters, limit int) (int64, error) {
+	defer r.feed.Notify(TopicApplications, TopicErasures)
+	return r.Repository.BulkDeleteApplications(ctx, filters, limit)
+}
+
+func (r *Repository) MoveApplicationsToColdTier(ctx context.Context, before time.Time, limit int) (int64, error) {
+	defer r.feed.Notify(TopicApplications)
+	return r.Repository.MoveApplicationsToColdTier(ctx, before, limit)
+}
+
+func (r *Repository) UpdateHistory(
+	ctx context.Context,
+	apps []*dao.ApplicationHistoryDAOInfo,
+	containers []*dao.ContainerHistoryDAOInfo,
+) error {
+	defer r.feed.Notify(TopicHistory)
+	return r.Repository.UpdateHistory(ctx, apps, containers)
+}
+
+func (r *Repository) DeleteHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	defer r.feed.Notify(TopicHistory)
+	return r.Repository.DeleteHistoryBefore(ctx, before, limit)
+}
+
+func (r *Repository) UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
+	defer r.feed.Notify(TopicNodes)
+	return r.Repository.UpsertNodes(ctx, nodes, partition)
+}
+
+func (r *Repository) RecordNodeTermination(
+	ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+) (*model.NodeTermination, error) {
+	defer r.feed.Notify(TopicNodes)
+	return r.Repository.RecordNodeTermination(ctx, nodeID, terminatedAt, spotSelectors)
+}
+
+func (r *Repository) AddAutoscalerEvents(ctx context.Context, events []*model.AutoscalerEvent) (int, error) {
+	defer r.feed.Notify(TopicNodes)
+	return r.Repository.AddAutoscalerEvents(ctx, events)
+}
+
+func (r *Repository) InsertNodeUtilizations(
+	ctx context.Context,
+	uuid uuid.UUID,
+	partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo,
+) error {
+	defer r.feed.Notify(TopicNodes)
+	return r.Repository.InsertNodeUtilizations(ctx, uuid, partitionNodesUtil)
+}
+
+func (r *Repository) UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error {
+	defer r.feed.Notify(TopicPartitions)
+	return r.Repository.UpsertPartitions(ctx, partitions)
+}
+
+func (r *Repository) AddQueues(ctx context.Context, parentId *string, queues []*dao.PartitionQueueDAOInfo) error {
+	defer r.feed.Notify(TopicQueues)
+	return r.Repository.AddQueues(ctx, parentId, queues)
+}
+
+func (r *Repository) UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	defer r.feed.Notify(TopicQueues)
+	return r.Repository.UpsertQueues(ctx, queues)
+}
+
+func (r *Repository) DeleteQueues(ctx context.Context, queues []*model.PartitionQueueDAOInfo) error {
+	defer r.feed.Notify(TopicQueues)
+	return r.Repository.DeleteQueues(ctx, queues)
+}
+
+func (r *Repository) CreateLegalHold(ctx context.Context, hold *model.LegalHold) error {
+	defer r.feed.Notify(TopicLegalHolds)
+	return r.Repository.CreateLegalHold(ctx, hold)
+}
+
+func (r *Repository) ReleaseLegalHold(ctx context.Context, id, releasedBy, reason string, ifVersion *int64) (*model.LegalHold, error) {
+	defer r.feed.Notify(TopicLegalHolds)
+	return r.Repository.ReleaseLegalHold(ctx, id, releasedBy, reason, ifVersion)
+}
+
+func (r *Repository) EraseUserApplications(ctx context.Context, user, pseudonym, mode string, limit int) (int64, error) {
+	defer r.feed.Notify(TopicApplications, TopicErasures)
+	return r.Repository.EraseUserApplications(ctx, user, pseudonym, mode, limit)
+}
+
+func (r *Repository) PseudonymizeAuditRecords(ctx context.Context, user, pseudonym string) (int64, error) {
+	defer r.feed.Notify(TopicLegalHolds)
+	return r.Repository.PseudonymizeAuditRecords(ctx, user, pseudonym)
+}
+
+func (r *Repository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	defer r.feed.Notify(TopicQueues)
+	return r.Repository.UpdateQueueACLs(ctx, acls, at)
+}
+
+func (r *Repository) RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error) {
+	defer r.feed.Notify(TopicEpochs)
+	return r.Repository.RecordSchedulerEpoch(ctx, epoch)
+}
+
+// ApplyIngestBatch notifies the feed of the topics changed by the writes of the batch once the transaction applying
+// them ended, so that clients do not poll for writes which are not visible yet.
+func (r *Repository) ApplyIngestBatch(
+	ctx context.Context,
+	source string,
+	sequence int64,
+	fn func(ctx context.Context, tx repository.Repository) error,
+) (int64, error) {
+	batchFeed := NewFeed()
+	var changed []Topic
+	batchFeed.Subscribe(func(topics []Topic) {
+		changed = append(changed, topics...)
+	})
+	defer func() {
+		r.feed.Notify(changed...)
+	}()
+	return r.Repository.ApplyIngestBatch(ctx, source, sequence, func(ctx context.Context, tx repository.Repository) error {
+		return fn(ctx, NewRepository(tx, batchFeed))
+	})
+}
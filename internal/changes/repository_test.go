@@ -0,0 +1,170 @@
+package changes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+// methodTopics maps the methods of the repository to the topics they change, nil for reads.
+var methodTopics = map[string][]Topic{
+	"UpsertApplications":               {TopicApplications},
+	"GetAllApplications":               nil,
+	"GetAppsPerPartitionPerQueue":      nil,
+	"GetAllocationConstraints":         nil,
+	"StreamAppsPerPartitionPerQueue":   nil,
+	"GetAppsPerPartitionPerQueuePage":  nil,
+	"GetApplicationSummaries":          nil,
+	"CountFinishedApplications":        nil,
+	"DeleteApplicationsFinishedBefore": {TopicApplications},
+	"CountBulkDeleteApplications":      nil,
+	"BulkDeleteApplications":           {TopicApplications, TopicErasures},
+	"MoveApplicationsToColdTier":       {TopicApplications},
+	"UpdateHistory":                    {TopicHistory},
+	"DeleteHistoryBefore":              {TopicHistory},
+	"GetApplicationsHistory":           nil,
+	"GetContainersHistory":             nil,
+	"GetApplicationsHistoryPage":       nil,
+	"GetContainersHistoryPage":         nil,
+	"StreamApplicationsHistory":        nil,
+	"StreamContainersHistory":          nil,
+	"UpsertNodes":                      {TopicNodes},
+	"InsertNodeUtilizations":           {TopicNodes},
+	"GetNodeUtilizations":              nil,
+	"GetNodesPerPartition":             nil,
+	"GetNodesPerPartitionPage":         nil,
+	"GetNode":                          nil,
+	"GetNodeTopologies":                nil,
+	"RecordNodeTermination":            {TopicNodes},
+	"GetNodeTerminations":              nil,
+	"AddAutoscalerEvents":              {TopicNodes},
+	"GetAutoscalerEvents":              nil,
+	"GetPendingBacklog":                nil,
+	"UpsertPartitions":                 {TopicPartitions},
+	"GetAllPartitions":                 nil,
+	"AddQueues":                        {TopicQueues},
+	"UpsertQueues":                     {TopicQueues},
+	"GetAllQueues":                     nil,
+	"GetQueuesPerPartition":            nil,
+	"GetQueue":                         nil,
+	"DeleteQueues":                     {TopicQueues},
+	"CreateLegalHold":                  {TopicLegalHolds},
+	"GetLegalHolds":                    nil,
+	"GetLegalHold":                     nil,
+	"ReleaseLegalHold":                 {TopicLegalHolds},
+	"AddQueueLineage":                  nil,
+	"GetQueueLineage":                  nil,
+	"DeleteQueueLineage":               nil,
+	"StartUserErasure":                 nil,
+	"UpdateUserErasure":                nil,
+	"EraseUserApplications":            {TopicApplications, TopicErasures},
+	"CountUserApplications":            nil,
+	"PseudonymizeAuditRecords":         {TopicLegalHolds},
+	"UpdateQueueACLs":                  {TopicQueues},
+	"GetQueueACLs":                     nil,
+	"GetNamespaceQueues":               nil,
+	"AddTraceEvent":                    nil,
+	"GetTraceEvents":                   nil,
+	"DeleteTraceEventsBefore":          nil,
+	"RecordSchedulerEpoch":             {TopicEpochs},
+	"GetSchedulerEpochs":               nil,
+	"QueryAnalytics":                   nil,
+	"EstimateAnalytics":                nil,
+	"StartAnalyticsSink":               nil,
+	"StopAnalyticsSink":                nil,
+	"GetAnalyticsSink":                 nil,
+	"BackfillAnalyticsSink":            nil,
+	"ConsumeAnalyticsChanges":          nil,
+	"GetQueueThroughput":               nil,
+	"GetDuplicateApplications":         nil,
+	"GetTopUsage":                      nil,
+	"GetHourlyUsage":                   nil,
+	"GetPrincipals":                    nil,
+	"GetQueueWaitPhases":               nil,
+	"CheckDataQuality":                 nil,
+	"GetDataQualityChecks":             nil,
+	"GetDataQualityViolations":         nil,
+	"SyncConfigSLOs":                   nil,
+	"PutSLO":                           nil,
+	"DeleteSLO":                        nil,
+	"GetSLOs":                          nil,
+	"EvaluateSLO":                      nil,
+	"GetSLOEvaluations":                nil,
+	"DeleteSLOEvaluationsBefore":       nil,
+	"RegisterCluster":                  nil,
+	"HeartbeatCluster":                 nil,
+	"GetClusters":                      nil,
+	"PauseOperation":                   nil,
+	"ResumeOperation":                  nil,
+	"GetPausedOperations":              nil,
+	"ApplyIngestBatch":                 nil,
+}
+
+// TestRepository_Topics ensures that new methods of the repository are classified, so that writes are not
+// forgotten to notify the feed.
+func TestRepository_Topics(t *testing.T) {
+	repoType := reflect.TypeOf((*repository.Repository)(nil)).Elem()
+	for i := 0; i < repoType.NumMethod(); i++ {
+		method := repoType.Method(i)
+		t.Run(method.Name, func(t *testing.T) {
+			want, ok := methodTopics[method.Name]
+			if !assert.True(t, ok, "method %s must be classified", method.Name) {
+				return
+			}
+			feed := NewFeed()
+			var got []Topic
+			feed.Subscribe(func(topics []Topic) {
+				got = append(got, topics...)
+			})
+			// the wrapped repository is nil, so every call panics after the feed is notified, if it is
+			r := reflect.ValueOf(NewRepository(nil, feed))
+			args := make([]reflect.Value, method.Type.NumIn())
+			for j := range args {
+				args[j] = reflect.Zero(method.Type.In(j))
+			}
+			func() {
+				defer func() {
+					_ = recover()
+				}()
+				r.MethodByName(method.Name).Call(args)
+			}()
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+// TestRepository_ApplyIngestBatch ensures that the writes of a batch notify the feed once the batch is applied.
+func TestRepository_ApplyIngestBatch(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	feed := NewFeed()
+	var got []Topic
+	feed.Subscribe(func(topics []Topic) {
+		got = append(got, topics...)
+	})
+
+	repo.EXPECT().UpsertNodes(gomock.Any(), gomock.Any(), "default").Return(nil)
+	repo.EXPECT().UpdateHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().ApplyIngestBatch(gomock.Any(), "collector-1", int64(1), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string, sequence int64, fn func(context.Context, repository.Repository) error) (int64, error) {
+			err := fn(ctx, repo)
+			assert.Empty(t, got, "writes must not be notified before the batch is applied")
+			return sequence, err
+		})
+
+	_, err := NewRepository(repo, feed).ApplyIngestBatch(ctx, "collector-1", 1, func(ctx context.Context, tx repository.Repository) error {
+		if err := tx.UpsertNodes(ctx, nil, "default"); err != nil {
+			return err
+		}
+		return tx.UpdateHistory(ctx, nil, nil)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Topic{TopicNodes, TopicHistory}, got)
+}
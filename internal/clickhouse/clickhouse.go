@@ -0,0 +1,161 @@
+// Package clickhouse replicates the applications to ClickHouse and serves the analytics queries from there,
+// as aggregations over the applications table of the database are too slow once it holds hundreds of millions of
+// rows. The database remains the source of truth: the applications are copied to ClickHouse by a backfill, and then
+// by consuming the changes recorded in the database, so the results of the analytics queries lag behind by the
+// interval at which the changes are consumed.
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// maxErrorBodySize is the maximum size of an error response of ClickHouse which is returned in errors.
+const maxErrorBodySize = 4096
+
+// createTableSQL creates the applications table. Every change of an application is inserted as a new version of its
+// row, and the ReplacingMergeTree engine keeps the last version of the rows with the same sorting key, which
+// identifies an application, as the partition and queue of an application do not change. Deleted applications are
+// inserted with is_deleted set, and the replaced and deleted rows are skipped by queries with FINAL.
+const createTableSQL = `CREATE TABLE IF NOT EXISTS applications (
+	id UUID,
+	partition String,
+	queue_name String,
+	"user" Nullable(String),
+	state Nullable(String),
+	workflow_id Nullable(String),
+	has_reserved Nullable(Bool),
+	max_request_priority Nullable(Int32),
+	priority Nullable(Int32),
+	priority_class Nullable(String),
+	wait_time Nullable(Int64),
+	submission_time Nullable(Int64),
+	finished_time Nullable(Int64),
+	version UInt64,
+	is_deleted UInt8
+) ENGINE = ReplacingMergeTree(version, is_deleted)
+ORDER BY (partition, queue_name, id)`
+
+// Client sends statements to the HTTP interface of a ClickHouse server.
+type Client struct {
+	url        string
+	database   string
+	username   string
+	password   string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient creates a client of the configured ClickHouse server.
+func NewClient(cfg *config.ClickHouseConfig) *Client {
+	return &Client{
+		url:        strings.TrimSuffix(cfg.URL, "/"),
+		database:   cfg.Database,
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: &http.Client{Timeout: time.Minute},
+		now:        time.Now,
+	}
+}
+
+// CreateTable creates the applications table if it does not exist.
+func (c *Client) CreateTable(ctx context.Context) error {
+	body, err := c.do(ctx, createTableSQL, nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not create applications table in ClickHouse: %w", err)
+	}
+	return body.Close()
+}
+
+// applicationRow returns the row of the application in the applications table of ClickHouse.
+func applicationRow(app *model.AnalyticsApplication, version int64) map[string]any {
+	isDeleted := 0
+	if app.Deleted {
+		isDeleted = 1
+	}
+	return map[string]any{
+		"id":                   app.ID,
+		"partition":            app.Partition,
+		"queue_name":           app.QueueName,
+		"user":                 app.User,
+		"state":                app.State,
+		"workflow_id":          app.WorkflowID,
+		"has_reserved":         app.HasReserved,
+		"max_request_priority": app.MaxRequestPriority,
+		"priority":             app.Priority,
+		"priority_class":       app.PriorityClass,
+		"wait_time":            app.WaitTime,
+		"submission_time":      app.SubmissionTime,
+		"finished_time":        app.FinishedTime,
+		"version":              version,
+		"is_deleted":           isDeleted,
+	}
+}
+
+// InsertApplications inserts the current rows of the applications, which replace their previous rows. The rows are
+// versioned by the time of the insert, so they must be inserted in the order of the changes of the applications.
+func (c *Client) InsertApplications(ctx context.Context, apps []*model.AnalyticsApplication) error {
+	if len(apps) == 0 {
+		return nil
+	}
+	version := c.now().UnixNano()
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	for _, app := range apps {
+		if err := encoder.Encode(applicationRow(app, version)); err != nil {
+			return fmt.Errorf("could not encode application %s for ClickHouse: %v", app.ID, err)
+		}
+	}
+	body, err := c.do(ctx, "INSERT INTO applications FORMAT JSONEachRow", nil, &data)
+	if err != nil {
+		return fmt.Errorf("could not insert applications into ClickHouse: %w", err)
+	}
+	return body.Close()
+}
+
+// do sends the statement with the values of its query parameters, and returns the body of the response, which
+// the caller must close. If data is set, it is sent as the data of an INSERT statement.
+func (c *Client) do(ctx context.Context, statement string, params map[string]string, data io.Reader) (io.ReadCloser, error) {
+	values := url.Values{}
+	values.Set("database", c.database)
+	// positional arguments in GROUP BY and ORDER BY, as in the queries of the database
+	values.Set("enable_positional_arguments", "1")
+	values.Set("output_format_json_quote_64bit_integers", "0")
+	for name, value := range params {
+		values.Set("param_"+name, value)
+	}
+	body := data
+	if data == nil {
+		body = strings.NewReader(statement)
+	} else {
+		values.Set("query", statement)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/?"+values.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" {
+		req.Header.Set("X-ClickHouse-User", c.username)
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		problem, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, fmt.Errorf("ClickHouse returned status %d: %s", resp.StatusCode, bytes.TrimSpace(problem))
+	}
+	return resp.Body, nil
+}
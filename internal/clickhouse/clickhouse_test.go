@@ -0,0 +1,130 @@
+package clickhouse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// fakeClickHouse records the statements sent to it and answers queries with a fixed response.
+type fakeClickHouse struct {
+	mutex      sync.Mutex
+	statements []string
+	params     []map[string]string
+	inserted   []map[string]any
+	response   string
+	status     int
+}
+
+func (f *fakeClickHouse) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if user, key := r.Header.Get("X-ClickHouse-User"), r.Header.Get("X-ClickHouse-Key"); user != "yhs" || key != "s3cret" {
+		http.Error(w, "Authentication failed", http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Query().Get("database") != "analytics" {
+		http.Error(w, "Unknown database", http.StatusNotFound)
+		return
+	}
+	params := make(map[string]string)
+	for name, values := range r.URL.Query() {
+		if strings.HasPrefix(name, "param_") {
+			params[strings.TrimPrefix(name, "param_")] = values[0]
+		}
+	}
+	statement := r.URL.Query().Get("query")
+	if statement == "" {
+		body, _ := io.ReadAll(r.Body)
+		statement = string(body)
+	} else {
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var row map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			f.inserted = append(f.inserted, row)
+		}
+	}
+	f.statements = append(f.statements, statement)
+	f.params = append(f.params, params)
+	if f.status != 0 {
+		http.Error(w, "Code: 60. DB::Exception: Table analytics.applications does not exist", f.status)
+		return
+	}
+	_, _ = io.WriteString(w, f.response)
+}
+
+func newFakeClickHouse(t *testing.T) (*fakeClickHouse, *Client) {
+	t.Helper()
+	fake := &fakeClickHouse{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client := NewClient(&config.ClickHouseConfig{
+		URL:      server.URL + "/",
+		Database: "analytics",
+		Username: "yhs",
+		Password: "s3cret",
+	})
+	return fake, client
+}
+
+func TestClient_InsertApplications(t *testing.T) {
+	fake, client := newFakeClickHouse(t)
+	client.now = func() time.Time { return time.Unix(0, 42) }
+
+	apps := []*model.AnalyticsApplication{
+		{
+			ID:          "0b5fc2d6-0d6c-4a1c-9e40-6a1c2e6f1e5b",
+			Partition:   "default",
+			QueueName:   "root.default",
+			User:        util.ToPtr("alice"),
+			HasReserved: util.ToPtr(true),
+			Priority:    util.ToPtr(int32(10)),
+		},
+		{
+			ID:        "5d1f8f7e-8d0e-4d6f-a5f4-0f0f6c3b9c1a",
+			Partition: "default",
+			QueueName: "root.default",
+			Deleted:   true,
+		},
+	}
+	require.NoError(t, client.InsertApplications(context.Background(), apps))
+	require.NoError(t, client.InsertApplications(context.Background(), nil))
+
+	require.Equal(t, []string{"INSERT INTO applications FORMAT JSONEachRow"}, fake.statements)
+	require.Len(t, fake.inserted, 2)
+	assert.Equal(t, "alice", fake.inserted[0]["user"])
+	assert.Equal(t, true, fake.inserted[0]["has_reserved"])
+	assert.Equal(t, float64(10), fake.inserted[0]["priority"])
+	assert.Equal(t, float64(42), fake.inserted[0]["version"])
+	assert.Equal(t, float64(0), fake.inserted[0]["is_deleted"])
+	assert.Nil(t, fake.inserted[1]["user"])
+	assert.Equal(t, float64(1), fake.inserted[1]["is_deleted"])
+}
+
+func TestClient_Error(t *testing.T) {
+	fake, client := newFakeClickHouse(t)
+	fake.status = http.StatusNotFound
+
+	err := client.CreateTable(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404: Code: 60. DB::Exception")
+	assert.Contains(t, fake.statements[0], "CREATE TABLE IF NOT EXISTS applications")
+}
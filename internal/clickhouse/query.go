@@ -0,0 +1,309 @@
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// timeBuckets return the expression of the start of the time bucket of a timestamp in a timezone, as Unix seconds.
+// Weeks start on Monday, like in the database.
+var timeBuckets = map[sql.TimeBucket]func(timestamp, timezone string) string{
+	sql.Hour: func(timestamp, _ string) string {
+		return fmt.Sprintf("toUnixTimestamp(toStartOfHour(%s))", timestamp)
+	},
+	sql.Day: func(timestamp, _ string) string {
+		return fmt.Sprintf("toUnixTimestamp(toStartOfDay(%s))", timestamp)
+	},
+	sql.Week: func(timestamp, timezone string) string {
+		return fmt.Sprintf("toUnixTimestamp(toDateTime(toMonday(%s), %s))", timestamp, timezone)
+	},
+	sql.Month: func(timestamp, timezone string) string {
+		return fmt.Sprintf("toUnixTimestamp(toDateTime(toStartOfMonth(%s), %s))", timestamp, timezone)
+	},
+}
+
+// aggregateFunctions are the ClickHouse functions of the aggregate functions of analytics queries. Percentiles
+// interpolate between the values like the percentiles of the database.
+var aggregateFunctions = map[sql.AggregateFunction]string{
+	sql.Count:        "count(%s)",
+	sql.Sum:          "sum(%s)",
+	sql.Average:      "avg(%s)",
+	sql.Minimum:      "min(%s)",
+	sql.Maximum:      "max(%s)",
+	sql.Median:       "quantileExactInclusive(0.5)(%s)",
+	sql.Percentile95: "quantileExactInclusive(0.95)(%s)",
+	sql.Percentile99: "quantileExactInclusive(0.99)(%s)",
+}
+
+// parameterTypes are the ClickHouse types of the query parameters of the field types.
+var parameterTypes = map[repository.AnalyticsFieldType]string{
+	repository.AnalyticsString:  "String",
+	repository.AnalyticsInteger: "Int64",
+	repository.AnalyticsBoolean: "Bool",
+	repository.AnalyticsTime:    "Int64",
+}
+
+// analyticsStatement is an analytics query translated to ClickHouse SQL, whose values are passed as query parameters.
+type analyticsStatement struct {
+	sql    string
+	params map[string]string
+}
+
+// analyticsSQL translates the analytics query, which must be valid, to a query of the applications table of
+// ClickHouse. The time bucket, the groups and the aggregates are selected in this order, like by the database, and
+// values of encrypted fields are matched whether they are encrypted or not.
+func analyticsSQL(query repository.AnalyticsQuery, cipher *encryption.Cipher) (*analyticsStatement, error) {
+	if query.Entity != "applications" {
+		return nil, fmt.Errorf("%w: entity %s is not replicated to ClickHouse", repository.ErrInvalidAnalyticsQuery, query.Entity)
+	}
+	stmt := &analyticsStatement{params: make(map[string]string)}
+	var selections, conditions []string
+
+	for _, filter := range query.Filters {
+		fieldType, column, encrypted, err := lookup(query.Entity, filter.Field)
+		if err != nil {
+			return nil, err
+		}
+		values := filter.Values
+		if encrypted {
+			strs := make([]string, len(values))
+			for i, value := range values {
+				strs[i] = value.(string)
+			}
+			values = nil
+			for _, candidate := range cipher.Candidates(strs...) {
+				values = append(values, candidate)
+			}
+		}
+		if len(values) == 1 && !encrypted {
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", column, filter.Operator, stmt.param(fieldType, values[0])))
+			continue
+		}
+		placeholders := make([]string, len(values))
+		for i, value := range values {
+			placeholders[i] = stmt.param(fieldType, value)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")))
+	}
+
+	if query.TimeBucket != nil {
+		_, column, _, err := lookup(query.Entity, query.TimeBucket.Field)
+		if err != nil {
+			return nil, err
+		}
+		location := time.UTC
+		if query.TimeBucket.Location != nil {
+			location = query.TimeBucket.Location
+		}
+		timezone := stmt.param(repository.AnalyticsString, location.String())
+		timestamp := fmt.Sprintf("fromUnixTimestamp64Nano(%s, %s)", column, timezone)
+		bucket, ok := timeBuckets[query.TimeBucket.Bucket]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported time bucket %q", repository.ErrInvalidAnalyticsQuery, query.TimeBucket.Bucket)
+		}
+		selections = append(selections, bucket(timestamp, timezone))
+	}
+	for _, name := range query.GroupBy {
+		_, column, _, err := lookup(query.Entity, name)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, column)
+	}
+	groups := make([]string, len(selections))
+	for i := range selections {
+		groups[i] = strconv.Itoa(i + 1)
+	}
+	for _, aggregate := range query.Aggregates {
+		function, ok := aggregateFunctions[aggregate.Function]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported aggregate function %q", repository.ErrInvalidAnalyticsQuery, aggregate.Function)
+		}
+		arg := ""
+		if aggregate.Field != "" {
+			_, column, _, err := lookup(query.Entity, aggregate.Field)
+			if err != nil {
+				return nil, err
+			}
+			arg = column
+		}
+		selections = append(selections, fmt.Sprintf("toFloat64(%s)", fmt.Sprintf(function, arg)))
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(selections, ", "))
+	b.WriteString(" FROM applications FINAL")
+	if len(conditions) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(conditions, " AND "))
+	}
+	if len(groups) > 0 {
+		b.WriteString(" GROUP BY ")
+		b.WriteString(strings.Join(groups, ", "))
+		b.WriteString(" ORDER BY ")
+		b.WriteString(strings.Join(groups, ", "))
+	}
+	if query.Limit > 0 {
+		b.WriteString(" LIMIT ")
+		b.WriteString(strconv.Itoa(query.Limit))
+	}
+	b.WriteString(" FORMAT JSONCompact")
+	stmt.sql = b.String()
+	return stmt, nil
+}
+
+// lookup returns the type and the quoted column of the field of the entity, and whether it is encrypted.
+func lookup(entity, field string) (repository.AnalyticsFieldType, string, bool, error) {
+	fieldType, err := repository.AnalyticsFieldTypeOf(entity, field)
+	if err != nil {
+		return "", "", false, err
+	}
+	column, encrypted, err := repository.AnalyticsColumn(entity, field)
+	if err != nil {
+		return "", "", false, err
+	}
+	return fieldType, `"` + column + `"`, encrypted, nil
+}
+
+// param adds the value as the next query parameter of the field type, and returns its placeholder.
+// Time values are passed as Unix nanoseconds, like they are stored.
+func (s *analyticsStatement) param(fieldType repository.AnalyticsFieldType, value any) string {
+	name := fmt.Sprintf("p%d", len(s.params)+1)
+	switch v := value.(type) {
+	case string:
+		s.params[name] = escapeParam(v)
+	case int64:
+		s.params[name] = strconv.FormatInt(v, 10)
+	case bool:
+		s.params[name] = strconv.FormatBool(v)
+	case time.Time:
+		s.params[name] = strconv.FormatInt(v.UnixNano(), 10)
+	}
+	return fmt.Sprintf("{%s:%s}", name, parameterTypes[fieldType])
+}
+
+// paramEscaper escapes the values of string parameters, which ClickHouse parses like values of the TSV format.
+var paramEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func escapeParam(value string) string {
+	return paramEscaper.Replace(value)
+}
+
+// analyticsResponse is the response of a query in the JSONCompact format.
+type analyticsResponse struct {
+	Data [][]json.RawMessage `json:"data"`
+}
+
+// queryAnalytics runs the analytics query, which must be valid. Groups of encrypted fields are decrypted.
+func (c *Client) queryAnalytics(ctx context.Context, query repository.AnalyticsQuery, cipher *encryption.Cipher) ([]*model.AnalyticsRow, error) {
+	stmt, err := analyticsSQL(query, cipher)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.do(ctx, stmt.sql, stmt.params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not query analytics from ClickHouse: %w", err)
+	}
+	defer body.Close()
+	var resp analyticsResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("could not decode analytics from ClickHouse: %v", err)
+	}
+
+	results := make([]*model.AnalyticsRow, 0, len(resp.Data))
+	for _, values := range resp.Data {
+		row, err := analyticsRow(query, values, cipher)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, row)
+	}
+	return results, nil
+}
+
+// analyticsRow returns the row of the results of the query from the values of a row of the response.
+func analyticsRow(query repository.AnalyticsQuery, values []json.RawMessage, cipher *encryption.Cipher) (*model.AnalyticsRow, error) {
+	expected := len(query.GroupBy) + len(query.Aggregates)
+	if query.TimeBucket != nil {
+		expected++
+	}
+	if len(values) != expected {
+		return nil, fmt.Errorf("could not decode analytics from ClickHouse: %d values instead of %d", len(values), expected)
+	}
+
+	row := &model.AnalyticsRow{Aggregates: make(map[string]*float64, len(query.Aggregates))}
+	if query.TimeBucket != nil {
+		var seconds *int64
+		if err := json.Unmarshal(values[0], &seconds); err != nil {
+			return nil, fmt.Errorf("could not decode time bucket from ClickHouse: %v", err)
+		}
+		if seconds != nil {
+			bucket := time.Unix(*seconds, 0)
+			row.Bucket = &bucket
+		}
+		values = values[1:]
+	}
+	if len(query.GroupBy) > 0 {
+		row.Groups = make(map[string]any, len(query.GroupBy))
+	}
+	for i, name := range query.GroupBy {
+		fieldType, _, encrypted, err := lookup(query.Entity, name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := groupValue(fieldType, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("could not decode group %s from ClickHouse: %v", name, err)
+		}
+		if s, ok := value.(string); ok && encrypted {
+			if value, err = cipher.Decrypt(s); err != nil {
+				return nil, fmt.Errorf("could not decrypt %s of analytics group: %v", name, err)
+			}
+		}
+		row.Groups[name] = value
+	}
+	values = values[len(query.GroupBy):]
+	for i, aggregate := range query.Aggregates {
+		var value *float64
+		if err := json.Unmarshal(values[i], &value); err != nil {
+			return nil, fmt.Errorf("could not decode %s from ClickHouse: %v", aggregate.Name(), err)
+		}
+		row.Aggregates[aggregate.Name()] = value
+	}
+	return row, nil
+}
+
+// groupValue returns the group value of the field type, or nil for NULL, with the Go type of the group values
+// returned by the database.
+func groupValue(fieldType repository.AnalyticsFieldType, raw json.RawMessage) (any, error) {
+	switch fieldType {
+	case repository.AnalyticsInteger:
+		var v *int64
+		if err := json.Unmarshal(raw, &v); err != nil || v == nil {
+			return nil, err
+		}
+		return *v, nil
+	case repository.AnalyticsBoolean:
+		var v *bool
+		if err := json.Unmarshal(raw, &v); err != nil || v == nil {
+			return nil, err
+		}
+		return *v, nil
+	default:
+		var v *string
+		if err := json.Unmarshal(raw, &v); err != nil || v == nil {
+			return nil, err
+		}
+		return *v, nil
+	}
+}
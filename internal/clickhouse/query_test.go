@@ -0,0 +1,151 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func newTestCipher(t *testing.T) *encryption.Cipher {
+	t.Helper()
+	cipher, err := encryption.New(&config.EncryptionConfig{
+		Keys:      map[string]string{"k1": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+		ActiveKey: "k1",
+	})
+	require.NoError(t, err)
+	return cipher
+}
+
+func TestAnalyticsSQL(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	since := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		query  repository.AnalyticsQuery
+		sql    string
+		params map[string]string
+	}{
+		{
+			name: "count",
+			query: repository.AnalyticsQuery{
+				Entity:     "applications",
+				Aggregates: []repository.AnalyticsAggregate{{Function: sql.Count}},
+			},
+			sql:    "SELECT toFloat64(count()) FROM applications FINAL FORMAT JSONCompact",
+			params: map[string]string{},
+		},
+		{
+			name: "filters, groups and aggregates",
+			query: repository.AnalyticsQuery{
+				Entity: "applications",
+				Filters: []repository.AnalyticsFilter{
+					{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{since}},
+					{Field: "applicationState", Operator: sql.Equal, Values: []any{"Completed", "Failed"}},
+					{Field: "queueName", Operator: sql.Equal, Values: []any{"root.a\tb"}},
+				},
+				GroupBy: []string{"queueName", "hasReserved"},
+				Aggregates: []repository.AnalyticsAggregate{
+					{Function: sql.Count, Field: "priority"},
+					{Function: sql.Percentile95, Field: "waitTime"},
+				},
+				Limit: 10,
+			},
+			sql: `SELECT "queue_name", "has_reserved", toFloat64(count("priority")), ` +
+				`toFloat64(quantileExactInclusive(0.95)("wait_time")) FROM applications FINAL ` +
+				`WHERE "submission_time" >= {p1:Int64} AND "state" IN ({p2:String}, {p3:String}) ` +
+				`AND "queue_name" = {p4:String} GROUP BY 1, 2 ORDER BY 1, 2 LIMIT 10 FORMAT JSONCompact`,
+			params: map[string]string{
+				"p1": "1719792000000000000",
+				"p2": "Completed",
+				"p3": "Failed",
+				"p4": `root.a\tb`,
+			},
+		},
+		{
+			name: "weekly buckets in timezone",
+			query: repository.AnalyticsQuery{
+				Entity:     "applications",
+				TimeBucket: &repository.AnalyticsTimeBucket{Field: "finishedTime", Bucket: sql.Week, Location: berlin},
+				Aggregates: []repository.AnalyticsAggregate{{Function: sql.Average, Field: "maxRequestPriority"}},
+			},
+			sql: `SELECT toUnixTimestamp(toDateTime(toMonday(fromUnixTimestamp64Nano("finished_time", {p1:String})), ` +
+				`{p1:String})), toFloat64(avg("max_request_priority")) FROM applications FINAL ` +
+				`GROUP BY 1 ORDER BY 1 FORMAT JSONCompact`,
+			params: map[string]string{"p1": "Europe/Berlin"},
+		},
+		{
+			name: "hourly buckets",
+			query: repository.AnalyticsQuery{
+				Entity:     "applications",
+				TimeBucket: &repository.AnalyticsTimeBucket{Field: "submissionTime", Bucket: sql.Hour},
+				Filters: []repository.AnalyticsFilter{
+					{Field: "hasReserved", Operator: sql.Equal, Values: []any{true}},
+				},
+				Aggregates: []repository.AnalyticsAggregate{{Function: sql.Maximum, Field: "finishedTime"}},
+			},
+			sql: `SELECT toUnixTimestamp(toStartOfHour(fromUnixTimestamp64Nano("submission_time", {p2:String}))), ` +
+				`toFloat64(max("finished_time")) FROM applications FINAL WHERE "has_reserved" = {p1:Bool} ` +
+				`GROUP BY 1 ORDER BY 1 FORMAT JSONCompact`,
+			params: map[string]string{"p1": "true", "p2": "UTC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, repository.ValidateAnalyticsQuery(tt.query))
+			stmt, err := analyticsSQL(tt.query, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.sql, stmt.sql)
+			assert.Equal(t, tt.params, stmt.params)
+		})
+	}
+}
+
+func TestAnalyticsSQL_EncryptedFilter(t *testing.T) {
+	cipher := newTestCipher(t)
+	stmt, err := analyticsSQL(repository.AnalyticsQuery{
+		Entity:     "applications",
+		Filters:    []repository.AnalyticsFilter{{Field: "user", Operator: sql.Equal, Values: []any{"alice"}}},
+		Aggregates: []repository.AnalyticsAggregate{{Function: sql.Count}},
+	}, cipher)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT toFloat64(count()) FROM applications FINAL WHERE "user" IN ({p1:String}, {p2:String}) `+
+		`FORMAT JSONCompact`, stmt.sql)
+	assert.Equal(t, map[string]string{"p1": "alice", "p2": cipher.Encrypt("alice")}, stmt.params)
+}
+
+func TestAnalyticsRow(t *testing.T) {
+	cipher := newTestCipher(t)
+	query := repository.AnalyticsQuery{
+		Entity:     "applications",
+		TimeBucket: &repository.AnalyticsTimeBucket{Field: "submissionTime", Bucket: sql.Day},
+		GroupBy:    []string{"user", "priority", "hasReserved", "queueName"},
+		Aggregates: []repository.AnalyticsAggregate{{Function: sql.Count}, {Function: sql.Average, Field: "waitTime"}},
+	}
+	encrypted, err := json.Marshal(cipher.Encrypt("alice"))
+	require.NoError(t, err)
+	values := []json.RawMessage{
+		json.RawMessage("1719792000"), encrypted, json.RawMessage("100"), json.RawMessage("true"),
+		json.RawMessage("null"), json.RawMessage("3"), json.RawMessage("null"),
+	}
+
+	row, err := analyticsRow(query, values, cipher)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Equal(*row.Bucket))
+	assert.Equal(t, map[string]any{"user": "alice", "priority": int64(100), "hasReserved": true, "queueName": nil}, row.Groups)
+	assert.Equal(t, map[string]*float64{"count": util.ToPtr(3.0), "avg(waitTime)": nil}, row.Aggregates)
+
+	_, err = analyticsRow(query, values[1:], cipher)
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package clickhouse
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// SinkName is the name of ClickHouse as analytics sink in the database.
+const SinkName = "clickhouse"
+
+// Repository serves the analytics queries from ClickHouse once the applications were backfilled, and passes the
+// other calls, including the lookups of entities, to the wrapped repository, which is the source of truth.
+type Repository struct {
+	repository.Repository
+	client *Client
+	cipher *encryption.Cipher
+	// backfilled caches that the backfill completed, after which it is never repeated for the sink
+	backfilled atomic.Bool
+}
+
+var _ repository.Repository = &Repository{}
+
+// NewRepository wraps the repository, so that the analytics queries are served by the ClickHouse server.
+// The cipher decrypts the groups of encrypted fields.
+func NewRepository(repo repository.Repository, client *Client, cipher *encryption.Cipher) *Repository {
+	return &Repository{Repository: repo, client: client, cipher: cipher}
+}
+
+// QueryAnalytics runs the analytics query on ClickHouse, or on the database until the applications were copied
+// to ClickHouse. Queries merging the duplicate applications of several clusters are run on the database, as their
+// canonical runs are selected across the tiers of the database.
+func (r *Repository) QueryAnalytics(ctx context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	if query.Duplicates != "" || !r.isBackfilled(ctx) {
+		return r.Repository.QueryAnalytics(ctx, query)
+	}
+	if err := repository.ValidateAnalyticsQuery(query); err != nil {
+		return nil, err
+	}
+	return r.client.queryAnalytics(ctx, query, r.cipher)
+}
+
+// isBackfilled returns whether the applications were copied to ClickHouse. It is false if the backfill state
+// cannot be read, so that the queries are served by the database.
+func (r *Repository) isBackfilled(ctx context.Context) bool {
+	if r.backfilled.Load() {
+		return true
+	}
+	sink, err := r.Repository.GetAnalyticsSink(ctx, SinkName)
+	if err != nil {
+		log.FromContext(ctx).Warnf("could not get state of ClickHouse sink, querying database: %v", err)
+		return false
+	}
+	if sink == nil || sink.BackfilledAt == nil {
+		return false
+	}
+	r.backfilled.Store(true)
+	return true
+}
@@ -0,0 +1,71 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestRepository_QueryAnalytics(t *testing.T) {
+	fake, client := newFakeClickHouse(t)
+	fake.response = `{"meta":[{"name":"queue_name","type":"String"},{"name":"count","type":"Float64"}],` +
+		`"data":[["root.a",2],["root.b",1]],"rows":2}`
+	query := repository.AnalyticsQuery{
+		Entity:     "applications",
+		GroupBy:    []string{"queueName"},
+		Aggregates: []repository.AnalyticsAggregate{{Function: sql.Count}},
+	}
+	fromDatabase := []*model.AnalyticsRow{{Aggregates: map[string]*float64{"count": util.ToPtr(3.0)}}}
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	gomock.InOrder(
+		// the database is queried until the backfill completed, or if its state cannot be read
+		repo.EXPECT().GetAnalyticsSink(gomock.Any(), SinkName).Return(nil, errors.New("connection refused")),
+		repo.EXPECT().QueryAnalytics(gomock.Any(), query).Return(fromDatabase, nil),
+		repo.EXPECT().GetAnalyticsSink(gomock.Any(), SinkName).Return(&model.AnalyticsSink{Name: SinkName}, nil),
+		repo.EXPECT().QueryAnalytics(gomock.Any(), query).Return(fromDatabase, nil),
+		repo.EXPECT().GetAnalyticsSink(gomock.Any(), SinkName).
+			Return(&model.AnalyticsSink{Name: SinkName, BackfilledAt: util.ToPtr(int64(1))}, nil),
+	)
+	r := NewRepository(repo, client, nil)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		rows, err := r.QueryAnalytics(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, fromDatabase, rows)
+	}
+	// the completed backfill is cached
+	for i := 0; i < 2; i++ {
+		rows, err := r.QueryAnalytics(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.AnalyticsRow{
+			{Groups: map[string]any{"queueName": "root.a"}, Aggregates: map[string]*float64{"count": util.ToPtr(2.0)}},
+			{Groups: map[string]any{"queueName": "root.b"}, Aggregates: map[string]*float64{"count": util.ToPtr(1.0)}},
+		}, rows)
+	}
+	assert.Len(t, fake.statements, 2)
+
+	// the duplicate applications of several clusters are merged by the database
+	merged := query
+	merged.Duplicates = repository.DuplicateLatest
+	repo.EXPECT().QueryAnalytics(gomock.Any(), merged).Return(fromDatabase, nil)
+	rows, err := r.QueryAnalytics(ctx, merged)
+	require.NoError(t, err)
+	assert.Equal(t, fromDatabase, rows)
+	assert.Len(t, fake.statements, 2)
+
+	_, err = r.QueryAnalytics(ctx, repository.AnalyticsQuery{Entity: "applications"})
+	assert.ErrorIs(t, err, repository.ErrInvalidAnalyticsQuery)
+	assert.Len(t, fake.statements, 2, "invalid queries must not be sent to ClickHouse")
+}
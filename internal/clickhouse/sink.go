@@ -0,0 +1,100 @@
+package clickhouse
+
+import (
+	"context"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Sink copies the applications to ClickHouse: it registers ClickHouse as analytics sink, so that the changes of the
+// applications are recorded in the database, copies the existing applications, and then copies the current rows of
+// the changed applications on every run. It is run by the scheduler, which does not run it concurrently.
+type Sink struct {
+	repo      repository.Repository
+	client    *Client
+	batchSize int
+	// started is set once the table was created and the sink registered
+	started bool
+	// backfilled is set once the existing applications were copied
+	backfilled bool
+}
+
+type Option func(*Sink)
+
+// WithBatchSize sets the number of applications copied per insert.
+func WithBatchSize(batchSize int) Option {
+	return func(s *Sink) {
+		s.batchSize = batchSize
+	}
+}
+
+// NewSink creates a sink which copies the applications of the repository to the ClickHouse server.
+func NewSink(repo repository.Repository, client *Client, opts ...Option) *Sink {
+	s := &Sink{
+		repo:      repo,
+		client:    client,
+		batchSize: 10000,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Sync creates the table and registers the sink if it was not started yet, copies the applications which were not
+// backfilled yet, and then the changed applications, batch by batch until a batch is not full.
+func (s *Sink) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	if !s.started {
+		if err := s.client.CreateTable(ctx); err != nil {
+			return err
+		}
+		sink, err := s.repo.StartAnalyticsSink(ctx, SinkName)
+		if err != nil {
+			return err
+		}
+		if sink.BackfilledAt == nil {
+			logger.Infow("backfilling ClickHouse", "cursor", sink.BackfillCursor)
+		}
+		s.started, s.backfilled = true, sink.BackfilledAt != nil
+	}
+
+	for !s.backfilled {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		done, err := s.repo.BackfillAnalyticsSink(ctx, SinkName, s.batchSize, func(apps []*model.AnalyticsApplication) error {
+			return s.client.InsertApplications(ctx, apps)
+		})
+		if err != nil {
+			return err
+		}
+		if done {
+			logger.Info("finished backfilling ClickHouse")
+			s.backfilled = true
+		}
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		consumed, err := s.repo.ConsumeAnalyticsChanges(ctx, s.batchSize, func(apps []*model.AnalyticsApplication) error {
+			return s.client.InsertApplications(ctx, apps)
+		})
+		if err != nil {
+			return err
+		}
+		total += consumed
+		if consumed < s.batchSize {
+			break
+		}
+	}
+	if total > 0 {
+		logger.Debugw("copied changed applications to ClickHouse", "changes", total)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// copyApplications returns the implementation of BackfillAnalyticsSink and ConsumeAnalyticsChanges which passes
+// the applications to fn.
+func copyApplications(apps ...*model.AnalyticsApplication) func(fn func([]*model.AnalyticsApplication) error) error {
+	return func(fn func([]*model.AnalyticsApplication) error) error {
+		return fn(apps)
+	}
+}
+
+func TestSink_Sync(t *testing.T) {
+	fake, client := newFakeClickHouse(t)
+	app := func(id string) *model.AnalyticsApplication {
+		return &model.AnalyticsApplication{ID: id, Partition: "default", QueueName: "root.default"}
+	}
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	gomock.InOrder(
+		repo.EXPECT().StartAnalyticsSink(gomock.Any(), SinkName).Return(&model.AnalyticsSink{Name: SinkName}, nil),
+		repo.EXPECT().BackfillAnalyticsSink(gomock.Any(), SinkName, 2, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ int, fn func([]*model.AnalyticsApplication) error) (bool, error) {
+				return false, copyApplications(app("a"), app("b"))(fn)
+			}),
+		repo.EXPECT().BackfillAnalyticsSink(gomock.Any(), SinkName, 2, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ int, fn func([]*model.AnalyticsApplication) error) (bool, error) {
+				return true, copyApplications(app("c"))(fn)
+			}),
+		repo.EXPECT().ConsumeAnalyticsChanges(gomock.Any(), 2, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int, fn func([]*model.AnalyticsApplication) error) (int, error) {
+				deleted := app("a")
+				deleted.Deleted = true
+				return 2, copyApplications(deleted, app("b"))(fn)
+			}),
+		repo.EXPECT().ConsumeAnalyticsChanges(gomock.Any(), 2, gomock.Any()).Return(0, nil),
+		// once backfilled, only the changes are consumed
+		repo.EXPECT().ConsumeAnalyticsChanges(gomock.Any(), 2, gomock.Any()).Return(0, nil),
+	)
+
+	s := NewSink(repo, client, WithBatchSize(2))
+	require.NoError(t, s.Sync(context.Background()))
+	require.NoError(t, s.Sync(context.Background()))
+
+	require.Len(t, fake.statements, 4)
+	assert.Contains(t, fake.statements[0], "CREATE TABLE IF NOT EXISTS applications")
+	ids := make([]string, len(fake.inserted))
+	for i, row := range fake.inserted {
+		ids[i] = row["id"].(string)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a", "b"}, ids)
+	assert.Equal(t, float64(1), fake.inserted[3]["is_deleted"])
+}
+
+func TestSink_SyncResumesBackfill(t *testing.T) {
+	_, client := newFakeClickHouse(t)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	gomock.InOrder(
+		repo.EXPECT().StartAnalyticsSink(gomock.Any(), SinkName).
+			Return(&model.AnalyticsSink{Name: SinkName, BackfillCursor: util.ToPtr("b")}, nil),
+		repo.EXPECT().BackfillAnalyticsSink(gomock.Any(), SinkName, 10000, gomock.Any()).
+			Return(false, errors.New("connection refused")),
+		// the sink is registered once, and the backfill continues at the next sync
+		repo.EXPECT().BackfillAnalyticsSink(gomock.Any(), SinkName, 10000, gomock.Any()).Return(true, nil),
+		repo.EXPECT().ConsumeAnalyticsChanges(gomock.Any(), 10000, gomock.Any()).Return(0, nil),
+	)
+
+	s := NewSink(repo, client)
+	assert.EqualError(t, s.Sync(context.Background()), "connection refused")
+	require.NoError(t, s.Sync(context.Background()))
+}
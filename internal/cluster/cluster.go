@@ -0,0 +1,65 @@
+// Package cluster scopes the data of the Yunikorn schedulers of several clusters synced by one server. Like Yunikorn
+// does internally for the partitions of its resource managers, the partitions of a cluster are qualified with the ID
+// of the cluster, e.g. [eu-west]default, so that every record of a partition is attributed to its cluster and the
+// partitions, queues and applications of different clusters with the same names are kept apart.
+package cluster
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// validID matches the IDs of clusters, which are lowercase DNS labels so that they need no escaping in paths
+// and patterns.
+var validID = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// ValidID reports whether id is a valid cluster ID: a lowercase DNS label, e.g. eu-west.
+func ValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// Qualify returns the partition qualified with the cluster ID. The partitions of the default cluster, whose ID is
+// empty, and partitions which are already qualified are returned unchanged.
+func Qualify(id, partition string) string {
+	if id == "" || strings.HasPrefix(partition, "[") {
+		return partition
+	}
+	return "[" + id + "]" + partition
+}
+
+// Split returns the cluster ID and the name of a partition, which is not qualified for the default cluster.
+func Split(partition string) (id, name string) {
+	if !strings.HasPrefix(partition, "[") {
+		return "", partition
+	}
+	end := strings.Index(partition, "]")
+	if end < 0 {
+		return "", partition
+	}
+	return partition[1:end], partition[end+1:]
+}
+
+// Pattern returns the LIKE pattern matching the qualified partitions of the cluster. As cluster IDs are DNS labels,
+// they contain no wildcards of LIKE.
+func Pattern(id string) string {
+	return "[" + id + "]%"
+}
+
+type idKey struct{}
+
+// WithID returns a copy of the context whose writes are attributed to the cluster and whose reads are scoped to it, for
+// the records which do not belong to a partition, such as the applications and containers history.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// IDFromContext returns the ID of the cluster the writes of the context are attributed to and its reads scoped to,
+// empty for the default cluster.
+func IDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidID(t *testing.T) {
+	for _, id := range []string{"eu-west", "a", "cluster1"} {
+		assert.True(t, ValidID(id), id)
+	}
+	for _, id := range []string{"", "EU", "-eu", "eu-", "eu_west", "eu.west", "[eu]", "eu%"} {
+		assert.False(t, ValidID(id), id)
+	}
+}
+
+func TestQualify(t *testing.T) {
+	assert.Equal(t, "default", Qualify("", "default"))
+	assert.Equal(t, "[eu-west]default", Qualify("eu-west", "default"))
+	assert.Equal(t, "[us-east]default", Qualify("eu-west", "[us-east]default"))
+}
+
+func TestSplit(t *testing.T) {
+	tests := map[string]struct {
+		partition string
+		wantID    string
+		wantName  string
+	}{
+		"default cluster": {partition: "default", wantID: "", wantName: "default"},
+		"qualified":       {partition: "[eu-west]default", wantID: "eu-west", wantName: "default"},
+		"unterminated":    {partition: "[eu-west", wantID: "", wantName: "[eu-west"},
+		"empty partition": {partition: "[eu-west]", wantID: "eu-west", wantName: ""},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, partition := Split(tt.partition)
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantName, partition)
+		})
+	}
+}
+
+func TestWithID(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, IDFromContext(ctx))
+	assert.Equal(t, ctx, WithID(ctx, ""))
+	assert.Equal(t, "eu-west", IDFromContext(WithID(ctx, "eu-west")))
+}
@@ -0,0 +1,361 @@
+// Package collector forwards the data of a Yunikorn instance to a central server, so that the collector can run
+// next to Yunikorn without access to the database of the central server.
+package collector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/ingest"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
+)
+
+const (
+	ingestPath   = "/ws/v1/ingest"
+	clustersPath = "/ws/v1/clusters/"
+	// shutdownTimeout is how long the writes collected before shutdown may take to be forwarded.
+	shutdownTimeout = 10 * time.Second
+	// maxErrorBodySize is the maximum size of an error response of the central server which is logged.
+	maxErrorBodySize = 4096
+)
+
+// ErrBacklogFull is returned for writes while the maximum number of writes is pending.
+var ErrBacklogFull = errors.New("too many writes are pending to be forwarded")
+
+// errNotRegistered is returned for heartbeats of a cluster which is not registered at the central server.
+var errNotRegistered = errors.New("cluster is not registered")
+
+// Forwarder collects the writes of the Yunikorn service and forwards them to the central server in gzip
+// compressed batches. Batches are numbered and sent again until the central server acknowledged them, which
+// applies every batch exactly once. If a spool directory is configured, batches are stored until they are
+// acknowledged, so that they are forwarded after a restart. If the cluster is configured, it is registered
+// at the central server and a heartbeat reports the ingestion progress after every flush.
+type Forwarder struct {
+	serverURL        string
+	apiKey           string
+	cluster          string
+	schedulerVersion string
+	interval         time.Duration
+	maxPending       int
+	spool            *spool
+	httpClient       *http.Client
+	now              func() time.Time
+
+	// source identifies the forwarder at the central server, which tracks the batches applied per source
+	source string
+
+	mutex   sync.Mutex
+	pending []ingest.Entry
+	// unacked are the batches which were not acknowledged yet, in the order of their sequence numbers
+	unacked        []*sealedBatch
+	unackedEntries int
+	// sequence is the number of the last batch
+	sequence int64
+
+	registered bool
+}
+
+// sealedBatch is a numbered batch, ready to be sent.
+type sealedBatch struct {
+	sequence int64
+	entries  int
+	// body is the gzip compressed JSON of the batch
+	body []byte
+}
+
+var (
+	_ yunikorn.Repository    = &Forwarder{}
+	_ yunikorn.EventRecorder = &Forwarder{}
+)
+
+// NewForwarder creates a forwarder, which resumes from the batches stored in the spool directory, if configured.
+func NewForwarder(cfg *config.CollectorConfig) (*Forwarder, error) {
+	f := &Forwarder{
+		serverURL:        strings.TrimSuffix(cfg.ServerURL, "/"),
+		apiKey:           cfg.APIKey,
+		cluster:          cfg.Cluster,
+		schedulerVersion: cfg.SchedulerVersion,
+		interval:         cfg.FlushInterval,
+		maxPending:       cfg.MaxPending,
+		httpClient:       &http.Client{Timeout: 30 * time.Second},
+		now:              time.Now,
+		// without spool, the numbering of the batches cannot be resumed, so the forwarder starts as a new source
+		source: uuid.NewString(),
+	}
+	if cfg.SpoolDir == "" {
+		return f, nil
+	}
+
+	var err error
+	if f.spool, err = openSpool(cfg.SpoolDir); err != nil {
+		return nil, err
+	}
+	if f.source, err = f.spool.source(); err != nil {
+		return nil, err
+	}
+	if f.unacked, f.sequence, err = f.spool.batches(); err != nil {
+		return nil, err
+	}
+	for _, batch := range f.unacked {
+		f.unackedEntries += batch.entries
+	}
+	return f, nil
+}
+
+func (f *Forwarder) UpsertPartitions(_ context.Context, partitions []*dao.PartitionInfo) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindPartitions, Partitions: partitions})
+}
+
+func (f *Forwarder) UpsertQueues(_ context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindQueues, Queues: queues})
+}
+
+func (f *Forwarder) UpsertNodes(_ context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindNodes, Nodes: nodes, Partition: partition})
+}
+
+func (f *Forwarder) UpsertApplications(_ context.Context, apps []*dao.ApplicationDAOInfo) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindApplications, Applications: apps})
+}
+
+// InsertNodeUtilizations forwards the node utilizations, which are stored with an ID assigned by the central server.
+func (f *Forwarder) InsertNodeUtilizations(_ context.Context, _ uuid.UUID, partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindNodeUtilizations, NodeUtilizations: partitionNodesUtil})
+}
+
+func (f *Forwarder) UpdateHistory(
+	ctx context.Context,
+	apps []*dao.ApplicationHistoryDAOInfo,
+	containers []*dao.ContainerHistoryDAOInfo,
+) error {
+	return f.enqueue(ingest.Entry{
+		Kind:                ingest.KindHistory,
+		ApplicationsHistory: apps,
+		ContainersHistory:   containers,
+		ClusterID:           cluster.IDFromContext(ctx),
+	})
+}
+
+func (f *Forwarder) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindQueueACLs, QueueACLs: acls, ClusterID: cluster.IDFromContext(ctx), At: at})
+}
+
+func (f *Forwarder) Record(_ context.Context, event *si.EventRecord) error {
+	return f.enqueue(ingest.Entry{Kind: ingest.KindEvent, Event: event})
+}
+
+// enqueue adds the write to the next batch, unless the maximum number of writes is pending.
+func (f *Forwarder) enqueue(entry ingest.Entry) error {
+	if entry.At.IsZero() {
+		entry.At = f.now()
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.pending)+f.unackedEntries >= f.maxPending {
+		return ErrBacklogFull
+	}
+	f.pending = append(f.pending, entry)
+	return nil
+}
+
+// Run forwards the collected writes at the configured interval until the context is cancelled,
+// and then forwards the writes collected until then.
+func (f *Forwarder) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "collector")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Infow("starting collector",
+		"server", f.serverURL, "cluster", f.cluster, "source", f.source, "unacknowledged_batches", len(f.unacked))
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warn("shutting down collector")
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+			defer cancel()
+			if err := f.flush(flushCtx); err != nil {
+				logger.Errorf("could not forward the collected data on shutdown: %v", err)
+			}
+			return nil
+		case <-ticker.C:
+			if err := f.flush(ctx); err != nil {
+				logger.Errorf("could not forward the collected data: %v", err)
+			}
+		}
+	}
+}
+
+// flush seals the pending writes into a batch, sends the batches which were not acknowledged yet in order,
+// and sends a heartbeat of the cluster once all batches are acknowledged.
+func (f *Forwarder) flush(ctx context.Context) error {
+	// all writes collected until now are forwarded with the sealed batch
+	collectedUntil := f.now()
+	if err := f.seal(); err != nil {
+		return err
+	}
+
+	for {
+		f.mutex.Lock()
+		if len(f.unacked) == 0 {
+			f.mutex.Unlock()
+			break
+		}
+		batch := f.unacked[0]
+		f.mutex.Unlock()
+
+		var result ingest.Result
+		if err := f.send(ctx, http.MethodPost, ingestPath, batch.body, true, &result); err != nil {
+			return err
+		}
+		if result.Sequence < batch.sequence {
+			return fmt.Errorf("batch %d was not acknowledged, the last acknowledged batch is %d", batch.sequence, result.Sequence)
+		}
+		log.FromContext(ctx).Debugw("forwarded collected data",
+			"sequence", batch.sequence, "entries", batch.entries, "duplicate", result.Duplicate)
+		if err := f.acknowledge(result.Sequence); err != nil {
+			return err
+		}
+	}
+	if f.cluster == "" {
+		return nil
+	}
+	return f.heartbeat(ctx, collectedUntil)
+}
+
+// seal numbers the pending writes as the next batch, and stores it in the spool directory, if configured.
+func (f *Forwarder) seal() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.pending) == 0 {
+		return nil
+	}
+
+	batch := ingest.Batch{Source: f.source, Sequence: f.sequence + 1, Cluster: f.cluster, Entries: f.pending}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(batch); err != nil {
+		return fmt.Errorf("could not encode batch: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("could not compress batch: %v", err)
+	}
+	sealed := &sealedBatch{sequence: batch.Sequence, entries: len(batch.Entries), body: buf.Bytes()}
+	if f.spool != nil {
+		if err := f.spool.store(sealed); err != nil {
+			return err
+		}
+	}
+
+	f.sequence = sealed.sequence
+	f.unacked = append(f.unacked, sealed)
+	f.unackedEntries += sealed.entries
+	f.pending = nil
+	return nil
+}
+
+// acknowledge removes the batches up to the acknowledged sequence number.
+func (f *Forwarder) acknowledge(sequence int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for len(f.unacked) > 0 && f.unacked[0].sequence <= sequence {
+		batch := f.unacked[0]
+		if f.spool != nil {
+			if err := f.spool.remove(batch.sequence); err != nil {
+				return err
+			}
+		}
+		f.unacked = f.unacked[1:]
+		f.unackedEntries -= batch.entries
+	}
+	return nil
+}
+
+// heartbeat registers the cluster at the central server if it is not registered yet, and reports that
+// the data collected until the given time was forwarded.
+func (f *Forwarder) heartbeat(ctx context.Context, collectedUntil time.Time) error {
+	path := clustersPath + url.PathEscape(f.cluster)
+	if !f.registered {
+		registration := map[string]any{"schedulerVersion": f.schedulerVersion}
+		if err := f.sendJSON(ctx, http.MethodPut, path, registration); err != nil {
+			return fmt.Errorf("could not register cluster: %w", err)
+		}
+		f.registered = true
+		log.FromContext(ctx).Infow("registered cluster", "cluster", f.cluster)
+	}
+	err := f.sendJSON(ctx, http.MethodPost, path+"/heartbeat", map[string]any{"ingestedUntil": collectedUntil.Unix()})
+	if errors.Is(err, errNotRegistered) {
+		// the cluster is registered again with the next heartbeat
+		f.registered = false
+	}
+	if err != nil {
+		return fmt.Errorf("could not send heartbeat: %w", err)
+	}
+	return nil
+}
+
+// sendJSON sends the body as JSON to the path of the central server.
+func (f *Forwarder) sendJSON(ctx context.Context, method, path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("could not encode request body: %v", err)
+	}
+	return f.send(ctx, method, path, data, false, nil)
+}
+
+// send sends the JSON body, which is gzip compressed if gzipped is set, to the path of the central server,
+// and decodes the response into out, if given.
+func (f *Forwarder) send(ctx context.Context, method, path string, body []byte, gzipped bool, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, f.serverURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if gzipped {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if f.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+f.apiKey)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("could not decode response of %s %s: %v", method, path, err)
+			}
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	problem, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if resp.StatusCode == http.StatusNotFound && strings.HasSuffix(path, "/heartbeat") {
+		return fmt.Errorf("%w: %s", errNotRegistered, problem)
+	}
+	return fmt.Errorf("%s %s returned status %d: %s", method, path, resp.StatusCode, problem)
+}
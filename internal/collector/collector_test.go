@@ -0,0 +1,231 @@
+package collector
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/ingest"
+)
+
+// centralServer records the requests a collector sends to the central server.
+type centralServer struct {
+	mutex        sync.Mutex
+	requests     []string
+	batches      []ingest.Batch
+	heartbeats   []map[string]int64
+	failIngest   bool
+	unregistered bool
+	// acked is the sequence number of the last applied batch of each source
+	acked map[string]int64
+	// lostAck drops the response of the next applied batch, as if the connection failed
+	lostAck bool
+}
+
+func (s *centralServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "Bearer key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.URL.Path {
+	case ingestPath:
+		if s.failIngest {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = gz
+		}
+		var batch ingest.Batch
+		if err := json.NewDecoder(body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if s.acked == nil {
+			s.acked = map[string]int64{}
+		}
+		result := ingest.Result{Sequence: s.acked[batch.Source], Duplicate: true}
+		if batch.Sequence > s.acked[batch.Source] {
+			s.batches = append(s.batches, batch)
+			s.acked[batch.Source] = batch.Sequence
+			result = ingest.Result{Applied: len(batch.Entries), Sequence: batch.Sequence}
+		}
+		if s.lostAck {
+			s.lostAck = false
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	case "/ws/v1/clusters/eu-west":
+		s.unregistered = false
+	case "/ws/v1/clusters/eu-west/heartbeat":
+		if s.unregistered {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var heartbeat map[string]int64
+		_ = json.NewDecoder(r.Body).Decode(&heartbeat)
+		s.heartbeats = append(s.heartbeats, heartbeat)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestForwarder(t *testing.T, server *centralServer, maxPending int, spoolDir string) *Forwarder {
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	f, err := NewForwarder(&config.CollectorConfig{
+		ServerURL:        ts.URL + "/",
+		APIKey:           "key",
+		Cluster:          "eu-west",
+		SchedulerVersion: "1.5.0",
+		FlushInterval:    time.Second,
+		MaxPending:       maxPending,
+		SpoolDir:         spoolDir,
+	})
+	require.NoError(t, err)
+	f.now = func() time.Time { return time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC) }
+	return f
+}
+
+func TestForwarderFlush(t *testing.T) {
+	ctx := context.Background()
+	server := &centralServer{}
+	f := newTestForwarder(t, server, 10, "")
+
+	require.NoError(t, f.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: "default"}}))
+	require.NoError(t, f.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP}))
+	require.NoError(t, f.flush(ctx))
+
+	assert.Equal(t, []string{
+		"POST /ws/v1/ingest",
+		"PUT /ws/v1/clusters/eu-west",
+		"POST /ws/v1/clusters/eu-west/heartbeat",
+	}, server.requests)
+	require.Len(t, server.batches, 1)
+	batch := server.batches[0]
+	assert.Equal(t, f.source, batch.Source)
+	assert.Equal(t, int64(1), batch.Sequence)
+	assert.Equal(t, "eu-west", batch.Cluster)
+	require.Len(t, batch.Entries, 2)
+	assert.Equal(t, ingest.KindPartitions, batch.Entries[0].Kind)
+	assert.Equal(t, ingest.KindEvent, batch.Entries[1].Kind)
+	assert.Equal(t, []map[string]int64{{"ingestedUntil": f.now().Unix()}}, server.heartbeats)
+
+	// the cluster is registered once, and heartbeats are sent without pending writes
+	require.NoError(t, f.flush(ctx))
+	assert.Len(t, server.requests, 4)
+	assert.Len(t, server.batches, 1)
+	assert.Len(t, server.heartbeats, 2)
+}
+
+func TestForwarderFlushFailed(t *testing.T) {
+	ctx := context.Background()
+	server := &centralServer{failIngest: true}
+	f := newTestForwarder(t, server, 2, "")
+
+	require.NoError(t, f.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: "default"}}))
+	assert.Error(t, f.flush(ctx))
+	assert.Empty(t, server.heartbeats, "the ingestion progress is not reported for failed batches")
+
+	// the failed batch is sent again before the next one, and writes are rejected while the backlog is full
+	require.NoError(t, f.UpsertQueues(ctx, []*dao.PartitionQueueDAOInfo{{QueueName: "root"}}))
+	assert.ErrorIs(t, f.UpsertNodes(ctx, nil, "default"), ErrBacklogFull)
+	server.failIngest = false
+	require.NoError(t, f.flush(ctx))
+	require.Len(t, server.batches, 2)
+	assert.Equal(t, int64(1), server.batches[0].Sequence)
+	assert.Equal(t, ingest.KindPartitions, server.batches[0].Entries[0].Kind)
+	assert.Equal(t, int64(2), server.batches[1].Sequence)
+	assert.Equal(t, ingest.KindQueues, server.batches[1].Entries[0].Kind)
+	require.NoError(t, f.UpsertNodes(ctx, nil, "default"))
+}
+
+func TestForwarderLostAcknowledgement(t *testing.T) {
+	ctx := context.Background()
+	server := &centralServer{lostAck: true}
+	f := newTestForwarder(t, server, 10, "")
+
+	require.NoError(t, f.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: "default"}}))
+	assert.Error(t, f.flush(ctx))
+	// the batch is sent again with the same sequence number, which the server acknowledges without applying it
+	require.NoError(t, f.flush(ctx))
+	require.Len(t, server.batches, 1)
+	assert.Empty(t, f.unacked)
+	assert.Equal(t, []string{
+		"POST /ws/v1/ingest",
+		"POST /ws/v1/ingest",
+		"PUT /ws/v1/clusters/eu-west",
+		"POST /ws/v1/clusters/eu-west/heartbeat",
+	}, server.requests)
+}
+
+func TestForwarderSpool(t *testing.T) {
+	ctx := context.Background()
+	spoolDir := t.TempDir()
+	server := &centralServer{failIngest: true}
+	f := newTestForwarder(t, server, 10, spoolDir)
+
+	require.NoError(t, f.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: "default"}}))
+	assert.Error(t, f.flush(ctx))
+	require.NoError(t, f.UpsertQueues(ctx, []*dao.PartitionQueueDAOInfo{{QueueName: "root"}}))
+	assert.Error(t, f.flush(ctx))
+
+	// a restarted forwarder resumes as the same source with the stored batches
+	server.failIngest = false
+	restarted := newTestForwarder(t, server, 10, spoolDir)
+	assert.Equal(t, f.source, restarted.source)
+	assert.Equal(t, int64(2), restarted.sequence)
+	assert.Equal(t, 2, restarted.unackedEntries)
+	require.NoError(t, restarted.flush(ctx))
+	require.Len(t, server.batches, 2)
+	assert.Equal(t, int64(1), server.batches[0].Sequence)
+	assert.Equal(t, int64(2), server.batches[1].Sequence)
+
+	// acknowledged batches are removed, and the numbering continues after another restart
+	require.NoError(t, restarted.UpsertNodes(ctx, nil, "default"))
+	require.NoError(t, restarted.flush(ctx))
+	restarted = newTestForwarder(t, server, 10, spoolDir)
+	assert.Empty(t, restarted.unacked)
+	assert.Equal(t, int64(3), restarted.sequence)
+}
+
+func TestForwarderReregistersCluster(t *testing.T) {
+	ctx := context.Background()
+	server := &centralServer{}
+	f := newTestForwarder(t, server, 10, "")
+
+	require.NoError(t, f.flush(ctx))
+	// the central server lost the registration
+	server.unregistered = true
+	assert.Error(t, f.flush(ctx))
+	require.NoError(t, f.flush(ctx))
+	assert.Equal(t, []string{
+		"PUT /ws/v1/clusters/eu-west",
+		"POST /ws/v1/clusters/eu-west/heartbeat",
+		"POST /ws/v1/clusters/eu-west/heartbeat",
+		"PUT /ws/v1/clusters/eu-west",
+		"POST /ws/v1/clusters/eu-west/heartbeat",
+	}, server.requests)
+}
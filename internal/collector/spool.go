@@ -0,0 +1,152 @@
+package collector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	sourceFile     = "source"
+	sequenceFile   = "sequence"
+	batchExtension = ".batch"
+)
+
+// spool stores the batches of the forwarder in a directory until they are acknowledged. It also stores the source
+// ID of the forwarder and the number of its last batch, so that a restarted forwarder continues the numbering of
+// its batches, which would otherwise be discarded by the central server as duplicates.
+type spool struct {
+	dir string
+}
+
+func openSpool(dir string) (*spool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("could not create spool directory: %v", err)
+	}
+	return &spool{dir: dir}, nil
+}
+
+// source returns the source ID stored in the spool, which is generated on first use.
+func (s *spool) source() (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, sourceFile))
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("could not read source: %v", err)
+	}
+	source := uuid.NewString()
+	if err := s.writeFile(sourceFile, []byte(source)); err != nil {
+		return "", err
+	}
+	return source, nil
+}
+
+// batches returns the stored batches in the order of their sequence numbers, and the number of the last batch.
+func (s *spool) batches() ([]*sealedBatch, int64, error) {
+	var sequence int64
+	data, err := os.ReadFile(filepath.Join(s.dir, sequenceFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, fmt.Errorf("could not read sequence: %v", err)
+	}
+	if err == nil {
+		if sequence, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid sequence in spool: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+batchExtension))
+	if err != nil {
+		return nil, 0, err
+	}
+	var batches []*sealedBatch
+	for _, file := range files {
+		batch, err := readBatch(file)
+		if err != nil {
+			return nil, 0, err
+		}
+		batches = append(batches, batch)
+		// the batch is stored before the sequence number
+		sequence = max(sequence, batch.sequence)
+	}
+	sort.Slice(batches, func(i, j int) bool { return batches[i].sequence < batches[j].sequence })
+	return batches, sequence, nil
+}
+
+// readBatch reads the stored batch, and counts its entries.
+func readBatch(file string) (*sealedBatch, error) {
+	sequence, err := strconv.ParseInt(strings.TrimSuffix(filepath.Base(file), batchExtension), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch file name %s: %v", file, err)
+	}
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read batch: %v", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress batch %s: %v", file, err)
+	}
+	var batch struct {
+		Entries []json.RawMessage `json:"entries"`
+	}
+	if err := json.NewDecoder(gz).Decode(&batch); err != nil {
+		return nil, fmt.Errorf("could not decode batch %s: %v", file, err)
+	}
+	return &sealedBatch{sequence: sequence, entries: len(batch.Entries), body: body}, nil
+}
+
+// store stores the batch, and its number as the number of the last batch.
+func (s *spool) store(batch *sealedBatch) error {
+	if err := s.writeFile(batchFileName(batch.sequence), batch.body); err != nil {
+		return err
+	}
+	return s.writeFile(sequenceFile, []byte(strconv.FormatInt(batch.sequence, 10)))
+}
+
+// remove removes the acknowledged batch.
+func (s *spool) remove(sequence int64) error {
+	err := os.Remove(filepath.Join(s.dir, batchFileName(sequence)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not remove acknowledged batch: %v", err)
+	}
+	return nil
+}
+
+// writeFile replaces the file atomically, so that a crash leaves either the old or the new content.
+func (s *spool) writeFile(name string, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("could not write %s to spool: %v", name, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("could not write %s to spool: %v", name, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("could not write %s to spool: %v", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write %s to spool: %v", name, err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("could not write %s to spool: %v", name, err)
+	}
+	return nil
+}
+
+func batchFileName(sequence int64) string {
+	return fmt.Sprintf("%020d%s", sequence, batchExtension)
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// AdminConfig specifies how the admin surface, i.e. the admin routes, the metrics and the debug endpoints, is served
+// and authenticated.
+type AdminConfig struct {
+	// Listen specifies the addresses of the admin listener, which serves the admin surface instead of the listeners
+	// of the API, so that network policies can restrict it. The debug endpoints are only served by the admin
+	// listener. If empty, the listeners of the API serve the admin routes and the metrics.
+	Listen []string
+	// APIKeys maps client names to the API keys of the admin routes, which replace those of the API for them.
+	// If empty, the admin routes are authenticated with the API keys of the API.
+	APIKeys map[string]string
+}
+
+func (c *AdminConfig) Validate() error {
+	var errorMessages []string
+	seen := make(map[string]bool, len(c.Listen))
+	for _, address := range c.Listen {
+		if _, _, err := ParseListenAddress(address); err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if seen[address] {
+			errorMessages = append(errorMessages, fmt.Sprintf("duplicate listen address %q", address))
+		}
+		seen[address] = true
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("admin config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	listen := stringListSchema("Addresses of the admin listener, which serves the admin routes, the metrics and the " +
+		"debug endpoints instead of the listeners of the API, so that network policies can restrict them. Each is " +
+		"a host and port or the path of a Unix domain socket prefixed with unix:, as in yhs.listen. If empty, the " +
+		"listeners of the API serve the admin routes and the metrics, and the debug endpoints are not served.")
+	listen.Examples = []any{[]string{":9090"}}
+	apiKeys := mapSchema(
+		"API keys of the admin routes keyed by client name, which replace auth.api_keys for them. "+
+			"If empty, the admin routes are authenticated with auth.api_keys.",
+		&Schema{Type: "string", Pattern: "^\\S+$"},
+	)
+	apiKeys.PropertyNames = &Schema{Pattern: "^[A-Za-z0-9-]+$"}
+	schema := objectSchema("Configuration of the admin surface, i.e. the admin routes, the metrics and the debug "+
+		"endpoints.", map[string]*Schema{
+		"listen":   listen,
+		"api_keys": apiKeys,
+	})
+	registerSection("admin", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.AdminConfig = AdminConfig{
+			Listen:  k.Strings("admin_listen"),
+			APIKeys: k.StringMap("admin_api_keys"),
+		}
+		return cfg.AdminConfig.Validate()
+	})
+}
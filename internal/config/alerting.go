@@ -0,0 +1,36 @@
+package config
+
+import (
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// AlertingConfig specifies the configuration for the evaluation of alert rules.
+type AlertingConfig struct {
+	// Interval is the interval at which alert rules are evaluated.
+	Interval time.Duration
+	// WebhookURL is the URL notifications are posted to when an alert rule starts or stops firing.
+	WebhookURL string
+}
+
+func init() {
+	interval := durationSchema("Interval at which alert rules are evaluated, " +
+		"unless the schedule of the alerting job is set in jobs.schedules.")
+	interval.Default = "1m"
+	schema := objectSchema("Configuration of the alert rule evaluation.", map[string]*Schema{
+		"interval":    interval,
+		"webhook_url": stringSchema("URL notifications are posted to when an alert rule starts or stops firing."),
+	})
+	registerSection("alerting", schema, func(k *koanf.Koanf, cfg *Config) error {
+		interval := k.Duration("alerting_interval")
+		if interval == 0 {
+			interval = time.Minute
+		}
+		cfg.AlertingConfig = AlertingConfig{
+			Interval:   interval,
+			WebhookURL: k.String("alerting_webhook_url"),
+		}
+		return nil
+	})
+}
@@ -0,0 +1,27 @@
+package config
+
+import "github.com/knadh/koanf/v2"
+
+// AuthConfig specifies the configuration for authenticating API clients.
+type AuthConfig struct {
+	// APIKeys maps client names to their API keys.
+	// If empty, API authentication is disabled.
+	APIKeys map[string]string
+}
+
+func init() {
+	apiKeys := mapSchema(
+		"API keys keyed by client name. If empty, API authentication is disabled.",
+		&Schema{Type: "string", Pattern: "^\\S+$"},
+	)
+	apiKeys.PropertyNames = &Schema{Pattern: "^[A-Za-z0-9-]+$"}
+	schema := objectSchema("Configuration of the authentication of API clients.", map[string]*Schema{
+		"api_keys": apiKeys,
+	})
+	registerSection("auth", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.AuthConfig = AuthConfig{
+			APIKeys: k.StringMap("auth_api_keys"),
+		}
+		return nil
+	})
+}
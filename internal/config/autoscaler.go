@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultAutoscalerSourceComponents are the components reporting the Kubernetes events of the cluster-autoscaler.
+var DefaultAutoscalerSourceComponents = []string{"cluster-autoscaler"}
+
+// AutoscalerConfig specifies whether the server accepts the Kubernetes events of the cluster-autoscaler, which are
+// correlated with the pending resources of the partitions.
+type AutoscalerConfig struct {
+	// Enabled specifies whether the events of the cluster-autoscaler may be posted to the server, e.g. by an event
+	// exporter forwarding the Kubernetes events with a webhook.
+	Enabled bool
+	// SourceComponents are the source components of the events which are stored, other events are ignored.
+	SourceComponents []string
+}
+
+// Validate validates the configuration of the autoscaler events.
+func (c *AutoscalerConfig) Validate() error {
+	if c.Enabled && len(c.SourceComponents) == 0 {
+		return fmt.Errorf("autoscaler config validation errors: [source components are required]")
+	}
+	return nil
+}
+
+func init() {
+	schema := objectSchema("Correlation of the scale-ups and scale-downs of the cluster-autoscaler with the pending "+
+		"resources of the partitions.", map[string]*Schema{
+		"enabled": boolSchema("Whether the Kubernetes events of the cluster-autoscaler may be posted to the server."),
+		"source_components": {
+			Type:        "array",
+			Description: "Source components of the events which are stored, other events are ignored.",
+			Items:       stringSchema("Source component of Kubernetes events."),
+			Default:     DefaultAutoscalerSourceComponents,
+		},
+	})
+	registerSection("autoscaler", schema, func(k *koanf.Koanf, cfg *Config) error {
+		sourceComponents := DefaultAutoscalerSourceComponents
+		if k.Exists("autoscaler_source_components") {
+			sourceComponents = k.Strings("autoscaler_source_components")
+		}
+		cfg.AutoscalerConfig = AutoscalerConfig{
+			Enabled:          k.Bool("autoscaler_enabled"),
+			SourceComponents: sourceComponents,
+		}
+		return cfg.AutoscalerConfig.Validate()
+	})
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultCacheTTL                  = 30 * time.Second
+	defaultCacheInvalidationInterval = time.Second
+	defaultCacheMaxEntries           = 10000
+	defaultCacheRedisKeyPrefix       = "yhs"
+	defaultResultsRecentTTL          = time.Minute
+	defaultResultsPastTTL            = 24 * time.Hour
+	defaultResultsSettleAfter        = 24 * time.Hour
+)
+
+// CacheConfig specifies the cache of the responses of the read endpoints.
+type CacheConfig struct {
+	// Enabled specifies whether responses are cached.
+	Enabled bool
+	// TTL is how long a response is cached at most.
+	TTL time.Duration
+	// InvalidationInterval is the interval at which the server writing the data invalidates the cached responses,
+	// if it wrote anything since the last invalidation.
+	InvalidationInterval time.Duration
+	// MaxEntries is the maximum number of responses cached in memory. It does not apply to Redis.
+	MaxEntries int
+	// Redis specifies the Redis server the responses are cached in, so that all replicas share the cache.
+	// Responses are cached in memory if no Redis address is configured.
+	Redis RedisConfig
+	// Results specifies how long the results of the analytics and report endpoints are cached.
+	Results ResultsCacheConfig
+}
+
+// ResultsCacheConfig specifies how long the results of the analytics and report endpoints are cached, depending on
+// the time window they aggregate. Unlike the other responses, they are not invalidated when the data changes.
+type ResultsCacheConfig struct {
+	// RecentTTL is how long the results of windows which extend to the present, or ended within the settle period,
+	// are cached.
+	RecentTTL time.Duration
+	// PastTTL is how long the results of windows which ended before the settle period are cached.
+	PastTTL time.Duration
+	// SettleAfter is how long after the end of a window the data it aggregates may still change, e.g. because its
+	// applications are still running.
+	SettleAfter time.Duration
+}
+
+// RedisConfig specifies the connection to a Redis server.
+type RedisConfig struct {
+	// Address is the host:port of the Redis server.
+	Address  string
+	Username string
+	// Password may be a secret reference.
+	Password string
+	DB       int
+	TLS      bool
+	// KeyPrefix prefixes the keys and the channels of the server, so that a Redis server can be shared.
+	KeyPrefix string
+}
+
+func (c *CacheConfig) Validate() error {
+	var errorMessages []string
+	if c.TTL <= 0 {
+		errorMessages = append(errorMessages, "ttl must be positive")
+	}
+	if c.InvalidationInterval <= 0 {
+		errorMessages = append(errorMessages, "invalidation interval must be positive")
+	}
+	if c.MaxEntries <= 0 {
+		errorMessages = append(errorMessages, "max entries must be positive")
+	}
+	if c.Results.RecentTTL <= 0 {
+		errorMessages = append(errorMessages, "results recent ttl must be positive")
+	}
+	if c.Results.PastTTL < c.Results.RecentTTL {
+		errorMessages = append(errorMessages, "results past ttl must not be shorter than the recent ttl")
+	}
+	if c.Results.SettleAfter < 0 {
+		errorMessages = append(errorMessages, "results settle after must not be negative")
+	}
+	if c.Redis.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Redis.Address); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("redis address %q is not a host:port address", c.Redis.Address))
+		}
+		if c.Redis.DB < 0 {
+			errorMessages = append(errorMessages, "redis db must not be negative")
+		}
+		if c.Redis.KeyPrefix == "" {
+			errorMessages = append(errorMessages, "redis key prefix is required")
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("cache config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	ttl := durationSchema("How long a response is cached at most.")
+	ttl.Default = defaultCacheTTL.String()
+	invalidationInterval := durationSchema("Interval at which the server writing the data invalidates the cached " +
+		"responses of all replicas, if it wrote anything since the last invalidation.")
+	invalidationInterval.Default = defaultCacheInvalidationInterval.String()
+	maxEntries := intSchema("Maximum number of responses cached in memory. It does not apply to Redis.")
+	maxEntries.Default = defaultCacheMaxEntries
+	keyPrefix := stringSchema("Prefix of the Redis keys and channels, so that a Redis server can be shared.")
+	keyPrefix.Default = defaultCacheRedisKeyPrefix
+	address := stringSchema("host:port of the Redis server the responses are cached in, so that all replicas share " +
+		"the cache and receive invalidations. Responses are cached in memory if it is not set.")
+	address.Examples = []any{"redis:6379"}
+	recentTTL := durationSchema("How long the results of the analytics and report endpoints are cached, if their " +
+		"time window extends to the present or ended within the settle period.")
+	recentTTL.Default = defaultResultsRecentTTL.String()
+	pastTTL := durationSchema("How long the results of the analytics and report endpoints are cached, if their " +
+		"time window ended before the settle period.")
+	pastTTL.Default = defaultResultsPastTTL.String()
+	settleAfter := durationSchema("How long after the end of a time window the data it aggregates may still change, " +
+		"e.g. because its applications are still running.")
+	settleAfter.Default = defaultResultsSettleAfter.String()
+	schema := objectSchema("Cache of the responses of the read endpoints.", map[string]*Schema{
+		"enabled":               boolSchema("Whether the responses of the read endpoints are cached."),
+		"ttl":                   ttl,
+		"invalidation_interval": invalidationInterval,
+		"max_entries":           maxEntries,
+		"redis_address":         address,
+		"redis_username":        stringSchema("Username of the Redis server."),
+		"redis_password":        stringSchema("Password of the Redis server, which may be a secret reference."),
+		"redis_db":              intSchema("Redis database the responses are cached in."),
+		"redis_tls":             boolSchema("Whether the connection to the Redis server uses TLS."),
+		"redis_key_prefix":      keyPrefix,
+		"results_recent_ttl":    recentTTL,
+		"results_past_ttl":      pastTTL,
+		"results_settle_after":  settleAfter,
+	})
+	registerSection("cache", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.CacheConfig = CacheConfig{
+			Enabled:              k.Bool("cache_enabled"),
+			TTL:                  defaultCacheTTL,
+			InvalidationInterval: defaultCacheInvalidationInterval,
+			MaxEntries:           defaultCacheMaxEntries,
+			Redis: RedisConfig{
+				Address:   k.String("cache_redis_address"),
+				Username:  k.String("cache_redis_username"),
+				Password:  k.String("cache_redis_password"),
+				DB:        k.Int("cache_redis_db"),
+				TLS:       k.Bool("cache_redis_tls"),
+				KeyPrefix: defaultCacheRedisKeyPrefix,
+			},
+			Results: ResultsCacheConfig{
+				RecentTTL:   defaultResultsRecentTTL,
+				PastTTL:     defaultResultsPastTTL,
+				SettleAfter: defaultResultsSettleAfter,
+			},
+		}
+		if k.Exists("cache_ttl") {
+			cfg.CacheConfig.TTL = k.Duration("cache_ttl")
+		}
+		if k.Exists("cache_invalidation_interval") {
+			cfg.CacheConfig.InvalidationInterval = k.Duration("cache_invalidation_interval")
+		}
+		if k.Exists("cache_max_entries") {
+			cfg.CacheConfig.MaxEntries = k.Int("cache_max_entries")
+		}
+		if k.Exists("cache_redis_key_prefix") {
+			cfg.CacheConfig.Redis.KeyPrefix = k.String("cache_redis_key_prefix")
+		}
+		if k.Exists("cache_results_recent_ttl") {
+			cfg.CacheConfig.Results.RecentTTL = k.Duration("cache_results_recent_ttl")
+		}
+		if k.Exists("cache_results_past_ttl") {
+			cfg.CacheConfig.Results.PastTTL = k.Duration("cache_results_past_ttl")
+		}
+		if k.Exists("cache_results_settle_after") {
+			cfg.CacheConfig.Results.SettleAfter = k.Duration("cache_results_settle_after")
+		}
+		return cfg.CacheConfig.Validate()
+	})
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const defaultCatchUpBatchSize = 100
+
+// defaultCatchUpPausedJobs are the periodic jobs paused in catch-up mode by default, whose results are not needed
+// while the ingestion falls behind.
+var defaultCatchUpPausedJobs = []string{"data-quality", "slo"}
+
+// CatchUpConfig specifies when the ingestion of the event stream of Yunikorn switches to the catch-up mode, in which
+// it trades the freshness of the derived data for throughput until it caught up.
+type CatchUpConfig struct {
+	// LagThreshold is the lag of the ingested events behind their time in Yunikorn beyond which the catch-up mode is
+	// entered. If zero, the catch-up mode is disabled.
+	LagThreshold time.Duration
+	// RecoveryLag is the lag below which the ingestion caught up and the catch-up mode is left. It defaults to half
+	// of the threshold, so that the mode does not flap around the threshold.
+	RecoveryLag time.Duration
+	// BatchSize is the number of finished applications stored at once in catch-up mode.
+	BatchSize int
+	// PausedJobs are the periodic jobs paused in catch-up mode.
+	PausedJobs []string
+	// WebhookURL is the URL notifications are posted to when the catch-up mode is entered and left.
+	WebhookURL string
+}
+
+// Enabled returns whether the ingestion switches to the catch-up mode.
+func (c *CatchUpConfig) Enabled() bool {
+	return c.LagThreshold > 0
+}
+
+func (c *CatchUpConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var errorMessages []string
+	if c.RecoveryLag <= 0 || c.RecoveryLag >= c.LagThreshold {
+		errorMessages = append(errorMessages, "recovery lag must be positive and below the lag threshold")
+	}
+	if c.BatchSize < 1 {
+		errorMessages = append(errorMessages, "batch size must be positive")
+	}
+	for _, name := range c.PausedJobs {
+		if !slices.Contains(JobNames, name) {
+			errorMessages = append(errorMessages, fmt.Sprintf("unknown paused job %q, must be one of %v", name, JobNames))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("catch up config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	lagThreshold := durationSchema("Lag of the ingested events behind their time in Yunikorn beyond which the ingestion " +
+		"switches to the catch-up mode. If 0, the catch-up mode is disabled.")
+	lagThreshold.Default = "0s"
+	lagThreshold.Examples = []any{"5m"}
+	recoveryLag := durationSchema("Lag below which the ingestion caught up and leaves the catch-up mode. " +
+		"Defaults to half of the lag threshold.")
+	minBatchSize := 1
+	batchSize := intSchema("Number of finished applications stored at once in catch-up mode.")
+	batchSize.Minimum = &minBatchSize
+	batchSize.Default = defaultCatchUpBatchSize
+	names := make([]any, len(JobNames))
+	for i, name := range JobNames {
+		names[i] = name
+	}
+	pausedJobs := stringListSchema("Periodic jobs paused in catch-up mode.")
+	pausedJobs.Items.Enum = names
+	pausedJobs.Default = defaultCatchUpPausedJobs
+	schema := objectSchema("Configuration of the catch-up mode of the ingestion of the event stream of Yunikorn, "+
+		"which stores finished applications in batches, defers looking up new applications in Yunikorn and pauses "+
+		"periodic jobs while the ingestion lags behind.", map[string]*Schema{
+		"lag_threshold": lagThreshold,
+		"recovery_lag":  recoveryLag,
+		"batch_size":    batchSize,
+		"paused_jobs":   pausedJobs,
+		"webhook_url":   stringSchema("URL notifications are posted to when the catch-up mode is entered and left."),
+	})
+	registerSection("catch_up", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.CatchUpConfig = CatchUpConfig{
+			LagThreshold: k.Duration("catch_up_lag_threshold"),
+			RecoveryLag:  k.Duration("catch_up_recovery_lag"),
+			BatchSize:    defaultCatchUpBatchSize,
+			PausedJobs:   defaultCatchUpPausedJobs,
+			WebhookURL:   k.String("catch_up_webhook_url"),
+		}
+		if cfg.CatchUpConfig.RecoveryLag == 0 {
+			cfg.CatchUpConfig.RecoveryLag = cfg.CatchUpConfig.LagThreshold / 2
+		}
+		if k.Exists("catch_up_batch_size") {
+			cfg.CatchUpConfig.BatchSize = k.Int("catch_up_batch_size")
+		}
+		if k.Exists("catch_up_paused_jobs") {
+			cfg.CatchUpConfig.PausedJobs = k.Strings("catch_up_paused_jobs")
+		}
+		return cfg.CatchUpConfig.Validate()
+	})
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultCircuitBreakerFailureThreshold = 5
+	defaultCircuitBreakerOpenDuration     = 30 * time.Second
+)
+
+// CircuitBreakerConfig specifies the circuit breaker of the repository, which rejects the operations while
+// the database is unavailable, so that they fail fast instead of piling up on the database.
+type CircuitBreakerConfig struct {
+	// Enabled specifies whether the operations of the repository go through the circuit breaker.
+	Enabled bool
+	// FailureThreshold is the number of consecutive operations failing because the database is unavailable or
+	// too slow after which the circuit opens.
+	FailureThreshold int
+	// OpenDuration is how long the circuit rejects the operations before it lets a probe operation through.
+	OpenDuration time.Duration
+}
+
+func (c *CircuitBreakerConfig) Validate() error {
+	var errorMessages []string
+	if c.FailureThreshold <= 0 {
+		errorMessages = append(errorMessages, "failure threshold must be positive")
+	}
+	if c.OpenDuration <= 0 {
+		errorMessages = append(errorMessages, "open duration must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("circuit breaker config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	failureThreshold := intSchema("Number of consecutive operations failing because the database is unavailable " +
+		"or too slow, e.g. connection errors and statement timeouts, after which the circuit opens.")
+	failureThreshold.Default = defaultCircuitBreakerFailureThreshold
+	openDuration := durationSchema("How long the circuit rejects the operations before it lets a probe operation " +
+		"through, which closes the circuit if it succeeds.")
+	openDuration.Default = defaultCircuitBreakerOpenDuration.String()
+	schema := objectSchema("Circuit breaker of the repository, which rejects the operations while the database "+
+		"is unavailable, so that requests fail fast with 503 Service Unavailable.", map[string]*Schema{
+		"enabled":           boolSchema("Whether the operations of the repository go through the circuit breaker."),
+		"failure_threshold": failureThreshold,
+		"open_duration":     openDuration,
+	})
+	registerSection("circuit_breaker", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.CircuitBreakerConfig = CircuitBreakerConfig{
+			Enabled:          k.Bool("circuit_breaker_enabled"),
+			FailureThreshold: defaultCircuitBreakerFailureThreshold,
+			OpenDuration:     defaultCircuitBreakerOpenDuration,
+		}
+		if k.Exists("circuit_breaker_failure_threshold") {
+			cfg.CircuitBreakerConfig.FailureThreshold = k.Int("circuit_breaker_failure_threshold")
+		}
+		if k.Exists("circuit_breaker_open_duration") {
+			cfg.CircuitBreakerConfig.OpenDuration = k.Duration("circuit_breaker_open_duration")
+		}
+		return cfg.CircuitBreakerConfig.Validate()
+	})
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultClickHouseDatabase  = "default"
+	defaultClickHouseInterval  = 10 * time.Second
+	defaultClickHouseBatchSize = 10000
+)
+
+// ClickHouseConfig specifies the ClickHouse server the applications are replicated to, which serves the
+// analytics queries instead of the database once the existing applications were copied.
+type ClickHouseConfig struct {
+	// URL is the URL of the HTTP interface of the ClickHouse server, e.g. http://clickhouse:8123.
+	// If empty, the analytics queries are served by the database.
+	URL      string
+	Database string
+	Username string
+	// Password may be a secret reference.
+	Password string
+	// Interval is the interval at which the changes of the applications are copied to ClickHouse,
+	// which bounds how stale the results of the analytics queries are.
+	Interval time.Duration
+	// BatchSize is the number of applications copied to ClickHouse per insert.
+	BatchSize int
+}
+
+// Enabled returns whether the applications are replicated to ClickHouse.
+func (c *ClickHouseConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+func (c *ClickHouseConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var errorMessages []string
+	if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errorMessages = append(errorMessages, fmt.Sprintf("url %q is not an http or https URL", c.URL))
+	}
+	if c.Database == "" {
+		errorMessages = append(errorMessages, "database is required")
+	}
+	if c.Interval <= 0 {
+		errorMessages = append(errorMessages, "interval must be positive")
+	}
+	if c.BatchSize < 1 {
+		errorMessages = append(errorMessages, "batch size must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("clickhouse config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	address := stringSchema("URL of the HTTP interface of the ClickHouse server the applications are replicated to, " +
+		"which serves the analytics queries. If empty, the analytics queries are served by the database.")
+	address.Examples = []any{"http://clickhouse:8123"}
+	database := stringSchema("Database of the applications table in ClickHouse.")
+	database.Default = defaultClickHouseDatabase
+	interval := durationSchema("Interval at which the changes of the applications are copied to ClickHouse, " +
+		"unless the schedule of the clickhouse-sink job is set in jobs.schedules.")
+	interval.Default = defaultClickHouseInterval.String()
+	minBatchSize := 1
+	batchSize := intSchema("Number of applications copied to ClickHouse per insert.")
+	batchSize.Minimum = &minBatchSize
+	batchSize.Default = defaultClickHouseBatchSize
+	schema := objectSchema("Configuration of the ClickHouse server which serves the analytics queries.", map[string]*Schema{
+		"url":      address,
+		"database": database,
+		"username": stringSchema("Username of the ClickHouse server."),
+		"password": stringSchema("Password of the ClickHouse server, which may be a secret reference, " +
+			"preferably provided via YHS_CLICKHOUSE_PASSWORD."),
+		"interval":   interval,
+		"batch_size": batchSize,
+	})
+	registerSection("clickhouse", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.ClickHouseConfig = ClickHouseConfig{
+			URL:       k.String("clickhouse_url"),
+			Database:  defaultClickHouseDatabase,
+			Username:  k.String("clickhouse_username"),
+			Password:  k.String("clickhouse_password"),
+			Interval:  defaultClickHouseInterval,
+			BatchSize: defaultClickHouseBatchSize,
+		}
+		if k.Exists("clickhouse_database") {
+			cfg.ClickHouseConfig.Database = k.String("clickhouse_database")
+		}
+		if k.Exists("clickhouse_interval") {
+			cfg.ClickHouseConfig.Interval = k.Duration("clickhouse_interval")
+		}
+		if k.Exists("clickhouse_batch_size") {
+			cfg.ClickHouseConfig.BatchSize = k.Int("clickhouse_batch_size")
+		}
+		return cfg.ClickHouseConfig.Validate()
+	})
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultCollectorFlushInterval    = 5 * time.Second
+	defaultCollectorMaxPending       = 10000
+	defaultCollectorSchedulerVersion = "unknown"
+)
+
+// CollectorConfig specifies how the collector forwards the data of Yunikorn to the central server.
+type CollectorConfig struct {
+	// ServerURL is the URL of the central server the data is forwarded to.
+	ServerURL string
+	// APIKey authenticates the collector at the central server, if it has API authentication enabled.
+	// It may be a secret reference.
+	APIKey string
+	// Cluster is the name the cluster is registered with at the central server. It is not registered if empty.
+	Cluster string
+	// SchedulerVersion is the version of Yunikorn the cluster is registered with.
+	SchedulerVersion string
+	// FlushInterval is the interval at which the collected data is forwarded.
+	FlushInterval time.Duration
+	// MaxPending is the maximum number of collected writes which were not forwarded yet. Writes are rejected
+	// while the central server cannot be reached and the limit is reached.
+	MaxPending int
+	// SpoolDir is the directory the batches are stored in until the central server acknowledged them, so that
+	// they are forwarded after a restart. Batches which were not acknowledged are lost on restart if empty.
+	SpoolDir string
+}
+
+// Validate validates the configuration of the collector. The server URL is only required to run the collector.
+func (c *CollectorConfig) Validate() error {
+	var errorMessages []string
+	if c.ServerURL != "" {
+		if u, err := url.Parse(c.ServerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("server url %q is not an http or https URL", c.ServerURL))
+		}
+	}
+	if c.FlushInterval <= 0 {
+		errorMessages = append(errorMessages, "flush interval must be positive")
+	}
+	if c.MaxPending <= 0 {
+		errorMessages = append(errorMessages, "max pending must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("collector config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	serverURL := stringSchema("URL of the central server the collector forwards the data of Yunikorn to.")
+	serverURL.Examples = []any{"https://yhs.example.com"}
+	schedulerVersion := stringSchema("Version of Yunikorn the cluster is registered with.")
+	schedulerVersion.Default = defaultCollectorSchedulerVersion
+	flushInterval := durationSchema("Interval at which the collected data is forwarded.")
+	flushInterval.Default = defaultCollectorFlushInterval.String()
+	maxPending := intSchema("Maximum number of collected writes which were not forwarded yet. Writes are rejected " +
+		"while the central server cannot be reached and the limit is reached.")
+	maxPending.Default = defaultCollectorMaxPending
+	schema := objectSchema("Configuration of the collector, which forwards the data of Yunikorn to a central server.",
+		map[string]*Schema{
+			"server_url": serverURL,
+			"api_key": stringSchema("API key of the collector at the central server, which may be a secret " +
+				"reference."),
+			"cluster": stringSchema("Name the cluster is registered with at the central server. " +
+				"The cluster is not registered if it is not set."),
+			"scheduler_version": schedulerVersion,
+			"flush_interval":    flushInterval,
+			"max_pending":       maxPending,
+			"spool_dir": stringSchema("Directory the batches are stored in until the central server acknowledged " +
+				"them, so that they are forwarded after a restart. Batches which were not acknowledged are lost " +
+				"on restart if it is not set."),
+		})
+	registerSection("collector", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.CollectorConfig = CollectorConfig{
+			ServerURL:        k.String("collector_server_url"),
+			APIKey:           k.String("collector_api_key"),
+			Cluster:          k.String("collector_cluster"),
+			SpoolDir:         k.String("collector_spool_dir"),
+			SchedulerVersion: defaultCollectorSchedulerVersion,
+			FlushInterval:    defaultCollectorFlushInterval,
+			MaxPending:       defaultCollectorMaxPending,
+		}
+		if k.Exists("collector_scheduler_version") {
+			cfg.CollectorConfig.SchedulerVersion = k.String("collector_scheduler_version")
+		}
+		if k.Exists("collector_flush_interval") {
+			cfg.CollectorConfig.FlushInterval = k.Duration("collector_flush_interval")
+		}
+		if k.Exists("collector_max_pending") {
+			cfg.CollectorConfig.MaxPending = k.Int("collector_max_pending")
+		}
+		return cfg.CollectorConfig.Validate()
+	})
+}
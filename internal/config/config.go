@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
-	"github.com/rs/cors"
 
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/v2"
@@ -23,156 +21,96 @@ type Config struct {
 	YunikornConfig YunikornConfig
 	// LogConfig specifies the configuration for the logger.
 	LogConfig LogConfig
-}
-
-type YHSConfig struct {
-	// Port specifies the port on which the Yunikorn History Server listens for incoming requests.
-	Port int
-	// AssetsDir specifies the directory where the static assets are stored.
-	AssetsDir string
-	// DataSyncInterval specifies the interval at which the data is synced from the Yunikorn API.
-	DataSyncInterval time.Duration
-	// CORSConfig specifies the configuration for the CORS middleware.
-	CORSConfig cors.Options
-}
-
-func (c *YHSConfig) Validate() error {
-	var errorMessages []string
-	if c.Port < 1 {
-		errorMessages = append(errorMessages, "yhs config validation error: port is required")
-	}
-	if len(errorMessages) > 0 {
-		return fmt.Errorf("yhs config validation errors: %v", errorMessages)
-	}
-	return nil
-}
-
-type PostgresConfig struct {
-	Host                string
-	DbName              string
-	Username            string
-	Password            string
-	PoolMaxConnLifetime time.Duration
-	PoolMaxConnIdleTime time.Duration
-	Port                int
-	PoolMaxConns        int
-	PoolMinConns        int
-	SSLMode             string
-	Schema              string
-}
-
-func (c *PostgresConfig) Validate() error {
-	var errorMessages []string
-	if c.Host == "" {
-		errorMessages = append(errorMessages, "db host is required")
-	}
-	if c.DbName == "" {
-		errorMessages = append(errorMessages, "db name is required")
-	}
-	if c.Username == "" {
-		errorMessages = append(errorMessages, "db user is required")
-	}
-	if c.Password == "" {
-		errorMessages = append(errorMessages, "db password is required")
-	}
-	if c.Port < 1 {
-		errorMessages = append(errorMessages, "db port is required")
-	}
-	if len(errorMessages) > 0 {
-		return fmt.Errorf("postgres config validation errors: %v", errorMessages)
-	}
-	return nil
-}
-
-// YunikornConfig specifies the configuration for the Yunikorn API.
-type YunikornConfig struct {
-	Host string
-	Port int
-	// Secure indicates whether the connection to the Yunikorn API is using encryption or not.
-	Secure bool
-}
-
-func (c *YunikornConfig) Validate() error {
-	var errorMessages []string
-	if c.Host == "" {
-		errorMessages = append(errorMessages, "yunikorn host is required")
-	}
-	if c.Port < 1 {
-		errorMessages = append(errorMessages, "yunikorn port is required")
-	}
-	if len(errorMessages) > 0 {
-		return fmt.Errorf("yunikorn config validation errors: %v", errorMessages)
-	}
-	return nil
-}
-
-type LogConfig struct {
-	LogLevel   string
-	JSONFormat bool
+	// FeatureFlagsConfig specifies which experimental features are enabled.
+	FeatureFlagsConfig FeatureFlagsConfig
+	// AuthConfig specifies the configuration for authenticating API clients.
+	AuthConfig AuthConfig
+	// QuotasConfig specifies the daily quotas of the API clients on the expensive endpoints.
+	QuotasConfig QuotasConfig
+	// ControllerConfig specifies the configuration for the Kubernetes controller.
+	ControllerConfig ControllerConfig
+	// AlertingConfig specifies the configuration for the evaluation of alert rules.
+	AlertingConfig AlertingConfig
+	// DataQualityConfig specifies the configuration for the data quality checks.
+	DataQualityConfig DataQualityConfig
+	// JobsConfig specifies the schedules of the periodic jobs.
+	JobsConfig JobsConfig
+	// RetentionConfig specifies how long finished applications are kept.
+	RetentionConfig RetentionConfig
+	// StorageConfig specifies when finished applications are moved to the cold tier.
+	StorageConfig StorageConfig
+	// ClickHouseConfig specifies the ClickHouse server which serves the analytics queries.
+	ClickHouseConfig ClickHouseConfig
+	// LakeConfig specifies the object storage the raw events are written to for offline analytics.
+	LakeConfig LakeConfig
+	// ExportsConfig specifies the storage the exports are written to.
+	ExportsConfig ExportsConfig
+	// EncryptionConfig specifies the keys used to encrypt the user and group columns at rest.
+	EncryptionConfig EncryptionConfig
+	// SecretsConfig specifies the secret store from which secret references in the configuration are resolved.
+	SecretsConfig SecretsConfig
+	// LinksConfig specifies the links to external systems returned with the entities of responses.
+	LinksConfig LinksConfig
+	// SparkConfig specifies how applications run by Spark are correlated with the Spark History Server.
+	SparkConfig SparkConfig
+	// WorkflowsConfig specifies how applications are correlated with the workflow runs that caused them.
+	WorkflowsConfig WorkflowsConfig
+	// RetriesConfig specifies how the retried submissions of a job are linked into retry chains.
+	RetriesConfig RetriesConfig
+	// CacheConfig specifies the cache of the responses of the read endpoints.
+	CacheConfig CacheConfig
+	// CircuitBreakerConfig specifies the circuit breaker of the repository.
+	CircuitBreakerConfig CircuitBreakerConfig
+	// FallbackConfig specifies the stale responses served by the key read endpoints while the database is unavailable.
+	FallbackConfig FallbackConfig
+	// IngestConfig specifies whether the server accepts the data forwarded by collectors.
+	IngestConfig IngestConfig
+	// CollectorConfig specifies how the collector forwards the data of Yunikorn to the central server.
+	CollectorConfig CollectorConfig
+	// TransformConfig specifies the rules which transform the data of Yunikorn before it is stored or forwarded.
+	TransformConfig TransformConfig
+	// PriorityConfig specifies how the priority classes of applications are recorded.
+	PriorityConfig PriorityConfig
+	// TraceConfig specifies how the scheduling events of applications are stored as their decision trace.
+	TraceConfig TraceConfig
+	// NodeGroupsConfig specifies the groups of nodes whose utilization is aggregated.
+	NodeGroupsConfig NodeGroupsConfig
+	// TopologyConfig specifies the attributes of the nodes their zone and rack are taken from.
+	TopologyConfig TopologyConfig
+	// SpotConfig specifies how the terminations of nodes are recorded and which nodes are spot nodes.
+	SpotConfig SpotConfig
+	// AutoscalerConfig specifies whether the server accepts the events of the cluster-autoscaler.
+	AutoscalerConfig AutoscalerConfig
+	// SLOConfig specifies the service level objectives of queues and how they are evaluated.
+	SLOConfig SLOConfig
+	// SnapshotsConfig specifies the database snapshots held open for consistent reads across requests.
+	SnapshotsConfig SnapshotsConfig
+	// EgressConfig specifies the proxy, certificate authorities and host overrides of the outbound HTTP requests.
+	EgressConfig EgressConfig
+	// AdminConfig specifies the listener and the authentication of the admin surface.
+	AdminConfig AdminConfig
+	// CatchUpConfig specifies when the ingestion switches to the catch-up mode while it lags behind.
+	CatchUpConfig CatchUpConfig
+	// EventStatisticsConfig specifies the categories the event types of the event statistics are grouped into.
+	EventStatisticsConfig EventStatisticsConfig
+	// MirrorConfig specifies the mirroring of a sample of the read requests to a secondary deployment.
+	MirrorConfig MirrorConfig
 }
 
 // New creates a new Config object by loading the configuration from the provided path if provided,
 // then load the configuration from environment variables prefixed with YHS_, so that environment variables take precedence.
+// Each registered section of the configuration is loaded and validated in turn.
 func New(path string) (*Config, error) {
 	k, err := loadConfig(path)
 	if err != nil {
 		return nil, err
 	}
 
-	assetsDir := k.String("yhs_assets_dir")
-	if assetsDir == "" {
-		assetsDir = "assets"
-	}
-	dataSyncInterval := k.Duration("yhs_data_sync_interval")
-	if dataSyncInterval == 0 {
-		dataSyncInterval = 5 * time.Minute
-	}
-	corsConfig := cors.Options{
-		AllowedOrigins: k.Strings("yhs_cors_allowed_origins"),
-		AllowedMethods: k.Strings("yhs_cors_allowed_methods"),
-		AllowedHeaders: k.Strings("yhs_cors_allowed_headers"),
-	}
-
-	yhsConfig := YHSConfig{
-		Port:             k.Int("yhs_port"),
-		AssetsDir:        assetsDir,
-		DataSyncInterval: dataSyncInterval,
-		CORSConfig:       corsConfig,
-	}
-	if err := yhsConfig.Validate(); err != nil {
-		return nil, err
-	}
-
-	yunikornConfig := YunikornConfig{
-		Host:   k.String("yunikorn_host"),
-		Port:   k.Int("yunikorn_port"),
-		Secure: k.Bool("yunikorn_secure"),
-	}
-
-	logConfig := LogConfig{
-		JSONFormat: k.Bool("log_json_format"),
-		LogLevel:   k.String("log_level"),
-	}
-
-	postgresConfig := PostgresConfig{
-		Host:                k.String("db_host"),
-		Port:                k.Int("db_port"),
-		Username:            k.String("db_user"),
-		Password:            k.String("db_password"),
-		DbName:              k.String("db_dbname"),
-		SSLMode:             k.String("db_sslmode"),
-		Schema:              k.String("db_schema"),
-		PoolMaxConnLifetime: k.Duration("db_pool_max_conn_lifetime"),
-		PoolMaxConnIdleTime: k.Duration("db_pool_max_conn_idletime"),
-		PoolMaxConns:        k.Int("db_pool_max_conns"),
-		PoolMinConns:        k.Int("db_pool_min_conns"),
-	}
-
-	config := &Config{
-		YHSConfig:      yhsConfig,
-		YunikornConfig: yunikornConfig,
-		PostgresConfig: postgresConfig,
-		LogConfig:      logConfig,
+	config := &Config{}
+	for _, s := range sections {
+		if err := s.load(k, config); err != nil {
+			return nil, err
+		}
 	}
 	return config, nil
 }
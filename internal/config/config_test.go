@@ -32,8 +32,19 @@ func TestNew(t *testing.T) {
 			want: &Config{
 				YHSConfig: YHSConfig{
 					Port:             8080,
+					Listen:           []string{":8080", "unix:/run/yhs/yhs.sock"},
+					Limits:           RequestLimitsConfig{MaxBodySize: 2 << 20, MaxHeaderSize: 64 << 10},
 					AssetsDir:        "assets",
 					DataSyncInterval: 5 * time.Minute,
+					FieldNaming:      FieldNamingCamelCase,
+					APISchema:        APISchemaCurrent,
+					PageSizes: PageSizesConfig{
+						Applications: PageSizeConfig{DefaultLimit: 100, MaxLimit: 1000},
+						LegalHolds:   PageSizeConfig{DefaultLimit: 500, MaxLimit: 500},
+						Nodes:        PageSizeConfig{DefaultLimit: 100, MaxLimit: 100},
+						History:      DefaultPageSize,
+						Other:        PageSizeConfig{DefaultLimit: 200, MaxLimit: 1000},
+					},
 					CORSConfig: cors.Options{
 						AllowedOrigins: []string{"*"},
 						AllowedMethods: []string{"GET"},
@@ -41,9 +52,14 @@ func TestNew(t *testing.T) {
 					},
 				},
 				YunikornConfig: YunikornConfig{
-					Host:   "localhost",
-					Port:   9090,
-					Secure: false,
+					Host:              "localhost",
+					Port:              9090,
+					Secure:            false,
+					SyncFailureBudget: 3,
+					ClusterID:         "us-east",
+					Clusters: []YunikornCluster{
+						{ID: "eu-west", Host: "yunikorn.eu-west.example.com", Port: 9443, Secure: true},
+					},
 				},
 				LogConfig: LogConfig{
 					LogLevel:   "info",
@@ -60,6 +76,235 @@ func TestNew(t *testing.T) {
 					PoolMaxConns:        10,
 					PoolMinConns:        1,
 					SSLMode:             "disable",
+					AuthMethod:          DBAuthMethodPassword,
+					StatementTimeouts: StatementTimeouts{
+						Interactive: 30 * time.Second,
+						Analytics:   5 * time.Minute,
+					},
+				},
+				FeatureFlagsConfig: FeatureFlagsConfig{
+					Flags: map[string]bool{
+						"analytics":    true,
+						"event-stream": false,
+					},
+					AdminOverrides: true,
+				},
+				AuthConfig: AuthConfig{
+					APIKeys: map[string]string{
+						"ops": "ops-secret",
+					},
+				},
+				QuotasConfig: QuotasConfig{
+					DailyRequests:  1000,
+					DailyQueryTime: 10 * time.Minute,
+					Overrides:      []QuotaOverride{{Client: "ops", DailyRequests: 1000}},
+				},
+				ControllerConfig: ControllerConfig{
+					Enabled:      true,
+					Namespace:    "yunikorn",
+					ResyncPeriod: 10 * time.Minute,
+				},
+				AlertingConfig: AlertingConfig{
+					Interval:   30 * time.Second,
+					WebhookURL: "http://alertmanager:9093/hooks/yhs",
+				},
+				DataQualityConfig: DataQualityConfig{
+					Interval: 12 * time.Hour,
+				},
+				JobsConfig: JobsConfig{
+					Schedules: map[string]string{
+						"retention":    "0 3 * * *",
+						"data-quality": "@daily",
+					},
+				},
+				RetentionConfig: RetentionConfig{
+					Interval:       time.Hour,
+					ApplicationTTL: 2160 * time.Hour,
+					HistoryTTL:     8760 * time.Hour,
+					BatchSize:      200,
+					Overrides: []RetentionOverride{
+						{Queue: "root.compliance", ApplicationTTL: 17520 * time.Hour},
+						{Partition: "default", Queue: "root.scratch_space", ApplicationTTL: 24 * time.Hour},
+					},
+				},
+				StorageConfig: StorageConfig{
+					ColdAfter: 720 * time.Hour,
+					Interval:  time.Hour,
+					BatchSize: 500,
+				},
+				ClickHouseConfig: ClickHouseConfig{
+					URL:       "http://clickhouse:8123",
+					Database:  "yhs",
+					Username:  "yhs",
+					Interval:  30 * time.Second,
+					BatchSize: 10000,
+				},
+				LakeConfig: LakeConfig{
+					ObjectStorageConfig: ObjectStorageConfig{
+						URL:    "s3://yhs-events/production",
+						Region: "eu-west-1",
+					},
+					Format:            LakeFormatDelta,
+					FlushInterval:     10 * time.Minute,
+					MaxFileEvents:     100000,
+					MaxBufferedEvents: 1000000,
+				},
+				ExportsConfig: ExportsConfig{
+					ObjectStorageConfig: ObjectStorageConfig{
+						URL:    "s3://yhs-exports/production",
+						Region: "eu-west-1",
+					},
+					URLExpiry: time.Hour,
+					Encryption: ExportEncryptionConfig{
+						Recipients: []string{"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"},
+						KMSKeyARNs: []string{"arn:aws:kms:eu-west-1:123456789012:alias/yhs-exports"},
+					},
+				},
+				EncryptionConfig: EncryptionConfig{
+					Keys: map[string]string{
+						"v1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+						"v2": "ICEiIyQlJicoKSorLC0uLzAxMjM0NTY3ODk6Ozw9Pj8=",
+					},
+					ActiveKey: "v2",
+				},
+				SecretsConfig: SecretsConfig{
+					Provider:        SecretProviderVault,
+					RefreshInterval: 5 * time.Minute,
+					Vault: VaultConfig{
+						Address:         "https://vault:8200",
+						Mount:           "secret",
+						KubernetesRole:  "yhs",
+						KubernetesMount: "kubernetes",
+					},
+				},
+				LinksConfig: LinksConfig{
+					Applications: []LinkTemplate{
+						{Name: "logs", URL: "https://logs.example.com/explore?query={{ .ApplicationID | urlquery }}"},
+					},
+				},
+				SparkConfig: SparkConfig{
+					ApplicationIDTag: DefaultSparkApplicationIDTag,
+					HistoryServerURL: "https://spark-history.example.com",
+				},
+				WorkflowsConfig: WorkflowsConfig{
+					IDTags: []string{"kubernetes.io/label/workflows.argoproj.io/workflow"},
+				},
+				RetriesConfig: RetriesConfig{
+					KeyTags: []string{"kubernetes.io/label/job-group"},
+				},
+				CacheConfig: CacheConfig{
+					Enabled:              true,
+					TTL:                  time.Minute,
+					InvalidationInterval: time.Second,
+					MaxEntries:           10000,
+					Redis: RedisConfig{
+						Address:   "redis:6379",
+						Password:  "secret:yhs/redis#password",
+						KeyPrefix: "yhs",
+					},
+					Results: ResultsCacheConfig{
+						RecentTTL:   time.Minute,
+						PastTTL:     7 * 24 * time.Hour,
+						SettleAfter: 24 * time.Hour,
+					},
+				},
+				CircuitBreakerConfig: CircuitBreakerConfig{
+					Enabled:          true,
+					FailureThreshold: 10,
+					OpenDuration:     30 * time.Second,
+				},
+				FallbackConfig: FallbackConfig{
+					Enabled:    true,
+					MaxAge:     30 * time.Minute,
+					MaxEntries: 1000,
+				},
+				IngestConfig: IngestConfig{
+					Enabled: true,
+				},
+				CollectorConfig: CollectorConfig{
+					ServerURL:        "https://yhs.example.com",
+					APIKey:           "secret:yhs/collector#api_key",
+					Cluster:          "eu-west",
+					SchedulerVersion: "unknown",
+					FlushInterval:    10 * time.Second,
+					MaxPending:       10000,
+					SpoolDir:         "/var/lib/yhs-collector",
+				},
+				TransformConfig: TransformConfig{
+					Drop: []EventSelector{{Type: "NODE", ChangeType: "SET"}},
+					Redact: []Redaction{
+						{Fields: []string{"user", "message"}, Pattern: `[^@\s]+@[^@\s]+`, Replacement: "[REDACTED]"},
+						{Fields: []string{"groups"}, Replacement: ""},
+					},
+					QueueMappings: []QueueMapping{{From: `^root\.legacy\.(.*)$`, To: "root.$1"}},
+				},
+				PriorityConfig: PriorityConfig{ClassTag: "kubernetes.io/label/priority-class"},
+				TraceConfig: TraceConfig{
+					Enabled:          true,
+					SampleRate:       0.1,
+					MaxEvents:        500,
+					MaxMessageLength: 1024,
+					MaxAge:           7 * 24 * time.Hour,
+				},
+				NodeGroupsConfig: NodeGroupsConfig{Groups: []NodeGroup{{
+					Name:     "gpu-pool",
+					Selector: map[string]string{"si/instance-type": "p3.2xlarge", "si/node-partition": "default"},
+				}}},
+				TopologyConfig: TopologyConfig{
+					ZoneAttribute: DefaultZoneAttribute,
+					RackAttribute: "example.com/rack",
+				},
+				SpotConfig: SpotConfig{
+					Enabled:   true,
+					Selectors: []map[string]string{{"karpenter.sh/capacity-type": "spot"}},
+				},
+				AutoscalerConfig: AutoscalerConfig{
+					Enabled:          true,
+					SourceComponents: []string{"cluster-autoscaler"},
+				},
+				SLOConfig: SLOConfig{
+					Interval:   5 * time.Minute,
+					HistoryTTL: 30 * 24 * time.Hour,
+					Objectives: []QueueSLO{{
+						Name:        "analytics-start",
+						Queue:       "root.analytics",
+						Objective:   0.95,
+						StartWithin: 5 * time.Minute,
+						Window:      24 * time.Hour,
+					}},
+				},
+				SnapshotsConfig: SnapshotsConfig{
+					TTL:     30 * time.Second,
+					MaxOpen: 2,
+				},
+				EgressConfig: EgressConfig{
+					ProxyURL:      "http://proxy.example.com:3128",
+					ProxyUsername: "yhs",
+					ProxyPassword: "proxy-secret",
+					NoProxy:       []string{"yunikorn-service", ".svc.cluster.local"},
+					CAFile:        "/etc/yhs/ca.crt",
+					Hosts:         map[string]string{"s3.eu-west-1.amazonaws.com": "10.20.0.15"},
+				},
+				AdminConfig: AdminConfig{
+					Listen:  []string{":9090"},
+					APIKeys: map[string]string{"platform-admin": "admin-secret"},
+				},
+				CatchUpConfig: CatchUpConfig{
+					LagThreshold: 5 * time.Minute,
+					RecoveryLag:  150 * time.Second,
+					BatchSize:    500,
+					PausedJobs:   []string{"data-quality", "slo", "cold-tier"},
+				},
+				EventStatisticsConfig: EventStatisticsConfig{Categories: []EventCategory{
+					{Name: "scheduling", Events: []string{"REQUEST-*", "APP-SET"}},
+					{Name: "app lifecycle", Events: []string{"APP-*"}},
+				}},
+				MirrorConfig: MirrorConfig{
+					URL:        "https://yhs-staging.example.com",
+					APIKey:     "secret:yhs/mirror#api-key",
+					SampleRate: 0.25,
+					Timeout:    10 * time.Second,
+					MaxPending: 100,
 				},
 			},
 			wantErr: false,
@@ -108,6 +353,123 @@ func TestYHSConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid config - request limits",
+			config: YHSConfig{
+				Port:   8080,
+				Limits: RequestLimitsConfig{MaxBodySize: 4 << 20, MaxHeaderSize: 16 << 10},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - zero max header size",
+			config: YHSConfig{
+				Port:   8080,
+				Limits: RequestLimitsConfig{MaxBodySize: 4 << 20},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - listen addresses without port",
+			config: YHSConfig{
+				Listen: []string{":8080", "[::1]:8081", "unix:/run/yhs/yhs.sock"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - listen address without port",
+			config: YHSConfig{
+				Listen: []string{"0.0.0.0"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - listen address without socket path",
+			config: YHSConfig{
+				Listen: []string{"unix:"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - duplicate listen address",
+			config: YHSConfig{
+				Listen: []string{":8080", ":8080"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - snake case field naming",
+			config: YHSConfig{
+				Port:        8080,
+				FieldNaming: FieldNamingSnakeCase,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - unknown field naming",
+			config: YHSConfig{
+				Port:        8080,
+				FieldNaming: "kebab-case",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - previous API schema",
+			config: YHSConfig{
+				Port:      8080,
+				APISchema: APISchemaPrevious,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - unknown API schema",
+			config: YHSConfig{
+				Port:      8080,
+				APISchema: "v0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - page sizes",
+			config: YHSConfig{
+				Port: 8080,
+				PageSizes: PageSizesConfig{
+					Applications: PageSizeConfig{DefaultLimit: 100, MaxLimit: 1000},
+					LegalHolds:   DefaultPageSize,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - default limit above max limit",
+			config: YHSConfig{
+				Port: 8080,
+				PageSizes: PageSizesConfig{
+					Applications: PageSizeConfig{DefaultLimit: 1000, MaxLimit: 100},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - default limit of other endpoints not positive",
+			config: YHSConfig{
+				Port: 8080,
+				PageSizes: PageSizesConfig{
+					Other: PageSizeConfig{DefaultLimit: -1, MaxLimit: 100},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - max limit not positive",
+			config: YHSConfig{
+				Port: 8080,
+				PageSizes: PageSizesConfig{
+					LegalHolds: PageSizeConfig{DefaultLimit: 0, MaxLimit: -1},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -186,6 +548,53 @@ func TestPostgresConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid config - IAM auth without password",
+			config: PostgresConfig{
+				Host:       "localhost",
+				DbName:     "testdb",
+				Username:   "user",
+				Port:       5432,
+				AuthMethod: DBAuthMethodAWSIAM,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - unknown auth method",
+			config: PostgresConfig{
+				Host:       "localhost",
+				DbName:     "testdb",
+				Username:   "user",
+				Password:   "password",
+				Port:       5432,
+				AuthMethod: "kerberos",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - statement timeouts",
+			config: PostgresConfig{
+				Host:              "localhost",
+				DbName:            "testdb",
+				Username:          "user",
+				Password:          "password",
+				Port:              5432,
+				StatementTimeouts: StatementTimeouts{Interactive: 30 * time.Second, Export: time.Hour},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - negative statement timeout",
+			config: PostgresConfig{
+				Host:              "localhost",
+				DbName:            "testdb",
+				Username:          "user",
+				Password:          "password",
+				Port:              5432,
+				StatementTimeouts: StatementTimeouts{Analytics: -time.Minute},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,6 +635,44 @@ func TestYunikornConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid config - clusters",
+			config: YunikornConfig{
+				Host:      "localhost",
+				Port:      8080,
+				ClusterID: "us-east",
+				Clusters:  []YunikornCluster{{ID: "eu-west", Host: "yunikorn.eu-west", Port: 9080}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - invalid cluster id",
+			config: YunikornConfig{
+				Host:      "localhost",
+				Port:      8080,
+				ClusterID: "US_East",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - duplicate cluster id",
+			config: YunikornConfig{
+				Host:      "localhost",
+				Port:      8080,
+				ClusterID: "eu-west",
+				Clusters:  []YunikornCluster{{ID: "eu-west", Host: "yunikorn.eu-west", Port: 9080}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - cluster without id and host",
+			config: YunikornConfig{
+				Host:     "localhost",
+				Port:     8080,
+				Clusters: []YunikornCluster{{Port: 9080}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -328,3 +775,1437 @@ func TestLoadConfig_FromEnv(t *testing.T) {
 	assert.Equal(t, "120s", k.String("db_pool_max_conn_idle_time"))
 	assert.Equal(t, "psw", k.String("db_password"))
 }
+
+func TestEncryptionConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  EncryptionConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - disabled",
+			config:  EncryptionConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: EncryptionConfig{
+				Keys:      map[string]string{"v1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+				ActiveKey: "v1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - unknown active key",
+			config: EncryptionConfig{
+				Keys:      map[string]string{"v1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+				ActiveKey: "v2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - short key",
+			config: EncryptionConfig{
+				Keys:      map[string]string{"v1": "AAECAwQFBgcICQoLDA0ODw=="},
+				ActiveKey: "v1",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("EncryptionConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinksConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  LinksConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no links",
+			config:  LinksConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: LinksConfig{Applications: []LinkTemplate{
+				{Name: "logs", URL: "https://logs.example.com/?query={{ .ApplicationID | urlquery }}"},
+				{Name: "spark", URL: "https://spark.example.com/history/{{ .ApplicationID | pathescape }}"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - name missing",
+			config:  LinksConfig{Applications: []LinkTemplate{{URL: "https://logs.example.com"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - url missing",
+			config:  LinksConfig{Applications: []LinkTemplate{{Name: "logs"}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - duplicate name",
+			config: LinksConfig{Applications: []LinkTemplate{
+				{Name: "logs", URL: "https://a.example.com"},
+				{Name: "logs", URL: "https://b.example.com"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LinksConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSparkConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SparkConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no history server",
+			config:  SparkConfig{ApplicationIDTag: DefaultSparkApplicationIDTag},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: SparkConfig{
+				ApplicationIDTag: "kubernetes.io/label/spark-app-selector",
+				HistoryServerURL: "https://spark-history.example.com/",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - tag missing",
+			config:  SparkConfig{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - history server url without scheme",
+			config:  SparkConfig{ApplicationIDTag: DefaultSparkApplicationIDTag, HistoryServerURL: "spark-history:18080"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SparkConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  WorkflowsConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no tags",
+			config:  WorkflowsConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - default tags",
+			config:  WorkflowsConfig{IDTags: DefaultWorkflowIDTags},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - empty tag",
+			config:  WorkflowsConfig{IDTags: []string{""}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - empty combined tag",
+			config:  WorkflowsConfig{IDTags: []string{"kubernetes.io/label/dag_id+"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("WorkflowsConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetriesConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RetriesConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no tags",
+			config:  RetriesConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - default tags",
+			config:  RetriesConfig{KeyTags: DefaultRetryKeyTags},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - empty combined tag",
+			config:  RetriesConfig{KeyTags: []string{"kubernetes.io/label/dag_id++kubernetes.io/label/run_id"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("RetriesConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCacheConfigValidate(t *testing.T) {
+	valid := CacheConfig{
+		TTL:                  30 * time.Second,
+		InvalidationInterval: time.Second,
+		MaxEntries:           10000,
+		Redis:                RedisConfig{KeyPrefix: "yhs"},
+		Results:              ResultsCacheConfig{RecentTTL: time.Minute, PastTTL: 24 * time.Hour, SettleAfter: 24 * time.Hour},
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *CacheConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config - memory",
+			modify:  func(c *CacheConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - redis",
+			modify:  func(c *CacheConfig) { c.Redis.Address = "redis:6379" },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - zero ttl",
+			modify:  func(c *CacheConfig) { c.TTL = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero invalidation interval",
+			modify:  func(c *CacheConfig) { c.InvalidationInterval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max entries",
+			modify:  func(c *CacheConfig) { c.MaxEntries = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - redis address without port",
+			modify:  func(c *CacheConfig) { c.Redis.Address = "redis" },
+			wantErr: true,
+		},
+		{
+			name: "invalid config - empty redis key prefix",
+			modify: func(c *CacheConfig) {
+				c.Redis.Address = "redis:6379"
+				c.Redis.KeyPrefix = ""
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero results recent ttl",
+			modify:  func(c *CacheConfig) { c.Results.RecentTTL = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - results past ttl shorter than recent ttl",
+			modify:  func(c *CacheConfig) { c.Results.PastTTL = time.Second },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - negative results settle after",
+			modify:  func(c *CacheConfig) { c.Results.SettleAfter = -time.Hour },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CacheConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerConfigValidate(t *testing.T) {
+	valid := CircuitBreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *CircuitBreakerConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *CircuitBreakerConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - zero failure threshold",
+			modify:  func(c *CircuitBreakerConfig) { c.FailureThreshold = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero open duration",
+			modify:  func(c *CircuitBreakerConfig) { c.OpenDuration = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CircuitBreakerConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFallbackConfigValidate(t *testing.T) {
+	valid := FallbackConfig{
+		Enabled:    true,
+		MaxAge:     time.Hour,
+		MaxEntries: 1000,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *FallbackConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *FallbackConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - zero max age",
+			modify:  func(c *FallbackConfig) { c.MaxAge = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max entries",
+			modify:  func(c *FallbackConfig) { c.MaxEntries = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("FallbackConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMirrorConfigValidate(t *testing.T) {
+	valid := MirrorConfig{
+		URL:        "https://yhs-staging.example.com",
+		SampleRate: 0.1,
+		Timeout:    10 * time.Second,
+		MaxPending: 100,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *MirrorConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *MirrorConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - disabled",
+			modify:  func(c *MirrorConfig) { c.URL = "" },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - not an http url",
+			modify:  func(c *MirrorConfig) { c.URL = "yhs-staging:8989" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - sample rate above 1",
+			modify:  func(c *MirrorConfig) { c.SampleRate = 1.5 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero timeout",
+			modify:  func(c *MirrorConfig) { c.Timeout = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max pending",
+			modify:  func(c *MirrorConfig) { c.MaxPending = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("MirrorConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCollectorConfigValidate(t *testing.T) {
+	valid := CollectorConfig{
+		ServerURL:     "https://yhs.example.com",
+		FlushInterval: 5 * time.Second,
+		MaxPending:    10000,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *CollectorConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *CollectorConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - no server url",
+			modify:  func(c *CollectorConfig) { c.ServerURL = "" },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - server url without scheme",
+			modify:  func(c *CollectorConfig) { c.ServerURL = "yhs.example.com" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero flush interval",
+			modify:  func(c *CollectorConfig) { c.FlushInterval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max pending",
+			modify:  func(c *CollectorConfig) { c.MaxPending = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CollectorConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransformConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TransformConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no rules",
+			config:  TransformConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: TransformConfig{
+				Drop:          []EventSelector{{Type: "NODE"}},
+				Redact:        []Redaction{{Fields: []string{"user", "object_id"}}},
+				QueueMappings: []QueueMapping{{From: "root.a", To: "root.b"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - drop rule without selector",
+			config:  TransformConfig{Drop: []EventSelector{{}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - redaction without fields",
+			config:  TransformConfig{Redact: []Redaction{{Pattern: "@"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - redaction of unknown field",
+			config:  TransformConfig{Redact: []Redaction{{Fields: []string{"email"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - queue mapping without target",
+			config:  TransformConfig{QueueMappings: []QueueMapping{{From: "root.a"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TransformConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTraceConfigValidate(t *testing.T) {
+	valid := TraceConfig{
+		Enabled:          true,
+		SampleRate:       1,
+		MaxEvents:        1000,
+		MaxMessageLength: 1024,
+		MaxAge:           time.Hour,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *TraceConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *TraceConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - no sampled applications",
+			modify:  func(c *TraceConfig) { c.SampleRate = 0 },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - sample rate above 1",
+			modify:  func(c *TraceConfig) { c.SampleRate = 1.5 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max events",
+			modify:  func(c *TraceConfig) { c.MaxEvents = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max message length",
+			modify:  func(c *TraceConfig) { c.MaxMessageLength = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max age",
+			modify:  func(c *TraceConfig) { c.MaxAge = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TraceConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJobsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  JobsConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no schedules",
+			config:  JobsConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "valid config",
+			config:  JobsConfig{Schedules: map[string]string{"retention": "0 3 * * *", "clickhouse-sink": "@every 1m"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - unknown job",
+			config:  JobsConfig{Schedules: map[string]string{"vacuum": "@daily"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - empty schedule",
+			config:  JobsConfig{Schedules: map[string]string{"retention": ""}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("JobsConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStorageConfigValidate(t *testing.T) {
+	valid := StorageConfig{
+		ColdAfter: 720 * time.Hour,
+		Interval:  time.Hour,
+		BatchSize: 1000,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *StorageConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *StorageConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - tiering disabled",
+			modify:  func(c *StorageConfig) { c.ColdAfter = 0 },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - negative cold after",
+			modify:  func(c *StorageConfig) { c.ColdAfter = -time.Hour },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero batch size",
+			modify:  func(c *StorageConfig) { c.BatchSize = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("StorageConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClickHouseConfigValidate(t *testing.T) {
+	valid := ClickHouseConfig{
+		URL:       "http://clickhouse:8123",
+		Database:  "default",
+		Interval:  10 * time.Second,
+		BatchSize: 10000,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *ClickHouseConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *ClickHouseConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - disabled",
+			modify:  func(c *ClickHouseConfig) { *c = ClickHouseConfig{} },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - url without scheme",
+			modify:  func(c *ClickHouseConfig) { c.URL = "clickhouse:8123" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - no database",
+			modify:  func(c *ClickHouseConfig) { c.Database = "" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero interval",
+			modify:  func(c *ClickHouseConfig) { c.Interval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero batch size",
+			modify:  func(c *ClickHouseConfig) { c.BatchSize = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ClickHouseConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLakeConfigValidate(t *testing.T) {
+	valid := LakeConfig{
+		ObjectStorageConfig: ObjectStorageConfig{URL: "s3://yhs-events/production"},
+		Format:              LakeFormatParquet,
+		FlushInterval:       5 * time.Minute,
+		MaxFileEvents:       100000,
+		MaxBufferedEvents:   1000000,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *LakeConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *LakeConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - disabled",
+			modify:  func(c *LakeConfig) { *c = LakeConfig{} },
+			wantErr: false,
+		},
+		{
+			name: "valid config - gcs",
+			modify: func(c *LakeConfig) {
+				c.URL = "gs://yhs-events"
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - local directory",
+			modify: func(c *LakeConfig) {
+				c.URL = "file:///var/lib/yhs/events"
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - S3 compatible endpoint",
+			modify: func(c *LakeConfig) {
+				c.Endpoint = "http://minio:9000"
+				c.PathStyle = true
+			},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - delta table",
+			modify:  func(c *LakeConfig) { c.Format = LakeFormatDelta },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - unsupported format",
+			modify:  func(c *LakeConfig) { c.Format = "iceberg" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - unsupported scheme",
+			modify:  func(c *LakeConfig) { c.URL = "hdfs://namenode/events" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - no bucket",
+			modify:  func(c *LakeConfig) { c.URL = "s3:///events" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - endpoint without scheme",
+			modify:  func(c *LakeConfig) { c.Endpoint = "minio:9000" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero flush interval",
+			modify:  func(c *LakeConfig) { c.FlushInterval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero max file events",
+			modify:  func(c *LakeConfig) { c.MaxFileEvents = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - buffer smaller than a file",
+			modify:  func(c *LakeConfig) { c.MaxBufferedEvents = 1000 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LakeConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportsConfigValidate(t *testing.T) {
+	valid := ExportsConfig{
+		ObjectStorageConfig: ObjectStorageConfig{URL: "s3://yhs-exports/production"},
+		URLExpiry:           15 * time.Minute,
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *ExportsConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *ExportsConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - disabled",
+			modify:  func(c *ExportsConfig) { *c = ExportsConfig{} },
+			wantErr: false,
+		},
+		{
+			name:    "valid config - local directory",
+			modify:  func(c *ExportsConfig) { c.URL = "file:///var/lib/yhs/exports" },
+			wantErr: false,
+		},
+		{
+			name: "valid config - S3 compatible endpoint",
+			modify: func(c *ExportsConfig) {
+				c.Endpoint = "http://minio:9000"
+				c.PathStyle = true
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - S3 compatible storage with static credentials",
+			modify: func(c *ExportsConfig) {
+				c.Endpoint = "https://ceph-rgw.example.com"
+				c.PathStyle = true
+				c.AccessKeyID = "yhs"
+				c.SecretAccessKey = "secret:yhs/ceph#secret_access_key"
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - access key id without secret access key",
+			modify:  func(c *ExportsConfig) { c.AccessKeyID = "yhs" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - gcs",
+			modify:  func(c *ExportsConfig) { c.URL = "gs://yhs-exports" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - no bucket",
+			modify:  func(c *ExportsConfig) { c.URL = "s3:///exports" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - endpoint without scheme",
+			modify:  func(c *ExportsConfig) { c.Endpoint = "minio:9000" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - zero url expiry",
+			modify:  func(c *ExportsConfig) { c.URLExpiry = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - url expiry beyond 7 days",
+			modify:  func(c *ExportsConfig) { c.URLExpiry = 8 * 24 * time.Hour },
+			wantErr: true,
+		},
+		{
+			name: "valid config - encrypted",
+			modify: func(c *ExportsConfig) {
+				c.Encryption.Recipients = []string{"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"}
+				c.Encryption.KMSKeyARNs = []string{"arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - encryption recipient is an identity",
+			modify:  func(c *ExportsConfig) { c.Encryption.Recipients = []string{"AGE-SECRET-KEY-1QQQQ"} },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - encryption kms key id instead of arn",
+			modify:  func(c *ExportsConfig) { c.Encryption.KMSKeyARNs = []string{"1234abcd-12ab-34cd-56ef-1234567890ab"} },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ExportsConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuotasConfigValidate(t *testing.T) {
+	valid := QuotasConfig{
+		DailyRequests:  1000,
+		DailyQueryTime: 10 * time.Minute,
+		Overrides:      []QuotaOverride{{Client: "notebooks", DailyRequests: 100}},
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *QuotasConfig)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *QuotasConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - disabled",
+			modify:  func(c *QuotasConfig) { *c = QuotasConfig{} },
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - negative daily requests",
+			modify:  func(c *QuotasConfig) { c.DailyRequests = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - negative daily query time",
+			modify:  func(c *QuotasConfig) { c.DailyQueryTime = -time.Second },
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - override without client",
+			modify:  func(c *QuotasConfig) { c.Overrides[0].Client = "" },
+			wantErr: true,
+		},
+		{
+			name: "invalid config - duplicate client",
+			modify: func(c *QuotasConfig) {
+				c.Overrides = append(c.Overrides, QuotaOverride{Client: "notebooks"})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - negative override",
+			modify:  func(c *QuotasConfig) { c.Overrides[0].DailyQueryTime = -time.Second },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			cfg.Overrides = append([]QuotaOverride(nil), valid.Overrides...)
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeGroupsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  NodeGroupsConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no groups",
+			config:  NodeGroupsConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: NodeGroupsConfig{Groups: []NodeGroup{
+				{Name: "gpu-pool", Selector: map[string]string{"si/instance-type": "p3.2xlarge"}},
+				{Name: "cpu-pool", Selector: map[string]string{"si/instance-type": "m5.xlarge"}},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - name missing",
+			config:  NodeGroupsConfig{Groups: []NodeGroup{{Selector: map[string]string{"si/instance-type": "m5.xlarge"}}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - reserved name",
+			config: NodeGroupsConfig{Groups: []NodeGroup{
+				{Name: UngroupedNodeGroup, Selector: map[string]string{"si/instance-type": "m5.xlarge"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - duplicate name",
+			config: NodeGroupsConfig{Groups: []NodeGroup{
+				{Name: "pool", Selector: map[string]string{"si/instance-type": "m5.xlarge"}},
+				{Name: "pool", Selector: map[string]string{"si/instance-type": "p3.2xlarge"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - selector missing",
+			config:  NodeGroupsConfig{Groups: []NodeGroup{{Name: "pool"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("NodeGroupsConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	labels, err := parseLabelSelector("si/instance-type=m5.xlarge, zone = eu-west-1a,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"si/instance-type": "m5.xlarge", "zone": "eu-west-1a"}, labels)
+
+	for _, selector := range []string{"si/instance-type", "=m5.xlarge", "zone=a,zone=b"} {
+		_, err := parseLabelSelector(selector)
+		assert.Error(t, err, selector)
+	}
+}
+
+func TestNodeGroupMatches(t *testing.T) {
+	group := NodeGroup{Name: "pool", Selector: map[string]string{"si/instance-type": "m5.xlarge", "zone": "a"}}
+	assert.True(t, group.Matches(map[string]string{"si/instance-type": "m5.xlarge", "zone": "a", "rack": "r1"}))
+	assert.False(t, group.Matches(map[string]string{"si/instance-type": "m5.xlarge", "zone": "b"}))
+	assert.False(t, group.Matches(map[string]string{"si/instance-type": "m5.xlarge"}))
+}
+
+func TestSpotConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SpotConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no selectors",
+			config:  SpotConfig{Enabled: true},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: SpotConfig{Enabled: true, Selectors: []map[string]string{
+				{"karpenter.sh/capacity-type": "spot"},
+				{"cloud.google.com/gke-spot": "true"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - empty selector",
+			config:  SpotConfig{Enabled: true, Selectors: []map[string]string{{}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SpotConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSpotSelectors(t *testing.T) {
+	selectors, err := parseSpotSelectors(DefaultSpotSelectors)
+	assert.NoError(t, err)
+	assert.Len(t, selectors, len(DefaultSpotSelectors))
+	assert.Equal(t, map[string]string{"karpenter.sh/capacity-type": "spot"}, selectors[0])
+
+	_, err = parseSpotSelectors([]string{"karpenter.sh/capacity-type"})
+	assert.Error(t, err)
+}
+
+func TestAutoscalerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AutoscalerConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - disabled",
+			config:  AutoscalerConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "valid config",
+			config:  AutoscalerConfig{Enabled: true, SourceComponents: DefaultAutoscalerSourceComponents},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - no source components",
+			config:  AutoscalerConfig{Enabled: true, SourceComponents: []string{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("AutoscalerConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSLOConfigValidate(t *testing.T) {
+	valid := QueueSLO{Name: "slo", Queue: "root.a", Objective: 0.95, StartWithin: time.Minute, Window: time.Hour}
+	tests := []struct {
+		name    string
+		config  SLOConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - no objectives",
+			config:  SLOConfig{HistoryTTL: time.Hour},
+			wantErr: false,
+		},
+		{
+			name:    "valid config",
+			config:  SLOConfig{HistoryTTL: time.Hour, Objectives: []QueueSLO{valid}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - no history TTL",
+			config:  SLOConfig{Objectives: []QueueSLO{valid}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - duplicate name",
+			config:  SLOConfig{HistoryTTL: time.Hour, Objectives: []QueueSLO{valid, valid}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - objective out of range",
+			config: SLOConfig{HistoryTTL: time.Hour, Objectives: []QueueSLO{
+				{Name: "slo", Objective: 1.5, StartWithin: time.Minute, Window: time.Hour},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - start within missing",
+			config: SLOConfig{HistoryTTL: time.Hour, Objectives: []QueueSLO{
+				{Name: "slo", Objective: 0.9, Window: time.Hour},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - invalid queue",
+			config: SLOConfig{HistoryTTL: time.Hour, Objectives: []QueueSLO{
+				{Name: "slo", Queue: "root..a", Objective: 0.9, StartWithin: time.Minute, Window: time.Hour},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SLOConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSnapshotsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SnapshotsConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			config:  SnapshotsConfig{TTL: time.Minute, MaxOpen: 4},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - disabled",
+			config:  SnapshotsConfig{TTL: time.Minute},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - zero TTL",
+			config:  SnapshotsConfig{MaxOpen: 4},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - negative max open",
+			config:  SnapshotsConfig{TTL: time.Minute, MaxOpen: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SnapshotsConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEgressConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  EgressConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - disabled",
+			config:  EgressConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: EgressConfig{
+				ProxyURL:      "https://proxy.example.com:3128",
+				ProxyUsername: "yhs",
+				ProxyPassword: "secret",
+				NoProxy:       []string{".svc.cluster.local"},
+				Hosts:         map[string]string{"yunikorn.example.com": "10.0.0.1", "s3.example.com": "fd00::1"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - proxy url without scheme",
+			config:  EgressConfig{ProxyURL: "proxy.example.com:3128"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - credentials without proxy url",
+			config:  EgressConfig{ProxyUsername: "yhs", ProxyPassword: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - host address is not an IP address",
+			config:  EgressConfig{Hosts: map[string]string{"yunikorn.example.com": "yunikorn.internal"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("EgressConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AdminConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - served by the listeners of the API",
+			config:  AdminConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: AdminConfig{
+				Listen:  []string{":9090", "unix:/run/yhs/admin.sock"},
+				APIKeys: map[string]string{"platform-admin": "admin-secret"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - invalid listen address",
+			config:  AdminConfig{Listen: []string{"localhost"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - duplicate listen address",
+			config:  AdminConfig{Listen: []string{":9090", ":9090"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("AdminConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatchUpConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CatchUpConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - disabled",
+			config:  CatchUpConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid config",
+			config: CatchUpConfig{
+				LagThreshold: 5 * time.Minute,
+				RecoveryLag:  time.Minute,
+				BatchSize:    100,
+				PausedJobs:   []string{"data-quality", "slo"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - recovery lag above the threshold",
+			config:  CatchUpConfig{LagThreshold: time.Minute, RecoveryLag: 2 * time.Minute, BatchSize: 100},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - batch size not positive",
+			config:  CatchUpConfig{LagThreshold: time.Minute, RecoveryLag: 30 * time.Second},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - unknown paused job",
+			config: CatchUpConfig{
+				LagThreshold: time.Minute,
+				RecoveryLag:  30 * time.Second,
+				BatchSize:    100,
+				PausedJobs:   []string{"rollups"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CatchUpConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventStatisticsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  EventStatisticsConfig
+		wantErr bool
+	}{
+		{
+			name:    "valid config - default categories",
+			config:  EventStatisticsConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - default categories configured",
+			config:  EventStatisticsConfig{Categories: DefaultEventCategories},
+			wantErr: false,
+		},
+		{
+			name:    "invalid config - missing name",
+			config:  EventStatisticsConfig{Categories: []EventCategory{{Events: []string{"APP-*"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - reserved name",
+			config:  EventStatisticsConfig{Categories: []EventCategory{{Name: OtherEventCategory, Events: []string{"APP-*"}}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - duplicate name",
+			config: EventStatisticsConfig{Categories: []EventCategory{
+				{Name: "apps", Events: []string{"APP-ADD"}},
+				{Name: "apps", Events: []string{"APP-SET"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - missing events",
+			config:  EventStatisticsConfig{Categories: []EventCategory{{Name: "apps"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid config - invalid pattern",
+			config:  EventStatisticsConfig{Categories: []EventCategory{{Name: "apps", Events: []string{"APP-["}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("EventStatisticsConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventCategoryMatches(t *testing.T) {
+	category := EventCategory{Name: "scheduling", Events: []string{"REQUEST-*", "APP-SET"}}
+	assert.True(t, category.Matches("REQUEST-ADD"))
+	assert.True(t, category.Matches("APP-SET"))
+	assert.False(t, category.Matches("APP-ADD"))
+	assert.False(t, category.Matches("NODE-SET"))
+}
+
+func TestYunikornConfigSchedulers(t *testing.T) {
+	cfg := YunikornConfig{
+		Host:              "localhost",
+		Port:              8080,
+		SyncFailureBudget: 2,
+		Clusters:          []YunikornCluster{{ID: "eu-west", Host: "yunikorn.eu-west", Port: 9443, Secure: true}},
+	}
+	assert.Equal(t, []YunikornConfig{
+		{Host: "localhost", Port: 8080, SyncFailureBudget: 2},
+		{Host: "yunikorn.eu-west", Port: 9443, Secure: true, SyncFailureBudget: 2, ClusterID: "eu-west"},
+	}, cfg.Schedulers())
+
+	// the scheduler at the host is only synced if it is set
+	cfg.Host = ""
+	assert.Len(t, cfg.Schedulers(), 1)
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// ControllerConfig specifies the configuration for the Kubernetes controller which applies
+// HistoryRetentionPolicy and HistoryAlertRule custom resources to the running server.
+type ControllerConfig struct {
+	// Enabled indicates whether the controller watches the custom resources.
+	Enabled bool
+	// Namespace restricts the watched custom resources to a single namespace. Empty watches all namespaces.
+	Namespace string
+	// Kubeconfig is the path to a kubeconfig file. Empty uses the in-cluster configuration.
+	Kubeconfig string
+	// ResyncPeriod is the interval at which all custom resources are re-applied.
+	ResyncPeriod time.Duration
+}
+
+func init() {
+	resyncPeriod := durationSchema("Interval at which all custom resources are re-applied.")
+	resyncPeriod.Default = "10m"
+	description := "Configuration of the Kubernetes controller applying retention policies and alert rules from custom resources."
+	schema := objectSchema(description, map[string]*Schema{
+		"enabled":       boolSchema("Whether the controller watches HistoryRetentionPolicy and HistoryAlertRule custom resources."),
+		"namespace":     stringSchema("Namespace of the watched custom resources. Empty watches all namespaces."),
+		"kubeconfig":    stringSchema("Path to a kubeconfig file. Empty uses the in-cluster configuration."),
+		"resync_period": resyncPeriod,
+	})
+	registerSection("controller", schema, func(k *koanf.Koanf, cfg *Config) error {
+		resyncPeriod := k.Duration("controller_resync_period")
+		if resyncPeriod == 0 {
+			resyncPeriod = 10 * time.Minute
+		}
+		cfg.ControllerConfig = ControllerConfig{
+			Enabled:      k.Bool("controller_enabled"),
+			Namespace:    k.String("controller_namespace"),
+			Kubeconfig:   k.String("controller_kubeconfig"),
+			ResyncPeriod: resyncPeriod,
+		}
+		return nil
+	})
+}
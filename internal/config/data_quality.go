@@ -0,0 +1,30 @@
+package config
+
+import (
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// DataQualityConfig specifies the configuration for the data quality checks.
+type DataQualityConfig struct {
+	// Interval is the interval at which the data quality checks run.
+	Interval time.Duration
+}
+
+func init() {
+	interval := durationSchema("Interval at which the data quality checks run, " +
+		"unless the schedule of the data-quality job is set in jobs.schedules.")
+	interval.Default = "24h"
+	schema := objectSchema("Configuration of the data quality checks.", map[string]*Schema{
+		"interval": interval,
+	})
+	registerSection("data_quality", schema, func(k *koanf.Koanf, cfg *Config) error {
+		interval := k.Duration("data_quality_interval")
+		if interval == 0 {
+			interval = 24 * time.Hour
+		}
+		cfg.DataQualityConfig = DataQualityConfig{Interval: interval}
+		return nil
+	})
+}
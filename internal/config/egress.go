@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// EgressConfig specifies how the outbound HTTP requests, e.g. to Yunikorn, webhooks or object storage, reach their
+// destination in environments which restrict the egress.
+type EgressConfig struct {
+	// ProxyURL is the URL of the HTTP or HTTPS proxy the outbound requests are sent through. The HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables apply if empty.
+	ProxyURL string
+	// ProxyUsername is the username authenticating at the proxy.
+	ProxyUsername string
+	// ProxyPassword is the password authenticating at the proxy.
+	ProxyPassword string
+	// NoProxy are the hosts, domains, IP addresses and CIDR ranges which are reached without the proxy.
+	NoProxy []string
+	// CAFile is the PEM file of the certificate authorities which are trusted in addition to those of the system.
+	CAFile string
+	// Hosts maps host names to the IP addresses the connections to the hosts are opened to instead of resolving them.
+	Hosts map[string]string
+}
+
+// Enabled returns whether the outbound requests are sent differently than by default.
+func (c *EgressConfig) Enabled() bool {
+	return c.ProxyURL != "" || c.CAFile != "" || len(c.Hosts) > 0
+}
+
+func (c *EgressConfig) Validate() error {
+	var errorMessages []string
+	if c.ProxyURL != "" {
+		if u, err := url.Parse(c.ProxyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("proxy url %q is not an http or https URL", c.ProxyURL))
+		}
+	} else if c.ProxyUsername != "" || c.ProxyPassword != "" || len(c.NoProxy) > 0 {
+		errorMessages = append(errorMessages, "proxy username, password and no proxy require the proxy url")
+	}
+	hosts := make([]string, 0, len(c.Hosts))
+	for host := range c.Hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		if net.ParseIP(c.Hosts[host]) == nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("address %q of host %s is not an IP address",
+				c.Hosts[host], host))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("egress config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	proxyURL := stringSchema("URL of the HTTP or HTTPS proxy the outbound requests, e.g. to Yunikorn, webhooks or " +
+		"object storage, are sent through. The HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply " +
+		"if it is not set.")
+	proxyURL.Examples = []any{"http://proxy.example.com:3128"}
+	noProxy := stringListSchema("Hosts, domains, IP addresses and CIDR ranges which are reached without the proxy. " +
+		"Domains starting with a dot match their subdomains.")
+	noProxy.Examples = []any{[]string{"yunikorn-service", ".svc.cluster.local", "10.0.0.0/8"}}
+	hosts := mapSchema("IP addresses keyed by host name, which the connections to the hosts are opened to instead "+
+		"of resolving them. TLS certificates are still verified against the host name.", &Schema{Type: "string"})
+	hosts.Examples = []any{map[string]string{"s3.eu-west-1.amazonaws.com": "10.20.0.15"}}
+	schema := objectSchema("Configuration of the outbound HTTP requests in environments which restrict the egress.",
+		map[string]*Schema{
+			"proxy_url":      proxyURL,
+			"proxy_username": stringSchema("Username authenticating at the proxy."),
+			"proxy_password": stringSchema("Password authenticating at the proxy."),
+			"no_proxy":       noProxy,
+			"ca_file": stringSchema("PEM file of the certificate authorities which are trusted in addition to " +
+				"those of the system."),
+			"hosts": hosts,
+		})
+	registerSection("egress", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.EgressConfig = EgressConfig{
+			ProxyURL:      k.String("egress_proxy_url"),
+			ProxyUsername: k.String("egress_proxy_username"),
+			ProxyPassword: k.String("egress_proxy_password"),
+			NoProxy:       k.Strings("egress_no_proxy"),
+			CAFile:        k.String("egress_ca_file"),
+			Hosts:         k.StringMap("egress_hosts"),
+		}
+		return cfg.EgressConfig.Validate()
+	})
+}
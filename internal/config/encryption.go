@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// EncryptionKeySize is the size in bytes of the keys used to encrypt columns.
+const EncryptionKeySize = 32
+
+// EncryptionConfig specifies the keys used to encrypt the user and group columns at rest.
+type EncryptionConfig struct {
+	// Keys maps key IDs to base64 encoded 32 byte keys.
+	// If empty, columns are not encrypted.
+	Keys map[string]string
+	// ActiveKey is the ID of the key used to encrypt new values. The other keys are only used for decryption,
+	// so that keys can be rotated.
+	ActiveKey string
+}
+
+// Enabled returns true if columns are encrypted.
+func (c *EncryptionConfig) Enabled() bool {
+	return len(c.Keys) > 0
+}
+
+func (c *EncryptionConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var errorMessages []string
+	if _, ok := c.Keys[c.ActiveKey]; !ok {
+		errorMessages = append(errorMessages, fmt.Sprintf("active key %q is not configured", c.ActiveKey))
+	}
+	ids := make([]string, 0, len(c.Keys))
+	for id := range c.Keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if IsSecretReference(c.Keys[id]) {
+			// validated once resolved
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(c.Keys[id])
+		if err != nil || len(key) != EncryptionKeySize {
+			errorMessages = append(errorMessages, fmt.Sprintf("key %q must be %d base64 encoded bytes", id, EncryptionKeySize))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("encryption config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	keys := mapSchema(
+		"Base64 encoded 32 byte keys or secret references keyed by key ID, preferably provided via YHS_ENCRYPTION_KEYS_<ID>. "+
+			"If empty, the user and group columns are not encrypted.",
+		&Schema{Type: "string", Pattern: "^([A-Za-z0-9+/]{43}=|secret:.+)$"},
+	)
+	keys.PropertyNames = &Schema{Pattern: "^[a-z0-9]+$"}
+	schema := objectSchema("Configuration of the encryption of the user and group columns at rest.", map[string]*Schema{
+		"keys":       keys,
+		"active_key": stringSchema("ID of the key used to encrypt new values. The other keys are only used for decryption."),
+	})
+	registerSection("encryption", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.EncryptionConfig = EncryptionConfig{
+			Keys:      k.StringMap("encryption_keys"),
+			ActiveKey: k.String("encryption_active_key"),
+		}
+		return cfg.EncryptionConfig.Validate()
+	})
+}
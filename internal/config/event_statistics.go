@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// OtherEventCategory is the name of the category of the event types which are not in any event category.
+const OtherEventCategory = "other"
+
+// DefaultEventCategories are the event categories of the event statistics if none are configured.
+var DefaultEventCategories = []EventCategory{
+	{Name: "app lifecycle", Events: []string{"APP-*"}},
+	{Name: "scheduling", Events: []string{"REQUEST-*"}},
+	{Name: "node", Events: []string{"NODE-*"}},
+	{Name: "queue", Events: []string{"QUEUE-*"}},
+	{Name: "users and groups", Events: []string{"USERGROUP-*"}},
+}
+
+// EventStatisticsConfig specifies how the event types of the event statistics are grouped into categories.
+type EventStatisticsConfig struct {
+	// Categories are the event categories, in the order they are reported. An event type is counted in the first
+	// category matching it. If empty, DefaultEventCategories are used.
+	Categories []EventCategory
+}
+
+// EventCategory is a named category of event types, such as scheduling.
+type EventCategory struct {
+	// Name identifies the category in responses, e.g. scheduling.
+	Name string
+	// Events are the patterns of the event types of the category, which are the type and the change type of events,
+	// e.g. APP-ADD. A pattern matches as in path.Match, e.g. APP-* matches all the application events.
+	Events []string
+}
+
+// Matches returns whether the event type matches one of the patterns of the category.
+func (c EventCategory) Matches(eventType string) bool {
+	for _, pattern := range c.Events {
+		if ok, _ := path.Match(pattern, eventType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *EventStatisticsConfig) Validate() error {
+	var errorMessages []string
+	names := make(map[string]bool, len(c.Categories))
+	for i, category := range c.Categories {
+		switch {
+		case category.Name == "":
+			errorMessages = append(errorMessages, fmt.Sprintf("event category %d: name is required", i))
+		case category.Name == OtherEventCategory:
+			errorMessages = append(errorMessages, fmt.Sprintf("event category %d: name %q is reserved", i, category.Name))
+		case names[category.Name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("event category %d: duplicate name %q", i, category.Name))
+		}
+		names[category.Name] = true
+		if len(category.Events) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("event category %d: events are required", i))
+		}
+		for _, pattern := range category.Events {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("event category %d: invalid pattern %q", i, pattern))
+			}
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("event statistics config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	category := objectSchema("Category of event types.", map[string]*Schema{
+		"name": stringSchema("Name identifying the category in responses, e.g. scheduling. other is reserved for the " +
+			"event types which are not in any category."),
+		"events": {
+			Type: "array",
+			Description: "Patterns of the event types of the category, which are the type and the change type of " +
+				"events, in which * matches any characters, e.g. APP-* for all the application events.",
+			Items:    &Schema{Type: "string"},
+			Examples: []any{[]string{"REQUEST-*", "APP-SET"}},
+		},
+	})
+	schema := objectSchema("Categories of the event statistics, which group the event types reported by Yunikorn.",
+		map[string]*Schema{
+			"categories": {
+				Type: "array",
+				Description: "Event categories in the order they are reported. An event type is counted in the first " +
+					"category matching it. Defaults to the app lifecycle, scheduling, node, queue and users and " +
+					"groups categories.",
+				Items: category,
+			},
+		})
+	registerSection("event_statistics", schema, func(k *koanf.Koanf, cfg *Config) error {
+		var categories []EventCategory
+		for _, c := range k.Slices("event_statistics_categories") {
+			categories = append(categories, EventCategory{Name: c.String("name"), Events: c.Strings("events")})
+		}
+		cfg.EventStatisticsConfig = EventStatisticsConfig{Categories: categories}
+		return cfg.EventStatisticsConfig.Validate()
+	})
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultExportsURLExpiry = 15 * time.Minute
+	// maxExportsURLExpiry is the longest validity of presigned URLs supported by S3.
+	maxExportsURLExpiry = 7 * 24 * time.Hour
+)
+
+var (
+	// ageRecipientPattern matches the Bech32 encoded age X25519 recipients.
+	ageRecipientPattern = regexp.MustCompile(`^age1[02-9ac-hj-np-z]{58}$`)
+	// kmsKeyARNPattern matches the ARNs of AWS KMS keys and aliases.
+	kmsKeyARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:(key|alias)/.+$`)
+)
+
+// ExportsConfig specifies the storage the exports are written to as immutable files, whose downloads can be resumed
+// with range requests.
+type ExportsConfig struct {
+	// ObjectStorageConfig is the location of the exports: s3://bucket/prefix or file:///path. If its URL is empty,
+	// exports are disabled.
+	ObjectStorageConfig
+	// URLExpiry is how long the presigned URLs of exports stored in S3 are valid.
+	URLExpiry time.Duration
+	// Encryption specifies the keys the exports are encrypted for, so that they can be stored in buckets shared
+	// with other tenants.
+	Encryption ExportEncryptionConfig
+}
+
+// ExportEncryptionConfig specifies the keys the exports are encrypted for in the age format. Each export is encrypted
+// with a new file key, which is encrypted for each of the keys, so that any of them decrypts the export.
+type ExportEncryptionConfig struct {
+	// Recipients are the age X25519 recipients, age1..., whose identities decrypt the exports.
+	Recipients []string
+	// KMSKeyARNs are the ARNs of the AWS KMS keys, or of their aliases, which encrypt the file keys of the exports.
+	// The exports are decrypted by principals allowed to decrypt with the keys.
+	KMSKeyARNs []string
+}
+
+// Enabled returns whether the exports are encrypted.
+func (c *ExportEncryptionConfig) Enabled() bool {
+	return len(c.Recipients) > 0 || len(c.KMSKeyARNs) > 0
+}
+
+// Enabled returns whether exports can be created.
+func (c *ExportsConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+func (c *ExportsConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	// Google Cloud Storage is not supported, as its presigned URLs need the private key of a service account
+	errorMessages := c.ObjectStorageConfig.validate("s3", "file")
+	if c.URLExpiry < time.Second || c.URLExpiry > maxExportsURLExpiry {
+		errorMessages = append(errorMessages, fmt.Sprintf("url expiry must be between 1s and %s", maxExportsURLExpiry))
+	}
+	for _, recipient := range c.Encryption.Recipients {
+		if !ageRecipientPattern.MatchString(recipient) {
+			errorMessages = append(errorMessages, fmt.Sprintf("encryption recipient %q is not an age X25519 recipient", recipient))
+		}
+	}
+	for _, arn := range c.Encryption.KMSKeyARNs {
+		if !kmsKeyARNPattern.MatchString(arn) {
+			errorMessages = append(errorMessages, fmt.Sprintf("encryption kms key arn %q is not the ARN of a KMS key or alias", arn))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("exports config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	location := stringSchema("Location the exports are written to as immutable files: s3://bucket/prefix or " +
+		"file:///path. Exports stored in S3 are downloaded with presigned URLs. If empty, exports are disabled.")
+	location.Examples = []any{"s3://yhs-exports/production", "file:///var/lib/yhs/exports"}
+	urlExpiry := durationSchema("How long the presigned URLs of exports stored in S3 are valid, at most 7 days.")
+	urlExpiry.Default = defaultExportsURLExpiry.String()
+	recipients := stringListSchema("age X25519 recipients, whose identities decrypt the exports.")
+	recipients.Items.Pattern = ageRecipientPattern.String()
+	kmsKeyARNs := stringListSchema("ARNs of the AWS KMS keys or aliases which encrypt the file keys of the exports. " +
+		"Credentials are loaded from the default AWS credential chain.")
+	kmsKeyARNs.Items.Pattern = kmsKeyARNPattern.String()
+	kmsKeyARNs.Examples = []any{[]string{"arn:aws:kms:eu-west-1:123456789012:alias/yhs-exports"}}
+	encryption := objectSchema("Keys the exports are encrypted for in the age format, so that they can be stored in "+
+		"shared buckets. Any of the keys decrypts an export. If empty, exports are not encrypted.", map[string]*Schema{
+		"recipients":   recipients,
+		"kms_key_arns": kmsKeyARNs,
+	})
+	properties := objectStorageSchemas(location)
+	properties["url_expiry"] = urlExpiry
+	properties["encryption"] = encryption
+	schema := objectSchema("Configuration of the storage of the exports, whose downloads can be resumed.", properties)
+	registerSection("exports", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.ExportsConfig = ExportsConfig{
+			ObjectStorageConfig: loadObjectStorage(k, "exports"),
+			URLExpiry:           defaultExportsURLExpiry,
+			Encryption: ExportEncryptionConfig{
+				Recipients: k.Strings("exports_encryption_recipients"),
+				KMSKeyARNs: k.Strings("exports_encryption_kms_key_arns"),
+			},
+		}
+		if k.Exists("exports_url_expiry") {
+			cfg.ExportsConfig.URLExpiry = k.Duration("exports_url_expiry")
+		}
+		return cfg.ExportsConfig.Validate()
+	})
+}
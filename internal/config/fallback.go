@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultFallbackMaxAge     = time.Hour
+	defaultFallbackMaxEntries = 1000
+)
+
+// FallbackConfig specifies the stale responses served by the key read endpoints while the database is unavailable,
+// e.g. during a failover, instead of errors.
+type FallbackConfig struct {
+	// Enabled specifies whether the last successful responses of the key read endpoints are kept and served while
+	// the database is unavailable.
+	Enabled bool
+	// MaxAge is how old a response may be to be served while the database is unavailable.
+	MaxAge time.Duration
+	// MaxEntries is the maximum number of responses kept in memory.
+	MaxEntries int
+}
+
+func (c *FallbackConfig) Validate() error {
+	var errorMessages []string
+	if c.MaxAge <= 0 {
+		errorMessages = append(errorMessages, "max age must be positive")
+	}
+	if c.MaxEntries <= 0 {
+		errorMessages = append(errorMessages, "max entries must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("fallback config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	maxAge := durationSchema("How old a response may be to be served while the database is unavailable.")
+	maxAge.Default = defaultFallbackMaxAge.String()
+	maxEntries := intSchema("Maximum number of responses kept in memory.")
+	maxEntries.Default = defaultFallbackMaxEntries
+	schema := objectSchema("Stale responses served by the key read endpoints while the database is unavailable, "+
+		"e.g. during a failover, instead of errors.", map[string]*Schema{
+		"enabled": boolSchema("Whether the last successful responses of the key read endpoints are kept in memory " +
+			"and served while the database is unavailable."),
+		"max_age":     maxAge,
+		"max_entries": maxEntries,
+	})
+	registerSection("fallback", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.FallbackConfig = FallbackConfig{
+			Enabled:    k.Bool("fallback_enabled"),
+			MaxAge:     defaultFallbackMaxAge,
+			MaxEntries: defaultFallbackMaxEntries,
+		}
+		if k.Exists("fallback_max_age") {
+			cfg.FallbackConfig.MaxAge = k.Duration("fallback_max_age")
+		}
+		if k.Exists("fallback_max_entries") {
+			cfg.FallbackConfig.MaxEntries = k.Int("fallback_max_entries")
+		}
+		return cfg.FallbackConfig.Validate()
+	})
+}
@@ -0,0 +1,27 @@
+package config
+
+import "github.com/knadh/koanf/v2"
+
+// FeatureFlagsConfig specifies the configuration for the feature flags.
+type FeatureFlagsConfig struct {
+	// Flags maps feature flag names to their enabled state.
+	Flags map[string]bool
+	// AdminOverrides indicates whether feature flags can be toggled at runtime via the admin API.
+	AdminOverrides bool
+}
+
+func init() {
+	flags := mapSchema("Feature flags keyed by name: analytics or event-stream.", &Schema{Type: "boolean"})
+	flags.PropertyNames = &Schema{Pattern: "^[a-z0-9-]+$"}
+	schema := objectSchema("Configuration of the feature flags gating experimental features.", map[string]*Schema{
+		"flags":           flags,
+		"admin_overrides": boolSchema("Whether feature flags can be overridden at runtime via the admin API."),
+	})
+	registerSection("features", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.FeatureFlagsConfig = FeatureFlagsConfig{
+			Flags:          k.BoolMap("features_flags"),
+			AdminOverrides: k.Bool("features_admin_overrides"),
+		}
+		return nil
+	})
+}
@@ -0,0 +1,21 @@
+package config
+
+import "github.com/knadh/koanf/v2"
+
+// IngestConfig specifies whether the server accepts the data forwarded by collectors.
+type IngestConfig struct {
+	// Enabled specifies whether collectors may forward data to the server.
+	Enabled bool
+}
+
+func init() {
+	schema := objectSchema("Configuration of the ingestion of the data forwarded by collectors.", map[string]*Schema{
+		"enabled": boolSchema("Whether collectors may forward the data of Yunikorn to the server."),
+	})
+	registerSection("ingest", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.IngestConfig = IngestConfig{
+			Enabled: k.Bool("ingest_enabled"),
+		}
+		return nil
+	})
+}
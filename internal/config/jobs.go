@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// JobNames are the names of the periodic jobs run by the scheduler.
+var JobNames = []string{"alerting", "clickhouse-sink", "cold-tier", "data-quality", "retention", "slo"}
+
+// JobsConfig specifies the schedules of the periodic jobs.
+type JobsConfig struct {
+	// Schedules maps job names to their schedules, which are cron expressions of five fields evaluated in UTC,
+	// descriptors like @daily, or @every followed by a duration. A job whose schedule is not set runs at the
+	// interval of its section, e.g. retention.interval.
+	Schedules map[string]string
+}
+
+func (c *JobsConfig) Validate() error {
+	for name, schedule := range c.Schedules {
+		if !slices.Contains(JobNames, name) {
+			return fmt.Errorf("unknown job %q in jobs schedules, must be one of %v", name, JobNames)
+		}
+		if schedule == "" {
+			return fmt.Errorf("schedule of job %s is empty", name)
+		}
+	}
+	return nil
+}
+
+func init() {
+	names := make([]any, len(JobNames))
+	for i, name := range JobNames {
+		names[i] = name
+	}
+	schedules := mapSchema(
+		"Schedules keyed by job name, which are cron expressions of five fields evaluated in UTC, "+
+			"descriptors like @daily, or @every followed by a duration, e.g. \"@every 30m\". "+
+			"A job whose schedule is not set runs at the interval of its section, e.g. retention.interval.",
+		&Schema{Type: "string"},
+	)
+	schedules.PropertyNames = &Schema{Enum: names}
+	schedules.Examples = []any{map[string]string{"retention": "0 3 * * *", "data-quality": "@daily"}}
+	schema := objectSchema("Configuration of the scheduler running the periodic jobs.", map[string]*Schema{
+		"schedules": schedules,
+	})
+	registerSection("jobs", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.JobsConfig = JobsConfig{
+			Schedules: k.StringMap("jobs_schedules"),
+		}
+		return cfg.JobsConfig.Validate()
+	})
+}
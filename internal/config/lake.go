@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	// LakeFormatParquet writes loose Parquet files, which are listed in manifests.
+	LakeFormatParquet = "parquet"
+	// LakeFormatDelta commits the Parquet files to a Delta Lake table.
+	LakeFormatDelta = "delta"
+)
+
+const (
+	defaultLakeFlushInterval     = 5 * time.Minute
+	defaultLakeMaxFileEvents     = 100000
+	defaultLakeMaxBufferedEvents = 1000000
+)
+
+// LakeConfig specifies the object storage the raw events are written to as hourly partitioned Parquet files,
+// which offline analytics read instead of the database.
+type LakeConfig struct {
+	// ObjectStorageConfig is the location of the files. If its URL is empty, the events are not written to object
+	// storage.
+	ObjectStorageConfig
+	// Format is the format of the files, LakeFormatParquet or LakeFormatDelta.
+	Format string
+	// FlushInterval is the interval at which the buffered events are written, which bounds how stale the files are.
+	FlushInterval time.Duration
+	// MaxFileEvents is the maximum number of events per file. The events are written early once as many are buffered.
+	MaxFileEvents int
+	// MaxBufferedEvents is the maximum number of events buffered while the object storage is unavailable,
+	// beyond which events are dropped.
+	MaxBufferedEvents int
+}
+
+// Enabled returns whether the events are written to object storage.
+func (c *LakeConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+func (c *LakeConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	errorMessages := c.ObjectStorageConfig.validate("s3", "gs", "file")
+	if c.Format != LakeFormatParquet && c.Format != LakeFormatDelta {
+		errorMessages = append(errorMessages, fmt.Sprintf("format %q is not %s or %s", c.Format, LakeFormatParquet, LakeFormatDelta))
+	}
+	if c.FlushInterval <= 0 {
+		errorMessages = append(errorMessages, "flush interval must be positive")
+	}
+	if c.MaxFileEvents < 1 {
+		errorMessages = append(errorMessages, "max file events must be positive")
+	}
+	if c.MaxBufferedEvents < c.MaxFileEvents {
+		errorMessages = append(errorMessages, "max buffered events must be at least max file events")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("lake config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	location := stringSchema("Location the raw events are written to as hourly partitioned Parquet files: " +
+		"s3://bucket/prefix, gs://bucket/prefix or file:///path. If empty, the events are not written to object storage.")
+	location.Examples = []any{"s3://yhs-events/production", "gs://yhs-events/production"}
+	format := stringSchema("Format of the files: parquet writes loose Parquet files listed in manifests, " +
+		"delta commits them to a Delta Lake table with ACID snapshots and time travel.")
+	format.Enum = []any{LakeFormatParquet, LakeFormatDelta}
+	format.Default = LakeFormatParquet
+	flushInterval := durationSchema("Interval at which the buffered events are written, which bounds how stale the files are.")
+	flushInterval.Default = defaultLakeFlushInterval.String()
+	minEvents := 1
+	maxFileEvents := intSchema("Maximum number of events per file. The events are written early once as many are buffered.")
+	maxFileEvents.Minimum = &minEvents
+	maxFileEvents.Default = defaultLakeMaxFileEvents
+	maxBufferedEvents := intSchema("Maximum number of events buffered while the object storage is unavailable, " +
+		"beyond which events are dropped.")
+	maxBufferedEvents.Minimum = &minEvents
+	maxBufferedEvents.Default = defaultLakeMaxBufferedEvents
+	properties := objectStorageSchemas(location)
+	properties["format"] = format
+	properties["flush_interval"] = flushInterval
+	properties["max_file_events"] = maxFileEvents
+	properties["max_buffered_events"] = maxBufferedEvents
+	schema := objectSchema("Configuration of the object storage the raw events are written to for offline analytics.", properties)
+	registerSection("lake", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.LakeConfig = LakeConfig{
+			ObjectStorageConfig: loadObjectStorage(k, "lake"),
+			Format:              LakeFormatParquet,
+			FlushInterval:       defaultLakeFlushInterval,
+			MaxFileEvents:       defaultLakeMaxFileEvents,
+			MaxBufferedEvents:   defaultLakeMaxBufferedEvents,
+		}
+		if k.Exists("lake_format") {
+			cfg.LakeConfig.Format = k.String("lake_format")
+		}
+		if k.Exists("lake_flush_interval") {
+			cfg.LakeConfig.FlushInterval = k.Duration("lake_flush_interval")
+		}
+		if k.Exists("lake_max_file_events") {
+			cfg.LakeConfig.MaxFileEvents = k.Int("lake_max_file_events")
+		}
+		if k.Exists("lake_max_buffered_events") {
+			cfg.LakeConfig.MaxBufferedEvents = k.Int("lake_max_buffered_events")
+		}
+		return cfg.LakeConfig.Validate()
+	})
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// LinksConfig specifies the links to external systems, such as log stores, returned with the entities of responses.
+type LinksConfig struct {
+	// Applications are the links returned with every application.
+	Applications []LinkTemplate
+}
+
+// LinkTemplate is a named link whose URL is a Go template rendered for each entity.
+type LinkTemplate struct {
+	// Name identifies the link in responses, e.g. logs.
+	Name string
+	// URL is the Go template of the URL, e.g. https://logs.example.com/?query={{ .ApplicationID | urlquery }}.
+	URL string
+}
+
+func (c *LinksConfig) Validate() error {
+	var errorMessages []string
+	names := make(map[string]bool, len(c.Applications))
+	for i, l := range c.Applications {
+		if l.Name == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("application link %d: name is required", i))
+		} else if names[l.Name] {
+			errorMessages = append(errorMessages, fmt.Sprintf("application link %d: duplicate name %q", i, l.Name))
+		}
+		names[l.Name] = true
+		if l.URL == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("application link %d: url is required", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("links config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	link := objectSchema("Link rendered for each application.", map[string]*Schema{
+		"name": stringSchema("Name identifying the link in responses, e.g. logs."),
+		"url": {
+			Type: "string",
+			Description: "Go template of the http or https URL, rendered with the application ID, partition, queue name, " +
+				"user, state, submission and finished times. Values must be escaped with the urlquery or pathescape functions.",
+			Examples: []any{"https://logs.example.com/explore?query={{ .ApplicationID | urlquery }}"},
+		},
+	})
+	schema := objectSchema("Links to external systems, such as log stores, returned with the entities of responses.",
+		map[string]*Schema{
+			"applications": {
+				Type:        "array",
+				Description: "Links returned with every application as externalLinks, e.g. to its logs.",
+				Items:       link,
+			},
+		})
+	registerSection("links", schema, func(k *koanf.Koanf, cfg *Config) error {
+		var applications []LinkTemplate
+		for _, l := range k.Slices("links_applications") {
+			applications = append(applications, LinkTemplate{
+				Name: l.String("name"),
+				URL:  l.String("url"),
+			})
+		}
+		cfg.LinksConfig = LinksConfig{Applications: applications}
+		return cfg.LinksConfig.Validate()
+	})
+}
@@ -0,0 +1,26 @@
+package config
+
+import "github.com/knadh/koanf/v2"
+
+type LogConfig struct {
+	LogLevel   string
+	JSONFormat bool
+}
+
+func init() {
+	schema := objectSchema("Configuration of the logger.", map[string]*Schema{
+		"level": {
+			Type:        "string",
+			Description: "Log level, one of DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL, or a numeric level between -1 and 5.",
+			Examples:    []any{"INFO"},
+		},
+		"json_format": boolSchema("Whether the log is written in JSON format."),
+	})
+	registerSection("log", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.LogConfig = LogConfig{
+			JSONFormat: k.Bool("log_json_format"),
+			LogLevel:   k.String("log_level"),
+		}
+		return nil
+	})
+}
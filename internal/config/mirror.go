@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultMirrorSampleRate = 0.1
+	defaultMirrorTimeout    = 10 * time.Second
+	defaultMirrorMaxPending = 100
+)
+
+// MirrorConfig specifies the mirroring of a sample of the read requests of the API to a secondary deployment, e.g.
+// a staging deployment of a new release, to validate it against real traffic before promoting it.
+type MirrorConfig struct {
+	// URL is the base URL of the deployment the requests are mirrored to. Requests are not mirrored if empty.
+	URL string
+	// APIKey authenticates the mirrored requests at the deployment, whose API keys are not those of the clients.
+	// It may be a secret reference.
+	APIKey string
+	// SampleRate is the fraction of the read requests which are mirrored.
+	SampleRate float64
+	// Timeout is how long a mirrored request may take.
+	Timeout time.Duration
+	// MaxPending is the maximum number of mirrored requests which were not sent yet. Requests are not mirrored
+	// while the limit is reached.
+	MaxPending int
+}
+
+// Enabled returns whether requests are mirrored.
+func (c *MirrorConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+func (c *MirrorConfig) Validate() error {
+	var errorMessages []string
+	if c.URL != "" {
+		if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("url %q is not an http or https URL", c.URL))
+		}
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		errorMessages = append(errorMessages, "sample rate must be between 0 and 1")
+	}
+	if c.Timeout <= 0 {
+		errorMessages = append(errorMessages, "timeout must be positive")
+	}
+	if c.MaxPending <= 0 {
+		errorMessages = append(errorMessages, "max pending must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("mirror config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	mirrorURL := stringSchema("Base URL of the deployment a sample of the read requests is mirrored to, e.g. a " +
+		"staging deployment. Requests are not mirrored if it is not set.")
+	mirrorURL.Examples = []any{"https://yhs-staging.example.com"}
+	minRate, maxRate := 0, 1
+	sampleRate := numberSchema("Fraction of the read requests which are mirrored.")
+	sampleRate.Minimum, sampleRate.Maximum = &minRate, &maxRate
+	sampleRate.Default = defaultMirrorSampleRate
+	timeout := durationSchema("How long a mirrored request may take.")
+	timeout.Default = defaultMirrorTimeout.String()
+	maxPending := intSchema("Maximum number of mirrored requests which were not sent yet. Requests are not " +
+		"mirrored while the limit is reached.")
+	maxPending.Default = defaultMirrorMaxPending
+	schema := objectSchema("Mirroring of a sample of the read requests of the API to a secondary deployment, e.g. "+
+		"a staging deployment of a new release, whose responses are ignored.", map[string]*Schema{
+		"url": mirrorURL,
+		"api_key": stringSchema("API key of the mirrored requests at the deployment, which may be a secret " +
+			"reference. The API keys of the clients are not mirrored."),
+		"sample_rate": sampleRate,
+		"timeout":     timeout,
+		"max_pending": maxPending,
+	})
+	registerSection("mirror", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.MirrorConfig = MirrorConfig{
+			URL:        k.String("mirror_url"),
+			APIKey:     k.String("mirror_api_key"),
+			SampleRate: defaultMirrorSampleRate,
+			Timeout:    defaultMirrorTimeout,
+			MaxPending: defaultMirrorMaxPending,
+		}
+		if k.Exists("mirror_sample_rate") {
+			cfg.MirrorConfig.SampleRate = k.Float64("mirror_sample_rate")
+		}
+		if k.Exists("mirror_timeout") {
+			cfg.MirrorConfig.Timeout = k.Duration("mirror_timeout")
+		}
+		if k.Exists("mirror_max_pending") {
+			cfg.MirrorConfig.MaxPending = k.Int("mirror_max_pending")
+		}
+		return cfg.MirrorConfig.Validate()
+	})
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// UngroupedNodeGroup is the name of the group of the nodes which are not selected by any node group.
+const UngroupedNodeGroup = "ungrouped"
+
+// NodeGroupsConfig specifies the groups of nodes, such as node pools, whose utilization is aggregated.
+type NodeGroupsConfig struct {
+	// Groups are the node groups. A node belongs to every group whose selector it matches.
+	Groups []NodeGroup
+}
+
+// NodeGroup is a named group of the nodes selected by their labels.
+type NodeGroup struct {
+	// Name identifies the group in responses, e.g. gpu-pool.
+	Name string
+	// Selector maps the labels to the values a node must have to belong to the group. Labels are matched against
+	// the attributes of the nodes reported by Yunikorn, e.g. si/instance-type.
+	Selector map[string]string
+}
+
+// Matches returns whether the labels match all the labels of the selector of the group.
+func (g NodeGroup) Matches(labels map[string]string) bool {
+	for label, value := range g.Selector {
+		if v, ok := labels[label]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *NodeGroupsConfig) Validate() error {
+	var errorMessages []string
+	names := make(map[string]bool, len(c.Groups))
+	for i, g := range c.Groups {
+		switch {
+		case g.Name == "":
+			errorMessages = append(errorMessages, fmt.Sprintf("node group %d: name is required", i))
+		case g.Name == UngroupedNodeGroup:
+			errorMessages = append(errorMessages, fmt.Sprintf("node group %d: name %q is reserved", i, g.Name))
+		case names[g.Name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("node group %d: duplicate name %q", i, g.Name))
+		}
+		names[g.Name] = true
+		if len(g.Selector) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("node group %d: selector is required", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("node groups config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+// parseLabelSelector parses a selector of comma separated label=value pairs, e.g.
+// si/instance-type=m5.xlarge,topology.kubernetes.io/zone=eu-west-1a.
+func parseLabelSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, requirement := range strings.Split(selector, ",") {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			continue
+		}
+		label, value, ok := strings.Cut(requirement, "=")
+		label = strings.TrimSpace(label)
+		if !ok || label == "" {
+			return nil, fmt.Errorf("invalid requirement %q, expected label=value", requirement)
+		}
+		if _, ok := labels[label]; ok {
+			return nil, fmt.Errorf("duplicate label %q", label)
+		}
+		labels[label] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
+
+func init() {
+	group := objectSchema("Group of the nodes selected by their labels.", map[string]*Schema{
+		"name": stringSchema("Name identifying the group in responses, e.g. gpu-pool. ungrouped is reserved for the " +
+			"nodes which are not in any group."),
+		"selector": {
+			Type: "string",
+			Description: "Comma separated label=value pairs a node must all match to be in the group. Labels are " +
+				"matched against the attributes of the nodes reported by Yunikorn.",
+			Examples: []any{"si/instance-type=m5.xlarge"},
+		},
+	})
+	schema := objectSchema("Groups of nodes, such as node pools, whose utilization and allocations are aggregated.",
+		map[string]*Schema{
+			"groups": {
+				Type:        "array",
+				Description: "Node groups. A node is in every group whose selector it matches.",
+				Items:       group,
+			},
+		})
+	registerSection("node_groups", schema, func(k *koanf.Koanf, cfg *Config) error {
+		var groups []NodeGroup
+		for i, g := range k.Slices("node_groups_groups") {
+			selector, err := parseLabelSelector(g.String("selector"))
+			if err != nil {
+				return fmt.Errorf("node group %d: invalid selector: %v", i, err)
+			}
+			groups = append(groups, NodeGroup{Name: g.String("name"), Selector: selector})
+		}
+		cfg.NodeGroupsConfig = NodeGroupsConfig{Groups: groups}
+		return cfg.NodeGroupsConfig.Validate()
+	})
+}
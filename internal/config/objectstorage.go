@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// ObjectStorageConfig specifies a location in object storage: a bucket of AWS S3, of an S3 compatible storage like
+// MinIO or Ceph, or of Google Cloud Storage, or a local directory.
+type ObjectStorageConfig struct {
+	// URL is the location: s3://bucket/prefix, gs://bucket/prefix or file:///path.
+	URL string
+	// Endpoint overrides the endpoint of the object storage, e.g. of an S3 compatible storage like MinIO.
+	Endpoint string
+	// Region is the region of the S3 bucket. If empty, the region of the AWS configuration is used, or us-east-1
+	// for S3 compatible storages.
+	Region string
+	// PathStyle addresses S3 buckets in the path of the URLs instead of the host.
+	PathStyle bool
+	// AccessKeyID and SecretAccessKey are the static credentials of S3 buckets, e.g. of S3 compatible storages
+	// outside of AWS. If empty, credentials are loaded from the default AWS credential chain.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// validate returns the validation errors of the location, whose URL must have one of the schemes.
+func (c *ObjectStorageConfig) validate(schemes ...string) []string {
+	var errorMessages []string
+	u, err := url.Parse(c.URL)
+	switch {
+	case err != nil:
+		errorMessages = append(errorMessages, fmt.Sprintf("url %q is invalid: %v", c.URL, err))
+	case !slices.Contains(schemes, u.Scheme):
+		errorMessages = append(errorMessages, fmt.Sprintf("url %q is not an %s URL", c.URL, orList(schemes)))
+	case u.Scheme == "file":
+		if u.Path == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("url %q has no path", c.URL))
+		}
+	default:
+		if u.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("url %q has no bucket", c.URL))
+		}
+	}
+	if c.Endpoint != "" {
+		if u, err := url.Parse(c.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("endpoint %q is not an http or https URL", c.Endpoint))
+		}
+	}
+	if (c.AccessKeyID == "") != (c.SecretAccessKey == "") {
+		errorMessages = append(errorMessages, "access key id and secret access key must be set together")
+	}
+	return errorMessages
+}
+
+// orList formats the values as "a, b or c".
+func orList(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
+
+// objectStorageSchemas returns the schemas of the options of a location in object storage, in addition to its url.
+func objectStorageSchemas(location *Schema) map[string]*Schema {
+	endpoint := stringSchema("Endpoint of the object storage, e.g. of an S3 compatible storage like MinIO or Ceph. " +
+		"Defaults to the endpoint of AWS S3 or Google Cloud Storage.")
+	endpoint.Examples = []any{"http://minio:9000"}
+	return map[string]*Schema{
+		"url":      location,
+		"endpoint": endpoint,
+		"region": stringSchema("Region of the S3 bucket. Defaults to the region of the AWS configuration, or to " +
+			"us-east-1 if an endpoint is set."),
+		"path_style": boolSchema("Whether S3 buckets are addressed in the path of the URLs instead of the host, as " +
+			"most S3 compatible storages require."),
+		"access_key_id": stringSchema("Access key ID of the S3 bucket, e.g. of an S3 compatible storage. If empty, " +
+			"credentials are loaded from the default AWS credential chain, and those of Google Cloud Storage from " +
+			"the Google application default credentials."),
+		"secret_access_key": stringSchema("Secret access key of the S3 bucket, or a secret reference, preferably " +
+			"provided via an environment variable."),
+	}
+}
+
+// loadObjectStorage loads the options of a location in object storage of the section.
+func loadObjectStorage(k *koanf.Koanf, section string) ObjectStorageConfig {
+	return ObjectStorageConfig{
+		URL:             k.String(section + "_url"),
+		Endpoint:        k.String(section + "_endpoint"),
+		Region:          k.String(section + "_region"),
+		PathStyle:       k.Bool(section + "_path_style"),
+		AccessKeyID:     k.String(section + "_access_key_id"),
+		SecretAccessKey: k.String(section + "_secret_access_key"),
+	}
+}
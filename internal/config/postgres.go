@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	// DBAuthMethodPassword authenticates with the configured password.
+	DBAuthMethodPassword = "password"
+	// DBAuthMethodAWSIAM authenticates with IAM database authentication tokens of AWS RDS.
+	DBAuthMethodAWSIAM = "aws_iam"
+	// DBAuthMethodGCPIAM authenticates with IAM database authentication of GCP Cloud SQL.
+	DBAuthMethodGCPIAM = "gcp_iam"
+)
+
+type PostgresConfig struct {
+	Host                string
+	DbName              string
+	Username            string
+	Password            string
+	PoolMaxConnLifetime time.Duration
+	PoolMaxConnIdleTime time.Duration
+	Port                int
+	PoolMaxConns        int
+	PoolMinConns        int
+	SSLMode             string
+	Schema              string
+	AuthMethod          string
+	AWSRegion           string
+	// StatementTimeouts limit the duration of the statements of the classes of queries.
+	StatementTimeouts StatementTimeouts
+}
+
+// StatementTimeouts are the statement timeouts of the classes of queries, so that long analytics queries are
+// cancelled before they hold connections and locks needed by the ingestion of events and the retention policies,
+// which are not limited. A timeout of 0 keeps the statement timeout of the database.
+type StatementTimeouts struct {
+	// Interactive limits the queries of the API requests of the UI and clients.
+	Interactive time.Duration
+	// Analytics limits the queries of the analytics and reports endpoints.
+	Analytics time.Duration
+	// Export limits the queries of responses streamed as newline delimited JSON.
+	Export time.Duration
+}
+
+func (t *StatementTimeouts) Validate() error {
+	var errorMessages []string
+	for name, timeout := range map[string]time.Duration{
+		"interactive": t.Interactive,
+		"analytics":   t.Analytics,
+		"export":      t.Export,
+	} {
+		if timeout != 0 && timeout < time.Millisecond {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s statement timeout must be 0 or at least 1ms", name))
+		}
+	}
+	if len(errorMessages) > 0 {
+		sort.Strings(errorMessages)
+		return fmt.Errorf("statement timeout validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+// IAMAuth reports whether the database is accessed with short-lived IAM tokens instead of a password.
+func (c *PostgresConfig) IAMAuth() bool {
+	return c.AuthMethod == DBAuthMethodAWSIAM || c.AuthMethod == DBAuthMethodGCPIAM
+}
+
+func (c *PostgresConfig) Validate() error {
+	var errorMessages []string
+	if c.Host == "" {
+		errorMessages = append(errorMessages, "db host is required")
+	}
+	if c.DbName == "" {
+		errorMessages = append(errorMessages, "db name is required")
+	}
+	if c.Username == "" {
+		errorMessages = append(errorMessages, "db user is required")
+	}
+	if c.Password == "" && !c.IAMAuth() {
+		errorMessages = append(errorMessages, "db password is required")
+	}
+	if c.Port < 1 {
+		errorMessages = append(errorMessages, "db port is required")
+	}
+	switch c.AuthMethod {
+	case "", DBAuthMethodPassword, DBAuthMethodAWSIAM, DBAuthMethodGCPIAM:
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid db auth method: %s", c.AuthMethod))
+	}
+	if err := c.StatementTimeouts.Validate(); err != nil {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("postgres config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	schema := objectSchema("Configuration of the Postgres database.", map[string]*Schema{
+		"host":                   stringSchema("Host of the database server."),
+		"port":                   portSchema("Port of the database server."),
+		"dbname":                 stringSchema("Name of the database."),
+		"user":                   stringSchema("User used to connect to the database."),
+		"password":               stringSchema("Password used to connect to the database, preferably provided via YHS_DB_PASSWORD."),
+		"sslmode":                {Type: "string", Description: "SSL mode of the connection.", Enum: []any{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}},
+		"schema":                 stringSchema("Schema in which the tables are stored."),
+		"pool_max_conns":         intSchema("Maximum number of connections in the pool, 0 uses the driver default."),
+		"pool_min_conns":         intSchema("Minimum number of connections in the pool."),
+		"pool_max_conn_lifetime": durationSchema("Maximum lifetime of a connection in the pool."),
+		"pool_max_conn_idletime": durationSchema("Maximum idle time of a connection in the pool."),
+		"auth_method": {
+			Type:        "string",
+			Description: "Authentication method. The IAM methods use short-lived tokens, which are refreshed for new connections.",
+			Enum:        []any{DBAuthMethodPassword, DBAuthMethodAWSIAM, DBAuthMethodGCPIAM},
+			Default:     DBAuthMethodPassword,
+		},
+		"aws_region": stringSchema("Region of the RDS instance. If empty, the region of the default AWS configuration is used."),
+		"statement_timeout": objectSchema("Statement timeouts of the classes of queries, after which Postgres cancels "+
+			"their statements. Writes, e.g. of the ingestion of events and the retention policies, are not limited. "+
+			"A timeout of 0 keeps the statement timeout of the database.", map[string]*Schema{
+			"interactive": durationSchema("Statement timeout of the queries of the API requests of the UI and clients."),
+			"analytics":   durationSchema("Statement timeout of the queries of the analytics and reports endpoints."),
+			"export":      durationSchema("Statement timeout of the queries of responses streamed as newline delimited JSON."),
+		}),
+	})
+	registerSection("db", schema, func(k *koanf.Koanf, cfg *Config) error {
+		authMethod := k.String("db_auth_method")
+		if authMethod == "" {
+			authMethod = DBAuthMethodPassword
+		}
+		cfg.PostgresConfig = PostgresConfig{
+			Host:                k.String("db_host"),
+			Port:                k.Int("db_port"),
+			Username:            k.String("db_user"),
+			Password:            k.String("db_password"),
+			DbName:              k.String("db_dbname"),
+			SSLMode:             k.String("db_sslmode"),
+			Schema:              k.String("db_schema"),
+			PoolMaxConnLifetime: k.Duration("db_pool_max_conn_lifetime"),
+			PoolMaxConnIdleTime: k.Duration("db_pool_max_conn_idletime"),
+			PoolMaxConns:        k.Int("db_pool_max_conns"),
+			PoolMinConns:        k.Int("db_pool_min_conns"),
+			AuthMethod:          authMethod,
+			AWSRegion:           k.String("db_aws_region"),
+			StatementTimeouts: StatementTimeouts{
+				Interactive: k.Duration("db_statement_timeout_interactive"),
+				Analytics:   k.Duration("db_statement_timeout_analytics"),
+				Export:      k.Duration("db_statement_timeout_export"),
+			},
+		}
+		return cfg.PostgresConfig.StatementTimeouts.Validate()
+	})
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/knadh/koanf/v2"
+)
+
+// PriorityConfig specifies how the priority classes of applications are recorded.
+type PriorityConfig struct {
+	// ClassTag is the allocation tag the priority class of applications is taken from. The YuniKorn k8shim does not
+	// tag the priority class of pods, so it is only recorded if the pods carry it in a label, e.g.
+	// kubernetes.io/label/priority-class. The numeric priority of applications is always recorded.
+	ClassTag string
+}
+
+func init() {
+	classTag := stringSchema("Allocation tag the priority class of applications is taken from, e.g. " +
+		"kubernetes.io/label/priority-class. Priority classes are not recorded if it is not set.")
+	schema := objectSchema("Recording of the priority classes of applications.", map[string]*Schema{
+		"class_tag": classTag,
+	})
+	registerSection("priority", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.PriorityConfig = PriorityConfig{
+			ClassTag: k.String("priority_class_tag"),
+		}
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// QuotasConfig specifies the daily quotas of the API clients on the expensive endpoints, the analytics and export
+// endpoints, so that a single client cannot overload the shared database. Quotas apply to the clients of API keys,
+// and reset at midnight UTC.
+type QuotasConfig struct {
+	// DailyRequests is the number of requests each client can make per day. Zero means unlimited.
+	DailyRequests int
+	// DailyQueryTime is the time the database can spend on the queries of the requests of each client per day.
+	// Zero means unlimited.
+	DailyQueryTime time.Duration
+	// Overrides override the quotas of clients.
+	Overrides []QuotaOverride
+}
+
+// QuotaOverride overrides the quotas of a client.
+type QuotaOverride struct {
+	// Client is the name of the API key of the client.
+	Client string
+	// DailyRequests is the number of requests the client can make per day. Zero means unlimited.
+	DailyRequests int
+	// DailyQueryTime is the time the database can spend on the queries of the client per day. Zero means unlimited.
+	DailyQueryTime time.Duration
+}
+
+// Enabled returns whether any client has a quota.
+func (c *QuotasConfig) Enabled() bool {
+	if c.DailyRequests > 0 || c.DailyQueryTime > 0 {
+		return true
+	}
+	for _, o := range c.Overrides {
+		if o.DailyRequests > 0 || o.DailyQueryTime > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *QuotasConfig) Validate() error {
+	var errorMessages []string
+	if c.DailyRequests < 0 {
+		errorMessages = append(errorMessages, "daily requests must not be negative")
+	}
+	if c.DailyQueryTime < 0 {
+		errorMessages = append(errorMessages, "daily query time must not be negative")
+	}
+	clients := make(map[string]bool, len(c.Overrides))
+	for i, o := range c.Overrides {
+		if o.Client == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("override %d: client is required", i))
+		} else if clients[o.Client] {
+			errorMessages = append(errorMessages, fmt.Sprintf("override %d: duplicate client %s", i, o.Client))
+		}
+		clients[o.Client] = true
+		if o.DailyRequests < 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("override %d: daily requests must not be negative", i))
+		}
+		if o.DailyQueryTime < 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("override %d: daily query time must not be negative", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("quotas config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	override := objectSchema("Quotas of a client. Quotas which are not set are the ones of all clients.", map[string]*Schema{
+		"client":           stringSchema("Name of the API key of the client."),
+		"daily_requests":   intSchema("Number of requests the client can make per day. 0 is unlimited."),
+		"daily_query_time": durationSchema("Time the database can spend on the queries of the client per day. 0 is unlimited."),
+	})
+	schema := objectSchema("Configuration of the daily quotas of the API clients on the analytics and export endpoints. "+
+		"Quotas apply to the clients of API keys, are tracked by each replica, and reset at midnight UTC.", map[string]*Schema{
+		"daily_requests": intSchema("Number of requests each client can make per day. 0 is unlimited."),
+		"daily_query_time": durationSchema("Time the database can spend on the queries of the requests of each client " +
+			"per day, which bounds the data they scan. 0 is unlimited."),
+		"overrides": {
+			Type:        "array",
+			Description: "Quotas of clients which differ from the ones of all clients.",
+			Items:       override,
+		},
+	})
+	registerSection("quotas", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.QuotasConfig = QuotasConfig{
+			DailyRequests:  k.Int("quotas_daily_requests"),
+			DailyQueryTime: k.Duration("quotas_daily_query_time"),
+		}
+		for _, o := range k.Slices("quotas_overrides") {
+			override := QuotaOverride{
+				Client:         o.String("client"),
+				DailyRequests:  cfg.QuotasConfig.DailyRequests,
+				DailyQueryTime: cfg.QuotasConfig.DailyQueryTime,
+			}
+			if o.Exists("daily_requests") {
+				override.DailyRequests = o.Int("daily_requests")
+			}
+			if o.Exists("daily_query_time") {
+				override.DailyQueryTime = o.Duration("daily_query_time")
+			}
+			cfg.QuotasConfig.Overrides = append(cfg.QuotasConfig.Overrides, override)
+		}
+		return cfg.QuotasConfig.Validate()
+	})
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const defaultRetentionBatchSize = 1000
+
+// RetentionConfig specifies how long finished applications and the applications and containers history are kept
+// before they are pruned.
+type RetentionConfig struct {
+	// Interval is the interval at which finished applications and the history are pruned.
+	Interval time.Duration
+	// ApplicationTTL is the duration finished applications are kept for, unless overridden for their queue.
+	// Zero means they are kept forever.
+	ApplicationTTL time.Duration
+	// HistoryTTL is the duration the entries of the applications and containers history are kept for.
+	// Zero means they are kept forever.
+	HistoryTTL time.Duration
+	// BatchSize is the number of rows deleted at once, so that the tables are not locked for long.
+	BatchSize int
+	// Overrides override the application TTL for queue subtrees.
+	Overrides []RetentionOverride
+}
+
+// RetentionOverride overrides the application TTL for a queue subtree.
+type RetentionOverride struct {
+	// Partition the override applies to. Empty matches all partitions.
+	Partition string
+	// Queue is the root of the queue subtree the override applies to, e.g. root.compliance.
+	Queue string
+	// ApplicationTTL is the duration finished applications in the subtree are kept for. Zero means they are kept forever.
+	ApplicationTTL time.Duration
+}
+
+func (c *RetentionConfig) Validate() error {
+	var errorMessages []string
+	if c.ApplicationTTL < 0 {
+		errorMessages = append(errorMessages, "application TTL must not be negative")
+	}
+	if c.HistoryTTL < 0 {
+		errorMessages = append(errorMessages, "history TTL must not be negative")
+	}
+	if c.BatchSize < 1 {
+		errorMessages = append(errorMessages, "batch size must be positive")
+	}
+	for i, o := range c.Overrides {
+		if o.Queue == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("override %d: queue is required", i))
+		}
+		if o.ApplicationTTL < 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("override %d: application TTL must not be negative", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("retention config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	interval := durationSchema("Interval at which finished applications and the history are pruned, " +
+		"unless the schedule of the retention job is set in jobs.schedules.")
+	interval.Default = "1h"
+	applicationTTL := durationSchema("Duration finished applications are kept for, unless overridden for their queue. " +
+		"0 keeps them forever.")
+	applicationTTL.Default = "0s"
+	historyTTL := durationSchema("Duration the entries of the applications and containers history are kept for. " +
+		"0 keeps them forever.")
+	historyTTL.Default = "0s"
+	minBatchSize := 1
+	batchSize := intSchema("Number of rows deleted at once, so that the tables are not locked for long under load.")
+	batchSize.Minimum = &minBatchSize
+	batchSize.Default = defaultRetentionBatchSize
+	override := objectSchema("Application TTL of a queue subtree.", map[string]*Schema{
+		"partition":       stringSchema("Partition the override applies to. Empty matches all partitions."),
+		"queue":           stringSchema("Root of the queue subtree the override applies to, e.g. root.compliance."),
+		"application_ttl": durationSchema("Duration finished applications in the subtree are kept for. 0 keeps them forever."),
+	})
+	schema := objectSchema("Configuration of the pruning of finished applications and of the history.", map[string]*Schema{
+		"interval":        interval,
+		"application_ttl": applicationTTL,
+		"history_ttl":     historyTTL,
+		"batch_size":      batchSize,
+		"overrides": {
+			Type:        "array",
+			Description: "Application TTL overrides for queue subtrees. The most specific queue takes precedence.",
+			Items:       override,
+		},
+	})
+	registerSection("retention", schema, func(k *koanf.Koanf, cfg *Config) error {
+		interval := k.Duration("retention_interval")
+		if interval == 0 {
+			interval = time.Hour
+		}
+		var overrides []RetentionOverride
+		for _, o := range k.Slices("retention_overrides") {
+			overrides = append(overrides, RetentionOverride{
+				Partition:      o.String("partition"),
+				Queue:          o.String("queue"),
+				ApplicationTTL: o.Duration("application_ttl"),
+			})
+		}
+		cfg.RetentionConfig = RetentionConfig{
+			Interval:       interval,
+			ApplicationTTL: k.Duration("retention_application_ttl"),
+			HistoryTTL:     k.Duration("retention_history_ttl"),
+			BatchSize:      defaultRetentionBatchSize,
+			Overrides:      overrides,
+		}
+		if k.Exists("retention_batch_size") {
+			cfg.RetentionConfig.BatchSize = k.Int("retention_batch_size")
+		}
+		return cfg.RetentionConfig.Validate()
+	})
+}
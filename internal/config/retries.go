@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultRetryKeyTags are the allocation tags the retry key of applications is taken from by default: the DAG, task
+// and run labels Airflow adds to the pods of a task instance, which are the same for every try of the task, and the
+// label the Spark operator adds to the pods of a SparkApplication, which are resubmitted by its restart policy.
+var DefaultRetryKeyTags = []string{
+	"kubernetes.io/label/dag_id+kubernetes.io/label/task_id+kubernetes.io/label/run_id",
+	"kubernetes.io/label/sparkoperator.k8s.io/app-name",
+}
+
+// RetriesConfig specifies how the retried submissions of a job are linked into retry chains.
+type RetriesConfig struct {
+	// KeyTags are the allocation tags the retry key of applications is taken from. The first tag found is used.
+	// A tag can combine several tags with +, whose values are joined with : and must all be present.
+	// The applications of a partition with the same retry key are attempts of the same job.
+	KeyTags []string
+}
+
+func (c *RetriesConfig) Validate() error {
+	var errorMessages []string
+	for i, tag := range c.KeyTags {
+		for _, t := range strings.Split(tag, "+") {
+			if strings.TrimSpace(t) == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("key tag %d: %q has an empty tag", i, tag))
+				break
+			}
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("retries config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	keyTags := stringListSchema("Allocation tags the retry key of applications is taken from, the first tag found " +
+		"is used. The applications of a partition with the same retry key are attempts of the same job. Several tags " +
+		"can be combined with +, e.g. kubernetes.io/label/dag_id+kubernetes.io/label/task_id, their values are " +
+		"joined with :. An empty list disables the retry chains.")
+	keyTags.Default = DefaultRetryKeyTags
+	schema := objectSchema("Linking of the retried submissions of a job into retry chains.", map[string]*Schema{
+		"key_tags": keyTags,
+	})
+	registerSection("retries", schema, func(k *koanf.Koanf, cfg *Config) error {
+		keyTags := DefaultRetryKeyTags
+		if k.Exists("retries_key_tags") {
+			keyTags = k.Strings("retries_key_tags")
+		}
+		cfg.RetriesConfig = RetriesConfig{KeyTags: keyTags}
+		return cfg.RetriesConfig.Validate()
+	})
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// Schema is a JSON Schema describing a part of the configuration file.
+// Only the subset of JSON Schema needed to describe the configuration is supported.
+type Schema struct {
+	Type                 any                `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Examples             []any              `json:"examples,omitempty"`
+}
+
+func stringSchema(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+func intSchema(description string) *Schema {
+	return &Schema{Type: "integer", Description: description}
+}
+
+func numberSchema(description string) *Schema {
+	return &Schema{Type: "number", Description: description}
+}
+
+func boolSchema(description string) *Schema {
+	return &Schema{Type: "boolean", Description: description}
+}
+
+func portSchema(description string) *Schema {
+	minPort, maxPort := 1, 65535
+	return &Schema{Type: "integer", Description: description, Minimum: &minPort, Maximum: &maxPort}
+}
+
+// durationSchema describes a duration, which can be provided as a string such as "30s" or "5m",
+// or as an integer number of nanoseconds.
+func durationSchema(description string) *Schema {
+	return &Schema{
+		Type:        []string{"string", "integer"},
+		Description: description,
+		Pattern:     `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`,
+	}
+}
+
+func stringListSchema(description string) *Schema {
+	return &Schema{Type: "array", Description: description, Items: &Schema{Type: "string"}}
+}
+
+// objectSchema describes an object with the given properties, rejecting unknown properties
+// so that typos in the configuration file are detected.
+func objectSchema(description string, properties map[string]*Schema) *Schema {
+	return &Schema{Type: "object", Description: description, Properties: properties, AdditionalProperties: false}
+}
+
+// mapSchema describes an object with arbitrary keys whose values match the given schema.
+func mapSchema(description string, values *Schema) *Schema {
+	return &Schema{Type: "object", Description: description, AdditionalProperties: values}
+}
+
+// section is a top-level section of the configuration file, e.g. "db".
+// Each subsystem registers its section, so that it is loaded by New and described by JSONSchema.
+type section struct {
+	// key is the top-level key of the section in the configuration file.
+	key string
+	// schema describes the section.
+	schema *Schema
+	// load loads the section from the configuration into cfg.
+	load func(k *koanf.Koanf, cfg *Config) error
+}
+
+var sections []section
+
+// registerSection registers a top-level section of the configuration file.
+// Sections are loaded in the order in which they are registered.
+func registerSection(key string, schema *Schema, load func(k *koanf.Koanf, cfg *Config) error) {
+	for _, s := range sections {
+		if s.key == key {
+			panic("config section already registered: " + key)
+		}
+	}
+	sections = append(sections, section{key: key, schema: schema, load: load})
+}
+
+// JSONSchema returns the JSON Schema of the configuration file.
+func JSONSchema() ([]byte, error) {
+	properties := make(map[string]*Schema, len(sections))
+	for _, s := range sections {
+		properties[s.key] = s.schema
+	}
+	root := struct {
+		SchemaURI string `json:"$schema"`
+		Title     string `json:"title"`
+		*Schema
+	}{
+		SchemaURI: "https://json-schema.org/draft/2020-12/schema",
+		Title:     "Yunikorn History Server configuration",
+		Schema: objectSchema(
+			"Configuration file of the Yunikorn History Server. "+
+				"Every option can be overridden by an environment variable prefixed with YHS_, "+
+				"e.g. db.pool_max_conns can be set with YHS_DB_POOL_MAX_CONNS.",
+			properties,
+		),
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const sampleConfigDir = "../../config/yunikorn-history-server"
+
+func TestJSONSchemaUpToDate(t *testing.T) {
+	schema, err := JSONSchema()
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile(filepath.Join(sampleConfigDir, "config.schema.json"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(committed), string(schema), "config.schema.json is outdated, run 'make config-schema'")
+}
+
+func TestConfigFilesMatchSchema(t *testing.T) {
+	schemaJSON, err := JSONSchema()
+	require.NoError(t, err)
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(schemaJSON, &schema))
+
+	files, err := filepath.Glob(filepath.Join(sampleConfigDir, "*.yml"))
+	require.NoError(t, err)
+	files = append(files, filepath.Join("testdata", "config.yml"))
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var cfg map[string]any
+			require.NoError(t, yaml.Unmarshal(data, &cfg))
+
+			assert.Empty(t, validate(schema, cfg, ""))
+		})
+	}
+
+	t.Run("invalid config", func(t *testing.T) {
+		cfg := map[string]any{
+			"db":       map[string]any{"pool_max_conn_idle_time": "120s", "port": "5432"},
+			"features": map[string]any{"flags": map[string]any{"analytics": "yes"}},
+			"unknown":  true,
+		}
+		want := []string{
+			"/db/pool_max_conn_idle_time: unknown property",
+			"/db/port: expected integer, got string",
+			"/features/flags/analytics: expected boolean, got string",
+			"/unknown: unknown property",
+		}
+		assert.Equal(t, want, validate(schema, cfg, ""))
+	})
+}
+
+// validate returns the violations of the value against the schema. It supports the subset of JSON Schema
+// generated by JSONSchema, which is sufficient to detect unknown keys and wrongly typed values.
+func validate(schema map[string]any, value any, path string) []string {
+	var violations []string
+	if !matchesType(schema["type"], value) {
+		return []string{fmt.Sprintf("%s: expected %v, got %s", path, schema["type"], typeOf(value))}
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if s, ok := value.(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			violations = append(violations, fmt.Sprintf("%s: %q does not match %s", path, s, pattern))
+		}
+	}
+	if list, ok := value.([]any); ok {
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range list {
+				violations = append(violations, validate(items, item, fmt.Sprintf("%s/%d", path, i))...)
+			}
+		}
+		return violations
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return violations
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		keyPath := path + "/" + key
+		if property, ok := properties[key].(map[string]any); ok {
+			violations = append(violations, validate(property, object[key], keyPath)...)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case map[string]any:
+			violations = append(violations, validate(additional, object[key], keyPath)...)
+		case bool:
+			if !additional {
+				violations = append(violations, keyPath+": unknown property")
+			}
+		}
+	}
+	return violations
+}
+
+func matchesType(schemaType any, value any) bool {
+	switch t := schemaType.(type) {
+	case nil:
+		return true
+	case string:
+		return t == typeOf(value) || (t == "number" && typeOf(value) == "integer")
+	case []any:
+		for _, candidate := range t {
+			if matchesType(candidate, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
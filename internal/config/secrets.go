@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	SecretProviderVault = "vault"
+	SecretProviderAWS   = "aws"
+
+	// SecretReferencePrefix marks values which reference a secret as secret:<name>#<key>,
+	// and are resolved from the configured secret store.
+	SecretReferencePrefix = "secret:"
+)
+
+// IsSecretReference returns true if the value references a secret.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretReferencePrefix)
+}
+
+// SecretsConfig specifies the secret store from which secret references in the configuration are resolved.
+type SecretsConfig struct {
+	// Provider is the secret store, either vault or aws. If empty, secret references are not resolved.
+	Provider string
+	// RefreshInterval is the interval after which resolved secrets are fetched again, so that rotated
+	// database credentials are used by new connections.
+	RefreshInterval time.Duration
+	Vault           VaultConfig
+	AWS             AWSSecretsConfig
+}
+
+// VaultConfig specifies how secrets are read from the KV version 2 secrets engine of HashiCorp Vault.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault:8200.
+	Address string
+	// Mount is the mount path of the KV secrets engine.
+	Mount string
+	// Token authenticates the server. If empty, the server logs in with KubernetesRole.
+	Token string
+	// KubernetesRole is the role used to log in with the Kubernetes auth method.
+	KubernetesRole string
+	// KubernetesMount is the mount path of the Kubernetes auth method.
+	KubernetesMount string
+}
+
+// AWSSecretsConfig specifies how secrets are read from AWS Secrets Manager.
+// Credentials are loaded from the default credential chain.
+type AWSSecretsConfig struct {
+	// Region of the secrets. If empty, the region of the default configuration is used.
+	Region string
+}
+
+func (c *SecretsConfig) Validate() error {
+	var errorMessages []string
+	switch c.Provider {
+	case "", SecretProviderAWS:
+	case SecretProviderVault:
+		if c.Vault.Address == "" {
+			errorMessages = append(errorMessages, "vault address is required")
+		}
+		if c.Vault.Token == "" && c.Vault.KubernetesRole == "" {
+			errorMessages = append(errorMessages, "vault token or kubernetes role is required")
+		}
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("unknown secret provider %q", c.Provider))
+	}
+	if c.RefreshInterval < 0 {
+		errorMessages = append(errorMessages, "refresh interval must not be negative")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("secrets config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	refreshInterval := durationSchema("Interval after which resolved secrets are fetched again, " +
+		"so that rotated database credentials are used by new connections.")
+	refreshInterval.Default = "5m"
+	mount := stringSchema("Mount path of the KV version 2 secrets engine.")
+	mount.Default = "secret"
+	kubernetesMount := stringSchema("Mount path of the Kubernetes auth method.")
+	kubernetesMount.Default = "kubernetes"
+	schema := objectSchema("Configuration of the secret store from which secret:<name>#<key> references "+
+		"in the configuration are resolved.", map[string]*Schema{
+		"provider": {
+			Type:        "string",
+			Description: "Secret store. If empty, secret references are not resolved.",
+			Enum:        []any{"", SecretProviderVault, SecretProviderAWS},
+		},
+		"refresh_interval": refreshInterval,
+		"vault": objectSchema("Configuration of HashiCorp Vault.", map[string]*Schema{
+			"address": stringSchema("Address of the Vault server, e.g. https://vault:8200."),
+			"mount":   mount,
+			"token": stringSchema(
+				"Token, preferably provided via YHS_SECRETS_VAULT_TOKEN. If empty, the Kubernetes auth method is used.",
+			),
+			"kubernetes_role":  stringSchema("Role used to log in with the Kubernetes auth method."),
+			"kubernetes_mount": kubernetesMount,
+		}),
+		"aws": objectSchema("Configuration of AWS Secrets Manager. Credentials are loaded from the default credential chain.",
+			map[string]*Schema{
+				"region": stringSchema("Region of the secrets. If empty, the region of the default configuration is used."),
+			}),
+	})
+	registerSection("secrets", schema, func(k *koanf.Koanf, cfg *Config) error {
+		refreshInterval := k.Duration("secrets_refresh_interval")
+		if refreshInterval == 0 {
+			refreshInterval = 5 * time.Minute
+		}
+		mount := k.String("secrets_vault_mount")
+		if mount == "" {
+			mount = "secret"
+		}
+		kubernetesMount := k.String("secrets_vault_kubernetes_mount")
+		if kubernetesMount == "" {
+			kubernetesMount = "kubernetes"
+		}
+		cfg.SecretsConfig = SecretsConfig{
+			Provider:        k.String("secrets_provider"),
+			RefreshInterval: refreshInterval,
+			Vault: VaultConfig{
+				Address:         k.String("secrets_vault_address"),
+				Mount:           mount,
+				Token:           k.String("secrets_vault_token"),
+				KubernetesRole:  k.String("secrets_vault_kubernetes_role"),
+				KubernetesMount: kubernetesMount,
+			},
+			AWS: AWSSecretsConfig{
+				Region: k.String("secrets_aws_region"),
+			},
+		}
+		return cfg.SecretsConfig.Validate()
+	})
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// SLOConfig specifies the service level objectives of queues and how they are evaluated.
+type SLOConfig struct {
+	// Interval is the interval at which the SLOs are evaluated.
+	Interval time.Duration
+	// HistoryTTL is the duration the evaluations of the SLOs are kept for.
+	HistoryTTL time.Duration
+	// Objectives are the SLOs defined in the configuration file. More SLOs can be defined via the API.
+	Objectives []QueueSLO
+}
+
+// QueueSLO requires that at least the Objective share of the applications of a queue subtree submitted within the
+// Window start running within StartWithin of their submission.
+type QueueSLO struct {
+	// Name uniquely identifies the SLO.
+	Name string
+	// Partition the SLO applies to. Empty matches all partitions.
+	Partition string
+	// Queue is the root of the queue subtree the SLO applies to, e.g. root.analytics. Empty matches all queues.
+	Queue string
+	// Objective is the share of the applications which must start in time, between 0 and 1, e.g. 0.95.
+	Objective float64
+	// StartWithin is the time after their submission within which applications must start running.
+	StartWithin time.Duration
+	// Window is the sliding window of the submission of the applications the SLO is evaluated against.
+	Window time.Duration
+}
+
+func (c *SLOConfig) Validate() error {
+	var errorMessages []string
+	if c.HistoryTTL <= 0 {
+		errorMessages = append(errorMessages, "history TTL must be greater than zero")
+	}
+	names := make(map[string]bool, len(c.Objectives))
+	for i, o := range c.Objectives {
+		switch {
+		case o.Name == "":
+			errorMessages = append(errorMessages, fmt.Sprintf("objective %d: name is required", i))
+		case names[o.Name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("objective %d: duplicate name %q", i, o.Name))
+		}
+		names[o.Name] = true
+		if o.Objective <= 0 || o.Objective > 1 {
+			errorMessages = append(errorMessages, fmt.Sprintf("objective %d: objective must be in (0, 1]", i))
+		}
+		if o.StartWithin <= 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("objective %d: start within must be greater than zero", i))
+		}
+		if o.Window <= 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("objective %d: window must be greater than zero", i))
+		}
+		if o.Queue != "" && slices.Contains(strings.Split(o.Queue, "."), "") {
+			errorMessages = append(errorMessages, fmt.Sprintf("objective %d: invalid queue name %q", i, o.Queue))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("slo config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	interval := durationSchema("Interval at which the SLOs are evaluated, " +
+		"unless the schedule of the slo job is set in jobs.schedules.")
+	interval.Default = "5m"
+	historyTTL := durationSchema("Duration the evaluations of the SLOs are kept for.")
+	historyTTL.Default = "2160h"
+	window := durationSchema("Sliding window of the submission of the applications the SLO is evaluated against.")
+	window.Default = "24h"
+	objective := objectSchema("SLO of a queue subtree: the share of its applications which must start running "+
+		"within a time of their submission.", map[string]*Schema{
+		"name":         stringSchema("Name uniquely identifying the SLO."),
+		"partition":    stringSchema("Partition the SLO applies to. Empty matches all partitions."),
+		"queue":        stringSchema("Root of the queue subtree the SLO applies to, e.g. root.analytics. Empty matches all queues."),
+		"objective":    numberSchema("Share of the applications which must start in time, between 0 and 1, e.g. 0.95."),
+		"start_within": durationSchema("Time after their submission within which applications must start running."),
+		"window":       window,
+	})
+	schema := objectSchema("Service level objectives of queues, which are evaluated continuously against the stored "+
+		"applications.", map[string]*Schema{
+		"interval":    interval,
+		"history_ttl": historyTTL,
+		"objectives": {
+			Type:        "array",
+			Description: "SLOs defined in the configuration file. More SLOs can be defined via the API.",
+			Items:       objective,
+		},
+	})
+	registerSection("slo", schema, func(k *koanf.Koanf, cfg *Config) error {
+		interval := k.Duration("slo_interval")
+		if interval == 0 {
+			interval = 5 * time.Minute
+		}
+		historyTTL := k.Duration("slo_history_ttl")
+		if historyTTL == 0 {
+			historyTTL = 90 * 24 * time.Hour
+		}
+		var objectives []QueueSLO
+		for _, o := range k.Slices("slo_objectives") {
+			window := o.Duration("window")
+			if window == 0 {
+				window = 24 * time.Hour
+			}
+			objectives = append(objectives, QueueSLO{
+				Name:        o.String("name"),
+				Partition:   o.String("partition"),
+				Queue:       o.String("queue"),
+				Objective:   o.Float64("objective"),
+				StartWithin: o.Duration("start_within"),
+				Window:      window,
+			})
+		}
+		cfg.SLOConfig = SLOConfig{
+			Interval:   interval,
+			HistoryTTL: historyTTL,
+			Objectives: objectives,
+		}
+		return cfg.SLOConfig.Validate()
+	})
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultSnapshotsTTL     = time.Minute
+	defaultSnapshotsMaxOpen = 4
+)
+
+// SnapshotsConfig specifies the database snapshots held open for clients composing several requests, so that they
+// read a consistent view of the data.
+type SnapshotsConfig struct {
+	// TTL is the duration a snapshot is held open after it is created, unless it is released before.
+	TTL time.Duration
+	// MaxOpen is the maximum number of snapshots held open by a replica, each of which holds a connection of the
+	// pool. 0 disables snapshots.
+	MaxOpen int
+}
+
+// Enabled returns whether snapshots can be created.
+func (c *SnapshotsConfig) Enabled() bool {
+	return c.MaxOpen > 0
+}
+
+func (c *SnapshotsConfig) Validate() error {
+	var errorMessages []string
+	if c.TTL <= 0 {
+		errorMessages = append(errorMessages, "TTL must be greater than zero")
+	}
+	if c.MaxOpen < 0 {
+		errorMessages = append(errorMessages, "max open must not be negative")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("snapshots config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	ttl := durationSchema("Duration a snapshot is held open after it is created, unless it is released before.")
+	ttl.Default = defaultSnapshotsTTL.String()
+	maxOpen := intSchema("Maximum number of snapshots held open by a replica, each of which holds a connection of " +
+		"the pool. 0 disables snapshots.")
+	maxOpen.Default = defaultSnapshotsMaxOpen
+	schema := objectSchema("Database snapshots held open for clients composing several requests, which pass the "+
+		"snapshot parameter to read a consistent view of the data.", map[string]*Schema{
+		"ttl":      ttl,
+		"max_open": maxOpen,
+	})
+	registerSection("snapshots", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.SnapshotsConfig = SnapshotsConfig{
+			TTL:     defaultSnapshotsTTL,
+			MaxOpen: defaultSnapshotsMaxOpen,
+		}
+		if k.Exists("snapshots_ttl") {
+			cfg.SnapshotsConfig.TTL = k.Duration("snapshots_ttl")
+		}
+		if k.Exists("snapshots_max_open") {
+			cfg.SnapshotsConfig.MaxOpen = k.Int("snapshots_max_open")
+		}
+		return cfg.SnapshotsConfig.Validate()
+	})
+}
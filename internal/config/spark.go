@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultSparkApplicationIDTag is the allocation tag holding the Spark application ID. The YuniKorn k8shim adds
+// the labels of pods as allocation tags prefixed with kubernetes.io/label/, and Spark labels its driver and executor
+// pods with spark-app-selector.
+const DefaultSparkApplicationIDTag = "kubernetes.io/label/spark-app-selector"
+
+// SparkConfig specifies how applications run by Spark are correlated with the Spark History Server.
+type SparkConfig struct {
+	// ApplicationIDTag is the allocation tag the Spark application ID of applications is taken from.
+	ApplicationIDTag string
+	// HistoryServerURL is the base URL of the Spark History Server UI. If it is set, applications with
+	// a Spark application ID are returned with a link to the application in the UI.
+	HistoryServerURL string
+}
+
+func (c *SparkConfig) Validate() error {
+	var errorMessages []string
+	if c.ApplicationIDTag == "" {
+		errorMessages = append(errorMessages, "application id tag is required")
+	}
+	if c.HistoryServerURL != "" {
+		u, err := url.Parse(c.HistoryServerURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("history server url %q is not an http or https url", c.HistoryServerURL))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("spark config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	tag := stringSchema("Allocation tag the Spark application ID of applications is taken from.")
+	tag.Default = DefaultSparkApplicationIDTag
+	historyServerURL := stringSchema("Base URL of the Spark History Server UI. If it is set, applications with " +
+		"a Spark application ID are returned with a spark-history link to the application in the UI.")
+	historyServerURL.Examples = []any{"https://spark-history.example.com"}
+	schema := objectSchema("Correlation of applications run by Spark with the Spark History Server.", map[string]*Schema{
+		"application_id_tag": tag,
+		"history_server_url": historyServerURL,
+	})
+	registerSection("spark", schema, func(k *koanf.Koanf, cfg *Config) error {
+		tag := k.String("spark_application_id_tag")
+		if tag == "" {
+			tag = DefaultSparkApplicationIDTag
+		}
+		cfg.SparkConfig = SparkConfig{
+			ApplicationIDTag: tag,
+			HistoryServerURL: k.String("spark_history_server_url"),
+		}
+		return cfg.SparkConfig.Validate()
+	})
+}
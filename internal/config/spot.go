@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultSpotSelectors select the spot and preemptible nodes of the common node provisioners and cloud providers.
+var DefaultSpotSelectors = []string{
+	"karpenter.sh/capacity-type=spot",
+	"eks.amazonaws.com/capacityType=SPOT",
+	"cloud.google.com/gke-spot=true",
+	"cloud.google.com/gke-preemptible=true",
+	"kubernetes.azure.com/scalesetpriority=spot",
+}
+
+// SpotConfig specifies how the terminations of nodes are recorded, and which nodes are spot or preemptible nodes
+// whose terminations are attributed to their reclamation by the cloud provider.
+type SpotConfig struct {
+	// Enabled records the nodes removed from Yunikorn, and the allocations they were running when they were removed.
+	Enabled bool
+	// Selectors select the spot nodes by their attributes. A node is a spot node if it matches all the labels of
+	// any of the selectors. The selectors of DefaultSpotSelectors are used by default.
+	Selectors []map[string]string
+}
+
+// Validate validates the configuration of the spot node terminations.
+func (c *SpotConfig) Validate() error {
+	var errorMessages []string
+	for i, selector := range c.Selectors {
+		if len(selector) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("selector %d is empty", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("spot config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+// parseSpotSelectors parses the selectors of spot nodes.
+func parseSpotSelectors(selectors []string) ([]map[string]string, error) {
+	parsed := make([]map[string]string, len(selectors))
+	for i, selector := range selectors {
+		labels, err := parseLabelSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spot selector %d: %v", i, err)
+		}
+		parsed[i] = labels
+	}
+	return parsed, nil
+}
+
+func init() {
+	selectors := &Schema{
+		Type: "array",
+		Description: "Selectors of the spot and preemptible nodes, each of comma separated attribute=value pairs. " +
+			"A node is a spot node if it matches all the pairs of any selector.",
+		Items:   stringSchema("Comma separated attribute=value pairs."),
+		Default: DefaultSpotSelectors,
+	}
+	schema := objectSchema("Recording of the terminations of nodes, to correlate the reclamation of spot and "+
+		"preemptible nodes with the workloads it disrupts.", map[string]*Schema{
+		"enabled":   boolSchema("Record the nodes removed from Yunikorn and the allocations they were running."),
+		"selectors": selectors,
+	})
+	registerSection("spot", schema, func(k *koanf.Koanf, cfg *Config) error {
+		selectorStrings := DefaultSpotSelectors
+		if k.Exists("spot_selectors") {
+			selectorStrings = k.Strings("spot_selectors")
+		}
+		selectors, err := parseSpotSelectors(selectorStrings)
+		if err != nil {
+			return err
+		}
+		cfg.SpotConfig = SpotConfig{
+			Enabled:   k.Bool("spot_enabled"),
+			Selectors: selectors,
+		}
+		return cfg.SpotConfig.Validate()
+	})
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const defaultStorageBatchSize = 1000
+
+// StorageConfig specifies the tiering of the applications: the applications which finished a while ago are moved
+// from the applications table to the cold tier, which has fewer indexes and compresses its rows.
+type StorageConfig struct {
+	// ColdAfter is the duration after which finished applications are moved to the cold tier. Zero disables the tiering.
+	// The servers of a deployment must be configured with the same duration.
+	ColdAfter time.Duration
+	// Interval is the interval at which finished applications are moved to the cold tier.
+	Interval time.Duration
+	// BatchSize is the number of applications moved to the cold tier per transaction.
+	BatchSize int
+}
+
+func (c *StorageConfig) Validate() error {
+	var errorMessages []string
+	if c.ColdAfter < 0 {
+		errorMessages = append(errorMessages, "cold after must not be negative")
+	}
+	if c.BatchSize < 1 {
+		errorMessages = append(errorMessages, "batch size must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("storage config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	coldAfter := durationSchema("Duration after which finished applications are moved to the cold tier. " +
+		"0 disables the tiering. The servers of a deployment must be configured with the same duration.")
+	coldAfter.Default = "0s"
+	interval := durationSchema("Interval at which finished applications are moved to the cold tier, " +
+		"unless the schedule of the cold-tier job is set in jobs.schedules.")
+	interval.Default = "1h"
+	minBatchSize := 1
+	batchSize := intSchema("Number of applications moved to the cold tier per transaction.")
+	batchSize.Minimum = &minBatchSize
+	batchSize.Default = defaultStorageBatchSize
+	schema := objectSchema("Configuration of the cold tier of the applications.", map[string]*Schema{
+		"cold_after": coldAfter,
+		"interval":   interval,
+		"batch_size": batchSize,
+	})
+	registerSection("storage", schema, func(k *koanf.Koanf, cfg *Config) error {
+		interval := k.Duration("storage_interval")
+		if interval == 0 {
+			interval = time.Hour
+		}
+		cfg.StorageConfig = StorageConfig{
+			ColdAfter: k.Duration("storage_cold_after"),
+			Interval:  interval,
+			BatchSize: defaultStorageBatchSize,
+		}
+		if k.Exists("storage_batch_size") {
+			cfg.StorageConfig.BatchSize = k.Int("storage_batch_size")
+		}
+		return cfg.StorageConfig.Validate()
+	})
+}
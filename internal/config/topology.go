@@ -0,0 +1,40 @@
+package config
+
+import (
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultZoneAttribute is the attribute of the nodes their zone is taken from by default, the well-known zone label
+// of Kubernetes.
+const DefaultZoneAttribute = "topology.kubernetes.io/zone"
+
+// TopologyConfig specifies the attributes of the nodes their position in the topology of the cluster is taken from,
+// to break the utilization and the placements of allocations down by zone and rack.
+type TopologyConfig struct {
+	// ZoneAttribute is the attribute of the nodes their zone is taken from, DefaultZoneAttribute by default.
+	ZoneAttribute string
+	// RackAttribute is the attribute of the nodes their rack is taken from. If not set, the rack is the rack name
+	// reported by Yunikorn.
+	RackAttribute string
+}
+
+func init() {
+	zoneAttribute := stringSchema("Attribute of the nodes their zone is taken from.")
+	zoneAttribute.Default = DefaultZoneAttribute
+	schema := objectSchema("Attributes of the nodes their zone and rack are taken from, to break the utilization "+
+		"and the placements of allocations down by topology.", map[string]*Schema{
+		"zone_attribute": zoneAttribute,
+		"rack_attribute": stringSchema("Attribute of the nodes their rack is taken from. The rack name reported by " +
+			"Yunikorn is used if it is not set."),
+	})
+	registerSection("topology", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.TopologyConfig = TopologyConfig{
+			ZoneAttribute: DefaultZoneAttribute,
+			RackAttribute: k.String("topology_rack_attribute"),
+		}
+		if k.Exists("topology_zone_attribute") {
+			cfg.TopologyConfig.ZoneAttribute = k.String("topology_zone_attribute")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	defaultTraceSampleRate       = 1.0
+	defaultTraceMaxEvents        = 1000
+	defaultTraceMaxMessageLength = 1024
+	defaultTraceMaxAge           = 7 * 24 * time.Hour
+)
+
+// TraceConfig specifies how the scheduling events of applications are stored as their decision trace.
+type TraceConfig struct {
+	// Enabled stores the events of the event stream of Yunikorn which refer to an application, and serves them
+	// as the trace of the application. It is disabled by default, as Yunikorn may emit many events per application.
+	Enabled bool
+	// SampleRate is the fraction of the applications whose events are stored. Applications are sampled by their ID,
+	// so that the trace of a sampled application is complete.
+	SampleRate float64
+	// MaxEvents is the maximum number of events stored per application. Later events are not stored.
+	MaxEvents int
+	// MaxMessageLength is the maximum length in bytes of the stored messages of the events, which are truncated.
+	MaxMessageLength int
+	// MaxAge is the age after which the stored events are deleted.
+	MaxAge time.Duration
+}
+
+// Validate validates the configuration of the decision traces.
+func (c *TraceConfig) Validate() error {
+	var errorMessages []string
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		errorMessages = append(errorMessages, "sample rate must be between 0 and 1")
+	}
+	if c.MaxEvents <= 0 {
+		errorMessages = append(errorMessages, "max events must be positive")
+	}
+	if c.MaxMessageLength <= 0 {
+		errorMessages = append(errorMessages, "max message length must be positive")
+	}
+	if c.MaxAge <= 0 {
+		errorMessages = append(errorMessages, "max age must be positive")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("trace config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	minRate, maxRate := 0, 1
+	sampleRate := numberSchema("Fraction of the applications whose events are stored. Applications are sampled " +
+		"by their ID, so that the trace of a sampled application is complete.")
+	sampleRate.Minimum, sampleRate.Maximum = &minRate, &maxRate
+	sampleRate.Default = defaultTraceSampleRate
+	maxEvents := intSchema("Maximum number of events stored per application. Later events are not stored.")
+	maxEvents.Default = defaultTraceMaxEvents
+	maxMessageLength := intSchema("Maximum length in bytes of the stored messages of the events, which are truncated.")
+	maxMessageLength.Default = defaultTraceMaxMessageLength
+	maxAge := durationSchema("Age after which the stored events are deleted.")
+	maxAge.Default = defaultTraceMaxAge.String()
+	schema := objectSchema("Storage of the scheduling events of applications as their decision trace.",
+		map[string]*Schema{
+			"enabled": boolSchema("Store the events of the event stream of Yunikorn which refer to an application " +
+				"and serve them as its trace."),
+			"sample_rate":        sampleRate,
+			"max_events":         maxEvents,
+			"max_message_length": maxMessageLength,
+			"max_age":            maxAge,
+		})
+	registerSection("trace", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.TraceConfig = TraceConfig{
+			Enabled:          k.Bool("trace_enabled"),
+			SampleRate:       defaultTraceSampleRate,
+			MaxEvents:        defaultTraceMaxEvents,
+			MaxMessageLength: defaultTraceMaxMessageLength,
+			MaxAge:           defaultTraceMaxAge,
+		}
+		if k.Exists("trace_sample_rate") {
+			cfg.TraceConfig.SampleRate = k.Float64("trace_sample_rate")
+		}
+		if k.Exists("trace_max_events") {
+			cfg.TraceConfig.MaxEvents = k.Int("trace_max_events")
+		}
+		if k.Exists("trace_max_message_length") {
+			cfg.TraceConfig.MaxMessageLength = k.Int("trace_max_message_length")
+		}
+		if k.Exists("trace_max_age") {
+			cfg.TraceConfig.MaxAge = k.Duration("trace_max_age")
+		}
+		return cfg.TraceConfig.Validate()
+	})
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// Fields of the ingested data which can be redacted.
+const (
+	RedactFieldUser        = "user"
+	RedactFieldGroups      = "groups"
+	RedactFieldMessage     = "message"
+	RedactFieldObjectID    = "object_id"
+	RedactFieldReferenceID = "reference_id"
+
+	// DefaultRedactReplacement replaces the redacted values if no replacement is configured.
+	DefaultRedactReplacement = "[REDACTED]"
+)
+
+var redactFields = []string{
+	RedactFieldUser, RedactFieldGroups, RedactFieldMessage, RedactFieldObjectID, RedactFieldReferenceID,
+}
+
+// TransformConfig specifies the rules which transform the data of Yunikorn before it is stored or forwarded,
+// so that it can be adapted to local conventions.
+type TransformConfig struct {
+	// Drop are the selectors of the events which are dropped.
+	Drop []EventSelector
+	// Redact are the redactions applied to the fields of events and applications.
+	Redact []Redaction
+	// QueueMappings rename queues. The first mapping matching the full name of a queue is applied.
+	QueueMappings []QueueMapping
+}
+
+// EventSelector selects the events matching all of its fields which are set.
+type EventSelector struct {
+	// Type is the type of the events, e.g. APP.
+	Type string
+	// ChangeType is the change type of the events, e.g. SET.
+	ChangeType string
+	// ChangeDetail is the change detail of the events, e.g. APP_RUNNING.
+	ChangeDetail string
+	// ObjectID is a regular expression the object ID of the events must match.
+	ObjectID string
+	// Message is a regular expression the message of the events must match.
+	Message string
+}
+
+// Redaction replaces the values of the fields matching the pattern, or the whole values if no pattern is set.
+type Redaction struct {
+	Fields      []string
+	Pattern     string
+	Replacement string
+}
+
+// QueueMapping renames the queues whose full name matches the regular expression From to To, which may refer to
+// the submatches of From, e.g. $1.
+type QueueMapping struct {
+	From string
+	To   string
+}
+
+func (c *TransformConfig) Validate() error {
+	var errorMessages []string
+	for i, s := range c.Drop {
+		if s == (EventSelector{}) {
+			errorMessages = append(errorMessages, fmt.Sprintf("drop rule %d: at least one selector is required", i))
+		}
+	}
+	for i, r := range c.Redact {
+		if len(r.Fields) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("redact rule %d: fields are required", i))
+		}
+		for _, field := range r.Fields {
+			if !slices.Contains(redactFields, field) {
+				errorMessages = append(errorMessages, fmt.Sprintf("redact rule %d: unknown field %q", i, field))
+			}
+		}
+	}
+	for i, m := range c.QueueMappings {
+		if m.From == "" || m.To == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("queue mapping %d: from and to are required", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("transform config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	selector := objectSchema("Selects the events matching all of its fields which are set.", map[string]*Schema{
+		"type": {
+			Type:        "string",
+			Description: "Type of the events.",
+			Enum:        []any{"REQUEST", "APP", "NODE", "QUEUE", "USERGROUP"},
+		},
+		"change_type": {
+			Type:        "string",
+			Description: "Change type of the events.",
+			Enum:        []any{"NONE", "SET", "ADD", "REMOVE"},
+		},
+		"change_detail": stringSchema("Change detail of the events, e.g. APP_RUNNING."),
+		"object_id":     stringSchema("Regular expression the object ID of the events must match."),
+		"message":       stringSchema("Regular expression the message of the events must match."),
+	})
+	fields := stringListSchema("Fields to redact: the user and groups of applications, and the message, object ID " +
+		"and reference ID of events.")
+	for _, field := range redactFields {
+		fields.Items.Enum = append(fields.Items.Enum, field)
+	}
+	replacement := stringSchema("Replacement of the redacted values, which may refer to the submatches of the pattern, e.g. $1.")
+	replacement.Default = DefaultRedactReplacement
+	redaction := objectSchema("Replaces the values of the fields matching the pattern.", map[string]*Schema{
+		"fields":      fields,
+		"pattern":     stringSchema("Regular expression of the redacted values, the whole values are redacted if not set."),
+		"replacement": replacement,
+	})
+	mapping := objectSchema("Renames the queues whose full name matches from.", map[string]*Schema{
+		"from": stringSchema("Regular expression the full name of the queues must match, e.g. ^root\\.legacy\\.(.*)$."),
+		"to":   stringSchema("Full name the queues are renamed to, which may refer to the submatches of from, e.g. root.$1."),
+	})
+	schema := objectSchema("Rules transforming the data of Yunikorn before it is stored or forwarded, "+
+		"so that it can be adapted to local conventions.", map[string]*Schema{
+		"drop":           {Type: "array", Description: "Selectors of the events which are dropped.", Items: selector},
+		"redact":         {Type: "array", Description: "Redactions applied to events and applications.", Items: redaction},
+		"queue_mappings": {Type: "array", Description: "Renames of queues, the first matching mapping is applied.", Items: mapping},
+	})
+	registerSection("transform", schema, func(k *koanf.Koanf, cfg *Config) error {
+		var transform TransformConfig
+		for _, s := range k.Slices("transform_drop") {
+			transform.Drop = append(transform.Drop, EventSelector{
+				Type:         s.String("type"),
+				ChangeType:   s.String("change_type"),
+				ChangeDetail: s.String("change_detail"),
+				ObjectID:     s.String("object_id"),
+				Message:      s.String("message"),
+			})
+		}
+		for _, r := range k.Slices("transform_redact") {
+			redaction := Redaction{
+				Fields:      r.Strings("fields"),
+				Pattern:     r.String("pattern"),
+				Replacement: DefaultRedactReplacement,
+			}
+			if r.Exists("replacement") {
+				redaction.Replacement = r.String("replacement")
+			}
+			transform.Redact = append(transform.Redact, redaction)
+		}
+		for _, m := range k.Slices("transform_queue_mappings") {
+			transform.QueueMappings = append(transform.QueueMappings, QueueMapping{
+				From: m.String("from"),
+				To:   m.String("to"),
+			})
+		}
+		cfg.TransformConfig = transform
+		return cfg.TransformConfig.Validate()
+	})
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultWorkflowIDTags are the allocation tags the workflow ID of applications is taken from by default:
+// the label Argo Workflows adds to the pods of a workflow, and the DAG and run labels Airflow adds to the pods
+// of a DAG run, since Airflow run IDs are only unique within a DAG.
+var DefaultWorkflowIDTags = []string{
+	"kubernetes.io/label/workflows.argoproj.io/workflow",
+	"kubernetes.io/label/dag_id+kubernetes.io/label/run_id",
+}
+
+// WorkflowsConfig specifies how applications are correlated with the workflow runs, such as Airflow DAG runs
+// or Argo workflows, that caused them.
+type WorkflowsConfig struct {
+	// IDTags are the allocation tags the workflow ID of applications is taken from. The first tag found is used.
+	// A tag can combine several tags with +, whose values are joined with : and must all be present.
+	IDTags []string
+}
+
+func (c *WorkflowsConfig) Validate() error {
+	var errorMessages []string
+	for i, tag := range c.IDTags {
+		for _, t := range strings.Split(tag, "+") {
+			if strings.TrimSpace(t) == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("id tag %d: %q has an empty tag", i, tag))
+				break
+			}
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("workflows config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	idTags := stringListSchema("Allocation tags the workflow ID of applications is taken from, the first tag found " +
+		"is used. Several tags can be combined with +, e.g. kubernetes.io/label/dag_id+kubernetes.io/label/run_id, " +
+		"their values are joined with :.")
+	idTags.Default = DefaultWorkflowIDTags
+	schema := objectSchema("Correlation of applications with the workflow runs, such as Airflow DAG runs or "+
+		"Argo workflows, that caused them.", map[string]*Schema{
+		"id_tags": idTags,
+	})
+	registerSection("workflows", schema, func(k *koanf.Koanf, cfg *Config) error {
+		idTags := DefaultWorkflowIDTags
+		if k.Exists("workflows_id_tags") {
+			idTags = k.Strings("workflows_id_tags")
+		}
+		cfg.WorkflowsConfig = WorkflowsConfig{IDTags: idTags}
+		return cfg.WorkflowsConfig.Validate()
+	})
+}
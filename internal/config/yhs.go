@@ -0,0 +1,321 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/rs/cors"
+)
+
+const (
+	// FieldNamingCamelCase renders the fields of responses in camelCase, as the upstream Yunikorn DAOs.
+	FieldNamingCamelCase = "camelCase"
+	// FieldNamingSnakeCase renders the fields of responses in snake_case, as the columns of the database models.
+	FieldNamingSnakeCase = "snake_case"
+	// APISchemaCurrent renders responses in the response schema of the current release.
+	APISchemaCurrent = "current"
+	// APISchemaPrevious renders responses in the response schema of the previous release, with the fields renamed
+	// since then under their previous names, so that clients can be upgraded after the server.
+	APISchemaPrevious = "previous"
+)
+
+type YHSConfig struct {
+	// Port specifies the port on which the Yunikorn History Server listens for incoming requests on all interfaces,
+	// unless Listen is set.
+	Port int
+	// Listen specifies the addresses on which the Yunikorn History Server listens for incoming requests, see
+	// ParseListenAddress.
+	Listen []string
+	// AssetsDir specifies the directory where the static assets are stored.
+	AssetsDir string
+	// DataSyncInterval specifies the interval at which the data is synced from the Yunikorn API.
+	DataSyncInterval time.Duration
+	// CORSConfig specifies the configuration for the CORS middleware.
+	CORSConfig cors.Options
+	// ReadOnly specifies whether the server only reads the database, e.g. when connected with the reader role.
+	ReadOnly bool
+	// FieldNaming specifies the naming convention of the fields of responses, unless requested otherwise.
+	FieldNaming string
+	// APISchema specifies the release whose response schema the responses are rendered in, unless requested otherwise.
+	APISchema string
+	// PageSizes specifies the default and maximum limits of the paginated endpoints.
+	PageSizes PageSizesConfig
+	// Limits specifies the maximum sizes of the requests.
+	Limits RequestLimitsConfig
+}
+
+// RequestLimitsConfig specifies the maximum sizes of the requests, beyond which they are rejected.
+type RequestLimitsConfig struct {
+	// MaxBodySize is the maximum size of a request body in bytes. The routes accepting the data forwarded by
+	// collectors and the events of the cluster-autoscaler have larger limits of their own.
+	MaxBodySize int64
+	// MaxHeaderSize is the maximum size of the request line and the headers of a request in bytes.
+	MaxHeaderSize int
+}
+
+// DefaultRequestLimits are the request limits unless configured otherwise.
+var DefaultRequestLimits = RequestLimitsConfig{MaxBodySize: 1 << 20, MaxHeaderSize: 64 << 10}
+
+// unixAddressPrefix prefixes the listen addresses which are paths of Unix domain sockets.
+const unixAddressPrefix = "unix:"
+
+// ParseListenAddress returns the network and the address of a listen address, which is either a host and port, e.g.
+// ":8080", "0.0.0.0:8080" or "[::1]:8080", or the path of a Unix domain socket prefixed with unix:, e.g.
+// "unix:/run/yhs/yhs.sock". An empty host listens on all interfaces of both IPv4 and IPv6, and port 0 on a port
+// chosen by the system.
+func ParseListenAddress(address string) (network, addr string, err error) {
+	if path, ok := strings.CutPrefix(address, unixAddressPrefix); ok {
+		if path == "" {
+			return "", "", fmt.Errorf("listen address %q has no socket path", address)
+		}
+		return "unix", path, nil
+	}
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid listen address %q: %v", address, err)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 0 || p > 65535 {
+		return "", "", fmt.Errorf("invalid port of listen address %q", address)
+	}
+	return "tcp", address, nil
+}
+
+// ListenAddresses returns the addresses the server listens on, which are those of Listen if set, else the port on
+// all interfaces.
+func (c *YHSConfig) ListenAddresses() []string {
+	if len(c.Listen) > 0 {
+		return c.Listen
+	}
+	return []string{fmt.Sprintf(":%d", c.Port)}
+}
+
+// PageSizeConfig specifies the limits of the pages of a family of paginated endpoints.
+type PageSizeConfig struct {
+	// DefaultLimit is the number of items returned if the request has no limit.
+	DefaultLimit int
+	// MaxLimit is the maximum limit of a request.
+	MaxLimit int
+}
+
+// DefaultPageSize is the page size of the endpoint families without configured page size.
+var DefaultPageSize = PageSizeConfig{DefaultLimit: 10000, MaxLimit: 10000}
+
+// PageSizesConfig specifies the page sizes per family of paginated endpoints.
+type PageSizesConfig struct {
+	// Applications specifies the page size of the endpoints listing applications.
+	Applications PageSizeConfig
+	// LegalHolds specifies the page size of the endpoints listing legal holds.
+	LegalHolds PageSizeConfig
+	// Nodes specifies the page size of the endpoints listing the nodes of a partition.
+	Nodes PageSizeConfig
+	// History specifies the page size of the applications and containers history.
+	History PageSizeConfig
+	// Other specifies the page size of the other paginated endpoints: the events and the trace of an application,
+	// the users and groups, the data quality violations and the duplicates report.
+	Other PageSizeConfig
+}
+
+// validate validates the page size of the endpoint family, an unset page size defaults to DefaultPageSize.
+func (c PageSizeConfig) validate(family string) []string {
+	var errorMessages []string
+	if c == (PageSizeConfig{}) {
+		return nil
+	}
+	if c.MaxLimit < 1 {
+		errorMessages = append(errorMessages, fmt.Sprintf("yhs config validation error: max limit of %s must be positive", family))
+	}
+	if c.DefaultLimit < 1 || c.DefaultLimit > c.MaxLimit {
+		errorMessages = append(errorMessages,
+			fmt.Sprintf("yhs config validation error: default limit of %s must be between 1 and the max limit", family))
+	}
+	return errorMessages
+}
+
+// loadPageSize loads the page size of the endpoint family, which defaults to DefaultPageSize.
+// The default limit is capped at the max limit, so that only lowering the max limit is enough.
+func loadPageSize(k *koanf.Koanf, family string) PageSizeConfig {
+	size := DefaultPageSize
+	if k.Exists("yhs_page_sizes_" + family + "_max_limit") {
+		size.MaxLimit = k.Int("yhs_page_sizes_" + family + "_max_limit")
+		size.DefaultLimit = min(size.DefaultLimit, size.MaxLimit)
+	}
+	if k.Exists("yhs_page_sizes_" + family + "_default_limit") {
+		size.DefaultLimit = k.Int("yhs_page_sizes_" + family + "_default_limit")
+	}
+	return size
+}
+
+func (c *YHSConfig) Validate() error {
+	var errorMessages []string
+	if c.Port < 1 && len(c.Listen) == 0 {
+		errorMessages = append(errorMessages, "yhs config validation error: port or listen addresses are required")
+	}
+	seen := make(map[string]bool, len(c.Listen))
+	for _, address := range c.Listen {
+		if _, _, err := ParseListenAddress(address); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("yhs config validation error: %v", err))
+		}
+		if seen[address] {
+			errorMessages = append(errorMessages, fmt.Sprintf("yhs config validation error: duplicate listen address %q", address))
+		}
+		seen[address] = true
+	}
+	switch c.FieldNaming {
+	case "", FieldNamingCamelCase, FieldNamingSnakeCase:
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("yhs config validation error: unknown field naming %q", c.FieldNaming))
+	}
+	switch c.APISchema {
+	case "", APISchemaCurrent, APISchemaPrevious:
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("yhs config validation error: unknown API schema %q", c.APISchema))
+	}
+	errorMessages = append(errorMessages, c.PageSizes.Applications.validate("applications")...)
+	errorMessages = append(errorMessages, c.PageSizes.LegalHolds.validate("legal_holds")...)
+	errorMessages = append(errorMessages, c.PageSizes.Nodes.validate("nodes")...)
+	errorMessages = append(errorMessages, c.PageSizes.History.validate("history")...)
+	errorMessages = append(errorMessages, c.PageSizes.Other.validate("other")...)
+	if c.Limits != (RequestLimitsConfig{}) {
+		if c.Limits.MaxBodySize < 1 {
+			errorMessages = append(errorMessages, "yhs config validation error: max body size must be positive")
+		}
+		if c.Limits.MaxHeaderSize < 1 {
+			errorMessages = append(errorMessages, "yhs config validation error: max header size must be positive")
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("yhs config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func init() {
+	assetsDir := stringSchema("Directory where the static assets are stored.")
+	assetsDir.Default = "assets"
+	dataSyncInterval := durationSchema("Interval at which the data is synced from the Yunikorn API.")
+	dataSyncInterval.Default = "5m"
+	fieldNaming := stringSchema("Naming convention of the fields of responses, unless requested otherwise with the X-Field-Naming header.")
+	fieldNaming.Enum = []any{FieldNamingCamelCase, FieldNamingSnakeCase}
+	fieldNaming.Default = FieldNamingCamelCase
+	apiSchema := stringSchema("Release whose response schema the responses are rendered in, unless requested otherwise " +
+		"with the X-API-Schema header. previous renders the fields renamed in the current release under their previous " +
+		"names, for one release, so that clients can be upgraded after the server.")
+	apiSchema.Enum = []any{APISchemaCurrent, APISchemaPrevious}
+	apiSchema.Default = APISchemaCurrent
+	listen := stringListSchema("Addresses on which the Yunikorn History Server listens for incoming requests " +
+		"instead of the port, each either a host and port, where an empty host listens on all interfaces of both " +
+		"IPv4 and IPv6, or the path of a Unix domain socket prefixed with unix:.")
+	listen.Examples = []any{[]string{"0.0.0.0:8080", "[::1]:8081", "unix:/run/yhs/yhs.sock"}}
+	minSize := 1
+	maxBodySize := intSchema("Maximum size of a request body in bytes, beyond which requests are rejected with 413 " +
+		"Content Too Large. The routes accepting the data forwarded by collectors and the events of the " +
+		"cluster-autoscaler have larger limits of their own.")
+	maxBodySize.Minimum = &minSize
+	maxBodySize.Default = DefaultRequestLimits.MaxBodySize
+	maxHeaderSize := intSchema("Maximum size of the request line and the headers of a request in bytes, beyond " +
+		"which requests are rejected with 431 Request Header Fields Too Large.")
+	maxHeaderSize.Minimum = &minSize
+	maxHeaderSize.Default = DefaultRequestLimits.MaxHeaderSize
+	minLimit := 1
+	pageSize := func(family string) *Schema {
+		defaultLimit := intSchema("Number of " + family + " returned if the request has no limit, capped at the max limit by default.")
+		defaultLimit.Minimum = &minLimit
+		defaultLimit.Default = DefaultPageSize.DefaultLimit
+		maxLimit := intSchema("Maximum limit of a request.")
+		maxLimit.Minimum = &minLimit
+		maxLimit.Default = DefaultPageSize.MaxLimit
+		return objectSchema("Page size of the endpoints listing "+family+".", map[string]*Schema{
+			"default_limit": defaultLimit,
+			"max_limit":     maxLimit,
+		})
+	}
+	historyPageSize := pageSize("history entries")
+	historyPageSize.Description = "Page size of the applications and containers history."
+	otherPageSize := pageSize("items")
+	otherPageSize.Description = "Page size of the other paginated endpoints: the events and the trace of an " +
+		"application, the users and groups, the data quality violations and the duplicates report."
+	schema := objectSchema("Configuration of the Yunikorn History Server.", map[string]*Schema{
+		"port": portSchema("Port on which the Yunikorn History Server listens for incoming requests on all " +
+			"interfaces, unless listen is set."),
+		"listen":             listen,
+		"assets_dir":         assetsDir,
+		"data_sync_interval": dataSyncInterval,
+		"read_only": {
+			Type: "boolean",
+			Description: "Whether the server only reads the database, so that it can connect with the uhs_reader role. " +
+				"The data is not synced from the Yunikorn API, applications are not pruned and write endpoints are rejected.",
+		},
+		"field_naming": fieldNaming,
+		"api_schema":   apiSchema,
+		"page_sizes": objectSchema("Default and maximum limits of the paginated endpoints, per endpoint family. "+
+			"Streamed responses have no default limit.", map[string]*Schema{
+			"applications": pageSize("applications"),
+			"legal_holds":  pageSize("legal holds"),
+			"nodes":        pageSize("nodes"),
+			"history":      historyPageSize,
+			"other":        otherPageSize,
+		}),
+		"limits": objectSchema("Maximum sizes of the requests.", map[string]*Schema{
+			"max_body_size":   maxBodySize,
+			"max_header_size": maxHeaderSize,
+		}),
+		"cors": objectSchema("Configuration of the CORS middleware.", map[string]*Schema{
+			"allowed_origins": stringListSchema("Origins allowed to perform cross-origin requests."),
+			"allowed_methods": stringListSchema("Methods allowed in cross-origin requests."),
+			"allowed_headers": stringListSchema("Headers allowed in cross-origin requests."),
+		}),
+	})
+	registerSection("yhs", schema, func(k *koanf.Koanf, cfg *Config) error {
+		assetsDir := k.String("yhs_assets_dir")
+		if assetsDir == "" {
+			assetsDir = "assets"
+		}
+		dataSyncInterval := k.Duration("yhs_data_sync_interval")
+		if dataSyncInterval == 0 {
+			dataSyncInterval = 5 * time.Minute
+		}
+		fieldNaming := k.String("yhs_field_naming")
+		if fieldNaming == "" {
+			fieldNaming = FieldNamingCamelCase
+		}
+		apiSchema := k.String("yhs_api_schema")
+		if apiSchema == "" {
+			apiSchema = APISchemaCurrent
+		}
+		corsConfig := cors.Options{
+			AllowedOrigins: k.Strings("yhs_cors_allowed_origins"),
+			AllowedMethods: k.Strings("yhs_cors_allowed_methods"),
+			AllowedHeaders: k.Strings("yhs_cors_allowed_headers"),
+		}
+
+		cfg.YHSConfig = YHSConfig{
+			Port:             k.Int("yhs_port"),
+			Listen:           k.Strings("yhs_listen"),
+			AssetsDir:        assetsDir,
+			DataSyncInterval: dataSyncInterval,
+			CORSConfig:       corsConfig,
+			ReadOnly:         k.Bool("yhs_read_only"),
+			FieldNaming:      fieldNaming,
+			APISchema:        apiSchema,
+			PageSizes: PageSizesConfig{
+				Applications: loadPageSize(k, "applications"),
+				LegalHolds:   loadPageSize(k, "legal_holds"),
+				Nodes:        loadPageSize(k, "nodes"),
+				History:      loadPageSize(k, "history"),
+				Other:        loadPageSize(k, "other"),
+			},
+			Limits: DefaultRequestLimits,
+		}
+		if k.Exists("yhs_limits_max_body_size") {
+			cfg.YHSConfig.Limits.MaxBodySize = k.Int64("yhs_limits_max_body_size")
+		}
+		if k.Exists("yhs_limits_max_header_size") {
+			cfg.YHSConfig.Limits.MaxHeaderSize = k.Int("yhs_limits_max_header_size")
+		}
+		return cfg.YHSConfig.Validate()
+	})
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/knadh/koanf/v2"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+)
+
+const defaultYunikornSyncFailureBudget = 2
+
+// YunikornConfig specifies the configuration for the Yunikorn API.
+type YunikornConfig struct {
+	Host string
+	Port int
+	// Secure indicates whether the connection to the Yunikorn API is using encryption or not.
+	Secure bool
+	// SyncFailureBudget is the number of consecutive failed syncs with the Yunikorn API which are tolerated
+	// before the server is reported as not ready.
+	SyncFailureBudget int
+	// ClusterID is the ID of the cluster of the scheduler at Host, see package cluster. Its data is attributed to
+	// the default cluster if empty.
+	ClusterID string
+	// Clusters are the schedulers of other clusters which are synced as well.
+	Clusters []YunikornCluster
+}
+
+// YunikornCluster is the Yunikorn scheduler of another cluster.
+type YunikornCluster struct {
+	ID     string
+	Host   string
+	Port   int
+	Secure bool
+}
+
+// Schedulers returns the configurations of the schedulers to sync: the scheduler at Host, if it is set, and the
+// schedulers of the other clusters, each with the ID of its cluster and the sync failure budget.
+func (c *YunikornConfig) Schedulers() []YunikornConfig {
+	var schedulers []YunikornConfig
+	if c.Host != "" {
+		schedulers = append(schedulers, YunikornConfig{
+			Host:              c.Host,
+			Port:              c.Port,
+			Secure:            c.Secure,
+			SyncFailureBudget: c.SyncFailureBudget,
+			ClusterID:         c.ClusterID,
+		})
+	}
+	for _, cl := range c.Clusters {
+		schedulers = append(schedulers, YunikornConfig{
+			Host:              cl.Host,
+			Port:              cl.Port,
+			Secure:            cl.Secure,
+			SyncFailureBudget: c.SyncFailureBudget,
+			ClusterID:         cl.ID,
+		})
+	}
+	return schedulers
+}
+
+// Validate validates the configuration of the scheduler at Host, which is required to run the collector.
+func (c *YunikornConfig) Validate() error {
+	var errorMessages []string
+	if c.Host == "" {
+		errorMessages = append(errorMessages, "yunikorn host is required")
+	}
+	if c.Port < 1 {
+		errorMessages = append(errorMessages, "yunikorn port is required")
+	}
+	errorMessages = append(errorMessages, c.clusterErrors()...)
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("yunikorn config validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+// clusterErrors validates the cluster IDs, which must be unique, and the schedulers of the other clusters.
+func (c *YunikornConfig) clusterErrors() []string {
+	var errorMessages []string
+	if c.ClusterID != "" && !cluster.ValidID(c.ClusterID) {
+		errorMessages = append(errorMessages, fmt.Sprintf("cluster id %q is not a lowercase DNS label", c.ClusterID))
+	}
+	seen := map[string]bool{c.ClusterID: c.Host != ""}
+	for i, cl := range c.Clusters {
+		switch {
+		case !cluster.ValidID(cl.ID):
+			errorMessages = append(errorMessages, fmt.Sprintf("cluster %d: id %q is not a lowercase DNS label", i, cl.ID))
+		case seen[cl.ID]:
+			errorMessages = append(errorMessages, fmt.Sprintf("cluster %d: id %q is not unique", i, cl.ID))
+		}
+		seen[cl.ID] = true
+		if cl.Host == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("cluster %d: host is required", i))
+		}
+		if cl.Port < 1 {
+			errorMessages = append(errorMessages, fmt.Sprintf("cluster %d: port is required", i))
+		}
+	}
+	return errorMessages
+}
+
+func init() {
+	minBudget := 0
+	syncFailureBudget := intSchema("Number of consecutive failed syncs with the Yunikorn API which are tolerated " +
+		"before the server is reported as not ready.")
+	syncFailureBudget.Minimum = &minBudget
+	syncFailureBudget.Default = defaultYunikornSyncFailureBudget
+	schema := objectSchema("Configuration of the Yunikorn API.", map[string]*Schema{
+		"host":                stringSchema("Host of the Yunikorn scheduler."),
+		"port":                portSchema("Port of the Yunikorn scheduler."),
+		"secure":              boolSchema("Whether the connection to the Yunikorn API is using encryption or not."),
+		"sync_failure_budget": syncFailureBudget,
+		"cluster_id": stringSchema("ID of the cluster of the scheduler, a lowercase DNS label which qualifies the " +
+			"partitions of the cluster, e.g. [eu-west]default. The partitions are not qualified if it is not set."),
+		"clusters": {
+			Type: "array",
+			Description: "Yunikorn schedulers of other clusters which are synced as well, each attributed to its " +
+				"cluster.",
+			Items: objectSchema("Yunikorn scheduler of another cluster.", map[string]*Schema{
+				"id":     stringSchema("ID of the cluster, a lowercase DNS label unique among the clusters."),
+				"host":   stringSchema("Host of the Yunikorn scheduler."),
+				"port":   portSchema("Port of the Yunikorn scheduler."),
+				"secure": boolSchema("Whether the connection to the Yunikorn API is using encryption or not."),
+			}),
+		},
+	})
+	registerSection("yunikorn", schema, func(k *koanf.Koanf, cfg *Config) error {
+		cfg.YunikornConfig = YunikornConfig{
+			Host:              k.String("yunikorn_host"),
+			Port:              k.Int("yunikorn_port"),
+			Secure:            k.Bool("yunikorn_secure"),
+			SyncFailureBudget: defaultYunikornSyncFailureBudget,
+			ClusterID:         k.String("yunikorn_cluster_id"),
+		}
+		for _, c := range k.Slices("yunikorn_clusters") {
+			cfg.YunikornConfig.Clusters = append(cfg.YunikornConfig.Clusters, YunikornCluster{
+				ID:     c.String("id"),
+				Host:   c.String("host"),
+				Port:   c.Int("port"),
+				Secure: c.Bool("secure"),
+			})
+		}
+		if k.Exists("yunikorn_sync_failure_budget") {
+			cfg.YunikornConfig.SyncFailureBudget = k.Int("yunikorn_sync_failure_budget")
+		}
+		if cfg.YunikornConfig.SyncFailureBudget < 0 {
+			return fmt.Errorf("yunikorn sync failure budget must not be negative")
+		}
+		if errorMessages := cfg.YunikornConfig.clusterErrors(); len(errorMessages) > 0 {
+			return fmt.Errorf("yunikorn config validation errors: %v", errorMessages)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+// Controller watches HistoryRetentionPolicy and HistoryAlertRule custom resources
+// and applies them to the policy store of the running server.
+type Controller struct {
+	client       dynamic.Interface
+	store        *policy.Store
+	namespace    string
+	resyncPeriod time.Duration
+}
+
+// New creates a Controller for the cluster configured by the kubeconfig file,
+// or for the cluster the server runs in if no kubeconfig file is configured.
+func New(cfg *config.ControllerConfig, store *policy.Store) (*Controller, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", cfg.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not load kubernetes client config: %v", err)
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create kubernetes client: %v", err)
+	}
+	return NewWithClient(client, store, cfg.Namespace, cfg.ResyncPeriod), nil
+}
+
+// NewWithClient creates a Controller using the given client.
+func NewWithClient(client dynamic.Interface, store *policy.Store, namespace string, resyncPeriod time.Duration) *Controller {
+	return &Controller{
+		client:       client,
+		store:        store,
+		namespace:    namespace,
+		resyncPeriod: resyncPeriod,
+	}
+}
+
+// Run watches the custom resources until the context is cancelled.
+func (c *Controller) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "controller")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Infow("starting kubernetes controller", "namespace", c.namespace)
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.client, c.resyncPeriod, c.namespace, nil)
+	handlers := []struct {
+		resource schema.GroupVersionResource
+		handler  resourceHandler
+	}{
+		{resource: RetentionPolicyResource, handler: resourceHandler{apply: c.applyRetentionPolicy, remove: c.store.DeleteRetentionPolicy}},
+		{resource: AlertRuleResource, handler: resourceHandler{apply: c.applyAlertRule, remove: c.store.DeleteAlertRule}},
+	}
+	for _, h := range handlers {
+		informer := factory.ForResource(h.resource).Informer()
+		if _, err := informer.AddEventHandler(h.handler.funcs(ctx)); err != nil {
+			return fmt.Errorf("could not register event handler for %s: %v", h.resource.Resource, err)
+		}
+	}
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+	for resource, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			logger.Warnw("could not sync custom resources", "resource", resource.Resource)
+		}
+	}
+
+	<-ctx.Done()
+	logger.Warn("shutting down kubernetes controller")
+	return nil
+}
+
+func (c *Controller) applyRetentionPolicy(name string, obj *unstructured.Unstructured) error {
+	var resource HistoryRetentionPolicy
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &resource); err != nil {
+		return err
+	}
+	return c.store.ApplyRetentionPolicy(resource.toPolicy(name))
+}
+
+func (c *Controller) applyAlertRule(name string, obj *unstructured.Unstructured) error {
+	var resource HistoryAlertRule
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &resource); err != nil {
+		return err
+	}
+	return c.store.ApplyAlertRule(resource.toRule(name))
+}
+
+// resourceHandler applies and removes the objects of a single custom resource.
+// Objects are named in the policy store after their <namespace>/<name> key.
+type resourceHandler struct {
+	apply  func(name string, obj *unstructured.Unstructured) error
+	remove func(name string) bool
+}
+
+func (h *resourceHandler) funcs(ctx context.Context) cache.ResourceEventHandlerFuncs {
+	logger := log.FromContext(ctx)
+	onApply := func(obj any) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return
+		}
+		name, err := cache.MetaNamespaceKeyFunc(u)
+		if err != nil {
+			logger.Errorf("could not get key of %s: %v", u.GetKind(), err)
+			return
+		}
+		if err := h.apply(name, u); err != nil {
+			// an invalid update must not leave the previous version of the resource applied
+			h.remove(name)
+			logger.Errorw("could not apply custom resource", "kind", u.GetKind(), "name", name, "error", err)
+			return
+		}
+		logger.Infow("applied custom resource", "kind", u.GetKind(), "name", name)
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: onApply,
+		UpdateFunc: func(_, obj any) {
+			onApply(obj)
+		},
+		DeleteFunc: func(obj any) {
+			name, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				logger.Errorf("could not get key of deleted custom resource: %v", err)
+				return
+			}
+			if h.remove(name) {
+				logger.Infow("removed custom resource", "name", name)
+			}
+		},
+	}
+}
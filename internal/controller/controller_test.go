@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+func TestController_Run(t *testing.T) {
+	retention := newUnstructured(t, "HistoryRetentionPolicy", "compliance", &HistoryRetentionPolicy{
+		Spec: HistoryRetentionPolicySpec{Queue: "root.compliance", ApplicationTTL: metav1.Duration{Duration: 17520 * time.Hour}},
+	})
+	invalid := newUnstructured(t, "HistoryAlertRule", "invalid", &HistoryAlertRule{
+		Spec: HistoryAlertRuleSpec{State: "Failed"},
+	})
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		RetentionPolicyResource: "HistoryRetentionPolicyList",
+		AlertRuleResource:       "HistoryAlertRuleList",
+	}, retention, invalid)
+
+	store := policy.NewStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = NewWithClient(client, store, "", time.Minute).Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool { return len(store.RetentionPolicies()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []policy.RetentionPolicy{{
+		Name:           "yunikorn/compliance",
+		Source:         policy.SourceKubernetes,
+		Queue:          "root.compliance",
+		ApplicationTTL: 17520 * time.Hour,
+	}}, store.RetentionPolicies())
+	assert.Empty(t, store.AlertRules(), "invalid alert rules must not be applied")
+
+	rule := newUnstructured(t, "HistoryAlertRule", "failures", &HistoryAlertRule{
+		Spec: HistoryAlertRuleSpec{Queue: "root.batch", State: "Failed", Threshold: 5, Window: metav1.Duration{Duration: time.Hour}},
+	})
+	_, err := client.Resource(AlertRuleResource).Namespace("yunikorn").Create(ctx, rule, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(store.AlertRules()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "yunikorn/failures", store.AlertRules()[0].Name)
+
+	err = client.Resource(RetentionPolicyResource).Namespace("yunikorn").Delete(ctx, "compliance", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(store.RetentionPolicies()) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func newUnstructured(t *testing.T, kind, name string, obj any) *unstructured.Unstructured {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	u := &unstructured.Unstructured{Object: content}
+	u.SetAPIVersion(Group + "/" + Version)
+	u.SetKind(kind)
+	u.SetNamespace("yunikorn")
+	u.SetName(name)
+	return u
+}
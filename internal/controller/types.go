@@ -0,0 +1,87 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+const (
+	// Group is the API group of the custom resources.
+	Group = "yhs.gresearch.co.uk"
+	// Version is the API version of the custom resources.
+	Version = "v1alpha1"
+)
+
+var (
+	// RetentionPolicyResource identifies the HistoryRetentionPolicy custom resource.
+	RetentionPolicyResource = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "historyretentionpolicies"}
+	// AlertRuleResource identifies the HistoryAlertRule custom resource.
+	AlertRuleResource = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "historyalertrules"}
+)
+
+// HistoryRetentionPolicy declares how long finished applications in a queue subtree are kept.
+type HistoryRetentionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HistoryRetentionPolicySpec `json:"spec"`
+}
+
+type HistoryRetentionPolicySpec struct {
+	// Partition the policy applies to. Empty matches all partitions.
+	Partition string `json:"partition,omitempty"`
+	// Queue is the root of the queue subtree the policy applies to. Empty matches all queues.
+	Queue string `json:"queue,omitempty"`
+	// ApplicationTTL is the duration finished applications are kept for, e.g. 2160h.
+	ApplicationTTL metav1.Duration `json:"applicationTTL"`
+}
+
+// toPolicy converts the custom resource to a retention policy named after the resource key.
+func (p *HistoryRetentionPolicy) toPolicy(name string) policy.RetentionPolicy {
+	return policy.RetentionPolicy{
+		Name:           name,
+		Source:         policy.SourceKubernetes,
+		Partition:      p.Spec.Partition,
+		Queue:          p.Spec.Queue,
+		ApplicationTTL: p.Spec.ApplicationTTL.Duration,
+	}
+}
+
+// HistoryAlertRule declares an alert on the number of applications which finished in a given state.
+type HistoryAlertRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HistoryAlertRuleSpec `json:"spec"`
+}
+
+type HistoryAlertRuleSpec struct {
+	// Partition the rule applies to. Empty matches all partitions.
+	Partition string `json:"partition,omitempty"`
+	// Queue is the root of the queue subtree the rule applies to. Empty matches all queues.
+	Queue string `json:"queue,omitempty"`
+	// State is the final application state which is counted, e.g. Failed.
+	State string `json:"state"`
+	// Threshold is the number of applications at which the rule fires.
+	Threshold int `json:"threshold"`
+	// Window is the sliding window in which applications are counted, e.g. 1h.
+	Window metav1.Duration `json:"window"`
+	// Severity is attached to notifications, e.g. warning.
+	Severity string `json:"severity,omitempty"`
+}
+
+// toRule converts the custom resource to an alert rule named after the resource key.
+func (r *HistoryAlertRule) toRule(name string) policy.AlertRule {
+	return policy.AlertRule{
+		Name:      name,
+		Source:    policy.SourceKubernetes,
+		Partition: r.Spec.Partition,
+		Queue:     r.Spec.Queue,
+		State:     r.Spec.State,
+		Threshold: r.Spec.Threshold,
+		Window:    r.Spec.Window.Duration,
+		Severity:  r.Spec.Severity,
+	}
+}
@@ -0,0 +1,107 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+const (
+	// WriterRole is granted the privileges to read and write the tables of the server.
+	WriterRole = "uhs_writer"
+	// ReaderRole is granted the privileges to read the tables of the server, e.g. for a server in read-only mode.
+	ReaderRole = "uhs_reader"
+	// DefaultSchema is the schema used if none is configured.
+	DefaultSchema = "public"
+)
+
+// DB executes SQL statements, e.g. a pgxpool.Pool or pgx.Conn.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+type options struct {
+	writers []string
+	readers []string
+}
+
+type Option func(*options)
+
+// WithWriters grants the WriterRole to the given existing login roles.
+func WithWriters(users ...string) Option {
+	return func(o *options) {
+		o.writers = append(o.writers, users...)
+	}
+}
+
+// WithReaders grants the ReaderRole to the given existing login roles.
+func WithReaders(users ...string) Option {
+	return func(o *options) {
+		o.readers = append(o.readers, users...)
+	}
+}
+
+// Bootstrap creates the schema, the WriterRole and ReaderRole and their grants. It is idempotent and must be run
+// by the role which runs the migrations, as the default privileges only apply to tables created by that role.
+func Bootstrap(ctx context.Context, db DB, schema string, opts ...Option) error {
+	for _, stmt := range Statements(schema, opts...) {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("could not bootstrap schema: %w", err)
+		}
+	}
+	log.FromContext(ctx).Infow("bootstrapped schema", "schema", schemaOrDefault(schema))
+	return nil
+}
+
+// Statements returns the SQL statements executed by Bootstrap.
+func Statements(schema string, opts ...Option) []string {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	s := pgx.Identifier{schemaOrDefault(schema)}.Sanitize()
+	writer := pgx.Identifier{WriterRole}.Sanitize()
+	reader := pgx.Identifier{ReaderRole}.Sanitize()
+
+	stmts := []string{
+		createRole(WriterRole),
+		createRole(ReaderRole),
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", s),
+		fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s, %s", s, writer, reader),
+		// privileges on the existing tables
+		fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA %s TO %s", s, writer),
+		fmt.Sprintf("GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA %s TO %s", s, writer),
+		fmt.Sprintf("GRANT SELECT ON ALL TABLES IN SCHEMA %s TO %s", s, reader),
+		// privileges on the tables created by future migrations
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO %s", s, writer),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT USAGE, SELECT ON SEQUENCES TO %s", s, writer),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT SELECT ON TABLES TO %s", s, reader),
+	}
+	for _, user := range o.writers {
+		stmts = append(stmts, fmt.Sprintf("GRANT %s TO %s", writer, pgx.Identifier{user}.Sanitize()))
+	}
+	for _, user := range o.readers {
+		stmts = append(stmts, fmt.Sprintf("GRANT %s TO %s", reader, pgx.Identifier{user}.Sanitize()))
+	}
+	return stmts
+}
+
+// createRole returns a statement creating the group role unless it exists, as CREATE ROLE has no IF NOT EXISTS clause.
+func createRole(role string) string {
+	return fmt.Sprintf(
+		"DO $$ BEGIN IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = '%s') THEN CREATE ROLE %s NOLOGIN; END IF; END $$",
+		role,
+		role,
+	)
+}
+
+func schemaOrDefault(schema string) string {
+	if schema == "" {
+		return DefaultSchema
+	}
+	return schema
+}
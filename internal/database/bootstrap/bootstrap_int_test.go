@@ -0,0 +1,52 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/test/database"
+	"github.com/G-Research/yunikorn-history-server/test/util"
+)
+
+func TestBootstrap_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	cfg := database.GetTestPostgresConfig(ctx, t)
+	cfg.Schema = fmt.Sprintf("test_%s", util.GenerateRandomAlphanum(t, 5))
+	pool := database.GetTestConnectionPool(ctx, t, cfg)
+	t.Cleanup(func() {
+		database.DropTestSchema(ctx, t, cfg.Schema)
+	})
+
+	require.NoError(t, Bootstrap(ctx, pool, cfg.Schema))
+	// bootstrapping is idempotent
+	require.NoError(t, Bootstrap(ctx, pool, cfg.Schema))
+	// tables created by the migrations are covered by the default privileges
+	database.ApplyMigrations(ctx, t, cfg)
+
+	insert := "INSERT INTO legal_holds (partition, app_id, reason, created_by, created_at) VALUES ('default', 'app', 'test', 'ops', 0)"
+	asRole := func(role string, stmt string) error {
+		return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, "SET LOCAL ROLE "+role); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, stmt)
+			return err
+		})
+	}
+
+	assert.NoError(t, asRole(WriterRole, insert))
+	assert.NoError(t, asRole(WriterRole, "DELETE FROM legal_holds"))
+	assert.NoError(t, asRole(ReaderRole, "SELECT * FROM applications"))
+	assert.ErrorContains(t, asRole(ReaderRole, insert), "permission denied")
+	assert.ErrorContains(t, asRole(WriterRole, "DROP TABLE legal_holds"), "must be owner")
+}
@@ -0,0 +1,32 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatements(t *testing.T) {
+	stmts := Statements("", WithWriters("yhs"), WithReaders("yhs-replica"))
+
+	assert.Equal(t, []string{
+		"DO $$ BEGIN IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'uhs_writer') THEN CREATE ROLE uhs_writer NOLOGIN; END IF; END $$",
+		"DO $$ BEGIN IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'uhs_reader') THEN CREATE ROLE uhs_reader NOLOGIN; END IF; END $$",
+		`CREATE SCHEMA IF NOT EXISTS "public"`,
+		`GRANT USAGE ON SCHEMA "public" TO "uhs_writer", "uhs_reader"`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA "public" TO "uhs_writer"`,
+		`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA "public" TO "uhs_writer"`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "uhs_reader"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO "uhs_writer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT USAGE, SELECT ON SEQUENCES TO "uhs_writer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT SELECT ON TABLES TO "uhs_reader"`,
+		`GRANT "uhs_writer" TO "yhs"`,
+		`GRANT "uhs_reader" TO "yhs-replica"`,
+	}, stmts)
+}
+
+func TestStatements_QuotesIdentifiers(t *testing.T) {
+	stmts := Statements(`history"; DROP TABLE applications; --`)
+
+	assert.Equal(t, `CREATE SCHEMA IF NOT EXISTS "history""; DROP TABLE applications; --"`, stmts[2])
+}
@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	// ErrChecksumMismatch is returned if an applied migration was changed after it was applied.
+	ErrChecksumMismatch = errors.New("applied migration was changed")
+	// ErrMissingMigration is returned if an applied migration does not exist in the migrations directory.
+	ErrMissingMigration = errors.New("applied migration is missing")
+	// ErrOutOfOrder is returned if a migration older than the applied version was never applied,
+	// e.g. because it was merged after a newer migration had been applied.
+	ErrOutOfOrder = errors.New("migration is older than the applied version")
+)
+
+// upMigrationPattern matches the files of up migrations, which are named <version>_<title>.up.sql.
+var upMigrationPattern = regexp.MustCompile(`^([0-9]+)_(.*)\.up\.sql$`)
+
+// migrationFile is an up migration of the migrations directory.
+type migrationFile struct {
+	version  uint
+	name     string
+	checksum string
+}
+
+// readMigrationFiles returns the up migrations of the directory ordered by version, with the SHA-256 checksum
+// of their content.
+func readMigrationFiles(dir string) ([]migrationFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read migrations directory: %w", err)
+	}
+	var files []migrationFile
+	for _, entry := range entries {
+		match := upMigrationPattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version of migration %s: %w", entry.Name(), err)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read migration %s: %w", entry.Name(), err)
+		}
+		sum := sha256.Sum256(content)
+		files = append(files, migrationFile{version: uint(version), name: match[2], checksum: hex.EncodeToString(sum[:])})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].version < files[j].version })
+	return files, nil
+}
+
+// verifyMigrations checks the migration files against the checksums recorded for the applied migrations,
+// by version. appliedVersion is the version of the last applied migration, 0 if none was applied.
+//
+// It returns the files which were applied before checksums were recorded, as they were applied by a version of
+// the server which did not record checksums. They are trusted and their checksums recorded.
+func verifyMigrations(files []migrationFile, recorded map[uint]string, appliedVersion uint) ([]migrationFile, error) {
+	byVersion := make(map[uint]migrationFile, len(files))
+	for _, f := range files {
+		byVersion[f.version] = f
+	}
+	versions := make([]uint, 0, len(recorded))
+	for version := range recorded {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	var errs []error
+	for _, version := range versions {
+		f, ok := byVersion[version]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %d", ErrMissingMigration, version))
+			continue
+		}
+		if f.checksum != recorded[version] {
+			errs = append(errs, fmt.Errorf("%w: %d_%s has checksum %s, applied %s",
+				ErrChecksumMismatch, version, f.name, f.checksum, recorded[version]))
+		}
+	}
+
+	var unrecorded []migrationFile
+	for _, f := range files {
+		if f.version > appliedVersion {
+			break
+		}
+		if _, ok := recorded[f.version]; ok {
+			continue
+		}
+		if len(recorded) > 0 {
+			errs = append(errs, fmt.Errorf("%w %d: %d_%s was never applied", ErrOutOfOrder, appliedVersion, f.version, f.name))
+			continue
+		}
+		unrecorded = append(unrecorded, f)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return unrecorded, nil
+}
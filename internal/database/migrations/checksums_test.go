@@ -0,0 +1,121 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMigrationFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"2_second.up.sql":   "CREATE TABLE b();",
+		"2_second.down.sql": "DROP TABLE b;",
+		"10_third.up.sql":   "CREATE TABLE c();",
+		"1_first.up.sql":    "CREATE TABLE a();",
+		"README.md":         "not a migration",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	files, err := readMigrationFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, []uint{1, 2, 10}, []uint{files[0].version, files[1].version, files[2].version})
+	assert.Equal(t, "first", files[0].name)
+	assert.Len(t, files[0].checksum, 64)
+	assert.NotEqual(t, files[0].checksum, files[1].checksum)
+
+	_, err = readMigrationFiles(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestReadRepositoryMigrations(t *testing.T) {
+	files, err := readMigrationFiles("../../../migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for i := 1; i < len(files); i++ {
+		assert.Less(t, files[i-1].version, files[i].version, "migration versions must be unique")
+	}
+}
+
+func TestVerifyMigrations(t *testing.T) {
+	files := []migrationFile{
+		{version: 1, name: "first", checksum: "a"},
+		{version: 2, name: "second", checksum: "b"},
+		{version: 3, name: "third", checksum: "c"},
+	}
+	tests := map[string]struct {
+		files          []migrationFile
+		recorded       map[uint]string
+		appliedVersion uint
+		wantUnrecorded []uint
+		wantErr        error
+	}{
+		"nothing applied": {
+			files: files,
+		},
+		"all applied": {
+			files:          files,
+			recorded:       map[uint]string{1: "a", 2: "b", 3: "c"},
+			appliedVersion: 3,
+		},
+		"new migration": {
+			files:          files,
+			recorded:       map[uint]string{1: "a", 2: "b"},
+			appliedVersion: 2,
+		},
+		"applied before checksums were recorded": {
+			files:          files,
+			appliedVersion: 2,
+			wantUnrecorded: []uint{1, 2},
+		},
+		"changed migration": {
+			files:          files,
+			recorded:       map[uint]string{1: "a", 2: "changed"},
+			appliedVersion: 2,
+			wantErr:        ErrChecksumMismatch,
+		},
+		"missing migration": {
+			files:          files[1:],
+			recorded:       map[uint]string{1: "a", 2: "b"},
+			appliedVersion: 2,
+			wantErr:        ErrMissingMigration,
+		},
+		"out of order migration": {
+			files:          files,
+			recorded:       map[uint]string{1: "a", 3: "c"},
+			appliedVersion: 3,
+			wantErr:        ErrOutOfOrder,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			unrecorded, err := verifyMigrations(tt.files, tt.recorded, tt.appliedVersion)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var versions []uint
+			for _, f := range unrecorded {
+				versions = append(versions, f.version)
+			}
+			assert.Equal(t, tt.wantUnrecorded, versions)
+		})
+	}
+}
+
+func TestVerifyMigrationsReportsAllErrors(t *testing.T) {
+	files := []migrationFile{{version: 1, name: "first", checksum: "changed"}, {version: 2, name: "second", checksum: "b"}}
+	_, err := verifyMigrations(files, map[uint]string{1: "a", 3: "c"}, 3)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.ErrorIs(t, err, ErrMissingMigration)
+	assert.ErrorIs(t, err, ErrOutOfOrder)
+	assert.EqualError(t, err, "applied migration was changed: 1_first has checksum changed, applied a\n"+
+		"applied migration is missing: 3\n"+
+		"migration is older than the applied version 3: 2_second was never applied")
+}
@@ -1,21 +1,32 @@
 package migrations
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/G-Research/yunikorn-history-server/internal/config"
 	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
 	"github.com/G-Research/yunikorn-history-server/internal/log"
 )
 
+// checksumsTable records the checksum of every applied up migration, so that changed migrations are detected.
+const checksumsTable = "schema_migration_checksums"
+
 type GoMigrate struct {
 	migrator      *migrate.Migrate
 	migrationsDir string
+	connString    string
+	// lockKey identifies the advisory lock which serializes the migration runs on the schema, so that replicas
+	// starting simultaneously verify and apply the migrations one after the other. It differs from the advisory lock
+	// golang-migrate takes on its own connection while applying the migrations.
+	lockKey string
 }
 
 func New(cfg *config.PostgresConfig, migrationsDir string) (*GoMigrate, error) {
@@ -26,6 +37,8 @@ func New(cfg *config.PostgresConfig, migrationsDir string) (*GoMigrate, error) {
 	return &GoMigrate{
 		migrator:      m,
 		migrationsDir: migrationsDir,
+		connString:    postgres.BuildConnectionStringFromConfig(cfg),
+		lockKey:       fmt.Sprintf("yunikorn-history-server/migrations/%s/%s", cfg.DbName, cfg.Schema),
 	}, nil
 }
 
@@ -35,10 +48,57 @@ func getMigrator(cfg *config.PostgresConfig, migrationsDir string) (*migrate.Mig
 	return migrate.New(source, connString)
 }
 
+// Up verifies the checksums of the applied migrations and applies the new migrations. It refuses to apply
+// migrations if an applied migration was changed or removed, or if a migration older than the applied version
+// was never applied.
 func (m *GoMigrate) Up() (applied bool, err error) {
 	log.Logger.Info("running migrate up")
 
-	err = m.migrator.Up()
+	err = m.withLock(context.Background(), func(ctx context.Context, conn *pgx.Conn) error {
+		files, err := readMigrationFiles(m.migrationsDir)
+		if err != nil {
+			return err
+		}
+		recorded, err := recordedChecksums(ctx, conn)
+		if err != nil {
+			return err
+		}
+		before, dirty, err := m.version()
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return migrate.ErrDirty{Version: int(before)}
+		}
+		unrecorded, err := verifyMigrations(files, recorded, before)
+		if err != nil {
+			return fmt.Errorf("refusing to run migrations: %w", err)
+		}
+		if len(unrecorded) > 0 {
+			log.Logger.Infow("recording checksums of migrations applied before checksums were recorded", "count", len(unrecorded))
+		}
+		if err := recordChecksums(ctx, conn, unrecorded); err != nil {
+			return err
+		}
+
+		upErr := m.migrator.Up()
+		// record the migrations applied before a failing migration
+		after, dirty, err := m.version()
+		if err != nil {
+			return errors.Join(upErr, err)
+		}
+		var newlyApplied []migrationFile
+		for _, f := range files {
+			// the migration of a dirty version failed
+			if f.version > before && (f.version < after || f.version == after && !dirty) {
+				newlyApplied = append(newlyApplied, f)
+			}
+		}
+		if err := recordChecksums(ctx, conn, newlyApplied); err != nil {
+			return errors.Join(upErr, err)
+		}
+		return upErr
+	})
 	if err != nil {
 		if errors.Is(err, migrate.ErrNoChange) {
 			log.Logger.Info("no change after running up migrations")
@@ -53,7 +113,18 @@ func (m *GoMigrate) Up() (applied bool, err error) {
 func (m *GoMigrate) Down() (applied bool, err error) {
 	log.Logger.Info("running migrate down")
 
-	err = m.migrator.Down()
+	err = m.withLock(context.Background(), func(ctx context.Context, conn *pgx.Conn) error {
+		downErr := m.migrator.Down()
+		// forget the checksums of the rolled back migrations, also if a migration failed
+		version, _, err := m.version()
+		if err != nil {
+			return errors.Join(downErr, err)
+		}
+		if _, err := conn.Exec(ctx, "DELETE FROM "+checksumsTable+" WHERE version > $1", version); err != nil {
+			return errors.Join(downErr, fmt.Errorf("could not delete migration checksums: %w", err))
+		}
+		return downErr
+	})
 	if err != nil {
 		if errors.Is(err, migrate.ErrNoChange) {
 			log.Logger.Info("no change after running down migrations")
@@ -64,3 +135,71 @@ func (m *GoMigrate) Down() (applied bool, err error) {
 
 	return true, nil
 }
+
+// version returns the version of the last applied migration, 0 if none was applied. The migration of a dirty
+// version failed.
+func (m *GoMigrate) version() (uint, bool, error) {
+	version, dirty, err := m.migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("could not get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// withLock runs fn while holding the advisory lock of the migrations, on a connection on which the checksums table
+// exists.
+func (m *GoMigrate) withLock(ctx context.Context, fn func(context.Context, *pgx.Conn) error) error {
+	conn, err := pgx.Connect(ctx, m.connString)
+	if err != nil {
+		return fmt.Errorf("could not connect to database: %w", err)
+	}
+	defer func() { _ = conn.Close(ctx) }()
+
+	// the lock is released when the connection is closed, even if unlocking fails
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock(hashtext($1))", m.lockKey); err != nil {
+		return fmt.Errorf("could not lock migrations: %w", err)
+	}
+	defer func() { _, _ = conn.Exec(ctx, "SELECT pg_advisory_unlock(hashtext($1))", m.lockKey) }()
+
+	createSQL := `CREATE TABLE IF NOT EXISTS ` + checksumsTable + ` (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now())`
+	if _, err := conn.Exec(ctx, createSQL); err != nil {
+		return fmt.Errorf("could not create migration checksums table: %w", err)
+	}
+	return fn(ctx, conn)
+}
+
+func recordedChecksums(ctx context.Context, conn *pgx.Conn) (map[uint]string, error) {
+	rows, err := conn.Query(ctx, "SELECT version, checksum FROM "+checksumsTable)
+	if err != nil {
+		return nil, fmt.Errorf("could not get migration checksums: %w", err)
+	}
+	defer rows.Close()
+	recorded := make(map[uint]string)
+	for rows.Next() {
+		var version int64
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("could not scan migration checksum: %w", err)
+		}
+		recorded[uint(version)] = checksum
+	}
+	return recorded, rows.Err()
+}
+
+func recordChecksums(ctx context.Context, conn *pgx.Conn, files []migrationFile) error {
+	for _, f := range files {
+		_, err := conn.Exec(ctx, "INSERT INTO "+checksumsTable+" (version, name, checksum) VALUES ($1, $2, $3)",
+			int64(f.version), f.name, f.checksum)
+		if err != nil {
+			return fmt.Errorf("could not record checksum of migration %d: %w", f.version, err)
+		}
+	}
+	return nil
+}
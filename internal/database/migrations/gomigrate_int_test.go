@@ -2,11 +2,14 @@ package migrations
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 
-	"github.com/G-Research/yunikorn-history-server/test/config"
 	"github.com/G-Research/yunikorn-history-server/test/database"
 )
 
@@ -17,13 +20,7 @@ func TestGoMigrate_Integration(t *testing.T) {
 
 	ctx := context.Background()
 
-	schema := database.CreateTestSchema(ctx, t)
-	t.Cleanup(func() {
-		database.DropTestSchema(ctx, t, schema)
-	})
-
-	cfg := config.GetTestPostgresConfig()
-	cfg.Schema = schema
+	cfg := database.CreateTestSchema(ctx, t)
 	m, err := New(cfg, "../../../migrations")
 	if err != nil {
 		t.Fatalf("could not create migrator: %v", err)
@@ -53,3 +50,53 @@ func TestGoMigrate_Integration(t *testing.T) {
 		t.Fatalf("error running migrations down: %v", err)
 	}
 }
+
+func TestGoMigrateChecksums_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	cfg := database.CreateTestSchema(ctx, t)
+
+	dir := t.TempDir()
+	files, err := filepath.Glob("../../../migrations/*.sql")
+	require.NoError(t, err)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, filepath.Base(file)), content, 0o600))
+	}
+
+	// replicas starting simultaneously apply the migrations once
+	results := make(chan bool, 2)
+	var g errgroup.Group
+	for range 2 {
+		g.Go(func() error {
+			m, err := New(cfg, dir)
+			if err != nil {
+				return err
+			}
+			applied, err := m.Up()
+			results <- applied
+			return err
+		})
+	}
+	require.NoError(t, g.Wait())
+	close(results)
+	var appliedCount int
+	for applied := range results {
+		if applied {
+			appliedCount++
+		}
+	}
+	assert.Equal(t, 1, appliedCount)
+
+	// changed migrations are detected
+	changed := filepath.Join(dir, filepath.Base(files[1]))
+	require.NoError(t, os.WriteFile(changed, []byte("-- changed\n"), 0o600))
+	m, err := New(cfg, dir)
+	require.NoError(t, err)
+	_, err = m.Up()
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
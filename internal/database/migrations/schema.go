@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// MinSchemaVersion and MaxSchemaVersion bound the versions of the database schema this binary is compatible with.
+// MaxSchemaVersion must be the version of the latest migration, MinSchemaVersion must be raised
+// when the queries depend on a new migration.
+const (
+	MinSchemaVersion uint = 20261018140000
+	MaxSchemaVersion uint = 20261018160000
+)
+
+// undefinedTable is the SQLSTATE code of queries on a table that does not exist.
+const undefinedTable = "42P01"
+
+// ErrIncompatibleSchema is returned if the version of the database schema is not supported by this binary.
+var ErrIncompatibleSchema = errors.New("incompatible database schema")
+
+// Querier is the subset of a connection or pool required to read the schema version.
+type Querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// SchemaVersion returns the version of the database schema recorded by golang-migrate and whether the last migration
+// failed. The version is 0 if no migration was applied.
+func SchemaVersion(ctx context.Context, db Querier) (version uint, dirty bool, err error) {
+	var v int64
+	err = db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&v, &dirty)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.As(err, &pgErr) && pgErr.Code == undefinedTable:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("could not read schema version: %w", err)
+	}
+	return uint(v), dirty, nil
+}
+
+// CheckSchemaVersion returns an ErrIncompatibleSchema error describing how to resolve the incompatibility
+// if the database schema is not migrated to a version between MinSchemaVersion and MaxSchemaVersion.
+func CheckSchemaVersion(ctx context.Context, db Querier) error {
+	version, dirty, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	switch {
+	case version == 0:
+		return fmt.Errorf("%w: no migrations applied, run 'migrate up' first", ErrIncompatibleSchema)
+	case dirty:
+		return fmt.Errorf("%w: migration %d failed, fix the schema and force the version before running 'migrate up'",
+			ErrIncompatibleSchema, version)
+	case version < MinSchemaVersion:
+		return fmt.Errorf("%w: schema version %d is older than the minimum version %d, run 'migrate up'",
+			ErrIncompatibleSchema, version, MinSchemaVersion)
+	case version > MaxSchemaVersion:
+		return fmt.Errorf("%w: schema version %d is newer than the maximum version %d, upgrade the server",
+			ErrIncompatibleSchema, version, MaxSchemaVersion)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRow struct {
+	version int64
+	dirty   bool
+	err     error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int64) = r.version
+	*dest[1].(*bool) = r.dirty
+	return nil
+}
+
+type fakeQuerier struct {
+	row fakeRow
+}
+
+func (q fakeQuerier) QueryRow(context.Context, string, ...any) pgx.Row {
+	return q.row
+}
+
+func TestSchemaVersionRangeMatchesMigrations(t *testing.T) {
+	files, err := readMigrationFiles("../../../migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	assert.Equal(t, files[len(files)-1].version, MaxSchemaVersion, "MaxSchemaVersion must be the latest migration")
+	assert.LessOrEqual(t, MinSchemaVersion, MaxSchemaVersion)
+	assert.True(t, func() bool {
+		for _, f := range files {
+			if f.version == MinSchemaVersion {
+				return true
+			}
+		}
+		return false
+	}(), "MinSchemaVersion must be the version of a migration")
+}
+
+func TestCheckSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     fakeRow
+		wantErr string
+	}{
+		{name: "supported version", row: fakeRow{version: int64(MaxSchemaVersion)}},
+		{name: "no schema_migrations table", row: fakeRow{err: &pgconn.PgError{Code: "42P01"}},
+			wantErr: "no migrations applied"},
+		{name: "no rows", row: fakeRow{err: pgx.ErrNoRows}, wantErr: "no migrations applied"},
+		{name: "dirty", row: fakeRow{version: int64(MaxSchemaVersion), dirty: true}, wantErr: "failed"},
+		{name: "older", row: fakeRow{version: int64(MinSchemaVersion) - 1}, wantErr: "run 'migrate up'"},
+		{name: "newer", row: fakeRow{version: int64(MaxSchemaVersion) + 1}, wantErr: "upgrade the server"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSchemaVersion(context.Background(), fakeQuerier{row: tt.row})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrIncompatibleSchema)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckSchemaVersionQueryError(t *testing.T) {
+	queryErr := errors.New("connection refused")
+	err := CheckSchemaVersion(context.Background(), fakeQuerier{row: fakeRow{err: queryErr}})
+	assert.ErrorIs(t, err, queryErr)
+	assert.NotErrorIs(t, err, ErrIncompatibleSchema)
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// cloudSQLLoginScope is the OAuth2 scope of access tokens used to log in to Cloud SQL with IAM database authentication.
+const cloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+
+// TokenSource returns short-lived tokens used as database password by IAM database authentication.
+type TokenSource interface {
+	// Token returns a token for the given database user.
+	Token(ctx context.Context, user string) (string, error)
+}
+
+// NewIAMTokenSource creates the TokenSource of the configured IAM auth method. It returns nil for password auth.
+func NewIAMTokenSource(ctx context.Context, cfg *config.PostgresConfig) (TokenSource, error) {
+	switch cfg.AuthMethod {
+	case config.DBAuthMethodAWSIAM:
+		var opts []func(*awsconfig.LoadOptions) error
+		if cfg.AWSRegion != "" {
+			opts = append(opts, awsconfig.WithRegion(cfg.AWSRegion))
+		}
+		// the SDK sends its requests with the default transport, to which the egress configuration applies
+		opts = append(opts, awsconfig.WithHTTPClient(http.DefaultClient))
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not load AWS config: %v", err)
+		}
+		return &rdsTokenSource{
+			endpoint:    fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			region:      awsCfg.Region,
+			credentials: awsCfg.Credentials,
+		}, nil
+	case config.DBAuthMethodGCPIAM:
+		ts, err := google.DefaultTokenSource(ctx, cloudSQLLoginScope)
+		if err != nil {
+			return nil, fmt.Errorf("could not load GCP credentials: %v", err)
+		}
+		return &cloudSQLTokenSource{tokenSource: ts}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// WithTokenAuth sets the password of every new connection to a token of the TokenSource, so that connections
+// are established with a valid token although each token expires after a few minutes.
+func WithTokenAuth(ts TokenSource) Option {
+	if ts == nil {
+		return func(*pgxpool.Config) {}
+	}
+	return WithBeforeConnect(func(ctx context.Context, cc *pgx.ConnConfig) error {
+		token, err := ts.Token(ctx, cc.User)
+		if err != nil {
+			return fmt.Errorf("could not get database auth token: %w", err)
+		}
+		cc.Password = token
+		return nil
+	})
+}
+
+// rdsTokenSource signs RDS IAM auth tokens with the AWS credentials. Tokens are valid for 15 minutes.
+type rdsTokenSource struct {
+	endpoint    string
+	region      string
+	credentials aws.CredentialsProvider
+}
+
+func (s *rdsTokenSource) Token(ctx context.Context, user string) (string, error) {
+	return auth.BuildAuthToken(ctx, s.endpoint, s.region, user, s.credentials)
+}
+
+// cloudSQLTokenSource uses OAuth2 access tokens of the GCP service account, which are cached until they expire.
+type cloudSQLTokenSource struct {
+	tokenSource oauth2.TokenSource
+}
+
+func (s *cloudSQLTokenSource) Token(_ context.Context, _ string) (string, error) {
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type fakeTokenSource struct {
+	tokens int
+	err    error
+}
+
+func (s *fakeTokenSource) Token(_ context.Context, user string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.tokens++
+	return user + "-token-" + string(rune('0'+s.tokens)), nil
+}
+
+func TestWithTokenAuth(t *testing.T) {
+	ts := &fakeTokenSource{}
+	poolConfig := &pgxpool.Config{}
+	resolveUser := func(_ context.Context, cc *pgx.ConnConfig) error {
+		cc.User = "yhs"
+		cc.Password = "static"
+		return nil
+	}
+	WithBeforeConnect(resolveUser)(poolConfig)
+	WithTokenAuth(ts)(poolConfig)
+
+	// every connection uses a new token for the user resolved by the previous hook
+	cc := &pgx.ConnConfig{}
+	require.NoError(t, poolConfig.BeforeConnect(context.Background(), cc))
+	assert.Equal(t, "yhs-token-1", cc.Password)
+	require.NoError(t, poolConfig.BeforeConnect(context.Background(), cc))
+	assert.Equal(t, "yhs-token-2", cc.Password)
+
+	ts.err = errors.New("expired credentials")
+	assert.EqualError(
+		t,
+		poolConfig.BeforeConnect(context.Background(), cc),
+		"could not get database auth token: expired credentials",
+	)
+
+	poolConfig = &pgxpool.Config{}
+	WithTokenAuth(nil)(poolConfig)
+	assert.Nil(t, poolConfig.BeforeConnect)
+}
+
+func TestRDSTokenSource(t *testing.T) {
+	ts := &rdsTokenSource{
+		endpoint:    "yhs.abc.eu-west-1.rds.amazonaws.com:5432",
+		region:      "eu-west-1",
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+
+	token, err := ts.Token(context.Background(), "yhs")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, "yhs.abc.eu-west-1.rds.amazonaws.com:5432?"), token)
+	query, err := url.ParseQuery(strings.SplitN(token, "?", 2)[1])
+	require.NoError(t, err)
+	assert.Equal(t, "connect", query.Get("Action"))
+	assert.Equal(t, "yhs", query.Get("DBUser"))
+	assert.Contains(t, query.Get("X-Amz-Credential"), "AKID/")
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
+
+func TestCloudSQLTokenSource(t *testing.T) {
+	ts := &cloudSQLTokenSource{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: "ya29.access-token",
+		Expiry:      time.Now().Add(time.Hour),
+	})}
+
+	token, err := ts.Token(context.Background(), "yhs@project.iam")
+	require.NoError(t, err)
+	assert.Equal(t, "ya29.access-token", token)
+}
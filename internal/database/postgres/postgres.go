@@ -3,19 +3,194 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
 )
 
-func NewConnectionPool(ctx context.Context, cfg *config.PostgresConfig) (*pgxpool.Pool, error) {
-	return pgxpool.New(ctx, buildConnectionInfoFromConfig(cfg))
+type Option func(*pgxpool.Config)
+
+// WithBeforeConnect adds a hook which is called before a connection is established, e.g. to set rotated credentials.
+// Hooks are called in the order they are added, so that later hooks can override the settings of earlier ones.
+func WithBeforeConnect(beforeConnect func(context.Context, *pgx.ConnConfig) error) Option {
+	return func(c *pgxpool.Config) {
+		if beforeConnect == nil {
+			return
+		}
+		previous := c.BeforeConnect
+		if previous == nil {
+			c.BeforeConnect = beforeConnect
+			return
+		}
+		c.BeforeConnect = func(ctx context.Context, cc *pgx.ConnConfig) error {
+			if err := previous(ctx, cc); err != nil {
+				return err
+			}
+			return beforeConnect(ctx, cc)
+		}
+	}
+}
+
+// WithReadOnly makes every transaction read-only, so that writes fail even if the user is allowed to write.
+func WithReadOnly() Option {
+	return func(c *pgxpool.Config) {
+		c.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+}
+
+// QueryClass is the class of the queries of a context, whose statements are limited by the statement timeout
+// configured for the class.
+type QueryClass string
+
+const (
+	// QueryClassInteractive are the queries of the API requests of the UI and clients.
+	QueryClassInteractive QueryClass = "interactive"
+	// QueryClassAnalytics are the queries of the analytics and reports endpoints.
+	QueryClassAnalytics QueryClass = "analytics"
+	// QueryClassExport are the queries of responses streamed as newline delimited JSON.
+	QueryClassExport QueryClass = "export"
+)
+
+type queryClassKey struct{}
+
+// WithQueryClass returns a copy of the context whose queries are of the class.
+// The queries of contexts without class, e.g. the writes of the ingestion of events, keep the statement timeout of
+// the database.
+func WithQueryClass(ctx context.Context, class QueryClass) context.Context {
+	return context.WithValue(ctx, queryClassKey{}, class)
+}
+
+// QueryClassFromContext returns the query class of the context, or an empty class if it has none.
+func QueryClassFromContext(ctx context.Context) QueryClass {
+	class, _ := ctx.Value(queryClassKey{}).(QueryClass)
+	return class
+}
+
+type txKey struct{}
+
+// WithTx returns a copy of the context whose queries are run in the transaction, e.g. to read an exported snapshot,
+// instead of on a connection of the pool. The transaction is bound to a single connection, so the queries of the
+// context must not run concurrently.
+func WithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction the queries of the context are run in, or nil if it has none.
+func TxFromContext(ctx context.Context) pgx.Tx {
+	tx, _ := ctx.Value(txKey{}).(pgx.Tx)
+	return tx
+}
+
+// QueryTimer accumulates the time the queries of a context take, from sending them until their rows are closed,
+// which bounds the time the database spends on them.
+type QueryTimer struct {
+	elapsed atomic.Int64
+}
+
+// Elapsed returns the time the queries took so far.
+func (t *QueryTimer) Elapsed() time.Duration {
+	return time.Duration(t.elapsed.Load())
+}
+
+type queryTimerKey struct{}
+
+type queryStartKey struct{}
+
+// WithQueryTimer returns a copy of the context whose queries are timed by the returned timer.
+func WithQueryTimer(ctx context.Context) (context.Context, *QueryTimer) {
+	timer := &QueryTimer{}
+	return context.WithValue(ctx, queryTimerKey{}, timer), timer
+}
+
+// queryTimeTracer adds the time of the queries to the timer of their context, if any.
+type queryTimeTracer struct{}
+
+func (queryTimeTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	if _, ok := ctx.Value(queryTimerKey{}).(*QueryTimer); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, time.Now())
+}
+
+func (queryTimeTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	timer, ok := ctx.Value(queryTimerKey{}).(*QueryTimer)
+	if !ok {
+		return
+	}
+	if start, ok := ctx.Value(queryStartKey{}).(time.Time); ok {
+		timer.elapsed.Add(int64(time.Since(start)))
+	}
+}
+
+// statementTimeoutKey is the key of the custom data of a connection holding the statement timeout set on its
+// session, which is absent while the session has the statement timeout of the database.
+const statementTimeoutKey = "yhs_statement_timeout"
+
+// withStatementTimeouts sets the statement timeout of the session of a connection to the timeout of the query class
+// of the context it is acquired with, or resets it for contexts without class. The statement is only sent when the
+// timeout of the session differs, so connections acquired by queries of the same class are not delayed.
+func withStatementTimeouts(timeouts config.StatementTimeouts) Option {
+	return func(c *pgxpool.Config) {
+		c.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+			var timeout time.Duration
+			switch QueryClassFromContext(ctx) {
+			case QueryClassInteractive:
+				timeout = timeouts.Interactive
+			case QueryClassAnalytics:
+				timeout = timeouts.Analytics
+			case QueryClassExport:
+				timeout = timeouts.Export
+			}
+			data := conn.PgConn().CustomData()
+			current, _ := data[statementTimeoutKey].(time.Duration)
+			if timeout == current {
+				return true
+			}
+			sql := "RESET statement_timeout"
+			if timeout > 0 {
+				sql = fmt.Sprintf("SET statement_timeout = %d", timeout.Milliseconds())
+			}
+			if _, err := conn.Exec(ctx, sql); err != nil {
+				// the connection is destroyed, as the timeout of its session is unknown
+				log.FromContext(ctx).Warnw("could not set statement timeout of connection", "error", err)
+				return false
+			}
+			if timeout > 0 {
+				data[statementTimeoutKey] = timeout
+			} else {
+				delete(data, statementTimeoutKey)
+			}
+			return true
+		}
+	}
+}
+
+func NewConnectionPool(ctx context.Context, cfg *config.PostgresConfig, opts ...Option) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(buildConnectionInfoFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = queryTimeTracer{}
+	if cfg.StatementTimeouts != (config.StatementTimeouts{}) {
+		withStatementTimeouts(cfg.StatementTimeouts)(poolConfig)
+	}
+	for _, opt := range opts {
+		opt(poolConfig)
+	}
+	return pgxpool.NewWithConfig(ctx, poolConfig)
 }
 
 func BuildConnectionStringFromConfig(cfg *config.PostgresConfig) string {
-	connString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.DbName)
+	// credentials are escaped as IAM auth tokens contain reserved characters
+	userinfo := url.UserPassword(cfg.Username, cfg.Password)
+	connString := fmt.Sprintf("postgres://%s@%s:%d/%s", userinfo, cfg.Host, cfg.Port, cfg.DbName)
 
 	var additonalParams []string
 	if cfg.SSLMode != "" {
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
+)
+
+// recordAllocationConstraints records the placement constraints of the requests of the application, and the nodes its
+// allocations were placed on. The constraints of a request are kept once it is allocated, since YuniKorn only reports
+// them while it is pending.
+func recordAllocationConstraints(ctx context.Context, tx pgx.Tx, applicationID string, app *dao.ApplicationDAOInfo) error {
+	upsertSQL := `INSERT INTO allocation_constraints (application_id, allocation_key, task_group_name, placeholder,
+			required_node_id, node_selector, affinity, tolerations, request_time, node_id, allocation_time)
+		VALUES (@application_id, @allocation_key, @task_group_name, @placeholder, @required_node_id, @node_selector,
+			@affinity, @tolerations, @request_time, @node_id, @allocation_time)
+		ON CONFLICT (application_id, allocation_key) DO UPDATE SET
+			task_group_name = COALESCE(EXCLUDED.task_group_name, allocation_constraints.task_group_name),
+			placeholder = allocation_constraints.placeholder OR EXCLUDED.placeholder,
+			required_node_id = COALESCE(EXCLUDED.required_node_id, allocation_constraints.required_node_id),
+			node_selector = COALESCE(EXCLUDED.node_selector, allocation_constraints.node_selector),
+			affinity = COALESCE(EXCLUDED.affinity, allocation_constraints.affinity),
+			tolerations = COALESCE(EXCLUDED.tolerations, allocation_constraints.tolerations),
+			request_time = COALESCE(allocation_constraints.request_time, EXCLUDED.request_time),
+			node_id = COALESCE(EXCLUDED.node_id, allocation_constraints.node_id),
+			allocation_time = COALESCE(EXCLUDED.allocation_time, allocation_constraints.allocation_time)`
+
+	for _, ask := range app.Requests {
+		if ask == nil || ask.AllocationKey == "" {
+			continue
+		}
+		args := constraintArgs(applicationID, ask.AllocationKey, ask.TaskGroupName, ask.Placeholder, ask.AllocationTags)
+		if ask.RequiredNodeID != "" {
+			args["required_node_id"] = ask.RequiredNodeID
+		}
+		if ask.RequestTime != 0 {
+			args["request_time"] = ask.RequestTime
+		}
+		if _, err := tx.Exec(ctx, upsertSQL, args); err != nil {
+			return fmt.Errorf("could not record constraints of request %s in DB: %v", ask.AllocationKey, err)
+		}
+	}
+	for _, alloc := range app.Allocations {
+		if alloc == nil || alloc.AllocationKey == "" {
+			continue
+		}
+		args := constraintArgs(applicationID, alloc.AllocationKey, alloc.TaskGroupName, alloc.Placeholder, alloc.AllocationTags)
+		if alloc.RequestTime != 0 {
+			args["request_time"] = alloc.RequestTime
+		}
+		if alloc.NodeID != "" {
+			args["node_id"] = alloc.NodeID
+		}
+		if alloc.AllocationTime != 0 {
+			args["allocation_time"] = alloc.AllocationTime
+		}
+		if _, err := tx.Exec(ctx, upsertSQL, args); err != nil {
+			return fmt.Errorf("could not record constraints of allocation %s in DB: %v", alloc.AllocationKey, err)
+		}
+	}
+	return nil
+}
+
+// constraintArgs returns the arguments of the constraints common to requests and allocations, taken from their
+// task group and allocation tags. Unknown constraints are nil, so that the stored ones are kept.
+func constraintArgs(
+	applicationID, allocationKey, taskGroupName string,
+	placeholder bool,
+	allocationTags map[string]string,
+) pgx.NamedArgs {
+	args := pgx.NamedArgs{
+		"application_id":   applicationID,
+		"allocation_key":   allocationKey,
+		"task_group_name":  nil,
+		"placeholder":      placeholder,
+		"required_node_id": nil,
+		"node_selector":    nil,
+		"affinity":         nil,
+		"tolerations":      nil,
+		"request_time":     nil,
+		"node_id":          nil,
+		"allocation_time":  nil,
+	}
+	if node := allocationTags[tags.RequiredNodeTag]; node != "" {
+		args["required_node_id"] = node
+	}
+	if taskGroupName == "" {
+		return args
+	}
+	args["task_group_name"] = taskGroupName
+	if taskGroup := tags.FindTaskGroup(allocationTags, taskGroupName); taskGroup != nil {
+		if len(taskGroup.NodeSelector) > 0 {
+			args["node_selector"] = taskGroup.NodeSelector
+		}
+		args["affinity"] = rawJSON(taskGroup.Affinity)
+		args["tolerations"] = rawJSON(taskGroup.Tolerations)
+	}
+	return args
+}
+
+// rawJSON returns the JSON value as an argument, or nil if it is empty or null.
+func rawJSON(value json.RawMessage) any {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	return value
+}
+
+// GetAllocationConstraints returns the placement constraints of the allocations of the application, in the order
+// they were requested, with the zone and rack of the nodes they were placed on. The application is looked up in
+// both tiers.
+func (s *PostgresRepository) GetAllocationConstraints(
+	ctx context.Context, partition, queue, appID string) ([]*model.AllocationConstraints, error) {
+	query := `SELECT c.*, n.zone, n.rack FROM allocation_constraints c
+		JOIN ` + applicationTiersTable.From() + ` ON applications.id = c.application_id
+		LEFT JOIN nodes n ON n.node_id = c.node_id
+		WHERE applications.partition = $1 AND applications.queue_name = $2 AND applications.app_id = $3
+		ORDER BY c.request_time NULLS LAST, c.allocation_key`
+	rows, err := s.dbpool.Query(ctx, query, partition, queue, appID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get allocation constraints from DB: %v", err)
+	}
+
+	var constraints []*model.AllocationConstraints
+	err = forEachRow(rows, "allocation constraints", func(row *placementRow) error {
+		c := row.AllocationConstraints
+		c.Zone, c.Rack = row.NodeZone, row.NodeRack
+		constraints = append(constraints, &c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return constraints, nil
+}
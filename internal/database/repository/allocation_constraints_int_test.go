@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestAllocationConstraints_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	requestedAt := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	allocationTags := map[string]string{
+		tags.TaskGroupsTag: `[{"name": "executors", "nodeSelector": {"disktype": "ssd"},
+			"affinity": {"nodeAffinity": {}}}]`,
+	}
+	app := &dao.ApplicationDAOInfo{
+		ApplicationID: "constrained",
+		Partition:     "default",
+		QueueName:     "root.default",
+		Requests: []*dao.AllocationAskDAOInfo{
+			{
+				AllocationKey:  "executor-1",
+				AllocationTags: allocationTags,
+				TaskGroupName:  "executors",
+				RequestTime:    requestedAt.UnixNano(),
+			},
+			{
+				AllocationKey:  "daemon",
+				RequiredNodeID: "node-2",
+				RequestTime:    requestedAt.Add(time.Second).UnixNano(),
+			},
+		},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{app}))
+	// the requests are allocated, YuniKorn no longer reports their constraints
+	app.Requests = nil
+	app.Allocations = []*dao.AllocationDAOInfo{
+		{
+			AllocationKey:  "executor-1",
+			TaskGroupName:  "executors",
+			NodeID:         "node-1",
+			RequestTime:    requestedAt.UnixNano(),
+			AllocationTime: requestedAt.Add(time.Minute).UnixNano(),
+		},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{app}))
+	require.NoError(t, repo.UpsertNodes(ctx, []*dao.NodeDAOInfo{{
+		NodeID:     "node-1",
+		RackName:   "rack-1",
+		Attributes: map[string]string{config.DefaultZoneAttribute: "eu-west-1a"},
+	}}, "default"))
+
+	constraints, err := repo.GetAllocationConstraints(ctx, "default", "root.default", "constrained")
+	require.NoError(t, err)
+	require.Len(t, constraints, 2)
+	assert.Equal(t, "executor-1", constraints[0].AllocationKey)
+	assert.Equal(t, util.ToPtr("executors"), constraints[0].TaskGroupName)
+	assert.Equal(t, map[string]string{"disktype": "ssd"}, constraints[0].NodeSelector)
+	assert.JSONEq(t, `{"nodeAffinity": {}}`, string(constraints[0].Affinity))
+	assert.Empty(t, constraints[0].Tolerations)
+	assert.Equal(t, util.ToPtr("node-1"), constraints[0].NodeID)
+	assert.Equal(t, util.ToPtr(requestedAt.Add(time.Minute).UnixNano()), constraints[0].AllocationTime)
+	assert.Equal(t, util.ToPtr("eu-west-1a"), constraints[0].Zone)
+	assert.Equal(t, util.ToPtr("rack-1"), constraints[0].Rack)
+	assert.Equal(t, "daemon", constraints[1].AllocationKey)
+	assert.Equal(t, util.ToPtr("node-2"), constraints[1].RequiredNodeID)
+	assert.Nil(t, constraints[1].NodeID)
+
+	// the constraints are kept when the application is moved to the cold tier
+	app.Allocations = nil
+	app.State = "Completed"
+	app.FinishedTime = util.ToPtr(requestedAt.Add(time.Hour).UnixNano())
+	require.NoError(t, repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{app}))
+	_, err = repo.MoveApplicationsToColdTier(ctx, requestedAt.Add(2*time.Hour), 100)
+	require.NoError(t, err)
+	constraints, err = repo.GetAllocationConstraints(ctx, "default", "root.default", "constrained")
+	require.NoError(t, err)
+	assert.Len(t, constraints, 2)
+
+	// the constraints are deleted with their application
+	_, err = repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.default", requestedAt.Add(2*time.Hour), 100)
+	require.NoError(t, err)
+	var count int
+	err = connPool.QueryRow(ctx, "SELECT COUNT(*) FROM allocation_constraints WHERE allocation_key IN ('executor-1', 'daemon')").
+		Scan(&count)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,505 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// AnalyticsFieldType is the type of the values of a field of an analytics entity.
+type AnalyticsFieldType string
+
+const (
+	AnalyticsString  AnalyticsFieldType = "string"
+	AnalyticsInteger AnalyticsFieldType = "integer"
+	AnalyticsBoolean AnalyticsFieldType = "boolean"
+	// AnalyticsTime fields hold timestamps. They can be filtered, aggregated and bucketed, but not grouped by.
+	AnalyticsTime AnalyticsFieldType = "time"
+)
+
+// ErrInvalidAnalyticsQuery is returned if an analytics query references an unknown entity or field, or uses
+// a field in a way its type does not support.
+var ErrInvalidAnalyticsQuery = errors.New("invalid analytics query")
+
+// analyticsField is a field of an analytics entity, stored in a column of the table of the entity.
+type analyticsField struct {
+	fieldType AnalyticsFieldType
+	column    string
+	// encrypted fields can only be filtered by equality, and their groups are decrypted.
+	encrypted bool
+}
+
+// analyticsEntity whitelists the fields of a table which analytics queries can reference.
+type analyticsEntity struct {
+	table *sql.Table
+	// tiers selects the rows of all tiers of the table, if its rows are moved to the cold tier. Rows are moved once
+	// they are older than the cold tier boundary, so the time fields of a tiered entity bound the age of its rows.
+	tiers *sql.Table
+	// timeField is the time field which narrowing suggestions of slow queries of the entity refer to.
+	timeField string
+	fields    map[string]analyticsField
+}
+
+// analyticsEntities are the entities of analytics queries, keyed by name. Fields are named like the fields
+// of the API responses. Timestamps of applications are Unix nanoseconds.
+var analyticsEntities = map[string]analyticsEntity{
+	"applications": {
+		table:     applicationsTable,
+		tiers:     applicationTiersTable,
+		timeField: "submissionTime",
+		fields: map[string]analyticsField{
+			"partition":          {fieldType: AnalyticsString, column: "partition"},
+			"queueName":          {fieldType: AnalyticsString, column: "queue_name"},
+			"user":               {fieldType: AnalyticsString, column: "user", encrypted: true},
+			"applicationState":   {fieldType: AnalyticsString, column: "state"},
+			"workflowId":         {fieldType: AnalyticsString, column: "workflow_id"},
+			"hasReserved":        {fieldType: AnalyticsBoolean, column: "has_reserved"},
+			"maxRequestPriority": {fieldType: AnalyticsInteger, column: "max_request_priority"},
+			"priority":           {fieldType: AnalyticsInteger, column: "priority"},
+			"priorityClass":      {fieldType: AnalyticsString, column: "priority_class"},
+			"waitTime":           {fieldType: AnalyticsInteger, column: "wait_time"},
+			"duration":           {fieldType: AnalyticsInteger, column: "duration"},
+			"submissionTime":     {fieldType: AnalyticsTime, column: "submission_time"},
+			"finishedTime":       {fieldType: AnalyticsTime, column: "finished_time"},
+		},
+	},
+}
+
+// AnalyticsQuery aggregates the rows of an entity, optionally grouped by fields and by time buckets,
+// so that charts can be built without a bespoke endpoint each. Fields are resolved from the whitelist of
+// the entity, and values are only passed as positional arguments.
+type AnalyticsQuery struct {
+	Entity     string
+	Filters    []AnalyticsFilter
+	GroupBy    []string
+	TimeBucket *AnalyticsTimeBucket
+	Aggregates []AnalyticsAggregate
+	Limit      int
+	// Duplicates merges the runs of the applications in several clusters into their canonical run by the strategy,
+	// so that they are counted once. The runs are not merged if it is empty.
+	Duplicates DuplicateStrategy
+}
+
+// AnalyticsFilter compares a field with a value, or matches any of the values with the Equal operator.
+// Values have the Go type of the field: string, int64, bool or time.Time.
+type AnalyticsFilter struct {
+	Field    string
+	Operator sql.Operator
+	Values   []any
+}
+
+// AnalyticsTimeBucket groups the rows by the time bucket of a time field, starting in the location.
+type AnalyticsTimeBucket struct {
+	Field    string
+	Bucket   sql.TimeBucket
+	Location *time.Location
+}
+
+// AnalyticsAggregate is an aggregate function of a field, or of all rows for Count without field.
+type AnalyticsAggregate struct {
+	Function sql.AggregateFunction
+	Field    string
+}
+
+// Name returns the name of the aggregate in the rows of the results, e.g. count or avg(maxRequestPriority).
+func (a AnalyticsAggregate) Name() string {
+	if a.Field == "" {
+		return string(a.Function)
+	}
+	return fmt.Sprintf("%s(%s)", a.Function, a.Field)
+}
+
+// AnalyticsFieldTypeOf returns the type of the field of the entity.
+func AnalyticsFieldTypeOf(entity, field string) (AnalyticsFieldType, error) {
+	e, ok := analyticsEntities[entity]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown entity %q", ErrInvalidAnalyticsQuery, entity)
+	}
+	f, ok := e.fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown field %q of entity %s", ErrInvalidAnalyticsQuery, field, entity)
+	}
+	return f.fieldType, nil
+}
+
+// AnalyticsTimeField returns the time field bounding the rows of the entity, e.g. submissionTime of applications.
+func AnalyticsTimeField(entity string) string {
+	return analyticsEntities[entity].timeField
+}
+
+// AnalyticsColumn returns the column storing the field of the entity and whether its values are encrypted,
+// for the stores the entity is replicated to.
+func AnalyticsColumn(entity, field string) (column string, encrypted bool, err error) {
+	if _, err := AnalyticsFieldTypeOf(entity, field); err != nil {
+		return "", false, err
+	}
+	f := analyticsEntities[entity].fields[field]
+	return f.column, f.encrypted, nil
+}
+
+// ValidateAnalyticsQuery returns an ErrInvalidAnalyticsQuery error if the query references an unknown entity
+// or field, or uses a field in a way its type does not support.
+func ValidateAnalyticsQuery(query AnalyticsQuery) error {
+	_, err := analyticsQuery(query, nil, false)
+	return err
+}
+
+// QueryAnalytics runs the analytics query. Groups of encrypted fields are decrypted, but while the rows of
+// a group are encrypted with different keys, e.g. during key rotation, they are returned as separate groups.
+func (s *PostgresRepository) QueryAnalytics(ctx context.Context, query AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	builder, err := analyticsQuery(query, s.cipher, s.selectsColdTier(analyticsLowerBounds(query)...))
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not query analytics from DB: %v", err)
+	}
+	defer rows.Close()
+
+	entity := analyticsEntities[query.Entity]
+	var results []*model.AnalyticsRow
+	for rows.Next() {
+		var bucket *time.Time
+		var dest []any
+		if query.TimeBucket != nil {
+			dest = append(dest, &bucket)
+		}
+		groups := make([]any, len(query.GroupBy))
+		for i, name := range query.GroupBy {
+			groups[i] = scanTarget(entity.fields[name].fieldType)
+		}
+		aggregates := make([]*float64, len(query.Aggregates))
+		dest = append(dest, groups...)
+		for i := range aggregates {
+			dest = append(dest, &aggregates[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("could not scan analytics from DB: %v", err)
+		}
+
+		row := &model.AnalyticsRow{Bucket: bucket, Aggregates: make(map[string]*float64, len(aggregates))}
+		if len(groups) > 0 {
+			row.Groups = make(map[string]any, len(groups))
+		}
+		for i, name := range query.GroupBy {
+			value, err := s.groupValue(entity.fields[name], groups[i])
+			if err != nil {
+				return nil, err
+			}
+			row.Groups[name] = value
+		}
+		for i, aggregate := range query.Aggregates {
+			row.Aggregates[aggregate.Name()] = aggregates[i]
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read analytics from DB: %v", err)
+	}
+	return results, nil
+}
+
+// EstimateAnalytics estimates the rows and the cost of the analytics query from the plan of the database, without
+// running the query. The estimate is the one of Postgres, also if the queries are served by ClickHouse.
+func (s *PostgresRepository) EstimateAnalytics(ctx context.Context, query AnalyticsQuery) (*model.AnalyticsEstimate, error) {
+	builder, err := analyticsQuery(query, s.cipher, s.selectsColdTier(analyticsLowerBounds(query)...))
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	var plan []byte
+	if err := s.dbpool.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+sqlQuery, args...).Scan(&plan); err != nil {
+		return nil, fmt.Errorf("could not explain analytics query in DB: %v", err)
+	}
+	return analyticsEstimate(plan)
+}
+
+// explainPlan is a node of a plan in the JSON format of EXPLAIN.
+type explainPlan struct {
+	NodeType       string        `json:"Node Type"`
+	ParallelAware  bool          `json:"Parallel Aware"`
+	WorkersPlanned int           `json:"Workers Planned"`
+	PlanRows       float64       `json:"Plan Rows"`
+	TotalCost      float64       `json:"Total Cost"`
+	Plans          []explainPlan `json:"Plans"`
+}
+
+// scanNodes are the plan nodes reading the rows of tables. Bitmap index scans are left out, as their rows are
+// read by the bitmap heap scan above them.
+var scanNodes = map[string]bool{
+	"Seq Scan":         true,
+	"Index Scan":       true,
+	"Index Only Scan":  true,
+	"Bitmap Heap Scan": true,
+}
+
+// analyticsEstimate returns the estimate of the plan in the JSON format of EXPLAIN: the rows and the cost of the
+// root node, and the sum of the rows read by the scans.
+func analyticsEstimate(data []byte) (*model.AnalyticsEstimate, error) {
+	var plans []struct {
+		Plan explainPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal(data, &plans); err != nil || len(plans) == 0 {
+		return nil, fmt.Errorf("could not decode plan of analytics query: %v", err)
+	}
+	root := plans[0].Plan
+	return &model.AnalyticsEstimate{
+		Rows:        int64(root.PlanRows),
+		ScannedRows: int64(scannedRows(root, 1)),
+		Cost:        root.TotalCost,
+	}, nil
+}
+
+// scannedRows sums the rows read by the scans of the plan. The rows of parallel scans are estimated per process,
+// so they are multiplied by the divisor of the planner for the workers of the gather node above them.
+func scannedRows(plan explainPlan, divisor float64) float64 {
+	if plan.WorkersPlanned > 0 {
+		divisor = float64(plan.WorkersPlanned)
+		if leader := 1 - 0.3*float64(plan.WorkersPlanned); leader > 0 {
+			divisor += leader
+		}
+	}
+	var rows float64
+	if scanNodes[plan.NodeType] {
+		rows = plan.PlanRows
+		if plan.ParallelAware {
+			rows *= divisor
+		}
+	}
+	for _, child := range plan.Plans {
+		rows += scannedRows(child, divisor)
+	}
+	return rows
+}
+
+// analyticsQuery builds the query of QueryAnalytics: the time bucket, the groups and the aggregates are selected
+// in this order. Values of encrypted fields are matched whether they are encrypted or not. The rows of all tiers
+// of a tiered entity are selected if coldTier is true.
+func analyticsQuery(query AnalyticsQuery, cipher *encryption.Cipher, coldTier bool) (*sql.Builder, error) {
+	entity, ok := analyticsEntities[query.Entity]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown entity %q", ErrInvalidAnalyticsQuery, query.Entity)
+	}
+	table := entity.table
+	if coldTier && entity.tiers != nil {
+		table = entity.tiers
+	}
+	lookup := func(name string) (analyticsField, error) {
+		field, ok := entity.fields[name]
+		if !ok {
+			return analyticsField{}, fmt.Errorf("%w: unknown field %q of entity %s", ErrInvalidAnalyticsQuery, name, query.Entity)
+		}
+		return field, nil
+	}
+
+	builder := sql.NewBuilder().SelectAll(table, "")
+	if query.Duplicates != "" {
+		if query.Entity != "applications" {
+			return nil, fmt.Errorf("%w: duplicates of entity %s, which is not applications", ErrInvalidAnalyticsQuery, query.Entity)
+		}
+		canonical, err := canonicalRun(query.Duplicates, "applications")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAnalyticsQuery, err)
+		}
+		builder.ConditionExpression(canonical)
+	}
+	for _, filter := range query.Filters {
+		field, err := lookup(filter.Field)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyAnalyticsFilter(builder, field, filter, cipher); err != nil {
+			return nil, err
+		}
+	}
+	if query.TimeBucket != nil {
+		field, err := lookup(query.TimeBucket.Field)
+		if err != nil {
+			return nil, err
+		}
+		if field.fieldType != AnalyticsTime {
+			return nil, fmt.Errorf("%w: time bucket of field %s, which is not a time", ErrInvalidAnalyticsQuery, query.TimeBucket.Field)
+		}
+		location := time.UTC
+		if query.TimeBucket.Location != nil {
+			location = query.TimeBucket.Location
+		}
+		builder.GroupByUnixNanoBucket(field.column, query.TimeBucket.Bucket, location.String())
+	}
+	for _, name := range query.GroupBy {
+		field, err := lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		if field.fieldType == AnalyticsTime {
+			return nil, fmt.Errorf("%w: group by time field %s, use a time bucket instead", ErrInvalidAnalyticsQuery, name)
+		}
+		builder.GroupBy(field.column)
+	}
+	if len(query.Aggregates) == 0 {
+		return nil, fmt.Errorf("%w: no aggregates", ErrInvalidAnalyticsQuery)
+	}
+	for _, aggregate := range query.Aggregates {
+		if aggregate.Field == "" {
+			builder.Aggregate(aggregate.Function, "")
+			continue
+		}
+		field, err := lookup(aggregate.Field)
+		if err != nil {
+			return nil, err
+		}
+		if aggregate.Function != sql.Count && field.fieldType != AnalyticsInteger && field.fieldType != AnalyticsTime {
+			return nil, fmt.Errorf("%w: %s of field %s, which is not a number or a time",
+				ErrInvalidAnalyticsQuery, aggregate.Function, aggregate.Field)
+		}
+		builder.Aggregate(aggregate.Function, field.column)
+	}
+	builder.Limit(query.Limit)
+	return builder, nil
+}
+
+// analyticsLowerBounds returns the lower bounds of the time fields set by the filters of the query.
+func analyticsLowerBounds(query AnalyticsQuery) []*time.Time {
+	var bounds []*time.Time
+	for _, filter := range query.Filters {
+		if t, err := AnalyticsFieldTypeOf(query.Entity, filter.Field); err != nil || t != AnalyticsTime {
+			continue
+		}
+		switch filter.Operator {
+		case sql.Equal, sql.GreaterThan, sql.GreaterThanOrEqual:
+		default:
+			continue
+		}
+		var bound *time.Time
+		for _, value := range filter.Values {
+			v, ok := value.(time.Time)
+			if !ok {
+				bound = nil
+				break
+			}
+			if bound == nil || v.Before(*bound) {
+				bound = &v
+			}
+		}
+		bounds = append(bounds, bound)
+	}
+	return bounds
+}
+
+// applyAnalyticsFilter adds the condition of the filter to the query, with time values as Unix nanoseconds.
+func applyAnalyticsFilter(builder *sql.Builder, field analyticsField, filter AnalyticsFilter, cipher *encryption.Cipher) error {
+	if len(filter.Values) == 0 {
+		return fmt.Errorf("%w: no value for field %s", ErrInvalidAnalyticsQuery, filter.Field)
+	}
+	if (len(filter.Values) > 1 || field.encrypted) && filter.Operator != sql.Equal {
+		return fmt.Errorf("%w: field %s can only be compared with %s to any of the values",
+			ErrInvalidAnalyticsQuery, filter.Field, sql.Equal)
+	}
+	values := make([]any, len(filter.Values))
+	for i, value := range filter.Values {
+		v, ok := columnValue(field.fieldType, value)
+		if !ok {
+			return fmt.Errorf("%w: value %v of field %s is not a %s", ErrInvalidAnalyticsQuery, value, filter.Field, field.fieldType)
+		}
+		values[i] = v
+	}
+	if len(values) == 1 && !field.encrypted {
+		builder.Conditionp(field.column, filter.Operator, values[0])
+		return nil
+	}
+	switch field.fieldType {
+	case AnalyticsString:
+		strs := typedValues[string](values)
+		if field.encrypted {
+			strs = cipher.Candidates(strs...)
+		}
+		builder.ConditionAny(field.column, strs)
+	case AnalyticsBoolean:
+		builder.ConditionAny(field.column, typedValues[bool](values))
+	default:
+		builder.ConditionAny(field.column, typedValues[int64](values))
+	}
+	return nil
+}
+
+// columnValue returns the value as stored in the column of a field of the type, or false if it is not of the type.
+func columnValue(fieldType AnalyticsFieldType, value any) (any, bool) {
+	switch fieldType {
+	case AnalyticsString:
+		v, ok := value.(string)
+		return v, ok
+	case AnalyticsInteger:
+		v, ok := value.(int64)
+		return v, ok
+	case AnalyticsBoolean:
+		v, ok := value.(bool)
+		return v, ok
+	default:
+		v, ok := value.(time.Time)
+		return v.UnixNano(), ok
+	}
+}
+
+// typedValues returns the values, which are known to be of type T, as a slice of T for array arguments.
+func typedValues[T any](values []any) []T {
+	typed := make([]T, len(values))
+	for i, value := range values {
+		typed[i] = value.(T)
+	}
+	return typed
+}
+
+// scanTarget returns a pointer to scan a nullable group value of the field type into.
+func scanTarget(fieldType AnalyticsFieldType) any {
+	switch fieldType {
+	case AnalyticsInteger:
+		return new(*int64)
+	case AnalyticsBoolean:
+		return new(*bool)
+	default:
+		return new(*string)
+	}
+}
+
+// groupValue returns the scanned group value, decrypted if the field is encrypted, or nil for NULL.
+func (s *PostgresRepository) groupValue(field analyticsField, target any) (any, error) {
+	switch v := target.(type) {
+	case **int64:
+		if *v == nil {
+			return nil, nil
+		}
+		return **v, nil
+	case **bool:
+		if *v == nil {
+			return nil, nil
+		}
+		return **v, nil
+	default:
+		value := *target.(**string)
+		if value == nil {
+			return nil, nil
+		}
+		if !field.encrypted {
+			return *value, nil
+		}
+		decrypted, err := s.cipher.Decrypt(*value)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt %s of analytics group: %v", field.column, err)
+		}
+		return decrypted, nil
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestQueryAnalytics_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	seedApplications(ctx, t, repo)
+
+	rows, err := repo.QueryAnalytics(ctx, AnalyticsQuery{
+		Entity:  "applications",
+		Filters: []AnalyticsFilter{{Field: "user", Operator: sql.Equal, Values: []any{"user1", "user2"}}},
+		GroupBy: []string{"user"},
+		Aggregates: []AnalyticsAggregate{
+			{Function: sql.Count},
+			{Function: sql.Count, Field: "finishedTime"},
+		},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "user1", rows[0].Groups["user"])
+	assert.Equal(t, 3.0, *rows[0].Aggregates["count"])
+	assert.Equal(t, 1.0, *rows[0].Aggregates["count(finishedTime)"])
+	assert.Equal(t, "user2", rows[1].Groups["user"])
+	assert.Equal(t, 2.0, *rows[1].Aggregates["count"])
+	assert.Equal(t, 2.0, *rows[1].Aggregates["count(finishedTime)"])
+
+	rows, err = repo.QueryAnalytics(ctx, AnalyticsQuery{
+		Entity:     "applications",
+		TimeBucket: &AnalyticsTimeBucket{Field: "submissionTime", Bucket: sql.Month},
+		Aggregates: []AnalyticsAggregate{{Function: sql.Maximum, Field: "maxRequestPriority"}},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+	require.NotNil(t, rows[0].Bucket)
+	assert.Equal(t, 1, rows[0].Bucket.Day())
+}
+
+func TestEstimateAnalytics_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	seedApplications(ctx, t, repo)
+
+	estimate, err := repo.EstimateAnalytics(ctx, AnalyticsQuery{
+		Entity:     "applications",
+		Filters:    []AnalyticsFilter{{Field: "user", Operator: sql.Equal, Values: []any{"user1", "user2"}}},
+		GroupBy:    []string{"user"},
+		Aggregates: []AnalyticsAggregate{{Function: sql.Count}},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	assert.Positive(t, estimate.Rows)
+	assert.LessOrEqual(t, estimate.Rows, int64(10))
+	assert.Positive(t, estimate.ScannedRows)
+	assert.Positive(t, estimate.Cost)
+
+	_, err = repo.EstimateAnalytics(ctx, AnalyticsQuery{Entity: "nodes", Aggregates: []AnalyticsAggregate{{Function: sql.Count}}})
+	assert.ErrorIs(t, err, ErrInvalidAnalyticsQuery)
+}
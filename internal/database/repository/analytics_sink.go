@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// analyticsColumns are the columns of the applications which are replicated to the analytics sinks.
+const analyticsColumns = `id, partition, queue_name, "user", state, workflow_id, has_reserved, max_request_priority,
+	priority, priority_class, wait_time, submission_time, finished_time`
+
+// ErrAnalyticsSinkNotFound is returned if an analytics sink is not registered.
+var ErrAnalyticsSinkNotFound = errors.New("analytics sink not found")
+
+// StartAnalyticsSink registers the analytics sink if it is not registered yet, and returns it. The changes of
+// the applications are recorded from then on, until they are consumed. The outbox of the changes is shared,
+// so a single sink is supported.
+func (s *PostgresRepository) StartAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	_, err := s.dbpool.Exec(ctx, `INSERT INTO analytics_sinks (name, created_at) VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING`, name, time.Now().UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("could not insert analytics sink %s into DB: %v", name, err)
+	}
+	sink, err := s.GetAnalyticsSink(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if sink == nil {
+		return nil, fmt.Errorf("%w: %s was stopped while starting", ErrAnalyticsSinkNotFound, name)
+	}
+	return sink, nil
+}
+
+// StopAnalyticsSink unregisters the analytics sink. The recorded changes are deleted once no sink is registered,
+// so a sink registered again is backfilled from scratch.
+func (s *PostgresRepository) StopAnalyticsSink(ctx context.Context, name string) error {
+	return pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM analytics_sinks WHERE name = $1", name); err != nil {
+			return fmt.Errorf("could not delete analytics sink %s from DB: %v", name, err)
+		}
+		_, err := tx.Exec(ctx, "DELETE FROM analytics_outbox WHERE NOT EXISTS (SELECT 1 FROM analytics_sinks)")
+		if err != nil {
+			return fmt.Errorf("could not delete analytics changes from DB: %v", err)
+		}
+		return nil
+	})
+}
+
+// GetAnalyticsSink returns the analytics sink, or nil if it is not registered.
+func (s *PostgresRepository) GetAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	rows, err := s.dbpool.Query(ctx, "SELECT * FROM analytics_sinks WHERE name = $1", name)
+	if err != nil {
+		return nil, fmt.Errorf("could not get analytics sink %s from DB: %v", name, err)
+	}
+	sink, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[model.AnalyticsSink])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not scan analytics sink %s from DB: %v", name, err)
+	}
+	return sink, nil
+}
+
+// BackfillAnalyticsSink copies the next limit applications of both tiers, in the order of their IDs, to the
+// analytics sink by calling fn, and returns whether all applications were copied. The applications changed during
+// the backfill are recorded as changes too, so the sink receives their current rows later if fn received older ones.
+// The progress is only stored if fn succeeds.
+func (s *PostgresRepository) BackfillAnalyticsSink(ctx context.Context, name string, limit int,
+	fn func([]*model.AnalyticsApplication) error) (bool, error) {
+	done := false
+	err := pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT * FROM analytics_sinks WHERE name = $1 FOR UPDATE", name)
+		if err != nil {
+			return fmt.Errorf("could not get analytics sink %s from DB: %v", name, err)
+		}
+		sink, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[model.AnalyticsSink])
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", ErrAnalyticsSinkNotFound, name)
+		}
+		if err != nil {
+			return fmt.Errorf("could not scan analytics sink %s from DB: %v", name, err)
+		}
+		if sink.BackfilledAt != nil {
+			done = true
+			return nil
+		}
+
+		query := `SELECT ` + analyticsColumns + ` FROM ` + applicationTiersTable.From() + `
+			WHERE $1::uuid IS NULL OR id > $1 ORDER BY id LIMIT $2`
+		rows, err = tx.Query(ctx, query, sink.BackfillCursor, limit)
+		if err != nil {
+			return fmt.Errorf("could not get applications of analytics sink %s from DB: %v", name, err)
+		}
+		apps, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[model.AnalyticsApplication])
+		if err != nil {
+			return fmt.Errorf("could not scan applications of analytics sink %s from DB: %v", name, err)
+		}
+		if len(apps) > 0 {
+			if err := fn(apps); err != nil {
+				return err
+			}
+			sink.BackfillCursor = &apps[len(apps)-1].ID
+		}
+		var backfilledAt *int64
+		if len(apps) < limit {
+			now := time.Now().UnixNano()
+			backfilledAt, done = &now, true
+		}
+		_, err = tx.Exec(ctx, "UPDATE analytics_sinks SET backfill_cursor = $2, backfilled_at = $3 WHERE name = $1",
+			name, sink.BackfillCursor, backfilledAt)
+		if err != nil {
+			return fmt.Errorf("could not update analytics sink %s in DB: %v", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return done, nil
+}
+
+// ConsumeAnalyticsChanges passes the current rows of the applications of the next limit recorded changes to fn,
+// and deletes the changes if fn succeeds. Deleted applications are passed with Deleted set. It returns the number
+// of consumed changes, which is less than limit once the recorded changes are consumed.
+func (s *PostgresRepository) ConsumeAnalyticsChanges(ctx context.Context, limit int,
+	fn func([]*model.AnalyticsApplication) error) (int, error) {
+	consumed := 0
+	err := pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT * FROM analytics_outbox ORDER BY seq LIMIT $1 FOR UPDATE SKIP LOCKED", limit)
+		if err != nil {
+			return fmt.Errorf("could not get analytics changes from DB: %v", err)
+		}
+		var seqs []int64
+		var ids []string
+		// changes are the last changes of the applications, which identify them if they were deleted
+		changes := make(map[string]*analyticsChangeRow)
+		err = forEachRow(rows, "analytics changes", func(change *analyticsChangeRow) error {
+			seqs = append(seqs, change.Seq)
+			if _, ok := changes[change.ApplicationID]; !ok {
+				ids = append(ids, change.ApplicationID)
+			}
+			changes[change.ApplicationID] = change
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(seqs) == 0 {
+			return nil
+		}
+
+		query := `SELECT ` + analyticsColumns + ` FROM ` + applicationTiersTable.From() + `
+			WHERE id = ANY($1)`
+		rows, err = tx.Query(ctx, query, ids)
+		if err != nil {
+			return fmt.Errorf("could not get applications of analytics changes from DB: %v", err)
+		}
+		current := make(map[string]*model.AnalyticsApplication, len(ids))
+		err = forEachRow(rows, "applications of analytics changes", func(app *model.AnalyticsApplication) error {
+			current[app.ID] = app
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		apps := make([]*model.AnalyticsApplication, 0, len(ids))
+		for _, id := range ids {
+			app, ok := current[id]
+			if !ok {
+				change := changes[id]
+				app = &model.AnalyticsApplication{
+					ID:        id,
+					Partition: change.Partition,
+					QueueName: change.QueueName,
+					Deleted:   true,
+				}
+			}
+			apps = append(apps, app)
+		}
+		if err := fn(apps); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, "DELETE FROM analytics_outbox WHERE seq = ANY($1)", seqs); err != nil {
+			return fmt.Errorf("could not delete analytics changes from DB: %v", err)
+		}
+		consumed = len(seqs)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return consumed, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestAnalyticsSink_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithColdTier(time.Hour))
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+	// app2 and app3 are in the cold tier, which is copied too
+	_, err = repo.MoveApplicationsToColdTier(ctx, time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+
+	var received []*model.AnalyticsApplication
+	collect := func(apps []*model.AnalyticsApplication) error {
+		received = append(received, apps...)
+		return nil
+	}
+
+	// the changes are not recorded while no sink is registered
+	consumed, err := repo.ConsumeAnalyticsChanges(ctx, 100, collect)
+	require.NoError(t, err)
+	assert.Zero(t, consumed)
+	_, err = repo.BackfillAnalyticsSink(ctx, "clickhouse", 100, collect)
+	assert.ErrorIs(t, err, ErrAnalyticsSinkNotFound)
+
+	sink, err := repo.StartAnalyticsSink(ctx, "clickhouse")
+	require.NoError(t, err)
+	assert.Equal(t, "clickhouse", sink.Name)
+	assert.Nil(t, sink.BackfilledAt)
+	again, err := repo.StartAnalyticsSink(ctx, "clickhouse")
+	require.NoError(t, err)
+	assert.Equal(t, sink, again, "a registered sink must be kept")
+
+	done, err := repo.BackfillAnalyticsSink(ctx, "clickhouse", 4, collect)
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Len(t, received, 4)
+	done, err = repo.BackfillAnalyticsSink(ctx, "clickhouse", 4, collect)
+	require.NoError(t, err)
+	assert.True(t, done)
+	require.Len(t, received, 6)
+	ids := make([]string, len(received))
+	for i, app := range received {
+		ids[i] = app.ID
+	}
+	assert.True(t, sort.StringsAreSorted(ids), "applications must be copied in the order of their IDs")
+	users := make(map[string]int)
+	for _, app := range received {
+		users[*app.User]++
+	}
+	assert.Equal(t, map[string]int{"user1": 3, "user2": 2, "user3": 1}, users)
+
+	received = nil
+	done, err = repo.BackfillAnalyticsSink(ctx, "clickhouse", 4, collect)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Empty(t, received, "applications must be copied once")
+	sink, err = repo.GetAnalyticsSink(ctx, "clickhouse")
+	require.NoError(t, err)
+	assert.NotNil(t, sink.BackfilledAt)
+
+	// changes of an application are consumed once with its current row, and deletions as tombstones
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "app1",
+		Partition:     "default",
+		QueueName:     "root.default",
+		FinishedTime:  util.ToPtr(time.Now().UnixNano()),
+		State:         si.EventRecord_APP_COMPLETED.String(),
+	}})
+	require.NoError(t, err)
+	deleted, err := repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.default", time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	received = nil
+	consumed, err = repo.ConsumeAnalyticsChanges(ctx, 2, collect)
+	require.NoError(t, err)
+	assert.Equal(t, 2, consumed)
+	consumed, err = repo.ConsumeAnalyticsChanges(ctx, 2, collect)
+	require.NoError(t, err)
+	assert.Equal(t, 1, consumed)
+	require.Len(t, received, 3)
+	assert.Equal(t, si.EventRecord_APP_COMPLETED.String(), *received[0].State)
+	assert.NotNil(t, received[0].FinishedTime)
+	for _, app := range received[1:] {
+		assert.True(t, app.Deleted)
+		assert.Equal(t, "default", app.Partition)
+		assert.Equal(t, "root.default", app.QueueName)
+		assert.Nil(t, app.User)
+	}
+	consumed, err = repo.ConsumeAnalyticsChanges(ctx, 2, collect)
+	require.NoError(t, err)
+	assert.Zero(t, consumed)
+
+	// the changes of a stopped sink are deleted and not recorded anymore
+	_, err = repo.MoveApplicationsToColdTier(ctx, time.Now().Add(time.Hour), 100)
+	require.NoError(t, err)
+	require.NoError(t, repo.StopAnalyticsSink(ctx, "clickhouse"))
+	sink, err = repo.GetAnalyticsSink(ctx, "clickhouse")
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "app4",
+		Partition:     "default",
+		QueueName:     "root.default",
+		State:         si.EventRecord_APP_COMPLETING.String(),
+	}})
+	require.NoError(t, err)
+	consumed, err = repo.ConsumeAnalyticsChanges(ctx, 100, collect)
+	require.NoError(t, err)
+	assert.Zero(t, consumed)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestAnalyticsEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     string
+		expected *model.AnalyticsEstimate
+	}{
+		{
+			name: "sequential scan",
+			plan: `[{"Plan": {"Node Type": "Limit", "Total Cost": 1250.5, "Plan Rows": 3, "Plans": [
+				{"Node Type": "HashAggregate", "Total Cost": 1250.5, "Plan Rows": 3, "Plans": [
+					{"Node Type": "Seq Scan", "Total Cost": 1000, "Plan Rows": 50000}
+				]}
+			]}}]`,
+			expected: &model.AnalyticsEstimate{Rows: 3, ScannedRows: 50000, Cost: 1250.5},
+		},
+		{
+			name: "parallel scans of both tiers",
+			plan: `[{"Plan": {"Node Type": "Aggregate", "Total Cost": 90000, "Plan Rows": 1, "Plans": [
+				{"Node Type": "Gather", "Workers Planned": 2, "Plan Rows": 2, "Plans": [
+					{"Node Type": "Aggregate", "Plan Rows": 1, "Plans": [
+						{"Node Type": "Append", "Parallel Aware": true, "Plan Rows": 1200, "Plans": [
+							{"Node Type": "Seq Scan", "Parallel Aware": true, "Plan Rows": 1000},
+							{"Node Type": "Seq Scan", "Parallel Aware": true, "Plan Rows": 200}
+						]}
+					]}
+				]}
+			]}}]`,
+			// 2 workers and 0.4 of the leader
+			expected: &model.AnalyticsEstimate{Rows: 1, ScannedRows: 2880, Cost: 90000},
+		},
+		{
+			name: "bitmap scan",
+			plan: `[{"Plan": {"Node Type": "Aggregate", "Total Cost": 40.25, "Plan Rows": 1, "Plans": [
+				{"Node Type": "Bitmap Heap Scan", "Plan Rows": 120, "Plans": [
+					{"Node Type": "Bitmap Index Scan", "Plan Rows": 120}
+				]}
+			]}}]`,
+			expected: &model.AnalyticsEstimate{Rows: 1, ScannedRows: 120, Cost: 40.25},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate, err := analyticsEstimate([]byte(tt.plan))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, estimate)
+		})
+	}
+
+	_, err := analyticsEstimate([]byte(`[]`))
+	assert.Error(t, err)
+}
@@ -2,11 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
 	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/spark"
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
 	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/internal/workflow"
 
 	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
 
@@ -16,48 +23,179 @@ import (
 )
 
 type ApplicationFilters struct {
+	// Cluster only includes the applications of the partitions of the cluster, or of the default cluster if empty.
+	Cluster             *string
+	ApplicationID       *string
+	SparkApplicationID  *string
+	WorkflowID          *string
+	RetryKey            *string
 	SubmissionStartTime *time.Time
 	SubmissionEndTime   *time.Time
 	FinishedStartTime   *time.Time
 	FinishedEndTime     *time.Time
 	User                *string
 	Groups              []string
+	MinDuration         *time.Duration
+	MaxDuration         *time.Duration
+	MinWaitTime         *time.Duration
+	MaxWaitTime         *time.Duration
+	OrderBy             ApplicationOrder
 	Offset              *int
 	Limit               *int
+	// After only includes the applications after the cursor in the order of OrderBy, see Cursor. It is only supported
+	// by the applications of a queue.
+	After *Cursor
+	// NotFinishedBefore only includes the applications which did not finish before this time, including the
+	// applications which did not finish yet, i.e. the applications which were active at or after this time.
+	NotFinishedBefore *time.Time
+	// FormerQueues are the former paths of the queue of the applications, see GetQueueLineage, whose applications
+	// are included as well.
+	FormerQueues []string
+}
+
+// ApplicationOrder is what applications are ordered by, in descending order.
+type ApplicationOrder string
+
+const (
+	// OrderBySubmissionTime orders the applications by their submission time, which is the default.
+	OrderBySubmissionTime ApplicationOrder = "submissionTime"
+	// OrderByDuration orders the finished applications by their duration, the longest first.
+	OrderByDuration ApplicationOrder = "duration"
+	// OrderByWaitTime orders the started applications by their wait time, the longest first.
+	OrderByWaitTime ApplicationOrder = "waitTime"
+)
+
+// ApplicationOrders are the supported orders of applications.
+var ApplicationOrders = []ApplicationOrder{OrderBySubmissionTime, OrderByDuration, OrderByWaitTime}
+
+// applicationOrderColumns are the columns of the orders of applications other than the submission time.
+var applicationOrderColumns = map[ApplicationOrder]string{
+	OrderByDuration: "duration",
+	OrderByWaitTime: "wait_time",
+}
+
+// cursorColumns returns the columns the applications are ordered by before their application ID, whose values are
+// the keys of the cursors of the applications.
+func (o ApplicationOrder) cursorColumns() []string {
+	if column, ok := applicationOrderColumns[o]; ok {
+		return []string{column, "submission_time"}
+	}
+	return []string{"submission_time"}
+}
+
+// applicationCursor returns the cursor of the application in the order. The applications ordered by their duration or
+// wait time have one, as the applications without it are left out.
+func applicationCursor(app *model.ApplicationDAOInfo, order ApplicationOrder) *Cursor {
+	keys := []int64{app.SubmissionTime}
+	switch {
+	case order == OrderByDuration && app.Duration != nil:
+		keys = []int64{*app.Duration, app.SubmissionTime}
+	case order == OrderByWaitTime && app.WaitTime != nil:
+		keys = []int64{*app.WaitTime, app.SubmissionTime}
+	}
+	return &Cursor{Keys: keys, ID: app.ApplicationID}
+}
+
+// applyApplicationCursor orders the applications with equal keys by their application ID, which is unique in a queue,
+// so that they have a total order, and only selects the applications after the cursor if it is not nil.
+func applyApplicationCursor(builder *sql.Builder, order ApplicationOrder, after *Cursor) {
+	builder.OrderBy("app_id", sql.OrderByDescending)
+	if after == nil {
+		return
+	}
+	columns := append(order.cursorColumns(), "app_id")
+	vals := make([]any, 0, len(columns))
+	for _, key := range after.Keys {
+		vals = append(vals, key)
+	}
+	builder.ConditionRowp(columns, sql.LessThan, append(vals, after.ID)...)
+}
+
+// applyApplicationOrder orders the applications of the query. Applications without a duration or wait time are left
+// out when ordering by it, as they would be ordered first, and applications with equal values are ordered by their
+// submission time. The durations and wait times are stored and indexed, so they are not computed for every row.
+func applyApplicationOrder(builder *sql.Builder, order ApplicationOrder) {
+	if column, ok := applicationOrderColumns[order]; ok {
+		builder.ConditionNull(column, false).OrderBy(column, sql.OrderByDescending)
+	}
+	builder.OrderBy("submission_time", sql.OrderByDescending)
 }
 
 // applyApplicationFilters adds application filters to the sql query using positional arguments and
-// returns the arguments in the same order.
-func applyApplicationFilters(builder *sql.Builder, filters ApplicationFilters) {
+// returns the arguments in the same order. The user and groups are matched whether they are encrypted or not.
+func applyApplicationFilters(builder *sql.Builder, filters ApplicationFilters, cipher *encryption.Cipher) {
+	if filters.Cluster != nil {
+		applyClusterFilter(builder, *filters.Cluster)
+	}
+	if filters.ApplicationID != nil {
+		builder.Conditionp("app_id", sql.Equal, *filters.ApplicationID)
+	}
+	if filters.SparkApplicationID != nil {
+		builder.Conditionp("spark_app_id", sql.Equal, *filters.SparkApplicationID)
+	}
+	if filters.WorkflowID != nil {
+		builder.Conditionp("workflow_id", sql.Equal, *filters.WorkflowID)
+	}
+	if filters.RetryKey != nil {
+		builder.Conditionp("retry_key", sql.Equal, *filters.RetryKey)
+	}
 	if filters.SubmissionStartTime != nil {
-		builder.Conditionp("submission_time", ">=", filters.SubmissionStartTime.UnixMilli())
+		builder.Conditionp("submission_time", sql.GreaterThanOrEqual, filters.SubmissionStartTime.UnixNano())
 	}
 	if filters.SubmissionEndTime != nil {
-		builder.Conditionp("submission_time", "<=", filters.SubmissionEndTime.UnixMilli())
+		builder.Conditionp("submission_time", sql.LessThanOrEqual, filters.SubmissionEndTime.UnixNano())
 	}
 	if filters.FinishedStartTime != nil {
-		builder.Conditionp("finished_time", ">=", filters.FinishedStartTime.UnixMilli())
+		builder.Conditionp("finished_time", sql.GreaterThanOrEqual, filters.FinishedStartTime.UnixNano())
 	}
 	if filters.FinishedEndTime != nil {
-		builder.Conditionp("finished_time", "<=", filters.FinishedEndTime.UnixMilli())
+		builder.Conditionp("finished_time", sql.LessThanOrEqual, filters.FinishedEndTime.UnixNano())
+	}
+	if filters.NotFinishedBefore != nil {
+		builder.ConditionNullOrp("finished_time", sql.GreaterThanOrEqual, filters.NotFinishedBefore.UnixNano())
+	}
+	if filters.MinDuration != nil {
+		builder.Conditionp("duration", sql.GreaterThanOrEqual, filters.MinDuration.Nanoseconds())
+	}
+	if filters.MaxDuration != nil {
+		builder.Conditionp("duration", sql.LessThanOrEqual, filters.MaxDuration.Nanoseconds())
+	}
+	if filters.MinWaitTime != nil {
+		builder.Conditionp("wait_time", sql.GreaterThanOrEqual, filters.MinWaitTime.Nanoseconds())
+	}
+	if filters.MaxWaitTime != nil {
+		builder.Conditionp("wait_time", sql.LessThanOrEqual, filters.MaxWaitTime.Nanoseconds())
 	}
 	if len(filters.Groups) > 0 {
-		builder.Conditionf("groups && ARRAY[%s]", util.SliceToCommaSeparated(filters.Groups, true))
+		builder.Conditionp("groups", sql.Overlaps, cipher.Candidates(filters.Groups...))
 	}
 	if filters.User != nil {
-		builder.Conditionp("\"user\"", "=", *filters.User)
+		builder.ConditionAny("user", cipher.Candidates(*filters.User))
 	}
 	applyLimitAndOffset(builder, filters.Limit, filters.Offset)
 }
 
+// applyClusterFilter only selects the records of the partitions of the cluster, which are qualified with its ID, or
+// the records of the unqualified partitions of the default cluster if the ID is empty.
+func applyClusterFilter(builder *sql.Builder, id string) {
+	if id == "" {
+		builder.Conditionp("partition", sql.NotLike, "[%")
+		return
+	}
+	builder.Conditionp("partition", sql.Like, cluster.Pattern(id))
+}
+
 func (s *PostgresRepository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
 	upsertSQL := `INSERT INTO applications (id, app_id, used_resource, max_used_resource, pending_resource,
 			partition, queue_name, queue_id, submission_time, finished_time, requests, allocations, state,
 			"user", groups, rejected_message, state_log, place_holder_data, has_reserved, reservations,
-			max_request_priority)
+			max_request_priority, spark_app_id, workflow_id, usage_observed_at, priority, priority_class, wait_time,
+			retry_key, accept_wait, placeholder_wait, allocation_wait, wait_reason)
 			VALUES (@id, @app_id,@used_resource, @max_used_resource, @pending_resource, @partition, @queue_name, @queue_id,
 			@submission_time, @finished_time, @requests, @allocations, @state, @user, @groups,
-			@rejected_message, @state_log, @place_holder_data, @has_reserved, @reservations, @max_request_priority)
+			@rejected_message, @state_log, @place_holder_data, @has_reserved, @reservations, @max_request_priority,
+			@spark_app_id, @workflow_id, @usage_observed_at, @priority, @priority_class, @wait_time, @retry_key,
+			@accept_wait, @placeholder_wait, @allocation_wait, @wait_reason)
 		ON CONFLICT (partition, queue_name, app_id) DO UPDATE SET
 			used_resource = COALESCE(EXCLUDED.used_resource, applications.used_resource),
 			max_used_resource = COALESCE(EXCLUDED.max_used_resource, applications.max_used_resource),
@@ -71,15 +209,64 @@ func (s *PostgresRepository) UpsertApplications(ctx context.Context, apps []*dao
 			place_holder_data = COALESCE(EXCLUDED.place_holder_data, applications.place_holder_data),
 			has_reserved = COALESCE(EXCLUDED.has_reserved, applications.has_reserved),
 			reservations = COALESCE(EXCLUDED.reservations, applications.reservations),
-			max_request_priority = COALESCE(EXCLUDED.max_request_priority, applications.max_request_priority)`
+			max_request_priority = COALESCE(EXCLUDED.max_request_priority, applications.max_request_priority),
+			spark_app_id = COALESCE(EXCLUDED.spark_app_id, applications.spark_app_id),
+			workflow_id = COALESCE(EXCLUDED.workflow_id, applications.workflow_id),
+			usage_observed_at = EXCLUDED.usage_observed_at,
+			priority = COALESCE(EXCLUDED.priority, applications.priority),
+			priority_class = COALESCE(EXCLUDED.priority_class, applications.priority_class),
+			wait_time = COALESCE(applications.wait_time, EXCLUDED.wait_time),
+			retry_key = COALESCE(EXCLUDED.retry_key, applications.retry_key),
+			accept_wait = COALESCE(applications.accept_wait, EXCLUDED.accept_wait),
+			placeholder_wait = COALESCE(applications.placeholder_wait, EXCLUDED.placeholder_wait),
+			allocation_wait = COALESCE(applications.allocation_wait, EXCLUDED.allocation_wait),
+			wait_reason = CASE WHEN applications.allocation_wait IS NULL
+				THEN COALESCE(EXCLUDED.wait_reason, applications.wait_reason) ELSE applications.wait_reason END
+		RETURNING id`
 
 	for _, a := range apps {
 		queueId, err := s.getQueueID(ctx, a.QueueName, a.Partition)
 		if err != nil {
 			return fmt.Errorf("could not get queue_id from DB: %v", err)
 		}
-		_, err = s.dbpool.Exec(ctx, upsertSQL,
-			pgx.NamedArgs{
+		// the Spark application and workflow IDs and the retry key are kept once the allocations they are taken
+		// from are released
+		var sparkAppID, workflowID, retryKey *string
+		if id := spark.ApplicationID(a, s.sparkApplicationIDTag); id != "" {
+			sparkAppID = &id
+		}
+		if id := workflow.ID(a, s.workflowIDTags); id != "" {
+			workflowID = &id
+		}
+		if key := tags.Key(a, s.retryKeyTags); key != "" {
+			retryKey = &key
+		}
+		// like the Spark application and workflow IDs, the priority class is kept once the allocations are released
+		var priorityClass *string
+		if s.priorityClassTag != "" {
+			if class := tags.Value(a, s.priorityClassTag); class != "" {
+				priorityClass = &class
+			}
+		}
+		// the throughput counters and usage rollups are incremented in the same transaction, with the application
+		// locked, so that concurrent updates of the application do not count it twice, and the application is
+		// summarized once its usage was rolled up
+		now := time.Now()
+		err = pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+			previous, err := getStoredApplication(ctx, tx, a)
+			if err != nil {
+				return err
+			}
+			// the scheduler may still report an application which was moved to the cold tier, which is not
+			// stored again
+			if previous == nil {
+				if cold, err := s.inColdTier(ctx, tx, a); err != nil || cold {
+					return err
+				}
+			}
+			phases := waitPhases(a, previous)
+			var applicationID string
+			err = tx.QueryRow(ctx, upsertSQL, pgx.NamedArgs{
 				"id":                   uuid.NewString(),
 				"app_id":               a.ApplicationID,
 				"used_resource":        a.UsedResource,
@@ -93,15 +280,43 @@ func (s *PostgresRepository) UpsertApplications(ctx context.Context, apps []*dao
 				"requests":             a.Requests,
 				"allocations":          a.Allocations,
 				"state":                a.State,
-				"user":                 a.User,
-				"groups":               a.Groups,
+				"user":                 s.cipher.Encrypt(a.User),
+				"groups":               s.cipher.EncryptAll(a.Groups),
 				"rejected_message":     a.RejectedMessage,
 				"state_log":            a.StateLog,
 				"place_holder_data":    a.PlaceholderData,
 				"has_reserved":         a.HasReserved,
 				"reservations":         a.Reservations,
 				"max_request_priority": a.MaxRequestPriority,
-			})
+				"spark_app_id":         sparkAppID,
+				"workflow_id":          workflowID,
+				"usage_observed_at":    now.UnixNano(),
+				"priority":             applicationPriority(a),
+				"priority_class":       priorityClass,
+				"wait_time":            waitTime(a, previous, now),
+				"retry_key":            retryKey,
+				"accept_wait":          phases.AcceptWait,
+				"placeholder_wait":     phases.PlaceholderWait,
+				"allocation_wait":      phases.AllocationWait,
+				"wait_reason":          phases.Reason,
+			}).Scan(&applicationID)
+			if err != nil {
+				return err
+			}
+			if err := countThroughput(ctx, tx, a, previous, now); err != nil {
+				return err
+			}
+			if err := recordNamespaceQueue(ctx, tx, a, previous); err != nil {
+				return err
+			}
+			if err := recordAllocationConstraints(ctx, tx, applicationID, a); err != nil {
+				return err
+			}
+			if err := s.rollUpUsage(ctx, tx, a, previous, now); err != nil {
+				return err
+			}
+			return upsertApplicationSummary(ctx, tx, applicationID)
+		})
 		if err != nil {
 			return err
 		}
@@ -109,63 +324,268 @@ func (s *PostgresRepository) UpsertApplications(ctx context.Context, apps []*dao
 	return nil
 }
 
-func (s *PostgresRepository) GetAllApplications(ctx context.Context, filters ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
-	queryBuilder := sql.NewBuilder().SelectAll("applications", "a").OrderBy("a.submission_time", sql.OrderByDescending)
-	applyApplicationFilters(queryBuilder, filters)
-
-	var apps []*model.ApplicationDAOInfo
+// storedApplication is the part of a previously stored application which the throughput counters and usage
+// rollups are incremented from.
+type storedApplication struct {
+	State           *string
+	StateLog        []*dao.StateDAOInfo
+	SubmissionTime  *int64
+	User            *string
+	UsedResource    map[string]int64
+	UsageObservedAt *int64
+}
 
-	query := queryBuilder.Query()
-	args := queryBuilder.Args()
-	rows, err := s.dbpool.Query(ctx, query, args...)
+// getStoredApplication locks the stored application and returns it, or nil if it is not stored yet.
+func getStoredApplication(ctx context.Context, tx pgx.Tx, app *dao.ApplicationDAOInfo) (*storedApplication, error) {
+	var previous storedApplication
+	err := tx.QueryRow(ctx, `SELECT state, state_log, submission_time, "user", used_resource, usage_observed_at
+		FROM applications WHERE partition = $1 AND queue_name = $2 AND app_id = $3 FOR UPDATE`,
+		app.Partition, app.QueueName, app.ApplicationID).Scan(&previous.State, &previous.StateLog,
+		&previous.SubmissionTime, &previous.User, &previous.UsedResource, &previous.UsageObservedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("could not get applications from DB: %v", err)
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var app model.ApplicationDAOInfo
-		var id string
-		err := rows.Scan(&id, &app.ApplicationID, &app.UsedResource, &app.MaxUsedResource, &app.PendingResource,
-			&app.Partition, &app.QueueName, &app.QueueID, &app.SubmissionTime, &app.FinishedTime, &app.Requests, &app.Allocations,
-			&app.State, &app.User, &app.Groups, &app.RejectedMessage, &app.StateLog, &app.PlaceholderData,
-			&app.HasReserved, &app.Reservations, &app.MaxRequestPriority)
+		return nil, fmt.Errorf("could not get application %s from DB: %v", app.ApplicationID, err)
+	}
+	return &previous, nil
+}
+
+// applicationPriority returns the highest priority of the requests and allocations of the application, or nil if
+// it has none, e.g. once it finished, in which case the stored priority is kept.
+func applicationPriority(app *dao.ApplicationDAOInfo) *int32 {
+	var priority *int32
+	update := func(value string) {
+		p, err := strconv.ParseInt(value, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("could not scan application from DB: %v", err)
+			return
+		}
+		if priority == nil || int32(p) > *priority {
+			priority = util.ToPtr(int32(p))
 		}
-		apps = append(apps, &app)
+	}
+	for _, alloc := range app.Allocations {
+		if alloc != nil {
+			update(alloc.Priority)
+		}
+	}
+	for _, ask := range app.Requests {
+		if ask != nil {
+			update(ask.Priority)
+		}
+	}
+	return priority
+}
+
+// waitTime returns the time in nanoseconds the application waited from its submission until it started running,
+// or nil if it did not start yet. The stored wait time is kept, as it does not change once the application started.
+func waitTime(app *dao.ApplicationDAOInfo, previous *storedApplication, now time.Time) *int64 {
+	submittedAt := app.SubmissionTime
+	if submittedAt == 0 && previous != nil && previous.SubmissionTime != nil {
+		submittedAt = *previous.SubmissionTime
+	}
+	stateLog := app.StateLog
+	if stateLog == nil && previous != nil {
+		stateLog = previous.StateLog
+	}
+	startedAt, started := reachedState(app.State, stateLog, app.FinishedTime, "RUNNING", now)
+	if !started || submittedAt == 0 || startedAt < submittedAt {
+		return nil
+	}
+	return util.ToPtr(startedAt - submittedAt)
+}
+
+func (s *storedApplication) state() string {
+	if s.State == nil {
+		return ""
+	}
+	return *s.State
+}
+
+func (s *PostgresRepository) GetAllApplications(ctx context.Context, filters ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+	var apps []*model.ApplicationDAOInfo
+	source := s.applicationsSource(filters.SubmissionStartTime, filters.FinishedStartTime, filters.NotFinishedBefore)
+	err := s.queryApplications(ctx, allApplicationsQuery(source, filters, s.cipher), func(app *model.ApplicationDAOInfo) error {
+		apps = append(apps, app)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return apps, nil
 }
 
+// allApplicationsQuery builds the query of GetAllApplications on the applications of the source.
+func allApplicationsQuery(source *sql.Table, filters ApplicationFilters, cipher *encryption.Cipher) *sql.Builder {
+	queryBuilder := sql.NewBuilder().SelectAll(source, "a")
+	applyApplicationOrder(queryBuilder, filters.OrderBy)
+	applyApplicationFilters(queryBuilder, filters, cipher)
+	return queryBuilder
+}
+
 func (s *PostgresRepository) GetAppsPerPartitionPerQueue(ctx context.Context, partition, queue string, filters ApplicationFilters) (
 	[]*model.ApplicationDAOInfo, error) {
+	var apps []*model.ApplicationDAOInfo
+	err := s.StreamAppsPerPartitionPerQueue(ctx, partition, queue, filters, func(app *model.ApplicationDAOInfo) error {
+		apps = append(apps, app)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// StreamAppsPerPartitionPerQueue calls fn for every application of GetAppsPerPartitionPerQueue as it is read from
+// the database, so that the applications don't have to be held in memory. It stops at the first error returned by fn.
+func (s *PostgresRepository) StreamAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters ApplicationFilters,
+	fn func(*model.ApplicationDAOInfo) error,
+) error {
+	if err := filters.After.checkKeys(len(filters.OrderBy.cursorColumns())); err != nil {
+		return err
+	}
+	source := s.applicationsSource(filters.SubmissionStartTime, filters.FinishedStartTime, filters.NotFinishedBefore)
+	return s.queryApplications(ctx, appsPerPartitionPerQueueQuery(source, partition, queue, filters, s.cipher), fn)
+}
+
+// GetAppsPerPartitionPerQueuePage returns a page of the applications of GetAppsPerPartitionPerQueue, with the number of
+// applications matching the filters and the cursor of the next page, which is paginated with filters.After.
+func (s *PostgresRepository) GetAppsPerPartitionPerQueuePage(
+	ctx context.Context,
+	partition, queue string,
+	filters ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, PageInfo, error) {
+	limit := filters.Limit
+	queryFilters := filters
+	queryFilters.Limit = pageQueryLimit(limit)
+	apps, err := s.GetAppsPerPartitionPerQueue(ctx, partition, queue, queryFilters)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	var page PageInfo
+	apps, page.Next = trimPage(apps, limit, func(app *model.ApplicationDAOInfo) *Cursor {
+		return applicationCursor(app, filters.OrderBy)
+	})
+
+	source := s.applicationsSource(filters.SubmissionStartTime, filters.FinishedStartTime, filters.NotFinishedBefore)
+	query, args, err := appsPerPartitionPerQueueCountQuery(source, partition, queue, filters, s.cipher).Build()
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	var total float64
+	if err := s.dbpool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not count applications in DB: %v", err)
+	}
+	page.Total = int(total)
+	return apps, page, nil
+}
+
+// appsPerPartitionPerQueueQuery builds the query of StreamAppsPerPartitionPerQueue on the applications of the source.
+func appsPerPartitionPerQueueQuery(
+	source *sql.Table, partition, queue string, filters ApplicationFilters, cipher *encryption.Cipher) *sql.Builder {
 	queryBuilder := sql.NewBuilder().
-		SelectAll("applications", "").
-		Conditionp("queue_name", "=", queue).
-		Conditionp("partition", "=", partition).
-		OrderBy("submission_time", sql.OrderByDescending)
-	applyApplicationFilters(queryBuilder, filters)
+		SelectAll(source, "")
+	conditionQueue(queryBuilder, queue, filters.FormerQueues).
+		Conditionp("partition", sql.Equal, partition)
+	applyApplicationOrder(queryBuilder, filters.OrderBy)
+	applyApplicationCursor(queryBuilder, filters.OrderBy, filters.After)
+	applyApplicationFilters(queryBuilder, filters, cipher)
+	return queryBuilder
+}
 
-	var apps []*model.ApplicationDAOInfo
+// appsPerPartitionPerQueueCountQuery builds the query counting the applications of StreamAppsPerPartitionPerQueue on all
+// pages, i.e. without cursor, offset and limit. Like applyApplicationOrder, it leaves out the applications without
+// the duration or wait time they are ordered by.
+func appsPerPartitionPerQueueCountQuery(
+	source *sql.Table, partition, queue string, filters ApplicationFilters, cipher *encryption.Cipher) *sql.Builder {
+	filters.After, filters.Offset, filters.Limit = nil, nil, nil
+	queryBuilder := sql.NewBuilder().
+		SelectAll(source, "").
+		Aggregate(sql.Count, "")
+	conditionQueue(queryBuilder, queue, filters.FormerQueues).
+		Conditionp("partition", sql.Equal, partition)
+	if column, ok := applicationOrderColumns[filters.OrderBy]; ok {
+		queryBuilder.ConditionNull(column, false)
+	}
+	applyApplicationFilters(queryBuilder, filters, cipher)
+	return queryBuilder
+}
 
-	query := queryBuilder.Query()
-	args := queryBuilder.Args()
+// queryApplications runs the query selecting all columns of applications and calls fn for every decrypted application.
+func (s *PostgresRepository) queryApplications(
+	ctx context.Context,
+	queryBuilder *sql.Builder,
+	fn func(*model.ApplicationDAOInfo) error,
+) error {
+	query, args, err := queryBuilder.Build()
+	if err != nil {
+		return err
+	}
 	rows, err := s.dbpool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("could not get applications from DB: %v", err)
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var app model.ApplicationDAOInfo
-		var id string
-		err := rows.Scan(&id, &app.ApplicationID, &app.UsedResource, &app.MaxUsedResource, &app.PendingResource,
-			&app.Partition, &app.QueueName, &app.QueueID, &app.SubmissionTime, &app.FinishedTime, &app.Requests, &app.Allocations,
-			&app.State, &app.User, &app.Groups, &app.RejectedMessage, &app.StateLog, &app.PlaceholderData,
-			&app.HasReserved, &app.Reservations, &app.MaxRequestPriority)
+		return fmt.Errorf("could not get applications from DB: %v", err)
+	}
+	return forEachRow(rows, "applications", func(row *applicationRow) error {
+		app := row.toModel()
+		if err := s.decryptApplication(app); err != nil {
+			return err
+		}
+		return fn(app)
+	})
+}
+
+// decryptApplication decrypts the user and groups of the application.
+func (s *PostgresRepository) decryptApplication(app *model.ApplicationDAOInfo) error {
+	var err error
+	if app.User, err = s.cipher.Decrypt(app.User); err != nil {
+		return fmt.Errorf("could not decrypt user of application %s: %v", app.ApplicationID, err)
+	}
+	if app.Groups, err = s.cipher.DecryptAll(app.Groups); err != nil {
+		return fmt.Errorf("could not decrypt groups of application %s: %v", app.ApplicationID, err)
+	}
+	return nil
+}
+
+// CountFinishedApplications returns the number of applications in the given state which finished since the given time.
+// Empty partition and queue match all partitions and queues, otherwise the queue matches its whole subtree.
+func (s *PostgresRepository) CountFinishedApplications(
+	ctx context.Context, partition, queue, state string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM ` + s.applicationsSource(&since).From() + `
+		WHERE state = $1 AND finished_time >= $2
+		AND ($3 = '' OR partition = $3)
+		AND ($4 = '' OR queue_name = $4 OR starts_with(queue_name, $4 || '.'))`
+	var count int
+	if err := s.dbpool.QueryRow(ctx, query, state, since.UnixNano(), partition, queue).Scan(&count); err != nil {
+		return 0, fmt.Errorf("could not count applications in DB: %v", err)
+	}
+	return count, nil
+}
+
+// DeleteApplicationsFinishedBefore deletes at most limit applications of the given queue which finished before
+// the given time from both tiers, with their allocation constraints, summaries and trace events, and returns the number
+// of deleted applications. Applications of child queues and applications under an active legal hold are not deleted.
+func (s *PostgresRepository) DeleteApplicationsFinishedBefore(
+	ctx context.Context, partition, queue string, before time.Time, limit int) (int64, error) {
+	var total int64
+	for _, tier := range applicationTiers {
+		if total >= int64(limit) {
+			break
+		}
+		condition := `id IN (
+			SELECT id FROM ` + tier.From() + ` AS applications
+			WHERE partition = $1 AND queue_name = $2 AND finished_time IS NOT NULL AND finished_time < $3
+			AND ` + applicationNotHeld + `
+			LIMIT $4)`
+		var deleted int64
+		err := s.dbpool.QueryRow(ctx, deleteApplicationsSQL(tier, condition),
+			partition, queue, before.UnixNano(), int64(limit)-total).Scan(&deleted)
 		if err != nil {
-			return nil, fmt.Errorf("could not scan application from DB: %v", err)
+			return total, fmt.Errorf("could not delete applications from DB: %v", err)
 		}
-		apps = append(apps, &app)
+		total += deleted
 	}
-	return apps, nil
+	return total, nil
 }
@@ -10,6 +10,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
 	"github.com/G-Research/yunikorn-history-server/internal/util"
 	"github.com/G-Research/yunikorn-history-server/test/database"
 )
@@ -182,6 +186,214 @@ func TestGetAppsPerPartitionPerQueue_Integration(t *testing.T) {
 	}
 }
 
+func TestApplicationEncryption_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	plain, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	// applications stored before encryption was enabled must still be found
+	seedApplications(ctx, t, plain)
+
+	cipher, err := encryption.New(&config.EncryptionConfig{
+		Keys:      map[string]string{"v1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+		ActiveKey: "v1",
+	})
+	require.NoError(t, err)
+	repo, err := NewPostgresRepository(connPool, WithCipher(cipher))
+	require.NoError(t, err)
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "app-encrypted",
+		Partition:     "default",
+		QueueName:     "root.default",
+		User:          "user3",
+		Groups:        []string{"group1"},
+	}})
+	require.NoError(t, err)
+
+	var user string
+	var groups []string
+	err = connPool.QueryRow(ctx, `SELECT "user", groups FROM applications WHERE app_id = 'app-encrypted'`).Scan(&user, &groups)
+	require.NoError(t, err)
+	assert.NotEqual(t, "user3", user)
+	assert.NotContains(t, groups, "group1")
+
+	apps, err := repo.GetAllApplications(ctx, ApplicationFilters{User: util.ToPtr("user3"), Groups: []string{"group1"}})
+	require.NoError(t, err)
+	require.Len(t, apps, 2)
+	for _, app := range apps {
+		assert.Equal(t, "user3", app.User)
+		assert.Equal(t, []string{"group1"}, app.Groups)
+	}
+}
+
+func TestApplicationSparkApplicationID_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	tags := map[string]string{config.DefaultSparkApplicationIDTag: "spark-0123456789abcdef"}
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "spark-pi",
+		Partition:     "default",
+		QueueName:     "root.default",
+		Allocations:   []*dao.AllocationDAOInfo{{AllocationTags: tags}},
+	}})
+	require.NoError(t, err)
+	// the Spark application ID is kept once the allocations are released
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "spark-pi",
+		Partition:     "default",
+		QueueName:     "root.default",
+		State:         "Completed",
+	}})
+	require.NoError(t, err)
+
+	apps, err := repo.GetAllApplications(ctx, ApplicationFilters{SparkApplicationID: util.ToPtr("spark-0123456789abcdef")})
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "spark-pi", apps[0].ApplicationID)
+	assert.Equal(t, "spark-0123456789abcdef", apps[0].SparkApplicationID)
+
+	apps, err = repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{ApplicationID: util.ToPtr("app1")})
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Empty(t, apps[0].SparkApplicationID)
+}
+
+func TestApplicationWorkflowID_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	var apps []*dao.ApplicationDAOInfo
+	for _, task := range []string{"extract", "load"} {
+		apps = append(apps, &dao.ApplicationDAOInfo{
+			ApplicationID: "daily-etl-" + task,
+			Partition:     "default",
+			QueueName:     "root.default",
+			Requests: []*dao.AllocationAskDAOInfo{{AllocationTags: map[string]string{
+				"kubernetes.io/label/dag_id": "daily_etl",
+				"kubernetes.io/label/run_id": "manual__2024-07-01",
+			}}},
+		})
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	got, err := repo.GetAllApplications(ctx, ApplicationFilters{WorkflowID: util.ToPtr("daily_etl:manual__2024-07-01")})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, app := range got {
+		assert.Equal(t, "daily_etl:manual__2024-07-01", app.WorkflowID)
+	}
+}
+
+func TestApplicationRetryKey_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	var apps []*dao.ApplicationDAOInfo
+	for _, try := range []string{"1", "2"} {
+		apps = append(apps, &dao.ApplicationDAOInfo{
+			ApplicationID: "daily-etl-load-" + try,
+			Partition:     "default",
+			QueueName:     "root.default",
+			Requests: []*dao.AllocationAskDAOInfo{{AllocationTags: map[string]string{
+				"kubernetes.io/label/dag_id":  "daily_etl",
+				"kubernetes.io/label/task_id": "load",
+				"kubernetes.io/label/run_id":  "manual__2024-07-01",
+			}}},
+		})
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	got, err := repo.GetAllApplications(ctx, ApplicationFilters{RetryKey: util.ToPtr("daily_etl:load:manual__2024-07-01")})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, app := range got {
+		assert.Equal(t, "daily_etl:load:manual__2024-07-01", app.RetryKey)
+	}
+}
+
+func TestApplicationDurations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithColdTier(time.Hour))
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	appIDs := func(apps []*model.ApplicationDAOInfo) []string {
+		ids := make([]string, 0, len(apps))
+		for _, app := range apps {
+			ids = append(ids, app.ApplicationID)
+		}
+		return ids
+	}
+
+	// the duration is generated once the application finished, and only finished applications are ordered by it
+	apps, err := repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{OrderBy: OrderByDuration})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app3", "app2", "app6"}, appIDs(apps))
+	for _, app := range apps {
+		require.NotNil(t, app.Duration)
+		assert.Equal(t, *app.FinishedTime-app.SubmissionTime, *app.Duration)
+	}
+
+	apps, err = repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{
+		MinDuration: util.ToPtr(39 * time.Minute),
+		MaxDuration: util.ToPtr(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app2"}, appIDs(apps))
+
+	// the durations of the applications moved to the cold tier are copied with them
+	moved, err := repo.MoveApplicationsToColdTier(ctx, time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+	apps, err = repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{
+		MinDuration: util.ToPtr(39 * time.Minute),
+		OrderBy:     OrderByDuration,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app3", "app2"}, appIDs(apps))
+}
+
 func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepository) {
 	t.Helper()
 
@@ -212,7 +424,7 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 			PendingResource: map[string]int64{"cpu": 1},
 			Partition:       "default",
 			QueueName:       "root.default",
-			SubmissionTime:  now.Add(-6 * time.Hour).UnixMilli(),
+			SubmissionTime:  now.Add(-6 * time.Hour).UnixNano(),
 			User:            "user1",
 			State:           si.EventRecord_APP_RUNNING.String(),
 		},
@@ -223,8 +435,8 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 			PendingResource: map[string]int64{"memory": 1},
 			Partition:       "default",
 			QueueName:       "root.default",
-			SubmissionTime:  now.Add(-5 * time.Hour).UnixMilli(),
-			FinishedTime:    util.ToPtr(now.Add(-4 * time.Hour).Add(-20 * time.Minute).UnixMilli()),
+			SubmissionTime:  now.Add(-5 * time.Hour).UnixNano(),
+			FinishedTime:    util.ToPtr(now.Add(-4 * time.Hour).Add(-20 * time.Minute).UnixNano()),
 			User:            "user2",
 			State:           si.EventRecord_APP_COMPLETED.String(),
 		},
@@ -235,8 +447,8 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 			PendingResource: map[string]int64{"cpu": 3},
 			Partition:       "default",
 			QueueName:       "root.default",
-			SubmissionTime:  now.Add(-3 * time.Hour).UnixMilli(),
-			FinishedTime:    util.ToPtr(now.Add(-1 * time.Hour).Add(-33 * time.Minute).UnixMilli()),
+			SubmissionTime:  now.Add(-3 * time.Hour).UnixNano(),
+			FinishedTime:    util.ToPtr(now.Add(-1 * time.Hour).Add(-33 * time.Minute).UnixNano()),
 			User:            "user2",
 			State:           si.EventRecord_APP_FAILED.String(),
 			Groups:          []string{"group2"},
@@ -248,7 +460,7 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 			PendingResource: map[string]int64{"memory": 4},
 			Partition:       "default",
 			QueueName:       "root.default",
-			SubmissionTime:  now.Add(-2 * time.Hour).UnixMilli(),
+			SubmissionTime:  now.Add(-2 * time.Hour).UnixNano(),
 			User:            "user3",
 			State:           si.EventRecord_APP_RUNNING.String(),
 			Groups:          []string{"group1"},
@@ -260,7 +472,7 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 			PendingResource: map[string]int64{"cpu": 5},
 			Partition:       "default",
 			QueueName:       "root.default",
-			SubmissionTime:  now.Add(-1 * time.Hour).UnixMilli(),
+			SubmissionTime:  now.Add(-1 * time.Hour).UnixNano(),
 			User:            "user1",
 			State:           si.EventRecord_APP_STARTING.String(),
 			Groups:          []string{"group1", "group2"},
@@ -272,8 +484,8 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 			PendingResource: map[string]int64{"memory": 6},
 			Partition:       "default",
 			QueueName:       "root.default",
-			SubmissionTime:  now.Add(-43 * time.Minute).UnixMilli(),
-			FinishedTime:    util.ToPtr(now.Add(-5 * time.Minute).UnixMilli()),
+			SubmissionTime:  now.Add(-43 * time.Minute).UnixNano(),
+			FinishedTime:    util.ToPtr(now.Add(-5 * time.Minute).UnixNano()),
 			User:            "user1",
 			State:           si.EventRecord_APP_COMPLETED.String(),
 			Groups:          []string{"group1", "group3"},
@@ -283,3 +495,87 @@ func seedApplications(ctx context.Context, t *testing.T, repo *PostgresRepositor
 	err = repo.UpsertApplications(ctx, apps)
 	require.NoError(t, err, "could not seed applications")
 }
+
+func TestApplicationPriority_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithPriorityClassTag("kubernetes.io/label/priority-class"))
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	submitted := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID:  "urgent",
+		Partition:      "default",
+		QueueName:      "root.default",
+		SubmissionTime: submitted.UnixNano(),
+		State:          "Running",
+		StateLog:       []*dao.StateDAOInfo{{Time: submitted.Add(time.Minute).UnixNano(), ApplicationState: "Running"}},
+		Allocations: []*dao.AllocationDAOInfo{{
+			Priority:       "1000",
+			AllocationTags: map[string]string{"kubernetes.io/label/priority-class": "high"},
+		}},
+	}})
+	require.NoError(t, err)
+	// the priority, priority class and wait time are kept once the allocations are released
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID:  "urgent",
+		Partition:      "default",
+		QueueName:      "root.default",
+		SubmissionTime: submitted.UnixNano(),
+		State:          "Completed",
+	}})
+	require.NoError(t, err)
+
+	apps, err := repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{ApplicationID: util.ToPtr("urgent")})
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, util.ToPtr(int32(1000)), apps[0].Priority)
+	assert.Equal(t, "high", apps[0].PriorityClass)
+	assert.Equal(t, util.ToPtr(time.Minute.Nanoseconds()), apps[0].WaitTime)
+
+	rows, err := repo.QueryAnalytics(ctx, AnalyticsQuery{
+		Entity:     "applications",
+		Filters:    []AnalyticsFilter{{Field: "priorityClass", Operator: sql.Equal, Values: []any{"high"}}},
+		GroupBy:    []string{"priority"},
+		Aggregates: []AnalyticsAggregate{{Function: sql.Median, Field: "waitTime"}},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int32(1000), rows[0].Groups["priority"])
+	assert.Equal(t, util.ToPtr(float64(time.Minute.Nanoseconds())), rows[0].Aggregates["p50(waitTime)"])
+}
+
+func TestCountFinishedApplicationsQueueSubtree_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	now := time.Now()
+	finished := util.ToPtr(now.Add(-time.Hour).UnixNano())
+	state := si.EventRecord_APP_COMPLETED.String()
+	require.NoError(t, repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{
+		{ApplicationID: "app1", Partition: "default", QueueName: "root.a_b", State: state, FinishedTime: finished},
+		{ApplicationID: "app2", Partition: "default", QueueName: "root.a_b.etl", State: state, FinishedTime: finished},
+		{ApplicationID: "app3", Partition: "default", QueueName: "root.axb.etl", State: state, FinishedTime: finished},
+	}))
+
+	// _ is a LIKE wildcard, which must match itself only in the subtree of the queue
+	count, err := repo.CountFinishedApplications(ctx, "default", "root.a_b", state, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
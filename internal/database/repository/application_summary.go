@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// upsertApplicationSummary summarizes the stored application, in the transaction which stored it and rolled up its
+// usage. The summary is only written if it changed, so that the stores of running applications which only change
+// their allocations do not rewrite it.
+func upsertApplicationSummary(ctx context.Context, tx pgx.Tx, applicationID string) error {
+	upsertSQL := `INSERT INTO application_summary (application_id, app_id, partition, queue_name, "user", groups, state,
+			submission_time, finished_time, duration, max_used_resource, vcore_seconds, memory_seconds, spark_app_id,
+			workflow_id, wait_time)
+		SELECT a.id, a.app_id, a.partition, a.queue_name, a."user", a.groups, a.state, a.submission_time,
+			a.finished_time, a.duration, a.max_used_resource, COALESCE(u.vcore_seconds, 0), COALESCE(u.memory_seconds, 0),
+			a.spark_app_id, a.workflow_id, a.wait_time
+		FROM applications a
+		LEFT JOIN LATERAL (
+			SELECT SUM(vcore_seconds) AS vcore_seconds, SUM(memory_seconds) AS memory_seconds
+			FROM usage_rollups
+			WHERE partition = a.partition AND queue_name = a.queue_name AND app_id = a.app_id
+		) u ON TRUE
+		WHERE a.id = $1
+		ON CONFLICT (application_id) DO UPDATE SET
+			"user" = EXCLUDED."user",
+			groups = EXCLUDED.groups,
+			state = EXCLUDED.state,
+			submission_time = EXCLUDED.submission_time,
+			finished_time = EXCLUDED.finished_time,
+			duration = EXCLUDED.duration,
+			max_used_resource = EXCLUDED.max_used_resource,
+			vcore_seconds = EXCLUDED.vcore_seconds,
+			memory_seconds = EXCLUDED.memory_seconds,
+			spark_app_id = EXCLUDED.spark_app_id,
+			workflow_id = EXCLUDED.workflow_id,
+			wait_time = EXCLUDED.wait_time
+		WHERE (application_summary."user", application_summary.groups, application_summary.state,
+				application_summary.submission_time, application_summary.finished_time,
+				application_summary.max_used_resource, application_summary.vcore_seconds,
+				application_summary.memory_seconds, application_summary.spark_app_id, application_summary.workflow_id,
+				application_summary.wait_time)
+			IS DISTINCT FROM (EXCLUDED."user", EXCLUDED.groups, EXCLUDED.state, EXCLUDED.submission_time,
+				EXCLUDED.finished_time, EXCLUDED.max_used_resource, EXCLUDED.vcore_seconds, EXCLUDED.memory_seconds,
+				EXCLUDED.spark_app_id, EXCLUDED.workflow_id, EXCLUDED.wait_time)`
+	if _, err := tx.Exec(ctx, upsertSQL, applicationID); err != nil {
+		return fmt.Errorf("could not summarize application %s in DB: %v", applicationID, err)
+	}
+	return nil
+}
+
+// GetApplicationSummaries returns the summaries of the applications of both tiers for a given partition and queue,
+// ordered by submission time in descending order unless the filters order them otherwise. Unlike GetAppsPerPartitionPerQueue, it neither reads the requests
+// and allocations of the applications nor unions the tiers.
+func (s *PostgresRepository) GetApplicationSummaries(
+	ctx context.Context, partition, queue string, filters ApplicationFilters) ([]*model.ApplicationSummary, error) {
+	query, args, err := applicationSummariesQuery(partition, queue, filters, s.cipher).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get application summaries from DB: %v", err)
+	}
+	var summaries []*model.ApplicationSummary
+	err = forEachRow(rows, "application summaries", func(summary *model.ApplicationSummary) error {
+		if summary.User, err = s.cipher.Decrypt(summary.User); err != nil {
+			return fmt.Errorf("could not decrypt user of application %s: %v", summary.ApplicationID, err)
+		}
+		if summary.Groups, err = s.cipher.DecryptAll(summary.Groups); err != nil {
+			return fmt.Errorf("could not decrypt groups of application %s: %v", summary.ApplicationID, err)
+		}
+		summaries = append(summaries, summary)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// applicationSummariesQuery builds the query of GetApplicationSummaries.
+func applicationSummariesQuery(
+	partition, queue string, filters ApplicationFilters, cipher *encryption.Cipher) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(applicationSummaryTable, "")
+	conditionQueue(queryBuilder, queue, filters.FormerQueues).
+		Conditionp("partition", sql.Equal, partition)
+	applyApplicationOrder(queryBuilder, filters.OrderBy)
+	applyApplicationFilters(queryBuilder, filters, cipher)
+	return queryBuilder
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestApplicationSummaries_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithColdTier(time.Hour))
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	summaries, err := repo.GetApplicationSummaries(ctx, "default", "root.default", ApplicationFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app6", "app5", "app4", "app3", "app2", "app1"}, summaryIDs(summaries))
+	app2 := summaries[4]
+	assert.Equal(t, "user2", app2.User)
+	assert.Equal(t, si.EventRecord_APP_COMPLETED.String(), app2.State)
+	assert.Equal(t, map[string]int64{"memory": 2}, app2.MaxUsedResource)
+	require.NotNil(t, app2.Duration)
+	assert.Equal(t, *app2.FinishedTime-app2.SubmissionTime, *app2.Duration)
+	assert.Nil(t, summaries[5].Duration, "running applications have no duration")
+
+	summaries, err = repo.GetApplicationSummaries(ctx, "default", "root.default", ApplicationFilters{
+		User:   util.ToPtr("user1"),
+		Groups: []string{"group1"},
+		Limit:  util.ToPtr(1),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app6"}, summaryIDs(summaries))
+
+	// the summary holds the final state and the accumulated usage once the application finished
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "app1",
+		Partition:     "default",
+		QueueName:     "root.default",
+		UsedResource:  map[string]int64{"vcore": 2000},
+		State:         si.EventRecord_APP_RUNNING.String(),
+	}})
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	finishedAt := time.Now().UnixNano()
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "app1",
+		Partition:     "default",
+		QueueName:     "root.default",
+		FinishedTime:  &finishedAt,
+		State:         si.EventRecord_APP_COMPLETED.String(),
+	}})
+	require.NoError(t, err)
+	summaries, err = repo.GetApplicationSummaries(ctx, "default", "root.default", ApplicationFilters{
+		ApplicationID: util.ToPtr("app1"),
+	})
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, si.EventRecord_APP_COMPLETED.String(), summaries[0].State)
+	assert.Equal(t, finishedAt, *summaries[0].FinishedTime)
+	assert.Equal(t, finishedAt-summaries[0].SubmissionTime, *summaries[0].Duration)
+	assert.Positive(t, summaries[0].VcoreSeconds)
+
+	// the summaries of the applications moved to the cold tier are kept, and erased and deleted with them
+	moved, err := repo.MoveApplicationsToColdTier(ctx, time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+	summaries, err = repo.GetApplicationSummaries(ctx, "default", "root.default", ApplicationFilters{})
+	require.NoError(t, err)
+	assert.Len(t, summaries, 6)
+
+	erased, err := repo.EraseUserApplications(ctx, "user2", "pseudonym", model.ErasureModePseudonymize, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), erased)
+	summaries, err = repo.GetApplicationSummaries(ctx, "default", "root.default", ApplicationFilters{
+		User: util.ToPtr("pseudonym"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app3", "app2"}, summaryIDs(summaries))
+
+	deleted, err := repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.default", time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	summaries, err = repo.GetApplicationSummaries(ctx, "default", "root.default", ApplicationFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app6", "app5", "app4", "app1"}, summaryIDs(summaries))
+}
+
+func summaryIDs(summaries []*model.ApplicationSummary) []string {
+	ids := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		ids = append(ids, summary.ApplicationID)
+	}
+	return ids
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestApplicationPriority(t *testing.T) {
+	tests := map[string]struct {
+		app  *dao.ApplicationDAOInfo
+		want *int32
+	}{
+		"no requests or allocations": {app: &dao.ApplicationDAOInfo{}},
+		"highest of requests and allocations": {
+			app: &dao.ApplicationDAOInfo{
+				Allocations: []*dao.AllocationDAOInfo{nil, {Priority: "10"}},
+				Requests:    []*dao.AllocationAskDAOInfo{{Priority: "100"}, {Priority: "-5"}},
+			},
+			want: util.ToPtr(int32(100)),
+		},
+		"invalid priorities are ignored": {
+			app:  &dao.ApplicationDAOInfo{Requests: []*dao.AllocationAskDAOInfo{{Priority: "high"}, {Priority: "-5"}}},
+			want: util.ToPtr(int32(-5)),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, applicationPriority(tt.app))
+		})
+	}
+}
+
+func TestWaitTime(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	running := []*dao.StateDAOInfo{{Time: 100, ApplicationState: "New"}, {Time: 250, ApplicationState: "Running"}}
+	tests := map[string]struct {
+		app      *dao.ApplicationDAOInfo
+		previous *storedApplication
+		want     *int64
+	}{
+		"not started": {
+			app: &dao.ApplicationDAOInfo{State: "New", SubmissionTime: 100, StateLog: running[:1]},
+		},
+		"started": {
+			app:  &dao.ApplicationDAOInfo{State: "Running", SubmissionTime: 100, StateLog: running},
+			want: util.ToPtr(int64(150)),
+		},
+		"submission time and state log of the stored application": {
+			app:      &dao.ApplicationDAOInfo{State: "Completed"},
+			previous: &storedApplication{SubmissionTime: util.ToPtr(int64(50)), StateLog: running},
+			want:     util.ToPtr(int64(200)),
+		},
+		"without submission time": {
+			app: &dao.ApplicationDAOInfo{State: "Running", StateLog: running},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, waitTime(tt.app, tt.previous, now))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// AutoscalerEventFilters select the occurrences of the events of the cluster-autoscaler during a period.
+type AutoscalerEventFilters struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// PendingBacklogFilters select the samples of the pending resources of a partition during a period.
+type PendingBacklogFilters struct {
+	Partition string
+	Start     *time.Time
+	End       *time.Time
+}
+
+// AddAutoscalerEvents stores the occurrences of the events of the cluster-autoscaler, and returns the number of
+// occurrences which were not stored yet.
+func (s *PostgresRepository) AddAutoscalerEvents(ctx context.Context, events []*model.AutoscalerEvent) (int, error) {
+	insertSQL := `INSERT INTO autoscaler_events (uid, time, reason, direction, object_kind, object_namespace,
+		object_name, message, count)
+		VALUES (@uid, @time, @reason, @direction, @object_kind, @object_namespace, @object_name, @message, @count)
+	ON CONFLICT (uid, time) DO NOTHING`
+
+	stored := 0
+	err := pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		for _, e := range events {
+			tag, err := tx.Exec(ctx, insertSQL, pgx.NamedArgs{
+				"uid":              e.UID,
+				"time":             e.Time,
+				"reason":           e.Reason,
+				"direction":        e.Direction,
+				"object_kind":      e.ObjectKind,
+				"object_namespace": e.ObjectNamespace,
+				"object_name":      e.ObjectName,
+				"message":          e.Message,
+				"count":            e.Count,
+			})
+			if err != nil {
+				return fmt.Errorf("could not insert autoscaler event into DB: %v", err)
+			}
+			stored += int(tag.RowsAffected())
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return stored, nil
+}
+
+// GetAutoscalerEvents returns the occurrences of the events of the cluster-autoscaler, oldest first.
+func (s *PostgresRepository) GetAutoscalerEvents(
+	ctx context.Context, filters AutoscalerEventFilters) ([]*model.AutoscalerEvent, error) {
+	query, args, err := autoscalerEventsQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get autoscaler events from DB: %v", err)
+	}
+
+	var events []*model.AutoscalerEvent
+	err = forEachRow(rows, "autoscaler events", func(e *model.AutoscalerEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// autoscalerEventsQuery builds the query of GetAutoscalerEvents.
+func autoscalerEventsQuery(filters AutoscalerEventFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(autoscalerEventsTable, "").
+		OrderBy("time", sql.OrderByAscending)
+	if filters.Start != nil {
+		queryBuilder.Conditionp("time", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("time", sql.LessThan, filters.End.UnixNano())
+	}
+	return queryBuilder
+}
+
+// GetPendingBacklog returns the samples of the pending resources of a partition, oldest first.
+func (s *PostgresRepository) GetPendingBacklog(
+	ctx context.Context, filters PendingBacklogFilters) ([]*model.PendingBacklogSample, error) {
+	query, args, err := pendingBacklogQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get pending backlog from DB: %v", err)
+	}
+
+	var samples []*model.PendingBacklogSample
+	err = forEachRow(rows, "pending backlog", func(sample *model.PendingBacklogSample) error {
+		samples = append(samples, sample)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// pendingBacklogQuery builds the query of GetPendingBacklog.
+func pendingBacklogQuery(filters PendingBacklogFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(pendingBacklogTable, "").
+		Conditionp("partition", sql.Equal, filters.Partition).
+		OrderBy("sampled_at", sql.OrderByAscending)
+	if filters.Start != nil {
+		queryBuilder.Conditionp("sampled_at", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("sampled_at", sql.LessThan, filters.End.UnixNano())
+	}
+	return queryBuilder
+}
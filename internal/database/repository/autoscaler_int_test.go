@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestAutoscalerEvents_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	events := []*model.AutoscalerEvent{
+		{
+			UID:        "uid-1",
+			Time:       2000,
+			Reason:     "TriggeredScaleUp",
+			Direction:  model.AutoscalerScaleUp,
+			ObjectKind: util.ToPtr("Pod"),
+			ObjectName: util.ToPtr("pod-1"),
+			Message:    util.ToPtr("pod triggered scale-up"),
+			Count:      1,
+		},
+		{UID: "uid-2", Time: 1000, Reason: "ScaleDown", Direction: model.AutoscalerScaleDown, Count: 1},
+	}
+	stored, err := repo.AddAutoscalerEvents(ctx, events)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored)
+
+	// occurrences already stored are skipped, and new occurrences of an event are stored
+	stored, err = repo.AddAutoscalerEvents(ctx, []*model.AutoscalerEvent{
+		events[0],
+		{UID: "uid-1", Time: 3000, Reason: "TriggeredScaleUp", Direction: model.AutoscalerScaleUp, Count: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored)
+
+	got, err := repo.GetAutoscalerEvents(ctx, AutoscalerEventFilters{})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "uid-2", got[0].UID)
+	assert.NotEmpty(t, got[1].ID)
+	events[0].ID = got[1].ID
+	assert.Equal(t, events[0], got[1])
+
+	got, err = repo.GetAutoscalerEvents(ctx, AutoscalerEventFilters{
+		Start: util.ToPtr(time.Unix(0, 1500)),
+		End:   util.ToPtr(time.Unix(0, 3000)),
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(2000), got[0].Time)
+}
+
+func TestPendingBacklog_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	start := time.Now()
+	queues := []*dao.PartitionQueueDAOInfo{
+		{QueueName: "root", Partition: "default", PendingResource: map[string]int64{"vcore": 4000}},
+		{QueueName: "root.a", Parent: "root", Partition: "default", PendingResource: map[string]int64{"vcore": 4000}},
+	}
+	require.NoError(t, repo.UpsertQueues(ctx, queues))
+	queues[0].PendingResource = map[string]int64{}
+	require.NoError(t, repo.UpsertQueues(ctx, queues[:1]))
+
+	// the pending resources of the root queues are sampled at every sync
+	samples, err := repo.GetPendingBacklog(ctx, PendingBacklogFilters{Partition: "default", Start: &start})
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, map[string]int64{"vcore": 4000}, samples[0].PendingResource)
+	assert.Empty(t, samples[1].PendingResource)
+	assert.Less(t, samples[0].SampledAt, samples[1].SampledAt)
+
+	samples, err = repo.GetPendingBacklog(ctx, PendingBacklogFilters{Partition: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, samples)
+}
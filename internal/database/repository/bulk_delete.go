@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BulkDeleteFilters select the applications of a bulk delete: those submitted before a time to the queues matching
+// a pattern, in which * matches any characters, e.g. root.sandbox.* for all queues below root.sandbox.
+// An empty partition matches all partitions.
+type BulkDeleteFilters struct {
+	Partition    string
+	QueuePattern string
+	Before       time.Time
+}
+
+// condition returns the condition on the applications matching the filters and its arguments, numbered from 1.
+func (f BulkDeleteFilters) condition() (string, []any) {
+	condition := `($1 = '' OR partition = $1) AND queue_name LIKE $2 AND submission_time < $3`
+	return condition, []any{f.Partition, queuePatternToLike(f.QueuePattern), f.Before.UnixNano()}
+}
+
+// queuePatternToLike converts a queue pattern, in which * matches any characters, to a LIKE pattern.
+func queuePatternToLike(pattern string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(pattern)
+	return strings.ReplaceAll(escaped, "*", "%")
+}
+
+// CountBulkDeleteApplications returns the number of applications in both tiers matching the filters which a bulk
+// delete would delete, and the number of those retained because they are under an active legal hold.
+func (s *PostgresRepository) CountBulkDeleteApplications(
+	ctx context.Context, filters BulkDeleteFilters) (deletable, held int64, err error) {
+	condition, args := filters.condition()
+	// the tiers are aliased as applications, which the legal hold condition refers to
+	query := `SELECT COUNT(*) FILTER (WHERE ` + applicationNotHeld + `), COUNT(*) FILTER (WHERE NOT ` + applicationNotHeld + `)
+		FROM ` + applicationTiersTable.From() + ` WHERE ` + condition
+	if err := s.dbpool.QueryRow(ctx, query, args...).Scan(&deletable, &held); err != nil {
+		return 0, 0, fmt.Errorf("could not count applications to delete in DB: %v", err)
+	}
+	return deletable, held, nil
+}
+
+// BulkDeleteApplications deletes at most limit applications in both tiers matching the filters, together with their
+// allocation constraints, summaries and trace events, see deleteApplicationsSQL, and returns the number of deleted
+// applications. Applications under an active legal hold are not deleted.
+func (s *PostgresRepository) BulkDeleteApplications(
+	ctx context.Context, filters BulkDeleteFilters, limit int) (int64, error) {
+	condition, args := filters.condition()
+	var total int64
+	for _, tier := range applicationTiers {
+		if total >= int64(limit) {
+			break
+		}
+		query := deleteApplicationsSQL(tier, `id IN (
+			SELECT id FROM `+tier.From()+` AS applications WHERE `+condition+` AND `+applicationNotHeld+`
+			LIMIT $4)`)
+		var deleted int64
+		if err := s.dbpool.QueryRow(ctx, query, append(args, int64(limit)-total)...).Scan(&deleted); err != nil {
+			return total, fmt.Errorf("could not delete applications from DB: %v", err)
+		}
+		total += deleted
+	}
+	return total, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestBulkDeleteApplications_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	if err != nil {
+		t.Fatalf("could not create repository: %v", err)
+	}
+
+	now := time.Now()
+	old := now.Add(-48 * time.Hour).UnixNano()
+	queues := []*dao.PartitionQueueDAOInfo{
+		{Partition: "default", QueueName: "root"},
+		{Partition: "default", QueueName: "root.sandbox"},
+		{Partition: "default", QueueName: "root.sandbox.load"},
+		{Partition: "default", QueueName: "root.sandbox_prod"},
+	}
+	require.NoError(t, repo.AddQueues(ctx, nil, queues))
+	apps := []*dao.ApplicationDAOInfo{
+		{ApplicationID: "load-1", Partition: "default", QueueName: "root.sandbox.load", SubmissionTime: old},
+		{ApplicationID: "load-2", Partition: "default", QueueName: "root.sandbox.load", SubmissionTime: old},
+		{ApplicationID: "load-3", Partition: "default", QueueName: "root.sandbox.load", SubmissionTime: old},
+		{ApplicationID: "held", Partition: "default", QueueName: "root.sandbox.load", SubmissionTime: old},
+		{ApplicationID: "recent", Partition: "default", QueueName: "root.sandbox.load", SubmissionTime: now.UnixNano()},
+		{ApplicationID: "parent", Partition: "default", QueueName: "root.sandbox", SubmissionTime: old},
+		{ApplicationID: "underscore", Partition: "default", QueueName: "root.sandbox_prod", SubmissionTime: old},
+		// the same ID in another partition, whose trace is shared with the deleted application
+		{ApplicationID: "shared", Partition: "default", QueueName: "root.sandbox.load", SubmissionTime: old},
+		{ApplicationID: "shared", Partition: "gpu", QueueName: "root.batch", SubmissionTime: old},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	require.NoError(t, repo.CreateLegalHold(ctx, &model.LegalHold{
+		Partition:     "default",
+		ApplicationID: util.ToPtr("held"),
+		Reason:        "investigation",
+		CreatedBy:     "ops",
+	}))
+	stored, err := repo.AddTraceEvent(ctx, &model.TraceEvent{
+		ApplicationID: "load-1", Type: "APP", ObjectID: "load-1", ChangeType: "ADD", ChangeDetail: "APP_NEW", Timestamp: old,
+	}, 10)
+	require.NoError(t, err)
+	require.True(t, stored)
+	stored, err = repo.AddTraceEvent(ctx, &model.TraceEvent{
+		ApplicationID: "shared", Type: "APP", ObjectID: "shared", ChangeType: "ADD", ChangeDetail: "APP_NEW", Timestamp: old,
+	}, 10)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	filters := BulkDeleteFilters{QueuePattern: "root.sandbox.*", Before: now.Add(-time.Hour)}
+	deletable, held, err := repo.CountBulkDeleteApplications(ctx, filters)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), deletable)
+	assert.Equal(t, int64(1), held)
+
+	deleted, err := repo.BulkDeleteApplications(ctx, filters, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	deleted, err = repo.BulkDeleteApplications(ctx, filters, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	deleted, err = repo.BulkDeleteApplications(ctx, filters, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	remaining, err := repo.GetAllApplications(ctx, ApplicationFilters{})
+	require.NoError(t, err)
+	var ids []string
+	for _, app := range remaining {
+		ids = append(ids, app.ApplicationID)
+	}
+	assert.ElementsMatch(t, []string{"held", "recent", "parent", "underscore", "shared"}, ids)
+
+	events, err := repo.GetTraceEvents(ctx, "load-1", TraceEventFilters{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = repo.GetTraceEvents(ctx, "shared", TraceEventFilters{})
+	require.NoError(t, err)
+	assert.Len(t, events, 1, "the trace of an application remaining in another partition must be kept")
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// RegisterCluster stores the cluster, or updates its scheduler version and labels if it is already registered,
+// and records that it was seen now. It sets the times of the cluster.
+func (s *PostgresRepository) RegisterCluster(ctx context.Context, cluster *model.Cluster) error {
+	labels := cluster.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	upsertSQL := `INSERT INTO clusters (name, scheduler_version, labels, registered_at, last_seen)
+		VALUES (@name, @scheduler_version, @labels, @now, @now)
+		ON CONFLICT (name) DO UPDATE SET
+			scheduler_version = EXCLUDED.scheduler_version,
+			labels = EXCLUDED.labels,
+			last_seen = EXCLUDED.last_seen
+		RETURNING registered_at, last_seen, ingested_until`
+	err := s.dbpool.QueryRow(ctx, upsertSQL, pgx.NamedArgs{
+		"name":              cluster.Name,
+		"scheduler_version": cluster.SchedulerVersion,
+		"labels":            labels,
+		"now":               time.Now().Unix(),
+	}).Scan(&cluster.RegisteredAt, &cluster.LastSeen, &cluster.IngestedUntil)
+	if err != nil {
+		return fmt.Errorf("could not upsert cluster into DB: %v", err)
+	}
+	cluster.Labels = labels
+	return nil
+}
+
+// HeartbeatCluster records that the registered cluster was seen now and, if given, the time up to which its
+// agent ingested the data of the cluster.
+func (s *PostgresRepository) HeartbeatCluster(ctx context.Context, name string, ingestedUntil *time.Time) (*model.Cluster, error) {
+	var until *int64
+	if ingestedUntil != nil {
+		until = util.ToPtr(ingestedUntil.Unix())
+	}
+	updateSQL := `UPDATE clusters SET last_seen = $2, ingested_until = COALESCE($3, ingested_until)
+		WHERE name = $1
+		RETURNING *`
+	rows, err := s.dbpool.Query(ctx, updateSQL, name, time.Now().Unix(), until)
+	if err != nil {
+		return nil, fmt.Errorf("could not update cluster in DB: %v", err)
+	}
+	cluster, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[model.Cluster])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not update cluster in DB: %v", err)
+	}
+	return cluster, nil
+}
+
+// GetClusters returns the registered clusters ordered by name.
+func (s *PostgresRepository) GetClusters(ctx context.Context) ([]*model.Cluster, error) {
+	rows, err := s.dbpool.Query(ctx, "SELECT * FROM clusters ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("could not get clusters from DB: %v", err)
+	}
+	var clusters []*model.Cluster
+	err = forEachRow(rows, "clusters", func(cluster *model.Cluster) error {
+		clusters = append(clusters, cluster)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestClusters_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	_, err = repo.HeartbeatCluster(ctx, "eu-west", nil)
+	assert.ErrorIs(t, err, ErrClusterNotFound)
+
+	cluster := &model.Cluster{Name: "eu-west", SchedulerVersion: "1.5.0", Labels: map[string]string{"region": "eu"}}
+	require.NoError(t, repo.RegisterCluster(ctx, cluster))
+	assert.NotZero(t, cluster.RegisteredAt)
+	assert.Equal(t, cluster.RegisteredAt, cluster.LastSeen)
+	assert.Nil(t, cluster.IngestedUntil)
+	require.NoError(t, repo.RegisterCluster(ctx, &model.Cluster{Name: "ap-south", SchedulerVersion: "1.6.0"}))
+
+	ingestedUntil := time.Now().Add(-time.Minute)
+	heartbeat, err := repo.HeartbeatCluster(ctx, "eu-west", &ingestedUntil)
+	require.NoError(t, err)
+	require.NotNil(t, heartbeat.IngestedUntil)
+	assert.Equal(t, ingestedUntil.Unix(), *heartbeat.IngestedUntil)
+	// heartbeats without ingestion progress keep the last reported progress
+	heartbeat, err = repo.HeartbeatCluster(ctx, "eu-west", nil)
+	require.NoError(t, err)
+	require.NotNil(t, heartbeat.IngestedUntil)
+	assert.Equal(t, ingestedUntil.Unix(), *heartbeat.IngestedUntil)
+
+	// registering again updates the cluster and keeps its registration time and ingestion progress
+	reregistered := &model.Cluster{Name: "eu-west", SchedulerVersion: "1.6.0"}
+	require.NoError(t, repo.RegisterCluster(ctx, reregistered))
+	assert.Equal(t, cluster.RegisteredAt, reregistered.RegisteredAt)
+	assert.Equal(t, heartbeat.IngestedUntil, reregistered.IngestedUntil)
+
+	clusters, err := repo.GetClusters(ctx)
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, "ap-south", clusters[0].Name)
+	assert.Equal(t, map[string]string{}, clusters[0].Labels)
+	assert.Equal(t, "eu-west", clusters[1].Name)
+	assert.Equal(t, "1.6.0", clusters[1].SchedulerVersion)
+	assert.Equal(t, map[string]string{}, clusters[1].Labels)
+}
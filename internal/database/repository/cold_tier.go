@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+)
+
+// applicationTiers are the tables of the tiers of the applications: the hot tier, which the applications are stored
+// in, and the cold tier, which they are moved to once they finished before the cold tier boundary.
+var applicationTiers = []*sql.Table{applicationsTable, applicationsColdTable}
+
+// MoveApplicationsToColdTier moves at most limit applications which finished before the given time to the cold tier
+// and returns the number of moved applications. Their allocation constraints and summaries are kept, as they
+// reference the applications by ID.
+func (s *PostgresRepository) MoveApplicationsToColdTier(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := `WITH moved AS (
+			DELETE FROM applications WHERE id IN (
+				SELECT id FROM applications WHERE finished_time IS NOT NULL AND finished_time < $1 LIMIT $2)
+			RETURNING *)
+		INSERT INTO applications_cold SELECT * FROM moved`
+	tag, err := s.dbpool.Exec(ctx, query, before.UnixNano(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("could not move applications to cold tier in DB: %v", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// applicationsSource returns the table the applications are selected from, given the lower bounds of the submission
+// or finished times of the selected applications: the hot tier, or both tiers if the cold tier may hold
+// selected applications.
+func (s *PostgresRepository) applicationsSource(lowerBounds ...*time.Time) *sql.Table {
+	if s.selectsColdTier(lowerBounds...) {
+		return applicationTiersTable
+	}
+	return applicationsTable
+}
+
+// selectsColdTier returns whether the cold tier may hold applications submitted or finished after the lower bounds.
+// As only applications which finished before the cold tier boundary are in the cold tier, it does not if
+// the tiering is disabled or a bound is after the boundary.
+func (s *PostgresRepository) selectsColdTier(lowerBounds ...*time.Time) bool {
+	if s.coldAfter == 0 {
+		return false
+	}
+	boundary := s.coldTierBoundary()
+	for _, bound := range lowerBounds {
+		if bound != nil && !bound.Before(boundary) {
+			return false
+		}
+	}
+	return true
+}
+
+// coldTierBoundary returns the time the applications of the cold tier finished before. The applications are moved
+// once they finished coldAfter ago, so the boundary computed by any server is after the applications already moved,
+// as long as the servers are configured with the same coldAfter.
+func (s *PostgresRepository) coldTierBoundary() time.Time {
+	return time.Now().Add(-s.coldAfter)
+}
+
+// inColdTier returns whether the application was moved to the cold tier. Only applications which finished before
+// the cold tier boundary are looked up, as the others cannot be in the cold tier.
+func (s *PostgresRepository) inColdTier(ctx context.Context, tx pgx.Tx, app *dao.ApplicationDAOInfo) (bool, error) {
+	if s.coldAfter == 0 || app.FinishedTime == nil || *app.FinishedTime >= s.coldTierBoundary().UnixNano() {
+		return false, nil
+	}
+	var id string
+	err := tx.QueryRow(ctx, `SELECT id FROM applications_cold WHERE partition = $1 AND queue_name = $2 AND app_id = $3`,
+		app.Partition, app.QueueName, app.ApplicationID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not get application %s from cold tier in DB: %v", app.ApplicationID, err)
+	}
+	return true, nil
+}
+
+// deleteApplicationsSQL returns a statement which deletes the applications of the tier matching the condition
+// together with their allocation constraints, summaries and trace events, and selects the number of deleted
+// applications. The tier is aliased as applications, which the condition refers to. As traces are keyed by the ID of
+// the application only, the trace of an application is kept while an application with the same ID remains in another
+// partition or tier.
+func deleteApplicationsSQL(tier *sql.Table, condition string) string {
+	return `WITH deleted AS (
+			DELETE FROM ` + tier.From() + ` AS applications WHERE ` + condition + `
+			RETURNING id, partition, app_id
+		), deleted_constraints AS (
+			DELETE FROM allocation_constraints WHERE application_id IN (SELECT id FROM deleted)
+		), deleted_summaries AS (
+			DELETE FROM application_summary WHERE application_id IN (SELECT id FROM deleted)
+		), deleted_traces AS (
+			DELETE FROM trace_events t WHERE t.app_id IN (SELECT app_id FROM deleted)
+			AND NOT EXISTS (
+				SELECT 1 FROM ` + applicationTiersTable.From() + `
+				WHERE applications.app_id = t.app_id
+				AND (applications.partition, applications.app_id) NOT IN (SELECT partition, app_id FROM deleted))
+		)
+		SELECT COUNT(*) FROM deleted`
+}
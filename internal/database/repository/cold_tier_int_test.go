@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestColdTier_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithColdTier(time.Hour))
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	// app2 and app3 finished more than an hour ago
+	moved, err := repo.MoveApplicationsToColdTier(ctx, time.Now().Add(-time.Hour), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+	moved, err = repo.MoveApplicationsToColdTier(ctx, time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+
+	apps, err := repo.GetAllApplications(ctx, ApplicationFilters{})
+	require.NoError(t, err)
+	assert.Len(t, apps, 6, "both tiers must be selected")
+	apps, err = repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{
+		FinishedStartTime: util.ToPtr(time.Now().Add(-5 * time.Hour)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app6", "app3", "app2"}, applicationIDs(apps))
+	apps, err = repo.GetAllApplications(ctx, ApplicationFilters{SubmissionStartTime: util.ToPtr(time.Now().Add(-30 * time.Minute))})
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+
+	hotOnly, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	apps, err = hotOnly.GetAllApplications(ctx, ApplicationFilters{})
+	require.NoError(t, err)
+	assert.Len(t, apps, 4, "the cold tier must not be selected when the tiering is disabled")
+
+	// the scheduler may still report an application moved to the cold tier
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID: "app2",
+		Partition:     "default",
+		QueueName:     "root.default",
+		FinishedTime:  util.ToPtr(time.Now().Add(-4 * time.Hour).UnixNano()),
+		State:         si.EventRecord_APP_COMPLETED.String(),
+	}})
+	require.NoError(t, err)
+	apps, err = repo.GetAllApplications(ctx, ApplicationFilters{ApplicationID: util.ToPtr("app2")})
+	require.NoError(t, err)
+	assert.Len(t, apps, 1, "applications of the cold tier must not be stored again")
+
+	count, err := repo.CountFinishedApplications(ctx, "default", "root", si.EventRecord_APP_COMPLETED.String(),
+		time.Now().Add(-5*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// the applications of the cold tier are erased and pruned
+	userCount, err := repo.CountUserApplications(ctx, "user2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), userCount)
+	erased, err := repo.EraseUserApplications(ctx, "user2", "pseudonym", model.ErasureModePseudonymize, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), erased)
+	// the traces of the pruned applications are deleted with them, those of the remaining applications are kept
+	for _, appID := range []string{"app2", "app6"} {
+		stored, err := repo.AddTraceEvent(ctx, &model.TraceEvent{
+			ApplicationID: appID, Type: "APP", ObjectID: appID, ChangeType: "ADD", ChangeDetail: "APP_NEW",
+			Timestamp: time.Now().UnixNano(),
+		}, 10)
+		require.NoError(t, err)
+		require.True(t, stored)
+	}
+	deleted, err := repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.default", time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	apps, err = repo.GetAllApplications(ctx, ApplicationFilters{})
+	require.NoError(t, err)
+	assert.Len(t, apps, 4)
+	events, err := repo.GetTraceEvents(ctx, "app2", TraceEventFilters{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	events, err = repo.GetTraceEvents(ctx, "app6", TraceEventFilters{})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func applicationIDs(apps []*model.ApplicationDAOInfo) []string {
+	ids := make([]string, 0, len(apps))
+	for _, app := range apps {
+		ids = append(ids, app.ApplicationID)
+	}
+	return ids
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestApplicationsSource(t *testing.T) {
+	beforeBoundary := util.ToPtr(time.Now().Add(-60 * 24 * time.Hour))
+	afterBoundary := util.ToPtr(time.Now().Add(-24 * time.Hour))
+
+	hotOnly := &PostgresRepository{}
+	assert.Equal(t, applicationsTable, hotOnly.applicationsSource(nil, beforeBoundary))
+
+	tiered := &PostgresRepository{coldAfter: 30 * 24 * time.Hour}
+	assert.Equal(t, applicationTiersTable, tiered.applicationsSource(), "unbounded queries must select both tiers")
+	assert.Equal(t, applicationTiersTable, tiered.applicationsSource(nil, beforeBoundary))
+	assert.Equal(t, applicationsTable, tiered.applicationsSource(afterBoundary, nil))
+	assert.Equal(t, applicationsTable, tiered.applicationsSource(beforeBoundary, afterBoundary))
+}
+
+func TestAnalyticsLowerBounds(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	query := AnalyticsQuery{
+		Entity: "applications",
+		Filters: []AnalyticsFilter{
+			{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{start.Add(time.Hour)}},
+			{Field: "finishedTime", Operator: sql.Equal, Values: []any{start.Add(time.Hour), start}},
+			{Field: "finishedTime", Operator: sql.LessThan, Values: []any{start}},
+			{Field: "queueName", Operator: sql.Equal, Values: []any{"root.default"}},
+		},
+	}
+	assert.Equal(t, []*time.Time{util.ToPtr(start.Add(time.Hour)), &start}, analyticsLowerBounds(query))
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// MaxDataQualityViolations is the maximum number of violations stored per data quality check.
+const MaxDataQualityViolations = 10000
+
+// DataQualityCheck is an invariant of the stored data. Its query selects the partition, queue name,
+// application ID, node ID and detail of the records violating it.
+type DataQualityCheck struct {
+	Name        string
+	Description string
+	query       string
+}
+
+// finalApplicationStates are the states of applications which finished.
+const finalApplicationStates = `('COMPLETED', 'FAILED', 'REJECTED', 'EXPIRED')`
+
+// DataQualityChecks are the data quality checks, which are run in this order.
+var DataQualityChecks = []DataQualityCheck{
+	{
+		Name:        "allocations_without_finish",
+		Description: "Applications in a final state which still have allocations but no finished time.",
+		query: `SELECT partition, queue_name, app_id, NULL,
+				'application ' || state || ' with ' || jsonb_array_length(allocations) || ' allocations and no finished time'
+			FROM applications
+			WHERE finished_time IS NULL AND jsonb_typeof(allocations) = 'array' AND jsonb_array_length(allocations) > 0
+				AND regexp_replace(upper(state), '^APP_', '') IN ` + finalApplicationStates,
+	},
+	{
+		Name:        "negative_durations",
+		Description: "Applications which finished before they were submitted, and allocations made before they were requested.",
+		query: `SELECT partition, queue_name, app_id, NULL,
+				'application finished ' || ((submission_time - finished_time) / 1e9)::TEXT || 's before it was submitted'
+			FROM applications
+			WHERE submission_time > 0 AND finished_time > 0 AND finished_time < submission_time
+			UNION ALL
+			SELECT a.partition, a.queue_name, a.app_id, NULLIF(e->>'nodeId', ''),
+				'allocation ' || (e->>'allocationKey') || ' made ' ||
+					(((e->>'requestTime')::BIGINT - (e->>'allocationTime')::BIGINT) / 1e9)::TEXT || 's before it was requested'
+			FROM applications a, jsonb_array_elements(CASE WHEN jsonb_typeof(a.allocations) = 'array'
+				THEN a.allocations ELSE '[]' END) e
+			WHERE (e->>'allocationTime')::BIGINT > 0 AND (e->>'allocationTime')::BIGINT < (e->>'requestTime')::BIGINT`,
+	},
+	{
+		Name:        "unknown_nodes",
+		Description: "Allocations of applications on nodes which are not stored.",
+		query: `SELECT a.partition, a.queue_name, a.app_id, e->>'nodeId',
+				'allocation ' || (e->>'allocationKey') || ' on unknown node ' || (e->>'nodeId')
+			FROM applications a, jsonb_array_elements(CASE WHEN jsonb_typeof(a.allocations) = 'array'
+				THEN a.allocations ELSE '[]' END) e
+			WHERE COALESCE(e->>'nodeId', '') <> '' AND NOT EXISTS (SELECT 1 FROM nodes n WHERE n.node_id = e->>'nodeId')`,
+	},
+}
+
+// DataQualityFilters select the violations of a check, or of all checks.
+type DataQualityFilters struct {
+	Check  *string
+	Offset *int
+	Limit  *int
+}
+
+// CheckDataQuality runs the check at the given time and replaces its stored violations with the records violating
+// it, of which at most MaxDataQualityViolations are stored. It returns the number of violating records.
+func (s *PostgresRepository) CheckDataQuality(ctx context.Context, check DataQualityCheck, at time.Time) (int64, error) {
+	var violations int64
+	err := pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM data_quality_violations WHERE check_name = $1", check.Name); err != nil {
+			return fmt.Errorf("could not delete data quality violations from DB: %v", err)
+		}
+		// the violations are counted and stored by one statement, so that the check query runs once
+		checkSQL := `WITH violations AS MATERIALIZED (` + check.query + `),
+			stored AS (
+				INSERT INTO data_quality_violations (check_name, partition, queue_name, app_id, node_id, detail, detected_at)
+				SELECT $1, *, $2 FROM violations LIMIT $3
+			)
+			SELECT COUNT(*) FROM violations`
+		err := tx.QueryRow(ctx, checkSQL, check.Name, at.Unix(), MaxDataQualityViolations).Scan(&violations)
+		if err != nil {
+			return fmt.Errorf("could not run data quality check %s in DB: %v", check.Name, err)
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO data_quality_checks (check_name, violations, checked_at) VALUES ($1, $2, $3)
+			ON CONFLICT (check_name) DO UPDATE SET violations = EXCLUDED.violations, checked_at = EXCLUDED.checked_at`,
+			check.Name, violations, at.Unix())
+		if err != nil {
+			return fmt.Errorf("could not store data quality check %s in DB: %v", check.Name, err)
+		}
+		return nil
+	})
+	return violations, err
+}
+
+// GetDataQualityChecks returns the data quality checks with the results of their last run, if any.
+func (s *PostgresRepository) GetDataQualityChecks(ctx context.Context) ([]*model.DataQualityCheck, error) {
+	rows, err := s.dbpool.Query(ctx, "SELECT * FROM data_quality_checks")
+	if err != nil {
+		return nil, fmt.Errorf("could not get data quality checks from DB: %v", err)
+	}
+	results := make(map[string]*model.DataQualityCheck)
+	err = forEachRow(rows, "data quality checks", func(check *model.DataQualityCheck) error {
+		results[check.Name] = check
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	checks := make([]*model.DataQualityCheck, 0, len(DataQualityChecks))
+	for _, check := range DataQualityChecks {
+		result, ok := results[check.Name]
+		if !ok {
+			result = &model.DataQualityCheck{Name: check.Name}
+		}
+		result.Description = check.Description
+		checks = append(checks, result)
+	}
+	return checks, nil
+}
+
+// GetDataQualityViolations returns the stored violations of the last runs of the checks, ordered by check
+// and then by ID, so that they can be paginated.
+func (s *PostgresRepository) GetDataQualityViolations(
+	ctx context.Context,
+	filters DataQualityFilters,
+) ([]*model.DataQualityViolation, error) {
+	query, args, err := dataQualityViolationsQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get data quality violations from DB: %v", err)
+	}
+	var violations []*model.DataQualityViolation
+	err = forEachRow(rows, "data quality violations", func(violation *model.DataQualityViolation) error {
+		violations = append(violations, violation)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return violations, nil
+}
+
+// dataQualityViolationsQuery builds the query of GetDataQualityViolations.
+func dataQualityViolationsQuery(filters DataQualityFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().SelectAll(dataQualityViolationsTable, "").
+		OrderBy("check_name", sql.OrderByAscending).
+		OrderBy("id", sql.OrderByAscending)
+	if filters.Check != nil {
+		queryBuilder.Conditionp("check_name", sql.Equal, *filters.Check)
+	}
+	applyLimitAndOffset(queryBuilder, filters.Limit, filters.Offset)
+	return queryBuilder
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestDataQuality_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpsertNodes(ctx, []*dao.NodeDAOInfo{{NodeID: "node-1", HostName: "node-1"}}, "default"))
+
+	submitted := time.Now().Add(-time.Hour).UnixNano()
+	apps := []*dao.ApplicationDAOInfo{
+		{
+			ApplicationID:  "consistent",
+			Partition:      "default",
+			QueueName:      "root.default",
+			SubmissionTime: submitted,
+			FinishedTime:   util.ToPtr(submitted + int64(time.Minute)),
+			State:          "Completed",
+			Allocations: []*dao.AllocationDAOInfo{
+				{AllocationKey: "alloc-1", NodeID: "node-1", RequestTime: submitted, AllocationTime: submitted},
+			},
+		},
+		{
+			ApplicationID:  "unfinished",
+			Partition:      "default",
+			QueueName:      "root.default",
+			SubmissionTime: submitted,
+			State:          "Failed",
+			Allocations: []*dao.AllocationDAOInfo{
+				{AllocationKey: "alloc-2", NodeID: "node-2", RequestTime: submitted, AllocationTime: submitted},
+			},
+		},
+		{
+			ApplicationID:  "time-travelling",
+			Partition:      "default",
+			QueueName:      "root.default",
+			SubmissionTime: submitted,
+			FinishedTime:   util.ToPtr(submitted - int64(time.Minute)),
+			State:          "Completed",
+			Allocations: []*dao.AllocationDAOInfo{
+				{AllocationKey: "alloc-3", NodeID: "node-1", RequestTime: submitted, AllocationTime: submitted - 1},
+			},
+		},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	checks, err := repo.GetDataQualityChecks(ctx)
+	require.NoError(t, err)
+	require.Len(t, checks, len(DataQualityChecks))
+	for _, check := range checks {
+		assert.NotEmpty(t, check.Description)
+		assert.Nil(t, check.CheckedAt, "checks which did not run have no results")
+	}
+
+	at := time.Now()
+	expected := map[string]int64{
+		"allocations_without_finish": 1,
+		"negative_durations":         2,
+		"unknown_nodes":              1,
+	}
+	for _, check := range DataQualityChecks {
+		violations, err := repo.CheckDataQuality(ctx, check, at)
+		require.NoError(t, err)
+		assert.Equal(t, expected[check.Name], violations, check.Name)
+	}
+
+	checks, err = repo.GetDataQualityChecks(ctx)
+	require.NoError(t, err)
+	for _, check := range checks {
+		assert.Equal(t, expected[check.Name], check.Violations, check.Name)
+		require.NotNil(t, check.CheckedAt)
+		assert.Equal(t, at.Unix(), *check.CheckedAt)
+	}
+
+	violations, err := repo.GetDataQualityViolations(ctx, DataQualityFilters{Check: util.ToPtr("unknown_nodes")})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "unfinished", *violations[0].ApplicationID)
+	assert.Equal(t, "node-2", *violations[0].NodeID)
+
+	// violations are replaced by the next run of their check
+	_, err = connPool.Exec(ctx, "DELETE FROM applications WHERE app_id = 'unfinished'")
+	require.NoError(t, err)
+	for _, check := range DataQualityChecks {
+		_, err := repo.CheckDataQuality(ctx, check, at)
+		require.NoError(t, err)
+	}
+	violations, err = repo.GetDataQualityViolations(ctx, DataQualityFilters{})
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+	for _, violation := range violations {
+		assert.Equal(t, "negative_durations", violation.Check)
+		assert.Equal(t, "time-travelling", *violation.ApplicationID)
+	}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// DuplicateStrategy selects the canonical run of an application whose ID appears in several clusters, e.g. after
+// the application was resubmitted to another cluster on failover, so that the runs are counted once.
+type DuplicateStrategy string
+
+const (
+	// DuplicateLatest selects the run submitted last, e.g. the rerun which completed the work after a failover.
+	DuplicateLatest DuplicateStrategy = "latest"
+	// DuplicateFirst selects the run submitted first, e.g. to report the applications by the time they were submitted.
+	DuplicateFirst DuplicateStrategy = "first"
+)
+
+// DuplicateStrategies are the strategies to disambiguate the runs of an application in several clusters.
+var DuplicateStrategies = []DuplicateStrategy{DuplicateLatest, DuplicateFirst}
+
+// DuplicateApplicationFilters select the applications with runs in several clusters, submitted in [Start, End).
+type DuplicateApplicationFilters struct {
+	Strategy DuplicateStrategy
+	Start    time.Time
+	End      time.Time
+	Offset   *int
+	Limit    *int
+}
+
+// clusterOfPartition returns the SQL expression of the ID of the cluster of the partition in the column, like
+// cluster.Split.
+func clusterOfPartition(column string) string {
+	return fmt.Sprintf(`CASE WHEN starts_with(%[1]s, '[') AND strpos(%[1]s, ']') > 0
+		THEN substr(%[1]s, 2, strpos(%[1]s, ']') - 2) ELSE '' END`, column)
+}
+
+// canonicalRun returns the SQL condition which matches the rows of the table, with the app_id, partition and
+// submission_time columns of the applications, which are the canonical run of their application ID by the strategy:
+// no other run, in any cluster or partition, was submitted later, or earlier for DuplicateFirst. Runs submitted at
+// the same time are ordered by partition, so exactly one run is canonical. The runs of both tiers are compared, so
+// that the canonical run is the same whichever tier is selected.
+func canonicalRun(strategy DuplicateStrategy, table string) (string, error) {
+	var op string
+	switch strategy {
+	case DuplicateLatest:
+		op = ">"
+	case DuplicateFirst:
+		op = "<"
+	default:
+		return "", fmt.Errorf("unknown duplicate strategy %q", strategy)
+	}
+	return `NOT EXISTS (
+		SELECT 1 FROM (
+			SELECT app_id, partition, submission_time FROM ` + applicationsTable.From() + `
+			UNION ALL
+			SELECT app_id, partition, submission_time FROM ` + applicationsColdTable.From() + `
+		) AS runs
+		WHERE runs.app_id = ` + table + `.app_id
+			AND (COALESCE(runs.submission_time, 0), runs.partition) ` + op + `
+				(COALESCE(` + table + `.submission_time, 0), ` + table + `.partition))`, nil
+}
+
+// GetDuplicateApplications returns the applications with runs in several clusters, ordered by application ID, with
+// their runs of all clusters ordered by submission time, and the number of these applications on all pages. The
+// canonical run of each application is marked by the strategy, so none is marked if it was submitted outside the
+// time range.
+func (s *PostgresRepository) GetDuplicateApplications(
+	ctx context.Context,
+	filters DuplicateApplicationFilters,
+) ([]*model.DuplicateApplication, PageInfo, error) {
+	canonical, err := canonicalRun(filters.Strategy, "applications")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	source := s.applicationsSource(&filters.Start).From()
+	duplicateIDs := `SELECT app_id FROM ` + source + `
+			WHERE submission_time >= $1 AND submission_time < $2
+			GROUP BY app_id
+			HAVING COUNT(DISTINCT ` + clusterOfPartition("partition") + `) > 1`
+	var page PageInfo
+	if err := s.dbpool.QueryRow(ctx, `SELECT COUNT(*) FROM (`+duplicateIDs+`) AS duplicates`,
+		filters.Start.UnixNano(), filters.End.UnixNano()).Scan(&page.Total); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not count duplicate applications in DB: %v", err)
+	}
+	query := `WITH duplicates AS (
+			` + duplicateIDs + `
+			ORDER BY app_id
+			LIMIT $3 OFFSET $4
+		)
+		SELECT applications.app_id, applications.partition, applications.queue_name, applications.state,
+			applications.submission_time, ` + canonical + `
+		FROM ` + source + `
+		JOIN duplicates ON duplicates.app_id = applications.app_id
+		WHERE applications.submission_time >= $1 AND applications.submission_time < $2
+		ORDER BY applications.app_id, applications.submission_time, applications.partition`
+	rows, err := s.dbpool.Query(ctx, query, filters.Start.UnixNano(), filters.End.UnixNano(), filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not get duplicate applications from DB: %v", err)
+	}
+	defer rows.Close()
+
+	var duplicates []*model.DuplicateApplication
+	for rows.Next() {
+		var appID string
+		var run model.DuplicateRun
+		if err := rows.Scan(&appID, &run.Partition, &run.QueueName, &run.ApplicationState, &run.SubmissionTime,
+			&run.Canonical); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("could not scan duplicate applications from DB: %v", err)
+		}
+		run.ClusterID, _ = cluster.Split(run.Partition)
+		if len(duplicates) == 0 || duplicates[len(duplicates)-1].ApplicationID != appID {
+			duplicates = append(duplicates, &model.DuplicateApplication{ApplicationID: appID})
+		}
+		last := duplicates[len(duplicates)-1]
+		last.Runs = append(last.Runs, &run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not read duplicate applications from DB: %v", err)
+	}
+	return duplicates, page, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestGetDuplicateApplications_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	// app1 failed over from eu-west to us-east, app2 runs in two partitions of the default cluster only, and app3 runs
+	// in two partitions of eu-west before it failed over to us-east
+	start := time.Now().Add(-time.Hour)
+	apps := []*dao.ApplicationDAOInfo{
+		{ApplicationID: "app1", Partition: "[eu-west]default", SubmissionTime: start.Add(time.Minute).UnixNano()},
+		{ApplicationID: "app1", Partition: "[us-east]default", SubmissionTime: start.Add(2 * time.Minute).UnixNano()},
+		{ApplicationID: "app2", Partition: "default", SubmissionTime: start.Add(time.Minute).UnixNano()},
+		{ApplicationID: "app2", Partition: "batch", SubmissionTime: start.Add(2 * time.Minute).UnixNano()},
+		{ApplicationID: "app3", Partition: "[eu-west]default", SubmissionTime: start.Add(time.Minute).UnixNano()},
+		{ApplicationID: "app3", Partition: "[eu-west]batch", SubmissionTime: start.Add(2 * time.Minute).UnixNano()},
+		{ApplicationID: "app3", Partition: "[us-east]default", SubmissionTime: start.Add(3 * time.Minute).UnixNano()},
+	}
+	for _, app := range apps {
+		app.QueueName = "root." + app.ApplicationID
+		app.User = "user-" + app.ApplicationID
+		app.State = "Completed"
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	filters := DuplicateApplicationFilters{Strategy: DuplicateLatest, Start: start, End: time.Now()}
+	duplicates, page, err := repo.GetDuplicateApplications(ctx, filters)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, duplicates, 2)
+	assert.Equal(t, "app1", duplicates[0].ApplicationID)
+	require.Len(t, duplicates[0].Runs, 2)
+	assert.Equal(t, "eu-west", duplicates[0].Runs[0].ClusterID)
+	assert.False(t, duplicates[0].Runs[0].Canonical)
+	assert.Equal(t, "us-east", duplicates[0].Runs[1].ClusterID)
+	assert.True(t, duplicates[0].Runs[1].Canonical)
+	// the runs in the partitions of one cluster are not both canonical
+	assert.Equal(t, "app3", duplicates[1].ApplicationID)
+	require.Len(t, duplicates[1].Runs, 3)
+	assert.False(t, duplicates[1].Runs[0].Canonical)
+	assert.False(t, duplicates[1].Runs[1].Canonical)
+	assert.True(t, duplicates[1].Runs[2].Canonical)
+
+	// the total counts the applications of all pages
+	paged := filters
+	paged.Offset, paged.Limit = util.ToPtr(1), util.ToPtr(1)
+	duplicates, page, err = repo.GetDuplicateApplications(ctx, paged)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, "app3", duplicates[0].ApplicationID)
+
+	filters.Strategy = DuplicateFirst
+	duplicates, _, err = repo.GetDuplicateApplications(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 2)
+	assert.True(t, duplicates[0].Runs[0].Canonical)
+	assert.False(t, duplicates[0].Runs[1].Canonical)
+	assert.True(t, duplicates[1].Runs[0].Canonical)
+	assert.False(t, duplicates[1].Runs[1].Canonical)
+	assert.False(t, duplicates[1].Runs[2].Canonical)
+
+	// the merged view counts app1 once, in the cluster of its canonical run
+	rows, err := repo.QueryAnalytics(ctx, AnalyticsQuery{
+		Entity:     "applications",
+		Filters:    []AnalyticsFilter{{Field: "queueName", Operator: sql.Equal, Values: []any{"root.app1"}}},
+		GroupBy:    []string{"partition"},
+		Aggregates: []AnalyticsAggregate{{Function: sql.Count}},
+		Limit:      10,
+		Duplicates: DuplicateLatest,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "[us-east]default", rows[0].Groups["partition"])
+	assert.Equal(t, 1.0, *rows[0].Aggregates["count"])
+
+	// app2 is counted once as well, although both its runs are in the default cluster
+	rows, err = repo.QueryAnalytics(ctx, AnalyticsQuery{
+		Entity:     "applications",
+		Filters:    []AnalyticsFilter{{Field: "queueName", Operator: sql.Equal, Values: []any{"root.app2"}}},
+		GroupBy:    []string{"partition"},
+		Aggregates: []AnalyticsAggregate{{Function: sql.Count}},
+		Limit:      10,
+		Duplicates: DuplicateLatest,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "batch", rows[0].Groups["partition"])
+	assert.Equal(t, 1.0, *rows[0].Aggregates["count"])
+
+	// the reports accepting a strategy count the canonical runs only
+	phases, err := repo.GetQueueWaitPhases(ctx, WaitPhaseFilters{
+		Queue:      util.ToPtr("root.app3"),
+		Start:      start,
+		End:        time.Now(),
+		Duplicates: DuplicateLatest,
+	})
+	require.NoError(t, err)
+	require.Len(t, phases, 1)
+	assert.Equal(t, "[us-east]default", phases[0].Partition)
+	assert.Equal(t, int64(1), phases[0].Applications)
+
+	users, err := repo.GetPrincipals(ctx, PrincipalUser, PrincipalFilters{Prefix: util.ToPtr("user-app3")})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, int64(3), users[0].Applications)
+	users, err = repo.GetPrincipals(ctx, PrincipalUser, PrincipalFilters{
+		Prefix:     util.ToPtr("user-app3"),
+		Duplicates: DuplicateFirst,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, int64(1), users[0].Applications)
+}
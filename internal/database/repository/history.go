@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/apache/yunikorn-core/pkg/webservice/dao"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
 )
 
 func (s *PostgresRepository) UpdateHistory(
@@ -15,10 +18,12 @@ func (s *PostgresRepository) UpdateHistory(
 	containers []*dao.ContainerHistoryDAOInfo,
 ) error {
 
-	appSQL := `INSERT INTO history (id, history_type, total_number, timestamp)
-		VALUES (@id, 'application', @total_number, @timestamp)`
-	containerSQL := `INSERT INTO history (id, history_type, total_number, timestamp)
-		VALUES (@id, 'container', @total_number, @timestamp)`
+	appSQL := `INSERT INTO history (id, history_type, total_number, timestamp, cluster_id)
+		VALUES (@id, 'application', @total_number, @timestamp, @cluster_id)`
+	containerSQL := `INSERT INTO history (id, history_type, total_number, timestamp, cluster_id)
+		VALUES (@id, 'container', @total_number, @timestamp, @cluster_id)`
+	// the history of a cluster is attributed to it by the context, as the entries have no partition
+	clusterID := cluster.IDFromContext(ctx)
 
 	for _, app := range apps {
 		_, err := s.dbpool.Exec(ctx, appSQL,
@@ -26,6 +31,7 @@ func (s *PostgresRepository) UpdateHistory(
 				"id":           uuid.NewString(),
 				"total_number": app.TotalApplications,
 				"timestamp":    app.Timestamp,
+				"cluster_id":   clusterID,
 			})
 		if err != nil {
 			return fmt.Errorf("could not update applications history into DB: %v", err)
@@ -37,6 +43,7 @@ func (s *PostgresRepository) UpdateHistory(
 				"id":           uuid.NewString(),
 				"total_number": container.TotalContainers,
 				"timestamp":    container.Timestamp,
+				"cluster_id":   clusterID,
 			})
 		if err != nil {
 			return fmt.Errorf("could not update containers history into DB: %v", err)
@@ -45,44 +52,135 @@ func (s *PostgresRepository) UpdateHistory(
 	return nil
 }
 
+// GetApplicationsHistory returns the applications history of the cluster of the context.
 func (s *PostgresRepository) GetApplicationsHistory(ctx context.Context) ([]*dao.ApplicationHistoryDAOInfo, error) {
-	selectSQL := `SELECT * FROM history WHERE history_type = 'application'`
-	rows, err := s.dbpool.Query(ctx, selectSQL)
-	if err != nil {
-		return nil, fmt.Errorf("could not get applications history from DB: %v", err)
-	}
-	defer rows.Close()
-
 	var apps []*dao.ApplicationHistoryDAOInfo
-	for rows.Next() {
-		app := &dao.ApplicationHistoryDAOInfo{}
-		var id string
-		err := rows.Scan(&id, nil, &app.TotalApplications, &app.Timestamp)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan applications history from DB: %v", err)
-		}
+	err := s.StreamApplicationsHistory(ctx, func(app *dao.ApplicationHistoryDAOInfo) error {
 		apps = append(apps, app)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return apps, nil
 }
 
-func (s *PostgresRepository) GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
-	selectSQL := `SELECT * FROM history WHERE history_type = 'container'`
-	rows, err := s.dbpool.Query(ctx, selectSQL)
+// StreamApplicationsHistory calls fn for every entry of the applications history of the cluster of the context as it
+// is read from the database. It stops at the first error returned by fn.
+func (s *PostgresRepository) StreamApplicationsHistory(ctx context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error {
+	selectSQL := `SELECT * FROM history WHERE history_type = 'application' AND cluster_id = $1`
+	rows, err := s.dbpool.Query(ctx, selectSQL, cluster.IDFromContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("could not get containers history from DB: %v", err)
+		return fmt.Errorf("could not get applications history from DB: %v", err)
 	}
-	defer rows.Close()
+	return forEachRow(rows, "applications history", func(row *historyRow) error {
+		return fn(&dao.ApplicationHistoryDAOInfo{TotalApplications: row.TotalNumber, Timestamp: row.Timestamp})
+	})
+}
 
+// GetContainersHistory returns the containers history of the cluster of the context.
+func (s *PostgresRepository) GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
 	var containers []*dao.ContainerHistoryDAOInfo
-	for rows.Next() {
-		container := &dao.ContainerHistoryDAOInfo{}
-		var id string
-		err := rows.Scan(&id, nil, &container.TotalContainers, &container.Timestamp)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan contaienrs history from DB: %v", err)
-		}
+	err := s.StreamContainersHistory(ctx, func(container *dao.ContainerHistoryDAOInfo) error {
 		containers = append(containers, container)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return containers, nil
 }
+
+// StreamContainersHistory calls fn for every entry of the containers history of the cluster of the context as it is
+// read from the database. It stops at the first error returned by fn.
+func (s *PostgresRepository) StreamContainersHistory(ctx context.Context, fn func(*dao.ContainerHistoryDAOInfo) error) error {
+	selectSQL := `SELECT * FROM history WHERE history_type = 'container' AND cluster_id = $1`
+	rows, err := s.dbpool.Query(ctx, selectSQL, cluster.IDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("could not get containers history from DB: %v", err)
+	}
+	return forEachRow(rows, "containers history", func(row *historyRow) error {
+		return fn(&dao.ContainerHistoryDAOInfo{TotalContainers: row.TotalNumber, Timestamp: row.Timestamp})
+	})
+}
+
+// GetApplicationsHistoryPage returns a page of the applications history of the cluster of the context in chronological
+// order, with the number of entries and the cursor of the next page.
+func (s *PostgresRepository) GetApplicationsHistoryPage(ctx context.Context, page Page) (
+	[]*dao.ApplicationHistoryDAOInfo, PageInfo, error) {
+	rows, info, err := s.historyPage(ctx, "application", page)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	apps := make([]*dao.ApplicationHistoryDAOInfo, len(rows))
+	for i, row := range rows {
+		apps[i] = &dao.ApplicationHistoryDAOInfo{TotalApplications: row.TotalNumber, Timestamp: row.Timestamp}
+	}
+	return apps, info, nil
+}
+
+// GetContainersHistoryPage returns a page of the containers history of the cluster of the context in chronological
+// order, with the number of entries and the cursor of the next page.
+func (s *PostgresRepository) GetContainersHistoryPage(ctx context.Context, page Page) (
+	[]*dao.ContainerHistoryDAOInfo, PageInfo, error) {
+	rows, info, err := s.historyPage(ctx, "container", page)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	containers := make([]*dao.ContainerHistoryDAOInfo, len(rows))
+	for i, row := range rows {
+		containers[i] = &dao.ContainerHistoryDAOInfo{TotalContainers: row.TotalNumber, Timestamp: row.Timestamp}
+	}
+	return containers, info, nil
+}
+
+// historyPage returns a page of the history entries of the type of the cluster of the context ordered by their
+// timestamp and ID, whose cursors have the timestamp as key.
+func (s *PostgresRepository) historyPage(ctx context.Context, historyType string, page Page) (
+	[]*historyRow, PageInfo, error) {
+	if err := page.After.checkKeys(1); err != nil {
+		return nil, PageInfo{}, err
+	}
+	clusterID := cluster.IDFromContext(ctx)
+	selectSQL := `SELECT * FROM history WHERE cluster_id = $1 AND history_type = $2
+		AND ($3::BIGINT IS NULL OR (timestamp, id) > ($3, $4::UUID))
+		ORDER BY timestamp, id LIMIT $5`
+	var afterTimestamp *int64
+	var afterID *string
+	if page.After != nil {
+		afterTimestamp, afterID = &page.After.Keys[0], &page.After.ID
+	}
+	rows, err := s.dbpool.Query(ctx, selectSQL, clusterID, historyType, afterTimestamp, afterID, pageQueryLimit(page.Limit))
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not get %ss history from DB: %v", historyType, err)
+	}
+	var entries []*historyRow
+	err = forEachRow(rows, historyType+"s history", func(row *historyRow) error {
+		entries = append(entries, row)
+		return nil
+	})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	var info PageInfo
+	entries, info.Next = trimPage(entries, page.Limit, func(row *historyRow) *Cursor {
+		return &Cursor{Keys: []int64{row.Timestamp}, ID: row.ID}
+	})
+
+	countSQL := `SELECT COUNT(*) FROM history WHERE cluster_id = $1 AND history_type = $2`
+	if err := s.dbpool.QueryRow(ctx, countSQL, clusterID, historyType).Scan(&info.Total); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not count %ss history in DB: %v", historyType, err)
+	}
+	return entries, info, nil
+}
+
+// DeleteHistoryBefore deletes at most limit entries of the applications and containers history which were recorded
+// before the given time and returns the number of deleted entries.
+func (s *PostgresRepository) DeleteHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	deleteSQL := `DELETE FROM history WHERE id IN (SELECT id FROM history WHERE timestamp < $1 LIMIT $2)`
+	tag, err := s.dbpool.Exec(ctx, deleteSQL, before.UnixNano(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete history from DB: %v", err)
+	}
+	return tag.RowsAffected(), nil
+}
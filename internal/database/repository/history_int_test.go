@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestDeleteHistoryBefore_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	now := time.Now()
+	err = repo.UpdateHistory(ctx,
+		[]*dao.ApplicationHistoryDAOInfo{
+			{TotalApplications: "1", Timestamp: now.Add(-3 * time.Hour).UnixNano()},
+			{TotalApplications: "2", Timestamp: now.Add(-2 * time.Hour).UnixNano()},
+			{TotalApplications: "3", Timestamp: now.UnixNano()},
+		},
+		[]*dao.ContainerHistoryDAOInfo{
+			{TotalContainers: "4", Timestamp: now.Add(-2 * time.Hour).UnixNano()},
+			{TotalContainers: "5", Timestamp: now.UnixNano()},
+		},
+	)
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteHistoryBefore(ctx, now.Add(-time.Hour), 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	deleted, err = repo.DeleteHistoryBefore(ctx, now.Add(-time.Hour), 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	apps, err := repo.GetApplicationsHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "3", apps[0].TotalApplications)
+	containers, err := repo.GetContainersHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+	assert.Equal(t, "5", containers[0].TotalContainers)
+}
+
+func TestHistoryPerCluster_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	euWest := cluster.WithID(ctx, "eu-west")
+	now := time.Now().UnixNano()
+	require.NoError(t, repo.UpdateHistory(ctx,
+		[]*dao.ApplicationHistoryDAOInfo{{TotalApplications: "1", Timestamp: now}},
+		[]*dao.ContainerHistoryDAOInfo{{TotalContainers: "2", Timestamp: now}},
+	))
+	require.NoError(t, repo.UpdateHistory(euWest,
+		[]*dao.ApplicationHistoryDAOInfo{
+			{TotalApplications: "10", Timestamp: now},
+			{TotalApplications: "11", Timestamp: now + 1},
+		},
+		[]*dao.ContainerHistoryDAOInfo{{TotalContainers: "20", Timestamp: now}},
+	))
+
+	// the series of the clusters are read separately, the default cluster unless the context names another
+	apps, err := repo.GetApplicationsHistory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*dao.ApplicationHistoryDAOInfo{{TotalApplications: "1", Timestamp: now}}, apps)
+	apps, err = repo.GetApplicationsHistory(euWest)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*dao.ApplicationHistoryDAOInfo{
+		{TotalApplications: "10", Timestamp: now},
+		{TotalApplications: "11", Timestamp: now + 1},
+	}, apps)
+	containers, err := repo.GetContainersHistory(euWest)
+	require.NoError(t, err)
+	assert.Equal(t, []*dao.ContainerHistoryDAOInfo{{TotalContainers: "20", Timestamp: now}}, containers)
+
+	apps, page, err := repo.GetApplicationsHistoryPage(ctx, Page{})
+	require.NoError(t, err)
+	assert.Len(t, apps, 1)
+	assert.Equal(t, 1, page.Total)
+	containers, page, err = repo.GetContainersHistoryPage(euWest, Page{})
+	require.NoError(t, err)
+	assert.Len(t, containers, 1)
+	assert.Equal(t, 1, page.Total)
+
+	apps, page, err = repo.GetApplicationsHistoryPage(cluster.WithID(ctx, "us-east"), Page{})
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+	assert.Zero(t, page.Total)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrDuplicateBatch is returned for batches which were already applied.
+var ErrDuplicateBatch = errors.New("batch was already applied")
+
+// ApplyIngestBatch applies the batch with the sequence number forwarded by the source exactly once: fn applies the
+// writes of the batch to the given repository, in the same transaction which records the sequence number as the last
+// one applied for the source. Batches whose sequence number is not greater than the last one applied are not applied,
+// and ErrDuplicateBatch is returned. It returns the sequence number of the last batch applied for the source.
+func (s *PostgresRepository) ApplyIngestBatch(
+	ctx context.Context,
+	source string,
+	sequence int64,
+	fn func(ctx context.Context, tx Repository) error,
+) (int64, error) {
+	var acked int64
+	err := pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		now := time.Now().Unix()
+		insertSQL := `INSERT INTO ingest_sources (source, sequence, acked_at) VALUES ($1, 0, $2)
+			ON CONFLICT (source) DO NOTHING`
+		if _, err := tx.Exec(ctx, insertSQL, source, now); err != nil {
+			return fmt.Errorf("could not insert ingest source into DB: %v", err)
+		}
+		// the row of the source is locked until the transaction ends, so that retries of a batch which is
+		// still being applied wait for it and are then detected as duplicates
+		selectSQL := `SELECT sequence FROM ingest_sources WHERE source = $1 FOR UPDATE`
+		if err := tx.QueryRow(ctx, selectSQL, source).Scan(&acked); err != nil {
+			return fmt.Errorf("could not get ingest source from DB: %v", err)
+		}
+		if sequence <= acked {
+			return ErrDuplicateBatch
+		}
+		if err := fn(ctx, s.withTx(tx)); err != nil {
+			return err
+		}
+		updateSQL := `UPDATE ingest_sources SET sequence = $2, acked_at = $3 WHERE source = $1`
+		if _, err := tx.Exec(ctx, updateSQL, source, sequence, now); err != nil {
+			return fmt.Errorf("could not update ingest source in DB: %v", err)
+		}
+		acked = sequence
+		return nil
+	})
+	return acked, err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestApplyIngestBatch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	upsertPartition := func(name string) func(context.Context, Repository) error {
+		return func(ctx context.Context, tx Repository) error {
+			return tx.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: name}})
+		}
+	}
+	partitionNames := func() []string {
+		partitions, err := repo.GetAllPartitions(ctx)
+		require.NoError(t, err)
+		var names []string
+		for _, p := range partitions {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	acked, err := repo.ApplyIngestBatch(ctx, "collector-1", 1, upsertPartition("first"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), acked)
+
+	// a batch sent again is not applied again
+	acked, err = repo.ApplyIngestBatch(ctx, "collector-1", 1, func(context.Context, Repository) error {
+		t.Fatal("duplicate batch must not be applied")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrDuplicateBatch)
+	assert.Equal(t, int64(1), acked)
+
+	// the writes of a failed batch are rolled back, and the batch is not acknowledged
+	_, err = repo.ApplyIngestBatch(ctx, "collector-1", 2, func(ctx context.Context, tx Repository) error {
+		if err := upsertPartition("rolled-back")(ctx, tx); err != nil {
+			return err
+		}
+		return errors.New("connection refused")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"first"}, partitionNames())
+
+	acked, err = repo.ApplyIngestBatch(ctx, "collector-1", 2, upsertPartition("second"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), acked)
+	assert.ElementsMatch(t, []string{"first", "second"}, partitionNames())
+
+	// sources are numbered independently
+	acked, err = repo.ApplyIngestBatch(ctx, "collector-2", 1, upsertPartition("third"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), acked)
+}
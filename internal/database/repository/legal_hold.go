@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+var (
+	ErrLegalHoldNotFound = errors.New("legal hold not found")
+	ErrLegalHoldReleased = errors.New("legal hold already released")
+)
+
+type LegalHoldFilters struct {
+	Partition *string
+	// Active filters holds which are not released if true, and released holds if false.
+	Active *bool
+	Offset *int
+	Limit  *int
+}
+
+// applicationNotHeld is a condition on the applications table which excludes applications under an active legal hold.
+const applicationNotHeld = `NOT EXISTS (
+		SELECT 1 FROM legal_holds h
+		WHERE h.released_at IS NULL AND h.partition = applications.partition
+		AND (h.queue_name IS NULL OR applications.queue_name = h.queue_name
+			OR starts_with(applications.queue_name, h.queue_name || '.'))
+		AND (h.app_id IS NULL OR applications.app_id = h.app_id))`
+
+// CreateLegalHold stores the legal hold and sets its ID and creation time.
+func (s *PostgresRepository) CreateLegalHold(ctx context.Context, hold *model.LegalHold) error {
+	insertSQL := `INSERT INTO legal_holds (partition, queue_name, app_id, reason, created_by, created_at)
+		VALUES (@partition, @queue_name, @app_id, @reason, @created_by, @created_at)
+		RETURNING id, created_at, version`
+	err := s.dbpool.QueryRow(ctx, insertSQL, pgx.NamedArgs{
+		"partition":  hold.Partition,
+		"queue_name": hold.QueueName,
+		"app_id":     hold.ApplicationID,
+		"reason":     hold.Reason,
+		"created_by": hold.CreatedBy,
+		"created_at": time.Now().Unix(),
+	}).Scan(&hold.ID, &hold.CreatedAt, &hold.Version)
+	if err != nil {
+		return fmt.Errorf("could not insert legal hold into DB: %v", err)
+	}
+	return nil
+}
+
+// GetLegalHolds returns the legal holds ordered by creation time in descending order.
+func (s *PostgresRepository) GetLegalHolds(ctx context.Context, filters LegalHoldFilters) ([]*model.LegalHold, error) {
+	query, args, err := legalHoldsQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get legal holds from DB: %v", err)
+	}
+
+	var holds []*model.LegalHold
+	err = forEachRow(rows, "legal holds", func(hold *model.LegalHold) error {
+		holds = append(holds, hold)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// legalHoldsQuery builds the query of GetLegalHolds.
+func legalHoldsQuery(filters LegalHoldFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().SelectAll(legalHoldsTable, "").OrderBy("created_at", sql.OrderByDescending)
+	if filters.Partition != nil {
+		queryBuilder.Conditionp("partition", sql.Equal, *filters.Partition)
+	}
+	if filters.Active != nil {
+		// active holds are not released
+		queryBuilder.ConditionNull("released_at", *filters.Active)
+	}
+	applyLimitAndOffset(queryBuilder, filters.Limit, filters.Offset)
+	return queryBuilder
+}
+
+// GetLegalHold returns the legal hold with the given ID.
+func (s *PostgresRepository) GetLegalHold(ctx context.Context, id string) (*model.LegalHold, error) {
+	hold, err := s.queryLegalHold(ctx, "SELECT * FROM legal_holds WHERE id = $1", id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrLegalHoldNotFound, id)
+	}
+	return hold, err
+}
+
+// ReleaseLegalHold releases the legal hold with the given ID, so its applications can be pruned again. If ifVersion
+// is set, the hold is only released in that version, and ErrVersionMismatch is returned if it was changed since.
+func (s *PostgresRepository) ReleaseLegalHold(
+	ctx context.Context, id, releasedBy, reason string, ifVersion *int64) (*model.LegalHold, error) {
+	updateSQL := `UPDATE legal_holds
+		SET released_by = @released_by, released_at = @released_at, release_reason = @reason, version = version + 1
+		WHERE id = @id AND released_at IS NULL AND (@if_version::BIGINT IS NULL OR version = @if_version)
+		RETURNING *`
+	hold, err := s.queryLegalHold(ctx, updateSQL, pgx.NamedArgs{
+		"id":          id,
+		"released_by": releasedBy,
+		"released_at": time.Now().Unix(),
+		"reason":      reason,
+		"if_version":  ifVersion,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// distinguish unknown holds and holds which are already released from holds which were changed
+		current, err := s.GetLegalHold(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if current.ReleasedAt != nil {
+			return nil, fmt.Errorf("%w: %s", ErrLegalHoldReleased, id)
+		}
+		return nil, fmt.Errorf("%w: legal hold %s is in version %d", ErrVersionMismatch, id, current.Version)
+	}
+	return hold, err
+}
+
+// queryLegalHold runs the query returning all columns of a single legal hold. It returns pgx.ErrNoRows
+// if the query returns no legal hold.
+func (s *PostgresRepository) queryLegalHold(ctx context.Context, query string, args ...any) (*model.LegalHold, error) {
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get legal hold from DB: %v", err)
+	}
+	hold, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[model.LegalHold])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not scan legal hold from DB: %v", err)
+	}
+	return hold, nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestLegalHolds_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	if err != nil {
+		t.Fatalf("could not create repository: %v", err)
+	}
+
+	queueHold := &model.LegalHold{
+		Partition: "default",
+		QueueName: util.ToPtr("root.compliance"),
+		Reason:    "investigation",
+		CreatedBy: "ops",
+	}
+	require.NoError(t, repo.CreateLegalHold(ctx, queueHold))
+	assert.NotEmpty(t, queueHold.ID)
+	assert.NotZero(t, queueHold.CreatedAt)
+	assert.Equal(t, int64(1), queueHold.Version)
+
+	appHold := &model.LegalHold{
+		Partition:     "default",
+		ApplicationID: util.ToPtr("app2"),
+		Reason:        "audit",
+		CreatedBy:     "ops",
+	}
+	require.NoError(t, repo.CreateLegalHold(ctx, appHold))
+
+	holds, err := repo.GetLegalHolds(ctx, LegalHoldFilters{Active: util.ToPtr(true)})
+	require.NoError(t, err)
+	assert.Len(t, holds, 2)
+
+	_, err = repo.ReleaseLegalHold(ctx, appHold.ID, "ops", "audit closed", util.ToPtr(int64(2)))
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	released, err := repo.ReleaseLegalHold(ctx, appHold.ID, "ops", "audit closed", util.ToPtr(int64(1)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), released.Version)
+	assert.Equal(t, util.ToPtr("ops"), released.ReleasedBy)
+	assert.Equal(t, util.ToPtr("audit closed"), released.ReleaseReason)
+	assert.NotNil(t, released.ReleasedAt)
+
+	_, err = repo.ReleaseLegalHold(ctx, appHold.ID, "ops", "audit closed", nil)
+	assert.ErrorIs(t, err, ErrLegalHoldReleased)
+	_, err = repo.GetLegalHold(ctx, "6f1c5e4e-4a47-4b8f-9d0c-1f3a2b4c5d6e")
+	assert.ErrorIs(t, err, ErrLegalHoldNotFound)
+
+	holds, err = repo.GetLegalHolds(ctx, LegalHoldFilters{Active: util.ToPtr(false)})
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, appHold.ID, holds[0].ID)
+}
+
+func TestDeleteApplicationsFinishedBefore_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	if err != nil {
+		t.Fatalf("could not create repository: %v", err)
+	}
+
+	now := time.Now()
+	queues := []*dao.PartitionQueueDAOInfo{
+		{
+			Partition: "default",
+			QueueName: "root",
+			Children: []dao.PartitionQueueDAOInfo{
+				{Partition: "default", QueueName: "root.compliance", Parent: "root"},
+			},
+		},
+	}
+	require.NoError(t, repo.AddQueues(ctx, nil, queues))
+
+	finished := util.ToPtr(now.Add(-48 * time.Hour).UnixNano())
+	apps := []*dao.ApplicationDAOInfo{
+		{ApplicationID: "app1", Partition: "default", QueueName: "root.compliance", FinishedTime: finished},
+		{ApplicationID: "app2", Partition: "default", QueueName: "root.compliance", FinishedTime: finished},
+		{ApplicationID: "app3", Partition: "default", QueueName: "root.compliance"},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	require.NoError(t, repo.CreateLegalHold(ctx, &model.LegalHold{
+		Partition:     "default",
+		ApplicationID: util.ToPtr("app1"),
+		Reason:        "investigation",
+		CreatedBy:     "ops",
+	}))
+
+	deleted, err := repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.compliance", now.Add(-24*time.Hour), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted, "held and running applications must not be deleted")
+
+	count, err := repo.CountFinishedApplications(ctx, "default", "root", "", now.Add(-72*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestLegalHoldQueueWildcards_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	if err != nil {
+		t.Fatalf("could not create repository: %v", err)
+	}
+
+	finished := util.ToPtr(time.Now().Add(-48 * time.Hour).UnixNano())
+	apps := []*dao.ApplicationDAOInfo{
+		{ApplicationID: "app1", Partition: "default", QueueName: "root.a_b.etl", FinishedTime: finished},
+		{ApplicationID: "app2", Partition: "default", QueueName: "root.axb.etl", FinishedTime: finished},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	// _ is a LIKE wildcard, which must match itself only in the queue of the hold
+	require.NoError(t, repo.CreateLegalHold(ctx, &model.LegalHold{
+		Partition: "default",
+		QueueName: util.ToPtr("root.a_b"),
+		Reason:    "investigation",
+		CreatedBy: "ops",
+	}))
+
+	before := time.Now().Add(-24 * time.Hour)
+	deleted, err := repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.a_b.etl", before, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted, "applications in the child queues of the held queue must not be deleted")
+
+	deleted, err = repo.DeleteApplicationsFinishedBefore(ctx, "default", "root.axb.etl", before, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted, "applications in queues matching the held queue as a LIKE pattern must be deleted")
+}
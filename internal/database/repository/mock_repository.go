@@ -12,6 +12,7 @@ package repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/G-Research/yunikorn-history-server/internal/model"
 	dao "github.com/apache/yunikorn-core/pkg/webservice/dao"
@@ -42,6 +43,35 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddAutoscalerEvents mocks base method.
+func (m *MockRepository) AddAutoscalerEvents(arg0 context.Context, arg1 []*model.AutoscalerEvent) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAutoscalerEvents", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAutoscalerEvents indicates an expected call of AddAutoscalerEvents.
+func (mr *MockRepositoryMockRecorder) AddAutoscalerEvents(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAutoscalerEvents", reflect.TypeOf((*MockRepository)(nil).AddAutoscalerEvents), arg0, arg1)
+}
+
+// AddQueueLineage mocks base method.
+func (m *MockRepository) AddQueueLineage(arg0 context.Context, arg1 *model.QueueLineage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddQueueLineage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddQueueLineage indicates an expected call of AddQueueLineage.
+func (mr *MockRepositoryMockRecorder) AddQueueLineage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQueueLineage", reflect.TypeOf((*MockRepository)(nil).AddQueueLineage), arg0, arg1)
+}
+
 // AddQueues mocks base method.
 func (m *MockRepository) AddQueues(arg0 context.Context, arg1 *string, arg2 []*dao.PartitionQueueDAOInfo) error {
 	m.ctrl.T.Helper()
@@ -56,6 +86,200 @@ func (mr *MockRepositoryMockRecorder) AddQueues(arg0, arg1, arg2 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQueues", reflect.TypeOf((*MockRepository)(nil).AddQueues), arg0, arg1, arg2)
 }
 
+// AddTraceEvent mocks base method.
+func (m *MockRepository) AddTraceEvent(arg0 context.Context, arg1 *model.TraceEvent, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTraceEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTraceEvent indicates an expected call of AddTraceEvent.
+func (mr *MockRepositoryMockRecorder) AddTraceEvent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTraceEvent", reflect.TypeOf((*MockRepository)(nil).AddTraceEvent), arg0, arg1, arg2)
+}
+
+// ApplyIngestBatch mocks base method.
+func (m *MockRepository) ApplyIngestBatch(arg0 context.Context, arg1 string, arg2 int64, arg3 func(context.Context, Repository) error) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyIngestBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyIngestBatch indicates an expected call of ApplyIngestBatch.
+func (mr *MockRepositoryMockRecorder) ApplyIngestBatch(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyIngestBatch", reflect.TypeOf((*MockRepository)(nil).ApplyIngestBatch), arg0, arg1, arg2, arg3)
+}
+
+// BackfillAnalyticsSink mocks base method.
+func (m *MockRepository) BackfillAnalyticsSink(arg0 context.Context, arg1 string, arg2 int, arg3 func([]*model.AnalyticsApplication) error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillAnalyticsSink", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillAnalyticsSink indicates an expected call of BackfillAnalyticsSink.
+func (mr *MockRepositoryMockRecorder) BackfillAnalyticsSink(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillAnalyticsSink", reflect.TypeOf((*MockRepository)(nil).BackfillAnalyticsSink), arg0, arg1, arg2, arg3)
+}
+
+// BulkDeleteApplications mocks base method.
+func (m *MockRepository) BulkDeleteApplications(arg0 context.Context, arg1 BulkDeleteFilters, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteApplications", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteApplications indicates an expected call of BulkDeleteApplications.
+func (mr *MockRepositoryMockRecorder) BulkDeleteApplications(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteApplications", reflect.TypeOf((*MockRepository)(nil).BulkDeleteApplications), arg0, arg1, arg2)
+}
+
+// CheckDataQuality mocks base method.
+func (m *MockRepository) CheckDataQuality(arg0 context.Context, arg1 DataQualityCheck, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDataQuality", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDataQuality indicates an expected call of CheckDataQuality.
+func (mr *MockRepositoryMockRecorder) CheckDataQuality(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDataQuality", reflect.TypeOf((*MockRepository)(nil).CheckDataQuality), arg0, arg1, arg2)
+}
+
+// ConsumeAnalyticsChanges mocks base method.
+func (m *MockRepository) ConsumeAnalyticsChanges(arg0 context.Context, arg1 int, arg2 func([]*model.AnalyticsApplication) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeAnalyticsChanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeAnalyticsChanges indicates an expected call of ConsumeAnalyticsChanges.
+func (mr *MockRepositoryMockRecorder) ConsumeAnalyticsChanges(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeAnalyticsChanges", reflect.TypeOf((*MockRepository)(nil).ConsumeAnalyticsChanges), arg0, arg1, arg2)
+}
+
+// CountBulkDeleteApplications mocks base method.
+func (m *MockRepository) CountBulkDeleteApplications(arg0 context.Context, arg1 BulkDeleteFilters) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBulkDeleteApplications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountBulkDeleteApplications indicates an expected call of CountBulkDeleteApplications.
+func (mr *MockRepositoryMockRecorder) CountBulkDeleteApplications(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBulkDeleteApplications", reflect.TypeOf((*MockRepository)(nil).CountBulkDeleteApplications), arg0, arg1)
+}
+
+// CountFinishedApplications mocks base method.
+func (m *MockRepository) CountFinishedApplications(arg0 context.Context, arg1, arg2, arg3 string, arg4 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFinishedApplications", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFinishedApplications indicates an expected call of CountFinishedApplications.
+func (mr *MockRepositoryMockRecorder) CountFinishedApplications(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFinishedApplications", reflect.TypeOf((*MockRepository)(nil).CountFinishedApplications), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CountUserApplications mocks base method.
+func (m *MockRepository) CountUserApplications(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserApplications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserApplications indicates an expected call of CountUserApplications.
+func (mr *MockRepositoryMockRecorder) CountUserApplications(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserApplications", reflect.TypeOf((*MockRepository)(nil).CountUserApplications), arg0, arg1)
+}
+
+// CreateLegalHold mocks base method.
+func (m *MockRepository) CreateLegalHold(arg0 context.Context, arg1 *model.LegalHold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLegalHold indicates an expected call of CreateLegalHold.
+func (mr *MockRepositoryMockRecorder) CreateLegalHold(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLegalHold", reflect.TypeOf((*MockRepository)(nil).CreateLegalHold), arg0, arg1)
+}
+
+// DeleteApplicationsFinishedBefore mocks base method.
+func (m *MockRepository) DeleteApplicationsFinishedBefore(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplicationsFinishedBefore", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApplicationsFinishedBefore indicates an expected call of DeleteApplicationsFinishedBefore.
+func (mr *MockRepositoryMockRecorder) DeleteApplicationsFinishedBefore(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationsFinishedBefore", reflect.TypeOf((*MockRepository)(nil).DeleteApplicationsFinishedBefore), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteHistoryBefore mocks base method.
+func (m *MockRepository) DeleteHistoryBefore(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHistoryBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHistoryBefore indicates an expected call of DeleteHistoryBefore.
+func (mr *MockRepositoryMockRecorder) DeleteHistoryBefore(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistoryBefore", reflect.TypeOf((*MockRepository)(nil).DeleteHistoryBefore), arg0, arg1, arg2)
+}
+
+// DeleteQueueLineage mocks base method.
+func (m *MockRepository) DeleteQueueLineage(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueueLineage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQueueLineage indicates an expected call of DeleteQueueLineage.
+func (mr *MockRepositoryMockRecorder) DeleteQueueLineage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueueLineage", reflect.TypeOf((*MockRepository)(nil).DeleteQueueLineage), arg0, arg1)
+}
+
 // DeleteQueues mocks base method.
 func (m *MockRepository) DeleteQueues(arg0 context.Context, arg1 []*model.PartitionQueueDAOInfo) error {
 	m.ctrl.T.Helper()
@@ -70,6 +294,95 @@ func (mr *MockRepositoryMockRecorder) DeleteQueues(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueues", reflect.TypeOf((*MockRepository)(nil).DeleteQueues), arg0, arg1)
 }
 
+// DeleteSLO mocks base method.
+func (m *MockRepository) DeleteSLO(arg0 context.Context, arg1 string, arg2 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSLO", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSLO indicates an expected call of DeleteSLO.
+func (mr *MockRepositoryMockRecorder) DeleteSLO(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSLO", reflect.TypeOf((*MockRepository)(nil).DeleteSLO), arg0, arg1, arg2)
+}
+
+// DeleteSLOEvaluationsBefore mocks base method.
+func (m *MockRepository) DeleteSLOEvaluationsBefore(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSLOEvaluationsBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSLOEvaluationsBefore indicates an expected call of DeleteSLOEvaluationsBefore.
+func (mr *MockRepositoryMockRecorder) DeleteSLOEvaluationsBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSLOEvaluationsBefore", reflect.TypeOf((*MockRepository)(nil).DeleteSLOEvaluationsBefore), arg0, arg1)
+}
+
+// DeleteTraceEventsBefore mocks base method.
+func (m *MockRepository) DeleteTraceEventsBefore(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTraceEventsBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTraceEventsBefore indicates an expected call of DeleteTraceEventsBefore.
+func (mr *MockRepositoryMockRecorder) DeleteTraceEventsBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTraceEventsBefore", reflect.TypeOf((*MockRepository)(nil).DeleteTraceEventsBefore), arg0, arg1)
+}
+
+// EraseUserApplications mocks base method.
+func (m *MockRepository) EraseUserApplications(arg0 context.Context, arg1, arg2, arg3 string, arg4 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUserApplications", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUserApplications indicates an expected call of EraseUserApplications.
+func (mr *MockRepositoryMockRecorder) EraseUserApplications(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUserApplications", reflect.TypeOf((*MockRepository)(nil).EraseUserApplications), arg0, arg1, arg2, arg3, arg4)
+}
+
+// EstimateAnalytics mocks base method.
+func (m *MockRepository) EstimateAnalytics(arg0 context.Context, arg1 AnalyticsQuery) (*model.AnalyticsEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateAnalytics", arg0, arg1)
+	ret0, _ := ret[0].(*model.AnalyticsEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateAnalytics indicates an expected call of EstimateAnalytics.
+func (mr *MockRepositoryMockRecorder) EstimateAnalytics(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateAnalytics", reflect.TypeOf((*MockRepository)(nil).EstimateAnalytics), arg0, arg1)
+}
+
+// EvaluateSLO mocks base method.
+func (m *MockRepository) EvaluateSLO(arg0 context.Context, arg1 *model.QueueSLO, arg2 time.Time) (*model.SLOEvaluation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateSLO", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.SLOEvaluation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateSLO indicates an expected call of EvaluateSLO.
+func (mr *MockRepositoryMockRecorder) EvaluateSLO(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateSLO", reflect.TypeOf((*MockRepository)(nil).EvaluateSLO), arg0, arg1, arg2)
+}
+
 // GetAllApplications mocks base method.
 func (m *MockRepository) GetAllApplications(arg0 context.Context, arg1 ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +428,51 @@ func (mr *MockRepositoryMockRecorder) GetAllQueues(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllQueues", reflect.TypeOf((*MockRepository)(nil).GetAllQueues), arg0)
 }
 
+// GetAllocationConstraints mocks base method.
+func (m *MockRepository) GetAllocationConstraints(arg0 context.Context, arg1, arg2, arg3 string) ([]*model.AllocationConstraints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllocationConstraints", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.AllocationConstraints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllocationConstraints indicates an expected call of GetAllocationConstraints.
+func (mr *MockRepositoryMockRecorder) GetAllocationConstraints(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllocationConstraints", reflect.TypeOf((*MockRepository)(nil).GetAllocationConstraints), arg0, arg1, arg2, arg3)
+}
+
+// GetAnalyticsSink mocks base method.
+func (m *MockRepository) GetAnalyticsSink(arg0 context.Context, arg1 string) (*model.AnalyticsSink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalyticsSink", arg0, arg1)
+	ret0, _ := ret[0].(*model.AnalyticsSink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalyticsSink indicates an expected call of GetAnalyticsSink.
+func (mr *MockRepositoryMockRecorder) GetAnalyticsSink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalyticsSink", reflect.TypeOf((*MockRepository)(nil).GetAnalyticsSink), arg0, arg1)
+}
+
+// GetApplicationSummaries mocks base method.
+func (m *MockRepository) GetApplicationSummaries(arg0 context.Context, arg1, arg2 string, arg3 ApplicationFilters) ([]*model.ApplicationSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationSummaries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.ApplicationSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationSummaries indicates an expected call of GetApplicationSummaries.
+func (mr *MockRepositoryMockRecorder) GetApplicationSummaries(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationSummaries", reflect.TypeOf((*MockRepository)(nil).GetApplicationSummaries), arg0, arg1, arg2, arg3)
+}
+
 // GetApplicationsHistory mocks base method.
 func (m *MockRepository) GetApplicationsHistory(arg0 context.Context) ([]*dao.ApplicationHistoryDAOInfo, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +488,22 @@ func (mr *MockRepositoryMockRecorder) GetApplicationsHistory(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsHistory", reflect.TypeOf((*MockRepository)(nil).GetApplicationsHistory), arg0)
 }
 
+// GetApplicationsHistoryPage mocks base method.
+func (m *MockRepository) GetApplicationsHistoryPage(arg0 context.Context, arg1 Page) ([]*dao.ApplicationHistoryDAOInfo, PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationsHistoryPage", arg0, arg1)
+	ret0, _ := ret[0].([]*dao.ApplicationHistoryDAOInfo)
+	ret1, _ := ret[1].(PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetApplicationsHistoryPage indicates an expected call of GetApplicationsHistoryPage.
+func (mr *MockRepositoryMockRecorder) GetApplicationsHistoryPage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsHistoryPage", reflect.TypeOf((*MockRepository)(nil).GetApplicationsHistoryPage), arg0, arg1)
+}
+
 // GetAppsPerPartitionPerQueue mocks base method.
 func (m *MockRepository) GetAppsPerPartitionPerQueue(arg0 context.Context, arg1, arg2 string, arg3 ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +519,52 @@ func (mr *MockRepositoryMockRecorder) GetAppsPerPartitionPerQueue(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppsPerPartitionPerQueue", reflect.TypeOf((*MockRepository)(nil).GetAppsPerPartitionPerQueue), arg0, arg1, arg2, arg3)
 }
 
+// GetAppsPerPartitionPerQueuePage mocks base method.
+func (m *MockRepository) GetAppsPerPartitionPerQueuePage(arg0 context.Context, arg1, arg2 string, arg3 ApplicationFilters) ([]*model.ApplicationDAOInfo, PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppsPerPartitionPerQueuePage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.ApplicationDAOInfo)
+	ret1, _ := ret[1].(PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAppsPerPartitionPerQueuePage indicates an expected call of GetAppsPerPartitionPerQueuePage.
+func (mr *MockRepositoryMockRecorder) GetAppsPerPartitionPerQueuePage(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppsPerPartitionPerQueuePage", reflect.TypeOf((*MockRepository)(nil).GetAppsPerPartitionPerQueuePage), arg0, arg1, arg2, arg3)
+}
+
+// GetAutoscalerEvents mocks base method.
+func (m *MockRepository) GetAutoscalerEvents(arg0 context.Context, arg1 AutoscalerEventFilters) ([]*model.AutoscalerEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoscalerEvents", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AutoscalerEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoscalerEvents indicates an expected call of GetAutoscalerEvents.
+func (mr *MockRepositoryMockRecorder) GetAutoscalerEvents(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoscalerEvents", reflect.TypeOf((*MockRepository)(nil).GetAutoscalerEvents), arg0, arg1)
+}
+
+// GetClusters mocks base method.
+func (m *MockRepository) GetClusters(arg0 context.Context) ([]*model.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusters", arg0)
+	ret0, _ := ret[0].([]*model.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusters indicates an expected call of GetClusters.
+func (mr *MockRepositoryMockRecorder) GetClusters(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockRepository)(nil).GetClusters), arg0)
+}
+
 // GetContainersHistory mocks base method.
 func (m *MockRepository) GetContainersHistory(arg0 context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
 	m.ctrl.T.Helper()
@@ -160,70 +580,448 @@ func (mr *MockRepositoryMockRecorder) GetContainersHistory(arg0 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainersHistory", reflect.TypeOf((*MockRepository)(nil).GetContainersHistory), arg0)
 }
 
-// GetNodeUtilizations mocks base method.
-func (m *MockRepository) GetNodeUtilizations(arg0 context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error) {
+// GetContainersHistoryPage mocks base method.
+func (m *MockRepository) GetContainersHistoryPage(arg0 context.Context, arg1 Page) ([]*dao.ContainerHistoryDAOInfo, PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNodeUtilizations", arg0)
-	ret0, _ := ret[0].([]*dao.PartitionNodesUtilDAOInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetContainersHistoryPage", arg0, arg1)
+	ret0, _ := ret[0].([]*dao.ContainerHistoryDAOInfo)
+	ret1, _ := ret[1].(PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetNodeUtilizations indicates an expected call of GetNodeUtilizations.
-func (mr *MockRepositoryMockRecorder) GetNodeUtilizations(arg0 any) *gomock.Call {
+// GetContainersHistoryPage indicates an expected call of GetContainersHistoryPage.
+func (mr *MockRepositoryMockRecorder) GetContainersHistoryPage(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeUtilizations", reflect.TypeOf((*MockRepository)(nil).GetNodeUtilizations), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainersHistoryPage", reflect.TypeOf((*MockRepository)(nil).GetContainersHistoryPage), arg0, arg1)
 }
 
-// GetNodesPerPartition mocks base method.
-func (m *MockRepository) GetNodesPerPartition(arg0 context.Context, arg1 string) ([]*dao.NodeDAOInfo, error) {
+// GetDataQualityChecks mocks base method.
+func (m *MockRepository) GetDataQualityChecks(arg0 context.Context) ([]*model.DataQualityCheck, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNodesPerPartition", arg0, arg1)
-	ret0, _ := ret[0].([]*dao.NodeDAOInfo)
+	ret := m.ctrl.Call(m, "GetDataQualityChecks", arg0)
+	ret0, _ := ret[0].([]*model.DataQualityCheck)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNodesPerPartition indicates an expected call of GetNodesPerPartition.
-func (mr *MockRepositoryMockRecorder) GetNodesPerPartition(arg0, arg1 any) *gomock.Call {
+// GetDataQualityChecks indicates an expected call of GetDataQualityChecks.
+func (mr *MockRepositoryMockRecorder) GetDataQualityChecks(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesPerPartition", reflect.TypeOf((*MockRepository)(nil).GetNodesPerPartition), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataQualityChecks", reflect.TypeOf((*MockRepository)(nil).GetDataQualityChecks), arg0)
 }
 
-// GetQueue mocks base method.
-func (m *MockRepository) GetQueue(arg0 context.Context, arg1, arg2 string) (*model.PartitionQueueDAOInfo, error) {
+// GetDataQualityViolations mocks base method.
+func (m *MockRepository) GetDataQualityViolations(arg0 context.Context, arg1 DataQualityFilters) ([]*model.DataQualityViolation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQueue", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*model.PartitionQueueDAOInfo)
+	ret := m.ctrl.Call(m, "GetDataQualityViolations", arg0, arg1)
+	ret0, _ := ret[0].([]*model.DataQualityViolation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetQueue indicates an expected call of GetQueue.
-func (mr *MockRepositoryMockRecorder) GetQueue(arg0, arg1, arg2 any) *gomock.Call {
+// GetDataQualityViolations indicates an expected call of GetDataQualityViolations.
+func (mr *MockRepositoryMockRecorder) GetDataQualityViolations(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockRepository)(nil).GetQueue), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataQualityViolations", reflect.TypeOf((*MockRepository)(nil).GetDataQualityViolations), arg0, arg1)
 }
 
-// GetQueuesPerPartition mocks base method.
-func (m *MockRepository) GetQueuesPerPartition(arg0 context.Context, arg1 string) ([]*model.PartitionQueueDAOInfo, error) {
+// GetDuplicateApplications mocks base method.
+func (m *MockRepository) GetDuplicateApplications(arg0 context.Context, arg1 DuplicateApplicationFilters) ([]*model.DuplicateApplication, PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQueuesPerPartition", arg0, arg1)
-	ret0, _ := ret[0].([]*model.PartitionQueueDAOInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetDuplicateApplications", arg0, arg1)
+	ret0, _ := ret[0].([]*model.DuplicateApplication)
+	ret1, _ := ret[1].(PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetQueuesPerPartition indicates an expected call of GetQueuesPerPartition.
-func (mr *MockRepositoryMockRecorder) GetQueuesPerPartition(arg0, arg1 any) *gomock.Call {
+// GetDuplicateApplications indicates an expected call of GetDuplicateApplications.
+func (mr *MockRepositoryMockRecorder) GetDuplicateApplications(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueuesPerPartition", reflect.TypeOf((*MockRepository)(nil).GetQueuesPerPartition), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateApplications", reflect.TypeOf((*MockRepository)(nil).GetDuplicateApplications), arg0, arg1)
 }
 
-// InsertNodeUtilizations mocks base method.
-func (m *MockRepository) InsertNodeUtilizations(arg0 context.Context, arg1 uuid.UUID, arg2 []*dao.PartitionNodesUtilDAOInfo) error {
+// GetHourlyUsage mocks base method.
+func (m *MockRepository) GetHourlyUsage(arg0 context.Context, arg1 HourlyUsageFilters) ([]*model.HourlyUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertNodeUtilizations", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetHourlyUsage", arg0, arg1)
+	ret0, _ := ret[0].([]*model.HourlyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHourlyUsage indicates an expected call of GetHourlyUsage.
+func (mr *MockRepositoryMockRecorder) GetHourlyUsage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHourlyUsage", reflect.TypeOf((*MockRepository)(nil).GetHourlyUsage), arg0, arg1)
+}
+
+// GetLegalHold mocks base method.
+func (m *MockRepository) GetLegalHold(arg0 context.Context, arg1 string) (*model.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(*model.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegalHold indicates an expected call of GetLegalHold.
+func (mr *MockRepositoryMockRecorder) GetLegalHold(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHold", reflect.TypeOf((*MockRepository)(nil).GetLegalHold), arg0, arg1)
+}
+
+// GetLegalHolds mocks base method.
+func (m *MockRepository) GetLegalHolds(arg0 context.Context, arg1 LegalHoldFilters) ([]*model.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHolds", arg0, arg1)
+	ret0, _ := ret[0].([]*model.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegalHolds indicates an expected call of GetLegalHolds.
+func (mr *MockRepositoryMockRecorder) GetLegalHolds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHolds", reflect.TypeOf((*MockRepository)(nil).GetLegalHolds), arg0, arg1)
+}
+
+// GetNamespaceQueues mocks base method.
+func (m *MockRepository) GetNamespaceQueues(arg0 context.Context, arg1 string, arg2 NamespaceQueueFilters) ([]*model.NamespaceQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaceQueues", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.NamespaceQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespaceQueues indicates an expected call of GetNamespaceQueues.
+func (mr *MockRepositoryMockRecorder) GetNamespaceQueues(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceQueues", reflect.TypeOf((*MockRepository)(nil).GetNamespaceQueues), arg0, arg1, arg2)
+}
+
+// GetNode mocks base method.
+func (m *MockRepository) GetNode(arg0 context.Context, arg1 string) (*model.NodeDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNode", arg0, arg1)
+	ret0, _ := ret[0].(*model.NodeDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNode indicates an expected call of GetNode.
+func (mr *MockRepositoryMockRecorder) GetNode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockRepository)(nil).GetNode), arg0, arg1)
+}
+
+// GetNodeTerminations mocks base method.
+func (m *MockRepository) GetNodeTerminations(arg0 context.Context, arg1 NodeTerminationFilters) ([]*model.NodeTermination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeTerminations", arg0, arg1)
+	ret0, _ := ret[0].([]*model.NodeTermination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeTerminations indicates an expected call of GetNodeTerminations.
+func (mr *MockRepositoryMockRecorder) GetNodeTerminations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeTerminations", reflect.TypeOf((*MockRepository)(nil).GetNodeTerminations), arg0, arg1)
+}
+
+// GetNodeTopologies mocks base method.
+func (m *MockRepository) GetNodeTopologies(arg0 context.Context, arg1 string) ([]*model.NodeTopology, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeTopologies", arg0, arg1)
+	ret0, _ := ret[0].([]*model.NodeTopology)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeTopologies indicates an expected call of GetNodeTopologies.
+func (mr *MockRepositoryMockRecorder) GetNodeTopologies(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeTopologies", reflect.TypeOf((*MockRepository)(nil).GetNodeTopologies), arg0, arg1)
+}
+
+// GetNodeUtilizations mocks base method.
+func (m *MockRepository) GetNodeUtilizations(arg0 context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeUtilizations", arg0)
+	ret0, _ := ret[0].([]*dao.PartitionNodesUtilDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeUtilizations indicates an expected call of GetNodeUtilizations.
+func (mr *MockRepositoryMockRecorder) GetNodeUtilizations(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeUtilizations", reflect.TypeOf((*MockRepository)(nil).GetNodeUtilizations), arg0)
+}
+
+// GetNodesPerPartition mocks base method.
+func (m *MockRepository) GetNodesPerPartition(arg0 context.Context, arg1 string) ([]*dao.NodeDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodesPerPartition", arg0, arg1)
+	ret0, _ := ret[0].([]*dao.NodeDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodesPerPartition indicates an expected call of GetNodesPerPartition.
+func (mr *MockRepositoryMockRecorder) GetNodesPerPartition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesPerPartition", reflect.TypeOf((*MockRepository)(nil).GetNodesPerPartition), arg0, arg1)
+}
+
+// GetNodesPerPartitionPage mocks base method.
+func (m *MockRepository) GetNodesPerPartitionPage(arg0 context.Context, arg1 string, arg2 Page) ([]*dao.NodeDAOInfo, PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodesPerPartitionPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dao.NodeDAOInfo)
+	ret1, _ := ret[1].(PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNodesPerPartitionPage indicates an expected call of GetNodesPerPartitionPage.
+func (mr *MockRepositoryMockRecorder) GetNodesPerPartitionPage(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesPerPartitionPage", reflect.TypeOf((*MockRepository)(nil).GetNodesPerPartitionPage), arg0, arg1, arg2)
+}
+
+// GetPausedOperations mocks base method.
+func (m *MockRepository) GetPausedOperations(arg0 context.Context) ([]*model.PausedOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPausedOperations", arg0)
+	ret0, _ := ret[0].([]*model.PausedOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPausedOperations indicates an expected call of GetPausedOperations.
+func (mr *MockRepositoryMockRecorder) GetPausedOperations(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPausedOperations", reflect.TypeOf((*MockRepository)(nil).GetPausedOperations), arg0)
+}
+
+// GetPendingBacklog mocks base method.
+func (m *MockRepository) GetPendingBacklog(arg0 context.Context, arg1 PendingBacklogFilters) ([]*model.PendingBacklogSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingBacklog", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PendingBacklogSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingBacklog indicates an expected call of GetPendingBacklog.
+func (mr *MockRepositoryMockRecorder) GetPendingBacklog(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingBacklog", reflect.TypeOf((*MockRepository)(nil).GetPendingBacklog), arg0, arg1)
+}
+
+// GetPrincipals mocks base method.
+func (m *MockRepository) GetPrincipals(arg0 context.Context, arg1 PrincipalKind, arg2 PrincipalFilters) ([]*model.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrincipals", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrincipals indicates an expected call of GetPrincipals.
+func (mr *MockRepositoryMockRecorder) GetPrincipals(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrincipals", reflect.TypeOf((*MockRepository)(nil).GetPrincipals), arg0, arg1, arg2)
+}
+
+// GetQueue mocks base method.
+func (m *MockRepository) GetQueue(arg0 context.Context, arg1, arg2 string) (*model.PartitionQueueDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PartitionQueueDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueue indicates an expected call of GetQueue.
+func (mr *MockRepositoryMockRecorder) GetQueue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockRepository)(nil).GetQueue), arg0, arg1, arg2)
+}
+
+// GetQueueACLs mocks base method.
+func (m *MockRepository) GetQueueACLs(arg0 context.Context, arg1, arg2 string, arg3 QueueACLFilters) ([]*model.QueueACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueACLs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.QueueACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueACLs indicates an expected call of GetQueueACLs.
+func (mr *MockRepositoryMockRecorder) GetQueueACLs(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueACLs", reflect.TypeOf((*MockRepository)(nil).GetQueueACLs), arg0, arg1, arg2, arg3)
+}
+
+// GetQueueLineage mocks base method.
+func (m *MockRepository) GetQueueLineage(arg0 context.Context, arg1 *string) ([]*model.QueueLineage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueLineage", arg0, arg1)
+	ret0, _ := ret[0].([]*model.QueueLineage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueLineage indicates an expected call of GetQueueLineage.
+func (mr *MockRepositoryMockRecorder) GetQueueLineage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueLineage", reflect.TypeOf((*MockRepository)(nil).GetQueueLineage), arg0, arg1)
+}
+
+// GetQueueThroughput mocks base method.
+func (m *MockRepository) GetQueueThroughput(arg0 context.Context, arg1 ThroughputFilters) ([]*model.ThroughputBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueThroughput", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ThroughputBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueThroughput indicates an expected call of GetQueueThroughput.
+func (mr *MockRepositoryMockRecorder) GetQueueThroughput(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueThroughput", reflect.TypeOf((*MockRepository)(nil).GetQueueThroughput), arg0, arg1)
+}
+
+// GetQueueWaitPhases mocks base method.
+func (m *MockRepository) GetQueueWaitPhases(arg0 context.Context, arg1 WaitPhaseFilters) ([]*model.QueueWaitPhases, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueWaitPhases", arg0, arg1)
+	ret0, _ := ret[0].([]*model.QueueWaitPhases)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueWaitPhases indicates an expected call of GetQueueWaitPhases.
+func (mr *MockRepositoryMockRecorder) GetQueueWaitPhases(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueWaitPhases", reflect.TypeOf((*MockRepository)(nil).GetQueueWaitPhases), arg0, arg1)
+}
+
+// GetQueuesPerPartition mocks base method.
+func (m *MockRepository) GetQueuesPerPartition(arg0 context.Context, arg1 string) ([]*model.PartitionQueueDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueuesPerPartition", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PartitionQueueDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueuesPerPartition indicates an expected call of GetQueuesPerPartition.
+func (mr *MockRepositoryMockRecorder) GetQueuesPerPartition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueuesPerPartition", reflect.TypeOf((*MockRepository)(nil).GetQueuesPerPartition), arg0, arg1)
+}
+
+// GetSLOEvaluations mocks base method.
+func (m *MockRepository) GetSLOEvaluations(arg0 context.Context, arg1 SLOEvaluationFilters) ([]*model.SLOEvaluation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSLOEvaluations", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SLOEvaluation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSLOEvaluations indicates an expected call of GetSLOEvaluations.
+func (mr *MockRepositoryMockRecorder) GetSLOEvaluations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSLOEvaluations", reflect.TypeOf((*MockRepository)(nil).GetSLOEvaluations), arg0, arg1)
+}
+
+// GetSLOs mocks base method.
+func (m *MockRepository) GetSLOs(arg0 context.Context) ([]*model.QueueSLO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSLOs", arg0)
+	ret0, _ := ret[0].([]*model.QueueSLO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSLOs indicates an expected call of GetSLOs.
+func (mr *MockRepositoryMockRecorder) GetSLOs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSLOs", reflect.TypeOf((*MockRepository)(nil).GetSLOs), arg0)
+}
+
+// GetSchedulerEpochs mocks base method.
+func (m *MockRepository) GetSchedulerEpochs(arg0 context.Context, arg1 SchedulerEpochFilters) ([]*model.SchedulerEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulerEpochs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SchedulerEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulerEpochs indicates an expected call of GetSchedulerEpochs.
+func (mr *MockRepositoryMockRecorder) GetSchedulerEpochs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulerEpochs", reflect.TypeOf((*MockRepository)(nil).GetSchedulerEpochs), arg0, arg1)
+}
+
+// GetTopUsage mocks base method.
+func (m *MockRepository) GetTopUsage(arg0 context.Context, arg1 TopUsageFilters) ([]*model.TopUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopUsage", arg0, arg1)
+	ret0, _ := ret[0].([]*model.TopUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopUsage indicates an expected call of GetTopUsage.
+func (mr *MockRepositoryMockRecorder) GetTopUsage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopUsage", reflect.TypeOf((*MockRepository)(nil).GetTopUsage), arg0, arg1)
+}
+
+// GetTraceEvents mocks base method.
+func (m *MockRepository) GetTraceEvents(arg0 context.Context, arg1 string, arg2 TraceEventFilters) ([]*model.TraceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTraceEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.TraceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTraceEvents indicates an expected call of GetTraceEvents.
+func (mr *MockRepositoryMockRecorder) GetTraceEvents(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTraceEvents", reflect.TypeOf((*MockRepository)(nil).GetTraceEvents), arg0, arg1, arg2)
+}
+
+// HeartbeatCluster mocks base method.
+func (m *MockRepository) HeartbeatCluster(arg0 context.Context, arg1 string, arg2 *time.Time) (*model.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartbeatCluster", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeartbeatCluster indicates an expected call of HeartbeatCluster.
+func (mr *MockRepositoryMockRecorder) HeartbeatCluster(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartbeatCluster", reflect.TypeOf((*MockRepository)(nil).HeartbeatCluster), arg0, arg1, arg2)
+}
+
+// InsertNodeUtilizations mocks base method.
+func (m *MockRepository) InsertNodeUtilizations(arg0 context.Context, arg1 uuid.UUID, arg2 []*dao.PartitionNodesUtilDAOInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNodeUtilizations", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
@@ -234,6 +1032,251 @@ func (mr *MockRepositoryMockRecorder) InsertNodeUtilizations(arg0, arg1, arg2 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNodeUtilizations", reflect.TypeOf((*MockRepository)(nil).InsertNodeUtilizations), arg0, arg1, arg2)
 }
 
+// MoveApplicationsToColdTier mocks base method.
+func (m *MockRepository) MoveApplicationsToColdTier(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveApplicationsToColdTier", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveApplicationsToColdTier indicates an expected call of MoveApplicationsToColdTier.
+func (mr *MockRepositoryMockRecorder) MoveApplicationsToColdTier(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveApplicationsToColdTier", reflect.TypeOf((*MockRepository)(nil).MoveApplicationsToColdTier), arg0, arg1, arg2)
+}
+
+// PauseOperation mocks base method.
+func (m *MockRepository) PauseOperation(arg0 context.Context, arg1 *model.PausedOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseOperation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseOperation indicates an expected call of PauseOperation.
+func (mr *MockRepositoryMockRecorder) PauseOperation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseOperation", reflect.TypeOf((*MockRepository)(nil).PauseOperation), arg0, arg1)
+}
+
+// PseudonymizeAuditRecords mocks base method.
+func (m *MockRepository) PseudonymizeAuditRecords(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PseudonymizeAuditRecords", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PseudonymizeAuditRecords indicates an expected call of PseudonymizeAuditRecords.
+func (mr *MockRepositoryMockRecorder) PseudonymizeAuditRecords(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PseudonymizeAuditRecords", reflect.TypeOf((*MockRepository)(nil).PseudonymizeAuditRecords), arg0, arg1, arg2)
+}
+
+// PutSLO mocks base method.
+func (m *MockRepository) PutSLO(arg0 context.Context, arg1 *model.QueueSLO, arg2 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSLO", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutSLO indicates an expected call of PutSLO.
+func (mr *MockRepositoryMockRecorder) PutSLO(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSLO", reflect.TypeOf((*MockRepository)(nil).PutSLO), arg0, arg1, arg2)
+}
+
+// QueryAnalytics mocks base method.
+func (m *MockRepository) QueryAnalytics(arg0 context.Context, arg1 AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAnalytics", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AnalyticsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAnalytics indicates an expected call of QueryAnalytics.
+func (mr *MockRepositoryMockRecorder) QueryAnalytics(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAnalytics", reflect.TypeOf((*MockRepository)(nil).QueryAnalytics), arg0, arg1)
+}
+
+// RecordNodeTermination mocks base method.
+func (m *MockRepository) RecordNodeTermination(arg0 context.Context, arg1 string, arg2 int64, arg3 []map[string]string) (*model.NodeTermination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordNodeTermination", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.NodeTermination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordNodeTermination indicates an expected call of RecordNodeTermination.
+func (mr *MockRepositoryMockRecorder) RecordNodeTermination(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordNodeTermination", reflect.TypeOf((*MockRepository)(nil).RecordNodeTermination), arg0, arg1, arg2, arg3)
+}
+
+// RecordSchedulerEpoch mocks base method.
+func (m *MockRepository) RecordSchedulerEpoch(arg0 context.Context, arg1 *model.SchedulerEpoch) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSchedulerEpoch", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSchedulerEpoch indicates an expected call of RecordSchedulerEpoch.
+func (mr *MockRepositoryMockRecorder) RecordSchedulerEpoch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSchedulerEpoch", reflect.TypeOf((*MockRepository)(nil).RecordSchedulerEpoch), arg0, arg1)
+}
+
+// RegisterCluster mocks base method.
+func (m *MockRepository) RegisterCluster(arg0 context.Context, arg1 *model.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterCluster", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterCluster indicates an expected call of RegisterCluster.
+func (mr *MockRepositoryMockRecorder) RegisterCluster(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCluster", reflect.TypeOf((*MockRepository)(nil).RegisterCluster), arg0, arg1)
+}
+
+// ReleaseLegalHold mocks base method.
+func (m *MockRepository) ReleaseLegalHold(arg0 context.Context, arg1, arg2, arg3 string, arg4 *int64) (*model.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLegalHold", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLegalHold indicates an expected call of ReleaseLegalHold.
+func (mr *MockRepositoryMockRecorder) ReleaseLegalHold(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLegalHold", reflect.TypeOf((*MockRepository)(nil).ReleaseLegalHold), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ResumeOperation mocks base method.
+func (m *MockRepository) ResumeOperation(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeOperation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeOperation indicates an expected call of ResumeOperation.
+func (mr *MockRepositoryMockRecorder) ResumeOperation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeOperation", reflect.TypeOf((*MockRepository)(nil).ResumeOperation), arg0, arg1)
+}
+
+// StartAnalyticsSink mocks base method.
+func (m *MockRepository) StartAnalyticsSink(arg0 context.Context, arg1 string) (*model.AnalyticsSink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAnalyticsSink", arg0, arg1)
+	ret0, _ := ret[0].(*model.AnalyticsSink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAnalyticsSink indicates an expected call of StartAnalyticsSink.
+func (mr *MockRepositoryMockRecorder) StartAnalyticsSink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAnalyticsSink", reflect.TypeOf((*MockRepository)(nil).StartAnalyticsSink), arg0, arg1)
+}
+
+// StartUserErasure mocks base method.
+func (m *MockRepository) StartUserErasure(arg0 context.Context, arg1 *model.UserErasure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartUserErasure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartUserErasure indicates an expected call of StartUserErasure.
+func (mr *MockRepositoryMockRecorder) StartUserErasure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartUserErasure", reflect.TypeOf((*MockRepository)(nil).StartUserErasure), arg0, arg1)
+}
+
+// StopAnalyticsSink mocks base method.
+func (m *MockRepository) StopAnalyticsSink(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopAnalyticsSink", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopAnalyticsSink indicates an expected call of StopAnalyticsSink.
+func (mr *MockRepositoryMockRecorder) StopAnalyticsSink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAnalyticsSink", reflect.TypeOf((*MockRepository)(nil).StopAnalyticsSink), arg0, arg1)
+}
+
+// StreamApplicationsHistory mocks base method.
+func (m *MockRepository) StreamApplicationsHistory(arg0 context.Context, arg1 func(*dao.ApplicationHistoryDAOInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamApplicationsHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamApplicationsHistory indicates an expected call of StreamApplicationsHistory.
+func (mr *MockRepositoryMockRecorder) StreamApplicationsHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamApplicationsHistory", reflect.TypeOf((*MockRepository)(nil).StreamApplicationsHistory), arg0, arg1)
+}
+
+// StreamAppsPerPartitionPerQueue mocks base method.
+func (m *MockRepository) StreamAppsPerPartitionPerQueue(arg0 context.Context, arg1, arg2 string, arg3 ApplicationFilters, arg4 func(*model.ApplicationDAOInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamAppsPerPartitionPerQueue", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamAppsPerPartitionPerQueue indicates an expected call of StreamAppsPerPartitionPerQueue.
+func (mr *MockRepositoryMockRecorder) StreamAppsPerPartitionPerQueue(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamAppsPerPartitionPerQueue", reflect.TypeOf((*MockRepository)(nil).StreamAppsPerPartitionPerQueue), arg0, arg1, arg2, arg3, arg4)
+}
+
+// StreamContainersHistory mocks base method.
+func (m *MockRepository) StreamContainersHistory(arg0 context.Context, arg1 func(*dao.ContainerHistoryDAOInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamContainersHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamContainersHistory indicates an expected call of StreamContainersHistory.
+func (mr *MockRepositoryMockRecorder) StreamContainersHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamContainersHistory", reflect.TypeOf((*MockRepository)(nil).StreamContainersHistory), arg0, arg1)
+}
+
+// SyncConfigSLOs mocks base method.
+func (m *MockRepository) SyncConfigSLOs(arg0 context.Context, arg1 []*model.QueueSLO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncConfigSLOs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncConfigSLOs indicates an expected call of SyncConfigSLOs.
+func (mr *MockRepositoryMockRecorder) SyncConfigSLOs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncConfigSLOs", reflect.TypeOf((*MockRepository)(nil).SyncConfigSLOs), arg0, arg1)
+}
+
 // UpdateHistory mocks base method.
 func (m *MockRepository) UpdateHistory(arg0 context.Context, arg1 []*dao.ApplicationHistoryDAOInfo, arg2 []*dao.ContainerHistoryDAOInfo) error {
 	m.ctrl.T.Helper()
@@ -248,6 +1291,34 @@ func (mr *MockRepositoryMockRecorder) UpdateHistory(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistory", reflect.TypeOf((*MockRepository)(nil).UpdateHistory), arg0, arg1, arg2)
 }
 
+// UpdateQueueACLs mocks base method.
+func (m *MockRepository) UpdateQueueACLs(arg0 context.Context, arg1 []*model.QueueACL, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQueueACLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQueueACLs indicates an expected call of UpdateQueueACLs.
+func (mr *MockRepositoryMockRecorder) UpdateQueueACLs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQueueACLs", reflect.TypeOf((*MockRepository)(nil).UpdateQueueACLs), arg0, arg1, arg2)
+}
+
+// UpdateUserErasure mocks base method.
+func (m *MockRepository) UpdateUserErasure(arg0 context.Context, arg1 *model.UserErasure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserErasure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserErasure indicates an expected call of UpdateUserErasure.
+func (mr *MockRepositoryMockRecorder) UpdateUserErasure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserErasure", reflect.TypeOf((*MockRepository)(nil).UpdateUserErasure), arg0, arg1)
+}
+
 // UpsertApplications mocks base method.
 func (m *MockRepository) UpsertApplications(arg0 context.Context, arg1 []*dao.ApplicationDAOInfo) error {
 	m.ctrl.T.Helper()
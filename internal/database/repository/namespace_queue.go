@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
+)
+
+// NamespaceQueueFilters select the queues of namespaces which applications were submitted to at any time of a period.
+type NamespaceQueueFilters struct {
+	Namespace *string
+	QueueName *string
+	Start     *time.Time
+	End       *time.Time
+}
+
+// recordNamespaceQueue records that the application of its namespace was placed in its queue. The submission times
+// are widened to include the application, and the application is counted if it was not stored before, so that
+// storing an application again does not count it again.
+func recordNamespaceQueue(ctx context.Context, tx pgx.Tx, app *dao.ApplicationDAOInfo, previous *storedApplication) error {
+	namespace := tags.Namespace(app)
+	if namespace == "" || app.SubmissionTime == 0 {
+		return nil
+	}
+	upsertSQL := `INSERT INTO namespace_queues (partition, namespace, queue_name, first_submission_time,
+			last_submission_time, applications)
+		VALUES (@partition, @namespace, @queue_name, @submission_time, @submission_time, @applications)
+		ON CONFLICT (partition, namespace, queue_name) DO UPDATE SET
+			first_submission_time = LEAST(namespace_queues.first_submission_time, EXCLUDED.first_submission_time),
+			last_submission_time = GREATEST(namespace_queues.last_submission_time, EXCLUDED.last_submission_time),
+			applications = namespace_queues.applications + EXCLUDED.applications`
+	applications := 0
+	if previous == nil {
+		applications = 1
+	}
+	_, err := tx.Exec(ctx, upsertSQL, pgx.NamedArgs{
+		"partition":       app.Partition,
+		"namespace":       namespace,
+		"queue_name":      app.QueueName,
+		"submission_time": app.SubmissionTime,
+		"applications":    applications,
+	})
+	if err != nil {
+		return fmt.Errorf("could not record namespace of application %s in DB: %v", app.ApplicationID, err)
+	}
+	return nil
+}
+
+// GetNamespaceQueues returns the queues the applications of the namespaces of the partition were placed in,
+// ordered by namespace and most recent first.
+func (s *PostgresRepository) GetNamespaceQueues(
+	ctx context.Context, partition string, filters NamespaceQueueFilters) ([]*model.NamespaceQueue, error) {
+	query, args, err := namespaceQueuesQuery(partition, filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get namespace queues from DB: %v", err)
+	}
+
+	var mappings []*model.NamespaceQueue
+	err = forEachRow(rows, "namespace queues", func(mapping *model.NamespaceQueue) error {
+		mappings = append(mappings, mapping)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// namespaceQueuesQuery builds the query of GetNamespaceQueues. A queue is selected if applications of the namespace
+// were submitted to it at any time of the period.
+func namespaceQueuesQuery(partition string, filters NamespaceQueueFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(namespaceQueuesTable, "").
+		Conditionp("partition", sql.Equal, partition).
+		OrderBy("namespace", sql.OrderByAscending).
+		OrderBy("last_submission_time", sql.OrderByDescending)
+	if filters.Namespace != nil {
+		queryBuilder.Conditionp("namespace", sql.Equal, *filters.Namespace)
+	}
+	if filters.QueueName != nil {
+		queryBuilder.Conditionp("queue_name", sql.Equal, *filters.QueueName)
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("first_submission_time", sql.LessThanOrEqual, filters.End.UnixNano())
+	}
+	if filters.Start != nil {
+		queryBuilder.Conditionp("last_submission_time", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	}
+	return queryBuilder
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestNamespaceQueues_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	queues := []*dao.PartitionQueueDAOInfo{{
+		Partition: "default",
+		QueueName: "root",
+		Children: []dao.PartitionQueueDAOInfo{
+			{Partition: "default", QueueName: "root.legacy", Parent: "root"},
+			{Partition: "default", QueueName: "root.team-a", Parent: "root"},
+		},
+	}}
+	require.NoError(t, repo.AddQueues(ctx, nil, queues))
+
+	t1 := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(24 * time.Hour)
+	t3 := t2.Add(24 * time.Hour)
+	app := func(id, queue string, submittedAt time.Time) *dao.ApplicationDAOInfo {
+		return &dao.ApplicationDAOInfo{
+			ApplicationID:  id,
+			Partition:      "default",
+			QueueName:      queue,
+			SubmissionTime: submittedAt.UnixNano(),
+			Requests:       []*dao.AllocationAskDAOInfo{{AllocationTags: map[string]string{tags.NamespaceTag: "team-a"}}},
+		}
+	}
+	// the mapping of the namespace changed from root.legacy to root.team-a on the second day
+	apps := []*dao.ApplicationDAOInfo{
+		app("app-1", "root.legacy", t1),
+		app("app-2", "root.legacy", t1.Add(time.Hour)),
+		app("app-3", "root.team-a", t2),
+		app("app-4", "root.team-a", t3),
+		{ApplicationID: "app-5", Partition: "default", QueueName: "root.team-a", SubmissionTime: t3.UnixNano()},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	// storing the applications again does not count them again
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	mappings, err := repo.GetNamespaceQueues(ctx, "default", NamespaceQueueFilters{})
+	require.NoError(t, err)
+	require.Len(t, mappings, 2)
+	assert.Equal(t, "team-a", mappings[0].Namespace)
+	assert.Equal(t, "root.team-a", mappings[0].QueueName)
+	assert.Equal(t, t2.UnixNano(), mappings[0].FirstSubmissionTime)
+	assert.Equal(t, t3.UnixNano(), mappings[0].LastSubmissionTime)
+	assert.Equal(t, int64(2), mappings[0].Applications)
+	assert.Equal(t, "root.legacy", mappings[1].QueueName)
+	assert.Equal(t, int64(2), mappings[1].Applications)
+
+	// on the first day, the namespace was mapped to root.legacy only
+	start, end := t1, t1.Add(12*time.Hour)
+	mappings, err = repo.GetNamespaceQueues(ctx, "default", NamespaceQueueFilters{
+		Namespace: util.ToPtr("team-a"),
+		Start:     &start,
+		End:       &end,
+	})
+	require.NoError(t, err)
+	require.Len(t, mappings, 1)
+	assert.Equal(t, "root.legacy", mappings[0].QueueName)
+
+	mappings, err = repo.GetNamespaceQueues(ctx, "default", NamespaceQueueFilters{QueueName: util.ToPtr("root.team-a")})
+	require.NoError(t, err)
+	require.Len(t, mappings, 1)
+	assert.Equal(t, "team-a", mappings[0].Namespace)
+}
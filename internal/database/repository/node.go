@@ -2,19 +2,27 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/apache/yunikorn-core/pkg/webservice/dao"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
 )
 
+// ErrNodeNotFound is returned for nodes which are not stored.
+var ErrNodeNotFound = errors.New("node not found")
+
 func (s *PostgresRepository) UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
 	upsertSQL := `INSERT INTO nodes (id, node_id, partition, host_name, rack_name, attributes, capacity, allocated,
-		occupied, available, utilized, allocations, schedulable, is_reserved, reservations )
+		occupied, available, utilized, allocations, schedulable, is_reserved, reservations, zone, rack)
 		VALUES (@id, @node_id, @partition, @host_name, @rack_name, @attributes, @capacity, @allocated,
-		@occupied, @available, @utilized, @allocations, @schedulable, @is_reserved, @reservations)
+		@occupied, @available, @utilized, @allocations, @schedulable, @is_reserved, @reservations, @zone, @rack)
 	ON CONFLICT (node_id) DO UPDATE SET
+		zone = EXCLUDED.zone,
+		rack = EXCLUDED.rack,
 		capacity = EXCLUDED.capacity,
 		allocated = EXCLUDED.allocated,
 		occupied = EXCLUDED.occupied,
@@ -43,6 +51,8 @@ func (s *PostgresRepository) UpsertNodes(ctx context.Context, nodes []*dao.NodeD
 				"schedulable":  n.Schedulable,
 				"is_reserved":  n.IsReserved,
 				"reservations": n.Reservations,
+				"zone":         s.nodeZone(n),
+				"rack":         s.nodeRack(n),
 			})
 		if err != nil {
 			return fmt.Errorf("could not insert application into DB: %v", err)
@@ -51,6 +61,27 @@ func (s *PostgresRepository) UpsertNodes(ctx context.Context, nodes []*dao.NodeD
 	return nil
 }
 
+// nodeZone returns the zone of the node taken from its zone attribute, nil if it has none.
+func (s *PostgresRepository) nodeZone(n *dao.NodeDAOInfo) *string {
+	return nonEmpty(n.Attributes[s.zoneAttribute])
+}
+
+// nodeRack returns the rack of the node taken from its rack attribute if set, its rack name otherwise, nil if it
+// has none.
+func (s *PostgresRepository) nodeRack(n *dao.NodeDAOInfo) *string {
+	if s.rackAttribute != "" {
+		return nonEmpty(n.Attributes[s.rackAttribute])
+	}
+	return nonEmpty(n.RackName)
+}
+
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 func (s *PostgresRepository) InsertNodeUtilizations(
 	ctx context.Context,
 	u uuid.UUID,
@@ -83,17 +114,14 @@ func (s *PostgresRepository) GetNodeUtilizations(ctx context.Context) ([]*dao.Pa
 	if err != nil {
 		return nil, fmt.Errorf("could not get node utilizations from DB: %v", err)
 	}
-	defer rows.Close()
 
 	var nodesUtil []*dao.PartitionNodesUtilDAOInfo
-	for rows.Next() {
-		nu := &dao.PartitionNodesUtilDAOInfo{}
-		var id string
-		err := rows.Scan(&id, &nu.ClusterID, &nu.Partition, &nu.NodesUtilList)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan node utilizations from DB: %v", err)
-		}
-		nodesUtil = append(nodesUtil, nu)
+	err = forEachRow(rows, "node utilizations", func(row *nodeUtilizationRow) error {
+		nodesUtil = append(nodesUtil, row.toModel())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return nodesUtil, nil
 }
@@ -107,17 +135,92 @@ func (s *PostgresRepository) GetNodesPerPartition(ctx context.Context, partition
 	if err != nil {
 		return nil, fmt.Errorf("could not get nodes from DB: %v", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		n := dao.NodeDAOInfo{}
-		var id string
-		err := rows.Scan(&id, &n.NodeID, nil, &n.HostName, &n.RackName, &n.Attributes, &n.Capacity,
-			&n.Allocated, &n.Occupied, &n.Available, &n.Utilized, &n.Allocations, &n.Schedulable,
-			&n.IsReserved, &n.Reservations)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan node: %v", err)
-		}
-		nodes = append(nodes, &n)
+	err = forEachRow(rows, "nodes", func(row *nodeRow) error {
+		nodes = append(nodes, row.toModel())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return nodes, nil
 }
+
+// GetNodesPerPartitionPage returns a page of the nodes of the partition ordered by their node ID, with the number of
+// nodes of the partition and the cursor of the next page, whose cursors have no keys.
+func (s *PostgresRepository) GetNodesPerPartitionPage(ctx context.Context, partition string, page Page) (
+	[]*dao.NodeDAOInfo, PageInfo, error) {
+	if err := page.After.checkKeys(0); err != nil {
+		return nil, PageInfo{}, err
+	}
+	selectSQL := `SELECT * FROM nodes WHERE partition = $1 AND ($2::TEXT IS NULL OR node_id > $2)
+		ORDER BY node_id LIMIT $3`
+	var afterNodeID *string
+	if page.After != nil {
+		afterNodeID = &page.After.ID
+	}
+
+	nodes := []*dao.NodeDAOInfo{}
+
+	rows, err := s.dbpool.Query(ctx, selectSQL, partition, afterNodeID, pageQueryLimit(page.Limit))
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not get nodes from DB: %v", err)
+	}
+	err = forEachRow(rows, "nodes", func(row *nodeRow) error {
+		nodes = append(nodes, row.toModel())
+		return nil
+	})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	var info PageInfo
+	nodes, info.Next = trimPage(nodes, page.Limit, func(node *dao.NodeDAOInfo) *Cursor {
+		return &Cursor{ID: node.NodeID}
+	})
+
+	countSQL := `SELECT COUNT(*) FROM nodes WHERE partition = $1`
+	if err := s.dbpool.QueryRow(ctx, countSQL, partition).Scan(&info.Total); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("could not count nodes in DB: %v", err)
+	}
+	return nodes, info, nil
+}
+
+// GetNode returns the node with its partition, or ErrNodeNotFound if it is not stored.
+func (s *PostgresRepository) GetNode(ctx context.Context, nodeID string) (*model.NodeDAOInfo, error) {
+	selectSQL := "SELECT * FROM nodes WHERE node_id = $1"
+
+	rows, err := s.dbpool.Query(ctx, selectSQL, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get node from DB: %v", err)
+	}
+	var node *model.NodeDAOInfo
+	err = forEachRow(rows, "node", func(row *nodeRow) error {
+		node = &model.NodeDAOInfo{Partition: row.Partition, NodeDAOInfo: *row.toModel()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+	return node, nil
+}
+
+// GetNodeTopologies returns the zone and rack of the nodes of the partition.
+func (s *PostgresRepository) GetNodeTopologies(ctx context.Context, partition string) ([]*model.NodeTopology, error) {
+	selectSQL := `SELECT node_id, partition, zone, rack FROM nodes WHERE partition = $1 ORDER BY node_id`
+
+	rows, err := s.dbpool.Query(ctx, selectSQL, partition)
+	if err != nil {
+		return nil, fmt.Errorf("could not get node topologies from DB: %v", err)
+	}
+	topologies := []*model.NodeTopology{}
+	err = forEachRow(rows, "node topologies", func(t *model.NodeTopology) error {
+		topologies = append(topologies, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return topologies, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestNodeTopologies_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithTopologyAttributes("example.com/zone", "example.com/rack"))
+	require.NoError(t, err)
+
+	nodes := []*dao.NodeDAOInfo{
+		{
+			NodeID:     "node-1",
+			RackName:   "/rack-default",
+			Attributes: map[string]string{"example.com/zone": "a", "example.com/rack": "a-1"},
+		},
+		{NodeID: "node-2", RackName: "/rack-default"},
+	}
+	require.NoError(t, repo.UpsertNodes(ctx, nodes, "default"))
+	require.NoError(t, repo.UpsertNodes(ctx, []*dao.NodeDAOInfo{{NodeID: "node-3"}}, "other"))
+
+	topologies, err := repo.GetNodeTopologies(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, []*model.NodeTopology{
+		{NodeID: "node-1", Partition: "default", Zone: util.ToPtr("a"), Rack: util.ToPtr("a-1")},
+		{NodeID: "node-2", Partition: "default"},
+	}, topologies)
+
+	// the topology of a node is updated when it is upserted again
+	nodes[1].Attributes = map[string]string{"example.com/zone": "b"}
+	require.NoError(t, repo.UpsertNodes(ctx, nodes[1:], "default"))
+	topologies, err = repo.GetNodeTopologies(ctx, "default")
+	require.NoError(t, err)
+	require.Len(t, topologies, 2)
+	assert.Equal(t, util.ToPtr("b"), topologies[1].Zone)
+}
+
+func TestGetNode_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	allocations := []*dao.AllocationDAOInfo{{ApplicationID: "app-1", ResourcePerAlloc: map[string]int64{"vcore": 1000}}}
+	require.NoError(t, repo.UpsertNodes(ctx, []*dao.NodeDAOInfo{{NodeID: "node-1", Allocations: allocations}}, "default"))
+
+	node, err := repo.GetNode(ctx, "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "default", node.Partition)
+	assert.Equal(t, "node-1", node.NodeID)
+	assert.Equal(t, allocations, node.Allocations)
+
+	_, err = repo.GetNode(ctx, "node-2")
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
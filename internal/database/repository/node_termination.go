@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// NodeTerminationFilters select the terminations of nodes during a period.
+type NodeTerminationFilters struct {
+	Partition *string
+	Start     *time.Time
+	End       *time.Time
+	// SpotOnly selects only the terminations of spot nodes.
+	SpotOnly bool
+}
+
+// RecordNodeTermination records the termination of a node at a time in nanoseconds, with its attributes, topology
+// and allocations as of its last sync. The node is a spot node if its attributes match all the labels of any of the
+// spot selectors. It returns nil if the node is not known, or if its termination at the time was already recorded.
+func (s *PostgresRepository) RecordNodeTermination(
+	ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+) (*model.NodeTermination, error) {
+	insertSQL := `INSERT INTO node_terminations (node_id, partition, terminated_at, spot, drained, zone, rack,
+		attributes, allocations)
+		SELECT node_id, partition, @terminated_at,
+			EXISTS (SELECT 1 FROM jsonb_array_elements(@spot_selectors::jsonb) selector
+				WHERE COALESCE(attributes, '{}') @> selector),
+			NOT schedulable, zone, rack, attributes, allocations
+		FROM nodes WHERE node_id = @node_id
+	ON CONFLICT (node_id, terminated_at) DO NOTHING
+	RETURNING *`
+
+	if spotSelectors == nil {
+		spotSelectors = []map[string]string{}
+	}
+	rows, err := s.dbpool.Query(ctx, insertSQL, pgx.NamedArgs{
+		"node_id":        nodeID,
+		"terminated_at":  terminatedAt,
+		"spot_selectors": spotSelectors,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not insert node termination into DB: %v", err)
+	}
+	termination, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[model.NodeTermination])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not insert node termination into DB: %v", err)
+	}
+	return termination, nil
+}
+
+// GetNodeTerminations returns the terminations of nodes, oldest first.
+func (s *PostgresRepository) GetNodeTerminations(
+	ctx context.Context, filters NodeTerminationFilters) ([]*model.NodeTermination, error) {
+	query, args, err := nodeTerminationsQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get node terminations from DB: %v", err)
+	}
+
+	var terminations []*model.NodeTermination
+	err = forEachRow(rows, "node terminations", func(t *model.NodeTermination) error {
+		terminations = append(terminations, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return terminations, nil
+}
+
+// nodeTerminationsQuery builds the query of GetNodeTerminations.
+func nodeTerminationsQuery(filters NodeTerminationFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(nodeTerminationsTable, "").
+		OrderBy("terminated_at", sql.OrderByAscending)
+	if filters.Partition != nil {
+		queryBuilder.Conditionp("partition", sql.Equal, *filters.Partition)
+	}
+	if filters.Start != nil {
+		queryBuilder.Conditionp("terminated_at", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("terminated_at", sql.LessThan, filters.End.UnixNano())
+	}
+	if filters.SpotOnly {
+		queryBuilder.Conditionp("spot", sql.Equal, true)
+	}
+	return queryBuilder
+}
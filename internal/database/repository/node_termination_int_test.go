@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestNodeTerminations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	nodes := []*dao.NodeDAOInfo{
+		{
+			NodeID: "spot-1",
+			Attributes: map[string]string{
+				"karpenter.sh/capacity-type":  "spot",
+				"topology.kubernetes.io/zone": "a",
+			},
+			Allocations: []*dao.AllocationDAOInfo{
+				{AllocationKey: "pod-1", ApplicationID: "app-1", ResourcePerAlloc: map[string]int64{"vcore": 1000}},
+			},
+			Schedulable: true,
+		},
+		{NodeID: "on-demand-1", Attributes: map[string]string{"karpenter.sh/capacity-type": "on-demand"}},
+	}
+	require.NoError(t, repo.UpsertNodes(ctx, nodes, "default"))
+	selectors := []map[string]string{{"karpenter.sh/capacity-type": "spot"}}
+
+	spot, err := repo.RecordNodeTermination(ctx, "spot-1", 2000, selectors)
+	require.NoError(t, err)
+	require.NotNil(t, spot)
+	assert.NotEmpty(t, spot.ID)
+	assert.Equal(t, "default", spot.Partition)
+	assert.True(t, spot.Spot)
+	assert.False(t, spot.Drained)
+	assert.Equal(t, util.ToPtr("a"), spot.Zone)
+	require.Len(t, spot.Allocations, 1)
+	assert.Equal(t, "app-1", spot.Allocations[0].ApplicationID)
+
+	onDemand, err := repo.RecordNodeTermination(ctx, "on-demand-1", 1000, selectors)
+	require.NoError(t, err)
+	require.NotNil(t, onDemand)
+	assert.False(t, onDemand.Spot)
+	assert.True(t, onDemand.Drained)
+
+	// the termination of a node at a time is recorded once, and unknown nodes are not recorded
+	again, err := repo.RecordNodeTermination(ctx, "spot-1", 2000, selectors)
+	require.NoError(t, err)
+	assert.Nil(t, again)
+	unknown, err := repo.RecordNodeTermination(ctx, "unknown", 2000, selectors)
+	require.NoError(t, err)
+	assert.Nil(t, unknown)
+
+	terminations, err := repo.GetNodeTerminations(ctx, NodeTerminationFilters{})
+	require.NoError(t, err)
+	require.Len(t, terminations, 2)
+	assert.Equal(t, "on-demand-1", terminations[0].NodeID)
+	assert.Equal(t, "spot-1", terminations[1].NodeID)
+
+	terminations, err = repo.GetNodeTerminations(ctx, NodeTerminationFilters{
+		Partition: util.ToPtr("default"),
+		Start:     util.ToPtr(time.Unix(0, 1500)),
+		SpotOnly:  true,
+	})
+	require.NoError(t, err)
+	require.Len(t, terminations, 1)
+	assert.Equal(t, spot, terminations[0])
+}
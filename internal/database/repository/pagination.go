@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned if a cursor cannot be parsed, or is not a cursor of the order of the rows it is used on.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position after the last row of a page of keyset paginated rows, which are ordered by their keys and
+// their ID. Unlike an offset, the rows before the cursor are skipped with an index instead of being read, and rows
+// inserted or deleted before the cursor don't shift the next page.
+type Cursor struct {
+	Keys []int64 `json:"k,omitempty"`
+	ID   string  `json:"id"`
+}
+
+// String encodes the cursor as an opaque URL-safe string, see ParseCursor.
+func (c *Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor parses a cursor encoded by Cursor.String.
+func ParseCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if cursor.ID == "" {
+		return nil, fmt.Errorf("%w: missing ID", ErrInvalidCursor)
+	}
+	return &cursor, nil
+}
+
+// checkKeys returns ErrInvalidCursor if the cursor is not nil and does not have the keys of the order of the rows.
+func (c *Cursor) checkKeys(keys int) error {
+	if c != nil && len(c.Keys) != keys {
+		return fmt.Errorf("%w: expected %d keys, got %d", ErrInvalidCursor, keys, len(c.Keys))
+	}
+	return nil
+}
+
+// Page selects a page of keyset paginated rows: at most Limit rows after the cursor After, or from the first row
+// if After is nil. All rows after the cursor are selected if Limit is nil.
+type Page struct {
+	After *Cursor
+	Limit *int
+}
+
+// PageInfo describes a page of keyset paginated rows.
+type PageInfo struct {
+	// Total is the number of rows of all pages.
+	Total int
+	// Next is the cursor of the next page, nil if the page is the last page.
+	Next *Cursor
+}
+
+// pageQueryLimit returns the limit of the query of a page, which selects a row more than the limit of the page to
+// tell whether there is a next page, see trimPage.
+func pageQueryLimit(limit *int) *int {
+	if limit == nil {
+		return nil
+	}
+	queryLimit := *limit + 1
+	return &queryLimit
+}
+
+// trimPage trims the rows selected with pageQueryLimit to the limit of the page, and returns the cursor of the last row
+// of the page if there is a next page.
+func trimPage[T any](rows []T, limit *int, cursor func(T) *Cursor) ([]T, *Cursor) {
+	if limit == nil || len(rows) <= *limit {
+		return rows, nil
+	}
+	rows = rows[:*limit]
+	if len(rows) == 0 {
+		return rows, nil
+	}
+	return rows, cursor(rows[len(rows)-1])
+}
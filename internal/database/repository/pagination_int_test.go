@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestGetAppsPerPartitionPerQueuePage_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool, WithColdTier(time.Hour))
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+	// the pages span both tiers
+	_, err = repo.MoveApplicationsToColdTier(ctx, time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+
+	for _, order := range ApplicationOrders {
+		t.Run(string(order), func(t *testing.T) {
+			all, err := repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{OrderBy: order})
+			require.NoError(t, err)
+			require.Greater(t, len(all), 2)
+
+			var paged []*model.ApplicationDAOInfo
+			filters := ApplicationFilters{OrderBy: order, Limit: util.ToPtr(2)}
+			for {
+				apps, page, err := repo.GetAppsPerPartitionPerQueuePage(ctx, "default", "root.default", filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(all), page.Total)
+				require.LessOrEqual(t, len(apps), 2)
+				paged = append(paged, apps...)
+				if page.Next == nil {
+					break
+				}
+				// cursors are passed between requests as strings
+				filters.After, err = ParseCursor(page.Next.String())
+				require.NoError(t, err)
+			}
+			assert.Equal(t, all, paged)
+		})
+	}
+
+	// a cursor of another order is rejected
+	_, _, err = repo.GetAppsPerPartitionPerQueuePage(ctx, "default", "root.default", ApplicationFilters{
+		OrderBy: OrderByDuration,
+		After:   &Cursor{Keys: []int64{1}, ID: "app1"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestHistoryPage_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	now := time.Now()
+	err = repo.UpdateHistory(ctx,
+		[]*dao.ApplicationHistoryDAOInfo{
+			{TotalApplications: "3", Timestamp: now.UnixNano()},
+			{TotalApplications: "1", Timestamp: now.Add(-2 * time.Hour).UnixNano()},
+			{TotalApplications: "2", Timestamp: now.Add(-time.Hour).UnixNano()},
+		},
+		[]*dao.ContainerHistoryDAOInfo{
+			{TotalContainers: "4", Timestamp: now.UnixNano()},
+		},
+	)
+	require.NoError(t, err)
+
+	apps, page, err := repo.GetApplicationsHistoryPage(ctx, Page{Limit: util.ToPtr(2)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	require.Len(t, apps, 2)
+	assert.Equal(t, "1", apps[0].TotalApplications)
+	assert.Equal(t, "2", apps[1].TotalApplications)
+	require.NotNil(t, page.Next)
+
+	apps, page, err = repo.GetApplicationsHistoryPage(ctx, Page{After: page.Next, Limit: util.ToPtr(2)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "3", apps[0].TotalApplications)
+	assert.Nil(t, page.Next)
+
+	containers, page, err := repo.GetContainersHistoryPage(ctx, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, containers, 1)
+	assert.Equal(t, "4", containers[0].TotalContainers)
+	assert.Nil(t, page.Next)
+
+	_, _, err = repo.GetApplicationsHistoryPage(ctx, Page{After: &Cursor{ID: "node-1"}})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestGetNodesPerPartitionPage_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	nodes := []*dao.NodeDAOInfo{{NodeID: "node-3"}, {NodeID: "node-1"}, {NodeID: "node-2"}}
+	require.NoError(t, repo.UpsertNodes(ctx, nodes, "default"))
+	require.NoError(t, repo.UpsertNodes(ctx, []*dao.NodeDAOInfo{{NodeID: "node-4"}}, "other"))
+
+	page1, page, err := repo.GetNodesPerPartitionPage(ctx, "default", Page{Limit: util.ToPtr(2)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	require.Len(t, page1, 2)
+	assert.Equal(t, "node-1", page1[0].NodeID)
+	assert.Equal(t, "node-2", page1[1].NodeID)
+	require.NotNil(t, page.Next)
+
+	page2, page, err := repo.GetNodesPerPartitionPage(ctx, "default", Page{After: page.Next, Limit: util.ToPtr(2)})
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+	assert.Equal(t, "node-3", page2[0].NodeID)
+	assert.Nil(t, page.Next)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestParseCursor(t *testing.T) {
+	cursor := &Cursor{Keys: []int64{1719835200000000000, 42}, ID: "app-1"}
+	parsed, err := ParseCursor(cursor.String())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+	assert.NotContains(t, cursor.String(), "=")
+
+	for _, value := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := ParseCursor(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	cursor := func(item int) *Cursor {
+		return &Cursor{Keys: []int64{int64(item)}, ID: "item"}
+	}
+	tests := map[string]struct {
+		rows     []int
+		limit    *int
+		want     []int
+		wantNext *Cursor
+	}{
+		"without limit":  {rows: []int{1, 2, 3}, want: []int{1, 2, 3}},
+		"last page":      {rows: []int{1, 2}, limit: util.ToPtr(2), want: []int{1, 2}},
+		"with next page": {rows: []int{1, 2, 3}, limit: util.ToPtr(2), want: []int{1, 2}, wantNext: cursor(2)},
+		"zero limit":     {rows: []int{1}, limit: util.ToPtr(0), want: []int{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, next := trimPage(tt.rows, tt.limit, cursor)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNext, next)
+		})
+	}
+	assert.Equal(t, util.ToPtr(3), pageQueryLimit(util.ToPtr(2)))
+	assert.Nil(t, pageQueryLimit(nil))
+}
+
+func TestAppsPerPartitionPerQueueCursor(t *testing.T) {
+	app := &model.ApplicationDAOInfo{Duration: util.ToPtr(int64(30))}
+	app.ApplicationID = "app-1"
+	app.SubmissionTime = 100
+
+	tests := map[ApplicationOrder]string{
+		OrderBySubmissionTime: `("submission_time", "app_id") < ($3, $4)`,
+		OrderByDuration:       `("duration", "submission_time", "app_id") < ($3, $4, $5)`,
+	}
+	for order, condition := range tests {
+		t.Run(string(order), func(t *testing.T) {
+			filters := ApplicationFilters{OrderBy: order, After: applicationCursor(app, order)}
+			query, args, err := appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", filters, nil).Build()
+			require.NoError(t, err)
+			assert.Contains(t, query, condition)
+			assert.True(t, strings.HasSuffix(query, `"submission_time" DESC, "app_id" DESC`), query)
+			assert.Equal(t, "app-1", args[len(args)-1])
+		})
+	}
+
+	// the count of all pages ignores the cursor and the limit
+	filters := ApplicationFilters{After: applicationCursor(app, ""), Limit: util.ToPtr(10), Offset: util.ToPtr(10)}
+	query, _, err := appsPerPartitionPerQueueCountQuery(applicationsTable, "default", "root.default", filters, nil).Build()
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT CAST(COUNT(*) AS DOUBLE PRECISION) FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2`,
+		query)
+
+	_, _, err = appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default",
+		ApplicationFilters{After: &Cursor{ID: "app-1"}}, nil).Build()
+	assert.ErrorIs(t, err, sql.ErrInvalidQuery)
+}
@@ -60,27 +60,12 @@ func (s *PostgresRepository) GetAllPartitions(ctx context.Context) ([]*dao.Parti
 	if err != nil {
 		return nil, fmt.Errorf("could not get partitions from DB: %v", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var p dao.PartitionInfo
-		var id string
-		err = rows.Scan(
-			&id,
-			&p.ClusterID,
-			&p.Name,
-			&p.Capacity.Capacity,
-			&p.Capacity.UsedCapacity,
-			&p.Capacity.Utilization,
-			&p.TotalNodes,
-			&p.Applications,
-			&p.TotalContainers,
-			&p.State,
-			&p.LastStateTransitionTime,
-		)
-		partitions = append(partitions, &p)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan partition from DB: %v", err)
-		}
+	err = forEachRow(rows, "partitions", func(row *partitionRow) error {
+		partitions = append(partitions, row.toModel())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return partitions, nil
 }
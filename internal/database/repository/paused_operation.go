@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// PauseOperation stores the pause of the operation and sets its time. If the operation is already paused, the stored
+// pause is kept and returned in op instead.
+func (s *PostgresRepository) PauseOperation(ctx context.Context, op *model.PausedOperation) error {
+	insertSQL := `INSERT INTO paused_operations (name, reason, paused_by, paused_at)
+		VALUES (@name, @reason, @paused_by, @paused_at)
+		ON CONFLICT (name) DO UPDATE SET name = paused_operations.name
+		RETURNING reason, paused_by, paused_at`
+	err := s.dbpool.QueryRow(ctx, insertSQL, pgx.NamedArgs{
+		"name":      op.Name,
+		"reason":    op.Reason,
+		"paused_by": op.PausedBy,
+		"paused_at": time.Now().Unix(),
+	}).Scan(&op.Reason, &op.PausedBy, &op.PausedAt)
+	if err != nil {
+		return fmt.Errorf("could not insert paused operation %s into DB: %v", op.Name, err)
+	}
+	return nil
+}
+
+// ResumeOperation deletes the pause of the operation. Resuming an operation which is not paused has no effect.
+func (s *PostgresRepository) ResumeOperation(ctx context.Context, name string) error {
+	if _, err := s.dbpool.Exec(ctx, "DELETE FROM paused_operations WHERE name = $1", name); err != nil {
+		return fmt.Errorf("could not delete paused operation %s from DB: %v", name, err)
+	}
+	return nil
+}
+
+// GetPausedOperations returns the paused operations ordered by name.
+func (s *PostgresRepository) GetPausedOperations(ctx context.Context) ([]*model.PausedOperation, error) {
+	rows, err := s.dbpool.Query(ctx, "SELECT * FROM paused_operations ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("could not get paused operations from DB: %v", err)
+	}
+	var ops []*model.PausedOperation
+	err = forEachRow(rows, "paused operations", func(op *model.PausedOperation) error {
+		ops = append(ops, op)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestPausedOperations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	ops, err := repo.GetPausedOperations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	retention := &model.PausedOperation{Name: "retention", Reason: "database maintenance", PausedBy: "ops"}
+	require.NoError(t, repo.PauseOperation(ctx, retention))
+	assert.NotZero(t, retention.PausedAt)
+	require.NoError(t, repo.PauseOperation(ctx, &model.PausedOperation{Name: "ingestion", Reason: "", PausedBy: "ops"}))
+
+	// pausing a paused operation keeps its pause
+	again := &model.PausedOperation{Name: "retention", Reason: "corrupted applications", PausedBy: "admin"}
+	require.NoError(t, repo.PauseOperation(ctx, again))
+	assert.Equal(t, retention, again)
+
+	ops, err = repo.GetPausedOperations(ctx)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, "ingestion", ops[0].Name)
+	assert.Equal(t, retention, ops[1])
+
+	require.NoError(t, repo.ResumeOperation(ctx, "retention"))
+	require.NoError(t, repo.ResumeOperation(ctx, "retention"))
+	ops, err = repo.GetPausedOperations(ctx)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, "ingestion", ops[0].Name)
+}
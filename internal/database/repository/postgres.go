@@ -1,15 +1,150 @@
 package repository
 
 import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
 )
 
+// conn is the connection pool of the repository, or the transaction of a repository bound to one.
+// Transactions begun on a transaction are savepoints.
+type conn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// poolConn runs the statements on the connection pool, or in the transaction of their context if it has one, so that
+// the repository reads the snapshot of the transaction.
+type poolConn struct {
+	pool *pgxpool.Pool
+}
+
+func (c poolConn) conn(ctx context.Context) conn {
+	if tx := postgres.TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return c.pool
+}
+
+func (c poolConn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return c.conn(ctx).Exec(ctx, sql, arguments...)
+}
+
+func (c poolConn) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return c.conn(ctx).Query(ctx, sql, args...)
+}
+
+func (c poolConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return c.conn(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (c poolConn) Begin(ctx context.Context) (pgx.Tx, error) {
+	return c.conn(ctx).Begin(ctx)
+}
+
 type PostgresRepository struct {
-	dbpool *pgxpool.Pool
+	dbpool conn
+	// cipher encrypts the user and group columns, which are stored unencrypted if it is nil.
+	cipher *encryption.Cipher
+	// sparkApplicationIDTag is the allocation tag the Spark application ID of applications is taken from.
+	sparkApplicationIDTag string
+	// workflowIDTags are the allocation tags the workflow ID of applications is taken from.
+	workflowIDTags []string
+	// retryKeyTags are the allocation tags the retry key of applications is taken from.
+	retryKeyTags []string
+	// priorityClassTag is the allocation tag the priority class of applications is taken from, if any.
+	priorityClassTag string
+	// coldAfter is the duration after which finished applications are moved to the cold tier, 0 if they are not.
+	coldAfter time.Duration
+	// zoneAttribute is the attribute of the nodes their zone is taken from.
+	zoneAttribute string
+	// rackAttribute is the attribute of the nodes their rack is taken from, their rack name if it is empty.
+	rackAttribute string
 }
 
-func NewPostgresRepository(pool *pgxpool.Pool) (*PostgresRepository, error) {
-	return &PostgresRepository{dbpool: pool}, nil
+type Option func(*PostgresRepository)
+
+// WithCipher sets the cipher used to encrypt the user and group columns at rest.
+func WithCipher(cipher *encryption.Cipher) Option {
+	return func(s *PostgresRepository) {
+		s.cipher = cipher
+	}
+}
+
+// WithSparkApplicationIDTag sets the allocation tag the Spark application ID of applications is taken from,
+// config.DefaultSparkApplicationIDTag by default.
+func WithSparkApplicationIDTag(tag string) Option {
+	return func(s *PostgresRepository) {
+		s.sparkApplicationIDTag = tag
+	}
+}
+
+// WithWorkflowIDTags sets the allocation tags the workflow ID of applications is taken from,
+// config.DefaultWorkflowIDTags by default.
+func WithWorkflowIDTags(tags []string) Option {
+	return func(s *PostgresRepository) {
+		s.workflowIDTags = tags
+	}
+}
+
+// WithRetryKeyTags sets the allocation tags the retry key of applications is taken from,
+// config.DefaultRetryKeyTags by default.
+func WithRetryKeyTags(tags []string) Option {
+	return func(s *PostgresRepository) {
+		s.retryKeyTags = tags
+	}
+}
+
+// WithPriorityClassTag sets the allocation tag the priority class of applications is taken from. Priority classes
+// are not recorded by default.
+func WithPriorityClassTag(tag string) Option {
+	return func(s *PostgresRepository) {
+		s.priorityClassTag = tag
+	}
+}
+
+// WithColdTier selects the applications from the cold tier too when they may have finished the given duration ago,
+// after which they are moved to the cold tier. The cold tier is not selected by default.
+func WithColdTier(after time.Duration) Option {
+	return func(s *PostgresRepository) {
+		s.coldAfter = after
+	}
+}
+
+// WithTopologyAttributes sets the attributes of the nodes their zone and rack are taken from. The zone is taken from
+// config.DefaultZoneAttribute and the rack is the rack name of the nodes by default.
+func WithTopologyAttributes(zone, rack string) Option {
+	return func(s *PostgresRepository) {
+		s.zoneAttribute = zone
+		s.rackAttribute = rack
+	}
+}
+
+func NewPostgresRepository(pool *pgxpool.Pool, opts ...Option) (*PostgresRepository, error) {
+	s := &PostgresRepository{dbpool: poolConn{pool: pool}, sparkApplicationIDTag: config.DefaultSparkApplicationIDTag,
+		workflowIDTags: config.DefaultWorkflowIDTags, retryKeyTags: config.DefaultRetryKeyTags,
+		zoneAttribute: config.DefaultZoneAttribute,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 var _ Repository = &PostgresRepository{}
+
+// withTx returns a copy of the repository whose operations run in the transaction.
+func (s *PostgresRepository) withTx(tx pgx.Tx) *PostgresRepository {
+	txRepository := *s
+	txRepository.dbpool = tx
+	return &txRepository
+}
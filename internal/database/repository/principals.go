@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// PrincipalKind is the kind of principal the applications are summarized per.
+type PrincipalKind string
+
+const (
+	PrincipalUser  PrincipalKind = "user"
+	PrincipalGroup PrincipalKind = "group"
+)
+
+// principalSelects select the principals of the application summaries: the user of an application, or each of its
+// groups.
+var principalSelects = map[PrincipalKind]string{
+	PrincipalUser: `SELECT "user" AS name, partition, submission_time, finished_time, vcore_seconds, memory_seconds
+		FROM application_summary`,
+	PrincipalGroup: `SELECT g.name, partition, submission_time, finished_time, vcore_seconds, memory_seconds
+		FROM application_summary, unnest(groups) AS g(name)`,
+}
+
+// PrincipalFilters select the principals whose name starts with Prefix, ordered by name, optionally only
+// considering the applications of a partition. The runs of the applications in several clusters are merged into
+// their canonical run by the Duplicates strategy, unless it is empty.
+type PrincipalFilters struct {
+	Partition  *string
+	Prefix     *string
+	Duplicates DuplicateStrategy
+	Offset     *int
+	Limit      *int
+}
+
+// GetPrincipals returns the users or groups seen in the applications of both tiers, with the times in nanoseconds
+// an application of them was first submitted and last submitted or finished, and the resources their applications
+// used. As the names may be encrypted at rest, the principals are filtered by prefix, ordered and paginated after
+// they were decrypted.
+func (s *PostgresRepository) GetPrincipals(
+	ctx context.Context, kind PrincipalKind, filters PrincipalFilters) ([]*model.Principal, error) {
+	selectSQL, ok := principalSelects[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown principal kind %q", kind)
+	}
+	if filters.Duplicates != "" {
+		canonical, err := canonicalRun(filters.Duplicates, "application_summary")
+		if err != nil {
+			return nil, err
+		}
+		selectSQL += " WHERE " + canonical
+	}
+	query := `SELECT name, MIN(NULLIF(submission_time, 0)), MAX(GREATEST(NULLIF(submission_time, 0), finished_time)),
+			COUNT(*), SUM(vcore_seconds), SUM(memory_seconds)
+		FROM (` + selectSQL + `) p
+		WHERE name IS NOT NULL AND name <> '' AND ($1::TEXT IS NULL OR partition = $1)
+		GROUP BY name`
+	rows, err := s.dbpool.Query(ctx, query, filters.Partition)
+	if err != nil {
+		return nil, fmt.Errorf("could not get %ss from DB: %v", kind, err)
+	}
+	defer rows.Close()
+
+	// with encryption, the applications of a principal are grouped per key until they are re-encrypted with
+	// the active key
+	byName := make(map[string]*model.Principal)
+	for rows.Next() {
+		var principal model.Principal
+		var firstSeen, lastSeen *int64
+		err := rows.Scan(&principal.Name, &firstSeen, &lastSeen, &principal.Applications, &principal.VcoreSeconds,
+			&principal.MemorySeconds)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan %s from DB: %v", kind, err)
+		}
+		if principal.Name, err = s.cipher.Decrypt(principal.Name); err != nil {
+			return nil, fmt.Errorf("could not decrypt %s: %v", kind, err)
+		}
+		if filters.Prefix != nil && !strings.HasPrefix(principal.Name, *filters.Prefix) {
+			continue
+		}
+		if firstSeen != nil {
+			principal.FirstSeen = *firstSeen
+		}
+		if lastSeen != nil {
+			principal.LastSeen = *lastSeen
+		}
+		merged, ok := byName[principal.Name]
+		if !ok {
+			byName[principal.Name] = &principal
+			continue
+		}
+		if merged.FirstSeen == 0 || (principal.FirstSeen != 0 && principal.FirstSeen < merged.FirstSeen) {
+			merged.FirstSeen = principal.FirstSeen
+		}
+		merged.LastSeen = max(merged.LastSeen, principal.LastSeen)
+		merged.Applications += principal.Applications
+		merged.VcoreSeconds += principal.VcoreSeconds
+		merged.MemorySeconds += principal.MemorySeconds
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read %ss from DB: %v", kind, err)
+	}
+
+	principals := make([]*model.Principal, 0, len(byName))
+	for _, principal := range byName {
+		principals = append(principals, principal)
+	}
+	sort.Slice(principals, func(i, j int) bool {
+		return principals[i].Name < principals[j].Name
+	})
+	return paginate(principals, filters.Offset, filters.Limit), nil
+}
+
+// paginate returns the page of the items starting at offset with at most limit items.
+func paginate[T any](items []T, offset, limit *int) []T {
+	if offset != nil {
+		items = items[min(*offset, len(items)):]
+	}
+	if limit != nil {
+		items = items[:min(*limit, len(items))]
+	}
+	return items
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestGetPrincipals_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	users, err := repo.GetPrincipals(ctx, PrincipalUser, PrincipalFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user1", "user2", "user3"}, principalNames(users))
+	assert.Equal(t, int64(3), users[0].Applications)
+	assert.Positive(t, users[0].FirstSeen)
+	assert.Less(t, users[0].FirstSeen, users[0].LastSeen)
+
+	users, err = repo.GetPrincipals(ctx, PrincipalUser, PrincipalFilters{
+		Prefix: util.ToPtr("user"),
+		Offset: util.ToPtr(1),
+		Limit:  util.ToPtr(1),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2"}, principalNames(users))
+
+	groups, err := repo.GetPrincipals(ctx, PrincipalGroup, PrincipalFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"group1", "group2", "group3"}, principalNames(groups))
+	assert.Equal(t, int64(3), groups[0].Applications)
+
+	groups, err = repo.GetPrincipals(ctx, PrincipalGroup, PrincipalFilters{Partition: util.ToPtr("gpu")})
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func principalNames(principals []*model.Principal) []string {
+	names := make([]string, 0, len(principals))
+	for _, principal := range principals {
+		names = append(names, principal.Name)
+	}
+	return names
+}
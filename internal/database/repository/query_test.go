@@ -0,0 +1,524 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the SQL queries")
+
+// assertGoldenQuery compares the query and arguments built by the builder with testdata/golden/<name>.sql,
+// which holds the query followed by a comment per positional argument, so that query changes are readable in review.
+// Run the tests with -update to write the golden files after an intended change of a query.
+func assertGoldenQuery(t *testing.T, name string, builder *sql.Builder) {
+	t.Helper()
+	query, args, err := builder.Build()
+	require.NoError(t, err)
+	var got strings.Builder
+	got.WriteString(query + "\n")
+	for i, arg := range args {
+		value, err := json.Marshal(arg)
+		require.NoError(t, err)
+		fmt.Fprintf(&got, "-- $%d: %s\n", i+1, value)
+	}
+
+	path := filepath.Join("testdata", "golden", name+".sql")
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got.String()), 0o600))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run the tests with -update to create the golden file")
+	assert.Equal(t, string(want), got.String())
+}
+
+var (
+	goldenStart = time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	goldenEnd   = time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+)
+
+func TestApplicationQueries(t *testing.T) {
+	cipher, err := encryption.New(&config.EncryptionConfig{
+		Keys: map[string]string{
+			"k1": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			"k2": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=",
+		},
+		ActiveKey: "k2",
+	})
+	require.NoError(t, err)
+
+	allFilters := ApplicationFilters{
+		SubmissionStartTime: &goldenStart,
+		SubmissionEndTime:   &goldenEnd,
+		FinishedStartTime:   &goldenStart,
+		FinishedEndTime:     &goldenEnd,
+		User:                util.ToPtr("john"),
+		Groups:              []string{"admin", "dev"},
+		Offset:              util.ToPtr(20),
+		Limit:               util.ToPtr(10),
+	}
+	tests := map[string]*sql.Builder{
+		"all_applications":             allApplicationsQuery(applicationsTable, ApplicationFilters{}, nil),
+		"all_applications_all_filters": allApplicationsQuery(applicationsTable, allFilters, nil),
+		"apps_per_queue":               appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{}, nil),
+		"apps_per_queue_all_filters":   appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", allFilters, nil),
+		"apps_per_queue_submission_range": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			SubmissionStartTime: &goldenStart,
+			SubmissionEndTime:   &goldenEnd,
+		}, nil),
+		"apps_per_queue_finished_range": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			FinishedStartTime: &goldenStart,
+			FinishedEndTime:   &goldenEnd,
+		}, nil),
+		"apps_per_queue_user_and_groups": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			User:   util.ToPtr("john"),
+			Groups: []string{"admin", "dev"},
+		}, nil),
+		"apps_per_queue_pagination": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			Offset: util.ToPtr(20),
+			Limit:  util.ToPtr(10),
+		}, nil),
+		"apps_per_queue_cursor": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			OrderBy: OrderByDuration,
+			After:   &Cursor{Keys: []int64{3600000000000, 1719792000000000000}, ID: "app-1"},
+			Limit:   util.ToPtr(11),
+		}, nil),
+		"apps_per_queue_encrypted": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			User:   util.ToPtr("john"),
+			Groups: []string{"admin"},
+		}, cipher),
+		"apps_per_queue_application_id": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			ApplicationID: util.ToPtr("spark-pi-1"),
+		}, nil),
+		"all_applications_spark_application_id": allApplicationsQuery(applicationsTable, ApplicationFilters{
+			SparkApplicationID: util.ToPtr("spark-0123456789abcdef"),
+		}, nil),
+		"all_applications_workflow_id": allApplicationsQuery(applicationsTable, ApplicationFilters{
+			WorkflowID: util.ToPtr("daily_etl:scheduled__2024-07-01"),
+			Limit:      util.ToPtr(10),
+		}, nil),
+		"all_applications_cluster": allApplicationsQuery(applicationsTable, ApplicationFilters{
+			Cluster: util.ToPtr("eu-west"),
+		}, nil),
+		"all_applications_default_cluster": allApplicationsQuery(applicationsTable, ApplicationFilters{
+			Cluster: util.ToPtr(""),
+		}, nil),
+		"all_applications_tiers": allApplicationsQuery(applicationTiersTable, ApplicationFilters{
+			SubmissionStartTime: &goldenStart,
+			Limit:               util.ToPtr(10),
+		}, nil),
+		"apps_per_queue_tiers": appsPerPartitionPerQueueQuery(applicationTiersTable, "default", "root.default",
+			ApplicationFilters{FinishedStartTime: &goldenStart}, nil),
+		"apps_per_queue_duration": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			MinDuration: util.ToPtr(time.Hour),
+			MaxDuration: util.ToPtr(24 * time.Hour),
+			OrderBy:     OrderByDuration,
+			Limit:       util.ToPtr(10),
+		}, nil),
+		"all_applications_not_finished_before": allApplicationsQuery(applicationsTable, ApplicationFilters{
+			SubmissionEndTime: &goldenEnd,
+			NotFinishedBefore: &goldenStart,
+		}, nil),
+		"all_applications_wait_time": allApplicationsQuery(applicationsTable, ApplicationFilters{
+			MinWaitTime: util.ToPtr(time.Minute),
+			MaxWaitTime: util.ToPtr(time.Hour),
+			OrderBy:     OrderByWaitTime,
+		}, nil),
+		"apps_per_queue_lineage": appsPerPartitionPerQueueQuery(applicationsTable, "default", "root.default", ApplicationFilters{
+			FormerQueues: []string{"root.legacy", "root.old.default"},
+		}, nil),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, builder)
+		})
+	}
+}
+
+func TestApplicationSummaryQueries(t *testing.T) {
+	tests := map[string]ApplicationFilters{
+		"application_summaries": {},
+		"application_summaries_all_filters": {
+			SubmissionStartTime: &goldenStart,
+			SubmissionEndTime:   &goldenEnd,
+			FinishedStartTime:   &goldenStart,
+			FinishedEndTime:     &goldenEnd,
+			User:                util.ToPtr("john"),
+			Groups:              []string{"admin", "dev"},
+			Offset:              util.ToPtr(20),
+			Limit:               util.ToPtr(10),
+		},
+		"application_summaries_duration": {
+			MinDuration: util.ToPtr(time.Hour),
+			MaxWaitTime: util.ToPtr(time.Minute),
+			OrderBy:     OrderByDuration,
+		},
+		"application_summaries_lineage": {FormerQueues: []string{"root.legacy"}},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, applicationSummariesQuery("default", "root.default", filters, nil))
+		})
+	}
+}
+
+func TestLegalHoldQueries(t *testing.T) {
+	tests := map[string]LegalHoldFilters{
+		"legal_holds":                    {},
+		"legal_holds_active":             {Partition: util.ToPtr("default"), Active: util.ToPtr(true)},
+		"legal_holds_released_paginated": {Active: util.ToPtr(false), Offset: util.ToPtr(20), Limit: util.ToPtr(10)},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, legalHoldsQuery(filters))
+		})
+	}
+}
+
+func TestQueueLineageQueries(t *testing.T) {
+	tests := map[string]*string{
+		"queue_lineage":           nil,
+		"queue_lineage_partition": util.ToPtr("default"),
+	}
+	for name, partition := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, queueLineageQuery(partition))
+		})
+	}
+}
+
+func TestQueueACLQueries(t *testing.T) {
+	tests := map[string]QueueACLFilters{
+		"queue_acls":        {},
+		"queue_acls_period": {Start: &goldenStart, End: &goldenEnd},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, queueACLsQuery("default", "root.analytics", filters))
+		})
+	}
+}
+
+func TestNamespaceQueueQueries(t *testing.T) {
+	tests := map[string]NamespaceQueueFilters{
+		"namespace_queues": {},
+		"namespace_queues_all_filters": {
+			Namespace: util.ToPtr("team-a"),
+			QueueName: util.ToPtr("root.team-a"),
+			Start:     &goldenStart,
+			End:       &goldenEnd,
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, namespaceQueuesQuery("default", filters))
+		})
+	}
+}
+
+func TestTraceEventQueries(t *testing.T) {
+	tests := map[string]TraceEventFilters{
+		"trace_events": {},
+		"trace_events_all_filters": {
+			Start:  &goldenStart,
+			End:    &goldenEnd,
+			Offset: util.ToPtr(10),
+			Limit:  util.ToPtr(100),
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, traceEventsQuery("app-1", filters))
+		})
+	}
+}
+
+func TestSchedulerEpochQueries(t *testing.T) {
+	tests := map[string]SchedulerEpochFilters{
+		"scheduler_epochs":             {},
+		"scheduler_epochs_all_filters": {Cluster: util.ToPtr("eu-west"), Start: &goldenStart, End: &goldenEnd},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, schedulerEpochsQuery(filters))
+		})
+	}
+}
+
+func TestNodeTerminationQueries(t *testing.T) {
+	tests := map[string]NodeTerminationFilters{
+		"node_terminations": {},
+		"node_terminations_all_filters": {
+			Partition: util.ToPtr("default"), Start: &goldenStart, End: &goldenEnd, SpotOnly: true,
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, nodeTerminationsQuery(filters))
+		})
+	}
+}
+
+func TestAutoscalerQueries(t *testing.T) {
+	events := map[string]AutoscalerEventFilters{
+		"autoscaler_events":             {},
+		"autoscaler_events_all_filters": {Start: &goldenStart, End: &goldenEnd},
+	}
+	for name, filters := range events {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, autoscalerEventsQuery(filters))
+		})
+	}
+	backlog := map[string]PendingBacklogFilters{
+		"pending_backlog":             {Partition: "default"},
+		"pending_backlog_all_filters": {Partition: "default", Start: &goldenStart, End: &goldenEnd},
+	}
+	for name, filters := range backlog {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, pendingBacklogQuery(filters))
+		})
+	}
+}
+
+func TestSLOEvaluationsQueries(t *testing.T) {
+	tests := map[string]SLOEvaluationFilters{
+		"slo_evaluations":             {Name: "analytics-start"},
+		"slo_evaluations_all_filters": {Name: "analytics-start", Start: &goldenStart, End: &goldenEnd},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, sloEvaluationsQuery(filters))
+		})
+	}
+}
+
+func TestAnalyticsQueries(t *testing.T) {
+	cipher, err := encryption.New(&config.EncryptionConfig{
+		Keys:      map[string]string{"k1": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+		ActiveKey: "k1",
+	})
+	require.NoError(t, err)
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+
+	tests := map[string]AnalyticsQuery{
+		"analytics_count": {
+			Entity:     "applications",
+			Aggregates: []AnalyticsAggregate{{Function: sql.Count}},
+			Limit:      100,
+		},
+		"analytics_count_merging_duplicates": {
+			Entity:     "applications",
+			Aggregates: []AnalyticsAggregate{{Function: sql.Count}},
+			Limit:      100,
+			Duplicates: DuplicateLatest,
+		},
+		"analytics_per_queue_and_day": {
+			Entity: "applications",
+			Filters: []AnalyticsFilter{
+				{Field: "partition", Operator: sql.Equal, Values: []any{"default"}},
+				{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{goldenStart}},
+				{Field: "applicationState", Operator: sql.Equal, Values: []any{"Completed", "Failed"}},
+				{Field: "user", Operator: sql.Equal, Values: []any{"john"}},
+			},
+			GroupBy: []string{"queueName", "hasReserved"},
+			TimeBucket: &AnalyticsTimeBucket{
+				Field:    "submissionTime",
+				Bucket:   sql.Day,
+				Location: amsterdam,
+			},
+			Aggregates: []AnalyticsAggregate{
+				{Function: sql.Count},
+				{Function: sql.Average, Field: "maxRequestPriority"},
+				{Function: sql.Maximum, Field: "finishedTime"},
+			},
+			Limit: 1001,
+		},
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := analyticsQuery(query, cipher, false)
+			require.NoError(t, err)
+			assertGoldenQuery(t, name, builder)
+		})
+	}
+	t.Run("analytics_count_tiers", func(t *testing.T) {
+		builder, err := analyticsQuery(tests["analytics_count"], cipher, true)
+		require.NoError(t, err)
+		assertGoldenQuery(t, "analytics_count_tiers", builder)
+	})
+}
+
+func TestInvalidAnalyticsQueries(t *testing.T) {
+	count := []AnalyticsAggregate{{Function: sql.Count}}
+	tests := map[string]AnalyticsQuery{
+		"unknown entity":  {Entity: "secrets", Aggregates: count},
+		"unknown field":   {Entity: "applications", GroupBy: []string{"password"}, Aggregates: count},
+		"column name":     {Entity: "applications", GroupBy: []string{"queue_name"}, Aggregates: count},
+		"no aggregates":   {Entity: "applications", GroupBy: []string{"queueName"}},
+		"group by time":   {Entity: "applications", GroupBy: []string{"submissionTime"}, Aggregates: count},
+		"sum of a string": {Entity: "applications", Aggregates: []AnalyticsAggregate{{Function: sql.Sum, Field: "user"}}},
+		"bucket of a string": {
+			Entity:     "applications",
+			TimeBucket: &AnalyticsTimeBucket{Field: "queueName", Bucket: sql.Day},
+			Aggregates: count,
+		},
+		"unknown bucket": {
+			Entity:     "applications",
+			TimeBucket: &AnalyticsTimeBucket{Field: "submissionTime", Bucket: "fortnight"},
+			Aggregates: count,
+		},
+		"unknown function":           {Entity: "applications", Aggregates: []AnalyticsAggregate{{Function: "pg_sleep"}}},
+		"unknown duplicate strategy": {Entity: "applications", Aggregates: count, Duplicates: "any"},
+		"wrong value type": {
+			Entity:     "applications",
+			Filters:    []AnalyticsFilter{{Field: "maxRequestPriority", Operator: sql.Equal, Values: []any{"1"}}},
+			Aggregates: count,
+		},
+		"no values": {
+			Entity:     "applications",
+			Filters:    []AnalyticsFilter{{Field: "queueName", Operator: sql.Equal}},
+			Aggregates: count,
+		},
+		"range of values": {
+			Entity:     "applications",
+			Filters:    []AnalyticsFilter{{Field: "queueName", Operator: sql.LessThan, Values: []any{"a", "b"}}},
+			Aggregates: count,
+		},
+		"range of encrypted field": {
+			Entity:     "applications",
+			Filters:    []AnalyticsFilter{{Field: "user", Operator: sql.GreaterThan, Values: []any{"a"}}},
+			Aggregates: count,
+		},
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := analyticsQuery(query, nil, false)
+			if err == nil {
+				_, _, err = builder.Build()
+			}
+			assert.True(t, errors.Is(err, ErrInvalidAnalyticsQuery) || errors.Is(err, sql.ErrInvalidQuery), "error: %v", err)
+		})
+	}
+}
+
+func TestThroughputQueries(t *testing.T) {
+	tests := map[string]ThroughputFilters{
+		"throughput": {Start: goldenStart, End: goldenEnd, Interval: time.Hour},
+		"throughput_queue_per_day": {
+			Partition: util.ToPtr("default"),
+			Queue:     util.ToPtr("root.analytics"),
+			Start:     goldenStart,
+			End:       goldenEnd,
+			Interval:  24 * time.Hour,
+		},
+		"throughput_queue_lineage": {
+			Partition:    util.ToPtr("default"),
+			Queue:        util.ToPtr("root.analytics"),
+			FormerQueues: []string{"root.reporting"},
+			Start:        goldenStart,
+			End:          goldenEnd,
+			Interval:     time.Hour,
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := throughputQuery(filters)
+			require.NoError(t, err)
+			assertGoldenQuery(t, name, builder)
+		})
+	}
+}
+
+func TestInvalidThroughputQueries(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Hour, 30 * time.Minute, 90 * time.Minute} {
+		_, err := throughputQuery(ThroughputFilters{Start: goldenStart, End: goldenEnd, Interval: interval})
+		assert.ErrorIs(t, err, sql.ErrInvalidQuery, "interval %s", interval)
+	}
+}
+
+func TestTopUsageQueries(t *testing.T) {
+	tests := map[string]TopUsageFilters{
+		"top_usage_vcore_seconds_by_user": {Metric: VcoreSeconds, By: ByUser, Start: goldenStart, End: goldenEnd, K: 20},
+		"top_usage_wait_time_by_app_in_partition": {
+			Metric:    WaitTime,
+			By:        ByApplication,
+			Partition: util.ToPtr("default"),
+			Start:     goldenStart,
+			End:       goldenEnd,
+			K:         5,
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := topUsageQuery(filters)
+			require.NoError(t, err)
+			assertGoldenQuery(t, name, builder)
+		})
+	}
+}
+
+func TestHourlyUsageQueries(t *testing.T) {
+	tests := map[string]HourlyUsageFilters{
+		"hourly_usage": {Start: goldenStart, End: goldenEnd},
+		"hourly_usage_queue": {
+			Partition: util.ToPtr("default"),
+			Queue:     util.ToPtr("root.analytics"),
+			Start:     goldenStart,
+			End:       goldenEnd,
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, hourlyUsageQuery(filters))
+		})
+	}
+}
+
+func TestInvalidTopUsageQueries(t *testing.T) {
+	tests := map[string]TopUsageFilters{
+		"unknown metric":   {Metric: "gpu_seconds", By: ByUser, K: 20},
+		"unknown grouping": {Metric: VcoreSeconds, By: "node", K: 20},
+		"negative k":       {Metric: VcoreSeconds, By: ByUser, K: -1},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := topUsageQuery(filters)
+			if err == nil {
+				_, _, err = builder.Build()
+			}
+			assert.ErrorIs(t, err, sql.ErrInvalidQuery)
+		})
+	}
+}
+
+func TestDataQualityViolationsQueries(t *testing.T) {
+	tests := map[string]DataQualityFilters{
+		"data_quality_violations": {},
+		"data_quality_violations_of_check": {
+			Check:  util.ToPtr("unknown_nodes"),
+			Offset: util.ToPtr(100),
+			Limit:  util.ToPtr(50),
+		},
+	}
+	for name, filters := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertGoldenQuery(t, name, dataQualityViolationsQuery(filters))
+		})
+	}
+}
@@ -84,6 +84,22 @@ func (s *PostgresRepository) UpsertQueues(ctx context.Context, queues []*dao.Par
 		if err != nil {
 			return fmt.Errorf("could not insert/update queue into DB: %v", err)
 		}
+		if q.Parent == "" {
+			if err := s.addPendingBacklogSample(ctx, q); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addPendingBacklogSample samples the pending resources of the partition of a root queue.
+func (s *PostgresRepository) addPendingBacklogSample(ctx context.Context, root *dao.PartitionQueueDAOInfo) error {
+	insertSQL := `INSERT INTO pending_backlog (partition, sampled_at, pending_resource) VALUES ($1, $2, $3)
+		ON CONFLICT (partition, sampled_at) DO NOTHING`
+	_, err := s.dbpool.Exec(ctx, insertSQL, root.Partition, time.Now().UnixNano(), root.PendingResource)
+	if err != nil {
+		return fmt.Errorf("could not insert pending backlog sample into DB: %v", err)
 	}
 	return nil
 }
@@ -169,38 +185,12 @@ func (s *PostgresRepository) GetAllQueues(ctx context.Context) ([]*model.Partiti
 	if err != nil {
 		return nil, fmt.Errorf("could not get queues from DB: %v", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var q model.PartitionQueueDAOInfo
-		err = rows.Scan(
-			&q.Id,
-			&q.ParentId,
-			&q.CreatedAt,
-			&q.DeletedAt,
-			&q.QueueName,
-			&q.Status,
-			&q.Partition,
-			&q.PendingResource,
-			&q.MaxResource,
-			&q.GuaranteedResource,
-			&q.AllocatedResource,
-			&q.PreemptingResource,
-			&q.HeadRoom,
-			&q.IsLeaf,
-			&q.IsManaged,
-			&q.Properties,
-			&q.Parent,
-			&q.TemplateInfo,
-			&q.AbsUsedCapacity,
-			&q.MaxRunningApps,
-			&q.RunningApps,
-			&q.CurrentPriority,
-			&q.AllocatingAcceptedApps,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan queue from DB: %v", err)
-		}
-		queues = append(queues, &q)
+	err = forEachRow(rows, "queues", func(row *queueRow) error {
+		queues = append(queues, row.toModel())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return queues, nil
 }
@@ -220,42 +210,17 @@ func (s *PostgresRepository) GetQueuesPerPartition(
 	if err != nil {
 		return nil, fmt.Errorf("could not get queues from DB: %v", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var q model.PartitionQueueDAOInfo
-		err = rows.Scan(
-			&q.Id,
-			&q.ParentId,
-			&q.CreatedAt,
-			&q.DeletedAt,
-			&q.QueueName,
-			&q.Status,
-			&q.Partition,
-			&q.PendingResource,
-			&q.MaxResource,
-			&q.GuaranteedResource,
-			&q.AllocatedResource,
-			&q.PreemptingResource,
-			&q.HeadRoom,
-			&q.IsLeaf,
-			&q.IsManaged,
-			&q.Properties,
-			&q.Parent,
-			&q.TemplateInfo,
-			&q.AbsUsedCapacity,
-			&q.MaxRunningApps,
-			&q.RunningApps,
-			&q.CurrentPriority,
-			&q.AllocatingAcceptedApps,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan queue from DB: %v", err)
-		}
+	err = forEachRow(rows, "queues", func(row *queueRow) error {
+		q := row.toModel()
 		if q.ParentId.Valid {
-			childrenMap[q.ParentId.String] = append(childrenMap[q.ParentId.String], &q)
+			childrenMap[q.ParentId.String] = append(childrenMap[q.ParentId.String], q)
 		} else {
-			queues = append(queues, &q)
+			queues = append(queues, q)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	for _, queue := range queues {
 		queue.Children = getChildrenFromMap(queue.Id, childrenMap)
@@ -291,52 +256,24 @@ func (s *PostgresRepository) GetQueue(ctx context.Context, partition, queueName
 	if err != nil {
 		return nil, fmt.Errorf("could not get queues from DB: %v", err)
 	}
-	defer rows.Close()
 
 	// Initialize map to track parent-child relationships
 	childrenMap := make(map[string][]*model.PartitionQueueDAOInfo)
 	var rootQueue *model.PartitionQueueDAOInfo
 
-	for rows.Next() {
-		var q model.PartitionQueueDAOInfo
-		var generationNumber int
-		err = rows.Scan(
-			&q.Id,
-			&q.ParentId,
-			&q.CreatedAt,
-			&q.DeletedAt,
-			&q.QueueName,
-			&q.Status,
-			&q.Partition,
-			&q.PendingResource,
-			&q.MaxResource,
-			&q.GuaranteedResource,
-			&q.AllocatedResource,
-			&q.PreemptingResource,
-			&q.HeadRoom,
-			&q.IsLeaf,
-			&q.IsManaged,
-			&q.Properties,
-			&q.Parent,
-			&q.TemplateInfo,
-			&q.AbsUsedCapacity,
-			&q.MaxRunningApps,
-			&q.RunningApps,
-			&q.CurrentPriority,
-			&q.AllocatingAcceptedApps,
-			&generationNumber,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("could not scan queue from DB: %v", err)
-		}
-
+	err = forEachRow(rows, "queues", func(row *queueGenerationRow) error {
+		q := row.toModel()
 		// Track the root queue for the current query
-		if rootQueue == nil && generationNumber == 0 {
-			rootQueue = &q
+		if rootQueue == nil && row.GenerationNumber == 0 {
+			rootQueue = q
 		} else if q.ParentId.Valid {
 			// Otherwise, add the queue to the children map
-			childrenMap[q.ParentId.String] = append(childrenMap[q.ParentId.String], &q)
+			childrenMap[q.ParentId.String] = append(childrenMap[q.ParentId.String], q)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if rootQueue == nil {
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// QueueACLFilters select the ACL snapshots of a queue which were valid at any time of a period.
+type QueueACLFilters struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// UpdateQueueACLs records the ACLs of the queues of the scheduler configuration read at the given time.
+// The current snapshot of a queue is only replaced if its ACLs changed, and the current snapshots of queues
+// which are no longer configured are closed, so that the snapshots cover the periods the ACLs were in effect.
+// Only the snapshots of the partitions of the cluster of the context are closed, as the configuration is of the
+// scheduler of that cluster.
+func (s *PostgresRepository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	return pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT * FROM queue_acls WHERE valid_to IS NULL FOR UPDATE")
+		if err != nil {
+			return fmt.Errorf("could not get queue ACLs from DB: %v", err)
+		}
+		current := make(map[[2]string]*model.QueueACL)
+		clusterID := cluster.IDFromContext(ctx)
+		err = forEachRow(rows, "queue ACLs", func(acl *model.QueueACL) error {
+			if id, _ := cluster.Split(acl.Partition); id != clusterID {
+				return nil
+			}
+			if err := s.decryptQueueACL(acl); err != nil {
+				return err
+			}
+			current[[2]string{acl.Partition, acl.QueueName}] = acl
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		closeSQL := "UPDATE queue_acls SET valid_to = $2 WHERE id = $1"
+		insertSQL := `INSERT INTO queue_acls (partition, queue_name, submit_acl, admin_acl, submit_users, submit_groups,
+			admin_users, admin_groups, valid_from)
+			VALUES (@partition, @queue_name, @submit_acl, @admin_acl, @submit_users, @submit_groups,
+			@admin_users, @admin_groups, @valid_from)`
+		for _, acl := range acls {
+			key := [2]string{acl.Partition, acl.QueueName}
+			if previous, ok := current[key]; ok {
+				delete(current, key)
+				if sameQueueACLs(previous, acl) {
+					continue
+				}
+				if _, err := tx.Exec(ctx, closeSQL, previous.ID, at.Unix()); err != nil {
+					return fmt.Errorf("could not close ACLs of queue %s in DB: %v", acl.QueueName, err)
+				}
+			}
+			_, err := tx.Exec(ctx, insertSQL, pgx.NamedArgs{
+				"partition":     acl.Partition,
+				"queue_name":    acl.QueueName,
+				"submit_acl":    s.cipher.Encrypt(acl.SubmitACL),
+				"admin_acl":     s.cipher.Encrypt(acl.AdminACL),
+				"submit_users":  s.cipher.EncryptAll(nonNil(acl.SubmitUsers)),
+				"submit_groups": s.cipher.EncryptAll(nonNil(acl.SubmitGroups)),
+				"admin_users":   s.cipher.EncryptAll(nonNil(acl.AdminUsers)),
+				"admin_groups":  s.cipher.EncryptAll(nonNil(acl.AdminGroups)),
+				"valid_from":    at.Unix(),
+			})
+			if err != nil {
+				return fmt.Errorf("could not insert ACLs of queue %s into DB: %v", acl.QueueName, err)
+			}
+		}
+		// the remaining queues were removed from the configuration
+		for _, previous := range current {
+			if _, err := tx.Exec(ctx, closeSQL, previous.ID, at.Unix()); err != nil {
+				return fmt.Errorf("could not close ACLs of queue %s in DB: %v", previous.QueueName, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetQueueACLs returns the ACL snapshots of the queue, most recent first.
+func (s *PostgresRepository) GetQueueACLs(
+	ctx context.Context, partition, queue string, filters QueueACLFilters) ([]*model.QueueACL, error) {
+	query, args, err := queueACLsQuery(partition, queue, filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get queue ACLs from DB: %v", err)
+	}
+
+	var acls []*model.QueueACL
+	err = forEachRow(rows, "queue ACLs", func(acl *model.QueueACL) error {
+		if err := s.decryptQueueACL(acl); err != nil {
+			return err
+		}
+		acls = append(acls, acl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acls, nil
+}
+
+// queueACLsQuery builds the query of GetQueueACLs. A snapshot is selected if it was valid at any time of the period.
+func queueACLsQuery(partition, queue string, filters QueueACLFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(queueACLsTable, "").
+		Conditionp("partition", sql.Equal, partition).
+		Conditionp("queue_name", sql.Equal, queue).
+		OrderBy("valid_from", sql.OrderByDescending)
+	if filters.End != nil {
+		queryBuilder.Conditionp("valid_from", sql.LessThanOrEqual, filters.End.Unix())
+	}
+	if filters.Start != nil {
+		queryBuilder.ConditionNullOrp("valid_to", sql.GreaterThan, filters.Start.Unix())
+	}
+	return queryBuilder
+}
+
+// decryptQueueACL decrypts the ACLs and the users and groups of the snapshot.
+func (s *PostgresRepository) decryptQueueACL(acl *model.QueueACL) error {
+	var err error
+	for _, value := range []*string{&acl.SubmitACL, &acl.AdminACL} {
+		if *value, err = s.cipher.Decrypt(*value); err != nil {
+			return fmt.Errorf("could not decrypt ACLs of queue %s: %v", acl.QueueName, err)
+		}
+	}
+	for _, values := range []*[]string{&acl.SubmitUsers, &acl.SubmitGroups, &acl.AdminUsers, &acl.AdminGroups} {
+		if *values, err = s.cipher.DecryptAll(*values); err != nil {
+			return fmt.Errorf("could not decrypt ACLs of queue %s: %v", acl.QueueName, err)
+		}
+	}
+	return nil
+}
+
+// sameQueueACLs returns whether the snapshots grant the same access to the queue.
+func sameQueueACLs(a, b *model.QueueACL) bool {
+	return a.SubmitACL == b.SubmitACL && a.AdminACL == b.AdminACL &&
+		slices.Equal(nonNil(a.SubmitUsers), nonNil(b.SubmitUsers)) &&
+		slices.Equal(nonNil(a.SubmitGroups), nonNil(b.SubmitGroups)) &&
+		slices.Equal(nonNil(a.AdminUsers), nonNil(b.AdminUsers)) &&
+		slices.Equal(nonNil(a.AdminGroups), nonNil(b.AdminGroups))
+}
+
+// nonNil returns an empty slice instead of nil, for the NOT NULL array columns.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
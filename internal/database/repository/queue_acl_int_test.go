@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestQueueACLs_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	analytics := func(submitACL string, users ...string) *model.QueueACL {
+		return &model.QueueACL{Partition: "default", QueueName: "root.analytics", SubmitACL: submitACL, SubmitUsers: users}
+	}
+	scratch := &model.QueueACL{Partition: "default", QueueName: "root.scratch", SubmitACL: "*", SubmitUsers: []string{"*"}}
+	t1 := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	require.NoError(t, repo.UpdateQueueACLs(ctx, []*model.QueueACL{analytics("alice", "alice"), scratch}, t1))
+	// unchanged ACLs keep their snapshot
+	require.NoError(t, repo.UpdateQueueACLs(ctx, []*model.QueueACL{analytics("alice", "alice"), scratch}, t2))
+	// changed ACLs replace the snapshot, and the snapshot of a removed queue is closed
+	require.NoError(t, repo.UpdateQueueACLs(ctx, []*model.QueueACL{analytics("alice,bob", "alice", "bob")}, t3))
+
+	acls, err := repo.GetQueueACLs(ctx, "default", "root.analytics", QueueACLFilters{})
+	require.NoError(t, err)
+	require.Len(t, acls, 2)
+	assert.Equal(t, []string{"alice", "bob"}, acls[0].SubmitUsers)
+	assert.Equal(t, t3.Unix(), acls[0].ValidFrom)
+	assert.Nil(t, acls[0].ValidTo)
+	assert.Equal(t, []string{"alice"}, acls[1].SubmitUsers)
+	assert.Equal(t, t1.Unix(), acls[1].ValidFrom)
+	require.NotNil(t, acls[1].ValidTo)
+	assert.Equal(t, t3.Unix(), *acls[1].ValidTo)
+
+	// who could submit during the second hour
+	start, end := t2, t2.Add(30*time.Minute)
+	acls, err = repo.GetQueueACLs(ctx, "default", "root.analytics", QueueACLFilters{Start: &start, End: &end})
+	require.NoError(t, err)
+	require.Len(t, acls, 1)
+	assert.Equal(t, "alice", acls[0].SubmitACL)
+
+	acls, err = repo.GetQueueACLs(ctx, "default", "root.scratch", QueueACLFilters{})
+	require.NoError(t, err)
+	require.Len(t, acls, 1)
+	require.NotNil(t, acls[0].ValidTo)
+	assert.Equal(t, t3.Unix(), *acls[0].ValidTo)
+}
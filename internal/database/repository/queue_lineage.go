@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+var (
+	ErrQueueLineageNotFound = errors.New("queue lineage not found")
+	ErrQueueLineageExists   = errors.New("queue lineage already exists")
+)
+
+// AddQueueLineage stores the link from the former path of a queue to its current path and sets its ID and creation
+// time. It returns ErrQueueLineageExists if the former path of the partition is already linked.
+func (s *PostgresRepository) AddQueueLineage(ctx context.Context, link *model.QueueLineage) error {
+	insertSQL := `INSERT INTO queue_lineage (partition, from_queue, to_queue, reason, created_by, created_at)
+		VALUES (@partition, @from_queue, @to_queue, @reason, @created_by, @created_at)
+		ON CONFLICT (partition, from_queue) DO NOTHING
+		RETURNING id, created_at`
+	err := s.dbpool.QueryRow(ctx, insertSQL, pgx.NamedArgs{
+		"partition":  link.Partition,
+		"from_queue": link.FromQueue,
+		"to_queue":   link.ToQueue,
+		"reason":     link.Reason,
+		"created_by": link.CreatedBy,
+		"created_at": time.Now().Unix(),
+	}).Scan(&link.ID, &link.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s in partition %s", ErrQueueLineageExists, link.FromQueue, link.Partition)
+	}
+	if err != nil {
+		return fmt.Errorf("could not insert queue lineage into DB: %v", err)
+	}
+	return nil
+}
+
+// GetQueueLineage returns the queue lineage links of the partition, or of all partitions if partition is nil,
+// ordered by creation time.
+func (s *PostgresRepository) GetQueueLineage(ctx context.Context, partition *string) ([]*model.QueueLineage, error) {
+	query, args, err := queueLineageQuery(partition).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get queue lineage from DB: %v", err)
+	}
+
+	var links []*model.QueueLineage
+	err = forEachRow(rows, "queue lineage", func(link *model.QueueLineage) error {
+		links = append(links, link)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// queueLineageQuery builds the query of GetQueueLineage.
+func queueLineageQuery(partition *string) *sql.Builder {
+	queryBuilder := sql.NewBuilder().SelectAll(queueLineageTable, "").OrderBy("created_at", sql.OrderByAscending)
+	if partition != nil {
+		queryBuilder.Conditionp("partition", sql.Equal, *partition)
+	}
+	return queryBuilder
+}
+
+// DeleteQueueLineage deletes the queue lineage link with the given ID, so that the history of the former path
+// is no longer reachable under the current path. It returns ErrQueueLineageNotFound if there is no such link.
+func (s *PostgresRepository) DeleteQueueLineage(ctx context.Context, id string) error {
+	tag, err := s.dbpool.Exec(ctx, "DELETE FROM queue_lineage WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("could not delete queue lineage from DB: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrQueueLineageNotFound, id)
+	}
+	return nil
+}
+
+// conditionQueue adds the condition on the queue of the applications, which matches the former paths of the queue
+// as well if there are any.
+func conditionQueue(queryBuilder *sql.Builder, queue string, formerQueues []string) *sql.Builder {
+	if len(formerQueues) == 0 {
+		return queryBuilder.Conditionp("queue_name", sql.Equal, queue)
+	}
+	return queryBuilder.ConditionAny("queue_name", append([]string{queue}, formerQueues...))
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestQueueLineage_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	if err != nil {
+		t.Fatalf("could not create repository: %v", err)
+	}
+
+	link := &model.QueueLineage{
+		Partition: "default",
+		FromQueue: "root.reporting",
+		ToQueue:   "root.analytics",
+		Reason:    util.ToPtr("team merge"),
+		CreatedBy: "ops",
+	}
+	require.NoError(t, repo.AddQueueLineage(ctx, link))
+	assert.NotEmpty(t, link.ID)
+	assert.NotZero(t, link.CreatedAt)
+
+	err = repo.AddQueueLineage(ctx, &model.QueueLineage{
+		Partition: "default",
+		FromQueue: "root.reporting",
+		ToQueue:   "root.bi",
+		CreatedBy: "ops",
+	})
+	assert.ErrorIs(t, err, ErrQueueLineageExists)
+	require.NoError(t, repo.AddQueueLineage(ctx, &model.QueueLineage{
+		Partition: "gpu",
+		FromQueue: "root.reporting",
+		ToQueue:   "root.bi",
+		CreatedBy: "ops",
+	}))
+
+	links, err := repo.GetQueueLineage(ctx, util.ToPtr("default"))
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, link, links[0])
+	links, err = repo.GetQueueLineage(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+
+	require.NoError(t, repo.DeleteQueueLineage(ctx, link.ID))
+	assert.ErrorIs(t, repo.DeleteQueueLineage(ctx, link.ID), ErrQueueLineageNotFound)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/yunikorn-core/pkg/webservice/dao"
 	"github.com/google/uuid"
@@ -14,17 +15,50 @@ type Repository interface {
 	UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error
 	GetAllApplications(ctx context.Context, filters ApplicationFilters) ([]*model.ApplicationDAOInfo, error)
 	GetAppsPerPartitionPerQueue(ctx context.Context, partition, queue string, filters ApplicationFilters) ([]*model.ApplicationDAOInfo, error)
+	StreamAppsPerPartitionPerQueue(
+		ctx context.Context,
+		partition, queue string,
+		filters ApplicationFilters,
+		fn func(*model.ApplicationDAOInfo) error,
+	) error
+	GetAppsPerPartitionPerQueuePage(
+		ctx context.Context,
+		partition, queue string,
+		filters ApplicationFilters,
+	) ([]*model.ApplicationDAOInfo, PageInfo, error)
+	GetApplicationSummaries(ctx context.Context, partition, queue string, filters ApplicationFilters) ([]*model.ApplicationSummary, error)
+	GetAllocationConstraints(ctx context.Context, partition, queue, appID string) ([]*model.AllocationConstraints, error)
+	CountFinishedApplications(ctx context.Context, partition, queue, state string, since time.Time) (int, error)
+	DeleteApplicationsFinishedBefore(ctx context.Context, partition, queue string, before time.Time, limit int) (int64, error)
+	CountBulkDeleteApplications(ctx context.Context, filters BulkDeleteFilters) (deletable, held int64, err error)
+	BulkDeleteApplications(ctx context.Context, filters BulkDeleteFilters, limit int) (int64, error)
+	MoveApplicationsToColdTier(ctx context.Context, before time.Time, limit int) (int64, error)
 	UpdateHistory(
 		ctx context.Context,
 		apps []*dao.ApplicationHistoryDAOInfo,
 		containers []*dao.ContainerHistoryDAOInfo,
 	) error
+	DeleteHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error)
 	GetApplicationsHistory(ctx context.Context) ([]*dao.ApplicationHistoryDAOInfo, error)
 	GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error)
+	GetApplicationsHistoryPage(ctx context.Context, page Page) ([]*dao.ApplicationHistoryDAOInfo, PageInfo, error)
+	GetContainersHistoryPage(ctx context.Context, page Page) ([]*dao.ContainerHistoryDAOInfo, PageInfo, error)
+	StreamApplicationsHistory(ctx context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error
+	StreamContainersHistory(ctx context.Context, fn func(*dao.ContainerHistoryDAOInfo) error) error
 	UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error
 	InsertNodeUtilizations(ctx context.Context, uuid uuid.UUID, partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo) error
 	GetNodeUtilizations(ctx context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error)
 	GetNodesPerPartition(ctx context.Context, partition string) ([]*dao.NodeDAOInfo, error)
+	GetNodesPerPartitionPage(ctx context.Context, partition string, page Page) ([]*dao.NodeDAOInfo, PageInfo, error)
+	GetNode(ctx context.Context, nodeID string) (*model.NodeDAOInfo, error)
+	GetNodeTopologies(ctx context.Context, partition string) ([]*model.NodeTopology, error)
+	RecordNodeTermination(
+		ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+	) (*model.NodeTermination, error)
+	GetNodeTerminations(ctx context.Context, filters NodeTerminationFilters) ([]*model.NodeTermination, error)
+	AddAutoscalerEvents(ctx context.Context, events []*model.AutoscalerEvent) (int, error)
+	GetAutoscalerEvents(ctx context.Context, filters AutoscalerEventFilters) ([]*model.AutoscalerEvent, error)
+	GetPendingBacklog(ctx context.Context, filters PendingBacklogFilters) ([]*model.PendingBacklogSample, error)
 	UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error
 	GetAllPartitions(ctx context.Context) ([]*dao.PartitionInfo, error)
 	AddQueues(ctx context.Context, parentId *string, queues []*dao.PartitionQueueDAOInfo) error
@@ -33,4 +67,66 @@ type Repository interface {
 	GetQueuesPerPartition(ctx context.Context, partition string) ([]*model.PartitionQueueDAOInfo, error)
 	GetQueue(ctx context.Context, partition, queueName string) (*model.PartitionQueueDAOInfo, error)
 	DeleteQueues(ctx context.Context, queues []*model.PartitionQueueDAOInfo) error
+	CreateLegalHold(ctx context.Context, hold *model.LegalHold) error
+	GetLegalHolds(ctx context.Context, filters LegalHoldFilters) ([]*model.LegalHold, error)
+	GetLegalHold(ctx context.Context, id string) (*model.LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, id, releasedBy, reason string, ifVersion *int64) (*model.LegalHold, error)
+	AddQueueLineage(ctx context.Context, link *model.QueueLineage) error
+	GetQueueLineage(ctx context.Context, partition *string) ([]*model.QueueLineage, error)
+	DeleteQueueLineage(ctx context.Context, id string) error
+	StartUserErasure(ctx context.Context, erasure *model.UserErasure) error
+	UpdateUserErasure(ctx context.Context, erasure *model.UserErasure) error
+	EraseUserApplications(ctx context.Context, user, pseudonym, mode string, limit int) (int64, error)
+	CountUserApplications(ctx context.Context, user string) (int64, error)
+	PseudonymizeAuditRecords(ctx context.Context, user, pseudonym string) (int64, error)
+	UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error
+	GetQueueACLs(ctx context.Context, partition, queue string, filters QueueACLFilters) ([]*model.QueueACL, error)
+	AddTraceEvent(ctx context.Context, event *model.TraceEvent, maxEvents int) (bool, error)
+	GetTraceEvents(ctx context.Context, appID string, filters TraceEventFilters) ([]*model.TraceEvent, error)
+	DeleteTraceEventsBefore(ctx context.Context, before time.Time) (int64, error)
+	RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error)
+	GetSchedulerEpochs(ctx context.Context, filters SchedulerEpochFilters) ([]*model.SchedulerEpoch, error)
+	GetNamespaceQueues(ctx context.Context, partition string, filters NamespaceQueueFilters) ([]*model.NamespaceQueue, error)
+	QueryAnalytics(ctx context.Context, query AnalyticsQuery) ([]*model.AnalyticsRow, error)
+	EstimateAnalytics(ctx context.Context, query AnalyticsQuery) (*model.AnalyticsEstimate, error)
+	StartAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error)
+	StopAnalyticsSink(ctx context.Context, name string) error
+	GetAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error)
+	BackfillAnalyticsSink(
+		ctx context.Context,
+		name string,
+		limit int,
+		fn func([]*model.AnalyticsApplication) error,
+	) (bool, error)
+	ConsumeAnalyticsChanges(ctx context.Context, limit int, fn func([]*model.AnalyticsApplication) error) (int, error)
+	GetQueueThroughput(ctx context.Context, filters ThroughputFilters) ([]*model.ThroughputBucket, error)
+	GetTopUsage(ctx context.Context, filters TopUsageFilters) ([]*model.TopUsage, error)
+	GetDuplicateApplications(
+		ctx context.Context, filters DuplicateApplicationFilters,
+	) ([]*model.DuplicateApplication, PageInfo, error)
+	GetHourlyUsage(ctx context.Context, filters HourlyUsageFilters) ([]*model.HourlyUsage, error)
+	GetPrincipals(ctx context.Context, kind PrincipalKind, filters PrincipalFilters) ([]*model.Principal, error)
+	GetQueueWaitPhases(ctx context.Context, filters WaitPhaseFilters) ([]*model.QueueWaitPhases, error)
+	CheckDataQuality(ctx context.Context, check DataQualityCheck, at time.Time) (int64, error)
+	GetDataQualityChecks(ctx context.Context) ([]*model.DataQualityCheck, error)
+	GetDataQualityViolations(ctx context.Context, filters DataQualityFilters) ([]*model.DataQualityViolation, error)
+	SyncConfigSLOs(ctx context.Context, slos []*model.QueueSLO) error
+	PutSLO(ctx context.Context, slo *model.QueueSLO, ifVersion *int64) error
+	DeleteSLO(ctx context.Context, name string, ifVersion *int64) error
+	GetSLOs(ctx context.Context) ([]*model.QueueSLO, error)
+	EvaluateSLO(ctx context.Context, slo *model.QueueSLO, at time.Time) (*model.SLOEvaluation, error)
+	GetSLOEvaluations(ctx context.Context, filters SLOEvaluationFilters) ([]*model.SLOEvaluation, error)
+	DeleteSLOEvaluationsBefore(ctx context.Context, before time.Time) (int64, error)
+	RegisterCluster(ctx context.Context, cluster *model.Cluster) error
+	HeartbeatCluster(ctx context.Context, name string, ingestedUntil *time.Time) (*model.Cluster, error)
+	GetClusters(ctx context.Context) ([]*model.Cluster, error)
+	PauseOperation(ctx context.Context, op *model.PausedOperation) error
+	ResumeOperation(ctx context.Context, name string) error
+	GetPausedOperations(ctx context.Context) ([]*model.PausedOperation, error)
+	ApplyIngestBatch(
+		ctx context.Context,
+		source string,
+		sequence int64,
+		fn func(ctx context.Context, tx Repository) error,
+	) (int64, error)
 }
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// The row types map the columns of the tables by name with pgx.RowToStructByName, which fails if a column has no field
+// or a field has no column, instead of silently scanning values into the wrong fields when the columns change.
+// TestRowsMatchMigrations checks that every row type, and every model scanned directly such as model.LegalHold,
+// maps exactly the columns created by the migrations.
+
+type applicationRow struct {
+	ID                 string                      `db:"id"`
+	ApplicationID      string                      `db:"app_id"`
+	UsedResource       map[string]int64            `db:"used_resource"`
+	MaxUsedResource    map[string]int64            `db:"max_used_resource"`
+	PendingResource    map[string]int64            `db:"pending_resource"`
+	Partition          string                      `db:"partition"`
+	QueueName          string                      `db:"queue_name"`
+	QueueID            string                      `db:"queue_id"`
+	SubmissionTime     int64                       `db:"submission_time"`
+	FinishedTime       *int64                      `db:"finished_time"`
+	Requests           []*dao.AllocationAskDAOInfo `db:"requests"`
+	Allocations        []*dao.AllocationDAOInfo    `db:"allocations"`
+	State              string                      `db:"state"`
+	User               string                      `db:"user"`
+	Groups             []string                    `db:"groups"`
+	RejectedMessage    string                      `db:"rejected_message"`
+	StateLog           []*dao.StateDAOInfo         `db:"state_log"`
+	PlaceholderData    []*dao.PlaceholderDAOInfo   `db:"place_holder_data"`
+	HasReserved        bool                        `db:"has_reserved"`
+	Reservations       []string                    `db:"reservations"`
+	MaxRequestPriority int32                       `db:"max_request_priority"`
+	SparkAppID         *string                     `db:"spark_app_id"`
+	WorkflowID         *string                     `db:"workflow_id"`
+	UsageObservedAt    *int64                      `db:"usage_observed_at"`
+	Priority           *int32                      `db:"priority"`
+	PriorityClass      *string                     `db:"priority_class"`
+	WaitTime           *int64                      `db:"wait_time"`
+	Duration           *int64                      `db:"duration"`
+	RetryKey           *string                     `db:"retry_key"`
+	AcceptWait         *int64                      `db:"accept_wait"`
+	PlaceholderWait    *int64                      `db:"placeholder_wait"`
+	AllocationWait     *int64                      `db:"allocation_wait"`
+	WaitReason         *string                     `db:"wait_reason"`
+}
+
+func (r *applicationRow) toModel() *model.ApplicationDAOInfo {
+	app := &model.ApplicationDAOInfo{
+		QueueID: r.QueueID,
+		ApplicationDAOInfo: dao.ApplicationDAOInfo{
+			ApplicationID:      r.ApplicationID,
+			UsedResource:       r.UsedResource,
+			MaxUsedResource:    r.MaxUsedResource,
+			PendingResource:    r.PendingResource,
+			Partition:          r.Partition,
+			QueueName:          r.QueueName,
+			SubmissionTime:     r.SubmissionTime,
+			FinishedTime:       r.FinishedTime,
+			Requests:           r.Requests,
+			Allocations:        r.Allocations,
+			State:              r.State,
+			User:               r.User,
+			Groups:             r.Groups,
+			RejectedMessage:    r.RejectedMessage,
+			StateLog:           r.StateLog,
+			PlaceholderData:    r.PlaceholderData,
+			HasReserved:        r.HasReserved,
+			Reservations:       r.Reservations,
+			MaxRequestPriority: r.MaxRequestPriority,
+		},
+	}
+	if r.SparkAppID != nil {
+		app.SparkApplicationID = *r.SparkAppID
+	}
+	if r.WorkflowID != nil {
+		app.WorkflowID = *r.WorkflowID
+	}
+	if r.RetryKey != nil {
+		app.RetryKey = *r.RetryKey
+	}
+	app.Priority = r.Priority
+	if r.PriorityClass != nil {
+		app.PriorityClass = *r.PriorityClass
+	}
+	app.WaitTime = r.WaitTime
+	app.Duration = r.Duration
+	if r.AcceptWait != nil || r.PlaceholderWait != nil || r.AllocationWait != nil || r.WaitReason != nil {
+		app.WaitPhases = &model.WaitPhases{
+			AcceptWait:      r.AcceptWait,
+			PlaceholderWait: r.PlaceholderWait,
+			AllocationWait:  r.AllocationWait,
+		}
+		if r.WaitReason != nil {
+			app.WaitPhases.Reason = *r.WaitReason
+		}
+	}
+	return app
+}
+
+type partitionRow struct {
+	ID                      string           `db:"id"`
+	ClusterID               string           `db:"cluster_id"`
+	Name                    string           `db:"name"`
+	Capacity                map[string]int64 `db:"capacity"`
+	UsedCapacity            map[string]int64 `db:"used_capacity"`
+	Utilization             map[string]int64 `db:"utilization"`
+	TotalNodes              int              `db:"total_nodes"`
+	Applications            map[string]int   `db:"applications"`
+	TotalContainers         int              `db:"total_containers"`
+	State                   string           `db:"state"`
+	LastStateTransitionTime int64            `db:"last_state_transition_time"`
+}
+
+func (r *partitionRow) toModel() *dao.PartitionInfo {
+	return &dao.PartitionInfo{
+		ClusterID: r.ClusterID,
+		Name:      r.Name,
+		Capacity: dao.PartitionCapacity{
+			Capacity:     r.Capacity,
+			UsedCapacity: r.UsedCapacity,
+			Utilization:  r.Utilization,
+		},
+		TotalNodes:              r.TotalNodes,
+		Applications:            r.Applications,
+		TotalContainers:         r.TotalContainers,
+		State:                   r.State,
+		LastStateTransitionTime: r.LastStateTransitionTime,
+	}
+}
+
+type queueRow struct {
+	ID                     string            `db:"id"`
+	ParentID               sql.NullString    `db:"parent_id"`
+	CreatedAt              sql.NullInt64     `db:"created_at"`
+	DeletedAt              sql.NullInt64     `db:"deleted_at"`
+	QueueName              string            `db:"queue_name"`
+	Status                 string            `db:"status"`
+	Partition              string            `db:"partition"`
+	PendingResource        map[string]int64  `db:"pending_resource"`
+	MaxResource            map[string]int64  `db:"max_resource"`
+	GuaranteedResource     map[string]int64  `db:"guaranteed_resource"`
+	AllocatedResource      map[string]int64  `db:"allocated_resource"`
+	PreemptingResource     map[string]int64  `db:"preempting_resource"`
+	HeadRoom               map[string]int64  `db:"head_room"`
+	IsLeaf                 bool              `db:"is_leaf"`
+	IsManaged              bool              `db:"is_managed"`
+	Properties             map[string]string `db:"properties"`
+	Parent                 string            `db:"parent"`
+	TemplateInfo           *dao.TemplateInfo `db:"template_info"`
+	AbsUsedCapacity        map[string]int64  `db:"abs_used_capacity"`
+	MaxRunningApps         uint64            `db:"max_running_apps"`
+	RunningApps            uint64            `db:"running_apps"`
+	CurrentPriority        int32             `db:"current_priority"`
+	AllocatingAcceptedApps []string          `db:"allocating_accepted_apps"`
+}
+
+func (r *queueRow) toModel() *model.PartitionQueueDAOInfo {
+	return &model.PartitionQueueDAOInfo{
+		Id:        r.ID,
+		ParentId:  r.ParentID,
+		CreatedAt: r.CreatedAt,
+		DeletedAt: r.DeletedAt,
+		PartitionQueueDAOInfo: dao.PartitionQueueDAOInfo{
+			QueueName:              r.QueueName,
+			Status:                 r.Status,
+			Partition:              r.Partition,
+			PendingResource:        r.PendingResource,
+			MaxResource:            r.MaxResource,
+			GuaranteedResource:     r.GuaranteedResource,
+			AllocatedResource:      r.AllocatedResource,
+			PreemptingResource:     r.PreemptingResource,
+			HeadRoom:               r.HeadRoom,
+			IsLeaf:                 r.IsLeaf,
+			IsManaged:              r.IsManaged,
+			Properties:             r.Properties,
+			Parent:                 r.Parent,
+			TemplateInfo:           r.TemplateInfo,
+			AbsUsedCapacity:        r.AbsUsedCapacity,
+			MaxRunningApps:         r.MaxRunningApps,
+			RunningApps:            r.RunningApps,
+			CurrentPriority:        r.CurrentPriority,
+			AllocatingAcceptedApps: r.AllocatingAcceptedApps,
+		},
+	}
+}
+
+// queueGenerationRow is a queue of the subtree selected by GetQueue, with its depth in the subtree.
+type queueGenerationRow struct {
+	queueRow
+	GenerationNumber int `db:"generation_number"`
+}
+
+// placementRow is the placement constraints of an allocation, with the zone and rack of the node it was placed on.
+type placementRow struct {
+	model.AllocationConstraints
+	NodeZone *string `db:"zone"`
+	NodeRack *string `db:"rack"`
+}
+
+type nodeRow struct {
+	ID           string                   `db:"id"`
+	NodeID       string                   `db:"node_id"`
+	Partition    string                   `db:"partition"`
+	HostName     string                   `db:"host_name"`
+	RackName     string                   `db:"rack_name"`
+	Attributes   map[string]string        `db:"attributes"`
+	Capacity     map[string]int64         `db:"capacity"`
+	Allocated    map[string]int64         `db:"allocated"`
+	Occupied     map[string]int64         `db:"occupied"`
+	Available    map[string]int64         `db:"available"`
+	Utilized     map[string]int64         `db:"utilized"`
+	Allocations  []*dao.AllocationDAOInfo `db:"allocations"`
+	Schedulable  bool                     `db:"schedulable"`
+	IsReserved   bool                     `db:"is_reserved"`
+	Reservations []string                 `db:"reservations"`
+	Zone         *string                  `db:"zone"`
+	Rack         *string                  `db:"rack"`
+}
+
+func (r *nodeRow) toModel() *dao.NodeDAOInfo {
+	return &dao.NodeDAOInfo{
+		NodeID:       r.NodeID,
+		HostName:     r.HostName,
+		RackName:     r.RackName,
+		Attributes:   r.Attributes,
+		Capacity:     r.Capacity,
+		Allocated:    r.Allocated,
+		Occupied:     r.Occupied,
+		Available:    r.Available,
+		Utilized:     r.Utilized,
+		Allocations:  r.Allocations,
+		Schedulable:  r.Schedulable,
+		IsReserved:   r.IsReserved,
+		Reservations: r.Reservations,
+	}
+}
+
+type nodeUtilizationRow struct {
+	ID            string                  `db:"id"`
+	ClusterID     string                  `db:"cluster_id"`
+	Partition     string                  `db:"partition"`
+	NodesUtilList []*dao.NodesUtilDAOInfo `db:"nodes_util_list"`
+}
+
+func (r *nodeUtilizationRow) toModel() *dao.PartitionNodesUtilDAOInfo {
+	return &dao.PartitionNodesUtilDAOInfo{
+		ClusterID:     r.ClusterID,
+		Partition:     r.Partition,
+		NodesUtilList: r.NodesUtilList,
+	}
+}
+
+type historyRow struct {
+	ID          string `db:"id"`
+	HistoryType string `db:"history_type"`
+	TotalNumber string `db:"total_number"`
+	Timestamp   int64  `db:"timestamp"`
+	ClusterID   string `db:"cluster_id"`
+}
+
+// forEachRow scans every row into a T by name and calls fn with it, until fn returns an error. It closes the rows.
+// The scan and read errors name the entity of the rows, e.g. "applications".
+func forEachRow[T any](rows pgx.Rows, entity string, fn func(*T) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		row, err := pgx.RowToAddrOfStructByName[T](rows)
+		if err != nil {
+			return fmt.Errorf("could not scan %s from DB: %v", entity, err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("could not read %s from DB: %v", entity, err)
+	}
+	return nil
+}
+
+// analyticsChangeRow is a change of an application recorded in the outbox of the analytics sinks.
+type analyticsChangeRow struct {
+	Seq           int64  `db:"seq"`
+	ApplicationID string `db:"application_id"`
+	Partition     string `db:"partition"`
+	QueueName     string `db:"queue_name"`
+}
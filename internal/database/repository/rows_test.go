@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// rowTypes maps the tables to the types their rows are scanned into by name, or to nil for tables which are
+// only queried for aggregates.
+var rowTypes = map[string]any{
+	"applications":            applicationRow{},
+	"applications_cold":       applicationRow{},
+	"application_summary":     model.ApplicationSummary{},
+	"partitions":              partitionRow{},
+	"queues":                  queueRow{},
+	"nodes":                   nodeRow{},
+	"partition_nodes_util":    nodeUtilizationRow{},
+	"history":                 historyRow{},
+	"legal_holds":             model.LegalHold{},
+	"queue_lineage":           model.QueueLineage{},
+	"user_erasures":           model.UserErasure{},
+	"queue_acls":              model.QueueACL{},
+	"namespace_queues":        model.NamespaceQueue{},
+	"allocation_constraints":  model.AllocationConstraints{},
+	"trace_events":            model.TraceEvent{},
+	"scheduler_epochs":        model.SchedulerEpoch{},
+	"queue_throughput":        nil,
+	"usage_rollups":           nil,
+	"data_quality_checks":     model.DataQualityCheck{},
+	"data_quality_violations": model.DataQualityViolation{},
+	"clusters":                model.Cluster{},
+	"ingest_sources":          nil,
+	"analytics_sinks":         model.AnalyticsSink{},
+	"analytics_outbox":        analyticsChangeRow{},
+	"paused_operations":       model.PausedOperation{},
+	"node_terminations":       model.NodeTermination{},
+	"autoscaler_events":       model.AutoscalerEvent{},
+	"pending_backlog":         model.PendingBacklogSample{},
+	"queue_slos":              model.QueueSLO{},
+	"slo_evaluations":         model.SLOEvaluation{},
+}
+
+// dbColumns returns the columns mapped by the db tags of the struct type, including the tags of embedded structs.
+// Exported fields without a db tag are reported as untagged, since pgx would map them by their field name.
+func dbColumns(typ reflect.Type) (columns, untagged []string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded, embeddedUntagged := dbColumns(field.Type)
+			columns = append(columns, embedded...)
+			untagged = append(untagged, embeddedUntagged...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("db")
+		switch {
+		case !ok:
+			untagged = append(untagged, field.Name)
+		case tag != "-":
+			columns = append(columns, tag)
+		}
+	}
+	return columns, untagged
+}
+
+func TestRowsMatchMigrations(t *testing.T) {
+	tables := migrationColumns(t)
+	for table, columns := range tables {
+		row, ok := rowTypes[table]
+		if !assert.Truef(t, ok, "table %s has no row type", table) || row == nil {
+			continue
+		}
+		mapped, untagged := dbColumns(reflect.TypeOf(row))
+		assert.Emptyf(t, untagged, "fields of %T have no db tag", row)
+		var expected []string
+		for column := range columns {
+			expected = append(expected, column)
+		}
+		sort.Strings(expected)
+		sort.Strings(mapped)
+		assert.Equalf(t, expected, mapped, "%T does not map the columns of table %s", row, table)
+	}
+	for table := range rowTypes {
+		assert.Containsf(t, tables, table, "table %s is not created by the migrations", table)
+	}
+}
+
+func TestQueueGenerationRowExtendsQueueRow(t *testing.T) {
+	queueColumns, _ := dbColumns(reflect.TypeOf(queueRow{}))
+	columns, untagged := dbColumns(reflect.TypeOf(queueGenerationRow{}))
+	assert.Empty(t, untagged)
+	assert.Equal(t, append(queueColumns, "generation_number"), columns)
+}
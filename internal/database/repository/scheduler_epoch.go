@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// SchedulerEpochFilters select the epochs of the scheduler which overlap a period, optionally only of the scheduler
+// of a cluster.
+type SchedulerEpochFilters struct {
+	Cluster *string
+	Start   *time.Time
+	End     *time.Time
+}
+
+// RecordSchedulerEpoch records the epoch of the instance of the scheduler which is running, and returns whether
+// the scheduler restarted, i.e. whether the current epoch was of another instance, which is ended by the epoch.
+// Instances are told apart by their start time, and by their ID if it is known for both instances. If the epoch is
+// of the current instance, the ID of the instance is recorded if it was not known yet. The epochs of the scheduler
+// of each cluster are recorded separately.
+func (s *PostgresRepository) RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error) {
+	restarted := false
+	err := pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT * FROM scheduler_epochs WHERE end_time IS NULL AND cluster_id = $1 FOR UPDATE",
+			epoch.ClusterID)
+		if err != nil {
+			return fmt.Errorf("could not get scheduler epochs from DB: %v", err)
+		}
+		var current []*model.SchedulerEpoch
+		err = forEachRow(rows, "scheduler epochs", func(e *model.SchedulerEpoch) error {
+			current = append(current, e)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, previous := range current {
+			if sameSchedulerInstance(previous, epoch) {
+				_, err := tx.Exec(ctx, "UPDATE scheduler_epochs SET instance_id = COALESCE(instance_id, $2) WHERE id = $1",
+					previous.ID, epoch.InstanceID)
+				if err != nil {
+					return fmt.Errorf("could not update scheduler epoch in DB: %v", err)
+				}
+				if epoch.InstanceID == nil {
+					epoch.InstanceID = previous.InstanceID
+				}
+				epoch.ID, epoch.DetectedAt = previous.ID, previous.DetectedAt
+				return nil
+			}
+		}
+		for _, previous := range current {
+			_, err := tx.Exec(ctx, "UPDATE scheduler_epochs SET end_time = $2 WHERE id = $1", previous.ID, epoch.StartTime)
+			if err != nil {
+				return fmt.Errorf("could not end scheduler epoch in DB: %v", err)
+			}
+			restarted = true
+		}
+
+		insertSQL := `INSERT INTO scheduler_epochs (instance_id, start_time, detected_at, cluster_id)
+			VALUES (@instance_id, @start_time, @detected_at, @cluster_id)
+			RETURNING id`
+		err = tx.QueryRow(ctx, insertSQL, pgx.NamedArgs{
+			"instance_id": epoch.InstanceID,
+			"start_time":  epoch.StartTime,
+			"detected_at": epoch.DetectedAt,
+			"cluster_id":  epoch.ClusterID,
+		}).Scan(&epoch.ID)
+		if err != nil {
+			return fmt.Errorf("could not insert scheduler epoch into DB: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return restarted, nil
+}
+
+// GetSchedulerEpochs returns the epochs of the scheduler, most recent first.
+func (s *PostgresRepository) GetSchedulerEpochs(
+	ctx context.Context, filters SchedulerEpochFilters) ([]*model.SchedulerEpoch, error) {
+	query, args, err := schedulerEpochsQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get scheduler epochs from DB: %v", err)
+	}
+
+	var epochs []*model.SchedulerEpoch
+	err = forEachRow(rows, "scheduler epochs", func(epoch *model.SchedulerEpoch) error {
+		epochs = append(epochs, epoch)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// schedulerEpochsQuery builds the query of GetSchedulerEpochs. An epoch is selected if it overlaps the period.
+func schedulerEpochsQuery(filters SchedulerEpochFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(schedulerEpochsTable, "").
+		OrderBy("start_time", sql.OrderByDescending)
+	if filters.Cluster != nil {
+		queryBuilder.Conditionp("cluster_id", sql.Equal, *filters.Cluster)
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("start_time", sql.LessThanOrEqual, filters.End.UnixNano())
+	}
+	if filters.Start != nil {
+		queryBuilder.ConditionNullOrp("end_time", sql.GreaterThan, filters.Start.UnixNano())
+	}
+	return queryBuilder
+}
+
+// sameSchedulerInstance returns whether the epochs are of the same instance of the scheduler.
+func sameSchedulerInstance(a, b *model.SchedulerEpoch) bool {
+	if a.StartTime != b.StartTime {
+		return false
+	}
+	return a.InstanceID == nil || b.InstanceID == nil || *a.InstanceID == *b.InstanceID
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestSchedulerEpochs_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	restart := start.Add(24 * time.Hour)
+
+	// the first epoch is not a restart
+	first := &model.SchedulerEpoch{StartTime: start.UnixNano(), DetectedAt: start.Add(time.Minute).UnixNano()}
+	restarted, err := repo.RecordSchedulerEpoch(ctx, first)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+	assert.NotEmpty(t, first.ID)
+
+	// the ID of the current instance is recorded once it is known
+	same := &model.SchedulerEpoch{
+		InstanceID: util.ToPtr("instance-1"),
+		StartTime:  start.UnixNano(),
+		DetectedAt: start.Add(time.Hour).UnixNano(),
+	}
+	restarted, err = repo.RecordSchedulerEpoch(ctx, same)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+	assert.Equal(t, first.ID, same.ID)
+	assert.Equal(t, first.DetectedAt, same.DetectedAt)
+
+	// an instance started later is a restart, which ends the epoch of the previous instance
+	second := &model.SchedulerEpoch{
+		InstanceID: util.ToPtr("instance-2"),
+		StartTime:  restart.UnixNano(),
+		DetectedAt: restart.Add(time.Minute).UnixNano(),
+	}
+	restarted, err = repo.RecordSchedulerEpoch(ctx, second)
+	require.NoError(t, err)
+	assert.True(t, restarted)
+
+	epochs, err := repo.GetSchedulerEpochs(ctx, SchedulerEpochFilters{})
+	require.NoError(t, err)
+	require.Len(t, epochs, 2)
+	assert.Equal(t, second.ID, epochs[0].ID)
+	assert.Nil(t, epochs[0].EndTime)
+	assert.Equal(t, first.ID, epochs[1].ID)
+	assert.Equal(t, util.ToPtr("instance-1"), epochs[1].InstanceID)
+	assert.Equal(t, util.ToPtr(restart.UnixNano()), epochs[1].EndTime)
+
+	epochs, err = repo.GetSchedulerEpochs(ctx, SchedulerEpochFilters{
+		Start: util.ToPtr(start.Add(time.Hour)),
+		End:   util.ToPtr(start.Add(2 * time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Len(t, epochs, 1)
+	assert.Equal(t, first.ID, epochs[0].ID)
+
+	epochs, err = repo.GetSchedulerEpochs(ctx, SchedulerEpochFilters{Start: util.ToPtr(restart.Add(time.Hour))})
+	require.NoError(t, err)
+	require.Len(t, epochs, 1)
+	assert.Equal(t, second.ID, epochs[0].ID)
+
+	// the scheduler of another cluster has its own epochs, which do not end the epochs of the default cluster
+	other := &model.SchedulerEpoch{
+		ClusterID:  "eu-west",
+		StartTime:  restart.Add(time.Hour).UnixNano(),
+		DetectedAt: restart.Add(time.Hour).UnixNano(),
+	}
+	restarted, err = repo.RecordSchedulerEpoch(ctx, other)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+
+	epochs, err = repo.GetSchedulerEpochs(ctx, SchedulerEpochFilters{Cluster: util.ToPtr("")})
+	require.NoError(t, err)
+	require.Len(t, epochs, 2)
+	assert.Equal(t, second.ID, epochs[0].ID)
+	assert.Nil(t, epochs[0].EndTime)
+
+	epochs, err = repo.GetSchedulerEpochs(ctx, SchedulerEpochFilters{Cluster: util.ToPtr("eu-west")})
+	require.NoError(t, err)
+	require.Len(t, epochs, 1)
+	assert.Equal(t, other.ID, epochs[0].ID)
+	assert.Equal(t, "eu-west", epochs[0].ClusterID)
+}
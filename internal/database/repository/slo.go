@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+var (
+	ErrSLONotFound        = errors.New("slo not found")
+	ErrSLOManagedByConfig = errors.New("slo is defined in the configuration file")
+)
+
+// SLOEvaluationFilters select the evaluations of an SLO during a period.
+type SLOEvaluationFilters struct {
+	Name  string
+	Start *time.Time
+	End   *time.Time
+}
+
+const upsertSLOSQL = `INSERT INTO queue_slos (name, source, partition, queue_name, objective, start_within, window_length)
+	VALUES (@name, @source, @partition, @queue_name, @objective, @start_within, @window_length)
+	ON CONFLICT (name) DO UPDATE SET
+		source = EXCLUDED.source,
+		partition = EXCLUDED.partition,
+		queue_name = EXCLUDED.queue_name,
+		objective = EXCLUDED.objective,
+		start_within = EXCLUDED.start_within,
+		window_length = EXCLUDED.window_length,
+		version = queue_slos.version + 1`
+
+func sloArgs(slo *model.QueueSLO) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"name":          slo.Name,
+		"source":        slo.Source,
+		"partition":     slo.Partition,
+		"queue_name":    slo.Queue,
+		"objective":     slo.Objective,
+		"start_within":  slo.StartWithin,
+		"window_length": slo.Window,
+	}
+}
+
+// SyncConfigSLOs replaces the SLOs defined in the configuration file with the given SLOs, whose source and version
+// are set. An SLO defined via the API with the name of one of them is replaced too. The evaluations of the SLOs which
+// are no longer defined are deleted with them, while those of the SLOs which are still defined are kept.
+func (s *PostgresRepository) SyncConfigSLOs(ctx context.Context, slos []*model.QueueSLO) error {
+	names := make([]string, 0, len(slos))
+	for _, slo := range slos {
+		names = append(names, slo.Name)
+	}
+	return pgx.BeginFunc(ctx, s.dbpool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "DELETE FROM queue_slos WHERE source = $1 AND NOT (name = ANY($2))",
+			model.SLOSourceConfig, names)
+		if err != nil {
+			return fmt.Errorf("could not delete slos from DB: %v", err)
+		}
+		for _, slo := range slos {
+			slo.Source = model.SLOSourceConfig
+			if err := tx.QueryRow(ctx, upsertSLOSQL+" RETURNING version", sloArgs(slo)).Scan(&slo.Version); err != nil {
+				return fmt.Errorf("could not upsert slo into DB: %v", err)
+			}
+		}
+		return nil
+	})
+}
+
+// PutSLO stores the SLO defined via the API and sets its source and version, replacing the SLO with the same name.
+// It returns ErrSLOManagedByConfig if the SLO with the same name is defined in the configuration file. If ifVersion
+// is set, the SLO must exist in that version, and ErrVersionMismatch is returned if it does not.
+func (s *PostgresRepository) PutSLO(ctx context.Context, slo *model.QueueSLO, ifVersion *int64) error {
+	slo.Source = model.SLOSourceAPI
+	args := sloArgs(slo)
+	args["config"] = model.SLOSourceConfig
+	query := upsertSLOSQL + ` WHERE queue_slos.source <> @config RETURNING version`
+	if ifVersion != nil {
+		// the SLO is updated instead of upserted, so that an SLO deleted since it was read is not defined again
+		args["if_version"] = *ifVersion
+		query = `UPDATE queue_slos SET partition = @partition, queue_name = @queue_name, objective = @objective,
+				start_within = @start_within, window_length = @window_length, version = version + 1
+			WHERE name = @name AND source <> @config AND version = @if_version
+			RETURNING version`
+	}
+	err := s.dbpool.QueryRow(ctx, query, args).Scan(&slo.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.sloConflict(ctx, slo.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("could not upsert slo into DB: %v", err)
+	}
+	return nil
+}
+
+// sloConflict returns why the SLO defined via the API with the name could not be changed: ErrSLOManagedByConfig if
+// it is defined in the configuration file, and ErrVersionMismatch otherwise.
+func (s *PostgresRepository) sloConflict(ctx context.Context, name string) error {
+	var source string
+	var version int64
+	err := s.dbpool.QueryRow(ctx, "SELECT source, version FROM queue_slos WHERE name = $1", name).Scan(&source, &version)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("%w: slo %s does not exist", ErrVersionMismatch, name)
+	case err != nil:
+		return fmt.Errorf("could not get slo from DB: %v", err)
+	case source == model.SLOSourceConfig:
+		return fmt.Errorf("%w: %s", ErrSLOManagedByConfig, name)
+	default:
+		return fmt.Errorf("%w: slo %s is in version %d", ErrVersionMismatch, name, version)
+	}
+}
+
+// DeleteSLO deletes the SLO defined via the API with its evaluations. It returns ErrSLONotFound if there is no SLO
+// with the name, and ErrSLOManagedByConfig if it is defined in the configuration file. If ifVersion is set, the SLO
+// is only deleted in that version, and ErrVersionMismatch is returned if it was changed since.
+func (s *PostgresRepository) DeleteSLO(ctx context.Context, name string, ifVersion *int64) error {
+	// the select of the main statement sees the SLO as it was before the deletion
+	deleteSQL := `WITH deleted AS (
+			DELETE FROM queue_slos
+			WHERE name = @name AND source <> @config AND (@if_version::BIGINT IS NULL OR version = @if_version)
+			RETURNING name
+		)
+		SELECT (SELECT COUNT(*) FROM deleted), (SELECT source FROM queue_slos WHERE name = @name)`
+	var deleted int64
+	var source *string
+	err := s.dbpool.QueryRow(ctx, deleteSQL, pgx.NamedArgs{
+		"name":       name,
+		"config":     model.SLOSourceConfig,
+		"if_version": ifVersion,
+	}).Scan(&deleted, &source)
+	if err != nil {
+		return fmt.Errorf("could not delete slo from DB: %v", err)
+	}
+	switch {
+	case deleted > 0:
+		return nil
+	case source == nil:
+		return fmt.Errorf("%w: %s", ErrSLONotFound, name)
+	case *source == model.SLOSourceConfig:
+		return fmt.Errorf("%w: %s", ErrSLOManagedByConfig, name)
+	default:
+		return fmt.Errorf("%w: slo %s was changed", ErrVersionMismatch, name)
+	}
+}
+
+// GetSLOs returns the SLOs ordered by name.
+func (s *PostgresRepository) GetSLOs(ctx context.Context) ([]*model.QueueSLO, error) {
+	rows, err := s.dbpool.Query(ctx, "SELECT * FROM queue_slos ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("could not get slos from DB: %v", err)
+	}
+	var slos []*model.QueueSLO
+	err = forEachRow(rows, "slos", func(slo *model.QueueSLO) error {
+		slos = append(slos, slo)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slos, nil
+}
+
+// EvaluateSLO evaluates the SLO at the given time against the applications submitted within its window before, and
+// stores the evaluation. The applications which started within the threshold of their submission are on time, those
+// which started later or did not start although they waited longer than the threshold are late, and those which are
+// still waiting within the threshold are not counted yet.
+func (s *PostgresRepository) EvaluateSLO(ctx context.Context, slo *model.QueueSLO, at time.Time) (*model.SLOEvaluation, error) {
+	since := at.Add(-time.Duration(slo.Window))
+	evaluateSQL := `WITH counted AS (
+			SELECT COUNT(*) FILTER (WHERE wait_time IS NOT NULL OR submission_time <= @late) AS applications,
+				COUNT(*) FILTER (WHERE wait_time <= @start_within) AS on_time
+			FROM ` + s.applicationsSource(&since).From() + `
+			WHERE submission_time >= @since AND submission_time < @at
+				AND (@partition = '' OR partition = @partition)
+				AND (@queue_name = '' OR queue_name = @queue_name OR starts_with(queue_name, @queue_name || '.'))
+		)
+		INSERT INTO slo_evaluations (slo_name, evaluated_at, objective, applications, on_time, compliance, met)
+		SELECT @name, @evaluated_at, @objective::DOUBLE PRECISION, applications, on_time,
+			on_time::DOUBLE PRECISION / NULLIF(applications, 0),
+			on_time >= @objective::DOUBLE PRECISION * applications
+		FROM counted
+		ON CONFLICT (slo_name, evaluated_at) DO UPDATE SET
+			objective = EXCLUDED.objective,
+			applications = EXCLUDED.applications,
+			on_time = EXCLUDED.on_time,
+			compliance = EXCLUDED.compliance,
+			met = EXCLUDED.met
+		RETURNING *`
+	rows, err := s.dbpool.Query(ctx, evaluateSQL, pgx.NamedArgs{
+		"name":         slo.Name,
+		"partition":    slo.Partition,
+		"queue_name":   slo.Queue,
+		"objective":    slo.Objective,
+		"start_within": slo.StartWithin,
+		"since":        since.UnixNano(),
+		"late":         at.Add(-time.Duration(slo.StartWithin)).UnixNano(),
+		"at":           at.UnixNano(),
+		"evaluated_at": at.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate slo %s in DB: %v", slo.Name, err)
+	}
+	evaluation, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[model.SLOEvaluation])
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate slo %s in DB: %v", slo.Name, err)
+	}
+	return evaluation, nil
+}
+
+// GetSLOEvaluations returns the evaluations of an SLO, oldest first.
+func (s *PostgresRepository) GetSLOEvaluations(
+	ctx context.Context, filters SLOEvaluationFilters) ([]*model.SLOEvaluation, error) {
+	query, args, err := sloEvaluationsQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get slo evaluations from DB: %v", err)
+	}
+
+	var evaluations []*model.SLOEvaluation
+	err = forEachRow(rows, "slo evaluations", func(evaluation *model.SLOEvaluation) error {
+		evaluations = append(evaluations, evaluation)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+// sloEvaluationsQuery builds the query of GetSLOEvaluations.
+func sloEvaluationsQuery(filters SLOEvaluationFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(sloEvaluationsTable, "").
+		Conditionp("slo_name", sql.Equal, filters.Name).
+		OrderBy("evaluated_at", sql.OrderByAscending)
+	if filters.Start != nil {
+		queryBuilder.Conditionp("evaluated_at", sql.GreaterThanOrEqual, filters.Start.Unix())
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("evaluated_at", sql.LessThan, filters.End.Unix())
+	}
+	return queryBuilder
+}
+
+// DeleteSLOEvaluationsBefore deletes the evaluations of all SLOs made before the given time, and returns the number
+// of deleted evaluations.
+func (s *PostgresRepository) DeleteSLOEvaluationsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.dbpool.Exec(ctx, "DELETE FROM slo_evaluations WHERE evaluated_at < $1", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("could not delete slo evaluations from DB: %v", err)
+	}
+	return tag.RowsAffected(), nil
+}
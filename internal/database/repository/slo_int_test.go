@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestSLOs_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	configured := &model.QueueSLO{
+		Name:        "configured",
+		Queue:       "root.a",
+		Objective:   0.5,
+		StartWithin: time.Minute.Nanoseconds(),
+		Window:      time.Hour.Nanoseconds(),
+	}
+	require.NoError(t, repo.SyncConfigSLOs(ctx, []*model.QueueSLO{configured}))
+	assert.Equal(t, model.SLOSourceConfig, configured.Source)
+
+	defined := &model.QueueSLO{
+		Name:        "defined",
+		Partition:   "default",
+		Objective:   0.9,
+		StartWithin: time.Minute.Nanoseconds(),
+		Window:      time.Hour.Nanoseconds(),
+	}
+	require.NoError(t, repo.PutSLO(ctx, defined, nil))
+	assert.Equal(t, model.SLOSourceAPI, defined.Source)
+	assert.Equal(t, int64(1), defined.Version)
+	assert.ErrorIs(t, repo.PutSLO(ctx, &model.QueueSLO{Name: "configured", Objective: 0.1}, nil), ErrSLOManagedByConfig)
+	assert.ErrorIs(t, repo.PutSLO(ctx, &model.QueueSLO{Name: "configured", Objective: 0.1}, util.ToPtr(int64(1))),
+		ErrSLOManagedByConfig)
+
+	// a change conditional on a version is refused if the SLO was changed since
+	defined.Objective = 0.8
+	require.NoError(t, repo.PutSLO(ctx, defined, util.ToPtr(int64(1))))
+	assert.Equal(t, int64(2), defined.Version)
+	assert.ErrorIs(t, repo.PutSLO(ctx, defined, util.ToPtr(int64(1))), ErrVersionMismatch)
+	assert.ErrorIs(t, repo.PutSLO(ctx, &model.QueueSLO{Name: "unknown", Objective: 0.1}, util.ToPtr(int64(1))),
+		ErrVersionMismatch)
+
+	slos, err := repo.GetSLOs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.QueueSLO{configured, defined}, slos)
+
+	now := time.Now()
+	submitted := now.Add(-10 * time.Minute)
+	app := func(id, queue string, wait *time.Duration) *dao.ApplicationDAOInfo {
+		app := &dao.ApplicationDAOInfo{
+			ApplicationID:  id,
+			Partition:      "default",
+			QueueName:      queue,
+			SubmissionTime: submitted.UnixNano(),
+			State:          "Accepted",
+		}
+		if wait != nil {
+			app.State = "Running"
+			app.StateLog = []*dao.StateDAOInfo{{Time: submitted.Add(*wait).UnixNano(), ApplicationState: "Running"}}
+		}
+		return app
+	}
+	apps := []*dao.ApplicationDAOInfo{
+		app("app1", "root.a", util.ToPtr(30*time.Second)),
+		app("app2", "root.a.b", util.ToPtr(5*time.Minute)),
+		// waiting longer than the threshold
+		app("app3", "root.a", nil),
+		app("app4", "root.c", util.ToPtr(10*time.Second)),
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	// submitted within the threshold and still waiting, not counted yet
+	recent := app("app5", "root.a", nil)
+	recent.SubmissionTime = now.Add(-30 * time.Second).UnixNano()
+	require.NoError(t, repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{recent}))
+
+	evaluation, err := repo.EvaluateSLO(ctx, configured, now)
+	require.NoError(t, err)
+	assert.Equal(t, &model.SLOEvaluation{
+		SLOName:      "configured",
+		EvaluatedAt:  now.Unix(),
+		Objective:    0.5,
+		Applications: 3,
+		OnTime:       1,
+		Compliance:   util.ToPtr(1.0 / 3),
+		Met:          false,
+	}, evaluation)
+
+	evaluation, err = repo.EvaluateSLO(ctx, defined, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), evaluation.Applications)
+	assert.Equal(t, int64(2), evaluation.OnTime)
+
+	// an SLO without applications is met
+	later := now.Add(2 * time.Hour)
+	evaluation, err = repo.EvaluateSLO(ctx, configured, later)
+	require.NoError(t, err)
+	assert.Nil(t, evaluation.Compliance)
+	assert.True(t, evaluation.Met)
+
+	evaluations, err := repo.GetSLOEvaluations(ctx, SLOEvaluationFilters{Name: "configured"})
+	require.NoError(t, err)
+	require.Len(t, evaluations, 2)
+	assert.Equal(t, now.Unix(), evaluations[0].EvaluatedAt)
+	evaluations, err = repo.GetSLOEvaluations(ctx, SLOEvaluationFilters{Name: "configured", Start: &later})
+	require.NoError(t, err)
+	assert.Len(t, evaluations, 1)
+
+	deleted, err := repo.DeleteSLOEvaluationsBefore(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	assert.ErrorIs(t, repo.DeleteSLO(ctx, "configured", nil), ErrSLOManagedByConfig)
+	assert.ErrorIs(t, repo.DeleteSLO(ctx, "unknown", nil), ErrSLONotFound)
+	assert.ErrorIs(t, repo.DeleteSLO(ctx, "defined", util.ToPtr(int64(1))), ErrVersionMismatch)
+	require.NoError(t, repo.DeleteSLO(ctx, "defined", util.ToPtr(int64(2))))
+
+	// the SLOs which are no longer configured are deleted with their evaluations
+	require.NoError(t, repo.SyncConfigSLOs(ctx, nil))
+	slos, err = repo.GetSLOs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, slos)
+	evaluations, err = repo.GetSLOEvaluations(ctx, SLOEvaluationFilters{Name: "configured"})
+	require.NoError(t, err)
+	assert.Empty(t, evaluations)
+}
+
+func TestEvaluateSLOQueueSubtree_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	slo := &model.QueueSLO{
+		Name:        "etl",
+		Queue:       "root.a_b",
+		Objective:   0.5,
+		StartWithin: time.Minute.Nanoseconds(),
+		Window:      time.Hour.Nanoseconds(),
+	}
+	require.NoError(t, repo.PutSLO(ctx, slo, nil))
+
+	now := time.Now()
+	submitted := now.Add(-10 * time.Minute)
+	var apps []*dao.ApplicationDAOInfo
+	for id, queue := range map[string]string{"app1": "root.a_b", "app2": "root.a_b.etl", "app3": "root.axb.etl"} {
+		apps = append(apps, &dao.ApplicationDAOInfo{
+			ApplicationID:  id,
+			Partition:      "default",
+			QueueName:      queue,
+			SubmissionTime: submitted.UnixNano(),
+			State:          "Running",
+			StateLog:       []*dao.StateDAOInfo{{Time: submitted.Add(30 * time.Second).UnixNano(), ApplicationState: "Running"}},
+		})
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	// _ is a LIKE wildcard, which must match itself only in the subtree of the queue
+	evaluation, err := repo.EvaluateSLO(ctx, slo, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), evaluation.Applications)
+}
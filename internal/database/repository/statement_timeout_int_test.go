@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestStatementTimeouts_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	cfg := *database.GetTestPostgresConfig(ctx, t)
+	cfg.PoolMaxConns = 1
+	cfg.StatementTimeouts = config.StatementTimeouts{Interactive: 30 * time.Second, Analytics: 200 * time.Millisecond}
+	pool := database.GetTestConnectionPool(ctx, t, &cfg)
+
+	timeout := func(ctx context.Context) string {
+		var timeout string
+		require.NoError(t, pool.QueryRow(ctx, "SHOW statement_timeout").Scan(&timeout))
+		return timeout
+	}
+	// the single connection of the pool is reused by the queries of all classes
+	assert.Equal(t, "30s", timeout(postgres.WithQueryClass(ctx, postgres.QueryClassInteractive)))
+	assert.Equal(t, "200ms", timeout(postgres.WithQueryClass(ctx, postgres.QueryClassAnalytics)))
+	assert.Equal(t, "0", timeout(postgres.WithQueryClass(ctx, postgres.QueryClassExport)))
+	assert.Equal(t, "0", timeout(ctx))
+
+	_, err := pool.Exec(postgres.WithQueryClass(ctx, postgres.QueryClassAnalytics), "SELECT pg_sleep(1)")
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "57014", pgErr.Code, "the statement must be cancelled")
+	_, err = pool.Exec(ctx, "SELECT pg_sleep(0.3)")
+	assert.NoError(t, err, "writes must not be limited")
+}
@@ -0,0 +1,68 @@
+package repository
+
+import "github.com/G-Research/yunikorn-history-server/internal/database/sql"
+
+// Tables queried with the sql.Builder, with the columns that filters and orderings can reference.
+// TestTablesMatchMigrations checks that the declared columns exist in the migrations.
+var (
+	applicationColumns = []string{
+		"id", "app_id", "used_resource", "max_used_resource", "pending_resource", "partition", "queue_name", "queue_id",
+		"submission_time", "finished_time", "requests", "allocations", "state", "user", "groups", "rejected_message",
+		"state_log", "place_holder_data", "has_reserved", "reservations", "max_request_priority", "spark_app_id",
+		"workflow_id", "priority", "priority_class", "wait_time", "duration", "retry_key",
+		"accept_wait", "placeholder_wait", "allocation_wait", "wait_reason",
+	}
+	applicationsTable     = sql.NewTable("applications", applicationColumns...)
+	applicationsColdTable = sql.NewTable("applications_cold", applicationColumns...)
+	// applicationTiersTable selects the applications of both tiers, see PostgresRepository.applicationsSource.
+	applicationTiersTable = sql.UnionAll(applicationsTable, applicationsColdTable)
+	// applicationSummaryTable summarizes the applications of both tiers for the lists of applications.
+	applicationSummaryTable = sql.NewTable("application_summary",
+		"application_id", "app_id", "partition", "queue_name", "user", "groups", "state", "submission_time",
+		"finished_time", "duration", "max_used_resource", "vcore_seconds", "memory_seconds", "spark_app_id",
+		"workflow_id", "wait_time",
+	)
+
+	legalHoldsTable = sql.NewTable("legal_holds",
+		"id", "partition", "queue_name", "app_id", "reason", "created_by", "created_at", "released_by", "released_at",
+		"release_reason", "version",
+	)
+	queueLineageTable = sql.NewTable("queue_lineage",
+		"id", "partition", "from_queue", "to_queue", "reason", "created_by", "created_at",
+	)
+	queueACLsTable = sql.NewTable("queue_acls",
+		"id", "partition", "queue_name", "submit_acl", "admin_acl", "submit_users", "submit_groups", "admin_users",
+		"admin_groups", "valid_from", "valid_to",
+	)
+	namespaceQueuesTable = sql.NewTable("namespace_queues",
+		"id", "partition", "namespace", "queue_name", "first_submission_time", "last_submission_time", "applications",
+	)
+	traceEventsTable = sql.NewTable("trace_events",
+		"id", "app_id", "type", "object_id", "reference_id", "change_type", "change_detail", "message", "resource",
+		"timestamp",
+	)
+	schedulerEpochsTable = sql.NewTable("scheduler_epochs",
+		"id", "instance_id", "start_time", "end_time", "detected_at", "cluster_id",
+	)
+	nodeTerminationsTable = sql.NewTable("node_terminations",
+		"id", "node_id", "partition", "terminated_at", "spot", "drained", "zone", "rack", "attributes", "allocations",
+	)
+	autoscalerEventsTable = sql.NewTable("autoscaler_events",
+		"id", "uid", "time", "reason", "direction", "object_kind", "object_namespace", "object_name", "message", "count",
+	)
+	pendingBacklogTable = sql.NewTable("pending_backlog",
+		"partition", "sampled_at", "pending_resource",
+	)
+	queueThroughputTable = sql.NewTable("queue_throughput",
+		"partition", "queue_name", "bucket_start", "started", "completed", "failed",
+	)
+	dataQualityViolationsTable = sql.NewTable("data_quality_violations",
+		"id", "check_name", "partition", "queue_name", "app_id", "node_id", "detail", "detected_at",
+	)
+	sloEvaluationsTable = sql.NewTable("slo_evaluations",
+		"slo_name", "evaluated_at", "objective", "applications", "on_time", "compliance", "met",
+	)
+	usageRollupsTable = sql.NewTable("usage_rollups",
+		"partition", "queue_name", "app_id", "user", "bucket_start", "vcore_seconds", "memory_seconds", "wait_seconds",
+	)
+)
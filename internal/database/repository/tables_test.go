@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+var (
+	createTablePattern = regexp.MustCompile(`(?s)CREATE TABLE (\w+)\s*\((.*?)\n\)[^;]*;`)
+	addColumnPattern   = regexp.MustCompile(`ALTER TABLE (\w+) ADD COLUMN (?:IF NOT EXISTS )?"?(\w+)"?`)
+	// constraintKeywords start the lines of table constraints, which are not columns.
+	constraintKeywords = map[string]bool{"CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true, "CHECK": true, "FOREIGN": true}
+)
+
+// migrationColumns returns the columns of the tables created by the up migrations.
+func migrationColumns(t *testing.T) map[string]map[string]bool {
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "*.up.sql"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	tables := make(map[string]map[string]bool)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		for _, match := range createTablePattern.FindAllStringSubmatch(string(content), -1) {
+			columns := make(map[string]bool)
+			for _, line := range strings.Split(match[2], "\n") {
+				fields := strings.Fields(strings.TrimSpace(line))
+				// LIKE copies the columns of a table created by a previous migration
+				if len(fields) > 1 && fields[0] == "LIKE" {
+					for column := range tables[strings.TrimSuffix(fields[1], ",")] {
+						columns[column] = true
+					}
+					continue
+				}
+				if len(fields) > 1 && !constraintKeywords[fields[0]] {
+					columns[strings.Trim(fields[0], `"`)] = true
+				}
+			}
+			tables[match[1]] = columns
+		}
+		for _, match := range addColumnPattern.FindAllStringSubmatch(string(content), -1) {
+			tables[match[1]][match[2]] = true
+		}
+	}
+	return tables
+}
+
+func TestTablesMatchMigrations(t *testing.T) {
+	tables := migrationColumns(t)
+	for _, table := range []*sql.Table{applicationsTable, applicationsColdTable, applicationSummaryTable, legalHoldsTable, queueLineageTable, queueACLsTable, queueThroughputTable, usageRollupsTable, dataQualityViolationsTable, sloEvaluationsTable} {
+		columns, ok := tables[table.Name()]
+		if !assert.Truef(t, ok, "table %s is not created by the migrations", table.Name()) {
+			continue
+		}
+		for _, column := range table.Columns() {
+			assert.Truef(t, columns[column], "column %s of table %s is not created by the migrations", column, table.Name())
+		}
+	}
+}
+
+func FuzzApplyApplicationFilters(f *testing.F) {
+	f.Add("john", "admin", int64(1719835200), int64(1719921600), 10, 0)
+	f.Add("'; DROP TABLE applications; --", `admin" OR 1=1`, int64(-1), int64(0), -1, -1)
+
+	f.Fuzz(func(t *testing.T, user, group string, start, end int64, limit, offset int) {
+		filters := ApplicationFilters{
+			User:                &user,
+			Groups:              []string{group},
+			SubmissionStartTime: util.ToPtr(time.Unix(start, 0)),
+			SubmissionEndTime:   util.ToPtr(time.Unix(end, 0)),
+			FinishedStartTime:   util.ToPtr(time.Unix(0, start)),
+			Limit:               &limit,
+			Offset:              &offset,
+		}
+		builder := sql.NewBuilder().SelectAll(applicationsTable, "a")
+		applyApplicationFilters(builder, filters, nil)
+		query, args, err := builder.Build()
+		if limit < 0 || offset < 0 {
+			assert.ErrorIs(t, err, sql.ErrInvalidQuery)
+			return
+		}
+		require.NoError(t, err)
+		// user input is only passed as positional arguments, the query has no literals
+		assert.NotContains(t, query, "'")
+		assert.Equal(t, len(args), strings.Count(query, "$"))
+		assert.Contains(t, args, []string{user})
+		assert.Contains(t, args, []string{group})
+	})
+}
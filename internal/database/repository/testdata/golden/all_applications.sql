@@ -0,0 +1 @@
+SELECT * FROM "applications" AS "a" ORDER BY "a"."submission_time" DESC
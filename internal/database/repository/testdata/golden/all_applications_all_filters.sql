@@ -0,0 +1,7 @@
+SELECT * FROM "applications" AS "a" WHERE "a"."submission_time" >= $1 AND "a"."submission_time" <= $2 AND "a"."finished_time" >= $3 AND "a"."finished_time" <= $4 AND "a"."groups" && $5 AND "a"."user" = ANY($6) ORDER BY "a"."submission_time" DESC LIMIT 10 OFFSET 20
+-- $1: 1719792000000000000
+-- $2: 1719878400000000000
+-- $3: 1719792000000000000
+-- $4: 1719878400000000000
+-- $5: ["admin","dev"]
+-- $6: ["john"]
@@ -0,0 +1,2 @@
+SELECT * FROM "applications" AS "a" WHERE "a"."partition" NOT LIKE $1 ORDER BY "a"."submission_time" DESC
+-- $1: "[%"
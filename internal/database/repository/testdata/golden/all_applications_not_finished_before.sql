@@ -0,0 +1,3 @@
+SELECT * FROM "applications" AS "a" WHERE "a"."submission_time" <= $1 AND ("a"."finished_time" IS NULL OR "a"."finished_time" >= $2) ORDER BY "a"."submission_time" DESC
+-- $1: 1719878400000000000
+-- $2: 1719792000000000000
@@ -0,0 +1,2 @@
+SELECT * FROM "applications" AS "a" WHERE "a"."spark_app_id" = $1 ORDER BY "a"."submission_time" DESC
+-- $1: "spark-0123456789abcdef"
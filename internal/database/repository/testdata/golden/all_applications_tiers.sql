@@ -0,0 +1,2 @@
+SELECT * FROM (SELECT * FROM "applications" UNION ALL SELECT * FROM "applications_cold") AS "a" WHERE "a"."submission_time" >= $1 ORDER BY "a"."submission_time" DESC LIMIT 10
+-- $1: 1719792000000000000
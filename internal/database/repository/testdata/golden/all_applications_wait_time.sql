@@ -0,0 +1,3 @@
+SELECT * FROM "applications" AS "a" WHERE "a"."wait_time" IS NOT NULL AND "a"."wait_time" >= $1 AND "a"."wait_time" <= $2 ORDER BY "a"."wait_time" DESC, "a"."submission_time" DESC
+-- $1: 60000000000
+-- $2: 3600000000000
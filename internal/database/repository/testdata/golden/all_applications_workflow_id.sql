@@ -0,0 +1,2 @@
+SELECT * FROM "applications" AS "a" WHERE "a"."workflow_id" = $1 ORDER BY "a"."submission_time" DESC LIMIT 10
+-- $1: "daily_etl:scheduled__2024-07-01"
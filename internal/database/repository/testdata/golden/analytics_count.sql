@@ -0,0 +1 @@
+SELECT CAST(COUNT(*) AS DOUBLE PRECISION) FROM "applications" LIMIT 100
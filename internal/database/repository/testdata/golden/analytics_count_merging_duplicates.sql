@@ -0,0 +1,9 @@
+SELECT CAST(COUNT(*) AS DOUBLE PRECISION) FROM "applications" WHERE NOT EXISTS (
+		SELECT 1 FROM (
+			SELECT app_id, partition, submission_time FROM "applications"
+			UNION ALL
+			SELECT app_id, partition, submission_time FROM "applications_cold"
+		) AS runs
+		WHERE runs.app_id = applications.app_id
+			AND (COALESCE(runs.submission_time, 0), runs.partition) >
+				(COALESCE(applications.submission_time, 0), applications.partition)) LIMIT 100
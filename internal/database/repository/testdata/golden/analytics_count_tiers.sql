@@ -0,0 +1 @@
+SELECT CAST(COUNT(*) AS DOUBLE PRECISION) FROM (SELECT * FROM "applications" UNION ALL SELECT * FROM "applications_cold") AS "applications" LIMIT 100
@@ -0,0 +1,7 @@
+SELECT date_trunc($5, to_timestamp("submission_time" / 1e9), $6), "queue_name", "has_reserved", CAST(COUNT(*) AS DOUBLE PRECISION), CAST(AVG("max_request_priority") AS DOUBLE PRECISION), CAST(MAX("finished_time") AS DOUBLE PRECISION) FROM "applications" WHERE "partition" = $1 AND "submission_time" >= $2 AND "state" = ANY($3) AND "user" = ANY($4) GROUP BY 1, 2, 3 ORDER BY 1, 2, 3 LIMIT 1001
+-- $1: "default"
+-- $2: 1719792000000000000
+-- $3: ["Completed","Failed"]
+-- $4: ["john","enc:k1:S5wklpJKP3IUl/ebRVn2afDOIWCgR2HlX0U/74aElFg"]
+-- $5: "day"
+-- $6: "Europe/Amsterdam"
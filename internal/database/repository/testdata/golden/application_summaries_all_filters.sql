@@ -0,0 +1,9 @@
+SELECT * FROM "application_summary" WHERE "queue_name" = $1 AND "partition" = $2 AND "submission_time" >= $3 AND "submission_time" <= $4 AND "finished_time" >= $5 AND "finished_time" <= $6 AND "groups" && $7 AND "user" = ANY($8) ORDER BY "submission_time" DESC LIMIT 10 OFFSET 20
+-- $1: "root.default"
+-- $2: "default"
+-- $3: 1719792000000000000
+-- $4: 1719878400000000000
+-- $5: 1719792000000000000
+-- $6: 1719878400000000000
+-- $7: ["admin","dev"]
+-- $8: ["john"]
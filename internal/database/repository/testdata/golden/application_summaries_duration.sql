@@ -0,0 +1,5 @@
+SELECT * FROM "application_summary" WHERE "queue_name" = $1 AND "partition" = $2 AND "duration" IS NOT NULL AND "duration" >= $3 AND "wait_time" <= $4 ORDER BY "duration" DESC, "submission_time" DESC
+-- $1: "root.default"
+-- $2: "default"
+-- $3: 3600000000000
+-- $4: 60000000000
@@ -0,0 +1,3 @@
+SELECT * FROM "application_summary" WHERE "queue_name" = ANY($1) AND "partition" = $2 ORDER BY "submission_time" DESC
+-- $1: ["root.default","root.legacy"]
+-- $2: "default"
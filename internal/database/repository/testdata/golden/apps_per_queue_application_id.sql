@@ -0,0 +1,4 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "app_id" = $3 ORDER BY "submission_time" DESC, "app_id" DESC
+-- $1: "root.default"
+-- $2: "default"
+-- $3: "spark-pi-1"
@@ -0,0 +1,6 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "duration" IS NOT NULL AND ("duration", "submission_time", "app_id") < ($3, $4, $5) ORDER BY "duration" DESC, "submission_time" DESC, "app_id" DESC LIMIT 11
+-- $1: "root.default"
+-- $2: "default"
+-- $3: 3600000000000
+-- $4: 1719792000000000000
+-- $5: "app-1"
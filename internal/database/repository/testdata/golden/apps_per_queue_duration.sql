@@ -0,0 +1,5 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "duration" IS NOT NULL AND "duration" >= $3 AND "duration" <= $4 ORDER BY "duration" DESC, "submission_time" DESC, "app_id" DESC LIMIT 10
+-- $1: "root.default"
+-- $2: "default"
+-- $3: 3600000000000
+-- $4: 86400000000000
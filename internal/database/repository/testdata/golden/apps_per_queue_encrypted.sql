@@ -0,0 +1,5 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "groups" && $3 AND "user" = ANY($4) ORDER BY "submission_time" DESC, "app_id" DESC
+-- $1: "root.default"
+-- $2: "default"
+-- $3: ["admin","enc:k1:P+id56UyQjOHWz0sB3VuPKV/ukSkyxIfqrvtkJ68YSI5","enc:k2:dZoog88hWKAccWN65x0RhxuN6YbnK2uCsjZCCI7k4K6J"]
+-- $4: ["john","enc:k1:S5wklpJKP3IUl/ebRVn2afDOIWCgR2HlX0U/74aElFg","enc:k2:vX/F40Ycl8t4uY90HUBhVQah5oDGAxqAyT5igToqTA4"]
@@ -0,0 +1,3 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 ORDER BY "submission_time" DESC, "app_id" DESC LIMIT 10 OFFSET 20
+-- $1: "root.default"
+-- $2: "default"
@@ -0,0 +1,5 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "submission_time" >= $3 AND "submission_time" <= $4 ORDER BY "submission_time" DESC, "app_id" DESC
+-- $1: "root.default"
+-- $2: "default"
+-- $3: 1719792000000000000
+-- $4: 1719878400000000000
@@ -0,0 +1,4 @@
+SELECT * FROM (SELECT * FROM "applications" UNION ALL SELECT * FROM "applications_cold") AS "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "finished_time" >= $3 ORDER BY "submission_time" DESC, "app_id" DESC
+-- $1: "root.default"
+-- $2: "default"
+-- $3: 1719792000000000000
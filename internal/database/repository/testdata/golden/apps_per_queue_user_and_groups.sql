@@ -0,0 +1,5 @@
+SELECT * FROM "applications" WHERE "queue_name" = $1 AND "partition" = $2 AND "groups" && $3 AND "user" = ANY($4) ORDER BY "submission_time" DESC, "app_id" DESC
+-- $1: "root.default"
+-- $2: "default"
+-- $3: ["admin","dev"]
+-- $4: ["john"]
@@ -0,0 +1 @@
+SELECT * FROM "autoscaler_events" ORDER BY "time" ASC
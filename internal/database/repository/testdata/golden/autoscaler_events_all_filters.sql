@@ -0,0 +1,3 @@
+SELECT * FROM "autoscaler_events" WHERE "time" >= $1 AND "time" < $2 ORDER BY "time" ASC
+-- $1: 1719792000000000000
+-- $2: 1719878400000000000
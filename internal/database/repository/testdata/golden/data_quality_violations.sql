@@ -0,0 +1 @@
+SELECT * FROM "data_quality_violations" ORDER BY "check_name" ASC, "id" ASC
@@ -0,0 +1,2 @@
+SELECT * FROM "data_quality_violations" WHERE "check_name" = $1 ORDER BY "check_name" ASC, "id" ASC LIMIT 50 OFFSET 100
+-- $1: "unknown_nodes"
@@ -0,0 +1,3 @@
+SELECT "bucket_start", CAST(SUM("vcore_seconds") AS DOUBLE PRECISION), CAST(SUM("memory_seconds") AS DOUBLE PRECISION) FROM "usage_rollups" WHERE "bucket_start" >= $1 AND "bucket_start" < $2 GROUP BY 1 ORDER BY 1
+-- $1: 1719792000000000000
+-- $2: 1719878400000000000
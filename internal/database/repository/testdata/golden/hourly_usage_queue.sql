@@ -0,0 +1,5 @@
+SELECT "bucket_start", CAST(SUM("vcore_seconds") AS DOUBLE PRECISION), CAST(SUM("memory_seconds") AS DOUBLE PRECISION) FROM "usage_rollups" WHERE "partition" = $1 AND "queue_name" = $2 AND "bucket_start" >= $3 AND "bucket_start" < $4 GROUP BY 1 ORDER BY 1
+-- $1: "default"
+-- $2: "root.analytics"
+-- $3: 1719792000000000000
+-- $4: 1719878400000000000
@@ -0,0 +1 @@
+SELECT * FROM "legal_holds" ORDER BY "created_at" DESC
@@ -0,0 +1,2 @@
+SELECT * FROM "legal_holds" WHERE "partition" = $1 AND "released_at" IS NULL ORDER BY "created_at" DESC
+-- $1: "default"
@@ -0,0 +1 @@
+SELECT * FROM "legal_holds" WHERE "released_at" IS NOT NULL ORDER BY "created_at" DESC LIMIT 10 OFFSET 20
@@ -0,0 +1,2 @@
+SELECT * FROM "namespace_queues" WHERE "partition" = $1 ORDER BY "namespace" ASC, "last_submission_time" DESC
+-- $1: "default"
@@ -0,0 +1,6 @@
+SELECT * FROM "namespace_queues" WHERE "partition" = $1 AND "namespace" = $2 AND "queue_name" = $3 AND "first_submission_time" <= $4 AND "last_submission_time" >= $5 ORDER BY "namespace" ASC, "last_submission_time" DESC
+-- $1: "default"
+-- $2: "team-a"
+-- $3: "root.team-a"
+-- $4: 1719878400000000000
+-- $5: 1719792000000000000
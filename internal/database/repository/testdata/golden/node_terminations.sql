@@ -0,0 +1 @@
+SELECT * FROM "node_terminations" ORDER BY "terminated_at" ASC
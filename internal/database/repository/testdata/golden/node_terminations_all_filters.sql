@@ -0,0 +1,5 @@
+SELECT * FROM "node_terminations" WHERE "partition" = $1 AND "terminated_at" >= $2 AND "terminated_at" < $3 AND "spot" = $4 ORDER BY "terminated_at" ASC
+-- $1: "default"
+-- $2: 1719792000000000000
+-- $3: 1719878400000000000
+-- $4: true
@@ -0,0 +1,2 @@
+SELECT * FROM "pending_backlog" WHERE "partition" = $1 ORDER BY "sampled_at" ASC
+-- $1: "default"
@@ -0,0 +1,4 @@
+SELECT * FROM "pending_backlog" WHERE "partition" = $1 AND "sampled_at" >= $2 AND "sampled_at" < $3 ORDER BY "sampled_at" ASC
+-- $1: "default"
+-- $2: 1719792000000000000
+-- $3: 1719878400000000000
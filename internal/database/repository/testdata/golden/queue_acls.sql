@@ -0,0 +1,3 @@
+SELECT * FROM "queue_acls" WHERE "partition" = $1 AND "queue_name" = $2 ORDER BY "valid_from" DESC
+-- $1: "default"
+-- $2: "root.analytics"
@@ -0,0 +1,5 @@
+SELECT * FROM "queue_acls" WHERE "partition" = $1 AND "queue_name" = $2 AND "valid_from" <= $3 AND ("valid_to" IS NULL OR "valid_to" > $4) ORDER BY "valid_from" DESC
+-- $1: "default"
+-- $2: "root.analytics"
+-- $3: 1719878400
+-- $4: 1719792000
@@ -0,0 +1 @@
+SELECT * FROM "queue_lineage" ORDER BY "created_at" ASC
@@ -0,0 +1,2 @@
+SELECT * FROM "queue_lineage" WHERE "partition" = $1 ORDER BY "created_at" ASC
+-- $1: "default"
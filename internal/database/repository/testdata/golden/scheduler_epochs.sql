@@ -0,0 +1 @@
+SELECT * FROM "scheduler_epochs" ORDER BY "start_time" DESC
@@ -0,0 +1,4 @@
+SELECT * FROM "scheduler_epochs" WHERE "cluster_id" = $1 AND "start_time" <= $2 AND ("end_time" IS NULL OR "end_time" > $3) ORDER BY "start_time" DESC
+-- $1: "eu-west"
+-- $2: 1719878400000000000
+-- $3: 1719792000000000000
@@ -0,0 +1,2 @@
+SELECT * FROM "slo_evaluations" WHERE "slo_name" = $1 ORDER BY "evaluated_at" ASC
+-- $1: "analytics-start"
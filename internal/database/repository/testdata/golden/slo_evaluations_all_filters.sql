@@ -0,0 +1,4 @@
+SELECT * FROM "slo_evaluations" WHERE "slo_name" = $1 AND "evaluated_at" >= $2 AND "evaluated_at" < $3 ORDER BY "evaluated_at" ASC
+-- $1: "analytics-start"
+-- $2: 1719792000
+-- $3: 1719878400
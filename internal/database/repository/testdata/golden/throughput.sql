@@ -0,0 +1,4 @@
+SELECT ("bucket_start" / $1) * $1, CAST(SUM("started") AS DOUBLE PRECISION), CAST(SUM("completed") AS DOUBLE PRECISION), CAST(SUM("failed") AS DOUBLE PRECISION) FROM "queue_throughput" WHERE "bucket_start" >= $2 AND "bucket_start" < $3 GROUP BY 1 ORDER BY 1
+-- $1: 3600000000000
+-- $2: 1719792000000000000
+-- $3: 1719878400000000000
@@ -0,0 +1,6 @@
+SELECT ("bucket_start" / $1) * $1, CAST(SUM("started") AS DOUBLE PRECISION), CAST(SUM("completed") AS DOUBLE PRECISION), CAST(SUM("failed") AS DOUBLE PRECISION) FROM "queue_throughput" WHERE "partition" = $2 AND "queue_name" = $3 AND "bucket_start" >= $4 AND "bucket_start" < $5 GROUP BY 1 ORDER BY 1
+-- $1: 86400000000000
+-- $2: "default"
+-- $3: "root.analytics"
+-- $4: 1719792000000000000
+-- $5: 1719878400000000000
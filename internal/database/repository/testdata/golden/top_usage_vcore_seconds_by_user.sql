@@ -0,0 +1,4 @@
+SELECT "user", CAST(SUM("vcore_seconds") AS DOUBLE PRECISION) FROM "usage_rollups" WHERE "bucket_start" >= $1 AND "bucket_start" < $2 AND "vcore_seconds" > $3 GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT 20
+-- $1: 1719792000000000000
+-- $2: 1719878400000000000
+-- $3: 0
@@ -0,0 +1,5 @@
+SELECT "partition", "queue_name", "app_id", CAST(SUM("wait_seconds") AS DOUBLE PRECISION) FROM "usage_rollups" WHERE "partition" = $1 AND "bucket_start" >= $2 AND "bucket_start" < $3 AND "wait_seconds" > $4 GROUP BY 1, 2, 3 ORDER BY 4 DESC, 1, 2, 3 LIMIT 5
+-- $1: "default"
+-- $2: 1719792000000000000
+-- $3: 1719878400000000000
+-- $4: 0
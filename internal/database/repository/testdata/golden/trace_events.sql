@@ -0,0 +1,2 @@
+SELECT * FROM "trace_events" WHERE "app_id" = $1 ORDER BY "timestamp" ASC
+-- $1: "app-1"
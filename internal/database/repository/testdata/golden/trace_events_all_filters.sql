@@ -0,0 +1,4 @@
+SELECT * FROM "trace_events" WHERE "app_id" = $1 AND "timestamp" >= $2 AND "timestamp" <= $3 ORDER BY "timestamp" ASC LIMIT 100 OFFSET 10
+-- $1: "app-1"
+-- $2: 1719792000000000000
+-- $3: 1719878400000000000
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// ThroughputBucketWidth is the width of the buckets the throughput counters are maintained in.
+// Coarser intervals are aggregated from them.
+const ThroughputBucketWidth = time.Hour
+
+// throughputMilestones are the states whose transitions the throughput counters count, with the column counting
+// them. The migration creating the counters must count them the same way.
+var throughputMilestones = []struct {
+	column string
+	state  string
+}{
+	{column: "started", state: "RUNNING"},
+	{column: "completed", state: "COMPLETED"},
+	{column: "failed", state: "FAILED"},
+}
+
+// ThroughputFilters select the throughput counters of a queue, or of all queues if no queue is given,
+// between Start (inclusive) and End (exclusive), summed per Interval, a multiple of ThroughputBucketWidth.
+type ThroughputFilters struct {
+	Partition *string
+	Queue     *string
+	Start     time.Time
+	End       time.Time
+	Interval  time.Duration
+	// FormerQueues are the former paths of the queue, see GetQueueLineage, whose applications are counted as well.
+	FormerQueues []string
+}
+
+// normalizeState returns the application state in upper case without the APP_ prefix, as states are recorded
+// as Running by the scheduler and as APP_RUNNING from events.
+func normalizeState(state string) string {
+	return strings.TrimPrefix(strings.ToUpper(state), "APP_")
+}
+
+// newlyReachedState returns when the application reached the state in Unix nanoseconds, if it did since it was
+// previously stored.
+func newlyReachedState(app *dao.ApplicationDAOInfo, previous *storedApplication, milestone string, now time.Time) (int64, bool) {
+	// the state log of the application is kept if it is not sent again
+	stateLog := app.StateLog
+	if stateLog == nil && previous != nil {
+		stateLog = previous.StateLog
+	}
+	reachedAt, reached := reachedState(app.State, stateLog, app.FinishedTime, milestone, now)
+	if !reached {
+		return 0, false
+	}
+	if previous != nil {
+		if _, reachedBefore := reachedState(previous.state(), previous.StateLog, nil, milestone, now); reachedBefore {
+			return 0, false
+		}
+	}
+	return reachedAt, true
+}
+
+// reachedState returns when the application reached the state in Unix nanoseconds, and whether it did.
+// It is the time of the first entry of the state log in that state, or if the application is in that state
+// without such an entry, its finished time for final states, or now.
+func reachedState(state string, stateLog []*dao.StateDAOInfo, finishedTime *int64, milestone string, now time.Time) (int64, bool) {
+	if reachedAt := stateLogTime(stateLog, milestone); reachedAt > 0 {
+		return reachedAt, true
+	}
+	if normalizeState(state) != milestone {
+		return 0, false
+	}
+	if milestone != "RUNNING" && finishedTime != nil && *finishedTime > 0 {
+		return *finishedTime, true
+	}
+	return now.UnixNano(), true
+}
+
+// stateLogTime returns when the application first reached the state according to its state log in Unix
+// nanoseconds, or 0 if the state log does not tell.
+func stateLogTime(stateLog []*dao.StateDAOInfo, milestone string) int64 {
+	var reachedAt int64
+	for _, entry := range stateLog {
+		if entry != nil && entry.Time > 0 && normalizeState(entry.ApplicationState) == milestone &&
+			(reachedAt == 0 || entry.Time < reachedAt) {
+			reachedAt = entry.Time
+		}
+	}
+	return reachedAt
+}
+
+// countThroughput increments the throughput counters of the states the application reached since it was
+// previously stored, so that storing an application again does not count it again.
+func countThroughput(ctx context.Context, tx pgx.Tx, app *dao.ApplicationDAOInfo, previous *storedApplication, now time.Time) error {
+	incrementSQL := `INSERT INTO queue_throughput (partition, queue_name, bucket_start, started, completed, failed)
+		VALUES (@partition, @queue_name, @bucket_start, @started, @completed, @failed)
+		ON CONFLICT (partition, queue_name, bucket_start) DO UPDATE SET
+			started = queue_throughput.started + EXCLUDED.started,
+			completed = queue_throughput.completed + EXCLUDED.completed,
+			failed = queue_throughput.failed + EXCLUDED.failed`
+
+	for _, m := range throughputMilestones {
+		reachedAt, reached := newlyReachedState(app, previous, m.state, now)
+		if !reached {
+			continue
+		}
+		args := pgx.NamedArgs{
+			"partition":    app.Partition,
+			"queue_name":   app.QueueName,
+			"bucket_start": reachedAt - reachedAt%ThroughputBucketWidth.Nanoseconds(),
+			"started":      0,
+			"completed":    0,
+			"failed":       0,
+		}
+		args[m.column] = 1
+		if _, err := tx.Exec(ctx, incrementSQL, args); err != nil {
+			return fmt.Errorf("could not count throughput of application %s in DB: %v", app.ApplicationID, err)
+		}
+	}
+	return nil
+}
+
+func (s *PostgresRepository) GetQueueThroughput(ctx context.Context, filters ThroughputFilters) ([]*model.ThroughputBucket, error) {
+	builder, err := throughputQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get queue throughput from DB: %v", err)
+	}
+	defer rows.Close()
+
+	var buckets []*model.ThroughputBucket
+	for rows.Next() {
+		var start int64
+		var started, completed, failed float64
+		if err := rows.Scan(&start, &started, &completed, &failed); err != nil {
+			return nil, fmt.Errorf("could not scan queue throughput from DB: %v", err)
+		}
+		buckets = append(buckets, &model.ThroughputBucket{
+			Start:     time.Unix(0, start).UTC(),
+			Started:   int64(started),
+			Completed: int64(completed),
+			Failed:    int64(failed),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read queue throughput from DB: %v", err)
+	}
+	return buckets, nil
+}
+
+// throughputQuery sums the throughput counters per interval, starting at multiples of the interval since
+// the Unix epoch.
+func throughputQuery(filters ThroughputFilters) (*sql.Builder, error) {
+	if filters.Interval <= 0 || filters.Interval%ThroughputBucketWidth != 0 {
+		return nil, fmt.Errorf("%w: interval %s is not a multiple of %s", sql.ErrInvalidQuery, filters.Interval, ThroughputBucketWidth)
+	}
+	builder := sql.NewBuilder().SelectAll(queueThroughputTable, "").
+		GroupByMultiple("bucket_start", filters.Interval.Nanoseconds()).
+		Aggregate(sql.Sum, "started").
+		Aggregate(sql.Sum, "completed").
+		Aggregate(sql.Sum, "failed")
+	if filters.Partition != nil {
+		builder.Conditionp("partition", sql.Equal, *filters.Partition)
+	}
+	if filters.Queue != nil {
+		conditionQueue(builder, *filters.Queue, filters.FormerQueues)
+	}
+	builder.Conditionp("bucket_start", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	builder.Conditionp("bucket_start", sql.LessThan, filters.End.UnixNano())
+	return builder, nil
+}
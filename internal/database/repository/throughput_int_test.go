@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestQueueThroughput_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	queues := []*dao.PartitionQueueDAOInfo{{
+		Partition: "default",
+		QueueName: "root",
+		Children: []dao.PartitionQueueDAOInfo{
+			{Partition: "default", QueueName: "root.a", Parent: "root"},
+			{Partition: "default", QueueName: "root.b", Parent: "root"},
+		},
+	}}
+	require.NoError(t, repo.AddQueues(ctx, nil, queues))
+
+	t1 := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	running := []*dao.StateDAOInfo{
+		{Time: t1.Add(5 * time.Minute).UnixNano(), ApplicationState: "New"},
+		{Time: t1.Add(10 * time.Minute).UnixNano(), ApplicationState: "Running"},
+	}
+	completed := []*dao.StateDAOInfo{
+		running[0],
+		running[1],
+		{Time: t1.Add(2*time.Hour + 5*time.Minute).UnixNano(), ApplicationState: "Completed"},
+	}
+	upsert := func(apps ...*dao.ApplicationDAOInfo) {
+		t.Helper()
+		require.NoError(t, repo.UpsertApplications(ctx, apps))
+	}
+	upsert(&dao.ApplicationDAOInfo{ApplicationID: "app1", Partition: "default", QueueName: "root.a", State: "Running", StateLog: running})
+	// storing the application again does not count it again
+	upsert(&dao.ApplicationDAOInfo{ApplicationID: "app1", Partition: "default", QueueName: "root.a", State: "Running", StateLog: running})
+	upsert(&dao.ApplicationDAOInfo{ApplicationID: "app1", Partition: "default", QueueName: "root.a", State: "Completed", StateLog: completed})
+	upsert(&dao.ApplicationDAOInfo{
+		ApplicationID: "app2",
+		Partition:     "default",
+		QueueName:     "root.b",
+		State:         "Failed",
+		FinishedTime:  util.ToPtr(t1.Add(2*time.Hour + 30*time.Minute).UnixNano()),
+	})
+
+	buckets, err := repo.GetQueueThroughput(ctx, ThroughputFilters{
+		Partition: util.ToPtr("default"),
+		Queue:     util.ToPtr("root.a"),
+		Start:     t1,
+		End:       t1.Add(3 * time.Hour),
+		Interval:  time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ThroughputBucket{
+		{Start: t1, Started: 1},
+		{Start: t1.Add(2 * time.Hour), Completed: 1},
+	}, buckets)
+
+	buckets, err = repo.GetQueueThroughput(ctx, ThroughputFilters{Start: t1, End: t1.Add(24 * time.Hour), Interval: 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ThroughputBucket{{Start: t1, Started: 1, Completed: 1, Failed: 1}}, buckets)
+}
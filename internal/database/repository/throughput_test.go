@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestReachedState(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	stateLog := []*dao.StateDAOInfo{
+		{Time: 100, ApplicationState: "New"},
+		{Time: 200, ApplicationState: "Running"},
+		{Time: 300, ApplicationState: "Completing"},
+		{Time: 400, ApplicationState: "Running"},
+		{Time: 0, ApplicationState: "Completed"},
+	}
+	tests := map[string]struct {
+		state        string
+		stateLog     []*dao.StateDAOInfo
+		finishedTime *int64
+		milestone    string
+		reachedAt    int64
+		reached      bool
+	}{
+		"first entry of the state log": {
+			state: "Completed", stateLog: stateLog, milestone: "RUNNING", reachedAt: 200, reached: true,
+		},
+		"event state": {
+			state: "APP_RUNNING", stateLog: []*dao.StateDAOInfo{{Time: 50, ApplicationState: "APP_RUNNING"}},
+			milestone: "RUNNING", reachedAt: 50, reached: true,
+		},
+		"finished time without entry": {
+			state: "Completed", stateLog: stateLog, finishedTime: util.ToPtr(int64(500)), milestone: "COMPLETED",
+			reachedAt: 500, reached: true,
+		},
+		"now without entry": {
+			state: "Running", milestone: "RUNNING", finishedTime: util.ToPtr(int64(500)), reachedAt: now.UnixNano(),
+			reached: true,
+		},
+		"not reached": {
+			state: "Completed", stateLog: stateLog, milestone: "FAILED",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reachedAt, reached := reachedState(tt.state, tt.stateLog, tt.finishedTime, tt.milestone, now)
+			assert.Equal(t, tt.reached, reached)
+			assert.Equal(t, tt.reachedAt, reachedAt)
+		})
+	}
+}
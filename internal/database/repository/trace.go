@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// TraceEventFilters select the events of the trace of an application which happened during a period.
+type TraceEventFilters struct {
+	Start  *time.Time
+	End    *time.Time
+	Offset *int
+	Limit  *int
+}
+
+// AddTraceEvent stores the event in the trace of its application, unless the trace already has maxEvents events.
+// It returns whether the event was stored.
+func (s *PostgresRepository) AddTraceEvent(ctx context.Context, event *model.TraceEvent, maxEvents int) (bool, error) {
+	insertSQL := `INSERT INTO trace_events (app_id, type, object_id, reference_id, change_type, change_detail,
+			message, resource, timestamp)
+		SELECT @app_id, @type, @object_id, @reference_id, @change_type, @change_detail, @message, @resource, @timestamp
+		WHERE (SELECT COUNT(*) FROM trace_events WHERE app_id = @app_id) < @max_events`
+	tag, err := s.dbpool.Exec(ctx, insertSQL, pgx.NamedArgs{
+		"app_id":        event.ApplicationID,
+		"type":          event.Type,
+		"object_id":     event.ObjectID,
+		"reference_id":  event.ReferenceID,
+		"change_type":   event.ChangeType,
+		"change_detail": event.ChangeDetail,
+		"message":       event.Message,
+		"resource":      event.Resource,
+		"timestamp":     event.Timestamp,
+		"max_events":    maxEvents,
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not add trace event of application %s in DB: %v", event.ApplicationID, err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetTraceEvents returns the events of the trace of the application, in the order they happened.
+func (s *PostgresRepository) GetTraceEvents(
+	ctx context.Context, appID string, filters TraceEventFilters) ([]*model.TraceEvent, error) {
+	query, args, err := traceEventsQuery(appID, filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get trace events from DB: %v", err)
+	}
+
+	var events []*model.TraceEvent
+	err = forEachRow(rows, "trace events", func(event *model.TraceEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// traceEventsQuery builds the query of GetTraceEvents.
+func traceEventsQuery(appID string, filters TraceEventFilters) *sql.Builder {
+	queryBuilder := sql.NewBuilder().
+		SelectAll(traceEventsTable, "").
+		Conditionp("app_id", sql.Equal, appID).
+		OrderBy("timestamp", sql.OrderByAscending)
+	if filters.Start != nil {
+		queryBuilder.Conditionp("timestamp", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	}
+	if filters.End != nil {
+		queryBuilder.Conditionp("timestamp", sql.LessThanOrEqual, filters.End.UnixNano())
+	}
+	applyLimitAndOffset(queryBuilder, filters.Limit, filters.Offset)
+	return queryBuilder
+}
+
+// DeleteTraceEventsBefore deletes the trace events which happened before the given time and returns the number
+// of deleted events.
+func (s *PostgresRepository) DeleteTraceEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.dbpool.Exec(ctx, "DELETE FROM trace_events WHERE timestamp < $1", before.UnixNano())
+	if err != nil {
+		return 0, fmt.Errorf("could not delete trace events from DB: %v", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestTraceEvents_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	event := func(appID string, at time.Time) *model.TraceEvent {
+		return &model.TraceEvent{
+			ApplicationID: appID,
+			Type:          "REQUEST",
+			ObjectID:      "pod-1",
+			ReferenceID:   util.ToPtr(appID),
+			ChangeType:    "NONE",
+			ChangeDetail:  "DETAILS_NONE",
+			Message:       util.ToPtr("predicate failed"),
+			Resource:      map[string]int64{"memory": 1024},
+			Timestamp:     at.UnixNano(),
+		}
+	}
+	for i := 0; i < 3; i++ {
+		stored, err := repo.AddTraceEvent(ctx, event("app-1", start.Add(time.Duration(i)*time.Minute)), 2)
+		require.NoError(t, err)
+		// the trace is capped at 2 events
+		assert.Equal(t, i < 2, stored)
+	}
+	stored, err := repo.AddTraceEvent(ctx, event("app-2", start.Add(time.Hour)), 2)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	events, err := repo.GetTraceEvents(ctx, "app-1", TraceEventFilters{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, start.UnixNano(), events[0].Timestamp)
+	assert.Equal(t, map[string]int64{"memory": 1024}, events[0].Resource)
+	assert.Equal(t, util.ToPtr("predicate failed"), events[0].Message)
+
+	events, err = repo.GetTraceEvents(ctx, "app-1", TraceEventFilters{Start: util.ToPtr(start.Add(time.Second))})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, start.Add(time.Minute).UnixNano(), events[0].Timestamp)
+
+	deleted, err := repo.DeleteTraceEventsBefore(ctx, start.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	events, err = repo.GetTraceEvents(ctx, "app-2", TraceEventFilters{})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
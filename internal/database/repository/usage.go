@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	siCommon "github.com/apache/yunikorn-scheduler-interface/lib/go/common"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// UsageMetric is a metric of the usage rollups which top consumers are ranked by.
+type UsageMetric string
+
+const (
+	// VcoreSeconds is the number of vcores used times the number of seconds they were used.
+	VcoreSeconds UsageMetric = "vcore_seconds"
+	// MemorySeconds is the number of bytes of memory used times the number of seconds they were used.
+	MemorySeconds UsageMetric = "memory_seconds"
+	// WaitTime is the number of seconds applications waited from their submission until they started running.
+	WaitTime UsageMetric = "wait_time"
+)
+
+// UsageMetrics are the supported usage metrics.
+var UsageMetrics = []UsageMetric{VcoreSeconds, MemorySeconds, WaitTime}
+
+// usageMetricColumns maps the usage metrics to the columns of the usage rollups.
+var usageMetricColumns = map[UsageMetric]string{
+	VcoreSeconds:  "vcore_seconds",
+	MemorySeconds: "memory_seconds",
+	WaitTime:      "wait_seconds",
+}
+
+// UsageGrouping is the kind of consumer the usage rollups are summed per.
+type UsageGrouping string
+
+const (
+	ByUser        UsageGrouping = "user"
+	ByQueue       UsageGrouping = "queue"
+	ByApplication UsageGrouping = "app"
+)
+
+// UsageGroupings are the supported usage groupings.
+var UsageGroupings = []UsageGrouping{ByUser, ByQueue, ByApplication}
+
+// usageGroupingColumns maps the usage groupings to the columns identifying a consumer.
+var usageGroupingColumns = map[UsageGrouping][]string{
+	ByUser:        {"user"},
+	ByQueue:       {"partition", "queue_name"},
+	ByApplication: {"partition", "queue_name", "app_id"},
+}
+
+// UsageRollupWidth is the width of the buckets the usage rollups are maintained in.
+const UsageRollupWidth = time.Hour
+
+// TopUsageFilters select the K consumers with the largest sum of the metric between Start (inclusive) and
+// End (exclusive), optionally in a partition.
+type TopUsageFilters struct {
+	Metric    UsageMetric
+	By        UsageGrouping
+	Partition *string
+	Start     time.Time
+	End       time.Time
+	K         int
+}
+
+// rollUpUsage adds the resources the application used since it was previously stored, and the time it waited to
+// start running if it started since, to the usage rollups. The resources used since the previous observation are
+// the ones used at that observation, until the application finished.
+func (s *PostgresRepository) rollUpUsage(
+	ctx context.Context,
+	tx pgx.Tx,
+	app *dao.ApplicationDAOInfo,
+	previous *storedApplication,
+	now time.Time,
+) error {
+	incrementSQL := `INSERT INTO usage_rollups (partition, queue_name, app_id, "user", bucket_start,
+			vcore_seconds, memory_seconds, wait_seconds)
+		VALUES (@partition, @queue_name, @app_id, @user, @bucket_start, @vcore_seconds, @memory_seconds, @wait_seconds)
+		ON CONFLICT (partition, queue_name, app_id, bucket_start) DO UPDATE SET
+			"user" = COALESCE(EXCLUDED."user", usage_rollups."user"),
+			vcore_seconds = usage_rollups.vcore_seconds + EXCLUDED.vcore_seconds,
+			memory_seconds = usage_rollups.memory_seconds + EXCLUDED.memory_seconds,
+			wait_seconds = usage_rollups.wait_seconds + EXCLUDED.wait_seconds`
+
+	var user *string
+	if app.User != "" {
+		user = new(string)
+		*user = s.cipher.Encrypt(app.User)
+	} else if previous != nil {
+		user = previous.User
+	}
+	increment := func(at int64, vcoreSeconds, memorySeconds, waitSeconds float64) error {
+		_, err := tx.Exec(ctx, incrementSQL, pgx.NamedArgs{
+			"partition":      app.Partition,
+			"queue_name":     app.QueueName,
+			"app_id":         app.ApplicationID,
+			"user":           user,
+			"bucket_start":   at - at%UsageRollupWidth.Nanoseconds(),
+			"vcore_seconds":  vcoreSeconds,
+			"memory_seconds": memorySeconds,
+			"wait_seconds":   waitSeconds,
+		})
+		if err != nil {
+			return fmt.Errorf("could not roll up usage of application %s in DB: %v", app.ApplicationID, err)
+		}
+		return nil
+	}
+
+	if previous != nil && previous.UsageObservedAt != nil {
+		from, to := *previous.UsageObservedAt, now.UnixNano()
+		if app.FinishedTime != nil && *app.FinishedTime > 0 && *app.FinishedTime < to {
+			to = *app.FinishedTime
+		}
+		// YuniKorn measures vcores in thousandths
+		vcores := float64(previous.UsedResource[siCommon.CPU]) / 1000
+		memory := float64(previous.UsedResource[siCommon.Memory])
+		for start := from; start < to && (vcores > 0 || memory > 0); {
+			end := min(start-start%UsageRollupWidth.Nanoseconds()+UsageRollupWidth.Nanoseconds(), to)
+			seconds := float64(end-start) / 1e9
+			if err := increment(start, vcores*seconds, memory*seconds, 0); err != nil {
+				return err
+			}
+			start = end
+		}
+	}
+
+	submittedAt := app.SubmissionTime
+	if submittedAt == 0 && previous != nil && previous.SubmissionTime != nil {
+		submittedAt = *previous.SubmissionTime
+	}
+	startedAt, started := newlyReachedState(app, previous, "RUNNING", now)
+	if started && submittedAt > 0 && startedAt >= submittedAt {
+		return increment(startedAt, 0, 0, float64(startedAt-submittedAt)/1e9)
+	}
+	return nil
+}
+
+func (s *PostgresRepository) GetTopUsage(ctx context.Context, filters TopUsageFilters) ([]*model.TopUsage, error) {
+	builder, err := topUsageQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get top usage from DB: %v", err)
+	}
+	defer rows.Close()
+
+	var top []*model.TopUsage
+	// with encryption, the rollups of a user are grouped per key until they are re-encrypted with the active key
+	users := make(map[string]*model.TopUsage)
+	for rows.Next() {
+		var usage model.TopUsage
+		var user *string
+		var err error
+		switch filters.By {
+		case ByUser:
+			err = rows.Scan(&user, &usage.Value)
+		case ByQueue:
+			err = rows.Scan(&usage.Partition, &usage.QueueName, &usage.Value)
+		default:
+			err = rows.Scan(&usage.Partition, &usage.QueueName, &usage.ApplicationID, &usage.Value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not scan top usage from DB: %v", err)
+		}
+		if filters.By == ByUser {
+			if user != nil {
+				if usage.User, err = s.cipher.Decrypt(*user); err != nil {
+					return nil, err
+				}
+			}
+			if merged, ok := users[usage.User]; ok {
+				merged.Value += usage.Value
+				continue
+			}
+			users[usage.User] = &usage
+		}
+		top = append(top, &usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read top usage from DB: %v", err)
+	}
+	return top, nil
+}
+
+// topUsageQuery sums the metric of the usage rollups per consumer, ordered by the sum in descending order.
+func topUsageQuery(filters TopUsageFilters) (*sql.Builder, error) {
+	column, ok := usageMetricColumns[filters.Metric]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown usage metric %q", sql.ErrInvalidQuery, filters.Metric)
+	}
+	groups, ok := usageGroupingColumns[filters.By]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown usage grouping %q", sql.ErrInvalidQuery, filters.By)
+	}
+	builder := sql.NewBuilder().SelectAll(usageRollupsTable, "")
+	for _, group := range groups {
+		builder.GroupBy(group)
+	}
+	builder.Aggregate(sql.Sum, column).OrderByAggregate(sql.OrderByDescending)
+	if filters.Partition != nil {
+		builder.Conditionp("partition", sql.Equal, *filters.Partition)
+	}
+	builder.Conditionp("bucket_start", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	builder.Conditionp("bucket_start", sql.LessThan, filters.End.UnixNano())
+	builder.Conditionp(column, sql.GreaterThan, 0)
+	return builder.Limit(filters.K), nil
+}
+
+// HourlyUsageFilters select the usage rollups of a queue, or of all queues if no queue is given, between Start
+// (inclusive) and End (exclusive).
+type HourlyUsageFilters struct {
+	Partition *string
+	Queue     *string
+	Start     time.Time
+	End       time.Time
+}
+
+// GetHourlyUsage returns the resources used per hour, summed over the applications, for the hours any were used.
+func (s *PostgresRepository) GetHourlyUsage(ctx context.Context, filters HourlyUsageFilters) ([]*model.HourlyUsage, error) {
+	query, args, err := hourlyUsageQuery(filters).Build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get hourly usage from DB: %v", err)
+	}
+	defer rows.Close()
+
+	var hours []*model.HourlyUsage
+	for rows.Next() {
+		var start int64
+		var usage model.HourlyUsage
+		if err := rows.Scan(&start, &usage.VcoreSeconds, &usage.MemorySeconds); err != nil {
+			return nil, fmt.Errorf("could not scan hourly usage from DB: %v", err)
+		}
+		usage.Start = time.Unix(0, start).UTC()
+		hours = append(hours, &usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read hourly usage from DB: %v", err)
+	}
+	return hours, nil
+}
+
+// hourlyUsageQuery sums the used resources of the usage rollups per hour.
+func hourlyUsageQuery(filters HourlyUsageFilters) *sql.Builder {
+	builder := sql.NewBuilder().SelectAll(usageRollupsTable, "").
+		GroupBy("bucket_start").
+		Aggregate(sql.Sum, "vcore_seconds").
+		Aggregate(sql.Sum, "memory_seconds")
+	if filters.Partition != nil {
+		builder.Conditionp("partition", sql.Equal, *filters.Partition)
+	}
+	if filters.Queue != nil {
+		builder.Conditionp("queue_name", sql.Equal, *filters.Queue)
+	}
+	builder.Conditionp("bucket_start", sql.GreaterThanOrEqual, filters.Start.UnixNano())
+	builder.Conditionp("bucket_start", sql.LessThan, filters.End.UnixNano())
+	return builder
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestUsageRollups_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	queues := []*dao.PartitionQueueDAOInfo{{
+		Partition: "default",
+		QueueName: "root",
+		Children: []dao.PartitionQueueDAOInfo{
+			{Partition: "default", QueueName: "root.a", Parent: "root"},
+			{Partition: "default", QueueName: "root.b", Parent: "root"},
+		},
+	}}
+	require.NoError(t, repo.AddQueues(ctx, nil, queues))
+
+	submitted := time.Now().Add(-3 * time.Hour)
+	running := func(id, queue, user string, wait time.Duration, vcores int64) *dao.ApplicationDAOInfo {
+		return &dao.ApplicationDAOInfo{
+			ApplicationID:  id,
+			Partition:      "default",
+			QueueName:      queue,
+			User:           user,
+			SubmissionTime: submitted.UnixNano(),
+			State:          "Running",
+			StateLog:       []*dao.StateDAOInfo{{Time: submitted.Add(wait).UnixNano(), ApplicationState: "Running"}},
+			UsedResource:   map[string]int64{"vcore": vcores, "memory": 1 << 30},
+		}
+	}
+	apps := []*dao.ApplicationDAOInfo{
+		running("app1", "root.a", "alice", 30*time.Second, 2000),
+		running("app2", "root.a", "bob", 90*time.Second, 1000),
+		running("app3", "root.b", "alice", 10*time.Second, 500),
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	// the resources are used from the previous observation, two hours ago
+	_, err = connPool.Exec(ctx, "UPDATE applications SET usage_observed_at = $1", time.Now().Add(-2*time.Hour).UnixNano())
+	require.NoError(t, err)
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+
+	filters := TopUsageFilters{
+		Start: time.Now().Add(-24 * time.Hour),
+		End:   time.Now().Add(time.Hour),
+		K:     10,
+	}
+	filters.Metric, filters.By = VcoreSeconds, ByUser
+	top, err := repo.GetTopUsage(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "alice", top[0].User)
+	assert.InDelta(t, 2.5*7200, top[0].Value, 10)
+	assert.Equal(t, "bob", top[1].User)
+	assert.InDelta(t, 7200, top[1].Value, 10)
+
+	filters.Metric, filters.By = MemorySeconds, ByQueue
+	top, err = repo.GetTopUsage(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "root.a", top[0].QueueName)
+	assert.InDelta(t, 2*7200*float64(1<<30), top[0].Value, 10*float64(1<<30))
+
+	// storing the applications again does not count their wait time again
+	filters.Metric, filters.By, filters.K = WaitTime, ByApplication, 2
+	filters.Partition = util.ToPtr("default")
+	top, err = repo.GetTopUsage(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "app2", top[0].ApplicationID)
+	assert.Equal(t, "root.a", top[0].QueueName)
+	assert.InDelta(t, 90, top[0].Value, 0.001)
+	assert.Equal(t, "app1", top[1].ApplicationID)
+	assert.InDelta(t, 30, top[1].Value, 0.001)
+
+	hours, err := repo.GetHourlyUsage(ctx, HourlyUsageFilters{
+		Partition: util.ToPtr("default"),
+		Queue:     util.ToPtr("root.a"),
+		Start:     filters.Start,
+		End:       filters.End,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, hours)
+	var vcoreSeconds, memorySeconds float64
+	for i, hour := range hours {
+		if i > 0 {
+			assert.True(t, hour.Start.After(hours[i-1].Start))
+		}
+		vcoreSeconds += hour.VcoreSeconds
+		memorySeconds += hour.MemorySeconds
+	}
+	assert.InDelta(t, 3*7200, vcoreSeconds, 10)
+	assert.InDelta(t, 2*7200*float64(1<<30), memorySeconds, 10*float64(1<<30))
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// StartUserErasure stores the erasure if no erasure of the same user is in progress. Otherwise, the erasure
+// is replaced with the one in progress, so that it can be resumed with the same pseudonym and mode.
+func (s *PostgresRepository) StartUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	insertSQL := `INSERT INTO user_erasures (subject_hash, pseudonym, mode, status, requested_by, created_at)
+		VALUES (@subject_hash, @pseudonym, @mode, @status, @requested_by, @created_at)
+		ON CONFLICT (subject_hash) WHERE status = 'in_progress' DO UPDATE SET subject_hash = EXCLUDED.subject_hash
+		RETURNING *`
+	rows, err := s.dbpool.Query(ctx, insertSQL, pgx.NamedArgs{
+		"subject_hash": erasure.SubjectHash,
+		"pseudonym":    erasure.Pseudonym,
+		"mode":         erasure.Mode,
+		"status":       model.ErasureStatusInProgress,
+		"requested_by": erasure.RequestedBy,
+		"created_at":   time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not insert user erasure into DB: %v", err)
+	}
+	stored, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[model.UserErasure])
+	if err != nil {
+		return fmt.Errorf("could not insert user erasure into DB: %v", err)
+	}
+	*erasure = stored
+	return nil
+}
+
+// UpdateUserErasure stores the progress and status of the erasure.
+func (s *PostgresRepository) UpdateUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	updateSQL := `UPDATE user_erasures SET status = @status, completed_at = @completed_at,
+		applications = @applications, applications_retained = @applications_retained, audit_records = @audit_records
+		WHERE id = @id`
+	_, err := s.dbpool.Exec(ctx, updateSQL, pgx.NamedArgs{
+		"id":                    erasure.ID,
+		"status":                erasure.Status,
+		"completed_at":          erasure.CompletedAt,
+		"applications":          erasure.Applications,
+		"applications_retained": erasure.ApplicationsRetained,
+		"audit_records":         erasure.AuditRecords,
+	})
+	if err != nil {
+		return fmt.Errorf("could not update user erasure in DB: %v", err)
+	}
+	return nil
+}
+
+// EraseUserApplications pseudonymizes or deletes, depending on the erasure mode, at most limit applications
+// of the user in both tiers and returns the number of erased applications. Applications under an active legal hold
+// are not erased.
+func (s *PostgresRepository) EraseUserApplications(
+	ctx context.Context, user, pseudonym, mode string, limit int) (int64, error) {
+	var total int64
+	for _, tier := range applicationTiers {
+		if total >= int64(limit) {
+			break
+		}
+		// the tier is aliased as applications, which the legal hold condition refers to
+		batch := `SELECT id FROM ` + tier.From() + ` AS applications WHERE "user" = ANY($1) AND ` + applicationNotHeld +
+			` LIMIT $2`
+		args := []any{s.cipher.Candidates(user), int64(limit) - total}
+		var query string
+		switch mode {
+		case model.ErasureModePseudonymize:
+			query = `WITH erased AS (UPDATE ` + tier.From() + ` SET "user" = $3 WHERE id IN (` + batch + `) RETURNING id),
+				summarized AS (UPDATE application_summary SET "user" = $3 WHERE application_id IN (SELECT id FROM erased))
+				SELECT COUNT(*) FROM erased`
+			args = append(args, s.cipher.Encrypt(pseudonym))
+		case model.ErasureModeDelete:
+			query = deleteApplicationsSQL(tier, `id IN (`+batch+`)`)
+		default:
+			return 0, fmt.Errorf("unknown erasure mode: %s", mode)
+		}
+		var erased int64
+		if err := s.dbpool.QueryRow(ctx, query, args...).Scan(&erased); err != nil {
+			return total, fmt.Errorf("could not erase user applications in DB: %v", err)
+		}
+		total += erased
+	}
+	return total, nil
+}
+
+// CountUserApplications returns the number of applications of the user in both tiers.
+func (s *PostgresRepository) CountUserApplications(ctx context.Context, user string) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM ` + applicationTiersTable.From() + ` WHERE "user" = ANY($1)`
+	if err := s.dbpool.QueryRow(ctx, query, s.cipher.Candidates(user)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("could not count user applications in DB: %v", err)
+	}
+	return count, nil
+}
+
+// PseudonymizeAuditRecords replaces the user with the pseudonym in the audit records, such as the creators
+// of legal holds, and in the usage rollups of the applications which are not under an active legal hold,
+// and returns the number of updated records. The usage rollups are pseudonymized whatever the erasure mode,
+// so that the usage of the cluster is still accounted for.
+func (s *PostgresRepository) PseudonymizeAuditRecords(ctx context.Context, user, pseudonym string) (int64, error) {
+	updateSQL := `UPDATE legal_holds SET
+			created_by = CASE WHEN created_by = $1 THEN $2 ELSE created_by END,
+			released_by = CASE WHEN released_by = $1 THEN $2 ELSE released_by END
+		WHERE created_by = $1 OR released_by = $1`
+	tag, err := s.dbpool.Exec(ctx, updateSQL, user, pseudonym)
+	if err != nil {
+		return 0, fmt.Errorf("could not pseudonymize audit records in DB: %v", err)
+	}
+	// the rollups are aliased as applications, which the legal hold condition refers to
+	rollupsSQL := `UPDATE usage_rollups AS applications SET "user" = $2 WHERE "user" = ANY($1) AND ` + applicationNotHeld
+	rollups, err := s.dbpool.Exec(ctx, rollupsSQL, s.cipher.Candidates(user), s.cipher.Encrypt(pseudonym))
+	if err != nil {
+		return 0, fmt.Errorf("could not pseudonymize usage rollups in DB: %v", err)
+	}
+	return tag.RowsAffected() + rollups.RowsAffected(), nil
+}
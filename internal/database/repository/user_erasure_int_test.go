@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestUserErasure_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	if err != nil {
+		t.Fatalf("could not create repository: %v", err)
+	}
+
+	queues := []*dao.PartitionQueueDAOInfo{{Partition: "default", QueueName: "root"}}
+	require.NoError(t, repo.AddQueues(ctx, nil, queues))
+	apps := []*dao.ApplicationDAOInfo{
+		{ApplicationID: "app1", Partition: "default", QueueName: "root", User: "alice"},
+		{ApplicationID: "app2", Partition: "default", QueueName: "root", User: "alice"},
+		{ApplicationID: "app3", Partition: "default", QueueName: "root", User: "alice"},
+		{ApplicationID: "app4", Partition: "default", QueueName: "root", User: "bob"},
+	}
+	require.NoError(t, repo.UpsertApplications(ctx, apps))
+	require.NoError(t, repo.CreateLegalHold(ctx, &model.LegalHold{
+		Partition:     "default",
+		ApplicationID: util.ToPtr("app3"),
+		Reason:        "investigation",
+		CreatedBy:     "alice",
+	}))
+
+	erasure := &model.UserErasure{SubjectHash: "hash", Pseudonym: "erased-1", Mode: model.ErasureModePseudonymize, RequestedBy: "ops"}
+	require.NoError(t, repo.StartUserErasure(ctx, erasure))
+	assert.NotEmpty(t, erasure.ID)
+	assert.Equal(t, model.ErasureStatusInProgress, erasure.Status)
+
+	erased, err := repo.EraseUserApplications(ctx, "alice", erasure.Pseudonym, erasure.Mode, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), erased)
+	erasure.Applications = erased
+	require.NoError(t, repo.UpdateUserErasure(ctx, erasure))
+
+	// starting the erasure again resumes the erasure in progress
+	resumed := &model.UserErasure{SubjectHash: "hash", Pseudonym: "erased-2", Mode: model.ErasureModeDelete, RequestedBy: "ops"}
+	require.NoError(t, repo.StartUserErasure(ctx, resumed))
+	assert.Equal(t, erasure.ID, resumed.ID)
+	assert.Equal(t, "erased-1", resumed.Pseudonym)
+	assert.Equal(t, model.ErasureModePseudonymize, resumed.Mode)
+	assert.Equal(t, int64(1), resumed.Applications)
+
+	erased, err = repo.EraseUserApplications(ctx, "alice", resumed.Pseudonym, resumed.Mode, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), erased, "the held application must not be erased")
+
+	count, err := repo.CountUserApplications(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, err = repo.CountUserApplications(ctx, "erased-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	audited, err := repo.PseudonymizeAuditRecords(ctx, "alice", resumed.Pseudonym)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), audited)
+
+	resumed.Status = model.ErasureStatusCompleted
+	require.NoError(t, repo.UpdateUserErasure(ctx, resumed))
+	next := &model.UserErasure{SubjectHash: "hash", Pseudonym: "erased-3", Mode: model.ErasureModeDelete, RequestedBy: "ops"}
+	require.NoError(t, repo.StartUserErasure(ctx, next))
+	assert.NotEqual(t, erasure.ID, next.ID)
+
+	erased, err = repo.EraseUserApplications(ctx, "bob", next.Pseudonym, next.Mode, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), erased)
+	count, err = repo.CountUserApplications(ctx, "erased-3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count, "deleted applications must not be pseudonymized")
+}
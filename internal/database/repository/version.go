@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrVersionMismatch is returned if a resource is changed or deleted on condition of a version which is not its
+// current version, because the resource was changed since the version was read.
+var ErrVersionMismatch = errors.New("version does not match the current version")
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// waitPhaseColumns are the columns of the wait phases of applications, in the order of the phases of the results.
+var waitPhaseColumns = []struct {
+	phase  string
+	column string
+}{
+	{phase: model.WaitPhaseAccept, column: "accept_wait"},
+	{phase: model.WaitPhasePlaceholder, column: "placeholder_wait"},
+	{phase: model.WaitPhaseAllocation, column: "allocation_wait"},
+}
+
+// waitReasons are the reasons the applications are counted by, in the order of the results.
+var waitReasons = []string{model.WaitReasonQuota, model.WaitReasonPlacement, model.WaitReasonCapacity}
+
+// applicationWaitPhases are the wait phases of an application which are stored with it, see model.WaitPhases.
+// The phases are nil until they are known, and are kept once they are.
+type applicationWaitPhases struct {
+	AcceptWait      *int64
+	PlaceholderWait *int64
+	AllocationWait  *int64
+	Reason          *string
+}
+
+// waitPhases returns the phases of the wait of the application until its first allocation, as far as the state log
+// and the allocations of the application tell them. The allocations are released once the application finished,
+// so the phases are taken while the application runs and kept afterward.
+func waitPhases(app *dao.ApplicationDAOInfo, previous *storedApplication) applicationWaitPhases {
+	var phases applicationWaitPhases
+	submittedAt := app.SubmissionTime
+	if submittedAt == 0 && previous != nil && previous.SubmissionTime != nil {
+		submittedAt = *previous.SubmissionTime
+	}
+	stateLog := app.StateLog
+	if stateLog == nil && previous != nil {
+		stateLog = previous.StateLog
+	}
+	acceptedAt := stateLogTime(stateLog, "ACCEPTED")
+	if submittedAt > 0 && acceptedAt >= submittedAt {
+		phases.AcceptWait = util.ToPtr(acceptedAt - submittedAt)
+	}
+
+	// the allocations which replaced placeholders were requested when their placeholder was allocated
+	var placeholderAt, allocatedAt int64
+	for _, alloc := range app.Allocations {
+		if alloc == nil || alloc.AllocationTime <= 0 {
+			continue
+		}
+		if alloc.Placeholder {
+			placeholderAt = earliest(placeholderAt, alloc.AllocationTime)
+			continue
+		}
+		if alloc.PlaceholderUsed && alloc.RequestTime > 0 {
+			placeholderAt = earliest(placeholderAt, alloc.RequestTime)
+		}
+		allocatedAt = earliest(allocatedAt, alloc.AllocationTime)
+	}
+	waitingSince := acceptedAt
+	if placeholderAt > 0 {
+		if acceptedAt > 0 && placeholderAt >= acceptedAt {
+			phases.PlaceholderWait = util.ToPtr(placeholderAt - acceptedAt)
+		}
+		waitingSince = placeholderAt
+	}
+	if allocatedAt > 0 && waitingSince > 0 && allocatedAt >= waitingSince {
+		phases.AllocationWait = util.ToPtr(allocatedAt - waitingSince)
+	}
+	if allocatedAt == 0 {
+		phases.Reason = waitReason(app.Requests)
+	}
+	return phases
+}
+
+// earliest returns the earlier of the times, ignoring a time which is not set.
+func earliest(at, other int64) int64 {
+	if at == 0 || other < at {
+		return other
+	}
+	return at
+}
+
+// waitReason classifies why the pending asks were not allocated from their allocation logs, or returns nil if there
+// are no pending asks. Yunikorn logs that an ask did not fit into a quota, or the reasons nodes rejected it, but
+// does not log the nodes which did not have its resources free, so the asks without log are waiting for capacity.
+func waitReason(asks []*dao.AllocationAskDAOInfo) *string {
+	var pending, rejected bool
+	for _, ask := range asks {
+		if ask == nil {
+			continue
+		}
+		pending = true
+		for _, entry := range ask.AllocationLog {
+			if entry == nil {
+				continue
+			}
+			if strings.Contains(strings.ToLower(entry.Message), "quota") {
+				return util.ToPtr(model.WaitReasonQuota)
+			}
+			rejected = true
+		}
+	}
+	switch {
+	case rejected:
+		return util.ToPtr(model.WaitReasonPlacement)
+	case pending:
+		return util.ToPtr(model.WaitReasonCapacity)
+	default:
+		return nil
+	}
+}
+
+// WaitPhaseFilters select the applications submitted from Start until End whose wait phases are summarized,
+// optionally only those of a partition or of a queue, without its child queues. The runs of the applications in
+// several clusters are merged into their canonical run by the Duplicates strategy, unless it is empty.
+type WaitPhaseFilters struct {
+	Partition  *string
+	Queue      *string
+	Start      time.Time
+	End        time.Time
+	Duplicates DuplicateStrategy
+}
+
+// GetQueueWaitPhases summarizes the wait phases of the applications of each queue, ordered by partition and queue.
+func (s *PostgresRepository) GetQueueWaitPhases(ctx context.Context, filters WaitPhaseFilters) ([]*model.QueueWaitPhases, error) {
+	var selections []string
+	for _, c := range waitPhaseColumns {
+		selections = append(selections, fmt.Sprintf(`COUNT(%[1]s), PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY %[1]s),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY %[1]s), PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY %[1]s),
+			MAX(%[1]s)::DOUBLE PRECISION`, c.column))
+	}
+	for i := range waitReasons {
+		selections = append(selections, fmt.Sprintf("COUNT(*) FILTER (WHERE wait_reason = $%d)", i+5))
+	}
+	conditions := `submission_time >= $1 AND submission_time < $2
+			AND ($3::TEXT IS NULL OR partition = $3) AND ($4::TEXT IS NULL OR queue_name = $4)`
+	if filters.Duplicates != "" {
+		canonical, err := canonicalRun(filters.Duplicates, "applications")
+		if err != nil {
+			return nil, err
+		}
+		conditions += " AND " + canonical
+	}
+	query := `SELECT partition, queue_name, COUNT(*), ` + strings.Join(selections, ", ") + `
+		FROM ` + s.applicationsSource(&filters.Start).From() + `
+		WHERE ` + conditions + `
+		GROUP BY partition, queue_name
+		ORDER BY partition, queue_name`
+	args := []any{filters.Start.UnixNano(), filters.End.UnixNano(), filters.Partition, filters.Queue}
+	for _, reason := range waitReasons {
+		args = append(args, reason)
+	}
+	rows, err := s.dbpool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get wait phases from DB: %v", err)
+	}
+	results, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*model.QueueWaitPhases, error) {
+		result := &model.QueueWaitPhases{Reasons: make(map[string]int64, len(waitReasons))}
+		dest := []any{&result.Partition, &result.QueueName, &result.Applications}
+		for _, c := range waitPhaseColumns {
+			stats := &model.WaitPhaseStats{Phase: c.phase}
+			result.Phases = append(result.Phases, stats)
+			dest = append(dest, &stats.Applications, &stats.MedianWait, &stats.P95Wait, &stats.P99Wait, &stats.MaxWait)
+		}
+		counts := make([]int64, len(waitReasons))
+		for i := range counts {
+			dest = append(dest, &counts[i])
+		}
+		if err := row.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, reason := range waitReasons {
+			result.Reasons[reason] = counts[i]
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get wait phases from DB: %v", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestGetQueueWaitPhases_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	connPool := database.NewTestConnectionPool(ctx, t)
+
+	repo, err := NewPostgresRepository(connPool)
+	require.NoError(t, err)
+	seedApplications(ctx, t, repo)
+
+	submitted := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return submitted.Add(d).UnixNano() }
+	stateLog := []*dao.StateDAOInfo{{Time: at(time.Second), ApplicationState: "Accepted"}}
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{
+		{
+			ApplicationID:  "gang",
+			Partition:      "default",
+			QueueName:      "root.default",
+			SubmissionTime: submitted.UnixNano(),
+			State:          "Accepted",
+			StateLog:       stateLog,
+			Requests: []*dao.AllocationAskDAOInfo{{
+				AllocationLog: []*dao.AllocationAskLogDAOInfo{{Message: "Not enough queue quota"}},
+			}},
+		},
+		{
+			ApplicationID:  "waiting",
+			Partition:      "default",
+			QueueName:      "root.default",
+			SubmissionTime: submitted.UnixNano(),
+			State:          "Accepted",
+			StateLog:       stateLog,
+			Requests:       []*dao.AllocationAskDAOInfo{{AllocationKey: "ask-1"}},
+		},
+	})
+	require.NoError(t, err)
+	// the placeholders of the gang are allocated and replaced, after which the reason is kept
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID:  "gang",
+		Partition:      "default",
+		QueueName:      "root.default",
+		SubmissionTime: submitted.UnixNano(),
+		State:          "Running",
+		Allocations: []*dao.AllocationDAOInfo{{
+			AllocationTime:  at(time.Minute),
+			RequestTime:     at(11 * time.Second),
+			PlaceholderUsed: true,
+		}},
+	}})
+	require.NoError(t, err)
+	err = repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{{
+		ApplicationID:  "gang",
+		Partition:      "default",
+		QueueName:      "root.default",
+		SubmissionTime: submitted.UnixNano(),
+		State:          "Completed",
+	}})
+	require.NoError(t, err)
+
+	apps, err := repo.GetAppsPerPartitionPerQueue(ctx, "default", "root.default", ApplicationFilters{ApplicationID: util.ToPtr("gang")})
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, &model.WaitPhases{
+		AcceptWait:      util.ToPtr(time.Second.Nanoseconds()),
+		PlaceholderWait: util.ToPtr(10 * time.Second.Nanoseconds()),
+		AllocationWait:  util.ToPtr(49 * time.Second.Nanoseconds()),
+		Reason:          model.WaitReasonQuota,
+	}, apps[0].WaitPhases)
+
+	results, err := repo.GetQueueWaitPhases(ctx, WaitPhaseFilters{
+		Partition: util.ToPtr("default"),
+		Start:     submitted,
+		End:       submitted.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	result := results[0]
+	assert.Equal(t, "root.default", result.QueueName)
+	assert.Equal(t, int64(2), result.Applications)
+	require.Len(t, result.Phases, 3)
+	assert.Equal(t, model.WaitPhaseAccept, result.Phases[0].Phase)
+	assert.Equal(t, int64(2), result.Phases[0].Applications)
+	assert.Equal(t, util.ToPtr(float64(time.Second.Nanoseconds())), result.Phases[0].MedianWait)
+	assert.Equal(t, int64(1), result.Phases[1].Applications)
+	assert.Equal(t, util.ToPtr(float64(49*time.Second.Nanoseconds())), result.Phases[2].MaxWait)
+	assert.Equal(t, map[string]int64{
+		model.WaitReasonQuota:     1,
+		model.WaitReasonPlacement: 0,
+		model.WaitReasonCapacity:  1,
+	}, result.Reasons)
+
+	results, err = repo.GetQueueWaitPhases(ctx, WaitPhaseFilters{
+		Queue: util.ToPtr("root.other"),
+		Start: submitted,
+		End:   submitted.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
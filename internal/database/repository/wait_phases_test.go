@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWaitPhases(t *testing.T) {
+	accepted := []*dao.StateDAOInfo{{Time: 100, ApplicationState: "New"}, {Time: 130, ApplicationState: "Accepted"}}
+	tests := map[string]struct {
+		app      *dao.ApplicationDAOInfo
+		previous *storedApplication
+		want     applicationWaitPhases
+	}{
+		"not accepted": {
+			app: &dao.ApplicationDAOInfo{State: "New", SubmissionTime: 100, StateLog: accepted[:1]},
+		},
+		"waiting for capacity": {
+			app: &dao.ApplicationDAOInfo{
+				State: "Accepted", SubmissionTime: 100, StateLog: accepted,
+				Requests: []*dao.AllocationAskDAOInfo{{AllocationKey: "ask-1"}},
+			},
+			want: applicationWaitPhases{
+				AcceptWait: util.ToPtr(int64(30)),
+				Reason:     util.ToPtr(model.WaitReasonCapacity),
+			},
+		},
+		"allocated": {
+			app: &dao.ApplicationDAOInfo{
+				State: "Running", SubmissionTime: 100, StateLog: accepted,
+				Allocations: []*dao.AllocationDAOInfo{{AllocationTime: 300}, {AllocationTime: 200}},
+				Requests:    []*dao.AllocationAskDAOInfo{{AllocationKey: "ask-3"}},
+			},
+			want: applicationWaitPhases{AcceptWait: util.ToPtr(int64(30)), AllocationWait: util.ToPtr(int64(70))},
+		},
+		"placeholders allocated": {
+			app: &dao.ApplicationDAOInfo{
+				State: "Running", SubmissionTime: 100, StateLog: accepted,
+				Allocations: []*dao.AllocationDAOInfo{{AllocationTime: 180, Placeholder: true}},
+				Requests: []*dao.AllocationAskDAOInfo{{
+					AllocationLog: []*dao.AllocationAskLogDAOInfo{{Message: "Not enough queue quota", Count: 3}},
+				}},
+			},
+			want: applicationWaitPhases{
+				AcceptWait:      util.ToPtr(int64(30)),
+				PlaceholderWait: util.ToPtr(int64(50)),
+				Reason:          util.ToPtr(model.WaitReasonQuota),
+			},
+		},
+		"placeholders replaced": {
+			app: &dao.ApplicationDAOInfo{
+				State: "Running", StateLog: accepted,
+				Allocations: []*dao.AllocationDAOInfo{{AllocationTime: 400, RequestTime: 180, PlaceholderUsed: true}},
+			},
+			previous: &storedApplication{SubmissionTime: util.ToPtr(int64(100))},
+			want: applicationWaitPhases{
+				AcceptWait:      util.ToPtr(int64(30)),
+				PlaceholderWait: util.ToPtr(int64(50)),
+				AllocationWait:  util.ToPtr(int64(220)),
+			},
+		},
+		"state log of the stored application": {
+			app:      &dao.ApplicationDAOInfo{State: "Completed", SubmissionTime: 100},
+			previous: &storedApplication{StateLog: accepted},
+			want:     applicationWaitPhases{AcceptWait: util.ToPtr(int64(30))},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, waitPhases(tt.app, tt.previous))
+		})
+	}
+}
+
+func TestWaitReason(t *testing.T) {
+	rejected := &dao.AllocationAskDAOInfo{
+		AllocationLog: []*dao.AllocationAskLogDAOInfo{{Message: "node(s) didn't match Pod's node affinity/selector"}},
+	}
+	quota := &dao.AllocationAskDAOInfo{
+		AllocationLog: []*dao.AllocationAskLogDAOInfo{{Message: "Not enough user quota"}},
+	}
+	tests := map[string]struct {
+		asks []*dao.AllocationAskDAOInfo
+		want *string
+	}{
+		"no pending asks": {},
+		"asks without log": {
+			asks: []*dao.AllocationAskDAOInfo{{}, nil},
+			want: util.ToPtr(model.WaitReasonCapacity),
+		},
+		"asks rejected by nodes": {
+			asks: []*dao.AllocationAskDAOInfo{{}, rejected},
+			want: util.ToPtr(model.WaitReasonPlacement),
+		},
+		"quota takes precedence": {
+			asks: []*dao.AllocationAskDAOInfo{rejected, quota},
+			want: util.ToPtr(model.WaitReasonQuota),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, waitReason(tt.asks))
+		})
+	}
+}
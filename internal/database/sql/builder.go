@@ -1,7 +1,12 @@
 package sql
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -12,15 +17,191 @@ const (
 	OrderByDescending OrderDirection = "DESC"
 )
 
+// Operator is a comparison operator of a condition.
+type Operator string
+
+const (
+	Equal              Operator = "="
+	NotEqual           Operator = "<>"
+	LessThan           Operator = "<"
+	LessThanOrEqual    Operator = "<="
+	GreaterThan        Operator = ">"
+	GreaterThanOrEqual Operator = ">="
+	// Overlaps matches if two arrays have an element in common.
+	Overlaps Operator = "&&"
+	// Like and NotLike match a text against a pattern, in which % matches any sequence of characters.
+	Like    Operator = "LIKE"
+	NotLike Operator = "NOT LIKE"
+)
+
+// operators lists the supported operators. As untyped string constants convert to Operator,
+// operators are checked when the condition is added.
+var operators = map[Operator]bool{
+	Equal:              true,
+	NotEqual:           true,
+	LessThan:           true,
+	LessThanOrEqual:    true,
+	GreaterThan:        true,
+	GreaterThanOrEqual: true,
+	Overlaps:           true,
+	Like:               true,
+	NotLike:            true,
+}
+
+// AggregateFunction is an aggregate function of a grouped query.
+type AggregateFunction string
+
+const (
+	Count   AggregateFunction = "count"
+	Sum     AggregateFunction = "sum"
+	Average AggregateFunction = "avg"
+	Minimum AggregateFunction = "min"
+	Maximum AggregateFunction = "max"
+	// Median, Percentile95 and Percentile99 are continuous percentiles, interpolated between the values.
+	Median       AggregateFunction = "p50"
+	Percentile95 AggregateFunction = "p95"
+	Percentile99 AggregateFunction = "p99"
+)
+
+// aggregateFunctions maps the supported aggregate functions to the SQL expression of their argument.
+var aggregateFunctions = map[AggregateFunction]string{
+	Count:        "COUNT(%s)",
+	Sum:          "SUM(%s)",
+	Average:      "AVG(%s)",
+	Minimum:      "MIN(%s)",
+	Maximum:      "MAX(%s)",
+	Median:       "PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY %s)",
+	Percentile95: "PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY %s)",
+	Percentile99: "PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY %s)",
+}
+
+// TimeBucket is the width of the time buckets a grouped query groups timestamps by.
+type TimeBucket string
+
+const (
+	Hour  TimeBucket = "hour"
+	Day   TimeBucket = "day"
+	Week  TimeBucket = "week"
+	Month TimeBucket = "month"
+)
+
+var timeBuckets = map[TimeBucket]bool{
+	Hour:  true,
+	Day:   true,
+	Week:  true,
+	Month: true,
+}
+
+// identifierPattern matches the unquoted lower case identifiers used by the schema.
+var identifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ErrInvalidQuery is returned by Build if the query references an undeclared column, or uses an unsupported operator,
+// aggregate function, time bucket, order direction, limit or offset.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Table declares a table and the columns which queries may reference.
+// Identifiers are never taken from the arguments of a query, so that values cannot be interpreted as SQL.
+type Table struct {
+	name    string
+	columns map[string]bool
+	// union are the tables whose rows the table selects with UNION ALL, or nil if it is a table of the schema.
+	union []*Table
+}
+
+// NewTable declares a table with its columns. It panics if an identifier is not a lower case SQL identifier,
+// as tables are declared as package variables and an invalid identifier is a programming error.
+func NewTable(name string, columns ...string) *Table {
+	mustBeIdentifier(name)
+	t := &Table{name: name, columns: make(map[string]bool, len(columns))}
+	for _, column := range columns {
+		mustBeIdentifier(column)
+		t.columns[column] = true
+	}
+	return t
+}
+
+// UnionAll declares the union of tables with the same columns in the same order, e.g. the tiers of a table.
+// It is selected as a subquery named like the first table, so that queries and their conditions are the same
+// as on the first table. It panics if no table is given or the columns of the tables differ.
+func UnionAll(tables ...*Table) *Table {
+	if len(tables) == 0 {
+		panic("no tables in union")
+	}
+	for _, table := range tables[1:] {
+		if !slices.Equal(table.Columns(), tables[0].Columns()) {
+			panic(fmt.Sprintf("columns of table %s differ from columns of table %s", table.name, tables[0].name))
+		}
+	}
+	return &Table{name: tables[0].name, columns: tables[0].columns, union: tables}
+}
+
+// Name returns the name of the table.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Columns returns the declared columns of the table in alphabetical order.
+func (t *Table) Columns() []string {
+	columns := make([]string, 0, len(t.columns))
+	for column := range t.columns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// From returns the expression the table is selected from by queries which are not built with a Builder:
+// the quoted name of the table, or the subquery of a union aliased as the table.
+func (t *Table) From() string {
+	if t.union == nil {
+		return t.from()
+	}
+	return t.from() + " AS " + quoteIdentifier(t.name)
+}
+
+// from returns the expression the table is selected from, which must be aliased if it is a union.
+func (t *Table) from() string {
+	if t.union == nil {
+		return quoteIdentifier(t.name)
+	}
+	selects := make([]string, 0, len(t.union))
+	for _, table := range t.union {
+		selects = append(selects, "SELECT * FROM "+table.from())
+	}
+	return "(" + strings.Join(selects, " UNION ALL ") + ")"
+}
+
+func mustBeIdentifier(identifier string) {
+	if !identifierPattern.MatchString(identifier) {
+		panic(fmt.Sprintf("invalid SQL identifier %q", identifier))
+	}
+}
+
+// quoteIdentifier quotes an identifier, which is validated by NewTable, so that it cannot clash with a keyword.
+func quoteIdentifier(identifier string) string {
+	return `"` + identifier + `"`
+}
+
+// Builder builds a SELECT query on a declared table. Values are always passed as positional arguments
+// ('$1', '$2'...), and columns, operators and order directions are checked against the declared table and
+// the supported operators. The first invalid input is returned by Build.
 type Builder struct {
-	selectStatement  string
-	whereClauses     string
-	hasWhere         bool
-	conditionCounter int
-	args             []any
-	orderByClauses   string
+	table *Table
+	alias string
+	// selections are the selected expressions, or nil to select all columns.
+	selections     []string
+	groupBy        []string
+	whereClauses   string
+	hasWhere       bool
+	args           []any
+	orderByClauses string
+	// lastAggregate is the position of the last selected aggregate, counting from 1, or 0 if none is selected.
+	lastAggregate int
+	// orderByAggregate orders grouped rows by an aggregate, e.g. "3 DESC", before the groups.
+	orderByAggregate string
 	limit            string
 	offset           string
+	err              error
 }
 
 func NewBuilder() *Builder {
@@ -28,40 +209,179 @@ func NewBuilder() *Builder {
 }
 
 // SelectAll creates a new query with a SELECT statement which selects all ('*') entities.
-// If an alias is provided, it will be used as the table alias.
-func (b *Builder) SelectAll(table string, alias string) *Builder {
-	b.selectStatement = fmt.Sprintf("SELECT * FROM %s", table)
-	if alias != "" {
-		b.selectStatement += " AS " + alias
+// If an alias is provided, it will be used as the table alias and to qualify the columns.
+// The columns selected by GroupBy and Aggregate, if any, are selected instead of all columns.
+func (b *Builder) SelectAll(table *Table, alias string) *Builder {
+	if alias != "" && !identifierPattern.MatchString(alias) {
+		b.fail("invalid table alias %q", alias)
+		return b
 	}
+	b.table = table
+	b.alias = alias
 	return b
 }
 
-// Conditionf adds a condition to the query.
-// This function accepts a format string and arguments, which will be used to create the condition.
-// If the condition uses special characters like '%', they need to be escaped like '%%'.
-// Example: Conditionf("name = '%s'", "John")
-func (b *Builder) Conditionf(format string, args ...any) *Builder {
-	expression := fmt.Sprintf(format, args...)
-	return b.condition(expression)
+// GroupBy selects the column and groups the rows by its values.
+func (b *Builder) GroupBy(column string) *Builder {
+	col, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	return b.selectGroup(col)
 }
 
-// Condition adds a condition to the query.
-// This function accepts a raw expression string.
-// Special characters like '%' do not need to be escaped.
-// Example: Condition("name = '@name'")
-func (b *Builder) Condition(expression string) *Builder {
-	return b.condition(expression)
+// GroupByUnixNanoBucket selects the start of the time bucket of the column, which holds Unix nanoseconds,
+// and groups the rows by it. Buckets start at midnight, or the start of the hour, in the timezone, which
+// is passed as a positional argument like the width of the buckets.
+//
+// Example: GroupByUnixNanoBucket("submission_time", Day, "UTC") will select
+// `date_trunc($1, to_timestamp("submission_time" / 1e9), $2)`.
+func (b *Builder) GroupByUnixNanoBucket(column string, bucket TimeBucket, timezone string) *Builder {
+	if !timeBuckets[bucket] {
+		b.fail("unsupported time bucket %q", bucket)
+		return b
+	}
+	col, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	b.args = append(b.args, string(bucket), timezone)
+	return b.selectGroup(fmt.Sprintf("date_trunc($%d, to_timestamp(%s / 1e9), $%d)", len(b.args)-1, col, len(b.args)))
+}
+
+// GroupByMultiple selects the value of the column rounded down to a multiple of step, which is passed as
+// a positional argument, and groups the rows by it. The column must not hold negative values.
+//
+// Example: GroupByMultiple("bucket_start", 3600) will select `("bucket_start" / $1) * $1`.
+func (b *Builder) GroupByMultiple(column string, step int64) *Builder {
+	if step <= 0 {
+		b.fail("step %d of column %q is not positive", step, column)
+		return b
+	}
+	col, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	b.args = append(b.args, step)
+	return b.selectGroup(fmt.Sprintf("(%s / $%d) * $%d", col, len(b.args), len(b.args)))
+}
+
+func (b *Builder) selectGroup(expression string) *Builder {
+	b.selections = append(b.selections, expression)
+	b.groupBy = append(b.groupBy, strconv.Itoa(len(b.selections)))
+	return b
+}
+
+// Aggregate selects the aggregate function of the column, or of all rows for Count with an empty column.
+// Aggregates are cast to double precision, so that they are scanned the same way whatever the column type.
+//
+// Example: Aggregate(Average, "age") will select `CAST(AVG("age") AS DOUBLE PRECISION)`.
+func (b *Builder) Aggregate(fn AggregateFunction, column string) *Builder {
+	function, ok := aggregateFunctions[fn]
+	if !ok {
+		b.fail("unsupported aggregate function %q", fn)
+		return b
+	}
+	arg := "*"
+	if column != "" || fn != Count {
+		if arg, ok = b.column(column); !ok {
+			return b
+		}
+	}
+	b.selections = append(b.selections, fmt.Sprintf("CAST(%s AS DOUBLE PRECISION)", fmt.Sprintf(function, arg)))
+	b.lastAggregate = len(b.selections)
+	return b
+}
+
+// Conditionp adds a condition to the query with the value as a positional argument ('$1', '$2'...).
+// column is the left-hand side of the condition and op is the operator.
+//
+// Example: Conditionp("name", Equal, "John") will be added as `"name" = $1`.
+func (b *Builder) Conditionp(column string, op Operator, val any) *Builder {
+	if !operators[op] {
+		b.fail("unsupported operator %q", op)
+		return b
+	}
+	lhs, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	b.args = append(b.args, val)
+	return b.condition(fmt.Sprintf("%s %s $%d", lhs, op, len(b.args)))
+}
+
+// ConditionAny adds a condition to the query which matches if the column is equal to any element of vals,
+// passed as a positional argument.
+//
+// Example: ConditionAny("name", []string{"John", "Jane"}) will be added as `"name" = ANY($1)`.
+func (b *Builder) ConditionAny(column string, vals any) *Builder {
+	lhs, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	b.args = append(b.args, vals)
+	return b.condition(fmt.Sprintf("%s = ANY($%d)", lhs, len(b.args)))
 }
 
-// Conditionp adds a condition to the query as a positional argument ('$1', '$2'...).
-// lhs is the left-hand side of the condition, op is the operator and val is the value.
+// ConditionNull adds a condition to the query which matches if the column is NULL, or NOT NULL if null is false.
+func (b *Builder) ConditionNull(column string, null bool) *Builder {
+	lhs, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	if null {
+		return b.condition(lhs + " IS NULL")
+	}
+	return b.condition(lhs + " IS NOT NULL")
+}
+
+// ConditionNullOrp adds a condition to the query which matches if the column is NULL or the condition of Conditionp
+// matches, e.g. for the end of a validity period which is NULL while the period lasts.
 //
-// Example: Conditionp("name", "=", "John") will be added as "name = '$1'".
-func (b *Builder) Conditionp(lhs, op string, val any) *Builder {
-	b.conditionCounter++
-	expression := fmt.Sprintf("%s %s $%d", lhs, op, b.conditionCounter)
+// Example: ConditionNullOrp("valid_to", GreaterThan, 10) will be added as `("valid_to" IS NULL OR "valid_to" > $1)`.
+func (b *Builder) ConditionNullOrp(column string, op Operator, val any) *Builder {
+	if !operators[op] {
+		b.fail("unsupported operator %q", op)
+		return b
+	}
+	lhs, ok := b.column(column)
+	if !ok {
+		return b
+	}
 	b.args = append(b.args, val)
+	return b.condition(fmt.Sprintf("(%s IS NULL OR %s %s $%d)", lhs, lhs, op, len(b.args)))
+}
+
+// ConditionRowp adds a condition to the query which compares the row of the columns with the row of the values,
+// passed as positional arguments, in lexicographic order, e.g. for keyset pagination after the last row of a page.
+//
+// Example: ConditionRowp([]string{"age", "name"}, LessThan, 20, "John") will be added as `("age", "name") < ($1, $2)`.
+func (b *Builder) ConditionRowp(columns []string, op Operator, vals ...any) *Builder {
+	if op != LessThan && op != GreaterThan {
+		b.fail("unsupported row operator %q", op)
+		return b
+	}
+	if len(columns) == 0 || len(columns) != len(vals) {
+		b.fail("row of %d columns compared with %d values", len(columns), len(vals))
+		return b
+	}
+	lhs := make([]string, len(columns))
+	rhs := make([]string, len(columns))
+	for i, column := range columns {
+		col, ok := b.column(column)
+		if !ok {
+			return b
+		}
+		b.args = append(b.args, vals[i])
+		lhs[i] = col
+		rhs[i] = fmt.Sprintf("$%d", len(b.args))
+	}
+	return b.condition(fmt.Sprintf("(%s) %s (%s)", strings.Join(lhs, ", "), op, strings.Join(rhs, ", ")))
+}
+
+// ConditionExpression adds a condition given as an SQL expression without arguments, e.g. a correlated subquery
+// on other tables. The expression is added as is, so it must never be built from input.
+func (b *Builder) ConditionExpression(expression string) *Builder {
 	return b.condition(expression)
 }
 
@@ -77,45 +397,126 @@ func (b *Builder) condition(expression string) *Builder {
 
 // Limit adds a LIMIT clause to the query.
 func (b *Builder) Limit(limit int) *Builder {
+	if limit < 0 {
+		b.fail("negative limit %d", limit)
+		return b
+	}
 	b.limit = fmt.Sprintf("LIMIT %d", limit)
 	return b
 }
 
 // Offset adds an OFFSET clause to the query.
 func (b *Builder) Offset(offset int) *Builder {
+	if offset < 0 {
+		b.fail("negative offset %d", offset)
+		return b
+	}
 	b.offset = fmt.Sprintf("OFFSET %d", offset)
 	return b
 }
 
+// OrderBy orders the rows by the column. Calling it again orders the rows with equal values by the next column.
+//
+// Example: OrderBy("name", OrderByAscending).OrderBy("age", OrderByDescending) will be ordered
+// by `ORDER BY "name" ASC, "age" DESC`.
 func (b *Builder) OrderBy(column string, direction OrderDirection) *Builder {
-	b.orderByClauses = fmt.Sprintf("ORDER BY %s %s", column, direction)
+	if direction != OrderByAscending && direction != OrderByDescending {
+		b.fail("unsupported order direction %q", direction)
+		return b
+	}
+	col, ok := b.column(column)
+	if !ok {
+		return b
+	}
+	if b.orderByClauses == "" {
+		b.orderByClauses = "ORDER BY "
+	} else {
+		b.orderByClauses += ", "
+	}
+	b.orderByClauses += fmt.Sprintf("%s %s", col, direction)
 	return b
 }
 
-// Query returns the final query string.
-func (b *Builder) Query() string {
-	query := strings.Builder{}
-	query.WriteString(b.selectStatement)
-	if b.whereClauses != "" {
-		query.WriteString(" ")
-		query.WriteString(b.whereClauses)
+// OrderByAggregate orders the rows by the last selected aggregate, and rows with equal aggregates by their groups,
+// e.g. to select the groups with the largest sums with a limit.
+//
+// Example: GroupBy("name").Aggregate(Sum, "age").OrderByAggregate(OrderByDescending) will be ordered
+// by `ORDER BY 2 DESC, 1`.
+func (b *Builder) OrderByAggregate(direction OrderDirection) *Builder {
+	if direction != OrderByAscending && direction != OrderByDescending {
+		b.fail("unsupported order direction %q", direction)
+		return b
 	}
-	if b.orderByClauses != "" {
-		query.WriteString(" ")
-		query.WriteString(b.orderByClauses)
+	if b.lastAggregate == 0 {
+		b.fail("no aggregate selected to order by")
+		return b
+	}
+	b.orderByAggregate = fmt.Sprintf("%d %s", b.lastAggregate, direction)
+	return b
+}
+
+// column returns the quoted and qualified column, or records an error if the column is not declared.
+func (b *Builder) column(column string) (string, bool) {
+	if b.table == nil {
+		b.fail("column %q referenced before selecting a table", column)
+		return "", false
+	}
+	if !b.table.columns[column] {
+		b.fail("unknown column %q of table %s", column, b.table.name)
+		return "", false
 	}
-	if b.limit != "" {
-		query.WriteString(" ")
-		query.WriteString(b.limit)
+	if b.alias != "" {
+		return quoteIdentifier(b.alias) + "." + quoteIdentifier(column), true
 	}
-	if b.offset != "" {
-		query.WriteString(" ")
-		query.WriteString(b.offset)
+	return quoteIdentifier(column), true
+}
+
+func (b *Builder) fail(format string, args ...any) {
+	if b.err == nil {
+		b.err = fmt.Errorf("%w: %s", ErrInvalidQuery, fmt.Sprintf(format, args...))
 	}
-	return query.String()
 }
 
-// Args returns the positional arguments used in the query.
-func (b *Builder) Args() []any {
-	return b.args
+// Build returns the query and its positional arguments, or the first error of the query.
+// Grouped queries are ordered by their groups, unless an order is given.
+func (b *Builder) Build() (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	if b.table == nil {
+		return "", nil, fmt.Errorf("%w: no table selected", ErrInvalidQuery)
+	}
+	query := strings.Builder{}
+	query.WriteString("SELECT ")
+	if b.selections == nil {
+		query.WriteString("*")
+	} else {
+		query.WriteString(strings.Join(b.selections, ", "))
+	}
+	query.WriteString(" FROM ")
+	if b.alias != "" {
+		query.WriteString(b.table.from())
+		query.WriteString(" AS ")
+		query.WriteString(quoteIdentifier(b.alias))
+	} else {
+		query.WriteString(b.table.From())
+	}
+	var groupByClause string
+	orderByClauses := b.orderByClauses
+	if b.orderByAggregate != "" {
+		orderByClauses = "ORDER BY " + strings.Join(append([]string{b.orderByAggregate}, b.groupBy...), ", ")
+	}
+	if len(b.groupBy) > 0 {
+		groupByClause = "GROUP BY " + strings.Join(b.groupBy, ", ")
+		if orderByClauses == "" {
+			orderByClauses = "ORDER BY " + strings.Join(b.groupBy, ", ")
+		}
+	}
+	for _, clause := range []string{b.whereClauses, groupByClause, orderByClauses, b.limit, b.offset} {
+		if clause != "" {
+			query.WriteString(" ")
+			query.WriteString(clause)
+		}
+	}
+	return query.String(), b.args, nil
 }
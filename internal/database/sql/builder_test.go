@@ -1,68 +1,162 @@
 package sql
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+var (
+	usersTable         = NewTable("users", "name", "age", "user", "groups", "deleted_at")
+	archivedUsersTable = NewTable("archived_users", "name", "age", "user", "groups", "deleted_at")
+	allUsersTable      = UnionAll(usersTable, archivedUsersTable)
+	productsTable      = NewTable("products", "price")
+)
+
+func TestNewTable(t *testing.T) {
+	assert.Equal(t, "users", usersTable.Name())
+	assert.Equal(t, []string{"age", "deleted_at", "groups", "name", "user"}, usersTable.Columns())
+	assert.Panics(t, func() { NewTable("users; DROP TABLE users") })
+	assert.Panics(t, func() { NewTable("users", `name" = '' OR "1`) })
+	assert.Panics(t, func() { NewTable("Users") })
+}
+
+func TestUnionAll(t *testing.T) {
+	assert.Equal(t, "users", allUsersTable.Name())
+	assert.Equal(t, usersTable.Columns(), allUsersTable.Columns())
+	assert.Equal(t, `"users"`, usersTable.From())
+	assert.Equal(t, `(SELECT * FROM "users" UNION ALL SELECT * FROM "archived_users") AS "users"`, allUsersTable.From())
+	assert.Panics(t, func() { UnionAll() })
+	assert.Panics(t, func() { UnionAll(usersTable, productsTable) })
+
+	query, args, err := NewBuilder().SelectAll(allUsersTable, "").
+		Conditionp("age", GreaterThan, 30).
+		OrderBy("name", OrderByAscending).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM (SELECT * FROM "users" UNION ALL SELECT * FROM "archived_users") AS "users" `+
+		`WHERE "age" > $1 ORDER BY "name" ASC`, query)
+	assert.Equal(t, []any{30}, args)
+}
+
 func TestSelectAll(t *testing.T) {
 	tests := []struct {
 		name     string
-		table    string
+		table    *Table
 		alias    string
 		expected string
 	}{
-		{"Without alias", "users", "", "SELECT * FROM users"},
-		{"With alias", "users", "u", "SELECT * FROM users AS u"},
+		{"Without alias", usersTable, "", `SELECT * FROM "users"`},
+		{"With alias", usersTable, "u", `SELECT * FROM "users" AS "u"`},
+		{
+			"Union with alias", allUsersTable, "u",
+			`SELECT * FROM (SELECT * FROM "users" UNION ALL SELECT * FROM "archived_users") AS "u"`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := NewBuilder().SelectAll(tt.table, tt.alias)
-			assert.Equal(t, tt.expected, b.selectStatement)
-			assert.Empty(t, b.whereClauses)
-			assert.False(t, b.hasWhere)
-			assert.Empty(t, b.orderByClauses)
-			assert.Empty(t, b.limit)
-			assert.Empty(t, b.offset)
+			query, args, err := NewBuilder().SelectAll(tt.table, tt.alias).Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+			assert.Empty(t, args)
 		})
 	}
 }
 
-func TestConditionf(t *testing.T) {
+func TestConditions(t *testing.T) {
 	tests := []struct {
 		name     string
 		setup    func(*Builder)
 		expected string
+		args     []any
 	}{
 		{
 			"Single condition",
 			func(b *Builder) {
-				b.Conditionf("age > %d", 30)
+				b.Conditionp("age", GreaterThan, 30)
 			},
-			"WHERE age > 30",
+			`SELECT * FROM "users" WHERE "age" > $1`,
+			[]any{30},
 		},
 		{
 			"Multiple conditions",
 			func(b *Builder) {
-				b.Conditionf("name = '%s'", "John")
-				b.Conditionf("age > %d", 30)
+				b.Conditionp("name", Equal, "John").
+					Conditionp("age", GreaterThan, 30)
+			},
+			`SELECT * FROM "users" WHERE "name" = $1 AND "age" > $2`,
+			[]any{"John", 30},
+		},
+		{
+			"Any and null conditions",
+			func(b *Builder) {
+				b.ConditionAny("user", []string{"John", "Jane"}).
+					ConditionNull("deleted_at", true).
+					Conditionp("groups", Overlaps, []string{"admin"}).
+					ConditionNull("name", false)
+			},
+			`SELECT * FROM "users" WHERE "user" = ANY($1) AND "deleted_at" IS NULL AND "groups" && $2 AND "name" IS NOT NULL`,
+			[]any{[]string{"John", "Jane"}, []string{"admin"}},
+		},
+		{
+			"Pattern conditions",
+			func(b *Builder) {
+				b.Conditionp("name", Like, "J%").
+					Conditionp("user", NotLike, "[%")
+			},
+			`SELECT * FROM "users" WHERE "name" LIKE $1 AND "user" NOT LIKE $2`,
+			[]any{"J%", "[%"},
+		},
+		{
+			"Null or condition",
+			func(b *Builder) {
+				b.Conditionp("age", LessThanOrEqual, 20).
+					ConditionNullOrp("deleted_at", GreaterThan, 10)
+			},
+			`SELECT * FROM "users" WHERE "age" <= $1 AND ("deleted_at" IS NULL OR "deleted_at" > $2)`,
+			[]any{20, 10},
+		},
+		{
+			"Row condition",
+			func(b *Builder) {
+				b.Conditionp("user", Equal, "John").
+					ConditionRowp([]string{"age", "name"}, LessThan, 20, "Jane")
+			},
+			`SELECT * FROM "users" WHERE "user" = $1 AND ("age", "name") < ($2, $3)`,
+			[]any{"John", 20, "Jane"},
+		},
+		{
+			"Expression condition",
+			func(b *Builder) {
+				b.Conditionp("age", GreaterThan, 30).
+					ConditionExpression(`NOT EXISTS (SELECT 1 FROM "bans" WHERE "bans"."user" = "users"."user")`)
 			},
-			"WHERE name = 'John' AND age > 30",
+			`SELECT * FROM "users" WHERE "age" > $1 AND NOT EXISTS (SELECT 1 FROM "bans" WHERE "bans"."user" = "users"."user")`,
+			[]any{30},
+		},
+		{
+			"Values are never interpolated",
+			func(b *Builder) {
+				b.Conditionp("name", Equal, "'; DROP TABLE users; --")
+			},
+			`SELECT * FROM "users" WHERE "name" = $1`,
+			[]any{"'; DROP TABLE users; --"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := NewBuilder()
+			b := NewBuilder().SelectAll(usersTable, "")
 			tt.setup(b)
-			assert.Equal(t, tt.expected, b.whereClauses)
-			assert.Empty(t, b.selectStatement)
-			assert.True(t, b.hasWhere)
-			assert.Empty(t, b.orderByClauses)
-			assert.Empty(t, b.limit)
-			assert.Empty(t, b.offset)
+			query, args, err := b.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+			assert.Equal(t, tt.args, args)
 		})
 	}
 }
@@ -73,19 +167,15 @@ func TestLimit(t *testing.T) {
 		limit    int
 		expected string
 	}{
-		{"Limit 10", 10, "LIMIT 10"},
-		{"Limit 50", 50, "LIMIT 50"},
+		{"Limit 10", 10, `SELECT * FROM "users" LIMIT 10`},
+		{"Limit 50", 50, `SELECT * FROM "users" LIMIT 50`},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := NewBuilder().Limit(tt.limit)
-			assert.Equal(t, tt.expected, b.limit)
-			assert.Empty(t, b.selectStatement)
-			assert.Empty(t, b.whereClauses)
-			assert.False(t, b.hasWhere)
-			assert.Empty(t, b.orderByClauses)
-			assert.Empty(t, b.offset)
+			query, _, err := NewBuilder().SelectAll(usersTable, "").Limit(tt.limit).Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
 		})
 	}
 }
@@ -96,19 +186,15 @@ func TestOffset(t *testing.T) {
 		offset   int
 		expected string
 	}{
-		{"Offset 10", 10, "OFFSET 10"},
-		{"Offset 50", 50, "OFFSET 50"},
+		{"Offset 10", 10, `SELECT * FROM "users" OFFSET 10`},
+		{"Offset 50", 50, `SELECT * FROM "users" OFFSET 50`},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := NewBuilder().Offset(tt.offset)
-			assert.Equal(t, tt.expected, b.offset)
-			assert.Empty(t, b.selectStatement)
-			assert.Empty(t, b.whereClauses)
-			assert.False(t, b.hasWhere)
-			assert.Empty(t, b.orderByClauses)
-			assert.Empty(t, b.limit)
+			query, _, err := NewBuilder().SelectAll(usersTable, "").Offset(tt.offset).Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
 		})
 	}
 }
@@ -120,21 +206,24 @@ func TestOrderBy(t *testing.T) {
 		direction OrderDirection
 		expected  string
 	}{
-		{"Order by ascending", "name", OrderByAscending, "ORDER BY name ASC"},
-		{"Order by descending", "age", OrderByDescending, "ORDER BY age DESC"},
+		{"Order by ascending", "name", OrderByAscending, `SELECT * FROM "users" ORDER BY "name" ASC`},
+		{"Order by descending", "age", OrderByDescending, `SELECT * FROM "users" ORDER BY "age" DESC`},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := NewBuilder().OrderBy(tt.column, tt.direction)
-			assert.Equal(t, tt.expected, b.orderByClauses)
-			assert.Empty(t, b.selectStatement)
-			assert.Empty(t, b.whereClauses)
-			assert.False(t, b.hasWhere)
-			assert.Empty(t, b.offset)
-			assert.Empty(t, b.limit)
+			query, _, err := NewBuilder().SelectAll(usersTable, "").OrderBy(tt.column, tt.direction).Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
 		})
 	}
+
+	t.Run("Order by several columns", func(t *testing.T) {
+		query, _, err := NewBuilder().SelectAll(usersTable, "").
+			OrderBy("name", OrderByAscending).OrderBy("age", OrderByDescending).Build()
+		require.NoError(t, err)
+		assert.Equal(t, `SELECT * FROM "users" ORDER BY "name" ASC, "age" DESC`, query)
+	})
 }
 
 func TestCombined(t *testing.T) {
@@ -144,119 +233,297 @@ func TestCombined(t *testing.T) {
 		expected string
 	}{
 		{
-			name: "SelectAll, Conditionf, Limit, Offset, OrderBy",
+			name: "SelectAll, Conditionp, Limit, Offset, OrderBy",
 			setup: func(b *Builder) {
-				b.SelectAll("users", "u").
-					Conditionf("age > %d", 30).
-					Conditionf("name = '%s'", "John").
+				b.SelectAll(usersTable, "u").
+					Conditionp("age", GreaterThan, 30).
+					Conditionp("name", Equal, "John").
 					OrderBy("age", OrderByAscending).
 					Limit(10).
 					Offset(5)
 			},
-			expected: "SELECT * FROM users AS u WHERE age > 30 AND name = 'John' ORDER BY age ASC LIMIT 10 OFFSET 5",
+			expected: `SELECT * FROM "users" AS "u" WHERE "u"."age" > $1 AND "u"."name" = $2 ORDER BY "u"."age" ASC LIMIT 10 OFFSET 5`,
 		},
 		{
-			name: "SelectAll with alias, Conditionf, Limit",
+			name: "SelectAll with alias, Conditionp, Limit",
 			setup: func(b *Builder) {
-				b.SelectAll("products", "p").
-					Conditionf("price < %f", 100.50).
+				b.SelectAll(productsTable, "p").
+					Conditionp("price", LessThan, 100.50).
 					Limit(20)
 			},
-			expected: "SELECT * FROM products AS p WHERE price < 100.500000 LIMIT 20",
+			expected: `SELECT * FROM "products" AS "p" WHERE "p"."price" < $1 LIMIT 20`,
 		},
 		{
 			name: "SelectAll without alias, OrderBy, Offset",
 			setup: func(b *Builder) {
-				b.SelectAll("employees", "").
-					OrderBy("salary", OrderByDescending).
+				b.SelectAll(productsTable, "").
+					OrderBy("price", OrderByDescending).
 					Offset(10)
 			},
-			expected: "SELECT * FROM employees ORDER BY salary DESC OFFSET 10",
+			expected: `SELECT * FROM "products" ORDER BY "price" DESC OFFSET 10`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder()
+			tt.setup(b)
+			query, _, err := b.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*Builder)
+		expected string
+		args     []any
+	}{
+		{
+			"Aggregates without groups",
+			func(b *Builder) {
+				b.Aggregate(Count, "").Aggregate(Average, "age")
+			},
+			`SELECT CAST(COUNT(*) AS DOUBLE PRECISION), CAST(AVG("age") AS DOUBLE PRECISION) FROM "users"`,
+			[]any{},
 		},
 		{
-			name: "SelectAll, Conditionf with special characters",
-			setup: func(b *Builder) {
-				b.SelectAll("logs", "l").
-					Conditionf("message LIKE '%%%%error%%%%'").
-					OrderBy("timestamp", OrderByDescending)
+			"Groups ordered by groups",
+			func(b *Builder) {
+				b.Conditionp("age", GreaterThan, 30).
+					GroupByUnixNanoBucket("deleted_at", Day, "Europe/London").
+					GroupBy("name").
+					Aggregate(Maximum, "age").
+					Limit(100)
 			},
-			expected: "SELECT * FROM logs AS l WHERE message LIKE '%%error%%' ORDER BY timestamp DESC",
+			`SELECT date_trunc($2, to_timestamp("deleted_at" / 1e9), $3), "name", CAST(MAX("age") AS DOUBLE PRECISION) ` +
+				`FROM "users" WHERE "age" > $1 GROUP BY 1, 2 ORDER BY 1, 2 LIMIT 100`,
+			[]any{30, "day", "Europe/London"},
 		},
 		{
-			name: "SelectAll, multiple Conditionf, no alias",
-			setup: func(b *Builder) {
-				b.SelectAll("transactions", "").
-					Conditionf("amount > %d", 1000).
-					Conditionf("status = '%s'", "completed")
+			"Percentiles",
+			func(b *Builder) {
+				b.GroupBy("name").Aggregate(Median, "age").Aggregate(Percentile95, "age")
+			},
+			`SELECT "name", CAST(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "age") AS DOUBLE PRECISION), ` +
+				`CAST(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY "age") AS DOUBLE PRECISION) FROM "users" GROUP BY 1 ORDER BY 1`,
+			[]any{},
+		},
+		{
+			"Groups of multiples",
+			func(b *Builder) {
+				b.GroupByMultiple("deleted_at", 3600).Aggregate(Sum, "age")
+			},
+			`SELECT ("deleted_at" / $1) * $1, CAST(SUM("age") AS DOUBLE PRECISION) FROM "users" GROUP BY 1 ORDER BY 1`,
+			[]any{int64(3600)},
+		},
+		{
+			"Groups with order",
+			func(b *Builder) {
+				b.GroupBy("name").Aggregate(Count, "age").OrderBy("name", OrderByDescending)
 			},
-			expected: "SELECT * FROM transactions WHERE amount > 1000 AND status = 'completed'",
+			`SELECT "name", CAST(COUNT("age") AS DOUBLE PRECISION) FROM "users" GROUP BY 1 ORDER BY "name" DESC`,
+			[]any{},
+		},
+		{
+			"Groups ordered by aggregate",
+			func(b *Builder) {
+				b.GroupBy("name").Aggregate(Count, "").Aggregate(Sum, "age").OrderByAggregate(OrderByDescending).
+					GroupBy("deleted_at").Limit(10)
+			},
+			`SELECT "name", CAST(COUNT(*) AS DOUBLE PRECISION), CAST(SUM("age") AS DOUBLE PRECISION), "deleted_at" ` +
+				`FROM "users" GROUP BY 1, 4 ORDER BY 3 DESC, 1, 4 LIMIT 10`,
+			[]any{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := NewBuilder()
+			b := NewBuilder().SelectAll(usersTable, "")
 			tt.setup(b)
-			assert.Equal(t, tt.expected, b.Query())
+			query, args, err := b.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+			assert.Equal(t, tt.args, args)
 		})
 	}
 }
 
-func TestConditionp(t *testing.T) {
+func TestInvalidQueries(t *testing.T) {
 	tests := []struct {
-		name               string
-		lhs                string
-		op                 string
-		val                any
-		initialConditions  string
-		initialArgs        []any
-		expectedConditions string
-		expectedArgs       []any
+		name  string
+		setup func(*Builder)
+		err   string
 	}{
 		{
-			name:               "Simple equality condition",
-			lhs:                "name",
-			op:                 "=",
-			val:                "John",
-			initialConditions:  "",
-			initialArgs:        []any{},
-			expectedConditions: "WHERE name = $1",
-			expectedArgs:       []any{"John"},
-		},
-		{
-			name:               "Greater than condition",
-			lhs:                "age",
-			op:                 ">",
-			val:                30,
-			initialConditions:  "",
-			initialArgs:        []any{},
-			expectedConditions: "WHERE age > $1",
-			expectedArgs:       []any{30},
-		},
-		{
-			name:               "Multiple conditions",
-			lhs:                "salary",
-			op:                 "<",
-			val:                5000,
-			initialConditions:  "WHERE name = $1",
-			initialArgs:        []any{"John"},
-			expectedConditions: "WHERE name = $1 AND salary < $2",
-			expectedArgs:       []any{"John", 5000},
+			"No table",
+			func(*Builder) {},
+			"invalid query: no table selected",
+		},
+		{
+			"Condition before table",
+			func(b *Builder) {
+				b.Conditionp("name", Equal, "John").SelectAll(usersTable, "")
+			},
+			`invalid query: column "name" referenced before selecting a table`,
+		},
+		{
+			"Invalid alias",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "u; DROP TABLE users")
+			},
+			`invalid query: invalid table alias "u; DROP TABLE users"`,
+		},
+		{
+			"Unknown column",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Conditionp("password", Equal, "secret")
+			},
+			`invalid query: unknown column "password" of table users`,
+		},
+		{
+			"Expression as column",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").ConditionAny("1 = 1 OR name", []string{"John"})
+			},
+			`invalid query: unknown column "1 = 1 OR name" of table users`,
+		},
+		{
+			"Unsupported operator",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Conditionp("name", "= '' OR 1 =", "John")
+			},
+			`invalid query: unsupported operator "= '' OR 1 ="`,
+		},
+		{
+			"Unsupported order direction",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").OrderBy("name", "ASC; DROP TABLE users")
+			},
+			`invalid query: unsupported order direction "ASC; DROP TABLE users"`,
+		},
+		{
+			"Order by aggregate without aggregate",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").GroupBy("name").OrderByAggregate(OrderByDescending)
+			},
+			"invalid query: no aggregate selected to order by",
+		},
+		{
+			"Unsupported aggregate function",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Aggregate("pg_sleep", "age")
+			},
+			`invalid query: unsupported aggregate function "pg_sleep"`,
+		},
+		{
+			"Aggregate of all rows",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Aggregate(Sum, "")
+			},
+			`invalid query: unknown column "" of table users`,
+		},
+		{
+			"Step of multiples not positive",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").GroupByMultiple("deleted_at", 0)
+			},
+			`invalid query: step 0 of column "deleted_at" is not positive`,
+		},
+		{
+			"Unsupported time bucket",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").GroupByUnixNanoBucket("deleted_at", "fortnight", "UTC")
+			},
+			`invalid query: unsupported time bucket "fortnight"`,
+		},
+		{
+			"Unsupported row operator",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").ConditionRowp([]string{"age", "name"}, Equal, 20, "Jane")
+			},
+			`invalid query: unsupported row operator "="`,
+		},
+		{
+			"Row of columns without values",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").ConditionRowp([]string{"age", "name"}, LessThan, 20)
+			},
+			"invalid query: row of 2 columns compared with 1 values",
+		},
+		{
+			"Negative limit",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Limit(-1)
+			},
+			"invalid query: negative limit -1",
+		},
+		{
+			"Negative offset",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Offset(-1)
+			},
+			"invalid query: negative offset -1",
+		},
+		{
+			"First error is returned",
+			func(b *Builder) {
+				b.SelectAll(usersTable, "").Conditionp("password", Equal, "secret").Limit(-1)
+			},
+			`invalid query: unknown column "password" of table users`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := &Builder{
-				whereClauses:     tt.initialConditions,
-				args:             tt.initialArgs,
-				hasWhere:         tt.initialConditions != "",
-				conditionCounter: len(tt.initialArgs),
-			}
-			b.Conditionp(tt.lhs, tt.op, tt.val)
-
-			assert.Equal(t, tt.expectedConditions, b.whereClauses)
-			assert.Equal(t, tt.expectedArgs, b.args)
+			b := NewBuilder()
+			tt.setup(b)
+			query, args, err := b.Build()
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+			assert.EqualError(t, err, tt.err)
+			assert.Empty(t, query)
+			assert.Nil(t, args)
 		})
 	}
 }
+
+// safeQueryPattern matches the queries which can be built: quoted identifiers, supported operators, positional
+// arguments and numeric limits and offsets only.
+var safeQueryPattern = regexp.MustCompile(
+	`^SELECT \* FROM "[a-z_][a-z0-9_]*"( AS "[a-z_][a-z0-9_]*")?` +
+		`( WHERE (("[a-z_][a-z0-9_]*"\.)?"[a-z_][a-z0-9_]*" (=|<>|<|<=|>|>=|&&|LIKE|NOT LIKE) \$\d+|` +
+		`("[a-z_][a-z0-9_]*"\.)?"[a-z_][a-z0-9_]*" = ANY\(\$\d+\)|("[a-z_][a-z0-9_]*"\.)?"[a-z_][a-z0-9_]*" IS (NOT )?NULL)` +
+		`( AND .+)?)?` +
+		`( ORDER BY ("[a-z_][a-z0-9_]*"\.)?"[a-z_][a-z0-9_]*" (ASC|DESC))?( LIMIT \d+)?( OFFSET \d+)?$`,
+)
+
+func FuzzBuilder(f *testing.F) {
+	f.Add("u", "name", "=", "John", "age", "DESC", 10, 5)
+	f.Add("", "name; DROP TABLE users", "=", "x", "name", "ASC", -1, -1)
+	f.Add("u\"", "age", "= 1 OR", "'; --", "name\"", "ASC, 1", 0, 0)
+
+	f.Fuzz(func(t *testing.T, alias, column, op, value, orderColumn, direction string, limit, offset int) {
+		b := NewBuilder().
+			SelectAll(usersTable, alias).
+			Conditionp(column, Operator(op), value).
+			ConditionAny(column, []string{value}).
+			ConditionNull(column, len(value)%2 == 0).
+			OrderBy(orderColumn, OrderDirection(direction)).
+			Limit(limit).
+			Offset(offset)
+		query, args, err := b.Build()
+		if err != nil {
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+			return
+		}
+		if !safeQueryPattern.MatchString(query) {
+			t.Errorf("unexpected query %q", query)
+		}
+		// the value is only passed as argument
+		assert.Equal(t, []any{value, []string{value}}, args)
+		assert.Equal(t, 2, strings.Count(query, "$"), fmt.Sprintf("placeholders of %q", query))
+	})
+}
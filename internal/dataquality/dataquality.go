@@ -0,0 +1,50 @@
+package dataquality
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// Checker runs the data quality checks of the repository, which store the records violating the invariants of
+// the stored data. It is run by the scheduler.
+type Checker struct {
+	repo repository.Repository
+	now  func() time.Time
+}
+
+func NewChecker(repo repository.Repository) *Checker {
+	return &Checker{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Check runs all data quality checks once. A failing check does not prevent the others from running, but fails
+// the run.
+func (c *Checker) Check(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	now := c.now()
+	var total int64
+	failed := 0
+	for _, check := range repository.DataQualityChecks {
+		violations, err := c.repo.CheckDataQuality(ctx, check, now)
+		if err != nil {
+			logger.Errorw("could not run data quality check", "check", check.Name, "error", err)
+			failed++
+			continue
+		}
+		if violations > 0 {
+			logger.Warnw("data quality check found violations", "check", check.Name, "violations", violations)
+		}
+		total += violations
+	}
+	logger.Infow("finished data quality checks", "violations", total)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d data quality checks failed", failed, len(repository.DataQualityChecks))
+	}
+	return nil
+}
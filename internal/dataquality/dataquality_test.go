@@ -0,0 +1,35 @@
+package dataquality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestChecker_Check(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	// every check runs, even after one of them failed
+	var calls []any
+	for i, check := range repository.DataQualityChecks {
+		var err error
+		if i == 0 {
+			err = errors.New("connection refused")
+		}
+		calls = append(calls, repo.EXPECT().CheckDataQuality(gomock.Any(), check, now).Return(int64(i), err))
+	}
+	gomock.InOrder(calls...)
+
+	c := NewChecker(repo)
+	c.now = func() time.Time { return now }
+	assert.EqualError(t, c.Check(context.Background()), fmt.Sprintf("1 of %d data quality checks failed", len(repository.DataQualityChecks)))
+}
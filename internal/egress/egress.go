@@ -0,0 +1,131 @@
+// Package egress configures the transport of the outbound HTTP requests, e.g. to Yunikorn, webhooks, object storage
+// or the secret store, so that they reach their destination in environments which only allow the egress through a
+// proxy, intercept TLS with certificates of their own certificate authority, or do not resolve the hosts.
+//
+// The configured transport replaces the default transport of the process, which the HTTP clients of the server
+// use, so that every outbound request is sent with it.
+package egress
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+const (
+	// dialTimeout and dialKeepAlive are those of the dialer of the default transport.
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+// Install replaces the default transport of the process with the transport of the configuration. The default
+// transport is kept if the configuration does not change how outbound requests are sent.
+func Install(cfg *config.EgressConfig) error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return err
+	}
+	http.DefaultTransport = transport
+	return nil
+}
+
+// NewTransport creates a transport sending the requests through the proxy, trusting the additional certificate
+// authorities and connecting to the overridden addresses of the hosts of the configuration.
+func NewTransport(cfg *config.EgressConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxy, err := proxyFunc(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxy
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := certPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}
+	}
+
+	if len(cfg.Hosts) > 0 {
+		// host names are case-insensitive
+		hosts := make(map[string]string, len(cfg.Hosts))
+		for host, ip := range cfg.Hosts {
+			hosts[strings.ToLower(host)] = ip
+		}
+		dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: dialKeepAlive}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, overrideHost(hosts, addr))
+		}
+	}
+	return transport, nil
+}
+
+// proxyFunc returns the function selecting the proxy of the requests, which authenticates with the credentials of
+// the configuration. Hosts of link-local addresses, such as the instance metadata services of the cloud providers,
+// are always reached without the proxy.
+func proxyFunc(cfg *config.EgressConfig) (func(*http.Request) (*url.URL, error), error) {
+	proxyURL, err := url.Parse(cfg.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %v", err)
+	}
+	if cfg.ProxyUsername != "" || cfg.ProxyPassword != "" {
+		proxyURL.User = url.UserPassword(cfg.ProxyUsername, cfg.ProxyPassword)
+	}
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    strings.Join(cfg.NoProxy, ","),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		if ip := net.ParseIP(req.URL.Hostname()); ip != nil && ip.IsLinkLocalUnicast() {
+			return nil, nil
+		}
+		return proxy(req.URL)
+	}, nil
+}
+
+// certPool returns the certificate authorities of the system together with those of the PEM file.
+func certPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read egress CA file: %v", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("egress CA file %s does not contain PEM encoded certificates", caFile)
+	}
+	return pool, nil
+}
+
+// overrideHost returns the address with the host replaced by its overridden IP address, if any.
+func overrideHost(hosts map[string]string, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip, ok := hosts[strings.ToLower(host)]; ok {
+		return net.JoinHostPort(ip, port)
+	}
+	return addr
+}
@@ -0,0 +1,172 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// prefix marks encrypted values, which are formatted as enc:<key ID>:<base64 nonce and ciphertext>.
+// Values without the prefix were stored before encryption was enabled and are returned as they are.
+const prefix = "enc:"
+
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Cipher encrypts column values with AES-GCM. The nonce is derived from the value, so that encryption is
+// deterministic and encrypted columns can still be filtered by equality. As a consequence, equal values have equal
+// ciphertexts, which reveals which rows share a value but not the value itself.
+//
+// A nil Cipher leaves values unencrypted.
+type Cipher struct {
+	activeKey string
+	keys      map[string]*key
+	// ids are the IDs of the keys in a stable order.
+	ids []string
+}
+
+type key struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// New creates a Cipher from the configured keys. It returns nil if encryption is not enabled.
+func New(cfg *config.EncryptionConfig) (*Cipher, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	c := &Cipher{activeKey: cfg.ActiveKey, keys: make(map[string]*key, len(cfg.Keys))}
+	for id, encoded := range cfg.Keys {
+		secret, _ := base64.StdEncoding.DecodeString(encoded)
+		k, err := newKey(secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not create encryption key %q: %v", id, err)
+		}
+		c.keys[id] = k
+		c.ids = append(c.ids, id)
+	}
+	sort.Strings(c.ids)
+	return c, nil
+}
+
+// newKey derives separate encryption and nonce keys from the secret.
+func newKey(secret []byte) (*key, error) {
+	block, err := aes.NewCipher(derive(secret, "encryption"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &key{aead: aead, nonceKey: derive(secret, "nonce")}, nil
+}
+
+func derive(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// Encrypt encrypts the value with the active key. Empty values are not encrypted.
+func (c *Cipher) Encrypt(value string) string {
+	if c == nil || value == "" {
+		return value
+	}
+	return c.encrypt(c.activeKey, value)
+}
+
+func (c *Cipher) encrypt(id, value string) string {
+	k := c.keys[id]
+	mac := hmac.New(sha256.New, k.nonceKey)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:k.aead.NonceSize()]
+	// the key ID is authenticated, so that a value cannot be decrypted with a different key
+	sealed := k.aead.Seal(nonce, nonce, []byte(value), []byte(id))
+	return prefix + id + ":" + base64.RawStdEncoding.EncodeToString(sealed)
+}
+
+// EncryptAll encrypts the values with the active key.
+func (c *Cipher) EncryptAll(values []string) []string {
+	if c == nil || values == nil {
+		return values
+	}
+	encrypted := make([]string, len(values))
+	for i, v := range values {
+		encrypted[i] = c.Encrypt(v)
+	}
+	return encrypted
+}
+
+// Decrypt decrypts the value. Values which are not encrypted are returned as they are.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+	if c == nil || c.keys[id] == nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	k := c.keys[id]
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < k.aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():]
+	plaintext, err := k.aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt value with key %s: %v", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// DecryptAll decrypts the values.
+func (c *Cipher) DecryptAll(values []string) ([]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	decrypted := make([]string, len(values))
+	for i, v := range values {
+		var err error
+		if decrypted[i], err = c.Decrypt(v); err != nil {
+			return nil, err
+		}
+	}
+	return decrypted, nil
+}
+
+// Candidates returns every form in which the values may be stored: unencrypted, and encrypted with each key.
+// They are used to filter encrypted columns by equality, including values stored before a key rotation.
+func (c *Cipher) Candidates(values ...string) []string {
+	candidates := make([]string, 0, len(values)*(len(c.keyIDs())+1))
+	for _, v := range values {
+		candidates = append(candidates, v)
+		if v == "" {
+			continue
+		}
+		for _, id := range c.keyIDs() {
+			candidates = append(candidates, c.encrypt(id, v))
+		}
+	}
+	return candidates
+}
+
+func (c *Cipher) keyIDs() []string {
+	if c == nil {
+		return nil
+	}
+	return c.ids
+}
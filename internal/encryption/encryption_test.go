@@ -0,0 +1,73 @@
+package encryption
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+const (
+	testKeyV1 = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+	testKeyV2 = "ICEiIyQlJicoKSorLC0uLzAxMjM0NTY3ODk6Ozw9Pj8="
+)
+
+func TestCipher(t *testing.T) {
+	c, err := New(&config.EncryptionConfig{Keys: map[string]string{"v1": testKeyV1}, ActiveKey: "v1"})
+	require.NoError(t, err)
+
+	encrypted := c.Encrypt("alice")
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:"))
+	assert.NotContains(t, encrypted, "alice")
+	assert.Equal(t, encrypted, c.Encrypt("alice"), "encryption must be deterministic")
+	assert.NotEqual(t, encrypted, c.Encrypt("bob"))
+	assert.Equal(t, "", c.Encrypt(""))
+
+	decrypted, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", decrypted)
+
+	decrypted, err = c.Decrypt("bob")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", decrypted, "values stored before encryption was enabled are returned as they are")
+
+	groups, err := c.DecryptAll(c.EncryptAll([]string{"admins", "users"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins", "users"}, groups)
+
+	_, err = c.Decrypt(encrypted[:len(encrypted)-2])
+	assert.Error(t, err)
+	_, err = c.Decrypt(strings.Replace(encrypted, "enc:v1:", "enc:v2:", 1))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestCipher_KeyRotation(t *testing.T) {
+	old, err := New(&config.EncryptionConfig{Keys: map[string]string{"v1": testKeyV1}, ActiveKey: "v1"})
+	require.NoError(t, err)
+	rotated, err := New(&config.EncryptionConfig{Keys: map[string]string{"v1": testKeyV1, "v2": testKeyV2}, ActiveKey: "v2"})
+	require.NoError(t, err)
+
+	encrypted := old.Encrypt("alice")
+	decrypted, err := rotated.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", decrypted)
+	assert.True(t, strings.HasPrefix(rotated.Encrypt("alice"), "enc:v2:"))
+
+	candidates := rotated.Candidates("alice")
+	assert.Equal(t, []string{"alice", encrypted, rotated.Encrypt("alice")}, candidates)
+}
+
+func TestCipher_Disabled(t *testing.T) {
+	c, err := New(&config.EncryptionConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	assert.Equal(t, "alice", c.Encrypt("alice"))
+	assert.Equal(t, []string{"admins"}, c.EncryptAll([]string{"admins"}))
+	assert.Equal(t, []string{"alice", "admins"}, c.Candidates("alice", "admins"))
+	_, err = c.Decrypt("enc:v1:AAAA")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
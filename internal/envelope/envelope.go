@@ -0,0 +1,260 @@
+// Package envelope encrypts files with envelope encryption in the age format (https://age-encryption.org/v1): each
+// file is encrypted with a new file key, which is encrypted for each recipient, so that any of their identities
+// decrypts the file. The recipients are age X25519 recipients, whose files are decrypted with the age command line
+// tool, and AWS KMS keys, whose file keys are decrypted by KMS for the principals allowed to.
+package envelope
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+const (
+	// version is the first line of the header of the age format.
+	version = "age-encryption.org/v1"
+	// fileKeySize is the size of the file keys in bytes.
+	fileKeySize = 16
+	// nonceSize is the size of the nonce of the payload key in bytes.
+	nonceSize = 16
+	// columnsPerLine is the maximum number of characters of a line of the body of a stanza.
+	columnsPerLine = 64
+	// maxStanzas is the maximum number of stanzas of a header which is decrypted.
+	maxStanzas = 256
+)
+
+var (
+	// ErrNoIdentity is returned if none of the identities decrypts the file.
+	ErrNoIdentity = errors.New("no identity matched any of the recipients of the file")
+	// errIncorrectIdentity is returned by identities for the stanzas of other recipients.
+	errIncorrectIdentity = errors.New("incorrect identity for recipient stanza")
+)
+
+// stanza is the file key encrypted for a recipient.
+type stanza struct {
+	Type string
+	Args []string
+	Body []byte
+}
+
+// recipient encrypts the file key in a stanza.
+type recipient interface {
+	wrap(ctx context.Context, fileKey []byte) (*stanza, error)
+}
+
+// Identity decrypts the file keys encrypted for its recipient.
+type Identity interface {
+	unwrap(ctx context.Context, s *stanza) ([]byte, error)
+}
+
+// Encrypter encrypts files for the configured recipients.
+type Encrypter struct {
+	recipients []recipient
+}
+
+// New creates the Encrypter of the configured recipients. It returns nil if encryption is not enabled.
+// Credentials of AWS KMS are loaded from the default AWS credential chain.
+func New(ctx context.Context, cfg *config.ExportEncryptionConfig) (*Encrypter, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	e := &Encrypter{}
+	for _, s := range cfg.Recipients {
+		r, err := parseX25519Recipient(s)
+		if err != nil {
+			return nil, err
+		}
+		e.recipients = append(e.recipients, r)
+	}
+	if len(cfg.KMSKeyARNs) > 0 {
+		client, err := newKMSClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, arn := range cfg.KMSKeyARNs {
+			e.recipients = append(e.recipients, &kmsRecipient{client: client, arn: arn})
+		}
+	}
+	return e, nil
+}
+
+// Encrypt writes the header of a new file to the writer, and returns the writer of its plaintext, which must be
+// closed to write the end of the file.
+func (e *Encrypter) Encrypt(ctx context.Context, w io.Writer) (io.WriteCloser, error) {
+	fileKey := make([]byte, fileKeySize)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, err
+	}
+	var header bytes.Buffer
+	header.WriteString(version + "\n")
+	for _, r := range e.recipients {
+		s, err := r.wrap(ctx, fileKey)
+		if err != nil {
+			return nil, err
+		}
+		writeStanza(&header, s)
+	}
+	header.WriteString("---")
+	header.WriteString(" " + base64.RawStdEncoding.EncodeToString(headerMAC(fileKey, header.Bytes())) + "\n")
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(nonce); err != nil {
+		return nil, err
+	}
+	return newStreamWriter(hkdfKey(fileKey, nonce, "payload"), w)
+}
+
+// Decrypt reads the header of the file and returns the reader of its plaintext, decrypted with the file key of the
+// first stanza one of the identities decrypts. It returns ErrNoIdentity if none does.
+func Decrypt(ctx context.Context, r io.Reader, identities ...Identity) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	stanzas, header, mac, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	fileKey, err := unwrap(ctx, stanzas, identities)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(headerMAC(fileKey, header), mac) {
+		return nil, errors.New("the header of the file was modified")
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(br, nonce); err != nil {
+		return nil, fmt.Errorf("could not read payload nonce: %v", err)
+	}
+	return newStreamReader(hkdfKey(fileKey, nonce, "payload"), br)
+}
+
+// unwrap returns the file key of the first stanza one of the identities decrypts. Errors of identities, e.g. of
+// KMS keys the principal is not allowed to decrypt with, are returned if no identity decrypts any stanza.
+func unwrap(ctx context.Context, stanzas []*stanza, identities []Identity) ([]byte, error) {
+	var errs []error
+	for _, s := range stanzas {
+		for _, identity := range identities {
+			fileKey, err := identity.unwrap(ctx, s)
+			if errors.Is(err, errIncorrectIdentity) {
+				continue
+			}
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			return fileKey, nil
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(append([]error{ErrNoIdentity}, errs...)...)
+	}
+	return nil, ErrNoIdentity
+}
+
+// headerMAC returns the HMAC of the header up to and including the "---" of its last line.
+func headerMAC(fileKey, header []byte) []byte {
+	mac := hmac.New(sha256.New, hkdfKey(fileKey, nil, "header"))
+	mac.Write(header)
+	return mac.Sum(nil)
+}
+
+// writeStanza writes the stanza as its "->" line followed by its body in base64 lines of 64 columns, the last of
+// which is shorter, and therefore empty if the body fills the previous line.
+func writeStanza(w *bytes.Buffer, s *stanza) {
+	w.WriteString("-> " + s.Type)
+	for _, arg := range s.Args {
+		w.WriteString(" " + arg)
+	}
+	w.WriteString("\n")
+	body := base64.RawStdEncoding.EncodeToString(s.Body)
+	for len(body) >= columnsPerLine {
+		w.WriteString(body[:columnsPerLine] + "\n")
+		body = body[columnsPerLine:]
+	}
+	w.WriteString(body + "\n")
+}
+
+// readHeader reads the stanzas of the header and its MAC, and returns the header covered by the MAC.
+func readHeader(r *bufio.Reader) ([]*stanza, []byte, []byte, error) {
+	var header bytes.Buffer
+	line, err := readLine(r, &header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if line != version {
+		return nil, nil, nil, errors.New("not an age encrypted file")
+	}
+	var stanzas []*stanza
+	for {
+		line, err := readLine(r, &header)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if mac, ok := strings.CutPrefix(line, "--- "); ok {
+			// the MAC covers the header up to the "---" of its last line
+			covered := header.Bytes()[:header.Len()-len(line)-1+len("---")]
+			decoded, err := base64.RawStdEncoding.Strict().DecodeString(mac)
+			if err != nil {
+				return nil, nil, nil, errors.New("malformed header MAC")
+			}
+			return stanzas, covered, decoded, nil
+		}
+		fields, ok := strings.CutPrefix(line, "-> ")
+		if !ok || len(stanzas) == maxStanzas {
+			return nil, nil, nil, errors.New("malformed header")
+		}
+		args := strings.Split(fields, " ")
+		s := &stanza{Type: args[0], Args: args[1:]}
+		if s.Body, err = readStanzaBody(r, &header); err != nil {
+			return nil, nil, nil, err
+		}
+		stanzas = append(stanzas, s)
+	}
+}
+
+// readStanzaBody reads the base64 lines of the body of a stanza, up to the first line shorter than 64 columns.
+func readStanzaBody(r *bufio.Reader, header *bytes.Buffer) ([]byte, error) {
+	var body strings.Builder
+	for {
+		line, err := readLine(r, header)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) > columnsPerLine {
+			return nil, errors.New("malformed stanza body")
+		}
+		body.WriteString(line)
+		if len(line) < columnsPerLine {
+			break
+		}
+	}
+	decoded, err := base64.RawStdEncoding.Strict().DecodeString(body.String())
+	if err != nil {
+		return nil, errors.New("malformed stanza body")
+	}
+	return decoded, nil
+}
+
+// readLine reads a line of the header, which it appends to the header with its newline.
+func readLine(r *bufio.Reader, header *bytes.Buffer) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("could not read header: %v", err)
+	}
+	header.WriteString(line)
+	return strings.TrimSuffix(line, "\n"), nil
+}
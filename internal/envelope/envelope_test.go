@@ -0,0 +1,201 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestParseX25519Recipient(t *testing.T) {
+	r, err := parseX25519Recipient("age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p")
+	require.NoError(t, err)
+	assert.Len(t, r.publicKey.Bytes(), 32)
+
+	for _, invalid := range []string{
+		"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8q",
+		"AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX",
+		"age1",
+	} {
+		_, err := parseX25519Recipient(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestX25519Identity(t *testing.T) {
+	identity, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(identity.String(), "AGE-SECRET-KEY-1"))
+	assert.True(t, strings.HasPrefix(identity.Recipient(), "age1"))
+
+	parsed, err := ParseIdentities(strings.NewReader("# created: 2026-10-17\n" + identity.String() + "\n"))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	assert.Equal(t, identity.Recipient(), parsed[0].(*X25519Identity).Recipient())
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	identity, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	other, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	e, err := New(context.Background(), &config.ExportEncryptionConfig{Recipients: []string{other.Recipient(), identity.Recipient()}})
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 17} {
+		plaintext := bytes.Repeat([]byte("0123456789abcdef"), size/16+1)[:size]
+		encrypted := encrypt(t, e, plaintext)
+
+		r, err := Decrypt(context.Background(), bytes.NewReader(encrypted), identity)
+		require.NoError(t, err, size)
+		decrypted, err := io.ReadAll(r)
+		require.NoError(t, err, size)
+		assert.Equal(t, plaintext, decrypted, size)
+
+		// the payload is encrypted in full chunks, and the last chunk
+		chunks := size/chunkSize + 1
+		if size > 0 && size%chunkSize == 0 {
+			chunks--
+		}
+		headerSize := bytes.Index(encrypted, []byte("\n---")) + 1
+		headerSize += bytes.IndexByte(encrypted[headerSize:], '\n') + 1
+		assert.Equal(t, headerSize+nonceSize+size+chunks*16, len(encrypted), size)
+	}
+}
+
+func TestDecrypt_Rejected(t *testing.T) {
+	identity, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	other, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	e, err := New(context.Background(), &config.ExportEncryptionConfig{Recipients: []string{identity.Recipient()}})
+	require.NoError(t, err)
+	encrypted := encrypt(t, e, bytes.Repeat([]byte("x"), 2*chunkSize))
+	headerSize := bytes.Index(encrypted, []byte("\n---")) + 1
+
+	tests := map[string]struct {
+		modify   func(b []byte) []byte
+		identity Identity
+		wantErr  error
+	}{
+		"other identity": {
+			modify:   func(b []byte) []byte { return b },
+			identity: other,
+			wantErr:  ErrNoIdentity,
+		},
+		"modified header": {
+			modify: func(b []byte) []byte {
+				return bytes.Replace(b, []byte("-> X25519 "), []byte("-> X25519 \n-> other\n\n-> X25519 "), 1)
+			},
+			identity: identity,
+		},
+		"truncated payload": {
+			modify:   func(b []byte) []byte { return b[:len(b)-chunkSize-16] },
+			identity: identity,
+		},
+		"modified payload": {
+			modify: func(b []byte) []byte {
+				b[headerSize+100]++
+				return b
+			},
+			identity: identity,
+		},
+		"not encrypted": {
+			modify:   func([]byte) []byte { return []byte(`{"applicationID": "app-1"}` + "\n") },
+			identity: identity,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			modified := tt.modify(bytes.Clone(encrypted))
+			r, err := Decrypt(context.Background(), bytes.NewReader(modified), tt.identity)
+			if err == nil {
+				_, err = io.ReadAll(r)
+			}
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncryptDecrypt_KMS(t *testing.T) {
+	const (
+		alias = "arn:aws:kms:eu-west-1:123456789012:alias/yhs-exports"
+		key   = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	)
+	// the fake KMS "encrypts" by prefixing the plaintext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/kms/aws4_request")
+		var req struct {
+			KeyId          string
+			Plaintext      []byte
+			CiphertextBlob []byte
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			assert.Equal(t, alias, req.KeyId)
+			_ = json.NewEncoder(w).Encode(map[string]any{"CiphertextBlob": append([]byte("kms:"), req.Plaintext...), "KeyId": key})
+		case "TrentService.Decrypt":
+			if req.KeyId != key {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type": "AccessDeniedException"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"Plaintext": bytes.TrimPrefix(req.CiphertextBlob, []byte("kms:"))})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	client := &kmsClient{
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		signer:      v4.NewSigner(),
+		httpClient:  server.Client(),
+		endpoint:    func(string, string) string { return server.URL },
+		now:         time.Now,
+	}
+
+	e := &Encrypter{recipients: []recipient{&kmsRecipient{client: client, arn: alias}}}
+	encrypted := encrypt(t, e, []byte("app-1\n"))
+	assert.Contains(t, string(encrypted), "-> aws-kms "+key+"\n")
+
+	r, err := Decrypt(context.Background(), bytes.NewReader(encrypted), &KMSIdentity{client: client})
+	require.NoError(t, err)
+	decrypted, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "app-1\n", string(decrypted))
+
+	// the errors of KMS are returned if no identity decrypts the file
+	x25519, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	modified := bytes.Replace(encrypted, []byte(key), []byte(alias), 1)
+	_, err = Decrypt(context.Background(), bytes.NewReader(modified), x25519, &KMSIdentity{client: client})
+	assert.ErrorIs(t, err, ErrNoIdentity)
+	assert.ErrorContains(t, err, "AccessDeniedException")
+}
+
+func encrypt(t *testing.T, e *Encrypter, plaintext []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := e.Encrypt(context.Background(), &buf)
+	require.NoError(t, err)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
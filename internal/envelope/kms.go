@@ -0,0 +1,181 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+const (
+	// kmsStanzaType is the type of the stanzas of the file keys encrypted with AWS KMS, whose argument is the ARN
+	// of the key and whose body is the ciphertext blob of KMS.
+	kmsStanzaType = "aws-kms"
+	// maxKMSErrorBodySize is the maximum size of an error response of KMS which is returned in errors.
+	maxKMSErrorBodySize = 4096
+)
+
+// kmsClient sends requests to the JSON API of AWS KMS, signed with Signature Version 4. The requests are sent to the
+// region of the ARN of their key.
+type kmsClient struct {
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+	// endpoint returns the endpoint of KMS in the region of the partition.
+	endpoint func(partition, region string) string
+	now      func() time.Time
+}
+
+// newKMSClient creates a client whose credentials are loaded from the default AWS credential chain.
+func newKMSClient(ctx context.Context) (*kmsClient, error) {
+	// the SDK sends its requests with the default transport, to which the egress configuration applies
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithHTTPClient(http.DefaultClient))
+	if err != nil {
+		return nil, fmt.Errorf("could not load AWS config: %v", err)
+	}
+	return &kmsClient{
+		credentials: awsCfg.Credentials,
+		signer:      v4.NewSigner(),
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		endpoint:    kmsEndpoint,
+		now:         time.Now,
+	}, nil
+}
+
+func kmsEndpoint(partition, region string) string {
+	if partition == "aws-cn" {
+		return fmt.Sprintf("https://kms.%s.amazonaws.com.cn/", region)
+	}
+	return fmt.Sprintf("https://kms.%s.amazonaws.com/", region)
+}
+
+// encrypt encrypts the plaintext with the key, and returns the ciphertext blob and the ARN of the key, which is the
+// ARN of the key an alias refers to.
+func (c *kmsClient) encrypt(ctx context.Context, arn string, plaintext []byte) ([]byte, string, error) {
+	var resp struct {
+		CiphertextBlob []byte
+		KeyId          string
+	}
+	err := c.do(ctx, arn, "Encrypt", map[string]any{"KeyId": arn, "Plaintext": plaintext}, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.CiphertextBlob, resp.KeyId, nil
+}
+
+// decrypt decrypts the ciphertext blob encrypted with the key.
+func (c *kmsClient) decrypt(ctx context.Context, arn string, ciphertext []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext []byte
+	}
+	err := c.do(ctx, arn, "Decrypt", map[string]any{"KeyId": arn, "CiphertextBlob": ciphertext}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// do sends the request of the action to the region of the key, and decodes its response. Byte slices are encoded
+// in base64 in both, as in the API.
+func (c *kmsClient) do(ctx context.Context, arn, action string, request, response any) error {
+	// arn:partition:kms:region:account:key/id
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[2] != "kms" {
+		return fmt.Errorf("%q is not the ARN of a KMS key", arn)
+	}
+	partition, region := parts[1], parts[3]
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(partition, region), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	credentials, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve AWS credentials: %v", err)
+	}
+	sum := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(sum[:]), "kms", region, c.now()); err != nil {
+		return fmt.Errorf("could not sign request: %v", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not %s with KMS key %s: %v", strings.ToLower(action), arn, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		problem, _ := io.ReadAll(io.LimitReader(resp.Body, maxKMSErrorBodySize))
+		return fmt.Errorf("could not %s with KMS key %s: KMS returned status %d: %s", strings.ToLower(action), arn,
+			resp.StatusCode, bytes.TrimSpace(problem))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("could not decode KMS %s response: %v", action, err)
+	}
+	return nil
+}
+
+// kmsRecipient encrypts the file key with an AWS KMS key.
+type kmsRecipient struct {
+	client *kmsClient
+	arn    string
+}
+
+func (r *kmsRecipient) wrap(ctx context.Context, fileKey []byte) (*stanza, error) {
+	blob, keyARN, err := r.client.encrypt(ctx, r.arn, fileKey)
+	if err != nil {
+		return nil, err
+	}
+	if keyARN == "" {
+		keyARN = r.arn
+	}
+	// the ARN of the key, rather than of its alias, decrypts the file key even if the alias is moved to another key
+	return &stanza{Type: kmsStanzaType, Args: []string{keyARN}, Body: blob}, nil
+}
+
+// KMSIdentity decrypts the file keys encrypted with AWS KMS keys, with the credentials of the default AWS credential
+// chain.
+type KMSIdentity struct {
+	client *kmsClient
+}
+
+// NewKMSIdentity creates the identity decrypting with the AWS KMS keys the principal of the default AWS credential
+// chain is allowed to decrypt with.
+func NewKMSIdentity(ctx context.Context) (*KMSIdentity, error) {
+	client, err := newKMSClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &KMSIdentity{client: client}, nil
+}
+
+func (i *KMSIdentity) unwrap(ctx context.Context, s *stanza) ([]byte, error) {
+	if s.Type != kmsStanzaType {
+		return nil, errIncorrectIdentity
+	}
+	if len(s.Args) != 1 {
+		return nil, errors.New("malformed aws-kms stanza")
+	}
+	fileKey, err := i.client.decrypt(ctx, s.Args[0], s.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileKey) != fileKeySize {
+		return nil, errors.New("invalid aws-kms stanza: invalid file key size")
+	}
+	return fileKey, nil
+}
@@ -0,0 +1,147 @@
+package envelope
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// chunkSize is the size of the plaintext of the chunks of the payload, all but the last of which are full.
+const chunkSize = 64 << 10
+
+// streamWriter encrypts the payload in chunks with ChaCha20-Poly1305, whose nonces are the counter of the chunk
+// followed by a flag marking the last chunk, so that truncated and reordered payloads are detected.
+type streamWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	buf     []byte
+	counter uint64
+	closed  bool
+}
+
+func newStreamWriter(key []byte, w io.Writer) (*streamWriter, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &streamWriter{w: w, aead: aead, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, errors.New("write to closed encrypted stream")
+	}
+	written := 0
+	for len(p) > 0 {
+		// a full chunk is only written once more data follows, as the last chunk must be marked
+		if len(s.buf) == chunkSize {
+			if err := s.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(s.buf[len(s.buf):chunkSize], p)
+		s.buf = s.buf[:len(s.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the last chunk. It does not close the underlying writer.
+func (s *streamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.flush(true)
+}
+
+func (s *streamWriter) flush(last bool) error {
+	sealed := s.aead.Seal(nil, chunkNonce(s.counter, last), s.buf, nil)
+	if _, err := s.w.Write(sealed); err != nil {
+		return err
+	}
+	s.counter++
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// streamReader decrypts the payload written by a streamWriter.
+type streamReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	chunk   []byte
+	buf     []byte
+	counter uint64
+	done    bool
+}
+
+func newStreamReader(key []byte, r *bufio.Reader) (*streamReader, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &streamReader{r: r, aead: aead, chunk: make([]byte, chunkSize+aead.Overhead())}, nil
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+		if err := s.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// next decrypts the next chunk. A chunk is the last one if it is not full, or if the payload ends after it.
+func (s *streamReader) next() error {
+	n, err := io.ReadFull(s.r, s.chunk)
+	last := false
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := s.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		}
+	}
+	if n < s.aead.Overhead() {
+		return errors.New("encrypted payload is truncated")
+	}
+	plaintext, err := s.aead.Open(s.chunk[:0], chunkNonce(s.counter, last), s.chunk[:n], nil)
+	if err != nil {
+		return fmt.Errorf("could not decrypt chunk %d of the payload", s.counter)
+	}
+	// only the payload of an empty file ends with an empty chunk
+	if last && len(plaintext) == 0 && s.counter > 0 {
+		return errors.New("encrypted payload ends with an empty chunk")
+	}
+	s.buf = plaintext
+	s.counter++
+	s.done = last
+	return nil
+}
+
+// chunkNonce returns the nonce of the chunk: its big endian counter in 11 bytes, and 1 if it is the last chunk.
+func chunkNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	for i := 10; i >= 3; i-- {
+		nonce[i] = byte(counter)
+		counter >>= 8
+	}
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
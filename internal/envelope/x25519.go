@@ -0,0 +1,200 @@
+package envelope
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// x25519StanzaType is the type of the stanzas of the age X25519 recipients.
+	x25519StanzaType = "X25519"
+	// x25519Label is the HKDF info of the key wrapping the file key for an X25519 recipient.
+	x25519Label = "age-encryption.org/v1/X25519"
+	// recipientHRP and identityHRP are the human-readable parts of the Bech32 encoded recipients and identities.
+	recipientHRP = "age"
+	identityHRP  = "AGE-SECRET-KEY-"
+)
+
+// x25519Recipient encrypts the file key for the holder of the identity of the public key.
+type x25519Recipient struct {
+	publicKey *ecdh.PublicKey
+}
+
+// parseX25519Recipient parses the Bech32 encoded age X25519 recipient, age1...
+func parseX25519Recipient(s string) (*x25519Recipient, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed age recipient %q: %v", s, err)
+	}
+	if hrp != recipientHRP {
+		return nil, fmt.Errorf("malformed age recipient %q: not an X25519 recipient", s)
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("malformed age recipient %q: %v", s, err)
+	}
+	return &x25519Recipient{publicKey: publicKey}, nil
+}
+
+func (r *x25519Recipient) wrap(_ context.Context, fileKey []byte) (*stanza, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(r.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	share := ephemeral.PublicKey().Bytes()
+	wrapped, err := aeadSeal(x25519WrapKey(shared, share, r.publicKey.Bytes()), fileKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stanza{
+		Type: x25519StanzaType,
+		Args: []string{base64.RawStdEncoding.EncodeToString(share)},
+		Body: wrapped,
+	}, nil
+}
+
+// X25519Identity decrypts the file keys encrypted for its age X25519 recipient.
+type X25519Identity struct {
+	privateKey *ecdh.PrivateKey
+}
+
+// GenerateX25519Identity generates a new identity.
+func GenerateX25519Identity() (*X25519Identity, error) {
+	privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{privateKey: privateKey}, nil
+}
+
+// ParseX25519Identity parses the Bech32 encoded age X25519 identity, AGE-SECRET-KEY-1...
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed age identity: %v", err)
+	}
+	if hrp != strings.ToLower(identityHRP) {
+		return nil, errors.New("malformed age identity: not an X25519 identity")
+	}
+	privateKey, err := ecdh.X25519().NewPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("malformed age identity: %v", err)
+	}
+	return &X25519Identity{privateKey: privateKey}, nil
+}
+
+// ParseIdentities parses the age X25519 identities of an identity file, one per line. Empty lines and comments
+// starting with # are ignored.
+func ParseIdentities(r io.Reader) ([]Identity, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var identities []Identity
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identity, err := ParseX25519Identity(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		identities = append(identities, identity)
+	}
+	if len(identities) == 0 {
+		return nil, errors.New("no identities found")
+	}
+	return identities, nil
+}
+
+// String returns the Bech32 encoded identity.
+func (i *X25519Identity) String() string {
+	s, _ := bech32Encode(identityHRP, i.privateKey.Bytes())
+	return strings.ToUpper(s)
+}
+
+// Recipient returns the Bech32 encoded recipient of the identity.
+func (i *X25519Identity) Recipient() string {
+	s, _ := bech32Encode(recipientHRP, i.privateKey.PublicKey().Bytes())
+	return s
+}
+
+func (i *X25519Identity) unwrap(_ context.Context, s *stanza) ([]byte, error) {
+	if s.Type != x25519StanzaType {
+		return nil, errIncorrectIdentity
+	}
+	if len(s.Args) != 1 {
+		return nil, errors.New("malformed X25519 stanza")
+	}
+	share, err := base64.RawStdEncoding.Strict().DecodeString(s.Args[0])
+	if err != nil {
+		return nil, errors.New("malformed X25519 stanza")
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(share)
+	if err != nil {
+		return nil, errors.New("malformed X25519 stanza")
+	}
+	shared, err := i.privateKey.ECDH(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid X25519 stanza: %v", err)
+	}
+	fileKey, err := aeadOpen(x25519WrapKey(shared, share, i.privateKey.PublicKey().Bytes()), s.Body)
+	if err != nil {
+		// the file key was encrypted for another recipient
+		return nil, errIncorrectIdentity
+	}
+	return fileKey, nil
+}
+
+// x25519WrapKey derives the key wrapping the file key from the shared secret, the ephemeral share and the public key
+// of the recipient.
+func x25519WrapKey(shared, share, publicKey []byte) []byte {
+	salt := make([]byte, 0, len(share)+len(publicKey))
+	salt = append(salt, share...)
+	salt = append(salt, publicKey...)
+	return hkdfKey(shared, salt, x25519Label)
+}
+
+func hkdfKey(secret, salt []byte, info string) []byte {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// aeadSeal and aeadOpen encrypt and decrypt the file key with ChaCha20-Poly1305 and a zero nonce, which is safe as
+// each wrapping key is used once.
+func aeadSeal(key, plaintext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), plaintext, nil), nil
+}
+
+func aeadOpen(key, ciphertext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) != fileKeySize+aead.Overhead() {
+		return nil, errors.New("invalid wrapped file key size")
+	}
+	return aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), ciphertext, nil)
+}
@@ -0,0 +1,110 @@
+package erasure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// DefaultBatchSize is the default number of applications erased per database statement.
+const DefaultBatchSize = 1000
+
+// Eraser erases the records attributable to a user. Applications are erased in batches and the progress is
+// stored after every batch, so that an interrupted erasure is resumed when the same user is erased again.
+type Eraser struct {
+	repo      repository.Repository
+	batchSize int
+	now       func() time.Time
+}
+
+type Option func(*Eraser)
+
+// WithBatchSize sets the number of applications erased per database statement.
+func WithBatchSize(batchSize int) Option {
+	return func(e *Eraser) {
+		e.batchSize = batchSize
+	}
+}
+
+func NewEraser(repo repository.Repository, opts ...Option) *Eraser {
+	e := &Eraser{
+		repo:      repo,
+		batchSize: DefaultBatchSize,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Erase pseudonymizes or deletes, depending on the mode, the applications of the user and pseudonymizes
+// the user in the audit records. Applications under an active legal hold are retained.
+// If an erasure of the user is in progress, it is resumed with its original mode and pseudonym.
+func (e *Eraser) Erase(ctx context.Context, user, mode, requestedBy string) (*model.UserErasure, error) {
+	if mode != model.ErasureModePseudonymize && mode != model.ErasureModeDelete {
+		return nil, fmt.Errorf("unknown erasure mode: %s", mode)
+	}
+	erasure := &model.UserErasure{
+		SubjectHash: SubjectHash(user),
+		Pseudonym:   "erased-" + uuid.NewString()[:8],
+		Mode:        mode,
+		RequestedBy: requestedBy,
+	}
+	if err := e.repo.StartUserErasure(ctx, erasure); err != nil {
+		return nil, err
+	}
+	logger := log.FromContext(ctx).With("erasure", erasure.ID)
+
+	for {
+		erased, err := e.repo.EraseUserApplications(ctx, user, erasure.Pseudonym, erasure.Mode, e.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if erased == 0 {
+			break
+		}
+		erasure.Applications += erased
+		if err := e.repo.UpdateUserErasure(ctx, erasure); err != nil {
+			return nil, err
+		}
+		logger.Debugw("erased applications", "mode", erasure.Mode, "applications", erasure.Applications)
+		if erased < int64(e.batchSize) {
+			break
+		}
+	}
+
+	retained, err := e.repo.CountUserApplications(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	audited, err := e.repo.PseudonymizeAuditRecords(ctx, user, erasure.Pseudonym)
+	if err != nil {
+		return nil, err
+	}
+	completedAt := e.now().Unix()
+	erasure.ApplicationsRetained = retained
+	erasure.AuditRecords += audited
+	erasure.Status = model.ErasureStatusCompleted
+	erasure.CompletedAt = &completedAt
+	if err := e.repo.UpdateUserErasure(ctx, erasure); err != nil {
+		return nil, err
+	}
+	logger.Infow("user erased", "mode", erasure.Mode, "applications", erasure.Applications,
+		"retained", erasure.ApplicationsRetained, "auditRecords", erasure.AuditRecords)
+	return erasure, nil
+}
+
+// SubjectHash returns the hash identifying the erasures of the user.
+func SubjectHash(user string) string {
+	sum := sha256.Sum256([]byte(user))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package erasure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestEraser_Erase(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	var progress []int64
+	gomock.InOrder(
+		repo.EXPECT().StartUserErasure(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, erasure *model.UserErasure) error {
+				assert.Equal(t, SubjectHash("alice"), erasure.SubjectHash)
+				assert.Equal(t, model.ErasureModeDelete, erasure.Mode)
+				// resume an erasure in progress which was started in pseudonymize mode
+				erasure.ID = "erasure-1"
+				erasure.Pseudonym = "erased-1234abcd"
+				erasure.Mode = model.ErasureModePseudonymize
+				erasure.Status = model.ErasureStatusInProgress
+				erasure.Applications = 4
+				return nil
+			}),
+		repo.EXPECT().EraseUserApplications(gomock.Any(), "alice", "erased-1234abcd", model.ErasureModePseudonymize, 2).
+			Return(int64(2), nil),
+		repo.EXPECT().UpdateUserErasure(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, erasure *model.UserErasure) error {
+				progress = append(progress, erasure.Applications)
+				return nil
+			}),
+		repo.EXPECT().EraseUserApplications(gomock.Any(), "alice", "erased-1234abcd", model.ErasureModePseudonymize, 2).
+			Return(int64(1), nil),
+		repo.EXPECT().UpdateUserErasure(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, erasure *model.UserErasure) error {
+				progress = append(progress, erasure.Applications)
+				return nil
+			}),
+		repo.EXPECT().CountUserApplications(gomock.Any(), "alice").Return(int64(3), nil),
+		repo.EXPECT().PseudonymizeAuditRecords(gomock.Any(), "alice", "erased-1234abcd").Return(int64(2), nil),
+		repo.EXPECT().UpdateUserErasure(gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	e := NewEraser(repo, WithBatchSize(2))
+	e.now = func() time.Time { return now }
+	erasure, err := e.Erase(context.Background(), "alice", model.ErasureModeDelete, "ops")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{6, 7}, progress)
+	assert.Equal(t, &model.UserErasure{
+		ID:                   "erasure-1",
+		SubjectHash:          SubjectHash("alice"),
+		Pseudonym:            "erased-1234abcd",
+		Mode:                 model.ErasureModePseudonymize,
+		Status:               model.ErasureStatusCompleted,
+		RequestedBy:          "ops",
+		CompletedAt:          util.ToPtr(now.Unix()),
+		Applications:         7,
+		ApplicationsRetained: 3,
+		AuditRecords:         2,
+	}, erasure)
+}
+
+func TestEraser_EraseInterrupted(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().StartUserErasure(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().EraseUserApplications(gomock.Any(), "alice", gomock.Any(), model.ErasureModeDelete, DefaultBatchSize).
+		Return(int64(0), errors.New("connection refused"))
+
+	_, err := NewEraser(repo).Erase(context.Background(), "alice", model.ErasureModeDelete, "ops")
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestEraser_EraseUnknownMode(t *testing.T) {
+	_, err := NewEraser(nil).Erase(context.Background(), "alice", "shred", "ops")
+	assert.EqualError(t, err, "unknown erasure mode: shred")
+}
@@ -0,0 +1,93 @@
+// Package eventschema describes the JSON payloads of the events and records stored by the history server,
+// so that downstream consumers can validate their parsers.
+package eventschema
+
+import (
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+
+	"github.com/G-Research/yunikorn-history-server/internal/jsonschema"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// EventSchema is the JSON Schema of a version of the payload of an event type.
+type EventSchema struct {
+	Type        string             `json:"type"`
+	Version     int                `json:"version"`
+	Description string             `json:"description"`
+	Schema      *jsonschema.Schema `json:"schema"`
+}
+
+// eventType registers the Go type of the payload of an event type. The version must be incremented whenever
+// the payload changes, which is enforced by the golden files of the tests.
+type eventType struct {
+	name        string
+	version     int
+	description string
+	payload     any
+}
+
+var eventTypes = []eventType{
+	{
+		name:        "scheduler-event",
+		version:     1,
+		description: "Event received from the YuniKorn scheduler event stream.",
+		payload:     si.EventRecord{},
+	},
+	{
+		name:        "application",
+		version:     5,
+		description: "Application, as returned by the application endpoints.",
+		payload:     model.ApplicationDAOInfo{},
+	},
+	{
+		name:        "queue",
+		version:     1,
+		description: "Queue and its child queues, as returned by the queue endpoints.",
+		payload:     model.PartitionQueueDAOInfo{},
+	},
+	{
+		name:        "queue-acl",
+		version:     1,
+		description: "Snapshot of the access control lists of a queue.",
+		payload:     model.QueueACL{},
+	},
+	{
+		name:        "node",
+		version:     1,
+		description: "Node of a partition.",
+		payload:     dao.NodeDAOInfo{},
+	},
+	{
+		name:        "partition",
+		version:     1,
+		description: "Partition of the scheduler.",
+		payload:     dao.PartitionInfo{},
+	},
+	{
+		name:        "application-history",
+		version:     1,
+		description: "Total number of applications at a point in time.",
+		payload:     dao.ApplicationHistoryDAOInfo{},
+	},
+	{
+		name:        "container-history",
+		version:     1,
+		description: "Total number of containers at a point in time.",
+		payload:     dao.ContainerHistoryDAOInfo{},
+	},
+}
+
+// Events returns the schemas of all event types, generated from their Go types.
+func Events() []*EventSchema {
+	schemas := make([]*EventSchema, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		schemas = append(schemas, &EventSchema{
+			Type:        t.name,
+			Version:     t.version,
+			Description: t.description,
+			Schema:      jsonschema.For(t.payload),
+		})
+	}
+	return schemas
+}
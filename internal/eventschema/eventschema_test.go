@@ -0,0 +1,49 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "create the golden files of new event schema versions")
+
+// TestEventsGolden compares each event schema with testdata/<type>.v<version>.json. A published version must
+// never change, so an existing golden file is never updated: change the version of the event type instead,
+// and run the tests with -update to create the golden file of the new version.
+func TestEventsGolden(t *testing.T) {
+	for _, schema := range Events() {
+		t.Run(fmt.Sprintf("%s.v%d", schema.Type, schema.Version), func(t *testing.T) {
+			got, err := json.MarshalIndent(schema.Schema, "", "  ")
+			require.NoError(t, err)
+			got = append(got, '\n')
+
+			path := filepath.Join("testdata", fmt.Sprintf("%s.v%d.json", schema.Type, schema.Version))
+			want, err := os.ReadFile(path)
+			if os.IsNotExist(err) && *updateGolden {
+				require.NoError(t, os.WriteFile(path, got, 0o600))
+				return
+			}
+			require.NoError(t, err, "run the tests with -update to create the golden file of a new version")
+			assert.Equal(t, string(want), string(got),
+				"the payload of event type %s changed: increment its version instead of changing version %d",
+				schema.Type, schema.Version)
+		})
+	}
+}
+
+func TestEventsUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, schema := range Events() {
+		assert.False(t, seen[schema.Type], "duplicate event type %s", schema.Type)
+		seen[schema.Type] = true
+		assert.Positive(t, schema.Version)
+		assert.NotEmpty(t, schema.Description)
+	}
+}
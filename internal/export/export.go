@@ -0,0 +1,246 @@
+// Package export writes exports of the history as immutable newline delimited JSON files, whose downloads can be
+// resumed with range requests: local files are served by the history server, and files in S3 are downloaded from
+// the object storage directly with presigned URLs.
+package export
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/envelope"
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+)
+
+const (
+	// ContentType is the media type of exports.
+	ContentType = "application/x-ndjson"
+	// EncryptedContentType is the media type of encrypted exports, which are files in the age format.
+	EncryptedContentType = "application/octet-stream"
+)
+
+// ErrNotFound is returned for exports which do not exist.
+var ErrNotFound = errors.New("export not found")
+
+// Export is an export written to the store. Its content never changes, so that interrupted downloads can be resumed.
+type Export struct {
+	// ID identifies the export.
+	ID string `json:"id"`
+	// Rows is the number of rows of the export.
+	Rows int `json:"rows"`
+	// Size is the size of the export in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded SHA-256 checksum of the export, with which clients verify resumed downloads.
+	SHA256 string `json:"sha256"`
+	// Encrypted is whether the export is encrypted in the age format, in which case its size and checksum are those
+	// of the encrypted file.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// CreatedAt is the time the export was written.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Store writes exports to the configured storage and serves their downloads.
+type Store struct {
+	store     objectstore.Store
+	urlExpiry time.Duration
+	encrypter *envelope.Encrypter
+	now       func() time.Time
+}
+
+// Option configures the store.
+type Option func(*Store)
+
+// WithEncrypter encrypts the exports written to the store. Exports are not encrypted if the encrypter is nil.
+func WithEncrypter(encrypter *envelope.Encrypter) Option {
+	return func(s *Store) {
+		s.encrypter = encrypter
+	}
+}
+
+// NewStore creates the store of the exports of the configuration, which must be enabled. The exports are encrypted
+// if encryption is configured.
+func NewStore(ctx context.Context, cfg *config.ExportsConfig) (*Store, error) {
+	store, err := objectstore.New(ctx, &cfg.ObjectStorageConfig)
+	if err != nil {
+		return nil, err
+	}
+	encrypter, err := envelope.New(ctx, &cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreWith(store, cfg.URLExpiry, WithEncrypter(encrypter))
+}
+
+// NewStoreWith creates the store of the exports written to the object store, which must support uploads from files,
+// and either presigned URLs or opening its objects.
+func NewStoreWith(store objectstore.Store, urlExpiry time.Duration, opts ...Option) (*Store, error) {
+	if _, ok := store.(objectstore.Uploader); !ok {
+		return nil, errors.New("the storage of the exports does not support uploads")
+	}
+	_, presigner := store.(objectstore.Presigner)
+	_, opener := store.(objectstore.Opener)
+	if !presigner && !opener {
+		return nil, errors.New("the storage of the exports does not support downloads")
+	}
+	s := &Store{store: store, urlExpiry: urlExpiry, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Writer writes the rows of an export.
+type Writer struct {
+	encoder *json.Encoder
+	rows    int
+}
+
+// Write writes the row as a line of JSON.
+func (w *Writer) Write(row any) error {
+	if err := w.encoder.Encode(row); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+// Create writes the rows written by the write function to a temporary file, and then uploads it as a new export.
+// The export is not created if the write function fails. If the store encrypts the exports, the rows are encrypted
+// before they are written to the file, so that they are never stored unencrypted.
+func (s *Store) Create(ctx context.Context, write func(w *Writer) error) (*Export, error) {
+	f, err := os.CreateTemp("", "yhs-export-*")
+	if err != nil {
+		return nil, fmt.Errorf("could not create export file: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(f, hash)}
+	plaintext := io.WriteCloser(nopCloser{counter})
+	if s.encrypter != nil {
+		if plaintext, err = s.encrypter.Encrypt(ctx, counter); err != nil {
+			return nil, fmt.Errorf("could not encrypt export: %v", err)
+		}
+	}
+	buffered := bufio.NewWriter(plaintext)
+	w := &Writer{encoder: json.NewEncoder(buffered)}
+	if err := write(w); err != nil {
+		return nil, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return nil, fmt.Errorf("could not write export file: %v", err)
+	}
+	if err := plaintext.Close(); err != nil {
+		return nil, fmt.Errorf("could not write export file: %v", err)
+	}
+
+	export := &Export{
+		ID:        uuid.NewString(),
+		Rows:      w.rows,
+		Size:      counter.n,
+		SHA256:    hex.EncodeToString(hash.Sum(nil)),
+		Encrypted: s.encrypter != nil,
+		CreatedAt: s.now().UTC(),
+	}
+	contentType := ContentType
+	if export.Encrypted {
+		contentType = EncryptedContentType
+	}
+	if err := s.store.(objectstore.Uploader).Upload(ctx, s.key(export.ID), f, export.Size, export.SHA256, contentType); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Serve serves the download of the export. Exports stored in object storage are redirected to a presigned URL,
+// and local exports are served with support for range requests and conditional requests on their ETag, which is
+// their ID as their content never changes. It returns ErrNotFound if the export does not exist.
+func (s *Store) Serve(w http.ResponseWriter, r *http.Request, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrNotFound
+	}
+	if presigner, ok := s.store.(objectstore.Presigner); ok {
+		presigned, err := presigner.Presign(r.Context(), s.key(id), s.key(id), s.urlExpiry)
+		if err != nil {
+			return err
+		}
+		http.Redirect(w, r, presigned, http.StatusTemporaryRedirect)
+		return nil
+	}
+	// local exports written before encryption was enabled or disabled are found by their other key
+	filename, contentType := plainKey(id), ContentType
+	f, err := s.store.(objectstore.Opener).Open(plainKey(id))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		filename, contentType = encryptedKey(id), EncryptedContentType
+		f, err = s.store.(objectstore.Opener).Open(encryptedKey(id))
+	}
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("could not read export %s: %v", id, err)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("ETag", `"`+id+`"`)
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	http.ServeContent(w, r, filename, info.ModTime(), f)
+	return nil
+}
+
+// key returns the key of the object of the export, which is also the file name of its download.
+func (s *Store) key(id string) string {
+	if s.encrypter != nil {
+		return encryptedKey(id)
+	}
+	return plainKey(id)
+}
+
+func plainKey(id string) string {
+	return id + ".ndjson"
+}
+
+func encryptedKey(id string) string {
+	return id + ".ndjson.age"
+}
+
+// nopCloser does not close the writer of the unencrypted exports.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// countingWriter counts the bytes written to the writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,194 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/envelope"
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+)
+
+// presigningStore stores the objects in memory, and presigns fake URLs.
+type presigningStore struct {
+	objects map[string][]byte
+}
+
+func (s *presigningStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	s.objects[key] = data
+	return nil
+}
+
+func (s *presigningStore) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	return s.Put(ctx, key, data, contentType)
+}
+
+func (s *presigningStore) Get(_ context.Context, key string) ([]byte, error) {
+	return s.objects[key], nil
+}
+
+func (s *presigningStore) Upload(_ context.Context, key string, f *os.File, _ int64, _ string, _ string) error {
+	data, err := os.ReadFile(f.Name())
+	s.objects[key] = data
+	return err
+}
+
+func (s *presigningStore) Presign(_ context.Context, key, filename string, expiry time.Duration) (string, error) {
+	return "https://yhs-exports.s3.amazonaws.com/" + key + "?filename=" + filename + "&expires=" + expiry.String(), nil
+}
+
+func TestStore_File(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore(ctx, &config.ExportsConfig{ObjectStorageConfig: config.ObjectStorageConfig{URL: "file://" + t.TempDir()}, URLExpiry: time.Minute})
+	require.NoError(t, err)
+
+	export, err := store.Create(ctx, func(w *Writer) error {
+		for _, id := range []string{"app-1", "app-2"} {
+			if err := w.Write(map[string]string{"applicationID": id}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	content := "{\"applicationID\":\"app-1\"}\n{\"applicationID\":\"app-2\"}\n"
+	sum := sha256.Sum256([]byte(content))
+	assert.Equal(t, 2, export.Rows)
+	assert.Equal(t, int64(len(content)), export.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), export.SHA256)
+
+	serve := func(id string, header http.Header) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, "/ws/v1/exports/"+id, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		return rec, store.Serve(rec, req, id)
+	}
+
+	rec, err := serve(export.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, content, rec.Body.String())
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+	assert.Equal(t, `"`+export.ID+`"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "attachment; filename="+export.ID+".ndjson", rec.Header().Get("Content-Disposition"))
+
+	// interrupted downloads are resumed with range requests, conditional on the content being unchanged
+	rec, err = serve(export.ID, http.Header{"Range": {"bytes=26-"}, "If-Range": {`"` + export.ID + `"`}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, content[26:], rec.Body.String())
+	assert.Equal(t, "bytes 26-51/52", rec.Header().Get("Content-Range"))
+
+	rec, err = serve(export.ID, http.Header{"Range": {"bytes=26-"}, "If-Range": {`"other"`}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, content, rec.Body.String())
+
+	_, err = serve(uuid.NewString(), nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = serve("../config", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_CreateFails(t *testing.T) {
+	objects := make(map[string][]byte)
+	store, err := NewStoreWith(&presigningStore{objects: objects}, time.Minute)
+	require.NoError(t, err)
+
+	_, err = store.Create(context.Background(), func(w *Writer) error {
+		if err := w.Write(map[string]string{"applicationID": "app-1"}); err != nil {
+			return err
+		}
+		return errors.New("connection refused")
+	})
+	assert.EqualError(t, err, "connection refused")
+	assert.Empty(t, objects)
+}
+
+func TestStore_Presigned(t *testing.T) {
+	objects := make(map[string][]byte)
+	store, err := NewStoreWith(&presigningStore{objects: objects}, 15*time.Minute)
+	require.NoError(t, err)
+
+	export, err := store.Create(context.Background(), func(w *Writer) error {
+		return w.Write(map[string]string{"applicationID": "app-1"})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"applicationID\":\"app-1\"}\n", string(objects[export.ID+".ndjson"]))
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, store.Serve(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/exports/"+export.ID, nil), export.ID))
+	assert.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+	assert.Equal(t, "https://yhs-exports.s3.amazonaws.com/"+export.ID+".ndjson?filename="+export.ID+".ndjson&expires=15m0s",
+		rec.Header().Get("Location"))
+}
+
+func TestStore_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	identity, err := envelope.GenerateX25519Identity()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	cfg := &config.ExportsConfig{
+		ObjectStorageConfig: config.ObjectStorageConfig{URL: "file://" + dir},
+		URLExpiry:           time.Minute,
+		Encryption:          config.ExportEncryptionConfig{Recipients: []string{identity.Recipient()}},
+	}
+	store, err := NewStore(ctx, cfg)
+	require.NoError(t, err)
+
+	export, err := store.Create(ctx, func(w *Writer) error {
+		return w.Write(map[string]string{"applicationID": "app-1"})
+	})
+	require.NoError(t, err)
+	assert.True(t, export.Encrypted)
+	stored, err := os.ReadFile(filepath.Join(dir, export.ID+".ndjson.age"))
+	require.NoError(t, err)
+	sum := sha256.Sum256(stored)
+	assert.Equal(t, int64(len(stored)), export.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), export.SHA256)
+	assert.NotContains(t, string(stored), "app-1")
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, store.Serve(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/exports/"+export.ID, nil), export.ID))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, EncryptedContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename="+export.ID+".ndjson.age", rec.Header().Get("Content-Disposition"))
+	r, err := envelope.Decrypt(ctx, rec.Body, identity)
+	require.NoError(t, err)
+	decrypted, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"applicationID\":\"app-1\"}\n", string(decrypted))
+
+	// exports written before encryption was enabled are still served
+	plain, err := NewStore(ctx, &config.ExportsConfig{ObjectStorageConfig: config.ObjectStorageConfig{URL: "file://" + dir}, URLExpiry: time.Minute})
+	require.NoError(t, err)
+	unencrypted, err := plain.Create(ctx, func(w *Writer) error {
+		return w.Write(map[string]string{"applicationID": "app-2"})
+	})
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	require.NoError(t, store.Serve(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/exports/"+unencrypted.ID, nil), unencrypted.ID))
+	assert.Equal(t, "{\"applicationID\":\"app-2\"}\n", rec.Body.String())
+}
+
+func TestNewStoreWith_Unsupported(t *testing.T) {
+	// stores which only put and get objects in memory, like the Google Cloud Storage store
+	_, err := NewStoreWith(struct{ objectstore.Store }{&presigningStore{}}, time.Minute)
+	assert.EqualError(t, err, "the storage of the exports does not support uploads")
+}
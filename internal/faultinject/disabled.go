@@ -0,0 +1,6 @@
+//go:build !faultinject
+
+package faultinject
+
+// Enabled is true in builds with the faultinject build tag, which must not be used in production.
+const Enabled = false
@@ -0,0 +1,114 @@
+// Package faultinject injects faults into the database and the event stream, so that the retry paths can be
+// verified in resilience tests. Faults are only injected by builds with the faultinject build tag, see Enabled:
+//
+//	go build -tags faultinject ./cmd/yunikorn-history-server
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrInjected is returned by the database operations which fail because of an injected fault.
+var ErrInjected = errors.New("injected fault")
+
+// maxQueryDelay bounds the injected query delay, so that a typo cannot block the database operations for hours.
+const maxQueryDelay = time.Minute
+
+// Faults configures the injected faults. Rates are probabilities between 0 and 1.
+type Faults struct {
+	// DBErrorRate is the rate of database operations which fail with ErrInjected.
+	DBErrorRate float64 `json:"dbErrorRate"`
+	// QueryDelayMillis delays every database operation by the given number of milliseconds.
+	QueryDelayMillis int64 `json:"queryDelayMillis"`
+	// DropEventRate is the rate of events of the YuniKorn event stream which are dropped before they are handled.
+	DropEventRate float64 `json:"dropEventRate"`
+}
+
+func (f *Faults) Validate() error {
+	var errs []error
+	if f.DBErrorRate < 0 || f.DBErrorRate > 1 {
+		errs = append(errs, fmt.Errorf("dbErrorRate must be between 0 and 1, got %v", f.DBErrorRate))
+	}
+	if f.QueryDelayMillis < 0 || f.QueryDelayMillis > maxQueryDelay.Milliseconds() {
+		errs = append(errs, fmt.Errorf("queryDelayMillis must be between 0 and %d, got %d",
+			maxQueryDelay.Milliseconds(), f.QueryDelayMillis))
+	}
+	if f.DropEventRate < 0 || f.DropEventRate > 1 {
+		errs = append(errs, fmt.Errorf("dropEventRate must be between 0 and 1, got %v", f.DropEventRate))
+	}
+	return errors.Join(errs...)
+}
+
+// Injector injects the configured faults. No faults are configured initially, they are set at runtime through
+// the admin API of test builds.
+//
+// A nil Injector never injects faults.
+type Injector struct {
+	mutex  sync.RWMutex
+	faults Faults
+	// random returns a number in [0, 1), it is replaced in tests.
+	random func() float64
+}
+
+func New() *Injector {
+	return &Injector{random: rand.Float64}
+}
+
+// Faults returns the configured faults.
+func (i *Injector) Faults() Faults {
+	if i == nil {
+		return Faults{}
+	}
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return i.faults
+}
+
+// SetFaults configures the injected faults.
+func (i *Injector) SetFaults(faults Faults) error {
+	if err := faults.Validate(); err != nil {
+		return err
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.faults = faults
+	return nil
+}
+
+// Reset stops injecting faults.
+func (i *Injector) Reset() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.faults = Faults{}
+}
+
+// DBFault delays the database operation and returns ErrInjected if it should fail.
+// It returns the error of the context if the context is done while delaying.
+func (i *Injector) DBFault(ctx context.Context, operation string) error {
+	faults := i.Faults()
+	if faults.QueryDelayMillis > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(faults.QueryDelayMillis) * time.Millisecond):
+		}
+	}
+	if i.occurs(faults.DBErrorRate) {
+		return fmt.Errorf("%w: %s", ErrInjected, operation)
+	}
+	return nil
+}
+
+// DropEvent returns true if the event should be dropped.
+func (i *Injector) DropEvent() bool {
+	return i.occurs(i.Faults().DropEventRate)
+}
+
+func (i *Injector) occurs(rate float64) bool {
+	return rate > 0 && i.random() < rate
+}
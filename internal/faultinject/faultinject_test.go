@@ -0,0 +1,104 @@
+package faultinject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestFaultsValidate(t *testing.T) {
+	tests := map[string]struct {
+		faults  Faults
+		wantErr string
+	}{
+		"no faults":  {faults: Faults{}},
+		"all faults": {faults: Faults{DBErrorRate: 1, QueryDelayMillis: 60000, DropEventRate: 0.5}},
+		"invalid rates": {
+			faults:  Faults{DBErrorRate: 1.5, DropEventRate: -0.1},
+			wantErr: "dbErrorRate must be between 0 and 1, got 1.5\ndropEventRate must be between 0 and 1, got -0.1",
+		},
+		"delay too long": {
+			faults:  Faults{QueryDelayMillis: 60001},
+			wantErr: "queryDelayMillis must be between 0 and 60000, got 60001",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.faults.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInjector(t *testing.T) {
+	ctx := context.Background()
+	injector := New()
+	assert.NoError(t, injector.DBFault(ctx, "op"))
+	assert.False(t, injector.DropEvent())
+
+	require.NoError(t, injector.SetFaults(Faults{DBErrorRate: 0.5, DropEventRate: 0.5}))
+	injector.random = func() float64 { return 0.4 }
+	assert.ErrorIs(t, injector.DBFault(ctx, "op"), ErrInjected)
+	assert.True(t, injector.DropEvent())
+	injector.random = func() float64 { return 0.5 }
+	assert.NoError(t, injector.DBFault(ctx, "op"))
+	assert.False(t, injector.DropEvent())
+
+	assert.Error(t, injector.SetFaults(Faults{DBErrorRate: 2}))
+	assert.Equal(t, Faults{DBErrorRate: 0.5, DropEventRate: 0.5}, injector.Faults(), "invalid faults are not applied")
+
+	injector.Reset()
+	assert.Equal(t, Faults{}, injector.Faults())
+}
+
+func TestInjectorDelay(t *testing.T) {
+	injector := New()
+	require.NoError(t, injector.SetFaults(Faults{QueryDelayMillis: 20}))
+
+	start := time.Now()
+	assert.NoError(t, injector.DBFault(context.Background(), "op"))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, injector.DBFault(ctx, "op"), context.Canceled)
+}
+
+func TestNilInjector(t *testing.T) {
+	var injector *Injector
+	assert.NoError(t, injector.DBFault(context.Background(), "op"))
+	assert.False(t, injector.DropEvent())
+	assert.Equal(t, Faults{}, injector.Faults())
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(mockCtrl)
+
+	assert.Same(t, mockRepository, NewRepository(mockRepository, nil))
+
+	injector := New()
+	repo := NewRepository(mockRepository, injector)
+
+	mockRepository.EXPECT().GetAllPartitions(ctx).Return(nil, nil)
+	_, err := repo.GetAllPartitions(ctx)
+	assert.NoError(t, err)
+
+	// failing operations are not delegated
+	require.NoError(t, injector.SetFaults(Faults{DBErrorRate: 1}))
+	_, err = repo.GetAllPartitions(ctx)
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.EqualError(t, err, "injected fault: GetAllPartitions")
+	assert.ErrorIs(t, repo.UpsertPartitions(ctx, nil), ErrInjected)
+}
@@ -0,0 +1,761 @@
+package faultinject
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Repository injects database faults before every operation of the wrapped repository.
+type Repository struct {
+	repo     repository.Repository
+	injector *Injector
+}
+
+// NewRepository wraps the repository, so that its operations are delayed or fail as configured by the injector.
+// It returns the repository itself if the injector is nil.
+func NewRepository(repo repository.Repository, injector *Injector) repository.Repository {
+	if injector == nil {
+		return repo
+	}
+	return &Repository{repo: repo, injector: injector}
+}
+
+var _ repository.Repository = &Repository{}
+
+func (r *Repository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
+	if err := r.injector.DBFault(ctx, "UpsertApplications"); err != nil {
+		return err
+	}
+	return r.repo.UpsertApplications(ctx, apps)
+}
+
+func (r *Repository) GetAllApplications(ctx context.Context, filters repository.ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetAllApplications"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAllApplications(ctx, filters)
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetAppsPerPartitionPerQueue"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAppsPerPartitionPerQueue(ctx, partition, queue, filters)
+}
+
+func (r *Repository) StreamAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+	fn func(*model.ApplicationDAOInfo) error,
+) error {
+	if err := r.injector.DBFault(ctx, "StreamAppsPerPartitionPerQueue"); err != nil {
+		return err
+	}
+	return r.repo.StreamAppsPerPartitionPerQueue(ctx, partition, queue, filters, fn)
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueuePage(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, repository.PageInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetAppsPerPartitionPerQueuePage"); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	return r.repo.GetAppsPerPartitionPerQueuePage(ctx, partition, queue, filters)
+}
+
+func (r *Repository) GetApplicationSummaries(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationSummary, error) {
+	if err := r.injector.DBFault(ctx, "GetApplicationSummaries"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetApplicationSummaries(ctx, partition, queue, filters)
+}
+
+func (r *Repository) CountFinishedApplications(ctx context.Context, partition, queue, state string, since time.Time) (int, error) {
+	if err := r.injector.DBFault(ctx, "CountFinishedApplications"); err != nil {
+		return 0, err
+	}
+	return r.repo.CountFinishedApplications(ctx, partition, queue, state, since)
+}
+
+func (r *Repository) DeleteApplicationsFinishedBefore(
+	ctx context.Context,
+	partition, queue string,
+	before time.Time,
+	limit int,
+) (int64, error) {
+	if err := r.injector.DBFault(ctx, "DeleteApplicationsFinishedBefore"); err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteApplicationsFinishedBefore(ctx, partition, queue, before, limit)
+}
+
+func (r *Repository) CountBulkDeleteApplications(
+	ctx context.Context,
+	filters repository.BulkDeleteFilters,
+) (int64, int64, error) {
+	if err := r.injector.DBFault(ctx, "CountBulkDeleteApplications"); err != nil {
+		return 0, 0, err
+	}
+	return r.repo.CountBulkDeleteApplications(ctx, filters)
+}
+
+func (r *Repository) BulkDeleteApplications(ctx context.Context, filters repository.BulkDeleteFilters, limit int) (int64, error) {
+	if err := r.injector.DBFault(ctx, "BulkDeleteApplications"); err != nil {
+		return 0, err
+	}
+	return r.repo.BulkDeleteApplications(ctx, filters, limit)
+}
+
+func (r *Repository) MoveApplicationsToColdTier(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if err := r.injector.DBFault(ctx, "MoveApplicationsToColdTier"); err != nil {
+		return 0, err
+	}
+	return r.repo.MoveApplicationsToColdTier(ctx, before, limit)
+}
+
+func (r *Repository) UpdateHistory(
+	ctx context.Context,
+	apps []*dao.ApplicationHistoryDAOInfo,
+	containers []*dao.ContainerHistoryDAOInfo,
+) error {
+	if err := r.injector.DBFault(ctx, "UpdateHistory"); err != nil {
+		return err
+	}
+	return r.repo.UpdateHistory(ctx, apps, containers)
+}
+
+func (r *Repository) DeleteHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if err := r.injector.DBFault(ctx, "DeleteHistoryBefore"); err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteHistoryBefore(ctx, before, limit)
+}
+
+func (r *Repository) GetApplicationsHistory(ctx context.Context) ([]*dao.ApplicationHistoryDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetApplicationsHistory"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetApplicationsHistory(ctx)
+}
+
+func (r *Repository) GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetContainersHistory"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetContainersHistory(ctx)
+}
+
+func (r *Repository) GetApplicationsHistoryPage(
+	ctx context.Context, page repository.Page) ([]*dao.ApplicationHistoryDAOInfo, repository.PageInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetApplicationsHistoryPage"); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	return r.repo.GetApplicationsHistoryPage(ctx, page)
+}
+
+func (r *Repository) GetContainersHistoryPage(
+	ctx context.Context, page repository.Page) ([]*dao.ContainerHistoryDAOInfo, repository.PageInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetContainersHistoryPage"); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	return r.repo.GetContainersHistoryPage(ctx, page)
+}
+
+func (r *Repository) StreamApplicationsHistory(ctx context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error {
+	if err := r.injector.DBFault(ctx, "StreamApplicationsHistory"); err != nil {
+		return err
+	}
+	return r.repo.StreamApplicationsHistory(ctx, fn)
+}
+
+func (r *Repository) StreamContainersHistory(ctx context.Context, fn func(*dao.ContainerHistoryDAOInfo) error) error {
+	if err := r.injector.DBFault(ctx, "StreamContainersHistory"); err != nil {
+		return err
+	}
+	return r.repo.StreamContainersHistory(ctx, fn)
+}
+
+func (r *Repository) UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
+	if err := r.injector.DBFault(ctx, "UpsertNodes"); err != nil {
+		return err
+	}
+	return r.repo.UpsertNodes(ctx, nodes, partition)
+}
+
+func (r *Repository) InsertNodeUtilizations(
+	ctx context.Context,
+	uuid uuid.UUID,
+	partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo,
+) error {
+	if err := r.injector.DBFault(ctx, "InsertNodeUtilizations"); err != nil {
+		return err
+	}
+	return r.repo.InsertNodeUtilizations(ctx, uuid, partitionNodesUtil)
+}
+
+func (r *Repository) GetNodeUtilizations(ctx context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetNodeUtilizations"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetNodeUtilizations(ctx)
+}
+
+func (r *Repository) GetNodesPerPartition(ctx context.Context, partition string) ([]*dao.NodeDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetNodesPerPartition"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetNodesPerPartition(ctx, partition)
+}
+
+func (r *Repository) GetNodesPerPartitionPage(
+	ctx context.Context, partition string, page repository.Page) ([]*dao.NodeDAOInfo, repository.PageInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetNodesPerPartitionPage"); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	return r.repo.GetNodesPerPartitionPage(ctx, partition, page)
+}
+
+func (r *Repository) GetNode(ctx context.Context, nodeID string) (*model.NodeDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetNode"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetNode(ctx, nodeID)
+}
+
+func (r *Repository) GetNodeTopologies(ctx context.Context, partition string) ([]*model.NodeTopology, error) {
+	if err := r.injector.DBFault(ctx, "GetNodeTopologies"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetNodeTopologies(ctx, partition)
+}
+
+func (r *Repository) RecordNodeTermination(
+	ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+) (*model.NodeTermination, error) {
+	if err := r.injector.DBFault(ctx, "RecordNodeTermination"); err != nil {
+		return nil, err
+	}
+	return r.repo.RecordNodeTermination(ctx, nodeID, terminatedAt, spotSelectors)
+}
+
+func (r *Repository) GetNodeTerminations(
+	ctx context.Context,
+	filters repository.NodeTerminationFilters,
+) ([]*model.NodeTermination, error) {
+	if err := r.injector.DBFault(ctx, "GetNodeTerminations"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetNodeTerminations(ctx, filters)
+}
+
+func (r *Repository) AddAutoscalerEvents(ctx context.Context, events []*model.AutoscalerEvent) (int, error) {
+	if err := r.injector.DBFault(ctx, "AddAutoscalerEvents"); err != nil {
+		return 0, err
+	}
+	return r.repo.AddAutoscalerEvents(ctx, events)
+}
+
+func (r *Repository) GetAutoscalerEvents(
+	ctx context.Context,
+	filters repository.AutoscalerEventFilters,
+) ([]*model.AutoscalerEvent, error) {
+	if err := r.injector.DBFault(ctx, "GetAutoscalerEvents"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAutoscalerEvents(ctx, filters)
+}
+
+func (r *Repository) GetPendingBacklog(
+	ctx context.Context,
+	filters repository.PendingBacklogFilters,
+) ([]*model.PendingBacklogSample, error) {
+	if err := r.injector.DBFault(ctx, "GetPendingBacklog"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetPendingBacklog(ctx, filters)
+}
+
+func (r *Repository) UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error {
+	if err := r.injector.DBFault(ctx, "UpsertPartitions"); err != nil {
+		return err
+	}
+	return r.repo.UpsertPartitions(ctx, partitions)
+}
+
+func (r *Repository) GetAllPartitions(ctx context.Context) ([]*dao.PartitionInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetAllPartitions"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAllPartitions(ctx)
+}
+
+func (r *Repository) AddQueues(ctx context.Context, parentId *string, queues []*dao.PartitionQueueDAOInfo) error {
+	if err := r.injector.DBFault(ctx, "AddQueues"); err != nil {
+		return err
+	}
+	return r.repo.AddQueues(ctx, parentId, queues)
+}
+
+func (r *Repository) UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	if err := r.injector.DBFault(ctx, "UpsertQueues"); err != nil {
+		return err
+	}
+	return r.repo.UpsertQueues(ctx, queues)
+}
+
+func (r *Repository) GetAllQueues(ctx context.Context) ([]*model.PartitionQueueDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetAllQueues"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAllQueues(ctx)
+}
+
+func (r *Repository) GetQueuesPerPartition(ctx context.Context, partition string) ([]*model.PartitionQueueDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetQueuesPerPartition"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetQueuesPerPartition(ctx, partition)
+}
+
+func (r *Repository) GetQueue(ctx context.Context, partition, queueName string) (*model.PartitionQueueDAOInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetQueue"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetQueue(ctx, partition, queueName)
+}
+
+func (r *Repository) DeleteQueues(ctx context.Context, queues []*model.PartitionQueueDAOInfo) error {
+	if err := r.injector.DBFault(ctx, "DeleteQueues"); err != nil {
+		return err
+	}
+	return r.repo.DeleteQueues(ctx, queues)
+}
+
+func (r *Repository) CreateLegalHold(ctx context.Context, hold *model.LegalHold) error {
+	if err := r.injector.DBFault(ctx, "CreateLegalHold"); err != nil {
+		return err
+	}
+	return r.repo.CreateLegalHold(ctx, hold)
+}
+
+func (r *Repository) GetLegalHolds(ctx context.Context, filters repository.LegalHoldFilters) ([]*model.LegalHold, error) {
+	if err := r.injector.DBFault(ctx, "GetLegalHolds"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetLegalHolds(ctx, filters)
+}
+
+func (r *Repository) GetLegalHold(ctx context.Context, id string) (*model.LegalHold, error) {
+	if err := r.injector.DBFault(ctx, "GetLegalHold"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetLegalHold(ctx, id)
+}
+
+func (r *Repository) ReleaseLegalHold(ctx context.Context, id, releasedBy, reason string, ifVersion *int64) (*model.LegalHold, error) {
+	if err := r.injector.DBFault(ctx, "ReleaseLegalHold"); err != nil {
+		return nil, err
+	}
+	return r.repo.ReleaseLegalHold(ctx, id, releasedBy, reason, ifVersion)
+}
+
+func (r *Repository) AddQueueLineage(ctx context.Context, link *model.QueueLineage) error {
+	if err := r.injector.DBFault(ctx, "AddQueueLineage"); err != nil {
+		return err
+	}
+	return r.repo.AddQueueLineage(ctx, link)
+}
+
+func (r *Repository) GetQueueLineage(ctx context.Context, partition *string) ([]*model.QueueLineage, error) {
+	if err := r.injector.DBFault(ctx, "GetQueueLineage"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetQueueLineage(ctx, partition)
+}
+
+func (r *Repository) DeleteQueueLineage(ctx context.Context, id string) error {
+	if err := r.injector.DBFault(ctx, "DeleteQueueLineage"); err != nil {
+		return err
+	}
+	return r.repo.DeleteQueueLineage(ctx, id)
+}
+
+func (r *Repository) StartUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	if err := r.injector.DBFault(ctx, "StartUserErasure"); err != nil {
+		return err
+	}
+	return r.repo.StartUserErasure(ctx, erasure)
+}
+
+func (r *Repository) UpdateUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	if err := r.injector.DBFault(ctx, "UpdateUserErasure"); err != nil {
+		return err
+	}
+	return r.repo.UpdateUserErasure(ctx, erasure)
+}
+
+func (r *Repository) EraseUserApplications(ctx context.Context, user, pseudonym, mode string, limit int) (int64, error) {
+	if err := r.injector.DBFault(ctx, "EraseUserApplications"); err != nil {
+		return 0, err
+	}
+	return r.repo.EraseUserApplications(ctx, user, pseudonym, mode, limit)
+}
+
+func (r *Repository) CountUserApplications(ctx context.Context, user string) (int64, error) {
+	if err := r.injector.DBFault(ctx, "CountUserApplications"); err != nil {
+		return 0, err
+	}
+	return r.repo.CountUserApplications(ctx, user)
+}
+
+func (r *Repository) PseudonymizeAuditRecords(ctx context.Context, user, pseudonym string) (int64, error) {
+	if err := r.injector.DBFault(ctx, "PseudonymizeAuditRecords"); err != nil {
+		return 0, err
+	}
+	return r.repo.PseudonymizeAuditRecords(ctx, user, pseudonym)
+}
+
+func (r *Repository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	if err := r.injector.DBFault(ctx, "UpdateQueueACLs"); err != nil {
+		return err
+	}
+	return r.repo.UpdateQueueACLs(ctx, acls, at)
+}
+
+func (r *Repository) GetQueueACLs(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.QueueACLFilters,
+) ([]*model.QueueACL, error) {
+	if err := r.injector.DBFault(ctx, "GetQueueACLs"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetQueueACLs(ctx, partition, queue, filters)
+}
+
+func (r *Repository) GetAllocationConstraints(
+	ctx context.Context,
+	partition, queue, appID string,
+) ([]*model.AllocationConstraints, error) {
+	if err := r.injector.DBFault(ctx, "GetAllocationConstraints"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAllocationConstraints(ctx, partition, queue, appID)
+}
+
+func (r *Repository) AddTraceEvent(ctx context.Context, event *model.TraceEvent, maxEvents int) (bool, error) {
+	if err := r.injector.DBFault(ctx, "AddTraceEvent"); err != nil {
+		return false, err
+	}
+	return r.repo.AddTraceEvent(ctx, event, maxEvents)
+}
+
+func (r *Repository) GetTraceEvents(
+	ctx context.Context,
+	appID string,
+	filters repository.TraceEventFilters,
+) ([]*model.TraceEvent, error) {
+	if err := r.injector.DBFault(ctx, "GetTraceEvents"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetTraceEvents(ctx, appID, filters)
+}
+
+func (r *Repository) DeleteTraceEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.injector.DBFault(ctx, "DeleteTraceEventsBefore"); err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteTraceEventsBefore(ctx, before)
+}
+
+func (r *Repository) RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error) {
+	if err := r.injector.DBFault(ctx, "RecordSchedulerEpoch"); err != nil {
+		return false, err
+	}
+	return r.repo.RecordSchedulerEpoch(ctx, epoch)
+}
+
+func (r *Repository) GetSchedulerEpochs(
+	ctx context.Context,
+	filters repository.SchedulerEpochFilters,
+) ([]*model.SchedulerEpoch, error) {
+	if err := r.injector.DBFault(ctx, "GetSchedulerEpochs"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetSchedulerEpochs(ctx, filters)
+}
+
+func (r *Repository) GetNamespaceQueues(
+	ctx context.Context,
+	partition string,
+	filters repository.NamespaceQueueFilters,
+) ([]*model.NamespaceQueue, error) {
+	if err := r.injector.DBFault(ctx, "GetNamespaceQueues"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetNamespaceQueues(ctx, partition, filters)
+}
+
+func (r *Repository) QueryAnalytics(ctx context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	if err := r.injector.DBFault(ctx, "QueryAnalytics"); err != nil {
+		return nil, err
+	}
+	return r.repo.QueryAnalytics(ctx, query)
+}
+
+func (r *Repository) EstimateAnalytics(ctx context.Context, query repository.AnalyticsQuery) (*model.AnalyticsEstimate, error) {
+	if err := r.injector.DBFault(ctx, "EstimateAnalytics"); err != nil {
+		return nil, err
+	}
+	return r.repo.EstimateAnalytics(ctx, query)
+}
+
+func (r *Repository) StartAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	if err := r.injector.DBFault(ctx, "StartAnalyticsSink"); err != nil {
+		return nil, err
+	}
+	return r.repo.StartAnalyticsSink(ctx, name)
+}
+
+func (r *Repository) StopAnalyticsSink(ctx context.Context, name string) error {
+	if err := r.injector.DBFault(ctx, "StopAnalyticsSink"); err != nil {
+		return err
+	}
+	return r.repo.StopAnalyticsSink(ctx, name)
+}
+
+func (r *Repository) GetAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	if err := r.injector.DBFault(ctx, "GetAnalyticsSink"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetAnalyticsSink(ctx, name)
+}
+
+func (r *Repository) BackfillAnalyticsSink(
+	ctx context.Context,
+	name string,
+	limit int,
+	fn func([]*model.AnalyticsApplication) error,
+) (bool, error) {
+	if err := r.injector.DBFault(ctx, "BackfillAnalyticsSink"); err != nil {
+		return false, err
+	}
+	return r.repo.BackfillAnalyticsSink(ctx, name, limit, fn)
+}
+
+func (r *Repository) ConsumeAnalyticsChanges(
+	ctx context.Context,
+	limit int,
+	fn func([]*model.AnalyticsApplication) error,
+) (int, error) {
+	if err := r.injector.DBFault(ctx, "ConsumeAnalyticsChanges"); err != nil {
+		return 0, err
+	}
+	return r.repo.ConsumeAnalyticsChanges(ctx, limit, fn)
+}
+
+func (r *Repository) GetQueueThroughput(
+	ctx context.Context,
+	filters repository.ThroughputFilters,
+) ([]*model.ThroughputBucket, error) {
+	if err := r.injector.DBFault(ctx, "GetQueueThroughput"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetQueueThroughput(ctx, filters)
+}
+
+func (r *Repository) GetDuplicateApplications(
+	ctx context.Context,
+	filters repository.DuplicateApplicationFilters,
+) ([]*model.DuplicateApplication, repository.PageInfo, error) {
+	if err := r.injector.DBFault(ctx, "GetDuplicateApplications"); err != nil {
+		return nil, repository.PageInfo{}, err
+	}
+	return r.repo.GetDuplicateApplications(ctx, filters)
+}
+
+func (r *Repository) GetTopUsage(ctx context.Context, filters repository.TopUsageFilters) ([]*model.TopUsage, error) {
+	if err := r.injector.DBFault(ctx, "GetTopUsage"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetTopUsage(ctx, filters)
+}
+
+func (r *Repository) GetHourlyUsage(
+	ctx context.Context,
+	filters repository.HourlyUsageFilters,
+) ([]*model.HourlyUsage, error) {
+	if err := r.injector.DBFault(ctx, "GetHourlyUsage"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetHourlyUsage(ctx, filters)
+}
+
+func (r *Repository) GetPrincipals(
+	ctx context.Context,
+	kind repository.PrincipalKind,
+	filters repository.PrincipalFilters,
+) ([]*model.Principal, error) {
+	if err := r.injector.DBFault(ctx, "GetPrincipals"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetPrincipals(ctx, kind, filters)
+}
+
+func (r *Repository) GetQueueWaitPhases(
+	ctx context.Context, filters repository.WaitPhaseFilters) ([]*model.QueueWaitPhases, error) {
+	if err := r.injector.DBFault(ctx, "GetQueueWaitPhases"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetQueueWaitPhases(ctx, filters)
+}
+
+func (r *Repository) CheckDataQuality(ctx context.Context, check repository.DataQualityCheck, at time.Time) (int64, error) {
+	if err := r.injector.DBFault(ctx, "CheckDataQuality"); err != nil {
+		return 0, err
+	}
+	return r.repo.CheckDataQuality(ctx, check, at)
+}
+
+func (r *Repository) GetDataQualityChecks(ctx context.Context) ([]*model.DataQualityCheck, error) {
+	if err := r.injector.DBFault(ctx, "GetDataQualityChecks"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetDataQualityChecks(ctx)
+}
+
+func (r *Repository) GetDataQualityViolations(
+	ctx context.Context,
+	filters repository.DataQualityFilters,
+) ([]*model.DataQualityViolation, error) {
+	if err := r.injector.DBFault(ctx, "GetDataQualityViolations"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetDataQualityViolations(ctx, filters)
+}
+
+func (r *Repository) SyncConfigSLOs(ctx context.Context, slos []*model.QueueSLO) error {
+	if err := r.injector.DBFault(ctx, "SyncConfigSLOs"); err != nil {
+		return err
+	}
+	return r.repo.SyncConfigSLOs(ctx, slos)
+}
+
+func (r *Repository) PutSLO(ctx context.Context, slo *model.QueueSLO, ifVersion *int64) error {
+	if err := r.injector.DBFault(ctx, "PutSLO"); err != nil {
+		return err
+	}
+	return r.repo.PutSLO(ctx, slo, ifVersion)
+}
+
+func (r *Repository) DeleteSLO(ctx context.Context, name string, ifVersion *int64) error {
+	if err := r.injector.DBFault(ctx, "DeleteSLO"); err != nil {
+		return err
+	}
+	return r.repo.DeleteSLO(ctx, name, ifVersion)
+}
+
+func (r *Repository) GetSLOs(ctx context.Context) ([]*model.QueueSLO, error) {
+	if err := r.injector.DBFault(ctx, "GetSLOs"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetSLOs(ctx)
+}
+
+func (r *Repository) EvaluateSLO(ctx context.Context, slo *model.QueueSLO, at time.Time) (*model.SLOEvaluation, error) {
+	if err := r.injector.DBFault(ctx, "EvaluateSLO"); err != nil {
+		return nil, err
+	}
+	return r.repo.EvaluateSLO(ctx, slo, at)
+}
+
+func (r *Repository) GetSLOEvaluations(
+	ctx context.Context,
+	filters repository.SLOEvaluationFilters,
+) ([]*model.SLOEvaluation, error) {
+	if err := r.injector.DBFault(ctx, "GetSLOEvaluations"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetSLOEvaluations(ctx, filters)
+}
+
+func (r *Repository) DeleteSLOEvaluationsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.injector.DBFault(ctx, "DeleteSLOEvaluationsBefore"); err != nil {
+		return 0, err
+	}
+	return r.repo.DeleteSLOEvaluationsBefore(ctx, before)
+}
+
+func (r *Repository) RegisterCluster(ctx context.Context, cluster *model.Cluster) error {
+	if err := r.injector.DBFault(ctx, "RegisterCluster"); err != nil {
+		return err
+	}
+	return r.repo.RegisterCluster(ctx, cluster)
+}
+
+func (r *Repository) HeartbeatCluster(ctx context.Context, name string, ingestedUntil *time.Time) (*model.Cluster, error) {
+	if err := r.injector.DBFault(ctx, "HeartbeatCluster"); err != nil {
+		return nil, err
+	}
+	return r.repo.HeartbeatCluster(ctx, name, ingestedUntil)
+}
+
+func (r *Repository) GetClusters(ctx context.Context) ([]*model.Cluster, error) {
+	if err := r.injector.DBFault(ctx, "GetClusters"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetClusters(ctx)
+}
+
+func (r *Repository) PauseOperation(ctx context.Context, op *model.PausedOperation) error {
+	if err := r.injector.DBFault(ctx, "PauseOperation"); err != nil {
+		return err
+	}
+	return r.repo.PauseOperation(ctx, op)
+}
+
+func (r *Repository) ResumeOperation(ctx context.Context, name string) error {
+	if err := r.injector.DBFault(ctx, "ResumeOperation"); err != nil {
+		return err
+	}
+	return r.repo.ResumeOperation(ctx, name)
+}
+
+func (r *Repository) GetPausedOperations(ctx context.Context) ([]*model.PausedOperation, error) {
+	if err := r.injector.DBFault(ctx, "GetPausedOperations"); err != nil {
+		return nil, err
+	}
+	return r.repo.GetPausedOperations(ctx)
+}
+
+// ApplyIngestBatch injects faults into the writes of the batch too.
+func (r *Repository) ApplyIngestBatch(
+	ctx context.Context,
+	source string,
+	sequence int64,
+	fn func(ctx context.Context, tx repository.Repository) error,
+) (int64, error) {
+	if err := r.injector.DBFault(ctx, "ApplyIngestBatch"); err != nil {
+		return 0, err
+	}
+	return r.repo.ApplyIngestBatch(ctx, source, sequence, func(ctx context.Context, tx repository.Repository) error {
+		return fn(ctx, NewRepository(tx, r.injector))
+	})
+}
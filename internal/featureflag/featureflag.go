@@ -0,0 +1,162 @@
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// Flag identifies an experimental subsystem which can be enabled or disabled without rebuilding the server.
+type Flag string
+
+const (
+	// EventStream gates the live event streaming (SSE) API.
+	EventStream Flag = "event-stream"
+	// Analytics gates the experimental analytics APIs: the analytics queries and the /ws/v1/analytics/* endpoints.
+	// The reports, users and groups endpoints are stable and not gated.
+	Analytics Flag = "analytics"
+)
+
+// defaults contains all known feature flags and their default state.
+// Experimental subsystems are disabled by default so incomplete features can be shipped dark.
+var defaults = map[Flag]bool{
+	EventStream: false,
+	Analytics:   false,
+}
+
+var (
+	ErrUnknownFlag       = errors.New("unknown feature flag")
+	ErrOverridesDisabled = errors.New("runtime feature flag overrides are disabled")
+)
+
+// Status describes the state of a single feature flag.
+type Status struct {
+	Name Flag `json:"name"`
+	// Enabled is the effective state of the flag.
+	Enabled bool `json:"enabled"`
+	// Default is the state of the flag when it is neither configured nor overridden.
+	Default bool `json:"default"`
+	// Configured is the state of the flag set in the configuration file, if any.
+	Configured *bool `json:"configured,omitempty"`
+	// Override is the state of the flag set at runtime via the admin API, if any.
+	Override *bool `json:"override,omitempty"`
+}
+
+// Service resolves the state of feature flags.
+// Runtime overrides take precedence over the configuration, which takes precedence over the defaults.
+type Service struct {
+	mutex          sync.RWMutex
+	configured     map[Flag]bool
+	overrides      map[Flag]bool
+	allowOverrides bool
+}
+
+// New creates a new feature flag Service from the provided configuration.
+// An error is returned if the configuration references unknown flags.
+func New(cfg *config.FeatureFlagsConfig) (*Service, error) {
+	s := &Service{
+		configured:     make(map[Flag]bool),
+		overrides:      make(map[Flag]bool),
+		allowOverrides: cfg.AdminOverrides,
+	}
+	var unknown []string
+	for name, enabled := range cfg.Flags {
+		flag := Flag(name)
+		if _, ok := defaults[flag]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		s.configured[flag] = enabled
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFlag, unknown)
+	}
+	return s, nil
+}
+
+// Enabled returns true if the given flag is enabled.
+func (s *Service) Enabled(flag Flag) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if enabled, ok := s.overrides[flag]; ok {
+		return enabled
+	}
+	if enabled, ok := s.configured[flag]; ok {
+		return enabled
+	}
+	return defaults[flag]
+}
+
+// OverridesAllowed returns true if flags can be overridden at runtime.
+func (s *Service) OverridesAllowed() bool {
+	return s.allowOverrides
+}
+
+// Override sets the runtime state of the given flag, taking precedence over the configuration.
+func (s *Service) Override(flag Flag, enabled bool) error {
+	if !s.allowOverrides {
+		return ErrOverridesDisabled
+	}
+	if _, ok := defaults[flag]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.overrides[flag] = enabled
+	return nil
+}
+
+// ResetOverride removes the runtime override of the given flag, so the configured state applies again.
+func (s *Service) ResetOverride(flag Flag) error {
+	if !s.allowOverrides {
+		return ErrOverridesDisabled
+	}
+	if _, ok := defaults[flag]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.overrides, flag)
+	return nil
+}
+
+// Status returns the status of the given flag.
+func (s *Service) Status(flag Flag) (*Status, error) {
+	def, ok := defaults[flag]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	status := &Status{Name: flag, Enabled: def, Default: def}
+	if enabled, ok := s.configured[flag]; ok {
+		status.Configured = &enabled
+		status.Enabled = enabled
+	}
+	if enabled, ok := s.overrides[flag]; ok {
+		status.Override = &enabled
+		status.Enabled = enabled
+	}
+	return status, nil
+}
+
+// List returns the status of all known flags ordered by name.
+func (s *Service) List() []*Status {
+	flags := make([]Flag, 0, len(defaults))
+	for flag := range defaults {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i] < flags[j] })
+
+	statuses := make([]*Status, 0, len(flags))
+	for _, flag := range flags {
+		// flags are taken from the defaults so the status lookup cannot fail
+		status, _ := s.Status(flag)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
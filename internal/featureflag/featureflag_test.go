@@ -0,0 +1,92 @@
+package featureflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.FeatureFlagsConfig
+		wantErr bool
+	}{
+		{
+			name: "empty config",
+			cfg:  config.FeatureFlagsConfig{},
+		},
+		{
+			name: "known flags",
+			cfg:  config.FeatureFlagsConfig{Flags: map[string]bool{"event-stream": true, "analytics": false}},
+		},
+		{
+			name:    "unknown flag",
+			cfg:     config.FeatureFlagsConfig{Flags: map[string]bool{"analytics": true, "teleport": true}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&tt.cfg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownFlag)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceEnabled(t *testing.T) {
+	s, err := New(&config.FeatureFlagsConfig{
+		Flags:          map[string]bool{string(Analytics): true},
+		AdminOverrides: true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, s.Enabled(Analytics), "configured flag should be enabled")
+	assert.False(t, s.Enabled(EventStream), "unconfigured flag should use the default")
+	assert.False(t, s.Enabled(Flag("teleport")), "unknown flag should be disabled")
+
+	require.NoError(t, s.Override(Analytics, false))
+	require.NoError(t, s.Override(EventStream, true))
+	assert.False(t, s.Enabled(Analytics), "override should take precedence over configuration")
+	assert.True(t, s.Enabled(EventStream), "override should take precedence over default")
+
+	require.NoError(t, s.ResetOverride(Analytics))
+	assert.True(t, s.Enabled(Analytics), "configuration should apply after override is reset")
+
+	assert.ErrorIs(t, s.Override(Flag("teleport"), true), ErrUnknownFlag)
+	assert.ErrorIs(t, s.ResetOverride(Flag("teleport")), ErrUnknownFlag)
+}
+
+func TestServiceOverridesDisabled(t *testing.T) {
+	s, err := New(&config.FeatureFlagsConfig{Flags: map[string]bool{string(Analytics): true}})
+	require.NoError(t, err)
+
+	assert.False(t, s.OverridesAllowed())
+	assert.ErrorIs(t, s.Override(Analytics, false), ErrOverridesDisabled)
+	assert.ErrorIs(t, s.ResetOverride(Analytics), ErrOverridesDisabled)
+	assert.True(t, s.Enabled(Analytics))
+}
+
+func TestServiceList(t *testing.T) {
+	s, err := New(&config.FeatureFlagsConfig{
+		Flags:          map[string]bool{string(Analytics): true},
+		AdminOverrides: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Override(EventStream, true))
+
+	enabled := true
+	want := []*Status{
+		{Name: Analytics, Enabled: true, Default: false, Configured: &enabled},
+		{Name: EventStream, Enabled: true, Default: false, Override: &enabled},
+	}
+	assert.Equal(t, want, s.List())
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/G-Research/yunikorn-history-server/internal/database/migrations"
 	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
 )
 
@@ -12,6 +13,29 @@ type ComponentStatus struct {
 	Identifier string `json:"identifier"`
 	Healthy    bool   `json:"healthy"`
 	Error      string `json:"error,omitempty"`
+	// Reason tells apart the causes of the errors of the Yunikorn components, e.g. a scheduler which is down
+	// from a scheduler of an incompatible version.
+	Reason Reason `json:"reason,omitempty"`
+	// ConsecutiveFailures is the number of consecutive failed syncs of the Yunikorn sync component.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+}
+
+// Reason is the cause of the error of a component.
+type Reason string
+
+const (
+	ReasonSchedulerUnavailable Reason = "SchedulerUnavailable"
+	ReasonUnauthorized         Reason = "Unauthorized"
+	ReasonIncompatibleVersion  Reason = "IncompatibleVersion"
+	ReasonSyncFailed           Reason = "SyncFailed"
+)
+
+// reasons maps the categories of the errors of the Yunikorn API to the reasons reported by the components.
+var reasons = map[yunikorn.ErrorCategory]Reason{
+	yunikorn.ErrorCategoryNetwork: ReasonSchedulerUnavailable,
+	yunikorn.ErrorCategoryAuth:    ReasonUnauthorized,
+	yunikorn.ErrorCategoryParse:   ReasonIncompatibleVersion,
+	yunikorn.ErrorCategoryOther:   ReasonSyncFailed,
 }
 
 type Component interface {
@@ -20,15 +44,18 @@ type Component interface {
 }
 
 type YunikornComponent struct {
-	c yunikorn.Client
+	c       yunikorn.Client
+	cluster string
 }
 
-func NewYunikornComponent(client yunikorn.Client) *YunikornComponent {
-	return &YunikornComponent{c: client}
+// NewYunikornComponent returns a component which checks the health of the scheduler of the cluster, whose ID is
+// empty for the default cluster.
+func NewYunikornComponent(client yunikorn.Client, clusterID string) *YunikornComponent {
+	return &YunikornComponent{c: client, cluster: clusterID}
 }
 
 func (c *YunikornComponent) Identifier() string {
-	return "yunikorn"
+	return clusterIdentifier("yunikorn", c.cluster)
 }
 
 func (c *YunikornComponent) Check(ctx context.Context) *ComponentStatus {
@@ -36,12 +63,52 @@ func (c *YunikornComponent) Check(ctx context.Context) *ComponentStatus {
 	_, err := c.c.Healthcheck(ctx)
 	if err != nil {
 		s.Error = err.Error()
+		s.Reason = reasons[yunikorn.Categorize(err)]
 		return s
 	}
 	s.Healthy = true
 	return s
 }
 
+type YunikornSyncComponent struct {
+	tracker *yunikorn.SyncTracker
+	budget  int
+}
+
+// NewYunikornSyncComponent returns a component which is healthy as long as the number of consecutive failed syncs
+// with the Yunikorn API does not exceed the budget. The error of the last failed sync is reported with its reason
+// even within the budget.
+func NewYunikornSyncComponent(tracker *yunikorn.SyncTracker, budget int) *YunikornSyncComponent {
+	return &YunikornSyncComponent{tracker: tracker, budget: budget}
+}
+
+func (c *YunikornSyncComponent) Identifier() string {
+	return clusterIdentifier("yunikorn_sync", c.tracker.Cluster())
+}
+
+// clusterIdentifier returns the identifier of the component of the scheduler of a cluster, e.g. yunikorn/eu-west,
+// or the identifier as is for the default cluster.
+func clusterIdentifier(identifier, clusterID string) string {
+	if clusterID == "" {
+		return identifier
+	}
+	return identifier + "/" + clusterID
+}
+
+func (c *YunikornSyncComponent) Check(context.Context) *ComponentStatus {
+	status := c.tracker.Status()
+	s := &ComponentStatus{
+		Identifier:          c.Identifier(),
+		Healthy:             status.ConsecutiveFailures <= c.budget,
+		Error:               status.Error,
+		ConsecutiveFailures: status.ConsecutiveFailures,
+	}
+	if status.ConsecutiveFailures > 0 {
+		s.Reason = reasons[status.Category]
+	}
+	return s
+}
+
 type PostgresComponent struct {
 	pool *pgxpool.Pool
 }
@@ -64,3 +131,26 @@ func (c *PostgresComponent) Check(ctx context.Context) *ComponentStatus {
 	s.Healthy = true
 	return s
 }
+
+type SchemaComponent struct {
+	pool *pgxpool.Pool
+}
+
+// NewSchemaComponent returns a component which is healthy if the database schema version is supported by the server.
+func NewSchemaComponent(pool *pgxpool.Pool) *SchemaComponent {
+	return &SchemaComponent{pool: pool}
+}
+
+func (c *SchemaComponent) Identifier() string {
+	return "schema"
+}
+
+func (c *SchemaComponent) Check(ctx context.Context) *ComponentStatus {
+	s := &ComponentStatus{Identifier: c.Identifier()}
+	if err := migrations.CheckSchemaVersion(ctx, c.pool); err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.Healthy = true
+	return s
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
 	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
 	"github.com/G-Research/yunikorn-history-server/test/config"
+	"github.com/G-Research/yunikorn-history-server/test/database"
 )
 
 func TestNewComponent_Integration(t *testing.T) {
@@ -19,7 +20,7 @@ func TestNewComponent_Integration(t *testing.T) {
 	ctx := context.Background()
 
 	yunikornClient := yunikorn.NewRESTClient(config.GetTestYunikornConfig())
-	postgresPool, err := postgres.NewConnectionPool(ctx, config.GetTestPostgresConfig())
+	postgresPool, err := postgres.NewConnectionPool(ctx, database.GetTestPostgresConfig(ctx, t))
 	if err != nil {
 		t.Fatalf("error creating postgres connection pool: %v", err)
 	}
@@ -32,7 +33,7 @@ func TestNewComponent_Integration(t *testing.T) {
 	}{
 		{
 			name:               "should return a valid ComponentStatus when Yunikorn is reachable",
-			component:          NewYunikornComponent(yunikornClient),
+			component:          NewYunikornComponent(yunikornClient, ""),
 			expectedIdentifier: "yunikorn",
 			expectedHealthy:    true,
 		},
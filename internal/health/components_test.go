@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
+)
+
+func TestYunikornSyncComponent(t *testing.T) {
+	tracker := yunikorn.NewSyncTracker("")
+	component := NewYunikornSyncComponent(tracker, 1)
+
+	assert.Equal(t, &ComponentStatus{Identifier: "yunikorn_sync", Healthy: true}, component.Check(context.Background()))
+
+	tracker.RecordFailure(&yunikorn.DecodeError{Err: assert.AnError})
+	assert.Equal(t, &ComponentStatus{
+		Identifier:          "yunikorn_sync",
+		Healthy:             true,
+		Error:               assert.AnError.Error(),
+		Reason:              ReasonIncompatibleVersion,
+		ConsecutiveFailures: 1,
+	}, component.Check(context.Background()))
+
+	tracker.RecordFailure(&yunikorn.StatusError{StatusCode: http.StatusServiceUnavailable})
+	assert.Equal(t, &ComponentStatus{
+		Identifier:          "yunikorn_sync",
+		Healthy:             false,
+		Error:               "yunicorn api returned non-OK status code: 503",
+		Reason:              ReasonSchedulerUnavailable,
+		ConsecutiveFailures: 2,
+	}, component.Check(context.Background()))
+
+	tracker.RecordSuccess(time.Now())
+	assert.Equal(t, &ComponentStatus{Identifier: "yunikorn_sync", Healthy: true}, component.Check(context.Background()))
+}
+
+func TestClusterComponentIdentifiers(t *testing.T) {
+	assert.Equal(t, "yunikorn/eu-west", NewYunikornComponent(nil, "eu-west").Identifier())
+	assert.Equal(t, "yunikorn", NewYunikornComponent(nil, "").Identifier())
+	assert.Equal(t, "yunikorn_sync/eu-west", NewYunikornSyncComponent(yunikorn.NewSyncTracker("eu-west"), 1).Identifier())
+}
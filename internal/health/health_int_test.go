@@ -11,6 +11,7 @@ import (
 	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
 	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
 	testconfig "github.com/G-Research/yunikorn-history-server/test/config"
+	"github.com/G-Research/yunikorn-history-server/test/database"
 )
 
 func TestService_Readiness_Integration(t *testing.T) {
@@ -30,12 +31,12 @@ func TestService_Readiness_Integration(t *testing.T) {
 			Secure: false,
 		}
 		yunikornClient := yunikorn.NewRESTClient(&invalidYunikornConfig)
-		postgresPool, err := postgres.NewConnectionPool(ctx, testconfig.GetTestPostgresConfig())
+		postgresPool, err := postgres.NewConnectionPool(ctx, database.GetTestPostgresConfig(ctx, t))
 		if err != nil {
 			t.Fatalf("error creating postgres connection pool: %v", err)
 		}
 		components := []Component{
-			NewYunikornComponent(yunikornClient),
+			NewYunikornComponent(yunikornClient, ""),
 			NewPostgresComponent(postgresPool),
 		}
 		service := Service{
@@ -54,12 +55,12 @@ func TestService_Readiness_Integration(t *testing.T) {
 
 	t.Run("status is healthy when all components are healthy", func(t *testing.T) {
 		yunikornClient := yunikorn.NewRESTClient(testconfig.GetTestYunikornConfig())
-		postgresPool, err := postgres.NewConnectionPool(ctx, testconfig.GetTestPostgresConfig())
+		postgresPool, err := postgres.NewConnectionPool(ctx, database.GetTestPostgresConfig(ctx, t))
 		if err != nil {
 			t.Fatalf("error creating postgres connection pool: %v", err)
 		}
 		components := []Component{
-			NewYunikornComponent(yunikornClient),
+			NewYunikornComponent(yunikornClient, ""),
 			NewPostgresComponent(postgresPool),
 		}
 		service := Service{
@@ -0,0 +1,156 @@
+// Package ingest defines the batches of data which collectors running next to Yunikorn forward to the central
+// server, and applies them to the repository of the central server.
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
+)
+
+// ErrInvalidBatch is returned for batches with entries which cannot be applied.
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// Kind is the kind of write of an entry.
+type Kind string
+
+const (
+	KindPartitions       Kind = "partitions"
+	KindQueues           Kind = "queues"
+	KindNodes            Kind = "nodes"
+	KindApplications     Kind = "applications"
+	KindNodeUtilizations Kind = "node_utilizations"
+	KindHistory          Kind = "history"
+	KindQueueACLs        Kind = "queue_acls"
+	KindEvent            Kind = "event"
+)
+
+// Entry is a write of the data of Yunikorn, of which only the fields of its kind are set.
+type Entry struct {
+	Kind                Kind                             `json:"kind"`
+	Partitions          []*dao.PartitionInfo             `json:"partitions,omitempty"`
+	Queues              []*dao.PartitionQueueDAOInfo     `json:"queues,omitempty"`
+	Partition           string                           `json:"partition,omitempty"`
+	Nodes               []*dao.NodeDAOInfo               `json:"nodes,omitempty"`
+	Applications        []*dao.ApplicationDAOInfo        `json:"applications,omitempty"`
+	NodeUtilizations    []*dao.PartitionNodesUtilDAOInfo `json:"nodeUtilizations,omitempty"`
+	ApplicationsHistory []*dao.ApplicationHistoryDAOInfo `json:"applicationsHistory,omitempty"`
+	ContainersHistory   []*dao.ContainerHistoryDAOInfo   `json:"containersHistory,omitempty"`
+	QueueACLs           []*model.QueueACL                `json:"queueAcls,omitempty"`
+	Event               *si.EventRecord                  `json:"event,omitempty"`
+	// ClusterID is the ID of the cluster the history and queue ACLs are attributed to, as they have no partition
+	// qualified with it, see package cluster.
+	ClusterID string `json:"clusterId,omitempty"`
+	// At is the time the entry was collected.
+	At time.Time `json:"at"`
+}
+
+// Batch is the entries collected in the cluster since the last batch, in the order they were collected.
+// The collector numbers its batches, so that every batch is applied exactly once even if it is sent again.
+type Batch struct {
+	// Source identifies the collector which numbered the batch.
+	Source string `json:"source"`
+	// Sequence is the number of the batch, which is greater than the numbers of all batches collected before.
+	Sequence int64 `json:"sequence"`
+	// Cluster is the name of the cluster the entries were collected in, if the collector is configured with one.
+	Cluster string  `json:"cluster,omitempty"`
+	Entries []Entry `json:"entries"`
+}
+
+// Result is the outcome of applying a batch.
+type Result struct {
+	// Applied is the number of applied entries, zero for duplicate batches.
+	Applied int `json:"applied"`
+	// Sequence is the number of the last batch applied for the source. It acknowledges all batches of the source
+	// up to this number.
+	Sequence int64 `json:"sequence"`
+	// Duplicate is true if the batch was already applied before.
+	Duplicate bool `json:"duplicate"`
+}
+
+// Validate checks that the batch is numbered and that all its entries can be applied, so that invalid batches
+// are rejected before any of their entries is applied.
+func (b *Batch) Validate() error {
+	if b.Source == "" {
+		return fmt.Errorf("%w: source is required", ErrInvalidBatch)
+	}
+	if b.Sequence < 1 {
+		return fmt.Errorf("%w: sequence must be positive", ErrInvalidBatch)
+	}
+	for i, entry := range b.Entries {
+		switch entry.Kind {
+		case KindPartitions, KindQueues, KindNodes, KindApplications, KindNodeUtilizations, KindHistory, KindQueueACLs:
+		case KindEvent:
+			if entry.Event == nil {
+				return fmt.Errorf("%w: entry %d of kind %s has no event", ErrInvalidBatch, i, entry.Kind)
+			}
+		default:
+			return fmt.Errorf("%w: entry %d has unknown kind %q", ErrInvalidBatch, i, entry.Kind)
+		}
+	}
+	return nil
+}
+
+// Apply applies the validated batch exactly once. The entries are written in order in one transaction, which also
+// records the batch as applied for its source, so that either all or none of them are written. Events are
+// recorded once the transaction is committed. Batches which were already applied are acknowledged without
+// applying them again.
+func Apply(ctx context.Context, repo repository.Repository, events yunikorn.EventRecorder, batch *Batch) (*Result, error) {
+	var recorded []*si.EventRecord
+	acked, err := repo.ApplyIngestBatch(ctx, batch.Source, batch.Sequence, func(ctx context.Context, tx repository.Repository) error {
+		for i := range batch.Entries {
+			entry := &batch.Entries[i]
+			if entry.Kind == KindEvent {
+				recorded = append(recorded, entry.Event)
+				continue
+			}
+			if err := apply(ctx, tx, entry); err != nil {
+				return fmt.Errorf("could not apply entry %d of kind %s: %w", i, entry.Kind, err)
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, repository.ErrDuplicateBatch) {
+		return &Result{Sequence: acked, Duplicate: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range recorded {
+		if err := events.Record(ctx, event); err != nil {
+			return nil, fmt.Errorf("could not record event: %w", err)
+		}
+	}
+	return &Result{Applied: len(batch.Entries), Sequence: acked}, nil
+}
+
+func apply(ctx context.Context, repo yunikorn.Repository, entry *Entry) error {
+	switch entry.Kind {
+	case KindPartitions:
+		return repo.UpsertPartitions(ctx, entry.Partitions)
+	case KindQueues:
+		return repo.UpsertQueues(ctx, entry.Queues)
+	case KindNodes:
+		return repo.UpsertNodes(ctx, entry.Nodes, entry.Partition)
+	case KindApplications:
+		return repo.UpsertApplications(ctx, entry.Applications)
+	case KindNodeUtilizations:
+		return repo.InsertNodeUtilizations(ctx, uuid.New(), entry.NodeUtilizations)
+	case KindHistory:
+		return repo.UpdateHistory(cluster.WithID(ctx, entry.ClusterID), entry.ApplicationsHistory, entry.ContainersHistory)
+	case KindQueueACLs:
+		return repo.UpdateQueueACLs(cluster.WithID(ctx, entry.ClusterID), entry.QueueACLs, entry.At)
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidBatch, entry.Kind)
+	}
+}
@@ -0,0 +1,145 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestBatchValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		sequence int64
+		entries  []Entry
+		wantErr  bool
+	}{
+		{name: "empty"},
+		{name: "valid", entries: []Entry{{Kind: KindPartitions}, {Kind: KindEvent, Event: &si.EventRecord{}}}},
+		{name: "without source", source: "-", wantErr: true},
+		{name: "without sequence", sequence: -1, wantErr: true},
+		{name: "unknown kind", entries: []Entry{{Kind: KindPartitions}, {Kind: "users"}}, wantErr: true},
+		{name: "event without event", entries: []Entry{{Kind: KindEvent}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := Batch{Source: "collector-1", Sequence: 1, Entries: tt.entries}
+			if tt.source == "-" {
+				batch.Source = ""
+			}
+			if tt.sequence != 0 {
+				batch.Sequence = tt.sequence
+			}
+			err := batch.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBatch)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// applyInTx runs the writes of the batch on the repository itself, as if they ran in a transaction.
+func applyInTx(repo *repository.MockRepository, source string, sequence int64, acked int64, err error) *gomock.Call {
+	return repo.EXPECT().ApplyIngestBatch(gomock.Any(), source, sequence, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string, _ int64, fn func(context.Context, repository.Repository) error) (int64, error) {
+			if err != nil {
+				return acked, err
+			}
+			if err := fn(ctx, repo); err != nil {
+				return acked, err
+			}
+			return sequence, nil
+		})
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	events := repository.NewInMemoryEventRepository()
+	partitions := []*dao.PartitionInfo{{Name: "default"}}
+	nodes := []*dao.NodeDAOInfo{{NodeID: "node-1"}}
+	acls := []*model.QueueACL{{Partition: "[eu-west]default", QueueName: "root"}}
+	applyInTx(repo, "collector-1", 3, 2, nil)
+	gomock.InOrder(
+		repo.EXPECT().UpsertPartitions(gomock.Any(), partitions).Return(nil),
+		repo.EXPECT().UpsertNodes(gomock.Any(), nodes, "default").Return(nil),
+		repo.EXPECT().UpdateQueueACLs(gomock.Any(), acls, at).DoAndReturn(
+			func(ctx context.Context, _ []*model.QueueACL, _ time.Time) error {
+				assert.Equal(t, "eu-west", cluster.IDFromContext(ctx))
+				return nil
+			},
+		),
+	)
+
+	batch := Batch{Source: "collector-1", Sequence: 3, Cluster: "eu-west", Entries: []Entry{
+		{Kind: KindPartitions, Partitions: partitions, At: at},
+		{Kind: KindNodes, Nodes: nodes, Partition: "default", At: at},
+		{Kind: KindQueueACLs, QueueACLs: acls, ClusterID: "eu-west", At: at},
+		{Kind: KindEvent, Event: &si.EventRecord{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_ADD}, At: at},
+	}}
+	result, err := Apply(ctx, repo, events, &batch)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Applied: 4, Sequence: 3}, result)
+	counts, err := events.Counts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, counts, 1)
+}
+
+func TestApplyDuplicate(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	events := repository.NewInMemoryEventRepository()
+	applyInTx(repo, "collector-1", 3, 5, repository.ErrDuplicateBatch)
+
+	batch := Batch{Source: "collector-1", Sequence: 3, Entries: []Entry{
+		{Kind: KindPartitions},
+		{Kind: KindEvent, Event: &si.EventRecord{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_ADD}},
+	}}
+	result, err := Apply(ctx, repo, events, &batch)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Sequence: 5, Duplicate: true}, result)
+	counts, err := events.Counts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, counts, "events of duplicate batches are not recorded again")
+}
+
+func TestApplyFailed(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	events := repository.NewInMemoryEventRepository()
+	applyInTx(repo, "collector-1", 1, 0, nil)
+	repo.EXPECT().UpsertPartitions(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().UpsertQueues(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+	batch := Batch{Source: "collector-1", Sequence: 1, Entries: []Entry{
+		{Kind: KindPartitions},
+		{Kind: KindEvent, Event: &si.EventRecord{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_ADD}},
+		{Kind: KindQueues},
+		{Kind: KindApplications},
+	}}
+	_, err := Apply(ctx, repo, events, &batch)
+	assert.Error(t, err)
+	counts, err := events.Counts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, counts, "events of failed batches are not recorded")
+}
@@ -0,0 +1,186 @@
+// Package jsonschema generates JSON Schemas of Go types from their encoding/json representation.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema version of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema. Only the subset of JSON Schema needed to describe Go types is supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// For returns the schema of the JSON encoding of values of the type of v. Named struct types are described
+// in $defs and referenced, so that recursive types such as trees are supported. Fields are mapped like encoding/json
+// does: by their json tag, with the fields of embedded structs promoted, and omitempty fields are not required.
+// Types with a custom JSON marshaler, which cannot be described by reflection, are described by an empty schema.
+func For(v any) *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	schema := g.schema(reflect.TypeOf(v))
+	schema.Schema = Draft
+	if len(g.defs) > 0 {
+		schema.Defs = g.defs
+	}
+	return schema
+}
+
+type generator struct {
+	defs map[string]*Schema
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		return nullable(g.schema(t.Elem()))
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType, t.Implements(jsonMarshalerType), reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &Schema{}
+	case t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// nil slices are encoded as null
+		return nullable(&Schema{Type: "array", Items: g.schema(t.Elem())})
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return nullable(&Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())})
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := defName(t)
+		if _, ok := g.defs[name]; !ok {
+			// reserve the name before describing the fields, which may refer to the type
+			g.defs[name] = nil
+			g.defs[name] = g.object(t)
+		}
+		return &Schema{Ref: "#/$defs/" + name}
+	default:
+		// interfaces can hold any value
+		return &Schema{}
+	}
+}
+
+// object describes the fields of the struct type.
+func (g *generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range fields(t) {
+		schema.Properties[f.name] = g.schema(f.typ)
+		if !f.omitEmpty {
+			schema.Required = append(schema.Required, f.name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+type field struct {
+	name      string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// fields returns the JSON fields of the struct type. Fields of embedded structs are promoted unless a field
+// with the same name is declared at a shallower depth, like encoding/json does.
+func fields(t reflect.Type) []field {
+	var result []field
+	seen := make(map[string]bool)
+	current := []reflect.Type{t}
+	for len(current) > 0 {
+		var next []reflect.Type
+		var level []field
+		for _, st := range current {
+			for i := 0; i < st.NumField(); i++ {
+				sf := st.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				ft := sf.Type
+				if sf.Anonymous && name == "" {
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, ft)
+						continue
+					}
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				level = append(level, field{name: name, typ: ft, omitEmpty: strings.Contains(opts, "omitempty")})
+			}
+		}
+		for _, f := range level {
+			if !seen[f.name] {
+				seen[f.name] = true
+				result = append(result, f)
+			}
+		}
+		current = next
+	}
+	return result
+}
+
+// defName names the definition of the named type after its package and name, e.g. dao.NodeDAOInfo.
+func defName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return pkg + "." + t.Name()
+}
+
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" || s.Type == nil {
+		return s
+	}
+	if typ, ok := s.Type.(string); ok {
+		s.Type = []string{typ, "null"}
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type base struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type node struct {
+	base
+	Name     int               `json:"name,omitempty"`
+	Children []*node           `json:"children,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Created  time.Time         `json:"created"`
+	Parent   *string           `json:"parent"`
+	Data     []byte            `json:"data,omitempty"`
+	Value    any               `json:"value,omitempty"`
+	Ignored  string            `json:"-"`
+	Plain    bool
+	hidden   string
+}
+
+func TestFor(t *testing.T) {
+	schema := For(node{hidden: "not encoded"})
+	got, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/jsonschema.node",
+		"$defs": {
+			"jsonschema.node": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string"},
+					"name": {"type": "integer"},
+					"children": {"type": ["array", "null"], "items": {"$ref": "#/$defs/jsonschema.node"}},
+					"labels": {"type": ["object", "null"], "additionalProperties": {"type": "string"}},
+					"created": {"type": "string", "format": "date-time"},
+					"parent": {"type": ["string", "null"]},
+					"data": {"type": "string", "format": "byte"},
+					"value": {},
+					"Plain": {"type": "boolean"}
+				},
+				"required": ["Plain", "created", "id", "labels", "parent"]
+			}
+		}
+	}`, string(got))
+}
+
+func TestForAnonymousStruct(t *testing.T) {
+	schema := For(struct {
+		Count float64 `json:"count"`
+	}{})
+	assert.Nil(t, schema.Defs)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, &Schema{Type: "number"}, schema.Properties["count"])
+	assert.Equal(t, []string{"count"}, schema.Required)
+}
@@ -0,0 +1,416 @@
+package lake
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+)
+
+const (
+	// deltaLogPrefix is the prefix of the transaction log of the Delta Lake table, whose root is the prefix of the
+	// events, so that the files of the partitions are the data files of the table.
+	deltaLogPrefix = eventsPrefix + "_delta_log/"
+	// deltaCommitAttempts is the number of attempts to commit files while other writers commit concurrently.
+	deltaCommitAttempts = 3
+	// deltaTimestampLayout is the layout of the statistics of timestamps, which Delta Lake truncates to milliseconds.
+	deltaTimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// deltaPartitionColumns are the columns the table is partitioned by, whose values are those of the partitions.
+var deltaPartitionColumns = []string{"dt", "hour"}
+
+// deltaAction is an action of a commit of the transaction log, of which one field is set. Actions which are not
+// written by the writer, e.g. the removals of files compacted by Spark, are decoded as empty actions.
+type deltaAction struct {
+	CommitInfo *deltaCommitInfo `json:"commitInfo,omitempty"`
+	Protocol   *deltaProtocol   `json:"protocol,omitempty"`
+	MetaData   *deltaMetadata   `json:"metaData,omitempty"`
+	Add        *deltaAdd        `json:"add,omitempty"`
+}
+
+type deltaCommitInfo struct {
+	Timestamp           int64             `json:"timestamp"`
+	Operation           string            `json:"operation"`
+	OperationParameters map[string]string `json:"operationParameters"`
+	IsBlindAppend       bool              `json:"isBlindAppend"`
+	EngineInfo          string            `json:"engineInfo"`
+	// MetadataVersion is the version of the commit of the metadata of the table at the time of the commit, so that
+	// the writer finds the metadata without reading the commits since.
+	MetadataVersion *int64 `json:"yhsMetadataVersion,omitempty"`
+}
+
+type deltaProtocol struct {
+	MinReaderVersion int `json:"minReaderVersion"`
+	MinWriterVersion int `json:"minWriterVersion"`
+}
+
+type deltaMetadata struct {
+	ID               string            `json:"id"`
+	Name             *string           `json:"name,omitempty"`
+	Description      *string           `json:"description,omitempty"`
+	Format           deltaFormat       `json:"format"`
+	SchemaString     string            `json:"schemaString"`
+	PartitionColumns []string          `json:"partitionColumns"`
+	Configuration    map[string]string `json:"configuration"`
+	CreatedTime      *int64            `json:"createdTime,omitempty"`
+}
+
+type deltaFormat struct {
+	Provider string            `json:"provider"`
+	Options  map[string]string `json:"options"`
+}
+
+type deltaSchema struct {
+	Type   string       `json:"type"`
+	Fields []deltaField `json:"fields"`
+}
+
+// deltaField is a field of the schema. Its type is kept as is, as the fields added by other writers may be nested.
+type deltaField struct {
+	Name     string          `json:"name"`
+	Type     json.RawMessage `json:"type"`
+	Nullable bool            `json:"nullable"`
+	Metadata map[string]any  `json:"metadata"`
+}
+
+type deltaAdd struct {
+	Path             string            `json:"path"`
+	PartitionValues  map[string]string `json:"partitionValues"`
+	Size             int64             `json:"size"`
+	ModificationTime int64             `json:"modificationTime"`
+	DataChange       bool              `json:"dataChange"`
+	Stats            string            `json:"stats"`
+}
+
+// deltaStats are the statistics of a data file, which allow readers to skip files by their time range.
+type deltaStats struct {
+	NumRecords int            `json:"numRecords"`
+	MinValues  map[string]any `json:"minValues"`
+	MaxValues  map[string]any `json:"maxValues"`
+}
+
+// deltaTable commits the files written by the writer to the Delta Lake table, which gives readers ACID snapshots
+// and time travel over the events. Commits are created with a precondition on their absence, so that a commit of
+// the same version by another writer, e.g. a compaction by Spark, is detected, and the commit is retried.
+//
+// The table is read lazily, from the version of the last checkpoint if any, as the JSON commits since are enough to
+// find the latest version and the metadata. The writer does not write checkpoints itself.
+type deltaTable struct {
+	store  objectstore.Store
+	loaded bool
+	// version is the version of the last commit, or -1 if the table does not exist
+	version int64
+	// metadata is the metadata of the table and metadataVersion the version of its commit, or nil if the metadata
+	// is only in a checkpoint, in which case the schema is not evolved
+	metadata        *deltaMetadata
+	metadataVersion int64
+}
+
+func newDeltaTable(store objectstore.Store) *deltaTable {
+	return &deltaTable{store: store}
+}
+
+// commit adds the files written to the partitions to the table in a new commit, which creates the table if it does
+// not exist, and evolves its schema if it lacks columns of the files.
+func (t *deltaTable) commit(ctx context.Context, files map[string][]ManifestFile, now time.Time) error {
+	for attempt := 1; ; attempt++ {
+		if !t.loaded {
+			if err := t.load(ctx); err != nil {
+				return err
+			}
+			if t.version >= 0 && t.metadata == nil {
+				log.FromContext(ctx).Warnw("the metadata of the Delta table is only in a checkpoint, its schema is not evolved",
+					"version", t.version)
+			}
+		}
+		actions, metadata, err := t.actions(files, now)
+		if err != nil {
+			return err
+		}
+		var body bytes.Buffer
+		encoder := json.NewEncoder(&body)
+		for _, action := range actions {
+			if err := encoder.Encode(action); err != nil {
+				return fmt.Errorf("could not encode commit of the Delta table: %v", err)
+			}
+		}
+		version := t.version + 1
+		err = t.store.Create(ctx, deltaLogKey(version), body.Bytes(), "application/json")
+		if errors.Is(err, objectstore.ErrExists) && attempt < deltaCommitAttempts {
+			// another writer committed the version, so the table is read again before retrying
+			t.loaded = false
+			continue
+		}
+		if err != nil {
+			t.loaded = false
+			return fmt.Errorf("could not commit version %d of the Delta table: %w", version, err)
+		}
+		t.version = version
+		if metadata != nil {
+			t.metadata, t.metadataVersion = metadata, version
+		}
+		return nil
+	}
+}
+
+// actions returns the actions of the next commit, and the new metadata of the table if it is changed by the commit.
+func (t *deltaTable) actions(files map[string][]ManifestFile, now time.Time) ([]deltaAction, *deltaMetadata, error) {
+	commitInfo := &deltaCommitInfo{
+		Timestamp: now.UnixMilli(),
+		Operation: "WRITE",
+		OperationParameters: map[string]string{
+			"mode":        "Append",
+			"partitionBy": `["dt","hour"]`,
+		},
+		IsBlindAppend: true,
+		EngineInfo:    createdBy,
+	}
+	actions := []deltaAction{{CommitInfo: commitInfo}}
+
+	var metadata *deltaMetadata
+	switch {
+	case t.version < 0:
+		schema, err := json.Marshal(deltaSchema{Type: "struct", Fields: missingFields(nil)})
+		if err != nil {
+			return nil, nil, err
+		}
+		createdTime := now.UnixMilli()
+		metadata = &deltaMetadata{
+			ID:               uuid.NewString(),
+			Format:           deltaFormat{Provider: "parquet", Options: map[string]string{}},
+			SchemaString:     string(schema),
+			PartitionColumns: deltaPartitionColumns,
+			Configuration:    map[string]string{},
+			CreatedTime:      &createdTime,
+		}
+		actions = append(actions, deltaAction{Protocol: &deltaProtocol{MinReaderVersion: 1, MinWriterVersion: 2}})
+	case t.metadata != nil:
+		var schema deltaSchema
+		if err := json.Unmarshal([]byte(t.metadata.SchemaString), &schema); err != nil {
+			return nil, nil, fmt.Errorf("could not decode schema of the Delta table: %v", err)
+		}
+		if missing := missingFields(schema.Fields); len(missing) > 0 {
+			schema.Fields = append(schema.Fields, missing...)
+			evolved, err := json.Marshal(schema)
+			if err != nil {
+				return nil, nil, err
+			}
+			changed := *t.metadata
+			changed.SchemaString = string(evolved)
+			metadata = &changed
+		}
+	}
+	if metadata != nil {
+		actions = append(actions, deltaAction{MetaData: metadata})
+		commitInfo.MetadataVersion = ptr(t.version + 1)
+	} else if t.metadata != nil {
+		commitInfo.MetadataVersion = ptr(t.metadataVersion)
+	}
+
+	for _, partition := range sortedKeys(files) {
+		for _, file := range files[partition] {
+			add, err := deltaAddAction(partition, file)
+			if err != nil {
+				return nil, nil, err
+			}
+			actions = append(actions, deltaAction{Add: add})
+		}
+	}
+	return actions, metadata, nil
+}
+
+// missingFields returns the fields of the columns of the files and of the partition columns which are not in the
+// fields of the schema. All fields are nullable, so that columns can be added to tables with existing files.
+func missingFields(fields []deltaField) []deltaField {
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		names[field.Name] = true
+	}
+	var missing []deltaField
+	add := func(name, fieldType string) {
+		if !names[name] {
+			missing = append(missing, deltaField{
+				Name:     name,
+				Type:     json.RawMessage(`"` + fieldType + `"`),
+				Nullable: true,
+				Metadata: map[string]any{},
+			})
+		}
+	}
+	for _, col := range columns {
+		add(col.name, col.deltaType())
+	}
+	for _, name := range deltaPartitionColumns {
+		add(name, "string")
+	}
+	return missing
+}
+
+// deltaAddAction returns the action adding the file of the partition to the table.
+func deltaAddAction(partition string, file ManifestFile) (*deltaAdd, error) {
+	values := make(map[string]string, len(deltaPartitionColumns))
+	for _, part := range strings.Split(partition, "/") {
+		name, value, _ := strings.Cut(part, "=")
+		values[name] = value
+	}
+	maxTimestamp := file.MaxTimestamp.Truncate(time.Millisecond)
+	if maxTimestamp.Before(file.MaxTimestamp) {
+		maxTimestamp = maxTimestamp.Add(time.Millisecond)
+	}
+	stats, err := json.Marshal(deltaStats{
+		NumRecords: file.Rows,
+		MinValues: map[string]any{
+			"timestamp":      file.MinTimestamp.Truncate(time.Millisecond).Format(deltaTimestampLayout),
+			"timestamp_nano": file.MinTimestamp.UnixNano(),
+		},
+		MaxValues: map[string]any{
+			"timestamp":      maxTimestamp.Format(deltaTimestampLayout),
+			"timestamp_nano": file.MaxTimestamp.UnixNano(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deltaAdd{
+		Path:             strings.TrimPrefix(file.Path, eventsPrefix),
+		PartitionValues:  values,
+		Size:             int64(file.Bytes),
+		ModificationTime: file.WrittenAt.UnixMilli(),
+		DataChange:       true,
+		Stats:            string(stats),
+	}, nil
+}
+
+// load reads the latest version and the metadata of the table. Versions are contiguous, so the latest version is
+// found by probing exponentially growing versions from the last checkpoint, and then by bisection.
+func (t *deltaTable) load(ctx context.Context) error {
+	t.version, t.metadata, t.metadataVersion = -1, nil, 0
+
+	lowest := int64(0)
+	data, err := t.store.Get(ctx, deltaLogPrefix+"_last_checkpoint")
+	switch {
+	case err == nil:
+		var checkpoint struct {
+			Version int64 `json:"version"`
+		}
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return fmt.Errorf("could not decode last checkpoint of the Delta table: %v", err)
+		}
+		lowest = checkpoint.Version
+	case !errors.Is(err, objectstore.ErrNotFound):
+		return fmt.Errorf("could not read last checkpoint of the Delta table: %w", err)
+	}
+
+	exists := func(version int64) (bool, error) {
+		_, err := t.read(ctx, version)
+		if errors.Is(err, objectstore.ErrNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	ok, err := exists(lowest)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if lowest > 0 {
+			return fmt.Errorf("commit %d of the last checkpoint of the Delta table does not exist", lowest)
+		}
+		t.loaded = true
+		return nil
+	}
+	existing, missing := lowest, lowest+1
+	for step := int64(1); ; step *= 2 {
+		ok, err := exists(existing + step)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			missing = existing + step
+			break
+		}
+		existing += step
+	}
+	for missing-existing > 1 {
+		mid := existing + (missing-existing)/2
+		ok, err := exists(mid)
+		if err != nil {
+			return err
+		}
+		if ok {
+			existing = mid
+		} else {
+			missing = mid
+		}
+	}
+	t.version = existing
+
+	// the metadata is in the latest commit changing it, or in the commit referenced by a later commit of the writer
+	for version := t.version; version >= lowest; version-- {
+		actions, err := t.read(ctx, version)
+		if errors.Is(err, objectstore.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var metadataVersion *int64
+		for _, action := range actions {
+			if action.MetaData != nil {
+				t.metadata, t.metadataVersion = action.MetaData, version
+			}
+			if action.CommitInfo != nil && action.CommitInfo.MetadataVersion != nil {
+				metadataVersion = action.CommitInfo.MetadataVersion
+			}
+		}
+		if t.metadata != nil {
+			break
+		}
+		if metadataVersion != nil && *metadataVersion < version {
+			version = *metadataVersion + 1
+		}
+	}
+	t.loaded = true
+	return nil
+}
+
+// read returns the actions of the commit of the version.
+func (t *deltaTable) read(ctx context.Context, version int64) ([]deltaAction, error) {
+	data, err := t.store.Get(ctx, deltaLogKey(version))
+	if err != nil {
+		return nil, err
+	}
+	var actions []deltaAction
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var action deltaAction
+		if err := json.Unmarshal(line, &action); err != nil {
+			return nil, fmt.Errorf("could not decode commit %d of the Delta table: %v", version, err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, scanner.Err()
+}
+
+func deltaLogKey(version int64) string {
+	return fmt.Sprintf("%s%020d.json", deltaLogPrefix, version)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
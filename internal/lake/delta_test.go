@@ -0,0 +1,164 @@
+package lake
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+)
+
+// readCommit returns the actions of the commit of the version of the Delta table.
+func readCommit(t *testing.T, store *memoryStore, version int64) []deltaAction {
+	t.Helper()
+	data, err := store.Get(context.Background(), deltaLogKey(version))
+	require.NoError(t, err)
+	var actions []deltaAction
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var action deltaAction
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &action))
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+func schemaFields(t *testing.T, metadata *deltaMetadata) []string {
+	t.Helper()
+	var schema deltaSchema
+	require.NoError(t, json.Unmarshal([]byte(metadata.SchemaString), &schema))
+	var names []string
+	for _, field := range schema.Fields {
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+func TestWriter_FlushDeltaTable(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	w, _ := newTestWriter(store, WithDeltaTable())
+
+	for _, ts := range []int64{at(12, 59), at(13, 1) + 1500} {
+		require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: ts}))
+	}
+	require.NoError(t, w.flush(ctx))
+
+	// the first commit creates the table, and the files are not listed in manifests
+	assert.Len(t, store.files(), 2)
+	_, err := store.Get(ctx, indexKey)
+	assert.ErrorIs(t, err, objectstore.ErrNotFound)
+	actions := readCommit(t, store, 0)
+	require.Len(t, actions, 5)
+	assert.Equal(t, "WRITE", actions[0].CommitInfo.Operation)
+	assert.Equal(t, &deltaProtocol{MinReaderVersion: 1, MinWriterVersion: 2}, actions[1].Protocol)
+	metadata := actions[2].MetaData
+	require.NotNil(t, metadata)
+	assert.Equal(t, []string{"dt", "hour"}, metadata.PartitionColumns)
+	assert.Equal(t, []string{"timestamp", "timestamp_nano", "type", "object_id", "reference_id",
+		"change_type", "change_detail", "message", "resource", "dt", "hour"}, schemaFields(t, metadata))
+	assert.Equal(t, int64(0), *actions[0].CommitInfo.MetadataVersion)
+
+	add := actions[4].Add
+	require.NotNil(t, add)
+	assert.Equal(t, map[string]string{"dt": "2024-07-01", "hour": "13"}, add.PartitionValues)
+	assert.Regexp(t, `^dt=2024-07-01/hour=13/.*\.parquet$`, add.Path)
+	assert.Len(t, store.objects[eventsPrefix+add.Path], int(add.Size))
+	var stats deltaStats
+	require.NoError(t, json.Unmarshal([]byte(add.Stats), &stats))
+	assert.Equal(t, 1, stats.NumRecords)
+	// the statistics of the timestamp are widened to milliseconds
+	assert.Equal(t, "2024-07-01T13:01:00.000Z", stats.MinValues["timestamp"])
+	assert.Equal(t, "2024-07-01T13:01:00.001Z", stats.MaxValues["timestamp"])
+
+	// later commits only add files, and refer to the commit of the metadata
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node-1", TimestampNano: at(13, 45)}))
+	require.NoError(t, w.flush(ctx))
+	actions = readCommit(t, store, 1)
+	require.Len(t, actions, 2)
+	assert.Equal(t, int64(0), *actions[0].CommitInfo.MetadataVersion)
+	assert.NotNil(t, actions[1].Add)
+	assert.Empty(t, w.pending)
+}
+
+func TestDeltaTable_SchemaEvolution(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	// a table created by another writer, with a nested column of its own, lacking columns of the files
+	schema := `{"type":"struct","fields":[` +
+		`{"name":"timestamp","type":"timestamp","nullable":true,"metadata":{}},` +
+		`{"name":"tags","type":{"type":"map","keyType":"string","valueType":"string","valueContainsNull":true},"nullable":true,"metadata":{}},` +
+		`{"name":"dt","type":"string","nullable":true,"metadata":{}},` +
+		`{"name":"hour","type":"string","nullable":true,"metadata":{}}]}`
+	metadata, err := json.Marshal(deltaAction{MetaData: &deltaMetadata{
+		ID:               "table-id",
+		Format:           deltaFormat{Provider: "parquet", Options: map[string]string{}},
+		SchemaString:     schema,
+		PartitionColumns: deltaPartitionColumns,
+		Configuration:    map[string]string{"delta.appendOnly": "true"},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, deltaLogKey(0), append([]byte(`{"protocol":{"minReaderVersion":1,"minWriterVersion":2}}`+"\n"), metadata...), ""))
+	for version := int64(1); version <= 5; version++ {
+		require.NoError(t, store.Put(ctx, deltaLogKey(version), []byte(`{"remove":{"path":"old.parquet"}}`), ""))
+	}
+
+	w, _ := newTestWriter(store, WithDeltaTable())
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 0)}))
+	require.NoError(t, w.flush(ctx))
+
+	actions := readCommit(t, store, 6)
+	require.Len(t, actions, 3)
+	evolved := actions[1].MetaData
+	require.NotNil(t, evolved)
+	assert.Equal(t, "table-id", evolved.ID)
+	assert.Equal(t, map[string]string{"delta.appendOnly": "true"}, evolved.Configuration)
+	assert.Equal(t, []string{"timestamp", "tags", "dt", "hour", "timestamp_nano", "type", "object_id", "reference_id",
+		"change_type", "change_detail", "message", "resource"}, schemaFields(t, evolved))
+	assert.Contains(t, evolved.SchemaString, `"valueContainsNull":true`, "foreign types must be preserved")
+	assert.Equal(t, int64(6), *actions[0].CommitInfo.MetadataVersion)
+
+	// a new writer finds the evolved metadata, and does not evolve the schema again
+	require.NoError(t, store.Put(ctx, deltaLogKey(7), []byte(`{"remove":{"path":"old.parquet"}}`), ""))
+	w, _ = newTestWriter(store, WithDeltaTable())
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 5)}))
+	require.NoError(t, w.flush(ctx))
+	actions = readCommit(t, store, 8)
+	require.Len(t, actions, 2)
+	assert.Equal(t, int64(6), *actions[0].CommitInfo.MetadataVersion)
+}
+
+func TestDeltaTable_CommitConflicts(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	w, _ := newTestWriter(store, WithDeltaTable())
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 0)}))
+	require.NoError(t, w.flush(ctx))
+
+	// versions committed by another writer since are skipped
+	require.NoError(t, store.Put(ctx, deltaLogKey(1), []byte(`{"commitInfo":{"operation":"OPTIMIZE"}}`), ""))
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 1)}))
+	require.NoError(t, w.flush(ctx))
+	actions := readCommit(t, store, 2)
+	require.Len(t, actions, 2)
+	assert.NotNil(t, actions[1].Add)
+
+	// the files are committed on the next flush if the log is unavailable
+	store.setFailing(".json")
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 2)}))
+	require.Error(t, w.flush(ctx))
+	assert.Len(t, w.pending, 1)
+	store.setFailing()
+	require.NoError(t, w.flush(ctx))
+	assert.Empty(t, w.pending)
+	actions = readCommit(t, store, 3)
+	require.Len(t, actions, 2)
+	assert.Len(t, store.files(), 3)
+}
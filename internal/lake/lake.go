@@ -0,0 +1,499 @@
+// Package lake writes the raw events of the event stream of Yunikorn to object storage as hourly partitioned
+// Parquet files, in addition to the state derived from them in the database, so that heavy offline analytics run
+// in Spark or Trino over the files instead of querying the database. The files of an hour are listed in the manifest
+// of its partition, and the partitions in the index of the lake, so that readers need not list the objects.
+// Alternatively, the files are committed to a Delta Lake table, which gives readers ACID snapshots and time travel.
+//
+// The events are buffered in memory and written at an interval, so the events buffered when the server crashes
+// are lost, and the events are dropped while the buffer is full because the object storage is unavailable.
+package lake
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+)
+
+const (
+	// eventsPrefix is the prefix of the keys of the partitions of the events.
+	eventsPrefix = "events/"
+	// manifestName is the name of the manifest of the files of a partition, which is hidden from Spark and Trino
+	// by its leading underscore.
+	manifestName = "_manifest.json"
+	// indexKey is the key of the index of the partitions.
+	indexKey = eventsPrefix + "_index.json"
+	// partitionLayout is the layout of the Hive-style partitions of the events by their hour in UTC.
+	partitionLayout = "dt=2006-01-02/hour=15"
+	// shutdownTimeout is the time the buffered events are written for on shutdown.
+	shutdownTimeout = 30 * time.Second
+)
+
+var (
+	eventsWrittenTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "event_lake",
+		Name:      "events_written_total",
+		Help:      "Number of events written to object storage.",
+	})
+	filesWrittenTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "event_lake",
+		Name:      "files_written_total",
+		Help:      "Number of Parquet files written to object storage.",
+	})
+	eventsDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "event_lake",
+		Name:      "events_dropped_total",
+		Help:      "Number of events dropped because the buffer of events not yet written was full.",
+	})
+	writeFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "event_lake",
+		Name:      "write_failures_total",
+		Help:      "Number of failures writing files or manifests to object storage.",
+	})
+)
+
+// Event is a raw event as a row of the Parquet files.
+type Event struct {
+	TimestampNano int64
+	Type          string
+	ObjectID      string
+	ReferenceID   string
+	ChangeType    string
+	ChangeDetail  string
+	Message       string
+	// Resource is the JSON object of the quantities of the resource of the event, or empty.
+	Resource string
+}
+
+// Manifest lists the files of a partition.
+type Manifest struct {
+	Partition string         `json:"partition"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a file of a partition. Its path is relative to the location of the lake.
+type ManifestFile struct {
+	Path         string    `json:"path"`
+	Rows         int       `json:"rows"`
+	Bytes        int       `json:"bytes"`
+	MinTimestamp time.Time `json:"minTimestamp"`
+	MaxTimestamp time.Time `json:"maxTimestamp"`
+	WrittenAt    time.Time `json:"writtenAt"`
+}
+
+// Index lists the partitions of the lake in chronological order.
+type Index struct {
+	Partitions []IndexPartition `json:"partitions"`
+}
+
+// IndexPartition is a partition of the lake. Its manifest is relative to the location of the lake.
+type IndexPartition struct {
+	Partition    string    `json:"partition"`
+	Manifest     string    `json:"manifest"`
+	Files        int       `json:"files"`
+	Rows         int       `json:"rows"`
+	MinTimestamp time.Time `json:"minTimestamp"`
+	MaxTimestamp time.Time `json:"maxTimestamp"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Writer records the events in the wrapped event repository, and writes them to the lake.
+type Writer struct {
+	repository.EventRepository
+	store             objectstore.Store
+	flushInterval     time.Duration
+	maxFileEvents     int
+	maxBufferedEvents int
+	now               func() time.Time
+	// id distinguishes the files of this writer from those written before a restart
+	id  string
+	seq int
+
+	mutex   sync.Mutex
+	buffer  []*Event
+	dropped int
+	// full signals that a file of events is buffered
+	full chan struct{}
+
+	// pending are the files written whose partitions' manifests were not updated yet, by partition
+	pending map[string][]ManifestFile
+	// table is the Delta table the files are committed to instead of the manifests, if any
+	table *deltaTable
+}
+
+var _ repository.EventRepository = &Writer{}
+
+type Option func(*Writer)
+
+// WithFlushInterval sets the interval at which the buffered events are written.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(w *Writer) {
+		w.flushInterval = interval
+	}
+}
+
+// WithMaxFileEvents sets the maximum number of events per file.
+func WithMaxFileEvents(maxEvents int) Option {
+	return func(w *Writer) {
+		w.maxFileEvents = maxEvents
+	}
+}
+
+// WithMaxBufferedEvents sets the maximum number of buffered events, beyond which events are dropped.
+func WithMaxBufferedEvents(maxEvents int) Option {
+	return func(w *Writer) {
+		w.maxBufferedEvents = maxEvents
+	}
+}
+
+// WithDeltaTable commits the files to a Delta Lake table rooted at the prefix of the events, instead of listing
+// them in the manifests and the index.
+func WithDeltaTable() Option {
+	return func(w *Writer) {
+		w.table = newDeltaTable(w.store)
+	}
+}
+
+// NewWriter creates a writer, which records the events in the event repository and writes them to the store.
+func NewWriter(events repository.EventRepository, store objectstore.Store, opts ...Option) *Writer {
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+	w := &Writer{
+		EventRepository:   events,
+		store:             store,
+		flushInterval:     5 * time.Minute,
+		maxFileEvents:     100000,
+		maxBufferedEvents: 1000000,
+		now:               time.Now,
+		id:                hex.EncodeToString(id),
+		full:              make(chan struct{}, 1),
+		pending:           make(map[string][]ManifestFile),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Record records the event in the wrapped event repository and buffers it to be written to the lake.
+func (w *Writer) Record(ctx context.Context, event *si.EventRecord) error {
+	if err := w.EventRepository.Record(ctx, event); err != nil {
+		return err
+	}
+	w.add(w.lakeEvent(event))
+	return nil
+}
+
+// lakeEvent converts the event to its row in the files. Events without timestamp are stamped with the current time.
+func (w *Writer) lakeEvent(event *si.EventRecord) *Event {
+	e := &Event{
+		TimestampNano: event.GetTimestampNano(),
+		Type:          event.GetType().String(),
+		ObjectID:      event.GetObjectID(),
+		ReferenceID:   event.GetReferenceID(),
+		ChangeType:    event.GetEventChangeType().String(),
+		ChangeDetail:  event.GetEventChangeDetail().String(),
+		Message:       event.GetMessage(),
+	}
+	if e.TimestampNano == 0 {
+		e.TimestampNano = w.now().UnixNano()
+	}
+	if resources := event.GetResource().GetResources(); len(resources) > 0 {
+		quantities := make(map[string]int64, len(resources))
+		for name, quantity := range resources {
+			quantities[name] = quantity.GetValue()
+		}
+		if resource, err := json.Marshal(quantities); err == nil {
+			e.Resource = string(resource)
+		}
+	}
+	return e
+}
+
+func (w *Writer) add(event *Event) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.buffer) >= w.maxBufferedEvents {
+		w.dropped++
+		eventsDroppedTotal.Inc()
+		return
+	}
+	w.buffer = append(w.buffer, event)
+	if len(w.buffer)%w.maxFileEvents == 0 {
+		select {
+		case w.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes the buffered events at the configured interval, or once a file of events is buffered, until the
+// context is cancelled. The events buffered then are written before it returns.
+func (w *Writer) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "event_lake")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Info("starting event lake writer")
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warn("shutting down event lake writer")
+			flushCtx, cancel := context.WithTimeout(log.ToContext(context.Background(), logger), shutdownTimeout)
+			defer cancel()
+			if err := w.flush(flushCtx); err != nil {
+				logger.Errorf("error writing buffered events to the lake on shutdown: %v", err)
+			}
+			return nil
+		case <-ticker.C:
+		case <-w.full:
+		}
+		if err := w.flush(ctx); err != nil && ctx.Err() == nil {
+			logger.Errorf("error writing events to the lake: %v", err)
+		}
+	}
+}
+
+// flush writes the buffered events to files of their partitions, and then adds the files to the manifests of
+// their partitions and the partitions to the index, or commits them to the Delta table. The events of the files
+// which could not be written are buffered again, and the files which could not be added are added on the next flush.
+func (w *Writer) flush(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	w.mutex.Lock()
+	events, dropped := w.buffer, w.dropped
+	w.buffer, w.dropped = nil, 0
+	w.mutex.Unlock()
+	if dropped > 0 {
+		logger.Warnw("dropped events because the buffer of the event lake was full", "events", dropped)
+	}
+
+	partitions := make(map[string][]*Event)
+	for _, event := range events {
+		partition := partitionOf(event.TimestampNano)
+		partitions[partition] = append(partitions[partition], event)
+	}
+	var errs []error
+	var failed []*Event
+	for _, partition := range sortedKeys(partitions) {
+		partitionEvents := partitions[partition]
+		for start := 0; start < len(partitionEvents); start += w.maxFileEvents {
+			end := min(start+w.maxFileEvents, len(partitionEvents))
+			file, err := w.writeFile(ctx, partition, partitionEvents[start:end])
+			if err != nil {
+				writeFailuresTotal.Inc()
+				errs = append(errs, err)
+				failed = append(failed, partitionEvents[start:end]...)
+				continue
+			}
+			w.pending[partition] = append(w.pending[partition], *file)
+		}
+	}
+	if len(failed) > 0 {
+		w.requeue(failed)
+	}
+
+	if len(w.pending) == 0 {
+		return errors.Join(errs...)
+	}
+	if w.table != nil {
+		if err := w.table.commit(ctx, w.pending, w.now()); err != nil {
+			writeFailuresTotal.Inc()
+			return errors.Join(append(errs, err)...)
+		}
+		clear(w.pending)
+		return errors.Join(errs...)
+	}
+	return errors.Join(append(errs, w.updateManifests(ctx)...)...)
+}
+
+// updateManifests adds the pending files to the manifests of their partitions, and the partitions to the index.
+func (w *Writer) updateManifests(ctx context.Context) []error {
+	index, err := w.readIndex(ctx)
+	if err != nil {
+		writeFailuresTotal.Inc()
+		return []error{err}
+	}
+	var errs []error
+	updated := false
+	for _, partition := range sortedKeys(w.pending) {
+		manifest, err := w.updateManifest(ctx, partition, w.pending[partition])
+		if err != nil {
+			writeFailuresTotal.Inc()
+			errs = append(errs, err)
+			continue
+		}
+		delete(w.pending, partition)
+		index.update(manifest, w.now())
+		updated = true
+	}
+	if updated {
+		if err := w.put(ctx, indexKey, index); err != nil {
+			writeFailuresTotal.Inc()
+			errs = append(errs, fmt.Errorf("could not write index of the lake: %w", err))
+		}
+	}
+	return errs
+}
+
+// requeue buffers the events again in front of the events buffered since, dropping the oldest events beyond the
+// maximum number of buffered events.
+func (w *Writer) requeue(events []*Event) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer = append(events, w.buffer...)
+	if excess := len(w.buffer) - w.maxBufferedEvents; excess > 0 {
+		w.buffer = w.buffer[excess:]
+		w.dropped += excess
+		eventsDroppedTotal.Add(float64(excess))
+	}
+}
+
+// writeFile writes the events of the partition to a new file, and returns its entry of the manifest.
+func (w *Writer) writeFile(ctx context.Context, partition string, events []*Event) (*ManifestFile, error) {
+	data, err := encodeParquet(events)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode events of partition %s: %v", partition, err)
+	}
+	now := w.now()
+	w.seq++
+	key := fmt.Sprintf("%s%s/%d-%s-%06d.parquet", eventsPrefix, partition, now.UnixNano(), w.id, w.seq)
+	if err := w.store.Put(ctx, key, data, "application/vnd.apache.parquet"); err != nil {
+		return nil, err
+	}
+	file := &ManifestFile{
+		Path:      key,
+		Rows:      len(events),
+		Bytes:     len(data),
+		WrittenAt: now.UTC(),
+	}
+	for i, event := range events {
+		ts := time.Unix(0, event.TimestampNano).UTC()
+		if i == 0 || ts.Before(file.MinTimestamp) {
+			file.MinTimestamp = ts
+		}
+		if i == 0 || ts.After(file.MaxTimestamp) {
+			file.MaxTimestamp = ts
+		}
+	}
+	eventsWrittenTotal.Add(float64(len(events)))
+	filesWrittenTotal.Inc()
+	return file, nil
+}
+
+// updateManifest adds the files to the manifest of the partition. Files already listed are skipped, so that the
+// update can be retried.
+func (w *Writer) updateManifest(ctx context.Context, partition string, files []ManifestFile) (*Manifest, error) {
+	key := eventsPrefix + partition + "/" + manifestName
+	manifest := &Manifest{Partition: partition}
+	if err := w.get(ctx, key, manifest); err != nil && !errors.Is(err, objectstore.ErrNotFound) {
+		return nil, fmt.Errorf("could not read manifest of partition %s: %w", partition, err)
+	}
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, file := range manifest.Files {
+		listed[file.Path] = true
+	}
+	for _, file := range files {
+		if !listed[file.Path] {
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	if err := w.put(ctx, key, manifest); err != nil {
+		return nil, fmt.Errorf("could not write manifest of partition %s: %w", partition, err)
+	}
+	return manifest, nil
+}
+
+func (w *Writer) readIndex(ctx context.Context) (*Index, error) {
+	index := &Index{}
+	if err := w.get(ctx, indexKey, index); err != nil && !errors.Is(err, objectstore.ErrNotFound) {
+		return nil, fmt.Errorf("could not read index of the lake: %w", err)
+	}
+	return index, nil
+}
+
+// update sets the summary of the partition of the manifest, keeping the partitions in chronological order.
+func (i *Index) update(manifest *Manifest, now time.Time) {
+	partition := IndexPartition{
+		Partition: manifest.Partition,
+		Manifest:  eventsPrefix + manifest.Partition + "/" + manifestName,
+		Files:     len(manifest.Files),
+		UpdatedAt: now.UTC(),
+	}
+	for j, file := range manifest.Files {
+		partition.Rows += file.Rows
+		if j == 0 || file.MinTimestamp.Before(partition.MinTimestamp) {
+			partition.MinTimestamp = file.MinTimestamp
+		}
+		if j == 0 || file.MaxTimestamp.After(partition.MaxTimestamp) {
+			partition.MaxTimestamp = file.MaxTimestamp
+		}
+	}
+	pos := sort.Search(len(i.Partitions), func(j int) bool {
+		return i.Partitions[j].Partition >= partition.Partition
+	})
+	if pos < len(i.Partitions) && i.Partitions[pos].Partition == partition.Partition {
+		i.Partitions[pos] = partition
+		return
+	}
+	i.Partitions = append(i.Partitions, IndexPartition{})
+	copy(i.Partitions[pos+1:], i.Partitions[pos:])
+	i.Partitions[pos] = partition
+}
+
+func (w *Writer) get(ctx context.Context, key string, v any) error {
+	data, err := w.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("could not decode %s: %v", key, err)
+	}
+	return nil
+}
+
+func (w *Writer) put(ctx context.Context, key string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.store.Put(ctx, key, data, "application/json")
+}
+
+// partitionOf returns the partition of the events of the timestamp: the hour of the timestamp in UTC.
+func partitionOf(timestampNano int64) string {
+	return time.Unix(0, timestampNano).UTC().Format(partitionLayout)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,232 @@
+package lake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+)
+
+// memoryStore stores the objects in memory, and fails the puts of the keys with the failing suffixes.
+type memoryStore struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+	failing []string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{objects: make(map[string][]byte)}
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, suffix := range s.failing {
+		if strings.HasSuffix(key, suffix) {
+			return errors.New("bucket is unavailable")
+		}
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStore) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	s.mutex.Lock()
+	_, ok := s.objects[key]
+	s.mutex.Unlock()
+	if ok {
+		return objectstore.ErrExists
+	}
+	return s.Put(ctx, key, data, contentType)
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, objectstore.ErrNotFound
+	}
+	return data, nil
+}
+
+func (s *memoryStore) setFailing(suffixes ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failing = suffixes
+}
+
+// files returns the keys of the Parquet files.
+func (s *memoryStore) files() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var keys []string
+	for key := range s.objects {
+		if strings.HasSuffix(key, ".parquet") {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func decodeObject[T any](t *testing.T, store objectstore.Store, key string) *T {
+	t.Helper()
+	data, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	var v T
+	require.NoError(t, json.Unmarshal(data, &v))
+	return &v
+}
+
+func newTestWriter(store objectstore.Store, opts ...Option) (*Writer, *repository.InMemoryEventRepository) {
+	events := repository.NewInMemoryEventRepository()
+	w := NewWriter(events, store, opts...)
+	w.now = func() time.Time { return time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC) }
+	return w, events
+}
+
+// at returns the timestamp of the minute of 1 July 2024.
+func at(hour, minute int) int64 {
+	return time.Date(2024, 7, 1, hour, minute, 0, 0, time.UTC).UnixNano()
+}
+
+func TestWriter_Flush(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	w, events := newTestWriter(store, WithMaxFileEvents(2))
+
+	for _, ts := range []int64{at(12, 59), at(13, 1), at(13, 30), at(13, 2)} {
+		require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: ts}))
+	}
+	// events without timestamp are stamped with the current time
+	require.NoError(t, w.Record(ctx, &si.EventRecord{
+		Type:     si.EventRecord_REQUEST,
+		ObjectID: "pod-1",
+		Resource: &si.Resource{Resources: map[string]*si.Quantity{"vcore": {Value: 100}}},
+	}))
+	counts, err := events.Counts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, counts["APP-NONE"], "the events must be recorded in the wrapped repository")
+
+	require.NoError(t, w.flush(ctx))
+
+	// the three events of 13:00 are written to two files
+	files := store.files()
+	assert.Len(t, files, 4)
+	manifest := decodeObject[Manifest](t, store, "events/dt=2024-07-01/hour=13/_manifest.json")
+	assert.Equal(t, "dt=2024-07-01/hour=13", manifest.Partition)
+	require.Len(t, manifest.Files, 2)
+	assert.Equal(t, 2, manifest.Files[0].Rows)
+	assert.Equal(t, 1, manifest.Files[1].Rows)
+	assert.Equal(t, time.Date(2024, 7, 1, 13, 1, 0, 0, time.UTC), manifest.Files[0].MinTimestamp)
+	assert.Equal(t, time.Date(2024, 7, 1, 13, 30, 0, 0, time.UTC), manifest.Files[0].MaxTimestamp)
+	file := readParquet(t, store.objects[manifest.Files[1].Path])
+	assert.Equal(t, []any{at(13, 2)}, file.values["timestamp_nano"])
+	assert.Equal(t, len(store.objects[manifest.Files[1].Path]), manifest.Files[1].Bytes)
+
+	manifest = decodeObject[Manifest](t, store, "events/dt=2024-07-01/hour=14/_manifest.json")
+	require.Len(t, manifest.Files, 1)
+	file = readParquet(t, store.objects[manifest.Files[0].Path])
+	assert.Equal(t, []any{"REQUEST"}, file.values["type"])
+	assert.Equal(t, []any{`{"vcore":100}`}, file.values["resource"])
+
+	index := decodeObject[Index](t, store, indexKey)
+	require.Len(t, index.Partitions, 3)
+	assert.Equal(t, "dt=2024-07-01/hour=12", index.Partitions[0].Partition)
+	assert.Equal(t, "events/dt=2024-07-01/hour=13/_manifest.json", index.Partitions[1].Manifest)
+	assert.Equal(t, 2, index.Partitions[1].Files)
+	assert.Equal(t, 3, index.Partitions[1].Rows)
+
+	// the files of later flushes are added to the manifests and the index
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node-1", TimestampNano: at(13, 45)}))
+	require.NoError(t, w.flush(ctx))
+	assert.Len(t, store.files(), 5)
+	manifest = decodeObject[Manifest](t, store, "events/dt=2024-07-01/hour=13/_manifest.json")
+	assert.Len(t, manifest.Files, 3)
+	index = decodeObject[Index](t, store, indexKey)
+	require.Len(t, index.Partitions, 3)
+	assert.Equal(t, 4, index.Partitions[1].Rows)
+	assert.Equal(t, time.Date(2024, 7, 1, 13, 45, 0, 0, time.UTC), index.Partitions[1].MaxTimestamp)
+
+	// nothing is written without events
+	require.NoError(t, w.flush(ctx))
+	assert.Len(t, store.files(), 5)
+}
+
+func TestWriter_FlushFailures(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	w, _ := newTestWriter(store, WithMaxFileEvents(2), WithMaxBufferedEvents(3))
+
+	// the events of files which could not be written are buffered again, and new events are dropped once it is full
+	store.setFailing(".parquet")
+	for minute := 0; minute < 3; minute++ {
+		require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, minute)}))
+	}
+	require.Error(t, w.flush(ctx))
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 3)}))
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 4)}))
+	assert.Len(t, w.buffer, 3)
+	assert.Equal(t, 2, w.dropped)
+	assert.Empty(t, store.objects)
+
+	// files whose manifest could not be updated are added to it on the next flush
+	store.setFailing("_manifest.json")
+	require.Error(t, w.flush(ctx))
+	assert.Len(t, store.files(), 2)
+	assert.Empty(t, w.buffer)
+	_, err := store.Get(ctx, indexKey)
+	assert.ErrorIs(t, err, objectstore.ErrNotFound)
+
+	store.setFailing()
+	require.NoError(t, w.flush(ctx))
+	assert.Len(t, store.files(), 2, "files must not be written again")
+	manifest := decodeObject[Manifest](t, store, "events/dt=2024-07-01/hour=13/_manifest.json")
+	require.Len(t, manifest.Files, 2)
+	index := decodeObject[Index](t, store, indexKey)
+	require.Len(t, index.Partitions, 1)
+	assert.Equal(t, 3, index.Partitions[0].Rows)
+	assert.Equal(t, time.Date(2024, 7, 1, 13, 2, 0, 0, time.UTC), index.Partitions[0].MaxTimestamp)
+}
+
+func TestWriter_Run(t *testing.T) {
+	store, err := objectstore.New(context.Background(), &config.ObjectStorageConfig{URL: "file://" + t.TempDir() + "/lake"})
+	require.NoError(t, err)
+	w, _ := newTestWriter(store, WithFlushInterval(time.Hour), WithMaxFileEvents(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	// the events are written once a file of events is buffered
+	for minute := 0; minute < 2; minute++ {
+		require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, minute)}))
+	}
+	assert.Eventually(t, func() bool {
+		index, err := store.Get(context.Background(), indexKey)
+		return err == nil && strings.Contains(string(index), `"rows": 2`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the buffered events are written on shutdown
+	require.NoError(t, w.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1", TimestampNano: at(13, 2)}))
+	cancel()
+	require.NoError(t, <-done)
+	manifest := decodeObject[Manifest](t, store, "events/dt=2024-07-01/hour=13/_manifest.json")
+	require.Len(t, manifest.Files, 2)
+	data, err := store.Get(context.Background(), manifest.Files[1].Path)
+	require.NoError(t, err)
+	assert.Equal(t, []any{at(13, 2)}, readParquet(t, data).values["timestamp_nano"])
+}
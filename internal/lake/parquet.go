@@ -0,0 +1,309 @@
+package lake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Values of the enums of the Parquet format.
+const (
+	parquetInt64     int32 = 2
+	parquetByteArray int32 = 6
+
+	repetitionRequired int32 = 0
+	repetitionOptional int32 = 1
+
+	convertedUTF8            int32 = 0
+	convertedTimestampMicros int32 = 10
+
+	encodingPlain int32 = 0
+	encodingRLE   int32 = 3
+
+	codecGzip int32 = 2
+
+	pageTypeData int32 = 0
+)
+
+// parquetMagic starts and ends Parquet files.
+var parquetMagic = []byte("PAR1")
+
+// createdBy is the application recorded as the writer of the files.
+const createdBy = "yunikorn-history-server"
+
+// maxPageSize is the maximum size of the data page of a column, as the sizes of pages are 32-bit integers.
+const maxPageSize = math.MaxInt32
+
+// column is a column of the files, whose values are taken from the events. The values of optional columns are null
+// for empty strings.
+type column struct {
+	name      string
+	kind      int32
+	optional  bool
+	timestamp bool
+	int64Of   func(*Event) int64
+	stringOf  func(*Event) string
+}
+
+// deltaType returns the type of the column in the schema of Delta Lake tables.
+func (c column) deltaType() string {
+	switch {
+	case c.timestamp:
+		return "timestamp"
+	case c.kind == parquetInt64:
+		return "long"
+	default:
+		return "string"
+	}
+}
+
+// columns are the columns of the files, in the order of the fields of the events.
+var columns = []column{
+	{name: "timestamp", kind: parquetInt64, timestamp: true, int64Of: func(e *Event) int64 { return e.TimestampNano / 1000 }},
+	{name: "timestamp_nano", kind: parquetInt64, int64Of: func(e *Event) int64 { return e.TimestampNano }},
+	{name: "type", kind: parquetByteArray, stringOf: func(e *Event) string { return e.Type }},
+	{name: "object_id", kind: parquetByteArray, stringOf: func(e *Event) string { return e.ObjectID }},
+	{name: "reference_id", kind: parquetByteArray, optional: true, stringOf: func(e *Event) string { return e.ReferenceID }},
+	{name: "change_type", kind: parquetByteArray, stringOf: func(e *Event) string { return e.ChangeType }},
+	{name: "change_detail", kind: parquetByteArray, stringOf: func(e *Event) string { return e.ChangeDetail }},
+	{name: "message", kind: parquetByteArray, optional: true, stringOf: func(e *Event) string { return e.Message }},
+	{name: "resource", kind: parquetByteArray, optional: true, stringOf: func(e *Event) string { return e.Resource }},
+}
+
+// chunk is the metadata of the column chunk of a column in the single row group of a file.
+type chunk struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+	numValues        int64
+	nullCount        int64
+	// optional is set if the page holds definition levels
+	optional bool
+	// minValue and maxValue are the statistics of integer columns
+	minValue, maxValue int64
+	hasMinMax          bool
+}
+
+// encodeParquet encodes the events as a Parquet file with a single row group, with one gzip compressed data page
+// per column. Timestamps are written as microseconds, which Spark and Trino read as timestamps, and additionally
+// as nanoseconds to keep the order of events within a microsecond.
+func encodeParquet(events []*Event) ([]byte, error) {
+	file := bytes.NewBuffer(nil)
+	file.Write(parquetMagic)
+
+	chunks := make([]chunk, len(columns))
+	for i, col := range columns {
+		body, c := encodeColumn(col, events)
+		if len(body) > maxPageSize {
+			return nil, fmt.Errorf("column %s of %d events exceeds the maximum page size", col.name, len(events))
+		}
+		compressed, err := gzipCompress(body)
+		if err != nil {
+			return nil, fmt.Errorf("could not compress column %s: %v", col.name, err)
+		}
+		header := pageHeader(len(events), len(body), len(compressed))
+		c.offset = int64(file.Len())
+		c.uncompressedSize = int64(len(header) + len(body))
+		c.compressedSize = int64(len(header) + len(compressed))
+		file.Write(header)
+		file.Write(compressed)
+		chunks[i] = c
+	}
+
+	footer := fileMetaData(int64(len(events)), chunks)
+	file.Write(footer)
+	file.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+	file.Write(parquetMagic)
+	return file.Bytes(), nil
+}
+
+// encodeColumn returns the body of the data page of the column, which holds the definition levels of optional
+// columns and the non-null values in the plain encoding.
+func encodeColumn(col column, events []*Event) ([]byte, chunk) {
+	c := chunk{numValues: int64(len(events)), optional: col.optional}
+	var values []byte
+	var defined []bool
+	for i, event := range events {
+		if col.kind == parquetInt64 {
+			v := col.int64Of(event)
+			values = binary.LittleEndian.AppendUint64(values, uint64(v))
+			if i == 0 || v < c.minValue {
+				c.minValue = v
+			}
+			if i == 0 || v > c.maxValue {
+				c.maxValue = v
+			}
+			c.hasMinMax = true
+			continue
+		}
+		v := col.stringOf(event)
+		if col.optional {
+			defined = append(defined, v != "")
+			if v == "" {
+				c.nullCount++
+				continue
+			}
+		}
+		values = binary.LittleEndian.AppendUint32(values, uint32(len(v)))
+		values = append(values, v...)
+	}
+	if !col.optional {
+		return values, c
+	}
+	levels := definitionLevels(defined)
+	body := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	body = append(body, levels...)
+	return append(body, values...), c
+}
+
+// definitionLevels encodes the definition levels, which are 1 for values and 0 for nulls, with the RLE/bit-packing
+// hybrid encoding of bit width 1, as runs of equal levels.
+func definitionLevels(defined []bool) []byte {
+	var levels []byte
+	for start := 0; start < len(defined); {
+		end := start + 1
+		for end < len(defined) && defined[end] == defined[start] {
+			end++
+		}
+		levels = binary.AppendUvarint(levels, uint64(end-start)<<1)
+		if defined[start] {
+			levels = append(levels, 1)
+		} else {
+			levels = append(levels, 0)
+		}
+		start = end
+	}
+	return levels
+}
+
+func gzipCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pageHeader encodes the header of a data page of the values.
+func pageHeader(numValues, uncompressedSize, compressedSize int) []byte {
+	w := &thriftWriter{}
+	w.beginStruct()
+	w.i32Field(1, pageTypeData)
+	w.i32Field(2, int32(uncompressedSize))
+	w.i32Field(3, int32(compressedSize))
+	w.structField(5)
+	w.i32Field(1, int32(numValues))
+	w.i32Field(2, encodingPlain)
+	w.i32Field(3, encodingRLE)
+	w.i32Field(4, encodingRLE)
+	w.endStruct()
+	w.endStruct()
+	return w.bytes()
+}
+
+// fileMetaData encodes the footer of a file of the rows, with the schema and the metadata of the column chunks.
+func fileMetaData(numRows int64, chunks []chunk) []byte {
+	w := &thriftWriter{}
+	w.beginStruct()
+	w.i32Field(1, 1)
+
+	w.listField(2, thriftStruct, len(columns)+1)
+	w.beginStruct()
+	w.stringField(4, "schema")
+	w.i32Field(5, int32(len(columns)))
+	w.endStruct()
+	for _, col := range columns {
+		w.beginStruct()
+		w.i32Field(1, col.kind)
+		if col.optional {
+			w.i32Field(3, repetitionOptional)
+		} else {
+			w.i32Field(3, repetitionRequired)
+		}
+		w.stringField(4, col.name)
+		switch {
+		case col.timestamp:
+			w.i32Field(6, convertedTimestampMicros)
+			w.structField(10)
+			w.structField(8)
+			w.boolField(1, true)
+			w.structField(2)
+			w.emptyStructField(2)
+			w.endStruct()
+			w.endStruct()
+			w.endStruct()
+		case col.kind == parquetByteArray:
+			w.i32Field(6, convertedUTF8)
+			w.structField(10)
+			w.emptyStructField(1)
+			w.endStruct()
+		}
+		w.endStruct()
+	}
+
+	w.i64Field(3, numRows)
+
+	var totalSize, compressedSize int64
+	for _, c := range chunks {
+		totalSize += c.uncompressedSize
+		compressedSize += c.compressedSize
+	}
+	w.listField(4, thriftStruct, 1)
+	w.beginStruct()
+	w.listField(1, thriftStruct, len(chunks))
+	for i, c := range chunks {
+		w.beginStruct()
+		w.i64Field(2, c.offset)
+		w.structField(3)
+		w.i32Field(1, columns[i].kind)
+		if c.optional {
+			w.listField(2, thriftI32, 2)
+			w.varint(int64(encodingPlain))
+			w.varint(int64(encodingRLE))
+		} else {
+			w.listField(2, thriftI32, 1)
+			w.varint(int64(encodingPlain))
+		}
+		w.listField(3, thriftBinary, 1)
+		w.binary([]byte(columns[i].name))
+		w.i32Field(4, codecGzip)
+		w.i64Field(5, c.numValues)
+		w.i64Field(6, c.uncompressedSize)
+		w.i64Field(7, c.compressedSize)
+		w.i64Field(9, c.offset)
+		w.structField(12)
+		w.i64Field(3, c.nullCount)
+		if c.hasMinMax {
+			w.binaryField(5, binary.LittleEndian.AppendUint64(nil, uint64(c.maxValue)))
+			w.binaryField(6, binary.LittleEndian.AppendUint64(nil, uint64(c.minValue)))
+		}
+		w.endStruct()
+		w.endStruct()
+		w.endStruct()
+	}
+	w.i64Field(2, totalSize)
+	w.i64Field(3, numRows)
+	if len(chunks) > 0 {
+		w.i64Field(5, chunks[0].offset)
+	}
+	w.i64Field(6, compressedSize)
+	w.endStruct()
+
+	w.stringField(6, createdBy)
+
+	// the statistics are ordered by the order defined by the type of the columns
+	w.listField(7, thriftStruct, len(columns))
+	for range columns {
+		w.beginStruct()
+		w.emptyStructField(1)
+		w.endStruct()
+	}
+	w.endStruct()
+	return w.bytes()
+}
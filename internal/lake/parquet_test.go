@@ -0,0 +1,263 @@
+package lake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes structs of the Thrift compact protocol as maps of their field IDs to their values, which are
+// int64 for integers, string for binaries, []any for lists and map[int16]any for structs.
+type thriftReader struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	require.Positive(r.t, n, "invalid varint at %d", r.pos)
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) readStruct() map[int16]any {
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		header := r.data[r.pos]
+		r.pos++
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		last = id
+		fields[id] = r.value(header & 0x0f)
+	}
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case thriftBoolTrue:
+		return true
+	case thriftBoolFalse:
+		return false
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.uvarint())
+		v := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return v
+	case thriftList:
+		header := r.data[r.pos]
+		r.pos++
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	default:
+		r.t.Fatalf("unsupported thrift type %d at %d", typ, r.pos)
+		return nil
+	}
+}
+
+// parquetFile is a file decoded by readParquet.
+type parquetFile struct {
+	metadata map[int16]any
+	// values are the values of the columns by name, which are int64, string or nil for nulls
+	values map[string][]any
+}
+
+// readParquet decodes a file written by encodeParquet.
+func readParquet(t *testing.T, data []byte) *parquetFile {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(data, parquetMagic))
+	require.True(t, bytes.HasSuffix(data, parquetMagic))
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerSize
+	footer := &thriftReader{t: t, data: data[:len(data)-8], pos: footerStart}
+	file := &parquetFile{metadata: footer.readStruct(), values: make(map[string][]any)}
+	require.Equal(t, len(data)-8, footer.pos, "the footer must be decoded entirely")
+
+	schema := file.metadata[2].([]any)
+	numRows := int(file.metadata[3].(int64))
+	rowGroups := file.metadata[4].([]any)
+	require.Len(t, rowGroups, 1)
+	chunks := rowGroups[0].(map[int16]any)[1].([]any)
+	require.Len(t, chunks, len(schema)-1)
+	for i, c := range chunks {
+		element := schema[i+1].(map[int16]any)
+		meta := c.(map[int16]any)[3].(map[int16]any)
+		name := meta[3].([]any)[0].(string)
+		require.Equal(t, element[4], name)
+
+		page := &thriftReader{t: t, data: data, pos: int(meta[9].(int64))}
+		header := page.readStruct()
+		compressedSize := int(header[3].(int64))
+		assert.Equal(t, meta[7], int64(page.pos-int(meta[9].(int64))+compressedSize))
+		zr, err := gzip.NewReader(bytes.NewReader(data[page.pos : page.pos+compressedSize]))
+		require.NoError(t, err)
+		body, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.Equal(t, header[2], int64(len(body)))
+
+		defined := make([]bool, numRows)
+		for j := range defined {
+			defined[j] = true
+		}
+		if element[3] == int64(repetitionOptional) {
+			levelsSize := int(binary.LittleEndian.Uint32(body))
+			levels := &thriftReader{t: t, data: body[4 : 4+levelsSize]}
+			for j := 0; j < numRows; {
+				run := int(levels.uvarint())
+				require.Zero(t, run&1, "definition levels must be encoded as runs")
+				level := levels.data[levels.pos]
+				levels.pos++
+				for k := 0; k < run>>1; k++ {
+					defined[j] = level == 1
+					j++
+				}
+			}
+			body = body[4+levelsSize:]
+		}
+		for _, isDefined := range defined {
+			if !isDefined {
+				file.values[name] = append(file.values[name], nil)
+				continue
+			}
+			if element[1] == int64(parquetInt64) {
+				file.values[name] = append(file.values[name], int64(binary.LittleEndian.Uint64(body)))
+				body = body[8:]
+				continue
+			}
+			n := int(binary.LittleEndian.Uint32(body))
+			file.values[name] = append(file.values[name], string(body[4:4+n]))
+			body = body[4+n:]
+		}
+		require.Empty(t, body, "column %s must be decoded entirely", name)
+	}
+	return file
+}
+
+func TestEncodeParquet(t *testing.T) {
+	events := []*Event{
+		{
+			TimestampNano: 1719838800123456789,
+			Type:          "REQUEST",
+			ObjectID:      "pod-1",
+			ReferenceID:   "app-1",
+			ChangeType:    "NONE",
+			ChangeDetail:  "DETAILS_NONE",
+			Message:       "predicate failed",
+			Resource:      `{"memory":1024}`,
+		},
+		{
+			TimestampNano: 1719838800000000001,
+			Type:          "NODE",
+			ObjectID:      "node-1",
+			ChangeType:    "ADD",
+			ChangeDetail:  "NODE_ALLOC",
+		},
+		{
+			TimestampNano: 1719839000000000000,
+			Type:          "APP",
+			ObjectID:      "app-1",
+			ChangeType:    "SET",
+			ChangeDetail:  "APP_RUNNING",
+			Message:       "running",
+		},
+	}
+
+	data, err := encodeParquet(events)
+	require.NoError(t, err)
+	file := readParquet(t, data)
+
+	assert.Equal(t, int64(1), file.metadata[1])
+	assert.Equal(t, int64(3), file.metadata[3])
+	assert.Equal(t, createdBy, file.metadata[6])
+	assert.Equal(t, map[string][]any{
+		"timestamp":      {int64(1719838800123456), int64(1719838800000000), int64(1719839000000000)},
+		"timestamp_nano": {int64(1719838800123456789), int64(1719838800000000001), int64(1719839000000000000)},
+		"type":           {"REQUEST", "NODE", "APP"},
+		"object_id":      {"pod-1", "node-1", "app-1"},
+		"reference_id":   {"app-1", nil, nil},
+		"change_type":    {"NONE", "ADD", "SET"},
+		"change_detail":  {"DETAILS_NONE", "NODE_ALLOC", "APP_RUNNING"},
+		"message":        {"predicate failed", nil, "running"},
+		"resource":       {`{"memory":1024}`, nil, nil},
+	}, file.values)
+
+	schema := file.metadata[2].([]any)
+	require.Len(t, schema, len(columns)+1)
+	assert.Equal(t, map[int16]any{4: "schema", 5: int64(len(columns))}, schema[0])
+	// the timestamp is annotated as timestamp of microseconds adjusted to UTC, the strings as UTF-8
+	assert.Equal(t, map[int16]any{
+		1:  int64(parquetInt64),
+		3:  int64(repetitionRequired),
+		4:  "timestamp",
+		6:  int64(convertedTimestampMicros),
+		10: map[int16]any{8: map[int16]any{1: true, 2: map[int16]any{2: map[int16]any{}}}},
+	}, schema[1])
+	assert.Equal(t, map[int16]any{
+		1:  int64(parquetByteArray),
+		3:  int64(repetitionOptional),
+		4:  "message",
+		6:  int64(convertedUTF8),
+		10: map[int16]any{1: map[int16]any{}},
+	}, schema[8])
+
+	// the statistics of the timestamps allow readers to skip files
+	chunks := file.metadata[4].([]any)[0].(map[int16]any)[1].([]any)
+	stats := chunks[0].(map[int16]any)[3].(map[int16]any)[12].(map[int16]any)
+	assert.Equal(t, string(binary.LittleEndian.AppendUint64(nil, 1719838800000000)), stats[6])
+	assert.Equal(t, string(binary.LittleEndian.AppendUint64(nil, 1719839000000000)), stats[5])
+	assert.Len(t, file.metadata[7], len(columns))
+}
+
+func TestDefinitionLevels(t *testing.T) {
+	assert.Equal(t, []byte{4, 1, 2, 0, 18, 1}, definitionLevels([]bool{true, true, false, true, true, true, true, true, true, true, true, true}))
+	assert.Empty(t, definitionLevels(nil))
+}
+
+func TestThriftWriter_LongFields(t *testing.T) {
+	w := &thriftWriter{}
+	w.beginStruct()
+	w.i32Field(1, -1)
+	w.i64Field(20, 1<<40)
+	w.listField(21, thriftI32, 20)
+	for i := 0; i < 20; i++ {
+		w.varint(int64(i))
+	}
+	w.boolField(22, false)
+	w.endStruct()
+
+	r := &thriftReader{t: t, data: w.bytes()}
+	fields := r.readStruct()
+	assert.Equal(t, len(w.bytes()), r.pos)
+	assert.Equal(t, int64(-1), fields[1])
+	assert.Equal(t, int64(1<<40), fields[20])
+	assert.Len(t, fields[21], 20)
+	assert.Equal(t, int64(19), fields[21].([]any)[19])
+	assert.Equal(t, false, fields[22])
+}
@@ -0,0 +1,108 @@
+package lake
+
+import "encoding/binary"
+
+// Types of the Thrift compact protocol.
+const (
+	thriftBoolTrue  byte = 1
+	thriftBoolFalse byte = 2
+	thriftI32       byte = 5
+	thriftI64       byte = 6
+	thriftBinary    byte = 8
+	thriftList      byte = 9
+	thriftStruct    byte = 12
+)
+
+// thriftWriter encodes structs with the Thrift compact protocol, in which the metadata of Parquet files is encoded.
+// The fields of a struct must be written in the order of their IDs, between beginStruct and endStruct.
+type thriftWriter struct {
+	buf []byte
+	// lastIDs is the stack of the IDs of the last fields written of the structs being written, as the IDs of fields
+	// are encoded as delta to the previous field of their struct
+	lastIDs []int16
+}
+
+func (w *thriftWriter) bytes() []byte {
+	return w.buf
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+// varint appends the zigzag encoded varint of the value, in which integers are encoded.
+func (w *thriftWriter) varint(v int64) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(v<<1)^uint64(v>>63))
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) boolField(id int16, v bool) {
+	if v {
+		w.fieldHeader(id, thriftBoolTrue)
+	} else {
+		w.fieldHeader(id, thriftBoolFalse)
+	}
+}
+
+func (w *thriftWriter) binaryField(id int16, v []byte) {
+	w.fieldHeader(id, thriftBinary)
+	w.binary(v)
+}
+
+func (w *thriftWriter) stringField(id int16, v string) {
+	w.binaryField(id, []byte(v))
+}
+
+func (w *thriftWriter) binary(v []byte) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// listField writes the header of a list field of size elements of the type, which must be written next.
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elemType)
+	} else {
+		w.buf = append(w.buf, 0xf0|elemType)
+		w.buf = binary.AppendUvarint(w.buf, uint64(size))
+	}
+}
+
+// structField writes the header of a struct field, whose fields must be written next and then ended by endStruct.
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginStruct()
+}
+
+// beginStruct begins a struct which is not a field: an element of a list or the top-level struct.
+func (w *thriftWriter) beginStruct() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+// emptyStructField writes a struct field without fields, as the members of the unions of logical types.
+func (w *thriftWriter) emptyStructField(id int16) {
+	w.structField(id)
+	w.endStruct()
+}
@@ -0,0 +1,108 @@
+package links
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// funcs are the functions available to the URL templates, in addition to the text/template builtins such as urlquery.
+var funcs = template.FuncMap{
+	"pathescape": url.PathEscape,
+}
+
+// Application is the data the URL templates of application links are rendered with.
+type Application struct {
+	ApplicationID  string
+	Partition      string
+	QueueName      string
+	User           string
+	State          string
+	SubmissionTime time.Time
+	// FinishedTime is the time the application finished, or the time the link is rendered if it has not finished,
+	// so that it can bound the time range of log queries.
+	FinishedTime time.Time
+}
+
+// Renderer renders the configured links of applications. A nil Renderer renders no links.
+type Renderer struct {
+	applications []link
+	now          func() time.Time
+}
+
+type link struct {
+	name string
+	url  *template.Template
+}
+
+// New creates a Renderer from the configured link templates. It returns nil if no links are configured.
+func New(cfg *config.LinksConfig) (*Renderer, error) {
+	if len(cfg.Applications) == 0 {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	r := &Renderer{now: time.Now}
+	for _, l := range cfg.Applications {
+		tmpl, err := template.New(l.Name).Funcs(funcs).Option("missingkey=error").Parse(l.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url of application link %q: %v", l.Name, err)
+		}
+		r.applications = append(r.applications, link{name: l.Name, url: tmpl})
+	}
+	return r, nil
+}
+
+// Application returns the links of the application. Links which cannot be rendered, or which are not
+// http or https URLs, are left out and logged, so that a broken template does not fail the response.
+func (r *Renderer) Application(ctx context.Context, app *model.ApplicationDAOInfo) []model.ExternalLink {
+	if r == nil {
+		return nil
+	}
+	data := Application{
+		ApplicationID:  app.ApplicationID,
+		Partition:      app.Partition,
+		QueueName:      app.QueueName,
+		User:           app.User,
+		State:          app.State,
+		SubmissionTime: time.Unix(0, app.SubmissionTime).UTC(),
+		FinishedTime:   r.now().UTC(),
+	}
+	if app.FinishedTime != nil {
+		data.FinishedTime = time.Unix(0, *app.FinishedTime).UTC()
+	}
+	links := make([]model.ExternalLink, 0, len(r.applications))
+	for _, l := range r.applications {
+		u, err := render(l.url, data)
+		if err != nil {
+			log.FromContext(ctx).Warnw("could not render application link", "link", l.name, "applicationID", app.ApplicationID,
+				"error", err)
+			continue
+		}
+		links = append(links, model.ExternalLink{Name: l.name, URL: u})
+	}
+	return links
+}
+
+func render(tmpl *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	u, err := url.Parse(buf.String())
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("url %q is not an http or https url", buf.String())
+	}
+	return u.String(), nil
+}
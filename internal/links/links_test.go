@@ -0,0 +1,85 @@
+package links
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func testApplication() *model.ApplicationDAOInfo {
+	return &model.ApplicationDAOInfo{
+		ApplicationDAOInfo: dao.ApplicationDAOInfo{
+			ApplicationID:  "spark-app 1",
+			Partition:      "default",
+			QueueName:      "root.batch",
+			User:           "alice",
+			State:          "Completed",
+			SubmissionTime: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC).UnixNano(),
+			FinishedTime:   util.ToPtr(time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC).UnixNano()),
+		},
+	}
+}
+
+func TestRenderer_Application(t *testing.T) {
+	r, err := New(&config.LinksConfig{Applications: []config.LinkTemplate{
+		{Name: "logs", URL: "https://logs.example.com/explore?query={{ .ApplicationID | urlquery }}" +
+			"&from={{ .SubmissionTime.UnixMilli }}&to={{ .FinishedTime.UnixMilli }}"},
+		{Name: "spark", URL: "https://spark-history.example.com/history/{{ .ApplicationID | pathescape }}/jobs/"},
+	}})
+	require.NoError(t, err)
+
+	links := r.Application(context.Background(), testApplication())
+	assert.Equal(t, []model.ExternalLink{
+		{Name: "logs", URL: "https://logs.example.com/explore?query=spark-app+1&from=1719835200000&to=1719838800000"},
+		{Name: "spark", URL: "https://spark-history.example.com/history/spark-app%201/jobs/"},
+	}, links)
+}
+
+func TestRenderer_ApplicationNotFinished(t *testing.T) {
+	r, err := New(&config.LinksConfig{Applications: []config.LinkTemplate{
+		{Name: "logs", URL: "https://logs.example.com/?to={{ .FinishedTime.Unix }}"},
+	}})
+	require.NoError(t, err)
+	r.now = func() time.Time { return time.Unix(1719900000, 0) }
+
+	app := testApplication()
+	app.FinishedTime = nil
+	links := r.Application(context.Background(), app)
+	assert.Equal(t, []model.ExternalLink{{Name: "logs", URL: "https://logs.example.com/?to=1719900000"}}, links)
+}
+
+func TestRenderer_ApplicationSkipsInvalidLinks(t *testing.T) {
+	r, err := New(&config.LinksConfig{Applications: []config.LinkTemplate{
+		{Name: "script", URL: "javascript:alert({{ .ApplicationID }})"},
+		{Name: "missing", URL: "https://logs.example.com/{{ .Tags }}"},
+		{Name: "logs", URL: "https://logs.example.com/{{ .ApplicationID | pathescape }}"},
+	}})
+	require.NoError(t, err)
+
+	links := r.Application(context.Background(), testApplication())
+	assert.Equal(t, []model.ExternalLink{{Name: "logs", URL: "https://logs.example.com/spark-app%201"}}, links)
+}
+
+func TestNew(t *testing.T) {
+	r, err := New(&config.LinksConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, r)
+	assert.Nil(t, r.Application(context.Background(), testApplication()), "a nil renderer renders no links")
+
+	_, err = New(&config.LinksConfig{Applications: []config.LinkTemplate{{Name: "logs", URL: "https://{{ .ApplicationID"}}})
+	assert.Error(t, err)
+
+	_, err = New(&config.LinksConfig{Applications: []config.LinkTemplate{
+		{Name: "logs", URL: "https://a.example.com"},
+		{Name: "logs", URL: "https://b.example.com"},
+	}})
+	assert.Error(t, err)
+}
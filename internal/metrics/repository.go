@@ -0,0 +1,779 @@
+// Package metrics exposes Prometheus metrics of the operations of the repository.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+var repositoryOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "yhs",
+	Subsystem: "repository",
+	Name:      "operation_duration_seconds",
+	Help:      "Duration of the operations of the repository, by operation and outcome.",
+	Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+}, []string{"operation", "outcome"})
+
+// observe records the duration of the operation of the repository which started at start and failed with err, if any.
+func observe(operation string, start time.Time, err error) {
+	repositoryOperationDuration.WithLabelValues(operation, outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+// outcome returns the outcome label of an operation which failed with err, if any.
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// Repository records the duration of the operations of the wrapped repository, such as the latency of the inserts
+// of the ingestion and of the queries of the API.
+type Repository struct {
+	repo repository.Repository
+}
+
+// NewRepository wraps the repository, so that the duration of its operations is exposed as Prometheus metrics.
+func NewRepository(repo repository.Repository) repository.Repository {
+	return &Repository{repo: repo}
+}
+
+var _ repository.Repository = &Repository{}
+
+func (r *Repository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
+	start := time.Now()
+	err := r.repo.UpsertApplications(ctx, apps)
+	observe("UpsertApplications", start, err)
+	return err
+}
+
+func (r *Repository) GetAllApplications(ctx context.Context, filters repository.ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetAllApplications(ctx, filters)
+	observe("GetAllApplications", start, err)
+	return result, err
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetAppsPerPartitionPerQueue(ctx, partition, queue, filters)
+	observe("GetAppsPerPartitionPerQueue", start, err)
+	return result, err
+}
+
+func (r *Repository) StreamAppsPerPartitionPerQueue(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+	fn func(*model.ApplicationDAOInfo) error,
+) error {
+	start := time.Now()
+	err := r.repo.StreamAppsPerPartitionPerQueue(ctx, partition, queue, filters, fn)
+	observe("StreamAppsPerPartitionPerQueue", start, err)
+	return err
+}
+
+func (r *Repository) GetAppsPerPartitionPerQueuePage(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationDAOInfo, repository.PageInfo, error) {
+	start := time.Now()
+	result, info, err := r.repo.GetAppsPerPartitionPerQueuePage(ctx, partition, queue, filters)
+	observe("GetAppsPerPartitionPerQueuePage", start, err)
+	return result, info, err
+}
+
+func (r *Repository) GetApplicationSummaries(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.ApplicationFilters,
+) ([]*model.ApplicationSummary, error) {
+	start := time.Now()
+	result, err := r.repo.GetApplicationSummaries(ctx, partition, queue, filters)
+	observe("GetApplicationSummaries", start, err)
+	return result, err
+}
+
+func (r *Repository) CountFinishedApplications(ctx context.Context, partition, queue, state string, since time.Time) (int, error) {
+	start := time.Now()
+	result, err := r.repo.CountFinishedApplications(ctx, partition, queue, state, since)
+	observe("CountFinishedApplications", start, err)
+	return result, err
+}
+
+func (r *Repository) DeleteApplicationsFinishedBefore(
+	ctx context.Context,
+	partition, queue string,
+	before time.Time,
+	limit int,
+) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.DeleteApplicationsFinishedBefore(ctx, partition, queue, before, limit)
+	observe("DeleteApplicationsFinishedBefore", start, err)
+	return result, err
+}
+
+func (r *Repository) CountBulkDeleteApplications(
+	ctx context.Context,
+	filters repository.BulkDeleteFilters,
+) (int64, int64, error) {
+	start := time.Now()
+	deletable, held, err := r.repo.CountBulkDeleteApplications(ctx, filters)
+	observe("CountBulkDeleteApplications", start, err)
+	return deletable, held, err
+}
+
+func (r *Repository) BulkDeleteApplications(ctx context.Context, filters repository.BulkDeleteFilters, limit int) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.BulkDeleteApplications(ctx, filters, limit)
+	observe("BulkDeleteApplications", start, err)
+	return result, err
+}
+
+func (r *Repository) MoveApplicationsToColdTier(ctx context.Context, before time.Time, limit int) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.MoveApplicationsToColdTier(ctx, before, limit)
+	observe("MoveApplicationsToColdTier", start, err)
+	return result, err
+}
+
+func (r *Repository) UpdateHistory(
+	ctx context.Context,
+	apps []*dao.ApplicationHistoryDAOInfo,
+	containers []*dao.ContainerHistoryDAOInfo,
+) error {
+	start := time.Now()
+	err := r.repo.UpdateHistory(ctx, apps, containers)
+	observe("UpdateHistory", start, err)
+	return err
+}
+
+func (r *Repository) DeleteHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.DeleteHistoryBefore(ctx, before, limit)
+	observe("DeleteHistoryBefore", start, err)
+	return result, err
+}
+
+func (r *Repository) GetApplicationsHistory(ctx context.Context) ([]*dao.ApplicationHistoryDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetApplicationsHistory(ctx)
+	observe("GetApplicationsHistory", start, err)
+	return result, err
+}
+
+func (r *Repository) GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetContainersHistory(ctx)
+	observe("GetContainersHistory", start, err)
+	return result, err
+}
+
+func (r *Repository) GetApplicationsHistoryPage(
+	ctx context.Context, page repository.Page) ([]*dao.ApplicationHistoryDAOInfo, repository.PageInfo, error) {
+	start := time.Now()
+	result, info, err := r.repo.GetApplicationsHistoryPage(ctx, page)
+	observe("GetApplicationsHistoryPage", start, err)
+	return result, info, err
+}
+
+func (r *Repository) GetContainersHistoryPage(
+	ctx context.Context, page repository.Page) ([]*dao.ContainerHistoryDAOInfo, repository.PageInfo, error) {
+	start := time.Now()
+	result, info, err := r.repo.GetContainersHistoryPage(ctx, page)
+	observe("GetContainersHistoryPage", start, err)
+	return result, info, err
+}
+
+func (r *Repository) StreamApplicationsHistory(ctx context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error {
+	start := time.Now()
+	err := r.repo.StreamApplicationsHistory(ctx, fn)
+	observe("StreamApplicationsHistory", start, err)
+	return err
+}
+
+func (r *Repository) StreamContainersHistory(ctx context.Context, fn func(*dao.ContainerHistoryDAOInfo) error) error {
+	start := time.Now()
+	err := r.repo.StreamContainersHistory(ctx, fn)
+	observe("StreamContainersHistory", start, err)
+	return err
+}
+
+func (r *Repository) UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
+	start := time.Now()
+	err := r.repo.UpsertNodes(ctx, nodes, partition)
+	observe("UpsertNodes", start, err)
+	return err
+}
+
+func (r *Repository) InsertNodeUtilizations(
+	ctx context.Context,
+	uuid uuid.UUID,
+	partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo,
+) error {
+	start := time.Now()
+	err := r.repo.InsertNodeUtilizations(ctx, uuid, partitionNodesUtil)
+	observe("InsertNodeUtilizations", start, err)
+	return err
+}
+
+func (r *Repository) GetNodeUtilizations(ctx context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetNodeUtilizations(ctx)
+	observe("GetNodeUtilizations", start, err)
+	return result, err
+}
+
+func (r *Repository) GetNodesPerPartition(ctx context.Context, partition string) ([]*dao.NodeDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetNodesPerPartition(ctx, partition)
+	observe("GetNodesPerPartition", start, err)
+	return result, err
+}
+
+func (r *Repository) GetNodesPerPartitionPage(
+	ctx context.Context, partition string, page repository.Page) ([]*dao.NodeDAOInfo, repository.PageInfo, error) {
+	start := time.Now()
+	result, info, err := r.repo.GetNodesPerPartitionPage(ctx, partition, page)
+	observe("GetNodesPerPartitionPage", start, err)
+	return result, info, err
+}
+
+func (r *Repository) GetNode(ctx context.Context, nodeID string) (*model.NodeDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetNode(ctx, nodeID)
+	observe("GetNode", start, err)
+	return result, err
+}
+
+func (r *Repository) GetNodeTopologies(ctx context.Context, partition string) ([]*model.NodeTopology, error) {
+	start := time.Now()
+	result, err := r.repo.GetNodeTopologies(ctx, partition)
+	observe("GetNodeTopologies", start, err)
+	return result, err
+}
+
+func (r *Repository) RecordNodeTermination(
+	ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+) (*model.NodeTermination, error) {
+	start := time.Now()
+	result, err := r.repo.RecordNodeTermination(ctx, nodeID, terminatedAt, spotSelectors)
+	observe("RecordNodeTermination", start, err)
+	return result, err
+}
+
+func (r *Repository) GetNodeTerminations(
+	ctx context.Context,
+	filters repository.NodeTerminationFilters,
+) ([]*model.NodeTermination, error) {
+	start := time.Now()
+	result, err := r.repo.GetNodeTerminations(ctx, filters)
+	observe("GetNodeTerminations", start, err)
+	return result, err
+}
+
+func (r *Repository) AddAutoscalerEvents(ctx context.Context, events []*model.AutoscalerEvent) (int, error) {
+	start := time.Now()
+	result, err := r.repo.AddAutoscalerEvents(ctx, events)
+	observe("AddAutoscalerEvents", start, err)
+	return result, err
+}
+
+func (r *Repository) GetAutoscalerEvents(
+	ctx context.Context,
+	filters repository.AutoscalerEventFilters,
+) ([]*model.AutoscalerEvent, error) {
+	start := time.Now()
+	result, err := r.repo.GetAutoscalerEvents(ctx, filters)
+	observe("GetAutoscalerEvents", start, err)
+	return result, err
+}
+
+func (r *Repository) GetPendingBacklog(
+	ctx context.Context,
+	filters repository.PendingBacklogFilters,
+) ([]*model.PendingBacklogSample, error) {
+	start := time.Now()
+	result, err := r.repo.GetPendingBacklog(ctx, filters)
+	observe("GetPendingBacklog", start, err)
+	return result, err
+}
+
+func (r *Repository) UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error {
+	start := time.Now()
+	err := r.repo.UpsertPartitions(ctx, partitions)
+	observe("UpsertPartitions", start, err)
+	return err
+}
+
+func (r *Repository) GetAllPartitions(ctx context.Context) ([]*dao.PartitionInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetAllPartitions(ctx)
+	observe("GetAllPartitions", start, err)
+	return result, err
+}
+
+func (r *Repository) AddQueues(ctx context.Context, parentId *string, queues []*dao.PartitionQueueDAOInfo) error {
+	start := time.Now()
+	err := r.repo.AddQueues(ctx, parentId, queues)
+	observe("AddQueues", start, err)
+	return err
+}
+
+func (r *Repository) UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	start := time.Now()
+	err := r.repo.UpsertQueues(ctx, queues)
+	observe("UpsertQueues", start, err)
+	return err
+}
+
+func (r *Repository) GetAllQueues(ctx context.Context) ([]*model.PartitionQueueDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetAllQueues(ctx)
+	observe("GetAllQueues", start, err)
+	return result, err
+}
+
+func (r *Repository) GetQueuesPerPartition(ctx context.Context, partition string) ([]*model.PartitionQueueDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetQueuesPerPartition(ctx, partition)
+	observe("GetQueuesPerPartition", start, err)
+	return result, err
+}
+
+func (r *Repository) GetQueue(ctx context.Context, partition, queueName string) (*model.PartitionQueueDAOInfo, error) {
+	start := time.Now()
+	result, err := r.repo.GetQueue(ctx, partition, queueName)
+	observe("GetQueue", start, err)
+	return result, err
+}
+
+func (r *Repository) DeleteQueues(ctx context.Context, queues []*model.PartitionQueueDAOInfo) error {
+	start := time.Now()
+	err := r.repo.DeleteQueues(ctx, queues)
+	observe("DeleteQueues", start, err)
+	return err
+}
+
+func (r *Repository) CreateLegalHold(ctx context.Context, hold *model.LegalHold) error {
+	start := time.Now()
+	err := r.repo.CreateLegalHold(ctx, hold)
+	observe("CreateLegalHold", start, err)
+	return err
+}
+
+func (r *Repository) GetLegalHolds(ctx context.Context, filters repository.LegalHoldFilters) ([]*model.LegalHold, error) {
+	start := time.Now()
+	result, err := r.repo.GetLegalHolds(ctx, filters)
+	observe("GetLegalHolds", start, err)
+	return result, err
+}
+
+func (r *Repository) GetLegalHold(ctx context.Context, id string) (*model.LegalHold, error) {
+	start := time.Now()
+	result, err := r.repo.GetLegalHold(ctx, id)
+	observe("GetLegalHold", start, err)
+	return result, err
+}
+
+func (r *Repository) ReleaseLegalHold(ctx context.Context, id, releasedBy, reason string, ifVersion *int64) (*model.LegalHold, error) {
+	start := time.Now()
+	result, err := r.repo.ReleaseLegalHold(ctx, id, releasedBy, reason, ifVersion)
+	observe("ReleaseLegalHold", start, err)
+	return result, err
+}
+
+func (r *Repository) AddQueueLineage(ctx context.Context, link *model.QueueLineage) error {
+	start := time.Now()
+	err := r.repo.AddQueueLineage(ctx, link)
+	observe("AddQueueLineage", start, err)
+	return err
+}
+
+func (r *Repository) GetQueueLineage(ctx context.Context, partition *string) ([]*model.QueueLineage, error) {
+	start := time.Now()
+	result, err := r.repo.GetQueueLineage(ctx, partition)
+	observe("GetQueueLineage", start, err)
+	return result, err
+}
+
+func (r *Repository) DeleteQueueLineage(ctx context.Context, id string) error {
+	start := time.Now()
+	err := r.repo.DeleteQueueLineage(ctx, id)
+	observe("DeleteQueueLineage", start, err)
+	return err
+}
+
+func (r *Repository) StartUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	start := time.Now()
+	err := r.repo.StartUserErasure(ctx, erasure)
+	observe("StartUserErasure", start, err)
+	return err
+}
+
+func (r *Repository) UpdateUserErasure(ctx context.Context, erasure *model.UserErasure) error {
+	start := time.Now()
+	err := r.repo.UpdateUserErasure(ctx, erasure)
+	observe("UpdateUserErasure", start, err)
+	return err
+}
+
+func (r *Repository) EraseUserApplications(ctx context.Context, user, pseudonym, mode string, limit int) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.EraseUserApplications(ctx, user, pseudonym, mode, limit)
+	observe("EraseUserApplications", start, err)
+	return result, err
+}
+
+func (r *Repository) CountUserApplications(ctx context.Context, user string) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.CountUserApplications(ctx, user)
+	observe("CountUserApplications", start, err)
+	return result, err
+}
+
+func (r *Repository) PseudonymizeAuditRecords(ctx context.Context, user, pseudonym string) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.PseudonymizeAuditRecords(ctx, user, pseudonym)
+	observe("PseudonymizeAuditRecords", start, err)
+	return result, err
+}
+
+func (r *Repository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	start := time.Now()
+	err := r.repo.UpdateQueueACLs(ctx, acls, at)
+	observe("UpdateQueueACLs", start, err)
+	return err
+}
+
+func (r *Repository) GetQueueACLs(
+	ctx context.Context,
+	partition, queue string,
+	filters repository.QueueACLFilters,
+) ([]*model.QueueACL, error) {
+	start := time.Now()
+	result, err := r.repo.GetQueueACLs(ctx, partition, queue, filters)
+	observe("GetQueueACLs", start, err)
+	return result, err
+}
+
+func (r *Repository) GetAllocationConstraints(
+	ctx context.Context,
+	partition, queue, appID string,
+) ([]*model.AllocationConstraints, error) {
+	start := time.Now()
+	result, err := r.repo.GetAllocationConstraints(ctx, partition, queue, appID)
+	observe("GetAllocationConstraints", start, err)
+	return result, err
+}
+
+func (r *Repository) AddTraceEvent(ctx context.Context, event *model.TraceEvent, maxEvents int) (bool, error) {
+	start := time.Now()
+	result, err := r.repo.AddTraceEvent(ctx, event, maxEvents)
+	observe("AddTraceEvent", start, err)
+	return result, err
+}
+
+func (r *Repository) GetTraceEvents(
+	ctx context.Context,
+	appID string,
+	filters repository.TraceEventFilters,
+) ([]*model.TraceEvent, error) {
+	start := time.Now()
+	result, err := r.repo.GetTraceEvents(ctx, appID, filters)
+	observe("GetTraceEvents", start, err)
+	return result, err
+}
+
+func (r *Repository) DeleteTraceEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.DeleteTraceEventsBefore(ctx, before)
+	observe("DeleteTraceEventsBefore", start, err)
+	return result, err
+}
+
+func (r *Repository) RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error) {
+	start := time.Now()
+	result, err := r.repo.RecordSchedulerEpoch(ctx, epoch)
+	observe("RecordSchedulerEpoch", start, err)
+	return result, err
+}
+
+func (r *Repository) GetSchedulerEpochs(
+	ctx context.Context,
+	filters repository.SchedulerEpochFilters,
+) ([]*model.SchedulerEpoch, error) {
+	start := time.Now()
+	result, err := r.repo.GetSchedulerEpochs(ctx, filters)
+	observe("GetSchedulerEpochs", start, err)
+	return result, err
+}
+
+func (r *Repository) GetNamespaceQueues(
+	ctx context.Context,
+	partition string,
+	filters repository.NamespaceQueueFilters,
+) ([]*model.NamespaceQueue, error) {
+	start := time.Now()
+	result, err := r.repo.GetNamespaceQueues(ctx, partition, filters)
+	observe("GetNamespaceQueues", start, err)
+	return result, err
+}
+
+func (r *Repository) QueryAnalytics(ctx context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+	start := time.Now()
+	result, err := r.repo.QueryAnalytics(ctx, query)
+	observe("QueryAnalytics", start, err)
+	return result, err
+}
+
+func (r *Repository) EstimateAnalytics(ctx context.Context, query repository.AnalyticsQuery) (*model.AnalyticsEstimate, error) {
+	start := time.Now()
+	result, err := r.repo.EstimateAnalytics(ctx, query)
+	observe("EstimateAnalytics", start, err)
+	return result, err
+}
+
+func (r *Repository) StartAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	start := time.Now()
+	result, err := r.repo.StartAnalyticsSink(ctx, name)
+	observe("StartAnalyticsSink", start, err)
+	return result, err
+}
+
+func (r *Repository) StopAnalyticsSink(ctx context.Context, name string) error {
+	start := time.Now()
+	err := r.repo.StopAnalyticsSink(ctx, name)
+	observe("StopAnalyticsSink", start, err)
+	return err
+}
+
+func (r *Repository) GetAnalyticsSink(ctx context.Context, name string) (*model.AnalyticsSink, error) {
+	start := time.Now()
+	result, err := r.repo.GetAnalyticsSink(ctx, name)
+	observe("GetAnalyticsSink", start, err)
+	return result, err
+}
+
+func (r *Repository) BackfillAnalyticsSink(
+	ctx context.Context,
+	name string,
+	limit int,
+	fn func([]*model.AnalyticsApplication) error,
+) (bool, error) {
+	start := time.Now()
+	result, err := r.repo.BackfillAnalyticsSink(ctx, name, limit, fn)
+	observe("BackfillAnalyticsSink", start, err)
+	return result, err
+}
+
+func (r *Repository) ConsumeAnalyticsChanges(
+	ctx context.Context,
+	limit int,
+	fn func([]*model.AnalyticsApplication) error,
+) (int, error) {
+	start := time.Now()
+	result, err := r.repo.ConsumeAnalyticsChanges(ctx, limit, fn)
+	observe("ConsumeAnalyticsChanges", start, err)
+	return result, err
+}
+
+func (r *Repository) GetQueueThroughput(
+	ctx context.Context,
+	filters repository.ThroughputFilters,
+) ([]*model.ThroughputBucket, error) {
+	start := time.Now()
+	result, err := r.repo.GetQueueThroughput(ctx, filters)
+	observe("GetQueueThroughput", start, err)
+	return result, err
+}
+
+func (r *Repository) GetDuplicateApplications(
+	ctx context.Context,
+	filters repository.DuplicateApplicationFilters,
+) ([]*model.DuplicateApplication, repository.PageInfo, error) {
+	start := time.Now()
+	result, info, err := r.repo.GetDuplicateApplications(ctx, filters)
+	observe("GetDuplicateApplications", start, err)
+	return result, info, err
+}
+
+func (r *Repository) GetTopUsage(ctx context.Context, filters repository.TopUsageFilters) ([]*model.TopUsage, error) {
+	start := time.Now()
+	result, err := r.repo.GetTopUsage(ctx, filters)
+	observe("GetTopUsage", start, err)
+	return result, err
+}
+
+func (r *Repository) GetHourlyUsage(
+	ctx context.Context,
+	filters repository.HourlyUsageFilters,
+) ([]*model.HourlyUsage, error) {
+	start := time.Now()
+	result, err := r.repo.GetHourlyUsage(ctx, filters)
+	observe("GetHourlyUsage", start, err)
+	return result, err
+}
+
+func (r *Repository) GetPrincipals(
+	ctx context.Context,
+	kind repository.PrincipalKind,
+	filters repository.PrincipalFilters,
+) ([]*model.Principal, error) {
+	start := time.Now()
+	result, err := r.repo.GetPrincipals(ctx, kind, filters)
+	observe("GetPrincipals", start, err)
+	return result, err
+}
+
+func (r *Repository) GetQueueWaitPhases(
+	ctx context.Context, filters repository.WaitPhaseFilters) ([]*model.QueueWaitPhases, error) {
+	start := time.Now()
+	result, err := r.repo.GetQueueWaitPhases(ctx, filters)
+	observe("GetQueueWaitPhases", start, err)
+	return result, err
+}
+
+func (r *Repository) CheckDataQuality(ctx context.Context, check repository.DataQualityCheck, at time.Time) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.CheckDataQuality(ctx, check, at)
+	observe("CheckDataQuality", start, err)
+	return result, err
+}
+
+func (r *Repository) GetDataQualityChecks(ctx context.Context) ([]*model.DataQualityCheck, error) {
+	start := time.Now()
+	result, err := r.repo.GetDataQualityChecks(ctx)
+	observe("GetDataQualityChecks", start, err)
+	return result, err
+}
+
+func (r *Repository) GetDataQualityViolations(
+	ctx context.Context,
+	filters repository.DataQualityFilters,
+) ([]*model.DataQualityViolation, error) {
+	start := time.Now()
+	result, err := r.repo.GetDataQualityViolations(ctx, filters)
+	observe("GetDataQualityViolations", start, err)
+	return result, err
+}
+
+func (r *Repository) SyncConfigSLOs(ctx context.Context, slos []*model.QueueSLO) error {
+	start := time.Now()
+	err := r.repo.SyncConfigSLOs(ctx, slos)
+	observe("SyncConfigSLOs", start, err)
+	return err
+}
+
+func (r *Repository) PutSLO(ctx context.Context, slo *model.QueueSLO, ifVersion *int64) error {
+	start := time.Now()
+	err := r.repo.PutSLO(ctx, slo, ifVersion)
+	observe("PutSLO", start, err)
+	return err
+}
+
+func (r *Repository) DeleteSLO(ctx context.Context, name string, ifVersion *int64) error {
+	start := time.Now()
+	err := r.repo.DeleteSLO(ctx, name, ifVersion)
+	observe("DeleteSLO", start, err)
+	return err
+}
+
+func (r *Repository) GetSLOs(ctx context.Context) ([]*model.QueueSLO, error) {
+	start := time.Now()
+	result, err := r.repo.GetSLOs(ctx)
+	observe("GetSLOs", start, err)
+	return result, err
+}
+
+func (r *Repository) EvaluateSLO(ctx context.Context, slo *model.QueueSLO, at time.Time) (*model.SLOEvaluation, error) {
+	start := time.Now()
+	result, err := r.repo.EvaluateSLO(ctx, slo, at)
+	observe("EvaluateSLO", start, err)
+	return result, err
+}
+
+func (r *Repository) GetSLOEvaluations(
+	ctx context.Context,
+	filters repository.SLOEvaluationFilters,
+) ([]*model.SLOEvaluation, error) {
+	start := time.Now()
+	result, err := r.repo.GetSLOEvaluations(ctx, filters)
+	observe("GetSLOEvaluations", start, err)
+	return result, err
+}
+
+func (r *Repository) DeleteSLOEvaluationsBefore(ctx context.Context, before time.Time) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.DeleteSLOEvaluationsBefore(ctx, before)
+	observe("DeleteSLOEvaluationsBefore", start, err)
+	return result, err
+}
+
+func (r *Repository) RegisterCluster(ctx context.Context, cluster *model.Cluster) error {
+	start := time.Now()
+	err := r.repo.RegisterCluster(ctx, cluster)
+	observe("RegisterCluster", start, err)
+	return err
+}
+
+func (r *Repository) HeartbeatCluster(ctx context.Context, name string, ingestedUntil *time.Time) (*model.Cluster, error) {
+	start := time.Now()
+	result, err := r.repo.HeartbeatCluster(ctx, name, ingestedUntil)
+	observe("HeartbeatCluster", start, err)
+	return result, err
+}
+
+func (r *Repository) GetClusters(ctx context.Context) ([]*model.Cluster, error) {
+	start := time.Now()
+	result, err := r.repo.GetClusters(ctx)
+	observe("GetClusters", start, err)
+	return result, err
+}
+
+func (r *Repository) PauseOperation(ctx context.Context, op *model.PausedOperation) error {
+	start := time.Now()
+	err := r.repo.PauseOperation(ctx, op)
+	observe("PauseOperation", start, err)
+	return err
+}
+
+func (r *Repository) ResumeOperation(ctx context.Context, name string) error {
+	start := time.Now()
+	err := r.repo.ResumeOperation(ctx, name)
+	observe("ResumeOperation", start, err)
+	return err
+}
+
+func (r *Repository) GetPausedOperations(ctx context.Context) ([]*model.PausedOperation, error) {
+	start := time.Now()
+	result, err := r.repo.GetPausedOperations(ctx)
+	observe("GetPausedOperations", start, err)
+	return result, err
+}
+
+// ApplyIngestBatch records the duration of the batch as one operation, including the writes of the batch.
+func (r *Repository) ApplyIngestBatch(
+	ctx context.Context,
+	source string,
+	sequence int64,
+	fn func(ctx context.Context, tx repository.Repository) error,
+) (int64, error) {
+	start := time.Now()
+	result, err := r.repo.ApplyIngestBatch(ctx, source, sequence, fn)
+	observe("ApplyIngestBatch", start, err)
+	return result, err
+}
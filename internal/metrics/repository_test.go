@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+// sampleCount returns the number of operations observed with the labels.
+func sampleCount(t *testing.T, operation, outcome string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, repositoryOperationDuration.WithLabelValues(operation, outcome).(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	mock := repository.NewMockRepository(mockCtrl)
+	repo := NewRepository(mock)
+
+	successes := sampleCount(t, "GetAllPartitions", "success")
+	failures := sampleCount(t, "UpsertApplications", "error")
+
+	mock.EXPECT().GetAllPartitions(ctx).Return(nil, nil)
+	_, err := repo.GetAllPartitions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, successes+1, sampleCount(t, "GetAllPartitions", "success"))
+
+	errInsert := errors.New("insert failed")
+	mock.EXPECT().UpsertApplications(ctx, nil).Return(errInsert)
+	assert.ErrorIs(t, repo.UpsertApplications(ctx, nil), errInsert)
+	assert.Equal(t, failures+1, sampleCount(t, "UpsertApplications", "error"))
+}
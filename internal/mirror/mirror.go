@@ -0,0 +1,167 @@
+// Package mirror mirrors a sample of the read requests of the API to a secondary deployment, e.g. a staging
+// deployment of a new release, to validate it against real traffic before promoting it. The requests are mirrored
+// asynchronously and their responses are ignored, so that the secondary deployment cannot slow down or fail the
+// requests of the clients.
+package mirror
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// HeaderMirrored marks the mirrored requests, which are not mirrored again, e.g. by a secondary deployment which
+// mirrors its requests too.
+const HeaderMirrored = "X-YHS-Mirrored"
+
+// senders is the number of mirrored requests which are sent concurrently.
+const senders = 4
+
+// hopHeaders are the headers of the connection of the client, which are not mirrored, and the Authorization header,
+// as the API keys of the clients are not those of the secondary deployment.
+var hopHeaders = []string{
+	"Authorization",
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+var (
+	mirroredRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "mirror",
+		Name:      "requests_total",
+		Help: "Number of mirrored requests by outcome: sent, failed if no response was received, or dropped " +
+			"if too many requests were pending.",
+	}, []string{"outcome"})
+)
+
+// Mirror mirrors a sample of the read requests to a secondary deployment.
+type Mirror struct {
+	target     *url.URL
+	apiKey     string
+	sampleRate float64
+	httpClient *http.Client
+	pending    chan *http.Request
+	// sample returns whether a request is mirrored
+	sample func() bool
+}
+
+// New returns a mirror of the requests to the deployment of the configuration, or nil if requests are not mirrored.
+// The configuration must be valid.
+func New(cfg *config.MirrorConfig) *Mirror {
+	if !cfg.Enabled() {
+		return nil
+	}
+	target, _ := url.Parse(strings.TrimSuffix(cfg.URL, "/"))
+	m := &Mirror{
+		target:     target,
+		apiKey:     cfg.APIKey,
+		sampleRate: cfg.SampleRate,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		pending:    make(chan *http.Request, cfg.MaxPending),
+	}
+	m.sample = func() bool {
+		return rand.Float64() < m.sampleRate
+	}
+	return m
+}
+
+// Handler mirrors a sample of the GET and HEAD requests to the API, except those of the admin routes, before
+// serving them with the next handler. The requests are queued, and dropped if too many are pending.
+func (m *Mirror) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mirrored(r) && m.sample() {
+			select {
+			case m.pending <- m.mirror(r):
+			default:
+				mirroredRequestsTotal.WithLabelValues("dropped").Inc()
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// mirrored returns whether the request may be mirrored.
+func mirrored(r *http.Request) bool {
+	return (r.Method == http.MethodGet || r.Method == http.MethodHead) &&
+		strings.HasPrefix(r.URL.Path, "/ws/") &&
+		!strings.HasPrefix(r.URL.Path, "/ws/v1/admin/") &&
+		r.Header.Get(HeaderMirrored) == ""
+}
+
+// mirror returns a copy of the request to the secondary deployment, with the headers of the request except those of
+// its connection and its API key.
+func (m *Mirror) mirror(r *http.Request) *http.Request {
+	u := *m.target
+	u.Path = m.target.Path + r.URL.Path
+	if r.URL.RawPath != "" {
+		u.RawPath = m.target.EscapedPath() + r.URL.RawPath
+	}
+	u.RawQuery = r.URL.RawQuery
+	mirror := &http.Request{
+		Method: r.Method,
+		URL:    &u,
+		Header: r.Header.Clone(),
+		Host:   u.Host,
+	}
+	for _, h := range hopHeaders {
+		mirror.Header.Del(h)
+	}
+	if m.apiKey != "" {
+		mirror.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+	mirror.Header.Set(HeaderMirrored, "true")
+	return mirror
+}
+
+// Run sends the mirrored requests until the context is cancelled. The requests pending then are dropped.
+func (m *Mirror) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "mirror")
+	ctx = log.ToContext(ctx, logger)
+	logger.Infow("mirroring requests", "url", m.target.String(), "sample_rate", m.sampleRate)
+
+	for i := 0; i < senders; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case r := <-m.pending:
+					m.send(ctx, r)
+				}
+			}
+		}()
+	}
+	<-ctx.Done()
+	logger.Warn("shutting down mirror")
+	return nil
+}
+
+// send sends the mirrored request and discards its response.
+func (m *Mirror) send(ctx context.Context, r *http.Request) {
+	resp, err := m.httpClient.Do(r.WithContext(ctx))
+	if err != nil {
+		mirroredRequestsTotal.WithLabelValues("failed").Inc()
+		log.FromContext(ctx).Debugf("could not mirror request %s %s: %v", r.Method, r.URL.Path, err)
+		return
+	}
+	// the body is read so that the connection is reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	mirroredRequestsTotal.WithLabelValues("sent").Inc()
+}
@@ -0,0 +1,99 @@
+package mirror
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, New(&config.MirrorConfig{}))
+	assert.NotNil(t, New(&config.MirrorConfig{URL: "https://yhs-staging.example.com", Timeout: time.Second, MaxPending: 1}))
+}
+
+func TestMirror(t *testing.T) {
+	received := make(chan *http.Request, 10)
+	staging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer staging.Close()
+
+	m := New(&config.MirrorConfig{
+		URL:        staging.URL + "/",
+		APIKey:     "staging-secret",
+		SampleRate: 1,
+		Timeout:    time.Second,
+		MaxPending: 10,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = m.Run(ctx)
+	}()
+
+	served := 0
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+		w.WriteHeader(http.StatusOK)
+	}))
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/ws/v1/ingest", nil),
+		httptest.NewRequest(http.MethodGet, "/ws/v1/admin/features", nil),
+		httptest.NewRequest(http.MethodGet, "/index.html", nil),
+		httptest.NewRequest(http.MethodGet, "/ws/v1/partitions", nil),
+	}
+	requests[3].Header.Set(HeaderMirrored, "true")
+	read := httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/queue/root.a%2Fb/applications?limit=10", nil)
+	read.Header.Set("Authorization", "Bearer client-secret")
+	read.Header.Set("Accept", "application/json")
+	requests = append(requests, read)
+	for _, r := range requests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	assert.Equal(t, len(requests), served)
+
+	// only the read request to the API is mirrored, with the API key of the staging deployment
+	select {
+	case r := <-received:
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/ws/v1/partition/default/queue/root.a%2Fb/applications", r.URL.EscapedPath())
+		assert.Equal(t, "limit=10", r.URL.RawQuery)
+		assert.Equal(t, "Bearer staging-secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, "true", r.Header.Get(HeaderMirrored))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the request was not mirrored")
+	}
+	select {
+	case r := <-received:
+		assert.Failf(t, "unexpected mirrored request", "%s %s", r.Method, r.URL)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestMirrorSample(t *testing.T) {
+	m := New(&config.MirrorConfig{URL: "https://yhs-staging.example.com", Timeout: time.Second, MaxPending: 2})
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// requests which are not sampled are not mirrored
+	m.sample = func() bool { return false }
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ws/v1/partitions", nil))
+	assert.Empty(t, m.pending)
+
+	// requests are dropped while too many are pending
+	m.sample = func() bool { return true }
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ws/v1/partitions", nil))
+	}
+	assert.Len(t, m.pending, 2)
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/apache/yunikorn-core/pkg/webservice/dao"
@@ -11,6 +12,91 @@ type ApplicationDAOInfo struct {
 	CreatedAt time.Time `json:"createdAt"`
 	QueueID   string    `json:"queueId"`
 	dao.ApplicationDAOInfo
+	// SparkApplicationID is the ID of the application in Spark, taken from the allocation tags of applications
+	// run by Spark. It correlates the application with the Spark History Server.
+	SparkApplicationID string `json:"sparkApplicationId,omitempty"`
+	// WorkflowID is the ID of the workflow run, such as an Airflow DAG run or an Argo workflow, which caused
+	// the application, taken from its allocation tags.
+	WorkflowID string `json:"workflowId,omitempty"`
+	// RetryKey identifies the job the application is an attempt of, taken from its allocation tags. The applications
+	// of a partition with the same retry key are attempts of the same job.
+	RetryKey string `json:"retryKey,omitempty"`
+	// RetryChain are the attempts of the job of the application in the order they were submitted, including
+	// the application. It is only set on the application detail, if the application has a retry key.
+	RetryChain []*RetryAttempt `json:"retryChain,omitempty"`
+	// Priority is the highest priority of the requests and allocations of the application, kept once they are
+	// released. It is nil if the application never had any.
+	Priority *int32 `json:"priority,omitempty"`
+	// PriorityClass is the priority class of the application, taken from its allocation tags if configured.
+	PriorityClass string `json:"priorityClass,omitempty"`
+	// WaitTime is the time in nanoseconds the application waited from its submission until it started running.
+	WaitTime *int64 `json:"waitTime,omitempty"`
+	// WaitPhases decompose the wait of the application until its first allocation, nil if none of its phases is
+	// known.
+	WaitPhases *WaitPhases `json:"waitPhases,omitempty"`
+	// Duration is the time in nanoseconds from the submission of the application until it finished.
+	Duration *int64 `json:"duration,omitempty"`
+	// ExternalLinks link the application to external systems, such as its logs. They are rendered from the
+	// configured templates when the application is returned and are not stored.
+	ExternalLinks []ExternalLink `json:"externalLinks,omitempty"`
+}
+
+// RetryAttempt is an attempt of a job in its retry chain, numbered from 1 in the order the attempts were submitted.
+// Times are in nanoseconds.
+type RetryAttempt struct {
+	Attempt        int    `json:"attempt"`
+	ApplicationID  string `json:"applicationID"`
+	QueueName      string `json:"queueName"`
+	State          string `json:"applicationState"`
+	SubmissionTime int64  `json:"submissionTime"`
+	FinishedTime   *int64 `json:"finishedTime,omitempty"`
+}
+
+// LiveApplications are the applications of a queue which the scheduler runs, merged with those which finished
+// since a recent time according to the history.
+type LiveApplications struct {
+	// Since is the time since which the finished applications are included.
+	Since time.Time `json:"since"`
+	// SchedulerAvailable tells whether the applications of the scheduler are included. If it is false, the
+	// applications are only those of the history, which may lag behind the scheduler.
+	SchedulerAvailable bool               `json:"schedulerAvailable"`
+	Applications       []*LiveApplication `json:"applications"`
+}
+
+// LiveApplication is an application of the live tail of a queue.
+type LiveApplication struct {
+	*ApplicationDAOInfo
+	// Source is where the application was taken from: scheduler, or history if the scheduler does not run it.
+	Source string `json:"source"`
+}
+
+// ApplicationSummary is the summary of an application which lists of applications are served from, without its
+// requests and allocations. Times and the duration from the submission until the application finished are in
+// nanoseconds. VcoreSeconds and MemorySeconds are the resources the application used, accumulated like the usage
+// rollups, which are final once it finished.
+type ApplicationSummary struct {
+	ID                 string           `json:"-" db:"application_id"`
+	ApplicationID      string           `json:"applicationID" db:"app_id"`
+	Partition          string           `json:"partition" db:"partition"`
+	QueueName          string           `json:"queueName" db:"queue_name"`
+	User               string           `json:"user" db:"user"`
+	Groups             []string         `json:"groups" db:"groups"`
+	State              string           `json:"applicationState" db:"state"`
+	SubmissionTime     int64            `json:"submissionTime" db:"submission_time"`
+	FinishedTime       *int64           `json:"finishedTime" db:"finished_time"`
+	Duration           *int64           `json:"duration" db:"duration"`
+	WaitTime           *int64           `json:"waitTime,omitempty" db:"wait_time"`
+	MaxUsedResource    map[string]int64 `json:"maxUsedResource" db:"max_used_resource"`
+	VcoreSeconds       float64          `json:"vcoreSeconds" db:"vcore_seconds"`
+	MemorySeconds      float64          `json:"memorySeconds" db:"memory_seconds"`
+	SparkApplicationID *string          `json:"sparkApplicationId,omitempty" db:"spark_app_id"`
+	WorkflowID         *string          `json:"workflowId,omitempty" db:"workflow_id"`
+}
+
+// ExternalLink is a named link to an external system.
+type ExternalLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 type PartitionQueueDAOInfo struct {
@@ -21,3 +107,654 @@ type PartitionQueueDAOInfo struct {
 	CreatedAt sql.NullInt64            `json:"createdAt,omitempty"`
 	DeletedAt sql.NullInt64            `json:"deletedAt,omitempty"`
 }
+
+// QueueLineage links the former path of a renamed or moved queue to its current path. The link covers the child
+// queues of the queue as well, e.g. renaming root.a to root.b links root.a.c to root.b.c.
+type QueueLineage struct {
+	ID        string  `json:"id" db:"id"`
+	Partition string  `json:"partition" db:"partition"`
+	FromQueue string  `json:"fromQueue" db:"from_queue"`
+	ToQueue   string  `json:"toQueue" db:"to_queue"`
+	Reason    *string `json:"reason,omitempty" db:"reason"`
+	CreatedBy string  `json:"createdBy" db:"created_by"`
+	CreatedAt int64   `json:"createdAt" db:"created_at"`
+}
+
+// LegalHold prevents the applications of a queue subtree, or a single application, from being pruned
+// until the hold is released. Released holds are kept so that holds can be audited.
+type LegalHold struct {
+	ID        string `json:"id" db:"id"`
+	Partition string `json:"partition" db:"partition"`
+	// QueueName is the root of the held queue subtree, if any.
+	QueueName *string `json:"queueName,omitempty" db:"queue_name"`
+	// ApplicationID is the ID of the held application, if any.
+	ApplicationID *string `json:"applicationId,omitempty" db:"app_id"`
+	Reason        string  `json:"reason" db:"reason"`
+	CreatedBy     string  `json:"createdBy" db:"created_by"`
+	CreatedAt     int64   `json:"createdAt" db:"created_at"`
+	ReleasedBy    *string `json:"releasedBy,omitempty" db:"released_by"`
+	ReleasedAt    *int64  `json:"releasedAt,omitempty" db:"released_at"`
+	ReleaseReason *string `json:"releaseReason,omitempty" db:"release_reason"`
+	// Version is incremented by every change of the hold, so that it is only released in the version a client read.
+	Version int64 `json:"version" db:"version"`
+}
+
+const (
+	// ErasureModePseudonymize replaces the user of the erased records with a pseudonym.
+	ErasureModePseudonymize = "pseudonymize"
+	// ErasureModeDelete deletes the applications of the erased user.
+	ErasureModeDelete = "delete"
+
+	ErasureStatusInProgress = "in_progress"
+	ErasureStatusCompleted  = "completed"
+)
+
+// UserErasure is the report of the erasure of the records attributable to a user.
+// The erased user is not part of the report.
+type UserErasure struct {
+	ID string `json:"id" db:"id"`
+	// SubjectHash is the hash of the erased user, used to resume an interrupted erasure.
+	SubjectHash string `json:"-" db:"subject_hash"`
+	// Pseudonym replaces the erased user in the pseudonymized records.
+	Pseudonym   string `json:"pseudonym" db:"pseudonym"`
+	Mode        string `json:"mode" db:"mode"`
+	Status      string `json:"status" db:"status"`
+	RequestedBy string `json:"requestedBy" db:"requested_by"`
+	CreatedAt   int64  `json:"createdAt" db:"created_at"`
+	CompletedAt *int64 `json:"completedAt,omitempty" db:"completed_at"`
+	// Applications is the number of pseudonymized or deleted applications.
+	Applications int64 `json:"applications" db:"applications"`
+	// ApplicationsRetained is the number of applications of the user left untouched because of a legal hold.
+	ApplicationsRetained int64 `json:"applicationsRetained" db:"applications_retained"`
+	// AuditRecords is the number of pseudonymized audit records, such as the creators of legal holds.
+	AuditRecords int64 `json:"auditRecords" db:"audit_records"`
+}
+
+// QueueACL is a snapshot of the access control lists of a queue in the scheduler configuration,
+// valid from the sync which found the lists until the sync which found them changed or the queue removed.
+type QueueACL struct {
+	ID        string `json:"id" db:"id"`
+	Partition string `json:"partition" db:"partition"`
+	QueueName string `json:"queueName" db:"queue_name"`
+	// SubmitACL and AdminACL are the lists configured on the queue, in the YuniKorn format of comma-separated users
+	// and comma-separated groups separated by a space, e.g. "alice,bob analysts", or * for everyone.
+	SubmitACL string `json:"submitAcl" db:"submit_acl"`
+	AdminACL  string `json:"adminAcl" db:"admin_acl"`
+	// SubmitUsers and SubmitGroups could submit applications to the queue, and AdminUsers and AdminGroups could
+	// administer it, including through the lists of the parent queues. Admins can also submit. * means everyone.
+	SubmitUsers  []string `json:"submitUsers" db:"submit_users"`
+	SubmitGroups []string `json:"submitGroups" db:"submit_groups"`
+	AdminUsers   []string `json:"adminUsers" db:"admin_users"`
+	AdminGroups  []string `json:"adminGroups" db:"admin_groups"`
+	ValidFrom    int64    `json:"validFrom" db:"valid_from"`
+	// ValidTo is not set for the snapshot of the current scheduler configuration.
+	ValidTo *int64 `json:"validTo,omitempty" db:"valid_to"`
+}
+
+// NamespaceQueue is a queue the applications of a Kubernetes namespace were placed in, by the placement rules of
+// the scheduler configuration or by the admission controller, between the submission times in nanoseconds of the
+// first and last application of the namespace seen in the queue.
+type NamespaceQueue struct {
+	ID                  string `json:"id" db:"id"`
+	Partition           string `json:"partition" db:"partition"`
+	Namespace           string `json:"namespace" db:"namespace"`
+	QueueName           string `json:"queueName" db:"queue_name"`
+	FirstSubmissionTime int64  `json:"firstSubmissionTime" db:"first_submission_time"`
+	LastSubmissionTime  int64  `json:"lastSubmissionTime" db:"last_submission_time"`
+	// Applications is the number of applications of the namespace placed in the queue.
+	Applications int64 `json:"applications" db:"applications"`
+}
+
+// AllocationConstraints are the placement constraints of an allocation of an application, captured from its request
+// while it was pending, and the node it was placed on. Times are in nanoseconds.
+type AllocationConstraints struct {
+	ID            string  `json:"id" db:"id"`
+	ApplicationID string  `json:"-" db:"application_id"`
+	AllocationKey string  `json:"allocationKey" db:"allocation_key"`
+	TaskGroupName *string `json:"taskGroupName,omitempty" db:"task_group_name"`
+	Placeholder   bool    `json:"placeholder" db:"placeholder"`
+	// RequiredNodeID is the node the allocation had to be placed on, e.g. for the pods of daemon sets.
+	RequiredNodeID *string `json:"requiredNodeId,omitempty" db:"required_node_id"`
+	// NodeSelector, Affinity and Tolerations are those of the task group of the allocation, in the format of the
+	// Kubernetes API, since YuniKorn does not report those of the pods.
+	NodeSelector   map[string]string `json:"nodeSelector,omitempty" db:"node_selector"`
+	Affinity       json.RawMessage   `json:"affinity,omitempty" db:"affinity"`
+	Tolerations    json.RawMessage   `json:"tolerations,omitempty" db:"tolerations"`
+	RequestTime    *int64            `json:"requestTime,omitempty" db:"request_time"`
+	NodeID         *string           `json:"nodeId,omitempty" db:"node_id"`
+	AllocationTime *int64            `json:"allocationTime,omitempty" db:"allocation_time"`
+	// Zone and Rack are the position of the node in the topology of the cluster, if known.
+	Zone *string `json:"zone,omitempty" db:"-"`
+	Rack *string `json:"rack,omitempty" db:"-"`
+}
+
+// TraceEvent is an event of the event stream of Yunikorn which refers to an application, stored as part of the
+// decision trace of the application. The types are the names of the values of the scheduler interface, e.g. REQUEST,
+// and the timestamp is in nanoseconds.
+type TraceEvent struct {
+	ID            string `json:"id" db:"id"`
+	ApplicationID string `json:"applicationId" db:"app_id"`
+	Type          string `json:"type" db:"type"`
+	// ObjectID is the ID of the object of the event, e.g. the application or the allocation key of a request.
+	ObjectID     string           `json:"objectId" db:"object_id"`
+	ReferenceID  *string          `json:"referenceId,omitempty" db:"reference_id"`
+	ChangeType   string           `json:"changeType" db:"change_type"`
+	ChangeDetail string           `json:"changeDetail" db:"change_detail"`
+	Message      *string          `json:"message,omitempty" db:"message"`
+	Resource     map[string]int64 `json:"resource,omitempty" db:"resource"`
+	Timestamp    int64            `json:"timestamp" db:"timestamp"`
+}
+
+// StreamEvent is an event of the event stream of Yunikorn pushed to the subscribers of the live event stream as it
+// is ingested. Partition and QueueName are those of the application of the events of applications, and QueueName is
+// the queue of the events of queues; they are empty if unknown, e.g. for the events of nodes.
+type StreamEvent struct {
+	Type         string           `json:"type"`
+	ObjectID     string           `json:"objectId"`
+	ReferenceID  *string          `json:"referenceId,omitempty"`
+	ChangeType   string           `json:"changeType"`
+	ChangeDetail string           `json:"changeDetail"`
+	Message      *string          `json:"message,omitempty"`
+	Resource     map[string]int64 `json:"resource,omitempty"`
+	Timestamp    int64            `json:"timestamp"`
+	Partition    string           `json:"partition,omitempty"`
+	QueueName    string           `json:"queueName,omitempty"`
+}
+
+const (
+	ApplicationEventAsk        = "ask"
+	ApplicationEventAllocation = "allocation"
+	ApplicationEventPreemption = "preemption"
+	ApplicationEventState      = "state"
+	ApplicationEventOther      = "other"
+
+	// ApplicationEventSourceApplication is the source of the events normalized from the stored application.
+	ApplicationEventSourceApplication = "application"
+	// ApplicationEventSourceTrace is the source of the raw events of the decision trace of the application.
+	ApplicationEventSourceTrace = "trace"
+)
+
+// ApplicationEvent is an event of an application: an ask, an allocation, a preemption or a state change, normalized
+// from the stored application or taken as is from its decision trace. The timestamp is in nanoseconds.
+type ApplicationEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Kind      string `json:"kind"`
+	Source    string `json:"source"`
+	Partition string `json:"partition,omitempty"`
+	QueueName string `json:"queueName,omitempty"`
+	// ObjectID is the allocation key of asks and allocations, or the ID of the object of the raw events.
+	ObjectID string           `json:"objectId,omitempty"`
+	State    string           `json:"state,omitempty"`
+	NodeID   string           `json:"nodeId,omitempty"`
+	Resource map[string]int64 `json:"resource,omitempty"`
+	// Preempted is set on the allocations which were preempted later.
+	Preempted bool    `json:"preempted,omitempty"`
+	Message   *string `json:"message,omitempty"`
+	// Type, ChangeType and ChangeDetail are those of the raw events.
+	Type         string `json:"type,omitempty"`
+	ChangeType   string `json:"changeType,omitempty"`
+	ChangeDetail string `json:"changeDetail,omitempty"`
+}
+
+// AnalyticsRow is a row of the results of an analytics query. The time bucket and the values of the group-by
+// fields identify the group, and the aggregates are keyed by their name, e.g. count or avg(maxRequestPriority).
+// Aggregates of no values are nil.
+type AnalyticsRow struct {
+	Bucket     *time.Time          `json:"bucket,omitempty"`
+	Groups     map[string]any      `json:"groups,omitempty"`
+	Aggregates map[string]*float64 `json:"aggregates"`
+}
+
+// AnalyticsEstimate is the estimate of the database planner of an analytics query, before running it.
+type AnalyticsEstimate struct {
+	// Rows is the estimated number of rows of the results.
+	Rows int64 `json:"rows"`
+	// ScannedRows is the estimated number of rows read from the tables, which dominates the duration of the query.
+	ScannedRows int64 `json:"scannedRows"`
+	// Cost is the total cost of the plan, in the arbitrary units of the planner.
+	Cost float64 `json:"cost"`
+}
+
+// ThroughputBucket counts the applications of a queue which started running, completed or failed during
+// the interval starting at Start.
+type ThroughputBucket struct {
+	Start     time.Time `json:"start"`
+	Started   int64     `json:"started"`
+	Completed int64     `json:"completed"`
+	Failed    int64     `json:"failed"`
+}
+
+// PriorityWaitTimes compares the wait times of the applications of a queue with a priority and priority class,
+// which are nil if unknown. Wait times are in nanoseconds, from the submission of the applications until they
+// started running, and are nil if none of the applications started.
+type PriorityWaitTimes struct {
+	Partition     string  `json:"partition"`
+	QueueName     string  `json:"queueName"`
+	Priority      *int64  `json:"priority"`
+	PriorityClass *string `json:"priorityClass"`
+	// Applications is the number of applications submitted, and Started the number of them which started running.
+	Applications   int64    `json:"applications"`
+	Started        int64    `json:"started"`
+	AvgWaitTime    *float64 `json:"avgWaitTime"`
+	MedianWaitTime *float64 `json:"medianWaitTime"`
+	P95WaitTime    *float64 `json:"p95WaitTime"`
+	MaxWaitTime    *float64 `json:"maxWaitTime"`
+}
+
+// Phases of the wait of an application until its first allocation.
+const (
+	WaitPhaseAccept      = "accept"
+	WaitPhasePlaceholder = "placeholder"
+	WaitPhaseAllocation  = "allocation"
+)
+
+// Reasons the asks of an application were not allocated, classified from their allocation logs.
+const (
+	// WaitReasonQuota is the reason of asks which did not fit into the quota of their queue, user or group.
+	WaitReasonQuota = "quota"
+	// WaitReasonPlacement is the reason of asks which nodes were tried for but rejected, e.g. by the constraints of
+	// the asks or because the free resources of the nodes were too fragmented.
+	WaitReasonPlacement = "placement"
+	// WaitReasonCapacity is the reason of asks which no node was tried for, as no node had their resources free.
+	WaitReasonCapacity = "capacity"
+)
+
+// WaitPhases decompose the wait of an application until its first allocation into phases in nanoseconds, which are
+// nil if the data of the application does not tell them: AcceptWait from its submission until the scheduler accepted
+// it, PlaceholderWait from then until its first placeholder was allocated, only for gang scheduled applications, and
+// AllocationWait from then, or from its acceptance without placeholders, until its first allocation which is not a
+// placeholder. Reason is the last reason its asks were not allocated before its first allocation, if any.
+type WaitPhases struct {
+	AcceptWait      *int64 `json:"acceptWait,omitempty"`
+	PlaceholderWait *int64 `json:"placeholderWait,omitempty"`
+	AllocationWait  *int64 `json:"allocationWait,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// DuplicateApplication is an application whose ID appears in several clusters, e.g. because it was resubmitted to
+// another cluster on failover, with its runs in the clusters.
+type DuplicateApplication struct {
+	ApplicationID string          `json:"applicationID"`
+	Runs          []*DuplicateRun `json:"runs"`
+}
+
+// DuplicateRun is a run of a duplicate application in a cluster. Canonical marks the run which the queries merging
+// the duplicates count by the disambiguation strategy.
+type DuplicateRun struct {
+	ClusterID        string  `json:"clusterId"`
+	Partition        string  `json:"partition"`
+	QueueName        string  `json:"queueName"`
+	ApplicationState *string `json:"applicationState"`
+	SubmissionTime   *int64  `json:"submissionTime"`
+	Canonical        bool    `json:"canonical"`
+}
+
+// QueueWaitPhases summarize the wait phases of the applications of a queue: the statistics of each phase over the
+// applications it is known for, and the number of applications which last waited for each reason, by reason.
+type QueueWaitPhases struct {
+	Partition    string            `json:"partition"`
+	QueueName    string            `json:"queueName"`
+	Applications int64             `json:"applications"`
+	Phases       []*WaitPhaseStats `json:"phases"`
+	Reasons      map[string]int64  `json:"reasons"`
+}
+
+// WaitPhaseStats are the statistics of a wait phase in nanoseconds, which are nil if it is not known for any of the
+// applications.
+type WaitPhaseStats struct {
+	Phase        string   `json:"phase"`
+	Applications int64    `json:"applications"`
+	MedianWait   *float64 `json:"medianWait"`
+	P95Wait      *float64 `json:"p95Wait"`
+	P99Wait      *float64 `json:"p99Wait"`
+	MaxWait      *float64 `json:"maxWait"`
+}
+
+// TopUsage is the sum of a usage metric of a consumer: a user, a queue or an application, identified by the
+// fields which are set.
+type TopUsage struct {
+	User          string  `json:"user,omitempty"`
+	Partition     string  `json:"partition,omitempty"`
+	QueueName     string  `json:"queueName,omitempty"`
+	ApplicationID string  `json:"applicationID,omitempty"`
+	Value         float64 `json:"value"`
+}
+
+// HourlyUsage is the sum of the resources used during the hour starting at Start, from the usage rollups.
+type HourlyUsage struct {
+	Start         time.Time `json:"start"`
+	VcoreSeconds  float64   `json:"vcoreSeconds"`
+	MemorySeconds float64   `json:"memorySeconds"`
+}
+
+// Principal is a user or group seen in the applications of the history, between the time in nanoseconds one of its
+// applications was first submitted and the time one was last submitted or finished, with the number of its
+// applications and the resources they used.
+type Principal struct {
+	Name          string  `json:"name"`
+	FirstSeen     int64   `json:"firstSeen"`
+	LastSeen      int64   `json:"lastSeen"`
+	Applications  int64   `json:"applications"`
+	VcoreSeconds  float64 `json:"vcoreSeconds"`
+	MemorySeconds float64 `json:"memorySeconds"`
+}
+
+// UsageCalendarCell is the average usage during an hour of a day of the week: the vcores and bytes of memory used
+// on average during the hours of the period falling on that hour of the weekday. Weekday is the ISO day of the
+// week, 1 for Monday to 7 for Sunday, and Hour the hour of the day, from 0 to 23.
+type UsageCalendarCell struct {
+	Weekday int     `json:"weekday"`
+	Hour    int     `json:"hour"`
+	Hours   int     `json:"hours"`
+	Vcores  float64 `json:"vcores"`
+	Memory  float64 `json:"memory"`
+}
+
+// PartitionComparison is the activity of a partition during a period, to compare it with the other partitions.
+// Vcores and Memory are the vcores and bytes of memory used on average during the period, from the usage rollups,
+// and the utilizations their ratio to the current capacity of the partition, which are nil if it is unknown.
+// Wait times are in nanoseconds, of the applications submitted during the period which started running.
+type PartitionComparison struct {
+	Partition         string           `json:"partition"`
+	Capacity          map[string]int64 `json:"capacity"`
+	Vcores            float64          `json:"vcores"`
+	Memory            float64          `json:"memory"`
+	VcoreUtilization  *float64         `json:"vcoreUtilization"`
+	MemoryUtilization *float64         `json:"memoryUtilization"`
+	// Applications is the number of applications submitted during the period, and Started, Completed and Failed
+	// the number of applications which started running, completed or failed during the period.
+	Applications int64 `json:"applications"`
+	Started      int64 `json:"started"`
+	Completed    int64 `json:"completed"`
+	Failed       int64 `json:"failed"`
+	// CompletedPerHour is the average number of applications which completed per hour of the period.
+	CompletedPerHour float64  `json:"completedPerHour"`
+	MedianWaitTime   *float64 `json:"medianWaitTime"`
+	P95WaitTime      *float64 `json:"p95WaitTime"`
+	P99WaitTime      *float64 `json:"p99WaitTime"`
+	MaxWaitTime      *float64 `json:"maxWaitTime"`
+}
+
+// Cluster is a cluster registered by its ingestion agent, which sends heartbeats while it ingests the data
+// of the cluster. Times are Unix times in seconds.
+type Cluster struct {
+	Name             string            `json:"name" db:"name"`
+	SchedulerVersion string            `json:"schedulerVersion" db:"scheduler_version"`
+	Labels           map[string]string `json:"labels" db:"labels"`
+	RegisteredAt     int64             `json:"registeredAt" db:"registered_at"`
+	LastSeen         int64             `json:"lastSeen" db:"last_seen"`
+	// IngestedUntil is the time up to which the agent ingested the data of the cluster, if it reported it.
+	IngestedUntil *int64 `json:"ingestedUntil" db:"ingested_until"`
+}
+
+// DataQualityCheck is the result of the last run of a data quality check.
+type DataQualityCheck struct {
+	Name        string `json:"name" db:"check_name"`
+	Description string `json:"description" db:"-"`
+	// Violations is the number of records which violated the invariant, including those which were not stored.
+	Violations int64 `json:"violations" db:"violations"`
+	// CheckedAt is the Unix time in seconds of the last run, or nil if the check did not run yet.
+	CheckedAt *int64 `json:"checkedAt" db:"checked_at"`
+}
+
+// DataQualityViolation is a record which violated the invariant of a data quality check. The record is identified
+// by the fields which are set.
+type DataQualityViolation struct {
+	ID            string  `json:"id" db:"id"`
+	Check         string  `json:"check" db:"check_name"`
+	Partition     *string `json:"partition,omitempty" db:"partition"`
+	QueueName     *string `json:"queueName,omitempty" db:"queue_name"`
+	ApplicationID *string `json:"applicationId,omitempty" db:"app_id"`
+	NodeID        *string `json:"nodeId,omitempty" db:"node_id"`
+	Detail        string  `json:"detail" db:"detail"`
+	DetectedAt    int64   `json:"detectedAt" db:"detected_at"`
+}
+
+// SchedulerEpoch is the lifetime of an instance of Yunikorn, from its start until the start of the next instance.
+// Epochs delimit the windows around restarts of the scheduler, during which the stored data may be incomplete.
+// Times are in nanoseconds.
+type SchedulerEpoch struct {
+	ID string `json:"id" db:"id"`
+	// ClusterID is the ID of the cluster of the scheduler, empty for the default cluster.
+	ClusterID string `json:"clusterId,omitempty" db:"cluster_id"`
+	// InstanceID is the UUID of the instance, which Yunikorn only exposes if its event tracking is enabled.
+	InstanceID *string `json:"instanceId,omitempty" db:"instance_id"`
+	StartTime  int64   `json:"startTime" db:"start_time"`
+	// EndTime is the start time of the next instance, not set for the current instance.
+	EndTime *int64 `json:"endTime,omitempty" db:"end_time"`
+	// DetectedAt is the time the instance was detected, after which the data of the scheduler was synced again.
+	DetectedAt int64 `json:"detectedAt" db:"detected_at"`
+}
+
+// AnalyticsSink is an external store the applications are replicated to for analytics queries, such as ClickHouse.
+// Times are in nanoseconds.
+type AnalyticsSink struct {
+	Name      string `json:"name" db:"name"`
+	CreatedAt int64  `json:"createdAt" db:"created_at"`
+	// BackfillCursor is the ID of the last application copied to the sink by the backfill.
+	BackfillCursor *string `json:"backfillCursor,omitempty" db:"backfill_cursor"`
+	// BackfilledAt is the time the backfill completed, not set while the applications are copied.
+	BackfilledAt *int64 `json:"backfilledAt,omitempty" db:"backfilled_at"`
+}
+
+// PausedOperation is an operation paused by an administrator, the ingestion of the data of Yunikorn or
+// a periodic job, which does not run until it is resumed.
+type PausedOperation struct {
+	Name     string `json:"name" db:"name"`
+	Reason   string `json:"reason" db:"reason"`
+	PausedBy string `json:"pausedBy" db:"paused_by"`
+	// PausedAt is the time the operation was paused in seconds since the epoch.
+	PausedAt int64 `json:"pausedAt" db:"paused_at"`
+}
+
+// AnalyticsApplication is an application as replicated to analytics sinks: the fields analytics queries reference.
+// User is stored as in the database, i.e. encrypted if the user names are encrypted.
+type AnalyticsApplication struct {
+	ID                 string  `json:"id" db:"id"`
+	Partition          string  `json:"partition" db:"partition"`
+	QueueName          string  `json:"queueName" db:"queue_name"`
+	User               *string `json:"user" db:"user"`
+	State              *string `json:"applicationState" db:"state"`
+	WorkflowID         *string `json:"workflowId" db:"workflow_id"`
+	HasReserved        *bool   `json:"hasReserved" db:"has_reserved"`
+	MaxRequestPriority *int32  `json:"maxRequestPriority" db:"max_request_priority"`
+	Priority           *int32  `json:"priority" db:"priority"`
+	PriorityClass      *string `json:"priorityClass" db:"priority_class"`
+	WaitTime           *int64  `json:"waitTime" db:"wait_time"`
+	SubmissionTime     *int64  `json:"submissionTime" db:"submission_time"`
+	FinishedTime       *int64  `json:"finishedTime" db:"finished_time"`
+	// Deleted is set if the application was deleted, in which case only its ID, partition and queue are set.
+	Deleted bool `json:"deleted" db:"-"`
+}
+
+// NodeDAOInfo is a node with the partition it belongs to.
+type NodeDAOInfo struct {
+	Partition string `json:"partition"`
+	dao.NodeDAOInfo
+}
+
+// NodeImpactApplication is the part of an application which runs on a node, which draining the node would disrupt.
+// Resource is the sum of the resources of the allocations on the node, and Share the ratio of the allocations of the
+// application in the partition which are on the node: the application loses all of its allocations if it is 1.
+type NodeImpactApplication struct {
+	ApplicationID    string           `json:"applicationId"`
+	Allocations      int              `json:"allocations"`
+	Placeholders     int              `json:"placeholders"`
+	Resource         map[string]int64 `json:"resource"`
+	TotalAllocations int              `json:"totalAllocations"`
+	Share            float64          `json:"share"`
+}
+
+// NodeGroupUtilization is the utilization of the nodes of a partition in a node group, such as a node pool,
+// aggregated per resource. Utilization is the ratio of the allocated to the capacity of each resource.
+type NodeGroupUtilization struct {
+	Name             string             `json:"name"`
+	Selector         map[string]string  `json:"selector"`
+	Nodes            int                `json:"nodes"`
+	SchedulableNodes int                `json:"schedulableNodes"`
+	Allocations      int                `json:"allocations"`
+	Capacity         map[string]int64   `json:"capacity"`
+	Allocated        map[string]int64   `json:"allocated"`
+	Occupied         map[string]int64   `json:"occupied"`
+	Available        map[string]int64   `json:"available"`
+	Utilization      map[string]float64 `json:"utilization"`
+}
+
+// TopologyDimension is a dimension of the topology of the nodes of a cluster.
+type TopologyDimension string
+
+const (
+	TopologyZone TopologyDimension = "zone"
+	TopologyRack TopologyDimension = "rack"
+)
+
+// TopologyDimensions are the dimensions of the topology the nodes can be grouped by.
+var TopologyDimensions = []TopologyDimension{TopologyZone, TopologyRack}
+
+// NodeTopology is the position of a node in the topology of the cluster. Zone and Rack are nil if unknown.
+type NodeTopology struct {
+	NodeID    string  `json:"nodeId" db:"node_id"`
+	Partition string  `json:"partition" db:"partition"`
+	Zone      *string `json:"zone" db:"zone"`
+	Rack      *string `json:"rack" db:"rack"`
+}
+
+// Position returns the position of the node in a dimension of the topology, nil if unknown.
+func (t *NodeTopology) Position(dimension TopologyDimension) *string {
+	switch dimension {
+	case TopologyZone:
+		return t.Zone
+	case TopologyRack:
+		return t.Rack
+	}
+	return nil
+}
+
+// NodeTermination is the removal of a node from Yunikorn, with the allocations it was running when it was last
+// synced, which were disrupted by the termination. Spot is whether the node was a spot or preemptible node, whose
+// terminations are attributed to the reclamation of its capacity by the cloud provider, and Drained whether it was
+// unschedulable when it was removed, e.g. because it was cordoned. TerminatedAt is in nanoseconds.
+type NodeTermination struct {
+	ID           string                   `json:"id" db:"id"`
+	NodeID       string                   `json:"nodeId" db:"node_id"`
+	Partition    string                   `json:"partition" db:"partition"`
+	TerminatedAt int64                    `json:"terminatedAt" db:"terminated_at"`
+	Spot         bool                     `json:"spot" db:"spot"`
+	Drained      bool                     `json:"drained" db:"drained"`
+	Zone         *string                  `json:"zone,omitempty" db:"zone"`
+	Rack         *string                  `json:"rack,omitempty" db:"rack"`
+	Attributes   map[string]string        `json:"attributes" db:"attributes"`
+	Allocations  []*dao.AllocationDAOInfo `json:"allocations" db:"allocations"`
+}
+
+// SpotChurnApplication are the disruptions of an application by the terminations of spot nodes: the allocations of
+// the application the nodes were running when they were terminated. Resource is the sum of the resources of the
+// disrupted allocations, and the disruption times are in nanoseconds.
+type SpotChurnApplication struct {
+	Partition       string           `json:"partition"`
+	ApplicationID   string           `json:"applicationId"`
+	Nodes           int              `json:"nodes"`
+	Allocations     int              `json:"allocations"`
+	Resource        map[string]int64 `json:"resource"`
+	FirstDisruption int64            `json:"firstDisruption"`
+	LastDisruption  int64            `json:"lastDisruption"`
+}
+
+// AutoscalerDirection classifies the events of the cluster-autoscaler by their reason.
+type AutoscalerDirection string
+
+const (
+	// AutoscalerScaleUp are the scale-ups triggered by pending pods or of node groups.
+	AutoscalerScaleUp AutoscalerDirection = "scale_up"
+	// AutoscalerNoScaleUp are the pending pods no node group could be scaled up for.
+	AutoscalerNoScaleUp AutoscalerDirection = "no_scale_up"
+	// AutoscalerScaleUpFailed are the scale-ups of node groups which failed.
+	AutoscalerScaleUpFailed AutoscalerDirection = "scale_up_failed"
+	// AutoscalerScaleDown are the removals of nodes.
+	AutoscalerScaleDown AutoscalerDirection = "scale_down"
+	// AutoscalerScaleDownFailed are the removals of nodes which failed.
+	AutoscalerScaleDownFailed AutoscalerDirection = "scale_down_failed"
+	// AutoscalerOther are the other events of the cluster-autoscaler.
+	AutoscalerOther AutoscalerDirection = "other"
+)
+
+// AutoscalerEvent is an occurrence of a Kubernetes event of the cluster-autoscaler. An event with the same UID
+// occurs again with an increased count. Time is in nanoseconds.
+type AutoscalerEvent struct {
+	ID              string              `json:"id" db:"id"`
+	UID             string              `json:"uid" db:"uid"`
+	Time            int64               `json:"time" db:"time"`
+	Reason          string              `json:"reason" db:"reason"`
+	Direction       AutoscalerDirection `json:"direction" db:"direction"`
+	ObjectKind      *string             `json:"objectKind,omitempty" db:"object_kind"`
+	ObjectNamespace *string             `json:"objectNamespace,omitempty" db:"object_namespace"`
+	ObjectName      *string             `json:"objectName,omitempty" db:"object_name"`
+	Message         *string             `json:"message,omitempty" db:"message"`
+	Count           int32               `json:"count" db:"count"`
+}
+
+// PendingBacklogSample are the pending resources of a partition, those of its root queue, when the queues were
+// synced at SampledAt in nanoseconds.
+type PendingBacklogSample struct {
+	Partition       string           `json:"partition" db:"partition"`
+	SampledAt       int64            `json:"sampledAt" db:"sampled_at"`
+	PendingResource map[string]int64 `json:"pendingResource" db:"pending_resource"`
+}
+
+// UtilizationHeatmap counts the nodes of a partition per position in a dimension of the topology, such as zones, and
+// per utilization bucket of a resource. The utilization of a node is the ratio of its allocated to its capacity of
+// the resource, and the buckets are 10% wide, the last one including 100%. Nodes without capacity of the resource
+// are not counted.
+type UtilizationHeatmap struct {
+	Resource string                   `json:"resource"`
+	By       TopologyDimension        `json:"by"`
+	Buckets  []string                 `json:"buckets"`
+	Rows     []*UtilizationHeatmapRow `json:"rows"`
+}
+
+// UtilizationHeatmapRow is a row of a utilization heatmap: the nodes of a position in the topology. Counts are the
+// number of nodes per bucket, and Utilization the ratio of the allocated to the capacity of the nodes of the row.
+type UtilizationHeatmapRow struct {
+	Name        string  `json:"name"`
+	Nodes       int     `json:"nodes"`
+	Counts      []int   `json:"counts"`
+	Utilization float64 `json:"utilization"`
+}
+
+const (
+	// SLOSourceConfig is the source of the queue SLOs defined in the configuration file.
+	SLOSourceConfig = "config"
+	// SLOSourceAPI is the source of the queue SLOs defined via the API.
+	SLOSourceAPI = "api"
+)
+
+// QueueSLO is a service level objective of a queue subtree: the share of its applications which start running within
+// StartWithin nanoseconds of their submission, among the applications submitted in the last Window nanoseconds, must
+// be at least the Objective, e.g. 95% of the applications start within 5 minutes.
+type QueueSLO struct {
+	Name   string `json:"name" db:"name"`
+	Source string `json:"source" db:"source"`
+	// Partition the SLO applies to. An empty partition matches all partitions.
+	Partition string `json:"partition,omitempty" db:"partition"`
+	// Queue is the root of the queue subtree the SLO applies to. An empty queue matches all queues.
+	Queue       string  `json:"queue,omitempty" db:"queue_name"`
+	Objective   float64 `json:"objective" db:"objective"`
+	StartWithin int64   `json:"startWithin" db:"start_within"`
+	Window      int64   `json:"window" db:"window_length"`
+	// Version is incremented by every change of the SLO, so that it is only changed in the version a client read.
+	Version int64 `json:"version" db:"version"`
+}
+
+// SLOEvaluation is the result of the evaluation of a queue SLO at EvaluatedAt in Unix seconds. Applications is the
+// number of applications counted, those which started or already waited longer than the threshold, and OnTime the
+// number of those which started within the threshold. Compliance is their ratio, nil if no application was counted,
+// in which case the SLO is met.
+type SLOEvaluation struct {
+	SLOName      string   `json:"sloName" db:"slo_name"`
+	EvaluatedAt  int64    `json:"evaluatedAt" db:"evaluated_at"`
+	Objective    float64  `json:"objective" db:"objective"`
+	Applications int64    `json:"applications" db:"applications"`
+	OnTime       int64    `json:"onTime" db:"on_time"`
+	Compliance   *float64 `json:"compliance" db:"compliance"`
+	Met          bool     `json:"met" db:"met"`
+}
@@ -0,0 +1,78 @@
+package msgpack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []byte
+	}{
+		{name: "nil", json: `null`, want: []byte{0xc0}},
+		{name: "booleans", json: `[true, false]`, want: []byte{0x92, 0xc3, 0xc2}},
+		{name: "positive fixint", json: `127`, want: []byte{0x7f}},
+		{name: "negative fixint", json: `-32`, want: []byte{0xe0}},
+		{name: "uint8", json: `200`, want: []byte{0xcc, 0xc8}},
+		{name: "uint16", json: `65535`, want: []byte{0xcd, 0xff, 0xff}},
+		{name: "uint32", json: `65536`, want: []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+		{name: "uint64", json: `18446744073709551615`, want: []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "int8", json: `-33`, want: []byte{0xd0, 0xdf}},
+		{name: "int16", json: `-129`, want: []byte{0xd1, 0xff, 0x7f}},
+		{name: "int32", json: `-32769`, want: []byte{0xd2, 0xff, 0xff, 0x7f, 0xff}},
+		{name: "int64", json: `-4294967296`, want: []byte{0xd3, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00}},
+		{name: "float64", json: `1.5`, want: []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{name: "fixstr", json: `"abc"`, want: []byte{0xa3, 'a', 'b', 'c'}},
+		{
+			name: "object fields in document order",
+			json: `{"b": 1, "a": [], "c": {}}`,
+			want: []byte{0x83, 0xa1, 'b', 0x01, 0xa1, 'a', 0x90, 0xa1, 'c', 0x80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromJSON([]byte(tt.json))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromJSON_Lengths(t *testing.T) {
+	str8 := strings.Repeat("x", 32)
+	got, err := FromJSON([]byte(`"` + str8 + `"`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xd9, 32}, got[:2])
+
+	str16 := strings.Repeat("x", 256)
+	got, err = FromJSON([]byte(`"` + str16 + `"`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xda, 0x01, 0x00}, got[:3])
+
+	array16 := "[" + strings.Repeat("0,", 15) + "0]"
+	got, err = FromJSON([]byte(array16))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xdc, 0x00, 0x10}, got[:3])
+	assert.Len(t, got, 3+16)
+}
+
+func TestFromJSON_Invalid(t *testing.T) {
+	for _, data := range []string{``, `{"a": }`, `[1, 2`, `1 2`} {
+		_, err := FromJSON([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	got, err := Marshal(struct {
+		Name  string `json:"name"`
+		Empty string `json:"empty,omitempty"`
+	}{Name: "root"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x81, 0xa4, 'n', 'a', 'm', 'e', 0xa4, 'r', 'o', 'o', 't'}, got)
+}
@@ -0,0 +1,96 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fileStore stores the objects as files in a local directory, e.g. a volume shared with the offline analytics.
+type fileStore struct {
+	dir string
+}
+
+// Put writes the object to a temporary file which is renamed, so that readers do not see partial files.
+func (s *fileStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	return s.write(key, data, os.Rename)
+}
+
+// Create writes the object to a temporary file which is linked, which fails if the object exists.
+func (s *fileStore) Create(_ context.Context, key string, data []byte, _ string) error {
+	return s.write(key, data, func(tmp, path string) error {
+		err := os.Link(tmp, path)
+		if errors.Is(err, os.ErrExist) {
+			return ErrExists
+		}
+		return err
+	})
+}
+
+// write writes the data to a temporary file, and then moves it to the object with the commit function.
+func (s *fileStore) write(key string, data []byte, commit func(tmp, path string) error) error {
+	return s.commit(key, func(tmp *os.File) error {
+		_, err := tmp.Write(data)
+		return err
+	}, commit)
+}
+
+// commit writes a temporary file with the write function, and then moves it to the object with the commit function.
+func (s *fileStore) commit(key string, write func(tmp *os.File) error, commit func(tmp, path string) error) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create directory of object %s: %v", key, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("could not create object %s: %v", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("could not write object %s: %v", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write object %s: %v", key, err)
+	}
+	if err := commit(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not write object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Upload copies the file to a temporary file which is renamed, so that readers do not see partial files.
+func (s *fileStore) Upload(_ context.Context, key string, f *os.File, _ int64, _ string, _ string) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("could not upload object %s: %v", key, err)
+	}
+	return s.commit(key, func(tmp *os.File) error {
+		_, err := io.Copy(tmp, f)
+		return err
+	}, os.Rename)
+}
+
+func (s *fileStore) Open(key string) (*os.File, error) {
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open object %s: %v", key, err)
+	}
+	return f, nil
+}
+
+func (s *fileStore) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read object %s: %v", key, err)
+	}
+	return data, nil
+}
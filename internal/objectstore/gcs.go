@@ -0,0 +1,96 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// gcsScope is the OAuth2 scope of access tokens used to read and write the objects of Google Cloud Storage.
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// gcsStore stores the objects in a bucket of Google Cloud Storage, using its JSON API.
+type gcsStore struct {
+	endpoint    string
+	bucket      string
+	prefix      string
+	tokenSource oauth2.TokenSource
+	httpClient  *http.Client
+}
+
+// newGCSStore creates the store of the bucket, with the Google application default credentials.
+func newGCSStore(ctx context.Context, cfg *config.ObjectStorageConfig, bucket, prefix string, httpClient *http.Client) (*gcsStore, error) {
+	ts, err := google.DefaultTokenSource(ctx, gcsScope)
+	if err != nil {
+		return nil, fmt.Errorf("could not load GCP credentials: %v", err)
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	return &gcsStore{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		bucket:      bucket,
+		prefix:      prefix,
+		tokenSource: ts,
+		httpClient:  httpClient,
+	}, nil
+}
+
+func (s *gcsStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPost, s.uploadURL(key), data, contentType)
+	if err != nil {
+		return fmt.Errorf("could not put object %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// Create uploads the object with a precondition on generation 0, which matches only objects which do not exist.
+func (s *gcsStore) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPost, s.uploadURL(key)+"&ifGenerationMatch=0", data, contentType)
+	if err != nil {
+		return fmt.Errorf("could not create object %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+func (s *gcsStore) uploadURL(key string) string {
+	return fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		s.endpoint, url.PathEscape(s.bucket), url.QueryEscape(s.prefix+key))
+}
+
+func (s *gcsStore) Get(ctx context.Context, key string) ([]byte, error) {
+	objectURL := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		s.endpoint, url.PathEscape(s.bucket), url.PathEscape(s.prefix+key))
+	resp, err := s.do(ctx, http.MethodGet, objectURL, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (s *gcsStore) do(ctx context.Context, method, objectURL string, data []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("could not get GCP access token: %v", err)
+	}
+	token.SetAuthHeader(req)
+	return send(s.httpClient, req)
+}
@@ -0,0 +1,107 @@
+// Package objectstore stores objects in object storage: in buckets of AWS S3 and of S3 compatible storages like MinIO
+// or Ceph, in buckets of Google Cloud Storage, and in local directories. Each storage is a driver implementing Store,
+// and the optional interfaces of the operations it supports.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// maxErrorBodySize is the maximum size of an error response of the object storage which is returned in errors.
+const maxErrorBodySize = 4096
+
+var (
+	// ErrNotFound is returned by stores for objects which do not exist.
+	ErrNotFound = errors.New("object not found")
+	// ErrExists is returned by stores for objects which are created although they exist.
+	ErrExists = errors.New("object already exists")
+)
+
+// Store stores objects, whose keys are relative to the configured location.
+type Store interface {
+	// Put creates or replaces the object.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Create creates the object unless it exists, in which case it returns ErrExists. The check is atomic, so
+	// that of concurrent writers creating the same object only one succeeds.
+	Create(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the content of the object, or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Uploader is implemented by the stores which upload objects from files, so that the size of the objects is not
+// bounded by memory.
+type Uploader interface {
+	// Upload creates or replaces the object with the content of the file, whose size and hex encoded SHA-256
+	// checksum are given.
+	Upload(ctx context.Context, key string, f *os.File, size int64, sha256 string, contentType string) error
+}
+
+// Presigner is implemented by the stores whose objects are downloaded from the object storage directly, with
+// presigned URLs which support range requests.
+type Presigner interface {
+	// Presign returns a URL downloading the object as a file of the given name, which is valid for the expiry.
+	Presign(ctx context.Context, key, filename string, expiry time.Duration) (string, error)
+}
+
+// Opener is implemented by the stores whose objects are local files, which are downloaded from the history server.
+type Opener interface {
+	// Open opens the file of the object, or returns ErrNotFound.
+	Open(key string) (*os.File, error)
+}
+
+// New creates the store of the configured location. Credentials of S3 are the configured static credentials, or
+// are loaded from the default AWS credential chain, and those of Google Cloud Storage from the Google application
+// default credentials.
+func New(ctx context.Context, cfg *config.ObjectStorageConfig) (Store, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object storage url: %v", err)
+	}
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	httpClient := &http.Client{Timeout: 5 * time.Minute}
+	switch u.Scheme {
+	case "s3":
+		return newS3Store(ctx, cfg, u.Host, prefix, httpClient)
+	case "gs":
+		return newGCSStore(ctx, cfg, u.Host, prefix, httpClient)
+	case "file":
+		return &fileStore{dir: u.Path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported object storage url scheme %q", u.Scheme)
+	}
+}
+
+// send sends the request and returns the response if it succeeded, ErrNotFound if the object does not exist,
+// ErrExists if the precondition of its absence failed, or an error with the response of the object storage otherwise.
+func send(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	case http.StatusPreconditionFailed:
+		return nil, ErrExists
+	}
+	problem, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return nil, fmt.Errorf("object storage returned status %d: %s", resp.StatusCode, bytes.TrimSpace(problem))
+}
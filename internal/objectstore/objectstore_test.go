@@ -0,0 +1,269 @@
+package objectstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// fakeObjectStorage stores the objects of the requests by the escaped path of their URL.
+type fakeObjectStorage struct {
+	objects  map[string][]byte
+	requests []*http.Request
+}
+
+func newFakeObjectStorage(t *testing.T, handler func(f *fakeObjectStorage, w http.ResponseWriter, r *http.Request)) (*fakeObjectStorage, string) {
+	t.Helper()
+	fake := &fakeObjectStorage{objects: make(map[string][]byte)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.requests = append(fake.requests, r)
+		handler(fake, w, r)
+	}))
+	t.Cleanup(server.Close)
+	return fake, server.URL
+}
+
+func TestS3Store(t *testing.T) {
+	fake, endpoint := newFakeObjectStorage(t, func(f *fakeObjectStorage, w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			if _, ok := f.objects[r.URL.EscapedPath()]; ok && r.Header.Get("If-None-Match") == "*" {
+				http.Error(w, "<Error><Code>PreconditionFailed</Code></Error>", http.StatusPreconditionFailed)
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			f.objects[r.URL.EscapedPath()] = data
+		case http.MethodGet:
+			data, ok := f.objects[r.URL.EscapedPath()]
+			if !ok {
+				http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	})
+	store := &s3Store{
+		endpoint:    endpoint,
+		bucket:      "yhs-events",
+		prefix:      "production/",
+		region:      "eu-west-1",
+		pathStyle:   true,
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		signer:      v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true }),
+		httpClient:  http.DefaultClient,
+		now:         func() time.Time { return time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC) },
+	}
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "events/dt=2024-07-01/hour=13/_manifest.json", []byte("{}"), "application/json"))
+	data, err := store.Get(ctx, "events/dt=2024-07-01/hour=13/_manifest.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+	_, err = store.Get(ctx, "events/_index.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// the key is escaped once in the path, from which S3 derives the canonical path of the signature
+	put := fake.requests[0]
+	assert.Equal(t, "/yhs-events/production/events/dt%3D2024-07-01/hour%3D13/_manifest.json", put.URL.EscapedPath())
+	assert.True(t, strings.HasPrefix(put.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKID/20240701/eu-west-1/s3/aws4_request"))
+	assert.Equal(t, "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", put.Header.Get("X-Amz-Content-Sha256"))
+	assert.Equal(t, "application/json", put.Header.Get("Content-Type"))
+
+	// objects are created with a condition on their absence
+	require.NoError(t, store.Create(ctx, "events/_delta_log/00000000000000000000.json", []byte("{}"), "application/json"))
+	err = store.Create(ctx, "events/_delta_log/00000000000000000000.json", []byte("{}"), "application/json")
+	assert.ErrorIs(t, err, ErrExists)
+	assert.Equal(t, "*", fake.requests[len(fake.requests)-1].Header.Get("If-None-Match"))
+
+	store.pathStyle = false
+	store.endpoint = "https://s3.eu-west-1.amazonaws.com"
+	objectURL, err := store.objectURL("events/_index.json")
+	require.NoError(t, err)
+	assert.Equal(t, "https://yhs-events.s3.eu-west-1.amazonaws.com/production/events/_index.json", objectURL)
+}
+
+func TestNew_S3Compatible(t *testing.T) {
+	// no region is configured in the environment
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+	t.Setenv("AWS_CA_BUNDLE", "")
+	fake, endpoint := newFakeObjectStorage(t, func(f *fakeObjectStorage, w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.EscapedPath()] = data
+	})
+
+	ctx := context.Background()
+	store, err := New(ctx, &config.ObjectStorageConfig{
+		URL:             "s3://yhs-events/production",
+		Endpoint:        endpoint,
+		PathStyle:       true,
+		AccessKeyID:     "minio",
+		SecretAccessKey: "minio-secret",
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, "events/_index.json", []byte("{}"), "application/json"))
+
+	// requests are signed with the static credentials in the default region of S3 compatible storages
+	require.Len(t, fake.requests, 1)
+	assert.Equal(t, "/yhs-events/production/events/_index.json", fake.requests[0].URL.EscapedPath())
+	assert.Contains(t, fake.requests[0].Header.Get("Authorization"), "Credential=minio/")
+	assert.Contains(t, fake.requests[0].Header.Get("Authorization"), "/us-east-1/s3/aws4_request")
+
+	// without an endpoint, AWS S3 requires a region
+	_, err = New(ctx, &config.ObjectStorageConfig{URL: "s3://yhs-events", AccessKeyID: "AKID", SecretAccessKey: "SECRET"})
+	assert.ErrorContains(t, err, "region")
+}
+
+func TestS3Store_UploadAndPresign(t *testing.T) {
+	fake, endpoint := newFakeObjectStorage(t, func(f *fakeObjectStorage, w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.EscapedPath()] = data
+	})
+	store := &s3Store{
+		endpoint:    endpoint,
+		bucket:      "yhs-exports",
+		prefix:      "production/",
+		region:      "eu-west-1",
+		pathStyle:   true,
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		signer:      v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true }),
+		httpClient:  http.DefaultClient,
+		now:         func() time.Time { return time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC) },
+	}
+	ctx := context.Background()
+
+	f, err := os.CreateTemp(t.TempDir(), "export")
+	require.NoError(t, err)
+	_, err = f.WriteString("{}\n")
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte("{}\n"))
+	require.NoError(t, store.Upload(ctx, "export.ndjson", f, 3, hex.EncodeToString(sum[:]), "application/x-ndjson"))
+	assert.Equal(t, []byte("{}\n"), fake.objects["/yhs-exports/production/export.ndjson"])
+	put := fake.requests[0]
+	assert.Equal(t, int64(3), put.ContentLength)
+	assert.Equal(t, hex.EncodeToString(sum[:]), put.Header.Get("X-Amz-Content-Sha256"))
+	err = store.Upload(ctx, "export.ndjson", f, maxS3UploadSize+1, hex.EncodeToString(sum[:]), "application/x-ndjson")
+	assert.ErrorContains(t, err, "exceeds the maximum of a single upload")
+
+	presigned, err := store.Presign(ctx, "export.ndjson", "export.ndjson", 15*time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(presigned)
+	require.NoError(t, err)
+	assert.Equal(t, "/yhs-exports/production/export.ndjson", u.Path)
+	query := u.Query()
+	assert.Equal(t, "AWS4-HMAC-SHA256", query.Get("X-Amz-Algorithm"))
+	assert.Equal(t, "AKID/20240701/eu-west-1/s3/aws4_request", query.Get("X-Amz-Credential"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.Equal(t, "host", query.Get("X-Amz-SignedHeaders"))
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+	assert.Equal(t, "attachment; filename=export.ndjson", query.Get("response-content-disposition"))
+}
+
+func TestGCSStore(t *testing.T) {
+	fake, endpoint := newFakeObjectStorage(t, func(f *fakeObjectStorage, w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/yhs-events/o":
+			if _, ok := f.objects[r.URL.Query().Get("name")]; ok && r.URL.Query().Get("ifGenerationMatch") == "0" {
+				http.Error(w, "Precondition Failed", http.StatusPreconditionFailed)
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			f.objects[r.URL.Query().Get("name")] = data
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.EscapedPath(), "/storage/v1/b/yhs-events/o/"):
+			name := strings.TrimPrefix(r.URL.Path, "/storage/v1/b/yhs-events/o/")
+			data, ok := f.objects[name]
+			if !ok || r.URL.Query().Get("alt") != "media" {
+				http.Error(w, "No such object", http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	})
+	store := &gcsStore{
+		endpoint:    endpoint,
+		bucket:      "yhs-events",
+		prefix:      "production/",
+		tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		httpClient:  http.DefaultClient,
+	}
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "events/_index.json", []byte("{}"), "application/json"))
+	assert.Equal(t, []byte("{}"), fake.objects["production/events/_index.json"])
+	data, err := store.Get(ctx, "events/_index.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+	// the slashes of the name are escaped in the path of the object
+	assert.Equal(t, "/storage/v1/b/yhs-events/o/production%2Fevents%2F_index.json", fake.requests[1].URL.EscapedPath())
+	_, err = store.Get(ctx, "events/dt=2024-07-01/hour=13/_manifest.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Create(ctx, "events/_delta_log/00000000000000000000.json", []byte("{}"), "application/json"))
+	err = store.Create(ctx, "events/_delta_log/00000000000000000000.json", []byte("{}"), "application/json")
+	assert.ErrorIs(t, err, ErrExists)
+
+	store.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "expired"})
+	err = store.Put(ctx, "events/_index.json", []byte("{}"), "application/json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401: unauthorized")
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(ctx, &config.ObjectStorageConfig{URL: "file://" + t.TempDir()})
+	require.NoError(t, err)
+
+	_, err = store.Get(ctx, "events/_index.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, store.Put(ctx, "events/_index.json", []byte("{}"), "application/json"))
+	require.NoError(t, store.Put(ctx, "events/_index.json", []byte(`{"partitions":[]}`), "application/json"))
+	data, err := store.Get(ctx, "events/_index.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"partitions":[]}`, string(data))
+
+	require.NoError(t, store.Create(ctx, "events/_delta_log/00000000000000000000.json", []byte("{}"), "application/json"))
+	err = store.Create(ctx, "events/_delta_log/00000000000000000000.json", []byte(`{"add":{}}`), "application/json")
+	assert.ErrorIs(t, err, ErrExists)
+	data, err = store.Get(ctx, "events/_delta_log/00000000000000000000.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+
+	// files are uploaded by copying them, and opened to be served
+	f, err := os.CreateTemp(t.TempDir(), "export")
+	require.NoError(t, err)
+	_, err = f.WriteString("{}\n")
+	require.NoError(t, err)
+	require.NoError(t, store.(Uploader).Upload(ctx, "exports/export.ndjson", f, 3, "", "application/x-ndjson"))
+	opened, err := store.(Opener).Open("exports/export.ndjson")
+	require.NoError(t, err)
+	defer opened.Close()
+	data, err = io.ReadAll(opened)
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(data))
+	_, err = store.(Opener).Open("exports/missing.ndjson")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,211 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/smithy-go/encoding/httpbinding"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+const (
+	// maxS3UploadSize is the maximum size of an object uploaded to S3 with a single request.
+	maxS3UploadSize = 5 << 30
+	// defaultS3CompatibleRegion is the region of S3 compatible storages which do not configure one, which most of
+	// them accept in signatures.
+	defaultS3CompatibleRegion = "us-east-1"
+)
+
+// s3Store stores the objects in an S3 bucket, or a bucket of an S3 compatible storage.
+type s3Store struct {
+	endpoint    string
+	bucket      string
+	prefix      string
+	region      string
+	pathStyle   bool
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+// newS3Store creates the store of the bucket. Static credentials are used if configured, and the credentials of the
+// default AWS credential chain otherwise.
+func newS3Store(ctx context.Context, cfg *config.ObjectStorageConfig, bucket, prefix string, httpClient *http.Client) (*s3Store, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")))
+	}
+	// the SDK sends its requests with the default transport, to which the egress configuration applies
+	opts = append(opts, awsconfig.WithHTTPClient(http.DefaultClient))
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load AWS config: %v", err)
+	}
+	region := awsCfg.Region
+	if region == "" && cfg.Endpoint != "" {
+		region = defaultS3CompatibleRegion
+	}
+	if region == "" {
+		return nil, errors.New("the region of the S3 bucket is not configured")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	return &s3Store{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		bucket:      bucket,
+		prefix:      prefix,
+		region:      region,
+		pathStyle:   cfg.PathStyle,
+		credentials: awsCfg.Credentials,
+		signer:      v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true }),
+		httpClient:  httpClient,
+		now:         time.Now,
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType, nil)
+	if err != nil {
+		return fmt.Errorf("could not put object %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// Create puts the object with a condition on the absence of the object, which S3 supports since August 2024.
+func (s *s3Store) Create(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType, http.Header{"If-None-Match": {"*"}})
+	if err != nil {
+		return fmt.Errorf("could not create object %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// do sends the request of the object signed with Signature Version 4. The key is escaped in the path like by the
+// AWS SDK, as S3 derives the canonical path of the signature from the path escaped once.
+func (s *s3Store) do(ctx context.Context, method, key string, data []byte, contentType string, header http.Header) (*http.Response, error) {
+	sum := sha256.Sum256(data)
+	return s.doBody(ctx, method, key, bytes.NewReader(data), int64(len(data)), hex.EncodeToString(sum[:]), contentType, header)
+}
+
+// Upload puts the object with a single request, which S3 limits to 5 GiB.
+func (s *s3Store) Upload(ctx context.Context, key string, f *os.File, size int64, sha256 string, contentType string) error {
+	if size > maxS3UploadSize {
+		return fmt.Errorf("could not upload object %s: size %d exceeds the maximum of a single upload", key, size)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("could not upload object %s: %v", key, err)
+	}
+	// the file is not closed by the HTTP client
+	resp, err := s.doBody(ctx, http.MethodPut, key, io.NopCloser(f), size, sha256, contentType, nil)
+	if err != nil {
+		return fmt.Errorf("could not upload object %s: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// Presign returns a URL of the object signed with Signature Version 4 in its query, whose response is downloaded
+// as an attachment with the file name.
+func (s *s3Store) Presign(ctx context.Context, key, filename string, expiry time.Duration) (string, error) {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL, nil)
+	if err != nil {
+		return "", err
+	}
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	req.URL.RawQuery = query.Encode()
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve AWS credentials: %v", err)
+	}
+	presigned, _, err := s.signer.PresignHTTP(ctx, credentials, req, "UNSIGNED-PAYLOAD", "s3", s.region, s.now())
+	if err != nil {
+		return "", fmt.Errorf("could not presign url of object %s: %v", key, err)
+	}
+	return presigned, nil
+}
+
+// doBody sends the request of the object signed with Signature Version 4, whose body has the size and hex encoded
+// SHA-256 payload hash.
+func (s *s3Store) doBody(ctx context.Context, method, key string, body io.Reader, size int64, payloadHash, contentType string,
+	header http.Header,
+) (*http.Response, error) {
+	objectURL, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve AWS credentials: %v", err)
+	}
+	if err := s.signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", s.region, s.now()); err != nil {
+		return nil, fmt.Errorf("could not sign request: %v", err)
+	}
+	return send(s.httpClient, req)
+}
+
+// objectURL returns the URL of the object, whose bucket is addressed in the host unless path style is configured.
+func (s *s3Store) objectURL(key string) (string, error) {
+	u, err := url.Parse(s.endpoint)
+	if err != nil {
+		return "", err
+	}
+	objectPath := "/" + s.prefix + key
+	if s.pathStyle {
+		objectPath = "/" + s.bucket + objectPath
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = objectPath
+	u.RawPath = httpbinding.EscapePath(objectPath, false)
+	return u.String(), nil
+}
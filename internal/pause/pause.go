@@ -0,0 +1,224 @@
+// Package pause pauses and resumes the ingestion of the data of Yunikorn and the periodic jobs, e.g. during the
+// maintenance of the database. The pauses are stored in the database, so that they apply to all replicas and
+// persist across restarts.
+package pause
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Ingestion is the operation ingesting the data of Yunikorn, i.e. the sync with Yunikorn and the batches forwarded
+// by collectors.
+const Ingestion = "ingestion"
+
+// Operations are the operations which can be paused: the ingestion and the periodic jobs.
+var Operations = append([]string{Ingestion}, config.JobNames...)
+
+// ErrUnknownOperation is returned for operations which cannot be paused.
+var ErrUnknownOperation = errors.New("unknown operation")
+
+var operationPaused = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "yhs",
+	Name:      "operation_paused",
+	Help:      "Whether the operation, the ingestion or a periodic job, is paused on this replica (1) or not (0).",
+}, []string{"operation"})
+
+// Repository stores the pauses of the operations.
+type Repository interface {
+	PauseOperation(ctx context.Context, op *model.PausedOperation) error
+	ResumeOperation(ctx context.Context, name string) error
+	GetPausedOperations(ctx context.Context) ([]*model.PausedOperation, error)
+}
+
+// Controller pauses and resumes the operations. It reloads the pauses from the database at an interval, so that
+// the pauses made on other replicas apply to this replica too.
+type Controller struct {
+	repo     Repository
+	interval time.Duration
+
+	mutex  sync.RWMutex
+	paused map[string]*model.PausedOperation
+	// changed is closed and replaced when the pauses change
+	changed chan struct{}
+}
+
+type Option func(*Controller)
+
+// WithInterval sets the interval at which the pauses are reloaded from the database.
+func WithInterval(interval time.Duration) Option {
+	return func(c *Controller) {
+		c.interval = interval
+	}
+}
+
+func NewController(repo Repository, opts ...Option) *Controller {
+	c := &Controller{
+		repo:     repo,
+		interval: 10 * time.Second,
+		paused:   make(map[string]*model.PausedOperation),
+		changed:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run reloads the pauses at the configured interval until the context is cancelled.
+func (c *Controller) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "pause_controller")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Info("starting pause controller")
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warn("shutting down pause controller")
+			return nil
+		case <-ticker.C:
+			if err := c.Load(ctx); err != nil {
+				logger.Errorf("error loading paused operations: %v", err)
+			}
+		}
+	}
+}
+
+// Load loads the pauses from the database.
+func (c *Controller) Load(ctx context.Context) error {
+	ops, err := c.repo.GetPausedOperations(ctx)
+	if err != nil {
+		return err
+	}
+	paused := make(map[string]*model.PausedOperation, len(ops))
+	for _, op := range ops {
+		paused[op.Name] = op
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, name := range Operations {
+		if (c.paused[name] == nil) != (paused[name] == nil) {
+			if paused[name] != nil {
+				log.FromContext(ctx).Infow("operation was paused", "operation", name, "reason", paused[name].Reason)
+			} else {
+				log.FromContext(ctx).Infow("operation was resumed", "operation", name)
+			}
+		}
+	}
+	c.set(paused)
+	return nil
+}
+
+// Paused returns the pause of the operation, or nil if it is not paused.
+func (c *Controller) Paused(name string) *model.PausedOperation {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.paused[name]
+}
+
+// Pause pauses the operation and returns its pause. If the operation is already paused, its pause is kept.
+func (c *Controller) Pause(ctx context.Context, name, pausedBy, reason string) (*model.PausedOperation, error) {
+	if !slices.Contains(Operations, name) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOperation, name)
+	}
+	op := &model.PausedOperation{Name: name, Reason: reason, PausedBy: pausedBy}
+	if err := c.repo.PauseOperation(ctx, op); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	paused := maps.Clone(c.paused)
+	paused[name] = op
+	c.set(paused)
+	return op, nil
+}
+
+// Resume resumes the operation. Resuming an operation which is not paused has no effect.
+func (c *Controller) Resume(ctx context.Context, name string) error {
+	if !slices.Contains(Operations, name) {
+		return fmt.Errorf("%w: %s", ErrUnknownOperation, name)
+	}
+	if err := c.repo.ResumeOperation(ctx, name); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	paused := maps.Clone(c.paused)
+	delete(paused, name)
+	c.set(paused)
+	return nil
+}
+
+// RunUnlessPaused runs the long-running operation until the context is cancelled, except while it is paused:
+// the context of the run is cancelled when the operation is paused, and the operation is run again once it is
+// resumed. It returns the error of a run which returned while the operation was not paused.
+func (c *Controller) RunUnlessPaused(ctx context.Context, name string, run func(context.Context) error) error {
+	logger := log.FromContext(ctx)
+	for {
+		if !c.waitUntil(ctx, name, false) {
+			return nil
+		}
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			// stop waiting for a pause once the run returned
+			defer cancel()
+			done <- run(runCtx)
+		}()
+		paused := c.waitUntil(runCtx, name, true)
+		cancel()
+		err := <-done
+		if !paused || ctx.Err() != nil {
+			return err
+		}
+		logger.Warnw("operation is paused", "operation", name)
+	}
+}
+
+// waitUntil waits until the operation is paused or not, and returns false if the context was cancelled before.
+func (c *Controller) waitUntil(ctx context.Context, name string, paused bool) bool {
+	for {
+		c.mutex.RLock()
+		isPaused, changed := c.paused[name] != nil, c.changed
+		c.mutex.RUnlock()
+		if isPaused == paused {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// set replaces the pauses and wakes the operations waiting for a change. The controller must be locked.
+func (c *Controller) set(paused map[string]*model.PausedOperation) {
+	c.paused = paused
+	for _, name := range Operations {
+		value := 0.0
+		if paused[name] != nil {
+			value = 1
+		}
+		operationPaused.WithLabelValues(name).Set(value)
+	}
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
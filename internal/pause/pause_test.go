@@ -0,0 +1,133 @@
+package pause
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+type fakeRepository struct {
+	mutex  sync.Mutex
+	paused map[string]*model.PausedOperation
+}
+
+func (r *fakeRepository) PauseOperation(_ context.Context, op *model.PausedOperation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if existing, ok := r.paused[op.Name]; ok {
+		*op = *existing
+		return nil
+	}
+	op.PausedAt = time.Now().Unix()
+	stored := *op
+	r.paused[op.Name] = &stored
+	return nil
+}
+
+func (r *fakeRepository) ResumeOperation(_ context.Context, name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.paused, name)
+	return nil
+}
+
+func (r *fakeRepository) GetPausedOperations(context.Context) ([]*model.PausedOperation, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var ops []*model.PausedOperation
+	for _, op := range r.paused {
+		stored := *op
+		ops = append(ops, &stored)
+	}
+	return ops, nil
+}
+
+func TestController(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeRepository{paused: map[string]*model.PausedOperation{
+		"retention": {Name: "retention", Reason: "database maintenance", PausedBy: "alice", PausedAt: 1},
+	}}
+	c := NewController(repo)
+	assert.Nil(t, c.Paused("retention"), "pauses are not known until they are loaded")
+	require.NoError(t, c.Load(ctx))
+	assert.Equal(t, "database maintenance", c.Paused("retention").Reason)
+
+	op, err := c.Pause(ctx, Ingestion, "bob", "investigating corrupted data")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", op.PausedBy)
+	assert.NotZero(t, op.PausedAt)
+	assert.Equal(t, op, c.Paused(Ingestion))
+
+	// pausing a paused operation keeps its pause
+	op, err = c.Pause(ctx, "retention", "bob", "another reason")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", op.PausedBy)
+	assert.Equal(t, "database maintenance", op.Reason)
+
+	require.NoError(t, c.Resume(ctx, "retention"))
+	assert.Nil(t, c.Paused("retention"))
+	require.NoError(t, c.Resume(ctx, "retention"), "resuming is idempotent")
+
+	_, err = c.Pause(ctx, "compaction", "bob", "unknown")
+	assert.ErrorIs(t, err, ErrUnknownOperation)
+	assert.ErrorIs(t, c.Resume(ctx, "compaction"), ErrUnknownOperation)
+
+	// pauses made by other replicas apply once they are reloaded
+	other := NewController(repo, WithInterval(time.Millisecond))
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- other.Run(runCtx)
+	}()
+	require.Eventually(t, func() bool { return other.Paused(Ingestion) != nil }, 5*time.Second, time.Millisecond)
+	require.NoError(t, c.Resume(ctx, Ingestion))
+	require.Eventually(t, func() bool { return other.Paused(Ingestion) == nil }, 5*time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestController_RunUnlessPaused(t *testing.T) {
+	ctx := context.Background()
+	c := NewController(&fakeRepository{paused: make(map[string]*model.PausedOperation)})
+	var runs, running atomic.Int32
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- c.RunUnlessPaused(runCtx, Ingestion, func(ctx context.Context) error {
+			runs.Add(1)
+			running.Store(1)
+			defer running.Store(0)
+			<-ctx.Done()
+			return nil
+		})
+	}()
+	require.Eventually(t, func() bool { return running.Load() == 1 }, 5*time.Second, time.Millisecond)
+
+	// the run is cancelled when the operation is paused, and the operation is run again once it is resumed
+	_, err := c.Pause(ctx, Ingestion, "alice", "database maintenance")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return running.Load() == 0 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), runs.Load())
+	require.NoError(t, c.Resume(ctx, Ingestion))
+	require.Eventually(t, func() bool { return running.Load() == 1 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), runs.Load())
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, int32(0), running.Load())
+}
+
+func TestController_RunUnlessPaused_Error(t *testing.T) {
+	c := NewController(&fakeRepository{paused: make(map[string]*model.PausedOperation)})
+	err := c.RunUnlessPaused(context.Background(), Ingestion, func(context.Context) error {
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Source identifies where a policy was defined.
+type Source string
+
+const (
+	// SourceConfig is used for policies defined in the configuration file.
+	SourceConfig Source = "config"
+	// SourceKubernetes is used for policies applied by the Kubernetes controller from custom resources.
+	SourceKubernetes Source = "kubernetes"
+)
+
+// RetentionPolicy specifies how long finished applications in a queue subtree are kept.
+type RetentionPolicy struct {
+	// Name uniquely identifies the policy.
+	Name string `json:"name"`
+	// Source is where the policy was defined.
+	Source Source `json:"source"`
+	// Partition the policy applies to. An empty partition matches all partitions.
+	Partition string `json:"partition,omitempty"`
+	// Queue is the root of the queue subtree the policy applies to, e.g. root.compliance.
+	// An empty queue matches all queues.
+	Queue string `json:"queue,omitempty"`
+	// ApplicationTTL is the duration finished applications are kept for. Zero means they are kept forever.
+	ApplicationTTL time.Duration `json:"applicationTTL"`
+}
+
+// Validate returns an error if the policy is not valid.
+func (p *RetentionPolicy) Validate() error {
+	var errorMessages []string
+	if p.Name == "" {
+		errorMessages = append(errorMessages, "name is required")
+	}
+	if p.ApplicationTTL < 0 {
+		errorMessages = append(errorMessages, "application TTL must not be negative")
+	}
+	if err := validateQueue(p.Queue); err != nil {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("retention policy validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+// AlertRule fires when the number of applications in a queue subtree which finished in a given state
+// within a sliding window reaches a threshold.
+type AlertRule struct {
+	// Name uniquely identifies the rule.
+	Name string `json:"name"`
+	// Source is where the rule was defined.
+	Source Source `json:"source"`
+	// Partition the rule applies to. An empty partition matches all partitions.
+	Partition string `json:"partition,omitempty"`
+	// Queue is the root of the queue subtree the rule applies to. An empty queue matches all queues.
+	Queue string `json:"queue,omitempty"`
+	// State is the final application state which is counted, e.g. Failed or Rejected.
+	State string `json:"state"`
+	// Threshold is the number of applications at which the rule fires.
+	Threshold int `json:"threshold"`
+	// Window is the sliding window in which applications are counted.
+	Window time.Duration `json:"window"`
+	// Severity is a free-form severity attached to notifications, e.g. warning or critical.
+	Severity string `json:"severity,omitempty"`
+}
+
+// Validate returns an error if the rule is not valid.
+func (r *AlertRule) Validate() error {
+	var errorMessages []string
+	if r.Name == "" {
+		errorMessages = append(errorMessages, "name is required")
+	}
+	if r.State == "" {
+		errorMessages = append(errorMessages, "state is required")
+	}
+	if r.Threshold < 1 {
+		errorMessages = append(errorMessages, "threshold must be greater than zero")
+	}
+	if r.Window <= 0 {
+		errorMessages = append(errorMessages, "window must be greater than zero")
+	}
+	if err := validateQueue(r.Queue); err != nil {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("alert rule validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+func validateQueue(queue string) error {
+	if queue == "" {
+		return nil
+	}
+	for _, part := range strings.Split(queue, ".") {
+		if part == "" {
+			return fmt.Errorf("invalid queue name %q", queue)
+		}
+	}
+	return nil
+}
+
+// MatchesQueue returns true if queue is the given subtree root or one of its descendants.
+// An empty subtree matches all queues.
+func MatchesQueue(subtree, queue string) bool {
+	if subtree == "" || subtree == queue {
+		return true
+	}
+	return strings.HasPrefix(queue, subtree+".")
+}
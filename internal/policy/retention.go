@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// DefaultRetentionPolicyName is the name of the retention policy created from the default application TTL
+// in the configuration file.
+const DefaultRetentionPolicyName = "config:default"
+
+// ApplyRetentionConfig applies the default application TTL and the queue overrides from the configuration file
+// as retention policies. Overrides are named after their queue, e.g. config:root.compliance or
+// config:default/root.compliance if the override is restricted to the default partition.
+func (s *Store) ApplyRetentionConfig(cfg *config.RetentionConfig) error {
+	policies := []RetentionPolicy{{
+		Name:           DefaultRetentionPolicyName,
+		Source:         SourceConfig,
+		ApplicationTTL: cfg.ApplicationTTL,
+	}}
+	for _, o := range cfg.Overrides {
+		name := "config:" + o.Queue
+		if o.Partition != "" {
+			name = "config:" + o.Partition + "/" + o.Queue
+		}
+		policies = append(policies, RetentionPolicy{
+			Name:           name,
+			Source:         SourceConfig,
+			Partition:      o.Partition,
+			Queue:          o.Queue,
+			ApplicationTTL: o.ApplicationTTL,
+		})
+	}
+	for _, p := range policies {
+		if err := s.ApplyRetentionPolicy(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EffectiveRetentionPolicy returns the retention policy which applies to the given queue, if any.
+// The policy with the most specific queue subtree wins, policies restricted to the partition win over policies
+// matching all partitions, and policies applied from custom resources win over policies from the configuration file.
+func (s *Store) EffectiveRetentionPolicy(partition, queue string) (RetentionPolicy, bool) {
+	var effective *RetentionPolicy
+	for _, p := range s.RetentionPolicies() {
+		if (p.Partition != "" && p.Partition != partition) || !MatchesQueue(p.Queue, queue) {
+			continue
+		}
+		if effective == nil || moreSpecific(&p, effective) {
+			effective = &p
+		}
+	}
+	if effective == nil {
+		return RetentionPolicy{}, false
+	}
+	return *effective, true
+}
+
+// moreSpecific returns true if policy a takes precedence over policy b.
+// Policies are ordered by name, so ties are broken in favour of the first policy by name.
+func moreSpecific(a, b *RetentionPolicy) bool {
+	if depthA, depthB := queueDepth(a.Queue), queueDepth(b.Queue); depthA != depthB {
+		return depthA > depthB
+	}
+	if (a.Partition != "") != (b.Partition != "") {
+		return a.Partition != ""
+	}
+	return a.Source == SourceKubernetes && b.Source != SourceKubernetes
+}
+
+func queueDepth(queue string) int {
+	if queue == "" {
+		return 0
+	}
+	return strings.Count(queue, ".") + 1
+}
@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestStore_EffectiveRetentionPolicy(t *testing.T) {
+	s := NewStore()
+	require.NoError(t, s.ApplyRetentionConfig(&config.RetentionConfig{
+		ApplicationTTL: 2160 * time.Hour,
+		Overrides: []config.RetentionOverride{
+			{Queue: "root.compliance", ApplicationTTL: 17520 * time.Hour},
+			{Partition: "gpu", Queue: "root.compliance", ApplicationTTL: 8760 * time.Hour},
+			{Queue: "root.scratch", ApplicationTTL: 24 * time.Hour},
+		},
+	}))
+	require.NoError(t, s.ApplyRetentionPolicy(RetentionPolicy{
+		Name:           "yunikorn/scratch",
+		Source:         SourceKubernetes,
+		Queue:          "root.scratch",
+		ApplicationTTL: 12 * time.Hour,
+	}))
+
+	tests := []struct {
+		partition string
+		queue     string
+		want      string
+	}{
+		{partition: "default", queue: "root.default", want: DefaultRetentionPolicyName},
+		{partition: "default", queue: "root", want: DefaultRetentionPolicyName},
+		{partition: "default", queue: "root.compliance", want: "config:root.compliance"},
+		{partition: "default", queue: "root.compliance.audit", want: "config:root.compliance"},
+		{partition: "default", queue: "root.compliancex", want: DefaultRetentionPolicyName},
+		{partition: "gpu", queue: "root.compliance.audit", want: "config:gpu/root.compliance"},
+		{partition: "default", queue: "root.scratch.tmp", want: "yunikorn/scratch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.partition+"/"+tt.queue, func(t *testing.T) {
+			p, ok := s.EffectiveRetentionPolicy(tt.partition, tt.queue)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, p.Name)
+		})
+	}
+}
+
+func TestStore_EffectiveRetentionPolicyNone(t *testing.T) {
+	s := NewStore()
+	require.NoError(t, s.ApplyRetentionPolicy(RetentionPolicy{Name: "p", Partition: "gpu", ApplicationTTL: time.Hour}))
+
+	_, ok := s.EffectiveRetentionPolicy("default", "root.a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"sort"
+	"sync"
+)
+
+// Store holds the retention policies and alert rules currently applied to the server.
+// Policies can be applied and removed at runtime, e.g. by the Kubernetes controller.
+type Store struct {
+	mutex     sync.RWMutex
+	retention map[string]*RetentionPolicy
+	alerts    map[string]*AlertRule
+}
+
+// NewStore creates an empty Store.
+func NewStore() *Store {
+	return &Store{
+		retention: make(map[string]*RetentionPolicy),
+		alerts:    make(map[string]*AlertRule),
+	}
+}
+
+// ApplyRetentionPolicy validates the policy and adds it to the store, replacing any policy with the same name.
+func (s *Store) ApplyRetentionPolicy(p RetentionPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.retention[p.Name] = &p
+	return nil
+}
+
+// DeleteRetentionPolicy removes the policy with the given name and returns true if it existed.
+func (s *Store) DeleteRetentionPolicy(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.retention[name]
+	delete(s.retention, name)
+	return ok
+}
+
+// RetentionPolicies returns a copy of all retention policies ordered by name.
+func (s *Store) RetentionPolicies() []RetentionPolicy {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	policies := make([]RetentionPolicy, 0, len(s.retention))
+	for _, p := range s.retention {
+		policies = append(policies, *p)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies
+}
+
+// ApplyAlertRule validates the rule and adds it to the store, replacing any rule with the same name.
+func (s *Store) ApplyAlertRule(r AlertRule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.alerts[r.Name] = &r
+	return nil
+}
+
+// DeleteAlertRule removes the rule with the given name and returns true if it existed.
+func (s *Store) DeleteAlertRule(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.alerts[name]
+	delete(s.alerts, name)
+	return ok
+}
+
+// AlertRules returns a copy of all alert rules ordered by name.
+func (s *Store) AlertRules() []AlertRule {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	rules := make([]AlertRule, 0, len(s.alerts))
+	for _, r := range s.alerts {
+		rules = append(rules, *r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_RetentionPolicies(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.ApplyRetentionPolicy(RetentionPolicy{Name: "b", Queue: "root.b", ApplicationTTL: time.Hour}))
+	require.NoError(t, s.ApplyRetentionPolicy(RetentionPolicy{Name: "a", ApplicationTTL: 2 * time.Hour}))
+	require.NoError(t, s.ApplyRetentionPolicy(RetentionPolicy{Name: "b", Queue: "root.b", ApplicationTTL: 3 * time.Hour}))
+
+	policies := s.RetentionPolicies()
+	require.Len(t, policies, 2)
+	assert.Equal(t, "a", policies[0].Name)
+	assert.Equal(t, "b", policies[1].Name)
+	assert.Equal(t, 3*time.Hour, policies[1].ApplicationTTL)
+
+	assert.True(t, s.DeleteRetentionPolicy("a"))
+	assert.False(t, s.DeleteRetentionPolicy("a"))
+	assert.Len(t, s.RetentionPolicies(), 1)
+}
+
+func TestStore_ApplyRetentionPolicyInvalid(t *testing.T) {
+	s := NewStore()
+
+	err := s.ApplyRetentionPolicy(RetentionPolicy{Name: "invalid", Queue: "root..a", ApplicationTTL: -time.Hour})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "application TTL must not be negative")
+	assert.Contains(t, err.Error(), "invalid queue name")
+	assert.Empty(t, s.RetentionPolicies())
+}
+
+func TestStore_AlertRules(t *testing.T) {
+	s := NewStore()
+
+	rule := AlertRule{Name: "failures", Queue: "root.batch", State: "Failed", Threshold: 5, Window: time.Hour}
+	require.NoError(t, s.ApplyAlertRule(rule))
+	assert.Equal(t, []AlertRule{rule}, s.AlertRules())
+
+	err := s.ApplyAlertRule(AlertRule{Name: "invalid"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "state is required")
+	assert.Contains(t, err.Error(), "threshold must be greater than zero")
+	assert.Contains(t, err.Error(), "window must be greater than zero")
+
+	assert.True(t, s.DeleteAlertRule("failures"))
+	assert.Empty(t, s.AlertRules())
+}
+
+func TestMatchesQueue(t *testing.T) {
+	tests := []struct {
+		subtree string
+		queue   string
+		want    bool
+	}{
+		{subtree: "", queue: "root.a", want: true},
+		{subtree: "root.a", queue: "root.a", want: true},
+		{subtree: "root.a", queue: "root.a.b", want: true},
+		{subtree: "root.a", queue: "root.ab", want: false},
+		{subtree: "root.a.b", queue: "root.a", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subtree+"/"+tt.queue, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchesQueue(tt.subtree, tt.queue))
+		})
+	}
+}
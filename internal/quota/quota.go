@@ -0,0 +1,160 @@
+// Package quota tracks the daily usage of the API clients of the expensive endpoints, and rejects their requests
+// once they exceed their quotas, so that a single client cannot overload the shared database. The usage is tracked
+// in memory, so each replica enforces the quotas on the requests it serves.
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// ErrExceeded is returned for the requests of clients which exceeded their quota of the day.
+var ErrExceeded = errors.New("daily quota exceeded")
+
+var (
+	queryTimeSecondsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "quota",
+		Name:      "query_time_seconds_total",
+		Help:      "Time the database spent on the queries of the requests of the clients with quotas.",
+	}, []string{"client"})
+	rejectedRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "quota",
+		Name:      "rejected_requests_total",
+		Help:      "Number of requests rejected because their client exceeded its daily quota.",
+	}, []string{"client"})
+)
+
+// Limits are the daily quotas of a client. Zero limits are unlimited.
+type Limits struct {
+	Requests  int
+	QueryTime time.Duration
+}
+
+// Unlimited returns whether the client has no quota.
+func (l Limits) Unlimited() bool {
+	return l.Requests <= 0 && l.QueryTime <= 0
+}
+
+// State is the usage of the quotas of a client on the current day, which resets at Reset.
+type State struct {
+	Limits
+	Requests  int
+	QueryTime time.Duration
+	Reset     time.Time
+}
+
+// RemainingRequests returns the number of requests the client can still make today.
+func (s State) RemainingRequests() int {
+	return max(s.Limits.Requests-s.Requests, 0)
+}
+
+// RemainingQueryTime returns the time the database can still spend on the queries of the client today.
+func (s State) RemainingQueryTime() time.Duration {
+	return max(s.Limits.QueryTime-s.QueryTime, 0)
+}
+
+// exceeded returns whether the client used up any of its quotas.
+func (s State) exceeded() bool {
+	return (s.Limits.Requests > 0 && s.Requests >= s.Limits.Requests) ||
+		(s.Limits.QueryTime > 0 && s.QueryTime >= s.Limits.QueryTime)
+}
+
+// usage is the usage of a client on the current day.
+type usage struct {
+	requests  int
+	queryTime time.Duration
+}
+
+// Tracker tracks the usage of the clients on the current day.
+type Tracker struct {
+	defaults  Limits
+	overrides map[string]Limits
+	now       func() time.Time
+
+	mu    sync.Mutex
+	day   time.Time
+	usage map[string]*usage
+}
+
+// New creates the tracker of the quotas of the configuration, or returns nil if no client has a quota.
+func New(cfg *config.QuotasConfig) *Tracker {
+	if !cfg.Enabled() {
+		return nil
+	}
+	t := &Tracker{
+		defaults:  Limits{Requests: cfg.DailyRequests, QueryTime: cfg.DailyQueryTime},
+		overrides: make(map[string]Limits, len(cfg.Overrides)),
+		now:       time.Now,
+		usage:     make(map[string]*usage),
+	}
+	for _, o := range cfg.Overrides {
+		t.overrides[o.Client] = Limits{Requests: o.DailyRequests, QueryTime: o.DailyQueryTime}
+	}
+	return t
+}
+
+// Limits returns the daily quotas of the client.
+func (t *Tracker) Limits(client string) Limits {
+	if limits, ok := t.overrides[client]; ok {
+		return limits
+	}
+	return t.defaults
+}
+
+// Begin counts a request of the client, and returns the state of its quotas including the request. It returns
+// ErrExceeded without counting the request if the client exceeded its quotas, in which case the request must be
+// rejected.
+func (t *Tracker) Begin(client string) (State, error) {
+	limits := t.Limits(client)
+	if limits.Unlimited() {
+		return State{}, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u := t.current(client)
+	state := State{Limits: limits, Requests: u.requests, QueryTime: u.queryTime, Reset: t.day.AddDate(0, 0, 1)}
+	if state.exceeded() {
+		rejectedRequestsTotal.WithLabelValues(client).Inc()
+		return state, fmt.Errorf("%w: client %s can make more requests after %s", ErrExceeded, client,
+			state.Reset.Format(time.RFC3339))
+	}
+	u.requests++
+	state.Requests++
+	return state, nil
+}
+
+// AddQueryTime adds the time the database spent on the queries of a request of the client to its usage.
+func (t *Tracker) AddQueryTime(client string, queryTime time.Duration) {
+	if t.Limits(client).Unlimited() {
+		return
+	}
+	queryTimeSecondsTotal.WithLabelValues(client).Add(queryTime.Seconds())
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current(client).queryTime += queryTime
+}
+
+// current returns the usage of the client on the current day, resetting the usage of all clients when the day
+// changed. It must be called with the lock held.
+func (t *Tracker) current(client string) *usage {
+	day := t.now().UTC().Truncate(24 * time.Hour)
+	if !day.Equal(t.day) {
+		t.day = day
+		clear(t.usage)
+	}
+	u, ok := t.usage[client]
+	if !ok {
+		u = &usage{}
+		t.usage[client] = u
+	}
+	return u
+}
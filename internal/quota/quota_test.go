@@ -0,0 +1,70 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestNew_Disabled(t *testing.T) {
+	assert.Nil(t, New(&config.QuotasConfig{}))
+	assert.Nil(t, New(&config.QuotasConfig{Overrides: []config.QuotaOverride{{Client: "ops"}}}))
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2024, 7, 1, 22, 0, 0, 0, time.UTC)
+	tracker := New(&config.QuotasConfig{
+		DailyRequests:  2,
+		DailyQueryTime: time.Minute,
+		Overrides: []config.QuotaOverride{
+			{Client: "ops"},
+			{Client: "notebooks", DailyRequests: 10, DailyQueryTime: 10 * time.Second},
+		},
+	})
+	require.NotNil(t, tracker)
+	tracker.now = func() time.Time { return now }
+	reset := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+
+	state, err := tracker.Begin("dashboards")
+	require.NoError(t, err)
+	assert.Equal(t, State{Limits: Limits{Requests: 2, QueryTime: time.Minute}, Requests: 1, Reset: reset}, state)
+	assert.Equal(t, 1, state.RemainingRequests())
+	tracker.AddQueryTime("dashboards", 20*time.Second)
+	state, err = tracker.Begin("dashboards")
+	require.NoError(t, err)
+	assert.Equal(t, 0, state.RemainingRequests())
+	assert.Equal(t, 40*time.Second, state.RemainingQueryTime())
+
+	// the request quota is used up
+	_, err = tracker.Begin("dashboards")
+	assert.ErrorIs(t, err, ErrExceeded)
+
+	// the query time quota is used up, even though requests are left
+	_, err = tracker.Begin("notebooks")
+	require.NoError(t, err)
+	tracker.AddQueryTime("notebooks", 15*time.Second)
+	state, err = tracker.Begin("notebooks")
+	assert.ErrorIs(t, err, ErrExceeded)
+	assert.Equal(t, 1, state.Requests)
+	assert.Equal(t, time.Duration(0), state.RemainingQueryTime())
+
+	// clients without quota are not tracked
+	for range 5 {
+		state, err = tracker.Begin("ops")
+		require.NoError(t, err)
+		assert.True(t, state.Unlimited())
+	}
+
+	// the quotas reset at midnight UTC
+	now = reset.Add(time.Second)
+	state, err = tracker.Begin("dashboards")
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.Requests)
+	assert.Equal(t, reset.AddDate(0, 0, 1), state.Reset)
+	_, err = tracker.Begin("notebooks")
+	assert.NoError(t, err)
+}
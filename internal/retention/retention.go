@@ -0,0 +1,165 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+// EffectivePolicy is the retention policy which applies to a queue.
+type EffectivePolicy struct {
+	Partition string `json:"partition"`
+	Queue     string `json:"queue"`
+	// Policy is the effective retention policy of the queue. Finished applications are kept forever if it is nil.
+	Policy *policy.RetentionPolicy `json:"policy,omitempty"`
+}
+
+// Pruner deletes finished applications which are older than the application TTL of the effective retention policy
+// of their queue, and the entries of the applications and containers history which are older than the history TTL.
+// It deletes in batches so that the rows of the tables are not locked for long. It is run by the scheduler.
+type Pruner struct {
+	repo       repository.Repository
+	store      *policy.Store
+	historyTTL time.Duration
+	batchSize  int
+	now        func() time.Time
+}
+
+type Option func(*Pruner)
+
+// WithHistoryTTL sets how long the entries of the applications and containers history are kept.
+// They are kept forever if it is 0.
+func WithHistoryTTL(ttl time.Duration) Option {
+	return func(p *Pruner) {
+		p.historyTTL = ttl
+	}
+}
+
+// WithBatchSize sets the number of rows deleted per statement.
+func WithBatchSize(batchSize int) Option {
+	return func(p *Pruner) {
+		p.batchSize = batchSize
+	}
+}
+
+func NewPruner(repo repository.Repository, store *policy.Store, opts ...Option) *Pruner {
+	p := &Pruner{
+		repo:      repo,
+		store:     store,
+		batchSize: 1000,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// EffectivePolicies returns the effective retention policy of every known queue ordered by partition and queue.
+// If partition is not empty, only the queues of that partition are returned.
+func (p *Pruner) EffectivePolicies(ctx context.Context, partition string) ([]EffectivePolicy, error) {
+	queues, err := p.repo.GetAllQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]EffectivePolicy, 0, len(queues))
+	for _, q := range queues {
+		if partition != "" && q.Partition != partition {
+			continue
+		}
+		effective := EffectivePolicy{Partition: q.Partition, Queue: q.QueueName}
+		if rp, ok := p.store.EffectiveRetentionPolicy(q.Partition, q.QueueName); ok {
+			effective.Policy = &rp
+		}
+		policies = append(policies, effective)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Partition != policies[j].Partition {
+			return policies[i].Partition < policies[j].Partition
+		}
+		return policies[i].Queue < policies[j].Queue
+	})
+	return policies, nil
+}
+
+// Prune deletes the finished applications of every queue which are older than the application TTL
+// of the effective retention policy of the queue, and the history entries which are older than the history TTL.
+// A queue which cannot be pruned does not prevent the others or the history from being pruned, but fails the run.
+func (p *Pruner) Prune(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	policies, err := p.EffectivePolicies(ctx, "")
+	if err != nil {
+		return err
+	}
+	now := p.now()
+	var total int64
+	queues, failed := 0, 0
+	for _, ep := range policies {
+		if ep.Policy == nil || ep.Policy.ApplicationTTL == 0 {
+			continue
+		}
+		queues++
+		before := now.Add(-ep.Policy.ApplicationTTL)
+		deleted, err := p.deleteInBatches(ctx, func(limit int) (int64, error) {
+			return p.repo.DeleteApplicationsFinishedBefore(ctx, ep.Partition, ep.Queue, before, limit)
+		})
+		if deleted > 0 {
+			logger.Infow("pruned applications", "partition", ep.Partition, "queue", ep.Queue,
+				"policy", ep.Policy.Name, "deleted", deleted)
+		}
+		total += deleted
+		if err != nil {
+			logger.Errorw("could not prune applications", "partition", ep.Partition, "queue", ep.Queue, "error", err)
+			failed++
+		}
+	}
+	logger.Infow("finished pruning applications", "deleted", total)
+
+	historyErr := p.pruneHistory(ctx, now)
+	if failed > 0 {
+		return errors.Join(fmt.Errorf("could not prune the applications of %d of %d queues", failed, queues), historyErr)
+	}
+	return historyErr
+}
+
+// pruneHistory deletes the entries of the applications and containers history which are older than the history TTL.
+func (p *Pruner) pruneHistory(ctx context.Context, now time.Time) error {
+	if p.historyTTL == 0 {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	before := now.Add(-p.historyTTL)
+	deleted, err := p.deleteInBatches(ctx, func(limit int) (int64, error) {
+		return p.repo.DeleteHistoryBefore(ctx, before, limit)
+	})
+	if err != nil {
+		logger.Errorw("could not prune history", "error", err)
+		return fmt.Errorf("could not prune the history: %w", err)
+	}
+	logger.Infow("finished pruning history", "before", before, "deleted", deleted)
+	return nil
+}
+
+// deleteInBatches calls deleteBatch batch by batch until a batch is not full and returns the number of deleted rows.
+func (p *Pruner) deleteInBatches(ctx context.Context, deleteBatch func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		deleted, err := deleteBatch(p.batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < int64(p.batchSize) {
+			return total, nil
+		}
+	}
+}
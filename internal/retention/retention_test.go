@@ -0,0 +1,104 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	store := policy.NewStore()
+	require.NoError(t, store.ApplyRetentionConfig(&config.RetentionConfig{
+		ApplicationTTL: 2160 * time.Hour,
+		Overrides: []config.RetentionOverride{
+			{Queue: "root.compliance", ApplicationTTL: 17520 * time.Hour},
+			{Queue: "root.archive", ApplicationTTL: 0},
+		},
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllQueues(gomock.Any()).Return([]*model.PartitionQueueDAOInfo{
+		newQueue("default", "root"),
+		newQueue("default", "root.compliance"),
+		newQueue("default", "root.compliance.audit"),
+		newQueue("default", "root.archive"),
+	}, nil)
+	gomock.InOrder(
+		repo.EXPECT().DeleteApplicationsFinishedBefore(gomock.Any(), "default", "root", now.Add(-2160*time.Hour), 2).
+			Return(int64(2), nil),
+		repo.EXPECT().DeleteApplicationsFinishedBefore(gomock.Any(), "default", "root", now.Add(-2160*time.Hour), 2).
+			Return(int64(1), nil),
+	)
+	repo.EXPECT().DeleteApplicationsFinishedBefore(gomock.Any(), "default", "root.compliance", now.Add(-17520*time.Hour), 2).
+		Return(int64(0), errors.New("connection refused"))
+	repo.EXPECT().DeleteApplicationsFinishedBefore(gomock.Any(), "default", "root.compliance.audit", now.Add(-17520*time.Hour), 2).
+		Return(int64(1), nil)
+
+	p := NewPruner(repo, store, WithBatchSize(2))
+	p.now = func() time.Time { return now }
+	assert.EqualError(t, p.Prune(context.Background()), "could not prune the applications of 1 of 3 queues")
+}
+
+func TestPruner_PruneHistory(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllQueues(gomock.Any()).Return(nil, nil).Times(2)
+	gomock.InOrder(
+		repo.EXPECT().DeleteHistoryBefore(gomock.Any(), now.Add(-720*time.Hour), 100).Return(int64(100), nil),
+		repo.EXPECT().DeleteHistoryBefore(gomock.Any(), now.Add(-720*time.Hour), 100).Return(int64(40), nil),
+		repo.EXPECT().DeleteHistoryBefore(gomock.Any(), now.Add(-720*time.Hour), 100).
+			Return(int64(0), errors.New("connection refused")),
+	)
+
+	p := NewPruner(repo, policy.NewStore(), WithHistoryTTL(720*time.Hour), WithBatchSize(100))
+	p.now = func() time.Time { return now }
+	require.NoError(t, p.Prune(context.Background()))
+	assert.EqualError(t, p.Prune(context.Background()), "could not prune the history: connection refused")
+}
+
+func TestPruner_EffectivePolicies(t *testing.T) {
+	store := policy.NewStore()
+	require.NoError(t, store.ApplyRetentionPolicy(policy.RetentionPolicy{
+		Name:           "yunikorn/compliance",
+		Source:         policy.SourceKubernetes,
+		Queue:          "root.compliance",
+		ApplicationTTL: 17520 * time.Hour,
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllQueues(gomock.Any()).Return([]*model.PartitionQueueDAOInfo{
+		newQueue("gpu", "root.compliance"),
+		newQueue("default", "root.compliance"),
+		newQueue("default", "root"),
+	}, nil)
+
+	policies, err := NewPruner(repo, store).EffectivePolicies(context.Background(), "default")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, EffectivePolicy{Partition: "default", Queue: "root"}, policies[0])
+	assert.Equal(t, "root.compliance", policies[1].Queue)
+	require.NotNil(t, policies[1].Policy)
+	assert.Equal(t, "yunikorn/compliance", policies[1].Policy.Name)
+}
+
+func newQueue(partition, name string) *model.PartitionQueueDAOInfo {
+	return &model.PartitionQueueDAOInfo{
+		PartitionQueueDAOInfo: dao.PartitionQueueDAOInfo{Partition: partition, QueueName: name},
+	}
+}
@@ -0,0 +1,58 @@
+// Package retry links the attempts of a job, the applications of a partition submitted with the same retry key,
+// into retry chains.
+package retry
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Chain returns the retry chain of the application with the given ID among the applications with its retry key:
+// the attempts of its job in the order they were submitted, numbered from 1. A job is retried until an attempt
+// completes, so a chain ends with the first completed attempt and a later application with the same retry key
+// starts a new chain. It returns nil if the application is not among the applications.
+func Chain(apps []*model.ApplicationDAOInfo, appID string) []*model.RetryAttempt {
+	sorted := make([]*model.ApplicationDAOInfo, 0, len(apps))
+	for _, app := range apps {
+		if app != nil {
+			sorted = append(sorted, app)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].SubmissionTime != sorted[j].SubmissionTime {
+			return sorted[i].SubmissionTime < sorted[j].SubmissionTime
+		}
+		return sorted[i].ApplicationID < sorted[j].ApplicationID
+	})
+
+	var chain []*model.RetryAttempt
+	found := false
+	for _, app := range sorted {
+		chain = append(chain, &model.RetryAttempt{
+			Attempt:        len(chain) + 1,
+			ApplicationID:  app.ApplicationID,
+			QueueName:      app.QueueName,
+			State:          app.State,
+			SubmissionTime: app.SubmissionTime,
+			FinishedTime:   app.FinishedTime,
+		})
+		found = found || app.ApplicationID == appID
+		if completed(app.State) {
+			if found {
+				return chain
+			}
+			chain = nil
+		}
+	}
+	if !found {
+		return nil
+	}
+	return chain
+}
+
+// completed returns whether the state, with or without the APP_ prefix of the scheduler, is the completed state.
+func completed(state string) bool {
+	return strings.TrimPrefix(strings.ToUpper(state), "APP_") == "COMPLETED"
+}
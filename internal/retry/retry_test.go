@@ -0,0 +1,53 @@
+package retry
+
+import (
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestChain(t *testing.T) {
+	app := func(id, state string, submitted int64) *model.ApplicationDAOInfo {
+		return &model.ApplicationDAOInfo{ApplicationDAOInfo: dao.ApplicationDAOInfo{
+			ApplicationID: id, QueueName: "root.default", State: state, SubmissionTime: submitted,
+		}}
+	}
+	apps := []*model.ApplicationDAOInfo{
+		app("run-3", "Completed", 300),
+		app("run-1", "Failed", 100),
+		app("run-2", "Failed", 200),
+		app("run-4", "Failed", 400),
+		app("run-5", "Running", 500),
+	}
+
+	tests := map[string]struct {
+		appID string
+		want  []string
+	}{
+		"first attempt":              {appID: "run-1", want: []string{"run-1", "run-2", "run-3"}},
+		"completed attempt":          {appID: "run-3", want: []string{"run-1", "run-2", "run-3"}},
+		"attempt after a completion": {appID: "run-4", want: []string{"run-4", "run-5"}},
+		"unknown application":        {appID: "run-6", want: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain := Chain(apps, tt.appID)
+			var ids []string
+			for i, attempt := range chain {
+				assert.Equal(t, i+1, attempt.Attempt)
+				ids = append(ids, attempt.ApplicationID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestCompleted(t *testing.T) {
+	assert.True(t, completed("Completed"))
+	assert.True(t, completed("APP_COMPLETED"))
+	assert.False(t, completed("Failed"))
+	assert.False(t, completed("Completing"))
+}
@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Monday, 1 July 2024
+	from := time.Date(2024, 7, 1, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next []time.Time
+	}{
+		{
+			spec: "*/15 * * * *",
+			next: []time.Time{
+				time.Date(2024, 7, 1, 10, 30, 0, 0, time.UTC),
+				time.Date(2024, 7, 1, 10, 45, 0, 0, time.UTC),
+				time.Date(2024, 7, 1, 11, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			spec: "0 3 * * *",
+			next: []time.Time{
+				time.Date(2024, 7, 2, 3, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 3, 3, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			spec: "30 2 * * sat,SUN",
+			next: []time.Time{
+				time.Date(2024, 7, 6, 2, 30, 0, 0, time.UTC),
+				time.Date(2024, 7, 7, 2, 30, 0, 0, time.UTC),
+				time.Date(2024, 7, 13, 2, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the day of month or the day of week must match if both are restricted
+			spec: "0 0 15 * 7",
+			next: []time.Time{
+				time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			spec: "0 9-17/4 1 jan-mar,dec *",
+			next: []time.Time{
+				time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 12, 1, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, 12, 1, 17, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			spec: "0 0 29 2 *",
+			next: []time.Time{
+				time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			spec: "@daily",
+			next: []time.Time{
+				time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			spec: "@every 90m",
+			next: []time.Time{
+				time.Date(2024, 7, 1, 11, 47, 30, 0, time.UTC),
+				time.Date(2024, 7, 1, 13, 17, 30, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			next := from
+			for _, expected := range tt.next {
+				next = schedule.Next(next)
+				assert.Equal(t, expected, next)
+			}
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"0 0 30 feb *",
+		"@fortnightly",
+		"@every",
+		"@every 100ms",
+		"@every often",
+	} {
+		_, err := ParseSchedule(spec)
+		assert.Errorf(t, err, "schedule %q must be invalid", spec)
+	}
+}
@@ -0,0 +1,364 @@
+// Package scheduler runs the periodic tasks of the server on cron-style schedules. It records the last and next
+// run of every job, which the admin API exposes, and from which jobs can be triggered manually.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/singleton"
+)
+
+var (
+	// ErrJobNotFound is returned for jobs which are not registered.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when a job is triggered while it runs.
+	ErrJobRunning = errors.New("job is already running")
+	// ErrJobInactive is returned when a job is triggered on a replica on which it does not run, because another
+	// replica holds the lock of the exclusive job, or the scheduler is not running.
+	ErrJobInactive = errors.New("job does not run on this replica")
+	// ErrJobPaused is returned when a paused job is triggered.
+	ErrJobPaused = errors.New("job is paused")
+)
+
+var jobRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "yhs",
+	Subsystem: "scheduler",
+	Name:      "job_runs_total",
+	Help:      "Number of runs of the scheduled jobs by result, success or failure.",
+}, []string{"job", "result"})
+
+// JobStatus is the status of a job on this replica.
+type JobStatus struct {
+	Name string `json:"name"`
+	// Schedule is the schedule of the job, a cron expression, a descriptor like @daily or @every <duration>.
+	Schedule string `json:"schedule"`
+	// Exclusive is set if the job runs only on the replica holding its lock.
+	Exclusive bool `json:"exclusive"`
+	// Active is set if the job runs on this replica.
+	Active bool `json:"active"`
+	// Running is set while the job runs.
+	Running bool `json:"running"`
+	// LastRun is the time the last run on this replica started.
+	LastRun *time.Time `json:"lastRun,omitempty"`
+	// LastRunFinished is the time the last run on this replica finished.
+	LastRunFinished *time.Time `json:"lastRunFinished,omitempty"`
+	// LastError is the error of the last run, if it failed.
+	LastError string `json:"lastError,omitempty"`
+	// NextRun is the time of the next scheduled run, if the job is active.
+	NextRun *time.Time `json:"nextRun,omitempty"`
+	// Paused is set while the job is paused, in which case its scheduled runs are skipped.
+	Paused bool `json:"paused"`
+	// Pause is the pause of the job, if it is paused.
+	Pause *model.PausedOperation `json:"pause,omitempty"`
+}
+
+// Pauses returns the pauses of the jobs.
+type Pauses interface {
+	// Paused returns the pause of the job, or nil if it is not paused.
+	Paused(name string) *model.PausedOperation
+}
+
+type job struct {
+	name       string
+	spec       string
+	schedule   Schedule
+	run        func(context.Context) error
+	runOnStart bool
+	exclusive  bool
+
+	// ctx is the context the runs of the job are started with, which is the context of the lock of an exclusive
+	// job while it is held, and nil while the job is not active
+	ctx       context.Context
+	next      time.Time
+	running   bool
+	lastRun   *time.Time
+	lastEnd   *time.Time
+	lastError string
+}
+
+// Scheduler runs the registered jobs on their schedules. A run of a job is skipped if the previous run did not
+// finish yet.
+type Scheduler struct {
+	locker *singleton.Locker
+	pauses Pauses
+	now    func() time.Time
+
+	mutex sync.Mutex
+	jobs  map[string]*job
+	// wake signals that the next runs changed
+	wake chan struct{}
+	runs sync.WaitGroup
+}
+
+type Option func(*Scheduler)
+
+// WithLocker sets the locker of the exclusive jobs, which run only on the replica holding their lock.
+// Without locker, exclusive jobs run on every replica.
+func WithLocker(locker *singleton.Locker) Option {
+	return func(s *Scheduler) {
+		s.locker = locker
+	}
+}
+
+// WithPauses sets the pauses of the jobs. The scheduled runs of a paused job are skipped, and it cannot be
+// triggered, but a run in progress when the job is paused is not interrupted.
+func WithPauses(pauses Pauses) Option {
+	return func(s *Scheduler) {
+		s.pauses = pauses
+	}
+}
+
+type JobOption func(*job)
+
+// RunOnStart runs the job once it becomes active, e.g. when the server starts, in addition to its schedule, so that
+// jobs with long intervals are not postponed by every restart.
+func RunOnStart() JobOption {
+	return func(j *job) {
+		j.runOnStart = true
+	}
+}
+
+// Exclusive runs the job only on the replica holding its lock, so that replicas do not run it concurrently.
+func Exclusive() JobOption {
+	return func(j *job) {
+		j.exclusive = true
+	}
+}
+
+func New(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		now:  time.Now,
+		jobs: make(map[string]*job),
+		wake: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the job with the schedule, which is parsed with ParseSchedule. Jobs must be registered before
+// the scheduler runs.
+func (s *Scheduler) Register(name, spec string, run func(context.Context) error, opts ...JobOption) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule of job %s: %w", name, err)
+	}
+	j := &job{name: name, spec: spec, schedule: schedule, run: run}
+	for _, opt := range opts {
+		opt(j)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+	s.jobs[name] = j
+	return nil
+}
+
+// Run runs the jobs on their schedules until the context is cancelled, and then waits for the runs to finish.
+func (s *Scheduler) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "scheduler")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Infow("starting scheduler", "jobs", len(s.jobs))
+
+	s.mutex.Lock()
+	for _, j := range s.jobs {
+		if j.exclusive && s.locker != nil {
+			s.runs.Add(1)
+			go func(j *job) {
+				defer s.runs.Done()
+				_ = s.locker.RunExclusive(ctx, j.name, func(lockCtx context.Context) error {
+					s.mutex.Lock()
+					s.activate(lockCtx, j)
+					s.mutex.Unlock()
+					<-lockCtx.Done()
+					s.mutex.Lock()
+					j.ctx, j.next = nil, time.Time{}
+					s.mutex.Unlock()
+					return nil
+				})
+			}(j)
+			continue
+		}
+		s.activate(ctx, j)
+	}
+	s.mutex.Unlock()
+
+	for {
+		wait := s.startDue()
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logger.Warn("shutting down scheduler")
+			s.runs.Wait()
+			return nil
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		}
+	}
+}
+
+// Trigger starts a run of the job, unless it is running, paused or not active on this replica.
+func (s *Scheduler) Trigger(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	j, ok := s.jobs[name]
+	switch {
+	case !ok:
+		return ErrJobNotFound
+	case j.ctx == nil:
+		return ErrJobInactive
+	case j.running:
+		return ErrJobRunning
+	case s.paused(j.name) != nil:
+		return ErrJobPaused
+	}
+	log.FromContext(j.ctx).Infow("triggered job manually", "job", name)
+	s.start(j)
+	return nil
+}
+
+// Statuses returns the status of every job ordered by name.
+func (s *Scheduler) Statuses() []*JobStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	statuses := make([]*JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		statuses = append(statuses, s.status(j))
+	}
+	sort.Slice(statuses, func(i, k int) bool { return statuses[i].Name < statuses[k].Name })
+	return statuses
+}
+
+// Status returns the status of the job.
+func (s *Scheduler) Status(name string) (*JobStatus, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	j, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return s.status(j), nil
+}
+
+// status returns the status of the job. The scheduler must be locked.
+func (s *Scheduler) status(j *job) *JobStatus {
+	status := &JobStatus{
+		Name:            j.name,
+		Schedule:        j.spec,
+		Exclusive:       j.exclusive && s.locker != nil,
+		Active:          j.ctx != nil,
+		Running:         j.running,
+		LastRun:         j.lastRun,
+		LastRunFinished: j.lastEnd,
+		LastError:       j.lastError,
+	}
+	if !j.next.IsZero() {
+		next := j.next
+		status.NextRun = &next
+	}
+	status.Pause = s.paused(j.name)
+	status.Paused = status.Pause != nil
+	return status
+}
+
+// paused returns the pause of the job, or nil if it is not paused.
+func (s *Scheduler) paused(name string) *model.PausedOperation {
+	if s.pauses == nil {
+		return nil
+	}
+	return s.pauses.Paused(name)
+}
+
+// activate starts scheduling the runs of the job with the context. The scheduler must be locked.
+func (s *Scheduler) activate(ctx context.Context, j *job) {
+	j.ctx = ctx
+	j.next = j.schedule.Next(s.now())
+	if j.runOnStart {
+		j.next = s.now()
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// startDue starts the runs of the active jobs which are due, and returns the duration until the next run.
+func (s *Scheduler) startDue() time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.now()
+	var next time.Time
+	for _, j := range s.jobs {
+		if j.ctx == nil || j.next.IsZero() {
+			continue
+		}
+		if !j.next.After(now) {
+			switch {
+			case j.running:
+				log.FromContext(j.ctx).Warnw("skipping run of job, as the previous run did not finish", "job", j.name)
+			case s.paused(j.name) != nil:
+				log.FromContext(j.ctx).Infow("skipping run of job, as it is paused", "job", j.name)
+			default:
+				s.start(j)
+			}
+			j.next = j.schedule.Next(now)
+			if j.next.IsZero() {
+				continue
+			}
+		}
+		if next.IsZero() || j.next.Before(next) {
+			next = j.next
+		}
+	}
+	if next.IsZero() {
+		return time.Hour
+	}
+	return next.Sub(now)
+}
+
+// start runs the job in the background. The scheduler must be locked.
+func (s *Scheduler) start(j *job) {
+	ctx := j.ctx
+	logger := log.FromContext(ctx).With("job", j.name)
+	ctx = log.ToContext(ctx, logger)
+	started := s.now()
+	j.running, j.lastRun = true, &started
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		logger.Debug("running job")
+		err := j.run(ctx)
+		finished := s.now()
+		result := "success"
+		if err != nil {
+			result = "failure"
+			logger.Errorw("job failed", "error", err, "duration", finished.Sub(started))
+		} else {
+			logger.Debugw("job finished", "duration", finished.Sub(started))
+		}
+		jobRunsTotal.WithLabelValues(j.name, result).Inc()
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		j.running, j.lastEnd, j.lastError = false, &finished, ""
+		if err != nil {
+			j.lastError = err.Error()
+		}
+	}()
+}
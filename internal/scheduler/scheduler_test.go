@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func startScheduler(t *testing.T, s *Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func status(t *testing.T, s *Scheduler, name string) *JobStatus {
+	t.Helper()
+	status, err := s.Status(name)
+	require.NoError(t, err)
+	return status
+}
+
+func TestScheduler(t *testing.T) {
+	s := New()
+	var pruned, checked atomic.Int32
+	release := make(chan struct{})
+	require.NoError(t, s.Register("retention", "@every 1h", func(context.Context) error {
+		pruned.Add(1)
+		return errors.New("database is unavailable")
+	}))
+	require.NoError(t, s.Register("data-quality", "0 3 * * *", func(ctx context.Context) error {
+		checked.Add(1)
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}, RunOnStart()))
+	assert.Error(t, s.Register("retention", "@daily", func(context.Context) error { return nil }))
+	assert.ErrorIs(t, s.Trigger("retention"), ErrJobInactive, "jobs are inactive until the scheduler runs")
+
+	startScheduler(t, s)
+
+	// jobs run on start only if requested
+	require.Eventually(t, func() bool { return checked.Load() == 1 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int32(0), pruned.Load())
+	running := status(t, s, "data-quality")
+	assert.True(t, running.Active)
+	assert.True(t, running.Running)
+	assert.Equal(t, "0 3 * * *", running.Schedule)
+	require.NotNil(t, running.NextRun)
+	assert.Equal(t, 3, running.NextRun.Hour())
+	assert.ErrorIs(t, s.Trigger("data-quality"), ErrJobRunning)
+	close(release)
+	require.Eventually(t, func() bool { return !status(t, s, "data-quality").Running }, 5*time.Second, time.Millisecond)
+	assert.NotNil(t, status(t, s, "data-quality").LastRunFinished)
+
+	// jobs are triggered manually, and the errors of their runs are recorded
+	require.NoError(t, s.Trigger("retention"))
+	require.Eventually(t, func() bool { return status(t, s, "retention").LastRunFinished != nil }, 5*time.Second, time.Millisecond)
+	retention := status(t, s, "retention")
+	assert.Equal(t, int32(1), pruned.Load())
+	assert.Equal(t, "database is unavailable", retention.LastError)
+	assert.False(t, retention.Exclusive)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *retention.NextRun, time.Minute)
+
+	assert.ErrorIs(t, s.Trigger("compaction"), ErrJobNotFound)
+	_, err := s.Status("compaction")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	assert.Equal(t, []string{"data-quality", "retention"}, []string{s.Statuses()[0].Name, s.Statuses()[1].Name})
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	s := New()
+	var runs atomic.Int32
+	require.NoError(t, s.Register("sink", "@every 1s", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}))
+	startScheduler(t, s)
+
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+type pauses map[string]*model.PausedOperation
+
+func (p pauses) Paused(name string) *model.PausedOperation {
+	return p[name]
+}
+
+func TestScheduler_Paused(t *testing.T) {
+	pause := &model.PausedOperation{Name: "data-quality", Reason: "database maintenance", PausedBy: "alice"}
+	s := New(WithPauses(pauses{"data-quality": pause}))
+	var checked atomic.Int32
+	require.NoError(t, s.Register("data-quality", "0 3 * * *", func(context.Context) error {
+		checked.Add(1)
+		return nil
+	}, RunOnStart()))
+
+	startScheduler(t, s)
+
+	// the run on start is skipped, and the job is scheduled at its next run
+	require.Eventually(t, func() bool {
+		next := status(t, s, "data-quality").NextRun
+		return next != nil && next.After(time.Now())
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int32(0), checked.Load())
+	paused := status(t, s, "data-quality")
+	assert.True(t, paused.Paused)
+	assert.Equal(t, pause, paused.Pause)
+	assert.ErrorIs(t, s.Trigger("data-quality"), ErrJobPaused)
+}
@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// secretsManagerAPI is the subset of the Secrets Manager client used by AWSProvider.
+type secretsManagerAPI interface {
+	GetSecretValue(
+		ctx context.Context,
+		params *secretsmanager.GetSecretValueInput,
+		optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// AWSProvider reads secrets from AWS Secrets Manager. Secrets whose value is a JSON object are returned as its
+// key-value pairs, any other secret is returned under DefaultKey.
+type AWSProvider struct {
+	client secretsManagerAPI
+}
+
+// NewAWSProvider creates an AWSProvider using the default credential chain, e.g. IAM roles for service accounts.
+func NewAWSProvider(ctx context.Context, cfg *config.AWSSecretsConfig) (*AWSProvider, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	// the SDK sends its requests with the default transport, to which the egress configuration applies
+	opts = append(opts, awsconfig.WithHTTPClient(http.DefaultClient))
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load AWS config: %v", err)
+	}
+	return &AWSProvider{client: secretsmanager.NewFromConfig(awsCfg)}, nil
+}
+
+func (p *AWSProvider) GetSecret(ctx context.Context, name string) (map[string]string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return nil, err
+	}
+	value := aws.ToString(out.SecretString)
+	if out.SecretString == nil {
+		value = string(out.SecretBinary)
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return map[string]string{DefaultKey: value}, nil
+	}
+	return stringValues(object), nil
+}
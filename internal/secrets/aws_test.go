@@ -0,0 +1,52 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSecretsManager struct {
+	secrets map[string]*secretsmanager.GetSecretValueOutput
+}
+
+func (f *fakeSecretsManager) GetSecretValue(
+	_ context.Context,
+	params *secretsmanager.GetSecretValueInput,
+	_ ...func(*secretsmanager.Options),
+) (*secretsmanager.GetSecretValueOutput, error) {
+	out, ok := f.secrets[aws.ToString(params.SecretId)]
+	if !ok {
+		return nil, errors.New("ResourceNotFoundException")
+	}
+	return out, nil
+}
+
+func TestAWSProvider_GetSecret(t *testing.T) {
+	p := &AWSProvider{client: &fakeSecretsManager{secrets: map[string]*secretsmanager.GetSecretValueOutput{
+		"yhs/db":     {SecretString: aws.String(`{"username": "yhs", "password": "s3cret", "port": 5432}`)},
+		"yhs/plain":  {SecretString: aws.String("plain")},
+		"yhs/binary": {SecretBinary: []byte("binary")},
+	}}}
+	ctx := context.Background()
+
+	values, err := p.GetSecret(ctx, "yhs/db")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "yhs", "password": "s3cret", "port": "5432"}, values)
+
+	values, err = p.GetSecret(ctx, "yhs/plain")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{DefaultKey: "plain"}, values)
+
+	values, err = p.GetSecret(ctx, "yhs/binary")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{DefaultKey: "binary"}, values)
+
+	_, err = p.GetSecret(ctx, "yhs/missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// DefaultKey is the key of a secret whose value is not a JSON object, and of references without a key.
+const DefaultKey = "value"
+
+var ErrKeyNotFound = errors.New("secret key not found")
+
+// SecretProvider fetches secrets from a secret store.
+type SecretProvider interface {
+	// GetSecret returns the key-value pairs of the secret with the given name.
+	GetSecret(ctx context.Context, name string) (map[string]string, error)
+}
+
+// New creates the provider of the configured secret store. It returns nil if no secret store is configured.
+func New(ctx context.Context, cfg *config.SecretsConfig) (SecretProvider, error) {
+	switch cfg.Provider {
+	case config.SecretProviderVault:
+		return NewVaultProvider(&cfg.Vault), nil
+	case config.SecretProviderAWS:
+		return NewAWSProvider(ctx, &cfg.AWS)
+	default:
+		return nil, nil
+	}
+}
+
+// Resolver resolves secret references of the form secret:<name>#<key>. Secrets are cached for the refresh interval,
+// so that rotated secrets are fetched again without fetching them every time they are resolved.
+//
+// A nil Resolver returns every value as it is.
+type Resolver struct {
+	provider        SecretProvider
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mutex sync.Mutex
+	cache map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	values    map[string]string
+	fetchedAt time.Time
+}
+
+// NewResolver creates a Resolver fetching secrets from the provider. It returns nil if the provider is nil.
+func NewResolver(provider SecretProvider, refreshInterval time.Duration) *Resolver {
+	if provider == nil {
+		return nil
+	}
+	return &Resolver{
+		provider:        provider,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+		cache:           make(map[string]cachedSecret),
+	}
+}
+
+// Resolve returns the referenced secret if the value is a secret reference, and the value as it is otherwise.
+// If a cached secret cannot be fetched again, the cached value is returned.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if r == nil || !config.IsSecretReference(value) {
+		return value, nil
+	}
+	name, key, _ := strings.Cut(strings.TrimPrefix(value, config.SecretReferencePrefix), "#")
+	if key == "" {
+		key = DefaultKey
+	}
+	values, err := r.secret(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	v, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s#%s", ErrKeyNotFound, name, key)
+	}
+	return v, nil
+}
+
+func (r *Resolver) secret(ctx context.Context, name string) (map[string]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	cached, ok := r.cache[name]
+	if ok && r.now().Sub(cached.fetchedAt) < r.refreshInterval {
+		return cached.values, nil
+	}
+	values, err := r.provider.GetSecret(ctx, name)
+	if err != nil {
+		if ok {
+			log.FromContext(ctx).Warnw("could not refresh secret, using cached value", "secret", name, "error", err)
+			return cached.values, nil
+		}
+		return nil, fmt.Errorf("could not get secret %s: %w", name, err)
+	}
+	r.cache[name] = cachedSecret{values: values, fetchedAt: r.now()}
+	return values, nil
+}
+
+// ResolveConfig resolves the secret references of the configuration options which hold credentials:
+// the database user and password, the API keys, the encryption keys, the Redis and ClickHouse passwords and the
+// secret access keys of the object storage.
+func (r *Resolver) ResolveConfig(ctx context.Context, cfg *config.Config) error {
+	var err error
+	if cfg.PostgresConfig.Username, err = r.Resolve(ctx, cfg.PostgresConfig.Username); err != nil {
+		return err
+	}
+	if cfg.PostgresConfig.Password, err = r.Resolve(ctx, cfg.PostgresConfig.Password); err != nil {
+		return err
+	}
+	for client, key := range cfg.AuthConfig.APIKeys {
+		if cfg.AuthConfig.APIKeys[client], err = r.Resolve(ctx, key); err != nil {
+			return err
+		}
+	}
+	for client, key := range cfg.AdminConfig.APIKeys {
+		if cfg.AdminConfig.APIKeys[client], err = r.Resolve(ctx, key); err != nil {
+			return err
+		}
+	}
+	for id, key := range cfg.EncryptionConfig.Keys {
+		if cfg.EncryptionConfig.Keys[id], err = r.Resolve(ctx, key); err != nil {
+			return err
+		}
+	}
+	if cfg.CacheConfig.Redis.Password, err = r.Resolve(ctx, cfg.CacheConfig.Redis.Password); err != nil {
+		return err
+	}
+	if cfg.CollectorConfig.APIKey, err = r.Resolve(ctx, cfg.CollectorConfig.APIKey); err != nil {
+		return err
+	}
+	if cfg.ClickHouseConfig.Password, err = r.Resolve(ctx, cfg.ClickHouseConfig.Password); err != nil {
+		return err
+	}
+	if cfg.LakeConfig.SecretAccessKey, err = r.Resolve(ctx, cfg.LakeConfig.SecretAccessKey); err != nil {
+		return err
+	}
+	if cfg.ExportsConfig.SecretAccessKey, err = r.Resolve(ctx, cfg.ExportsConfig.SecretAccessKey); err != nil {
+		return err
+	}
+	if cfg.MirrorConfig.APIKey, err = r.Resolve(ctx, cfg.MirrorConfig.APIKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+// BeforeConnect returns a pgx BeforeConnect hook which resolves the database credentials every time a connection
+// is established, so that new connections use rotated credentials. It returns nil if neither is a secret reference.
+func (r *Resolver) BeforeConnect(username, password string) func(context.Context, *pgx.ConnConfig) error {
+	if r == nil || (!config.IsSecretReference(username) && !config.IsSecretReference(password)) {
+		return nil
+	}
+	return func(ctx context.Context, cc *pgx.ConnConfig) error {
+		var err error
+		if cc.User, err = r.Resolve(ctx, username); err != nil {
+			return err
+		}
+		cc.Password, err = r.Resolve(ctx, password)
+		return err
+	}
+}
+
+// stringValues formats the values of a secret which are not strings, e.g. numbers.
+func stringValues(object map[string]any) map[string]string {
+	values := make(map[string]string, len(object))
+	for k, v := range object {
+		if s, ok := v.(string); ok {
+			values[k] = s
+		} else {
+			values[k] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
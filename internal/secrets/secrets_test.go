@@ -0,0 +1,142 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// fakeProvider returns the secrets of its map and counts the fetches.
+type fakeProvider struct {
+	secrets map[string]map[string]string
+	err     error
+	fetches int
+}
+
+func (p *fakeProvider) GetSecret(_ context.Context, name string) (map[string]string, error) {
+	p.fetches++
+	if p.err != nil {
+		return nil, p.err
+	}
+	values, ok := p.secrets[name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return values, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeProvider{secrets: map[string]map[string]string{
+		"yhs/db":    {"password": "s3cret"},
+		"yhs/plain": {DefaultKey: "plain"},
+	}}
+	r := NewResolver(provider, time.Minute)
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	value, err := r.Resolve(ctx, "not-a-reference")
+	require.NoError(t, err)
+	assert.Equal(t, "not-a-reference", value)
+
+	value, err = r.Resolve(ctx, "secret:yhs/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+	value, err = r.Resolve(ctx, "secret:yhs/plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", value)
+
+	_, err = r.Resolve(ctx, "secret:yhs/db#user")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	_, err = r.Resolve(ctx, "secret:yhs/missing#password")
+	assert.EqualError(t, err, "could not get secret yhs/missing: not found")
+
+	// secrets are cached for the refresh interval
+	fetches := provider.fetches
+	_, err = r.Resolve(ctx, "secret:yhs/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, fetches, provider.fetches)
+
+	// rotated secrets are fetched once the refresh interval elapsed
+	provider.secrets["yhs/db"] = map[string]string{"password": "rotated"}
+	now = now.Add(time.Minute)
+	value, err = r.Resolve(ctx, "secret:yhs/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", value)
+
+	// the cached secret is used if it cannot be fetched again
+	provider.err = errors.New("connection refused")
+	now = now.Add(time.Minute)
+	value, err = r.Resolve(ctx, "secret:yhs/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", value)
+}
+
+func TestResolver_Nil(t *testing.T) {
+	r := NewResolver(nil, time.Minute)
+	assert.Nil(t, r)
+
+	value, err := r.Resolve(context.Background(), "secret:yhs/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "secret:yhs/db#password", value)
+	assert.Nil(t, r.BeforeConnect("secret:yhs/db#user", "secret:yhs/db#password"))
+}
+
+func TestResolver_ResolveConfig(t *testing.T) {
+	provider := &fakeProvider{secrets: map[string]map[string]string{
+		"yhs/db":    {"password": "s3cret"},
+		"yhs/keys":  {"ops": "ops-secret", "admin": "admin-secret", "v1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+		"yhs/redis": {"password": "r3dis"},
+		"yhs/ch":    {"password": "cl1ck"},
+		"yhs/minio": {"secret_access_key": "m1nio"},
+	}}
+	cfg := &config.Config{
+		PostgresConfig:   config.PostgresConfig{Username: "yhs", Password: "secret:yhs/db#password"},
+		AuthConfig:       config.AuthConfig{APIKeys: map[string]string{"ops": "secret:yhs/keys#ops", "ci": "ci-secret"}},
+		AdminConfig:      config.AdminConfig{APIKeys: map[string]string{"admin": "secret:yhs/keys#admin"}},
+		EncryptionConfig: config.EncryptionConfig{Keys: map[string]string{"v1": "secret:yhs/keys#v1"}, ActiveKey: "v1"},
+		CacheConfig:      config.CacheConfig{Redis: config.RedisConfig{Password: "secret:yhs/redis#password"}},
+		ClickHouseConfig: config.ClickHouseConfig{Password: "secret:yhs/ch#password"},
+		LakeConfig: config.LakeConfig{ObjectStorageConfig: config.ObjectStorageConfig{
+			AccessKeyID: "minio", SecretAccessKey: "secret:yhs/minio#secret_access_key",
+		}},
+		ExportsConfig: config.ExportsConfig{ObjectStorageConfig: config.ObjectStorageConfig{
+			AccessKeyID: "minio", SecretAccessKey: "static",
+		}},
+	}
+
+	require.NoError(t, NewResolver(provider, time.Minute).ResolveConfig(context.Background(), cfg))
+	assert.Equal(t, config.PostgresConfig{Username: "yhs", Password: "s3cret"}, cfg.PostgresConfig)
+	assert.Equal(t, map[string]string{"ops": "ops-secret", "ci": "ci-secret"}, cfg.AuthConfig.APIKeys)
+	assert.Equal(t, map[string]string{"admin": "admin-secret"}, cfg.AdminConfig.APIKeys)
+	assert.Equal(t, map[string]string{"v1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="}, cfg.EncryptionConfig.Keys)
+	assert.Equal(t, "r3dis", cfg.CacheConfig.Redis.Password)
+	assert.Equal(t, "cl1ck", cfg.ClickHouseConfig.Password)
+	assert.Equal(t, "m1nio", cfg.LakeConfig.SecretAccessKey)
+	assert.Equal(t, "static", cfg.ExportsConfig.SecretAccessKey)
+}
+
+func TestResolver_BeforeConnect(t *testing.T) {
+	provider := &fakeProvider{secrets: map[string]map[string]string{"yhs/db": {"password": "s3cret"}}}
+	r := NewResolver(provider, 0)
+
+	assert.Nil(t, r.BeforeConnect("yhs", "static"))
+
+	beforeConnect := r.BeforeConnect("yhs", "secret:yhs/db#password")
+	require.NotNil(t, beforeConnect)
+	cc := &pgx.ConnConfig{}
+	require.NoError(t, beforeConnect(context.Background(), cc))
+	assert.Equal(t, "yhs", cc.User)
+	assert.Equal(t, "s3cret", cc.Password)
+
+	provider.secrets["yhs/db"]["password"] = "rotated"
+	require.NoError(t, beforeConnect(context.Background(), cc))
+	assert.Equal(t, "rotated", cc.Password)
+}
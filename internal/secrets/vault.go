@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// serviceAccountTokenPath is the path of the token used to log in with the Kubernetes auth method.
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// VaultProvider reads secrets from the KV version 2 secrets engine of HashiCorp Vault.
+// It authenticates with the configured token, or logs in with the Kubernetes auth method
+// and logs in again once its token expires.
+type VaultProvider struct {
+	cfg        *config.VaultConfig
+	httpClient *http.Client
+	tokenPath  string
+
+	mutex sync.Mutex
+	token string
+}
+
+func NewVaultProvider(cfg *config.VaultConfig) *VaultProvider {
+	return &VaultProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		tokenPath:  serviceAccountTokenPath,
+		token:      cfg.Token,
+	}
+}
+
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) (map[string]string, error) {
+	var secret struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	path := fmt.Sprintf("/v1/%s/data/%s", strings.Trim(p.cfg.Mount, "/"), strings.TrimPrefix(name, "/"))
+	status, err := p.authenticatedRequest(ctx, http.MethodGet, path, &secret)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("vault secret %s not found", name)
+	case http.StatusForbidden:
+		return nil, fmt.Errorf("permission denied to read vault secret %s", name)
+	}
+	return stringValues(secret.Data.Data), nil
+}
+
+// authenticatedRequest sends the request with the current token, logging in again if the token is rejected
+// and the Kubernetes auth method is configured.
+func (p *VaultProvider) authenticatedRequest(ctx context.Context, method, path string, result any) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.token == "" {
+		if err := p.login(ctx); err != nil {
+			return 0, err
+		}
+	}
+	status, err := p.request(ctx, method, path, p.token, nil, result)
+	if status == http.StatusForbidden && p.cfg.KubernetesRole != "" {
+		if err := p.login(ctx); err != nil {
+			return 0, err
+		}
+		status, err = p.request(ctx, method, path, p.token, nil, result)
+	}
+	return status, err
+}
+
+// login logs in with the Kubernetes auth method using the service account token of the pod.
+func (p *VaultProvider) login(ctx context.Context) error {
+	if p.cfg.KubernetesRole == "" {
+		return errors.New("vault token or kubernetes role is required")
+	}
+	jwt, err := os.ReadFile(p.tokenPath)
+	if err != nil {
+		return fmt.Errorf("could not read service account token: %v", err)
+	}
+	var login struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	body := map[string]string{"role": p.cfg.KubernetesRole, "jwt": strings.TrimSpace(string(jwt))}
+	path := fmt.Sprintf("/v1/auth/%s/login", strings.Trim(p.cfg.KubernetesMount, "/"))
+	status, err := p.request(ctx, http.MethodPost, path, "", body, &login)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("vault responded with status %d", status)
+	}
+	if err != nil {
+		return fmt.Errorf("could not log in to vault: %v", err)
+	}
+	p.token = login.Auth.ClientToken
+	return nil
+}
+
+// request sends a request to Vault and decodes the response into result.
+// Forbidden and not found responses are returned as status without error, so that callers can handle them.
+func (p *VaultProvider) request(ctx context.Context, method, path, token string, body, result any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(p.cfg.Address, "/")+path, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound:
+		return resp.StatusCode, nil
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("vault responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return resp.StatusCode, fmt.Errorf("could not decode vault response: %v", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestVaultProvider_GetSecret(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/data/yhs/db":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "s3cret", "port": 5432}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	p := NewVaultProvider(&config.VaultConfig{Address: vault.URL, Mount: "kv", Token: "root"})
+	values, err := p.GetSecret(context.Background(), "yhs/db")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "s3cret", "port": "5432"}, values)
+
+	_, err = p.GetSecret(context.Background(), "yhs/missing")
+	assert.EqualError(t, err, "vault secret yhs/missing not found")
+
+	p = NewVaultProvider(&config.VaultConfig{Address: vault.URL, Mount: "kv", Token: "expired"})
+	_, err = p.GetSecret(context.Background(), "yhs/db")
+	assert.EqualError(t, err, "permission denied to read vault secret yhs/db")
+}
+
+func TestVaultProvider_KubernetesLogin(t *testing.T) {
+	logins := 0
+	validToken := ""
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/k8s/login":
+			var login map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&login))
+			assert.Equal(t, map[string]string{"role": "yhs", "jwt": "service-account-token"}, login)
+			logins++
+			validToken = fmt.Sprintf("token-%d", logins)
+			_, _ = fmt.Fprintf(w, `{"auth": {"client_token": %q}}`, validToken)
+		case "/v1/secret/data/yhs/db":
+			if r.Header.Get("X-Vault-Token") != validToken {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "s3cret"}}}`))
+		}
+	}))
+	defer vault.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("service-account-token\n"), 0o600))
+	p := NewVaultProvider(&config.VaultConfig{Address: vault.URL, Mount: "secret", KubernetesRole: "yhs", KubernetesMount: "k8s"})
+	p.tokenPath = tokenPath
+
+	values, err := p.GetSecret(context.Background(), "yhs/db")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", values["password"])
+	assert.Equal(t, 1, logins)
+
+	// the provider logs in again once its token expired
+	validToken = "revoked"
+	values, err = p.GetSecret(context.Background(), "yhs/db")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", values["password"])
+	assert.Equal(t, 2, logins)
+}
@@ -0,0 +1,303 @@
+// Package singleton runs the background jobs of the server writing the data at most once across the replicas of
+// the server, so that replicas started accidentally without leader election do not prune the same applications
+// or count the same usage twice. A job runs only while its replica holds the Postgres advisory lock of the job,
+// and the replicas not holding it wait to acquire it.
+package singleton
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// lockTimeout bounds the statements of the session holding the locks, which are run while the locker is locked.
+const lockTimeout = 5 * time.Second
+
+var jobLockHeld = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "yhs",
+	Subsystem: "background_job",
+	Name:      "lock_held",
+	Help:      "Whether the replica holds the lock of the background job and thus runs it.",
+}, []string{"job"})
+
+// session is a database session holding advisory locks, which are released when it is closed.
+type session interface {
+	tryLock(ctx context.Context, key string) (bool, error)
+	unlock(ctx context.Context, key string) error
+	ping(ctx context.Context) error
+	close()
+}
+
+// Locker acquires the advisory locks of the jobs on a single connection of the pool, which it checks at an
+// interval. If the connection is lost, the database releases its locks, so the jobs are cancelled and wait to
+// acquire their locks again. A job may thus run on two replicas during up to an interval after the connection of
+// the replica is lost, while the database is not reachable from it.
+type Locker struct {
+	connect   func(context.Context) (session, error)
+	namespace string
+	interval  time.Duration
+
+	mutex   sync.Mutex
+	session session
+	jobs    map[string]*job
+	// wake signals that a job waits for its lock
+	wake chan struct{}
+}
+
+type job struct {
+	name string
+	ctx  context.Context
+	// acquired receives the context of a run of the job once its lock is acquired, which is cancelled if it is lost
+	acquired chan context.Context
+	// cancel cancels the run of the job while the lock is held, and is nil otherwise
+	cancel context.CancelFunc
+	// running is set from the acquisition of the lock until the run of the job returns, so that a lost lock is
+	// not acquired again before the job stopped
+	running bool
+}
+
+type Option func(*Locker)
+
+// WithInterval sets the interval at which the connection holding the locks is checked and the locks of the
+// waiting jobs are tried to be acquired.
+func WithInterval(interval time.Duration) Option {
+	return func(l *Locker) {
+		l.interval = interval
+	}
+}
+
+// NewLocker creates a locker of the jobs, whose locks are held on a connection of the pool. The namespace
+// distinguishes the locks of servers using different databases or schemas of the same Postgres cluster.
+func NewLocker(pool *pgxpool.Pool, namespace string, opts ...Option) *Locker {
+	l := newLocker(func(ctx context.Context) (session, error) {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &poolSession{conn: conn}, nil
+	}, namespace)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+func newLocker(connect func(context.Context) (session, error), namespace string) *Locker {
+	return &Locker{
+		connect:   connect,
+		namespace: namespace,
+		interval:  10 * time.Second,
+		jobs:      make(map[string]*job),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Run acquires the locks of the waiting jobs and checks the connection holding the locks until the context is
+// cancelled, when the locks are released.
+func (l *Locker) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "singleton_locker")
+	ctx = log.ToContext(ctx, logger)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		l.acquire(ctx)
+		select {
+		case <-ctx.Done():
+			l.mutex.Lock()
+			l.release(ctx)
+			l.mutex.Unlock()
+			return nil
+		case <-ticker.C:
+		case <-l.wake:
+		}
+	}
+}
+
+// RunExclusive runs the job while the replica holds its lock, until the context is cancelled. If the lock is
+// lost, the job is cancelled and runs again once the lock is acquired again. It returns the error of the job
+// if the job returns while the lock is held.
+func (l *Locker) RunExclusive(ctx context.Context, name string, run func(context.Context) error) error {
+	logger := log.FromContext(ctx).With("job", name)
+	j := &job{name: name, ctx: ctx, acquired: make(chan context.Context, 1)}
+	l.mutex.Lock()
+	l.jobs[name] = j
+	l.mutex.Unlock()
+	defer l.unregister(ctx, j)
+	l.signal()
+
+	logger.Info("waiting for the lock of the background job")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case runCtx := <-j.acquired:
+			logger.Info("acquired the lock of the background job")
+			err := run(runCtx)
+			if ctx.Err() == nil && runCtx.Err() != nil {
+				logger.Warn("lost the lock of the background job, waiting to acquire it again")
+				l.mutex.Lock()
+				j.running = false
+				l.mutex.Unlock()
+				l.signal()
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// acquire checks the connection holding the locks, and acquires the locks of the waiting jobs.
+func (l *Locker) acquire(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var held, waiting []*job
+	for _, name := range sortedNames(l.jobs) {
+		switch j := l.jobs[name]; {
+		case j.cancel != nil:
+			held = append(held, j)
+		case !j.running:
+			waiting = append(waiting, j)
+		}
+	}
+	if l.session != nil && len(held) > 0 {
+		pingCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+		err := l.session.ping(pingCtx)
+		cancel()
+		if err != nil {
+			logger.Errorw("lost the connection holding the locks of the background jobs", "error", err)
+			l.release(ctx)
+			waiting = nil
+		}
+	}
+	if len(waiting) == 0 {
+		return
+	}
+	if l.session == nil {
+		connectCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+		s, err := l.connect(connectCtx)
+		cancel()
+		if err != nil {
+			logger.Errorw("could not connect to acquire the locks of the background jobs", "error", err)
+			return
+		}
+		l.session = s
+	}
+	for _, j := range waiting {
+		lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+		ok, err := l.session.tryLock(lockCtx, l.key(j.name))
+		cancel()
+		if err != nil {
+			logger.Errorw("could not acquire the lock of the background job", "job", j.name, "error", err)
+			l.release(ctx)
+			return
+		}
+		if !ok {
+			continue
+		}
+		runCtx, cancelRun := context.WithCancel(j.ctx)
+		j.cancel, j.running = cancelRun, true
+		j.acquired <- runCtx
+		jobLockHeld.WithLabelValues(j.name).Set(1)
+	}
+}
+
+// release closes the session, which releases the locks held by it, and cancels the jobs holding them.
+// The locker must be locked.
+func (l *Locker) release(ctx context.Context) {
+	for _, j := range l.jobs {
+		if j.cancel != nil {
+			j.cancel()
+			j.cancel = nil
+			jobLockHeld.WithLabelValues(j.name).Set(0)
+		}
+	}
+	if l.session != nil {
+		l.session.close()
+		l.session = nil
+	}
+	log.FromContext(ctx).Debug("released the locks of the background jobs")
+}
+
+// unregister releases the lock of the job, if it is held, once the job stopped.
+func (l *Locker) unregister(ctx context.Context, j *job) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.jobs, j.name)
+	if j.cancel == nil {
+		return
+	}
+	j.cancel()
+	j.cancel = nil
+	jobLockHeld.WithLabelValues(j.name).Set(0)
+	if l.session == nil {
+		return
+	}
+	// the lock is released even if the context of the job is cancelled at shutdown
+	unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lockTimeout)
+	defer cancel()
+	if err := l.session.unlock(unlockCtx, l.key(j.name)); err != nil {
+		log.FromContext(ctx).Errorw("could not release the lock of the background job", "job", j.name, "error", err)
+		l.release(ctx)
+	}
+}
+
+func (l *Locker) signal() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (l *Locker) key(name string) string {
+	return fmt.Sprintf("yunikorn-history-server/jobs/%s/%s", l.namespace, name)
+}
+
+// poolSession holds the locks on a connection acquired from the pool, which is destroyed when the session is
+// closed, so that its locks are released.
+type poolSession struct {
+	conn *pgxpool.Conn
+}
+
+func (s *poolSession) tryLock(ctx context.Context, key string) (bool, error) {
+	var ok bool
+	err := s.conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", key).Scan(&ok)
+	return ok, err
+}
+
+func (s *poolSession) unlock(ctx context.Context, key string) error {
+	_, err := s.conn.Exec(ctx, "SELECT pg_advisory_unlock(hashtext($1))", key)
+	return err
+}
+
+func (s *poolSession) ping(ctx context.Context) error {
+	return s.conn.Ping(ctx)
+}
+
+func (s *poolSession) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+	_ = s.conn.Conn().Close(ctx)
+	s.conn.Release()
+}
+
+func sortedNames(jobs map[string]*job) []string {
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
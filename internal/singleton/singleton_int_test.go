@@ -0,0 +1,43 @@
+package singleton
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestLocker_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := database.GetTestPostgresConfig(ctx, t)
+	var runs1, runs2 atomic.Int32
+	lockers := make([]*Locker, 2)
+	for i := range lockers {
+		lockers[i] = NewLocker(database.GetTestConnectionPool(ctx, t, cfg), "test/locker", WithInterval(10*time.Millisecond))
+		go func() { _ = lockers[i].Run(ctx) }()
+	}
+
+	ctx1, stop1 := context.WithCancel(ctx)
+	done1 := runJob(ctx1, lockers[0], "retention", &runs1)
+	require.Eventually(t, func() bool { return runs1.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	done2 := runJob(ctx, lockers[1], "retention", &runs2)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), runs2.Load(), "the job must not run on both replicas")
+
+	stop1()
+	require.NoError(t, <-done1)
+	require.Eventually(t, func() bool { return runs2.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done2)
+}
@@ -0,0 +1,181 @@
+package singleton
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDatabase holds the advisory locks of its sessions, which are released when a session is closed or lost.
+type fakeDatabase struct {
+	mutex sync.Mutex
+	locks map[string]*fakeSession
+}
+
+func newFakeDatabase() *fakeDatabase {
+	return &fakeDatabase{locks: make(map[string]*fakeSession)}
+}
+
+func (d *fakeDatabase) connect(context.Context) (session, error) {
+	return &fakeSession{db: d}, nil
+}
+
+// lose simulates the loss of the connections, whose locks are released by the database.
+func (d *fakeDatabase) lose() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for key, s := range d.locks {
+		s.lost = true
+		delete(d.locks, key)
+	}
+}
+
+type fakeSession struct {
+	db   *fakeDatabase
+	lost bool
+}
+
+func (s *fakeSession) tryLock(_ context.Context, key string) (bool, error) {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	if s.lost {
+		return false, errors.New("connection lost")
+	}
+	if holder, ok := s.db.locks[key]; ok {
+		return holder == s, nil
+	}
+	s.db.locks[key] = s
+	return true, nil
+}
+
+func (s *fakeSession) unlock(_ context.Context, key string) error {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	if s.db.locks[key] == s {
+		delete(s.db.locks, key)
+	}
+	return nil
+}
+
+func (s *fakeSession) ping(context.Context) error {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	if s.lost {
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+func (s *fakeSession) close() {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	for key, holder := range s.db.locks {
+		if holder == s {
+			delete(s.db.locks, key)
+		}
+	}
+}
+
+func newTestLocker(t *testing.T, db *fakeDatabase) *Locker {
+	t.Helper()
+	l := newLocker(db.connect, "yhs/public")
+	l.interval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = l.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return l
+}
+
+// runJob runs a job counting its runs, which blocks until its context is cancelled.
+func runJob(ctx context.Context, l *Locker, name string, runs *atomic.Int32) chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- l.RunExclusive(ctx, name, func(ctx context.Context) error {
+			runs.Add(1)
+			<-ctx.Done()
+			return nil
+		})
+	}()
+	return done
+}
+
+func TestLocker_RunExclusive(t *testing.T) {
+	db := newFakeDatabase()
+	replica1, replica2 := newTestLocker(t, db), newTestLocker(t, db)
+
+	var runs1, runs2 atomic.Int32
+	ctx1, stop1 := context.WithCancel(context.Background())
+	done1 := runJob(ctx1, replica1, "retention", &runs1)
+	require.Eventually(t, func() bool { return runs1.Load() == 1 }, 5*time.Second, time.Millisecond)
+	ctx2, stop2 := context.WithCancel(context.Background())
+	defer stop2()
+	done2 := runJob(ctx2, replica2, "retention", &runs2)
+
+	// the job runs on one replica only, and on the other once the first one stopped
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), runs2.Load())
+	stop1()
+	require.NoError(t, <-done1)
+	require.Eventually(t, func() bool { return runs2.Load() == 1 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), runs1.Load())
+
+	// the jobs of other names are not excluded
+	var runs3 atomic.Int32
+	ctx3, stop3 := context.WithCancel(context.Background())
+	defer stop3()
+	runJob(ctx3, replica1, "tiering", &runs3)
+	require.Eventually(t, func() bool { return runs3.Load() == 1 }, 5*time.Second, time.Millisecond)
+
+	stop2()
+	require.NoError(t, <-done2)
+}
+
+func TestLocker_LostConnection(t *testing.T) {
+	db := newFakeDatabase()
+	l := newTestLocker(t, db)
+
+	var runs atomic.Int32
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	done := runJob(ctx, l, "retention", &runs)
+	require.Eventually(t, func() bool { return runs.Load() == 1 }, 5*time.Second, time.Millisecond)
+
+	// the job is cancelled once the lost connection is detected, and runs again once the lock is acquired again
+	db.lose()
+	require.Eventually(t, func() bool { return runs.Load() == 2 }, 5*time.Second, time.Millisecond)
+	db.mutex.Lock()
+	assert.Len(t, db.locks, 1)
+	db.mutex.Unlock()
+
+	stop()
+	require.NoError(t, <-done)
+	db.mutex.Lock()
+	assert.Empty(t, db.locks, "the lock must be released once the job stopped")
+	db.mutex.Unlock()
+}
+
+func TestLocker_JobError(t *testing.T) {
+	db := newFakeDatabase()
+	l := newTestLocker(t, db)
+
+	err := l.RunExclusive(context.Background(), "retention", func(context.Context) error {
+		return errors.New("invalid policy")
+	})
+	require.EqualError(t, err, "invalid policy")
+	db.mutex.Lock()
+	assert.Empty(t, db.locks)
+	db.mutex.Unlock()
+}
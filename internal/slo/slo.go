@@ -0,0 +1,78 @@
+package slo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Evaluator evaluates the SLOs of the queues against the stored applications and records their compliance history.
+// It is run by the scheduler.
+type Evaluator struct {
+	repo       repository.Repository
+	historyTTL time.Duration
+	now        func() time.Time
+}
+
+func NewEvaluator(repo repository.Repository, historyTTL time.Duration) *Evaluator {
+	return &Evaluator{
+		repo:       repo,
+		historyTTL: historyTTL,
+		now:        time.Now,
+	}
+}
+
+// ConfigSLOs converts the SLOs defined in the configuration file to the SLOs stored by the repository.
+func ConfigSLOs(cfg *config.SLOConfig) []*model.QueueSLO {
+	slos := make([]*model.QueueSLO, 0, len(cfg.Objectives))
+	for _, o := range cfg.Objectives {
+		slos = append(slos, &model.QueueSLO{
+			Name:        o.Name,
+			Source:      model.SLOSourceConfig,
+			Partition:   o.Partition,
+			Queue:       o.Queue,
+			Objective:   o.Objective,
+			StartWithin: o.StartWithin.Nanoseconds(),
+			Window:      o.Window.Nanoseconds(),
+		})
+	}
+	return slos
+}
+
+// Evaluate evaluates all SLOs once and deletes the evaluations older than the history TTL. An SLO which cannot be
+// evaluated does not prevent the others from being evaluated, but fails the run.
+func (e *Evaluator) Evaluate(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	now := e.now()
+	slos, err := e.repo.GetSLOs(ctx)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, slo := range slos {
+		evaluation, err := e.repo.EvaluateSLO(ctx, slo, now)
+		if err != nil {
+			logger.Errorw("could not evaluate slo", "slo", slo.Name, "error", err)
+			failed++
+			continue
+		}
+		if !evaluation.Met {
+			logger.Warnw("slo is not met", "slo", slo.Name, "objective", slo.Objective,
+				"compliance", *evaluation.Compliance, "applications", evaluation.Applications)
+		}
+	}
+	deleted, err := e.repo.DeleteSLOEvaluationsBefore(ctx, now.Add(-e.historyTTL))
+	if err != nil {
+		return err
+	}
+	logger.Infow("finished slo evaluation", "slos", len(slos), "deleted", deleted)
+	if failed > 0 {
+		return fmt.Errorf("could not evaluate %d of %d slos", failed, len(slos))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	slos := []*model.QueueSLO{{Name: "a", Objective: 0.9}, {Name: "b", Objective: 0.9}, {Name: "c", Objective: 0.9}}
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetSLOs(gomock.Any()).Return(slos, nil)
+	// every SLO is evaluated, even after one of them failed
+	gomock.InOrder(
+		repo.EXPECT().EvaluateSLO(gomock.Any(), slos[0], now).Return(nil, errors.New("connection refused")),
+		repo.EXPECT().EvaluateSLO(gomock.Any(), slos[1], now).
+			Return(&model.SLOEvaluation{SLOName: "b", Applications: 10, OnTime: 5, Compliance: util.ToPtr(0.5)}, nil),
+		repo.EXPECT().EvaluateSLO(gomock.Any(), slos[2], now).Return(&model.SLOEvaluation{SLOName: "c", Met: true}, nil),
+	)
+	repo.EXPECT().DeleteSLOEvaluationsBefore(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(3), nil)
+
+	e := NewEvaluator(repo, 24*time.Hour)
+	e.now = func() time.Time { return now }
+	assert.EqualError(t, e.Evaluate(context.Background()), "could not evaluate 1 of 3 slos")
+}
+
+func TestConfigSLOs(t *testing.T) {
+	cfg := &config.SLOConfig{Objectives: []config.QueueSLO{{
+		Name:        "analytics-start",
+		Partition:   "default",
+		Queue:       "root.analytics",
+		Objective:   0.95,
+		StartWithin: 5 * time.Minute,
+		Window:      24 * time.Hour,
+	}}}
+	assert.Equal(t, []*model.QueueSLO{{
+		Name:        "analytics-start",
+		Source:      model.SLOSourceConfig,
+		Partition:   "default",
+		Queue:       "root.analytics",
+		Objective:   0.95,
+		StartWithin: (5 * time.Minute).Nanoseconds(),
+		Window:      (24 * time.Hour).Nanoseconds(),
+	}}, ConfigSLOs(cfg))
+	assert.Empty(t, ConfigSLOs(&config.SLOConfig{}))
+}
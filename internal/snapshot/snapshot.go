@@ -0,0 +1,239 @@
+// Package snapshot holds exported Postgres snapshots open, so that clients composing several requests, e.g.
+// a dashboard whose panels are loaded by different requests, read a consistent view of the data instead of mixing
+// the data of different syncs. A snapshot is exported by a repeatable read transaction which is held open on
+// a connection of the pool until it expires or is released, and the requests reading it run their queries in
+// a transaction importing it.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+const (
+	// expiryInterval is the interval at which the expired snapshots are released.
+	expiryInterval = time.Second
+	// releaseTimeout bounds the rollback of the transactions, which is not cancelled with the request.
+	releaseTimeout = 5 * time.Second
+	// invalidParameterValue is the SQLSTATE code of importing a snapshot which is not exported.
+	invalidParameterValue = "22023"
+)
+
+var (
+	ErrNotFound         = errors.New("snapshot not found or expired")
+	ErrTooManySnapshots = errors.New("too many open snapshots")
+)
+
+// idPattern matches the IDs of the snapshots exported by Postgres, e.g. 00000003-0000001B-1. The ID is part of the
+// statement importing the snapshot, which does not accept parameters.
+var idPattern = regexp.MustCompile(`^[0-9A-F]+(-[0-9A-F]+){1,2}$`)
+
+var openSnapshots = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "yhs",
+	Subsystem: "snapshots",
+	Name:      "open",
+	Help:      "Number of snapshots held open by the replica, each of which holds a connection of the pool.",
+})
+
+// Snapshot is a snapshot of the database held open until it expires.
+type Snapshot struct {
+	// ID identifies the snapshot, and is passed by the requests reading it.
+	ID string `json:"id"`
+	// ExpiresAt is the time the snapshot is released unless it is released before.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// session is a transaction holding an exported snapshot open.
+type session interface {
+	id() string
+	close(ctx context.Context)
+}
+
+type heldSnapshot struct {
+	session   session
+	expiresAt time.Time
+}
+
+// Manager exports the snapshots, holds them open until they expire, and runs the requests reading them.
+type Manager struct {
+	export func(ctx context.Context) (session, error)
+	// begin begins a transaction importing the snapshot, and returns the context whose queries run in it and the
+	// function ending it.
+	begin   func(ctx context.Context, id string) (context.Context, func(), error)
+	ttl     time.Duration
+	maxOpen int
+	now     func() time.Time
+
+	mutex     sync.Mutex
+	snapshots map[string]*heldSnapshot
+}
+
+// NewManager creates a manager of the snapshots of the database of the pool.
+func NewManager(pool *pgxpool.Pool, cfg *config.SnapshotsConfig) *Manager {
+	export := func(ctx context.Context) (session, error) {
+		tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+		if err != nil {
+			return nil, err
+		}
+		s := &txSession{tx: tx}
+		if err := tx.QueryRow(ctx, "SELECT pg_export_snapshot()").Scan(&s.snapshotID); err != nil {
+			s.close(ctx)
+			return nil, err
+		}
+		return s, nil
+	}
+	begin := func(ctx context.Context, id string) (context.Context, func(), error) {
+		tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+		if err != nil {
+			return nil, nil, err
+		}
+		end := func() { (&txSession{tx: tx}).close(ctx) }
+		if _, err := tx.Exec(ctx, fmt.Sprintf("SET TRANSACTION SNAPSHOT '%s'", id)); err != nil {
+			end()
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == invalidParameterValue {
+				return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+			}
+			return nil, nil, err
+		}
+		return postgres.WithTx(ctx, tx), end, nil
+	}
+	return newManager(export, begin, cfg)
+}
+
+func newManager(
+	export func(context.Context) (session, error),
+	begin func(context.Context, string) (context.Context, func(), error),
+	cfg *config.SnapshotsConfig,
+) *Manager {
+	return &Manager{
+		export:    export,
+		begin:     begin,
+		ttl:       cfg.TTL,
+		maxOpen:   cfg.MaxOpen,
+		now:       time.Now,
+		snapshots: make(map[string]*heldSnapshot),
+	}
+}
+
+// Create exports a snapshot of the current data, which is held open for the TTL. It returns ErrTooManySnapshots if
+// the replica already holds the maximum number of snapshots open.
+func (m *Manager) Create(ctx context.Context) (*Snapshot, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if len(m.snapshots) >= m.maxOpen {
+		return nil, fmt.Errorf("%w: at most %d snapshots can be open", ErrTooManySnapshots, m.maxOpen)
+	}
+	s, err := m.export(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not export snapshot: %v", err)
+	}
+	held := &heldSnapshot{session: s, expiresAt: m.now().Add(m.ttl)}
+	m.snapshots[s.id()] = held
+	openSnapshots.Set(float64(len(m.snapshots)))
+	return &Snapshot{ID: s.id(), ExpiresAt: held.expiresAt}, nil
+}
+
+// Release releases the snapshot before it expires. It returns ErrNotFound if the replica does not hold the snapshot
+// open.
+func (m *Manager) Release(ctx context.Context, id string) error {
+	m.mutex.Lock()
+	held, ok := m.snapshots[id]
+	delete(m.snapshots, id)
+	openSnapshots.Set(float64(len(m.snapshots)))
+	m.mutex.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	held.session.close(ctx)
+	return nil
+}
+
+// Read calls fn with a context whose queries read the snapshot, which may be held open by another replica using the
+// same database. It returns ErrNotFound if the snapshot is not open.
+func (m *Manager) Read(ctx context.Context, id string, fn func(ctx context.Context)) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	ctx, end, err := m.begin(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer end()
+	fn(ctx)
+	return nil
+}
+
+// Run releases the snapshots once they expire until the context is cancelled, when all snapshots are released.
+func (m *Manager) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "snapshots")
+	ctx = log.ToContext(ctx, logger)
+
+	ticker := time.NewTicker(expiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.release(ctx, func(*heldSnapshot) bool { return true })
+			return nil
+		case <-ticker.C:
+			now := m.now()
+			m.release(ctx, func(held *heldSnapshot) bool { return !now.Before(held.expiresAt) })
+		}
+	}
+}
+
+// release releases the snapshots matching the predicate.
+func (m *Manager) release(ctx context.Context, matches func(*heldSnapshot) bool) {
+	m.mutex.Lock()
+	var released []*heldSnapshot
+	for id, held := range m.snapshots {
+		if matches(held) {
+			released = append(released, held)
+			delete(m.snapshots, id)
+		}
+	}
+	openSnapshots.Set(float64(len(m.snapshots)))
+	m.mutex.Unlock()
+	for _, held := range released {
+		held.session.close(ctx)
+	}
+	if len(released) > 0 {
+		log.FromContext(ctx).Debugw("released snapshots", "snapshots", len(released))
+	}
+}
+
+// txSession is the transaction of the pool holding an exported snapshot, whose connection is released to the pool
+// when it ends.
+type txSession struct {
+	tx         pgx.Tx
+	snapshotID string
+}
+
+func (s *txSession) id() string {
+	return s.snapshotID
+}
+
+func (s *txSession) close(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+	if err := s.tx.Rollback(ctx); err != nil {
+		log.FromContext(ctx).Warnw("could not end snapshot transaction", "error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/test/database"
+)
+
+func TestManager_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	pool := database.NewTestConnectionPool(ctx, t)
+	repo, err := repository.NewPostgresRepository(pool)
+	require.NoError(t, err)
+	m := NewManager(pool, &config.SnapshotsConfig{TTL: time.Minute, MaxOpen: 1})
+
+	require.NoError(t, repo.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: "default"}}))
+	s, err := m.Create(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpsertPartitions(ctx, []*dao.PartitionInfo{{Name: "gpu"}}))
+
+	partitionNames := func(ctx context.Context) []string {
+		partitions, err := repo.GetAllPartitions(ctx)
+		require.NoError(t, err)
+		var names []string
+		for _, p := range partitions {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+	assert.ElementsMatch(t, []string{"default", "gpu"}, partitionNames(ctx))
+	// every read of the snapshot sees the data as it was when the snapshot was created
+	for i := 0; i < 2; i++ {
+		require.NoError(t, m.Read(ctx, s.ID, func(ctx context.Context) {
+			assert.Equal(t, []string{"default"}, partitionNames(ctx))
+		}))
+	}
+
+	require.NoError(t, m.Release(ctx, s.ID))
+	err = m.Read(ctx, s.ID, func(context.Context) { t.Fatal("released snapshot must not be read") })
+	assert.ErrorIs(t, err, ErrNotFound)
+}
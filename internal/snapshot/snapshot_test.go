@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// fakeDatabase exports snapshots which stay importable until their session is closed.
+type fakeDatabase struct {
+	mutex    sync.Mutex
+	exported int
+	open     map[string]bool
+}
+
+func newFakeDatabase() *fakeDatabase {
+	return &fakeDatabase{open: make(map[string]bool)}
+}
+
+func (d *fakeDatabase) export(context.Context) (session, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.exported++
+	s := &fakeSession{db: d, snapshotID: fmt.Sprintf("00000003-%08X-1", d.exported)}
+	d.open[s.snapshotID] = true
+	return s, nil
+}
+
+type snapshotKey struct{}
+
+func (d *fakeDatabase) begin(ctx context.Context, id string) (context.Context, func(), error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !d.open[id] {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return context.WithValue(ctx, snapshotKey{}, id), func() {}, nil
+}
+
+func (d *fakeDatabase) isOpen(id string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.open[id]
+}
+
+type fakeSession struct {
+	db         *fakeDatabase
+	snapshotID string
+}
+
+func (s *fakeSession) id() string {
+	return s.snapshotID
+}
+
+func (s *fakeSession) close(context.Context) {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	delete(s.db.open, s.snapshotID)
+}
+
+func TestManager_CreateReadRelease(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDatabase()
+	m := newManager(db.export, db.begin, &config.SnapshotsConfig{TTL: time.Minute, MaxOpen: 2})
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	s1, err := m.Create(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Minute), s1.ExpiresAt)
+	s2, err := m.Create(ctx)
+	require.NoError(t, err)
+	_, err = m.Create(ctx)
+	assert.ErrorIs(t, err, ErrTooManySnapshots)
+
+	var read any
+	require.NoError(t, m.Read(ctx, s1.ID, func(ctx context.Context) { read = ctx.Value(snapshotKey{}) }))
+	assert.Equal(t, s1.ID, read)
+
+	require.NoError(t, m.Release(ctx, s1.ID))
+	assert.False(t, db.isOpen(s1.ID))
+	assert.ErrorIs(t, m.Release(ctx, s1.ID), ErrNotFound)
+	assert.ErrorIs(t, m.Read(ctx, s1.ID, func(context.Context) { t.Fatal("released snapshot must not be read") }),
+		ErrNotFound)
+	assert.True(t, db.isOpen(s2.ID))
+
+	// a snapshot can be created again once one is released
+	_, err = m.Create(ctx)
+	require.NoError(t, err)
+}
+
+func TestManager_ReadInvalidID(t *testing.T) {
+	db := newFakeDatabase()
+	m := newManager(db.export, func(context.Context, string) (context.Context, func(), error) {
+		t.Fatal("an invalid ID must not be imported")
+		return nil, nil, nil
+	}, &config.SnapshotsConfig{TTL: time.Minute, MaxOpen: 1})
+
+	for _, id := range []string{"", "latest", "00000003-0000001B-1'; DROP TABLE applications; --"} {
+		err := m.Read(context.Background(), id, func(context.Context) {})
+		assert.ErrorIs(t, err, ErrNotFound, id)
+	}
+}
+
+func TestManager_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db := newFakeDatabase()
+	m := newManager(db.export, db.begin, &config.SnapshotsConfig{TTL: time.Minute, MaxOpen: 2})
+	var mutex sync.Mutex
+	now := time.Now()
+	m.now = func() time.Time {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return now
+	}
+
+	expiring, err := m.Create(ctx)
+	require.NoError(t, err)
+	mutex.Lock()
+	now = now.Add(30 * time.Second)
+	mutex.Unlock()
+	held, err := m.Create(ctx)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+	mutex.Lock()
+	now = now.Add(30 * time.Second)
+	mutex.Unlock()
+	require.Eventually(t, func() bool { return !db.isOpen(expiring.ID) }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, db.isOpen(held.ID))
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.False(t, db.isOpen(held.ID), "snapshots must be released on shutdown")
+}
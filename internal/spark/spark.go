@@ -0,0 +1,54 @@
+package spark
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
+)
+
+// HistoryLinkName is the name of the link to the Spark History Server returned with applications.
+const HistoryLinkName = "spark-history"
+
+// ApplicationID returns the Spark application ID of the application, taken from the given tag of its allocations,
+// or of its requests if nothing is allocated yet. It returns an empty string if the application was not run by Spark.
+func ApplicationID(app *dao.ApplicationDAOInfo, tag string) string {
+	return tags.Value(app, tag)
+}
+
+// HistoryServer links applications to the Spark History Server UI. A nil HistoryServer links no applications.
+type HistoryServer struct {
+	url *url.URL
+}
+
+// NewHistoryServer creates a HistoryServer from the config. It returns nil if no history server URL is configured.
+func NewHistoryServer(cfg *config.SparkConfig) (*HistoryServer, error) {
+	if cfg.HistoryServerURL == "" {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(strings.TrimSuffix(cfg.HistoryServerURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid spark history server url: %v", err)
+	}
+	return &HistoryServer{url: u}, nil
+}
+
+// Link returns the link to the Spark History Server UI of the application, or nil if the application
+// has no Spark application ID.
+func (h *HistoryServer) Link(app *model.ApplicationDAOInfo) *model.ExternalLink {
+	if h == nil || app.SparkApplicationID == "" {
+		return nil
+	}
+	return &model.ExternalLink{
+		Name: HistoryLinkName,
+		URL:  h.url.JoinPath("history", app.SparkApplicationID, "/").String(),
+	}
+}
@@ -0,0 +1,69 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestApplicationID(t *testing.T) {
+	tag := config.DefaultSparkApplicationIDTag
+	tests := map[string]struct {
+		app  *dao.ApplicationDAOInfo
+		want string
+	}{
+		"not spark": {
+			app: &dao.ApplicationDAOInfo{
+				Allocations: []*dao.AllocationDAOInfo{{AllocationTags: map[string]string{"kubernetes.io/label/app": "web"}}},
+			},
+			want: "",
+		},
+		"allocations": {
+			app: &dao.ApplicationDAOInfo{
+				Allocations: []*dao.AllocationDAOInfo{nil, {AllocationTags: map[string]string{tag: "spark-1"}}},
+				Requests:    []*dao.AllocationAskDAOInfo{{AllocationTags: map[string]string{tag: "spark-2"}}},
+			},
+			want: "spark-1",
+		},
+		"requests only": {
+			app: &dao.ApplicationDAOInfo{
+				Requests: []*dao.AllocationAskDAOInfo{{AllocationTags: map[string]string{tag: "spark-2"}}},
+			},
+			want: "spark-2",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ApplicationID(tt.app, tag))
+		})
+	}
+}
+
+func TestHistoryServerLink(t *testing.T) {
+	h, err := NewHistoryServer(&config.SparkConfig{
+		ApplicationIDTag: config.DefaultSparkApplicationIDTag,
+		HistoryServerURL: "https://spark-history.example.com/",
+	})
+	require.NoError(t, err)
+
+	link := h.Link(&model.ApplicationDAOInfo{SparkApplicationID: "spark-0123456789abcdef"})
+	assert.Equal(t, &model.ExternalLink{
+		Name: HistoryLinkName,
+		URL:  "https://spark-history.example.com/history/spark-0123456789abcdef/",
+	}, link)
+	assert.Nil(t, h.Link(&model.ApplicationDAOInfo{}))
+
+	var none *HistoryServer
+	assert.Nil(t, none.Link(&model.ApplicationDAOInfo{SparkApplicationID: "spark-0123456789abcdef"}))
+}
+
+func TestNewHistoryServerNotConfigured(t *testing.T) {
+	h, err := NewHistoryServer(&config.SparkConfig{ApplicationIDTag: config.DefaultSparkApplicationIDTag})
+	require.NoError(t, err)
+	assert.Nil(t, h)
+}
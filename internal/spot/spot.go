@@ -0,0 +1,79 @@
+// Package spot records the terminations of nodes from the event stream of Yunikorn, with the allocations the nodes
+// were running, so that the reclamation of spot and preemptible nodes by the cloud provider can be correlated with
+// the workloads it disrupts.
+package spot
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Repository is the part of the repository the terminations of the nodes are stored in.
+type Repository interface {
+	RecordNodeTermination(
+		ctx context.Context, nodeID string, terminatedAt int64, spotSelectors []map[string]string,
+	) (*model.NodeTermination, error)
+}
+
+// Recorder records the events in the wrapped event repository, and stores the terminations of the nodes removed
+// from Yunikorn.
+type Recorder struct {
+	repository.EventRepository
+	repo      Repository
+	selectors []map[string]string
+	now       func() time.Time
+}
+
+var _ repository.EventRepository = &Recorder{}
+
+// NewRecorder creates a recorder, which stores the terminations in the repository according to the configuration.
+func NewRecorder(events repository.EventRepository, repo Repository, cfg *config.SpotConfig) *Recorder {
+	return &Recorder{
+		EventRepository: events,
+		repo:            repo,
+		selectors:       cfg.Selectors,
+		now:             time.Now,
+	}
+}
+
+// Record records the event in the wrapped event repository and, if it is the removal of a node, stores the
+// termination of the node. Terminations are best effort: the errors storing them are logged.
+func (r *Recorder) Record(ctx context.Context, event *si.EventRecord) error {
+	if err := r.EventRepository.Record(ctx, event); err != nil {
+		return err
+	}
+	if !isNodeRemoval(event) {
+		return nil
+	}
+	terminatedAt := event.GetTimestampNano()
+	if terminatedAt == 0 {
+		terminatedAt = r.now().UnixNano()
+	}
+	logger := log.FromContext(ctx)
+	termination, err := r.repo.RecordNodeTermination(ctx, event.GetObjectID(), terminatedAt, r.selectors)
+	if err != nil {
+		logger.Errorf("could not store termination of node %s: %v", event.GetObjectID(), err)
+		return nil
+	}
+	if termination == nil {
+		logger.Debugw("not storing termination of unknown node", "nodeId", event.GetObjectID())
+		return nil
+	}
+	logger.Infow("stored termination of node", "nodeId", termination.NodeID, "spot", termination.Spot,
+		"drained", termination.Drained, "allocations", len(termination.Allocations))
+	return nil
+}
+
+// isNodeRemoval returns whether the event is the removal of a node from Yunikorn.
+func isNodeRemoval(event *si.EventRecord) bool {
+	return event.GetType() == si.EventRecord_NODE &&
+		event.GetEventChangeType() == si.EventRecord_REMOVE &&
+		event.GetEventChangeDetail() == si.EventRecord_NODE_DECOMISSION
+}
@@ -0,0 +1,78 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+var selectors = []map[string]string{{"karpenter.sh/capacity-type": "spot"}}
+
+func newTestRecorder(repo Repository) (*Recorder, *repository.InMemoryEventRepository) {
+	events := repository.NewInMemoryEventRepository()
+	recorder := NewRecorder(events, repo, &config.SpotConfig{Enabled: true, Selectors: selectors})
+	recorder.now = func() time.Time { return time.Unix(0, 99) }
+	return recorder, events
+}
+
+func TestRecorderRecord(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	recorder, events := newTestRecorder(repo)
+
+	repo.EXPECT().RecordNodeTermination(gomock.Any(), "node-1", int64(42), selectors).
+		Return(&model.NodeTermination{NodeID: "node-1", Spot: true}, nil)
+	// the time the event is recorded is used if the event has none
+	repo.EXPECT().RecordNodeTermination(gomock.Any(), "node-2", int64(99), selectors).Return(nil, nil)
+	repo.EXPECT().RecordNodeTermination(gomock.Any(), "node-3", gomock.Any(), selectors).
+		Return(nil, errors.New("database is down"))
+
+	ctx := context.Background()
+	for _, event := range []*si.EventRecord{
+		{
+			Type:              si.EventRecord_NODE,
+			ObjectID:          "node-1",
+			EventChangeType:   si.EventRecord_REMOVE,
+			EventChangeDetail: si.EventRecord_NODE_DECOMISSION,
+			TimestampNano:     42,
+		},
+		{
+			Type:              si.EventRecord_NODE,
+			ObjectID:          "node-2",
+			EventChangeType:   si.EventRecord_REMOVE,
+			EventChangeDetail: si.EventRecord_NODE_DECOMISSION,
+		},
+		// errors storing the termination are not returned
+		{
+			Type:              si.EventRecord_NODE,
+			ObjectID:          "node-3",
+			EventChangeType:   si.EventRecord_REMOVE,
+			EventChangeDetail: si.EventRecord_NODE_DECOMISSION,
+		},
+		// other events of the nodes are not terminations
+		{
+			Type:              si.EventRecord_NODE,
+			ObjectID:          "node-1",
+			EventChangeType:   si.EventRecord_SET,
+			EventChangeDetail: si.EventRecord_NODE_SCHEDULABLE,
+		},
+		{Type: si.EventRecord_APP, ObjectID: "app-1", EventChangeType: si.EventRecord_REMOVE},
+	} {
+		require.NoError(t, recorder.Record(ctx, event))
+	}
+
+	counts, err := events.Counts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, counts["NODE-REMOVE"])
+	assert.Equal(t, 1, counts["NODE-SET"])
+}
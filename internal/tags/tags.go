@@ -0,0 +1,98 @@
+// Package tags reads the allocation tags of applications. The YuniKorn k8shim adds the labels of pods
+// as allocation tags prefixed with kubernetes.io/label/, which identify the jobs and workflows applications belong to.
+package tags
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+)
+
+// Value returns the value of the tag of the allocations of the application, or of its requests if nothing
+// is allocated yet. It returns an empty string if no allocation or request has the tag.
+func Value(app *dao.ApplicationDAOInfo, tag string) string {
+	for _, alloc := range app.Allocations {
+		if alloc != nil && alloc.AllocationTags[tag] != "" {
+			return alloc.AllocationTags[tag]
+		}
+	}
+	for _, ask := range app.Requests {
+		if ask != nil && ask.AllocationTags[tag] != "" {
+			return ask.AllocationTags[tag]
+		}
+	}
+	return ""
+}
+
+// combinedTagSeparator combines several tags in a key tag, e.g. the DAG and run labels of Airflow.
+const combinedTagSeparator = "+"
+
+// combinedValueSeparator joins the values of combined tags. It cannot occur in Kubernetes label values,
+// so that the joined values are unambiguous.
+const combinedValueSeparator = ":"
+
+// Key returns the value of the first of the key tags found in the allocation tags of the application. A key tag
+// combining several tags with + is found if all of them are present, and their values are joined with :.
+// It returns an empty string if none of the key tags is found.
+func Key(app *dao.ApplicationDAOInfo, keyTags []string) string {
+	for _, keyTag := range keyTags {
+		var values []string
+		for _, tag := range strings.Split(keyTag, combinedTagSeparator) {
+			value := Value(app, strings.TrimSpace(tag))
+			if value == "" {
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if len(values) > 0 {
+			return strings.Join(values, combinedValueSeparator)
+		}
+	}
+	return ""
+}
+
+// NamespaceTag is the allocation tag the YuniKorn k8shim adds with the namespace of the pods.
+const NamespaceTag = "kubernetes.io/meta/namespace"
+
+// Namespace returns the Kubernetes namespace of the application, or an empty string if it is unknown.
+func Namespace(app *dao.ApplicationDAOInfo) string {
+	return Value(app, NamespaceTag)
+}
+
+// RequiredNodeTag is the allocation tag the YuniKorn k8shim adds with the node a pod must run on, e.g. for daemon sets.
+const RequiredNodeTag = "kubernetes.io/meta/requiredNode"
+
+// TaskGroupsTag is the allocation tag of the task groups annotation of gang-scheduled pods, if the annotations of
+// pods are exposed as allocation tags. YuniKorn does not report the node selectors, affinities and tolerations of
+// pods, but the task groups define those of the placeholders and are expected to match the pods they are swapped with.
+const TaskGroupsTag = "kubernetes.io/annotation/yunikorn.apache.org/task-groups"
+
+// TaskGroup is the definition of a task group in the task groups annotation. The affinity and tolerations are kept
+// as JSON, in the format of the Kubernetes API.
+type TaskGroup struct {
+	Name         string            `json:"name"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	Affinity     json.RawMessage   `json:"affinity,omitempty"`
+	Tolerations  json.RawMessage   `json:"tolerations,omitempty"`
+}
+
+// FindTaskGroup returns the definition of the named task group from the task groups annotation in the allocation
+// tags, or nil if it is not defined or the annotation is not valid.
+func FindTaskGroup(allocationTags map[string]string, name string) *TaskGroup {
+	annotation := allocationTags[TaskGroupsTag]
+	if annotation == "" || name == "" {
+		return nil
+	}
+	var taskGroups []*TaskGroup
+	if err := json.Unmarshal([]byte(annotation), &taskGroups); err != nil {
+		return nil
+	}
+	for _, taskGroup := range taskGroups {
+		if taskGroup != nil && taskGroup.Name == name {
+			return taskGroup
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTaskGroup(t *testing.T) {
+	allocationTags := map[string]string{
+		TaskGroupsTag: `[
+			{"name": "driver", "minMember": 1},
+			{"name": "executors", "minMember": 4, "nodeSelector": {"disktype": "ssd"},
+			 "tolerations": [{"key": "dedicated", "operator": "Equal", "value": "spark", "effect": "NoSchedule"}]}
+		]`,
+	}
+
+	taskGroup := FindTaskGroup(allocationTags, "executors")
+	require.NotNil(t, taskGroup)
+	assert.Equal(t, map[string]string{"disktype": "ssd"}, taskGroup.NodeSelector)
+	assert.JSONEq(t, `[{"key": "dedicated", "operator": "Equal", "value": "spark", "effect": "NoSchedule"}]`,
+		string(taskGroup.Tolerations))
+	assert.Empty(t, taskGroup.Affinity)
+
+	assert.NotNil(t, FindTaskGroup(allocationTags, "driver"))
+	assert.Nil(t, FindTaskGroup(allocationTags, "unknown"))
+	assert.Nil(t, FindTaskGroup(allocationTags, ""))
+	assert.Nil(t, FindTaskGroup(map[string]string{TaskGroupsTag: "not json"}, "executors"))
+	assert.Nil(t, FindTaskGroup(nil, "executors"))
+}
@@ -0,0 +1,64 @@
+package tiering
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// Mover moves the applications which finished before the cold tier boundary to the cold tier,
+// in batches so that the rows of the applications table are not locked for long. It is run by the scheduler.
+type Mover struct {
+	repo      repository.Repository
+	coldAfter time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+type Option func(*Mover)
+
+// WithBatchSize sets the number of applications moved to the cold tier per transaction.
+func WithBatchSize(batchSize int) Option {
+	return func(m *Mover) {
+		m.batchSize = batchSize
+	}
+}
+
+// NewMover creates a mover of the applications which finished coldAfter ago.
+func NewMover(repo repository.Repository, coldAfter time.Duration, opts ...Option) *Mover {
+	m := &Mover{
+		repo:      repo,
+		coldAfter: coldAfter,
+		batchSize: 1000,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Move moves the applications which finished before the cold tier boundary to the cold tier, batch by batch
+// until a batch is not full.
+func (m *Mover) Move(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	before := m.now().Add(-m.coldAfter)
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		moved, err := m.repo.MoveApplicationsToColdTier(ctx, before, m.batchSize)
+		if err != nil {
+			return err
+		}
+		total += moved
+		if moved < int64(m.batchSize) {
+			break
+		}
+	}
+	logger.Infow("finished moving applications to cold tier", "before", before, "moved", total)
+	return nil
+}
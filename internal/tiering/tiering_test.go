@@ -0,0 +1,40 @@
+package tiering
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestMover_Move(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-720 * time.Hour)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	gomock.InOrder(
+		repo.EXPECT().MoveApplicationsToColdTier(gomock.Any(), before, 2).Return(int64(2), nil),
+		repo.EXPECT().MoveApplicationsToColdTier(gomock.Any(), before, 2).Return(int64(2), nil),
+		repo.EXPECT().MoveApplicationsToColdTier(gomock.Any(), before, 2).Return(int64(1), nil),
+	)
+
+	m := NewMover(repo, 720*time.Hour, WithBatchSize(2))
+	m.now = func() time.Time { return now }
+	require.NoError(t, m.Move(context.Background()))
+}
+
+func TestMover_MoveError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().MoveApplicationsToColdTier(gomock.Any(), gomock.Any(), 1000).Return(int64(0), errors.New("connection refused"))
+
+	err := NewMover(repo, time.Hour).Move(context.Background())
+	assert.EqualError(t, err, "connection refused")
+}
@@ -0,0 +1,182 @@
+// Package trace stores the events of the event stream of Yunikorn which refer to an application as the decision
+// trace of the application, giving an after-the-fact view of the decisions of each scheduling cycle for it.
+// Tracing is opt-in, and the stored events are sampled per application and capped in number and size.
+package trace
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// pruneInterval is the interval at which the events older than the maximum age are deleted.
+const pruneInterval = time.Hour
+
+// sampleBuckets is the number of buckets the IDs of the applications are hashed into to sample them.
+const sampleBuckets = 1_000_000
+
+// Repository is the part of the repository the traces are stored in.
+type Repository interface {
+	AddTraceEvent(ctx context.Context, event *model.TraceEvent, maxEvents int) (bool, error)
+	DeleteTraceEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Recorder records the events in the wrapped event repository, and stores those of the sampled applications
+// in their traces.
+type Recorder struct {
+	repository.EventRepository
+	repo             Repository
+	sampleRate       float64
+	maxEvents        int
+	maxMessageLength int
+	maxAge           time.Duration
+	now              func() time.Time
+}
+
+var _ repository.EventRepository = &Recorder{}
+
+// NewRecorder creates a recorder, which stores the traces in the repository according to the configuration.
+func NewRecorder(events repository.EventRepository, repo Repository, cfg *config.TraceConfig) *Recorder {
+	return &Recorder{
+		EventRepository:  events,
+		repo:             repo,
+		sampleRate:       cfg.SampleRate,
+		maxEvents:        cfg.MaxEvents,
+		maxMessageLength: cfg.MaxMessageLength,
+		maxAge:           cfg.MaxAge,
+		now:              time.Now,
+	}
+}
+
+// Record records the event in the wrapped event repository and stores it in the trace of its application, if the
+// application is sampled and its trace is not full. Traces are best effort: the errors storing them are logged.
+func (r *Recorder) Record(ctx context.Context, event *si.EventRecord) error {
+	if err := r.EventRepository.Record(ctx, event); err != nil {
+		return err
+	}
+	appID := ApplicationID(event)
+	if appID == "" || !r.sampled(appID) {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	stored, err := r.repo.AddTraceEvent(ctx, r.traceEvent(appID, event), r.maxEvents)
+	if err != nil {
+		logger.Errorf("could not store event in the trace of application %s: %v", appID, err)
+		return nil
+	}
+	if !stored {
+		logger.Debugw("dropping event because the trace of the application is full", "applicationId", appID)
+	}
+	return nil
+}
+
+// ApplicationID returns the ID of the application the event refers to: the object of application events, or
+// the reference of request events, whose object is the allocation key. It returns an empty string for the events
+// of other objects.
+func ApplicationID(event *si.EventRecord) string {
+	switch event.GetType() {
+	case si.EventRecord_APP:
+		return event.GetObjectID()
+	case si.EventRecord_REQUEST:
+		return event.GetReferenceID()
+	default:
+		return ""
+	}
+}
+
+// sampled returns whether the events of the application are stored. The application is sampled by the hash of
+// its ID, so that all its events are stored or none.
+func (r *Recorder) sampled(appID string) bool {
+	if r.sampleRate >= 1 {
+		return true
+	}
+	if r.sampleRate <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(appID))
+	return float64(h.Sum32()%sampleBuckets) < r.sampleRate*sampleBuckets
+}
+
+// traceEvent converts the event to the event of the trace of the application, with its message truncated.
+func (r *Recorder) traceEvent(appID string, event *si.EventRecord) *model.TraceEvent {
+	traceEvent := &model.TraceEvent{
+		ApplicationID: appID,
+		Type:          event.GetType().String(),
+		ObjectID:      event.GetObjectID(),
+		ChangeType:    event.GetEventChangeType().String(),
+		ChangeDetail:  event.GetEventChangeDetail().String(),
+		Timestamp:     event.GetTimestampNano(),
+	}
+	if traceEvent.Timestamp == 0 {
+		traceEvent.Timestamp = r.now().UnixNano()
+	}
+	if referenceID := event.GetReferenceID(); referenceID != "" {
+		traceEvent.ReferenceID = &referenceID
+	}
+	if message := event.GetMessage(); message != "" {
+		message = truncate(message, r.maxMessageLength)
+		traceEvent.Message = &message
+	}
+	if resources := event.GetResource().GetResources(); len(resources) > 0 {
+		traceEvent.Resource = make(map[string]int64, len(resources))
+		for name, quantity := range resources {
+			traceEvent.Resource[name] = quantity.GetValue()
+		}
+	}
+	return traceEvent
+}
+
+// truncate returns the longest prefix of s of at most maxLength bytes which does not split a character.
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	for maxLength > 0 && !utf8.RuneStart(s[maxLength]) {
+		maxLength--
+	}
+	return s[:maxLength]
+}
+
+// Run deletes the events older than the maximum age at regular intervals until the context is cancelled.
+func (r *Recorder) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", "trace_pruner")
+	ctx = log.ToContext(ctx, logger)
+
+	logger.Info("starting trace pruner")
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warn("shutting down trace pruner")
+			return nil
+		case <-ticker.C:
+			r.prune(ctx)
+		}
+	}
+}
+
+// prune deletes the events older than the maximum age.
+func (r *Recorder) prune(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	deleted, err := r.repo.DeleteTraceEventsBefore(ctx, r.now().Add(-r.maxAge))
+	if err != nil {
+		logger.Errorf("error pruning trace events: %v", err)
+		return
+	}
+	if deleted > 0 {
+		logger.Infow("pruned trace events", "count", deleted)
+	}
+}
@@ -0,0 +1,119 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func newTestRecorder(repo Repository, sampleRate float64) (*Recorder, *repository.InMemoryEventRepository) {
+	events := repository.NewInMemoryEventRepository()
+	return NewRecorder(events, repo, &config.TraceConfig{
+		Enabled:          true,
+		SampleRate:       sampleRate,
+		MaxEvents:        10,
+		MaxMessageLength: 8,
+		MaxAge:           time.Hour,
+	}), events
+}
+
+func TestRecorderRecord(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	recorder, events := newTestRecorder(repo, 1)
+
+	repo.EXPECT().AddTraceEvent(gomock.Any(), &model.TraceEvent{
+		ApplicationID: "app-1",
+		Type:          "REQUEST",
+		ObjectID:      "pod-1",
+		ReferenceID:   util.ToPtr("app-1"),
+		ChangeType:    "NONE",
+		ChangeDetail:  "DETAILS_NONE",
+		Message:       util.ToPtr("predicat"),
+		Resource:      map[string]int64{"memory": 1024},
+		Timestamp:     42,
+	}, 10).Return(true, nil)
+	repo.EXPECT().AddTraceEvent(gomock.Any(), gomock.Any(), 10).Return(false, errors.New("database is down"))
+
+	ctx := context.Background()
+	require.NoError(t, recorder.Record(ctx, &si.EventRecord{
+		Type:          si.EventRecord_REQUEST,
+		ObjectID:      "pod-1",
+		ReferenceID:   "app-1",
+		Message:       "predicate failed",
+		TimestampNano: 42,
+		Resource:      &si.Resource{Resources: map[string]*si.Quantity{"memory": {Value: 1024}}},
+	}))
+	// the events of nodes do not refer to an application
+	require.NoError(t, recorder.Record(ctx, &si.EventRecord{Type: si.EventRecord_NODE, ObjectID: "node-1"}))
+	// traces are best effort
+	require.NoError(t, recorder.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "app-1"}))
+
+	counts, err := events.Counts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts["REQUEST-NONE"])
+	assert.Equal(t, 1, counts["NODE-NONE"])
+	assert.Equal(t, 1, counts["APP-NONE"])
+}
+
+func TestRecorderSampling(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	var sampled []string
+	repo.EXPECT().AddTraceEvent(gomock.Any(), gomock.Any(), 10).DoAndReturn(
+		func(_ context.Context, event *model.TraceEvent, _ int) (bool, error) {
+			sampled = append(sampled, event.ApplicationID)
+			return true, nil
+		}).AnyTimes()
+	recorder, _ := newTestRecorder(repo, 0.5)
+
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		// every application is sampled the same way for all its events
+		for j := 0; j < 2; j++ {
+			require.NoError(t, recorder.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: fmt.Sprintf("app-%d", i)}))
+		}
+	}
+	assert.InDelta(t, 1000, len(sampled), 100)
+	for i := 0; i < len(sampled); i += 2 {
+		assert.Equal(t, sampled[i], sampled[i+1])
+	}
+
+	stored := len(sampled)
+	recorder.sampleRate = 0
+	require.NoError(t, recorder.Record(ctx, &si.EventRecord{Type: si.EventRecord_APP, ObjectID: sampled[0]}))
+	assert.Len(t, sampled, stored)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 8))
+	assert.Equal(t, "exactly8", truncate("exactly8", 8))
+	assert.Equal(t, "too long", truncate("too long message", 8))
+	// characters are not split
+	assert.Equal(t, "caf", truncate("café", 4))
+	assert.Equal(t, "", truncate(strings.Repeat("é", 2), 1))
+}
+
+func TestRecorderPrune(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	recorder, _ := newTestRecorder(repo, 1)
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	repo.EXPECT().DeleteTraceEventsBefore(gomock.Any(), now.Add(-time.Hour)).Return(int64(3), nil)
+	recorder.prune(context.Background())
+}
@@ -0,0 +1,225 @@
+// Package transform applies the configured transformation rules to the data of Yunikorn before it is stored or
+// forwarded, so that operators can adapt it to local conventions: events matching selectors are dropped, fields
+// such as the user of applications are redacted, and queues are renamed.
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// Transformer applies the transformation rules. A nil Transformer leaves the data unchanged.
+type Transformer struct {
+	drop          []selector
+	redact        []redaction
+	queueMappings []queueMapping
+}
+
+type selector struct {
+	eventType    *si.EventRecord_Type
+	changeType   *si.EventRecord_ChangeType
+	changeDetail *si.EventRecord_ChangeDetail
+	objectID     *regexp.Regexp
+	message      *regexp.Regexp
+}
+
+type redaction struct {
+	fields      []string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+type queueMapping struct {
+	from *regexp.Regexp
+	to   string
+}
+
+// New compiles the rules configured by cfg, or returns nil if no rules are configured.
+func New(cfg *config.TransformConfig) (*Transformer, error) {
+	if len(cfg.Drop) == 0 && len(cfg.Redact) == 0 && len(cfg.QueueMappings) == 0 {
+		return nil, nil
+	}
+	t := &Transformer{}
+	for i, s := range cfg.Drop {
+		sel, err := newSelector(s)
+		if err != nil {
+			return nil, fmt.Errorf("drop rule %d: %w", i, err)
+		}
+		t.drop = append(t.drop, sel)
+	}
+	for i, r := range cfg.Redact {
+		red := redaction{fields: r.Fields, replacement: r.Replacement}
+		if r.Pattern != "" {
+			pattern, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("redact rule %d: invalid pattern: %w", i, err)
+			}
+			red.pattern = pattern
+		}
+		t.redact = append(t.redact, red)
+	}
+	for i, m := range cfg.QueueMappings {
+		// the mappings match the full names of queues
+		from, err := regexp.Compile("^(?:" + m.From + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("queue mapping %d: invalid from: %w", i, err)
+		}
+		t.queueMappings = append(t.queueMappings, queueMapping{from: from, to: m.To})
+	}
+	return t, nil
+}
+
+func newSelector(s config.EventSelector) (selector, error) {
+	var sel selector
+	if s.Type != "" {
+		v, ok := si.EventRecord_Type_value[s.Type]
+		if !ok {
+			return sel, fmt.Errorf("unknown type %q", s.Type)
+		}
+		sel.eventType = util.ToPtr(si.EventRecord_Type(v))
+	}
+	if s.ChangeType != "" {
+		v, ok := si.EventRecord_ChangeType_value[s.ChangeType]
+		if !ok {
+			return sel, fmt.Errorf("unknown change type %q", s.ChangeType)
+		}
+		sel.changeType = util.ToPtr(si.EventRecord_ChangeType(v))
+	}
+	if s.ChangeDetail != "" {
+		v, ok := si.EventRecord_ChangeDetail_value[s.ChangeDetail]
+		if !ok {
+			return sel, fmt.Errorf("unknown change detail %q", s.ChangeDetail)
+		}
+		sel.changeDetail = util.ToPtr(si.EventRecord_ChangeDetail(v))
+	}
+	var err error
+	if s.ObjectID != "" {
+		if sel.objectID, err = regexp.Compile(s.ObjectID); err != nil {
+			return sel, fmt.Errorf("invalid object ID: %w", err)
+		}
+	}
+	if s.Message != "" {
+		if sel.message, err = regexp.Compile(s.Message); err != nil {
+			return sel, fmt.Errorf("invalid message: %w", err)
+		}
+	}
+	return sel, nil
+}
+
+func (s *selector) matches(ev *si.EventRecord) bool {
+	return (s.eventType == nil || *s.eventType == ev.GetType()) &&
+		(s.changeType == nil || *s.changeType == ev.GetEventChangeType()) &&
+		(s.changeDetail == nil || *s.changeDetail == ev.GetEventChangeDetail()) &&
+		(s.objectID == nil || s.objectID.MatchString(ev.GetObjectID())) &&
+		(s.message == nil || s.message.MatchString(ev.GetMessage()))
+}
+
+// DropEvent returns true if the event matches one of the drop rules.
+func (t *Transformer) DropEvent(ev *si.EventRecord) bool {
+	if t == nil {
+		return false
+	}
+	for i := range t.drop {
+		if t.drop[i].matches(ev) {
+			return true
+		}
+	}
+	return false
+}
+
+// Event redacts the fields of the event and renames the queue of queue events in place.
+func (t *Transformer) Event(ev *si.EventRecord) {
+	if t == nil {
+		return
+	}
+	ev.Message = t.redactField(config.RedactFieldMessage, ev.Message)
+	ev.ObjectID = t.redactField(config.RedactFieldObjectID, ev.ObjectID)
+	ev.ReferenceID = t.redactField(config.RedactFieldReferenceID, ev.ReferenceID)
+	if ev.GetType() == si.EventRecord_QUEUE {
+		ev.ObjectID = t.QueueName(ev.ObjectID)
+	}
+}
+
+// Application returns a copy of the application with its user and groups redacted and its queue renamed.
+func (t *Transformer) Application(app *dao.ApplicationDAOInfo) *dao.ApplicationDAOInfo {
+	if t == nil {
+		return app
+	}
+	transformed := *app
+	transformed.QueueName = t.QueueName(app.QueueName)
+	transformed.User = t.redactField(config.RedactFieldUser, app.User)
+	if len(app.Groups) > 0 {
+		transformed.Groups = make([]string, len(app.Groups))
+		for i, group := range app.Groups {
+			transformed.Groups[i] = t.redactField(config.RedactFieldGroups, group)
+		}
+	}
+	return &transformed
+}
+
+// Queue returns a copy of the queue with the names of the queue, its parent and its children renamed.
+func (t *Transformer) Queue(q *dao.PartitionQueueDAOInfo) *dao.PartitionQueueDAOInfo {
+	if t == nil {
+		return q
+	}
+	transformed := *q
+	transformed.QueueName = t.QueueName(q.QueueName)
+	if q.Parent != "" {
+		transformed.Parent = t.QueueName(q.Parent)
+	}
+	if len(q.Children) > 0 {
+		transformed.Children = make([]dao.PartitionQueueDAOInfo, len(q.Children))
+		for i := range q.Children {
+			transformed.Children[i] = *t.Queue(&q.Children[i])
+		}
+	}
+	return &transformed
+}
+
+// QueueACL returns a copy of the ACL of a queue with the queue renamed.
+func (t *Transformer) QueueACL(acl *model.QueueACL) *model.QueueACL {
+	if t == nil {
+		return acl
+	}
+	transformed := *acl
+	transformed.QueueName = t.QueueName(acl.QueueName)
+	return &transformed
+}
+
+// QueueName returns the name of the queue renamed by the first mapping matching it.
+func (t *Transformer) QueueName(name string) string {
+	if t == nil {
+		return name
+	}
+	for _, m := range t.queueMappings {
+		if m.from.MatchString(name) {
+			return m.from.ReplaceAllString(name, m.to)
+		}
+	}
+	return name
+}
+
+func (t *Transformer) redactField(field, value string) string {
+	if value == "" {
+		return value
+	}
+	for _, r := range t.redact {
+		if !slices.Contains(r.fields, field) {
+			continue
+		}
+		if r.pattern == nil {
+			value = r.replacement
+		} else {
+			value = r.pattern.ReplaceAllString(value, r.replacement)
+		}
+	}
+	return value
+}
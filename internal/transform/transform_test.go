@@ -0,0 +1,144 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestNew(t *testing.T) {
+	tr, err := New(&config.TransformConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, tr)
+
+	_, err = New(&config.TransformConfig{Drop: []config.EventSelector{{ChangeDetail: "APP_UNKNOWN"}}})
+	assert.Error(t, err)
+	_, err = New(&config.TransformConfig{Redact: []config.Redaction{{Fields: []string{"user"}, Pattern: "("}}})
+	assert.Error(t, err)
+	_, err = New(&config.TransformConfig{QueueMappings: []config.QueueMapping{{From: "(", To: "root"}}})
+	assert.Error(t, err)
+}
+
+func TestTransformer_Nil(t *testing.T) {
+	var tr *Transformer
+	ev := &si.EventRecord{Type: si.EventRecord_QUEUE, ObjectID: "root.a", Message: "alice@example.com"}
+	assert.False(t, tr.DropEvent(ev))
+	tr.Event(ev)
+	assert.Equal(t, "alice@example.com", ev.Message)
+	app := &dao.ApplicationDAOInfo{User: "alice", QueueName: "root.a"}
+	assert.Same(t, app, tr.Application(app))
+	assert.Equal(t, "root.a", tr.QueueName("root.a"))
+}
+
+func TestTransformer_DropEvent(t *testing.T) {
+	tr, err := New(&config.TransformConfig{Drop: []config.EventSelector{
+		{Type: "NODE", ChangeType: "SET"},
+		{ObjectID: "^spark-", Message: "heartbeat"},
+	}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		event *si.EventRecord
+		want  bool
+	}{
+		{
+			name:  "matches type and change type",
+			event: &si.EventRecord{Type: si.EventRecord_NODE, EventChangeType: si.EventRecord_SET},
+			want:  true,
+		},
+		{
+			name:  "matches type only",
+			event: &si.EventRecord{Type: si.EventRecord_NODE, EventChangeType: si.EventRecord_ADD},
+			want:  false,
+		},
+		{
+			name:  "matches object ID and message",
+			event: &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "spark-1", Message: "heartbeat received"},
+			want:  true,
+		},
+		{
+			name:  "matches object ID only",
+			event: &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "spark-1", Message: "running"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tr.DropEvent(tt.event))
+		})
+	}
+}
+
+func TestTransformer_Redact(t *testing.T) {
+	tr, err := New(&config.TransformConfig{Redact: []config.Redaction{
+		{
+			Fields:      []string{config.RedactFieldUser, config.RedactFieldMessage},
+			Pattern:     `[^@\s]+@[^@\s]+`,
+			Replacement: config.DefaultRedactReplacement,
+		},
+		{Fields: []string{config.RedactFieldGroups}, Replacement: ""},
+	}})
+	require.NoError(t, err)
+
+	ev := &si.EventRecord{
+		Type:     si.EventRecord_APP,
+		ObjectID: "app-1",
+		Message:  "application submitted by alice@example.com",
+	}
+	tr.Event(ev)
+	assert.Equal(t, "application submitted by [REDACTED]", ev.Message)
+	assert.Equal(t, "app-1", ev.ObjectID)
+
+	app := &dao.ApplicationDAOInfo{ApplicationID: "app-1", User: "alice@example.com", Groups: []string{"dev", "ops"}}
+	transformed := tr.Application(app)
+	assert.Equal(t, "[REDACTED]", transformed.User)
+	assert.Equal(t, []string{"", ""}, transformed.Groups)
+	// the original application is unchanged
+	assert.Equal(t, "alice@example.com", app.User)
+	assert.Equal(t, []string{"dev", "ops"}, app.Groups)
+}
+
+func TestTransformer_QueueMappings(t *testing.T) {
+	tr, err := New(&config.TransformConfig{QueueMappings: []config.QueueMapping{
+		{From: `root\.legacy\.(.*)`, To: "root.$1"},
+		{From: `root\.legacy`, To: "root"},
+		{From: `root\.a`, To: "root.b"},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "root.batch", tr.QueueName("root.legacy.batch"))
+	assert.Equal(t, "root", tr.QueueName("root.legacy"))
+	// mappings match the full name
+	assert.Equal(t, "root.ab", tr.QueueName("root.ab"))
+
+	queue := &dao.PartitionQueueDAOInfo{
+		QueueName: "root.legacy",
+		Parent:    "root",
+		Children:  []dao.PartitionQueueDAOInfo{{QueueName: "root.legacy.batch", Parent: "root.legacy"}},
+	}
+	transformed := tr.Queue(queue)
+	assert.Equal(t, "root", transformed.QueueName)
+	assert.Equal(t, "root", transformed.Parent)
+	assert.Equal(t, "root.batch", transformed.Children[0].QueueName)
+	assert.Equal(t, "root", transformed.Children[0].Parent)
+	assert.Equal(t, "root.legacy.batch", queue.Children[0].QueueName)
+
+	app := tr.Application(&dao.ApplicationDAOInfo{QueueName: "root.a"})
+	assert.Equal(t, "root.b", app.QueueName)
+	acl := tr.QueueACL(&model.QueueACL{QueueName: "root.legacy.batch"})
+	assert.Equal(t, "root.batch", acl.QueueName)
+
+	ev := &si.EventRecord{Type: si.EventRecord_QUEUE, ObjectID: "root.a"}
+	tr.Event(ev)
+	assert.Equal(t, "root.b", ev.ObjectID)
+	ev = &si.EventRecord{Type: si.EventRecord_APP, ObjectID: "root.a"}
+	tr.Event(ev)
+	assert.Equal(t, "root.a", ev.ObjectID)
+}
@@ -0,0 +1,80 @@
+package webservice
+
+import (
+	"errors"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+)
+
+const (
+	// adminRoutePrefix prefixes the paths of the admin routes.
+	adminRoutePrefix = "/ws/v1/admin/"
+	// debugRoutePrefix prefixes the paths of the debug endpoints, which are only served by the admin listener.
+	debugRoutePrefix = "/debug/pprof/"
+)
+
+var (
+	errAdminListener    = errors.New("the route is served by the admin listener")
+	errNotAdminListener = errors.New("the admin listener only serves the admin routes, the metrics and the debug " +
+		"endpoints")
+)
+
+// WithAdminListener serves the admin surface, i.e. the admin routes, the metrics and the debug endpoints, on the
+// addresses instead of the listen addresses of the API, so that network policies can restrict it. The debug
+// endpoints are only served if the admin listener is set.
+func WithAdminListener(addresses []string) Option {
+	return func(ws *WebService) {
+		if len(addresses) == 0 {
+			return
+		}
+		ws.adminAddresses = addresses
+		ws.adminServer = &http.Server{ReadTimeout: 30 * time.Second, MaxHeaderBytes: ws.server.MaxHeaderBytes}
+	}
+}
+
+// WithAdminAPIKeys sets the API keys of the admin routes, which replace those of WithAPIKeys for them.
+// The keys map client names to their API keys.
+func WithAdminAPIKeys(apiKeys map[string]string) Option {
+	return func(ws *WebService) {
+		ws.adminAPIKeys = apiKeys
+	}
+}
+
+// isAdminRoute returns whether the path belongs to the admin surface.
+func isAdminRoute(path string) bool {
+	return strings.HasPrefix(path, adminRoutePrefix) || path == routeMetrics
+}
+
+// splitAdminSurface serves the admin surface of the handler on the admin listener and the rest on the listeners of
+// the API, if the admin listener is set. The health probes are served by both.
+func (ws *WebService) splitAdminSurface(handler http.Handler) {
+	if ws.adminServer == nil {
+		ws.server.Handler = handler
+		return
+	}
+	ws.server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdminRoute(r.URL.Path) {
+			notFoundResponse(w, r, errAdminListener)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	// the debug endpoints are neither authenticated nor documented, like the metrics
+	mux := http.NewServeMux()
+	mux.HandleFunc(debugRoutePrefix, pprof.Index)
+	mux.HandleFunc(debugRoutePrefix+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(debugRoutePrefix+"profile", pprof.Profile)
+	mux.HandleFunc(debugRoutePrefix+"symbol", pprof.Symbol)
+	mux.HandleFunc(debugRoutePrefix+"trace", pprof.Trace)
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAdminRoute(r.URL.Path) && !unauthenticatedRoutes[r.URL.Path] {
+			notFoundResponse(w, r, errNotAdminListener)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	ws.adminServer.Handler = mux
+}
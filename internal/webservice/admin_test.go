@@ -0,0 +1,155 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestWebServiceAdminListener(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, nil)
+	ws := NewWebService(
+		&config.YHSConfig{Port: 8080},
+		repo,
+		nil,
+		nil,
+		WithAPIKeys(map[string]string{"ci": "ci-secret"}),
+		WithAdminListener([]string{":9090"}),
+		WithAdminAPIKeys(map[string]string{"ops": "ops-secret"}),
+	)
+	ws.init(context.Background())
+
+	tests := map[string]struct {
+		server     *http.Server
+		path       string
+		apiKey     string
+		wantStatus int
+	}{
+		"api route": {
+			server:     ws.server,
+			path:       routePartitions,
+			apiKey:     "ci-secret",
+			wantStatus: http.StatusOK,
+		},
+		"admin route on the listener of the api": {
+			server:     ws.server,
+			path:       routeFeatureFlags,
+			apiKey:     "ops-secret",
+			wantStatus: http.StatusNotFound,
+		},
+		"metrics on the listener of the api": {
+			server:     ws.server,
+			path:       routeMetrics,
+			wantStatus: http.StatusNotFound,
+		},
+		"admin route": {
+			server:     ws.adminServer,
+			path:       routeFeatureFlags,
+			apiKey:     "ops-secret",
+			wantStatus: http.StatusOK,
+		},
+		"admin route with api key of the api": {
+			server:     ws.adminServer,
+			path:       routeFeatureFlags,
+			apiKey:     "ci-secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"metrics": {
+			server:     ws.adminServer,
+			path:       routeMetrics,
+			wantStatus: http.StatusOK,
+		},
+		"debug endpoint": {
+			server:     ws.adminServer,
+			path:       debugRoutePrefix,
+			wantStatus: http.StatusOK,
+		},
+		"api route on the admin listener": {
+			server:     ws.adminServer,
+			path:       routePartitions,
+			apiKey:     "ci-secret",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.apiKey != "" {
+				req.Header.Set(headerAuthorization, bearerPrefix+tc.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			tc.server.Handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestWebServiceAdminAPIKeys(t *testing.T) {
+	ws := &WebService{
+		apiKeys:      map[string]string{"ci": "ci-secret"},
+		adminAPIKeys: map[string]string{"ops": "ops-secret"},
+	}
+	handler := ws.authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		path       string
+		apiKey     string
+		wantStatus int
+	}{
+		{path: routePartitions, apiKey: "ci-secret", wantStatus: http.StatusOK},
+		{path: routePartitions, apiKey: "ops-secret", wantStatus: http.StatusUnauthorized},
+		{path: routeFeatureFlags, apiKey: "ops-secret", wantStatus: http.StatusOK},
+		{path: routeFeatureFlags, apiKey: "ci-secret", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set(headerAuthorization, bearerPrefix+tc.apiKey)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, tc.wantStatus, rec.Code, tc.path+" with "+tc.apiKey)
+	}
+
+	// without API keys of the API, only the admin routes are authenticated
+	ws = &WebService{adminAPIKeys: map[string]string{"ops": "ops-secret"}}
+	handler = ws.authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, routePartitions, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, routeFeatureFlags, nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestWebServiceHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, nil)
+	ws := NewWebService(
+		&config.YHSConfig{Port: 8080},
+		repo,
+		nil,
+		nil,
+		WithAdminListener([]string{":9090"}),
+	)
+	handler := ws.Handler(context.Background())
+
+	// the handler serves the admin surface, since the admin listener is not served
+	for _, path := range []string{routePartitions, routeFeatureFlags, routeMetrics} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+}
@@ -0,0 +1,327 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+const (
+	// slowAnalyticsScannedRows is the number of rows read from the tables above which analytics queries are
+	// estimated to be slow.
+	slowAnalyticsScannedRows = 1000000
+	// maxAnalyticsRows is the maximum and default number of rows of an analytics query.
+	maxAnalyticsRows = 10000
+	// maxAnalyticsTerms is the maximum number of filters, group-by fields and aggregates each of an analytics query.
+	maxAnalyticsTerms = 20
+)
+
+// analyticsOperators maps the operators of analytics filters to SQL operators. The in operator matches any of
+// the values.
+var analyticsOperators = map[string]sql.Operator{
+	"=":  sql.Equal,
+	"!=": sql.NotEqual,
+	"<":  sql.LessThan,
+	"<=": sql.LessThanOrEqual,
+	">":  sql.GreaterThan,
+	">=": sql.GreaterThanOrEqual,
+	"in": sql.Equal,
+}
+
+// analyticsRequest is the request body of an analytics query.
+type analyticsRequest struct {
+	Entity     string               `json:"entity"`
+	Filters    []analyticsFilter    `json:"filters"`
+	GroupBy    []string             `json:"groupBy"`
+	TimeBucket *analyticsTimeBucket `json:"timeBucket"`
+	Aggregates []analyticsAggregate `json:"aggregates"`
+	Limit      *int                 `json:"limit"`
+	Duplicates string               `json:"duplicates"`
+}
+
+type analyticsFilter struct {
+	Field    string            `json:"field"`
+	Operator string            `json:"op"`
+	Value    json.RawMessage   `json:"value"`
+	Values   []json.RawMessage `json:"values"`
+}
+
+type analyticsTimeBucket struct {
+	Field    string `json:"field"`
+	Interval string `json:"interval"`
+}
+
+type analyticsAggregate struct {
+	Function string `json:"function"`
+	Field    string `json:"field"`
+}
+
+// analyticsResponse holds the rows of an analytics query. Truncated is set if there were more rows than the limit.
+type analyticsResponse struct {
+	Rows      []*model.AnalyticsRow `json:"rows"`
+	Truncated bool                  `json:"truncated"`
+}
+
+// analyticsEstimateResponse is the estimate of an analytics query. Slow is set if the query reads so many rows that
+// it is likely slow, in which case the suggestions tell how to narrow it.
+type analyticsEstimateResponse struct {
+	*model.AnalyticsEstimate
+	Slow        bool     `json:"slow"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// query converts the request to an analytics query. Timestamps without offset are interpreted in the location,
+// in which the time buckets start too.
+func (req *analyticsRequest) query(loc *time.Location, now time.Time) (repository.AnalyticsQuery, error) {
+	query := repository.AnalyticsQuery{
+		Entity:     req.Entity,
+		GroupBy:    req.GroupBy,
+		Limit:      maxAnalyticsRows,
+		Duplicates: repository.DuplicateStrategy(req.Duplicates),
+	}
+	if req.Entity == "" {
+		return query, errors.New("entity is required")
+	}
+	if len(req.Aggregates) == 0 {
+		return query, errors.New("aggregates are required")
+	}
+	if len(req.Filters) > maxAnalyticsTerms || len(req.GroupBy) > maxAnalyticsTerms || len(req.Aggregates) > maxAnalyticsTerms {
+		return query, fmt.Errorf("filters, groupBy and aggregates must not have more than %d items each", maxAnalyticsTerms)
+	}
+	if req.Limit != nil {
+		if *req.Limit < 1 || *req.Limit > maxAnalyticsRows {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxAnalyticsRows)
+		}
+		query.Limit = *req.Limit
+	}
+	for _, f := range req.Filters {
+		filter, err := f.filter(req.Entity, loc, now)
+		if err != nil {
+			return query, err
+		}
+		query.Filters = append(query.Filters, filter)
+	}
+	if req.TimeBucket != nil {
+		query.TimeBucket = &repository.AnalyticsTimeBucket{
+			Field:    req.TimeBucket.Field,
+			Bucket:   sql.TimeBucket(req.TimeBucket.Interval),
+			Location: loc,
+		}
+	}
+	for _, a := range req.Aggregates {
+		query.Aggregates = append(query.Aggregates, repository.AnalyticsAggregate{
+			Function: sql.AggregateFunction(a.Function),
+			Field:    a.Field,
+		})
+	}
+	return query, nil
+}
+
+// filter converts the filter, decoding its values according to the type of its field.
+func (f *analyticsFilter) filter(entity string, loc *time.Location, now time.Time) (repository.AnalyticsFilter, error) {
+	filter := repository.AnalyticsFilter{Field: f.Field}
+	op, ok := analyticsOperators[f.Operator]
+	if !ok {
+		return filter, fmt.Errorf("unsupported operator %q of filter on %s", f.Operator, f.Field)
+	}
+	filter.Operator = op
+	values := []json.RawMessage{f.Value}
+	if f.Operator == "in" {
+		values = f.Values
+		if len(values) == 0 || len(values) > maxQueryParamItems {
+			return filter, fmt.Errorf("filter on %s must have between 1 and %d values", f.Field, maxQueryParamItems)
+		}
+	} else if f.Value == nil {
+		return filter, fmt.Errorf("filter on %s must have a value", f.Field)
+	}
+
+	fieldType, err := repository.AnalyticsFieldTypeOf(entity, f.Field)
+	if err != nil {
+		return filter, err
+	}
+	for _, raw := range values {
+		value, err := analyticsValue(fieldType, raw, loc, now)
+		if err != nil {
+			return filter, fmt.Errorf("invalid value %s of filter on %s: %v", raw, f.Field, err)
+		}
+		filter.Values = append(filter.Values, value)
+	}
+	return filter, nil
+}
+
+// analyticsValue decodes a JSON value of a field of the type. Times are in one of the formats of time filters.
+func analyticsValue(fieldType repository.AnalyticsFieldType, raw json.RawMessage, loc *time.Location, now time.Time) (any, error) {
+	switch fieldType {
+	case repository.AnalyticsString:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, errors.New("must be a string")
+		}
+		if reason := validateString(s); reason != "" {
+			return nil, errors.New(reason)
+		}
+		return s, nil
+	case repository.AnalyticsInteger:
+		var i int64
+		if err := json.Unmarshal(raw, &i); err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return i, nil
+	case repository.AnalyticsBoolean:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, errors.New("must be a boolean")
+		}
+		return b, nil
+	default:
+		// Unix timestamps may be numbers or strings
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			var ts json.Number
+			if err := json.Unmarshal(raw, &ts); err != nil {
+				return nil, errors.New("must be a string or a Unix timestamp")
+			}
+			value = ts.String()
+		}
+		t, err := parseTime(value, loc, now)
+		if err != nil {
+			return nil, err
+		}
+		if t.Before(minTime) || t.After(maxTime) {
+			return nil, fmt.Errorf("must be between %s and %s", minTime.UTC().Format(time.RFC3339), maxTime.UTC().Format(time.RFC3339))
+		}
+		return t, nil
+	}
+}
+
+// queryAnalytics runs the analytics query of the request body, e.g. the number of applications per queue and day.
+// The tz query parameter selects the timezone of timestamps without offset and of the time buckets.
+func (ws *WebService) queryAnalytics(w http.ResponseWriter, r *http.Request) {
+	query, loc, ok := decodeAnalyticsQuery(w, r)
+	if !ok {
+		return
+	}
+
+	// one more row than the limit tells whether the results are truncated
+	limit := query.Limit
+	query.Limit++
+	rows, err := ws.repository.QueryAnalytics(r.Context(), query)
+	if err != nil {
+		analyticsErrorResponse(w, r, err)
+		return
+	}
+	response := analyticsResponse{Rows: rows, Truncated: len(rows) > limit}
+	if response.Truncated {
+		response.Rows = rows[:limit]
+	}
+	if response.Rows == nil {
+		response.Rows = []*model.AnalyticsRow{}
+	}
+	for _, row := range response.Rows {
+		if row.Bucket != nil {
+			row.Bucket = util.ToPtr(row.Bucket.In(loc))
+		}
+	}
+	jsonResponse(w, r, response)
+}
+
+// estimateAnalytics estimates the rows and the cost of the analytics query of the request body without running it,
+// so that clients can warn about slow queries and suggest narrowing them before running them.
+func (ws *WebService) estimateAnalytics(w http.ResponseWriter, r *http.Request) {
+	query, _, ok := decodeAnalyticsQuery(w, r)
+	if !ok {
+		return
+	}
+	estimate, err := ws.repository.EstimateAnalytics(r.Context(), query)
+	if err != nil {
+		analyticsErrorResponse(w, r, err)
+		return
+	}
+	response := analyticsEstimateResponse{
+		AnalyticsEstimate: estimate,
+		Slow:              estimate.ScannedRows > slowAnalyticsScannedRows,
+		Suggestions:       []string{},
+	}
+	if response.Slow {
+		response.Suggestions = analyticsSuggestions(query)
+	}
+	jsonResponse(w, r, response)
+}
+
+// analyticsSuggestions returns how to narrow the slow analytics query: bounding or shortening its time range, and
+// filtering on other fields.
+func analyticsSuggestions(query repository.AnalyticsQuery) []string {
+	var bounded []string
+	filtered := false
+	for _, filter := range query.Filters {
+		fieldType, err := repository.AnalyticsFieldTypeOf(query.Entity, filter.Field)
+		if err != nil {
+			continue
+		}
+		if fieldType != repository.AnalyticsTime {
+			filtered = true
+			continue
+		}
+		switch filter.Operator {
+		case sql.Equal, sql.GreaterThan, sql.GreaterThanOrEqual:
+			bounded = append(bounded, filter.Field)
+		}
+	}
+
+	var suggestions []string
+	if len(bounded) == 0 {
+		field := repository.AnalyticsTimeField(query.Entity)
+		if query.TimeBucket != nil {
+			field = query.TimeBucket.Field
+		}
+		suggestions = append(suggestions, fmt.Sprintf(
+			`add a lower bound on %s to narrow the time range, e.g. {"field": "%s", "op": ">=", "value": "7d"}`, field, field))
+	} else {
+		suggestions = append(suggestions, fmt.Sprintf("shorten the time range of the filters on %s", strings.Join(bounded, ", ")))
+	}
+	if !filtered {
+		suggestions = append(suggestions, "add filters on other fields, e.g. on the queue, to aggregate fewer rows")
+	}
+	return suggestions
+}
+
+// decodeAnalyticsQuery decodes the analytics query of the request body, with the location of the tz query parameter.
+// It responds with 400 Bad Request and returns false if the request is invalid.
+func decodeAnalyticsQuery(w http.ResponseWriter, r *http.Request) (repository.AnalyticsQuery, *time.Location, bool) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return repository.AnalyticsQuery{}, nil, false
+	}
+	var req analyticsRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return repository.AnalyticsQuery{}, nil, false
+	}
+	query, err := req.query(loc, time.Now())
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return repository.AnalyticsQuery{}, nil, false
+	}
+	return query, loc, true
+}
+
+// analyticsErrorResponse responds with 400 Bad Request to invalid analytics queries, and with the error otherwise.
+func analyticsErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, repository.ErrInvalidAnalyticsQuery) || errors.Is(err, sql.ErrInvalidQuery) {
+		badRequestResponse(w, r, err)
+		return
+	}
+	errorResponse(w, r, err)
+}
@@ -0,0 +1,205 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceQueryAnalytics(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, london)
+	bucket := time.Date(2024, 7, 1, 0, 0, 0, 0, london).UTC()
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().QueryAnalytics(gomock.Any(), repository.AnalyticsQuery{
+		Entity: "applications",
+		Filters: []repository.AnalyticsFilter{
+			{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{start}},
+			{Field: "applicationState", Operator: sql.Equal, Values: []any{"Completed", "Failed"}},
+		},
+		GroupBy:    []string{"queueName"},
+		TimeBucket: &repository.AnalyticsTimeBucket{Field: "submissionTime", Bucket: sql.Day, Location: london},
+		Aggregates: []repository.AnalyticsAggregate{{Function: sql.Count}},
+		Limit:      3,
+	}).Return([]*model.AnalyticsRow{
+		{Bucket: &bucket, Groups: map[string]any{"queueName": "root.a"}, Aggregates: map[string]*float64{"count": util.ToPtr(2.0)}},
+		{Bucket: &bucket, Groups: map[string]any{"queueName": "root.b"}, Aggregates: map[string]*float64{"count": util.ToPtr(1.0)}},
+		{Bucket: &bucket, Groups: map[string]any{"queueName": "root.c"}, Aggregates: map[string]*float64{"count": util.ToPtr(1.0)}},
+	}, nil)
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{"analytics": true}})
+	require.NoError(t, err)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithFeatureFlags(featureFlags))
+	ws.init(context.Background())
+
+	body := `{
+		"entity": "applications",
+		"filters": [
+			{"field": "submissionTime", "op": ">=", "value": "2024-07-01"},
+			{"field": "applicationState", "op": "in", "values": ["Completed", "Failed"]}
+		],
+		"groupBy": ["queueName"],
+		"timeBucket": {"field": "submissionTime", "interval": "day"},
+		"aggregates": [{"function": "count"}],
+		"limit": 2
+	}`
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/query?tz=Europe/London", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"rows": [
+			{"bucket": "2024-07-01T00:00:00+01:00", "groups": {"queueName": "root.a"}, "aggregates": {"count": 2}},
+			{"bucket": "2024-07-01T00:00:00+01:00", "groups": {"queueName": "root.b"}, "aggregates": {"count": 1}}
+		],
+		"truncated": true
+	}`, rec.Body.String())
+}
+
+func TestWebServiceQueryAnalyticsInvalid(t *testing.T) {
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{"analytics": true}})
+	require.NoError(t, err)
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().QueryAnalytics(gomock.Any(), gomock.Any()).
+		Return(nil, repository.ErrInvalidAnalyticsQuery).AnyTimes()
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithFeatureFlags(featureFlags))
+	ws.init(context.Background())
+
+	tests := map[string]string{
+		"invalid body":     `{"entity": `,
+		"unknown property": `{"entity": "applications", "aggregates": [{"function": "count"}], "sql": "DROP TABLE"}`,
+		"no entity":        `{"aggregates": [{"function": "count"}]}`,
+		"no aggregates":    `{"entity": "applications"}`,
+		"unknown entity":   `{"entity": "secrets", "aggregates": [{"function": "count"}], "filters": [{"field": "a", "op": "=", "value": 1}]}`,
+		"unknown operator": `{"entity": "applications", "aggregates": [{"function": "count"}], "filters": [{"field": "user", "op": "~", "value": "a"}]}`,
+		"missing value":    `{"entity": "applications", "aggregates": [{"function": "count"}], "filters": [{"field": "user", "op": "="}]}`,
+		"wrong type":       `{"entity": "applications", "aggregates": [{"function": "count"}], "filters": [{"field": "hasReserved", "op": "=", "value": "yes"}]}`,
+		"invalid time":     `{"entity": "applications", "aggregates": [{"function": "count"}], "filters": [{"field": "finishedTime", "op": "<", "value": "soon"}]}`,
+		"limit too large":  `{"entity": "applications", "aggregates": [{"function": "count"}], "limit": 100000}`,
+		"rejected by repo": `{"entity": "applications", "aggregates": [{"function": "median"}]}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/query", strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestWebServiceQueryAnalyticsDisabled(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/query",
+		strings.NewReader(`{"entity": "applications", "aggregates": [{"function": "count"}]}`)))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestWebServiceAnalyticsRoutesDisabled(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	for _, path := range []string{
+		"/ws/v1/analytics/throughput",
+		"/ws/v1/analytics/priorities",
+		"/ws/v1/analytics/wait-phases?partition=default",
+		"/ws/v1/analytics/partitions/compare",
+	} {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+		})
+	}
+}
+
+func TestWebServiceEstimateAnalytics(t *testing.T) {
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{"analytics": true}})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		body     string
+		estimate *model.AnalyticsEstimate
+		expected string
+	}{
+		"fast": {
+			body: `{"entity": "applications", "aggregates": [{"function": "count"}],
+				"filters": [{"field": "queueName", "op": "=", "value": "root.a"}]}`,
+			estimate: &model.AnalyticsEstimate{Rows: 1, ScannedRows: 2000, Cost: 120.5},
+			expected: `{"rows": 1, "scannedRows": 2000, "cost": 120.5, "slow": false, "suggestions": []}`,
+		},
+		"unbounded": {
+			body: `{"entity": "applications", "aggregates": [{"function": "count"}],
+				"filters": [{"field": "finishedTime", "op": "<", "value": "1d"}], "timeBucket": {"field": "finishedTime", "interval": "month"}}`,
+			estimate: &model.AnalyticsEstimate{Rows: 12, ScannedRows: 40000000, Cost: 950000},
+			expected: `{"rows": 12, "scannedRows": 40000000, "cost": 950000, "slow": true, "suggestions": [
+				"add a lower bound on finishedTime to narrow the time range, e.g. {\"field\": \"finishedTime\", \"op\": \">=\", \"value\": \"7d\"}",
+				"add filters on other fields, e.g. on the queue, to aggregate fewer rows"
+			]}`,
+		},
+		"bounded": {
+			body: `{"entity": "applications", "aggregates": [{"function": "count"}], "filters": [
+				{"field": "submissionTime", "op": ">=", "value": "365d"},
+				{"field": "user", "op": "=", "value": "alice"}
+			]}`,
+			estimate: &model.AnalyticsEstimate{Rows: 1, ScannedRows: 3000000, Cost: 80000},
+			expected: `{"rows": 1, "scannedRows": 3000000, "cost": 80000, "slow": true, "suggestions": [
+				"shorten the time range of the filters on submissionTime"
+			]}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := repository.NewMockRepository(gomock.NewController(t))
+			repo.EXPECT().EstimateAnalytics(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, query repository.AnalyticsQuery) (*model.AnalyticsEstimate, error) {
+					assert.Equal(t, "applications", query.Entity)
+					assert.Equal(t, maxAnalyticsRows, query.Limit)
+					return tt.estimate, nil
+				})
+			ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithFeatureFlags(featureFlags))
+			ws.init(context.Background())
+
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/query/estimate", strings.NewReader(tt.body)))
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.JSONEq(t, tt.expected, rec.Body.String())
+		})
+	}
+
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().EstimateAnalytics(gomock.Any(), gomock.Any()).Return(nil, repository.ErrInvalidAnalyticsQuery)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithFeatureFlags(featureFlags))
+	ws.init(context.Background())
+	for _, body := range []string{`{"entity": "applications"}`, `{"entity": "applications", "aggregates": [{"function": "median"}]}`} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/query/estimate", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
+
+// withAnalytics enables the analytics feature flag gating the analytics routes.
+func withAnalytics(t *testing.T) Option {
+	t.Helper()
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{string(featureflag.Analytics): true}})
+	require.NoError(t, err)
+	return WithFeatureFlags(featureFlags)
+}
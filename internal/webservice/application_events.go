@@ -0,0 +1,165 @@
+package webservice
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// getApplicationEvents returns the events of an application in the order they happened: its asks, allocations and
+// state changes normalized from the stored application in all partitions and queues, together with the raw events
+// of its decision trace if traces are enabled and the application was sampled, which include its preemptions.
+// It responds with 404 Not Found if there are neither.
+// Following query params are supported:
+// - startTime: only include the events at or after this time
+// - endTime: only include the events at or before this time
+// - tz: timezone of the time filters without offset, UTC by default
+// - limit: limit the number of returned events
+// - offset: offset the returned events
+func (ws *WebService) getApplicationEvents(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	appID := params.ByName(paramsApplicationID)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	limit := q.Limit(ws.pageSizes.Other)
+	offset := q.Offset()
+	start, end := q.TimeRange(queryParamStartTime, queryParamEndTime, loc, time.Now())
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	apps, err := ws.repository.GetAllApplications(r.Context(), repository.ApplicationFilters{ApplicationID: &appID})
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	var traceEvents []*model.TraceEvent
+	if ws.traces {
+		traceEvents, err = ws.repository.GetTraceEvents(r.Context(), appID, repository.TraceEventFilters{Start: start, End: end})
+		if err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+	}
+	if len(apps) == 0 && len(traceEvents) == 0 {
+		notFoundResponse(w, r, fmt.Errorf("application %s not found", appID))
+		return
+	}
+
+	events := make([]*model.ApplicationEvent, 0)
+	for _, app := range apps {
+		for _, event := range normalizedApplicationEvents(app) {
+			if (start == nil || event.Timestamp >= start.UnixNano()) && (end == nil || event.Timestamp <= end.UnixNano()) {
+				events = append(events, event)
+			}
+		}
+	}
+	for _, event := range traceEvents {
+		events = append(events, rawApplicationEvent(event))
+	}
+	slices.SortStableFunc(events, func(a, b *model.ApplicationEvent) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+	if offset != nil {
+		events = events[min(*offset, len(events)):]
+	}
+	if limit != nil {
+		events = events[:min(*limit, len(events))]
+	}
+	jsonResponse(w, r, events)
+}
+
+// normalizedApplicationEvents returns the state changes of the state log of the stored application, and the asks and
+// allocations of its requests and allocations at the times they were requested and allocated. Events without time
+// are skipped.
+func normalizedApplicationEvents(app *model.ApplicationDAOInfo) []*model.ApplicationEvent {
+	var events []*model.ApplicationEvent
+	newEvent := func(timestamp int64, kind string) *model.ApplicationEvent {
+		event := &model.ApplicationEvent{
+			Timestamp: timestamp,
+			Kind:      kind,
+			Source:    model.ApplicationEventSourceApplication,
+			Partition: app.Partition,
+			QueueName: app.QueueName,
+		}
+		events = append(events, event)
+		return event
+	}
+	for _, state := range app.StateLog {
+		if state == nil || state.Time == 0 {
+			continue
+		}
+		event := newEvent(state.Time, model.ApplicationEventState)
+		event.State = state.ApplicationState
+		if strings.EqualFold(state.ApplicationState, "Rejected") && app.RejectedMessage != "" {
+			event.Message = &app.RejectedMessage
+		}
+	}
+	for _, ask := range app.Requests {
+		if ask == nil || ask.RequestTime == 0 {
+			continue
+		}
+		event := newEvent(ask.RequestTime, model.ApplicationEventAsk)
+		event.ObjectID = ask.AllocationKey
+		event.NodeID = ask.RequiredNodeID
+		event.Resource = ask.ResourcePerAlloc
+	}
+	for _, alloc := range app.Allocations {
+		if alloc == nil || alloc.AllocationTime == 0 {
+			continue
+		}
+		event := newEvent(alloc.AllocationTime, model.ApplicationEventAllocation)
+		event.ObjectID = alloc.AllocationKey
+		event.NodeID = alloc.NodeID
+		event.Resource = alloc.ResourcePerAlloc
+		event.Preempted = alloc.Preempted
+	}
+	return events
+}
+
+// rawApplicationEvent converts an event of the decision trace to an event of the application, of the kind of its
+// change detail.
+func rawApplicationEvent(event *model.TraceEvent) *model.ApplicationEvent {
+	return &model.ApplicationEvent{
+		Timestamp:    event.Timestamp,
+		Kind:         traceEventKind(event.ChangeDetail),
+		Source:       model.ApplicationEventSourceTrace,
+		ObjectID:     event.ObjectID,
+		Resource:     event.Resource,
+		Message:      event.Message,
+		Type:         event.Type,
+		ChangeType:   event.ChangeType,
+		ChangeDetail: event.ChangeDetail,
+	}
+}
+
+// traceEventKind returns the kind of the events of the change detail: the changes of requests are asks, those of
+// allocations are allocations except for preemptions, and the other changes of applications are state changes.
+func traceEventKind(changeDetail string) string {
+	switch changeDetail {
+	case si.EventRecord_ALLOC_PREEMPT.String():
+		return model.ApplicationEventPreemption
+	case si.EventRecord_APP_REQUEST.String(), si.EventRecord_REQUEST_CANCEL.String(), si.EventRecord_REQUEST_TIMEOUT.String():
+		return model.ApplicationEventAsk
+	case si.EventRecord_APP_ALLOC.String(), si.EventRecord_REQUEST_ALLOC.String():
+		return model.ApplicationEventAllocation
+	}
+	switch {
+	case strings.HasPrefix(changeDetail, "ALLOC_"):
+		return model.ApplicationEventAllocation
+	case strings.HasPrefix(changeDetail, "APP_"):
+		return model.ApplicationEventState
+	default:
+		return model.ApplicationEventOther
+	}
+}
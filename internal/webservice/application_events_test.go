@@ -0,0 +1,122 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetApplicationEvents(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return start.Add(d).UnixNano() }
+	app := &model.ApplicationDAOInfo{ApplicationDAOInfo: dao.ApplicationDAOInfo{
+		ApplicationID: "app-1",
+		Partition:     "default",
+		QueueName:     "root.batch",
+		StateLog: []*dao.StateDAOInfo{
+			{Time: at(0), ApplicationState: "New"},
+			{Time: at(3 * time.Second), ApplicationState: "Running"},
+		},
+		Requests: []*dao.AllocationAskDAOInfo{
+			{AllocationKey: "pod-2", RequestTime: at(2 * time.Second), ResourcePerAlloc: map[string]int64{"vcore": 1000}},
+		},
+		Allocations: []*dao.AllocationDAOInfo{
+			{AllocationKey: "pod-1", AllocationTime: at(4 * time.Second), NodeID: "node-1", Preempted: true},
+		},
+	}}
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().
+		GetAllApplications(gomock.Any(), repository.ApplicationFilters{ApplicationID: util.ToPtr("app-1")}).
+		Return([]*model.ApplicationDAOInfo{app}, nil).AnyTimes()
+	repo.EXPECT().
+		GetAllApplications(gomock.Any(), repository.ApplicationFilters{ApplicationID: util.ToPtr("unknown")}).
+		Return(nil, nil).AnyTimes()
+	repo.EXPECT().
+		GetTraceEvents(gomock.Any(), "app-1", gomock.Any()).
+		Return([]*model.TraceEvent{{
+			ApplicationID: "app-1",
+			Type:          "REQUEST",
+			ObjectID:      "pod-1",
+			ChangeType:    "REMOVE",
+			ChangeDetail:  "ALLOC_PREEMPT",
+			Timestamp:     at(5 * time.Second),
+		}}, nil).AnyTimes()
+	repo.EXPECT().GetTraceEvents(gomock.Any(), "unknown", gomock.Any()).Return(nil, nil).AnyTimes()
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithTraces())
+	ws.init(context.Background())
+	get := func(path string) ([]*model.ApplicationEvent, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var events []*model.ApplicationEvent
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+		}
+		return events, rec
+	}
+
+	events, rec := get("/ws/v1/application/app-1/events")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	kinds := make([]string, len(events))
+	for i, event := range events {
+		kinds[i] = event.Kind
+	}
+	assert.Equal(t, []string{
+		model.ApplicationEventState,
+		model.ApplicationEventAsk,
+		model.ApplicationEventState,
+		model.ApplicationEventAllocation,
+		model.ApplicationEventPreemption,
+	}, kinds)
+	assert.Equal(t, &model.ApplicationEvent{
+		Timestamp: at(4 * time.Second),
+		Kind:      model.ApplicationEventAllocation,
+		Source:    model.ApplicationEventSourceApplication,
+		Partition: "default",
+		QueueName: "root.batch",
+		ObjectID:  "pod-1",
+		NodeID:    "node-1",
+		Preempted: true,
+	}, events[3])
+	assert.Equal(t, model.ApplicationEventSourceTrace, events[4].Source)
+	assert.Equal(t, "ALLOC_PREEMPT", events[4].ChangeDetail)
+
+	events, rec = get("/ws/v1/application/app-1/events?startTime=2024-07-01T00:00:02Z&offset=1&limit=2")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, events, 2)
+	assert.Equal(t, "Running", events[0].State)
+	assert.Equal(t, "pod-1", events[1].ObjectID)
+
+	_, rec = get("/ws/v1/application/unknown/events")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	_, rec = get("/ws/v1/application/app-1/events?limit=-1")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTraceEventKind(t *testing.T) {
+	tests := map[string]string{
+		"ALLOC_PREEMPT":   model.ApplicationEventPreemption,
+		"ALLOC_CANCEL":    model.ApplicationEventAllocation,
+		"REQUEST_ALLOC":   model.ApplicationEventAllocation,
+		"REQUEST_TIMEOUT": model.ApplicationEventAsk,
+		"APP_REQUEST":     model.ApplicationEventAsk,
+		"APP_RUNNING":     model.ApplicationEventState,
+		"DETAILS_NONE":    model.ApplicationEventOther,
+	}
+	for changeDetail, want := range tests {
+		assert.Equal(t, want, traceEventKind(changeDetail), changeDetail)
+	}
+}
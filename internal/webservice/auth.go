@@ -0,0 +1,81 @@
+package webservice
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	headerAuthorization = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+type principalKey struct{}
+
+// unauthenticatedRoutes lists the API routes which are accessible without an API key,
+// so that probes keep working when API authentication is enabled.
+var unauthenticatedRoutes = map[string]bool{
+	routeHealthLiveness:  true,
+	routeHealthReadiness: true,
+}
+
+// authenticate wraps the handler so that API requests must carry a valid API key in the
+// "Authorization: Bearer <key>" header. Requests for the static assets of the web UI are not authenticated.
+// The name of the client owning the API key is stored in the request context.
+// The admin routes are authenticated with the admin API keys, if configured.
+// If no API keys are configured for a route, its authentication is disabled.
+func (ws *WebService) authenticate(next http.Handler) http.Handler {
+	if len(ws.apiKeys) == 0 && len(ws.adminAPIKeys) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/ws/") || unauthenticatedRoutes[r.URL.Path] || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+		apiKeys := ws.apiKeys
+		if len(ws.adminAPIKeys) > 0 && isAdminRoute(r.URL.Path) {
+			apiKeys = ws.adminAPIKeys
+		}
+		if len(apiKeys) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get(headerAuthorization)
+		if !strings.HasPrefix(header, bearerPrefix) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			problemResponse(w, r, http.StatusUnauthorized, errors.New("missing API key"))
+			return
+		}
+		principal, ok := lookupAPIKey(apiKeys, strings.TrimPrefix(header, bearerPrefix))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			problemResponse(w, r, http.StatusUnauthorized, errors.New("invalid API key"))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// lookupAPIKey returns the name of the client owning the API key.
+// All configured keys are compared in constant time to avoid leaking key material through timing.
+func lookupAPIKey(apiKeys map[string]string, key string) (string, bool) {
+	var principal string
+	for name, apiKey := range apiKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			principal = name
+		}
+	}
+	return principal, principal != ""
+}
+
+// principalFromContext returns the name of the authenticated client, if any.
+func principalFromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok
+}
@@ -0,0 +1,272 @@
+package webservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/G-Research/yunikorn-history-server/internal/autoscaler"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// maxAutoscalerEventsBodySize is the maximum size of the events posted at once.
+	maxAutoscalerEventsBodySize = 16 << 20
+	// defaultAutoscalerTimelinePeriod is the period before now of which the timeline is returned by default.
+	defaultAutoscalerTimelinePeriod = 24 * time.Hour
+)
+
+// WithAutoscalerEvents enables the route storing the Kubernetes events of the cluster-autoscaler, converted by the
+// converter.
+func WithAutoscalerEvents(converter *autoscaler.Converter) Option {
+	return func(ws *WebService) {
+		ws.autoscaler = converter
+	}
+}
+
+// autoscalerEventsResult is the response of the posted events of the cluster-autoscaler. Received is the number of
+// posted events, Stored the number of new occurrences stored, and Ignored the number of events of other components.
+type autoscalerEventsResult struct {
+	Received int `json:"received"`
+	Stored   int `json:"stored"`
+	Ignored  int `json:"ignored"`
+}
+
+// postAutoscalerEvents stores the Kubernetes events of the cluster-autoscaler posted by an event exporter. The body
+// is an event, an array of events or an EventList. The events of other components are ignored, and the occurrences
+// of the events already stored are skipped, so that events can be posted again.
+func (ws *WebService) postAutoscalerEvents(w http.ResponseWriter, r *http.Request) {
+	events, err := decodeKubernetesEvents(io.LimitReader(r.Body, maxAutoscalerEventsBodySize))
+	if err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	result := autoscalerEventsResult{Received: len(events)}
+	var converted []*model.AutoscalerEvent
+	for i, event := range events {
+		e, err := ws.autoscaler.Convert(event)
+		if err != nil {
+			badRequestResponse(w, r, fmt.Errorf("event %d: %v", i, err))
+			return
+		}
+		if e == nil {
+			result.Ignored++
+			continue
+		}
+		converted = append(converted, e)
+	}
+	if len(converted) > 0 {
+		result.Stored, err = ws.repository.AddAutoscalerEvents(r.Context(), converted)
+		if err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+	}
+	jsonResponse(w, r, result)
+}
+
+// decodeKubernetesEvents decodes an event, an array of events or an EventList.
+func decodeKubernetesEvents(body io.Reader) ([]*corev1.Event, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty body")
+	}
+	var events []*corev1.Event
+	if data[0] == '[' {
+		err := json.Unmarshal(data, &events)
+		return events, err
+	}
+	var object struct {
+		Kind  string          `json:"kind"`
+		Items []*corev1.Event `json:"items"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	if object.Kind == "EventList" || object.Items != nil {
+		return object.Items, nil
+	}
+	var event corev1.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return []*corev1.Event{&event}, nil
+}
+
+// autoscalerTimeline puts the events of the cluster-autoscaler on the timeline of the pending resources of a
+// partition. Summary evaluates the responsiveness of the autoscaler to the backlog episodes of the period.
+type autoscalerTimeline struct {
+	Partition string                        `json:"partition"`
+	From      time.Time                     `json:"from"`
+	To        time.Time                     `json:"to"`
+	Backlog   []*model.PendingBacklogSample `json:"backlog"`
+	Events    []*model.AutoscalerEvent      `json:"events"`
+	Episodes  []*backlogEpisode             `json:"episodes"`
+	Summary   backlogSummary                `json:"summary"`
+}
+
+// backlogEpisode is a period during which the partition had pending resources, from the first sample with pending
+// resources until the first sample without, which is not set if the partition still had pending resources at the end
+// of the timeline. ScaleUpAt is the time of the first scale-up during the episode, and ReactionTime the time from the
+// start of the episode until the scale-up, 0 if the scale-up was seen before the backlog was sampled. Times are in
+// nanoseconds.
+type backlogEpisode struct {
+	Start        int64            `json:"start"`
+	End          *int64           `json:"end,omitempty"`
+	PeakPending  map[string]int64 `json:"peakPending"`
+	ScaleUpAt    *int64           `json:"scaleUpAt,omitempty"`
+	ReactionTime *int64           `json:"reactionTime,omitempty"`
+}
+
+// backlogSummary counts the backlog episodes, and those the autoscaler scaled up for. Reaction times are in
+// nanoseconds, and are not set if the autoscaler did not scale up for any episode.
+type backlogSummary struct {
+	Episodes           int    `json:"episodes"`
+	ScaledUp           int    `json:"scaledUp"`
+	MedianReactionTime *int64 `json:"medianReactionTime,omitempty"`
+	MaxReactionTime    *int64 `json:"maxReactionTime,omitempty"`
+}
+
+// getAutoscalerTimeline returns the samples of the pending resources of a partition and the events of the
+// cluster-autoscaler during a period, with the backlog episodes of the partition and the reaction of the autoscaler
+// to them. Following query params are supported:
+// - from: the start of the period, 24 hours before the end by default
+// - to: the end of the period, now by default
+// - tz: timezone of the time params without offset and of the period of the response, UTC by default
+func (ws *WebService) getAutoscalerTimeline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultAutoscalerTimelinePeriod)
+		from = &start
+		if from.Before(minTime) {
+			from = &minTime
+		}
+	}
+
+	backlog, err := ws.repository.GetPendingBacklog(r.Context(), repository.PendingBacklogFilters{
+		Partition: partition, Start: from, End: to,
+	})
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	events, err := ws.repository.GetAutoscalerEvents(r.Context(), repository.AutoscalerEventFilters{
+		Start: from, End: to,
+	})
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if backlog == nil {
+		backlog = []*model.PendingBacklogSample{}
+	}
+	if events == nil {
+		events = []*model.AutoscalerEvent{}
+	}
+	episodes := backlogEpisodes(backlog, events)
+	jsonResponse(w, r, autoscalerTimeline{
+		Partition: partition,
+		From:      from.In(loc),
+		To:        to.In(loc),
+		Backlog:   backlog,
+		Events:    events,
+		Episodes:  episodes,
+		Summary:   summarizeBacklog(episodes),
+	})
+}
+
+// backlogEpisodes returns the backlog episodes of the samples, and the first scale-up during each. The scale-ups seen
+// since the last sample without pending resources count for an episode, as the backlog started in between.
+func backlogEpisodes(backlog []*model.PendingBacklogSample, events []*model.AutoscalerEvent) []*backlogEpisode {
+	episodes := []*backlogEpisode{}
+	var current *backlogEpisode
+	var previous *int64
+	for _, sample := range backlog {
+		if !hasPending(sample.PendingResource) {
+			if current != nil {
+				current.End = &sample.SampledAt
+				current = nil
+			}
+			previous = &sample.SampledAt
+			continue
+		}
+		if current == nil {
+			current = &backlogEpisode{Start: sample.SampledAt, PeakPending: map[string]int64{}}
+			episodes = append(episodes, current)
+			current.ScaleUpAt = firstScaleUp(events, previous)
+		}
+		for resource, quantity := range sample.PendingResource {
+			current.PeakPending[resource] = max(current.PeakPending[resource], quantity)
+		}
+	}
+	for _, episode := range episodes {
+		if episode.ScaleUpAt != nil && episode.End != nil && *episode.ScaleUpAt >= *episode.End {
+			episode.ScaleUpAt = nil
+		}
+		if episode.ScaleUpAt != nil {
+			reaction := max(*episode.ScaleUpAt-episode.Start, 0)
+			episode.ReactionTime = &reaction
+		}
+	}
+	return episodes
+}
+
+// firstScaleUp returns the time of the first scale-up after the time, or of the first scale-up if it is nil.
+func firstScaleUp(events []*model.AutoscalerEvent, after *int64) *int64 {
+	for _, e := range events {
+		if e.Direction == model.AutoscalerScaleUp && (after == nil || e.Time > *after) {
+			return &e.Time
+		}
+	}
+	return nil
+}
+
+func hasPending(resources map[string]int64) bool {
+	for _, quantity := range resources {
+		if quantity > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeBacklog(episodes []*backlogEpisode) backlogSummary {
+	summary := backlogSummary{Episodes: len(episodes)}
+	var reactions []int64
+	for _, episode := range episodes {
+		if episode.ReactionTime != nil {
+			reactions = append(reactions, *episode.ReactionTime)
+		}
+	}
+	summary.ScaledUp = len(reactions)
+	if len(reactions) > 0 {
+		slices.Sort(reactions)
+		summary.MedianReactionTime = &reactions[(len(reactions)-1)/2]
+		summary.MaxReactionTime = &reactions[len(reactions)-1]
+	}
+	return summary
+}
@@ -0,0 +1,150 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/autoscaler"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestWebServicePostAutoscalerEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().AddAutoscalerEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, events []*model.AutoscalerEvent) (int, error) {
+			require.Len(t, events, 2)
+			assert.Equal(t, "uid-1", events[0].UID)
+			assert.Equal(t, model.AutoscalerScaleUp, events[0].Direction)
+			assert.Equal(t, "uid-2", events[1].UID)
+			assert.Equal(t, model.AutoscalerScaleDown, events[1].Direction)
+			return 1, nil
+		})
+	converter := autoscaler.NewConverter(config.DefaultAutoscalerSourceComponents)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithAutoscalerEvents(converter))
+	ws.init(context.Background())
+
+	body := `{"kind": "EventList", "items": [
+		{"metadata": {"uid": "uid-1"}, "reason": "TriggeredScaleUp", "source": {"component": "cluster-autoscaler"},
+			"lastTimestamp": "2024-07-01T12:00:00Z", "count": 1},
+		{"metadata": {"uid": "uid-2"}, "reason": "ScaleDown", "reportingComponent": "cluster-autoscaler",
+			"eventTime": "2024-07-01T13:00:00.000000Z"},
+		{"metadata": {"uid": "uid-3"}, "reason": "Scheduled", "source": {"component": "yunikorn"}}
+	]}`
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/autoscaler/events",
+		strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"received": 3, "stored": 1, "ignored": 1}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/autoscaler/events",
+		strings.NewReader(`[{"reason": "ScaleDown", "source": {"component": "cluster-autoscaler"}}]`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/autoscaler/events",
+		strings.NewReader(`{"kind":`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServicePostAutoscalerEventsDisabled(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/autoscaler/events",
+		strings.NewReader(`[]`)))
+	assert.NotEqual(t, http.StatusOK, rec.Code)
+}
+
+func TestWebServiceGetAutoscalerTimeline(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetPendingBacklog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.PendingBacklogFilters) ([]*model.PendingBacklogSample, error) {
+			assert.Equal(t, "default", filters.Partition)
+			assert.True(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Equal(*filters.Start), filters.Start)
+			assert.True(t, time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC).Equal(*filters.End), filters.End)
+			return []*model.PendingBacklogSample{
+				{Partition: "default", SampledAt: 100, PendingResource: map[string]int64{"vcore": 0}},
+				{Partition: "default", SampledAt: 200, PendingResource: map[string]int64{"vcore": 1000}},
+				{Partition: "default", SampledAt: 300, PendingResource: map[string]int64{"vcore": 3000}},
+				{Partition: "default", SampledAt: 400, PendingResource: map[string]int64{}},
+				{Partition: "default", SampledAt: 500, PendingResource: map[string]int64{"memory": 512}},
+			}, nil
+		})
+	repo.EXPECT().GetAutoscalerEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.AutoscalerEventFilters) ([]*model.AutoscalerEvent, error) {
+			assert.True(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Equal(*filters.Start), filters.Start)
+			return []*model.AutoscalerEvent{
+				{ID: "e1", UID: "u1", Time: 50, Reason: "TriggeredScaleUp", Direction: model.AutoscalerScaleUp, Count: 1},
+				{ID: "e2", UID: "u2", Time: 150, Reason: "NotTriggerScaleUp", Direction: model.AutoscalerNoScaleUp, Count: 1},
+				{ID: "e3", UID: "u3", Time: 250, Reason: "TriggeredScaleUp", Direction: model.AutoscalerScaleUp, Count: 1},
+				{ID: "e4", UID: "u4", Time: 450, Reason: "ScaleDown", Direction: model.AutoscalerScaleDown, Count: 2},
+			}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/autoscaler-timeline?from=2024-07-01&to=2024-07-02", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var timeline autoscalerTimeline
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &timeline))
+	assert.Len(t, timeline.Backlog, 5)
+	assert.Len(t, timeline.Events, 4)
+	require.Len(t, timeline.Episodes, 2)
+	assert.Equal(t, int64(200), timeline.Episodes[0].Start)
+	assert.Equal(t, int64(400), *timeline.Episodes[0].End)
+	assert.Equal(t, map[string]int64{"vcore": 3000}, timeline.Episodes[0].PeakPending)
+	assert.Equal(t, int64(250), *timeline.Episodes[0].ScaleUpAt)
+	assert.Equal(t, int64(50), *timeline.Episodes[0].ReactionTime)
+	assert.Equal(t, int64(500), timeline.Episodes[1].Start)
+	assert.Nil(t, timeline.Episodes[1].End)
+	assert.Nil(t, timeline.Episodes[1].ScaleUpAt)
+	assert.Equal(t, 2, timeline.Summary.Episodes)
+	assert.Equal(t, 1, timeline.Summary.ScaledUp)
+	assert.Equal(t, int64(50), *timeline.Summary.MedianReactionTime)
+	assert.Equal(t, int64(50), *timeline.Summary.MaxReactionTime)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/autoscaler-timeline?to=tomorrow", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestBacklogEpisodesScaleUpBeforeSample(t *testing.T) {
+	backlog := []*model.PendingBacklogSample{
+		{SampledAt: 100, PendingResource: map[string]int64{}},
+		{SampledAt: 200, PendingResource: map[string]int64{"vcore": 1000}},
+		{SampledAt: 300, PendingResource: map[string]int64{}},
+	}
+	events := []*model.AutoscalerEvent{
+		{Time: 50, Direction: model.AutoscalerScaleUp},
+		{Time: 150, Direction: model.AutoscalerScaleUp},
+	}
+	episodes := backlogEpisodes(backlog, events)
+	require.Len(t, episodes, 1)
+	assert.Equal(t, int64(150), *episodes[0].ScaleUpAt)
+	assert.Equal(t, int64(0), *episodes[0].ReactionTime)
+
+	episodes = backlogEpisodes(backlog, events[:1])
+	require.Len(t, episodes, 1)
+	assert.Nil(t, episodes[0].ScaleUpAt)
+	assert.Nil(t, episodes[0].ReactionTime)
+}
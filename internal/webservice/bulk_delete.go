@@ -0,0 +1,95 @@
+package webservice
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// bulkDeleteBatchSize is the number of applications deleted per database statement by bulk deletes.
+const bulkDeleteBatchSize = 1000
+
+// bulkDeleteResult is the result of a bulk delete. Matched is the number of applications which are or would be
+// deleted, and Held the number of those retained because they are under an active legal hold.
+type bulkDeleteResult struct {
+	DryRun  bool  `json:"dryRun"`
+	Matched int64 `json:"matched"`
+	Held    int64 `json:"held"`
+	Deleted int64 `json:"deleted"`
+}
+
+// deleteApplications deletes the applications submitted before a time to the queues matching a pattern, e.g. to purge
+// the applications of load tests, in batches together with their allocation constraints and trace events.
+// Applications under an active legal hold are retained. Bulk deletes must be made by an authenticated API client.
+// Following query params are supported:
+// - queue: pattern of the queues, in which * matches any characters, e.g. root.sandbox.* (required)
+// - before: only delete the applications submitted before this time (required)
+// - partition: only delete the applications of this partition
+// - tz: timezone of the before time without offset, UTC by default
+// - dryRun: only count the applications which would be deleted
+func (ws *WebService) deleteApplications(w http.ResponseWriter, r *http.Request) {
+	principal, ok := principalFromContext(r.Context())
+	if !ok {
+		problemResponse(w, r, http.StatusForbidden, errors.New("bulk deletes must be made by an authenticated API client"))
+		return
+	}
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	var filters repository.BulkDeleteFilters
+	if partition := q.String(queryParamPartition); partition != nil {
+		filters.Partition = *partition
+	}
+	if queue := q.String(queryParamQueue); queue == nil {
+		q.invalidate(queryParamQueue, "is required")
+	} else if strings.HasPrefix(*queue, "*") {
+		q.invalidate(queryParamQueue, "must start with a queue name")
+	} else {
+		filters.QueuePattern = *queue
+	}
+	if before := q.Time(queryParamBefore, loc, time.Now()); before != nil {
+		filters.Before = *before
+	} else if _, ok := q.get(queryParamBefore); !ok {
+		q.invalidate(queryParamBefore, "is required")
+	}
+	dryRun := q.Bool(queryParamDryRun)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	var result bulkDeleteResult
+	var err error
+	result.Matched, result.Held, err = ws.repository.CountBulkDeleteApplications(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if dryRun != nil && *dryRun {
+		result.DryRun = true
+		jsonResponse(w, r, result)
+		return
+	}
+
+	logger := log.FromContext(r.Context()).With("principal", principal, "partition", filters.Partition,
+		"queue", filters.QueuePattern, "before", filters.Before)
+	logger.Infow("deleting applications", "applications", result.Matched)
+	for {
+		deleted, err := ws.repository.BulkDeleteApplications(r.Context(), filters, bulkDeleteBatchSize)
+		if err != nil {
+			logger.Errorw("bulk delete interrupted", "deleted", result.Deleted)
+			errorResponse(w, r, err)
+			return
+		}
+		result.Deleted += deleted
+		if deleted < bulkDeleteBatchSize {
+			break
+		}
+	}
+	logger.Infow("deleted applications", "deleted", result.Deleted)
+	jsonResponse(w, r, result)
+}
@@ -0,0 +1,65 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestWebServiceDeleteApplications(t *testing.T) {
+	before := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	filters := repository.BulkDeleteFilters{QueuePattern: "root.sandbox.*", Before: before}
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithAPIKeys(map[string]string{"ops": "ops-secret"}))
+	ws.init(context.Background())
+	serve := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, target, nil)
+		req.Header.Set(headerAuthorization, bearerPrefix+"ops-secret")
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	repo.EXPECT().CountBulkDeleteApplications(gomock.Any(), filters).Return(int64(2500), int64(3), nil).Times(2)
+	rec := serve("/ws/v1/admin/applications?queue=root.sandbox.*&before=2024-07-01&dryRun=true")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"dryRun": true, "matched": 2500, "held": 3, "deleted": 0}`, rec.Body.String())
+
+	gomock.InOrder(
+		repo.EXPECT().BulkDeleteApplications(gomock.Any(), filters, bulkDeleteBatchSize).Return(int64(1000), nil).Times(2),
+		repo.EXPECT().BulkDeleteApplications(gomock.Any(), filters, bulkDeleteBatchSize).Return(int64(500), nil),
+	)
+	rec = serve("/ws/v1/admin/applications?queue=root.sandbox.*&before=2024-07-01")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"dryRun": false, "matched": 2500, "held": 3, "deleted": 2500}`, rec.Body.String())
+
+	for _, target := range []string{
+		"/ws/v1/admin/applications?before=2024-07-01",
+		"/ws/v1/admin/applications?queue=root.sandbox.*",
+		"/ws/v1/admin/applications?queue=*&before=2024-07-01",
+		"/ws/v1/admin/applications?queue=root.sandbox.*&before=yesterday",
+	} {
+		rec = serve(target)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestWebServiceDeleteApplicationsUnauthenticated(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repository.NewMockRepository(gomock.NewController(t)), nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete,
+		"/ws/v1/admin/applications?queue=root.sandbox.*&before=2024-07-01", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,126 @@
+package webservice
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// headerCache tells whether the response was served from the cache.
+	headerCache = "X-Cache"
+	// maxCachedResponseSize is the maximum size of a cached response body.
+	maxCachedResponseSize = 1 << 20
+)
+
+// uncachedPrefixes lists the API routes whose responses are not cached: the admin routes, whose responses must
+// reflect changes immediately, the clusters, whose status depends on the time of the request, the traces and events
+// of applications, which change with every event of the event stream, the downloads of exports, the routes whose
+// responses do not come from the database, and the analytics and report routes, whose results are cached in the
+// result cache.
+var uncachedPrefixes = []string{
+	"/ws/v1/admin/",
+	"/ws/v1/analytics/",
+	"/ws/v1/exports/",
+	"/ws/v1/reports/",
+	routeClusters,
+	"/ws/v1/application/",
+	"/ws/v1/health/",
+	routeSchedulerHealthcheck,
+	routeEventStatistics,
+	routeEventStream,
+	routePoll,
+}
+
+// cacheResponses wraps the handler so that successful JSON responses of the read API routes are served from
+// the cache. Responses are cached per URL, Accept header and naming convention of the fields, with their page headers.
+// Streamed newline delimited JSON and MessagePack responses are not cached. If the cache is disabled, the handler is
+// returned as is.
+func (ws *WebService) cacheResponses(next http.Handler) http.Handler {
+	if ws.cache == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !cacheable(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, ok, entry := ws.cache.Get(r.Context(), responseKey(r))
+		if ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(headerCache, "HIT")
+			body = decodeCachedResponse(w.Header(), body)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+			return
+		}
+
+		w.Header().Set(headerCache, "MISS")
+		recorder := &cacheRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		if recorder.cacheable() {
+			entry.Store(r.Context(), encodeCachedResponse(recorder.Header(), recorder.body.Bytes()))
+		}
+	})
+}
+
+// responseKey returns the key of the response to the request, which depends on the URL, the Accept header,
+// the naming convention of the fields and the response schema.
+func responseKey(r *http.Request) string {
+	return strings.Join([]string{r.URL.RequestURI(), r.Header.Get("Accept"), fieldNamingFromContext(r.Context()),
+		apiSchemaFromContext(r.Context())}, "\n")
+}
+
+// cacheable reports whether the response to the request may be served from the cache.
+func cacheable(r *http.Request) bool {
+	if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, "/ws/") || acceptsNDJSON(r) {
+		return false
+	}
+	for _, prefix := range uncachedPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return false
+		}
+	}
+	// the live tails of the queues include the applications of the scheduler
+	return !strings.HasSuffix(r.URL.Path, liveAppsSuffix)
+}
+
+// cacheRecorder writes the response and records its body, unless it is too large to be cached.
+type cacheRecorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *cacheRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *cacheRecorder) Write(b []byte) (int, error) {
+	if !r.overflow {
+		if r.body.Len()+len(b) > maxCachedResponseSize {
+			r.overflow = true
+			r.body = bytes.Buffer{}
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// cacheable reports whether the recorded response may be cached. Stale responses are not cached.
+func (r *cacheRecorder) cacheable() bool {
+	if r.status != http.StatusOK || r.overflow || r.Header().Get(headerStale) != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header().Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// Unwrap returns the wrapped response writer, so that http.ResponseController can flush it.
+func (r *cacheRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
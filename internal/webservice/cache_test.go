@@ -0,0 +1,96 @@
+package webservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestWebServiceCache(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	backend := cache.NewMemoryBackend(100)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithCache(cache.NewWithBackend(backend, time.Minute, time.Second)))
+	ws.init(ctx)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, routePartitions, nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// errors are not cached
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, errors.New("connection refused"))
+	rec := get("", "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default", TotalNodes: 2}}, nil).Times(2)
+	rec = get("", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+	want := rec.Body.String()
+
+	rec = get("", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "HIT", rec.Header().Get(headerCache))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, want, rec.Body.String())
+
+	// responses with other field names are cached separately
+	rec = get(headerFieldNaming, config.FieldNamingSnakeCase)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+	assert.Contains(t, rec.Body.String(), `"total_nodes":2`)
+
+	require.NoError(t, backend.Invalidate(ctx))
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default", TotalNodes: 3}}, nil)
+	rec = get("", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+	assert.Contains(t, rec.Body.String(), `"totalNodes":3`)
+}
+
+func TestCacheable(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		path   string
+		accept string
+		want   bool
+	}{
+		"partitions":       {method: http.MethodGet, path: routePartitions, want: true},
+		"applications":     {method: http.MethodGet, path: "/ws/v1/partition/default/queue/root/applications?limit=10", want: true},
+		"live tail":        {method: http.MethodGet, path: "/ws/v1/partition/default/queue/root/applications/live?since=1h"},
+		"post":             {method: http.MethodPost, path: routeAnalyticsQuery},
+		"streamed":         {method: http.MethodGet, path: routeAppsHistory, accept: ndjsonContentType},
+		"admin":            {method: http.MethodGet, path: routeLegalHolds},
+		"health":           {method: http.MethodGet, path: routeHealthReadiness},
+		"event statistics": {method: http.MethodGet, path: routeEventStatistics},
+		"web UI":           {method: http.MethodGet, path: "/index.html"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			assert.Equal(t, tt.want, cacheable(req))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/canary"
+)
+
+// canaryRollout is the request body for changing the percentage of the calls running a candidate.
+type canaryRollout struct {
+	Percentage *float64 `json:"percentage"`
+}
+
+// WithCanary enables the admin API changing the rollout of the candidates of the canary, which must also wrap the
+// repository of the web service. The API is not registered if the canary is nil.
+func WithCanary(c *canary.Canary) Option {
+	return func(ws *WebService) {
+		ws.canary = c
+	}
+}
+
+func (ws *WebService) getCanaries(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.canary.List())
+}
+
+// setCanary sets the percentage of the calls of a method which run its candidate, 0 stops running it.
+func (ws *WebService) setCanary(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	var rollout canaryRollout
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rollout); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if rollout.Percentage == nil {
+		badRequestResponse(w, r, errors.New("percentage is required"))
+		return
+	}
+	status, err := ws.canary.SetPercentage(params.ByName(paramsMethod), *rollout.Percentage)
+	if err != nil {
+		if errors.Is(err, canary.ErrUnknownCandidate) {
+			notFoundResponse(w, r, err)
+			return
+		}
+		badRequestResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, status)
+}
@@ -0,0 +1,61 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/canary"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestCanariesRoutes(t *testing.T) {
+	c := canary.New(nil, []canary.Candidate{{
+		Method:     "QueryAnalytics",
+		Percentage: 5,
+		New:        func(repo repository.Repository) repository.Repository { return repo },
+	}})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithCanary(c))
+	ws.init(context.Background())
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodGet, routeCanaries, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var statuses []canary.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	assert.Equal(t, []canary.Status{{Method: "QueryAnalytics", Percentage: 5}}, statuses)
+
+	rec = serve(http.MethodPut, "/ws/v1/admin/canaries/QueryAnalytics", `{"percentage": 25}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"method": "QueryAnalytics", "percentage": 25}`, rec.Body.String())
+	assert.Equal(t, []canary.Status{{Method: "QueryAnalytics", Percentage: 25}}, c.List())
+
+	rec = serve(http.MethodPut, "/ws/v1/admin/canaries/QueryAnalytics", `{"percentage": 101}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(http.MethodPut, "/ws/v1/admin/canaries/QueryAnalytics", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(http.MethodPut, "/ws/v1/admin/canaries/GetTopUsage", `{"percentage": 25}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, []canary.Status{{Method: "QueryAnalytics", Percentage: 25}}, c.List())
+}
+
+func TestCanariesRoutesNotRegisteredWithoutCanary(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+	for _, r := range ws.routes {
+		assert.NotEqual(t, routeCanaries, r.path)
+	}
+}
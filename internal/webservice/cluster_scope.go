@@ -0,0 +1,69 @@
+package webservice
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+)
+
+const (
+	// clusterPathPrefix is the prefix of the paths scoped to a cluster.
+	clusterPathPrefix = "/ws/v1/clusters/"
+	// partitionPathPrefix is the prefix of the routes of a partition.
+	partitionPathPrefix = "/ws/v1/partition/"
+)
+
+// scopeClusterPaths wraps the handler so that the routes of a partition can be requested under the cluster of the
+// partition with its unqualified name: /ws/v1/clusters/{cluster}/partition/{partition}/... is served by the route
+// /ws/v1/partition/[{cluster}]{partition}/... of the qualified partition, see package cluster.
+func (ws *WebService) scopeClusterPaths(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if path, ok := clusterScopedPath(r.URL.Path); ok {
+			u := *r.URL
+			u.Path, u.RawPath = path, ""
+			r = r.Clone(r.Context())
+			r.URL = &u
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clusterScopedPath returns the path of the route of the qualified partition of a path scoped to a cluster, and
+// false for other paths and paths of clusters with invalid IDs.
+func clusterScopedPath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, clusterPathPrefix)
+	if !ok {
+		return "", false
+	}
+	segments := strings.SplitN(rest, "/", 4)
+	if len(segments) < 4 || segments[1] != "partition" || !cluster.ValidID(segments[0]) || segments[2] == "" {
+		return "", false
+	}
+	return partitionPathPrefix + cluster.Qualify(segments[0], segments[2]) + "/" + segments[3], true
+}
+
+// getClusterPartitions returns the partitions of a cluster, whose names are qualified with its ID.
+func (ws *WebService) getClusterPartitions(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id := params.ByName(paramsClusterName)
+	if !cluster.ValidID(id) {
+		badRequestResponse(w, r, fmt.Errorf("cluster id %q is not a lowercase DNS label", id))
+		return
+	}
+	partitions, err := ws.repository.GetAllPartitions(r.Context())
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	clusterPartitions := make([]*dao.PartitionInfo, 0, len(partitions))
+	for _, p := range partitions {
+		if partitionCluster, _ := cluster.Split(p.Name); partitionCluster == id {
+			clusterPartitions = append(clusterPartitions, p)
+		}
+	}
+	jsonResponse(w, r, clusterPartitions)
+}
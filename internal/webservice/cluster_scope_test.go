@@ -0,0 +1,124 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestClusterScopedPath(t *testing.T) {
+	tests := map[string]struct {
+		path string
+		want string
+		ok   bool
+	}{
+		"queues": {
+			path: "/ws/v1/clusters/eu-west/partition/default/queues",
+			want: "/ws/v1/partition/[eu-west]default/queues",
+			ok:   true,
+		},
+		"applications": {
+			path: "/ws/v1/clusters/eu-west/partition/default/queue/root.batch/applications",
+			want: "/ws/v1/partition/[eu-west]default/queue/root.batch/applications",
+			ok:   true,
+		},
+		"partitions of cluster": {path: "/ws/v1/clusters/eu-west/partitions"},
+		"heartbeat":             {path: "/ws/v1/clusters/eu-west/heartbeat"},
+		"invalid cluster id":    {path: "/ws/v1/clusters/EU_West/partition/default/queues"},
+		"empty partition":       {path: "/ws/v1/clusters/eu-west/partition//queues"},
+		"partition without route": {
+			path: "/ws/v1/clusters/eu-west/partition/default",
+		},
+		"other route": {path: "/ws/v1/partition/default/queues"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := clusterScopedPath(tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWebServiceClusterScopedRoutes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetQueuesPerPartition(gomock.Any(), "[eu-west]default").
+		Return([]*model.PartitionQueueDAOInfo{}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/clusters/eu-west/partition/default/queues", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestWebServiceGetClusterPartitions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{
+		{Name: "default", ClusterID: "mycluster"},
+		{Name: "[eu-west]default", ClusterID: "eu-west"},
+		{Name: "[eu-west]gpu", ClusterID: "eu-west"},
+		{Name: "[us-east]default", ClusterID: "us-east"},
+	}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/clusters/eu-west/partitions", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var partitions []*dao.PartitionInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &partitions))
+	require.Len(t, partitions, 2)
+	assert.Equal(t, "[eu-west]default", partitions[0].Name)
+	assert.Equal(t, "[eu-west]gpu", partitions[1].Name)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/clusters/EU_West/partitions", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceHistoryOfCluster(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	ofCluster := func(id string) gomock.Matcher {
+		return gomock.Cond(func(ctx any) bool { return cluster.IDFromContext(ctx.(context.Context)) == id })
+	}
+	repo.EXPECT().GetApplicationsHistoryPage(ofCluster(""), repository.Page{Limit: &config.DefaultPageSize.DefaultLimit}).
+		Return([]*dao.ApplicationHistoryDAOInfo{}, repository.PageInfo{}, nil)
+	repo.EXPECT().GetApplicationsHistoryPage(ofCluster("eu-west"), repository.Page{Limit: &config.DefaultPageSize.DefaultLimit}).
+		Return([]*dao.ApplicationHistoryDAOInfo{}, repository.PageInfo{}, nil)
+	repo.EXPECT().StreamContainersHistory(ofCluster("eu-west"), gomock.Any()).Return(nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	for _, path := range []string{"/ws/v1/history/apps", "/ws/v1/history/apps?cluster=eu-west"} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	req := httptest.NewRequest(http.MethodGet, "/ws/v1/history/containers?cluster=eu-west", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/ws/v1/history/containers?cluster=EU_West", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,170 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// clusterStaleAfter is the time after the last heartbeat of a cluster after which it is reported stale.
+	clusterStaleAfter = 5 * time.Minute
+	// maxClusterLabels is the maximum number of labels of a cluster.
+	maxClusterLabels = 64
+
+	clusterStatusActive = "active"
+	clusterStatusStale  = "stale"
+)
+
+// clusterRegistration is the request body for registering a cluster.
+type clusterRegistration struct {
+	SchedulerVersion string            `json:"schedulerVersion"`
+	Labels           map[string]string `json:"labels"`
+}
+
+func (req *clusterRegistration) validate() error {
+	var errorMessages []string
+	if req.SchedulerVersion == "" {
+		errorMessages = append(errorMessages, "schedulerVersion is required")
+	} else if reason := validateString(req.SchedulerVersion); reason != "" {
+		errorMessages = append(errorMessages, "schedulerVersion "+reason)
+	}
+	if len(req.Labels) > maxClusterLabels {
+		errorMessages = append(errorMessages, fmt.Sprintf("labels must not have more than %d entries", maxClusterLabels))
+	}
+	for key, value := range req.Labels {
+		if key == "" {
+			errorMessages = append(errorMessages, "label keys must not be empty")
+		} else if reason := validateString(key); reason != "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("label key %q %s", key, reason))
+		} else if reason := validateString(value); reason != "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("label %s %s", key, reason))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("invalid cluster registration: %v", errorMessages)
+	}
+	return nil
+}
+
+// clusterHeartbeat is the request body of a heartbeat of a cluster, which may be empty.
+type clusterHeartbeat struct {
+	// IngestedUntil is the Unix time in seconds up to which the agent ingested the data of the cluster.
+	IngestedUntil *int64 `json:"ingestedUntil"`
+}
+
+// clusterStatus is a registered cluster with its status at the time of the request.
+type clusterStatus struct {
+	*model.Cluster
+	// Status is active if the cluster sent a heartbeat recently, and stale otherwise.
+	Status string `json:"status"`
+	// IngestionLagSeconds is the time since the time up to which the data of the cluster was ingested,
+	// if the agent reported it.
+	IngestionLagSeconds *int64 `json:"ingestionLagSeconds"`
+}
+
+func newClusterStatus(cluster *model.Cluster, now time.Time) clusterStatus {
+	status := clusterStatus{Cluster: cluster, Status: clusterStatusActive}
+	if now.Sub(time.Unix(cluster.LastSeen, 0)) > clusterStaleAfter {
+		status.Status = clusterStatusStale
+	}
+	if cluster.IngestedUntil != nil {
+		lag := max(now.Unix()-*cluster.IngestedUntil, 0)
+		status.IngestionLagSeconds = &lag
+	}
+	return status
+}
+
+// getClusters returns the registered clusters ordered by name, with their status and ingestion lag.
+func (ws *WebService) getClusters(w http.ResponseWriter, r *http.Request) {
+	clusters, err := ws.repository.GetClusters(r.Context())
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	now := time.Now()
+	statuses := make([]clusterStatus, 0, len(clusters))
+	for _, cluster := range clusters {
+		statuses = append(statuses, newClusterStatus(cluster, now))
+	}
+	jsonResponse(w, r, statuses)
+}
+
+// registerCluster registers the cluster of an ingestion agent, or updates its scheduler version and labels
+// if it is already registered.
+func (ws *WebService) registerCluster(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name, ok := clusterName(w, r, params)
+	if !ok {
+		return
+	}
+	var req clusterRegistration
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	cluster := &model.Cluster{Name: name, SchedulerVersion: req.SchedulerVersion, Labels: req.Labels}
+	if err := ws.repository.RegisterCluster(r.Context(), cluster); err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("cluster registered", "cluster", name, "scheduler_version", req.SchedulerVersion)
+	jsonResponse(w, r, newClusterStatus(cluster, time.Now()))
+}
+
+// heartbeatCluster records that the agent of a registered cluster is alive and, optionally, how far it ingested
+// the data of the cluster.
+func (ws *WebService) heartbeatCluster(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name, ok := clusterName(w, r, params)
+	if !ok {
+		return
+	}
+	var heartbeat clusterHeartbeat
+	if err := json.NewDecoder(r.Body).Decode(&heartbeat); err != nil && !errors.Is(err, io.EOF) {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	var ingestedUntil *time.Time
+	if heartbeat.IngestedUntil != nil {
+		t := time.Unix(*heartbeat.IngestedUntil, 0)
+		if t.Before(minTime) || t.After(maxTime) {
+			badRequestResponse(w, r, fmt.Errorf("ingestedUntil must be between %d and %d", minTime.Unix(), maxTime.Unix()))
+			return
+		}
+		ingestedUntil = &t
+	}
+
+	cluster, err := ws.repository.HeartbeatCluster(r.Context(), name, ingestedUntil)
+	if err != nil {
+		if errors.Is(err, repository.ErrClusterNotFound) {
+			notFoundResponse(w, r, err)
+			return
+		}
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, newClusterStatus(cluster, time.Now()))
+}
+
+// clusterName returns the cluster name path parameter, responding with 400 Bad Request if it is not valid.
+func clusterName(w http.ResponseWriter, r *http.Request, params httprouter.Params) (string, bool) {
+	name := params.ByName(paramsClusterName)
+	if reason := validateString(name); reason != "" {
+		badRequestResponse(w, r, fmt.Errorf("cluster name %s", reason))
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,144 @@
+package webservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestNewClusterStatus(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	status := newClusterStatus(&model.Cluster{
+		Name:          "eu-west",
+		LastSeen:      now.Add(-time.Minute).Unix(),
+		IngestedUntil: util.ToPtr(now.Add(-90 * time.Second).Unix()),
+	}, now)
+	assert.Equal(t, clusterStatusActive, status.Status)
+	assert.Equal(t, util.ToPtr(int64(90)), status.IngestionLagSeconds)
+
+	status = newClusterStatus(&model.Cluster{Name: "ap-south", LastSeen: now.Add(-time.Hour).Unix()}, now)
+	assert.Equal(t, clusterStatusStale, status.Status)
+	assert.Nil(t, status.IngestionLagSeconds)
+
+	// clocks of agents may be ahead
+	status = newClusterStatus(&model.Cluster{LastSeen: now.Unix(), IngestedUntil: util.ToPtr(now.Add(time.Second).Unix())}, now)
+	assert.Equal(t, util.ToPtr(int64(0)), status.IngestionLagSeconds)
+}
+
+func TestWebServiceGetClusters(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	now := time.Now().Unix()
+	repo.EXPECT().GetClusters(gomock.Any()).Return([]*model.Cluster{{
+		Name:             "eu-west",
+		SchedulerVersion: "1.5.0",
+		Labels:           map[string]string{"region": "eu"},
+		RegisteredAt:     now - 3600,
+		LastSeen:         now,
+	}}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/clusters", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, fmt.Sprintf(`[{
+		"name": "eu-west",
+		"schedulerVersion": "1.5.0",
+		"labels": {"region": "eu"},
+		"registeredAt": %d,
+		"lastSeen": %d,
+		"ingestedUntil": null,
+		"status": "active",
+		"ingestionLagSeconds": null
+	}]`, now-3600, now), rec.Body.String())
+}
+
+func TestWebServiceRegisterCluster(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().RegisterCluster(gomock.Any(), &model.Cluster{
+		Name:             "eu-west",
+		SchedulerVersion: "1.5.0",
+		Labels:           map[string]string{"region": "eu"},
+	}).DoAndReturn(func(_ context.Context, cluster *model.Cluster) error {
+		cluster.RegisteredAt = time.Now().Unix()
+		cluster.LastSeen = cluster.RegisteredAt
+		return nil
+	})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/ws/v1/clusters/eu-west",
+		strings.NewReader(`{"schedulerVersion": "1.5.0", "labels": {"region": "eu"}}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"status":"active"`)
+}
+
+func TestWebServiceRegisterClusterInvalid(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	tests := map[string]string{
+		"missing scheduler version": `{"labels": {"region": "eu"}}`,
+		"empty label key":           `{"schedulerVersion": "1.5.0", "labels": {"": "eu"}}`,
+		"malformed body":            `{"schedulerVersion":`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/ws/v1/clusters/eu-west",
+				strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestWebServiceHeartbeatCluster(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	ingestedUntil := time.Unix(1719835200, 0)
+	repo.EXPECT().HeartbeatCluster(gomock.Any(), "eu-west", &ingestedUntil).Return(&model.Cluster{
+		Name:          "eu-west",
+		LastSeen:      time.Now().Unix(),
+		IngestedUntil: util.ToPtr(ingestedUntil.Unix()),
+	}, nil)
+	repo.EXPECT().HeartbeatCluster(gomock.Any(), "eu-west", nil).Return(&model.Cluster{
+		Name:     "eu-west",
+		LastSeen: time.Now().Unix(),
+	}, nil)
+	repo.EXPECT().HeartbeatCluster(gomock.Any(), "ap-south", nil).
+		Return(nil, repository.ErrClusterNotFound)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/clusters/eu-west/heartbeat",
+		strings.NewReader(`{"ingestedUntil": 1719835200}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"ingestedUntil":1719835200`)
+
+	// the body is optional
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/clusters/eu-west/heartbeat", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/clusters/ap-south/heartbeat", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,189 @@
+package webservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// headerAPISchema is the request header selecting the release whose response schema the response is rendered in,
+// overriding the configured default.
+const headerAPISchema = "X-API-Schema"
+
+// schemaRoot is the path of the root of a response schema.
+const schemaRoot = "$"
+
+// fieldRename is a field of a response renamed in the current release, which is rendered under its previous name
+// in the response schema of the previous release.
+type fieldRename struct {
+	// Path is the path of the field in the current response schema, as in the golden response schemas, e.g.
+	// $[].queueName for the queueName field of the items of an array. * selects the values of a map.
+	Path string
+	// Previous is the name of the field in the previous release.
+	Previous string
+}
+
+// responseFieldRenames lists the fields renamed in the current release per route, e.g.
+// "GET /ws/v1/partition/:partition_name/nodes", which clients can still read under their previous names by
+// requesting the previous response schema. A rename is kept for one release, after which it is removed together with
+// the previous name from the golden response schemas, see TestResponseSchemasCompatible.
+var responseFieldRenames = map[string][]fieldRename{}
+
+type apiSchemaKey struct{}
+
+type fieldRenamesKey struct{}
+
+// negotiateAPISchema wraps the handler so that the release whose response schema the response is rendered in,
+// requested with the X-API-Schema header or configured as default, is stored in the request context.
+func (ws *WebService) negotiateAPISchema(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		schema := ws.apiSchema
+		if header := r.Header.Get(headerAPISchema); header != "" {
+			if header != config.APISchemaCurrent && header != config.APISchemaPrevious {
+				badRequestResponse(w, r, fmt.Errorf(
+					"invalid %s header %q, must be %s or %s", headerAPISchema, header, config.APISchemaCurrent, config.APISchemaPrevious,
+				))
+				return
+			}
+			schema = header
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiSchemaKey{}, schema)))
+	})
+}
+
+// apiSchemaFromContext returns the release whose response schema the response is rendered in, the current one
+// by default.
+func apiSchemaFromContext(ctx context.Context) string {
+	schema, ok := ctx.Value(apiSchemaKey{}).(string)
+	if !ok || schema == "" {
+		return config.APISchemaCurrent
+	}
+	return schema
+}
+
+// withFieldRenames wraps the handle of a route whose fields were renamed in the current release, so that the renames
+// are stored in the context of the requests of the previous response schema and applied by renderFields.
+func withFieldRenames(renames []fieldRename, handle httprouter.Handle) httprouter.Handle {
+	renames = sortFieldRenames(renames)
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if apiSchemaFromContext(r.Context()) == config.APISchemaPrevious {
+			*r = *r.WithContext(context.WithValue(r.Context(), fieldRenamesKey{}, renames))
+		}
+		handle(w, r, p)
+	}
+}
+
+// sortFieldRenames returns the renames in the order they are applied in: the fields of renamed objects are renamed
+// first, as their paths have the current names of the objects.
+func sortFieldRenames(renames []fieldRename) []fieldRename {
+	renames = slices.Clone(renames)
+	depth := func(rename fieldRename) int {
+		return len(splitSchemaPath(strings.TrimPrefix(rename.Path, schemaRoot)))
+	}
+	slices.SortStableFunc(renames, func(a, b fieldRename) int {
+		return depth(b) - depth(a)
+	})
+	return renames
+}
+
+// renderPreviousSchema returns the rendered data with the fields renamed in the current release under their previous
+// names, if the request asked for the previous response schema of a route with renamed fields. root is the path of
+// the data in the response schema, $[] for the rows of streamed arrays.
+func renderPreviousSchema(r *http.Request, data any, root string) (any, error) {
+	renames, ok := r.Context().Value(fieldRenamesKey{}).([]fieldRename)
+	if !ok {
+		return data, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	// numbers are kept as is, as nanosecond timestamps are not exact as float64
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	snake := fieldNamingFromContext(r.Context()) == config.FieldNamingSnakeCase
+	for _, rename := range renames {
+		path, ok := strings.CutPrefix(rename.Path, root)
+		if !ok {
+			continue
+		}
+		segments, previous := splitSchemaPath(path), rename.Previous
+		if snake {
+			for i, segment := range segments {
+				segments[i] = snakeCase(segment)
+			}
+			previous = snakeCase(previous)
+		}
+		renameField(doc, segments, previous)
+	}
+	return doc, nil
+}
+
+// splitSchemaPath splits a path of a response schema relative to its root, e.g. [].queue.name, into its segments,
+// which are [] for the items of arrays, * for the values of maps and the names of fields otherwise.
+func splitSchemaPath(path string) []string {
+	var segments []string
+	for path != "" {
+		if rest, ok := strings.CutPrefix(path, "[]"); ok {
+			segments = append(segments, "[]")
+			path = rest
+			continue
+		}
+		if !strings.HasPrefix(path, ".") {
+			break
+		}
+		name := path[1:]
+		if i := strings.IndexAny(name, ".["); i >= 0 {
+			name = name[:i]
+		}
+		segments = append(segments, name)
+		path = path[1+len(name):]
+	}
+	return segments
+}
+
+// renameField renames the field at the path of the decoded JSON document to its previous name.
+func renameField(doc any, segments []string, previous string) {
+	if len(segments) == 0 {
+		return
+	}
+	switch segments[0] {
+	case "[]":
+		items, _ := doc.([]any)
+		for _, item := range items {
+			renameField(item, segments[1:], previous)
+		}
+	case "*":
+		values, _ := doc.(map[string]any)
+		for _, value := range values {
+			renameField(value, segments[1:], previous)
+		}
+	default:
+		fields, ok := doc.(map[string]any)
+		if !ok {
+			return
+		}
+		value, ok := fields[segments[0]]
+		if !ok {
+			return
+		}
+		if len(segments) > 1 {
+			renameField(value, segments[1:], previous)
+			return
+		}
+		delete(fields, segments[0])
+		fields[previous] = value
+	}
+}
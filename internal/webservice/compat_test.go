@@ -0,0 +1,319 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/yunikorn-history-server/api"
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+var updateSchemas = flag.Bool("update", false, "record the response schemas of the API in the golden files")
+
+var specParam = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// TestResponseSchemasCompatible compares the JSON response schemas of the operations of the OpenAPI specification
+// with the schemas released for the version of the API, recorded in testdata/schemas/<version>/<operationId>.txt
+// with a line per field: its path, e.g. $[].queueName, and its type. A field of the released schema which was removed
+// or changed breaks existing clients and fails the test, unless it was renamed and registered in responseFieldRenames,
+// so that the clients can still read it by requesting the previous schema for one release. New fields are compatible,
+// and are recorded by running the tests with -update. Removing a line of a golden file is a breaking change.
+func TestResponseSchemasCompatible(t *testing.T) {
+	var spec map[string]any
+	require.NoError(t, yaml.Unmarshal(api.Spec, &spec))
+	version := spec["info"].(map[string]any)["version"].(string)
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+
+	routes := make(map[string]bool)
+	for path, operations := range spec["paths"].(map[string]any) {
+		for method, operation := range operations.(map[string]any) {
+			if method == "parameters" {
+				continue
+			}
+			route := strings.ToUpper(method) + " " + specParam.ReplaceAllString(path, ":$1")
+			routes[route] = true
+			operationID := operation.(map[string]any)["operationId"].(string)
+			current := responseSchema(schemas, operation.(map[string]any))
+			t.Run(operationID, func(t *testing.T) {
+				golden := filepath.Join("testdata", "schemas", version, operationID+".txt")
+				released := readSchema(t, golden)
+				renames := responseFieldRenames[route]
+				for _, rename := range renames {
+					assert.Contains(t, current, rename.Path, "%s: renamed field %s is not in the response schema", route, rename.Path)
+				}
+				if current == nil {
+					// e.g. downloads and event streams
+					return
+				}
+				served := previousSchemaFields(current, renames)
+				for field, typ := range released {
+					if current[field] != typ && served[field] != typ {
+						t.Errorf("%s: field %s (%s) of the released schema was removed or changed, which breaks existing "+
+							"clients; register renamed fields in responseFieldRenames", route, field, typ)
+					}
+				}
+				if *updateSchemas {
+					for field, typ := range current {
+						released[field] = typ
+					}
+					writeSchema(t, golden, released)
+					return
+				}
+				for field, typ := range current {
+					if _, ok := released[field]; !ok {
+						t.Errorf("%s: field %s (%s) is not recorded in %s, run the tests with -update", route, field, typ, golden)
+					}
+				}
+			})
+		}
+	}
+	for route := range responseFieldRenames {
+		assert.True(t, routes[route], "renamed fields of unknown route %s", route)
+	}
+}
+
+// responseSchema returns the fields of the JSON response schema of the first successful response of the operation,
+// by path, or nil if the operation has no JSON response.
+func responseSchema(schemas map[string]any, operation map[string]any) map[string]string {
+	var codes []string
+	for code := range operation["responses"].(map[string]any) {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		response := operation["responses"].(map[string]any)[code].(map[string]any)
+		content, _ := response["content"].(map[string]any)
+		media, ok := content["application/json"].(map[string]any)
+		if !ok {
+			continue
+		}
+		fields := make(map[string]string)
+		flattenSchema(schemas, media["schema"].(map[string]any), schemaRoot, nil, fields)
+		return fields
+	}
+	return nil
+}
+
+// flattenSchema adds the type of the schema at the path, and the types of its properties, items and map values
+// below it. The schemas referenced by a schema of their own properties are not expanded again.
+func flattenSchema(schemas map[string]any, schema map[string]any, path string, refs []string, fields map[string]string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if slices.Contains(refs, name) {
+			fields[path] = "recursive(" + name + ")"
+			return
+		}
+		flattenSchema(schemas, schemas[name].(map[string]any), path, append(refs, name), fields)
+		return
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, part := range allOf {
+			flattenSchema(schemas, part.(map[string]any), path, refs, fields)
+		}
+		return
+	}
+	typ, _ := schema["type"].(string)
+	switch {
+	case typ == "" && schema["properties"] != nil:
+		typ = "object"
+	case typ == "":
+		typ = "any"
+	}
+	if format, ok := schema["format"].(string); ok {
+		typ += "(" + format + ")"
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		typ += ",nullable"
+	}
+	fields[path] = typ
+	properties, _ := schema["properties"].(map[string]any)
+	for name, property := range properties {
+		flattenSchema(schemas, property.(map[string]any), path+"."+name, refs, fields)
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		flattenSchema(schemas, items, path+"[]", refs, fields)
+	}
+	if values, ok := schema["additionalProperties"].(map[string]any); ok {
+		flattenSchema(schemas, values, path+".*", refs, fields)
+	}
+}
+
+// previousSchemaFields returns the fields of the current schema with the renamed fields under their previous names,
+// as rendered in the previous response schema.
+func previousSchemaFields(current map[string]string, renames []fieldRename) map[string]string {
+	renames = sortFieldRenames(renames)
+	previous := make(map[string]string, len(current))
+	for path, typ := range current {
+		for _, rename := range renames {
+			if path == rename.Path || strings.HasPrefix(path, rename.Path+".") || strings.HasPrefix(path, rename.Path+"[") {
+				parent := rename.Path[:strings.LastIndex(rename.Path, ".")]
+				path = parent + "." + rename.Previous + path[len(rename.Path):]
+			}
+		}
+		previous[path] = typ
+	}
+	return previous
+}
+
+func readSchema(t *testing.T, path string) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fields
+	}
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		field, typ, ok := strings.Cut(line, " ")
+		require.True(t, ok, "invalid line %q of %s", line, path)
+		fields[field] = typ
+	}
+	return fields
+}
+
+func writeSchema(t *testing.T, path string, fields map[string]string) {
+	t.Helper()
+	lines := make([]string, 0, len(fields))
+	for field, typ := range fields {
+		lines = append(lines, field+" "+typ)
+	}
+	sort.Strings(lines)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+}
+
+func TestPreviousSchemaFields(t *testing.T) {
+	current := map[string]string{
+		"$":                  "array",
+		"$[]":                "object",
+		"$[].queue":          "object",
+		"$[].queue.fullName": "string",
+		"$[].usage":          "object",
+		"$[].usage.*":        "integer(int64)",
+	}
+	renames := []fieldRename{
+		{Path: "$[].queue", Previous: "queueInfo"},
+		{Path: "$[].queue.fullName", Previous: "name"},
+		{Path: "$[].usage", Previous: "resources"},
+	}
+	assert.Equal(t, map[string]string{
+		"$":                  "array",
+		"$[]":                "object",
+		"$[].queueInfo":      "object",
+		"$[].queueInfo.name": "string",
+		"$[].resources":      "object",
+		"$[].resources.*":    "integer(int64)",
+	}, previousSchemaFields(current, renames))
+}
+
+func TestRenderPreviousSchema(t *testing.T) {
+	renames := []fieldRename{
+		{Path: "$[].queue", Previous: "queueInfo"},
+		{Path: "$[].queue.fullName", Previous: "name"},
+		{Path: "$[].usage.*.allocatedMemory", Previous: "memory"},
+	}
+	data := []map[string]any{{
+		"queue": map[string]any{"fullName": "root.a"},
+		"usage": map[string]any{"node-1": map[string]any{"allocatedMemory": int64(1719792000000000001)}},
+	}}
+	want := `[{"queueInfo":{"name":"root.a"},"usage":{"node-1":{"memory":1719792000000000001}}}]`
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	withFieldRenames(renames, func(_ http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		rendered, err := renderFields(r, data)
+		require.NoError(t, err)
+		assert.Equal(t, data, rendered, "the current schema is rendered as is")
+	})(nil, r, nil)
+
+	ctx := context.WithValue(r.Context(), apiSchemaKey{}, config.APISchemaPrevious)
+	withFieldRenames(renames, func(_ http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		rendered, err := renderFields(r, data)
+		require.NoError(t, err)
+		// the timestamps are not rounded by the renames
+		assert.Equal(t, want, marshal(t, rendered))
+
+		row, err := renderRow(r, data[0])
+		require.NoError(t, err)
+		assert.Equal(t, want[1:len(want)-1], marshal(t, row))
+	})(nil, r.WithContext(ctx), nil)
+
+	ctx = context.WithValue(ctx, fieldNamingKey{}, config.FieldNamingSnakeCase)
+	withFieldRenames(renames, func(_ http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		rendered, err := renderFields(r, []struct {
+			Queue struct {
+				FullName string `json:"fullName"`
+			} `json:"queue"`
+		}{{}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"queue_info":{"name":""}}]`, marshal(t, rendered))
+	})(nil, r.WithContext(ctx), nil)
+}
+
+func TestWebServicePreviousAPISchema(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetNodesPerPartitionPage(gomock.Any(), "default", repository.Page{Limit: &config.DefaultPageSize.DefaultLimit}).
+		Return([]*dao.NodeDAOInfo{{NodeID: "node-1"}}, repository.PageInfo{Total: 1}, nil).
+		Times(2)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithCache(cache.NewWithBackend(cache.NewMemoryBackend(100), time.Minute, time.Second)))
+	ws.fieldRenames = map[string][]fieldRename{
+		"GET " + routeNodesPerPartition: {{Path: "$[].nodeID", Previous: "name"}},
+	}
+	ws.init(context.Background())
+	get := func(schema string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/nodes", nil)
+		if schema != "" {
+			req.Header.Set(headerAPISchema, schema)
+		}
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[{"nodeID":"node-1","schedulable":false,"isReserved":false}]`, rec.Body.String())
+
+	// the responses of both schemas are cached separately
+	for _, want := range []string{"MISS", "HIT"} {
+		rec = get(config.APISchemaPrevious)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, want, rec.Header().Get(headerCache))
+		assert.JSONEq(t, `[{"name":"node-1","schedulable":false,"isReserved":false}]`, rec.Body.String())
+	}
+
+	rec = get("v0")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), headerAPISchema)
+}
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
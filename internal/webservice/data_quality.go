@@ -0,0 +1,61 @@
+package webservice
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// dataQualityReport is the response of the data quality endpoint.
+type dataQualityReport struct {
+	Checks     []*model.DataQualityCheck     `json:"checks"`
+	Violations []*model.DataQualityViolation `json:"violations"`
+}
+
+// getDataQuality returns the results of the last runs of the data quality checks and their stored violations,
+// ordered by check.
+// Following query params are supported:
+// - check: only return the check with this name and its violations
+// - limit: limit the number of returned violations
+// - offset: offset the returned violations
+func (ws *WebService) getDataQuality(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	filters := repository.DataQualityFilters{
+		Check:  q.String(queryParamCheck),
+		Offset: q.Offset(),
+		Limit:  q.Limit(ws.pageSizes.Other),
+	}
+	isCheck := func(check repository.DataQualityCheck) bool {
+		return filters.Check != nil && check.Name == *filters.Check
+	}
+	if filters.Check != nil && !slices.ContainsFunc(repository.DataQualityChecks, isCheck) {
+		q.invalidate(queryParamCheck, "unknown check %q", *filters.Check)
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	checks, err := ws.repository.GetDataQualityChecks(r.Context())
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	violations, err := ws.repository.GetDataQualityViolations(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	report := dataQualityReport{Checks: []*model.DataQualityCheck{}, Violations: violations}
+	for _, check := range checks {
+		if filters.Check == nil || check.Name == *filters.Check {
+			report.Checks = append(report.Checks, check)
+		}
+	}
+	if report.Violations == nil {
+		report.Violations = []*model.DataQualityViolation{}
+	}
+	jsonResponse(w, r, report)
+}
@@ -0,0 +1,92 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetDataQuality(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetDataQualityChecks(gomock.Any()).Return([]*model.DataQualityCheck{
+		{Name: "allocations_without_finish", Description: "unfinished", CheckedAt: util.ToPtr(int64(1719835200))},
+		{Name: "unknown_nodes", Description: "unknown", Violations: 1, CheckedAt: util.ToPtr(int64(1719835200))},
+	}, nil)
+	repo.EXPECT().GetDataQualityViolations(gomock.Any(), repository.DataQualityFilters{
+		Check:  util.ToPtr("unknown_nodes"),
+		Offset: util.ToPtr(10),
+		Limit:  util.ToPtr(5),
+	}).Return([]*model.DataQualityViolation{{
+		ID:            "7d3b8a4e-5c1f-4f0e-9a7b-2c6d8e9f0a1b",
+		Check:         "unknown_nodes",
+		Partition:     util.ToPtr("default"),
+		QueueName:     util.ToPtr("root.a"),
+		ApplicationID: util.ToPtr("app1"),
+		NodeID:        util.ToPtr("node-2"),
+		Detail:        "allocation alloc-1 on unknown node node-2",
+		DetectedAt:    1719835200,
+	}}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/admin/data-quality?check=unknown_nodes&offset=10&limit=5", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"checks": [
+			{"name": "unknown_nodes", "description": "unknown", "violations": 1, "checkedAt": 1719835200}
+		],
+		"violations": [{
+			"id": "7d3b8a4e-5c1f-4f0e-9a7b-2c6d8e9f0a1b",
+			"check": "unknown_nodes",
+			"partition": "default",
+			"queueName": "root.a",
+			"applicationId": "app1",
+			"nodeId": "node-2",
+			"detail": "allocation alloc-1 on unknown node node-2",
+			"detectedAt": 1719835200
+		}]
+	}`, rec.Body.String())
+}
+
+func TestWebServiceGetDataQualityNotChecked(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetDataQualityChecks(gomock.Any()).Return([]*model.DataQualityCheck{
+		{Name: "unknown_nodes", Description: "unknown"},
+	}, nil)
+	repo.EXPECT().GetDataQualityViolations(gomock.Any(), repository.DataQualityFilters{
+		Limit: util.ToPtr(config.DefaultPageSize.DefaultLimit),
+	}).Return(nil, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/admin/data-quality", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"checks": [{"name": "unknown_nodes", "description": "unknown", "violations": 0, "checkedAt": null}],
+		"violations": []
+	}`, rec.Body.String())
+}
+
+func TestWebServiceGetDataQualityUnknownCheck(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/admin/data-quality?check=orphans", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,57 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// userErasureRequest is the request body for erasing a user.
+type userErasureRequest struct {
+	User string `json:"user"`
+	// Mode is either pseudonymize (default) or delete.
+	Mode string `json:"mode"`
+}
+
+// eraseUser pseudonymizes or deletes the records attributable to a user and responds with the erasure report.
+// Erasing a user whose erasure was interrupted resumes the interrupted erasure. Erasures must be made by an
+// authenticated API client, which is recorded in the report.
+func (ws *WebService) eraseUser(w http.ResponseWriter, r *http.Request) {
+	principal, ok := principalFromContext(r.Context())
+	if !ok {
+		problemResponse(w, r, http.StatusForbidden, errors.New("user erasures must be made by an authenticated API client"))
+		return
+	}
+
+	var req userErasureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if req.User == "" {
+		badRequestResponse(w, r, errors.New("user is required"))
+		return
+	}
+	switch req.Mode {
+	case "":
+		req.Mode = model.ErasureModePseudonymize
+	case model.ErasureModePseudonymize, model.ErasureModeDelete:
+	default:
+		badRequestResponse(w, r, fmt.Errorf("invalid mode %q: must be %s or %s",
+			req.Mode, model.ErasureModePseudonymize, model.ErasureModeDelete))
+		return
+	}
+
+	// the erased user is deliberately not logged
+	log.FromContext(r.Context()).Infow("erasing user", "mode", req.Mode)
+	erasure, err := ws.eraser.Erase(r.Context(), req.User, req.Mode, principal)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, erasure)
+}
@@ -0,0 +1,90 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestWebServiceEraseUser(t *testing.T) {
+	tests := map[string]struct {
+		body            string
+		unauthenticated bool
+		wantMode        string
+		wantStatus      int
+	}{
+		"default mode": {
+			body:       `{"user": "alice"}`,
+			wantMode:   model.ErasureModePseudonymize,
+			wantStatus: http.StatusOK,
+		},
+		"unauthenticated": {
+			body:            `{"user": "alice"}`,
+			unauthenticated: true,
+			wantStatus:      http.StatusForbidden,
+		},
+		"delete": {
+			body:       `{"user": "alice", "mode": "delete"}`,
+			wantMode:   model.ErasureModeDelete,
+			wantStatus: http.StatusOK,
+		},
+		"missing user": {
+			body:       `{"mode": "delete"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"invalid mode": {
+			body:       `{"user": "alice", "mode": "shred"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			repo := repository.NewMockRepository(mockCtrl)
+			if tt.wantMode != "" {
+				repo.EXPECT().StartUserErasure(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, erasure *model.UserErasure) error {
+						assert.Equal(t, tt.wantMode, erasure.Mode)
+						assert.Equal(t, "ops", erasure.RequestedBy)
+						erasure.ID = "erasure-1"
+						return nil
+					})
+				repo.EXPECT().EraseUserApplications(gomock.Any(), "alice", gomock.Any(), tt.wantMode, gomock.Any()).
+					Return(int64(0), nil)
+				repo.EXPECT().CountUserApplications(gomock.Any(), "alice").Return(int64(1), nil)
+				repo.EXPECT().PseudonymizeAuditRecords(gomock.Any(), "alice", gomock.Any()).Return(int64(0), nil)
+				repo.EXPECT().UpdateUserErasure(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+
+			req := httptest.NewRequest(http.MethodPost, routeEraseUser, strings.NewReader(tt.body))
+			if !tt.unauthenticated {
+				req = req.WithContext(context.WithValue(req.Context(), principalKey{}, "ops"))
+			}
+			rec := httptest.NewRecorder()
+			ws.eraseUser(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var erasure model.UserErasure
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &erasure))
+				assert.Equal(t, "erasure-1", erasure.ID)
+				assert.Equal(t, model.ErasureStatusCompleted, erasure.Status)
+				assert.Equal(t, int64(1), erasure.ApplicationsRetained)
+				assert.NotContains(t, rec.Body.String(), "alice")
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package webservice
+
+import (
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn/model"
+)
+
+// WithEventCategories sets the categories the event types of the event statistics are grouped into.
+// If not set, the default categories are used.
+func WithEventCategories(categories []config.EventCategory) Option {
+	return func(ws *WebService) {
+		ws.eventCategories = categories
+	}
+}
+
+// getEventCategories returns the number of events per event category, in the order the categories are configured.
+// An event type is counted in the first category matching it, and the event types which are not in any category are
+// counted in the other category, which is returned last if there are any.
+func (ws *WebService) getEventCategories(w http.ResponseWriter, r *http.Request) {
+	counts, err := ws.eventRepository.Counts(r.Context())
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, categorizeEvents(counts, ws.eventCategories))
+}
+
+// categorizeEvents groups the counts of the event types into the categories.
+func categorizeEvents(counts model.EventTypeCounts, categories []config.EventCategory) []*model.EventCategoryCount {
+	result := make([]*model.EventCategoryCount, len(categories))
+	for i, category := range categories {
+		result[i] = &model.EventCategoryCount{Name: category.Name, Events: model.EventTypeCounts{}}
+	}
+	other := &model.EventCategoryCount{Name: config.OtherEventCategory, Events: model.EventTypeCounts{}}
+	for eventType, count := range counts {
+		categorized := other
+		for i, category := range categories {
+			if category.Matches(eventType) {
+				categorized = result[i]
+				break
+			}
+		}
+		categorized.Count += count
+		categorized.Events[eventType] = count
+	}
+	if len(other.Events) > 0 {
+		result = append(result, other)
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn/model"
+)
+
+func TestWebServiceGetEventCategories(t *testing.T) {
+	ctx := context.Background()
+	events := repository.NewInMemoryEventRepository()
+	for _, event := range []*si.EventRecord{
+		{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_ADD},
+		{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_SET},
+		{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_SET},
+		{Type: si.EventRecord_REQUEST, EventChangeType: si.EventRecord_ADD},
+		{Type: si.EventRecord_NODE, EventChangeType: si.EventRecord_ADD},
+	} {
+		require.NoError(t, events.Record(ctx, event))
+	}
+
+	tests := map[string]struct {
+		categories []config.EventCategory
+		want       []*model.EventCategoryCount
+	}{
+		"default categories": {
+			want: []*model.EventCategoryCount{
+				{Name: "app lifecycle", Count: 3, Events: model.EventTypeCounts{"APP-ADD": 1, "APP-SET": 2}},
+				{Name: "scheduling", Count: 1, Events: model.EventTypeCounts{"REQUEST-ADD": 1}},
+				{Name: "node", Count: 1, Events: model.EventTypeCounts{"NODE-ADD": 1}},
+				{Name: "queue", Events: model.EventTypeCounts{}},
+				{Name: "users and groups", Events: model.EventTypeCounts{}},
+			},
+		},
+		"configured categories": {
+			categories: []config.EventCategory{
+				{Name: "scheduling", Events: []string{"REQUEST-*", "APP-SET"}},
+				{Name: "apps", Events: []string{"APP-*"}},
+			},
+			want: []*model.EventCategoryCount{
+				{Name: "scheduling", Count: 3, Events: model.EventTypeCounts{"REQUEST-ADD": 1, "APP-SET": 2}},
+				{Name: "apps", Count: 1, Events: model.EventTypeCounts{"APP-ADD": 1}},
+				{Name: config.OtherEventCategory, Count: 1, Events: model.EventTypeCounts{"NODE-ADD": 1}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, events, nil, WithEventCategories(tt.categories))
+			ws.init(ctx)
+
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, routeEventCategories, nil))
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			var categories []*model.EventCategoryCount
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &categories))
+			assert.Equal(t, tt.want, categories)
+		})
+	}
+}
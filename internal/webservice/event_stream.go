@@ -0,0 +1,110 @@
+package webservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/broadcast"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// eventStreamKeepAlive is the interval at which a comment is sent to idle streams, below the idle timeouts of common
+// proxies.
+const eventStreamKeepAlive = 15 * time.Second
+
+// eventStreamDropped is the data of the dropped events of the event stream.
+type eventStreamDropped struct {
+	Dropped int `json:"dropped"`
+}
+
+// WithEventStream enables the live event stream, which pushes the events of Yunikorn published to the hub as they
+// are ingested. The route is not registered if hub is nil.
+func WithEventStream(hub *broadcast.Hub) Option {
+	return func(ws *WebService) {
+		ws.eventStream = hub
+	}
+}
+
+// streamEvents pushes the events of the applications, queues and nodes of Yunikorn as Server-Sent Events while they
+// are ingested, so that clients are notified of the state changes without polling. Each event is sent as a message
+// whose data is the event. The events are buffered for each client: if a client does not keep up, the events
+// overflowing its buffer are dropped, and a dropped event tells the client how many, so that it reloads the data.
+// Following query params are supported:
+// - partition: only the events of this partition, and those whose partition is unknown, such as events of nodes
+// - queue: only the events of this queue and of its child queues, and of their applications
+func (ws *WebService) streamEvents(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	var filter broadcast.Filter
+	if partition := q.String(queryParamPartition); partition != nil {
+		filter.Partition = *partition
+	}
+	if queue := q.String(queryParamQueue); queue != nil {
+		filter.Queue = *queue
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	subscription := ws.eventStream.Subscribe(filter)
+	defer subscription.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// proxies such as nginx must not buffer the events
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		log.FromContext(r.Context()).Errorf("could not flush event stream: %v", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-subscription.Events():
+			if dropped := subscription.TakeDropped(); dropped > 0 {
+				err = writeServerSentEvent(w, r, "dropped", eventStreamDropped{Dropped: dropped})
+			}
+			if err == nil {
+				err = writeServerSentEvent(w, r, "", event)
+			}
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			// the client is gone
+			return
+		}
+	}
+}
+
+// writeServerSentEvent writes the data as the JSON data of a Server-Sent Event of the type, or of a message if the
+// type is empty.
+func writeServerSentEvent(w http.ResponseWriter, r *http.Request, eventType string, data any) error {
+	rendered, err := renderFields(r, data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(rendered)
+	if err != nil {
+		return err
+	}
+	if eventType != "" {
+		if _, err := fmt.Fprintf(w, "event: %s\n", eventType); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", payload)
+	return err
+}
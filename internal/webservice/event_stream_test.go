@@ -0,0 +1,90 @@
+package webservice
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/broadcast"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestWebServiceStreamEvents(t *testing.T) {
+	hub := broadcast.NewHub(broadcast.DefaultBufferSize)
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{string(featureflag.EventStream): true}})
+	require.NoError(t, err)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithEventStream(hub), WithFeatureFlags(featureFlags))
+	ws.init(context.Background())
+	server := httptest.NewServer(ws.server.Handler)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/ws/v1/events/stream?queue=root.batch", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// the stream is subscribed once the headers are sent, and the event of another queue is filtered out
+	hub.Publish(&model.StreamEvent{Type: "APP", ObjectID: "app-1", QueueName: "root.default", Timestamp: 1})
+	hub.Publish(&model.StreamEvent{Type: "APP", ObjectID: "app-2", QueueName: "root.batch", Timestamp: 2})
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if line == "\n" {
+				return strings.Join(lines, "")
+			}
+			lines = append(lines, line)
+		}
+	}
+	assert.Equal(t,
+		"data: {\"type\":\"APP\",\"objectId\":\"app-2\",\"changeType\":\"\",\"changeDetail\":\"\",\"timestamp\":2,\"queueName\":\"root.batch\"}\n",
+		readEvent())
+
+	hub.Publish(&model.StreamEvent{Type: "QUEUE", ObjectID: "root.batch.etl", QueueName: "root.batch.etl", Timestamp: 3})
+	assert.Contains(t, readEvent(), `"objectId":"root.batch.etl"`)
+}
+
+func TestWriteServerSentEvent(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ws/v1/events/stream", nil)
+	require.NoError(t, writeServerSentEvent(rec, req, "dropped", eventStreamDropped{Dropped: 3}))
+	require.NoError(t, writeServerSentEvent(rec, req, "", &model.StreamEvent{Type: "NODE", ObjectID: "node-1"}))
+	assert.Equal(t,
+		"event: dropped\ndata: {\"dropped\":3}\n\n"+
+			"data: {\"type\":\"NODE\",\"objectId\":\"node-1\",\"changeType\":\"\",\"changeDetail\":\"\",\"timestamp\":0}\n\n",
+		rec.Body.String())
+}
+
+func TestWebServiceEventStreamNotRegisteredWithoutHub(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+	for _, r := range ws.routes {
+		assert.NotEqual(t, routeEventStream, r.path)
+	}
+}
+
+func TestWebServiceEventStreamDisabled(t *testing.T) {
+	hub := broadcast.NewHub(broadcast.DefaultBufferSize)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithEventStream(hub))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/events/stream", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
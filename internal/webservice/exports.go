@@ -0,0 +1,73 @@
+package webservice
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/export"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// WithExports enables the routes creating and downloading exports, which are written to the store. The routes are
+// not registered if the store is nil.
+func WithExports(store *export.Store) Option {
+	return func(ws *WebService) {
+		ws.exports = store
+	}
+}
+
+// createApplicationsExport writes the applications of the queue matching the filters of the request to a new export,
+// and returns the export with its checksum. Unlike streamed responses, the content of the export never changes, so
+// that its download can be resumed.
+func (ws *WebService) createApplicationsExport(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	queue := params.ByName(paramsQueueName)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	pageSize := ws.pageSizes.Applications
+	pageSize.DefaultLimit = 0
+	filters := parseApplicationFilters(q, loc, pageSize)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	created, err := ws.exports.Create(r.Context(), func(ew *export.Writer) error {
+		return ws.repository.StreamAppsPerPartitionPerQueue(r.Context(), partition, queue, filters,
+			func(app *model.ApplicationDAOInfo) error {
+				ws.prepareApplication(r, app, loc)
+				row, err := renderFields(r, app)
+				if err != nil {
+					return err
+				}
+				return ew.Write(row)
+			})
+	})
+	if err != nil {
+		errorResponse(w, r, fmt.Errorf("could not create export: %w", err))
+		return
+	}
+	log.FromContext(r.Context()).Infow("export created", "export", created.ID, "partition", partition, "queue", queue,
+		"rows", created.Rows, "size", created.Size)
+	w.Header().Set("Location", strings.Replace(routeExport, ":"+paramsExportID, created.ID, 1))
+	createdResponse(w, r, created)
+}
+
+// getExport downloads the export, either from the history server with support for range requests, or from
+// the object storage with a redirect to a presigned URL.
+func (ws *WebService) getExport(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id := params.ByName(paramsExportID)
+	if err := ws.exports.Serve(w, r, id); err != nil {
+		if errors.Is(err, export.ErrNotFound) {
+			notFoundResponse(w, r, fmt.Errorf("%w: %s", export.ErrNotFound, id))
+			return
+		}
+		errorResponse(w, r, err)
+	}
+}
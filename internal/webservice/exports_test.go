@@ -0,0 +1,101 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/export"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func newTestExports(t *testing.T) *export.Store {
+	t.Helper()
+	store, err := export.NewStore(context.Background(), &config.ExportsConfig{ObjectStorageConfig: config.ObjectStorageConfig{URL: "file://" + t.TempDir()}, URLExpiry: time.Minute})
+	require.NoError(t, err)
+	return store
+}
+
+func TestExportRoutes(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithExports(newTestExports(t)))
+	ws.init(context.Background())
+	serve := func(method, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	repo.EXPECT().
+		StreamAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _, _ string, filters repository.ApplicationFilters, fn func(*model.ApplicationDAOInfo) error,
+		) error {
+			assert.Equal(t, "alice", *filters.User)
+			assert.Nil(t, filters.Limit)
+			for _, id := range []string{"app-1", "app-2"} {
+				if err := fn(&model.ApplicationDAOInfo{ApplicationDAOInfo: dao.ApplicationDAOInfo{ApplicationID: id}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	rec := serve(http.MethodPost, "/ws/v1/partition/default/queue/root.default/applications/export?user=alice", nil)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created export.Export
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, 2, created.Rows)
+	assert.Len(t, created.SHA256, 64)
+	assert.Equal(t, "/ws/v1/exports/"+created.ID, rec.Header().Get("Location"))
+
+	// exports are downloaded, and their downloads are resumed with range requests
+	rec = serve(http.MethodGet, "/ws/v1/exports/"+created.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, created.Size, int64(rec.Body.Len()))
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Get(headerCache))
+	full := rec.Body.String()
+	rec = serve(http.MethodGet, "/ws/v1/exports/"+created.ID, http.Header{"Range": {"bytes=10-"}})
+	require.Equal(t, http.StatusPartialContent, rec.Code, rec.Body.String())
+	assert.Equal(t, full[10:], rec.Body.String())
+
+	rec = serve(http.MethodGet, "/ws/v1/exports/"+uuid.NewString(), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	// exports are not created if the applications cannot be read
+	repo.EXPECT().
+		StreamAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default", gomock.Any(), gomock.Any()).
+		Return(errors.New("connection refused"))
+	rec = serve(http.MethodPost, "/ws/v1/partition/default/queue/root.default/applications/export", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "could not create export: connection refused")
+
+	rec = serve(http.MethodPost, "/ws/v1/partition/default/queue/root.default/applications/export?submissionStartTime=x", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+func TestExportRoutesDisabled(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost,
+		"/ws/v1/partition/default/queue/root.default/applications/export", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
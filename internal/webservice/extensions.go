@@ -0,0 +1,78 @@
+package webservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+// ExtensionRoutePrefix prefixes the paths of the routes added by extensions, so that they cannot conflict with the
+// routes of the API.
+const ExtensionRoutePrefix = "/ws/v1/ext/"
+
+// Route is a route of the REST API added by an extension, e.g. a company-specific endpoint.
+type Route struct {
+	Method string
+	// Path is the path of the route, which starts with ExtensionRoutePrefix and may have parameters in the syntax
+	// of httprouter, e.g. /ws/v1/ext/teams/:team.
+	Path string
+	// Handle serves the requests of the route, reading and writing the data of the server through the repository.
+	Handle func(w http.ResponseWriter, r *http.Request, p httprouter.Params, repo repository.Repository)
+}
+
+// WithRoutes adds the routes of extensions to the REST API. Their requests are authenticated and limited like those
+// of the routes of the API: the queries of GET and HEAD routes are limited by the statement timeout of interactive
+// queries and their responses are cached, while the other routes are rejected in read-only mode. The routes must be
+// valid according to ValidateRoutes.
+func WithRoutes(routes ...Route) Option {
+	return func(ws *WebService) {
+		ws.extensionRoutes = append(ws.extensionRoutes, routes...)
+	}
+}
+
+// ValidateRoutes returns an error if a route of an extension has no method or handle, if its path does not start
+// with ExtensionRoutePrefix, or if routes have the same method and path.
+func ValidateRoutes(routes []Route) error {
+	var errorMessages []string
+	seen := make(map[route]bool, len(routes))
+	for i, r := range routes {
+		switch {
+		case r.Method == "":
+			errorMessages = append(errorMessages, fmt.Sprintf("route %d: method is required", i))
+		case !strings.HasPrefix(r.Path, ExtensionRoutePrefix):
+			errorMessages = append(errorMessages, fmt.Sprintf("route %d: path %q must start with %s", i, r.Path,
+				ExtensionRoutePrefix))
+		case seen[route{method: r.Method, path: r.Path}]:
+			errorMessages = append(errorMessages, fmt.Sprintf("route %d: duplicate route %s %s", i, r.Method, r.Path))
+		}
+		seen[route{method: r.Method, path: r.Path}] = true
+		if r.Handle == nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("route %d: handle is required", i))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("extension route validation errors: %v", errorMessages)
+	}
+	return nil
+}
+
+// registerExtensionRoutes registers the routes of the extensions with the router.
+func (ws *WebService) registerExtensionRoutes(ctx context.Context, router *httprouter.Router) {
+	for _, r := range ws.extensionRoutes {
+		handle := r.Handle
+		h := func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			handle(w, r, p, ws.repository)
+		}
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			ws.handle(router, r.Method, r.Path, h)
+		} else {
+			ws.handleWrite(router, r.Method, r.Path, h)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestWebServiceExtensionRoutes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default"}}, nil)
+
+	routes := []Route{
+		{
+			Method: http.MethodGet,
+			Path:   "/ws/v1/ext/teams/:team/partitions",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params, repo repository.Repository) {
+				partitions, err := repo.GetAllPartitions(r.Context())
+				if err != nil {
+					errorResponse(w, r, err)
+					return
+				}
+				jsonResponse(w, r, map[string]any{"team": p.ByName("team"), "partitions": len(partitions)})
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/ws/v1/ext/teams",
+			Handle: func(w http.ResponseWriter, r *http.Request, _ httprouter.Params, _ repository.Repository) {
+				w.WriteHeader(http.StatusCreated)
+			},
+		},
+	}
+	require.NoError(t, ValidateRoutes(routes))
+
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithRoutes(routes...))
+	ws.init(context.Background())
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/ext/teams/ml/partitions", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"team": "ml", "partitions": 1}`, rec.Body.String())
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/ext/teams", nil))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// the routes which write are rejected in read-only mode
+	ws = NewWebService(&config.YHSConfig{Port: 8080, ReadOnly: true}, repo, nil, nil, WithRoutes(routes...))
+	ws.init(context.Background())
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/ext/teams", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// the routes are authenticated like the routes of the API
+	ws = NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithRoutes(routes...), WithAPIKeys(map[string]string{"ci": "ci-secret"}))
+	ws.init(context.Background())
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/ext/teams", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestValidateRoutes(t *testing.T) {
+	handle := func(http.ResponseWriter, *http.Request, httprouter.Params, repository.Repository) {}
+	tests := map[string]struct {
+		routes  []Route
+		wantErr string
+	}{
+		"valid": {
+			routes: []Route{
+				{Method: http.MethodGet, Path: "/ws/v1/ext/teams", Handle: handle},
+				{Method: http.MethodPost, Path: "/ws/v1/ext/teams", Handle: handle},
+			},
+		},
+		"missing method": {
+			routes:  []Route{{Path: "/ws/v1/ext/teams", Handle: handle}},
+			wantErr: "method is required",
+		},
+		"path outside the extension prefix": {
+			routes:  []Route{{Method: http.MethodGet, Path: "/ws/v1/partitions", Handle: handle}},
+			wantErr: "must start with /ws/v1/ext/",
+		},
+		"duplicate route": {
+			routes: []Route{
+				{Method: http.MethodGet, Path: "/ws/v1/ext/teams", Handle: handle},
+				{Method: http.MethodGet, Path: "/ws/v1/ext/teams", Handle: handle},
+			},
+			wantErr: "duplicate route GET /ws/v1/ext/teams",
+		},
+		"missing handle": {
+			routes:  []Route{{Method: http.MethodGet, Path: "/ws/v1/ext/teams"}},
+			wantErr: "handle is required",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateRoutes(tc.routes)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
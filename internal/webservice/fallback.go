@@ -0,0 +1,151 @@
+package webservice
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/health"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+const (
+	// headerStale is set on stale responses served while the database is unavailable, to the time the response
+	// was produced.
+	headerStale = "X-Stale"
+	// databaseCheckTimeout is how long checking whether the database is available may take.
+	databaseCheckTimeout = 2 * time.Second
+)
+
+// fallbackRoutes lists the API routes shown by the UI, whose last successful responses are served while
+// the database is unavailable.
+var fallbackRoutes = map[string]bool{
+	routePartitions:               true,
+	routeQueuesPerPartition:       true,
+	routeNamespaceQueues:          true,
+	routeAppsPerPartitionPerQueue: true,
+	routeAppSummaries:             true,
+	routeApplication:              true,
+	routeNodesPerPartition:        true,
+	routeNodeUtilization:          true,
+	routeAppsHistory:              true,
+	routeContainersHistory:        true,
+}
+
+// WithFallback keeps the last successful responses of the routes shown by the UI, and serves them instead of
+// errors while the database component is unhealthy, e.g. during a failover. If not set, errors are returned.
+func WithFallback(stale *cache.Stale, database health.Component) Option {
+	return func(ws *WebService) {
+		ws.stale = stale
+		ws.database = database
+	}
+}
+
+// fallback wraps the handle of a route so that its last successful response is kept, and served when the handle
+// fails while the database is unavailable. Stale responses have the X-Stale header set to the time they were
+// produced, and the Age header set to their age in seconds. Streamed newline delimited JSON and MessagePack
+// responses are neither kept nor served.
+func (ws *WebService) fallback(handle httprouter.Handle) httprouter.Handle {
+	if ws.stale == nil {
+		return handle
+	}
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if acceptsNDJSON(r) {
+			handle(w, r, p)
+			return
+		}
+		key := responseKey(r)
+		recorder := &fallbackRecorder{ResponseWriter: w}
+		handle(recorder, r, p)
+		if !recorder.failed {
+			if recorder.keep() {
+				ws.stale.Store(r.Context(), key, encodeCachedResponse(recorder.Header(), recorder.body.Bytes()))
+			}
+			return
+		}
+		if body, produced, ok := ws.stale.Get(key); ok && !ws.databaseAvailable(r, recorder.status) {
+			log.FromContext(r.Context()).Warnw("serving stale response, as the database is unavailable",
+				"path", r.URL.Path, "produced", produced)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(headerStale, produced.UTC().Format(time.RFC3339))
+			w.Header().Set("Age", strconv.Itoa(int(time.Since(produced).Seconds())))
+			w.Header().Set(headerCache, "STALE")
+			w.Header().Del("Retry-After")
+			body = decodeCachedResponse(w.Header(), body)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+			return
+		}
+		w.WriteHeader(recorder.status)
+		_, _ = w.Write(recorder.body.Bytes())
+	}
+}
+
+// databaseAvailable reports whether the database is available after the request failed with the status. Requests
+// rejected by the circuit breaker of the repository fail with 503 Service Unavailable, so that the database is not
+// checked while the circuit is open.
+func (ws *WebService) databaseAvailable(r *http.Request, status int) bool {
+	if status == http.StatusServiceUnavailable {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), databaseCheckTimeout)
+	defer cancel()
+	return ws.database.Check(ctx).Healthy
+}
+
+// fallbackRecorder writes successful responses and records their body, unless it is too large to be kept.
+// Server errors are held back, so that a stale response can be served instead.
+type fallbackRecorder struct {
+	http.ResponseWriter
+	status   int
+	failed   bool
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *fallbackRecorder) WriteHeader(status int) {
+	r.status = status
+	if status >= http.StatusInternalServerError {
+		r.failed = true
+		return
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *fallbackRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	if r.failed {
+		return r.body.Write(b)
+	}
+	if !r.overflow {
+		if r.body.Len()+len(b) > maxCachedResponseSize {
+			r.overflow = true
+			r.body = bytes.Buffer{}
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// keep reports whether the recorded response may be served while the database is unavailable.
+func (r *fallbackRecorder) keep() bool {
+	if r.status != http.StatusOK || r.overflow {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header().Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// Unwrap returns the wrapped response writer, so that http.ResponseController can flush it.
+func (r *fallbackRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,135 @@
+package webservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/breaker"
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/health"
+)
+
+type fakeDatabase struct {
+	healthy bool
+}
+
+func (d *fakeDatabase) Identifier() string {
+	return "postgres"
+}
+
+func (d *fakeDatabase) Check(context.Context) *health.ComponentStatus {
+	return &health.ComponentStatus{Identifier: d.Identifier(), Healthy: d.healthy}
+}
+
+func TestWebServiceFallback(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	database := &fakeDatabase{healthy: true}
+	backend := cache.NewMemoryBackend(100)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithFallback(cache.NewStale(100, time.Hour), database),
+		WithCache(cache.NewWithBackend(backend, time.Minute, time.Second)))
+	ws.init(ctx)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// errors are returned until a response was kept
+	database.healthy = false
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, errors.New("connection refused"))
+	rec := get(routePartitions)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "connection refused")
+
+	database.healthy = true
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default", TotalNodes: 2}}, nil)
+	rec = get(routePartitions)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(headerStale))
+	want := rec.Body.String()
+	require.NoError(t, backend.Invalidate(ctx))
+
+	// errors are returned while the database is available
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, errors.New("syntax error"))
+	rec = get(routePartitions)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// the kept response is served while the database is unavailable, and is not cached
+	database.healthy = false
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, errors.New("connection refused")).Times(2)
+	for range 2 {
+		rec = get(routePartitions)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, want, rec.Body.String())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "STALE", rec.Header().Get(headerCache))
+		assert.NotEmpty(t, rec.Header().Get(headerStale))
+		assert.Equal(t, "0", rec.Header().Get("Age"))
+	}
+
+	// routes not shown by the UI return errors
+	repo.EXPECT().GetClusters(gomock.Any()).Return(nil, errors.New("connection refused"))
+	rec = get(routeClusters)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestWebServiceHistoryStoreUnavailable(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	database := &fakeDatabase{healthy: true}
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithFallback(cache.NewStale(100, time.Hour), database))
+	ws.init(ctx)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	unavailable := &breaker.UnavailableError{RetryAfter: 1500 * time.Millisecond}
+
+	// operations rejected by the circuit breaker fail with 503 Service Unavailable
+	repo.EXPECT().GetClusters(gomock.Any()).Return(nil, unavailable)
+	rec := get(routeClusters)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "history store unavailable")
+
+	// stale responses are served without checking the database while the circuit is open
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default"}}, nil)
+	rec = get(routePartitions)
+	require.Equal(t, http.StatusOK, rec.Code)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, unavailable)
+	rec = get(routePartitions)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "STALE", rec.Header().Get(headerCache))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+}
+
+func TestWebServiceWithoutFallback(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default"}}, nil)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, errors.New("connection refused"))
+	for _, want := range []int{http.StatusOK, http.StatusInternalServerError} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, routePartitions, nil))
+		assert.Equal(t, want, rec.Code)
+	}
+}
@@ -0,0 +1,43 @@
+package webservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+)
+
+// WithFaultInjector enables the admin API configuring the injected faults, which is only set by builds with
+// the faultinject build tag. The API is not registered if the injector is nil.
+func WithFaultInjector(injector *faultinject.Injector) Option {
+	return func(ws *WebService) {
+		ws.faults = injector
+	}
+}
+
+func (ws *WebService) getFaults(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.faults.Faults())
+}
+
+// setFaults replaces the injected faults with the faults of the request body.
+func (ws *WebService) setFaults(w http.ResponseWriter, r *http.Request) {
+	var faults faultinject.Faults
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&faults); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if err := ws.faults.SetFaults(faults); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, ws.faults.Faults())
+}
+
+// resetFaults stops injecting faults.
+func (ws *WebService) resetFaults(w http.ResponseWriter, r *http.Request) {
+	ws.faults.Reset()
+	jsonResponse(w, r, ws.faults.Faults())
+}
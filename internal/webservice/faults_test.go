@@ -0,0 +1,62 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+)
+
+func TestFaultsRoutes(t *testing.T) {
+	injector := faultinject.New()
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithFaultInjector(injector))
+	ws.init(context.Background())
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, routeFaults, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+	decode := func(rec *httptest.ResponseRecorder) faultinject.Faults {
+		var faults faultinject.Faults
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &faults))
+		return faults
+	}
+
+	rec := serve(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, faultinject.Faults{}, decode(rec))
+
+	rec = serve(http.MethodPut, `{"dbErrorRate": 0.5, "queryDelayMillis": 100, "dropEventRate": 0.1}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	want := faultinject.Faults{DBErrorRate: 0.5, QueryDelayMillis: 100, DropEventRate: 0.1}
+	assert.Equal(t, want, decode(rec))
+	assert.Equal(t, want, injector.Faults())
+
+	rec = serve(http.MethodPut, `{"dbErrorRate": 2}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(http.MethodPut, `{"dbErrorRatio": 0.5}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, want, injector.Faults())
+
+	rec = serve(http.MethodDelete, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, faultinject.Faults{}, injector.Faults())
+}
+
+func TestFaultsRoutesNotRegisteredWithoutInjector(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+	for _, r := range ws.routes {
+		assert.NotEqual(t, routeFaults, r.path)
+	}
+}
@@ -0,0 +1,85 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+)
+
+// featureFlagOverride is the request body for overriding a feature flag at runtime.
+type featureFlagOverride struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// requireFeature wraps the handler so that it responds with 404 Not Found when the given feature flag is disabled.
+// Flags are evaluated on every request, so runtime overrides take effect immediately.
+func (ws *WebService) requireFeature(flag featureflag.Flag, handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if !ws.featureFlags.Enabled(flag) {
+			notFoundResponse(w, r, fmt.Errorf("feature %q is disabled", flag))
+			return
+		}
+		handle(w, r, p)
+	}
+}
+
+func (ws *WebService) getFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.featureFlags.List())
+}
+
+// overrideFeatureFlag sets the runtime state of a feature flag.
+// The request body must be a JSON object with the "enabled" field set.
+func (ws *WebService) overrideFeatureFlag(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	flag := featureflag.Flag(params.ByName(paramsFeatureName))
+
+	var override featureFlagOverride
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if override.Enabled == nil {
+		badRequestResponse(w, r, errors.New("enabled is required"))
+		return
+	}
+
+	if err := ws.featureFlags.Override(flag, *override.Enabled); err != nil {
+		featureFlagErrorResponse(w, r, err)
+		return
+	}
+	ws.featureFlagStatus(w, r, flag)
+}
+
+// resetFeatureFlag removes the runtime override of a feature flag, so the configured state applies again.
+func (ws *WebService) resetFeatureFlag(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	flag := featureflag.Flag(params.ByName(paramsFeatureName))
+	if err := ws.featureFlags.ResetOverride(flag); err != nil {
+		featureFlagErrorResponse(w, r, err)
+		return
+	}
+	ws.featureFlagStatus(w, r, flag)
+}
+
+func (ws *WebService) featureFlagStatus(w http.ResponseWriter, r *http.Request, flag featureflag.Flag) {
+	status, err := ws.featureFlags.Status(flag)
+	if err != nil {
+		featureFlagErrorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, status)
+}
+
+func featureFlagErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, featureflag.ErrUnknownFlag):
+		notFoundResponse(w, r, err)
+	case errors.Is(err, featureflag.ErrOverridesDisabled):
+		problemResponse(w, r, http.StatusForbidden, err)
+	default:
+		errorResponse(w, r, err)
+	}
+}
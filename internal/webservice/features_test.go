@@ -0,0 +1,99 @@
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+)
+
+func TestWebServiceRequireFeature(t *testing.T) {
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{AdminOverrides: true})
+	require.NoError(t, err)
+	ws := &WebService{featureFlags: featureFlags}
+
+	handle := ws.requireFeature(featureflag.Analytics, func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	handle(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/analytics", nil), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	require.NoError(t, featureFlags.Override(featureflag.Analytics, true))
+
+	rec = httptest.NewRecorder()
+	handle(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/analytics", nil), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestWebServiceOverrideFeatureFlag(t *testing.T) {
+	tests := map[string]struct {
+		adminOverrides bool
+		feature        string
+		body           string
+		wantStatus     int
+		wantEnabled    bool
+	}{
+		"enable feature": {
+			adminOverrides: true,
+			feature:        "analytics",
+			body:           `{"enabled": true}`,
+			wantStatus:     http.StatusOK,
+			wantEnabled:    true,
+		},
+		"missing enabled": {
+			adminOverrides: true,
+			feature:        "analytics",
+			body:           `{}`,
+			wantStatus:     http.StatusBadRequest,
+		},
+		"invalid body": {
+			adminOverrides: true,
+			feature:        "analytics",
+			body:           `enabled`,
+			wantStatus:     http.StatusBadRequest,
+		},
+		"unknown feature": {
+			adminOverrides: true,
+			feature:        "teleport",
+			body:           `{"enabled": true}`,
+			wantStatus:     http.StatusNotFound,
+		},
+		"overrides disabled": {
+			adminOverrides: false,
+			feature:        "analytics",
+			body:           `{"enabled": true}`,
+			wantStatus:     http.StatusForbidden,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{AdminOverrides: tc.adminOverrides})
+			require.NoError(t, err)
+			ws := &WebService{featureFlags: featureFlags}
+
+			req := httptest.NewRequest(http.MethodPut, "/ws/v1/admin/features/"+tc.feature, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			ws.overrideFeatureFlag(rec, req, httprouter.Params{{Key: paramsFeatureName, Value: tc.feature}})
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var status featureflag.Status
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+			assert.Equal(t, tc.wantEnabled, status.Enabled)
+			assert.Equal(t, tc.wantEnabled, featureFlags.Enabled(featureflag.Flag(tc.feature)))
+		})
+	}
+}
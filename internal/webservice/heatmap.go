@@ -0,0 +1,117 @@
+package webservice
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// defaultHeatmapResource is the resource whose utilization is returned by heatmaps by default.
+	defaultHeatmapResource = "vcore"
+	// heatmapBuckets is the number of utilization buckets of heatmaps, each 10% wide.
+	heatmapBuckets = 10
+)
+
+// heatmapBucketNames are the names of the utilization buckets of heatmaps, named like those of the node
+// utilizations of Yunikorn.
+var heatmapBucketNames = func() []string {
+	names := make([]string, heatmapBuckets)
+	for i := range names {
+		names[i] = fmt.Sprintf("%d-%d%%", i*100/heatmapBuckets, (i+1)*100/heatmapBuckets)
+	}
+	return names
+}()
+
+// getUtilizationHeatmap returns the number of nodes of a partition per position in a dimension of the topology and
+// per utilization bucket of a resource, to compare the utilization of zones or racks. Rows are sorted by position,
+// followed by the nodes whose position is unknown in the ungrouped row if any.
+// Following query params are supported:
+// - by: the dimension of the topology of the rows, zone or rack, zone by default
+// - resource: the resource whose utilization is bucketed, vcore by default
+func (ws *WebService) getUtilizationHeatmap(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	q := newQueryParams(r)
+	by := topologyDimension(q)
+	if by == "" {
+		by = model.TopologyZone
+	}
+	resource := defaultHeatmapResource
+	if res := q.String(queryParamResource); res != nil {
+		resource = *res
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	nodes, err := ws.repository.GetNodesPerPartition(r.Context(), partition)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	positions, err := ws.nodePositions(r, partition, by)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+
+	type row struct {
+		*model.UtilizationHeatmapRow
+		allocated, capacity int64
+	}
+	rows := make(map[string]*row)
+	for _, node := range nodes {
+		capacity := node.Capacity[resource]
+		if capacity <= 0 {
+			continue
+		}
+		name, ok := positions[node.NodeID]
+		if !ok {
+			name = config.UngroupedNodeGroup
+		}
+		rw, ok := rows[name]
+		if !ok {
+			rw = &row{UtilizationHeatmapRow: &model.UtilizationHeatmapRow{Name: name, Counts: make([]int, heatmapBuckets)}}
+			rows[name] = rw
+		}
+		allocated := node.Allocated[resource]
+		rw.Nodes++
+		rw.Counts[utilizationBucket(allocated, capacity)]++
+		rw.allocated += allocated
+		rw.capacity += capacity
+	}
+
+	heatmap := &model.UtilizationHeatmap{
+		Resource: resource,
+		By:       by,
+		Buckets:  heatmapBucketNames,
+		Rows:     make([]*model.UtilizationHeatmapRow, 0, len(rows)),
+	}
+	for _, rw := range rows {
+		rw.Utilization = float64(rw.allocated) / float64(rw.capacity)
+		heatmap.Rows = append(heatmap.Rows, rw.UtilizationHeatmapRow)
+	}
+	slices.SortFunc(heatmap.Rows, func(a, b *model.UtilizationHeatmapRow) int {
+		if (a.Name == config.UngroupedNodeGroup) != (b.Name == config.UngroupedNodeGroup) {
+			if a.Name == config.UngroupedNodeGroup {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	jsonResponse(w, r, heatmap)
+}
+
+// utilizationBucket returns the bucket of the utilization of the allocated of a capacity, the last bucket
+// including full and over-allocated capacities.
+func utilizationBucket(allocated, capacity int64) int {
+	bucket := int(allocated * heatmapBuckets / capacity)
+	return max(0, min(bucket, heatmapBuckets-1))
+}
@@ -0,0 +1,78 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetUtilizationHeatmap(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().GetNodesPerPartition(gomock.Any(), "default").Return([]*dao.NodeDAOInfo{
+		{NodeID: "node-1", Capacity: map[string]int64{"vcore": 4000}, Allocated: map[string]int64{"vcore": 4000}},
+		{NodeID: "node-2", Capacity: map[string]int64{"vcore": 4000}, Allocated: map[string]int64{"vcore": 1000}},
+		{NodeID: "node-3", Capacity: map[string]int64{"vcore": 4000}},
+		{NodeID: "node-4", Capacity: map[string]int64{"vcore": 2000}, Allocated: map[string]int64{"vcore": 1100}},
+		{NodeID: "node-5", Capacity: map[string]int64{"memory": 1000}},
+	}, nil).Times(2)
+	repo.EXPECT().GetNodeTopologies(gomock.Any(), "default").Return([]*model.NodeTopology{
+		{NodeID: "node-1", Partition: "default", Zone: util.ToPtr("b"), Rack: util.ToPtr("b-1")},
+		{NodeID: "node-2", Partition: "default", Zone: util.ToPtr("a"), Rack: util.ToPtr("a-1")},
+		{NodeID: "node-3", Partition: "default", Zone: util.ToPtr("b"), Rack: util.ToPtr("b-1")},
+		{NodeID: "node-4", Partition: "default"},
+		{NodeID: "node-5", Partition: "default", Zone: util.ToPtr("c")},
+	}, nil).Times(2)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+	get := func(path string) (*model.UtilizationHeatmap, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var heatmap *model.UtilizationHeatmap
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &heatmap))
+		}
+		return heatmap, rec
+	}
+
+	heatmap, rec := get("/ws/v1/partition/default/utilization-heatmap")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "vcore", heatmap.Resource)
+	assert.Equal(t, model.TopologyZone, heatmap.By)
+	assert.Equal(t, heatmapBucketNames, heatmap.Buckets)
+	assert.Equal(t, []*model.UtilizationHeatmapRow{
+		{Name: "a", Nodes: 1, Counts: []int{0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, Utilization: 0.25},
+		{Name: "b", Nodes: 2, Counts: []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1}, Utilization: 0.5},
+		{Name: config.UngroupedNodeGroup, Nodes: 1, Counts: []int{0, 0, 0, 0, 0, 1, 0, 0, 0, 0}, Utilization: 0.55},
+	}, heatmap.Rows)
+
+	heatmap, rec = get("/ws/v1/partition/default/utilization-heatmap?by=rack&resource=memory")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []*model.UtilizationHeatmapRow{
+		{Name: config.UngroupedNodeGroup, Nodes: 1, Counts: []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Utilization: 0},
+	}, heatmap.Rows)
+
+	_, rec = get("/ws/v1/partition/default/utilization-heatmap?by=host")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUtilizationBucket(t *testing.T) {
+	assert.Equal(t, "0-10%", heatmapBucketNames[0])
+	assert.Equal(t, "90-100%", heatmapBucketNames[9])
+	assert.Equal(t, 0, utilizationBucket(0, 100))
+	assert.Equal(t, 0, utilizationBucket(9, 100))
+	assert.Equal(t, 1, utilizationBucket(10, 100))
+	assert.Equal(t, 9, utilizationBucket(100, 100))
+	assert.Equal(t, 9, utilizationBucket(150, 100))
+}
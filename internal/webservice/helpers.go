@@ -2,11 +2,18 @@ package webservice
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
 	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
 )
 
@@ -16,112 +23,414 @@ const (
 	queryParamGroups              = "groups"
 	queryParamLimit               = "limit"
 	queryParamOffset              = "offset"
+	queryParamCursor              = "cursor"
 	queryParamUser                = "user"
+	queryParamTimezone            = "tz"
+	queryParamPartition           = "partition"
+	queryParamActive              = "active"
+	queryParamStartTime           = "startTime"
+	queryParamEndTime             = "endTime"
+	queryParamSince               = "since"
+	queryParamTimeout             = "timeout"
+	queryParamTopics              = "topics"
+	queryParamQueue               = "queue"
+	queryParamInterval            = "interval"
+	queryParamMetric              = "metric"
+	queryParamBy                  = "by"
+	queryParamFrom                = "from"
+	queryParamTo                  = "to"
+	queryParamK                   = "k"
+	queryParamCheck               = "check"
+	queryParamStrategy            = "strategy"
+	queryParamNamespace           = "namespace"
+	queryParamBefore              = "before"
+	queryParamDryRun              = "dryRun"
+	queryParamResource            = "resource"
+	queryParamWeeks               = "weeks"
+	queryParamSnapshot            = "snapshot"
+	queryParamMinDuration         = "minDuration"
+	queryParamMaxDuration         = "maxDuration"
+	queryParamMinWaitTime         = "minWaitTime"
+	queryParamMaxWaitTime         = "maxWaitTime"
+	queryParamOrderBy             = "orderBy"
+	queryParamLineage             = "lineage"
+	queryParamPrefix              = "prefix"
+	queryParamCluster             = "cluster"
+	queryParamDuplicates          = "duplicates"
 )
 
-func parseApplicationFilters(r *http.Request) (*repository.ApplicationFilters, error) {
-	filters := repository.ApplicationFilters{}
-	user := getUserQueryParam(r)
-	if user != "" {
-		filters.User = &user
+const (
+	// maxOffset is the maximum offset of a page.
+	maxOffset = math.MaxInt32
+	// maxQueryParamLength is the maximum length in bytes of a string query parameter or list item.
+	maxQueryParamLength = 256
+	// maxQueryParamItems is the maximum number of items of a comma-separated list query parameter.
+	maxQueryParamItems = 100
+	// maxDurationFilter is the maximum of the duration and wait time filters of applications.
+	maxDurationFilter time.Duration = math.MaxInt64
+)
+
+var (
+	// minTime and maxTime bound time filters to the range of Unix nanoseconds, in which Yunikorn timestamps are stored.
+	minTime = time.Unix(0, 0)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// localTimeLayouts are the layouts of timestamps without offset, which are interpreted in the requested timezone.
+var localTimeLayouts = []string{"2006-01-02T15:04:05.999999999", "2006-01-02T15:04", "2006-01-02"}
+
+// queryParams parses and validates the query parameters of a request. Empty parameters are treated as absent.
+// Invalid parameters are collected, so that all of them are reported at once by Err.
+type queryParams struct {
+	values  url.Values
+	invalid []InvalidParam
+}
+
+func newQueryParams(r *http.Request) *queryParams {
+	return &queryParams{values: r.URL.Query()}
+}
+
+// Err returns an *invalidParamsError listing the invalid parameters, if any.
+func (q *queryParams) Err() error {
+	if len(q.invalid) == 0 {
+		return nil
 	}
-	submissionStartTime, err := getSubmissionStartTimeQueryParam(r)
-	if err != nil {
-		return nil, err
+	return &invalidParamsError{params: q.invalid}
+}
+
+func (q *queryParams) invalidate(name, format string, args ...any) {
+	q.invalid = append(q.invalid, InvalidParam{Name: name, Reason: fmt.Sprintf(format, args...)})
+}
+
+// get returns the value of the parameter. Repeated parameters are rejected, as it is ambiguous which one applies.
+func (q *queryParams) get(name string) (string, bool) {
+	values := q.values[name]
+	if len(values) > 1 {
+		q.invalidate(name, "must not be repeated")
+		return "", false
+	}
+	if len(values) == 0 || values[0] == "" {
+		return "", false
 	}
-	if submissionStartTime != nil {
-		filters.SubmissionStartTime = submissionStartTime
+	return values[0], true
+}
+
+// String returns the value of a string parameter, which must be valid UTF-8 without control characters.
+func (q *queryParams) String(name string) *string {
+	value, ok := q.get(name)
+	if !ok {
+		return nil
 	}
-	submissionEndTime, err := getSubmissionEndTimeQueryParam(r)
+	if reason := validateString(value); reason != "" {
+		q.invalidate(name, "%s", reason)
+		return nil
+	}
+	return &value
+}
+
+// List returns the items of a comma-separated list parameter, ignoring empty items.
+func (q *queryParams) List(name string) []string {
+	value, ok := q.get(name)
+	if !ok {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if reason := validateString(item); reason != "" {
+			q.invalidate(name, "item %s", reason)
+			return nil
+		}
+		items = append(items, item)
+	}
+	if len(items) > maxQueryParamItems {
+		q.invalidate(name, "must not have more than %d items", maxQueryParamItems)
+		return nil
+	}
+	return items
+}
+
+// Bool returns the value of a boolean parameter.
+func (q *queryParams) Bool(name string) *bool {
+	value, ok := q.get(name)
+	if !ok {
+		return nil
+	}
+	b, err := strconv.ParseBool(value)
 	if err != nil {
-		return nil, err
+		q.invalidate(name, "must be true or false")
+		return nil
 	}
-	if submissionEndTime != nil {
-		filters.SubmissionEndTime = submissionEndTime
+	return &b
+}
+
+// Int returns the value of an integer parameter between minValue and maxValue.
+func (q *queryParams) Int(name string, minValue, maxValue int) *int {
+	value, ok := q.get(name)
+	if !ok {
+		return nil
 	}
-	offset, err := getOffsetQueryParam(r)
+	i, err := strconv.Atoi(value)
 	if err != nil {
-		return nil, err
+		q.invalidate(name, "must be an integer")
+		return nil
 	}
-	if offset != nil {
-		filters.Offset = offset
+	if i < minValue || i > maxValue {
+		q.invalidate(name, "must be between %d and %d", minValue, maxValue)
+		return nil
+	}
+	return &i
+}
+
+// Duration returns the value of a duration parameter, e.g. 30s, between 0 and maxValue.
+func (q *queryParams) Duration(name string, maxValue time.Duration) *time.Duration {
+	value, ok := q.get(name)
+	if !ok {
+		return nil
 	}
-	limit, err := getLimitQueryParam(r)
+	d, err := time.ParseDuration(value)
 	if err != nil {
-		return nil, err
+		q.invalidate(name, "must be a duration, e.g. 30s")
+		return nil
+	}
+	if d < 0 || d > maxValue {
+		q.invalidate(name, "must be between 0s and %s", maxValue)
+		return nil
 	}
-	if limit != nil {
-		filters.Limit = limit
+	return &d
+}
+
+// Limit returns the limit parameter of a paginated endpoint, or the default limit of the page size if it is absent.
+// Larger results than the max limit must be paginated or streamed.
+func (q *queryParams) Limit(pageSize config.PageSizeConfig) *int {
+	limit := q.Int(queryParamLimit, 0, pageSize.MaxLimit)
+	if limit == nil && q.values.Get(queryParamLimit) == "" && pageSize.DefaultLimit > 0 {
+		return &pageSize.DefaultLimit
 	}
-	groups := getGroupsQueryParam(r)
-	if len(groups) > 0 {
-		filters.Groups = groups
+	return limit
+}
+
+// pageSizeOrDefault returns config.DefaultPageSize if the page size is not configured.
+func pageSizeOrDefault(pageSize config.PageSizeConfig) config.PageSizeConfig {
+	if pageSize == (config.PageSizeConfig{}) {
+		return config.DefaultPageSize
 	}
-	return &filters, nil
+	return pageSize
 }
 
-func getUserQueryParam(r *http.Request) string {
-	return r.URL.Query().Get(queryParamUser)
+// streamPageSize returns the page size of a streamed response, which has no default limit,
+// as streaming is how results larger than a page are read.
+func streamPageSize(r *http.Request, pageSize config.PageSizeConfig) config.PageSizeConfig {
+	if acceptsNDJSON(r) {
+		pageSize.DefaultLimit = 0
+	}
+	return pageSize
 }
 
-func getGroupsQueryParam(r *http.Request) []string {
-	groups := r.URL.Query().Get(queryParamGroups)
-	groupsSlice := strings.Split(groups, ",")
-	return groupsSlice
+// Cluster returns the cluster parameter, the ID of the cluster the results are scoped to, see package cluster.
+func (q *queryParams) Cluster() *string {
+	value, ok := q.get(queryParamCluster)
+	if !ok {
+		return nil
+	}
+	if !cluster.ValidID(value) {
+		q.invalidate(queryParamCluster, "must be a lowercase DNS label")
+		return nil
+	}
+	return &value
 }
 
-func getOffsetQueryParam(r *http.Request) (*int, error) {
-	offsetStr := r.URL.Query().Get(queryParamOffset)
-	if offsetStr == "" {
-		return nil, nil
+// DuplicateStrategy returns the value of a parameter naming the strategy which selects the canonical run of the
+// applications in several clusters, or empty if it is absent.
+func (q *queryParams) DuplicateStrategy(name string) repository.DuplicateStrategy {
+	value, ok := q.get(name)
+	if !ok {
+		return ""
+	}
+	strategy := repository.DuplicateStrategy(value)
+	if !slices.Contains(repository.DuplicateStrategies, strategy) {
+		q.invalidate(name, "must be one of %v", repository.DuplicateStrategies)
+		return ""
 	}
+	return strategy
+}
 
-	return toInt(offsetStr)
+// Offset returns the offset parameter of a paginated endpoint.
+func (q *queryParams) Offset() *int {
+	return q.Int(queryParamOffset, 0, maxOffset)
 }
 
-func getLimitQueryParam(r *http.Request) (*int, error) {
-	limitStr := r.URL.Query().Get(queryParamLimit)
-	if limitStr == "" {
-		return nil, nil
+// Cursor returns the cursor parameter of a keyset paginated endpoint, the cursor of the next page of the previous
+// page, which replaces the offset.
+func (q *queryParams) Cursor() *repository.Cursor {
+	value, ok := q.get(queryParamCursor)
+	if !ok {
+		return nil
 	}
+	if q.values.Get(queryParamOffset) != "" {
+		q.invalidate(queryParamCursor, "must not be combined with %s", queryParamOffset)
+		return nil
+	}
+	cursor, err := repository.ParseCursor(value)
+	if err != nil {
+		q.invalidate(queryParamCursor, "must be the cursor of the next page of a previous page")
+		return nil
+	}
+	return cursor
+}
 
-	return toInt(limitStr)
+// Page returns the cursor and limit parameters of a keyset paginated endpoint without offset, where the limit
+// defaults to the default limit of the page size like Limit.
+func (q *queryParams) Page(pageSize config.PageSizeConfig) repository.Page {
+	return repository.Page{After: q.Cursor(), Limit: q.Limit(pageSize)}
 }
 
-func toInt(numberString string) (*int, error) {
-	offset, err := strconv.Atoi(numberString)
+// Timezone returns the location of the IANA timezone in the tz parameter, UTC by default.
+// It is used to interpret timestamps without offset and to render the timestamps of the response.
+func (q *queryParams) Timezone() *time.Location {
+	tz, ok := q.get(queryParamTimezone)
+	if !ok {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
 	if err != nil {
-		return nil, fmt.Errorf("invalid 'offset' query parameter: %v", err)
+		q.invalidate(queryParamTimezone, "unknown timezone %q", tz)
+		return time.UTC
 	}
+	return loc
+}
 
-	return &offset, nil
+// Time returns the value of a time parameter in one of the formats accepted by parseTime.
+func (q *queryParams) Time(name string, loc *time.Location, now time.Time) *time.Time {
+	value, ok := q.get(name)
+	if !ok {
+		return nil
+	}
+	t, err := parseTime(value, loc, now)
+	if err != nil {
+		q.invalidate(name, "%v", err)
+		return nil
+	}
+	if t.Before(minTime) || t.After(maxTime) {
+		q.invalidate(name, "must be between %s and %s", minTime.UTC().Format(time.RFC3339), maxTime.UTC().Format(time.RFC3339))
+		return nil
+	}
+	return &t
 }
 
-func getSubmissionStartTimeQueryParam(r *http.Request) (*time.Time, error) {
-	startStr := r.URL.Query().Get(queryParamSubmissionStartTime)
-	if startStr == "" {
+// TimeRange returns the values of the time parameters bounding a range, the start must not be after the end.
+func (q *queryParams) TimeRange(startName, endName string, loc *time.Location, now time.Time) (*time.Time, *time.Time) {
+	start := q.Time(startName, loc, now)
+	end := q.Time(endName, loc, now)
+	if start != nil && end != nil && start.After(*end) {
+		q.invalidate(endName, "must not be before %s", startName)
 		return nil, nil
 	}
+	return start, end
+}
 
-	return toTime(startStr)
+// validateString returns the reason why the value is not a valid string parameter, or an empty string.
+// Control characters are rejected, as Postgres rejects NUL characters in text values.
+func validateString(value string) string {
+	if len(value) > maxQueryParamLength {
+		return fmt.Sprintf("must not be longer than %d bytes", maxQueryParamLength)
+	}
+	if !utf8.ValidString(value) {
+		return "must be valid UTF-8"
+	}
+	if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		return "must not contain control characters"
+	}
+	return ""
 }
 
-func getSubmissionEndTimeQueryParam(r *http.Request) (*time.Time, error) {
-	endStr := r.URL.Query().Get(queryParamSubmissionEndTime)
-	if endStr == "" {
-		return nil, nil
+// parseApplicationFilters parses the application filters of the query.
+// Timestamps without offset are interpreted in the given location.
+func parseApplicationFilters(q *queryParams, loc *time.Location, pageSize config.PageSizeConfig) repository.ApplicationFilters {
+	filters := repository.ApplicationFilters{
+		User:   q.String(queryParamUser),
+		Groups: q.List(queryParamGroups),
+		Offset: q.Offset(),
+		Limit:  q.Limit(pageSize),
+	}
+	filters.SubmissionStartTime, filters.SubmissionEndTime = q.TimeRange(
+		queryParamSubmissionStartTime, queryParamSubmissionEndTime, loc, time.Now(),
+	)
+	filters.MinDuration = q.Duration(queryParamMinDuration, maxDurationFilter)
+	filters.MaxDuration = q.Duration(queryParamMaxDuration, maxDurationFilter)
+	filters.MinWaitTime = q.Duration(queryParamMinWaitTime, maxDurationFilter)
+	filters.MaxWaitTime = q.Duration(queryParamMaxWaitTime, maxDurationFilter)
+	if orderBy := q.String(queryParamOrderBy); orderBy != nil {
+		filters.OrderBy = repository.ApplicationOrder(*orderBy)
+		if !slices.Contains(repository.ApplicationOrders, filters.OrderBy) {
+			q.invalidate(queryParamOrderBy, "must be one of %v", repository.ApplicationOrders)
+		}
 	}
+	return filters
+}
 
-	return toTime(endStr)
+// parseTime parses a time filter, which is one of:
+//   - a Unix timestamp in seconds, milliseconds, microseconds or nanoseconds, told apart by their magnitude
+//   - a duration before now, e.g. 24h or 7d
+//   - an RFC3339 timestamp, e.g. 2024-07-01T12:00:00+02:00
+//   - a date or a date and time without offset, e.g. 2024-07-01 or 2024-07-01T12:00:00, in the given location
+func parseTime(value string, loc *time.Location, now time.Time) (time.Time, error) {
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return unixTime(ts), nil
+	}
+	if d, err := parseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range localTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a Unix timestamp, a duration nor an RFC3339 timestamp", value)
 }
 
-func toTime(millisString string) (*time.Time, error) {
-	startMillis, err := strconv.ParseInt(millisString, 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("invalid 'start' query parameter: %v", err)
+// unixTime converts a Unix timestamp to a time. Timestamps below 10^11 are seconds (until the year 5138),
+// below 10^14 milliseconds, below 10^17 microseconds and nanoseconds otherwise.
+func unixTime(ts int64) time.Time {
+	abs := ts
+	if abs < 0 {
+		abs = -abs
 	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(ts, 0)
+	case abs < 1e14:
+		return time.UnixMilli(ts)
+	case abs < 1e17:
+		return time.UnixMicro(ts)
+	default:
+		return time.Unix(0, ts)
+	}
+}
 
-	// Convert milliseconds since epoch to a time.Time object
-	startTime := time.UnixMilli(startMillis)
-	return &startTime, nil
+// maxDays is the maximum number of days of a duration, as durations are limited to about 292 years.
+const maxDays = math.MaxInt64 / int64(24*time.Hour)
+
+// parseDuration parses a Go duration, or a number of days with the d suffix.
+func parseDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseInt(days, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if n > maxDays || n < -maxDays {
+			return 0, fmt.Errorf("duration %q out of range", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
 }
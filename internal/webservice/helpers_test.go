@@ -1,62 +1,145 @@
 package webservice
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
 	"github.com/G-Research/yunikorn-history-server/internal/util"
 )
 
-func TestGetUserQueryParam(t *testing.T) {
+func newTestQueryParams(t *testing.T, query string) *queryParams {
+	req, err := http.NewRequest("GET", "/?"+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newQueryParams(req)
+}
+
+func TestQueryParamsString(t *testing.T) {
 	tests := []struct {
 		name   string
 		query  string
-		result string
+		result *string
+		hasErr bool
 	}{
-		{"No user param", "", ""},
-		{"With user param", "user=john", "john"},
+		{"No user param", "", nil, false},
+		{"Empty user param", "user=", nil, false},
+		{"With user param", "user=john", util.ToPtr("john"), false},
+		{"Unicode user param", "user=j%C3%B6rg", util.ToPtr("jörg"), false},
+		{"Repeated user param", "user=john&user=jane", nil, true},
+		{"NUL character", "user=jo%00hn", nil, true},
+		{"Invalid UTF-8", "user=%ff", nil, true},
+		{"Too long", "user=" + strings.Repeat("a", maxQueryParamLength+1), nil, true},
 	}
 
 	for _, tt := range tests {
-		req, err := http.NewRequest("GET", "/?"+tt.query, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		result := getUserQueryParam(req)
-		if result != tt.result {
-			t.Errorf("expected %v, got %v", tt.result, result)
-		}
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			result := q.String(queryParamUser)
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("expected %v, got %v", tt.result, result)
+			}
+		})
 	}
 }
 
-func TestGetGroupsQueryParam(t *testing.T) {
+func TestQueryParamsList(t *testing.T) {
 	tests := []struct {
 		name   string
 		query  string
 		result []string
+		hasErr bool
 	}{
-		{"No groups param", "", []string{""}},
-		{"Single group", "groups=admin", []string{"admin"}},
-		{"Multiple groups", "groups=admin,user,guest", []string{"admin", "user", "guest"}},
+		{"No groups param", "", nil, false},
+		{"Single group", "groups=admin", []string{"admin"}, false},
+		{"Multiple groups", "groups=admin,user,guest", []string{"admin", "user", "guest"}, false},
+		{"Empty items", "groups=admin,, user ,", []string{"admin", "user"}, false},
+		{"Control character", "groups=admin,us%0Aer", nil, true},
+		{"Too many groups", "groups=" + strings.Repeat("g,", maxQueryParamItems+1), nil, true},
 	}
 
 	for _, tt := range tests {
-		req, err := http.NewRequest("GET", "/?"+tt.query, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		result := getGroupsQueryParam(req)
-		for i, group := range result {
-			if group != tt.result[i] {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			result := q.List(queryParamGroups)
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
 				t.Errorf("expected %v, got %v", tt.result, result)
-				break
 			}
-		}
+		})
 	}
 }
 
-func TestGetOffsetQueryParam(t *testing.T) {
+func TestQueryParamsBool(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		result *bool
+		hasErr bool
+	}{
+		{"No active param", "", nil, false},
+		{"Active", "active=true", util.ToPtr(true), false},
+		{"Released", "active=false", util.ToPtr(false), false},
+		{"Invalid active", "active=maybe", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			result := q.Bool(queryParamActive)
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("expected %v, got %v", tt.result, result)
+			}
+		})
+	}
+}
+
+func TestQueryParamsDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		result *time.Duration
+		hasErr bool
+	}{
+		{"No timeout param", "", nil, false},
+		{"Valid timeout", "timeout=30s", util.ToPtr(30 * time.Second), false},
+		{"Zero timeout", "timeout=0s", util.ToPtr(time.Duration(0)), false},
+		{"Invalid timeout", "timeout=30", nil, true},
+		{"Negative timeout", "timeout=-1s", nil, true},
+		{"Too long timeout", "timeout=2m", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			result := q.Duration(queryParamTimeout, time.Minute)
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("expected %v, got %v", tt.result, result)
+			}
+		})
+	}
+}
+
+func TestQueryParamsOffset(t *testing.T) {
 	tests := []struct {
 		name   string
 		query  string
@@ -66,103 +149,249 @@ func TestGetOffsetQueryParam(t *testing.T) {
 		{"No offset param", "", nil, false},
 		{"Valid offset", "offset=5", util.ToPtr(5), false},
 		{"Invalid offset", "offset=abc", nil, true},
+		{"Negative offset", "offset=-1", nil, true},
+		{"Overflowing offset", "offset=99999999999999999999", nil, true},
 	}
 
 	for _, tt := range tests {
-		req, err := http.NewRequest("GET", "/?"+tt.query, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		result, err := getOffsetQueryParam(req)
-		if (err != nil) != tt.hasErr {
-			t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
-		}
-		if result != nil && *result != *tt.result {
-			t.Errorf("expected %v, got %v", tt.result, result)
-		}
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			result := q.Offset()
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("expected %v, got %v", tt.result, result)
+			}
+		})
 	}
 }
 
-func TestGetLimitQueryParam(t *testing.T) {
+func TestQueryParamsLimit(t *testing.T) {
+	streamed := config.PageSizeConfig{MaxLimit: 10000}
+	configured := config.PageSizeConfig{DefaultLimit: 50, MaxLimit: 100}
+	tests := []struct {
+		name     string
+		query    string
+		pageSize config.PageSizeConfig
+		result   *int
+		hasErr   bool
+	}{
+		{"No limit param", "", streamed, nil, false},
+		{"Valid limit", "limit=10", streamed, util.ToPtr(10), false},
+		{"Invalid limit", "limit=xyz", streamed, nil, true},
+		{"Negative limit", "limit=-10", streamed, nil, true},
+		{"Too large limit", "limit=10001", streamed, nil, true},
+		{"Default limit", "", configured, util.ToPtr(50), false},
+		{"Empty limit uses the default limit", "limit=", configured, util.ToPtr(50), false},
+		{"Limit above the default limit", "limit=100", configured, util.ToPtr(100), false},
+		{"Limit above the configured max limit", "limit=101", configured, nil, true},
+		{"Invalid limit with default limit", "limit=xyz", configured, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			result := q.Limit(tt.pageSize)
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("expected %v, got %v", tt.result, result)
+			}
+		})
+	}
+}
+
+func TestStreamPageSize(t *testing.T) {
+	pageSize := config.PageSizeConfig{DefaultLimit: 50, MaxLimit: 100}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if got := streamPageSize(r, pageSize); got != pageSize {
+		t.Errorf("expected %v, got %v", pageSize, got)
+	}
+	r.Header.Set("Accept", ndjsonContentType)
+	if got := streamPageSize(r, pageSize); got != (config.PageSizeConfig{MaxLimit: 100}) {
+		t.Errorf("expected no default limit for streamed responses, got %v", got)
+	}
+}
+
+func TestQueryParamsTimeRange(t *testing.T) {
 	tests := []struct {
 		name   string
 		query  string
-		result *int
+		start  *time.Time
+		end    *time.Time
 		hasErr bool
 	}{
-		{"No limit param", "", nil, false},
-		{"Valid limit", "limit=10", util.ToPtr(10), false},
-		{"Invalid limit", "limit=xyz", nil, true},
+		{"No time params", "", nil, nil, false},
+		{"Valid start", "submissionStartTime=1625097600000", util.ToPtr(time.UnixMilli(1625097600000)), nil, false},
+		{"Invalid start", "submissionStartTime=invalid", nil, nil, true},
+		{"Valid end", "submissionEndTime=1625097600000", nil, util.ToPtr(time.UnixMilli(1625097600000)), false},
+		{"Invalid end", "submissionEndTime=invalid", nil, nil, true},
+		{
+			"Valid range", "submissionStartTime=2021-07-01&submissionEndTime=2021-07-02",
+			util.ToPtr(time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)), util.ToPtr(time.Date(2021, 7, 2, 0, 0, 0, 0, time.UTC)), false,
+		},
+		{"Reversed range", "submissionStartTime=2021-07-02&submissionEndTime=2021-07-01", nil, nil, true},
+		{"Before the epoch", "submissionStartTime=1969-12-31", nil, nil, true},
+		{"After the nanosecond range", "submissionEndTime=2300-01-01", nil, nil, true},
+		{"Overflowing duration", "submissionStartTime=9999999999999999d", nil, nil, true},
 	}
 
 	for _, tt := range tests {
-		req, err := http.NewRequest("GET", "/?"+tt.query, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		result, err := getLimitQueryParam(req)
-		if (err != nil) != tt.hasErr {
-			t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
-		}
-		if result != nil && *result != *tt.result {
-			t.Errorf("expected %v, got %v", tt.result, result)
-		}
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueryParams(t, tt.query)
+			start, end := q.TimeRange(queryParamSubmissionStartTime, queryParamSubmissionEndTime, time.UTC, time.Now())
+			if err := q.Err(); (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if (start == nil) != (tt.start == nil) || start != nil && !start.Equal(*tt.start) {
+				t.Errorf("expected start %v, got %v", tt.start, start)
+			}
+			if (end == nil) != (tt.end == nil) || end != nil && !end.Equal(*tt.end) {
+				t.Errorf("expected end %v, got %v", tt.end, end)
+			}
+		})
+	}
+}
+
+func TestQueryParamsErr(t *testing.T) {
+	q := newTestQueryParams(t, "limit=-1&offset=abc&tz=Mars/Olympus")
+	q.Timezone()
+	q.Offset()
+	q.Limit(config.DefaultPageSize)
+
+	var invalidParams *invalidParamsError
+	if !errors.As(q.Err(), &invalidParams) {
+		t.Fatalf("expected invalid params error, got %v", q.Err())
+	}
+	expected := []InvalidParam{
+		{Name: "tz", Reason: `unknown timezone "Mars/Olympus"`},
+		{Name: "offset", Reason: "must be an integer"},
+		{Name: "limit", Reason: "must be between 0 and 10000"},
+	}
+	if !reflect.DeepEqual(invalidParams.params, expected) {
+		t.Errorf("expected %v, got %v", expected, invalidParams.params)
 	}
 }
 
-func TestGetStartQueryParam(t *testing.T) {
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
 	tests := []struct {
 		name   string
-		query  string
-		result *time.Time
+		value  string
+		loc    *time.Location
+		result time.Time
 		hasErr bool
 	}{
-		{"No start param", "", nil, false},
-		{"Valid start", "submissionStartTime=1625097600000", util.ToPtr(time.UnixMilli(1625097600000)), false},
-		{"Invalid start", "submissionStartTime=invalid", nil, true},
+		{"Unix seconds", "1719835200", time.UTC, now, false},
+		{"Unix milliseconds", "1719835200000", time.UTC, now, false},
+		{"Unix microseconds", "1719835200000000", time.UTC, now, false},
+		{"Unix nanoseconds", "1719835200000000000", time.UTC, now, false},
+		{"Duration", "24h", time.UTC, now.Add(-24 * time.Hour), false},
+		{"Days", "7d", time.UTC, now.Add(-7 * 24 * time.Hour), false},
+		{"Negative duration", "-24h", time.UTC, time.Time{}, true},
+		{"RFC3339", "2024-07-01T14:00:00+02:00", berlin, now, false},
+		{"RFC3339 UTC", "2024-07-01T12:00:00Z", berlin, now, false},
+		{"Local time in UTC", "2024-07-01T12:00:00", time.UTC, now, false},
+		{"Local time in timezone", "2024-07-01T14:00:00", berlin, now, false},
+		{"Date in timezone", "2024-07-01", berlin, time.Date(2024, 6, 30, 22, 0, 0, 0, time.UTC), false},
+		{"Invalid", "yesterday", time.UTC, time.Time{}, true},
 	}
 
 	for _, tt := range tests {
-		req, err := http.NewRequest("GET", "/?"+tt.query, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		result, err := getSubmissionStartTimeQueryParam(req)
-		if (err != nil) != tt.hasErr {
-			t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
-		}
-		if result != nil && !result.Equal(*tt.result) {
-			t.Errorf("expected %v, got %v", tt.result, result)
-		}
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTime(tt.value, tt.loc, now)
+			if (err != nil) != tt.hasErr {
+				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
+			}
+			if !result.Equal(tt.result) {
+				t.Errorf("expected %v, got %v", tt.result, result)
+			}
+		})
 	}
 }
 
-func TestGetEndQueryParam(t *testing.T) {
+func TestQueryParamsTimezone(t *testing.T) {
 	tests := []struct {
 		name   string
 		query  string
-		result *time.Time
+		result string
 		hasErr bool
 	}{
-		{"No end param", "", nil, false},
-		{"Valid end", "submissionEndTime=1625097600000", util.ToPtr(time.UnixMilli(1625097600000)), false},
-		{"Invalid end", "submissionEndTime=invalid", nil, true},
+		{"No tz param", "", "UTC", false},
+		{"Valid tz", "tz=Europe/London", "Europe/London", false},
+		{"Invalid tz", "tz=Mars/Olympus", "UTC", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			req, err := http.NewRequest("GET", "/?"+tt.query, nil)
-			if err != nil {
-				t.Fatal(err)
-			}
-			result, err := getSubmissionEndTimeQueryParam(req)
-			if (err != nil) != tt.hasErr {
+			q := newTestQueryParams(t, tt.query)
+			result := q.Timezone()
+			if err := q.Err(); (err != nil) != tt.hasErr {
 				t.Errorf("expected error: %v, got: %v", tt.hasErr, err)
 			}
-			if result != nil && !result.Equal(*tt.result) {
+			if result.String() != tt.result {
 				t.Errorf("expected %v, got %v", tt.result, result)
 			}
 		})
 	}
 }
+
+func FuzzParseApplicationFilters(f *testing.F) {
+	f.Add("user=john&groups=admin,user&limit=10&offset=5")
+	f.Add("submissionStartTime=24h&submissionEndTime=2024-07-01T12:00:00&tz=Europe/Berlin")
+	f.Add("submissionStartTime=-9223372036854775808&limit=-1&offset=%00")
+	f.Add("groups=%ff,%00&user=" + strings.Repeat("a", 300))
+	f.Add("minDuration=1h&maxDuration=-1s&minWaitTime=5m&orderBy=waitTime")
+
+	f.Fuzz(func(t *testing.T, query string) {
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.URL.RawQuery = query
+		q := newQueryParams(req)
+		filters := parseApplicationFilters(q, q.Timezone(), config.DefaultPageSize)
+		if q.Err() != nil {
+			return
+		}
+		// valid filters must translate to valid SQL arguments
+		if filters.Limit != nil && (*filters.Limit < 0 || *filters.Limit > config.DefaultPageSize.MaxLimit) {
+			t.Errorf("limit %d out of range", *filters.Limit)
+		}
+		if filters.Offset != nil && (*filters.Offset < 0 || *filters.Offset > maxOffset) {
+			t.Errorf("offset %d out of range", *filters.Offset)
+		}
+		for _, ts := range []*time.Time{filters.SubmissionStartTime, filters.SubmissionEndTime} {
+			if ts != nil && (ts.Before(minTime) || ts.After(maxTime)) {
+				t.Errorf("time %v out of range", ts)
+			}
+		}
+		if filters.SubmissionStartTime != nil && filters.SubmissionEndTime != nil &&
+			filters.SubmissionStartTime.After(*filters.SubmissionEndTime) {
+			t.Errorf("start %v after end %v", filters.SubmissionStartTime, filters.SubmissionEndTime)
+		}
+		for _, d := range []*time.Duration{filters.MinDuration, filters.MaxDuration, filters.MinWaitTime, filters.MaxWaitTime} {
+			if d != nil && *d < 0 {
+				t.Errorf("duration %v is negative", d)
+			}
+		}
+		if filters.OrderBy != "" && !slices.Contains(repository.ApplicationOrders, filters.OrderBy) {
+			t.Errorf("unsupported order %q", filters.OrderBy)
+		}
+		values := filters.Groups
+		if filters.User != nil {
+			values = append(values, *filters.User)
+		}
+		for _, value := range values {
+			if reason := validateString(value); value == "" || reason != "" {
+				t.Errorf("invalid value %q: %s", value, reason)
+			}
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package webservice
+
+import (
+	"archive/zip"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// maxIncidentWindow bounds the window of an incident bundle, which holds all data of the window.
+	maxIncidentWindow = 30 * time.Minute
+
+	incidentManifestFile = "manifest.json"
+)
+
+// incidentManifest describes the window and the files of an incident bundle.
+type incidentManifest struct {
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Files is the number of records of every file of the bundle.
+	Files map[string]int `json:"files"`
+	// Current lists the files which hold the state at the time the bundle was generated instead of during the
+	// window, as their history is not stored.
+	Current []string `json:"current"`
+}
+
+// incidentFile is a file of an incident bundle, holding a JSON array of records.
+type incidentFile struct {
+	name    string
+	records any
+	count   int
+	current bool
+}
+
+// incidentAllocation is an allocation of an application of an incident bundle.
+type incidentAllocation struct {
+	QueueName string `json:"queueName"`
+	*dao.AllocationDAOInfo
+}
+
+// incidentEvent is an event of an application of an incident bundle.
+type incidentEvent struct {
+	ApplicationID string `json:"applicationId"`
+	*model.ApplicationEvent
+}
+
+// getIncidentBundle downloads a zip archive of everything known about a window of at most 30 minutes, e.g. around
+// an incident, so that it can be attached to a ticket and analyzed offline: the applications which were active
+// during the window with their allocations and events, the terminations of nodes, the events of the
+// cluster-autoscaler, the pending resources of the partitions and the epochs of the scheduler during the window,
+// and the current nodes, node utilizations and clusters. Every file holds a JSON array, and manifest.json tells the
+// window and the number of records of every file.
+// Following query params are supported:
+// - from: the start of the window, required
+// - to: the end of the window, at most 30 minutes after the start, 30 minutes after the start by default
+// - tz: timezone of the time params without offset and of the timestamps of the bundle, UTC by default
+func (ws *WebService) getIncidentBundle(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if from == nil {
+		if _, ok := q.get(queryParamFrom); !ok {
+			q.invalidate(queryParamFrom, "is required")
+		}
+	} else if to == nil {
+		end := from.Add(maxIncidentWindow)
+		to = &end
+	} else if to.Sub(*from) > maxIncidentWindow {
+		q.invalidate(queryParamTo, "must not be more than %s after %s", maxIncidentWindow, queryParamFrom)
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	files, err := ws.incidentFiles(r, *from, *to, loc)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	manifest := incidentManifest{
+		From:        from.In(loc),
+		To:          to.In(loc),
+		GeneratedAt: now.In(loc),
+		Files:       make(map[string]int, len(files)),
+		Current:     []string{},
+	}
+	for _, f := range files {
+		manifest.Files[f.name] = f.count
+		if f.current {
+			manifest.Current = append(manifest.Current, f.name)
+		}
+	}
+	files = append([]incidentFile{{name: incidentManifestFile, records: manifest}}, files...)
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="incident-%s.zip"`, from.UTC().Format("20060102T150405Z")))
+	if err := writeIncidentBundle(w, r, files); err != nil {
+		// the response was already started, so the error can only be logged
+		log.FromContext(r.Context()).Errorf("could not write incident bundle: %v", err)
+	}
+}
+
+// incidentFiles reads the data of the window and returns the files of the incident bundle other than the manifest.
+func (ws *WebService) incidentFiles(r *http.Request, from, to time.Time, loc *time.Location) ([]incidentFile, error) {
+	ctx := r.Context()
+	apps, err := ws.repository.GetAllApplications(ctx, repository.ApplicationFilters{
+		SubmissionEndTime: &to,
+		NotFinishedBefore: &from,
+	})
+	if err != nil {
+		return nil, err
+	}
+	allocations := []*incidentAllocation{}
+	events := []*incidentEvent{}
+	for _, app := range apps {
+		ws.prepareApplication(r, app, loc)
+		for _, alloc := range app.Allocations {
+			if alloc != nil && alloc.AllocationTime <= to.UnixNano() {
+				allocations = append(allocations, &incidentAllocation{QueueName: app.QueueName, AllocationDAOInfo: alloc})
+			}
+		}
+		for _, event := range normalizedApplicationEvents(app) {
+			if event.Timestamp >= from.UnixNano() && event.Timestamp <= to.UnixNano() {
+				events = append(events, &incidentEvent{ApplicationID: app.ApplicationID, ApplicationEvent: event})
+			}
+		}
+	}
+	if ws.traces {
+		traced := make(map[string]bool, len(apps))
+		for _, app := range apps {
+			if traced[app.ApplicationID] {
+				continue
+			}
+			traced[app.ApplicationID] = true
+			traceEvents, err := ws.repository.GetTraceEvents(ctx, app.ApplicationID,
+				repository.TraceEventFilters{Start: &from, End: &to})
+			if err != nil {
+				return nil, err
+			}
+			for _, event := range traceEvents {
+				events = append(events, &incidentEvent{ApplicationID: app.ApplicationID, ApplicationEvent: rawApplicationEvent(event)})
+			}
+		}
+	}
+	slices.SortStableFunc(events, func(a, b *incidentEvent) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	partitions, err := ws.repository.GetAllPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*model.NodeDAOInfo{}
+	backlog := []*model.PendingBacklogSample{}
+	for _, partition := range partitions {
+		partitionNodes, err := ws.repository.GetNodesPerPartition(ctx, partition.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range partitionNodes {
+			nodes = append(nodes, &model.NodeDAOInfo{Partition: partition.Name, NodeDAOInfo: *node})
+		}
+		samples, err := ws.repository.GetPendingBacklog(ctx, repository.PendingBacklogFilters{
+			Partition: partition.Name, Start: &from, End: &to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		backlog = append(backlog, samples...)
+	}
+	utilizations, err := ws.repository.GetNodeUtilizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	terminations, err := ws.repository.GetNodeTerminations(ctx, repository.NodeTerminationFilters{Start: &from, End: &to})
+	if err != nil {
+		return nil, err
+	}
+	autoscalerEvents, err := ws.repository.GetAutoscalerEvents(ctx, repository.AutoscalerEventFilters{Start: &from, End: &to})
+	if err != nil {
+		return nil, err
+	}
+	epochs, err := ws.repository.GetSchedulerEpochs(ctx, repository.SchedulerEpochFilters{Start: &from, End: &to})
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := ws.repository.GetClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	statuses := make([]clusterStatus, 0, len(clusters))
+	for _, cluster := range clusters {
+		statuses = append(statuses, newClusterStatus(cluster, now))
+	}
+
+	return []incidentFile{
+		newIncidentFile("applications.json", apps, false),
+		newIncidentFile("allocations.json", allocations, false),
+		newIncidentFile("events.json", events, false),
+		newIncidentFile("node-terminations.json", terminations, false),
+		newIncidentFile("autoscaler-events.json", autoscalerEvents, false),
+		newIncidentFile("pending-backlog.json", backlog, false),
+		newIncidentFile("scheduler-epochs.json", epochs, false),
+		newIncidentFile("nodes.json", nodes, true),
+		newIncidentFile("node-utilizations.json", utilizations, true),
+		newIncidentFile("clusters.json", statuses, true),
+	}, nil
+}
+
+// newIncidentFile returns the file of the records, which are written as an empty array if there are none.
+func newIncidentFile[T any](name string, records []T, current bool) incidentFile {
+	if records == nil {
+		records = []T{}
+	}
+	return incidentFile{name: name, records: records, count: len(records), current: current}
+}
+
+// writeIncidentBundle writes the files to a zip archive, with their fields named in the naming convention of the
+// request.
+func writeIncidentBundle(w http.ResponseWriter, r *http.Request, files []incidentFile) error {
+	archive := zip.NewWriter(w)
+	for _, f := range files {
+		data, err := renderFields(r, f.records)
+		if err != nil {
+			return err
+		}
+		fw, err := archive.Create(f.name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(fw)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("could not write %s: %w", f.name, err)
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,112 @@
+package webservice
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestWebServiceGetIncidentBundle(t *testing.T) {
+	from := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(maxIncidentWindow)
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetAllApplications(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filters repository.ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+			assert.Equal(t, to, *filters.SubmissionEndTime)
+			assert.Equal(t, from, *filters.NotFinishedBefore)
+			return []*model.ApplicationDAOInfo{{
+				ApplicationDAOInfo: dao.ApplicationDAOInfo{
+					ApplicationID:  "app-1",
+					QueueName:      "root.default",
+					SubmissionTime: from.Add(-time.Minute).UnixNano(),
+					State:          "Running",
+					Allocations: []*dao.AllocationDAOInfo{
+						{AllocationKey: "alloc-1", AllocationTime: from.Add(time.Minute).UnixNano()},
+						{AllocationKey: "alloc-2", AllocationTime: to.Add(time.Minute).UnixNano()},
+					},
+				},
+			}}, nil
+		})
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default"}}, nil)
+	repo.EXPECT().GetNodesPerPartition(gomock.Any(), "default").Return([]*dao.NodeDAOInfo{{NodeID: "node-1"}}, nil)
+	repo.EXPECT().GetPendingBacklog(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetNodeUtilizations(gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetNodeTerminations(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetAutoscalerEvents(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetSchedulerEpochs(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetClusters(gomock.Any()).Return(nil, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/reports/incident-bundle?from=2024-06-01T12:00:00Z", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="incident-20240601T120000Z.zip"`, rec.Header().Get("Content-Disposition"))
+
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = data
+	}
+	require.Contains(t, files, incidentManifestFile)
+	var manifest incidentManifest
+	require.NoError(t, json.Unmarshal(files[incidentManifestFile], &manifest))
+	assert.True(t, from.Equal(manifest.From))
+	assert.True(t, to.Equal(manifest.To))
+	assert.Equal(t, 1, manifest.Files["applications.json"])
+	assert.Equal(t, 1, manifest.Files["allocations.json"])
+	assert.Equal(t, 1, manifest.Files["nodes.json"])
+	assert.Equal(t, 0, manifest.Files["scheduler-epochs.json"])
+	assert.Equal(t, []string{"nodes.json", "node-utilizations.json", "clusters.json"}, manifest.Current)
+
+	var allocations []map[string]any
+	require.NoError(t, json.Unmarshal(files["allocations.json"], &allocations))
+	require.Len(t, allocations, 1, "allocations after the window are left out")
+	assert.Equal(t, "alloc-1", allocations[0]["allocationKey"])
+	assert.Equal(t, "root.default", allocations[0]["queueName"])
+	assert.JSONEq(t, `[]`, string(files["scheduler-epochs.json"]))
+	var nodes []map[string]any
+	require.NoError(t, json.Unmarshal(files["nodes.json"], &nodes))
+	require.Len(t, nodes, 1)
+	assert.Equal(t, "default", nodes[0]["partition"])
+	assert.Equal(t, "node-1", nodes[0]["nodeID"])
+}
+
+func TestWebServiceGetIncidentBundleInvalidWindow(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+	for _, query := range []string{
+		"",
+		"?from=2024-06-01T12:00:00Z&to=2024-06-01T13:00:00Z",
+		"?from=2024-06-01T12:00:00Z&to=2024-06-01T11:00:00Z",
+	} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/incident-bundle"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
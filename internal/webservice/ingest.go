@@ -0,0 +1,73 @@
+package webservice
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/ingest"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// maxIngestBodySize is the maximum size of a decompressed batch forwarded by a collector.
+const maxIngestBodySize = 64 << 20
+
+// ingestBatch applies a batch of data forwarded by a collector running next to Yunikorn, and acknowledges it.
+// Batches may be gzip compressed, and are rejected as a whole if any of their entries is invalid.
+// Batches which were already applied are acknowledged again without applying them. While the ingestion is paused,
+// batches are rejected with 503 Service Unavailable and collectors retry them later.
+func (ws *WebService) ingestBatch(w http.ResponseWriter, r *http.Request) {
+	if ws.ingestionPausedResponse(w, r) {
+		return
+	}
+	var body io.Reader = r.Body
+	switch encoding := r.Header.Get("Content-Encoding"); encoding {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			badRequestResponse(w, r, fmt.Errorf("could not decompress request body: %v", err))
+			return
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	default:
+		problemResponse(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content encoding %q", encoding))
+		return
+	}
+
+	var batch ingest.Batch
+	if err := json.NewDecoder(io.LimitReader(body, maxIngestBodySize)).Decode(&batch); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if reason := validateString(batch.Source); reason != "" {
+		badRequestResponse(w, r, fmt.Errorf("source %s", reason))
+		return
+	}
+	if reason := validateString(batch.Cluster); reason != "" {
+		badRequestResponse(w, r, fmt.Errorf("cluster %s", reason))
+		return
+	}
+	if err := batch.Validate(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	result, err := ingest.Apply(r.Context(), ws.repository, ws.eventRepository, &batch)
+	if err != nil {
+		if errors.Is(err, ingest.ErrInvalidBatch) {
+			badRequestResponse(w, r, err)
+			return
+		}
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Debugw("batch ingested",
+		"source", batch.Source, "sequence", batch.Sequence, "cluster", batch.Cluster,
+		"entries", result.Applied, "duplicate", result.Duplicate)
+	jsonResponse(w, r, result)
+}
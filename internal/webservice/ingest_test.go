@@ -0,0 +1,115 @@
+package webservice
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+const testBatch = `{"source": "collector-1", "sequence": 3, "cluster": "eu-west", "entries": [
+	{"kind": "partitions", "partitions": [{"name": "default"}], "at": "2024-07-01T12:00:00Z"},
+	{"kind": "event", "event": {"type": 2, "eventChangeType": 1}, "at": "2024-07-01T12:00:00Z"}
+]}`
+
+func TestWebServiceIngestBatch(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write([]byte(testBatch))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	tests := []struct {
+		name     string
+		body     []byte
+		encoding string
+	}{
+		{name: "uncompressed", body: []byte(testBatch)},
+		{name: "gzip", body: gzipped.Bytes(), encoding: "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			repo := repository.NewMockRepository(mockCtrl)
+			repo.EXPECT().ApplyIngestBatch(gomock.Any(), "collector-1", int64(3), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, _ string, sequence int64, fn func(context.Context, repository.Repository) error) (int64, error) {
+					return sequence, fn(ctx, repo)
+				})
+			repo.EXPECT().UpsertPartitions(gomock.Any(), []*dao.PartitionInfo{{Name: "default"}}).Return(nil)
+			events := repository.NewInMemoryEventRepository()
+			ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, events, nil, WithIngest())
+			ws.init(context.Background())
+
+			req := httptest.NewRequest(http.MethodPost, "/ws/v1/ingest", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.JSONEq(t, `{"applied": 2, "sequence": 3, "duplicate": false}`, rec.Body.String())
+
+			counts, err := events.Counts(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, counts, 1)
+		})
+	}
+}
+
+func TestWebServiceIngestDuplicateBatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().ApplyIngestBatch(gomock.Any(), "collector-1", int64(3), gomock.Any()).
+		Return(int64(4), repository.ErrDuplicateBatch)
+	events := repository.NewInMemoryEventRepository()
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, events, nil, WithIngest())
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/ingest", strings.NewReader(testBatch)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"applied": 0, "sequence": 4, "duplicate": true}`, rec.Body.String())
+	counts, err := events.Counts(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+func TestWebServiceIngestBatchRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		encoding   string
+		readOnly   bool
+		wantStatus int
+	}{
+		{name: "unknown kind", body: `{"source": "c", "sequence": 1, "entries": [{"kind": "users"}]}`, wantStatus: http.StatusBadRequest},
+		{name: "without sequence", body: `{"source": "c", "entries": []}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json", body: `{"entries": [`, wantStatus: http.StatusBadRequest},
+		{name: "invalid gzip", body: testBatch, encoding: "gzip", wantStatus: http.StatusBadRequest},
+		{name: "unsupported encoding", body: testBatch, encoding: "br", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "read-only", body: testBatch, readOnly: true, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// batches are rejected before any of their entries is applied
+			repo := repository.NewMockRepository(gomock.NewController(t))
+			ws := NewWebService(&config.YHSConfig{Port: 8080, ReadOnly: tt.readOnly}, repo, nil, nil, WithIngest())
+			ws.init(context.Background())
+
+			req := httptest.NewRequest(http.MethodPost, "/ws/v1/ingest", strings.NewReader(tt.body))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
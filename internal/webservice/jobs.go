@@ -0,0 +1,50 @@
+package webservice
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/scheduler"
+)
+
+// WithScheduler sets the scheduler running the periodic jobs, whose statuses are served by the admin API.
+// If not set, no jobs are listed.
+func WithScheduler(s *scheduler.Scheduler) Option {
+	return func(ws *WebService) {
+		ws.scheduler = s
+	}
+}
+
+// getJobs returns the status of every periodic job on this replica ordered by name.
+func (ws *WebService) getJobs(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.scheduler.Statuses())
+}
+
+// triggerJob starts a run of the job, and returns its status once the run started. Exclusive jobs can only be
+// triggered on the replica holding their lock.
+func (ws *WebService) triggerJob(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name := params.ByName(paramsJobName)
+	err := ws.scheduler.Trigger(name)
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		notFoundResponse(w, r, fmt.Errorf("%w: %s", err, name))
+		return
+	case errors.Is(err, scheduler.ErrJobRunning), errors.Is(err, scheduler.ErrJobInactive):
+		problemResponse(w, r, http.StatusConflict, fmt.Errorf("could not trigger job %s: %w", name, err))
+		return
+	case err != nil:
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("job triggered", "job", name)
+	status, err := ws.scheduler.Status(name)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponseWithStatus(w, r, http.StatusAccepted, status)
+}
@@ -0,0 +1,80 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/scheduler"
+)
+
+func TestJobsRoutes(t *testing.T) {
+	jobs := scheduler.New()
+	release := make(chan struct{})
+	require.NoError(t, jobs.Register("retention", "0 3 * * *", func(ctx context.Context) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- jobs.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-done)
+	}()
+	require.Eventually(t, func() bool { return jobs.Statuses()[0].Active }, 5*time.Second, time.Millisecond)
+
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithScheduler(jobs))
+	ws.init(context.Background())
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "/ws/v1/admin/jobs")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var statuses []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "retention", statuses[0]["name"])
+	assert.Equal(t, "0 3 * * *", statuses[0]["schedule"])
+	assert.Equal(t, true, statuses[0]["active"])
+	assert.NotContains(t, statuses[0], "lastRun")
+	assert.Contains(t, statuses[0], "nextRun")
+
+	rec = serve(http.MethodPost, "/ws/v1/admin/jobs/retention/trigger")
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var status map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, true, status["running"])
+	assert.Contains(t, status, "lastRun")
+
+	rec = serve(http.MethodPost, "/ws/v1/admin/jobs/retention/trigger")
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	rec = serve(http.MethodPost, "/ws/v1/admin/jobs/compaction/trigger")
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+	close(release)
+}
+
+func TestJobsRoutesWithoutScheduler(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/admin/jobs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+}
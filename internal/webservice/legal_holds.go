@@ -0,0 +1,189 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// anonymousPrincipal is recorded as the creator of legal holds when API authentication is disabled.
+const anonymousPrincipal = "anonymous"
+
+// legalHoldRequest is the request body for placing a legal hold.
+type legalHoldRequest struct {
+	Partition     string  `json:"partition"`
+	QueueName     *string `json:"queueName"`
+	ApplicationID *string `json:"applicationId"`
+	Reason        string  `json:"reason"`
+}
+
+func (req *legalHoldRequest) validate() error {
+	var errorMessages []string
+	if req.Partition == "" {
+		errorMessages = append(errorMessages, "partition is required")
+	}
+	if (req.QueueName == nil || *req.QueueName == "") && (req.ApplicationID == nil || *req.ApplicationID == "") {
+		errorMessages = append(errorMessages, "queueName or applicationId is required")
+	}
+	if req.Reason == "" {
+		errorMessages = append(errorMessages, "reason is required")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("invalid legal hold: %v", errorMessages)
+	}
+	return nil
+}
+
+// legalHoldRelease is the request body for releasing a legal hold.
+type legalHoldRelease struct {
+	Reason string `json:"reason"`
+}
+
+// getLegalHolds returns the legal holds ordered by creation time in descending order.
+// Following query params are supported:
+// - partition: filter by partition
+// - active: filter active (true) or released (false) holds
+// - limit: limit the number of returned holds
+// - offset: offset the returned holds
+func (ws *WebService) getLegalHolds(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	filters := repository.LegalHoldFilters{
+		Partition: q.String(queryParamPartition),
+		Active:    q.Bool(queryParamActive),
+		Offset:    q.Offset(),
+		Limit:     q.Limit(ws.pageSizes.LegalHolds),
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	holds, err := ws.repository.GetLegalHolds(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, holds)
+}
+
+// createLegalHold places a legal hold on a queue subtree or a single application,
+// so that their applications are not pruned until the hold is released.
+func (ws *WebService) createLegalHold(w http.ResponseWriter, r *http.Request) {
+	var req legalHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	hold := &model.LegalHold{
+		Partition:     req.Partition,
+		QueueName:     emptyToNil(req.QueueName),
+		ApplicationID: emptyToNil(req.ApplicationID),
+		Reason:        req.Reason,
+		CreatedBy:     requestPrincipal(r),
+	}
+	if err := ws.repository.CreateLegalHold(r.Context(), hold); err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("legal hold placed", "id", hold.ID, "partition", hold.Partition,
+		"queue", hold.QueueName, "application", hold.ApplicationID, "reason", hold.Reason)
+	setETag(w, hold.Version)
+	createdResponse(w, r, hold)
+}
+
+func (ws *WebService) getLegalHold(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := legalHoldID(w, r, params)
+	if !ok {
+		return
+	}
+	hold, err := ws.repository.GetLegalHold(r.Context(), id)
+	if err != nil {
+		legalHoldErrorResponse(w, r, err)
+		return
+	}
+	setETag(w, hold.Version)
+	jsonResponse(w, r, hold)
+}
+
+// releaseLegalHold releases a legal hold, so that its applications can be pruned again.
+// The hold is kept with the release metadata for auditing. If the If-Match header is set, the hold is only released
+// if it is still in the version of the header.
+func (ws *WebService) releaseLegalHold(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := legalHoldID(w, r, params)
+	if !ok {
+		return
+	}
+	ifVersion, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	var release legalHoldRelease
+	if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if release.Reason == "" {
+		badRequestResponse(w, r, errors.New("reason is required"))
+		return
+	}
+
+	hold, err := ws.repository.ReleaseLegalHold(r.Context(), id, requestPrincipal(r), release.Reason, ifVersion)
+	if err != nil {
+		legalHoldErrorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("legal hold released", "id", hold.ID, "reason", release.Reason)
+	setETag(w, hold.Version)
+	jsonResponse(w, r, hold)
+}
+
+// legalHoldID returns the legal hold ID path parameter, responding with 404 Not Found if it is not a valid ID.
+func legalHoldID(w http.ResponseWriter, r *http.Request, params httprouter.Params) (string, bool) {
+	id := params.ByName(paramsHoldID)
+	if _, err := uuid.Parse(id); err != nil {
+		notFoundResponse(w, r, fmt.Errorf("%w: %s", repository.ErrLegalHoldNotFound, id))
+		return "", false
+	}
+	return id, true
+}
+
+func legalHoldErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrLegalHoldNotFound):
+		notFoundResponse(w, r, err)
+	case errors.Is(err, repository.ErrLegalHoldReleased):
+		problemResponse(w, r, http.StatusConflict, err)
+	case errors.Is(err, repository.ErrVersionMismatch):
+		problemResponse(w, r, http.StatusPreconditionFailed, err)
+	default:
+		errorResponse(w, r, err)
+	}
+}
+
+// requestPrincipal returns the authenticated client of the request, or anonymous if authentication is disabled.
+func requestPrincipal(r *http.Request) string {
+	if principal, ok := principalFromContext(r.Context()); ok {
+		return principal
+	}
+	return anonymousPrincipal
+}
+
+func emptyToNil(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,213 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+const testHoldID = "6f1c5e4e-4a47-4b8f-9d0c-1f3a2b4c5d6e"
+
+func TestWebServiceCreateLegalHold(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		principal  string
+		wantStatus int
+		wantHold   *model.LegalHold
+	}{
+		"queue hold": {
+			body:       `{"partition": "default", "queueName": "root.compliance", "reason": "investigation 42"}`,
+			principal:  "ops",
+			wantStatus: http.StatusCreated,
+			wantHold: &model.LegalHold{
+				Partition: "default",
+				QueueName: util.ToPtr("root.compliance"),
+				Reason:    "investigation 42",
+				CreatedBy: "ops",
+			},
+		},
+		"application hold without authentication": {
+			body:       `{"partition": "default", "queueName": "", "applicationId": "app-1", "reason": "audit"}`,
+			wantStatus: http.StatusCreated,
+			wantHold: &model.LegalHold{
+				Partition:     "default",
+				ApplicationID: util.ToPtr("app-1"),
+				Reason:        "audit",
+				CreatedBy:     anonymousPrincipal,
+			},
+		},
+		"missing queue and application": {
+			body:       `{"partition": "default", "reason": "audit"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"missing reason": {
+			body:       `{"partition": "default", "queueName": "root.compliance"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"invalid body": {
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			repo := repository.NewMockRepository(mockCtrl)
+			if tt.wantHold != nil {
+				repo.EXPECT().CreateLegalHold(gomock.Any(), tt.wantHold).DoAndReturn(
+					func(_ context.Context, hold *model.LegalHold) error {
+						hold.ID = testHoldID
+						hold.Version = 1
+						return nil
+					})
+			}
+			ws := &WebService{repository: repo}
+
+			req := httptest.NewRequest(http.MethodPost, routeLegalHolds, strings.NewReader(tt.body))
+			if tt.principal != "" {
+				req = req.WithContext(context.WithValue(req.Context(), principalKey{}, tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			ws.createLegalHold(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantHold != nil {
+				var hold model.LegalHold
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &hold))
+				assert.Equal(t, testHoldID, hold.ID)
+				assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+				assert.Equal(t, `"1"`, rec.Header().Get(headerETag))
+			}
+		})
+	}
+}
+
+func TestWebServiceReleaseLegalHold(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		body          string
+		ifMatch       string
+		repoErr       error
+		wantIfVersion *int64
+		wantStatus    int
+	}{
+		"release": {
+			id:         testHoldID,
+			body:       `{"reason": "investigation closed"}`,
+			wantStatus: http.StatusOK,
+		},
+		"release in version": {
+			id:            testHoldID,
+			body:          `{"reason": "investigation closed"}`,
+			ifMatch:       `"3"`,
+			wantIfVersion: util.ToPtr(int64(3)),
+			wantStatus:    http.StatusOK,
+		},
+		"release in any version": {
+			id:         testHoldID,
+			body:       `{"reason": "investigation closed"}`,
+			ifMatch:    "*",
+			wantStatus: http.StatusOK,
+		},
+		"changed since read": {
+			id:            testHoldID,
+			body:          `{"reason": "investigation closed"}`,
+			ifMatch:       `"3"`,
+			repoErr:       fmt.Errorf("%w: legal hold %s is in version 4", repository.ErrVersionMismatch, testHoldID),
+			wantIfVersion: util.ToPtr(int64(3)),
+			wantStatus:    http.StatusPreconditionFailed,
+		},
+		"invalid if-match": {
+			id:         testHoldID,
+			body:       `{"reason": "investigation closed"}`,
+			ifMatch:    `W/"3"`,
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		"already released": {
+			id:         testHoldID,
+			body:       `{"reason": "investigation closed"}`,
+			repoErr:    fmt.Errorf("%w: %s", repository.ErrLegalHoldReleased, testHoldID),
+			wantStatus: http.StatusConflict,
+		},
+		"unknown hold": {
+			id:         testHoldID,
+			body:       `{"reason": "investigation closed"}`,
+			repoErr:    fmt.Errorf("%w: %s", repository.ErrLegalHoldNotFound, testHoldID),
+			wantStatus: http.StatusNotFound,
+		},
+		"invalid id": {
+			id:         "not-a-uuid",
+			body:       `{"reason": "investigation closed"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		"missing reason": {
+			id:         testHoldID,
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			repo := repository.NewMockRepository(mockCtrl)
+			if tt.wantStatus != http.StatusBadRequest && tt.id == testHoldID && !strings.HasPrefix(tt.ifMatch, "W/") {
+				var hold *model.LegalHold
+				if tt.repoErr == nil {
+					hold = &model.LegalHold{ID: testHoldID, ReleasedBy: util.ToPtr(anonymousPrincipal), Version: 4}
+				}
+				repo.EXPECT().
+					ReleaseLegalHold(gomock.Any(), testHoldID, anonymousPrincipal, "investigation closed", tt.wantIfVersion).
+					Return(hold, tt.repoErr)
+			}
+			ws := &WebService{repository: repo}
+
+			req := httptest.NewRequest(http.MethodPost, "/ws/v1/admin/legal-holds/"+tt.id+"/release", strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set(headerIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			ws.releaseLegalHold(rec, req, httprouter.Params{{Key: paramsHoldID, Value: tt.id}})
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, `"4"`, rec.Header().Get(headerETag))
+			}
+		})
+	}
+}
+
+func TestWebServiceGetLegalHolds(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	active := true
+	partition := "default"
+	repo.EXPECT().GetLegalHolds(gomock.Any(), repository.LegalHoldFilters{Partition: &partition, Active: &active}).
+		Return([]*model.LegalHold{{ID: testHoldID}}, nil)
+	ws := &WebService{repository: repo}
+
+	rec := httptest.NewRecorder()
+	ws.getLegalHolds(rec, httptest.NewRequest(http.MethodGet, routeLegalHolds+"?partition=default&active=true", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var holds []*model.LegalHold
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &holds))
+	assert.Len(t, holds, 1)
+
+	rec = httptest.NewRecorder()
+	ws.getLegalHolds(rec, httptest.NewRequest(http.MethodGet, routeLegalHolds+"?active=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,106 @@
+package webservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// bodyLimitRoutes lists the routes whose request bodies have larger limits than the configured maximum body size,
+// since they accept the batches of data of other components.
+var bodyLimitRoutes = map[string]int64{
+	routeIngest:           maxIngestBodySize,
+	routeAutoscalerEvents: maxAutoscalerEventsBodySize,
+}
+
+type limitedBodyKey struct{}
+
+// limitedBody is a request body which fails to be read beyond its limit, and records that it did.
+type limitedBody struct {
+	io.ReadCloser
+	limit    int64
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// requestLimitsOrDefault returns the request limits, which default to config.DefaultRequestLimits.
+func requestLimitsOrDefault(limits config.RequestLimitsConfig) config.RequestLimitsConfig {
+	if limits == (config.RequestLimitsConfig{}) {
+		return config.DefaultRequestLimits
+	}
+	return limits
+}
+
+// limitBody wraps the handle of the route so that its request bodies are limited to the maximum body size, or to
+// the limit of the route. Requests declaring a larger body are rejected before they are handled, and bodies which
+// turn out to be larger when they are read fail the request with the same error, see badRequestResponse.
+func (ws *WebService) limitBody(path string, handle httprouter.Handle) httprouter.Handle {
+	limit := ws.limits.MaxBodySize
+	if routeLimit, ok := bodyLimitRoutes[path]; ok {
+		limit = routeLimit
+	}
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if r.ContentLength > limit {
+			bodyTooLargeResponse(w, r, limit)
+			return
+		}
+		// the body is kept in the context, since handlers may replace the body of the request with a reader of it
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, limit), limit: limit}
+		r.Body = body
+		handle(w, r.WithContext(context.WithValue(r.Context(), limitedBodyKey{}, body)), p)
+	}
+}
+
+// bodyLimitExceeded returns the limit of the body of the request, if reading it failed because it exceeded it.
+func bodyLimitExceeded(r *http.Request) (int64, bool) {
+	body, ok := r.Context().Value(limitedBodyKey{}).(*limitedBody)
+	if !ok || !body.exceeded {
+		return 0, false
+	}
+	return body.limit, true
+}
+
+func bodyTooLargeResponse(w http.ResponseWriter, r *http.Request, limit int64) {
+	problemResponse(w, r, http.StatusRequestEntityTooLarge,
+		fmt.Errorf("request body exceeds the maximum size of %d bytes", limit))
+}
+
+// limitHeaders wraps the handler so that requests whose request line and headers exceed the maximum header size are
+// rejected. The server rejects requests exceeding it by far before they are handled, without a problem response.
+func (ws *WebService) limitHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if size := headerSize(r); size > ws.limits.MaxHeaderSize {
+			problemResponse(w, r, http.StatusRequestHeaderFieldsTooLarge,
+				fmt.Errorf("request line and headers exceed the maximum size of %d bytes", ws.limits.MaxHeaderSize))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// headerSize returns the size of the request line and the headers of the request as they were sent.
+func headerSize(r *http.Request) int {
+	// e.g. "GET /ws/v1/partitions HTTP/1.1\r\n" and "Host: yhs\r\n"
+	size := len(r.Method) + len(r.RequestURI) + len(r.Proto) + 4
+	size += len("Host: ") + len(r.Host) + 2
+	for name, values := range r.Header {
+		for _, value := range values {
+			size += len(name) + len(value) + 4
+		}
+	}
+	return size
+}
@@ -0,0 +1,95 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+)
+
+func TestWebServiceRequestLimits(t *testing.T) {
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{AdminOverrides: true})
+	require.NoError(t, err)
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().ApplyIngestBatch(gomock.Any(), "c", int64(1), gomock.Any()).Return(int64(1), nil)
+	limits := config.RequestLimitsConfig{MaxBodySize: 64, MaxHeaderSize: 256}
+	ws := NewWebService(
+		&config.YHSConfig{Port: 8080, Limits: limits},
+		repo,
+		repository.NewInMemoryEventRepository(),
+		nil,
+		WithFeatureFlags(featureFlags),
+		WithIngest(),
+	)
+	ws.init(context.Background())
+	assert.Equal(t, 256, ws.server.MaxHeaderBytes)
+
+	padding := `{"enabled": true, "padding": "` + strings.Repeat("x", 64) + `"}`
+	tests := map[string]struct {
+		method     string
+		path       string
+		body       io.Reader
+		header     string
+		wantStatus int
+	}{
+		"body within the limit": {
+			method:     http.MethodPut,
+			path:       "/ws/v1/admin/features/analytics",
+			body:       strings.NewReader(`{"enabled": true}`),
+			wantStatus: http.StatusOK,
+		},
+		"body exceeding the limit": {
+			method:     http.MethodPut,
+			path:       "/ws/v1/admin/features/analytics",
+			body:       strings.NewReader(padding),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		"body of unknown length exceeding the limit": {
+			method:     http.MethodPut,
+			path:       "/ws/v1/admin/features/analytics",
+			body:       io.MultiReader(strings.NewReader(padding)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		"body of a route with a larger limit": {
+			method: http.MethodPost,
+			path:   "/ws/v1/ingest",
+			body: strings.NewReader(`{"source": "c", "sequence": 1, "entries": [], "padding": "` +
+				strings.Repeat("x", 64) + `"}`),
+			wantStatus: http.StatusOK,
+		},
+		"headers exceeding the limit": {
+			method:     http.MethodGet,
+			path:       routeFeatureFlags,
+			header:     strings.Repeat("x", 256),
+			wantStatus: http.StatusRequestHeaderFieldsTooLarge,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, tt.body)
+			if tt.header != "" {
+				req.Header.Set("X-Padding", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, req)
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var problem ProblemDetails
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+			assert.Equal(t, tt.wantStatus, problem.Status)
+		})
+	}
+}
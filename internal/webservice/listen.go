@@ -0,0 +1,60 @@
+package webservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// listen opens a listener on each of the addresses, see config.ParseListenAddress. The listeners opened before are
+// closed if one of the addresses cannot be listened on.
+func listen(ctx context.Context, addresses []string) ([]net.Listener, error) {
+	var lc net.ListenConfig
+	listeners := make([]net.Listener, 0, len(addresses))
+	for _, address := range addresses {
+		l, err := func() (net.Listener, error) {
+			network, addr, err := config.ParseListenAddress(address)
+			if err != nil {
+				return nil, err
+			}
+			if network == "unix" {
+				if err := removeStaleSocket(addr); err != nil {
+					return nil, err
+				}
+			}
+			return lc.Listen(ctx, network, addr)
+		}()
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return nil, fmt.Errorf("could not listen on %s: %w", address, err)
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners, nil
+}
+
+// removeStaleSocket removes the Unix domain socket left behind by a server which did not shut down cleanly, which
+// prevents listening on its path. A socket which accepts connections is in use, and is not removed.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("socket %s is in use", path)
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,82 @@
+package webservice
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestWebServiceServesAllListenAddresses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{{Name: "default"}}, nil).Times(2)
+
+	socket := filepath.Join(t.TempDir(), "yhs.sock")
+	ws := NewWebService(&config.YHSConfig{Listen: []string{"127.0.0.1:0", "unix:" + socket}}, repo, nil, nil)
+	listeners, err := listen(context.Background(), ws.addresses)
+	require.NoError(t, err)
+	require.Len(t, listeners, 2)
+	tcpAddress := listeners[0].Addr().String()
+	ws.init(context.Background())
+	for _, l := range listeners {
+		go func() {
+			_ = ws.server.Serve(l)
+		}()
+	}
+
+	unixClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	for client, url := range map[*http.Client]string{
+		http.DefaultClient: "http://" + tcpAddress + "/ws/v1/partitions",
+		unixClient:         "http://yhs/ws/v1/partitions",
+	} {
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, url)
+	}
+
+	require.NoError(t, ws.Shutdown(context.Background()))
+	_, err = os.Stat(socket)
+	assert.ErrorIs(t, err, os.ErrNotExist, "the socket must be removed on shutdown")
+}
+
+func TestListen(t *testing.T) {
+	dir := t.TempDir()
+
+	// a socket left behind by a server which did not shut down cleanly is replaced
+	stale := filepath.Join(dir, "stale.sock")
+	l, err := net.Listen("unix", stale)
+	require.NoError(t, err)
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, l.Close())
+	listeners, err := listen(context.Background(), []string{"unix:" + stale})
+	require.NoError(t, err)
+
+	// a socket in use is not replaced, and the listeners opened before are closed
+	_, err = listen(context.Background(), []string{"127.0.0.1:0", "unix:" + stale})
+	assert.ErrorContains(t, err, "in use")
+
+	regular := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(regular, nil, 0o600))
+	_, err = listen(context.Background(), []string{"unix:" + regular})
+	assert.ErrorContains(t, err, "not a socket")
+
+	for _, l := range listeners {
+		require.NoError(t, l.Close())
+	}
+}
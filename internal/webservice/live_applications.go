@@ -0,0 +1,142 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// defaultLiveWindow is how long ago the finished applications of the live tail finished by default.
+	defaultLiveWindow = time.Hour
+	// maxLiveWindow bounds how long ago the finished applications of the live tail finished, as the tail is not
+	// paginated.
+	maxLiveWindow = 24 * time.Hour
+
+	// liveAppsSuffix is the suffix of the path of the live tails of the queues.
+	liveAppsSuffix = "/applications/live"
+
+	liveSourceScheduler = "scheduler"
+	liveSourceHistory   = "history"
+)
+
+// LiveApplications returns the applications of a queue of the running scheduler, e.g. the Yunikorn REST client.
+type LiveApplications interface {
+	GetApplications(ctx context.Context, partitionName, queueName string) ([]*dao.ApplicationDAOInfo, error)
+}
+
+// WithLiveApplications enables the live tail of the applications of a queue, which merges the applications of the
+// running scheduler with those which finished recently. The route is not registered if live is nil.
+func WithLiveApplications(live LiveApplications) Option {
+	return func(ws *WebService) {
+		ws.live = live
+	}
+}
+
+// getLiveApplications returns the applications of a queue which the scheduler runs, merged with those which
+// finished since a recent time according to the history, so that clients do not need to merge both sources.
+// The applications of the scheduler take precedence over those of the history. If the scheduler cannot be
+// reached, only the applications of the history are returned, which the response tells.
+// Results are ordered by submission time in descending order.
+// Following query params are supported:
+// - since: include the applications which finished since this time, at most 24h ago, 1h ago by default
+// - tz: timezone of the since filter without offset and of the createdAt timestamps, UTC by default
+func (ws *WebService) getLiveApplications(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	queue := params.ByName(paramsQueueName)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	now := time.Now()
+	since := now.Add(-defaultLiveWindow)
+	if t := q.Time(queryParamSince, loc, now); t != nil {
+		if t.Before(now.Add(-maxLiveWindow)) {
+			q.invalidate(queryParamSince, "must not be more than %s ago", maxLiveWindow)
+		}
+		since = *t
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	limit := pageSizeOrDefault(ws.pageSizes.Applications).MaxLimit
+	history, err := ws.repository.GetAppsPerPartitionPerQueue(r.Context(), partition, queue, repository.ApplicationFilters{
+		FinishedStartTime: &since,
+		Limit:             &limit,
+	})
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	live, err := ws.live.GetApplications(r.Context(), partition, queue)
+	if err != nil {
+		log.FromContext(r.Context()).Warnf("could not get applications of queue %s from the scheduler: %v", queue, err)
+		live = nil
+	}
+
+	apps := mergeLiveApplications(live, history, since)
+	for _, app := range apps {
+		ws.prepareApplication(r, app.ApplicationDAOInfo, loc)
+	}
+	jsonResponse(w, r, &model.LiveApplications{
+		Since:              since.In(loc),
+		SchedulerAvailable: err == nil,
+		Applications:       apps,
+	})
+}
+
+// mergeLiveApplications merges the applications of the scheduler which did not finish before since with those of
+// the history, whose versions are replaced by those of the scheduler. The applications are ordered by submission
+// time in descending order.
+func mergeLiveApplications(
+	live []*dao.ApplicationDAOInfo,
+	history []*model.ApplicationDAOInfo,
+	since time.Time,
+) []*model.LiveApplication {
+	stored := make(map[string]*model.ApplicationDAOInfo, len(history))
+	for _, app := range history {
+		stored[app.ApplicationID] = app
+	}
+
+	apps := make([]*model.LiveApplication, 0, len(live)+len(history))
+	seen := make(map[string]bool, len(live))
+	for _, app := range live {
+		if app.FinishedTime != nil && *app.FinishedTime < since.UnixNano() {
+			continue
+		}
+		// the fields only known to the history, e.g. the Spark application ID, are kept
+		merged := &model.ApplicationDAOInfo{CreatedAt: time.Unix(0, app.SubmissionTime)}
+		if storedApp, ok := stored[app.ApplicationID]; ok {
+			merged = storedApp
+		}
+		merged.ApplicationDAOInfo = *app
+		apps = append(apps, &model.LiveApplication{ApplicationDAOInfo: merged, Source: liveSourceScheduler})
+		seen[app.ApplicationID] = true
+	}
+	for _, app := range history {
+		if !seen[app.ApplicationID] {
+			apps = append(apps, &model.LiveApplication{ApplicationDAOInfo: app, Source: liveSourceHistory})
+		}
+	}
+
+	slices.SortFunc(apps, func(a, b *model.LiveApplication) int {
+		if a.SubmissionTime != b.SubmissionTime {
+			if a.SubmissionTime > b.SubmissionTime {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ApplicationID, b.ApplicationID)
+	})
+	return apps
+}
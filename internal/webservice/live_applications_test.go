@@ -0,0 +1,136 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+type fakeLiveApplications struct {
+	apps []*dao.ApplicationDAOInfo
+	err  error
+}
+
+func (f *fakeLiveApplications) GetApplications(context.Context, string, string) ([]*dao.ApplicationDAOInfo, error) {
+	return f.apps, f.err
+}
+
+func TestWebServiceGetLiveApplications(t *testing.T) {
+	now := time.Now()
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	history := func() []*model.ApplicationDAOInfo {
+		return []*model.ApplicationDAOInfo{
+			{
+				ApplicationDAOInfo: dao.ApplicationDAOInfo{
+					ApplicationID:  "completed",
+					SubmissionTime: now.Add(-20 * time.Minute).UnixNano(),
+					FinishedTime:   util.ToPtr(now.Add(-time.Minute).UnixNano()),
+					State:          "Completed",
+				},
+				SparkApplicationID: "spark-0123456789abcdef",
+			},
+			{
+				ApplicationDAOInfo: dao.ApplicationDAOInfo{
+					ApplicationID:  "finished",
+					SubmissionTime: now.Add(-40 * time.Minute).UnixNano(),
+					FinishedTime:   util.ToPtr(now.Add(-10 * time.Minute).UnixNano()),
+					State:          "Completed",
+				},
+			},
+		}
+	}
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, filters repository.ApplicationFilters) ([]*model.ApplicationDAOInfo, error) {
+			require.NotNil(t, filters.FinishedStartTime)
+			assert.WithinDuration(t, now.Add(-time.Hour), *filters.FinishedStartTime, time.Minute)
+			assert.Equal(t, config.DefaultPageSize.MaxLimit, *filters.Limit)
+			return history(), nil
+		}).
+		Times(2)
+	live := &fakeLiveApplications{apps: []*dao.ApplicationDAOInfo{
+		{ApplicationID: "running", SubmissionTime: now.Add(-5 * time.Minute).UnixNano(), State: "Running"},
+		{
+			ApplicationID:  "completed",
+			SubmissionTime: now.Add(-20 * time.Minute).UnixNano(),
+			FinishedTime:   util.ToPtr(now.Add(-time.Minute).UnixNano()),
+			UsedResource:   map[string]int64{"vcore": 1000},
+			State:          "Completed",
+		},
+		{
+			ApplicationID:  "finished-long-ago",
+			SubmissionTime: now.Add(-3 * time.Hour).UnixNano(),
+			FinishedTime:   util.ToPtr(now.Add(-2 * time.Hour).UnixNano()),
+			State:          "Completed",
+		},
+	}}
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithLiveApplications(live))
+	ws.init(context.Background())
+
+	serve := func(path string) (*httptest.ResponseRecorder, model.LiveApplications) {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var apps model.LiveApplications
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apps))
+		}
+		return rec, apps
+	}
+	type tail struct {
+		id, source, state, sparkID string
+		vcores                     int64
+	}
+	tails := func(apps model.LiveApplications) []tail {
+		var tails []tail
+		for _, app := range apps.Applications {
+			tails = append(tails, tail{app.ApplicationID, app.Source, app.State, app.SparkApplicationID, app.UsedResource["vcore"]})
+		}
+		return tails
+	}
+
+	// the applications of the scheduler replace those of the history, and are ordered by submission time
+	rec, apps := serve("/ws/v1/partition/default/queue/root.default/applications/live")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.True(t, apps.SchedulerAvailable)
+	assert.Equal(t, []tail{
+		{"running", "scheduler", "Running", "", 0},
+		{"completed", "scheduler", "Completed", "spark-0123456789abcdef", 1000},
+		{"finished", "history", "Completed", "", 0},
+	}, tails(apps))
+
+	// the applications of the history are returned if the scheduler is unavailable
+	live.err = errors.New("connection refused")
+	rec, apps = serve("/ws/v1/partition/default/queue/root.default/applications/live")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.False(t, apps.SchedulerAvailable)
+	assert.Equal(t, []tail{
+		{"completed", "history", "Completed", "spark-0123456789abcdef", 0},
+		{"finished", "history", "Completed", "", 0},
+	}, tails(apps))
+
+	rec, _ = serve("/ws/v1/partition/default/queue/root.default/applications/live?since=25h")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceLiveApplicationsNotRegisteredWithoutScheduler(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+	for _, r := range ws.routes {
+		assert.NotEqual(t, routeLiveApps, r.path)
+	}
+}
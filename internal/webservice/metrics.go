@@ -0,0 +1,79 @@
+package webservice
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "yhs",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the requests of the API, by method, route and status code.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{"method", "route", "code"})
+	requestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of requests of the API being served, by route.",
+	}, []string{"route"})
+)
+
+// instrument records the duration and status code of the requests of a route. The route is the registered path
+// rather than the path of the request, so that the metrics are not labelled with the IDs of the path.
+func instrument(method, path string, handle httprouter.Handle) httprouter.Handle {
+	inFlight := requestsInFlight.WithLabelValues(path)
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		start := time.Now()
+		inFlight.Inc()
+		recorder := &statusRecorder{ResponseWriter: w}
+		defer func() {
+			inFlight.Dec()
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			requestDuration.WithLabelValues(method, path, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+		}()
+		handle(recorder, r, p)
+	}
+}
+
+// statusRecorder records the status code of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush flushes the wrapped response writer, so that streamed responses are not held back.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped response writer, so that http.ResponseController can flush it.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
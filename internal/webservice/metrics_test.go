@@ -0,0 +1,46 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestWebServiceRequestMetrics(t *testing.T) {
+	requests := func(code string) uint64 {
+		t.Helper()
+		var m dto.Metric
+		histogram := requestDuration.WithLabelValues(http.MethodGet, routeApplication, code).(prometheus.Metric)
+		require.NoError(t, histogram.Write(&m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	notFound := requests("404")
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default", gomock.Any()).Return(nil, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/application/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	// the requests are labelled with the route rather than their path
+	assert.Equal(t, notFound+1, requests("404"))
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, routeMetrics, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "yhs_http_request_duration_seconds")
+}
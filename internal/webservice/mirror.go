@@ -0,0 +1,23 @@
+package webservice
+
+import (
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/mirror"
+)
+
+// WithMirror mirrors a sample of the authenticated read requests of the API to a secondary deployment, e.g. a staging
+// deployment of a new release. If the mirror is nil, requests are not mirrored.
+func WithMirror(m *mirror.Mirror) Option {
+	return func(ws *WebService) {
+		ws.mirror = m
+	}
+}
+
+// mirrorRequests wraps the handler so that a sample of the read requests is mirrored, if a mirror is set.
+func (ws *WebService) mirrorRequests(next http.Handler) http.Handler {
+	if ws.mirror == nil {
+		return next
+	}
+	return ws.mirror.Handler(next)
+}
@@ -12,4 +12,14 @@ type ProblemDetails struct {
 	Detail string `json:"detail,omitempty"`
 	// Instance is a URI reference that identifies the specific occurrence of the problem.
 	Instance string `json:"instance,omitempty"`
+	// InvalidParams lists the invalid request parameters and why they are invalid, if any.
+	InvalidParams []InvalidParam `json:"invalidParams,omitempty"`
+}
+
+// InvalidParam describes an invalid request parameter.
+type InvalidParam struct {
+	// Name is the name of the parameter.
+	Name string `json:"name"`
+	// Reason is a human-readable explanation why the parameter is invalid.
+	Reason string `json:"reason"`
 }
@@ -0,0 +1,24 @@
+package webservice
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// msgpackContentType is the media type of MessagePack responses.
+const msgpackContentType = "application/x-msgpack"
+
+// acceptsMsgpack reports whether the client requested a MessagePack response, with either the
+// application/x-msgpack or the application/msgpack media type.
+func acceptsMsgpack(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err == nil && (mediaType == msgpackContentType || mediaType == "application/msgpack") {
+				return true
+			}
+		}
+	}
+	return false
+}
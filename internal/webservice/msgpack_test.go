@@ -0,0 +1,83 @@
+package webservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/msgpack"
+)
+
+func TestAcceptsMsgpack(t *testing.T) {
+	tests := map[string]struct {
+		accept []string
+		want   bool
+	}{
+		"no accept header":   {want: false},
+		"json":               {accept: []string{"application/json"}, want: false},
+		"x-msgpack":          {accept: []string{"application/x-msgpack"}, want: true},
+		"msgpack":            {accept: []string{"application/msgpack"}, want: true},
+		"msgpack with q":     {accept: []string{"application/json;q=0.5, application/x-msgpack; q=0.9"}, want: true},
+		"multiple headers":   {accept: []string{"text/html", "application/msgpack"}, want: true},
+		"invalid media type": {accept: []string{";;"}, want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, routeAppsHistory, nil)
+			for _, accept := range tt.accept {
+				req.Header.Add("Accept", accept)
+			}
+			assert.Equal(t, tt.want, acceptsMsgpack(req))
+		})
+	}
+}
+
+func TestWebServiceMsgpack(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	history := []*dao.ApplicationHistoryDAOInfo{{Timestamp: 1719835200000000000, TotalApplications: "3"}}
+	repo.EXPECT().GetApplicationsHistoryPage(gomock.Any(), repository.Page{Limit: &config.DefaultPageSize.DefaultLimit}).
+		Return(history, repository.PageInfo{Total: 1}, nil).Times(3)
+	repo.EXPECT().GetApplicationsHistoryPage(gomock.Any(), repository.Page{Limit: &config.DefaultPageSize.DefaultLimit}).
+		Return(nil, repository.PageInfo{}, errors.New("connection refused"))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithCache(cache.NewWithBackend(cache.NewMemoryBackend(100), time.Minute, time.Second)))
+	ws.init(context.Background())
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, routeAppsHistory, nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("application/json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	want, err := msgpack.FromJSON(rec.Body.Bytes())
+	require.NoError(t, err)
+
+	// MessagePack responses encode the JSON document, and are not cached
+	for range 2 {
+		rec = get("application/x-msgpack")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, msgpackContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+		assert.Equal(t, want, rec.Body.Bytes())
+	}
+
+	// errors are responded as problem details
+	rec = get("application/msgpack")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+}
@@ -0,0 +1,262 @@
+package webservice
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+// headerFieldNaming is the request header selecting the naming convention of the fields of the response,
+// overriding the configured default.
+const headerFieldNaming = "X-Field-Naming"
+
+type fieldNamingKey struct{}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// negotiateFieldNaming wraps the handler so that the naming convention of the fields of the response,
+// requested with the X-Field-Naming header or configured as default, is stored in the request context.
+func (ws *WebService) negotiateFieldNaming(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		naming := ws.fieldNaming
+		if header := r.Header.Get(headerFieldNaming); header != "" {
+			if header != config.FieldNamingCamelCase && header != config.FieldNamingSnakeCase {
+				badRequestResponse(w, r, fmt.Errorf(
+					"invalid %s header %q, must be %s or %s", headerFieldNaming, header, config.FieldNamingCamelCase, config.FieldNamingSnakeCase,
+				))
+				return
+			}
+			naming = header
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), fieldNamingKey{}, naming)))
+	})
+}
+
+// fieldNamingFromContext returns the naming convention of the fields of the response, camelCase by default.
+func fieldNamingFromContext(ctx context.Context) string {
+	naming, ok := ctx.Value(fieldNamingKey{}).(string)
+	if !ok || naming == "" {
+		return config.FieldNamingCamelCase
+	}
+	return naming
+}
+
+// renderFields returns the data with its fields named in the naming convention and the response schema of the request.
+// The JSON tags of the models and DAOs are camelCase, so camelCase data of the current schema is returned as is.
+func renderFields(r *http.Request, data any) (any, error) {
+	return renderFieldsAt(r, data, schemaRoot)
+}
+
+// renderRow returns a row of a streamed array with its fields named as by renderFields.
+func renderRow(r *http.Request, row any) (any, error) {
+	return renderFieldsAt(r, row, schemaRoot+"[]")
+}
+
+// renderFieldsAt renders the data at the path of the response schema, see renderPreviousSchema.
+func renderFieldsAt(r *http.Request, data any, path string) (any, error) {
+	if fieldNamingFromContext(r.Context()) == config.FieldNamingSnakeCase {
+		var err error
+		if data, err = snakeCaseFields(reflect.ValueOf(data)); err != nil {
+			return nil, err
+		}
+	}
+	return renderPreviousSchema(r, data, path)
+}
+
+// snakeCaseFields converts the value into maps, slices and JSON values which encode like the value,
+// except that the names of struct fields are converted to snake_case. Map keys are data, e.g. resource names,
+// and are not converted. Values implementing json.Marshaler or encoding.TextMarshaler are encoded as is.
+func snakeCaseFields(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if implementsMarshaler(v) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil, nil
+		}
+		if v.CanAddr() && v.Kind() != reflect.Pointer && !v.Type().Implements(jsonMarshalerType) &&
+			!v.Type().Implements(textMarshalerType) {
+			v = v.Addr()
+		}
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(b), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return snakeCaseFields(v.Elem())
+	case reflect.Struct:
+		fields := make(map[string]any)
+		if err := snakeCaseStructFields(v, fields); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := mapKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			value, err := snakeCaseFields(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = value
+		}
+		return entries, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// byte slices are encoded as base64 strings
+			return v.Interface(), nil
+		}
+		fallthrough
+	case reflect.Array:
+		elements := make([]any, v.Len())
+		for i := range elements {
+			element, err := snakeCaseFields(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return elements, nil
+	default:
+		return v.Interface(), nil
+	}
+}
+
+// snakeCaseStructFields adds the fields of the struct to the map. As in encoding/json, the fields of embedded
+// structs are promoted unless the outer struct has a field of the same name.
+func snakeCaseStructFields(v reflect.Value, fields map[string]any) error {
+	t := v.Type()
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, v.Field(i))
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if strings.Contains(opts, "omitempty") && isEmptyValue(fv) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		value, err := snakeCaseFields(fv)
+		if err != nil {
+			return err
+		}
+		fields[snakeCase(name)] = value
+	}
+	for _, ev := range embedded {
+		if ev.Kind() == reflect.Pointer {
+			if ev.IsNil() {
+				continue
+			}
+			ev = ev.Elem()
+		}
+		promoted := make(map[string]any)
+		if err := snakeCaseStructFields(ev, promoted); err != nil {
+			return err
+		}
+		for name, value := range promoted {
+			if _, ok := fields[name]; !ok {
+				fields[name] = value
+			}
+		}
+	}
+	return nil
+}
+
+func implementsMarshaler(v reflect.Value) bool {
+	t := v.Type()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return true
+	}
+	// as in encoding/json, methods with pointer receivers are only used for addressable values
+	return v.CanAddr() && (reflect.PointerTo(t).Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType))
+}
+
+// mapKey returns the JSON object key of the map key, as encoded by encoding/json.
+func mapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	return fmt.Sprint(k.Interface()), nil
+}
+
+// isEmptyValue reports whether the value is omitted by the omitempty option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// snakeCase converts a camelCase name to snake_case. Acronyms are kept as one word,
+// e.g. applicationID becomes application_id and HTTPServer becomes http_server.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
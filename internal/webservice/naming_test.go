@@ -0,0 +1,153 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"id":                 "id",
+		"queueName":          "queue_name",
+		"applicationID":      "application_id",
+		"partitionNodesUtil": "partition_nodes_util",
+		"HTTPServer":         "http_server",
+		"maxRunningApps":     "max_running_apps",
+		"vcore2Cores":        "vcore2_cores",
+		"already_snake":      "already_snake",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, snakeCase(name), name)
+	}
+}
+
+func TestSnakeCaseFields(t *testing.T) {
+	type inner struct {
+		QueueName string `json:"queueName"`
+		Shadowed  string `json:"createdAt"`
+	}
+	type outer struct {
+		ApplicationID string           `json:"applicationID"`
+		CreatedAt     time.Time        `json:"createdAt"`
+		Resource      map[string]int64 `json:"allocatedResource"`
+		Optional      *string          `json:"optionalField,omitempty"`
+		Hidden        string           `json:"-"`
+		Untagged      int
+		Raw           []byte  `json:"rawBytes"`
+		Children      []inner `json:"children"`
+		inner
+	}
+	value := outer{
+		ApplicationID: "app-1",
+		CreatedAt:     time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		Resource:      map[string]int64{"memory": 1, "nvidia.com/gpu": 2},
+		Hidden:        "hidden",
+		Untagged:      3,
+		Raw:           []byte("raw"),
+		Children:      []inner{{QueueName: "root.child"}},
+		inner:         inner{QueueName: "root.default", Shadowed: "shadowed"},
+	}
+
+	fields, err := snakeCaseFields(reflect.ValueOf(&value))
+	require.NoError(t, err)
+	b, err := json.Marshal(fields)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"application_id": "app-1",
+		"created_at": "2024-07-01T12:00:00Z",
+		"allocated_resource": {"memory": 1, "nvidia.com/gpu": 2},
+		"untagged": 3,
+		"raw_bytes": "cmF3",
+		"children": [{"queue_name": "root.child", "created_at": ""}],
+		"queue_name": "root.default"
+	}`, string(b))
+}
+
+func TestNegotiateFieldNaming(t *testing.T) {
+	camelCaseFields := map[string]any{"applicationID": "app-1", "queueName": "root.default"}
+	snakeCaseFields := map[string]any{"application_id": "app-1", "queue_name": "root.default"}
+	tests := map[string]struct {
+		configured string
+		header     string
+		wantStatus int
+		want       map[string]any
+		notWant    map[string]any
+	}{
+		"default": {
+			wantStatus: http.StatusOK, want: camelCaseFields, notWant: snakeCaseFields,
+		},
+		"configured snake case": {
+			configured: config.FieldNamingSnakeCase, wantStatus: http.StatusOK, want: snakeCaseFields, notWant: camelCaseFields,
+		},
+		"header snake case": {
+			header: config.FieldNamingSnakeCase, wantStatus: http.StatusOK, want: snakeCaseFields, notWant: camelCaseFields,
+		},
+		"header overrides configured naming": {
+			configured: config.FieldNamingSnakeCase, header: config.FieldNamingCamelCase, wantStatus: http.StatusOK,
+			want: camelCaseFields, notWant: snakeCaseFields,
+		},
+		"invalid header": {
+			header: "kebab-case", wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ws := &WebService{fieldNaming: tt.configured}
+			handler := ws.negotiateFieldNaming(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				jsonResponse(w, r, dao.ApplicationDAOInfo{ApplicationID: "app-1", QueueName: "root.default"})
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/queue/root.default/applications", nil)
+			if tt.header != "" {
+				req.Header.Set(headerFieldNaming, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			for key, value := range tt.want {
+				assert.Equal(t, value, got[key], key)
+			}
+			for key := range tt.notWant {
+				assert.NotContains(t, got, key)
+			}
+		})
+	}
+}
+
+func TestWebServiceStreamSnakeCase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		StreamApplicationsHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error {
+			return fn(&dao.ApplicationHistoryDAOInfo{Timestamp: 1, TotalApplications: "2"})
+		})
+	ws := &WebService{repository: repo, fieldNaming: config.FieldNamingSnakeCase}
+
+	req := httptest.NewRequest(http.MethodGet, routeAppsHistory, nil)
+	req.Header.Set("Accept", ndjsonContentType)
+	rec := httptest.NewRecorder()
+	ws.negotiateFieldNaming(http.HandlerFunc(ws.getAppsHistory)).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"timestamp": 1, "total_applications": "2"}`, rec.Body.String())
+}
@@ -0,0 +1,91 @@
+package webservice
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+const (
+	// ndjsonContentType is the media type of newline delimited JSON, with one JSON object per line.
+	ndjsonContentType = "application/x-ndjson"
+	// ndjsonFlushRows is the number of rows after which a streamed response is flushed to the client.
+	ndjsonFlushRows = 100
+)
+
+// acceptsNDJSON reports whether the client requested a newline delimited JSON response.
+func acceptsNDJSON(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err == nil && mediaType == ndjsonContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ndjsonStream writes rows to the response as newline delimited JSON as they are read from the database,
+// so that memory usage does not depend on the number of rows.
+type ndjsonStream struct {
+	w       http.ResponseWriter
+	r       *http.Request
+	encoder *json.Encoder
+	rows    int
+}
+
+func newNDJSONStream(w http.ResponseWriter, r *http.Request) *ndjsonStream {
+	return &ndjsonStream{w: w, r: r, encoder: json.NewEncoder(w)}
+}
+
+// Write writes a row to the response, which is sent with the first row.
+func (s *ndjsonStream) Write(row any) error {
+	row, err := renderRow(s.r, row)
+	if err != nil {
+		return err
+	}
+	if s.rows == 0 {
+		s.writeHeader()
+	}
+	if err := s.encoder.Encode(row); err != nil {
+		return err
+	}
+	s.rows++
+	if s.rows%ndjsonFlushRows == 0 {
+		s.flush()
+	}
+	return nil
+}
+
+// Close completes the response. If err is not nil and no row has been written yet, a problem response is written.
+// Otherwise, the response is aborted without terminating the chunked encoding, so that clients don't mistake
+// the truncated response for a complete one.
+func (s *ndjsonStream) Close(err error) {
+	if err != nil {
+		if s.rows == 0 {
+			errorResponse(s.w, s.r, err)
+			return
+		}
+		log.FromContext(s.r.Context()).Errorf("could not stream response for %s after %d rows: %v", s.r.URL.Path, s.rows, err)
+		panic(http.ErrAbortHandler)
+	}
+	if s.rows == 0 {
+		s.writeHeader()
+	}
+	s.flush()
+}
+
+func (s *ndjsonStream) writeHeader() {
+	s.w.Header().Set("Content-Type", ndjsonContentType)
+	s.w.WriteHeader(http.StatusOK)
+}
+
+func (s *ndjsonStream) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,146 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestAcceptsNDJSON(t *testing.T) {
+	tests := map[string]struct {
+		accept []string
+		want   bool
+	}{
+		"no accept header":   {want: false},
+		"json":               {accept: []string{"application/json"}, want: false},
+		"ndjson":             {accept: []string{"application/x-ndjson"}, want: true},
+		"ndjson with q":      {accept: []string{"application/json;q=0.5, application/x-ndjson; q=0.9"}, want: true},
+		"multiple headers":   {accept: []string{"text/html", "application/x-ndjson"}, want: true},
+		"invalid media type": {accept: []string{";;"}, want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, routeAppsHistory, nil)
+			for _, accept := range tt.accept {
+				req.Header.Add("Accept", accept)
+			}
+			assert.Equal(t, tt.want, acceptsNDJSON(req))
+		})
+	}
+}
+
+func TestWebServiceStreamAppsPerPartitionPerQueue(t *testing.T) {
+	apps := make([]*model.ApplicationDAOInfo, ndjsonFlushRows+1)
+	for i := range apps {
+		apps[i] = &model.ApplicationDAOInfo{ApplicationDAOInfo: dao.ApplicationDAOInfo{ApplicationID: fmt.Sprintf("app-%d", i)}}
+	}
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		StreamAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _, _ string, _ repository.ApplicationFilters, fn func(*model.ApplicationDAOInfo) error,
+		) error {
+			for _, app := range apps {
+				if err := fn(app); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	ws := &WebService{repository: repo}
+
+	req := httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/queue/root.default/applications", nil)
+	req.Header.Set("Accept", ndjsonContentType)
+	rec := httptest.NewRecorder()
+	ws.getAppsPerPartitionPerQueue(rec, req, httprouter.Params{
+		{Key: paramsPartitionName, Value: "default"},
+		{Key: paramsQueueName, Value: "root.default"},
+	})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ndjsonContentType, rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	require.Len(t, lines, len(apps))
+	for i, line := range lines {
+		var app model.ApplicationDAOInfo
+		require.NoError(t, json.Unmarshal([]byte(line), &app))
+		assert.Equal(t, apps[i].ApplicationID, app.ApplicationID)
+	}
+}
+
+func TestWebServiceStreamHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().StreamContainersHistory(gomock.Any(), gomock.Any()).Return(nil)
+	ws := &WebService{repository: repo}
+
+	req := httptest.NewRequest(http.MethodGet, routeContainersHistory, nil)
+	req.Header.Set("Accept", ndjsonContentType)
+	rec := httptest.NewRecorder()
+	ws.getContainersHistory(rec, req)
+
+	// an empty result is an empty body
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ndjsonContentType, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestWebServiceStreamErrors(t *testing.T) {
+	errDB := errors.New("connection reset")
+
+	t.Run("before first row", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		repo := repository.NewMockRepository(mockCtrl)
+		repo.EXPECT().StreamApplicationsHistory(gomock.Any(), gomock.Any()).Return(errDB)
+		ws := &WebService{repository: repo}
+
+		req := httptest.NewRequest(http.MethodGet, routeAppsHistory, nil)
+		req.Header.Set("Accept", ndjsonContentType)
+		rec := httptest.NewRecorder()
+		ws.getAppsHistory(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("after first row", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		repo := repository.NewMockRepository(mockCtrl)
+		repo.EXPECT().StreamApplicationsHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(*dao.ApplicationHistoryDAOInfo) error) error {
+				if err := fn(&dao.ApplicationHistoryDAOInfo{TotalApplications: "1"}); err != nil {
+					return err
+				}
+				return errDB
+			})
+		ws := &WebService{repository: repo}
+
+		req := httptest.NewRequest(http.MethodGet, routeAppsHistory, nil)
+		req.Header.Set("Accept", ndjsonContentType)
+		rec := httptest.NewRecorder()
+
+		// the response is aborted, as its status has already been sent
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			ws.getAppsHistory(rec, req)
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,176 @@
+package webservice
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// WithNodeGroups sets the groups of nodes whose utilization is aggregated.
+// If not set, all nodes are ungrouped.
+func WithNodeGroups(groups []config.NodeGroup) Option {
+	return func(ws *WebService) {
+		ws.nodeGroups = groups
+	}
+}
+
+// getNodeGroups returns the utilization of the nodes of a partition aggregated per node group, in the order
+// the groups are configured. A node is counted in every group whose selector it matches, and the nodes which
+// are not in any group are aggregated in the ungrouped group, which is returned last if there are any.
+// Following query params are supported:
+// - by: aggregate the nodes per position in a dimension of the topology instead, zone or rack
+func (ws *WebService) getNodeGroups(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	q := newQueryParams(r)
+	by := topologyDimension(q)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	nodes, err := ws.repository.GetNodesPerPartition(r.Context(), partition)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+
+	var groups []*model.NodeGroupUtilization
+	if by != "" {
+		positions, err := ws.nodePositions(r, partition, by)
+		if err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+		groups = topologyGroups(nodes, positions, by)
+	} else {
+		groups = ws.configuredGroups(nodes)
+	}
+	for _, g := range groups {
+		for resource, capacity := range g.Capacity {
+			if capacity > 0 {
+				g.Utilization[resource] = float64(g.Allocated[resource]) / float64(capacity)
+			}
+		}
+	}
+	jsonResponse(w, r, groups)
+}
+
+// configuredGroups aggregates the nodes per configured node group, followed by the ungrouped nodes if any.
+func (ws *WebService) configuredGroups(nodes []*dao.NodeDAOInfo) []*model.NodeGroupUtilization {
+	groups := make([]*model.NodeGroupUtilization, len(ws.nodeGroups))
+	for i, g := range ws.nodeGroups {
+		groups[i] = newNodeGroupUtilization(g.Name, g.Selector)
+	}
+	ungrouped := newNodeGroupUtilization(config.UngroupedNodeGroup, map[string]string{})
+	for _, node := range nodes {
+		grouped := false
+		for i, g := range ws.nodeGroups {
+			if g.Matches(node.Attributes) {
+				addNode(groups[i], node)
+				grouped = true
+			}
+		}
+		if !grouped {
+			addNode(ungrouped, node)
+		}
+	}
+	if ungrouped.Nodes > 0 {
+		groups = append(groups, ungrouped)
+	}
+	return groups
+}
+
+// topologyGroups aggregates the nodes per position in a dimension of the topology, sorted by position, followed by
+// the nodes whose position is unknown in the ungrouped group if any.
+func topologyGroups(
+	nodes []*dao.NodeDAOInfo, positions map[string]string, by model.TopologyDimension) []*model.NodeGroupUtilization {
+	byPosition := make(map[string]*model.NodeGroupUtilization)
+	var groups []*model.NodeGroupUtilization
+	ungrouped := newNodeGroupUtilization(config.UngroupedNodeGroup, map[string]string{})
+	for _, node := range nodes {
+		position, ok := positions[node.NodeID]
+		if !ok {
+			addNode(ungrouped, node)
+			continue
+		}
+		group, ok := byPosition[position]
+		if !ok {
+			group = newNodeGroupUtilization(position, map[string]string{string(by): position})
+			byPosition[position] = group
+			groups = append(groups, group)
+		}
+		addNode(group, node)
+	}
+	slices.SortFunc(groups, func(a, b *model.NodeGroupUtilization) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	if ungrouped.Nodes > 0 {
+		groups = append(groups, ungrouped)
+	}
+	return groups
+}
+
+// topologyDimension returns the dimension of the topology in the by query param, empty if not set.
+func topologyDimension(q *queryParams) model.TopologyDimension {
+	by := q.String(queryParamBy)
+	if by == nil {
+		return ""
+	}
+	if dimension := model.TopologyDimension(*by); slices.Contains(model.TopologyDimensions, dimension) {
+		return dimension
+	}
+	q.invalidate(queryParamBy, "must be one of %v", model.TopologyDimensions)
+	return ""
+}
+
+// nodePositions returns the known positions of the nodes of a partition in a dimension of the topology by node ID.
+func (ws *WebService) nodePositions(
+	r *http.Request, partition string, by model.TopologyDimension) (map[string]string, error) {
+	topologies, err := ws.repository.GetNodeTopologies(r.Context(), partition)
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]string, len(topologies))
+	for _, t := range topologies {
+		if position := t.Position(by); position != nil {
+			positions[t.NodeID] = *position
+		}
+	}
+	return positions, nil
+}
+
+func newNodeGroupUtilization(name string, selector map[string]string) *model.NodeGroupUtilization {
+	return &model.NodeGroupUtilization{
+		Name:        name,
+		Selector:    selector,
+		Capacity:    map[string]int64{},
+		Allocated:   map[string]int64{},
+		Occupied:    map[string]int64{},
+		Available:   map[string]int64{},
+		Utilization: map[string]float64{},
+	}
+}
+
+// addNode adds the resources and allocations of a node to a group.
+func addNode(group *model.NodeGroupUtilization, node *dao.NodeDAOInfo) {
+	group.Nodes++
+	if node.Schedulable {
+		group.SchedulableNodes++
+	}
+	group.Allocations += len(node.Allocations)
+	addResources(group.Capacity, node.Capacity)
+	addResources(group.Allocated, node.Allocated)
+	addResources(group.Occupied, node.Occupied)
+	addResources(group.Available, node.Available)
+}
+
+func addResources(sum, resources map[string]int64) {
+	for resource, quantity := range resources {
+		sum[resource] += quantity
+	}
+}
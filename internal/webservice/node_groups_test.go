@@ -0,0 +1,137 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetNodeGroups(t *testing.T) {
+	nodes := []*dao.NodeDAOInfo{
+		{
+			NodeID:      "gpu-1",
+			Attributes:  map[string]string{"si/instance-type": "p3.2xlarge", "zone": "a"},
+			Capacity:    map[string]int64{"vcore": 8000, "nvidia.com/gpu": 1},
+			Allocated:   map[string]int64{"vcore": 6000, "nvidia.com/gpu": 1},
+			Available:   map[string]int64{"vcore": 2000},
+			Allocations: []*dao.AllocationDAOInfo{{AllocationKey: "pod-1"}, {AllocationKey: "pod-2"}},
+			Schedulable: true,
+		},
+		{
+			NodeID:     "gpu-2",
+			Attributes: map[string]string{"si/instance-type": "p3.2xlarge", "zone": "b"},
+			Capacity:   map[string]int64{"vcore": 8000, "nvidia.com/gpu": 1},
+			Available:  map[string]int64{"vcore": 8000, "nvidia.com/gpu": 1},
+		},
+		{
+			NodeID:      "cpu-1",
+			Attributes:  map[string]string{"si/instance-type": "m5.xlarge", "zone": "a"},
+			Capacity:    map[string]int64{"vcore": 4000},
+			Allocated:   map[string]int64{"vcore": 1000},
+			Available:   map[string]int64{"vcore": 3000},
+			Allocations: []*dao.AllocationDAOInfo{{AllocationKey: "pod-3"}},
+			Schedulable: true,
+		},
+	}
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().GetNodesPerPartition(gomock.Any(), "default").Return(nodes, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithNodeGroups([]config.NodeGroup{
+		{Name: "gpu-pool", Selector: map[string]string{"si/instance-type": "p3.2xlarge"}},
+		{Name: "zone-a", Selector: map[string]string{"zone": "a"}},
+		{Name: "zone-c", Selector: map[string]string{"zone": "c"}},
+	}))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/node-groups", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var groups []*model.NodeGroupUtilization
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &groups))
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, &model.NodeGroupUtilization{
+		Name:             "gpu-pool",
+		Selector:         map[string]string{"si/instance-type": "p3.2xlarge"},
+		Nodes:            2,
+		SchedulableNodes: 1,
+		Allocations:      2,
+		Capacity:         map[string]int64{"vcore": 16000, "nvidia.com/gpu": 2},
+		Allocated:        map[string]int64{"vcore": 6000, "nvidia.com/gpu": 1},
+		Occupied:         map[string]int64{},
+		Available:        map[string]int64{"vcore": 10000, "nvidia.com/gpu": 1},
+		Utilization:      map[string]float64{"vcore": 0.375, "nvidia.com/gpu": 0.5},
+	}, groups[0])
+	assert.Equal(t, "zone-a", groups[1].Name)
+	assert.Equal(t, 2, groups[1].Nodes)
+	assert.Equal(t, 3, groups[1].Allocations)
+	assert.Equal(t, map[string]float64{"vcore": 7000.0 / 12000, "nvidia.com/gpu": 1}, groups[1].Utilization)
+	assert.Equal(t, "zone-c", groups[2].Name)
+	assert.Zero(t, groups[2].Nodes)
+}
+
+func TestWebServiceGetNodeGroupsUngrouped(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().GetNodesPerPartition(gomock.Any(), "default").Return([]*dao.NodeDAOInfo{
+		{NodeID: "node-1", Capacity: map[string]int64{"memory": 1000}, Allocated: map[string]int64{"memory": 250}},
+	}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/node-groups", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var groups []*model.NodeGroupUtilization
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &groups))
+	require.Len(t, groups, 1)
+	assert.Equal(t, config.UngroupedNodeGroup, groups[0].Name)
+	assert.Equal(t, 1, groups[0].Nodes)
+	assert.Equal(t, map[string]float64{"memory": 0.25}, groups[0].Utilization)
+}
+
+func TestWebServiceGetNodeGroupsByTopology(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().GetNodesPerPartition(gomock.Any(), "default").Return([]*dao.NodeDAOInfo{
+		{NodeID: "node-1", Capacity: map[string]int64{"vcore": 4000}, Allocated: map[string]int64{"vcore": 3000}},
+		{NodeID: "node-2", Capacity: map[string]int64{"vcore": 4000}, Allocated: map[string]int64{"vcore": 1000}},
+		{NodeID: "node-3", Capacity: map[string]int64{"vcore": 4000}},
+		{NodeID: "node-4", Capacity: map[string]int64{"vcore": 4000}},
+	}, nil)
+	repo.EXPECT().GetNodeTopologies(gomock.Any(), "default").Return([]*model.NodeTopology{
+		{NodeID: "node-1", Partition: "default", Zone: util.ToPtr("b")},
+		{NodeID: "node-2", Partition: "default", Zone: util.ToPtr("a")},
+		{NodeID: "node-3", Partition: "default", Zone: util.ToPtr("b")},
+		{NodeID: "node-4", Partition: "default"},
+	}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/node-groups?by=zone", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var groups []*model.NodeGroupUtilization
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &groups))
+	require.Len(t, groups, 3)
+	assert.Equal(t, "a", groups[0].Name)
+	assert.Equal(t, map[string]string{"zone": "a"}, groups[0].Selector)
+	assert.Equal(t, "b", groups[1].Name)
+	assert.Equal(t, 2, groups[1].Nodes)
+	assert.Equal(t, map[string]float64{"vcore": 0.375}, groups[1].Utilization)
+	assert.Equal(t, config.UngroupedNodeGroup, groups[2].Name)
+	assert.Equal(t, 1, groups[2].Nodes)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/node-groups?by=region", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,112 @@
+package webservice
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// nodeImpactReport is the response of the impact report of a node.
+type nodeImpactReport struct {
+	NodeID      string `json:"nodeId"`
+	Partition   string `json:"partition"`
+	Schedulable bool   `json:"schedulable"`
+	// Allocations is the number of allocations running on the node, and Allocated the sum of their resources.
+	Allocations int              `json:"allocations"`
+	Allocated   map[string]int64 `json:"allocated"`
+	// AvailableElsewhere is the sum of the available resources of the other schedulable nodes of the partition, and
+	// Fits whether it covers the resources allocated on the node. As the allocations cannot be split across nodes,
+	// and may be constrained to some nodes, they may not be rescheduled even if they fit.
+	AvailableElsewhere map[string]int64               `json:"availableElsewhere"`
+	Fits               bool                           `json:"fits"`
+	Applications       []*model.NodeImpactApplication `json:"applications"`
+}
+
+// getNodeImpact estimates the impact of draining a node, e.g. for maintenance, from its current allocations: the
+// applications running on it with the allocations they would lose, and whether the other schedulable nodes of its
+// partition have the available resources to run the allocated resources of the node.
+func (ws *WebService) getNodeImpact(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	node, err := ws.repository.GetNode(r.Context(), params.ByName(paramsNodeID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			notFoundResponse(w, r, err)
+			return
+		}
+		errorResponse(w, r, err)
+		return
+	}
+	nodes, err := ws.repository.GetNodesPerPartition(r.Context(), node.Partition)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, nodeImpact(node, nodes))
+}
+
+// nodeImpact aggregates the allocations of the node per application, sorted by the number of allocations on the
+// node in descending order, and compares its allocated resources to the available resources of the other nodes.
+func nodeImpact(node *model.NodeDAOInfo, nodes []*dao.NodeDAOInfo) *nodeImpactReport {
+	report := &nodeImpactReport{
+		NodeID:             node.NodeID,
+		Partition:          node.Partition,
+		Schedulable:        node.Schedulable,
+		Allocations:        len(node.Allocations),
+		Allocated:          map[string]int64{},
+		AvailableElsewhere: map[string]int64{},
+		Applications:       []*model.NodeImpactApplication{},
+	}
+	byApp := make(map[string]*model.NodeImpactApplication)
+	for _, alloc := range node.Allocations {
+		app, ok := byApp[alloc.ApplicationID]
+		if !ok {
+			app = &model.NodeImpactApplication{ApplicationID: alloc.ApplicationID, Resource: map[string]int64{}}
+			byApp[alloc.ApplicationID] = app
+			report.Applications = append(report.Applications, app)
+		}
+		app.Allocations++
+		app.TotalAllocations++
+		if alloc.Placeholder {
+			app.Placeholders++
+		}
+		addResources(app.Resource, alloc.ResourcePerAlloc)
+		addResources(report.Allocated, alloc.ResourcePerAlloc)
+	}
+
+	for _, other := range nodes {
+		if other.NodeID == node.NodeID {
+			continue
+		}
+		if other.Schedulable {
+			addResources(report.AvailableElsewhere, other.Available)
+		}
+		for _, alloc := range other.Allocations {
+			if app, ok := byApp[alloc.ApplicationID]; ok {
+				app.TotalAllocations++
+			}
+		}
+	}
+	for _, app := range report.Applications {
+		app.Share = float64(app.Allocations) / float64(app.TotalAllocations)
+	}
+	report.Fits = true
+	for resource, allocated := range report.Allocated {
+		if allocated > report.AvailableElsewhere[resource] {
+			report.Fits = false
+		}
+	}
+
+	slices.SortFunc(report.Applications, func(a, b *model.NodeImpactApplication) int {
+		return cmp.Or(
+			cmp.Compare(b.Allocations, a.Allocations),
+			cmp.Compare(a.ApplicationID, b.ApplicationID),
+		)
+	})
+	return report
+}
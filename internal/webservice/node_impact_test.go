@@ -0,0 +1,81 @@
+package webservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestWebServiceGetNodeImpact(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	node := dao.NodeDAOInfo{
+		NodeID:      "node-1",
+		Schedulable: false,
+		Allocations: []*dao.AllocationDAOInfo{
+			{ApplicationID: "app-1", ResourcePerAlloc: map[string]int64{"vcore": 1000, "memory": 512}},
+			{ApplicationID: "app-2", ResourcePerAlloc: map[string]int64{"vcore": 500}},
+			{ApplicationID: "app-1", ResourcePerAlloc: map[string]int64{"vcore": 1000, "memory": 512}, Placeholder: true},
+		},
+	}
+	repo.EXPECT().GetNode(gomock.Any(), "node-1").Return(&model.NodeDAOInfo{Partition: "default", NodeDAOInfo: node}, nil)
+	repo.EXPECT().GetNode(gomock.Any(), "node-9").Return(nil, fmt.Errorf("%w: node-9", repository.ErrNodeNotFound))
+	repo.EXPECT().GetNodesPerPartition(gomock.Any(), "default").Return([]*dao.NodeDAOInfo{
+		&node,
+		{
+			NodeID:      "node-2",
+			Schedulable: true,
+			Available:   map[string]int64{"vcore": 4000, "memory": 512},
+			Allocations: []*dao.AllocationDAOInfo{{ApplicationID: "app-2"}, {ApplicationID: "app-3"}},
+		},
+		{NodeID: "node-3", Schedulable: false, Available: map[string]int64{"vcore": 4000, "memory": 4096}},
+	}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/node/node-1/impact", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"nodeId": "node-1",
+		"partition": "default",
+		"schedulable": false,
+		"allocations": 3,
+		"allocated": {"vcore": 2500, "memory": 1024},
+		"availableElsewhere": {"vcore": 4000, "memory": 512},
+		"fits": false,
+		"applications": [
+			{
+				"applicationId": "app-1", "allocations": 2, "placeholders": 1, "resource": {"vcore": 2000, "memory": 1024},
+				"totalAllocations": 2, "share": 1
+			},
+			{
+				"applicationId": "app-2", "allocations": 1, "placeholders": 0, "resource": {"vcore": 500},
+				"totalAllocations": 2, "share": 0.5
+			}
+		]
+	}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/node/node-9/impact", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestNodeImpactWithoutAllocations(t *testing.T) {
+	node := &model.NodeDAOInfo{Partition: "default", NodeDAOInfo: dao.NodeDAOInfo{NodeID: "node-1", Schedulable: true}}
+	report := nodeImpact(node, []*dao.NodeDAOInfo{&node.NodeDAOInfo})
+	assert.True(t, report.Fits)
+	assert.Empty(t, report.Applications)
+	assert.Equal(t, 0, report.Allocations)
+}
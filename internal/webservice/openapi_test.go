@@ -0,0 +1,89 @@
+package webservice
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/yunikorn-history-server/api"
+	"github.com/G-Research/yunikorn-history-server/internal/autoscaler"
+	"github.com/G-Research/yunikorn-history-server/internal/broadcast"
+	"github.com/G-Research/yunikorn-history-server/internal/canary"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+	"github.com/G-Research/yunikorn-history-server/internal/snapshot"
+)
+
+var routeParam = regexp.MustCompile(`:([a-z_]+)`)
+
+// TestOpenAPISpecMatchesRoutes ensures that every registered API route is documented in the OpenAPI
+// specification, from which the Go client is generated, and that the specification does not document unknown routes.
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]any `yaml:"paths"`
+	}
+	require.NoError(t, yaml.Unmarshal(api.Spec, &spec))
+
+	var documented []string
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(documented)
+
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{AdminOverrides: true})
+	require.NoError(t, err)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil,
+		WithFeatureFlags(featureFlags), WithFaultInjector(faultinject.New()), WithIngest(), WithTraces(), WithExports(newTestExports(t)),
+		WithSnapshots(snapshot.NewManager(nil, &config.SnapshotsConfig{TTL: time.Minute, MaxOpen: 1})),
+		WithAutoscalerEvents(autoscaler.NewConverter(config.DefaultAutoscalerSourceComponents)),
+		WithLiveApplications(&fakeLiveApplications{}), WithEventStream(broadcast.NewHub(0)),
+		WithCanary(canary.New(nil, []canary.Candidate{
+			{Method: "QueryAnalytics", New: func(repo repository.Repository) repository.Repository { return repo }},
+		})))
+	ws.init(context.Background())
+
+	var registered []string
+	for _, r := range ws.routes {
+		registered = append(registered, r.method+" "+routeParam.ReplaceAllString(r.path, "{$1}"))
+	}
+	sort.Strings(registered)
+
+	assert.Equal(t, documented, registered, "api/openapi.yaml is out of sync with the registered routes")
+}
+
+// TestOpenAPISpecPageSizes ensures that the default and maximum limits documented in the OpenAPI specification
+// are the built-in page sizes.
+func TestOpenAPISpecPageSizes(t *testing.T) {
+	var spec struct {
+		Components struct {
+			Parameters map[string]struct {
+				Schema struct {
+					Maximum int `yaml:"maximum"`
+					Default int `yaml:"default"`
+				} `yaml:"schema"`
+			} `yaml:"parameters"`
+		} `yaml:"components"`
+	}
+	require.NoError(t, yaml.Unmarshal(api.Spec, &spec))
+
+	for _, name := range []string{"ApplicationsLimit", "LegalHoldsLimit", "NodesLimit", "HistoryLimit"} {
+		param, ok := spec.Components.Parameters[name]
+		require.Truef(t, ok, "parameter %s is not documented", name)
+		assert.Equalf(t, config.DefaultPageSize.MaxLimit, param.Schema.Maximum, "maximum of %s", name)
+		assert.Equalf(t, config.DefaultPageSize.DefaultLimit, param.Schema.Default, "default of %s", name)
+	}
+}
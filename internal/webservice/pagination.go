@@ -0,0 +1,70 @@
+package webservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+const (
+	// headerTotalCount is set on the pages of paginated endpoints to the number of items of all pages.
+	headerTotalCount = "X-Total-Count"
+	// headerNextCursor is set on the pages of keyset paginated endpoints to the cursor of the next page, if any.
+	headerNextCursor = "X-Next-Cursor"
+	headerLink       = "Link"
+	// cachedPageMarker starts the cached responses which are prefixed with their page headers, as JSON never does.
+	cachedPageMarker = 'P'
+)
+
+// pageHeaders are the headers describing a page, which are cached and kept with the body of its response.
+var pageHeaders = []string{headerTotalCount, headerNextCursor, headerLink}
+
+// setPageHeaders sets the headers of a page of a paginated endpoint, whose body stays a bare array: X-Total-Count
+// to the number of items of all pages and, if there is a next page, X-Next-Cursor to its cursor and a Link header
+// to its URL relative to the URL of the page, with the cursor instead of the offset.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, page repository.PageInfo) {
+	w.Header().Set(headerTotalCount, strconv.Itoa(page.Total))
+	if page.Next == nil {
+		return
+	}
+	cursor := page.Next.String()
+	w.Header().Set(headerNextCursor, cursor)
+	query := r.URL.Query()
+	query.Del(queryParamOffset)
+	query.Set(queryParamCursor, cursor)
+	w.Header().Set(headerLink, `<?`+query.Encode()+`>; rel="next"`)
+}
+
+// encodeCachedResponse returns the body of a response to be cached, prefixed with its page headers, if any, so that
+// they are served with the body. Responses without page headers are cached as is.
+func encodeCachedResponse(header http.Header, body []byte) []byte {
+	values := make(map[string]string)
+	for _, name := range pageHeaders {
+		if value := header.Get(name); value != "" {
+			values[name] = value
+		}
+	}
+	if len(values) == 0 {
+		return body
+	}
+	prefix, _ := json.Marshal(values)
+	return append(append(append([]byte{cachedPageMarker}, prefix...), '\n'), body...)
+}
+
+// decodeCachedResponse sets the page headers of a response cached by encodeCachedResponse and returns its body.
+func decodeCachedResponse(header http.Header, cached []byte) []byte {
+	if len(cached) == 0 || cached[0] != cachedPageMarker {
+		return cached
+	}
+	prefix, body, _ := bytes.Cut(cached[1:], []byte{'\n'})
+	var values map[string]string
+	if err := json.Unmarshal(prefix, &values); err == nil {
+		for name, value := range values {
+			header.Set(name, value)
+		}
+	}
+	return body
+}
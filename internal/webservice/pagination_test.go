@@ -0,0 +1,140 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestSetPageHeaders(t *testing.T) {
+	next := &repository.Cursor{Keys: []int64{100}, ID: "app-1"}
+	r := httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/queue/root.default/applications?limit=2&offset=4", nil)
+
+	rec := httptest.NewRecorder()
+	setPageHeaders(rec, r, repository.PageInfo{Total: 7, Next: next})
+	assert.Equal(t, "7", rec.Header().Get(headerTotalCount))
+	assert.Equal(t, next.String(), rec.Header().Get(headerNextCursor))
+	// the next page is selected with the cursor instead of the offset
+	want := url.Values{queryParamLimit: {"2"}, queryParamCursor: {next.String()}}
+	assert.Equal(t, `<?`+want.Encode()+`>; rel="next"`, rec.Header().Get(headerLink))
+
+	rec = httptest.NewRecorder()
+	setPageHeaders(rec, r, repository.PageInfo{Total: 7})
+	assert.Equal(t, "7", rec.Header().Get(headerTotalCount))
+	assert.Empty(t, rec.Header().Get(headerNextCursor))
+	assert.Empty(t, rec.Header().Get(headerLink))
+}
+
+func TestCachedResponse(t *testing.T) {
+	body := []byte(`[{"nodeID":"node-1"}]`)
+	assert.Equal(t, body, encodeCachedResponse(http.Header{}, body))
+
+	header := http.Header{}
+	header.Set(headerTotalCount, "3")
+	header.Set(headerNextCursor, "abc")
+	header.Set("Content-Type", "application/json")
+	cached := encodeCachedResponse(header, body)
+
+	decoded := http.Header{}
+	assert.Equal(t, body, decodeCachedResponse(decoded, cached))
+	assert.Equal(t, http.Header{headerTotalCount: {"3"}, headerNextCursor: {"abc"}}, decoded)
+}
+
+func TestWebServiceGetAppsPerPartitionPerQueuePage(t *testing.T) {
+	cursor := &repository.Cursor{Keys: []int64{100}, ID: "app-2"}
+	next := &repository.Cursor{Keys: []int64{50}, ID: "app-1"}
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueuePage(gomock.Any(), "default", "root.default", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, filters repository.ApplicationFilters) (
+			[]*model.ApplicationDAOInfo, repository.PageInfo, error) {
+			assert.Equal(t, cursor, filters.After)
+			assert.Equal(t, util.ToPtr(1), filters.Limit)
+			return []*model.ApplicationDAOInfo{{}}, repository.PageInfo{Total: 5, Next: next}, nil
+		})
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueuePage(gomock.Any(), "default", "root.default", gomock.Any()).
+		Return(nil, repository.PageInfo{}, repository.ErrInvalidCursor)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/ws/v1/partition/default/queue/root.default/applications?"+query, nil))
+		return rec
+	}
+
+	rec := get("limit=1&cursor=" + cursor.String())
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "5", rec.Header().Get(headerTotalCount))
+	assert.Equal(t, next.String(), rec.Header().Get(headerNextCursor))
+
+	// a cursor of another order is rejected by the repository
+	rec = get("orderBy=duration&cursor=" + cursor.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	for _, query := range []string{"cursor=invalid", "offset=2&cursor=" + cursor.String()} {
+		rec = get(query)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		assert.Contains(t, rec.Body.String(), queryParamCursor, query)
+	}
+}
+
+func TestWebServiceGetNodesPerPartitionPage(t *testing.T) {
+	next := &repository.Cursor{ID: "node-1"}
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetNodesPerPartitionPage(gomock.Any(), "default", repository.Page{Limit: util.ToPtr(1)}).
+		Return([]*dao.NodeDAOInfo{{NodeID: "node-1"}}, repository.PageInfo{Total: 2, Next: next}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithCache(cache.NewWithBackend(cache.NewMemoryBackend(100), time.Minute, time.Second)))
+	ws.init(context.Background())
+
+	// the page headers are served with the cached responses
+	for _, want := range []string{"MISS", "HIT"} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/nodes?limit=1", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, want, rec.Header().Get(headerCache))
+		assert.Equal(t, "2", rec.Header().Get(headerTotalCount))
+		assert.Equal(t, next.String(), rec.Header().Get(headerNextCursor))
+		assert.JSONEq(t, `[{"nodeID":"node-1","schedulable":false,"isReserved":false}]`, rec.Body.String())
+	}
+}
+
+func TestWebServiceGetHistoryPageSize(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetContainersHistoryPage(gomock.Any(), repository.Page{Limit: util.ToPtr(5)}).
+		Return([]*dao.ContainerHistoryDAOInfo{}, repository.PageInfo{}, nil)
+	ws := NewWebService(&config.YHSConfig{
+		Port:      8080,
+		PageSizes: config.PageSizesConfig{History: config.PageSizeConfig{DefaultLimit: 5, MaxLimit: 10}},
+	}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/history/containers", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/history/apps?limit=11", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
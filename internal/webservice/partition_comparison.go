@@ -0,0 +1,179 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	siCommon "github.com/apache/yunikorn-scheduler-interface/lib/go/common"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// defaultPartitionComparisonPeriod is the period before now of which the partitions are compared by default.
+const defaultPartitionComparisonPeriod = 7 * 24 * time.Hour
+
+// partitionWaitTimeAggregates are the aggregates of the analytics query of the wait times of the partitions.
+var partitionWaitTimeAggregates = []repository.AnalyticsAggregate{
+	{Function: sql.Count},
+	{Function: sql.Median, Field: "waitTime"},
+	{Function: sql.Percentile95, Field: "waitTime"},
+	{Function: sql.Percentile99, Field: "waitTime"},
+	{Function: sql.Maximum, Field: "waitTime"},
+}
+
+// partitionComparison is the response of the partition comparison.
+type partitionComparison struct {
+	From       time.Time                    `json:"from"`
+	To         time.Time                    `json:"to"`
+	Partitions []*model.PartitionComparison `json:"partitions"`
+}
+
+// getPartitionComparison compares the utilization, the throughput and the wait times of the partitions side by side,
+// e.g. to review partitions used for different environments.
+// Following query params are supported:
+// - from: the start of the period, rounded down to the hour, 7 days before the end by default
+// - to: the end of the period, rounded down to the hour, now by default
+// - duplicates: count the applications in several clusters once in the wait times, by their canonical run selected
+// by the strategy
+// - tz: timezone of the time params without offset and of the period of the response, UTC by default
+//
+// The partitions are ordered by name, and include the partitions which are no longer known but had applications
+// submitted during the period.
+func (ws *WebService) getPartitionComparison(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	duplicates := q.DuplicateStrategy(queryParamDuplicates)
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	if from == nil {
+		from = util.ToPtr(to.Add(-defaultPartitionComparisonPeriod))
+		if from.Before(minTime) {
+			from = &minTime
+		}
+	}
+	// the usage rollups and the throughput counters are maintained per hour
+	start := time.Unix(0, from.UnixNano()-from.UnixNano()%repository.UsageRollupWidth.Nanoseconds()).UTC()
+	end := time.Unix(0, to.UnixNano()-to.UnixNano()%repository.UsageRollupWidth.Nanoseconds()).UTC()
+	if !start.Before(end) {
+		q.invalidate(queryParamTo, "must be in a later hour than %s", queryParamFrom)
+		badRequestResponse(w, r, q.Err())
+		return
+	}
+
+	comparisons, err := ws.comparePartitions(r.Context(), start, end, duplicates)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, partitionComparison{From: start.In(loc), To: end.In(loc), Partitions: comparisons})
+}
+
+// comparePartitions returns the activity of the partitions between start and end, which are aligned to the hour.
+// The wait times merge the runs of the applications in several clusters by the duplicates strategy, unless it is
+// empty, while the usage and the throughput count every run, as every run used resources.
+func (ws *WebService) comparePartitions(
+	ctx context.Context,
+	start, end time.Time,
+	duplicates repository.DuplicateStrategy,
+) ([]*model.PartitionComparison, error) {
+	byName := make(map[string]*model.PartitionComparison)
+	comparison := func(name string) *model.PartitionComparison {
+		c, ok := byName[name]
+		if !ok {
+			c = &model.PartitionComparison{Partition: name}
+			byName[name] = c
+		}
+		return c
+	}
+
+	partitions, err := ws.repository.GetAllPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range partitions {
+		comparison(p.Name).Capacity = p.Capacity.Capacity
+	}
+
+	rows, err := ws.repository.QueryAnalytics(ctx, repository.AnalyticsQuery{
+		Entity: "applications",
+		Filters: []repository.AnalyticsFilter{
+			{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{start}},
+			{Field: "submissionTime", Operator: sql.LessThan, Values: []any{end}},
+		},
+		GroupBy:    []string{"partition"},
+		Aggregates: partitionWaitTimeAggregates,
+		Limit:      maxAnalyticsRows,
+		Duplicates: duplicates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		c := comparison(groupValue[string](row, "partition"))
+		if count := row.Aggregates[partitionWaitTimeAggregates[0].Name()]; count != nil {
+			c.Applications = int64(*count)
+		}
+		c.MedianWaitTime = row.Aggregates[partitionWaitTimeAggregates[1].Name()]
+		c.P95WaitTime = row.Aggregates[partitionWaitTimeAggregates[2].Name()]
+		c.P99WaitTime = row.Aggregates[partitionWaitTimeAggregates[3].Name()]
+		c.MaxWaitTime = row.Aggregates[partitionWaitTimeAggregates[4].Name()]
+	}
+
+	comparisons := make([]*model.PartitionComparison, 0, len(byName))
+	for _, c := range byName {
+		comparisons = append(comparisons, c)
+	}
+	slices.SortFunc(comparisons, func(a, b *model.PartitionComparison) int {
+		return strings.Compare(a.Partition, b.Partition)
+	})
+
+	seconds := end.Sub(start).Seconds()
+	hours := end.Sub(start).Hours()
+	for _, c := range comparisons {
+		partition := c.Partition
+		hourlyUsage, err := ws.repository.GetHourlyUsage(ctx, repository.HourlyUsageFilters{
+			Partition: &partition, Start: start, End: end,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, hour := range hourlyUsage {
+			c.Vcores += hour.VcoreSeconds / seconds
+			c.Memory += hour.MemorySeconds / seconds
+		}
+		// the capacity of vcores is in millicores
+		if capacity := c.Capacity[siCommon.CPU]; capacity > 0 {
+			c.VcoreUtilization = util.ToPtr(c.Vcores * 1000 / float64(capacity))
+		}
+		if capacity := c.Capacity[siCommon.Memory]; capacity > 0 {
+			c.MemoryUtilization = util.ToPtr(c.Memory / float64(capacity))
+		}
+
+		throughput, err := ws.repository.GetQueueThroughput(ctx, repository.ThroughputFilters{
+			Partition: &partition, Start: start, End: end, Interval: repository.ThroughputBucketWidth,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, bucket := range throughput {
+			c.Started += bucket.Started
+			c.Completed += bucket.Completed
+			c.Failed += bucket.Failed
+		}
+		c.CompletedPerHour = float64(c.Completed) / hours
+	}
+	return comparisons, nil
+}
@@ -0,0 +1,104 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetPartitionComparison(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{
+		{Name: "prod", Capacity: dao.PartitionCapacity{Capacity: map[string]int64{"vcore": 8000, "memory": 1000}}},
+		{Name: "dev", Capacity: dao.PartitionCapacity{Capacity: map[string]int64{"vcore": 4000}}},
+	}, nil)
+	repo.EXPECT().QueryAnalytics(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+			assert.Equal(t, "applications", query.Entity)
+			assert.Equal(t, []repository.AnalyticsFilter{
+				{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{start}},
+				{Field: "submissionTime", Operator: sql.LessThan, Values: []any{end}},
+			}, query.Filters)
+			assert.Equal(t, []string{"partition"}, query.GroupBy)
+			assert.Equal(t, repository.DuplicateLatest, query.Duplicates)
+			return []*model.AnalyticsRow{
+				{
+					Groups: map[string]any{"partition": "prod"},
+					Aggregates: map[string]*float64{
+						"count": util.ToPtr(5.0), "p50(waitTime)": util.ToPtr(1e9), "p95(waitTime)": util.ToPtr(4e9),
+						"p99(waitTime)": util.ToPtr(6e9), "max(waitTime)": util.ToPtr(7e9),
+					},
+				},
+				{
+					Groups:     map[string]any{"partition": "staging"},
+					Aggregates: map[string]*float64{"count": util.ToPtr(1.0)},
+				},
+			}, nil
+		})
+	repo.EXPECT().GetHourlyUsage(gomock.Any(), repository.HourlyUsageFilters{
+		Partition: util.ToPtr("prod"), Start: start, End: end,
+	}).Return([]*model.HourlyUsage{
+		{Start: start, VcoreSeconds: 36000, MemorySeconds: 3600000},
+		{Start: start.Add(time.Hour), VcoreSeconds: 36000, MemorySeconds: 0},
+	}, nil)
+	repo.EXPECT().GetHourlyUsage(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	repo.EXPECT().GetQueueThroughput(gomock.Any(), repository.ThroughputFilters{
+		Partition: util.ToPtr("prod"), Start: start, End: end, Interval: repository.ThroughputBucketWidth,
+	}).Return([]*model.ThroughputBucket{
+		{Start: start, Started: 3, Completed: 2},
+		{Start: start.Add(time.Hour), Started: 1, Completed: 3, Failed: 1},
+	}, nil)
+	repo.EXPECT().GetQueueThroughput(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/analytics/partitions/compare?from=2024-07-01T00:30:00Z&to=2024-07-01T10:59:00Z&duplicates=latest", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"from": "2024-07-01T00:00:00Z",
+		"to": "2024-07-01T10:00:00Z",
+		"partitions": [
+			{"partition": "dev", "capacity": {"vcore": 4000}, "vcores": 0, "memory": 0,
+			 "vcoreUtilization": 0, "memoryUtilization": null, "applications": 0, "started": 0, "completed": 0,
+			 "failed": 0, "completedPerHour": 0, "medianWaitTime": null, "p95WaitTime": null, "p99WaitTime": null,
+			 "maxWaitTime": null},
+			{"partition": "prod", "capacity": {"vcore": 8000, "memory": 1000}, "vcores": 2, "memory": 100,
+			 "vcoreUtilization": 0.25, "memoryUtilization": 0.1, "applications": 5, "started": 4, "completed": 5,
+			 "failed": 1, "completedPerHour": 0.5, "medianWaitTime": 1e9, "p95WaitTime": 4e9, "p99WaitTime": 6e9,
+			 "maxWaitTime": 7e9},
+			{"partition": "staging", "capacity": null, "vcores": 0, "memory": 0,
+			 "vcoreUtilization": null, "memoryUtilization": null, "applications": 1, "started": 0, "completed": 0,
+			 "failed": 0, "completedPerHour": 0, "medianWaitTime": null, "p95WaitTime": null, "p99WaitTime": null,
+			 "maxWaitTime": null}
+		]
+	}`, rec.Body.String())
+
+	for _, target := range []string{
+		"/ws/v1/analytics/partitions/compare?from=2024-07-08&to=2024-07-01",
+		"/ws/v1/analytics/partitions/compare?from=2024-07-01T00:10:00Z&to=2024-07-01T00:50:00Z",
+		"/ws/v1/analytics/partitions/compare?duplicates=any",
+	} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
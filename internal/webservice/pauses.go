@@ -0,0 +1,156 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/pause"
+	"github.com/G-Research/yunikorn-history-server/internal/scheduler"
+)
+
+// ingestRetryAfter is the delay after which collectors are asked to retry the batches rejected while the ingestion
+// is paused.
+const ingestRetryAfter = 30 * time.Second
+
+var errIngestionPaused = errors.New("the ingestion is paused")
+
+type pauseRequest struct {
+	Reason string `json:"reason"`
+}
+
+// IngestionStatus is the status of the ingestion of the data of Yunikorn.
+type IngestionStatus struct {
+	// Paused is set while the ingestion is paused.
+	Paused bool `json:"paused"`
+	// Pause is the pause of the ingestion, if it is paused.
+	Pause *model.PausedOperation `json:"pause,omitempty"`
+}
+
+// WithPauses sets the controller pausing the ingestion and the periodic jobs. If not set, the pauses are stored in
+// the repository, but not reloaded from it.
+func WithPauses(c *pause.Controller) Option {
+	return func(ws *WebService) {
+		ws.pauses = c
+	}
+}
+
+// pauseJob pauses the job on all replicas until it is resumed, and returns its status. A run in progress is not
+// interrupted.
+func (ws *WebService) pauseJob(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name := params.ByName(paramsJobName)
+	if _, err := ws.scheduler.Status(name); err != nil {
+		jobErrorResponse(w, r, name, err)
+		return
+	}
+	reason, ok := pauseReason(w, r)
+	if !ok {
+		return
+	}
+	op, err := ws.pauses.Pause(r.Context(), name, requestPrincipal(r), reason)
+	if err != nil {
+		jobErrorResponse(w, r, name, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("job paused", "job", name, "reason", op.Reason)
+	ws.jobStatusResponse(w, r, name)
+}
+
+// resumeJob resumes the job, and returns its status. Resuming a job which is not paused has no effect.
+func (ws *WebService) resumeJob(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name := params.ByName(paramsJobName)
+	if _, err := ws.scheduler.Status(name); err != nil {
+		jobErrorResponse(w, r, name, err)
+		return
+	}
+	if err := ws.pauses.Resume(r.Context(), name); err != nil {
+		jobErrorResponse(w, r, name, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("job resumed", "job", name)
+	ws.jobStatusResponse(w, r, name)
+}
+
+func (ws *WebService) jobStatusResponse(w http.ResponseWriter, r *http.Request, name string) {
+	status, err := ws.scheduler.Status(name)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, status)
+}
+
+func jobErrorResponse(w http.ResponseWriter, r *http.Request, name string, err error) {
+	if errors.Is(err, scheduler.ErrJobNotFound) || errors.Is(err, pause.ErrUnknownOperation) {
+		notFoundResponse(w, r, fmt.Errorf("%w: %s", scheduler.ErrJobNotFound, name))
+		return
+	}
+	errorResponse(w, r, err)
+}
+
+func (ws *WebService) getIngestion(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.ingestionStatus())
+}
+
+// pauseIngestion pauses the sync with Yunikorn and rejects the batches forwarded by collectors until the ingestion
+// is resumed.
+func (ws *WebService) pauseIngestion(w http.ResponseWriter, r *http.Request) {
+	reason, ok := pauseReason(w, r)
+	if !ok {
+		return
+	}
+	op, err := ws.pauses.Pause(r.Context(), pause.Ingestion, requestPrincipal(r), reason)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("ingestion paused", "reason", op.Reason)
+	jsonResponse(w, r, ws.ingestionStatus())
+}
+
+// resumeIngestion resumes the ingestion. Resuming the ingestion while it is not paused has no effect.
+func (ws *WebService) resumeIngestion(w http.ResponseWriter, r *http.Request) {
+	if err := ws.pauses.Resume(r.Context(), pause.Ingestion); err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Info("ingestion resumed")
+	jsonResponse(w, r, ws.ingestionStatus())
+}
+
+func (ws *WebService) ingestionStatus() *IngestionStatus {
+	op := ws.pauses.Paused(pause.Ingestion)
+	return &IngestionStatus{Paused: op != nil, Pause: op}
+}
+
+// ingestionPausedResponse responds with 503 Service Unavailable if the ingestion is paused, so that collectors
+// keep the batch and retry it later. It returns whether the ingestion is paused.
+func (ws *WebService) ingestionPausedResponse(w http.ResponseWriter, r *http.Request) bool {
+	if ws.pauses.Paused(pause.Ingestion) == nil {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(ingestRetryAfter.Seconds())))
+	problemResponse(w, r, http.StatusServiceUnavailable, errIngestionPaused)
+	return true
+}
+
+// pauseReason decodes the reason of a pause from the request body, responding with 400 Bad Request if it is missing.
+func pauseReason(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req pauseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return "", false
+	}
+	if req.Reason == "" {
+		badRequestResponse(w, r, errors.New("reason is required"))
+		return "", false
+	}
+	return req.Reason, true
+}
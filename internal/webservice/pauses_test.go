@@ -0,0 +1,97 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/pause"
+	"github.com/G-Research/yunikorn-history-server/internal/scheduler"
+)
+
+func TestPauseRoutes(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	pauses := pause.NewController(repo)
+	jobs := scheduler.New(scheduler.WithPauses(pauses))
+	require.NoError(t, jobs.Register("retention", "0 3 * * *", func(context.Context) error { return nil }))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithScheduler(jobs), WithPauses(pauses), WithIngest())
+	ws.init(context.Background())
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(method, path, reader))
+		return rec
+	}
+
+	// jobs are paused with a reason
+	rec := serve(http.MethodPost, "/ws/v1/admin/jobs/retention/pause", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = serve(http.MethodPost, "/ws/v1/admin/jobs/compaction/pause", `{"reason": "database maintenance"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+	repo.EXPECT().PauseOperation(gomock.Any(), &model.PausedOperation{
+		Name: "retention", Reason: "database maintenance", PausedBy: anonymousPrincipal,
+	}).DoAndReturn(func(_ context.Context, op *model.PausedOperation) error {
+		op.PausedAt = 1700000000
+		return nil
+	})
+	rec = serve(http.MethodPost, "/ws/v1/admin/jobs/retention/pause", `{"reason": "database maintenance"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var status map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, true, status["paused"])
+	assert.Equal(t, map[string]any{
+		"name": "retention", "reason": "database maintenance", "pausedBy": anonymousPrincipal, "pausedAt": 1700000000.0,
+	}, status["pause"])
+
+	repo.EXPECT().ResumeOperation(gomock.Any(), "retention").Return(nil)
+	rec = serve(http.MethodPost, "/ws/v1/admin/jobs/retention/resume", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	status = nil
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, false, status["paused"])
+	assert.NotContains(t, status, "pause")
+
+	// batches are rejected while the ingestion is paused
+	rec = serve(http.MethodGet, "/ws/v1/admin/ingestion", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"paused": false}`, rec.Body.String())
+	repo.EXPECT().PauseOperation(gomock.Any(), gomock.Any()).Return(nil)
+	rec = serve(http.MethodPost, "/ws/v1/admin/ingestion/pause", `{"reason": "investigating corrupted data"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"paused": true, "pause": {"name": "ingestion", "reason": "investigating corrupted data", `+
+		`"pausedBy": "anonymous", "pausedAt": 0}}`, rec.Body.String())
+	rec = serve(http.MethodPost, "/ws/v1/ingest", testBatch)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+	repo.EXPECT().ResumeOperation(gomock.Any(), pause.Ingestion).Return(nil)
+	rec = serve(http.MethodPost, "/ws/v1/admin/ingestion/resume", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"paused": false}`, rec.Body.String())
+}
+
+func TestPauseRoutesReadOnly(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080, ReadOnly: true}, nil, nil, nil)
+	ws.init(context.Background())
+
+	for _, path := range []string{"/ws/v1/admin/ingestion/pause", "/ws/v1/admin/jobs/retention/resume"} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"reason": "x"}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code, path)
+	}
+}
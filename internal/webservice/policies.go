@@ -0,0 +1,36 @@
+package webservice
+
+import (
+	"net/http"
+)
+
+// getRetentionPolicies returns the retention policies currently applied to the server ordered by name.
+func (ws *WebService) getRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.policies.RetentionPolicies())
+}
+
+// getEffectiveRetentionPolicies returns the effective retention policy of every known queue.
+// Following query params are supported:
+// - partition: only return the queues of the given partition
+func (ws *WebService) getEffectiveRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	var partition string
+	if p := q.String(queryParamPartition); p != nil {
+		partition = *p
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	policies, err := ws.retention.EffectivePolicies(r.Context(), partition)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, policies)
+}
+
+// getAlertRules returns the alert rules currently applied to the server and the result of their last evaluation.
+func (ws *WebService) getAlertRules(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, ws.alerting.Statuses())
+}
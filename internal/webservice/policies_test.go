@@ -0,0 +1,82 @@
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/alerting"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+	"github.com/G-Research/yunikorn-history-server/internal/retention"
+)
+
+func TestWebServiceGetPolicies(t *testing.T) {
+	store := policy.NewStore()
+	require.NoError(t, store.ApplyRetentionPolicy(policy.RetentionPolicy{
+		Name:           "yunikorn/compliance",
+		Source:         policy.SourceKubernetes,
+		Queue:          "root.compliance",
+		ApplicationTTL: 17520 * time.Hour,
+	}))
+	require.NoError(t, store.ApplyAlertRule(policy.AlertRule{
+		Name:      "yunikorn/failures",
+		Source:    policy.SourceKubernetes,
+		State:     "Failed",
+		Threshold: 5,
+		Window:    time.Hour,
+	}))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithPolicies(store, alerting.NewService(nil, store)))
+
+	rec := httptest.NewRecorder()
+	ws.getRetentionPolicies(rec, httptest.NewRequest(http.MethodGet, routeRetentionPolicies, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var policies []policy.RetentionPolicy
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &policies))
+	assert.Equal(t, store.RetentionPolicies(), policies)
+
+	rec = httptest.NewRecorder()
+	ws.getAlertRules(rec, httptest.NewRequest(http.MethodGet, routeAlertRules, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var rules []alerting.RuleStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, "yunikorn/failures", rules[0].Name)
+	assert.Equal(t, "Failed", rules[0].State)
+	assert.Equal(t, alerting.StatePending, rules[0].Evaluation)
+}
+
+func TestWebServiceGetEffectiveRetentionPolicies(t *testing.T) {
+	store := policy.NewStore()
+	require.NoError(t, store.ApplyRetentionConfig(&config.RetentionConfig{
+		ApplicationTTL: 2160 * time.Hour,
+		Overrides:      []config.RetentionOverride{{Queue: "root.compliance", ApplicationTTL: 17520 * time.Hour}},
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllQueues(gomock.Any()).Return([]*model.PartitionQueueDAOInfo{
+		{PartitionQueueDAOInfo: dao.PartitionQueueDAOInfo{Partition: "default", QueueName: "root.compliance"}},
+		{PartitionQueueDAOInfo: dao.PartitionQueueDAOInfo{Partition: "gpu", QueueName: "root"}},
+	}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithPolicies(store, alerting.NewService(repo, store)))
+
+	rec := httptest.NewRecorder()
+	ws.getEffectiveRetentionPolicies(rec, httptest.NewRequest(http.MethodGet, routeEffectiveRetention+"?partition=default", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var policies []retention.EffectivePolicy
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &policies))
+	require.Len(t, policies, 1)
+	assert.Equal(t, "root.compliance", policies[0].Queue)
+	require.NotNil(t, policies[0].Policy)
+	assert.Equal(t, 17520*time.Hour, policies[0].Policy.ApplicationTTL)
+}
@@ -0,0 +1,56 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/changes"
+)
+
+const (
+	// defaultPollTimeout is the default time a poll waits for changes.
+	defaultPollTimeout = 30 * time.Second
+	// maxPollTimeout is the maximum time a poll waits for changes, below the idle timeouts of common proxies.
+	maxPollTimeout = 60 * time.Second
+)
+
+// poll waits until any of the topics in the topics query parameter, or any topic, changed since the cursor
+// in the since query parameter, or until the timeout elapses. It returns the changed topics and the cursor
+// to poll from next. Without cursor, it returns the current cursor immediately. Cursors are only valid for
+// the server which issued them; the response of an invalid cursor tells the client to reload the data.
+func (ws *WebService) poll(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	since := q.String(queryParamSince)
+	timeout := q.Duration(queryParamTimeout, maxPollTimeout)
+	var topics []changes.Topic
+	for _, name := range q.List(queryParamTopics) {
+		topic, err := changes.ParseTopic(name)
+		if err != nil {
+			q.invalidate(queryParamTopics, "%v", err)
+			continue
+		}
+		topics = append(topics, topic)
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	cursor := ""
+	if since != nil {
+		cursor = *since
+	}
+	wait := defaultPollTimeout
+	if timeout != nil {
+		wait = *timeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+	result := ws.changes.Wait(ctx, cursor, topics)
+	if r.Context().Err() != nil {
+		// the client is gone
+		return
+	}
+	jsonResponse(w, r, result)
+}
@@ -0,0 +1,62 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/changes"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestWebServicePoll(t *testing.T) {
+	feed := changes.NewFeed()
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithChanges(feed))
+	ws.init(context.Background())
+
+	poll := func(query url.Values) (int, changes.Changes) {
+		req := httptest.NewRequest(http.MethodGet, routePoll+"?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		var result changes.Changes
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		}
+		return rec.Code, result
+	}
+
+	status, initial := poll(url.Values{})
+	require.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, initial.Cursor)
+
+	// times out without changes of the topics
+	feed.Notify(changes.TopicNodes)
+	status, result := poll(url.Values{"since": {initial.Cursor}, "topics": {"applications,queues"}, "timeout": {"10ms"}})
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, result.Topics)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		feed.Notify(changes.TopicApplications)
+	}()
+	status, result = poll(url.Values{"since": {result.Cursor}, "topics": {"applications,queues"}, "timeout": {"5s"}})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []changes.Topic{changes.TopicApplications}, result.Topics)
+	assert.False(t, result.Reset)
+
+	status, result = poll(url.Values{"since": {"unknown.1"}})
+	require.Equal(t, http.StatusOK, status)
+	assert.True(t, result.Reset)
+
+	status, _ = poll(url.Values{"topics": {"events"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = poll(url.Values{"timeout": {"1h"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,42 @@
+package webservice
+
+import (
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// getPrincipals returns the users or groups seen in the history ordered by name, with the times they were first
+// and last seen and the number of their applications and the resources these used, as a directory to start
+// capacity or security investigations from.
+// Following query params are supported:
+// - prefix: only return the principals whose name starts with the prefix
+// - partition: only consider the applications of the partition
+// - duplicates: count the applications in several clusters once, by their canonical run selected by the strategy
+// - limit: limit the number of returned principals
+// - offset: offset the returned principals
+func (ws *WebService) getPrincipals(w http.ResponseWriter, r *http.Request, kind repository.PrincipalKind) {
+	q := newQueryParams(r)
+	filters := repository.PrincipalFilters{
+		Partition:  q.String(queryParamPartition),
+		Prefix:     q.String(queryParamPrefix),
+		Duplicates: q.DuplicateStrategy(queryParamDuplicates),
+		Offset:     q.Offset(),
+		Limit:      q.Limit(ws.pageSizes.Other),
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	principals, err := ws.repository.GetPrincipals(r.Context(), kind, filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if principals == nil {
+		principals = []*model.Principal{}
+	}
+	jsonResponse(w, r, principals)
+}
@@ -0,0 +1,58 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetPrincipals(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetPrincipals(gomock.Any(), repository.PrincipalUser, repository.PrincipalFilters{
+		Partition:  util.ToPtr("default"),
+		Prefix:     util.ToPtr("svc-"),
+		Duplicates: repository.DuplicateLatest,
+		Offset:     util.ToPtr(10),
+		Limit:      util.ToPtr(5),
+	}).Return([]*model.Principal{
+		{Name: "svc-etl", FirstSeen: 1, LastSeen: 2, Applications: 3, VcoreSeconds: 4, MemorySeconds: 5},
+	}, nil)
+	repo.EXPECT().GetPrincipals(gomock.Any(), repository.PrincipalGroup, repository.PrincipalFilters{
+		Limit: util.ToPtr(50),
+	}).Return(nil, nil)
+	ws := NewWebService(&config.YHSConfig{
+		Port:      8080,
+		PageSizes: config.PageSizesConfig{Other: config.PageSizeConfig{DefaultLimit: 50, MaxLimit: 100}},
+	}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/users?prefix=svc-&partition=default&duplicates=latest&offset=10&limit=5", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t,
+		`[{"name":"svc-etl","firstSeen":1,"lastSeen":2,"applications":3,"vcoreSeconds":4,"memorySeconds":5}]`,
+		rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/groups", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[]`, rec.Body.String())
+
+	for _, target := range []string{"/ws/v1/users?limit=-1", "/ws/v1/users?limit=101", "/ws/v1/groups?duplicates=any"} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
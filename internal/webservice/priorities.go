@@ -0,0 +1,123 @@
+package webservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// defaultPriorityPeriod is the period before now of which the applications are compared by default.
+const defaultPriorityPeriod = 7 * 24 * time.Hour
+
+// priorityAggregates are the aggregates of the analytics query of getPriorityWaitTimes, in the order of the fields
+// of model.PriorityWaitTimes.
+var priorityAggregates = []repository.AnalyticsAggregate{
+	{Function: sql.Count},
+	{Function: sql.Count, Field: "waitTime"},
+	{Function: sql.Average, Field: "waitTime"},
+	{Function: sql.Median, Field: "waitTime"},
+	{Function: sql.Percentile95, Field: "waitTime"},
+	{Function: sql.Maximum, Field: "waitTime"},
+}
+
+// getPriorityWaitTimes compares the wait times of the applications of each queue per priority and priority class,
+// to tell whether the priorities change when applications start running.
+// Following query params are supported:
+// - partition: compare the applications of the partition only
+// - queue: compare the applications of the queue only, without its child queues
+// - startTime: compare the applications submitted from this time, 7 days before the end time by default
+// - endTime: compare the applications submitted until this time, now by default
+// - duplicates: count the applications in several clusters once, by their canonical run selected by the strategy
+// - tz: timezone of the time params without offset, UTC by default
+//
+// Results are ordered by partition, queue, priority and priority class.
+func (ws *WebService) getPriorityWaitTimes(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	partition := q.String(queryParamPartition)
+	queue := q.String(queryParamQueue)
+	duplicates := q.DuplicateStrategy(queryParamDuplicates)
+	now := time.Now()
+	start, end := q.TimeRange(queryParamStartTime, queryParamEndTime, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if end == nil {
+		end = &now
+	}
+	if start == nil {
+		start = util.ToPtr(end.Add(-defaultPriorityPeriod))
+		if start.Before(minTime) {
+			start = &minTime
+		}
+	}
+
+	query := repository.AnalyticsQuery{
+		Entity: "applications",
+		Filters: []repository.AnalyticsFilter{
+			{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{*start}},
+			{Field: "submissionTime", Operator: sql.LessThan, Values: []any{*end}},
+		},
+		GroupBy:    []string{"partition", "queueName", "priority", "priorityClass"},
+		Aggregates: priorityAggregates,
+		Limit:      maxAnalyticsRows,
+		Duplicates: duplicates,
+	}
+	if partition != nil {
+		query.Filters = append(query.Filters, repository.AnalyticsFilter{
+			Field: "partition", Operator: sql.Equal, Values: []any{*partition},
+		})
+	}
+	if queue != nil {
+		query.Filters = append(query.Filters, repository.AnalyticsFilter{
+			Field: "queueName", Operator: sql.Equal, Values: []any{*queue},
+		})
+	}
+	rows, err := ws.repository.QueryAnalytics(r.Context(), query)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+
+	results := make([]*model.PriorityWaitTimes, 0, len(rows))
+	for _, row := range rows {
+		result := &model.PriorityWaitTimes{
+			Partition:      groupValue[string](row, "partition"),
+			QueueName:      groupValue[string](row, "queueName"),
+			Priority:       groupPointer[int64](row, "priority"),
+			PriorityClass:  groupPointer[string](row, "priorityClass"),
+			AvgWaitTime:    row.Aggregates[priorityAggregates[2].Name()],
+			MedianWaitTime: row.Aggregates[priorityAggregates[3].Name()],
+			P95WaitTime:    row.Aggregates[priorityAggregates[4].Name()],
+			MaxWaitTime:    row.Aggregates[priorityAggregates[5].Name()],
+		}
+		if count := row.Aggregates[priorityAggregates[0].Name()]; count != nil {
+			result.Applications = int64(*count)
+		}
+		if count := row.Aggregates[priorityAggregates[1].Name()]; count != nil {
+			result.Started = int64(*count)
+		}
+		results = append(results, result)
+	}
+	jsonResponse(w, r, results)
+}
+
+// groupValue returns the value of the group of the analytics row, or the zero value if it is NULL.
+func groupValue[T any](row *model.AnalyticsRow, name string) T {
+	value, _ := row.Groups[name].(T)
+	return value
+}
+
+// groupPointer returns a pointer to the value of the group of the analytics row, or nil if it is NULL.
+func groupPointer[T any](row *model.AnalyticsRow, name string) *T {
+	value, ok := row.Groups[name].(T)
+	if !ok {
+		return nil
+	}
+	return &value
+}
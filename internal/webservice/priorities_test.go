@@ -0,0 +1,73 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/database/sql"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetPriorityWaitTimes(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().QueryAnalytics(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, query repository.AnalyticsQuery) ([]*model.AnalyticsRow, error) {
+			assert.Equal(t, "applications", query.Entity)
+			assert.Equal(t, []repository.AnalyticsFilter{
+				{Field: "submissionTime", Operator: sql.GreaterThanOrEqual, Values: []any{start}},
+				{Field: "submissionTime", Operator: sql.LessThan, Values: []any{end}},
+				{Field: "queueName", Operator: sql.Equal, Values: []any{"root.batch"}},
+			}, query.Filters)
+			assert.Equal(t, []string{"partition", "queueName", "priority", "priorityClass"}, query.GroupBy)
+			assert.Equal(t, repository.DuplicateLatest, query.Duplicates)
+			return []*model.AnalyticsRow{
+				{
+					Groups: map[string]any{
+						"partition": "default", "queueName": "root.batch", "priority": int64(0), "priorityClass": nil,
+					},
+					Aggregates: map[string]*float64{
+						"count": util.ToPtr(4.0), "count(waitTime)": util.ToPtr(3.0), "avg(waitTime)": util.ToPtr(2e9),
+						"p50(waitTime)": util.ToPtr(1e9), "p95(waitTime)": util.ToPtr(4e9), "max(waitTime)": util.ToPtr(5e9),
+					},
+				},
+				{
+					Groups: map[string]any{
+						"partition": "default", "queueName": "root.batch", "priority": int64(1000), "priorityClass": "high",
+					},
+					Aggregates: map[string]*float64{"count": util.ToPtr(1.0), "count(waitTime)": util.ToPtr(0.0)},
+				},
+			}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/analytics/priorities?queue=root.batch&startTime=2024-07-01&endTime=2024-07-08&duplicates=latest", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[
+		{"partition": "default", "queueName": "root.batch", "priority": 0, "priorityClass": null,
+		 "applications": 4, "started": 3, "avgWaitTime": 2e9, "medianWaitTime": 1e9, "p95WaitTime": 4e9, "maxWaitTime": 5e9},
+		{"partition": "default", "queueName": "root.batch", "priority": 1000, "priorityClass": "high",
+		 "applications": 1, "started": 0, "avgWaitTime": null, "medianWaitTime": null, "p95WaitTime": null, "maxWaitTime": null}
+	]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/analytics/priorities?startTime=2024-07-08&endTime=2024-07-01", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
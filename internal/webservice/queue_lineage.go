@@ -0,0 +1,161 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// queueLineageRequest is the request body for linking the former path of a renamed or moved queue to its new path.
+type queueLineageRequest struct {
+	Partition string  `json:"partition"`
+	FromQueue string  `json:"fromQueue"`
+	ToQueue   string  `json:"toQueue"`
+	Reason    *string `json:"reason"`
+}
+
+func (req *queueLineageRequest) validate() error {
+	var errorMessages []string
+	if req.Partition == "" {
+		errorMessages = append(errorMessages, "partition is required")
+	}
+	if req.FromQueue == "" {
+		errorMessages = append(errorMessages, "fromQueue is required")
+	}
+	if req.ToQueue == "" {
+		errorMessages = append(errorMessages, "toQueue is required")
+	}
+	if req.FromQueue != "" && req.ToQueue != "" &&
+		(isQueueInSubtree(req.FromQueue, req.ToQueue) || isQueueInSubtree(req.ToQueue, req.FromQueue)) {
+		errorMessages = append(errorMessages, "fromQueue and toQueue must not contain each other")
+	}
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("invalid queue lineage: %v", errorMessages)
+	}
+	return nil
+}
+
+// getQueueLineage returns the queue lineage links ordered by creation time.
+// Following query params are supported:
+// - partition: filter by partition
+func (ws *WebService) getQueueLineage(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	partition := q.String(queryParamPartition)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	links, err := ws.repository.GetQueueLineage(r.Context(), partition)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, links)
+}
+
+// createQueueLineage links the former path of a renamed or moved queue to its new path, so that the applications
+// recorded under the former path, and under the paths of its child queues, are returned under the new paths
+// when the lineage is followed.
+func (ws *WebService) createQueueLineage(w http.ResponseWriter, r *http.Request) {
+	var req queueLineageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	link := &model.QueueLineage{
+		Partition: req.Partition,
+		FromQueue: req.FromQueue,
+		ToQueue:   req.ToQueue,
+		Reason:    emptyToNil(req.Reason),
+		CreatedBy: requestPrincipal(r),
+	}
+	if err := ws.repository.AddQueueLineage(r.Context(), link); err != nil {
+		queueLineageErrorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("queue lineage added", "id", link.ID, "partition", link.Partition,
+		"from", link.FromQueue, "to", link.ToQueue)
+	createdResponse(w, r, link)
+}
+
+// deleteQueueLineage deletes a queue lineage link, e.g. one which was added by mistake.
+func (ws *WebService) deleteQueueLineage(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id := params.ByName(paramsLineageID)
+	if _, err := uuid.Parse(id); err != nil {
+		notFoundResponse(w, r, fmt.Errorf("%w: %s", repository.ErrQueueLineageNotFound, id))
+		return
+	}
+	if err := ws.repository.DeleteQueueLineage(r.Context(), id); err != nil {
+		queueLineageErrorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("queue lineage deleted", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func queueLineageErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrQueueLineageNotFound):
+		notFoundResponse(w, r, err)
+	case errors.Is(err, repository.ErrQueueLineageExists):
+		problemResponse(w, r, http.StatusConflict, err)
+	default:
+		errorResponse(w, r, err)
+	}
+}
+
+// formerQueues returns the former paths of the queue of the partition, following its lineage links.
+func (ws *WebService) formerQueues(ctx context.Context, partition, queue string) ([]string, error) {
+	links, err := ws.repository.GetQueueLineage(ctx, &partition)
+	if err != nil {
+		return nil, err
+	}
+	return formerQueueNames(links, queue), nil
+}
+
+// formerQueueNames returns the sorted former paths of the queue, following the links transitively, e.g. the queue
+// root.b.c has the former path root.a.c if root.a was renamed to root.b.
+func formerQueueNames(links []*model.QueueLineage, queue string) []string {
+	var former []string
+	seen := map[string]bool{queue: true}
+	pending := []string{queue}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		for _, link := range links {
+			if !isQueueInSubtree(current, link.ToQueue) {
+				continue
+			}
+			name := link.FromQueue + strings.TrimPrefix(current, link.ToQueue)
+			if !seen[name] {
+				seen[name] = true
+				former = append(former, name)
+				pending = append(pending, name)
+			}
+		}
+	}
+	slices.Sort(former)
+	return former
+}
+
+// isQueueInSubtree returns whether the queue is the root queue of the subtree or one of its descendants.
+func isQueueInSubtree(queue, root string) bool {
+	return queue == root || strings.HasPrefix(queue, root+".")
+}
@@ -0,0 +1,142 @@
+package webservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestFormerQueueNames(t *testing.T) {
+	links := []*model.QueueLineage{
+		{FromQueue: "root.reporting", ToQueue: "root.analytics"},
+		{FromQueue: "root.legacy.bi", ToQueue: "root.reporting.bi"},
+		{FromQueue: "root.analytics.adhoc", ToQueue: "root.sandbox"},
+		// queues swapped their paths
+		{FromQueue: "root.blue", ToQueue: "root.green"},
+		{FromQueue: "root.green", ToQueue: "root.blue"},
+	}
+
+	tests := map[string]struct {
+		queue string
+		want  []string
+	}{
+		"renamed queue":              {queue: "root.analytics", want: []string{"root.reporting"}},
+		"child of renamed queue":     {queue: "root.analytics.bi", want: []string{"root.legacy.bi", "root.reporting.bi"}},
+		"moved queue":                {queue: "root.sandbox", want: []string{"root.analytics.adhoc", "root.reporting.adhoc"}},
+		"swapped queues":             {queue: "root.green", want: []string{"root.blue"}},
+		"queue without lineage":      {queue: "root.default", want: nil},
+		"queue with a common prefix": {queue: "root.analyticsx", want: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formerQueueNames(links, tt.queue))
+		})
+	}
+}
+
+func TestWebServiceCreateQueueLineage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().AddQueueLineage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, link *model.QueueLineage) error {
+			assert.Equal(t, &model.QueueLineage{
+				Partition: "default",
+				FromQueue: "root.reporting",
+				ToQueue:   "root.analytics",
+				Reason:    util.ToPtr("reorg"),
+				CreatedBy: anonymousPrincipal,
+			}, link)
+			link.ID = "3b241101-e2bb-4255-8caf-4136c566a962"
+			return nil
+		})
+	repo.EXPECT().AddQueueLineage(gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("%w: root.reporting in partition default", repository.ErrQueueLineageExists))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	body := `{"partition": "default", "fromQueue": "root.reporting", "toQueue": "root.analytics", "reason": "reorg"}`
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/admin/queue-lineage", strings.NewReader(body)))
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"id":"3b241101-e2bb-4255-8caf-4136c566a962"`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/admin/queue-lineage", strings.NewReader(body)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	for _, body := range []string{
+		`{"fromQueue": "root.a", "toQueue": "root.b"}`,
+		`{"partition": "default", "toQueue": "root.b"}`,
+		`{"partition": "default", "fromQueue": "root.a", "toQueue": "root.a"}`,
+		`{"partition": "default", "fromQueue": "root.a", "toQueue": "root.a.b"}`,
+		`{"partition":`,
+	} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/admin/queue-lineage", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestWebServiceDeleteQueueLineage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().DeleteQueueLineage(gomock.Any(), "3b241101-e2bb-4255-8caf-4136c566a962").Return(nil)
+	repo.EXPECT().DeleteQueueLineage(gomock.Any(), "9f1c5f1e-0a4c-4a43-9d36-7d1f1f8f6a11").
+		Return(fmt.Errorf("%w: 9f1c5f1e-0a4c-4a43-9d36-7d1f1f8f6a11", repository.ErrQueueLineageNotFound))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	for id, want := range map[string]int{
+		"3b241101-e2bb-4255-8caf-4136c566a962": http.StatusNoContent,
+		"9f1c5f1e-0a4c-4a43-9d36-7d1f1f8f6a11": http.StatusNotFound,
+		"not-a-uuid":                           http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/ws/v1/admin/queue-lineage/"+id, nil))
+		assert.Equal(t, want, rec.Code, id)
+	}
+}
+
+func TestWebServiceGetAppsPerPartitionPerQueueFollowsLineage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetQueueLineage(gomock.Any(), util.ToPtr("default")).Return([]*model.QueueLineage{
+		{Partition: "default", FromQueue: "root.reporting", ToQueue: "root.analytics"},
+	}, nil)
+	repo.EXPECT().GetAppsPerPartitionPerQueuePage(gomock.Any(), "default", "root.analytics.bi", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ string, filters repository.ApplicationFilters) (
+			[]*model.ApplicationDAOInfo, repository.PageInfo, error) {
+			assert.Equal(t, []string{"root.reporting.bi"}, filters.FormerQueues)
+			return nil, repository.PageInfo{}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.analytics.bi/applications?lineage=true", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestWebServiceGetQueueThroughputLineageRequiresQueue(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/analytics/throughput?partition=default&lineage=true", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "lineage")
+}
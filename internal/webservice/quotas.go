@@ -0,0 +1,68 @@
+package webservice
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/quota"
+)
+
+const (
+	headerQuotaRequestsLimit      = "X-Quota-Requests-Limit"
+	headerQuotaRequestsRemaining  = "X-Quota-Requests-Remaining"
+	headerQuotaQueryTimeLimit     = "X-Quota-Query-Time-Limit"
+	headerQuotaQueryTimeRemaining = "X-Quota-Query-Time-Remaining"
+	headerQuotaReset              = "X-Quota-Reset"
+)
+
+// WithQuotas enforces the daily quotas of the API clients on the analytics and export endpoints. Requests without
+// authenticated client are not limited. If the tracker is nil, no quotas are enforced.
+func WithQuotas(tracker *quota.Tracker) Option {
+	return func(ws *WebService) {
+		ws.quotas = tracker
+	}
+}
+
+// enforceQuota counts the request towards the quotas of its client and sets the state of the quotas in the headers
+// of the response. The request is rejected with 429 Too Many Requests if the client exceeded its quotas. Otherwise,
+// the queries of the request are timed, and the returned function adds their time to the usage of the client once
+// the request is handled.
+func (ws *WebService) enforceQuota(w http.ResponseWriter, r *http.Request) (func(), bool) {
+	client, ok := principalFromContext(r.Context())
+	if ws.quotas == nil || !ok {
+		return func() {}, true
+	}
+	state, err := ws.quotas.Begin(client)
+	if state.Unlimited() {
+		return func() {}, true
+	}
+	setQuotaHeaders(w, state)
+	if err != nil {
+		w.Header().Set("Retry-After", w.Header().Get(headerQuotaReset))
+		problemResponse(w, r, http.StatusTooManyRequests, err)
+		return nil, false
+	}
+	ctx, timer := postgres.WithQueryTimer(r.Context())
+	*r = *r.WithContext(ctx)
+	return func() {
+		ws.quotas.AddQueryTime(client, timer.Elapsed())
+	}, true
+}
+
+// setQuotaHeaders sets the limits and the remaining usage of the quotas in the headers of the response: the query time
+// in seconds, and the reset as the number of seconds until the quotas reset.
+func setQuotaHeaders(w http.ResponseWriter, state quota.State) {
+	if state.Limits.Requests > 0 {
+		w.Header().Set(headerQuotaRequestsLimit, strconv.Itoa(state.Limits.Requests))
+		w.Header().Set(headerQuotaRequestsRemaining, strconv.Itoa(state.RemainingRequests()))
+	}
+	if state.Limits.QueryTime > 0 {
+		w.Header().Set(headerQuotaQueryTimeLimit, strconv.Itoa(int(state.Limits.QueryTime.Seconds())))
+		w.Header().Set(headerQuotaQueryTimeRemaining, strconv.Itoa(int(state.RemainingQueryTime().Seconds())))
+	}
+	reset := int(math.Ceil(time.Until(state.Reset).Seconds()))
+	w.Header().Set(headerQuotaReset, strconv.Itoa(max(reset, 0)))
+}
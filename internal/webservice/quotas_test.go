@@ -0,0 +1,79 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/quota"
+)
+
+func TestWebServiceQuotas(t *testing.T) {
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{"analytics": true}})
+	require.NoError(t, err)
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().QueryAnalytics(gomock.Any(), gomock.Any()).Return([]*model.AnalyticsRow{}, nil).AnyTimes()
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return([]*dao.PartitionInfo{}, nil).AnyTimes()
+	tracker := quota.New(&config.QuotasConfig{
+		DailyRequests:  2,
+		DailyQueryTime: time.Hour,
+		Overrides:      []config.QuotaOverride{{Client: "ops"}},
+	})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithFeatureFlags(featureFlags),
+		WithAPIKeys(map[string]string{"notebooks": "notebooks-secret", "ops": "ops-secret"}),
+		WithQuotas(tracker),
+	)
+	ws.init(context.Background())
+	serve := func(method, path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(`{"entity": "applications", "aggregates": [{"function": "count"}]}`))
+		req.Header.Set(headerAuthorization, bearerPrefix+key)
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPost, routeAnalyticsQuery, "notebooks-secret")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "2", rec.Header().Get(headerQuotaRequestsLimit))
+	assert.Equal(t, "1", rec.Header().Get(headerQuotaRequestsRemaining))
+	assert.Equal(t, "3600", rec.Header().Get(headerQuotaQueryTimeLimit))
+	assert.NotEmpty(t, rec.Header().Get(headerQuotaQueryTimeRemaining))
+	reset, err := strconv.Atoi(rec.Header().Get(headerQuotaReset))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, reset, 24*60*60)
+
+	// the requests of the other endpoints do not count towards the quota
+	rec = serve(http.MethodGet, routePartitions, "notebooks-secret")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Empty(t, rec.Header().Get(headerQuotaRequestsLimit))
+
+	rec = serve(http.MethodPost, routeAnalyticsQuery, "notebooks-secret")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "0", rec.Header().Get(headerQuotaRequestsRemaining))
+
+	rec = serve(http.MethodPost, routeAnalyticsQuery, "notebooks-secret")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "daily quota exceeded")
+	assert.Equal(t, rec.Header().Get(headerQuotaReset), rec.Header().Get("Retry-After"))
+
+	// clients without quota are not limited
+	for range 3 {
+		rec = serve(http.MethodPost, routeAnalyticsQuery, "ops-secret")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Empty(t, rec.Header().Get(headerQuotaRequestsLimit))
+	}
+}
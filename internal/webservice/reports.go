@@ -0,0 +1,158 @@
+package webservice
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// defaultTopPeriod is the period before now of which the top consumers are returned by default.
+	defaultTopPeriod = 7 * 24 * time.Hour
+	// defaultTopK and maxTopK are the default and maximum number of top consumers.
+	defaultTopK = 20
+	maxTopK     = 1000
+	// defaultDuplicatesPeriod is the period before now of which the duplicate applications are returned by default.
+	defaultDuplicatesPeriod = 7 * 24 * time.Hour
+)
+
+// duplicatesReport is the response of the report of the applications duplicated across clusters.
+type duplicatesReport struct {
+	Strategy     repository.DuplicateStrategy  `json:"strategy"`
+	From         time.Time                     `json:"from"`
+	To           time.Time                     `json:"to"`
+	Applications []*model.DuplicateApplication `json:"applications"`
+}
+
+// topReport is the response of the top consumers report.
+type topReport struct {
+	Metric  repository.UsageMetric   `json:"metric"`
+	By      repository.UsageGrouping `json:"by"`
+	From    time.Time                `json:"from"`
+	To      time.Time                `json:"to"`
+	Entries []*model.TopUsage        `json:"entries"`
+}
+
+// getTopReport returns the consumers with the largest usage of a period, from the usage rollups.
+// Following query params are supported:
+// - metric: the metric to rank by, vcore_seconds, memory_seconds or wait_time (required)
+// - by: the consumers to rank, user, queue or app (required)
+// - partition: only rank the usage in the partition
+// - from: the start of the period, rounded down to the hour, 7 days before the end by default
+// - to: the end of the period, now by default
+// - k: the number of consumers, 20 by default
+// - tz: timezone of the time params without offset and of the period of the response, UTC by default
+func (ws *WebService) getTopReport(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.TopUsageFilters{
+		Partition: q.String(queryParamPartition),
+		K:         defaultTopK,
+	}
+	if metric := q.String(queryParamMetric); metric == nil {
+		q.invalidate(queryParamMetric, "is required")
+	} else if filters.Metric = repository.UsageMetric(*metric); !slices.Contains(repository.UsageMetrics, filters.Metric) {
+		q.invalidate(queryParamMetric, "must be one of %v", repository.UsageMetrics)
+	}
+	if by := q.String(queryParamBy); by == nil {
+		q.invalidate(queryParamBy, "is required")
+	} else if filters.By = repository.UsageGrouping(*by); !slices.Contains(repository.UsageGroupings, filters.By) {
+		q.invalidate(queryParamBy, "must be one of %v", repository.UsageGroupings)
+	}
+	if k := q.Int(queryParamK, 1, maxTopK); k != nil {
+		filters.K = *k
+	}
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultTopPeriod)
+		from = &start
+		if from.Before(minTime) {
+			from = &minTime
+		}
+	}
+	filters.Start = time.Unix(0, from.UnixNano()-from.UnixNano()%repository.UsageRollupWidth.Nanoseconds())
+	filters.End = *to
+
+	entries, err := ws.repository.GetTopUsage(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if entries == nil {
+		entries = []*model.TopUsage{}
+	}
+	jsonResponse(w, r, topReport{
+		Metric:  filters.Metric,
+		By:      filters.By,
+		From:    filters.Start.In(loc),
+		To:      filters.End.In(loc),
+		Entries: entries,
+	})
+}
+
+// getDuplicatesReport returns the applications whose IDs appear in several clusters during a period, e.g. after
+// failovers, with their runs in each cluster and the canonical run which the merged analytics queries count.
+// Following query params are supported:
+// - strategy: the strategy selecting the canonical run, latest or first, latest by default
+// - from: the start of the period, 7 days before the end by default
+// - to: the end of the period, now by default
+// - limit: limit the number of returned applications
+// - offset: offset the returned applications
+// The number of applications of all pages is returned in the X-Total-Count header.
+// - tz: timezone of the time params without offset and of the period of the response, UTC by default
+func (ws *WebService) getDuplicatesReport(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.DuplicateApplicationFilters{
+		Strategy: repository.DuplicateLatest,
+		Offset:   q.Offset(),
+		Limit:    q.Limit(ws.pageSizes.Other),
+	}
+	if strategy := q.DuplicateStrategy(queryParamStrategy); strategy != "" {
+		filters.Strategy = strategy
+	}
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultDuplicatesPeriod)
+		from = &start
+		if from.Before(minTime) {
+			from = &minTime
+		}
+	}
+	filters.Start, filters.End = *from, *to
+
+	applications, page, err := ws.repository.GetDuplicateApplications(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if applications == nil {
+		applications = []*model.DuplicateApplication{}
+	}
+	setPageHeaders(w, r, page)
+	jsonResponse(w, r, duplicatesReport{
+		Strategy:     filters.Strategy,
+		From:         filters.Start.In(loc),
+		To:           filters.End.In(loc),
+		Applications: applications,
+	})
+}
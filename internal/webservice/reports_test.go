@@ -0,0 +1,168 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetTopReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetTopUsage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.TopUsageFilters) ([]*model.TopUsage, error) {
+			assert.Equal(t, repository.VcoreSeconds, filters.Metric)
+			assert.Equal(t, repository.ByQueue, filters.By)
+			assert.Equal(t, util.ToPtr("default"), filters.Partition)
+			assert.Equal(t, 5, filters.K)
+			// the start is rounded down to the hour of the rollups
+			assert.True(t, time.Date(2024, 6, 30, 23, 0, 0, 0, time.UTC).Equal(filters.Start), filters.Start)
+			assert.True(t, time.Date(2024, 7, 7, 23, 0, 0, 0, time.UTC).Equal(filters.End), filters.End)
+			return []*model.TopUsage{
+				{Partition: "default", QueueName: "root.a", Value: 7200},
+				{Partition: "default", QueueName: "root.b", Value: 3600.5},
+			}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/reports/top?metric=vcore_seconds&by=queue&partition=default&k=5&from=2024-07-01T00:30&to=2024-07-08&tz=Europe/London",
+		nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"metric": "vcore_seconds",
+		"by": "queue",
+		"from": "2024-07-01T00:00:00+01:00",
+		"to": "2024-07-08T00:00:00+01:00",
+		"entries": [
+			{"partition": "default", "queueName": "root.a", "value": 7200},
+			{"partition": "default", "queueName": "root.b", "value": 3600.5}
+		]
+	}`, rec.Body.String())
+}
+
+func TestWebServiceGetTopReportDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetTopUsage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.TopUsageFilters) ([]*model.TopUsage, error) {
+			assert.Nil(t, filters.Partition)
+			assert.Equal(t, defaultTopK, filters.K)
+			assert.WithinDuration(t, filters.End.Add(-defaultTopPeriod), filters.Start, time.Hour)
+			return nil, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/top?metric=wait_time&by=user", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"entries":[]`)
+}
+
+func TestWebServiceGetTopReportInvalid(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	tests := map[string]string{
+		"no metric":        "by=user",
+		"unknown metric":   "metric=gpu_seconds&by=user",
+		"no grouping":      "metric=vcore_seconds",
+		"unknown grouping": "metric=vcore_seconds&by=node",
+		"k too large":      "metric=vcore_seconds&by=user&k=100000",
+		"k not positive":   "metric=vcore_seconds&by=user&k=0",
+		"to before from":   "metric=vcore_seconds&by=user&from=2024-07-08&to=2024-07-01",
+		"invalid from":     "metric=vcore_seconds&by=user&from=last-week",
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/top?"+query, nil))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestWebServiceGetDuplicatesReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetDuplicateApplications(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.DuplicateApplicationFilters,
+		) ([]*model.DuplicateApplication, repository.PageInfo, error) {
+			assert.Equal(t, repository.DuplicateFirst, filters.Strategy)
+			assert.Equal(t, util.ToPtr(10), filters.Limit)
+			assert.True(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Equal(filters.Start), filters.Start)
+			assert.True(t, time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC).Equal(filters.End), filters.End)
+			return []*model.DuplicateApplication{{
+				ApplicationID: "app1",
+				Runs: []*model.DuplicateRun{
+					{ClusterID: "eu-west", Partition: "[eu-west]default", QueueName: "root.a", Canonical: true},
+					{ClusterID: "us-east", Partition: "[us-east]default", QueueName: "root.a"},
+				},
+			}}, repository.PageInfo{Total: 12}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/reports/duplicates?strategy=first&from=2024-07-01&to=2024-07-08&limit=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "12", rec.Header().Get(headerTotalCount))
+	assert.JSONEq(t, `{
+		"strategy": "first",
+		"from": "2024-07-01T00:00:00Z",
+		"to": "2024-07-08T00:00:00Z",
+		"applications": [{
+			"applicationID": "app1",
+			"runs": [
+				{"clusterId": "eu-west", "partition": "[eu-west]default", "queueName": "root.a",
+					"applicationState": null, "submissionTime": null, "canonical": true},
+				{"clusterId": "us-east", "partition": "[us-east]default", "queueName": "root.a",
+					"applicationState": null, "submissionTime": null, "canonical": false}
+			]
+		}]
+	}`, rec.Body.String())
+}
+
+func TestWebServiceGetDuplicatesReportDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetDuplicateApplications(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.DuplicateApplicationFilters,
+		) ([]*model.DuplicateApplication, repository.PageInfo, error) {
+			assert.Equal(t, repository.DuplicateLatest, filters.Strategy)
+			assert.Equal(t, filters.End.Add(-defaultDuplicatesPeriod), filters.Start)
+			assert.Equal(t, util.ToPtr(50), filters.Limit)
+			return nil, repository.PageInfo{}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{
+		Port:      8080,
+		PageSizes: config.PageSizesConfig{Other: config.PageSizeConfig{DefaultLimit: 50, MaxLimit: 100}},
+	}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/duplicates", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"applications":[]`)
+
+	for _, target := range []string{"/ws/v1/reports/duplicates?strategy=any", "/ws/v1/reports/duplicates?limit=101"} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
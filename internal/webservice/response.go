@@ -2,48 +2,121 @@ package webservice
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/G-Research/yunikorn-history-server/internal/breaker"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
 	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/msgpack"
 )
 
-// jsonResponse writes the data to the response writer as a JSON object.
-func jsonResponse(w http.ResponseWriter, data any) {
-	err := json.NewEncoder(w).Encode(data)
+// jsonResponse writes the data to the response writer as a JSON object,
+// with its fields named in the naming convention of the request.
+// Clients accepting MessagePack get the same document encoded as MessagePack.
+func jsonResponse(w http.ResponseWriter, r *http.Request, data any) {
+	jsonResponseWithStatus(w, r, http.StatusOK, data)
+}
+
+// createdResponse writes the created resource to the response writer as a JSON object.
+func createdResponse(w http.ResponseWriter, r *http.Request, data any) {
+	jsonResponseWithStatus(w, r, http.StatusCreated, data)
+}
+
+func jsonResponseWithStatus(w http.ResponseWriter, r *http.Request, status int, data any) {
+	data, err := renderFields(r, data)
 	if err != nil {
-		log.Logger.Errorf("could not write response: %v", err)
+		errorResponse(w, r, fmt.Errorf("could not render response: %v", err))
+		return
+	}
+	if acceptsMsgpack(r) {
+		body, err := msgpack.Marshal(data)
+		if err != nil {
+			errorResponse(w, r, fmt.Errorf("could not render response: %v", err))
+			return
+		}
+		w.Header().Set("Content-Type", msgpackContentType)
+		w.WriteHeader(status)
+		if _, err := w.Write(body); err != nil {
+			log.FromContext(r.Context()).Errorf("could not write response: %v", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.FromContext(r.Context()).Errorf("could not write response: %v", err)
 	}
 }
 
-// errorResponse writes an RFC7807 Problem error response to the response writer.
+// errorResponse writes an RFC7807 Problem error response to the response writer. Errors of operations rejected
+// while the history store is unavailable are responded with 503 Service Unavailable, and a Retry-After header
+// telling when the store is probed again.
 func errorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	log.Logger.Errorf("error processing request for %s: %v", r.URL.Path, err)
-	problemDetails := ProblemDetails{
-		Type:     "about:blank",
-		Title:    "Internal Server Error",
-		Status:   http.StatusInternalServerError,
-		Detail:   err.Error(),
-		Instance: r.URL.Path,
+	var unavailable *breaker.UnavailableError
+	if errors.As(err, &unavailable) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(unavailable.RetryAfter.Seconds()))))
+		problemResponse(w, r, http.StatusServiceUnavailable, err)
+		return
 	}
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(http.StatusInternalServerError)
-	if err := json.NewEncoder(w).Encode(problemDetails); err != nil {
-		log.Logger.Errorf("could not write error response: %v", err)
+	// a cursor of another order or list is only rejected by the repository
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		badRequestResponse(w, r, err)
+		return
 	}
+	problemResponse(w, r, http.StatusInternalServerError, err)
 }
 
+// invalidParamsError is the error of a request with invalid parameters,
+// whose problem response lists the invalid parameters.
+type invalidParamsError struct {
+	params []InvalidParam
+}
+
+func (e *invalidParamsError) Error() string {
+	reasons := make([]string, len(e.params))
+	for i, p := range e.params {
+		reasons[i] = fmt.Sprintf("invalid '%s' query parameter: %s", p.Name, p.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// badRequestResponse writes a Bad Request response, or a Request Entity Too Large response if the request body could
+// not be read because it exceeded its limit.
 func badRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
-	log.Logger.Errorf("error processing request for %s: %v", r.URL.Path, err)
+	if limit, ok := bodyLimitExceeded(r); ok {
+		bodyTooLargeResponse(w, r, limit)
+		return
+	}
+	problemResponse(w, r, http.StatusBadRequest, err)
+}
+
+func notFoundResponse(w http.ResponseWriter, r *http.Request, err error) {
+	problemResponse(w, r, http.StatusNotFound, err)
+}
+
+// problemResponse writes an RFC7807 Problem response with the given status code to the response writer.
+func problemResponse(w http.ResponseWriter, r *http.Request, status int, err error) {
+	logger := log.FromContext(r.Context())
+	logger.Errorf("error processing request for %s: %v", r.URL.Path, err)
 	problemDetails := ProblemDetails{
 		Type:     "about:blank",
-		Title:    "Bad Request",
-		Status:   http.StatusBadRequest,
+		Title:    http.StatusText(status),
+		Status:   status,
 		Detail:   err.Error(),
 		Instance: r.URL.Path,
 	}
+	var invalidParams *invalidParamsError
+	if errors.As(err, &invalidParams) {
+		problemDetails.InvalidParams = invalidParams.params
+	}
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(problemDetails); err != nil {
-		log.Logger.Errorf("could not write error response: %v", err)
+		logger.Errorf("could not write error response: %v", err)
 	}
 }
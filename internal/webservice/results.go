@@ -0,0 +1,198 @@
+package webservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+// maxCachedRequestSize is the maximum size of a request body whose result is cached.
+const maxCachedRequestSize = 64 << 10
+
+// resultRoutes lists the analytics and report routes whose results are cached in the result cache, with the query
+// parameter of the end of their time range. The end of the time range of analytics queries is given by their filters.
+var resultRoutes = map[string]string{
+	routeAnalyticsQuery:      "",
+	routeQueueThroughput:     queryParamEndTime,
+	routePriorityWaitTimes:   queryParamEndTime,
+	routeQueueWaitPhases:     queryParamEndTime,
+	routePartitionComparison: queryParamTo,
+	routeTopReport:           queryParamTo,
+	routeSpotChurnReport:     queryParamTo,
+	routeDuplicatesReport:    queryParamTo,
+	routeUsageCalendarReport: queryParamTo,
+}
+
+// WithResultCache sets the cache the results of the analytics and report routes are served from. Results are cached
+// by their normalized parameters, for the past TTL if their time range ended before the settle period, as such data
+// no longer changes, and for the recent TTL otherwise. If the cache is nil, results are not cached.
+func WithResultCache(results *cache.Cache, cfg config.ResultsCacheConfig) Option {
+	return func(ws *WebService) {
+		ws.results = results
+		ws.resultTTLs = cfg
+	}
+}
+
+// cacheResults wraps the handle of a result route so that its successful JSON responses are served from the result
+// cache. If the result cache is disabled or the route is not a result route, the handle is returned as is.
+func (ws *WebService) cacheResults(path string, handle httprouter.Handle) httprouter.Handle {
+	endParam, ok := resultRoutes[path]
+	if ws.results == nil || !ok {
+		return handle
+	}
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if acceptsNDJSON(r) {
+			handle(w, r, p)
+			return
+		}
+		key, end, ok := resultKey(r, endParam)
+		if !ok {
+			handle(w, r, p)
+			return
+		}
+		body, ok, entry := ws.results.Get(r.Context(), key)
+		if ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(headerCache, "HIT")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(body)
+			return
+		}
+
+		w.Header().Set(headerCache, "MISS")
+		recorder := &cacheRecorder{ResponseWriter: w, status: http.StatusOK}
+		handle(recorder, r, p)
+		if recorder.cacheable() {
+			entry.StoreFor(r.Context(), recorder.body.Bytes(), ws.resultTTL(end, time.Now()))
+		}
+	}
+}
+
+// resultTTL returns how long a result whose time range ends at the end is cached. A nil end is a time range which
+// ends now.
+func (ws *WebService) resultTTL(end *time.Time, now time.Time) time.Duration {
+	if end != nil && end.Before(now.Add(-ws.resultTTLs.SettleAfter)) {
+		return ws.resultTTLs.PastTTL
+	}
+	return ws.resultTTLs.RecentTTL
+}
+
+// resultKey returns the key of the result of the request, which depends on the path, the sorted query parameters,
+// the normalized request body, the Accept header and the naming convention of the fields, together with the absolute
+// end of its time range, or nil if the time range ends now. It returns false if the result must not be cached, e.g.
+// because the request is invalid, in which case the handler responds with the error.
+func resultKey(r *http.Request, endParam string) (string, *time.Time, bool) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	if q.Err() != nil {
+		return "", nil, false
+	}
+	var end *time.Time
+	var body []byte
+	if r.Method == http.MethodPost {
+		req, ok := peekAnalyticsRequest(r)
+		if !ok {
+			return "", nil, false
+		}
+		// re-encoding the request normalizes the order of its fields and its whitespace
+		body, _ = json.Marshal(req)
+		end = analyticsWindowEnd(&req, loc)
+	} else {
+		end = absoluteTime(r.URL.Query().Get(endParam), loc)
+	}
+	key := strings.Join([]string{r.URL.Path, r.URL.Query().Encode(), string(body), r.Header.Get("Accept"),
+		fieldNamingFromContext(r.Context()), apiSchemaFromContext(r.Context())}, "\n")
+	return key, end, true
+}
+
+// peekAnalyticsRequest decodes the analytics request of the request body, and restores the body for the handler.
+// It returns false if the body is too large or not a valid analytics request.
+func peekAnalyticsRequest(r *http.Request) (analyticsRequest, bool) {
+	var req analyticsRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCachedRequestSize+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil || len(body) > maxCachedRequestSize {
+		return req, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	return req, decoder.Decode(&req) == nil
+}
+
+// analyticsWindowEnd returns the absolute end of the time range of the analytics request: the earliest of the upper
+// bounds of the filters on time fields. It returns nil if no filter bounds the time range with absolute times.
+func analyticsWindowEnd(req *analyticsRequest, loc *time.Location) *time.Time {
+	var end *time.Time
+	for _, f := range req.Filters {
+		fieldType, err := repository.AnalyticsFieldTypeOf(req.Entity, f.Field)
+		if err != nil || fieldType != repository.AnalyticsTime {
+			continue
+		}
+		values := []json.RawMessage{f.Value}
+		switch f.Operator {
+		case "<", "<=", "=":
+		case "in":
+			values = f.Values
+		default:
+			continue
+		}
+		if bound := latestTime(values, loc); bound != nil && (end == nil || bound.Before(*end)) {
+			end = bound
+		}
+	}
+	return end
+}
+
+// latestTime returns the latest of the JSON time values, or nil if any of them is not an absolute time.
+func latestTime(values []json.RawMessage, loc *time.Location) *time.Time {
+	var latest *time.Time
+	for _, raw := range values {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			var ts json.Number
+			if err := json.Unmarshal(raw, &ts); err != nil {
+				return nil
+			}
+			value = ts.String()
+		}
+		t := absoluteTime(value, loc)
+		if t == nil {
+			return nil
+		}
+		if latest == nil || t.After(*latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// absoluteTime parses the time value in one of the formats accepted by parseTime. It returns nil if the value is
+// empty, invalid or a duration relative to now.
+func absoluteTime(value string, loc *time.Location) *time.Time {
+	if value == "" {
+		return nil
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+		if _, err := parseDuration(value); err == nil {
+			return nil
+		}
+	}
+	t, err := parseTime(value, loc, time.Time{})
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,154 @@
+package webservice
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// ttlBackend records the TTL of the last stored value.
+type ttlBackend struct {
+	cache.Backend
+	ttl time.Duration
+}
+
+func (b *ttlBackend) Set(ctx context.Context, generation int64, key string, value []byte, ttl time.Duration) error {
+	b.ttl = ttl
+	return b.Backend.Set(ctx, generation, key, value, ttl)
+}
+
+func TestWebServiceResultCache(t *testing.T) {
+	featureFlags, err := featureflag.New(&config.FeatureFlagsConfig{Flags: map[string]bool{"analytics": true}})
+	require.NoError(t, err)
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	backend := &ttlBackend{Backend: cache.NewMemoryBackend(100)}
+	ttls := config.ResultsCacheConfig{RecentTTL: time.Minute, PastTTL: 24 * time.Hour, SettleAfter: time.Hour}
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil,
+		WithFeatureFlags(featureFlags),
+		WithResultCache(cache.NewWithBackend(backend, time.Minute, time.Second), ttls),
+	)
+	ws.init(context.Background())
+	query := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, routeAnalyticsQuery, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	repo.EXPECT().QueryAnalytics(gomock.Any(), gomock.Any()).Return([]*model.AnalyticsRow{}, nil)
+	rec := query(`{"entity": "applications", "aggregates": [{"function": "count"}],
+		"filters": [{"field": "submissionTime", "op": "<", "value": "2024-01-01T00:00:00Z"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+	assert.Equal(t, ttls.PastTTL, backend.ttl)
+	want := rec.Body.String()
+
+	// the same query with other field order and whitespace is served from the cache
+	rec = query(`{"filters":[{"value":"2024-01-01T00:00:00Z","op":"<","field":"submissionTime"}],
+		"aggregates":[{"function":"count"}],"entity":"applications"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "HIT", rec.Header().Get(headerCache))
+	assert.Equal(t, want, rec.Body.String())
+
+	// recent time ranges are cached briefly
+	repo.EXPECT().QueryAnalytics(gomock.Any(), gomock.Any()).Return([]*model.AnalyticsRow{}, nil)
+	rec = query(`{"entity": "applications", "aggregates": [{"function": "count"}],
+		"filters": [{"field": "submissionTime", "op": ">=", "value": "7d"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+	assert.Equal(t, ttls.RecentTTL, backend.ttl)
+
+	// invalid queries are not cached
+	for range 2 {
+		rec = query(`{"entity": "applications"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "MISS", rec.Header().Get(headerCache))
+	}
+}
+
+func TestResultKey(t *testing.T) {
+	now := time.Now()
+	ws := &WebService{resultTTLs: config.ResultsCacheConfig{RecentTTL: time.Minute, PastTTL: 24 * time.Hour, SettleAfter: time.Hour}}
+	tests := map[string]struct {
+		method   string
+		target   string
+		endParam string
+		body     string
+		wantTTL  time.Duration
+	}{
+		"past end time": {
+			method: http.MethodGet, target: routeQueueThroughput + "?endTime=2024-01-01T00:00:00Z", endParam: queryParamEndTime,
+			wantTTL: 24 * time.Hour,
+		},
+		"past Unix timestamp": {
+			method: http.MethodGet, target: routeTopReport + "?to=1704067200", endParam: queryParamTo,
+			wantTTL: 24 * time.Hour,
+		},
+		"without end time": {
+			method: http.MethodGet, target: routeQueueThroughput + "?startTime=2024-01-01T00:00:00Z", endParam: queryParamEndTime,
+			wantTTL: time.Minute,
+		},
+		"relative end time": {
+			method: http.MethodGet, target: routePriorityWaitTimes + "?endTime=30d", endParam: queryParamEndTime,
+			wantTTL: time.Minute,
+		},
+		"end time within settle period": {
+			method: http.MethodGet, target: routeTopReport + "?to=" + now.Add(-time.Minute).UTC().Format(time.RFC3339), endParam: queryParamTo,
+			wantTTL: time.Minute,
+		},
+		"query with earliest upper bound in the past": {
+			method: http.MethodPost, target: routeAnalyticsQuery,
+			body: `{"entity": "applications", "filters": [
+				{"field": "submissionTime", "op": "<=", "value": "2024-01-01"},
+				{"field": "finishedTime", "op": "<", "value": "7d"}]}`,
+			wantTTL: 24 * time.Hour,
+		},
+		"query with values in the past": {
+			method: http.MethodPost, target: routeAnalyticsQuery,
+			body:    `{"entity": "applications", "filters": [{"field": "submissionTime", "op": "in", "values": [1704067200, "2024-01-02T00:00:00Z"]}]}`,
+			wantTTL: 24 * time.Hour,
+		},
+		"query with lower bound only": {
+			method: http.MethodPost, target: routeAnalyticsQuery,
+			body:    `{"entity": "applications", "filters": [{"field": "submissionTime", "op": ">", "value": "2024-01-01"}]}`,
+			wantTTL: time.Minute,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			key, end, ok := resultKey(req, tt.endParam)
+			require.True(t, ok)
+			assert.NotEmpty(t, key)
+			assert.Equal(t, tt.wantTTL, ws.resultTTL(end, now))
+		})
+	}
+
+	// the query parameters are sorted
+	key1, _, _ := resultKey(httptest.NewRequest(http.MethodGet, routeTopReport+"?metric=cpu&by=user", nil), queryParamTo)
+	key2, _, _ := resultKey(httptest.NewRequest(http.MethodGet, routeTopReport+"?by=user&metric=cpu", nil), queryParamTo)
+	assert.Equal(t, key1, key2)
+
+	// the body is restored for the handler
+	body := `{"entity": "applications"}`
+	req := httptest.NewRequest(http.MethodPost, routeAnalyticsQuery, strings.NewReader(body))
+	_, _, ok := resultKey(req, "")
+	require.True(t, ok)
+	restored, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(restored))
+}
@@ -2,93 +2,602 @@ package webservice
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
 	"github.com/google/uuid"
 	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
 	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/retry"
 )
 
 const (
 	// routes
 	routeClusters                 = "/ws/v1/clusters"
+	routeCluster                  = "/ws/v1/clusters/:cluster_name"
+	routeClusterHeartbeat         = "/ws/v1/clusters/:cluster_name/heartbeat"
+	routeClusterPartitions        = "/ws/v1/clusters/:cluster_name/partitions"
+	routeIngest                   = "/ws/v1/ingest"
 	routePartitions               = "/ws/v1/partitions"
 	routeQueuesPerPartition       = "/ws/v1/partition/:partition_name/queues"
+	routeQueueACLs                = "/ws/v1/partition/:partition_name/queue/:queue_name/acls"
+	routeNamespaceQueues          = "/ws/v1/partition/:partition_name/namespace-queues"
 	routeAppsPerPartitionPerQueue = "/ws/v1/partition/:partition_name/queue/:queue_name/applications"
+	routeAppSummaries             = "/ws/v1/partition/:partition_name/queue/:queue_name/applications/summary"
+	routeApplication              = "/ws/v1/partition/:partition_name/queue/:queue_name/application/:application_id"
+	routeApplicationAllocations   = "/ws/v1/partition/:partition_name/queue/:queue_name/application/:application_id/allocations"
+	routeApplicationTrace         = "/ws/v1/application/:application_id/trace"
+	routeApplicationEvents        = "/ws/v1/application/:application_id/events"
+	routeAppsExport               = "/ws/v1/partition/:partition_name/queue/:queue_name/applications/export"
+	routeLiveApps                 = "/ws/v1/partition/:partition_name/queue/:queue_name/applications/live"
+	routeExport                   = "/ws/v1/exports/:export_id"
+	routeSnapshots                = "/ws/v1/snapshots"
+	routeSnapshot                 = "/ws/v1/snapshots/:snapshot_id"
+	routeAppsPerSparkApplication  = "/ws/v1/spark/applications/:spark_application_id"
+	routeAppsPerWorkflow          = "/ws/v1/workflows/:workflow_id/applications"
 	routeAppsHistory              = "/ws/v1/history/apps"
 	routeContainersHistory        = "/ws/v1/history/containers"
 	routeNodesPerPartition        = "/ws/v1/partition/:partition_name/nodes"
+	routeNodeGroups               = "/ws/v1/partition/:partition_name/node-groups"
+	routeUtilizationHeatmap       = "/ws/v1/partition/:partition_name/utilization-heatmap"
+	routeNodeImpact               = "/ws/v1/node/:node_id/impact"
 	routeNodeUtilization          = "/ws/v1/scheduler/node-utilizations"
 	routeSchedulerHealthcheck     = "/ws/v1/scheduler/healthcheck"
+	routeSchedulerEpochs          = "/ws/v1/scheduler/epochs"
 	routeEventStatistics          = "/ws/v1/event-statistics"
+	routeEventStream              = "/ws/v1/events/stream"
+	routeEventCategories          = "/ws/v1/event-statistics/categories"
+	routeEventSchemas             = "/ws/v1/schemas/events"
+	routeAnalyticsQuery           = "/ws/v1/query"
+	routeAnalyticsEstimate        = "/ws/v1/query/estimate"
+	routeQueueThroughput          = "/ws/v1/analytics/throughput"
+	routePriorityWaitTimes        = "/ws/v1/analytics/priorities"
+	routeQueueWaitPhases          = "/ws/v1/analytics/wait-phases"
+	routePartitionComparison      = "/ws/v1/analytics/partitions/compare"
+	routeTopReport                = "/ws/v1/reports/top"
+	routeSpotChurnReport          = "/ws/v1/reports/spot-churn"
+	routeDuplicatesReport         = "/ws/v1/reports/duplicates"
+	routeUsageCalendarReport      = "/ws/v1/reports/usage-calendar"
+	routeIncidentBundle           = "/ws/v1/reports/incident-bundle"
+	routeUsers                    = "/ws/v1/users"
+	routeGroups                   = "/ws/v1/groups"
+	routeAutoscalerEvents         = "/ws/v1/autoscaler/events"
+	routeAutoscalerTimeline       = "/ws/v1/partition/:partition_name/autoscaler-timeline"
+	routePoll                     = "/ws/v1/poll"
 	routeHealthLiveness           = "/ws/v1/health/liveness"
 	routeHealthReadiness          = "/ws/v1/health/readiness"
+	routeFeatureFlags             = "/ws/v1/admin/features"
+	routeFeatureFlag              = "/ws/v1/admin/features/:feature_name"
+	routeRetentionPolicies        = "/ws/v1/admin/retention-policies"
+	routeEffectiveRetention       = "/ws/v1/admin/retention-policies/effective"
+	routeAlertRules               = "/ws/v1/admin/alert-rules"
+	routeLegalHolds               = "/ws/v1/admin/legal-holds"
+	routeLegalHold                = "/ws/v1/admin/legal-holds/:hold_id"
+	routeLegalHoldRelease         = "/ws/v1/admin/legal-holds/:hold_id/release"
+	routeQueueLineage             = "/ws/v1/admin/queue-lineage"
+	routeQueueLineageLink         = "/ws/v1/admin/queue-lineage/:lineage_id"
+	routeEraseUser                = "/ws/v1/admin/erase-user"
+	routeBulkDelete               = "/ws/v1/admin/applications"
+	routeFaults                   = "/ws/v1/admin/faults"
+	routeCanaries                 = "/ws/v1/admin/canaries"
+	routeCanary                   = "/ws/v1/admin/canaries/:method"
+	routeDataQuality              = "/ws/v1/admin/data-quality"
+	routeSLOs                     = "/ws/v1/admin/slos"
+	routeSLO                      = "/ws/v1/admin/slos/:slo_name"
+	routeSLOCompliance            = "/ws/v1/admin/slos/:slo_name/compliance"
+	routeJobs                     = "/ws/v1/admin/jobs"
+	routeJobTrigger               = "/ws/v1/admin/jobs/:job_name/trigger"
+	routeJobPause                 = "/ws/v1/admin/jobs/:job_name/pause"
+	routeJobResume                = "/ws/v1/admin/jobs/:job_name/resume"
+	routeIngestion                = "/ws/v1/admin/ingestion"
+	routeIngestionPause           = "/ws/v1/admin/ingestion/pause"
+	routeIngestionResume          = "/ws/v1/admin/ingestion/resume"
+	routeMetrics                  = "/metrics"
 
 	// params
 	paramsPartitionName = "partition_name"
 	paramsQueueName     = "queue_name"
+	paramsApplicationID = "application_id"
+	paramsSparkAppID    = "spark_application_id"
+	paramsWorkflowID    = "workflow_id"
+	paramsFeatureName   = "feature_name"
+	paramsHoldID        = "hold_id"
+	paramsLineageID     = "lineage_id"
+	paramsClusterName   = "cluster_name"
+	paramsJobName       = "job_name"
+	paramsExportID      = "export_id"
+	paramsSLOName       = "slo_name"
+	paramsSnapshotID    = "snapshot_id"
+	paramsNodeID        = "node_id"
+	paramsMethod        = "method"
 )
 
+var errApplicationNotFound = errors.New("application not found")
+
 func (ws *WebService) init(ctx context.Context) {
 	router := httprouter.New()
 	router.NotFound = http.HandlerFunc(ws.serveSPA)
 
-	router.Handle(http.MethodGet, routePartitions, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeClusters, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getClusters(w, r)
+	})
+	ws.handleWrite(router, http.MethodPut, routeCluster, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.registerCluster(w, r, p)
+	})
+	ws.handleWrite(router, http.MethodPost, routeClusterHeartbeat, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.heartbeatCluster(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeClusterPartitions, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getClusterPartitions(w, r, p)
+	})
+	if ws.ingest {
+		ws.handleWrite(router, http.MethodPost, routeIngest, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.ingestBatch(w, r)
+		})
+	}
+	if ws.autoscaler != nil {
+		ws.handleWrite(router, http.MethodPost, routeAutoscalerEvents, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.postAutoscalerEvents(w, r)
+		})
+	}
+	ws.handle(router, http.MethodGet, routePartitions, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getPartitions(w, r, p)
 	})
-	router.Handle(http.MethodGet, routeQueuesPerPartition, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeQueuesPerPartition, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getQueuesPerPartition(w, r, p)
 	})
-	router.Handle(http.MethodGet, routeAppsPerPartitionPerQueue, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeQueueACLs, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getQueueACLs(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeNamespaceQueues, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getNamespaceQueues(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeAppsPerPartitionPerQueue, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getAppsPerPartitionPerQueue(w, r, p)
 	})
-	router.Handle(http.MethodGet, routeNodesPerPartition, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeAppSummaries, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getAppSummariesPerPartitionPerQueue(w, r, p)
+	})
+	if ws.live != nil {
+		ws.handle(router, http.MethodGet, routeLiveApps, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getLiveApplications(w, r, p)
+		})
+	}
+	ws.handle(router, http.MethodGet, routeApplication, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getApplication(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeApplicationAllocations, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getApplicationAllocations(w, r, p)
+	})
+	if ws.exports != nil {
+		ws.handle(router, http.MethodPost, routeAppsExport, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.createApplicationsExport(w, r, p)
+		})
+		ws.handle(router, http.MethodGet, routeExport, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getExport(w, r, p)
+		})
+	}
+	if ws.snapshots != nil {
+		ws.handle(router, http.MethodPost, routeSnapshots, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.createSnapshot(w, r)
+		})
+		ws.handle(router, http.MethodDelete, routeSnapshot, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.releaseSnapshot(w, r, p)
+		})
+	}
+	ws.handle(router, http.MethodGet, routeApplicationEvents, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getApplicationEvents(w, r, p)
+	})
+	if ws.traces {
+		ws.handle(router, http.MethodGet, routeApplicationTrace, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getApplicationTrace(w, r, p)
+		})
+	}
+	ws.handle(router, http.MethodGet, routeAppsPerSparkApplication, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getAppsPerSparkApplication(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeAppsPerWorkflow, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getAppsPerWorkflow(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeNodesPerPartition, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getNodesPerPartition(w, r, p)
 	})
-	router.Handle(http.MethodGet, routeAppsHistory, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeNodeGroups, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getNodeGroups(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeNodeImpact, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getNodeImpact(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeUtilizationHeatmap, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getUtilizationHeatmap(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeAutoscalerTimeline, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getAutoscalerTimeline(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeAppsHistory, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getAppsHistory(w, r)
 	})
-	router.Handle(http.MethodGet, routeContainersHistory, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeContainersHistory, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getContainersHistory(w, r)
 	})
-	router.Handle(http.MethodGet, routeNodeUtilization, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeNodeUtilization, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getNodeUtilizations(w, r)
 	})
-	router.Handle(http.MethodGet, routeEventStatistics, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeSchedulerEpochs, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getSchedulerEpochs(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeEventStatistics, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.getEventStatistics(w, r)
 	})
-	router.Handle(http.MethodGet, routeHealthLiveness, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if ws.eventStream != nil {
+		ws.handle(router, http.MethodGet, routeEventStream, ws.requireFeature(featureflag.EventStream,
+			func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+				enrichRequestContext(ctx, r)
+				ws.streamEvents(w, r)
+			},
+		))
+	}
+	ws.handle(router, http.MethodGet, routeEventCategories, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getEventCategories(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeEventSchemas, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getEventSchemas(w, r)
+	})
+	ws.handle(router, http.MethodPost, routeAnalyticsQuery, ws.requireFeature(featureflag.Analytics,
+		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.queryAnalytics(w, r)
+		},
+	))
+	ws.handle(router, http.MethodPost, routeAnalyticsEstimate, ws.requireFeature(featureflag.Analytics,
+		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.estimateAnalytics(w, r)
+		},
+	))
+	ws.handle(router, http.MethodGet, routeQueueThroughput, ws.requireFeature(featureflag.Analytics,
+		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getQueueThroughput(w, r)
+		},
+	))
+	ws.handle(router, http.MethodGet, routePriorityWaitTimes, ws.requireFeature(featureflag.Analytics,
+		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getPriorityWaitTimes(w, r)
+		},
+	))
+	ws.handle(router, http.MethodGet, routeQueueWaitPhases, ws.requireFeature(featureflag.Analytics,
+		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getQueueWaitPhases(w, r)
+		},
+	))
+	ws.handle(router, http.MethodGet, routePartitionComparison, ws.requireFeature(featureflag.Analytics,
+		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getPartitionComparison(w, r)
+		},
+	))
+	ws.handle(router, http.MethodGet, routeTopReport, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getTopReport(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeDuplicatesReport, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getDuplicatesReport(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeSpotChurnReport, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getSpotChurnReport(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeUsageCalendarReport, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getUsageCalendarReport(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeIncidentBundle, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getIncidentBundle(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeUsers, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getPrincipals(w, r, repository.PrincipalUser)
+	})
+	ws.handle(router, http.MethodGet, routeGroups, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getPrincipals(w, r, repository.PrincipalGroup)
+	})
+	ws.handle(router, http.MethodGet, routePoll, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.poll(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeHealthLiveness, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.LivenessHealthcheck(w, r)
 	})
-	router.Handle(http.MethodGet, routeHealthReadiness, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ws.handle(router, http.MethodGet, routeHealthReadiness, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		enrichRequestContext(ctx, r)
 		ws.ReadinessHealthcheck(w, r)
 	})
+	ws.handle(router, http.MethodGet, routeFeatureFlags, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getFeatureFlags(w, r)
+	})
+	if ws.featureFlags.OverridesAllowed() {
+		ws.handle(router, http.MethodPut, routeFeatureFlag, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.overrideFeatureFlag(w, r, p)
+		})
+		ws.handle(router, http.MethodDelete, routeFeatureFlag, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.resetFeatureFlag(w, r, p)
+		})
+	}
+	ws.handle(router, http.MethodGet, routeRetentionPolicies, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getRetentionPolicies(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeEffectiveRetention, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getEffectiveRetentionPolicies(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeAlertRules, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getAlertRules(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeLegalHolds, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getLegalHolds(w, r)
+	})
+	ws.handleWrite(router, http.MethodPost, routeLegalHolds, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.createLegalHold(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeLegalHold, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getLegalHold(w, r, p)
+	})
+	ws.handleWrite(router, http.MethodPost, routeLegalHoldRelease, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.releaseLegalHold(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeQueueLineage, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getQueueLineage(w, r)
+	})
+	ws.handleWrite(router, http.MethodPost, routeQueueLineage, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.createQueueLineage(w, r)
+	})
+	ws.handleWrite(router, http.MethodDelete, routeQueueLineageLink, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.deleteQueueLineage(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeDataQuality, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getDataQuality(w, r)
+	})
+	ws.handle(router, http.MethodGet, routeSLOs, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getSLOs(w, r)
+	})
+	ws.handleWrite(router, http.MethodPut, routeSLO, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.putSLO(w, r, p)
+	})
+	ws.handleWrite(router, http.MethodDelete, routeSLO, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.deleteSLO(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeSLOCompliance, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getSLOCompliance(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeJobs, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getJobs(w, r)
+	})
+	ws.handle(router, http.MethodPost, routeJobTrigger, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.triggerJob(w, r, p)
+	})
+	ws.handleWrite(router, http.MethodPost, routeJobPause, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.pauseJob(w, r, p)
+	})
+	ws.handleWrite(router, http.MethodPost, routeJobResume, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.resumeJob(w, r, p)
+	})
+	ws.handle(router, http.MethodGet, routeIngestion, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.getIngestion(w, r)
+	})
+	ws.handleWrite(router, http.MethodPost, routeIngestionPause, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.pauseIngestion(w, r)
+	})
+	ws.handleWrite(router, http.MethodPost, routeIngestionResume, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.resumeIngestion(w, r)
+	})
+	ws.handleWrite(router, http.MethodPost, routeEraseUser, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.eraseUser(w, r)
+	})
+	ws.handleWrite(router, http.MethodDelete, routeBulkDelete, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		enrichRequestContext(ctx, r)
+		ws.deleteApplications(w, r)
+	})
+	if ws.faults != nil {
+		ws.handle(router, http.MethodGet, routeFaults, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getFaults(w, r)
+		})
+		ws.handle(router, http.MethodPut, routeFaults, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.setFaults(w, r)
+		})
+		ws.handle(router, http.MethodDelete, routeFaults, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.resetFaults(w, r)
+		})
+	}
+	if ws.canary != nil {
+		ws.handle(router, http.MethodGet, routeCanaries, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.getCanaries(w, r)
+		})
+		ws.handle(router, http.MethodPut, routeCanary, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			enrichRequestContext(ctx, r)
+			ws.setCanary(w, r, p)
+		})
+	}
+	ws.registerExtensionRoutes(ctx, router)
+	// the Prometheus metrics are not part of the API, and are neither authenticated nor documented
+	router.Handler(http.MethodGet, routeMetrics, promhttp.Handler())
 
 	// Setup CORS
 	c := cors.New(ws.corsConfig)
-	ws.server.Handler = c.Handler(router)
+	handler := ws.negotiateFieldNaming(ws.negotiateAPISchema(ws.cacheResponses(ws.scopeClusterPaths(router))))
+	ws.splitAdminSurface(ws.limitHeaders(c.Handler(ws.authenticate(ws.mirrorRequests(handler)))))
+}
+
+// route identifies a registered API route.
+type route struct {
+	method string
+	path   string
+}
+
+// analyticsRoutes lists the API routes whose queries aggregate many rows, which are limited by the statement timeout
+// of analytics queries instead of the one of interactive queries.
+var analyticsRoutes = map[string]bool{
+	routeAnalyticsQuery:      true,
+	routeQueueThroughput:     true,
+	routePriorityWaitTimes:   true,
+	routeQueueWaitPhases:     true,
+	routePartitionComparison: true,
+	routeTopReport:           true,
+	routeSpotChurnReport:     true,
+	routeDuplicatesReport:    true,
+	routeUsageCalendarReport: true,
+	routeIncidentBundle:      true,
+	routeUsers:               true,
+	routeGroups:              true,
+}
+
+// handle registers the handle for the given method and path with the router and records the route.
+// The queries of the requests are limited by the statement timeout of their query class, and the analytics and
+// export requests count towards the quotas of their client. The routes shown by the UI serve their last successful
+// responses while the database is unavailable, if a fallback is configured.
+func (ws *WebService) handle(router *httprouter.Router, method, path string, handle httprouter.Handle) {
+	if fallbackRoutes[path] {
+		handle = ws.fallback(handle)
+	}
+	handle = ws.cacheResults(path, handle)
+	ws.register(router, method, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		class := postgres.QueryClassInteractive
+		switch {
+		case acceptsNDJSON(r) || path == routeAppsExport:
+			class = postgres.QueryClassExport
+		case analyticsRoutes[path]:
+			class = postgres.QueryClassAnalytics
+		}
+		*r = *r.WithContext(postgres.WithQueryClass(r.Context(), class))
+		if class != postgres.QueryClassInteractive {
+			done, ok := ws.enforceQuota(w, r)
+			if !ok {
+				return
+			}
+			defer done()
+		}
+		if id := r.URL.Query().Get(queryParamSnapshot); id != "" {
+			ws.readSnapshot(w, r, p, id, handle)
+			return
+		}
+		handle(w, r, p)
+	})
+}
+
+// handleWrite registers the handle of a route which writes to the database, whose queries are not limited by
+// a statement timeout. In read-only mode, the route is registered but requests are rejected.
+func (ws *WebService) handleWrite(router *httprouter.Router, method, path string, handle httprouter.Handle) {
+	if ws.readOnly {
+		handle = func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			problemResponse(w, r, http.StatusForbidden, errReadOnly)
+		}
+	}
+	ws.register(router, method, path, handle)
+}
+
+// register registers the handle with the router and records the route. The request bodies of the route are limited
+// to the maximum body size, and the duration and status codes of its requests are exposed as Prometheus metrics.
+// The fields renamed in the current release are rendered under their previous names if the previous response schema
+// is requested.
+func (ws *WebService) register(router *httprouter.Router, method, path string, handle httprouter.Handle) {
+	ws.routes = append(ws.routes, route{method: method, path: path})
+	if renames := ws.fieldRenames[method+" "+path]; len(renames) > 0 {
+		handle = withFieldRenames(renames, handle)
+	}
+	router.Handle(method, path, instrument(method, path, ws.limitBody(path, handle)))
 }
 
 func enrichRequestContext(ctx context.Context, r *http.Request) {
 	logger := log.FromContext(ctx)
 	rid := uuid.New().String()
 	logger = logger.With("request_id", rid)
-	ctx = log.ToContext(ctx, logger)
-	*r = *r.WithContext(ctx)
+	if principal, ok := principalFromContext(r.Context()); ok {
+		logger = logger.With("principal", principal)
+	}
+	*r = *r.WithContext(log.ToContext(r.Context(), logger))
 }
 
 func (ws *WebService) getPartitions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -97,7 +606,7 @@ func (ws *WebService) getPartitions(w http.ResponseWriter, r *http.Request, _ ht
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, partitions)
+	jsonResponse(w, r, partitions)
 }
 
 func (ws *WebService) getQueuesPerPartition(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
@@ -107,7 +616,91 @@ func (ws *WebService) getQueuesPerPartition(w http.ResponseWriter, r *http.Reque
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, queues)
+	jsonResponse(w, r, queues)
+}
+
+// getQueueACLs returns the snapshots of the access control lists of a queue, most recent first, to audit
+// who could submit to or administer the queue.
+// Following query params are supported:
+// - startTime: only include snapshots valid at or after this time
+// - endTime: only include snapshots valid at or before this time
+// - tz: timezone of the time filters without offset, UTC by default
+func (ws *WebService) getQueueACLs(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	queue := params.ByName(paramsQueueName)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	var filters repository.QueueACLFilters
+	filters.Start, filters.End = q.TimeRange(queryParamStartTime, queryParamEndTime, loc, time.Now())
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	acls, err := ws.repository.GetQueueACLs(r.Context(), partition, queue, filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, acls)
+}
+
+// getSchedulerEpochs returns the epochs of Yunikorn, most recent first, so that analytics can exclude or annotate
+// the windows around the restarts of the scheduler.
+// Following query params are supported:
+// - startTime: only include epochs which ended at or after this time
+// - endTime: only include epochs which started at or before this time
+// - tz: timezone of the time filters without offset, UTC by default
+// - cluster: only include the epochs of the scheduler of this cluster
+func (ws *WebService) getSchedulerEpochs(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.SchedulerEpochFilters{Cluster: q.Cluster()}
+	filters.Start, filters.End = q.TimeRange(queryParamStartTime, queryParamEndTime, loc, time.Now())
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	epochs, err := ws.repository.GetSchedulerEpochs(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, epochs)
+}
+
+// getNamespaceQueues returns the queues the applications of the Kubernetes namespaces of a partition were placed in,
+// ordered by namespace and most recent first, to attribute applications to the namespaces of teams after the
+// mappings changed.
+// Following query params are supported:
+// - namespace: filter by namespace
+// - queue: filter by queue
+// - startTime: only include queues applications were submitted to at or after this time
+// - endTime: only include queues applications were submitted to at or before this time
+// - tz: timezone of the time filters without offset, UTC by default
+func (ws *WebService) getNamespaceQueues(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.NamespaceQueueFilters{
+		Namespace: q.String(queryParamNamespace),
+		QueueName: q.String(queryParamQueue),
+	}
+	filters.Start, filters.End = q.TimeRange(queryParamStartTime, queryParamEndTime, loc, time.Now())
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	mappings, err := ws.repository.GetNamespaceQueues(r.Context(), partition, filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, mappings)
 }
 
 // getAppsPerPartitionPerQueue returns all applications for a given partition and queue.
@@ -117,52 +710,348 @@ func (ws *WebService) getQueuesPerPartition(w http.ResponseWriter, r *http.Reque
 // - groups: filter by groups (comma-separated list)
 // - submissionStartTime: filter from the submission time
 // - submissionEndTime: filter until the submission time
+// - minDuration, maxDuration: filter by the duration of finished applications, e.g. 1h
+// - minWaitTime, maxWaitTime: filter by the time applications waited until they started running, e.g. 5m
+// - orderBy: order by submissionTime (default), duration or waitTime in descending order
+// - lineage: include the applications of the former paths of the queue if true, see the queue lineage
+// - tz: timezone of the submission time filters without offset and of the createdAt timestamps, UTC by default
 // - limit: limit the number of returned applications
 // - offset: offset the returned applications
+// - cursor: return the applications after the cursor of the next page of a previous page, instead of an offset
+//
+// The number of applications of all pages and the cursor of the next page are returned in headers, see setPageHeaders.
 func (ws *WebService) getAppsPerPartitionPerQueue(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	partition := params.ByName(paramsPartitionName)
 	queue := params.ByName(paramsQueueName)
 
-	filters, err := parseApplicationFilters(r)
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := parseApplicationFilters(q, loc, streamPageSize(r, ws.pageSizes.Applications))
+	filters.After = q.Cursor()
+	lineage := q.Bool(queryParamLineage)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if lineage != nil && *lineage {
+		var err error
+		if filters.FormerQueues, err = ws.formerQueues(r.Context(), partition, queue); err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+	}
+
+	if acceptsNDJSON(r) {
+		stream := newNDJSONStream(w, r)
+		stream.Close(ws.repository.StreamAppsPerPartitionPerQueue(r.Context(), partition, queue, filters,
+			func(app *model.ApplicationDAOInfo) error {
+				ws.prepareApplication(r, app, loc)
+				return stream.Write(app)
+			}))
+		return
+	}
+
+	apps, page, err := ws.repository.GetAppsPerPartitionPerQueuePage(r.Context(), partition, queue, filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	for _, app := range apps {
+		ws.prepareApplication(r, app, loc)
+	}
+	setPageHeaders(w, r, page)
+	jsonResponse(w, r, apps)
+}
+
+// getAppSummariesPerPartitionPerQueue returns the summaries of the applications for a given partition and queue,
+// which are served from the denormalized summary table instead of the applications of both tiers.
+// Results are ordered by submission time in descending order.
+// Following query params are supported:
+// - user: filter by user
+// - groups: filter by groups (comma-separated list)
+// - submissionStartTime: filter from the submission time
+// - submissionEndTime: filter until the submission time
+// - minDuration, maxDuration: filter by the duration of finished applications, e.g. 1h
+// - minWaitTime, maxWaitTime: filter by the time applications waited until they started running, e.g. 5m
+// - orderBy: order by submissionTime (default), duration or waitTime in descending order
+// - lineage: include the summaries of the former paths of the queue if true, see the queue lineage
+// - tz: timezone of the submission time filters without offset, UTC by default
+// - limit: limit the number of returned summaries
+// - offset: offset the returned summaries
+func (ws *WebService) getAppSummariesPerPartitionPerQueue(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	queue := params.ByName(paramsQueueName)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := parseApplicationFilters(q, loc, ws.pageSizes.Applications)
+	lineage := q.Bool(queryParamLineage)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if lineage != nil && *lineage {
+		var err error
+		if filters.FormerQueues, err = ws.formerQueues(r.Context(), partition, queue); err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+	}
+
+	summaries, err := ws.repository.GetApplicationSummaries(r.Context(), partition, queue, filters)
 	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, summaries)
+}
+
+// getApplication returns an application of the given partition and queue, with its Spark application ID,
+// its retry chain and its links to external systems such as the Spark History Server.
+// Following query params are supported:
+// - tz: timezone of the createdAt timestamp, UTC by default
+func (ws *WebService) getApplication(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	queue := params.ByName(paramsQueueName)
+	appID := params.ByName(paramsApplicationID)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	if err := q.Err(); err != nil {
 		badRequestResponse(w, r, err)
 		return
 	}
 
-	apps, err := ws.repository.GetAppsPerPartitionPerQueue(r.Context(), partition, queue, *filters)
+	apps, err := ws.repository.GetAppsPerPartitionPerQueue(r.Context(), partition, queue,
+		repository.ApplicationFilters{ApplicationID: &appID})
 	if err != nil {
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, apps)
+	if len(apps) == 0 {
+		notFoundResponse(w, r, fmt.Errorf("%w: %s in queue %s of partition %s", errApplicationNotFound, appID, queue, partition))
+		return
+	}
+	app := apps[0]
+	if app.RetryKey != "" {
+		if app.RetryChain, err = ws.retryChain(r, app); err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+	}
+	ws.prepareApplication(r, app, loc)
+	jsonResponse(w, r, app)
 }
 
+// retryChain returns the retry chain of an application, linking it with the other attempts of its job: the
+// applications of its partition with the same retry key.
+func (ws *WebService) retryChain(r *http.Request, app *model.ApplicationDAOInfo) ([]*model.RetryAttempt, error) {
+	attempts, err := ws.repository.GetAllApplications(r.Context(), repository.ApplicationFilters{RetryKey: &app.RetryKey})
+	if err != nil {
+		return nil, err
+	}
+	var partitionAttempts []*model.ApplicationDAOInfo
+	for _, attempt := range attempts {
+		if attempt.Partition == app.Partition {
+			partitionAttempts = append(partitionAttempts, attempt)
+		}
+	}
+	return retry.Chain(partitionAttempts, app.ApplicationID), nil
+}
+
+// getApplicationAllocations returns the placement constraints of the allocations of an application and the nodes
+// they were placed on, in the order they were requested, to investigate why an allocation was placed on a node.
+func (ws *WebService) getApplicationAllocations(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	partition := params.ByName(paramsPartitionName)
+	queue := params.ByName(paramsQueueName)
+	appID := params.ByName(paramsApplicationID)
+
+	constraints, err := ws.repository.GetAllocationConstraints(r.Context(), partition, queue, appID)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if len(constraints) == 0 {
+		// tell an application without allocations from an application which does not exist
+		apps, err := ws.repository.GetAppsPerPartitionPerQueue(r.Context(), partition, queue,
+			repository.ApplicationFilters{ApplicationID: &appID})
+		if err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+		if len(apps) == 0 {
+			notFoundResponse(w, r, fmt.Errorf("%w: %s in queue %s of partition %s", errApplicationNotFound, appID, queue, partition))
+			return
+		}
+		constraints = []*model.AllocationConstraints{}
+	}
+	jsonResponse(w, r, constraints)
+}
+
+// getAppsPerSparkApplication returns the applications correlated with a Spark application ID, so that users
+// can pivot from the Spark History Server to the scheduler history of the application.
+// Results are ordered by submission time in descending order.
+// Following query params are supported:
+// - tz: timezone of the createdAt timestamps, UTC by default
+// - cluster: only include the applications of this cluster
+func (ws *WebService) getAppsPerSparkApplication(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	sparkAppID := params.ByName(paramsSparkAppID)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.ApplicationFilters{SparkApplicationID: &sparkAppID, Cluster: q.Cluster()}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	apps, err := ws.repository.GetAllApplications(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	for _, app := range apps {
+		ws.prepareApplication(r, app, loc)
+	}
+	jsonResponse(w, r, apps)
+}
+
+// getAppsPerWorkflow returns the applications caused by a workflow run, such as an Airflow DAG run
+// or an Argo workflow, across all partitions and queues.
+// Results are ordered by submission time in descending order.
+// Following query params are supported:
+// - user: filter by user
+// - groups: filter by groups (comma-separated list)
+// - submissionStartTime: filter from the submission time
+// - submissionEndTime: filter until the submission time
+// - minDuration, maxDuration: filter by the duration of finished applications, e.g. 1h
+// - minWaitTime, maxWaitTime: filter by the time applications waited until they started running, e.g. 5m
+// - orderBy: order by submissionTime (default), duration or waitTime in descending order
+// - tz: timezone of the submission time filters without offset and of the createdAt timestamps, UTC by default
+// - limit: limit the number of returned applications
+// - offset: offset the returned applications
+// - cluster: only include the applications of this cluster
+func (ws *WebService) getAppsPerWorkflow(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	workflowID := params.ByName(paramsWorkflowID)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := parseApplicationFilters(q, loc, ws.pageSizes.Applications)
+	filters.Cluster = q.Cluster()
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	filters.WorkflowID = &workflowID
+
+	apps, err := ws.repository.GetAllApplications(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	for _, app := range apps {
+		ws.prepareApplication(r, app, loc)
+	}
+	jsonResponse(w, r, apps)
+}
+
+// prepareApplication converts the timestamps of the application to the requested timezone
+// and adds its links to external systems.
+func (ws *WebService) prepareApplication(r *http.Request, app *model.ApplicationDAOInfo, loc *time.Location) {
+	app.CreatedAt = app.CreatedAt.In(loc)
+	app.ExternalLinks = ws.links.Application(r.Context(), app)
+	if link := ws.sparkHistory.Link(app); link != nil {
+		app.ExternalLinks = append(app.ExternalLinks, *link)
+	}
+}
+
+// getNodesPerPartition returns the nodes of a partition ordered by node ID.
+// Following query params are supported:
+// - limit: limit the number of returned nodes, the default limit of the nodes page size by default
+// - cursor: return the nodes after the cursor of the next page of a previous page
 func (ws *WebService) getNodesPerPartition(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	partition := params.ByName(paramsPartitionName)
-	nodes, err := ws.repository.GetNodesPerPartition(r.Context(), partition)
+	q := newQueryParams(r)
+	pageParams := q.Page(ws.pageSizes.Nodes)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	nodes, page, err := ws.repository.GetNodesPerPartitionPage(r.Context(), partition, pageParams)
 	if err != nil {
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, nodes)
+	setPageHeaders(w, r, page)
+	jsonResponse(w, r, nodes)
 }
 
+// getAppsHistory returns the applications history of the default cluster, or of the cluster param, in chronological
+// order. The limit and cursor params paginate it like the nodes of a partition, while streamed responses return the
+// whole history.
 func (ws *WebService) getAppsHistory(w http.ResponseWriter, r *http.Request) {
-	appsHistory, err := ws.repository.GetApplicationsHistory(r.Context())
+	q := newQueryParams(r)
+	ctx := historyContext(r.Context(), q.Cluster())
+	if acceptsNDJSON(r) {
+		if err := q.Err(); err != nil {
+			badRequestResponse(w, r, err)
+			return
+		}
+		stream := newNDJSONStream(w, r)
+		stream.Close(ws.repository.StreamApplicationsHistory(ctx, func(app *dao.ApplicationHistoryDAOInfo) error {
+			return stream.Write(app)
+		}))
+		return
+	}
+	pageParams := q.Page(ws.pageSizes.History)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	appsHistory, page, err := ws.repository.GetApplicationsHistoryPage(ctx, pageParams)
 	if err != nil {
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, appsHistory)
+	setPageHeaders(w, r, page)
+	jsonResponse(w, r, appsHistory)
 }
 
+// getContainersHistory returns the containers history in chronological order, paginated like the applications history.
 func (ws *WebService) getContainersHistory(w http.ResponseWriter, r *http.Request) {
-	containersHistory, err := ws.repository.GetContainersHistory(r.Context())
+	q := newQueryParams(r)
+	ctx := historyContext(r.Context(), q.Cluster())
+	if acceptsNDJSON(r) {
+		if err := q.Err(); err != nil {
+			badRequestResponse(w, r, err)
+			return
+		}
+		stream := newNDJSONStream(w, r)
+		stream.Close(ws.repository.StreamContainersHistory(ctx, func(container *dao.ContainerHistoryDAOInfo) error {
+			return stream.Write(container)
+		}))
+		return
+	}
+	pageParams := q.Page(ws.pageSizes.History)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	containersHistory, page, err := ws.repository.GetContainersHistoryPage(ctx, pageParams)
 	if err != nil {
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, containersHistory)
+	setPageHeaders(w, r, page)
+	jsonResponse(w, r, containersHistory)
+}
+
+// historyContext returns the context scoped to the cluster whose history is read, the default cluster unless id is set.
+func historyContext(ctx context.Context, id *string) context.Context {
+	if id == nil {
+		return ctx
+	}
+	return cluster.WithID(ctx, *id)
 }
 
 func (ws *WebService) getNodeUtilizations(w http.ResponseWriter, r *http.Request) {
@@ -171,7 +1060,7 @@ func (ws *WebService) getNodeUtilizations(w http.ResponseWriter, r *http.Request
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, nodeUtilization)
+	jsonResponse(w, r, nodeUtilization)
 }
 
 func (ws *WebService) getEventStatistics(w http.ResponseWriter, r *http.Request) {
@@ -180,15 +1069,15 @@ func (ws *WebService) getEventStatistics(w http.ResponseWriter, r *http.Request)
 		errorResponse(w, r, err)
 		return
 	}
-	jsonResponse(w, counts)
+	jsonResponse(w, r, counts)
 }
 
 func (ws *WebService) LivenessHealthcheck(w http.ResponseWriter, r *http.Request) {
-	jsonResponse(w, ws.healthService.Liveness(r.Context()))
+	jsonResponse(w, r, ws.healthService.Liveness(r.Context()))
 }
 
 func (ws *WebService) ReadinessHealthcheck(w http.ResponseWriter, r *http.Request) {
-	jsonResponse(w, ws.healthService.Readiness(r.Context()))
+	jsonResponse(w, r, ws.healthService.Readiness(r.Context()))
 }
 
 func (ws *WebService) serveSPA(w http.ResponseWriter, r *http.Request) {
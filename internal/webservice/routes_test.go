@@ -1,13 +1,29 @@
 package webservice
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/links"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/spark"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
 )
 
 func TestWebServiceServeSPA(t *testing.T) {
@@ -56,3 +72,490 @@ func TestWebServiceServeSPA(t *testing.T) {
 		})
 	}
 }
+
+func TestWebServiceReadOnly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetLegalHolds(gomock.Any(), gomock.Any()).Return([]*model.LegalHold{}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080, ReadOnly: true}, repo, nil, nil)
+	ws.init(context.Background())
+
+	tests := map[string]struct {
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		"read": {
+			method:     http.MethodGet,
+			path:       routeLegalHolds,
+			wantStatus: http.StatusOK,
+		},
+		"create legal hold": {
+			method:     http.MethodPost,
+			path:       routeLegalHolds,
+			body:       `{"partition": "default", "queueName": "root.compliance", "reason": "audit"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		"erase user": {
+			method:     http.MethodPost,
+			path:       routeEraseUser,
+			body:       `{"user": "alice"}`,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestWebServiceGetAppsTimezone(t *testing.T) {
+	createdAt := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueuePage(gomock.Any(), "default", "root.default", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, filters repository.ApplicationFilters) (
+			[]*model.ApplicationDAOInfo, repository.PageInfo, error) {
+			// the date is interpreted in the requested timezone
+			assert.True(t, filters.SubmissionStartTime.Equal(time.Date(2024, 6, 30, 22, 0, 0, 0, time.UTC)))
+			return []*model.ApplicationDAOInfo{{CreatedAt: createdAt}}, repository.PageInfo{Total: 1}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/applications?submissionStartTime=2024-07-01&tz=Europe/Berlin", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"createdAt":"2024-07-01T14:00:00+02:00"`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/applications?tz=Mars/Olympus", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceGetAppsExternalLinks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	app := &model.ApplicationDAOInfo{ApplicationDAOInfo: dao.ApplicationDAOInfo{ApplicationID: "app-1"}}
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueuePage(gomock.Any(), "default", "root.default", gomock.Any()).
+		Return([]*model.ApplicationDAOInfo{app}, repository.PageInfo{Total: 1}, nil)
+	renderer, err := links.New(&config.LinksConfig{Applications: []config.LinkTemplate{
+		{Name: "logs", URL: "https://logs.example.com/?app={{ .ApplicationID | urlquery }}"},
+	}})
+	require.NoError(t, err)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithLinks(renderer))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/applications", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"externalLinks":[{"name":"logs","url":"https://logs.example.com/?app=app-1"}]`)
+}
+
+func TestWebServiceGetApplication(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	app := &model.ApplicationDAOInfo{
+		ApplicationDAOInfo: dao.ApplicationDAOInfo{ApplicationID: "spark-pi"},
+		SparkApplicationID: "spark-0123456789abcdef",
+	}
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default",
+			repository.ApplicationFilters{ApplicationID: util.ToPtr("spark-pi")}).
+		Return([]*model.ApplicationDAOInfo{app}, nil)
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default",
+			repository.ApplicationFilters{ApplicationID: util.ToPtr("missing")}).
+		Return(nil, nil)
+	historyServer, err := spark.NewHistoryServer(&config.SparkConfig{
+		ApplicationIDTag: config.DefaultSparkApplicationIDTag,
+		HistoryServerURL: "https://spark-history.example.com",
+	})
+	require.NoError(t, err)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithSparkHistoryServer(historyServer))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/application/spark-pi", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"sparkApplicationId":"spark-0123456789abcdef"`)
+	assert.Contains(t, rec.Body.String(),
+		`"externalLinks":[{"name":"spark-history","url":"https://spark-history.example.com/history/spark-0123456789abcdef/"}]`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/application/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestWebServiceGetAppsPerSparkApplication(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	app := &model.ApplicationDAOInfo{
+		ApplicationDAOInfo: dao.ApplicationDAOInfo{ApplicationID: "spark-pi", Partition: "default", QueueName: "root.default"},
+		SparkApplicationID: "spark-0123456789abcdef",
+	}
+	repo.EXPECT().
+		GetAllApplications(gomock.Any(), repository.ApplicationFilters{SparkApplicationID: util.ToPtr("spark-0123456789abcdef")}).
+		Return([]*model.ApplicationDAOInfo{app}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/spark/applications/spark-0123456789abcdef", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"applicationID":"spark-pi"`)
+	assert.NotContains(t, rec.Body.String(), "externalLinks")
+}
+
+func TestWebServiceGetAppsPerWorkflow(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	app := &model.ApplicationDAOInfo{
+		ApplicationDAOInfo: dao.ApplicationDAOInfo{ApplicationID: "daily-etl-extract"},
+		WorkflowID:         "daily_etl:manual__2024-07-01",
+	}
+	repo.EXPECT().
+		GetAllApplications(gomock.Any(), repository.ApplicationFilters{
+			WorkflowID: util.ToPtr("daily_etl:manual__2024-07-01"),
+			User:       util.ToPtr("john"),
+			Limit:      util.ToPtr(10),
+		}).
+		Return([]*model.ApplicationDAOInfo{app}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/workflows/daily_etl:manual__2024-07-01/applications?user=john&limit=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"workflowId":"daily_etl:manual__2024-07-01"`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/workflows/etl/applications?limit=-1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceGetAppSummaries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	duration := int64(time.Minute)
+	summary := &model.ApplicationSummary{
+		ApplicationID: "app-1",
+		Partition:     "default",
+		QueueName:     "root.default",
+		User:          "john",
+		State:         "Completed",
+		Duration:      &duration,
+		VcoreSeconds:  120,
+	}
+	repo.EXPECT().
+		GetApplicationSummaries(gomock.Any(), "default", "root.default", repository.ApplicationFilters{
+			User:        util.ToPtr("john"),
+			MinDuration: util.ToPtr(time.Minute),
+			OrderBy:     repository.OrderByDuration,
+			Limit:       util.ToPtr(10),
+		}).
+		Return([]*model.ApplicationSummary{summary}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/applications/summary?user=john&minDuration=1m&orderBy=duration&limit=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"applicationID":"app-1"`)
+	assert.Contains(t, rec.Body.String(), `"duration":60000000000`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/applications/summary?limit=-1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/applications/summary?orderBy=user", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceGetQueueACLs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+	acl := &model.QueueACL{
+		Partition:   "default",
+		QueueName:   "root.analytics",
+		SubmitACL:   "alice analysts",
+		SubmitUsers: []string{"alice"},
+		ValidFrom:   start.Unix(),
+	}
+	repo.EXPECT().
+		GetQueueACLs(gomock.Any(), "default", "root.analytics", repository.QueueACLFilters{Start: &start, End: &end}).
+		Return([]*model.QueueACL{acl}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.analytics/acls?startTime=2024-07-01&endTime=2024-07-02", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"submitAcl":"alice analysts"`)
+	assert.NotContains(t, rec.Body.String(), "validTo")
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.analytics/acls?startTime=2024-07-02&endTime=2024-07-01", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceGetNamespaceQueues(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+	mapping := &model.NamespaceQueue{
+		Partition:           "default",
+		Namespace:           "team-a",
+		QueueName:           "root.team-a",
+		FirstSubmissionTime: start.UnixNano(),
+		LastSubmissionTime:  start.UnixNano(),
+		Applications:        1,
+	}
+	repo.EXPECT().
+		GetNamespaceQueues(gomock.Any(), "default", repository.NamespaceQueueFilters{
+			Namespace: util.ToPtr("team-a"),
+			Start:     &start,
+			End:       &end,
+		}).
+		Return([]*model.NamespaceQueue{mapping}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/namespace-queues?namespace=team-a&startTime=2024-07-01&endTime=2024-07-02", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"queueName":"root.team-a"`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/namespace-queues?startTime=2024-07-02&endTime=2024-07-01", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceGetApplicationRetryChain(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	attempt := func(id, partition, state string, submitted int64) *model.ApplicationDAOInfo {
+		return &model.ApplicationDAOInfo{
+			ApplicationDAOInfo: dao.ApplicationDAOInfo{
+				ApplicationID: id, Partition: partition, QueueName: "root.default", State: state, SubmissionTime: submitted,
+			},
+			RetryKey: "etl:load",
+		}
+	}
+	app := attempt("etl-2", "default", "Completed", 200)
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default",
+			repository.ApplicationFilters{ApplicationID: util.ToPtr("etl-2")}).
+		Return([]*model.ApplicationDAOInfo{app}, nil)
+	repo.EXPECT().
+		GetAllApplications(gomock.Any(), repository.ApplicationFilters{RetryKey: util.ToPtr("etl:load")}).
+		Return([]*model.ApplicationDAOInfo{
+			attempt("etl-1", "default", "Failed", 100),
+			app,
+			attempt("etl-3", "default", "Failed", 300),
+			attempt("etl-other", "other", "Failed", 150),
+		}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/application/etl-2", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got model.ApplicationDAOInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got.RetryChain, 2)
+	assert.Equal(t, "etl-1", got.RetryChain[0].ApplicationID)
+	assert.Equal(t, 1, got.RetryChain[0].Attempt)
+	assert.Equal(t, "etl-2", got.RetryChain[1].ApplicationID)
+	assert.Equal(t, 2, got.RetryChain[1].Attempt)
+}
+
+func TestWebServiceGetApplicationAllocations(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	constraints := &model.AllocationConstraints{
+		AllocationKey: "pod-1",
+		TaskGroupName: util.ToPtr("executors"),
+		NodeSelector:  map[string]string{"disktype": "ssd"},
+		NodeID:        util.ToPtr("node-1"),
+	}
+	repo.EXPECT().
+		GetAllocationConstraints(gomock.Any(), "default", "root.default", "app-1").
+		Return([]*model.AllocationConstraints{constraints}, nil)
+	repo.EXPECT().
+		GetAllocationConstraints(gomock.Any(), "default", "root.default", "unknown").
+		Return(nil, nil)
+	repo.EXPECT().
+		GetAppsPerPartitionPerQueue(gomock.Any(), "default", "root.default",
+			repository.ApplicationFilters{ApplicationID: util.ToPtr("unknown")}).
+		Return(nil, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/application/app-1/allocations", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"nodeSelector":{"disktype":"ssd"}`)
+	assert.Contains(t, rec.Body.String(), `"nodeId":"node-1"`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/partition/default/queue/root.default/application/unknown/allocations", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func FuzzWebServiceQueryParams(f *testing.F) {
+	f.Add("/ws/v1/partition/default/queue/root.default/applications", "user=john&limit=10&submissionStartTime=24h")
+	f.Add("/ws/v1/partition/default/queue/root.default/applications", "limit=-1&offset=1e9&tz=%00")
+	f.Add("/ws/v1/admin/legal-holds", "active=yes&partition=%ff&limit=99999999999999999999")
+
+	f.Fuzz(func(t *testing.T, path, query string) {
+		mockCtrl := gomock.NewController(t)
+		repo := repository.NewMockRepository(mockCtrl)
+		repo.EXPECT().GetAppsPerPartitionPerQueue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		repo.EXPECT().GetAppsPerPartitionPerQueuePage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, repository.PageInfo{}, nil).AnyTimes()
+		repo.EXPECT().GetLegalHolds(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+		ws.init(context.Background())
+
+		if path != routeLegalHolds {
+			path = "/ws/v1/partition/default/queue/root.default/applications"
+		}
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.URL.RawQuery = query
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK && rec.Code != http.StatusBadRequest {
+			t.Errorf("unexpected status %d for query %q: %s", rec.Code, query, rec.Body.String())
+		}
+	})
+}
+
+func TestWebServiceMetrics(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, WithAPIKeys(map[string]string{"ci": "ci-secret"}))
+	ws.init(context.Background())
+	// the sync metrics of the scheduler of a cluster are exported once it is synced
+	yunikorn.NewSyncTracker("eu-west").RecordFailure(errors.New("database error"))
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, routeMetrics, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `yhs_yunikorn_sync_consecutive_failures{cluster="eu-west"}`)
+	assert.Contains(t, rec.Body.String(), `yhs_yunikorn_sync_failures_total{category="parse",cluster="eu-west"}`)
+}
+
+func TestWebServiceGetSchedulerEpochs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+	epochs := []*model.SchedulerEpoch{
+		{ID: "e2", InstanceID: util.ToPtr("instance-2"), StartTime: 200, DetectedAt: 210},
+		{ID: "e1", StartTime: 100, EndTime: util.ToPtr(int64(200)), DetectedAt: 110},
+	}
+	repo.EXPECT().
+		GetSchedulerEpochs(gomock.Any(), repository.SchedulerEpochFilters{Start: &start, End: &end}).
+		Return(epochs, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/scheduler/epochs?startTime=2024-07-01&endTime=2024-07-02", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[
+		{"id": "e2", "instanceId": "instance-2", "startTime": 200, "detectedAt": 210},
+		{"id": "e1", "startTime": 100, "endTime": 200, "detectedAt": 110}
+	]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/scheduler/epochs?startTime=2024-07-02&endTime=2024-07-01", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	repo.EXPECT().
+		GetSchedulerEpochs(gomock.Any(), repository.SchedulerEpochFilters{Cluster: util.ToPtr("eu-west")}).
+		Return([]*model.SchedulerEpoch{{ID: "e3", ClusterID: "eu-west", StartTime: 300, DetectedAt: 310}}, nil)
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/scheduler/epochs?cluster=eu-west", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"id": "e3", "clusterId": "eu-west", "startTime": 300, "detectedAt": 310}]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/scheduler/epochs?cluster=EU_West", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceQueryClasses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	var classes []postgres.QueryClass
+	record := func(ctx context.Context) {
+		classes = append(classes, postgres.QueryClassFromContext(ctx))
+	}
+	repo.EXPECT().GetAllPartitions(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]*dao.PartitionInfo, error) {
+		record(ctx)
+		return nil, nil
+	})
+	repo.EXPECT().GetTopUsage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ repository.TopUsageFilters) ([]*model.TopUsage, error) {
+			record(ctx)
+			return nil, nil
+		})
+	repo.EXPECT().StreamAppsPerPartitionPerQueue(gomock.Any(), "default", "root.a", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _, _ string, _ repository.ApplicationFilters, _ func(*model.ApplicationDAOInfo) error) error {
+			record(ctx)
+			return nil
+		})
+	repo.EXPECT().RegisterCluster(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *model.Cluster) error {
+		record(ctx)
+		return nil
+	})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, routePartitions, nil),
+		httptest.NewRequest(http.MethodGet, "/ws/v1/reports/top?metric=vcore_seconds&by=queue", nil),
+		httptest.NewRequest(http.MethodGet, "/ws/v1/partition/default/queue/root.a/applications", nil),
+		httptest.NewRequest(http.MethodPut, "/ws/v1/clusters/prod", strings.NewReader(`{"schedulerVersion": "1.5.2"}`)),
+	} {
+		if strings.HasSuffix(req.URL.Path, "/applications") {
+			req.Header.Set("Accept", ndjsonContentType)
+		}
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	// the queries of writes are not limited by a statement timeout
+	assert.Equal(t, []postgres.QueryClass{
+		postgres.QueryClassInteractive, postgres.QueryClassAnalytics, postgres.QueryClassExport, "",
+	}, classes)
+}
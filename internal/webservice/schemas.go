@@ -0,0 +1,37 @@
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/G-Research/yunikorn-history-server/internal/eventschema"
+)
+
+// eventSchema is the response of an event schema. The schema is encoded beforehand, so that its keywords
+// and the property names it describes are not renamed by the field naming of the request.
+type eventSchema struct {
+	Type        string          `json:"type"`
+	Version     int             `json:"version"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// getEventSchemas returns the JSON Schema of each event type and version.
+func (ws *WebService) getEventSchemas(w http.ResponseWriter, r *http.Request) {
+	events := eventschema.Events()
+	schemas := make([]eventSchema, 0, len(events))
+	for _, event := range events {
+		schema, err := json.Marshal(event.Schema)
+		if err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+		schemas = append(schemas, eventSchema{
+			Type:        event.Type,
+			Version:     event.Version,
+			Description: event.Description,
+			Schema:      schema,
+		})
+	}
+	jsonResponse(w, r, schemas)
+}
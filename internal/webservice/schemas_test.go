@@ -0,0 +1,48 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestWebServiceGetEventSchemas(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	for _, naming := range []string{config.FieldNamingCamelCase, config.FieldNamingSnakeCase} {
+		t.Run(naming, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ws/v1/schemas/events", nil)
+			req.Header.Set(headerFieldNaming, naming)
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var schemas []struct {
+				Type    string         `json:"type"`
+				Version int            `json:"version"`
+				Schema  map[string]any `json:"schema"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &schemas))
+			require.NotEmpty(t, schemas)
+			var application map[string]any
+			for _, s := range schemas {
+				if s.Type == "application" {
+					application = s.Schema
+				}
+			}
+			require.NotNil(t, application)
+			assert.Equal(t, "#/$defs/model.ApplicationDAOInfo", application["$ref"])
+			defs := application["$defs"].(map[string]any)
+			properties := defs["model.ApplicationDAOInfo"].(map[string]any)["properties"].(map[string]any)
+			assert.Contains(t, properties, "sparkApplicationId")
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// defaultSLOCompliancePeriod is the period before the end of which the compliance history is returned by default.
+	defaultSLOCompliancePeriod = 7 * 24 * time.Hour
+	// defaultSLOWindow is the window of the SLOs defined without window.
+	defaultSLOWindow = 24 * time.Hour
+)
+
+// sloRequest is the request body for defining an SLO. Durations are in the format of Go durations, e.g. 5m, or
+// a number of days, e.g. 7d.
+type sloRequest struct {
+	Partition   string  `json:"partition"`
+	Queue       string  `json:"queue"`
+	Objective   float64 `json:"objective"`
+	StartWithin string  `json:"startWithin"`
+	Window      string  `json:"window"`
+}
+
+// slo validates the request and returns the SLO it defines.
+func (req *sloRequest) slo(name string) (*model.QueueSLO, error) {
+	var errorMessages []string
+	if reason := validateString(name); reason != "" {
+		errorMessages = append(errorMessages, "name "+reason)
+	}
+	if req.Objective <= 0 || req.Objective > 1 {
+		errorMessages = append(errorMessages, "objective must be in (0, 1]")
+	}
+	startWithin, err := parseDuration(req.StartWithin)
+	if err != nil || startWithin <= 0 {
+		errorMessages = append(errorMessages, "startWithin must be a positive duration")
+	}
+	window := defaultSLOWindow
+	if req.Window != "" {
+		window, err = parseDuration(req.Window)
+		if err != nil || window <= 0 {
+			errorMessages = append(errorMessages, "window must be a positive duration")
+		}
+	}
+	if req.Queue != "" && slices.Contains(strings.Split(req.Queue, "."), "") {
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid queue name %q", req.Queue))
+	}
+	if len(errorMessages) > 0 {
+		return nil, fmt.Errorf("invalid slo: %v", errorMessages)
+	}
+	return &model.QueueSLO{
+		Name:        name,
+		Partition:   req.Partition,
+		Queue:       req.Queue,
+		Objective:   req.Objective,
+		StartWithin: startWithin.Nanoseconds(),
+		Window:      window.Nanoseconds(),
+	}, nil
+}
+
+// sloCompliance is the response of the compliance history of an SLO.
+type sloCompliance struct {
+	SLO         *model.QueueSLO        `json:"slo"`
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Evaluations []*model.SLOEvaluation `json:"evaluations"`
+	// Met is the number of evaluations of the period which met the SLO.
+	Met int `json:"met"`
+	// Attainment is the share of the evaluations of the period which met the SLO, nil if there are none.
+	Attainment *float64 `json:"attainment"`
+}
+
+// getSLOs returns the SLOs of the queues, those defined in the configuration file and via the API, ordered by name.
+func (ws *WebService) getSLOs(w http.ResponseWriter, r *http.Request) {
+	slos, err := ws.repository.GetSLOs(r.Context())
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if slos == nil {
+		slos = []*model.QueueSLO{}
+	}
+	jsonResponse(w, r, slos)
+}
+
+// putSLO defines the SLO with the name of the path, replacing the SLO defined via the API with the same name.
+// SLOs defined in the configuration file cannot be replaced. If the If-Match header is set, the SLO is only replaced
+// if it is still in the version of the header.
+func (ws *WebService) putSLO(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	ifVersion, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	var req sloRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("could not decode request body: %v", err))
+		return
+	}
+	slo, err := req.slo(params.ByName(paramsSLOName))
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if err := ws.repository.PutSLO(r.Context(), slo, ifVersion); err != nil {
+		sloErrorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("slo defined", "slo", slo.Name, "partition", slo.Partition,
+		"queue", slo.Queue, "objective", slo.Objective, "version", slo.Version)
+	setETag(w, slo.Version)
+	jsonResponse(w, r, slo)
+}
+
+// deleteSLO deletes the SLO defined via the API with the name of the path, with its compliance history.
+// SLOs defined in the configuration file cannot be deleted. If the If-Match header is set, the SLO is only deleted
+// if it is still in the version of the header.
+func (ws *WebService) deleteSLO(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name := params.ByName(paramsSLOName)
+	ifVersion, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	if err := ws.repository.DeleteSLO(r.Context(), name, ifVersion); err != nil {
+		sloErrorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("slo deleted", "slo", name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getSLOCompliance returns the evaluations of an SLO during a period, oldest first, and the share of them which met
+// the SLO. Following query params are supported:
+// - from: the start of the period, 7 days before the end by default
+// - to: the end of the period, now by default
+// - tz: timezone of the time params without offset and of the period of the response, UTC by default
+func (ws *WebService) getSLOCompliance(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	name := params.ByName(paramsSLOName)
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultSLOCompliancePeriod)
+		from = &start
+		if from.Before(minTime) {
+			from = &minTime
+		}
+	}
+
+	slos, err := ws.repository.GetSLOs(r.Context())
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	i := slices.IndexFunc(slos, func(slo *model.QueueSLO) bool { return slo.Name == name })
+	if i < 0 {
+		notFoundResponse(w, r, fmt.Errorf("%w: %s", repository.ErrSLONotFound, name))
+		return
+	}
+	evaluations, err := ws.repository.GetSLOEvaluations(r.Context(), repository.SLOEvaluationFilters{
+		Name: name, Start: from, End: to,
+	})
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	compliance := sloCompliance{
+		SLO:         slos[i],
+		From:        from.In(loc),
+		To:          to.In(loc),
+		Evaluations: evaluations,
+	}
+	if compliance.Evaluations == nil {
+		compliance.Evaluations = []*model.SLOEvaluation{}
+	}
+	for _, evaluation := range evaluations {
+		if evaluation.Met {
+			compliance.Met++
+		}
+	}
+	if len(evaluations) > 0 {
+		attainment := float64(compliance.Met) / float64(len(evaluations))
+		compliance.Attainment = &attainment
+	}
+	jsonResponse(w, r, compliance)
+}
+
+func sloErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrSLONotFound):
+		notFoundResponse(w, r, err)
+	case errors.Is(err, repository.ErrSLOManagedByConfig):
+		problemResponse(w, r, http.StatusConflict, err)
+	case errors.Is(err, repository.ErrVersionMismatch):
+		problemResponse(w, r, http.StatusPreconditionFailed, err)
+	default:
+		errorResponse(w, r, err)
+	}
+}
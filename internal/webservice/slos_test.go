@@ -0,0 +1,172 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServicePutSLO(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().PutSLO(gomock.Any(), gomock.Any(), nil).DoAndReturn(
+		func(_ context.Context, slo *model.QueueSLO, _ *int64) error {
+			assert.Equal(t, &model.QueueSLO{
+				Name:        "analytics-start",
+				Queue:       "root.analytics",
+				Objective:   0.95,
+				StartWithin: (5 * time.Minute).Nanoseconds(),
+				Window:      (7 * 24 * time.Hour).Nanoseconds(),
+			}, slo)
+			slo.Source = model.SLOSourceAPI
+			slo.Version = 1
+			return nil
+		})
+	repo.EXPECT().PutSLO(gomock.Any(), gomock.Any(), nil).
+		Return(fmt.Errorf("%w: configured", repository.ErrSLOManagedByConfig))
+	repo.EXPECT().PutSLO(gomock.Any(), gomock.Any(), util.ToPtr(int64(1))).
+		Return(fmt.Errorf("%w: slo changed is in version 2", repository.ErrVersionMismatch))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/ws/v1/admin/slos/analytics-start",
+		strings.NewReader(`{"queue": "root.analytics", "objective": 0.95, "startWithin": "5m", "window": "7d"}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var slo model.QueueSLO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &slo))
+	assert.Equal(t, model.SLOSourceAPI, slo.Source)
+	assert.Equal(t, `"1"`, rec.Header().Get(headerETag))
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/ws/v1/admin/slos/configured",
+		strings.NewReader(`{"objective": 0.5, "startWithin": "1m"}`)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/ws/v1/admin/slos/changed",
+		strings.NewReader(`{"objective": 0.5, "startWithin": "1m"}`))
+	req.Header.Set(headerIfMatch, `"1"`)
+	ws.server.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	for _, body := range []string{
+		`{"objective": 0, "startWithin": "5m"}`,
+		`{"objective": 1.5, "startWithin": "5m"}`,
+		`{"objective": 0.9}`,
+		`{"objective": 0.9, "startWithin": "5m", "window": "-1h"}`,
+		`{"objective": 0.9, "startWithin": "5m", "queue": "root..a"}`,
+		`{"objective":`,
+	} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/ws/v1/admin/slos/invalid",
+			strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestWebServiceDeleteSLO(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().DeleteSLO(gomock.Any(), "defined", nil).Return(nil)
+	repo.EXPECT().DeleteSLO(gomock.Any(), "configured", nil).
+		Return(fmt.Errorf("%w: configured", repository.ErrSLOManagedByConfig))
+	repo.EXPECT().DeleteSLO(gomock.Any(), "unknown", nil).Return(fmt.Errorf("%w: unknown", repository.ErrSLONotFound))
+	repo.EXPECT().DeleteSLO(gomock.Any(), "changed", util.ToPtr(int64(1))).
+		Return(fmt.Errorf("%w: slo changed was changed", repository.ErrVersionMismatch))
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	for name, want := range map[string]int{
+		"defined":    http.StatusNoContent,
+		"configured": http.StatusConflict,
+		"unknown":    http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/ws/v1/admin/slos/"+name, nil))
+		assert.Equal(t, want, rec.Code, name)
+	}
+
+	for ifMatch, want := range map[string]int{
+		`"1"`:      http.StatusPreconditionFailed,
+		`"one"`:    http.StatusPreconditionFailed,
+		`W/"1"`:    http.StatusPreconditionFailed,
+		`"1", "2"`: http.StatusPreconditionFailed,
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/ws/v1/admin/slos/changed", nil)
+		req.Header.Set(headerIfMatch, ifMatch)
+		ws.server.Handler.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, ifMatch)
+	}
+}
+
+func TestWebServiceGetSLOCompliance(t *testing.T) {
+	slos := []*model.QueueSLO{{Name: "analytics-start", Source: model.SLOSourceConfig, Objective: 0.9}}
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetSLOs(gomock.Any()).Return(slos, nil).Times(3)
+	repo.EXPECT().GetSLOEvaluations(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.SLOEvaluationFilters) ([]*model.SLOEvaluation, error) {
+			assert.Equal(t, "analytics-start", filters.Name)
+			assert.True(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Equal(*filters.Start), filters.Start)
+			assert.True(t, time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC).Equal(*filters.End), filters.End)
+			return []*model.SLOEvaluation{
+				{SLOName: "analytics-start", EvaluatedAt: 1, Applications: 10, OnTime: 10, Compliance: util.ToPtr(1.0), Met: true},
+				{SLOName: "analytics-start", EvaluatedAt: 2, Applications: 10, OnTime: 8, Compliance: util.ToPtr(0.8)},
+				{SLOName: "analytics-start", EvaluatedAt: 3, Applications: 10, OnTime: 9, Compliance: util.ToPtr(0.9), Met: true},
+				{SLOName: "analytics-start", EvaluatedAt: 4, Met: true},
+			}, nil
+		})
+	repo.EXPECT().GetSLOEvaluations(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.SLOEvaluationFilters) ([]*model.SLOEvaluation, error) {
+			assert.Equal(t, defaultSLOCompliancePeriod, filters.End.Sub(*filters.Start))
+			return nil, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/admin/slos/analytics-start/compliance?from=2024-07-01&to=2024-07-02", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var compliance sloCompliance
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &compliance))
+	assert.Equal(t, slos[0], compliance.SLO)
+	assert.Len(t, compliance.Evaluations, 4)
+	assert.Equal(t, 3, compliance.Met)
+	assert.Equal(t, 0.75, *compliance.Attainment)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/admin/slos/analytics-start/compliance", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	compliance = sloCompliance{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &compliance))
+	assert.Empty(t, compliance.Evaluations)
+	assert.NotNil(t, compliance.Evaluations)
+	assert.Nil(t, compliance.Attainment)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/admin/slos/unknown/compliance", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/admin/slos/analytics-start/compliance?to=tomorrow", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
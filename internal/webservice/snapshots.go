@@ -0,0 +1,73 @@
+package webservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/snapshot"
+)
+
+var errSnapshotsDisabled = errors.New("snapshots are disabled")
+
+// WithSnapshots enables the routes creating and releasing snapshots, and the snapshot query parameter of the read
+// routes. The routes are not registered if the manager is nil.
+func WithSnapshots(m *snapshot.Manager) Option {
+	return func(ws *WebService) {
+		ws.snapshots = m
+	}
+}
+
+// createSnapshot exports a snapshot of the current data, which the requests passing its ID in the snapshot query
+// parameter read until it expires or is released.
+func (ws *WebService) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	created, err := ws.snapshots.Create(r.Context())
+	if err != nil {
+		if errors.Is(err, snapshot.ErrTooManySnapshots) {
+			problemResponse(w, r, http.StatusServiceUnavailable, err)
+			return
+		}
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("snapshot created", "snapshot", created.ID, "expiresAt", created.ExpiresAt)
+	createdResponse(w, r, created)
+}
+
+// releaseSnapshot releases the snapshot before it expires. Only the replica which created the snapshot can release it.
+func (ws *WebService) releaseSnapshot(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id := params.ByName(paramsSnapshotID)
+	if err := ws.snapshots.Release(r.Context(), id); err != nil {
+		if errors.Is(err, snapshot.ErrNotFound) {
+			notFoundResponse(w, r, err)
+			return
+		}
+		errorResponse(w, r, err)
+		return
+	}
+	log.FromContext(r.Context()).Infow("snapshot released", "snapshot", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readSnapshot serves the request with the handle, whose queries read the snapshot with the ID. The snapshot is
+// imported in a transaction of its own for each request, so that the snapshot may be held open by another replica.
+func (ws *WebService) readSnapshot(w http.ResponseWriter, r *http.Request, p httprouter.Params, id string,
+	handle httprouter.Handle) {
+	if ws.snapshots == nil {
+		badRequestResponse(w, r, errSnapshotsDisabled)
+		return
+	}
+	err := ws.snapshots.Read(r.Context(), id, func(ctx context.Context) {
+		handle(w, r.WithContext(ctx), p)
+	})
+	switch {
+	case errors.Is(err, snapshot.ErrNotFound):
+		problemResponse(w, r, http.StatusGone, err)
+	case err != nil:
+		errorResponse(w, r, fmt.Errorf("could not read snapshot: %w", err))
+	}
+}
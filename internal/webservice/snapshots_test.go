@@ -0,0 +1,55 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/snapshot"
+)
+
+func TestWebServiceSnapshots(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetAllPartitions(gomock.Any()).Return(nil, nil)
+	// the manager fails the requests before using the pool
+	m := snapshot.NewManager(nil, &config.SnapshotsConfig{TTL: time.Minute})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithSnapshots(m))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/snapshots", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/ws/v1/snapshots/00000003-0000001B-1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partitions?snapshot=latest", nil))
+	assert.Equal(t, http.StatusGone, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partitions", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestWebServiceSnapshotsDisabled(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repository.NewMockRepository(gomock.NewController(t)), nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ws/v1/snapshots", nil))
+	assert.NotEqual(t, http.StatusCreated, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/partitions?snapshot=00000003-0000001B-1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,114 @@
+package webservice
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// defaultSpotChurnPeriod is the period before now of which the spot churn is reported by default.
+const defaultSpotChurnPeriod = 7 * 24 * time.Hour
+
+// spotChurnReport is the response of the spot churn report.
+type spotChurnReport struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Terminations is the number of spot nodes terminated, Abrupt the number of those which were not drained before
+	// their termination, and Allocations the number of allocations the nodes were running.
+	Terminations int                           `json:"terminations"`
+	Abrupt       int                           `json:"abrupt"`
+	Allocations  int                           `json:"allocations"`
+	Applications []*model.SpotChurnApplication `json:"applications"`
+}
+
+// getSpotChurnReport returns the workloads impacted by the terminations of spot nodes during a period: the
+// applications whose allocations the nodes were running when they were last synced before their termination.
+// Following query params are supported:
+// - partition: only report the terminations of the nodes of the partition
+// - from: the start of the period, 7 days before the end by default
+// - to: the end of the period, now by default
+// - tz: timezone of the time params without offset and of the period of the response, UTC by default
+func (ws *WebService) getSpotChurnReport(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.NodeTerminationFilters{
+		Partition: q.String(queryParamPartition),
+		SpotOnly:  true,
+	}
+	now := time.Now()
+	from, to := q.TimeRange(queryParamFrom, queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultSpotChurnPeriod)
+		from = &start
+		if from.Before(minTime) {
+			from = &minTime
+		}
+	}
+	filters.Start, filters.End = from, to
+
+	terminations, err := ws.repository.GetNodeTerminations(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	report := spotChurn(terminations)
+	report.From, report.To = from.In(loc), to.In(loc)
+	jsonResponse(w, r, report)
+}
+
+// spotChurn aggregates the allocations of the terminated spot nodes per application, sorted by the number of
+// disrupted allocations in descending order.
+func spotChurn(terminations []*model.NodeTermination) *spotChurnReport {
+	type appKey struct{ partition, appID string }
+	report := &spotChurnReport{Applications: []*model.SpotChurnApplication{}}
+	byApp := make(map[appKey]*model.SpotChurnApplication)
+	for _, t := range terminations {
+		report.Terminations++
+		if !t.Drained {
+			report.Abrupt++
+		}
+		report.Allocations += len(t.Allocations)
+		disrupted := make(map[appKey]bool)
+		for _, alloc := range t.Allocations {
+			key := appKey{t.Partition, alloc.ApplicationID}
+			app, ok := byApp[key]
+			if !ok {
+				app = &model.SpotChurnApplication{
+					Partition:       t.Partition,
+					ApplicationID:   alloc.ApplicationID,
+					Resource:        map[string]int64{},
+					FirstDisruption: t.TerminatedAt,
+				}
+				byApp[key] = app
+				report.Applications = append(report.Applications, app)
+			}
+			if !disrupted[key] {
+				disrupted[key] = true
+				app.Nodes++
+			}
+			app.Allocations++
+			addResources(app.Resource, alloc.ResourcePerAlloc)
+			app.FirstDisruption = min(app.FirstDisruption, t.TerminatedAt)
+			app.LastDisruption = max(app.LastDisruption, t.TerminatedAt)
+		}
+	}
+	slices.SortFunc(report.Applications, func(a, b *model.SpotChurnApplication) int {
+		return cmp.Or(
+			cmp.Compare(b.Allocations, a.Allocations),
+			cmp.Compare(a.Partition, b.Partition),
+			cmp.Compare(a.ApplicationID, b.ApplicationID),
+		)
+	})
+	return report
+}
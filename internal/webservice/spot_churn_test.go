@@ -0,0 +1,106 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetSpotChurnReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetNodeTerminations(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.NodeTerminationFilters) ([]*model.NodeTermination, error) {
+			assert.Equal(t, util.ToPtr("default"), filters.Partition)
+			assert.True(t, filters.SpotOnly)
+			assert.True(t, time.Date(2024, 6, 30, 23, 30, 0, 0, time.UTC).Equal(*filters.Start), filters.Start)
+			assert.True(t, time.Date(2024, 7, 7, 23, 0, 0, 0, time.UTC).Equal(*filters.End), filters.End)
+			return []*model.NodeTermination{
+				{
+					NodeID:       "spot-1",
+					Partition:    "default",
+					TerminatedAt: 100,
+					Spot:         true,
+					Allocations: []*dao.AllocationDAOInfo{
+						{ApplicationID: "app-1", ResourcePerAlloc: map[string]int64{"vcore": 1000}},
+						{ApplicationID: "app-1", ResourcePerAlloc: map[string]int64{"vcore": 1000}},
+						{ApplicationID: "app-2", ResourcePerAlloc: map[string]int64{"memory": 512}},
+					},
+				},
+				{
+					NodeID:       "spot-2",
+					Partition:    "default",
+					TerminatedAt: 200,
+					Spot:         true,
+					Drained:      true,
+					Allocations: []*dao.AllocationDAOInfo{
+						{ApplicationID: "app-2", ResourcePerAlloc: map[string]int64{"memory": 512}},
+						{ApplicationID: "app-3", ResourcePerAlloc: map[string]int64{"vcore": 500}},
+					},
+				},
+				{NodeID: "spot-3", Partition: "default", TerminatedAt: 300, Spot: true},
+			}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/reports/spot-churn?partition=default&from=2024-07-01T00:30&to=2024-07-08&tz=Europe/London", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"from": "2024-07-01T00:30:00+01:00",
+		"to": "2024-07-08T00:00:00+01:00",
+		"terminations": 3,
+		"abrupt": 2,
+		"allocations": 5,
+		"applications": [
+			{
+				"partition": "default", "applicationId": "app-1", "nodes": 1, "allocations": 2,
+				"resource": {"vcore": 2000}, "firstDisruption": 100, "lastDisruption": 100
+			},
+			{
+				"partition": "default", "applicationId": "app-2", "nodes": 2, "allocations": 2,
+				"resource": {"memory": 1024}, "firstDisruption": 100, "lastDisruption": 200
+			},
+			{
+				"partition": "default", "applicationId": "app-3", "nodes": 1, "allocations": 1,
+				"resource": {"vcore": 500}, "firstDisruption": 200, "lastDisruption": 200
+			}
+		]
+	}`, rec.Body.String())
+}
+
+func TestWebServiceGetSpotChurnReportDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetNodeTerminations(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.NodeTerminationFilters) ([]*model.NodeTermination, error) {
+			assert.Nil(t, filters.Partition)
+			assert.Equal(t, filters.End.Add(-defaultSpotChurnPeriod), *filters.Start)
+			return nil, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/spot-churn", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"applications":[]`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/spot-churn?from=yesterday", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,113 @@
+package webservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+const (
+	// defaultThroughputPeriod is the period before now of which the throughput is returned by default.
+	defaultThroughputPeriod = 24 * time.Hour
+	// maxThroughputInterval is the maximum width of a throughput bucket.
+	maxThroughputInterval = 366 * 24 * time.Hour
+	// maxThroughputBuckets is the maximum number of buckets of a throughput response.
+	maxThroughputBuckets = 10000
+)
+
+// getQueueThroughput returns the number of applications which started running, completed or failed per interval.
+// Following query params are supported:
+// - partition: count the applications of the partition only
+// - queue: count the applications of the queue only, without its child queues
+// - lineage: count the applications of the former paths of the queue as well if true, requires partition and queue
+// - interval: the width of the buckets, a multiple of an hour, 1h by default
+// - startTime: the time from which to count, 24 hours before the end time by default
+// - endTime: the time until which to count, now by default
+// - tz: timezone of the time params without offset and of the bucket starts, UTC by default
+//
+// Buckets start at multiples of the interval since the Unix epoch, the first bucket is the one containing
+// the start time. Buckets without applications are returned with zero counts.
+func (ws *WebService) getQueueThroughput(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.ThroughputFilters{
+		Partition: q.String(queryParamPartition),
+		Queue:     q.String(queryParamQueue),
+		Interval:  repository.ThroughputBucketWidth,
+	}
+	if interval := q.Duration(queryParamInterval, maxThroughputInterval); interval != nil {
+		if *interval == 0 || *interval%repository.ThroughputBucketWidth != 0 {
+			q.invalidate(queryParamInterval, "must be a multiple of %s", repository.ThroughputBucketWidth)
+		}
+		filters.Interval = *interval
+	}
+	now := time.Now()
+	start, end := q.TimeRange(queryParamStartTime, queryParamEndTime, loc, now)
+	lineage := q.Bool(queryParamLineage)
+	if lineage != nil && *lineage && (filters.Partition == nil || filters.Queue == nil) {
+		q.invalidate(queryParamLineage, "requires the %s and %s params", queryParamPartition, queryParamQueue)
+	}
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if lineage != nil && *lineage {
+		var err error
+		if filters.FormerQueues, err = ws.formerQueues(r.Context(), *filters.Partition, *filters.Queue); err != nil {
+			errorResponse(w, r, err)
+			return
+		}
+	}
+	if end == nil {
+		end = &now
+	}
+	if start == nil {
+		start = util.ToPtr(end.Add(-defaultThroughputPeriod))
+		if start.Before(minTime) {
+			start = &minTime
+		}
+	}
+
+	// Time.Truncate would align the buckets to the zero time rather than the Unix epoch
+	filters.Start = time.Unix(0, start.UnixNano()-start.UnixNano()%filters.Interval.Nanoseconds())
+	buckets := end.Sub(filters.Start)/filters.Interval + 1
+	if buckets > maxThroughputBuckets {
+		q.invalidate(queryParamInterval, "must be wide enough for at most %d buckets from %s to %s",
+			maxThroughputBuckets, queryParamStartTime, queryParamEndTime)
+		badRequestResponse(w, r, q.Err())
+		return
+	}
+	filters.End = filters.Start.Add(time.Duration(buckets) * filters.Interval)
+
+	counts, err := ws.repository.GetQueueThroughput(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, fillThroughputBuckets(counts, filters, loc))
+}
+
+// fillThroughputBuckets returns a bucket for every interval of the filters, with the counts of the
+// intervals which had any.
+func fillThroughputBuckets(counts []*model.ThroughputBucket, filters repository.ThroughputFilters, loc *time.Location) []*model.ThroughputBucket {
+	byStart := make(map[int64]*model.ThroughputBucket, len(counts))
+	for _, count := range counts {
+		byStart[count.Start.UnixNano()] = count
+	}
+	var buckets []*model.ThroughputBucket
+	for start := filters.Start; start.Before(filters.End); start = start.Add(filters.Interval) {
+		bucket, ok := byStart[start.UnixNano()]
+		if !ok {
+			bucket = &model.ThroughputBucket{}
+		}
+		bucket.Start = start.In(loc)
+		buckets = append(buckets, bucket)
+	}
+	if buckets == nil {
+		return []*model.ThroughputBucket{}
+	}
+	return buckets
+}
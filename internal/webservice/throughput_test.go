@@ -0,0 +1,98 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetQueueThroughput(t *testing.T) {
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetQueueThroughput(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.ThroughputFilters) ([]*model.ThroughputBucket, error) {
+			assert.Equal(t, util.ToPtr("default"), filters.Partition)
+			assert.Equal(t, util.ToPtr("root.a"), filters.Queue)
+			assert.Equal(t, 2*time.Hour, filters.Interval)
+			// the buckets start at multiples of the interval and cover the end time
+			assert.True(t, day.Equal(filters.Start), filters.Start)
+			assert.True(t, day.Add(6*time.Hour).Equal(filters.End), filters.End)
+			return []*model.ThroughputBucket{
+				{Start: day.Add(2 * time.Hour), Started: 3, Completed: 1},
+			}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/analytics/throughput?partition=default&queue=root.a&interval=2h&startTime=2024-07-01T02:30&endTime=2024-07-01T05:00&tz=Europe/London",
+		nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[
+		{"start": "2024-07-01T01:00:00+01:00", "started": 0, "completed": 0, "failed": 0},
+		{"start": "2024-07-01T03:00:00+01:00", "started": 3, "completed": 1, "failed": 0},
+		{"start": "2024-07-01T05:00:00+01:00", "started": 0, "completed": 0, "failed": 0}
+	]`, rec.Body.String())
+}
+
+func TestWebServiceGetQueueThroughputDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetQueueThroughput(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.ThroughputFilters) ([]*model.ThroughputBucket, error) {
+			assert.Nil(t, filters.Partition)
+			assert.Nil(t, filters.Queue)
+			assert.Equal(t, time.Hour, filters.Interval)
+			assert.Equal(t, 25*time.Hour, filters.End.Sub(filters.Start))
+			return nil, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/analytics/throughput", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var buckets []*model.ThroughputBucket
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &buckets))
+	assert.Len(t, buckets, 25)
+}
+
+func TestWebServiceGetQueueThroughputInvalid(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	tests := map[string]string{
+		"not a duration":      "interval=hourly",
+		"not whole hours":     "interval=90m",
+		"zero interval":       "interval=0s",
+		"interval too large":  "interval=9000h",
+		"end before start":    "startTime=2024-07-02&endTime=2024-07-01",
+		"too many buckets":    "startTime=2000-01-01&endTime=2024-07-01",
+		"unknown timezone":    "tz=Mars/Olympus",
+		"invalid start time":  "startTime=soon",
+		"control character":   "queue=root%00a",
+		"too many with a day": "interval=24h&startTime=1970-01-01&endTime=2024-07-01",
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/analytics/throughput?"+query, nil))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package webservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// getApplicationTrace returns the events of the event stream of Yunikorn which referred to an application,
+// in the order they happened, if the application was sampled for tracing. The partition of the events is not
+// known, so the trace is identified by the ID of the application only.
+// Following query params are supported:
+// - startTime: only include the events at or after this time
+// - endTime: only include the events at or before this time
+// - tz: timezone of the time filters without offset, UTC by default
+// - limit: limit the number of returned events
+// - offset: offset the returned events
+func (ws *WebService) getApplicationTrace(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	appID := params.ByName(paramsApplicationID)
+
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.TraceEventFilters{
+		Limit:  q.Limit(ws.pageSizes.Other),
+		Offset: q.Offset(),
+	}
+	filters.Start, filters.End = q.TimeRange(queryParamStartTime, queryParamEndTime, loc, time.Now())
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	events, err := ws.repository.GetTraceEvents(r.Context(), appID, filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	if events == nil {
+		events = []*model.TraceEvent{}
+	}
+	jsonResponse(w, r, events)
+}
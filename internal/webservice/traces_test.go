@@ -0,0 +1,68 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetApplicationTrace(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().
+		GetTraceEvents(gomock.Any(), "app-1", repository.TraceEventFilters{
+			Start:  &start,
+			Limit:  util.ToPtr(100),
+			Offset: util.ToPtr(10),
+		}).
+		Return([]*model.TraceEvent{{
+			ApplicationID: "app-1",
+			Type:          "REQUEST",
+			ObjectID:      "pod-1",
+			ChangeType:    "NONE",
+			ChangeDetail:  "DETAILS_NONE",
+			Message:       util.ToPtr("predicate failed"),
+			Timestamp:     start.UnixNano(),
+		}}, nil)
+	repo.EXPECT().
+		GetTraceEvents(gomock.Any(), "unsampled", gomock.Any()).
+		Return(nil, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, WithTraces())
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/application/app-1/trace?startTime=2024-07-01&limit=100&offset=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"message":"predicate failed"`)
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/application/unsampled/trace", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/application/app-1/trace?limit=-1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebServiceGetApplicationTraceDisabled(t *testing.T) {
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, nil, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/application/app-1/trace", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,107 @@
+package webservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+const (
+	// defaultUsageCalendarWeeks and maxUsageCalendarWeeks are the default and maximum number of weeks the usage
+	// calendar averages over.
+	defaultUsageCalendarWeeks = 8
+	maxUsageCalendarWeeks     = 104
+)
+
+// usageCalendar is the response of the usage calendar report.
+type usageCalendar struct {
+	From  time.Time                  `json:"from"`
+	To    time.Time                  `json:"to"`
+	Weeks int                        `json:"weeks"`
+	Cells []*model.UsageCalendarCell `json:"cells"`
+}
+
+// getUsageCalendarReport returns the average usage per hour of the day and day of the week of the last weeks, from
+// the usage rollups, to find the idle periods of the cluster or a queue, e.g. for maintenance windows.
+// Following query params are supported:
+// - partition: only average the usage in the partition
+// - queue: only average the usage of the queue, without its child queues
+// - weeks: the number of weeks before the end, 8 by default
+// - to: the end of the period, rounded down to the hour, now by default
+// - tz: timezone of the days and hours of the cells, of the time params without offset and of the period of the
+// response, UTC by default
+//
+// The cells are ordered by weekday from Monday and by hour, and every cell averages the hours of the period which
+// start at its hour of its weekday in the timezone.
+func (ws *WebService) getUsageCalendarReport(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.HourlyUsageFilters{
+		Partition: q.String(queryParamPartition),
+		Queue:     q.String(queryParamQueue),
+	}
+	weeks := defaultUsageCalendarWeeks
+	if n := q.Int(queryParamWeeks, 1, maxUsageCalendarWeeks); n != nil {
+		weeks = *n
+	}
+	now := time.Now()
+	to := q.Time(queryParamTo, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if to == nil {
+		to = &now
+	}
+	filters.End = time.Unix(0, to.UnixNano()-to.UnixNano()%repository.UsageRollupWidth.Nanoseconds())
+	filters.Start = filters.End.Add(-time.Duration(weeks) * 7 * 24 * time.Hour)
+	if filters.Start.Before(minTime) {
+		filters.Start = minTime
+	}
+
+	hours, err := ws.repository.GetHourlyUsage(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, usageCalendar{
+		From:  filters.Start.In(loc),
+		To:    filters.End.In(loc),
+		Weeks: weeks,
+		Cells: usageCalendarCells(hours, filters.Start, filters.End, loc),
+	})
+}
+
+// usageCalendarCells averages the hourly usage between start and end per hour of the day and day of the week in the
+// timezone. The hours of the period without usage count as idle.
+func usageCalendarCells(hours []*model.HourlyUsage, start, end time.Time, loc *time.Location) []*model.UsageCalendarCell {
+	byStart := make(map[int64]*model.HourlyUsage, len(hours))
+	for _, hour := range hours {
+		byStart[hour.Start.UnixNano()] = hour
+	}
+	cells := make([]*model.UsageCalendarCell, 7*24)
+	for i := range cells {
+		cells[i] = &model.UsageCalendarCell{Weekday: i/24 + 1, Hour: i % 24}
+	}
+	var vcoreSeconds, memorySeconds [7 * 24]float64
+	for t := start; t.Before(end); t = t.Add(repository.UsageRollupWidth) {
+		local := t.In(loc)
+		// time.Weekday counts from Sunday, ISO weekdays from Monday
+		i := (int(local.Weekday())+6)%7*24 + local.Hour()
+		cells[i].Hours++
+		if hour, ok := byStart[t.UnixNano()]; ok {
+			vcoreSeconds[i] += hour.VcoreSeconds
+			memorySeconds[i] += hour.MemorySeconds
+		}
+	}
+	for i, cell := range cells {
+		if cell.Hours > 0 {
+			seconds := float64(cell.Hours) * repository.UsageRollupWidth.Seconds()
+			cell.Vcores = vcoreSeconds[i] / seconds
+			cell.Memory = memorySeconds[i] / seconds
+		}
+	}
+	return cells
+}
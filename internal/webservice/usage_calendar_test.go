@@ -0,0 +1,88 @@
+package webservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetUsageCalendarReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetHourlyUsage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.HourlyUsageFilters) ([]*model.HourlyUsage, error) {
+			assert.Equal(t, util.ToPtr("default"), filters.Partition)
+			assert.Equal(t, util.ToPtr("root.a"), filters.Queue)
+			// the end is rounded down to the hour of the rollups
+			assert.True(t, time.Date(2024, 6, 30, 23, 0, 0, 0, time.UTC).Equal(filters.Start), filters.Start)
+			assert.True(t, time.Date(2024, 7, 7, 23, 0, 0, 0, time.UTC).Equal(filters.End), filters.End)
+			return []*model.HourlyUsage{
+				{Start: time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC), VcoreSeconds: 7200},
+				{Start: time.Date(2024, 7, 6, 23, 0, 0, 0, time.UTC), MemorySeconds: 3600 * 1024},
+			}, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/reports/usage-calendar?partition=default&queue=root.a&weeks=1&to=2024-07-08T00:30&tz=Europe/London", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var calendar usageCalendar
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &calendar))
+	assert.Equal(t, "2024-07-01T00:00:00+01:00", calendar.From.Format(time.RFC3339))
+	assert.Equal(t, "2024-07-08T00:00:00+01:00", calendar.To.Format(time.RFC3339))
+	assert.Equal(t, 1, calendar.Weeks)
+	require.Len(t, calendar.Cells, 7*24)
+	for _, cell := range calendar.Cells {
+		assert.Equal(t, 1, cell.Hours)
+	}
+	// Monday 10:00 in London
+	assert.Equal(t, &model.UsageCalendarCell{Weekday: 1, Hour: 10, Hours: 1, Vcores: 2}, calendar.Cells[10])
+	// Sunday 00:00 in London
+	assert.Equal(t, &model.UsageCalendarCell{Weekday: 7, Hour: 0, Hours: 1, Memory: 1024}, calendar.Cells[6*24])
+	assert.Equal(t, &model.UsageCalendarCell{Weekday: 7, Hour: 23, Hours: 1}, calendar.Cells[7*24-1])
+}
+
+func TestWebServiceGetUsageCalendarReportDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetHourlyUsage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filters repository.HourlyUsageFilters) ([]*model.HourlyUsage, error) {
+			assert.Nil(t, filters.Partition)
+			assert.Nil(t, filters.Queue)
+			assert.Equal(t, 8*7*24*time.Hour, filters.End.Sub(filters.Start))
+			assert.Zero(t, filters.End.UnixNano()%time.Hour.Nanoseconds())
+			return nil, nil
+		})
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil)
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/usage-calendar", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var calendar usageCalendar
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &calendar))
+	assert.Equal(t, defaultUsageCalendarWeeks, calendar.Weeks)
+	require.Len(t, calendar.Cells, 7*24)
+	assert.Equal(t, 8, calendar.Cells[0].Hours)
+	assert.Zero(t, calendar.Cells[0].Vcores)
+
+	for _, query := range []string{"weeks=0", "weeks=105", "weeks=two", "to=tomorrow"} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/v1/reports/usage-calendar?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
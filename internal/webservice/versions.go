@@ -0,0 +1,41 @@
+package webservice
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+const (
+	// headerETag is the entity tag of a versioned resource, the quoted version of the resource.
+	headerETag = "ETag"
+	// headerIfMatch makes a change of a versioned resource conditional on the version of the resource.
+	headerIfMatch = "If-Match"
+)
+
+// setETag sets the entity tag of the response to the version of the resource.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set(headerETag, strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion returns the version of the If-Match header of the request, or nil if the header is absent or "*",
+// in which case the change is unconditional. Responds with 412 Precondition Failed if the header is not an entity tag
+// of a version, as it cannot match the current version of the resource.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (*int64, bool) {
+	header := strings.TrimSpace(r.Header.Get(headerIfMatch))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+	unquoted, err := strconv.Unquote(header)
+	if err == nil && strings.HasPrefix(header, `"`) {
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil {
+			return &version, true
+		}
+	}
+	problemResponse(w, r, http.StatusPreconditionFailed,
+		fmt.Errorf("%w: invalid %s header %s", repository.ErrVersionMismatch, headerIfMatch, header))
+	return nil, false
+}
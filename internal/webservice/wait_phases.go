@@ -0,0 +1,57 @@
+package webservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+// defaultWaitPhasePeriod is the period before now of which the applications are summarized by default.
+const defaultWaitPhasePeriod = 7 * 24 * time.Hour
+
+// getQueueWaitPhases returns the percentiles of the phases of the wait of the applications of each queue until their
+// first allocation, and the number of applications which last waited for each reason, to tell whether the
+// applications of a queue wait for its quota, for nodes they fit on or for capacity.
+// Following query params are supported:
+// - partition: summarize the applications of the partition only
+// - queue: summarize the applications of the queue only, without its child queues
+// - startTime: summarize the applications submitted from this time, 7 days before the end time by default
+// - endTime: summarize the applications submitted until this time, now by default
+// - duplicates: count the applications in several clusters once, by their canonical run selected by the strategy
+// - tz: timezone of the time params without offset, UTC by default
+//
+// Results are ordered by partition and queue.
+func (ws *WebService) getQueueWaitPhases(w http.ResponseWriter, r *http.Request) {
+	q := newQueryParams(r)
+	loc := q.Timezone()
+	filters := repository.WaitPhaseFilters{
+		Partition:  q.String(queryParamPartition),
+		Queue:      q.String(queryParamQueue),
+		Duplicates: q.DuplicateStrategy(queryParamDuplicates),
+	}
+	now := time.Now()
+	start, end := q.TimeRange(queryParamStartTime, queryParamEndTime, loc, now)
+	if err := q.Err(); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+	if end == nil {
+		end = &now
+	}
+	if start == nil {
+		start = util.ToPtr(end.Add(-defaultWaitPhasePeriod))
+		if start.Before(minTime) {
+			start = &minTime
+		}
+	}
+	filters.Start, filters.End = *start, *end
+
+	results, err := ws.repository.GetQueueWaitPhases(r.Context(), filters)
+	if err != nil {
+		errorResponse(w, r, err)
+		return
+	}
+	jsonResponse(w, r, results)
+}
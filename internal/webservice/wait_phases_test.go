@@ -0,0 +1,68 @@
+package webservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestWebServiceGetQueueWaitPhases(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(mockCtrl)
+	repo.EXPECT().GetQueueWaitPhases(gomock.Any(), repository.WaitPhaseFilters{
+		Partition:  util.ToPtr("default"),
+		Queue:      util.ToPtr("root.batch"),
+		Start:      time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		End:        time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC),
+		Duplicates: repository.DuplicateFirst,
+	}).Return([]*model.QueueWaitPhases{{
+		Partition:    "default",
+		QueueName:    "root.batch",
+		Applications: 4,
+		Phases: []*model.WaitPhaseStats{
+			{Phase: model.WaitPhaseAccept, Applications: 4, MedianWait: util.ToPtr(1e9), P95Wait: util.ToPtr(2e9),
+				P99Wait: util.ToPtr(2e9), MaxWait: util.ToPtr(2e9)},
+			{Phase: model.WaitPhasePlaceholder},
+			{Phase: model.WaitPhaseAllocation, Applications: 3, MedianWait: util.ToPtr(6e10), P95Wait: util.ToPtr(3e11),
+				P99Wait: util.ToPtr(3e11), MaxWait: util.ToPtr(3e11)},
+		},
+		Reasons: map[string]int64{model.WaitReasonQuota: 3, model.WaitReasonPlacement: 0, model.WaitReasonCapacity: 1},
+	}}, nil)
+	ws := NewWebService(&config.YHSConfig{Port: 8080}, repo, nil, nil, withAnalytics(t))
+	ws.init(context.Background())
+
+	rec := httptest.NewRecorder()
+	ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/ws/v1/analytics/wait-phases?partition=default&queue=root.batch&startTime=2024-07-01&endTime=2024-07-08"+
+			"&duplicates=first", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[{
+		"partition": "default", "queueName": "root.batch", "applications": 4,
+		"phases": [
+			{"phase": "accept", "applications": 4, "medianWait": 1e9, "p95Wait": 2e9, "p99Wait": 2e9, "maxWait": 2e9},
+			{"phase": "placeholder", "applications": 0, "medianWait": null, "p95Wait": null, "p99Wait": null, "maxWait": null},
+			{"phase": "allocation", "applications": 3, "medianWait": 6e10, "p95Wait": 3e11, "p99Wait": 3e11, "maxWait": 3e11}
+		],
+		"reasons": {"quota": 3, "placement": 0, "capacity": 1}
+	}]`, rec.Body.String())
+
+	for _, target := range []string{
+		"/ws/v1/analytics/wait-phases?startTime=2024-07-08&endTime=2024-07-01",
+		"/ws/v1/analytics/wait-phases?duplicates=any",
+	} {
+		rec = httptest.NewRecorder()
+		ws.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
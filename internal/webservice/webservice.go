@@ -2,25 +2,175 @@ package webservice
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/rs/cors"
 
+	"github.com/G-Research/yunikorn-history-server/internal/alerting"
+	"github.com/G-Research/yunikorn-history-server/internal/autoscaler"
+	"github.com/G-Research/yunikorn-history-server/internal/broadcast"
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/canary"
+	"github.com/G-Research/yunikorn-history-server/internal/changes"
 	"github.com/G-Research/yunikorn-history-server/internal/config"
 	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/erasure"
+	"github.com/G-Research/yunikorn-history-server/internal/export"
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
 	"github.com/G-Research/yunikorn-history-server/internal/health"
+	"github.com/G-Research/yunikorn-history-server/internal/links"
 	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/mirror"
+	"github.com/G-Research/yunikorn-history-server/internal/pause"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+	"github.com/G-Research/yunikorn-history-server/internal/quota"
+	"github.com/G-Research/yunikorn-history-server/internal/retention"
+	"github.com/G-Research/yunikorn-history-server/internal/scheduler"
+	"github.com/G-Research/yunikorn-history-server/internal/snapshot"
+	"github.com/G-Research/yunikorn-history-server/internal/spark"
 )
 
 type WebService struct {
 	server          *http.Server
+	addresses       []string
+	adminServer     *http.Server
+	adminAddresses  []string
 	repository      repository.Repository
 	eventRepository repository.EventRepository
 	healthService   health.Interface
+	featureFlags    *featureflag.Service
+	policies        *policy.Store
+	alerting        *alerting.Service
+	retention       *retention.Pruner
+	eraser          *erasure.Eraser
+	faults          *faultinject.Injector
+	canary          *canary.Canary
+	links           *links.Renderer
+	sparkHistory    *spark.HistoryServer
+	live            LiveApplications
+	cache           *cache.Cache
+	stale           *cache.Stale
+	database        health.Component
+	changes         *changes.Feed
+	eventStream     *broadcast.Hub
+	scheduler       *scheduler.Scheduler
+	pauses          *pause.Controller
+	exports         *export.Store
+	snapshots       *snapshot.Manager
+	quotas          *quota.Tracker
+	results         *cache.Cache
+	resultTTLs      config.ResultsCacheConfig
+	nodeGroups      []config.NodeGroup
+	eventCategories []config.EventCategory
+	extensionRoutes []Route
+	mirror          *mirror.Mirror
+	autoscaler      *autoscaler.Converter
+	apiKeys         map[string]string
+	adminAPIKeys    map[string]string
 	assetsDir       string
 	corsConfig      cors.Options
+	readOnly        bool
+	ingest          bool
+	traces          bool
+	fieldNaming     string
+	apiSchema       string
+	fieldRenames    map[string][]fieldRename
+	limits          config.RequestLimitsConfig
+	pageSizes       config.PageSizesConfig
+	routes          []route
+}
+
+var errReadOnly = errors.New("the server is in read-only mode")
+
+type Option func(*WebService)
+
+// WithFeatureFlags sets the feature flags used to gate experimental endpoints.
+// If not set, all feature flags are in their default state and cannot be overridden.
+func WithFeatureFlags(featureFlags *featureflag.Service) Option {
+	return func(ws *WebService) {
+		ws.featureFlags = featureFlags
+	}
+}
+
+// WithAPIKeys enables API key authentication for the API routes.
+// The keys map client names to their API keys.
+func WithAPIKeys(apiKeys map[string]string) Option {
+	return func(ws *WebService) {
+		ws.apiKeys = apiKeys
+	}
+}
+
+// WithPolicies sets the store of the retention policies and alert rules applied to the server,
+// and the service evaluating the alert rules.
+func WithPolicies(policies *policy.Store, alerts *alerting.Service) Option {
+	return func(ws *WebService) {
+		ws.policies = policies
+		ws.alerting = alerts
+	}
+}
+
+// WithRetention sets the pruner used to resolve the effective retention policies of queues.
+func WithRetention(pruner *retention.Pruner) Option {
+	return func(ws *WebService) {
+		ws.retention = pruner
+	}
+}
+
+// WithLinks sets the renderer of the links to external systems returned with applications.
+// If not set, no links are returned.
+func WithLinks(renderer *links.Renderer) Option {
+	return func(ws *WebService) {
+		ws.links = renderer
+	}
+}
+
+// WithSparkHistoryServer sets the Spark History Server that applications run by Spark are linked to.
+// If not set, applications are returned without a link to the Spark History Server.
+func WithSparkHistoryServer(historyServer *spark.HistoryServer) Option {
+	return func(ws *WebService) {
+		ws.sparkHistory = historyServer
+	}
+}
+
+// WithCache sets the cache the responses of the read API routes are served from.
+// If not set, responses are not cached.
+func WithCache(c *cache.Cache) Option {
+	return func(ws *WebService) {
+		ws.cache = c
+	}
+}
+
+// WithChanges sets the feed clients poll for new data.
+// If not set, the feed is not notified of any changes and polls time out.
+func WithChanges(feed *changes.Feed) Option {
+	return func(ws *WebService) {
+		ws.changes = feed
+	}
+}
+
+// WithEraser sets the eraser of the records attributable to a user.
+func WithEraser(eraser *erasure.Eraser) Option {
+	return func(ws *WebService) {
+		ws.eraser = eraser
+	}
+}
+
+// WithIngest enables the route applying the batches forwarded by collectors running next to Yunikorn.
+func WithIngest() Option {
+	return func(ws *WebService) {
+		ws.ingest = true
+	}
+}
+
+// WithTraces enables the route returning the decision traces of applications, which are stored if tracing is enabled.
+func WithTraces() Option {
+	return func(ws *WebService) {
+		ws.traces = true
+	}
 }
 
 func NewWebService(
@@ -28,21 +178,67 @@ func NewWebService(
 	repository repository.Repository,
 	eventRepository repository.EventRepository,
 	healthService health.Interface,
+	opts ...Option,
 ) *WebService {
-	return &WebService{
+	ws := &WebService{
 		server: &http.Server{
-			Addr:        fmt.Sprintf(":%d", cfg.Port),
-			ReadTimeout: 30 * time.Second,
+			ReadTimeout:    30 * time.Second,
+			MaxHeaderBytes: requestLimitsOrDefault(cfg.Limits).MaxHeaderSize,
 		},
+		addresses:       cfg.ListenAddresses(),
 		repository:      repository,
 		eventRepository: eventRepository,
 		healthService:   healthService,
 		assetsDir:       cfg.AssetsDir,
 		corsConfig:      cfg.CORSConfig,
+		readOnly:        cfg.ReadOnly,
+		fieldNaming:     cfg.FieldNaming,
+		apiSchema:       cfg.APISchema,
+		fieldRenames:    responseFieldRenames,
+		limits:          requestLimitsOrDefault(cfg.Limits),
+		pageSizes: config.PageSizesConfig{
+			Applications: pageSizeOrDefault(cfg.PageSizes.Applications),
+			LegalHolds:   pageSizeOrDefault(cfg.PageSizes.LegalHolds),
+			Nodes:        pageSizeOrDefault(cfg.PageSizes.Nodes),
+			History:      pageSizeOrDefault(cfg.PageSizes.History),
+			Other:        pageSizeOrDefault(cfg.PageSizes.Other),
+		},
+	}
+	for _, opt := range opts {
+		opt(ws)
+	}
+	if ws.featureFlags == nil {
+		// an empty configuration cannot reference unknown flags
+		ws.featureFlags, _ = featureflag.New(&config.FeatureFlagsConfig{})
+	}
+	if ws.policies == nil {
+		// alert rules of an empty store are never evaluated
+		ws.policies = policy.NewStore()
+		ws.alerting = alerting.NewService(repository, ws.policies)
 	}
+	if ws.retention == nil {
+		ws.retention = retention.NewPruner(repository, ws.policies)
+	}
+	if ws.eraser == nil {
+		ws.eraser = erasure.NewEraser(repository)
+	}
+	if ws.changes == nil {
+		ws.changes = changes.NewFeed()
+	}
+	if len(ws.eventCategories) == 0 {
+		ws.eventCategories = config.DefaultEventCategories
+	}
+	if ws.scheduler == nil {
+		ws.scheduler = scheduler.New()
+	}
+	if ws.pauses == nil {
+		ws.pauses = pause.NewController(repository)
+	}
+	return ws
 }
 
-// Start performs a blocking call to start the REST API server.
+// Start performs a blocking call to start the REST API server, which serves the requests of all listen addresses,
+// and of the admin listener if set. It returns once the server stops serving any of them.
 func (ws *WebService) Start(ctx context.Context) error {
 	logger := log.FromContext(ctx)
 	logger = logger.With("component", "webservice")
@@ -50,13 +246,52 @@ func (ws *WebService) Start(ctx context.Context) error {
 
 	ws.init(ctx)
 
-	logger.Infof("starting webservice on %s", ws.server.Addr)
-	return ws.server.ListenAndServe()
+	listeners, err := listen(ctx, ws.addresses)
+	if err != nil {
+		return err
+	}
+	var adminListeners []net.Listener
+	if ws.adminServer != nil {
+		if adminListeners, err = listen(ctx, ws.adminAddresses); err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return err
+		}
+	}
+	errs := make(chan error, len(listeners)+len(adminListeners))
+	for _, l := range listeners {
+		logger.Infof("starting webservice on %s %s", l.Addr().Network(), l.Addr())
+		go func() {
+			errs <- ws.server.Serve(l)
+		}()
+	}
+	for _, l := range adminListeners {
+		logger.Infof("starting admin listener on %s %s", l.Addr().Network(), l.Addr())
+		go func() {
+			errs <- ws.adminServer.Serve(l)
+		}()
+	}
+	// the shutdown closes all listeners, which the servers serve until then
+	return <-errs
+}
+
+// Handler returns the handler of the REST API, to be served by an HTTP server of the caller instead of Start.
+// The admin listener is not served, so the handler serves the admin surface too.
+func (ws *WebService) Handler(ctx context.Context) http.Handler {
+	logger := log.FromContext(ctx).With("component", "webservice")
+	ws.adminServer = nil
+	ws.init(log.ToContext(ctx, logger))
+	return ws.server.Handler
 }
 
 func (ws *WebService) Shutdown(ctx context.Context) error {
 	logger := log.FromContext(ctx)
 
 	logger.Warnw("shutting down webservice", "component", "webservice")
-	return ws.server.Shutdown(ctx)
+	err := ws.server.Shutdown(ctx)
+	if ws.adminServer != nil {
+		err = errors.Join(err, ws.adminServer.Shutdown(ctx))
+	}
+	return err
 }
@@ -0,0 +1,16 @@
+// Package workflow correlates applications with the workflow runs, such as Airflow DAG runs or Argo workflows,
+// that caused them.
+package workflow
+
+import (
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+
+	"github.com/G-Research/yunikorn-history-server/internal/tags"
+)
+
+// ID returns the workflow ID of the application, taken from the first of the ID tags found in the allocation tags
+// of the application. An ID tag combining several tags with + is found if all of them are present, and their values
+// are joined with :. It returns an empty string if the application was not caused by a workflow.
+func ID(app *dao.ApplicationDAOInfo, idTags []string) string {
+	return tags.Key(app, idTags)
+}
@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+)
+
+func TestID(t *testing.T) {
+	tests := map[string]struct {
+		tags map[string]string
+		want string
+	}{
+		"no workflow": {
+			tags: map[string]string{"kubernetes.io/label/app": "web"},
+			want: "",
+		},
+		"argo": {
+			tags: map[string]string{"kubernetes.io/label/workflows.argoproj.io/workflow": "etl-x7k2p"},
+			want: "etl-x7k2p",
+		},
+		"airflow": {
+			tags: map[string]string{
+				"kubernetes.io/label/dag_id": "daily_etl",
+				"kubernetes.io/label/run_id": "scheduled__2024-07-01T0000000000-2f5b1d3e1",
+			},
+			want: "daily_etl:scheduled__2024-07-01T0000000000-2f5b1d3e1",
+		},
+		"airflow without run": {
+			tags: map[string]string{"kubernetes.io/label/dag_id": "daily_etl"},
+			want: "",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			app := &dao.ApplicationDAOInfo{
+				Requests: []*dao.AllocationAskDAOInfo{{AllocationTags: tt.tags}},
+			}
+			assert.Equal(t, tt.want, ID(app, config.DefaultWorkflowIDTags))
+		})
+	}
+}
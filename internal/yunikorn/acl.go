@@ -0,0 +1,92 @@
+package yunikorn
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/apache/yunikorn-core/pkg/common/configs"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// wildcard is the ACL, and the user or group of the effective lists, which grants access to everyone.
+const wildcard = "*"
+
+// acl is the parsed form of an access control list.
+type acl struct {
+	users  []string
+	groups []string
+}
+
+// parseACL parses an ACL in the YuniKorn format of comma-separated users and comma-separated groups separated by
+// a single space, e.g. "alice,bob analysts" or " analysts" for groups only, or * for everyone. Like the scheduler,
+// it ignores an ACL with more than one space, which grants no access.
+func parseACL(value string) acl {
+	if strings.TrimSpace(value) == wildcard {
+		return acl{users: []string{wildcard}}
+	}
+	fields := strings.Split(value, " ")
+	if value == "" || len(fields) > 2 {
+		return acl{}
+	}
+	a := acl{users: splitList(fields[0])}
+	if len(fields) == 2 {
+		a.groups = splitList(fields[1])
+	}
+	return a
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// union returns the sorted users and groups of both ACLs, reduced to the wildcard if either grants access to everyone.
+func (a acl) union(b acl) acl {
+	merged := acl{users: append(slices.Clone(a.users), b.users...), groups: append(slices.Clone(a.groups), b.groups...)}
+	if slices.Contains(merged.users, wildcard) {
+		return acl{users: []string{wildcard}}
+	}
+	slices.Sort(merged.users)
+	slices.Sort(merged.groups)
+	return acl{users: slices.Compact(merged.users), groups: slices.Compact(merged.groups)}
+}
+
+// queueACLs returns the ACL snapshots of all queues of the scheduler configuration. Like the scheduler, the effective
+// lists of a queue include the lists of its parent queues, and admins can also submit applications.
+func queueACLs(schedulerConfig *configs.SchedulerConfig) []*model.QueueACL {
+	var acls []*model.QueueACL
+	var walk func(partition, parentName string, queue configs.QueueConfig, parentSubmit, parentAdmin acl)
+	walk = func(partition, parentName string, queue configs.QueueConfig, parentSubmit, parentAdmin acl) {
+		name := queue.Name
+		if parentName != "" {
+			name = parentName + "." + queue.Name
+		}
+		admin := parentAdmin.union(parseACL(queue.AdminACL))
+		submit := parentSubmit.union(parseACL(queue.SubmitACL)).union(admin)
+		acls = append(acls, &model.QueueACL{
+			Partition:    strings.ToLower(partition),
+			QueueName:    strings.ToLower(name),
+			SubmitACL:    queue.SubmitACL,
+			AdminACL:     queue.AdminACL,
+			SubmitUsers:  submit.users,
+			SubmitGroups: submit.groups,
+			AdminUsers:   admin.users,
+			AdminGroups:  admin.groups,
+		})
+		for _, child := range queue.Queues {
+			walk(partition, name, child, submit, admin)
+		}
+	}
+	for _, partition := range schedulerConfig.Partitions {
+		for _, queue := range partition.Queues {
+			walk(partition.Name, "", queue, acl{}, acl{})
+		}
+	}
+	return acls
+}
@@ -0,0 +1,81 @@
+package yunikorn
+
+import (
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/common/configs"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestParseACL(t *testing.T) {
+	tests := map[string]acl{
+		"":                   {},
+		"*":                  {users: []string{"*"}},
+		" * ":                {users: []string{"*"}},
+		"alice,bob":          {users: []string{"alice", "bob"}},
+		"alice analysts,ops": {users: []string{"alice"}, groups: []string{"analysts", "ops"}},
+		" analysts":          {groups: []string{"analysts"}},
+		"alice analysts ops": {},
+	}
+	for value, want := range tests {
+		t.Run(value, func(t *testing.T) {
+			assert.Equal(t, want, parseACL(value))
+		})
+	}
+}
+
+func TestQueueACLs(t *testing.T) {
+	schedulerConfig := &configs.SchedulerConfig{
+		Partitions: []configs.PartitionConfig{{
+			Name: "default",
+			Queues: []configs.QueueConfig{{
+				Name:     "root",
+				AdminACL: "admin",
+				Queues: []configs.QueueConfig{
+					{
+						Name:      "Analytics",
+						SubmitACL: "bob,alice analysts",
+						Queues:    []configs.QueueConfig{{Name: "adhoc", AdminACL: " analysts-leads"}},
+					},
+					{Name: "public", SubmitACL: "*"},
+				},
+			}},
+		}},
+	}
+
+	assert.Equal(t, []*model.QueueACL{
+		{
+			Partition:   "default",
+			QueueName:   "root",
+			AdminACL:    "admin",
+			SubmitUsers: []string{"admin"},
+			AdminUsers:  []string{"admin"},
+		},
+		{
+			Partition:    "default",
+			QueueName:    "root.analytics",
+			SubmitACL:    "bob,alice analysts",
+			SubmitUsers:  []string{"admin", "alice", "bob"},
+			SubmitGroups: []string{"analysts"},
+			AdminUsers:   []string{"admin"},
+		},
+		{
+			Partition:    "default",
+			QueueName:    "root.analytics.adhoc",
+			AdminACL:     " analysts-leads",
+			SubmitUsers:  []string{"admin", "alice", "bob"},
+			SubmitGroups: []string{"analysts", "analysts-leads"},
+			AdminUsers:   []string{"admin"},
+			AdminGroups:  []string{"analysts-leads"},
+		},
+		{
+			Partition:   "default",
+			QueueName:   "root.public",
+			SubmitACL:   "*",
+			SubmitUsers: []string{"*"},
+			AdminUsers:  []string{"admin"},
+		},
+	}, queueACLs(schedulerConfig))
+}
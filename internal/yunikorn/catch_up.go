@@ -0,0 +1,115 @@
+package yunikorn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+
+	"github.com/G-Research/yunikorn-history-server/internal/catchup"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+)
+
+// WithCatchUp sets the monitor of the ingestion lag, which switches the processing of the event stream to the
+// catch-up mode while the events lag behind.
+func WithCatchUp(monitor *catchup.Monitor) Option {
+	return func(s *Service) {
+		s.catchUp = monitor
+	}
+}
+
+// observeLag records the lag of the event, and completes the deferred work once the ingestion caught up.
+func (s *Service) observeLag(ctx context.Context, ev *si.EventRecord) {
+	if s.catchUp == nil {
+		return
+	}
+	wasCatchingUp := s.catchingUp
+	s.catchingUp = s.catchUp.Observe(ctx, time.Unix(0, ev.GetTimestampNano()))
+	if wasCatchingUp && !s.catchingUp {
+		s.flushApplications(ctx)
+		s.enrichDeferredApplications(ctx)
+	}
+}
+
+// lookUpApplication returns the application of the event of a new application from Yunikorn. In catch-up mode, the
+// application is not looked up but tracked from its events only, and looked up once it finished or the ingestion
+// caught up.
+func (s *Service) lookUpApplication(ctx context.Context, ev *si.EventRecord) (*dao.ApplicationDAOInfo, error) {
+	if s.catchingUp {
+		if s.deferred == nil {
+			s.deferred = make(map[string]struct{})
+		}
+		s.deferred[ev.GetObjectID()] = struct{}{}
+		return &dao.ApplicationDAOInfo{ApplicationID: ev.GetObjectID(), SubmissionTime: ev.GetTimestampNano()}, nil
+	}
+	return s.client.GetApplication(ctx, "", "", ev.GetObjectID())
+}
+
+// storeApplication stores the finished application, which is looked up first if its lookup was deferred. In
+// catch-up mode, the applications are stored in batches.
+func (s *Service) storeApplication(ctx context.Context, app *dao.ApplicationDAOInfo) {
+	logger := log.FromContext(ctx)
+
+	if _, ok := s.deferred[app.ApplicationID]; ok {
+		enriched, err := s.getApplication(ctx, app.ApplicationID)
+		if err != nil {
+			logger.Errorf("could not store application %s whose lookup was deferred: %v", app.ApplicationID, err)
+			return
+		}
+		delete(s.deferred, app.ApplicationID)
+		s.appMap[app.ApplicationID] = enriched
+		app = enriched
+	}
+	s.pending = append(s.pending, app)
+	if !s.catchingUp || len(s.pending) >= s.catchUp.BatchSize() {
+		s.flushApplications(ctx)
+	}
+}
+
+// flushApplications stores the pending finished applications.
+func (s *Service) flushApplications(ctx context.Context) {
+	if len(s.pending) == 0 {
+		return
+	}
+	if err := s.repo.UpsertApplications(ctx, s.pending); err != nil {
+		log.FromContext(ctx).Errorf("could not insert applications into DB: %v", err)
+	}
+	s.pending = nil
+}
+
+// enrichDeferredApplications looks up the applications whose lookup was deferred in catch-up mode.
+func (s *Service) enrichDeferredApplications(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	if len(s.deferred) > 0 {
+		logger.Infow("looking up applications deferred in catch-up mode", "applications", len(s.deferred))
+	}
+	for id := range s.deferred {
+		if _, ok := s.appMap[id]; !ok {
+			delete(s.deferred, id)
+			continue
+		}
+		// an application which cannot be looked up is looked up again once it finished
+		app, err := s.getApplication(ctx, id)
+		if err != nil {
+			logger.Errorf("could not look up application %s deferred in catch-up mode: %v", id, err)
+			continue
+		}
+		delete(s.deferred, id)
+		s.appMap[id] = app
+	}
+}
+
+// getApplication returns the application from Yunikorn, or an error if it was not found.
+func (s *Service) getApplication(ctx context.Context, id string) (*dao.ApplicationDAOInfo, error) {
+	app, err := s.client.GetApplication(ctx, "", "", id)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %s was not found in the scheduler", id)
+	}
+	return app, nil
+}
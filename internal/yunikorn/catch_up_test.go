@@ -0,0 +1,71 @@
+package yunikorn
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/catchup"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestProcessStreamResponseCatchUp(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	client := NewMockClient(mockCtrl)
+	repo := repository.NewMockRepository(mockCtrl)
+	monitor := catchup.NewMonitor(&config.CatchUpConfig{
+		LagThreshold: 5 * time.Minute,
+		RecoveryLag:  time.Minute,
+		BatchSize:    2,
+	})
+	s := NewService(repo, repository.NewInMemoryEventRepository(), client, WithCatchUp(monitor))
+
+	process := func(lag time.Duration, changeType si.EventRecord_ChangeType, detail si.EventRecord_ChangeDetail, id string) {
+		t.Helper()
+		response, err := json.Marshal(&si.EventRecord{
+			Type:              si.EventRecord_APP,
+			EventChangeType:   changeType,
+			EventChangeDetail: detail,
+			ObjectID:          id,
+			TimestampNano:     time.Now().Add(-lag).UnixNano(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.processStreamResponse(ctx, response))
+	}
+	application := func(id string) *dao.ApplicationDAOInfo {
+		return &dao.ApplicationDAOInfo{ApplicationID: id, QueueName: "root.default", Partition: "default"}
+	}
+
+	// new applications are not looked up while the events lag behind
+	process(10*time.Minute, si.EventRecord_ADD, si.EventRecord_APP_NEW, "app-1")
+	process(10*time.Minute, si.EventRecord_ADD, si.EventRecord_APP_NEW, "app-2")
+	process(10*time.Minute, si.EventRecord_ADD, si.EventRecord_APP_NEW, "app-3")
+	assert.True(t, monitor.Active())
+
+	// finished applications are looked up, and stored in batches
+	client.EXPECT().GetApplication(gomock.Any(), "", "", "app-1").Return(application("app-1"), nil)
+	process(9*time.Minute, si.EventRecord_SET, si.EventRecord_APP_COMPLETED, "app-1")
+	client.EXPECT().GetApplication(gomock.Any(), "", "", "app-2").Return(application("app-2"), nil)
+	repo.EXPECT().UpsertApplications(gomock.Any(), []*dao.ApplicationDAOInfo{application("app-1"), application("app-2")})
+	process(8*time.Minute, si.EventRecord_SET, si.EventRecord_APP_FAILED, "app-2")
+
+	// the deferred lookups are made once the ingestion caught up
+	client.EXPECT().GetApplication(gomock.Any(), "", "", "app-3").Return(application("app-3"), nil)
+	process(time.Second, si.EventRecord_SET, si.EventRecord_APP_RUNNING, "app-3")
+	assert.False(t, monitor.Active())
+	assert.Equal(t, "root.default", s.appMap["app-3"].QueueName)
+	assert.Equal(t, "APP_RUNNING", s.appMap["app-3"].State)
+
+	// finished applications are stored at once again
+	repo.EXPECT().UpsertApplications(gomock.Any(), []*dao.ApplicationDAOInfo{s.appMap["app-3"]})
+	process(time.Second, si.EventRecord_SET, si.EventRecord_APP_COMPLETED, "app-3")
+}
@@ -21,4 +21,7 @@ type Client interface {
 	GetContainersHistory(ctx context.Context) ([]*dao.ContainerHistoryDAOInfo, error)
 	GetEventStream(ctx context.Context) (*http.Response, error)
 	Healthcheck(ctx context.Context) (*dao.SchedulerHealthDAOInfo, error)
+	GetSchedulerConfig(ctx context.Context) (*dao.ConfigDAOInfo, error)
+	GetClusters(ctx context.Context) ([]*dao.ClusterDAOInfo, error)
+	GetEvents(ctx context.Context, count uint64) (*dao.EventRecordDAO, error)
 }
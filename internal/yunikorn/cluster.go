@@ -0,0 +1,125 @@
+package yunikorn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// WithCluster attributes the data of Yunikorn to the cluster with the given ID, see package cluster. The partitions
+// are qualified with the ID before the data is stored or forwarded, and the epochs and published events are
+// attributed to the cluster. The data is stored as is for the default cluster, whose ID is empty.
+func WithCluster(id string) Option {
+	return func(s *Service) {
+		s.clusterID = id
+	}
+}
+
+// clusterRepository qualifies the partitions of the data with the ID of its cluster before it is written to the
+// wrapped repository, and attributes the history and queue ACLs to the cluster by the context. Like the
+// transformations, the partitions are qualified in copies, as the sync reuses the data it wrote.
+type clusterRepository struct {
+	Repository
+	id string
+}
+
+func (r *clusterRepository) UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error {
+	qualified := make([]*dao.PartitionInfo, len(partitions))
+	for i, p := range partitions {
+		partition := *p
+		partition.ClusterID = r.id
+		partition.Name = cluster.Qualify(r.id, p.Name)
+		qualified[i] = &partition
+	}
+	return r.Repository.UpsertPartitions(ctx, qualified)
+}
+
+func (r *clusterRepository) UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	qualified := make([]*dao.PartitionQueueDAOInfo, len(queues))
+	for i, q := range queues {
+		qualified[i] = r.queue(q)
+	}
+	return r.Repository.UpsertQueues(ctx, qualified)
+}
+
+// queue returns a copy of the queue and its children with their partitions qualified.
+func (r *clusterRepository) queue(q *dao.PartitionQueueDAOInfo) *dao.PartitionQueueDAOInfo {
+	qualified := *q
+	qualified.Partition = cluster.Qualify(r.id, q.Partition)
+	if len(q.Children) > 0 {
+		qualified.Children = make([]dao.PartitionQueueDAOInfo, len(q.Children))
+		for i := range q.Children {
+			qualified.Children[i] = *r.queue(&q.Children[i])
+		}
+	}
+	return &qualified
+}
+
+func (r *clusterRepository) UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error {
+	return r.Repository.UpsertNodes(ctx, nodes, cluster.Qualify(r.id, partition))
+}
+
+func (r *clusterRepository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
+	qualified := make([]*dao.ApplicationDAOInfo, len(apps))
+	for i, a := range apps {
+		app := *a
+		app.Partition = cluster.Qualify(r.id, a.Partition)
+		qualified[i] = &app
+	}
+	return r.Repository.UpsertApplications(ctx, qualified)
+}
+
+func (r *clusterRepository) InsertNodeUtilizations(
+	ctx context.Context, id uuid.UUID, partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo) error {
+	qualified := make([]*dao.PartitionNodesUtilDAOInfo, len(partitionNodesUtil))
+	for i, nu := range partitionNodesUtil {
+		nodesUtil := *nu
+		nodesUtil.ClusterID = r.id
+		nodesUtil.Partition = cluster.Qualify(r.id, nu.Partition)
+		qualified[i] = &nodesUtil
+	}
+	return r.Repository.InsertNodeUtilizations(ctx, id, qualified)
+}
+
+func (r *clusterRepository) UpdateHistory(
+	ctx context.Context, apps []*dao.ApplicationHistoryDAOInfo, containers []*dao.ContainerHistoryDAOInfo) error {
+	return r.Repository.UpdateHistory(cluster.WithID(ctx, r.id), apps, containers)
+}
+
+func (r *clusterRepository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	qualified := make([]*model.QueueACL, len(acls))
+	for i, a := range acls {
+		acl := *a
+		acl.Partition = cluster.Qualify(r.id, a.Partition)
+		qualified[i] = &acl
+	}
+	return r.Repository.UpdateQueueACLs(cluster.WithID(ctx, r.id), qualified, at)
+}
+
+// ClusterApplications returns the applications of the queues of the schedulers of several clusters, by the ID
+// of the cluster the partitions are qualified with. The partitions of the applications are qualified like the
+// stored applications.
+type ClusterApplications map[string]Client
+
+func (c ClusterApplications) GetApplications(
+	ctx context.Context, partitionName, queueName string) ([]*dao.ApplicationDAOInfo, error) {
+	id, name := cluster.Split(partitionName)
+	client, ok := c[id]
+	if !ok {
+		return nil, fmt.Errorf("no scheduler of cluster %q is synced", id)
+	}
+	apps, err := client.GetApplications(ctx, name, queueName)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range apps {
+		app.Partition = cluster.Qualify(id, app.Partition)
+	}
+	return apps, nil
+}
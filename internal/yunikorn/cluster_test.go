@@ -0,0 +1,112 @@
+package yunikorn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+func TestClusterRepository(t *testing.T) {
+	mockRepository := repository.NewMockRepository(gomock.NewController(t))
+	service := NewService(mockRepository, nil, nil, WithCluster("eu-west"))
+	ctx := context.Background()
+
+	mockRepository.EXPECT().UpsertPartitions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, partitions []*dao.PartitionInfo) error {
+			require.Len(t, partitions, 1)
+			assert.Equal(t, "[eu-west]default", partitions[0].Name)
+			assert.Equal(t, "eu-west", partitions[0].ClusterID)
+			return nil
+		},
+	)
+	mockRepository.EXPECT().UpsertQueues(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+			require.Len(t, queues, 1)
+			assert.Equal(t, "[eu-west]default", queues[0].Partition)
+			assert.Equal(t, "[eu-west]default", queues[0].Children[0].Partition)
+			return nil
+		},
+	)
+	mockRepository.EXPECT().UpsertNodes(gomock.Any(), gomock.Any(), "[eu-west]default").Return(nil)
+	mockRepository.EXPECT().UpsertApplications(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, apps []*dao.ApplicationDAOInfo) error {
+			require.Len(t, apps, 1)
+			assert.Equal(t, "[eu-west]default", apps[0].Partition)
+			return nil
+		},
+	)
+	mockRepository.EXPECT().UpdateHistory(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ []*dao.ApplicationHistoryDAOInfo, _ []*dao.ContainerHistoryDAOInfo) error {
+			assert.Equal(t, "eu-west", cluster.IDFromContext(ctx))
+			return nil
+		},
+	)
+	mockRepository.EXPECT().UpdateQueueACLs(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, acls []*model.QueueACL, _ time.Time) error {
+			require.Len(t, acls, 1)
+			assert.Equal(t, "[eu-west]default", acls[0].Partition)
+			assert.Equal(t, "eu-west", cluster.IDFromContext(ctx))
+			return nil
+		},
+	)
+
+	partition := &dao.PartitionInfo{Name: "default", ClusterID: "mycluster"}
+	require.NoError(t, service.repo.UpsertPartitions(ctx, []*dao.PartitionInfo{partition}))
+	queue := &dao.PartitionQueueDAOInfo{
+		QueueName: "root",
+		Partition: "default",
+		Children:  []dao.PartitionQueueDAOInfo{{QueueName: "root.batch", Partition: "default"}},
+	}
+	require.NoError(t, service.repo.UpsertQueues(ctx, []*dao.PartitionQueueDAOInfo{queue}))
+	require.NoError(t, service.repo.UpsertNodes(ctx, nil, "default"))
+	app := &dao.ApplicationDAOInfo{ApplicationID: "app-1", Partition: "default"}
+	require.NoError(t, service.repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{app}))
+	require.NoError(t, service.repo.UpdateHistory(ctx, nil, nil))
+	acl := &model.QueueACL{Partition: "default", QueueName: "root.batch"}
+	require.NoError(t, service.repo.UpdateQueueACLs(ctx, []*model.QueueACL{acl}, time.Now()))
+	// the sync keeps using the original data
+	assert.Equal(t, "default", partition.Name)
+	assert.Equal(t, "default", queue.Children[0].Partition)
+	assert.Equal(t, "default", app.Partition)
+	assert.Equal(t, "default", acl.Partition)
+}
+
+func TestDefaultClusterRepository(t *testing.T) {
+	service := NewService(repository.NewMockRepository(gomock.NewController(t)), nil, nil, WithCluster(""))
+	_, ok := service.repo.(*clusterRepository)
+	assert.False(t, ok)
+}
+
+func TestClusterApplications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defaultClient := NewMockClient(mockCtrl)
+	euWestClient := NewMockClient(mockCtrl)
+	live := ClusterApplications{"": defaultClient, "eu-west": euWestClient}
+	ctx := context.Background()
+
+	euWestClient.EXPECT().GetApplications(gomock.Any(), "default", "root.batch").Return(
+		[]*dao.ApplicationDAOInfo{{ApplicationID: "app-1", Partition: "default"}}, nil)
+	apps, err := live.GetApplications(ctx, "[eu-west]default", "root.batch")
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "[eu-west]default", apps[0].Partition)
+
+	defaultClient.EXPECT().GetApplications(gomock.Any(), "default", "root.batch").Return(
+		[]*dao.ApplicationDAOInfo{{ApplicationID: "app-2", Partition: "default"}}, nil)
+	apps, err = live.GetApplications(ctx, "default", "root.batch")
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "default", apps[0].Partition)
+
+	_, err = live.GetApplications(ctx, "[us-east]default", "root.batch")
+	assert.Error(t, err)
+}
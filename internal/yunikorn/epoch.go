@@ -0,0 +1,76 @@
+package yunikorn
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// EpochRecorder records the epochs of Yunikorn, which delimit the restarts of the scheduler.
+type EpochRecorder interface {
+	RecordSchedulerEpoch(ctx context.Context, epoch *model.SchedulerEpoch) (bool, error)
+}
+
+// WithEpochRecorder enables the detection of the restarts of Yunikorn, which are recorded as epoch boundaries
+// by the recorder and trigger a sync of the data of the new instance of the scheduler.
+func WithEpochRecorder(recorder EpochRecorder) Option {
+	return func(s *Service) {
+		s.epochs = recorder
+	}
+}
+
+// detectRestart reads the start time and, if Yunikorn exposes it, the instance ID of the running scheduler,
+// records its epoch and returns whether the scheduler restarted since the last recorded epoch.
+// The epoch is only recorded again if something new is known about the instance.
+func (s *Service) detectRestart(ctx context.Context) (bool, error) {
+	if s.epochs == nil {
+		return false, nil
+	}
+	logger := log.FromContext(ctx)
+
+	clusters, err := s.client.GetClusters(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(clusters) == 0 || clusters[0].StartTime == 0 {
+		// the scheduler has no partitions yet
+		return false, nil
+	}
+	epoch := &model.SchedulerEpoch{
+		ClusterID:  s.clusterID,
+		StartTime:  clusters[0].StartTime,
+		DetectedAt: time.Now().UnixNano(),
+	}
+	// the instance ID is only exposed with the events kept by the scheduler
+	if events, err := s.client.GetEvents(ctx, 1); err != nil {
+		logger.Debugf("could not get instance ID of yunikorn: %v", err)
+	} else if events.InstanceUUID != "" {
+		epoch.InstanceID = &events.InstanceUUID
+	}
+
+	s.epochMu.Lock()
+	defer s.epochMu.Unlock()
+	if current := s.epoch; current != nil && current.StartTime == epoch.StartTime &&
+		(epoch.InstanceID == nil || current.InstanceID != nil && *current.InstanceID == *epoch.InstanceID) {
+		return false, nil
+	}
+	restarted, err := s.epochs.RecordSchedulerEpoch(ctx, epoch)
+	if err != nil {
+		return false, err
+	}
+	s.epoch = epoch
+	if restarted {
+		logger.Warnw("detected restart of yunikorn", "startTime", time.Unix(0, epoch.StartTime), "instanceId", epoch.InstanceID)
+	}
+	return restarted, nil
+}
+
+// requestReconciliation requests a sync of the data of Yunikorn, unless a sync is already requested.
+func (s *Service) requestReconciliation() {
+	select {
+	case s.reconcile <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,86 @@
+package yunikorn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/util"
+)
+
+func TestDetectRestart(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	client := NewMockClient(mockCtrl)
+	repo := repository.NewMockRepository(mockCtrl)
+	s := NewService(repo, repository.NewInMemoryEventRepository(), client, WithEpochRecorder(repo))
+
+	instance := func(startTime int64, instanceID string, eventsErr error) {
+		client.EXPECT().GetClusters(gomock.Any()).Return([]*dao.ClusterDAOInfo{{StartTime: startTime}}, nil)
+		client.EXPECT().GetEvents(gomock.Any(), uint64(1)).Return(&dao.EventRecordDAO{InstanceUUID: instanceID}, eventsErr)
+	}
+	record := func(startTime int64, instanceID *string, restarted bool) {
+		repo.EXPECT().RecordSchedulerEpoch(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, epoch *model.SchedulerEpoch) (bool, error) {
+				assert.Equal(t, startTime, epoch.StartTime)
+				assert.Equal(t, instanceID, epoch.InstanceID)
+				return restarted, nil
+			})
+	}
+
+	// the instance ID is not known if the event tracking of Yunikorn is disabled
+	instance(100, "", &StatusError{StatusCode: 500})
+	record(100, nil, false)
+	restarted, err := s.detectRestart(ctx)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+
+	// the epoch is not recorded again unless something new is known about the instance
+	instance(100, "", &StatusError{StatusCode: 500})
+	restarted, err = s.detectRestart(ctx)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+
+	instance(100, "instance-1", nil)
+	record(100, util.ToPtr("instance-1"), false)
+	restarted, err = s.detectRestart(ctx)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+
+	instance(200, "instance-2", nil)
+	record(200, util.ToPtr("instance-2"), true)
+	restarted, err = s.detectRestart(ctx)
+	require.NoError(t, err)
+	assert.True(t, restarted)
+
+	// a scheduler without partitions has no start time
+	client.EXPECT().GetClusters(gomock.Any()).Return(nil, nil)
+	restarted, err = s.detectRestart(ctx)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+}
+
+func TestDetectRestartDisabled(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	s := NewService(repository.NewMockRepository(mockCtrl), repository.NewInMemoryEventRepository(), NewMockClient(mockCtrl))
+
+	restarted, err := s.detectRestart(context.Background())
+	require.NoError(t, err)
+	assert.False(t, restarted)
+}
+
+func TestRequestReconciliation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	s := NewService(repository.NewMockRepository(mockCtrl), repository.NewInMemoryEventRepository(), NewMockClient(mockCtrl))
+
+	// requests are coalesced until the sync runs
+	s.requestReconciliation()
+	s.requestReconciliation()
+	assert.Len(t, s.reconcile, 1)
+}
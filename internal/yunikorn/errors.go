@@ -0,0 +1,110 @@
+package yunikorn
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ErrorCategory is the category of an error of the Yunikorn API, which tells apart a scheduler which cannot be
+// reached from a scheduler whose responses cannot be understood.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryNetwork is the category of the errors of requests which did not reach the scheduler,
+	// and of the responses of a scheduler which is unavailable.
+	ErrorCategoryNetwork ErrorCategory = "network"
+	// ErrorCategoryAuth is the category of the requests which the scheduler rejected as unauthorized.
+	ErrorCategoryAuth ErrorCategory = "auth"
+	// ErrorCategoryParse is the category of the responses which could not be decoded,
+	// which most likely come from an incompatible version of Yunikorn.
+	ErrorCategoryParse ErrorCategory = "parse"
+	// ErrorCategoryOther is the category of all other errors, such as errors of the database.
+	ErrorCategoryOther ErrorCategory = "other"
+)
+
+// ErrorCategories are the categories of the errors, in the order of precedence when an error wraps several errors.
+var ErrorCategories = []ErrorCategory{ErrorCategoryAuth, ErrorCategoryParse, ErrorCategoryNetwork, ErrorCategoryOther}
+
+// StatusError is the error of a response of the Yunikorn API with a non-OK status code.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("yunicorn api returned non-OK status code: %d", e.StatusCode)
+}
+
+// DecodeError is the error of a response of the Yunikorn API whose body could not be decoded.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Categorize returns the category of the error. If the error wraps errors of several categories,
+// the category with the highest precedence is returned: the errors which persist until the configuration of
+// the server or the scheduler is fixed take precedence over the errors of an unavailable scheduler.
+func Categorize(err error) ErrorCategory {
+	found := make(map[ErrorCategory]bool)
+	collectCategories(err, found)
+	for _, category := range ErrorCategories {
+		if found[category] {
+			return category
+		}
+	}
+	return ErrorCategoryOther
+}
+
+// collectCategories adds the categories of the error and of the errors it wraps to found.
+func collectCategories(err error, found map[ErrorCategory]bool) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *StatusError:
+		switch {
+		case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+			found[ErrorCategoryAuth] = true
+		case e.StatusCode >= http.StatusInternalServerError:
+			found[ErrorCategoryNetwork] = true
+		}
+		return
+	case *DecodeError:
+		found[ErrorCategoryParse] = true
+		return
+	case net.Error:
+		found[ErrorCategoryNetwork] = true
+		return
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			collectCategories(wrapped, found)
+		}
+	case interface{ Unwrap() error }:
+		collectCategories(e.Unwrap(), found)
+	}
+}
+
+// multiError is an error which wraps several errors, formatted as a list after its message.
+type multiError struct {
+	msg  string
+	errs []error
+}
+
+// wrapErrors returns an error which wraps the errors, so that they can be categorized.
+func wrapErrors(msg string, errs []error) error {
+	return &multiError{msg: fmt.Sprintf("%s: %v", msg, errs), errs: errs}
+}
+
+func (e *multiError) Error() string {
+	return e.msg
+}
+
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
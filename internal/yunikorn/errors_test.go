@@ -0,0 +1,85 @@
+package yunikorn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategorize(t *testing.T) {
+	tests := map[string]struct {
+		handler  http.HandlerFunc
+		expected ErrorCategory
+	}{
+		"server error": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "server error", http.StatusServiceUnavailable)
+			},
+			expected: ErrorCategoryNetwork,
+		},
+		"unauthorized": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			},
+			expected: ErrorCategoryAuth,
+		},
+		"forbidden": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+			},
+			expected: ErrorCategoryAuth,
+		},
+		"unexpected JSON": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeResponse(t, w, map[string]string{"unexpected": "unexpected"})
+			},
+			expected: ErrorCategoryParse,
+		},
+		"not found": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+			expected: ErrorCategoryOther,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(tt.handler)
+			defer ts.Close()
+
+			client := NewRESTClient(getMockServerYunikornConfig(t, ts.URL))
+			_, err := client.GetPartitions(context.Background())
+			assert.Equal(t, tt.expected, Categorize(fmt.Errorf("could not get partitions: %w", err)))
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		ts := httptest.NewServer(http.NotFoundHandler())
+		client := NewRESTClient(getMockServerYunikornConfig(t, ts.URL))
+		ts.Close()
+
+		_, err := client.GetPartitions(context.Background())
+		assert.Equal(t, ErrorCategoryNetwork, Categorize(err))
+	})
+
+	t.Run("several errors", func(t *testing.T) {
+		err := wrapErrors("some errors", []error{
+			&StatusError{StatusCode: http.StatusBadGateway},
+			fmt.Errorf("wrapped: %w", &DecodeError{Err: errors.New("unexpected end of JSON input")}),
+			errors.New("database error"),
+		})
+		assert.Equal(t, ErrorCategoryParse, Categorize(err))
+		assert.Equal(t, "some errors: [yunicorn api returned non-OK status code: 502 "+
+			"wrapped: unexpected end of JSON input database error]", err.Error())
+	})
+
+	t.Run("other", func(t *testing.T) {
+		assert.Equal(t, ErrorCategoryOther, Categorize(errors.New("database error")))
+	})
+}
@@ -77,7 +77,7 @@ func (s *Service) handleAppAddEvent(ctx context.Context, ev *si.EventRecord) {
 
 	switch ev.GetEventChangeDetail() {
 	case si.EventRecord_APP_NEW, si.EventRecord_DETAILS_NONE:
-		app, err := s.client.GetApplication(ctx, "", "", ev.GetObjectID())
+		app, err := s.lookUpApplication(ctx, ev)
 		if err != nil {
 			logger.Errorf("could not get application info %s from scheduler: %v\nReceived Event: %v",
 				ev.GetObjectID(), err, ev)
@@ -147,7 +147,7 @@ func (s *Service) handleAppSetEvent(ctx context.Context, ev *si.EventRecord) {
 
 	switch ev.GetEventChangeDetail() {
 	case si.EventRecord_APP_NEW:
-		app, err := s.client.GetApplication(ctx, "", "", ev.GetObjectID())
+		app, err := s.lookUpApplication(ctx, ev)
 		if err != nil {
 			logger.Errorf(
 				"could not get application info %s from scheduler: %v\nReceived Event: %v",
@@ -185,9 +185,7 @@ func (s *Service) handleAppSetEvent(ctx context.Context, ev *si.EventRecord) {
 		if ev.GetEventChangeDetail() == si.EventRecord_APP_COMPLETED ||
 			ev.GetEventChangeDetail() == si.EventRecord_APP_FAILED {
 
-			if err := s.repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{app}); err != nil {
-				logger.Errorf("could not insert application into DB: %v", err)
-			}
+			s.storeApplication(ctx, app)
 		}
 	default:
 		// should be warning
@@ -203,6 +201,7 @@ func (s *Service) handleAppRemoveEvent(ctx context.Context, ev *si.EventRecord)
 	case si.EventRecord_DETAILS_NONE:
 		// Should we reinsert the application into the DB in case we didn't a terminal state change event (e.g. completed)?
 		delete(s.appMap, ev.GetObjectID())
+		delete(s.deferred, ev.GetObjectID())
 	case si.EventRecord_APP_REJECT:
 		app, ok := s.appMap[ev.GetObjectID()]
 		if !ok || app == nil {
@@ -219,9 +218,7 @@ func (s *Service) handleAppRemoveEvent(ctx context.Context, ev *si.EventRecord)
 			ApplicationState: state,
 		})
 		app.State = state
-		if err := s.repo.UpsertApplications(ctx, []*dao.ApplicationDAOInfo{app}); err != nil {
-			logger.Errorf("could not insert application into DB: %v", err)
-		}
+		s.storeApplication(ctx, app)
 		// should we delete the application from the cache or it is guaranteed to recieve a REMOVE with DETAILS_NONE event?
 	case si.EventRecord_ALLOC_CANCEL, si.EventRecord_ALLOC_TIMEOUT,
 		si.EventRecord_ALLOC_REPLACED, si.EventRecord_ALLOC_PREEMPT,
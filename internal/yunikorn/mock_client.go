@@ -86,6 +86,21 @@ func (mr *MockClientMockRecorder) GetAppsHistory(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppsHistory", reflect.TypeOf((*MockClient)(nil).GetAppsHistory), arg0)
 }
 
+// GetClusters mocks base method.
+func (m *MockClient) GetClusters(arg0 context.Context) ([]*dao.ClusterDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusters", arg0)
+	ret0, _ := ret[0].([]*dao.ClusterDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusters indicates an expected call of GetClusters.
+func (mr *MockClientMockRecorder) GetClusters(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockClient)(nil).GetClusters), arg0)
+}
+
 // GetContainersHistory mocks base method.
 func (m *MockClient) GetContainersHistory(arg0 context.Context) ([]*dao.ContainerHistoryDAOInfo, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +131,21 @@ func (mr *MockClientMockRecorder) GetEventStream(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventStream", reflect.TypeOf((*MockClient)(nil).GetEventStream), arg0)
 }
 
+// GetEvents mocks base method.
+func (m *MockClient) GetEvents(arg0 context.Context, arg1 uint64) (*dao.EventRecordDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", arg0, arg1)
+	ret0, _ := ret[0].(*dao.EventRecordDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockClientMockRecorder) GetEvents(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockClient)(nil).GetEvents), arg0, arg1)
+}
+
 // GetNodeUtil mocks base method.
 func (m *MockClient) GetNodeUtil(arg0 context.Context) ([]*dao.PartitionNodesUtilDAOInfo, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +206,21 @@ func (mr *MockClientMockRecorder) GetPartitions(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartitions", reflect.TypeOf((*MockClient)(nil).GetPartitions), arg0)
 }
 
+// GetSchedulerConfig mocks base method.
+func (m *MockClient) GetSchedulerConfig(arg0 context.Context) (*dao.ConfigDAOInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulerConfig", arg0)
+	ret0, _ := ret[0].(*dao.ConfigDAOInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulerConfig indicates an expected call of GetSchedulerConfig.
+func (mr *MockClientMockRecorder) GetSchedulerConfig(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulerConfig", reflect.TypeOf((*MockClient)(nil).GetSchedulerConfig), arg0)
+}
+
 // Healthcheck mocks base method.
 func (m *MockClient) Healthcheck(arg0 context.Context) (*dao.SchedulerHealthDAOInfo, error) {
 	m.ctrl.T.Helper()
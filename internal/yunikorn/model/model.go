@@ -7,6 +7,14 @@ import (
 // EventTypeCounts is a map of event types to their counts.
 type EventTypeCounts map[string]int
 
+// EventCategoryCount is the number of events of the event types of an event category, such as scheduling.
+type EventCategoryCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	// Events are the counts of the event types of the category.
+	Events EventTypeCounts `json:"events"`
+}
+
 // EventTypeKey is a key for the EventTypeCounts map and is a combination of the event type and the change type.
 type EventTypeKey struct {
 	Type       si.EventRecord_Type
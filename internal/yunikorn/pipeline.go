@@ -0,0 +1,154 @@
+package yunikorn
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// eventQueueSize is the number of events of each priority which are read ahead from the event stream. Once a queue
+// is full, reading the stream blocks, so that a backed up stream is not buffered in memory.
+const eventQueueSize = 1024
+
+// priority is the priority of an event of the event stream in the ingestion pipeline.
+type priority string
+
+const (
+	// priorityHigh is the priority of the events which change the state of applications, such as finished
+	// applications and released allocations, so that the API reflects them promptly.
+	priorityHigh priority = "high"
+	// priorityLow is the priority of the informational events, such as those of nodes, queues and requests.
+	priorityLow priority = "low"
+)
+
+var queuedEvents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "yhs",
+	Subsystem: "ingestion",
+	Name:      "queued_events",
+	Help:      "Number of events read from the event stream of Yunikorn and waiting to be processed, by priority.",
+}, []string{"priority"})
+
+var (
+	processedEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "ingestion",
+		Name:      "processed_events_total",
+		Help:      "Number of events of the event stream of Yunikorn processed, by event type.",
+	}, []string{"type"})
+	eventProcessingDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "yhs",
+		Subsystem: "ingestion",
+		Name:      "event_processing_duration_seconds",
+		Help:      "Duration of the processing of an event, including the writes to the database it caused.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 16),
+	})
+	eventLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "yhs",
+		Subsystem: "ingestion",
+		Name:      "event_lag_seconds",
+		Help:      "Time from an event in Yunikorn until it was processed, which grows while the ingestion falls behind.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 16),
+	})
+)
+
+// observeProcessedEvent records the processing of the event, which started at start.
+func observeProcessedEvent(ev *si.EventRecord, start time.Time) {
+	processedEventsTotal.WithLabelValues(si.EventRecord_Type_name[int32(ev.GetType())]).Inc()
+	eventProcessingDuration.Observe(time.Since(start).Seconds())
+	if ts := ev.GetTimestampNano(); ts > 0 {
+		eventLag.Observe(max(time.Since(time.Unix(0, ts)).Seconds(), 0))
+	}
+}
+
+// eventPriority returns the priority of the event. The events of applications, which include their state changes and
+// the allocations added to and released from them, are processed before the informational events. The events of an
+// application keep their order, as they are all of the same priority.
+func eventPriority(ev *si.EventRecord) priority {
+	if ev.GetType() == si.EventRecord_APP {
+		return priorityHigh
+	}
+	return priorityLow
+}
+
+// eventQueues are the queues of the events read from the event stream, by priority.
+type eventQueues struct {
+	high chan *si.EventRecord
+	low  chan *si.EventRecord
+}
+
+func newEventQueues(size int) *eventQueues {
+	return &eventQueues{
+		high: make(chan *si.EventRecord, size),
+		low:  make(chan *si.EventRecord, size),
+	}
+}
+
+// push queues the event with its priority. It blocks while the queue of the priority is full, or until the context
+// is done.
+func (q *eventQueues) push(ctx context.Context, ev *si.EventRecord) error {
+	p := eventPriority(ev)
+	queue := q.low
+	if p == priorityHigh {
+		queue = q.high
+	}
+	select {
+	case queue <- ev:
+		queuedEvents.WithLabelValues(string(p)).Inc()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close closes the queues once no further events are pushed. The queued events are still processed.
+func (q *eventQueues) close() {
+	close(q.high)
+	close(q.low)
+}
+
+// empty returns whether no events are queued.
+func (q *eventQueues) empty() bool {
+	return len(q.high) == 0 && len(q.low) == 0
+}
+
+// processQueuedEvents processes the queued events until the queues are closed and drained. The events of the high
+// priority queue are processed first, so that an event of the low priority queue is processed only while no event
+// of high priority is queued.
+func (s *Service) processQueuedEvents(ctx context.Context, q *eventQueues) {
+	high, low := q.high, q.low
+	for high != nil || low != nil {
+		var ev *si.EventRecord
+		var ok bool
+		select {
+		case ev, ok = <-high:
+			if !ok {
+				high = nil
+				continue
+			}
+			queuedEvents.WithLabelValues(string(priorityHigh)).Dec()
+		default:
+			select {
+			case ev, ok = <-high:
+				if !ok {
+					high = nil
+					continue
+				}
+				queuedEvents.WithLabelValues(string(priorityHigh)).Dec()
+			case ev, ok = <-low:
+				if !ok {
+					low = nil
+					continue
+				}
+				queuedEvents.WithLabelValues(string(priorityLow)).Dec()
+			}
+		}
+		s.processEvent(ctx, ev)
+		// the batch of a catch-up is stored once no further events are queued, so that it waits for no events
+		if q.empty() {
+			s.flushApplications(ctx)
+		}
+	}
+}
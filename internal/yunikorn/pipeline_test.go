@@ -0,0 +1,100 @@
+package yunikorn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+)
+
+func TestEventPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    *si.EventRecord
+		expected priority
+	}{
+		{
+			name:     "finished application",
+			event:    &si.EventRecord{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_SET, EventChangeDetail: si.EventRecord_APP_COMPLETED},
+			expected: priorityHigh,
+		},
+		{
+			name:     "released allocation",
+			event:    &si.EventRecord{Type: si.EventRecord_APP, EventChangeType: si.EventRecord_REMOVE, EventChangeDetail: si.EventRecord_ALLOC_CANCEL},
+			expected: priorityHigh,
+		},
+		{
+			name:     "node utilization",
+			event:    &si.EventRecord{Type: si.EventRecord_NODE, EventChangeType: si.EventRecord_ADD, EventChangeDetail: si.EventRecord_NODE_ALLOC},
+			expected: priorityLow,
+		},
+		{
+			name:     "queue",
+			event:    &si.EventRecord{Type: si.EventRecord_QUEUE, EventChangeType: si.EventRecord_ADD},
+			expected: priorityLow,
+		},
+		{
+			name:     "request",
+			event:    &si.EventRecord{Type: si.EventRecord_REQUEST, EventChangeType: si.EventRecord_NONE},
+			expected: priorityLow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, eventPriority(tt.event))
+		})
+	}
+}
+
+func TestProcessQueuedEvents(t *testing.T) {
+	ctx := context.Background()
+
+	appEvents := testutil.ToFloat64(processedEventsTotal.WithLabelValues("APP"))
+	nodeEvents := testutil.ToFloat64(processedEventsTotal.WithLabelValues("NODE"))
+
+	var processed []string
+	service := Service{
+		eventRepository: repository.NewInMemoryEventRepository(),
+		eventHandler: func(ctx context.Context, ev *si.EventRecord) error {
+			processed = append(processed, ev.GetObjectID())
+			return nil
+		},
+	}
+
+	queues := newEventQueues(eventQueueSize)
+	events := []*si.EventRecord{
+		{Type: si.EventRecord_NODE, ObjectID: "node-1", EventChangeType: si.EventRecord_ADD},
+		{Type: si.EventRecord_APP, ObjectID: "app-1", EventChangeType: si.EventRecord_ADD},
+		{Type: si.EventRecord_QUEUE, ObjectID: "root.default", EventChangeType: si.EventRecord_SET},
+		{Type: si.EventRecord_APP, ObjectID: "app-2", EventChangeType: si.EventRecord_ADD},
+		{Type: si.EventRecord_NODE, ObjectID: "node-2", EventChangeType: si.EventRecord_ADD},
+		{Type: si.EventRecord_APP, ObjectID: "app-1", EventChangeType: si.EventRecord_SET, EventChangeDetail: si.EventRecord_APP_COMPLETED},
+	}
+	for _, ev := range events {
+		require.NoError(t, queues.push(ctx, ev))
+	}
+	queues.close()
+
+	service.processQueuedEvents(ctx, queues)
+
+	// the events of applications are processed first, and the events of each priority keep their order
+	assert.Equal(t, []string{"app-1", "app-2", "app-1", "node-1", "root.default", "node-2"}, processed)
+	assert.Equal(t, appEvents+3, testutil.ToFloat64(processedEventsTotal.WithLabelValues("APP")))
+	assert.Equal(t, nodeEvents+2, testutil.ToFloat64(processedEventsTotal.WithLabelValues("NODE")))
+}
+
+func TestEventQueuesPushCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	queues := newEventQueues(1)
+	require.NoError(t, queues.push(ctx, &si.EventRecord{Type: si.EventRecord_NODE}))
+	cancel()
+
+	// the queue of the low priority is full, so the push blocks until the context is done
+	assert.ErrorIs(t, queues.push(ctx, &si.EventRecord{Type: si.EventRecord_NODE}), context.Canceled)
+}
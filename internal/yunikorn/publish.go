@@ -0,0 +1,77 @@
+package yunikorn
+
+import (
+	"time"
+
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+
+	"github.com/G-Research/yunikorn-history-server/internal/cluster"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+// Publisher pushes the processed events to the subscribers of the live event stream. Publish must not block.
+type Publisher interface {
+	Publish(event *model.StreamEvent)
+}
+
+// WithPublisher sets the publisher the events of the event stream are pushed to once they are processed.
+func WithPublisher(publisher Publisher) Option {
+	return func(s *Service) {
+		s.publisher = publisher
+	}
+}
+
+// eventScope returns the partition and queue of the application the event refers to, if it is known, or the queue
+// of the events of queues. It must be called before the event is handled, as the handler forgets the applications
+// once they finish.
+func (s *Service) eventScope(ev *si.EventRecord) (string, string) {
+	switch ev.GetType() {
+	case si.EventRecord_APP:
+		if app, ok := s.appMap[ev.GetObjectID()]; ok && app != nil {
+			return app.Partition, s.transformer.QueueName(app.QueueName)
+		}
+	case si.EventRecord_REQUEST:
+		if app, ok := s.appMap[ev.GetReferenceID()]; ok && app != nil {
+			return app.Partition, s.transformer.QueueName(app.QueueName)
+		}
+	case si.EventRecord_QUEUE:
+		return "", s.transformer.QueueName(ev.GetObjectID())
+	}
+	return "", ""
+}
+
+// publishEvent pushes the transformed event with its partition, qualified with the ID of the cluster, and queue
+// to the publisher, if set.
+func (s *Service) publishEvent(ev *si.EventRecord, partition, queue string) {
+	if s.publisher == nil {
+		return
+	}
+	if partition != "" {
+		partition = cluster.Qualify(s.clusterID, partition)
+	}
+	event := &model.StreamEvent{
+		Type:         ev.GetType().String(),
+		ObjectID:     ev.GetObjectID(),
+		ChangeType:   ev.GetEventChangeType().String(),
+		ChangeDetail: ev.GetEventChangeDetail().String(),
+		Timestamp:    ev.GetTimestampNano(),
+		Partition:    partition,
+		QueueName:    queue,
+	}
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UnixNano()
+	}
+	if referenceID := ev.GetReferenceID(); referenceID != "" {
+		event.ReferenceID = &referenceID
+	}
+	if message := ev.GetMessage(); message != "" {
+		event.Message = &message
+	}
+	if resources := ev.GetResource().GetResources(); len(resources) > 0 {
+		event.Resource = make(map[string]int64, len(resources))
+		for name, quantity := range resources {
+			event.Resource[name] = quantity.GetValue()
+		}
+	}
+	s.publisher.Publish(event)
+}
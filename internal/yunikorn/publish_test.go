@@ -0,0 +1,67 @@
+package yunikorn
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+)
+
+type publishedEvents []*model.StreamEvent
+
+func (p *publishedEvents) Publish(event *model.StreamEvent) {
+	*p = append(*p, event)
+}
+
+func TestProcessStreamResponsePublishesEvents(t *testing.T) {
+	var published publishedEvents
+	var recorded recordedEvents
+	service := &Service{
+		eventRepository: &recorded,
+		appMap: map[string]*dao.ApplicationDAOInfo{
+			"app-1": {ApplicationID: "app-1", Partition: "default", QueueName: "root.batch"},
+		},
+		publisher: &published,
+	}
+	// the handler forgets the finished application before the event is published
+	service.eventHandler = func(_ context.Context, event *si.EventRecord) error {
+		delete(service.appMap, event.GetObjectID())
+		return nil
+	}
+
+	ctx := context.Background()
+	for _, response := range []string{
+		fmt.Sprintf(`{"type": 2, "objectID": "app-1", "eventChangeType": %d, "eventChangeDetail": %d, "timestampNano": 100}`,
+			si.EventRecord_SET, si.EventRecord_APP_COMPLETED),
+		`{"type": 1, "objectID": "alloc-1", "referenceID": "app-2", "message": "ask", "timestampNano": 200}`,
+		`{"type": 4, "objectID": "root.default", "timestampNano": 300}`,
+		`{"type": 3, "objectID": "node-1", "resource": {"resources": {"vcore": {"value": 1000}}}, "timestampNano": 400}`,
+	} {
+		require.NoError(t, service.processStreamResponse(ctx, []byte(response+"\n")))
+	}
+
+	require.Len(t, published, 4)
+	assert.Len(t, recorded, 4)
+	assert.Equal(t, &model.StreamEvent{
+		Type:         "APP",
+		ObjectID:     "app-1",
+		ChangeType:   "SET",
+		ChangeDetail: "APP_COMPLETED",
+		Timestamp:    100,
+		Partition:    "default",
+		QueueName:    "root.batch",
+	}, published[0])
+	// the application of the request is unknown
+	assert.Equal(t, "app-2", *published[1].ReferenceID)
+	assert.Equal(t, "ask", *published[1].Message)
+	assert.Empty(t, published[1].Partition)
+	assert.Equal(t, "root.default", published[2].QueueName)
+	assert.Equal(t, "NODE", published[3].Type)
+	assert.Equal(t, map[string]int64{"vcore": 1000}, published[3].Resource)
+}
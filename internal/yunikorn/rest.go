@@ -21,6 +21,9 @@ const (
 	endpointContainersHistory = "/ws/v1/history/containers"
 	endpointNodeUtil          = "/ws/v1/scheduler/node-utilizations"
 	endpointHealthcheck       = "/ws/v1/scheduler/healthcheck"
+	endpointConfig            = "/ws/v1/config"
+	endpointClusters          = "/ws/v1/clusters"
+	endpointEvents            = "/ws/v1/events/batch"
 )
 
 var (
@@ -274,6 +277,67 @@ func (c *RESTClient) GetEventStream(ctx context.Context) (*http.Response, error)
 	return resp, nil
 }
 
+// GetSchedulerConfig returns the scheduler configuration, which holds the access control lists of the queues.
+func (c *RESTClient) GetSchedulerConfig(ctx context.Context) (*dao.ConfigDAOInfo, error) {
+	resp, err := c.get(ctx, endpointConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(ctx, resp)
+
+	if resp.StatusCode != 200 {
+		return nil, handleNonOKResponse(ctx, resp)
+	}
+
+	var schedulerConfig *dao.ConfigDAOInfo
+	if err = unmarshallBody(ctx, resp, &schedulerConfig); err != nil {
+		return nil, err
+	}
+
+	return schedulerConfig, nil
+}
+
+// GetClusters returns the clusters of the partitions, which hold the start time of the scheduler.
+func (c *RESTClient) GetClusters(ctx context.Context) ([]*dao.ClusterDAOInfo, error) {
+	resp, err := c.get(ctx, endpointClusters)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(ctx, resp)
+
+	if resp.StatusCode != 200 {
+		return nil, handleNonOKResponse(ctx, resp)
+	}
+
+	var clusters []*dao.ClusterDAOInfo
+	if err = unmarshallBody(ctx, resp, &clusters); err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+// GetEvents returns at most count of the oldest events kept by the scheduler, together with the UUID of its instance.
+// It fails if the event tracking of the scheduler is disabled.
+func (c *RESTClient) GetEvents(ctx context.Context, count uint64) (*dao.EventRecordDAO, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("%s?count=%d", endpointEvents, count))
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(ctx, resp)
+
+	if resp.StatusCode != 200 {
+		return nil, handleNonOKResponse(ctx, resp)
+	}
+
+	var events dao.EventRecordDAO
+	if err = unmarshallBody(ctx, resp, &events); err != nil {
+		return nil, err
+	}
+
+	return &events, nil
+}
+
 // get makes a GET request to the given URL and returns the response
 func (c *RESTClient) get(ctx context.Context, endpoint string) (*http.Response, error) {
 	url := c.url(endpoint)
@@ -281,6 +345,8 @@ func (c *RESTClient) get(ctx context.Context, endpoint string) (*http.Response,
 	if err != nil {
 		return nil, err
 	}
+	// the configuration endpoint responds with YAML unless JSON is requested explicitly
+	req.Header.Set("Accept", "application/json")
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -305,7 +371,7 @@ func handleNonOKResponse(ctx context.Context, resp *http.Response) error {
 		"yunikorn api returned non-OK status code",
 		"endpoint", resp.Request.URL.Path, "statusCode", resp.StatusCode, "body", string(errBody),
 	)
-	return fmt.Errorf("yunicorn api returned non-OK status code: %d", resp.StatusCode)
+	return &StatusError{StatusCode: resp.StatusCode}
 }
 
 func unmarshallBody(ctx context.Context, resp *http.Response, v any) error {
@@ -317,7 +383,7 @@ func unmarshallBody(ctx context.Context, resp *http.Response, v any) error {
 			"error unmarshalling response body",
 			"endpoint", resp.Request.URL.Path, "statusCode", resp.StatusCode,
 		)
-		return err
+		return &DecodeError{Err: err}
 	}
 	return nil
 }
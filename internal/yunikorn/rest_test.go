@@ -16,6 +16,7 @@ import (
 
 	"github.com/G-Research/yunikorn-history-server/internal/config"
 
+	"github.com/apache/yunikorn-core/pkg/common/configs"
 	"github.com/apache/yunikorn-core/pkg/webservice/dao"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -725,6 +726,72 @@ func TestRESTClient_GetContainersHistory(t *testing.T) {
 	}
 }
 
+func TestRESTClient_GetSchedulerConfig(t *testing.T) {
+	schedulerConfig := &dao.ConfigDAOInfo{
+		SchedulerConfig: &configs.SchedulerConfig{
+			Partitions: []configs.PartitionConfig{{
+				Name: "default",
+				Queues: []configs.QueueConfig{{
+					Name:      "root",
+					SubmitACL: "*",
+					Queues:    []configs.QueueConfig{{Name: "analytics", AdminACL: "alice analysts"}},
+				}},
+			}},
+		},
+	}
+	tests := []struct {
+		name           string
+		setup          func() *httptest.Server
+		expected       *dao.ConfigDAOInfo
+		wantErr        bool
+		expectedErrMsg string
+	}{
+		{
+			name: "200 OK Response",
+			setup: func() *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != endpointConfig || r.Header.Get("Accept") != "application/json" {
+						http.Error(w, "unexpected request", http.StatusBadRequest)
+						return
+					}
+					writeResponse(t, w, schedulerConfig)
+				}))
+			},
+			expected: schedulerConfig,
+			wantErr:  false,
+		},
+		{
+			name: "Server Error",
+			setup: func() *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				}))
+			},
+			expected:       nil,
+			wantErr:        true,
+			expectedErrMsg: "yunicorn api returned non-OK status code: 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := tt.setup()
+			defer ts.Close()
+
+			client := NewRESTClient(getMockServerYunikornConfig(t, ts.URL))
+
+			got, err := client.GetSchedulerConfig(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedErrMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
+
 func getMockServerYunikornConfig(t *testing.T, serverURL string) *config.YunikornConfig {
 	parsedURL, err := url.Parse(serverURL)
 	require.NoError(t, err)
@@ -745,3 +812,29 @@ func writeResponse(t *testing.T, w http.ResponseWriter, response any) {
 		t.Fatalf("error writing response: %v", err)
 	}
 }
+
+func TestRESTClient_GetClustersAndEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case endpointClusters:
+			writeResponse(t, w, []*dao.ClusterDAOInfo{{StartTime: 1719835200000000000, PartitionName: "default"}})
+		case endpointEvents:
+			assert.Equal(t, "1", r.URL.Query().Get("count"))
+			writeResponse(t, w, &dao.EventRecordDAO{InstanceUUID: "instance-1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewRESTClient(getMockServerYunikornConfig(t, ts.URL))
+
+	clusters, err := client.GetClusters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, int64(1719835200000000000), clusters[0].StartTime)
+
+	events, err := client.GetEvents(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "instance-1", events.InstanceUUID)
+}
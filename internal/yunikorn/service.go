@@ -3,11 +3,18 @@ package yunikorn
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
+	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/google/uuid"
 	"github.com/oklog/run"
 
+	"github.com/G-Research/yunikorn-history-server/internal/catchup"
 	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/transform"
 	"github.com/G-Research/yunikorn-history-server/internal/workqueue"
 
 	"github.com/apache/yunikorn-core/pkg/webservice/dao"
@@ -15,9 +22,32 @@ import (
 	"github.com/G-Research/yunikorn-history-server/internal/log"
 )
 
+// Repository is the part of the repository the service stores the data of Yunikorn in. It is implemented by
+// the repository of the database, and by the forwarder of a collector, which forwards the data to a central server.
+type Repository interface {
+	UpsertPartitions(ctx context.Context, partitions []*dao.PartitionInfo) error
+	UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error
+	UpsertNodes(ctx context.Context, nodes []*dao.NodeDAOInfo, partition string) error
+	UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error
+	InsertNodeUtilizations(ctx context.Context, uuid uuid.UUID, partitionNodesUtil []*dao.PartitionNodesUtilDAOInfo) error
+	UpdateHistory(ctx context.Context, apps []*dao.ApplicationHistoryDAOInfo, containers []*dao.ContainerHistoryDAOInfo) error
+	UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error
+}
+
+// EventRecorder records the events of the Yunikorn event stream.
+type EventRecorder interface {
+	Record(ctx context.Context, event *si.EventRecord) error
+}
+
+var (
+	_ Repository    = repository.Repository(nil)
+	_ EventRecorder = repository.EventRepository(nil)
+	_ EpochRecorder = repository.Repository(nil)
+)
+
 type Service struct {
-	repo            repository.Repository
-	eventRepository repository.EventRepository
+	repo            Repository
+	eventRepository EventRecorder
 	client          Client
 	// eventHandler is a function that handles events from the Yunikorn event stream.
 	eventHandler EventHandler
@@ -27,6 +57,31 @@ type Service struct {
 	syncInterval time.Duration
 	// workqueue processes jobs which store data in database during data sync and retries them with exponential backoff.
 	workqueue *workqueue.WorkQueue
+	// faults drops events of the event stream in resilience tests.
+	faults *faultinject.Injector
+	// transformer applies the transformation rules to the data before it is stored or forwarded.
+	transformer *transform.Transformer
+	// syncTracker tracks the consecutive failures of the data syncs and the categories of their errors.
+	syncTracker *SyncTracker
+	// epochs records the epochs of Yunikorn if the detection of its restarts is enabled.
+	epochs EpochRecorder
+	// epoch is the last recorded epoch, guarded by epochMu.
+	epoch   *model.SchedulerEpoch
+	epochMu sync.Mutex
+	// reconcile requests a sync of the data after a restart of Yunikorn.
+	reconcile chan struct{}
+	// catchUp switches the processing of the event stream to the catch-up mode while the events lag behind.
+	catchUp *catchup.Monitor
+	// catchingUp is set while the processing of the event stream is in catch-up mode.
+	catchingUp bool
+	// deferred are the IDs of the applications whose lookup in Yunikorn was deferred in catch-up mode.
+	deferred map[string]struct{}
+	// pending are the finished applications which are stored with the next batch.
+	pending []*dao.ApplicationDAOInfo
+	// publisher pushes the processed events to the subscribers of the live event stream, if set.
+	publisher Publisher
+	// clusterID is the ID of the cluster of the scheduler, empty for the default cluster.
+	clusterID string
 }
 
 type Option func(*Service)
@@ -37,7 +92,29 @@ func WithSyncInterval(interval time.Duration) Option {
 	}
 }
 
-func NewService(repository repository.Repository, eventRepository repository.EventRepository, client Client, opts ...Option) *Service {
+// WithFaultInjector sets the injector which drops events of the event stream in resilience tests.
+func WithFaultInjector(injector *faultinject.Injector) Option {
+	return func(s *Service) {
+		s.faults = injector
+	}
+}
+
+// WithTransformer sets the transformation rules applied to the events, queues and applications of Yunikorn
+// before they are stored or forwarded.
+func WithTransformer(transformer *transform.Transformer) Option {
+	return func(s *Service) {
+		s.transformer = transformer
+	}
+}
+
+// WithSyncTracker sets the tracker of the failures of the data syncs, which is reported by the readiness probe.
+func WithSyncTracker(tracker *SyncTracker) Option {
+	return func(s *Service) {
+		s.syncTracker = tracker
+	}
+}
+
+func NewService(repository Repository, eventRepository EventRecorder, client Client, opts ...Option) *Service {
 	s := &Service{
 		repo:            repository,
 		eventRepository: eventRepository,
@@ -45,11 +122,19 @@ func NewService(repository repository.Repository, eventRepository repository.Eve
 		appMap:          make(map[string]*dao.ApplicationDAOInfo),
 		syncInterval:    5 * time.Minute,
 		workqueue:       workqueue.NewWorkQueue(workqueue.WithName("yunikorn_data_sync")),
+		syncTracker:     NewSyncTracker(""),
+		reconcile:       make(chan struct{}, 1),
 	}
 	s.eventHandler = s.handleEvent
 	for _, opt := range opts {
 		opt(s)
 	}
+	if s.transformer != nil {
+		s.repo = &transformingRepository{Repository: s.repo, transformer: s.transformer}
+	}
+	if s.clusterID != "" {
+		s.repo = &clusterRepository{Repository: s.repo, id: s.clusterID}
+	}
 
 	return s
 }
@@ -84,7 +169,18 @@ func (s *Service) runEventCollector(ctx context.Context) error {
 
 	logger.Info("starting yunikorn event stream client")
 	for {
-		err := s.ProcessEvents(ctx)
+		// the event stream is interrupted when Yunikorn restarts, so a restart is detected before reconnecting
+		restarted, err := s.detectRestart(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logger.Warnf("could not detect restarts of yunikorn: %v", err)
+		}
+		if restarted {
+			// the applications of the previous instance are reconciled by the sync
+			s.appMap = make(map[string]*dao.ApplicationDAOInfo)
+			s.deferred = nil
+			s.requestReconciliation()
+		}
+		err = s.ProcessEvents(ctx)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				logger.Warn("shutting down yunikorn event stream client")
@@ -105,9 +201,7 @@ func (s *Service) runDataSync(ctx context.Context) error {
 
 	logger.Info("starting yunikorn data sync")
 
-	if err := s.sync(ctx); err != nil {
-		logger.Errorf("error syncing data with yunikorn api: %v", err)
-	}
+	s.syncAndRecord(ctx)
 
 	// if sync interval is 0, sync data once and return
 	if s.syncInterval == 0 {
@@ -125,9 +219,29 @@ func (s *Service) runDataSync(ctx context.Context) error {
 			return nil
 		case <-ticker.C:
 			logger.Info("syncing data with yunikorn api")
-			if err := s.sync(ctx); err != nil {
-				logger.Errorf("error syncing data with yunikorn api: %v", err)
-			}
+			s.syncAndRecord(ctx)
+		case <-s.reconcile:
+			logger.Info("reconciling data with yunikorn api after a restart of yunikorn")
+			s.syncAndRecord(ctx)
+			ticker.Reset(s.syncInterval)
 		}
 	}
 }
+
+// syncAndRecord syncs the data with the Yunikorn API and records the outcome in the sync tracker.
+// A sync interrupted by the shutdown of the service is not recorded.
+func (s *Service) syncAndRecord(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	err := s.sync(ctx)
+	switch {
+	case err == nil:
+		s.syncTracker.RecordSuccess(time.Now())
+	case ctx.Err() != nil:
+		logger.Warnf("data sync interrupted: %v", err)
+	default:
+		category := s.syncTracker.RecordFailure(err)
+		logger.Errorw("error syncing data with yunikorn api", "error", err, "category", category,
+			"consecutiveFailures", s.syncTracker.Status().ConsecutiveFailures)
+	}
+}
@@ -0,0 +1,116 @@
+package yunikorn
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	syncFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "yhs",
+		Subsystem: "yunikorn_sync",
+		Name:      "failures_total",
+		Help:      "Number of failed syncs with the Yunikorn API, by cluster and category of the error.",
+	}, []string{"cluster", "category"})
+	syncConsecutiveFailures = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Subsystem: "yunikorn_sync",
+		Name:      "consecutive_failures",
+		Help:      "Number of consecutive failed syncs with the Yunikorn API since the last successful sync.",
+	}, []string{"cluster"})
+	syncLastFailureCategory = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Subsystem: "yunikorn_sync",
+		Name:      "last_failure_category",
+		Help:      "1 for the category of the error of the last sync with the Yunikorn API if it failed, 0 otherwise.",
+	}, []string{"cluster", "category"})
+	syncLastSuccessTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "yhs",
+		Subsystem: "yunikorn_sync",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful sync with the Yunikorn API.",
+	}, []string{"cluster"})
+)
+
+// SyncStatus is the status of the syncs with the Yunikorn API.
+type SyncStatus struct {
+	// ConsecutiveFailures is the number of failed syncs since the last successful sync.
+	ConsecutiveFailures int
+	// Category is the category of the error of the last sync, empty if it succeeded.
+	Category ErrorCategory
+	// Error is the error of the last sync, empty if it succeeded.
+	Error string
+	// LastSuccess is the time of the last successful sync, zero if no sync succeeded yet.
+	LastSuccess time.Time
+}
+
+// SyncTracker tracks the consecutive failures of the syncs with the Yunikorn API and the category of their errors,
+// and exports them as metrics labeled with the cluster of the scheduler.
+type SyncTracker struct {
+	mu     sync.Mutex
+	status SyncStatus
+	// cluster is the ID of the cluster of the scheduler, empty for the default cluster.
+	cluster string
+}
+
+func NewSyncTracker(clusterID string) *SyncTracker {
+	for _, category := range ErrorCategories {
+		syncFailuresTotal.WithLabelValues(clusterID, string(category))
+		syncLastFailureCategory.WithLabelValues(clusterID, string(category))
+	}
+	return &SyncTracker{cluster: clusterID}
+}
+
+// RecordSuccess records a successful sync, which resets the consecutive failures.
+func (t *SyncTracker) RecordSuccess(at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status = SyncStatus{LastSuccess: at}
+	syncConsecutiveFailures.WithLabelValues(t.cluster).Set(0)
+	t.setLastFailureCategory("")
+	syncLastSuccessTimestamp.WithLabelValues(t.cluster).Set(float64(at.Unix()))
+}
+
+// RecordFailure records a failed sync and returns the category of its error.
+func (t *SyncTracker) RecordFailure(err error) ErrorCategory {
+	category := Categorize(err)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status.ConsecutiveFailures++
+	t.status.Category = category
+	t.status.Error = err.Error()
+	syncFailuresTotal.WithLabelValues(t.cluster, string(category)).Inc()
+	syncConsecutiveFailures.WithLabelValues(t.cluster).Set(float64(t.status.ConsecutiveFailures))
+	t.setLastFailureCategory(category)
+	return category
+}
+
+// Cluster returns the ID of the cluster of the scheduler, empty for the default cluster.
+func (t *SyncTracker) Cluster() string {
+	return t.cluster
+}
+
+// Status returns the status of the syncs.
+func (t *SyncTracker) Status() SyncStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status
+}
+
+// setLastFailureCategory sets the gauge of the category to 1 and the gauges of the other categories to 0.
+func (t *SyncTracker) setLastFailureCategory(category ErrorCategory) {
+	for _, c := range ErrorCategories {
+		value := 0.0
+		if c == category {
+			value = 1
+		}
+		syncLastFailureCategory.WithLabelValues(t.cluster, string(c)).Set(value)
+	}
+}
@@ -0,0 +1,60 @@
+package yunikorn
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncTracker(t *testing.T) {
+	tracker := NewSyncTracker("")
+	assert.Equal(t, SyncStatus{}, tracker.Status())
+
+	authFailures := testutil.ToFloat64(syncFailuresTotal.WithLabelValues("", string(ErrorCategoryAuth)))
+	networkFailures := testutil.ToFloat64(syncFailuresTotal.WithLabelValues("", string(ErrorCategoryNetwork)))
+
+	assert.Equal(t, ErrorCategoryAuth, tracker.RecordFailure(&StatusError{StatusCode: http.StatusUnauthorized}))
+	assert.Equal(t, ErrorCategoryNetwork, tracker.RecordFailure(&StatusError{StatusCode: http.StatusBadGateway}))
+	assert.Equal(t, SyncStatus{
+		ConsecutiveFailures: 2,
+		Category:            ErrorCategoryNetwork,
+		Error:               "yunicorn api returned non-OK status code: 502",
+	}, tracker.Status())
+	assert.Equal(t, authFailures+1, testutil.ToFloat64(syncFailuresTotal.WithLabelValues("", string(ErrorCategoryAuth))))
+	assert.Equal(t, networkFailures+1,
+		testutil.ToFloat64(syncFailuresTotal.WithLabelValues("", string(ErrorCategoryNetwork))))
+	assert.Equal(t, 2.0, testutil.ToFloat64(syncConsecutiveFailures.WithLabelValues("")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(syncLastFailureCategory.WithLabelValues("", string(ErrorCategoryNetwork))))
+	assert.Equal(t, 0.0, testutil.ToFloat64(syncLastFailureCategory.WithLabelValues("", string(ErrorCategoryAuth))))
+
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	tracker.RecordSuccess(now)
+	assert.Equal(t, SyncStatus{LastSuccess: now}, tracker.Status())
+	assert.Equal(t, 0.0, testutil.ToFloat64(syncConsecutiveFailures.WithLabelValues("")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(syncLastFailureCategory.WithLabelValues("", string(ErrorCategoryNetwork))))
+	assert.Equal(t, float64(now.Unix()), testutil.ToFloat64(syncLastSuccessTimestamp.WithLabelValues("")))
+
+	assert.Equal(t, ErrorCategoryOther, tracker.RecordFailure(errors.New("database error")))
+	assert.Equal(t, SyncStatus{
+		ConsecutiveFailures: 1,
+		Category:            ErrorCategoryOther,
+		Error:               "database error",
+		LastSuccess:         now,
+	}, tracker.Status())
+}
+
+func TestSyncTrackerClusters(t *testing.T) {
+	tracker := NewSyncTracker("eu-west")
+	other := NewSyncTracker("us-east")
+
+	tracker.RecordFailure(&StatusError{StatusCode: http.StatusBadGateway})
+	other.RecordSuccess(time.Now())
+	assert.Equal(t, 1.0, testutil.ToFloat64(syncConsecutiveFailures.WithLabelValues("eu-west")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(syncConsecutiveFailures.WithLabelValues("us-east")))
+	assert.Equal(t, 1, tracker.Status().ConsecutiveFailures)
+	assert.Zero(t, other.Status().ConsecutiveFailures)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
 
@@ -26,8 +27,23 @@ func (s *Service) ProcessEvents(ctx context.Context) error {
 			logger.Errorf("error closing event stream response body: %v", err)
 		}
 	}()
+	// the applications pending in catch-up mode are stored when the stream ends
+	defer s.flushApplications(ctx)
 
-	reader := bufio.NewReader(resp.Body)
+	// the events are read ahead into queues by priority, so that the events changing the state of applications are
+	// not held up behind the informational events while the event stream is backed up
+	queues := newEventQueues(eventQueueSize)
+	readErr := make(chan error, 1)
+	go func() {
+		defer queues.close()
+		readErr <- s.readEvents(ctx, bufio.NewReader(resp.Body), queues)
+	}()
+	s.processQueuedEvents(ctx, queues)
+	return <-readErr
+}
+
+// readEvents reads the events from the event stream and queues them, until the stream ends.
+func (s *Service) readEvents(ctx context.Context, reader *bufio.Reader, queues *eventQueues) error {
 	for {
 		response, err := reader.ReadBytes('\n')
 		if err != nil {
@@ -36,33 +52,72 @@ func (s *Service) ProcessEvents(ctx context.Context) error {
 			}
 			return err
 		}
-		if err := s.processStreamResponse(ctx, response); err != nil {
+		ev, err := s.decodeStreamResponse(ctx, response)
+		if err != nil {
 			return fmt.Errorf("error processing stream response: %w", err)
 		}
+		if ev == nil {
+			continue
+		}
+		if err := queues.push(ctx, ev); err != nil {
+			return err
+		}
 	}
 }
 
 func (s *Service) processStreamResponse(ctx context.Context, response []byte) error {
+	ev, err := s.decodeStreamResponse(ctx, response)
+	if err != nil {
+		return err
+	}
+	if ev != nil {
+		s.processEvent(ctx, ev)
+	}
+	return nil
+}
+
+// decodeStreamResponse decodes the event of the response of the event stream. It returns nil if there is no event,
+// or the event is dropped.
+func (s *Service) decodeStreamResponse(ctx context.Context, response []byte) (*si.EventRecord, error) {
 	logger := log.FromContext(ctx)
 
 	if len(response) == 0 {
 		logger.Warn("empty response from yunikorn event stream")
-		return nil
+		return nil, nil
 	}
 
 	var eventRecord si.EventRecord
 	if err := json.Unmarshal(response, &eventRecord); err != nil {
-		return fmt.Errorf("could not unmarshal event from stream: %w", err)
+		return nil, fmt.Errorf("could not unmarshal event from stream: %w", err)
+	}
+	if s.faults.DropEvent() {
+		logger.Warnw("dropping event because of an injected fault", "objectId", eventRecord.GetObjectID())
+		return nil, nil
 	}
-	// TODO: This is Okayish for small number of events, but for large number of events this will be a bottleneck
-	// We should consider using a channel? or a pool of workers? or a different queuing system ? to handle events.
-	if err := s.eventHandler(ctx, &eventRecord); err != nil {
+	if s.transformer.DropEvent(&eventRecord) {
+		logger.Debugw("dropping event matching a transformation rule", "objectId", eventRecord.GetObjectID())
+		return nil, nil
+	}
+	return &eventRecord, nil
+}
+
+// processEvent handles and records the event.
+func (s *Service) processEvent(ctx context.Context, eventRecord *si.EventRecord) {
+	logger := log.FromContext(ctx)
+	defer observeProcessedEvent(eventRecord, time.Now())
+
+	s.observeLag(ctx, eventRecord)
+	partition, queue := s.eventScope(eventRecord)
+	if err := s.eventHandler(ctx, eventRecord); err != nil {
 		logger.Errorf("error handling event: %v", err)
 	}
+	// the handler sees the original event, so that it can look up the application or queue it refers to
+	s.transformer.Event(eventRecord)
 
-	if err := s.eventRepository.Record(ctx, &eventRecord); err != nil {
+	if err := s.eventRepository.Record(ctx, eventRecord); err != nil {
 		logger.Errorf("error recording event: %v", err)
 	}
+	s.publishEvent(eventRecord, partition, queue)
 
 	logger.Infow(
 		"received event from yunikorn event stream",
@@ -74,6 +129,4 @@ func (s *Service) processStreamResponse(ctx context.Context, response []byte) er
 		"reference_id", eventRecord.GetReferenceID(),
 		"resource", eventRecord.GetResource(),
 	)
-
-	return nil
 }
@@ -12,12 +12,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/G-Research/yunikorn-history-server/internal/config"
 	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+	"github.com/G-Research/yunikorn-history-server/internal/transform"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/apache/yunikorn-scheduler-interface/lib/go/si"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFetchEventStream(t *testing.T) {
@@ -78,7 +82,7 @@ func TestFetchEventStream(t *testing.T) {
 	}()
 
 	assert.Eventually(t, func() bool {
-		eventCounts, err := service.eventRepository.Counts(ctx)
+		eventCounts, err := eventRepository.Counts(ctx)
 		if err != nil {
 			t.Fatalf("error getting event counts: %v", err)
 		}
@@ -156,8 +160,9 @@ func TestProcessStreamResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			eventRepository := repository.NewInMemoryEventRepository()
 			service := &Service{
-				eventRepository: repository.NewInMemoryEventRepository(),
+				eventRepository: eventRepository,
 				eventHandler:    noopEventHandler,
 			}
 
@@ -170,7 +175,7 @@ func TestProcessStreamResponse(t *testing.T) {
 					t.Errorf("expected no error; got '%v'", err)
 				}
 
-				eventCounts, err := service.eventRepository.Counts(context.Background())
+				eventCounts, err := eventRepository.Counts(context.Background())
 				if err != nil {
 					t.Fatalf("error getting event counts: %v", err)
 				}
@@ -181,6 +186,63 @@ func TestProcessStreamResponse(t *testing.T) {
 	}
 }
 
+func TestProcessStreamResponseDropsEvents(t *testing.T) {
+	injector := faultinject.New()
+	require.NoError(t, injector.SetFaults(faultinject.Faults{DropEventRate: 1}))
+	handled := 0
+	eventRepository := repository.NewInMemoryEventRepository()
+	service := &Service{
+		eventRepository: eventRepository,
+		eventHandler: func(context.Context, *si.EventRecord) error {
+			handled++
+			return nil
+		},
+		faults: injector,
+	}
+
+	require.NoError(t, service.processStreamResponse(context.Background(), []byte(`{"type": 2, "eventChangeType": 2}`+"\n")))
+	assert.Zero(t, handled)
+	eventCounts, err := eventRepository.Counts(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, eventCounts)
+}
+
+type recordedEvents []*si.EventRecord
+
+func (r *recordedEvents) Record(_ context.Context, event *si.EventRecord) error {
+	*r = append(*r, event)
+	return nil
+}
+
+func TestProcessStreamResponseTransformsEvents(t *testing.T) {
+	transformer, err := transform.New(&config.TransformConfig{
+		Drop: []config.EventSelector{{Type: "NODE"}},
+		Redact: []config.Redaction{
+			{Fields: []string{config.RedactFieldMessage}, Pattern: `[^@\s]+@[^@\s]+`, Replacement: "[REDACTED]"},
+		},
+	})
+	require.NoError(t, err)
+	var handled []string
+	var recorded recordedEvents
+	service := &Service{
+		eventRepository: &recorded,
+		eventHandler: func(_ context.Context, event *si.EventRecord) error {
+			handled = append(handled, event.GetMessage())
+			return nil
+		},
+		transformer: transformer,
+	}
+
+	ctx := context.Background()
+	require.NoError(t, service.processStreamResponse(ctx, []byte(`{"type": 3, "objectID": "node-1"}`+"\n")))
+	require.NoError(t, service.processStreamResponse(ctx, []byte(`{"type": 2, "message": "submitted by alice@example.com"}`+"\n")))
+
+	// the handler sees the original event, the recorded event is redacted
+	assert.Equal(t, []string{"submitted by alice@example.com"}, handled)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "submitted by [REDACTED]", recorded[0].GetMessage())
+}
+
 func noopEventHandler(ctx context.Context, event *si.EventRecord) error {
 	return nil
 }
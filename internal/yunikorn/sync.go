@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/G-Research/yunikorn-history-server/internal/log"
 	"github.com/G-Research/yunikorn-history-server/internal/util"
@@ -17,7 +18,7 @@ import (
 func (s *Service) sync(ctx context.Context) error {
 	partitions, err := s.upsertPartitions(ctx)
 	if err != nil {
-		return fmt.Errorf("error getting and upserting partitions: %v", err)
+		return fmt.Errorf("error getting and upserting partitions: %w", err)
 	}
 
 	var mu sync.Mutex
@@ -29,46 +30,53 @@ func (s *Service) sync(ctx context.Context) error {
 	}
 
 	wg := sync.WaitGroup{}
-	wg.Add(4)
+	wg.Add(5)
 
 	go func() {
 		defer wg.Done()
 		queues, err := s.upsertPartitionQueues(ctx, partitions)
 		if err != nil {
-			addErr(fmt.Errorf("error getting and upserting queues: %v", err))
+			addErr(fmt.Errorf("error getting and upserting queues: %w", err))
 			return
 		}
 
 		if err = s.upsertApplications(ctx, queues); err != nil {
-			addErr(fmt.Errorf("error getting and upserting applications: %v", err))
+			addErr(fmt.Errorf("error getting and upserting applications: %w", err))
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
 		if err = s.upsertPartitionNodes(ctx, partitions); err != nil {
-			addErr(fmt.Errorf("error getting and upserting nodes: %v", err))
+			addErr(fmt.Errorf("error getting and upserting nodes: %w", err))
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
 		if err = s.upsertNodeUtilizations(ctx); err != nil {
-			addErr(fmt.Errorf("error getting and upserting node utilizations: %v", err))
+			addErr(fmt.Errorf("error getting and upserting node utilizations: %w", err))
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
 		if err = s.updateAppsHistory(ctx); err != nil {
-			addErr(fmt.Errorf("error updating apps history: %v", err))
+			addErr(fmt.Errorf("error updating apps history: %w", err))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err = s.updateQueueACLs(ctx); err != nil {
+			addErr(fmt.Errorf("error updating queue ACLs: %w", err))
 		}
 	}()
 
 	wg.Wait()
 
 	if len(allErrs) > 0 {
-		return fmt.Errorf("some errors encountered while syncing data: %v", allErrs)
+		return wrapErrors("some errors encountered while syncing data", allErrs)
 	}
 
 	return nil
@@ -80,7 +88,7 @@ func (s *Service) upsertPartitions(ctx context.Context) ([]*dao.PartitionInfo, e
 	// Get partitions from Yunikorn API and upsert into DB
 	partitions, err := s.client.GetPartitions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not get partitions: %v", err)
+		return nil, fmt.Errorf("could not get partitions: %w", err)
 	}
 
 	err = s.workqueue.Add(func(ctx context.Context) error {
@@ -114,7 +122,7 @@ func (s *Service) upsertPartitionQueues(ctx context.Context, partitions []*dao.P
 		mutex.Lock()
 		defer mutex.Unlock()
 		if err != nil {
-			errs = append(errs, fmt.Errorf("could not get queues for partition %s: %v", p.Name, err))
+			errs = append(errs, fmt.Errorf("could not get queues for partition %s: %w", p.Name, err))
 		} else {
 			queues = append(queues, queue)
 		}
@@ -128,7 +136,7 @@ func (s *Service) upsertPartitionQueues(ctx context.Context, partitions []*dao.P
 	wg.Wait()
 
 	if len(errs) > 0 {
-		return nil, fmt.Errorf("failed to get queues for some partitions: %v", errs)
+		return nil, wrapErrors("failed to get queues for some partitions", errs)
 	}
 
 	queues = flattenQueues(queues)
@@ -179,7 +187,7 @@ func (s *Service) upsertPartitionNodes(ctx context.Context, partitions []*dao.Pa
 		nodes, err := s.client.GetPartitionNodes(ctx, p.Name)
 		if err != nil {
 			mutex.Lock()
-			errs = append(errs, fmt.Errorf("could not get nodes for partition %s: %v", p.Name, err))
+			errs = append(errs, fmt.Errorf("could not get nodes for partition %s: %w", p.Name, err))
 			mutex.Unlock()
 			return
 		}
@@ -200,7 +208,7 @@ func (s *Service) upsertPartitionNodes(ctx context.Context, partitions []*dao.Pa
 	wg.Wait()
 
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to get nodes for some partitions: %v", errs)
+		return wrapErrors("failed to get nodes for some partitions", errs)
 	}
 
 	return nil
@@ -227,7 +235,7 @@ func (s *Service) upsertApplications(ctx context.Context, queues []*dao.Partitio
 			mutex.Lock()
 			errs = append(
 				errs,
-				fmt.Errorf("could not get applications for partition %s, queue %s: %v", q.Partition, q.QueueName, err),
+				fmt.Errorf("could not get applications for partition %s, queue %s: %w", q.Partition, q.QueueName, err),
 			)
 			mutex.Unlock()
 		} else {
@@ -246,7 +254,7 @@ func (s *Service) upsertApplications(ctx context.Context, queues []*dao.Partitio
 	wg.Wait()
 
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to get applications for some queues: %v", errs)
+		return wrapErrors("failed to get applications for some queues", errs)
 	}
 
 	err := s.workqueue.Add(func(ctx context.Context) error {
@@ -266,7 +274,7 @@ func (s *Service) upsertNodeUtilizations(ctx context.Context) error {
 
 	nus, err := s.client.GetNodeUtil(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get node utilizations: %v", err)
+		return fmt.Errorf("could not get node utilizations: %w", err)
 	}
 
 	err = s.workqueue.Add(func(ctx context.Context) error {
@@ -286,11 +294,11 @@ func (s *Service) updateAppsHistory(ctx context.Context) error {
 
 	appsHistory, err := s.client.GetAppsHistory(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get apps history: %v", err)
+		return fmt.Errorf("could not get apps history: %w", err)
 	}
 	containersHistory, err := s.client.GetContainersHistory(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get containers history: %v", err)
+		return fmt.Errorf("could not get containers history: %w", err)
 	}
 
 	err = s.workqueue.Add(func(ctx context.Context) error {
@@ -303,3 +311,28 @@ func (s *Service) updateAppsHistory(ctx context.Context) error {
 
 	return nil
 }
+
+// updateQueueACLs fetches the scheduler configuration and records the access control lists of its queues
+func (s *Service) updateQueueACLs(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	schedulerConfig, err := s.client.GetSchedulerConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get scheduler config: %w", err)
+	}
+	if schedulerConfig == nil || schedulerConfig.SchedulerConfig == nil {
+		return fmt.Errorf("scheduler config is empty")
+	}
+	acls := queueACLs(schedulerConfig.SchedulerConfig)
+	at := time.Now()
+
+	err = s.workqueue.Add(func(ctx context.Context) error {
+		logger.Infow("updating queue ACLs", "count", len(acls))
+		return s.repo.UpdateQueueACLs(ctx, acls, at)
+	}, workqueue.WithJobName("update_queue_acls"))
+	if err != nil {
+		logger.Errorf("could not add update queue ACLs job to workqueue: %v", err)
+	}
+
+	return nil
+}
@@ -25,13 +25,7 @@ func TestClient_sync_Integration(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
 
-	schema := database.CreateTestSchema(ctx, t)
-	t.Cleanup(func() {
-		database.DropTestSchema(ctx, t, schema)
-	})
-
-	cfg := config.GetTestPostgresConfig()
-	cfg.Schema = schema
+	cfg := database.CreateTestSchema(ctx, t)
 	m, err := migrations.New(cfg, "../../migrations")
 	if err != nil {
 		t.Fatalf("error creating migrator: %v", err)
@@ -0,0 +1,42 @@
+package yunikorn
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+
+	"github.com/G-Research/yunikorn-history-server/internal/model"
+	"github.com/G-Research/yunikorn-history-server/internal/transform"
+)
+
+// transformingRepository applies the transformation rules to the queues, applications and queue ACLs before they
+// are written to the wrapped repository. The rules are applied to copies, as the sync reuses the data it wrote.
+type transformingRepository struct {
+	Repository
+	transformer *transform.Transformer
+}
+
+func (r *transformingRepository) UpsertQueues(ctx context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+	transformed := make([]*dao.PartitionQueueDAOInfo, len(queues))
+	for i, q := range queues {
+		transformed[i] = r.transformer.Queue(q)
+	}
+	return r.Repository.UpsertQueues(ctx, transformed)
+}
+
+func (r *transformingRepository) UpsertApplications(ctx context.Context, apps []*dao.ApplicationDAOInfo) error {
+	transformed := make([]*dao.ApplicationDAOInfo, len(apps))
+	for i, app := range apps {
+		transformed[i] = r.transformer.Application(app)
+	}
+	return r.Repository.UpsertApplications(ctx, transformed)
+}
+
+func (r *transformingRepository) UpdateQueueACLs(ctx context.Context, acls []*model.QueueACL, at time.Time) error {
+	transformed := make([]*model.QueueACL, len(acls))
+	for i, acl := range acls {
+		transformed[i] = r.transformer.QueueACL(acl)
+	}
+	return r.Repository.UpdateQueueACLs(ctx, transformed, at)
+}
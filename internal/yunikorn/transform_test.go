@@ -0,0 +1,49 @@
+package yunikorn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/yunikorn-core/pkg/webservice/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/transform"
+)
+
+func TestTransformingRepository(t *testing.T) {
+	transformer, err := transform.New(&config.TransformConfig{
+		Redact:        []config.Redaction{{Fields: []string{config.RedactFieldUser}, Replacement: "[REDACTED]"}},
+		QueueMappings: []config.QueueMapping{{From: `root\.legacy\.(.*)`, To: "root.$1"}},
+	})
+	require.NoError(t, err)
+	mockRepository := repository.NewMockRepository(gomock.NewController(t))
+	service := NewService(mockRepository, nil, nil, WithTransformer(transformer))
+
+	mockRepository.EXPECT().UpsertApplications(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, apps []*dao.ApplicationDAOInfo) error {
+			require.Len(t, apps, 1)
+			assert.Equal(t, "[REDACTED]", apps[0].User)
+			assert.Equal(t, "root.batch", apps[0].QueueName)
+			return nil
+		},
+	)
+	mockRepository.EXPECT().UpsertQueues(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, queues []*dao.PartitionQueueDAOInfo) error {
+			require.Len(t, queues, 1)
+			assert.Equal(t, "root.batch", queues[0].QueueName)
+			return nil
+		},
+	)
+
+	app := &dao.ApplicationDAOInfo{ApplicationID: "app-1", User: "alice", QueueName: "root.legacy.batch"}
+	require.NoError(t, service.repo.UpsertApplications(context.Background(), []*dao.ApplicationDAOInfo{app}))
+	queue := &dao.PartitionQueueDAOInfo{QueueName: "root.legacy.batch"}
+	require.NoError(t, service.repo.UpsertQueues(context.Background(), []*dao.PartitionQueueDAOInfo{queue}))
+	// the sync keeps using the original data
+	assert.Equal(t, "alice", app.User)
+	assert.Equal(t, "root.legacy.batch", queue.QueueName)
+}
@@ -0,0 +1,19 @@
+-- Create legal_holds table
+-- Holds are never deleted, releasing a hold records who released it and why, so that holds can be audited.
+CREATE TABLE legal_holds(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    partition TEXT NOT NULL CHECK (partition <> ''),
+    queue_name TEXT,
+    app_id TEXT,
+    reason TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    released_by TEXT,
+    released_at BIGINT,
+    release_reason TEXT,
+    CHECK (queue_name IS NOT NULL OR app_id IS NOT NULL),
+    PRIMARY KEY (id)
+);
+
+-- Create index on active legal holds which are checked when pruning applications
+CREATE INDEX idx_legal_holds_active ON legal_holds (partition) WHERE released_at IS NULL;
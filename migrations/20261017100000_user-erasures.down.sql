@@ -0,0 +1,5 @@
+-- Drop index on application users
+DROP INDEX IF EXISTS idx_applications_user;
+
+-- Drop user_erasures table
+DROP TABLE IF EXISTS user_erasures;
@@ -0,0 +1,22 @@
+-- Create user_erasures table
+-- The erased user is only stored as a hash, so that an interrupted erasure can be resumed without keeping the user name.
+CREATE TABLE user_erasures(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    subject_hash TEXT NOT NULL,
+    pseudonym TEXT NOT NULL,
+    mode TEXT NOT NULL CHECK (mode IN ('pseudonymize', 'delete')),
+    status TEXT NOT NULL CHECK (status IN ('in_progress', 'completed')),
+    requested_by TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    completed_at BIGINT,
+    applications BIGINT NOT NULL DEFAULT 0,
+    applications_retained BIGINT NOT NULL DEFAULT 0,
+    audit_records BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+);
+
+-- Create unique index which allows at most one erasure in progress per user
+CREATE UNIQUE INDEX idx_user_erasures_in_progress ON user_erasures (subject_hash) WHERE status = 'in_progress';
+
+-- Create index on application users which are looked up when erasing a user
+CREATE INDEX idx_applications_user ON applications ("user");
@@ -0,0 +1,5 @@
+-- Drop index on Spark application IDs
+DROP INDEX IF EXISTS idx_applications_spark_app_id;
+
+-- Drop Spark application ID of applications
+ALTER TABLE applications DROP COLUMN IF EXISTS spark_app_id;
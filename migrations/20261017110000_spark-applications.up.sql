@@ -0,0 +1,6 @@
+-- Add the Spark application ID of applications run by Spark, taken from the allocation tags of their pods,
+-- so that applications can be correlated with the Spark History Server.
+ALTER TABLE applications ADD COLUMN spark_app_id TEXT;
+
+-- Create index on Spark application IDs which are looked up when pivoting from the Spark History Server
+CREATE INDEX idx_applications_spark_app_id ON applications (spark_app_id) WHERE spark_app_id IS NOT NULL;
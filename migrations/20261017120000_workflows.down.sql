@@ -0,0 +1,5 @@
+-- Drop index on workflow IDs
+DROP INDEX IF EXISTS idx_applications_workflow_id;
+
+-- Drop workflow ID of applications
+ALTER TABLE applications DROP COLUMN IF EXISTS workflow_id;
@@ -0,0 +1,6 @@
+-- Add the workflow ID of applications caused by a workflow run, such as an Airflow DAG run or an Argo workflow,
+-- taken from the allocation tags of their pods.
+ALTER TABLE applications ADD COLUMN workflow_id TEXT;
+
+-- Create index on workflow IDs which are looked up to list the applications of a workflow run
+CREATE INDEX idx_applications_workflow_id ON applications (workflow_id) WHERE workflow_id IS NOT NULL;
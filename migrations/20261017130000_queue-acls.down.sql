@@ -0,0 +1,2 @@
+-- Drop queue_acls table
+DROP TABLE IF EXISTS queue_acls;
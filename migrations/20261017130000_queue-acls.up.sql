@@ -0,0 +1,23 @@
+-- Create queue_acls table
+-- Every row is a snapshot of the access control lists of a queue, valid from valid_from until valid_to,
+-- which is NULL for the snapshot of the current scheduler configuration.
+CREATE TABLE queue_acls(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    partition TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    submit_acl TEXT NOT NULL,
+    admin_acl TEXT NOT NULL,
+    submit_users TEXT[] NOT NULL,
+    submit_groups TEXT[] NOT NULL,
+    admin_users TEXT[] NOT NULL,
+    admin_groups TEXT[] NOT NULL,
+    valid_from BIGINT NOT NULL,
+    valid_to BIGINT,
+    PRIMARY KEY (id)
+);
+
+-- Create unique index which allows at most one current snapshot per queue
+CREATE UNIQUE INDEX idx_queue_acls_current ON queue_acls (partition, queue_name) WHERE valid_to IS NULL;
+
+-- Create index on the queues and validity of the snapshots which are looked up for audits
+CREATE INDEX idx_queue_acls_queue ON queue_acls (partition, queue_name, valid_from);
@@ -0,0 +1,2 @@
+-- Drop queue_throughput table
+DROP TABLE IF EXISTS queue_throughput;
@@ -0,0 +1,43 @@
+-- Create queue_throughput table
+-- Every row counts the applications of a queue which started running, completed or failed during an hour,
+-- starting at bucket_start in Unix nanoseconds. The counters are incremented when the applications are stored,
+-- so that throughput is not computed from the applications table, and are kept when applications are pruned.
+CREATE TABLE queue_throughput(
+    partition TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    bucket_start BIGINT NOT NULL,
+    started INTEGER NOT NULL DEFAULT 0,
+    completed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (partition, queue_name, bucket_start)
+);
+
+-- Create index on the buckets which are looked up for the throughput of all queues
+CREATE INDEX idx_queue_throughput_bucket_start ON queue_throughput (bucket_start);
+
+-- Count the applications stored before the counters were maintained. An application reached a state at the time
+-- of the first entry of its state log in that state, or if it is in that state without such an entry,
+-- at its finished time or now.
+WITH milestones AS (
+    SELECT a.partition, a.queue_name, m.milestone,
+        COALESCE(
+            (SELECT MIN((e->>'time')::BIGINT)
+                FROM jsonb_array_elements(CASE WHEN jsonb_typeof(a.state_log) = 'array' THEN a.state_log ELSE '[]' END) e
+                WHERE regexp_replace(upper(e->>'applicationState'), '^APP_', '') = m.state
+                    AND (e->>'time')::BIGINT > 0),
+            CASE WHEN regexp_replace(upper(a.state), '^APP_', '') = m.state THEN
+                COALESCE(CASE WHEN m.milestone <> 'started' AND a.finished_time > 0 THEN a.finished_time END,
+                    (EXTRACT(EPOCH FROM now()) * 1e9)::BIGINT)
+            END
+        ) AS reached_at
+    FROM applications a
+    CROSS JOIN (VALUES ('started', 'RUNNING'), ('completed', 'COMPLETED'), ('failed', 'FAILED')) AS m(milestone, state)
+)
+INSERT INTO queue_throughput (partition, queue_name, bucket_start, started, completed, failed)
+SELECT partition, queue_name, (reached_at / 3600000000000) * 3600000000000,
+    COUNT(*) FILTER (WHERE milestone = 'started'),
+    COUNT(*) FILTER (WHERE milestone = 'completed'),
+    COUNT(*) FILTER (WHERE milestone = 'failed')
+FROM milestones
+WHERE reached_at IS NOT NULL
+GROUP BY 1, 2, 3;
@@ -0,0 +1,5 @@
+-- Drop usage_observed_at column
+ALTER TABLE applications DROP COLUMN IF EXISTS usage_observed_at;
+
+-- Drop usage_rollups table
+DROP TABLE IF EXISTS usage_rollups;
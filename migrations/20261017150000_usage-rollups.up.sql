@@ -0,0 +1,39 @@
+-- Create usage_rollups table
+-- Every row holds the resources used by an application during an hour, starting at bucket_start in Unix
+-- nanoseconds, and the time it waited to start running if it started during that hour. The rollups are
+-- accumulated when the applications are stored, so that reports do not scan the applications table, and are
+-- kept when applications are pruned. The user is stored like in the applications table, encrypted if enabled.
+CREATE TABLE usage_rollups(
+    partition TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    app_id TEXT NOT NULL,
+    "user" TEXT,
+    bucket_start BIGINT NOT NULL,
+    vcore_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    memory_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    wait_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    PRIMARY KEY (partition, queue_name, app_id, bucket_start)
+);
+
+-- Create index on the buckets which are looked up for the reports of a period
+CREATE INDEX idx_usage_rollups_bucket_start ON usage_rollups (bucket_start);
+
+-- Record when the used resources of an application were last accumulated
+ALTER TABLE applications ADD COLUMN usage_observed_at BIGINT;
+
+-- Accumulate the wait times of the applications stored before the rollups were maintained. Used resources
+-- cannot be accumulated in retrospect, as only the current ones are stored.
+WITH started AS (
+    SELECT a.partition, a.queue_name, a.app_id, a."user", a.submission_time,
+        (SELECT MIN((e->>'time')::BIGINT)
+            FROM jsonb_array_elements(CASE WHEN jsonb_typeof(a.state_log) = 'array' THEN a.state_log ELSE '[]' END) e
+            WHERE regexp_replace(upper(e->>'applicationState'), '^APP_', '') = 'RUNNING'
+                AND (e->>'time')::BIGINT > 0) AS started_at
+    FROM applications a
+    WHERE a.submission_time > 0
+)
+INSERT INTO usage_rollups (partition, queue_name, app_id, "user", bucket_start, wait_seconds)
+SELECT partition, queue_name, app_id, "user", (started_at / 3600000000000) * 3600000000000,
+    (started_at - submission_time) / 1e9
+FROM started
+WHERE started_at >= submission_time;
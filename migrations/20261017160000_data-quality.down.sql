@@ -0,0 +1,5 @@
+-- Drop data_quality_violations table
+DROP TABLE IF EXISTS data_quality_violations;
+
+-- Drop data_quality_checks table
+DROP TABLE IF EXISTS data_quality_checks;
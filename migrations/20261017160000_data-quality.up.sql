@@ -0,0 +1,26 @@
+-- Create data_quality_checks table
+-- Every row holds the result of the last run of a data quality check. checked_at is in Unix seconds.
+CREATE TABLE data_quality_checks(
+    check_name TEXT NOT NULL,
+    violations BIGINT NOT NULL,
+    checked_at BIGINT NOT NULL,
+    PRIMARY KEY (check_name)
+);
+
+-- Create data_quality_violations table
+-- Every row is a record violating an invariant in the last run of a check. The violations of a check are
+-- replaced whenever it runs, and only the first violations are stored if there are too many.
+CREATE TABLE data_quality_violations(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    check_name TEXT NOT NULL,
+    partition TEXT,
+    queue_name TEXT,
+    app_id TEXT,
+    node_id TEXT,
+    detail TEXT NOT NULL,
+    detected_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+-- Create index on checks whose violations are replaced and listed
+CREATE INDEX idx_data_quality_violations_check_name ON data_quality_violations (check_name);
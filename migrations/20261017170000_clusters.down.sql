@@ -0,0 +1,2 @@
+-- Drop clusters table
+DROP TABLE IF EXISTS clusters;
@@ -0,0 +1,12 @@
+-- Create clusters table
+-- Every row is a cluster registered by its ingestion agent. registered_at, last_seen and ingested_until are in
+-- Unix seconds; ingested_until is the time up to which the agent ingested the data of the cluster, if reported.
+CREATE TABLE clusters(
+    name TEXT NOT NULL,
+    scheduler_version TEXT NOT NULL,
+    labels JSONB NOT NULL,
+    registered_at BIGINT NOT NULL,
+    last_seen BIGINT NOT NULL,
+    ingested_until BIGINT,
+    PRIMARY KEY (name)
+);
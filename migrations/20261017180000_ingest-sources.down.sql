@@ -0,0 +1,2 @@
+-- Drop ingest_sources table
+DROP TABLE IF EXISTS ingest_sources;
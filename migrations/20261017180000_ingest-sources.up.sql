@@ -0,0 +1,9 @@
+-- Create ingest_sources table
+-- Every row is a collector forwarding batches to the server, identified by the source ID it generated. sequence is
+-- the sequence number of the last batch applied for the source, and acked_at the Unix time in seconds it was applied.
+CREATE TABLE ingest_sources(
+    source TEXT NOT NULL,
+    sequence BIGINT NOT NULL,
+    acked_at BIGINT NOT NULL,
+    PRIMARY KEY (source)
+);
@@ -0,0 +1,2 @@
+-- Drop namespace_queues table
+DROP TABLE IF EXISTS namespace_queues;
@@ -0,0 +1,19 @@
+-- Create namespace_queues table
+-- Every row is a queue the applications of a Kubernetes namespace were placed in, by the placement rules of the
+-- scheduler configuration or by the admission controller. first_submission_time and last_submission_time are the
+-- submission times in nanoseconds of the first and last application of the namespace seen in the queue, so that
+-- applications can be attributed to the namespaces of teams after the mappings changed.
+CREATE TABLE namespace_queues(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    partition TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    first_submission_time BIGINT NOT NULL,
+    last_submission_time BIGINT NOT NULL,
+    applications BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (partition, namespace, queue_name)
+);
+
+-- Create index on the queues, which are looked up to attribute their applications to namespaces
+CREATE INDEX idx_namespace_queues_queue ON namespace_queues (partition, queue_name);
@@ -0,0 +1,7 @@
+-- Drop index on the queues and priorities of applications
+DROP INDEX IF EXISTS idx_applications_priority;
+
+-- Drop priority, priority class and wait time of applications
+ALTER TABLE applications DROP COLUMN IF EXISTS wait_time;
+ALTER TABLE applications DROP COLUMN IF EXISTS priority_class;
+ALTER TABLE applications DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,8 @@
+-- Add the priority and priority class of applications, and the time in nanoseconds they waited from their submission
+-- until they started running, so that the wait times of the priorities of a queue can be compared.
+ALTER TABLE applications ADD COLUMN priority INTEGER;
+ALTER TABLE applications ADD COLUMN priority_class TEXT;
+ALTER TABLE applications ADD COLUMN wait_time BIGINT;
+
+-- Create index on the queues and priorities of applications, which are grouped to compare their wait times
+CREATE INDEX idx_applications_priority ON applications (partition, queue_name, priority);
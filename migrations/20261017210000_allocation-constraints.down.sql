@@ -0,0 +1,2 @@
+-- Drop allocation_constraints table
+DROP TABLE IF EXISTS allocation_constraints;
@@ -0,0 +1,20 @@
+-- Create allocation_constraints table
+-- Every row is the placement of an allocation of an application: the constraints of its request, captured while it
+-- was pending, and the node it was placed on, so that the inputs of the placement are kept once the allocation is
+-- released. The rows are deleted with their application.
+CREATE TABLE allocation_constraints(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+    allocation_key TEXT NOT NULL,
+    task_group_name TEXT,
+    placeholder BOOLEAN NOT NULL DEFAULT FALSE,
+    required_node_id TEXT,
+    node_selector JSONB,
+    affinity JSONB,
+    tolerations JSONB,
+    request_time BIGINT,
+    node_id TEXT,
+    allocation_time BIGINT,
+    PRIMARY KEY (id),
+    UNIQUE (application_id, allocation_key)
+);
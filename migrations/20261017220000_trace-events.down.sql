@@ -0,0 +1,2 @@
+-- Drop trace_events table
+DROP TABLE IF EXISTS trace_events;
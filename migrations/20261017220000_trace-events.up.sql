@@ -0,0 +1,23 @@
+-- Create trace_events table
+-- Every row is an event of the event stream of Yunikorn which refers to an application, stored as part of the
+-- decision trace of the application if tracing is enabled. The partition of the events is not known, so the trace
+-- of an application is identified by its ID. timestamp is the time of the event in nanoseconds.
+CREATE TABLE trace_events(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    app_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    object_id TEXT NOT NULL,
+    reference_id TEXT,
+    change_type TEXT NOT NULL,
+    change_detail TEXT NOT NULL,
+    message TEXT,
+    resource JSONB,
+    timestamp BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+-- Create index on the applications and times of the events, which are read and capped per application
+CREATE INDEX idx_trace_events_app_id ON trace_events (app_id, timestamp);
+
+-- Create index on the times of the events, which are deleted once they are too old
+CREATE INDEX idx_trace_events_timestamp ON trace_events (timestamp);
@@ -0,0 +1,2 @@
+-- Drop scheduler_epochs table
+DROP TABLE IF EXISTS scheduler_epochs;
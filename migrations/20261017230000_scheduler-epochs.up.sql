@@ -0,0 +1,16 @@
+-- Create scheduler_epochs table
+-- Every row is an epoch of Yunikorn: the lifetime of an instance of the scheduler, from its start until the start
+-- of the next instance, which is detected when the scheduler restarts. instance_id is the UUID of the instance, if
+-- Yunikorn exposes it. start_time and end_time are the start times of the instance and of the next instance, and
+-- detected_at is the time the instance was detected, all in nanoseconds. end_time is NULL for the current instance.
+CREATE TABLE scheduler_epochs(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    instance_id TEXT,
+    start_time BIGINT NOT NULL,
+    end_time BIGINT,
+    detected_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+-- Create index on the start times of the epochs, which are listed by time
+CREATE INDEX idx_scheduler_epochs_start_time ON scheduler_epochs (start_time);
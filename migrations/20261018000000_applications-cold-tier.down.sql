@@ -0,0 +1,10 @@
+-- Move the applications of the cold tier back to the applications table
+INSERT INTO applications SELECT * FROM applications_cold;
+
+-- Drop applications_cold table
+DROP TABLE IF EXISTS applications_cold;
+
+-- Delete the allocation constraints of deleted applications and restore their reference to the applications table
+DELETE FROM allocation_constraints c WHERE NOT EXISTS (SELECT 1 FROM applications a WHERE a.id = c.application_id);
+ALTER TABLE allocation_constraints ADD CONSTRAINT allocation_constraints_application_id_fkey
+    FOREIGN KEY (application_id) REFERENCES applications (id) ON DELETE CASCADE;
@@ -0,0 +1,22 @@
+-- Create applications_cold table
+-- The cold tier of the applications: the applications which finished before the cold tier boundary are moved from
+-- the applications table to this table, which has the same columns in the same order, so that rows can be moved
+-- with SELECT * and both tiers queried with UNION ALL. Columns added to the applications table must be added to
+-- this table too. The table has fewer indexes than the applications table, and its rows are compressed once they
+-- exceed 128 bytes instead of the default 2kB, which compresses the JSONB columns of most applications.
+CREATE TABLE applications_cold(
+    LIKE applications INCLUDING DEFAULTS,
+    PRIMARY KEY (id)
+) WITH (toast_tuple_target = 128);
+
+-- Create indexes on the applications of a queue, which are listed by submission time, and on the finished time,
+-- which the retention of finished applications deletes by
+CREATE INDEX idx_applications_cold_partition_queue_app_id ON applications_cold (partition, queue_name, app_id);
+CREATE INDEX idx_applications_cold_partition_queue_submission_time
+    ON applications_cold (partition, queue_name, submission_time);
+CREATE INDEX idx_applications_cold_finished_time ON applications_cold (finished_time);
+CREATE INDEX idx_applications_cold_user ON applications_cold ("user");
+
+-- The allocation constraints of an application are kept when it is moved to the cold tier, so they cannot reference
+-- the applications table anymore. They are deleted with their application by the repository instead.
+ALTER TABLE allocation_constraints DROP CONSTRAINT IF EXISTS allocation_constraints_application_id_fkey;
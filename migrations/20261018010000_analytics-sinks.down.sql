@@ -0,0 +1,10 @@
+-- Drop the triggers recording the changes of the applications
+DROP TRIGGER IF EXISTS applications_analytics_change ON applications;
+DROP TRIGGER IF EXISTS applications_cold_analytics_change ON applications_cold;
+DROP FUNCTION IF EXISTS record_analytics_change();
+
+-- Drop analytics_outbox table
+DROP TABLE IF EXISTS analytics_outbox;
+
+-- Drop analytics_sinks table
+DROP TABLE IF EXISTS analytics_sinks;
@@ -0,0 +1,45 @@
+-- Create analytics_sinks table
+-- Every row is an external store the applications are replicated to for analytics queries, such as ClickHouse.
+-- The existing applications are copied to a sink in the order of their IDs: backfill_cursor is the ID of the last
+-- copied application, and backfilled_at the time in nanoseconds the copy completed, NULL until then. created_at is
+-- the time in nanoseconds the sink was registered, from which on the changes of the applications are recorded.
+CREATE TABLE analytics_sinks(
+    name TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    backfill_cursor UUID,
+    backfilled_at BIGINT,
+    PRIMARY KEY (name)
+);
+
+-- Create analytics_outbox table
+-- Every row is a change of an application of either tier, recorded while a sink is registered, until the sink
+-- consumed it. The current row of the application is read when the change is consumed, so only the ID and the
+-- partition and queue of the application are recorded, which identify deleted applications in the sink.
+CREATE TABLE analytics_outbox(
+    seq BIGSERIAL NOT NULL,
+    application_id UUID NOT NULL,
+    partition TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    PRIMARY KEY (seq)
+);
+
+-- Record the changes of the applications in the outbox while a sink is registered
+CREATE FUNCTION record_analytics_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM analytics_sinks) THEN
+        IF TG_OP = 'DELETE' THEN
+            INSERT INTO analytics_outbox (application_id, partition, queue_name)
+                VALUES (OLD.id, OLD.partition, OLD.queue_name);
+        ELSE
+            INSERT INTO analytics_outbox (application_id, partition, queue_name)
+                VALUES (NEW.id, NEW.partition, NEW.queue_name);
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER applications_analytics_change AFTER INSERT OR UPDATE OR DELETE ON applications
+    FOR EACH ROW EXECUTE FUNCTION record_analytics_change();
+CREATE TRIGGER applications_cold_analytics_change AFTER INSERT OR UPDATE OR DELETE ON applications_cold
+    FOR EACH ROW EXECUTE FUNCTION record_analytics_change();
@@ -0,0 +1,2 @@
+-- Drop paused_operations table
+DROP TABLE IF EXISTS paused_operations;
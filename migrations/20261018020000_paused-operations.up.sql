@@ -0,0 +1,11 @@
+-- Create paused_operations table
+-- Every row is an operation paused by an administrator, the ingestion of the data of Yunikorn or a periodic job,
+-- which does not run until it is resumed and its row deleted. paused_at is the time in seconds the operation was
+-- paused, and paused_by the principal which paused it.
+CREATE TABLE paused_operations(
+    name TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    paused_by TEXT NOT NULL,
+    paused_at BIGINT NOT NULL,
+    PRIMARY KEY (name)
+);
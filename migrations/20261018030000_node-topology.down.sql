@@ -0,0 +1,3 @@
+-- Drop the zone and rack of nodes
+ALTER TABLE nodes DROP COLUMN IF EXISTS zone;
+ALTER TABLE nodes DROP COLUMN IF EXISTS rack;
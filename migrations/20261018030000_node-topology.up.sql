@@ -0,0 +1,7 @@
+-- Add the zone and rack of nodes, taken from their attributes when they are stored, so that the utilization and
+-- the placements of allocations can be broken down by topology. The zones of the stored nodes are taken from the
+-- default zone attribute, and their racks from the rack name reported by Yunikorn.
+ALTER TABLE nodes ADD COLUMN zone TEXT;
+ALTER TABLE nodes ADD COLUMN rack TEXT;
+
+UPDATE nodes SET zone = NULLIF(attributes->>'topology.kubernetes.io/zone', ''), rack = NULLIF(rack_name, '');
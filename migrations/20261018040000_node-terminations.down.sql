@@ -0,0 +1,2 @@
+-- Drop node_terminations table
+DROP TABLE IF EXISTS node_terminations;
@@ -0,0 +1,21 @@
+-- Create node_terminations table
+-- Every row is the removal of a node from Yunikorn, with the allocations it was running when it was last synced and
+-- its attributes, zone and rack at that time. terminated_at is the time in nanoseconds the node was removed, spot
+-- whether its attributes matched a spot selector, and drained whether it was unschedulable when it was removed,
+-- e.g. because it was cordoned before the termination.
+CREATE TABLE node_terminations(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    node_id TEXT NOT NULL,
+    partition TEXT NOT NULL,
+    terminated_at BIGINT NOT NULL,
+    spot BOOLEAN NOT NULL,
+    drained BOOLEAN NOT NULL,
+    zone TEXT,
+    rack TEXT,
+    attributes JSONB,
+    allocations JSONB,
+    PRIMARY KEY (id),
+    UNIQUE (node_id, terminated_at)
+);
+
+CREATE INDEX idx_node_terminations_terminated_at ON node_terminations (terminated_at);
@@ -0,0 +1,3 @@
+-- Drop autoscaler_events and pending_backlog tables
+DROP TABLE IF EXISTS autoscaler_events;
+DROP TABLE IF EXISTS pending_backlog;
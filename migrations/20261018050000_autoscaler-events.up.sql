@@ -0,0 +1,33 @@
+-- Create pending_backlog table
+-- Every row is a sample of the pending resources of a partition, those of its root queue, taken when the queues are
+-- synced, so that the backlog of the partitions can be put on a timeline. sampled_at is the time in nanoseconds of
+-- the sync.
+CREATE TABLE pending_backlog(
+    partition TEXT NOT NULL,
+    sampled_at BIGINT NOT NULL,
+    pending_resource JSONB,
+    PRIMARY KEY (partition, sampled_at)
+);
+
+CREATE INDEX idx_pending_backlog_sampled_at ON pending_backlog (sampled_at);
+
+-- Create autoscaler_events table
+-- Every row is an occurrence of a Kubernetes event of the cluster-autoscaler, such as the scale-up triggered by a
+-- pending pod. uid is the UID of the event, which occurs again with an increased count, and time the time in
+-- nanoseconds of the occurrence. direction classifies the reason of the event, e.g. scale_up or scale_down.
+CREATE TABLE autoscaler_events(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    uid TEXT NOT NULL,
+    time BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    direction TEXT NOT NULL,
+    object_kind TEXT,
+    object_namespace TEXT,
+    object_name TEXT,
+    message TEXT,
+    count INTEGER NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (uid, time)
+);
+
+CREATE INDEX idx_autoscaler_events_time ON autoscaler_events (time);
@@ -0,0 +1,5 @@
+-- Drop slo_evaluations table
+DROP TABLE IF EXISTS slo_evaluations;
+
+-- Drop queue_slos table
+DROP TABLE IF EXISTS queue_slos;
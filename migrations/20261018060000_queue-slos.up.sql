@@ -0,0 +1,31 @@
+-- Create queue_slos table
+-- Every row is a service level objective of a queue subtree, defined in the configuration file or via the API as
+-- given by source. At least the objective share of the applications submitted within the last window_length
+-- nanoseconds must start running within start_within nanoseconds of their submission. Empty partition and queue_name
+-- match all partitions and queues.
+CREATE TABLE queue_slos(
+    name TEXT NOT NULL,
+    source TEXT NOT NULL,
+    partition TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    objective DOUBLE PRECISION NOT NULL,
+    start_within BIGINT NOT NULL,
+    window_length BIGINT NOT NULL,
+    PRIMARY KEY (name)
+);
+
+-- Create slo_evaluations table
+-- Every row is the result of an evaluation of an SLO, which is deleted with the SLO. evaluated_at is in Unix seconds.
+-- compliance is the ratio of on_time to applications, NULL if no application was counted.
+CREATE TABLE slo_evaluations(
+    slo_name TEXT NOT NULL REFERENCES queue_slos (name) ON DELETE CASCADE,
+    evaluated_at BIGINT NOT NULL,
+    objective DOUBLE PRECISION NOT NULL,
+    applications BIGINT NOT NULL,
+    on_time BIGINT NOT NULL,
+    compliance DOUBLE PRECISION,
+    met BOOLEAN NOT NULL,
+    PRIMARY KEY (slo_name, evaluated_at)
+);
+
+CREATE INDEX idx_slo_evaluations_evaluated_at ON slo_evaluations (evaluated_at);
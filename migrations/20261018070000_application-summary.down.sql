@@ -0,0 +1,2 @@
+-- Drop application_summary table
+DROP TABLE IF EXISTS application_summary;
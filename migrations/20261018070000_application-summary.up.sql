@@ -0,0 +1,48 @@
+-- Create application_summary table
+-- Every row summarizes an application of either tier, keyed by the ID of the application, which is kept when it is
+-- moved to the cold tier. The lists of applications are served from this table, so that they neither read the JSONB
+-- columns of the allocations and requests of the applications nor union both tiers. The row is updated whenever the
+-- application is stored, and holds its final state once it reached a terminal state. duration is the time in
+-- nanoseconds from the submission until the application finished, vcore_seconds and memory_seconds the resources it
+-- used, accumulated like the usage rollups. The user and groups are stored like in the applications table.
+CREATE TABLE application_summary(
+    application_id UUID NOT NULL,
+    app_id TEXT NOT NULL,
+    partition TEXT NOT NULL,
+    queue_name TEXT NOT NULL,
+    "user" TEXT,
+    groups TEXT[],
+    state TEXT,
+    submission_time BIGINT,
+    finished_time BIGINT,
+    duration BIGINT,
+    max_used_resource JSONB,
+    vcore_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    memory_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    spark_app_id TEXT,
+    workflow_id TEXT,
+    PRIMARY KEY (application_id)
+);
+
+-- Create indexes on the applications of a queue, which are listed by submission time, and on the users and workflows
+-- the lists are filtered by
+CREATE UNIQUE INDEX idx_application_summary_partition_queue_app_id ON application_summary (partition, queue_name, app_id);
+CREATE INDEX idx_application_summary_partition_queue_submission_time
+    ON application_summary (partition, queue_name, submission_time);
+CREATE INDEX idx_application_summary_user ON application_summary ("user");
+CREATE INDEX idx_application_summary_workflow_id ON application_summary (workflow_id);
+
+-- Summarize the applications stored before the summaries were maintained
+INSERT INTO application_summary (application_id, app_id, partition, queue_name, "user", groups, state,
+    submission_time, finished_time, duration, max_used_resource, vcore_seconds, memory_seconds, spark_app_id,
+    workflow_id)
+SELECT a.id, a.app_id, a.partition, a.queue_name, a."user", a.groups, a.state, a.submission_time, a.finished_time,
+    CASE WHEN a.finished_time IS NOT NULL AND a.submission_time > 0 THEN a.finished_time - a.submission_time END,
+    a.max_used_resource, COALESCE(u.vcore_seconds, 0), COALESCE(u.memory_seconds, 0), a.spark_app_id, a.workflow_id
+FROM (SELECT * FROM applications UNION ALL SELECT * FROM applications_cold) a
+LEFT JOIN (
+    SELECT partition, queue_name, app_id, SUM(vcore_seconds) AS vcore_seconds, SUM(memory_seconds) AS memory_seconds
+    FROM usage_rollups
+    GROUP BY partition, queue_name, app_id
+) u ON u.partition = a.partition AND u.queue_name = a.queue_name AND u.app_id = a.app_id
+ON CONFLICT DO NOTHING;
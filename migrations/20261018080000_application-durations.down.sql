@@ -0,0 +1,12 @@
+-- Drop indexes on the durations and wait times of applications
+DROP INDEX IF EXISTS idx_application_summary_partition_queue_wait_time;
+DROP INDEX IF EXISTS idx_application_summary_partition_queue_duration;
+DROP INDEX IF EXISTS idx_applications_cold_partition_queue_wait_time;
+DROP INDEX IF EXISTS idx_applications_cold_partition_queue_duration;
+DROP INDEX IF EXISTS idx_applications_partition_queue_wait_time;
+DROP INDEX IF EXISTS idx_applications_partition_queue_duration;
+
+-- Drop wait time of application summaries and duration of applications
+ALTER TABLE application_summary DROP COLUMN IF EXISTS wait_time;
+ALTER TABLE applications_cold DROP COLUMN IF EXISTS duration;
+ALTER TABLE applications DROP COLUMN IF EXISTS duration;
@@ -0,0 +1,27 @@
+-- Add the duration of applications, the time in nanoseconds from their submission until they finished, so that
+-- applications can be filtered and ordered by their duration without computing it over every row of a queue.
+-- In the applications table it is generated from the timestamps, as the finished time is set by a later update.
+-- The rows of the cold tier are moved with SELECT *, which cannot insert into a generated column, so there it is
+-- a plain column copied with the rows, which are finished and whose timestamps do not change anymore.
+ALTER TABLE applications ADD COLUMN duration BIGINT GENERATED ALWAYS AS (
+    CASE WHEN finished_time IS NOT NULL AND submission_time > 0 THEN finished_time - submission_time END
+) STORED;
+ALTER TABLE applications_cold ADD COLUMN duration BIGINT;
+UPDATE applications_cold SET duration = finished_time - submission_time
+WHERE finished_time IS NOT NULL AND submission_time > 0;
+
+-- Add the wait time of applications to their summaries, which have a duration already
+ALTER TABLE application_summary ADD COLUMN wait_time BIGINT;
+UPDATE application_summary s SET wait_time = a.wait_time
+FROM (SELECT id, wait_time FROM applications UNION ALL SELECT id, wait_time FROM applications_cold) a
+WHERE a.id = s.application_id AND a.wait_time IS NOT NULL;
+
+-- Create indexes on the durations and wait times of the applications of a queue, which they are filtered and
+-- ordered by
+CREATE INDEX idx_applications_partition_queue_duration ON applications (partition, queue_name, duration);
+CREATE INDEX idx_applications_partition_queue_wait_time ON applications (partition, queue_name, wait_time);
+CREATE INDEX idx_applications_cold_partition_queue_duration ON applications_cold (partition, queue_name, duration);
+CREATE INDEX idx_applications_cold_partition_queue_wait_time ON applications_cold (partition, queue_name, wait_time);
+CREATE INDEX idx_application_summary_partition_queue_duration ON application_summary (partition, queue_name, duration);
+CREATE INDEX idx_application_summary_partition_queue_wait_time
+    ON application_summary (partition, queue_name, wait_time);
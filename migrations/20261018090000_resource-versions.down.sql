@@ -0,0 +1,3 @@
+-- Drop versions of the resources mutable via the API
+ALTER TABLE queue_slos DROP COLUMN IF EXISTS version;
+ALTER TABLE legal_holds DROP COLUMN IF EXISTS version;
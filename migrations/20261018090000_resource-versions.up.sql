@@ -0,0 +1,4 @@
+-- Add the versions of the resources mutable via the API, which are incremented by every change, so that a change
+-- conditional on the version a client read is refused if the resource was changed concurrently in the meantime
+ALTER TABLE legal_holds ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE queue_slos ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- Drop queue_lineage table
+DROP TABLE IF EXISTS queue_lineage;
@@ -0,0 +1,15 @@
+-- Create queue_lineage table
+-- A link records that a queue was renamed or moved from one path to another, so that the history of the applications
+-- recorded under the former path of the queue and of its child queues remains reachable under the current path.
+CREATE TABLE queue_lineage(
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    partition TEXT NOT NULL CHECK (partition <> ''),
+    from_queue TEXT NOT NULL CHECK (from_queue <> ''),
+    to_queue TEXT NOT NULL CHECK (to_queue <> ''),
+    reason TEXT,
+    created_by TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    CHECK (from_queue <> to_queue),
+    PRIMARY KEY (id),
+    UNIQUE (partition, from_queue)
+);
@@ -0,0 +1,2 @@
+-- Drop index on the timestamps of the history
+DROP INDEX IF EXISTS idx_history_timestamp;
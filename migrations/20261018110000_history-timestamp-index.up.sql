@@ -0,0 +1,2 @@
+-- Create an index on the timestamps of the history, which the retention deletes the expired entries by
+CREATE INDEX idx_history_timestamp ON history (timestamp);
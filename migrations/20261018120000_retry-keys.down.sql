@@ -0,0 +1,7 @@
+-- Drop indexes on retry keys
+DROP INDEX IF EXISTS idx_applications_cold_retry_key;
+DROP INDEX IF EXISTS idx_applications_retry_key;
+
+-- Drop retry key of applications
+ALTER TABLE applications_cold DROP COLUMN IF EXISTS retry_key;
+ALTER TABLE applications DROP COLUMN IF EXISTS retry_key;
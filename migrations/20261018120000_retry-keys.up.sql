@@ -0,0 +1,9 @@
+-- Add the retry key of applications, taken from the allocation tags of their pods, which is the same for every
+-- attempt of a job, e.g. the tries of an Airflow task instance. The column is added to both tiers in the same order,
+-- as the rows of the cold tier are moved with SELECT *.
+ALTER TABLE applications ADD COLUMN retry_key TEXT;
+ALTER TABLE applications_cold ADD COLUMN retry_key TEXT;
+
+-- Create indexes on retry keys which are looked up to link the attempts of a job into its retry chain
+CREATE INDEX idx_applications_retry_key ON applications (retry_key) WHERE retry_key IS NOT NULL;
+CREATE INDEX idx_applications_cold_retry_key ON applications_cold (retry_key) WHERE retry_key IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Drop the cluster of the records which do not belong to a partition
+ALTER TABLE history DROP COLUMN IF EXISTS cluster_id;
+ALTER TABLE scheduler_epochs DROP COLUMN IF EXISTS cluster_id;
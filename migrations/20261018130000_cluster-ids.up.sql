@@ -0,0 +1,4 @@
+-- Add the cluster of the records which do not belong to a partition, whose partitions are qualified with the ID of
+-- their cluster instead. The records of the default cluster have an empty cluster ID.
+ALTER TABLE scheduler_epochs ADD COLUMN cluster_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE history ADD COLUMN cluster_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- Drop the wait phases of applications
+ALTER TABLE applications_cold DROP COLUMN IF EXISTS wait_reason;
+ALTER TABLE applications_cold DROP COLUMN IF EXISTS allocation_wait;
+ALTER TABLE applications_cold DROP COLUMN IF EXISTS placeholder_wait;
+ALTER TABLE applications_cold DROP COLUMN IF EXISTS accept_wait;
+ALTER TABLE applications DROP COLUMN IF EXISTS wait_reason;
+ALTER TABLE applications DROP COLUMN IF EXISTS allocation_wait;
+ALTER TABLE applications DROP COLUMN IF EXISTS placeholder_wait;
+ALTER TABLE applications DROP COLUMN IF EXISTS accept_wait;
@@ -0,0 +1,13 @@
+-- Add the phases of the wait of applications until their first allocation, in nanoseconds: from their submission
+-- until they were accepted, from then until their first placeholder was allocated, and from then, or from their
+-- acceptance without placeholders, until their first allocation which is not a placeholder. The reason is the last
+-- reason the asks of an application were not allocated before its first allocation. The columns are added to both
+-- tiers in the same order, as the rows of the cold tier are moved with SELECT *.
+ALTER TABLE applications ADD COLUMN accept_wait BIGINT;
+ALTER TABLE applications ADD COLUMN placeholder_wait BIGINT;
+ALTER TABLE applications ADD COLUMN allocation_wait BIGINT;
+ALTER TABLE applications ADD COLUMN wait_reason TEXT;
+ALTER TABLE applications_cold ADD COLUMN accept_wait BIGINT;
+ALTER TABLE applications_cold ADD COLUMN placeholder_wait BIGINT;
+ALTER TABLE applications_cold ADD COLUMN allocation_wait BIGINT;
+ALTER TABLE applications_cold ADD COLUMN wait_reason TEXT;
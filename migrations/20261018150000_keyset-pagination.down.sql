@@ -0,0 +1,7 @@
+-- Drop indexes on the orders of the keyset paginated lists
+DROP INDEX IF EXISTS idx_nodes_partition_node_id;
+DROP INDEX IF EXISTS idx_history_type_timestamp_id;
+DROP INDEX IF EXISTS idx_applications_cold_partition_queue_submission_time_app_id;
+CREATE INDEX idx_applications_cold_partition_queue_submission_time
+    ON applications_cold (partition, queue_name, submission_time);
+DROP INDEX IF EXISTS idx_applications_partition_queue_submission_time_app_id;
@@ -0,0 +1,11 @@
+-- Create indexes on the orders of the keyset paginated lists, so that the rows after the cursor of a page are found
+-- without reading the rows of the previous pages: the applications of a queue by submission time and application ID,
+-- the history by timestamp and ID, and the nodes of a partition by node ID. The index on the submission times of the
+-- cold tier is replaced by an index ending with the application ID.
+CREATE INDEX idx_applications_partition_queue_submission_time_app_id
+    ON applications (partition, queue_name, submission_time, app_id);
+DROP INDEX IF EXISTS idx_applications_cold_partition_queue_submission_time;
+CREATE INDEX idx_applications_cold_partition_queue_submission_time_app_id
+    ON applications_cold (partition, queue_name, submission_time, app_id);
+CREATE INDEX idx_history_type_timestamp_id ON history (history_type, timestamp, id);
+CREATE INDEX idx_nodes_partition_node_id ON nodes (partition, node_id);
@@ -0,0 +1,3 @@
+-- Restore the index on the order of the history without the cluster ID
+DROP INDEX IF EXISTS idx_history_cluster_type_timestamp_id;
+CREATE INDEX idx_history_type_timestamp_id ON history (history_type, timestamp, id);
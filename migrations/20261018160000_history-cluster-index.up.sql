@@ -0,0 +1,4 @@
+-- Replace the index on the order of the history by an index starting with the cluster ID, as the history is read
+-- per cluster
+DROP INDEX IF EXISTS idx_history_type_timestamp_id;
+CREATE INDEX idx_history_cluster_type_timestamp_id ON history (cluster_id, history_type, timestamp, id);
@@ -0,0 +1,418 @@
+// Package client provides a Go client for the Yunikorn History Server REST API.
+//
+// The request and response types, as well as the low-level RawClient and ClientWithResponses,
+// are generated from the OpenAPI specification in api/openapi.yaml. Client wraps the generated
+// client with API key authentication, retries of idempotent requests, typed errors and pagination iterators:
+//
+//	c, err := client.New("http://localhost:8989", client.WithAPIKey(key))
+//	if err != nil {
+//		return err
+//	}
+//	apps := c.IterateApplications("default", "root.default", nil, 100)
+//	for apps.Next(ctx) {
+//		fmt.Println(apps.Value().ApplicationID)
+//	}
+//	if err := apps.Err(); err != nil {
+//		return err
+//	}
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Client is a client for the Yunikorn History Server REST API.
+// Its methods return the decoded response, or an *APIError if the server responded with an error.
+// Endpoints without a convenience method can be called using the generated Raw client.
+type Client struct {
+	// Raw is the generated client, which provides a typed method for every API endpoint.
+	Raw *ClientWithResponses
+}
+
+type options struct {
+	apiKey      string
+	timeout     time.Duration
+	transport   http.RoundTripper
+	retryPolicy RetryPolicy
+	userAgent   string
+}
+
+type Option func(*options)
+
+// WithAPIKey sets the API key sent as bearer token with every request.
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithTimeout sets the timeout of a single attempt of a request. Defaults to 30 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTransport sets the transport used to send requests. Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithRetryPolicy sets the retry policy of idempotent requests. Defaults to DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// New creates a new Client for the Yunikorn History Server at the given base URL, e.g. http://localhost:8989.
+func New(server string, opts ...Option) (*Client, error) {
+	o := options{
+		timeout:     30 * time.Second,
+		transport:   http.DefaultTransport,
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	httpClient := &http.Client{
+		Transport: &retryTransport{
+			next:    &timeoutTransport{next: o.transport, timeout: o.timeout},
+			policy:  o.retryPolicy,
+			sleeper: sleepContext,
+		},
+	}
+	raw, err := NewClientWithResponses(
+		server,
+		WithHTTPClient(httpClient),
+		WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Accept", "application/json")
+			// the generated types decode the camelCase field names, whatever the default of the server
+			req.Header.Set("X-Field-Naming", "camelCase")
+			if o.apiKey != "" {
+				req.Header.Set("Authorization", "Bearer "+o.apiKey)
+			}
+			if o.userAgent != "" {
+				req.Header.Set("User-Agent", o.userAgent)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create client: %v", err)
+	}
+	return &Client{Raw: raw}, nil
+}
+
+// APIError is returned when the server responds with an unexpected status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Problem contains the RFC 7807 problem returned by the server, if any.
+	Problem *ProblemDetails
+}
+
+func (e *APIError) Error() string {
+	if e.Problem != nil && e.Problem.Detail != nil {
+		return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, *e.Problem.Detail)
+	}
+	return fmt.Sprintf("request failed with status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// response is implemented by all generated response types.
+type response interface {
+	StatusCode() int
+}
+
+// result returns the decoded value of a successful response, or an *APIError if the request was not successful.
+func result[T any](resp response, body []byte, value *T) (T, error) {
+	var zero T
+	if resp.StatusCode() >= http.StatusOK && resp.StatusCode() < http.StatusMultipleChoices && value != nil {
+		return *value, nil
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode()}
+	var problem ProblemDetails
+	if json.Unmarshal(body, &problem) == nil {
+		apiErr.Problem = &problem
+	}
+	return zero, apiErr
+}
+
+// ListPartitions returns all partitions.
+func (c *Client) ListPartitions(ctx context.Context) ([]Partition, error) {
+	resp, err := c.Raw.GetPartitionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// ListQueues returns the root queues of the partition, including their children.
+func (c *Client) ListQueues(ctx context.Context, partition string) ([]Queue, error) {
+	resp, err := c.Raw.GetQueuesPerPartitionWithResponse(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// ListApplications returns a single page of the applications of a queue, ordered by submission time in descending order.
+// Use IterateApplications to iterate over all applications.
+func (c *Client) ListApplications(
+	ctx context.Context,
+	partition, queue string,
+	params *GetAppsPerPartitionPerQueueParams,
+) ([]Application, error) {
+	resp, err := c.Raw.GetAppsPerPartitionPerQueueWithResponse(ctx, partition, queue, params)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// IterateApplications returns an iterator over the applications of a queue which fetches pageSize applications per request.
+// The Limit of params is ignored, the Offset determines the first application returned.
+func (c *Client) IterateApplications(
+	partition, queue string,
+	params *GetAppsPerPartitionPerQueueParams,
+	pageSize int,
+) *Iterator[Application] {
+	var p GetAppsPerPartitionPerQueueParams
+	if params != nil {
+		p = *params
+	}
+	start := 0
+	if p.Offset != nil {
+		start = *p.Offset
+	}
+	return NewIterator(pageSize, start, func(ctx context.Context, offset, limit int) ([]Application, error) {
+		p.Offset = &offset
+		p.Limit = &limit
+		return c.ListApplications(ctx, partition, queue, &p)
+	})
+}
+
+// ListNodes returns the nodes of a partition.
+func (c *Client) ListNodes(ctx context.Context, partition string) ([]Node, error) {
+	resp, err := c.Raw.GetNodesPerPartitionWithResponse(ctx, partition, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// ApplicationsHistory returns the total number of applications over time.
+func (c *Client) ApplicationsHistory(ctx context.Context) ([]ApplicationHistory, error) {
+	resp, err := c.Raw.GetAppsHistoryWithResponse(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// ContainersHistory returns the total number of containers over time.
+func (c *Client) ContainersHistory(ctx context.Context) ([]ContainerHistory, error) {
+	resp, err := c.Raw.GetContainersHistoryWithResponse(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// NodeUtilizations returns the node utilization distribution of all partitions.
+func (c *Client) NodeUtilizations(ctx context.Context) ([]PartitionNodesUtilization, error) {
+	resp, err := c.Raw.GetNodeUtilizationsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// EventStatistics returns the number of received events per event type and change type.
+func (c *Client) EventStatistics(ctx context.Context) (EventStatistics, error) {
+	resp, err := c.Raw.GetEventStatisticsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// EventCategoryStatistics returns the number of received events per event category, in the order the categories are
+// configured.
+func (c *Client) EventCategoryStatistics(ctx context.Context) ([]EventCategoryCount, error) {
+	resp, err := c.Raw.GetEventCategoryStatisticsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// Liveness returns the liveness of the server.
+func (c *Client) Liveness(ctx context.Context) (*LivenessStatus, error) {
+	resp, err := c.Raw.GetLivenessWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Readiness returns the readiness of the server and its dependencies.
+func (c *Client) Readiness(ctx context.Context) (*ReadinessStatus, error) {
+	resp, err := c.Raw.GetReadinessWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// FeatureFlags returns the state of all feature flags.
+func (c *Client) FeatureFlags(ctx context.Context) ([]FeatureFlagStatus, error) {
+	resp, err := c.Raw.ListFeatureFlagsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// SetFeatureFlagOverride overrides the state of a feature flag at runtime.
+func (c *Client) SetFeatureFlagOverride(ctx context.Context, name string, enabled bool) (*FeatureFlagStatus, error) {
+	resp, err := c.Raw.OverrideFeatureFlagWithResponse(ctx, name, FeatureFlagOverride{Enabled: enabled})
+	if err != nil {
+		return nil, err
+	}
+	status, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ClearFeatureFlagOverride removes the runtime override of a feature flag.
+func (c *Client) ClearFeatureFlagOverride(ctx context.Context, name string) (*FeatureFlagStatus, error) {
+	resp, err := c.Raw.ResetFeatureFlagWithResponse(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	status, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RetentionPolicies returns the retention policies applied to the server ordered by name.
+func (c *Client) RetentionPolicies(ctx context.Context) ([]RetentionPolicy, error) {
+	resp, err := c.Raw.ListRetentionPoliciesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// EffectiveRetentionPolicies returns the effective retention policy of every known queue.
+// If partition is not empty, only the queues of that partition are returned.
+func (c *Client) EffectiveRetentionPolicies(ctx context.Context, partition string) ([]EffectiveRetentionPolicy, error) {
+	params := &ListEffectiveRetentionPoliciesParams{}
+	if partition != "" {
+		params.Partition = &partition
+	}
+	resp, err := c.Raw.ListEffectiveRetentionPoliciesWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// AlertRules returns the alert rules applied to the server and the result of their last evaluation.
+func (c *Client) AlertRules(ctx context.Context) ([]AlertRuleStatus, error) {
+	resp, err := c.Raw.ListAlertRulesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// LegalHolds returns the legal holds ordered by creation time in descending order.
+func (c *Client) LegalHolds(ctx context.Context, params *ListLegalHoldsParams) ([]LegalHold, error) {
+	resp, err := c.Raw.ListLegalHoldsWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return result(resp, resp.Body, resp.JSON200)
+}
+
+// PlaceLegalHold places a legal hold on a queue subtree or a single application,
+// so that their applications are not pruned until the hold is released.
+func (c *Client) PlaceLegalHold(ctx context.Context, hold LegalHoldRequest) (*LegalHold, error) {
+	resp, err := c.Raw.CreateLegalHoldWithResponse(ctx, hold)
+	if err != nil {
+		return nil, err
+	}
+	created, err := result(resp, resp.Body, resp.JSON201)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// LegalHold returns the legal hold with the given ID.
+func (c *Client) LegalHold(ctx context.Context, id HoldID) (*LegalHold, error) {
+	resp, err := c.Raw.GetLegalHoldWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	hold, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// ReleaseLegalHold releases the legal hold with the given ID, so that its applications can be pruned again.
+func (c *Client) ReleaseLegalHold(ctx context.Context, id HoldID, reason string) (*LegalHold, error) {
+	resp, err := c.Raw.ReleaseLegalHoldWithResponse(ctx, id, nil, LegalHoldRelease{Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+	hold, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// EraseUser pseudonymizes or deletes, depending on the mode, the records attributable to the user
+// and returns the erasure report.
+func (c *Client) EraseUser(ctx context.Context, user string, mode ErasureMode) (*UserErasure, error) {
+	resp, err := c.Raw.EraseUserWithResponse(ctx, UserErasureRequest{User: user, Mode: &mode})
+	if err != nil {
+		return nil, err
+	}
+	erasure, err := result(resp, resp.Body, resp.JSON200)
+	if err != nil {
+		return nil, err
+	}
+	return &erasure, nil
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientListApplications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws/v1/partition/default/queue/root.test/applications", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "camelCase", r.Header.Get("X-Field-Naming"))
+		assert.Equal(t, "alice", r.URL.Query().Get("user"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"applicationID":"app-1","partition":"default","queueName":"root.test",` +
+			`"queueId":"q1","createdAt":"2024-01-01T00:00:00Z","user":"alice"}]`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithAPIKey("secret"))
+	require.NoError(t, err)
+
+	user := "alice"
+	apps, err := c.ListApplications(context.Background(), "default", "root.test", &GetAppsPerPartitionPerQueueParams{User: &user})
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "app-1", apps[0].ApplicationID)
+	assert.Equal(t, "alice", *apps[0].User)
+}
+
+func TestClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"title":"Unauthorized","status":401,"detail":"invalid API key"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithAPIKey("wrong"))
+	require.NoError(t, err)
+
+	_, err = c.ListPartitions(context.Background())
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.EqualError(t, err, "request failed with status 401: invalid API key")
+}
+
+func TestClientSetFeatureFlagOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/ws/v1/admin/features/analytics", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"analytics","enabled":true,"default":false,"override":true}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	status, err := c.SetFeatureFlagOverride(context.Background(), "analytics", true)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.True(t, *status.Override)
+}
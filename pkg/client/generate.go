@@ -0,0 +1,3 @@
+package client
+
+//go:generate oapi-codegen -config oapi-codegen.yaml ../../api/openapi.yaml
@@ -0,0 +1,84 @@
+package client
+
+import "context"
+
+// DefaultPageSize is the page size used by iterators if none is provided.
+const DefaultPageSize = 100
+
+// PageFetcher fetches a page of at most limit items starting at offset.
+type PageFetcher[T any] func(ctx context.Context, offset, limit int) ([]T, error)
+
+// Iterator iterates over the items of a paginated API endpoint, fetching pages lazily.
+//
+//	for it.Next(ctx) {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch    PageFetcher[T]
+	pageSize int
+	offset   int
+	page     []T
+	index    int
+	current  T
+	last     bool
+	err      error
+}
+
+// NewIterator creates an Iterator which fetches pages of pageSize items, starting at offset.
+func NewIterator[T any](pageSize, offset int, fetch PageFetcher[T]) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Iterator[T]{fetch: fetch, pageSize: pageSize, offset: offset}
+}
+
+// Next advances the iterator to the next item, fetching the next page if needed.
+// It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index >= len(it.page) {
+		if it.last {
+			return false
+		}
+		page, err := it.fetch(ctx, it.offset, it.pageSize)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.offset += len(page)
+		// a short page means there are no further pages
+		it.last = len(page) < it.pageSize
+		if len(page) == 0 {
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All fetches all remaining items.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6}
+	var requests [][2]int
+	fetch := func(_ context.Context, offset, limit int) ([]int, error) {
+		requests = append(requests, [2]int{offset, limit})
+		if offset >= len(items) {
+			return nil, nil
+		}
+		return items[offset:min(offset+limit, len(items))], nil
+	}
+
+	t.Run("all pages", func(t *testing.T) {
+		requests = nil
+		got, err := NewIterator(3, 0, fetch).All(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, items, got)
+		assert.Equal(t, [][2]int{{0, 3}, {3, 3}, {6, 3}}, requests)
+	})
+
+	t.Run("exact multiple of page size", func(t *testing.T) {
+		requests = nil
+		got, err := NewIterator(7, 0, fetch).All(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, items, got)
+		assert.Equal(t, [][2]int{{0, 7}, {7, 7}}, requests)
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		requests = nil
+		got, err := NewIterator(0, 5, fetch).All(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 6}, got)
+		assert.Equal(t, [][2]int{{5, DefaultPageSize}}, requests)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		wantErr := errors.New("boom")
+		it := NewIterator(2, 0, func(_ context.Context, offset, limit int) ([]int, error) {
+			if offset > 0 {
+				return nil, wantErr
+			}
+			return []int{1, 2}, nil
+		})
+		got, err := it.All(context.Background())
+		assert.ErrorIs(t, err, wantErr)
+		assert.Equal(t, []int{1, 2}, got)
+		assert.False(t, it.Next(context.Background()))
+	})
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy specifies how idempotent requests are retried when they fail with a network error
+// or with a status code indicating a transient failure (429, 502, 503 or 504).
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// MinBackoff is the backoff before the first retry, which doubles with every further retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff between retries, including backoffs requested by the server via Retry-After.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used if none is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 200 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// backoff returns the backoff before the given retry, starting at 0.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, p.MaxBackoff)
+		}
+	}
+	backoff := p.MinBackoff
+	for i := 0; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.MaxBackoff)
+}
+
+// retryTransport retries idempotent requests according to the retry policy.
+type retryTransport struct {
+	next    http.RoundTripper
+	policy  RetryPolicy
+	sleeper func(ctx context.Context, d time.Duration) error
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	for retry := 0; ; retry++ {
+		resp, err := t.next.RoundTrip(req)
+		if retry >= t.policy.MaxRetries || !shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			// the body has been consumed and cannot be sent again
+			return resp, err
+		}
+
+		backoff := t.policy.backoff(retry, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := t.sleeper(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// timeoutTransport applies a timeout to every single attempt of a request, including reading the response body.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose cancels the context of the request when the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		statuses     []int
+		maxRetries   int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		"success": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		"transient failures": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		"retries exhausted": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxRetries:   2,
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 3,
+		},
+		"permanent failure": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusInternalServerError, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusInternalServerError,
+			wantAttempts: 1,
+		},
+		"put is retried with body": {
+			method:       http.MethodPut,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		"post is not retried": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if r.Method != http.MethodGet {
+					assert.Equal(t, "payload", string(body))
+				}
+				w.WriteHeader(tc.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			var slept []time.Duration
+			transport := &retryTransport{
+				next:   http.DefaultTransport,
+				policy: RetryPolicy{MaxRetries: tc.maxRetries, MinBackoff: time.Second, MaxBackoff: time.Minute},
+				sleeper: func(_ context.Context, d time.Duration) error {
+					slept = append(slept, d)
+					return nil
+				},
+			}
+
+			var body io.Reader
+			if tc.method != http.MethodGet {
+				body = strings.NewReader("payload")
+			}
+			req, err := http.NewRequest(tc.method, server.URL, body)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantAttempts, attempts.Load())
+			assert.Len(t, slept, int(tc.wantAttempts-1))
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.backoff(0, nil))
+	assert.Equal(t, 2*time.Second, policy.backoff(1, nil))
+	assert.Equal(t, 4*time.Second, policy.backoff(2, nil))
+	assert.Equal(t, 5*time.Second, policy.backoff(3, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	assert.Equal(t, 3*time.Second, policy.backoff(0, resp))
+	resp.Header.Set("Retry-After", "60")
+	assert.Equal(t, 5*time.Second, policy.backoff(0, resp))
+}
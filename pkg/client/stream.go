@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ndjsonContentType is the media type of newline delimited JSON, which the server streams one item per line.
+const ndjsonContentType = "application/x-ndjson"
+
+// Stream iterates over the items of a streamed response as they are received, so that large results can be
+// processed without holding them in memory. The stream must be closed.
+//
+//	for s.Next() {
+//		item := s.Value()
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+type Stream[T any] struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+	current T
+	err     error
+}
+
+// newStream creates a Stream of the response body, or returns an *APIError if the request was not successful.
+func newStream[T any](resp *http.Response, err error) (*Stream[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var problem ProblemDetails
+		if json.Unmarshal(body, &problem) == nil {
+			apiErr.Problem = &problem
+		}
+		return nil, apiErr
+	}
+	return &Stream[T]{body: resp.Body, decoder: json.NewDecoder(resp.Body)}, nil
+}
+
+// Next advances the stream to the next item. It returns false at the end of the stream or if an error occurred,
+// e.g. if the server aborted the response because of an error.
+func (s *Stream[T]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	var item T
+	if err := s.decoder.Decode(&item); err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.err = err
+		}
+		return false
+	}
+	s.current = item
+	return true
+}
+
+// Value returns the current item.
+func (s *Stream[T]) Value() T {
+	return s.current
+}
+
+// Err returns the error which stopped the stream, if any.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close closes the response body.
+func (s *Stream[T]) Close() error {
+	return s.body.Close()
+}
+
+// acceptNDJSON requests a streamed response.
+func acceptNDJSON(_ context.Context, req *http.Request) error {
+	req.Header.Set("Accept", ndjsonContentType)
+	return nil
+}
+
+// StreamApplications streams the applications of a queue, ordered by submission time in descending order.
+// The timeout of the client applies to the whole stream.
+func (c *Client) StreamApplications(
+	ctx context.Context,
+	partition, queue string,
+	params *GetAppsPerPartitionPerQueueParams,
+) (*Stream[Application], error) {
+	return newStream[Application](c.Raw.GetAppsPerPartitionPerQueue(ctx, partition, queue, params, acceptNDJSON))
+}
+
+// StreamApplicationsHistory streams the total number of applications over time.
+func (c *Client) StreamApplicationsHistory(ctx context.Context) (*Stream[ApplicationHistory], error) {
+	return newStream[ApplicationHistory](c.Raw.GetAppsHistory(ctx, nil, acceptNDJSON))
+}
+
+// StreamContainersHistory streams the total number of containers over time.
+func (c *Client) StreamContainersHistory(ctx context.Context) (*Stream[ContainerHistory], error) {
+	return newStream[ContainerHistory](c.Raw.GetContainersHistory(ctx, nil, acceptNDJSON))
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientStreamApplications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws/v1/partition/default/queue/root.test/applications", r.URL.Path)
+		assert.Equal(t, ndjsonContentType, r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", ndjsonContentType)
+		for i := 1; i <= 3; i++ {
+			_, _ = fmt.Fprintf(w, `{"applicationID":"app-%d","partition":"default","queueName":"root.test",`+
+				`"queueId":"q1","createdAt":"2024-01-01T00:00:00Z"}`+"\n", i)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	stream, err := c.StreamApplications(context.Background(), "default", "root.test", nil)
+	require.NoError(t, err)
+	defer stream.Close()
+	var ids []string
+	for stream.Next() {
+		ids = append(ids, stream.Value().ApplicationID)
+	}
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []string{"app-1", "app-2", "app-3"}, ids)
+}
+
+func TestClientStreamAborted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ndjsonContentType)
+		_, _ = w.Write([]byte(`{"totalApplications":"1","timestamp":1}` + "\n"))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetryPolicy(RetryPolicy{}))
+	require.NoError(t, err)
+
+	stream, err := c.StreamApplicationsHistory(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+	assert.True(t, stream.Next())
+	assert.False(t, stream.Next())
+	assert.ErrorIs(t, stream.Err(), io.ErrUnexpectedEOF)
+}
+
+func TestClientStreamAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"title":"Internal Server Error","status":500,"detail":"connection reset"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetryPolicy(RetryPolicy{}))
+	require.NoError(t, err)
+
+	_, err = c.StreamContainersHistory(context.Background())
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.EqualError(t, err, "request failed with status 500: connection reset")
+}
@@ -0,0 +1,630 @@
+// Package server runs the Yunikorn History Server within another process: the ingestion of the data of Yunikorn,
+// the repository storing it, the background jobs and the REST API serving it, as the yunikorn-history-server
+// command runs them. The database schema must be migrated beforehand, e.g. by the migrate command.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/oklog/run"
+	"go.uber.org/zap"
+
+	"github.com/G-Research/yunikorn-history-server/internal/alerting"
+	"github.com/G-Research/yunikorn-history-server/internal/autoscaler"
+	"github.com/G-Research/yunikorn-history-server/internal/breaker"
+	"github.com/G-Research/yunikorn-history-server/internal/broadcast"
+	"github.com/G-Research/yunikorn-history-server/internal/cache"
+	"github.com/G-Research/yunikorn-history-server/internal/canary"
+	"github.com/G-Research/yunikorn-history-server/internal/catchup"
+	"github.com/G-Research/yunikorn-history-server/internal/changes"
+	"github.com/G-Research/yunikorn-history-server/internal/clickhouse"
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/controller"
+	"github.com/G-Research/yunikorn-history-server/internal/database/migrations"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/internal/database/repository"
+	"github.com/G-Research/yunikorn-history-server/internal/dataquality"
+	"github.com/G-Research/yunikorn-history-server/internal/egress"
+	"github.com/G-Research/yunikorn-history-server/internal/encryption"
+	"github.com/G-Research/yunikorn-history-server/internal/export"
+	"github.com/G-Research/yunikorn-history-server/internal/faultinject"
+	"github.com/G-Research/yunikorn-history-server/internal/featureflag"
+	"github.com/G-Research/yunikorn-history-server/internal/health"
+	"github.com/G-Research/yunikorn-history-server/internal/lake"
+	"github.com/G-Research/yunikorn-history-server/internal/links"
+	"github.com/G-Research/yunikorn-history-server/internal/log"
+	"github.com/G-Research/yunikorn-history-server/internal/metrics"
+	"github.com/G-Research/yunikorn-history-server/internal/mirror"
+	"github.com/G-Research/yunikorn-history-server/internal/objectstore"
+	"github.com/G-Research/yunikorn-history-server/internal/pause"
+	"github.com/G-Research/yunikorn-history-server/internal/policy"
+	"github.com/G-Research/yunikorn-history-server/internal/quota"
+	"github.com/G-Research/yunikorn-history-server/internal/retention"
+	"github.com/G-Research/yunikorn-history-server/internal/scheduler"
+	"github.com/G-Research/yunikorn-history-server/internal/secrets"
+	"github.com/G-Research/yunikorn-history-server/internal/singleton"
+	"github.com/G-Research/yunikorn-history-server/internal/slo"
+	"github.com/G-Research/yunikorn-history-server/internal/snapshot"
+	"github.com/G-Research/yunikorn-history-server/internal/spark"
+	"github.com/G-Research/yunikorn-history-server/internal/spot"
+	"github.com/G-Research/yunikorn-history-server/internal/tiering"
+	"github.com/G-Research/yunikorn-history-server/internal/trace"
+	"github.com/G-Research/yunikorn-history-server/internal/transform"
+	"github.com/G-Research/yunikorn-history-server/internal/webservice"
+	"github.com/G-Research/yunikorn-history-server/internal/yunikorn"
+)
+
+// shutdownTimeout is how long the requests in flight may take to complete once the server stops.
+const shutdownTimeout = 30 * time.Second
+
+// Config is the configuration of the server, whose sections are documented by the schema of the configuration file.
+type Config = config.Config
+
+// LoadConfig loads the configuration from the file at the path, if not empty, and from the environment variables
+// prefixed with YHS_, which take precedence, as the yunikorn-history-server command does.
+func LoadConfig(path string) (*Config, error) {
+	return config.New(path)
+}
+
+// Repository is the repository of the data of the server, which the routes of extensions read and write.
+type Repository = repository.Repository
+
+// Route is a route of the REST API added by an extension. Its path must start with /ws/v1/ext/.
+type Route = webservice.Route
+
+// Candidate is a candidate implementation of a read method of the repository, e.g. an optimized query, which runs
+// next to the current implementation for a percentage of the calls of the REST API.
+type Candidate = canary.Candidate
+
+// Server is a Yunikorn History Server, which runs until the context of Run is canceled.
+type Server struct {
+	cfg        *Config
+	logger     *zap.SugaredLogger
+	version    string
+	mount      func(http.Handler)
+	routes     []Route
+	candidates []Candidate
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLogger sets the logger of the server. By default, a logger is created from the log section of the
+// configuration. The logger is global, so a process cannot embed several servers logging differently.
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithVersion sets the version reported by the health endpoints of the server.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithHandler serves the REST API from an HTTP server of the embedding process: mount is called with the handler of
+// the API once the server runs, which then does not listen on the addresses of the configuration. The admin
+// surface is served by the handler too, as the admin listener is not used either.
+func WithHandler(mount func(http.Handler)) Option {
+	return func(s *Server) {
+		s.mount = mount
+	}
+}
+
+// WithRoutes adds the routes of extensions to the REST API of the server, e.g. company-specific endpoints, which are
+// authenticated and limited like the routes of the API.
+func WithRoutes(routes ...Route) Option {
+	return func(s *Server) {
+		s.routes = append(s.routes, routes...)
+	}
+}
+
+// WithCandidates registers candidate implementations of the read methods of the repository. For their percentage of
+// the calls of the REST API, a candidate runs next to the current implementation, whose result is returned, and the
+// latencies and whether the results match are exposed as metrics. The percentages can be changed at runtime through
+// the admin API.
+func WithCandidates(candidates ...Candidate) Option {
+	return func(s *Server) {
+		s.candidates = append(s.candidates, candidates...)
+	}
+}
+
+// New returns a server with the configuration, which must have been loaded by LoadConfig or validated.
+func New(cfg *Config, opts ...Option) *Server {
+	s := &Server{cfg: cfg, version: "unknown"}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run starts the server and blocks until the context is canceled or one of the components of the server stops,
+// which stops the others. It returns an error if the server cannot start, e.g. if the configuration is invalid or
+// the database schema is not migrated, or if a component stopped with an error.
+func (s *Server) Run(ctx context.Context) error {
+	cfg := s.cfg
+	if s.logger != nil {
+		log.Logger = s.logger
+	} else if log.Logger == nil {
+		log.Init(&cfg.LogConfig)
+	}
+	ctx = log.ToContext(ctx, log.Logger)
+
+	if err := webservice.ValidateRoutes(s.routes); err != nil {
+		return fmt.Errorf("invalid routes: %w", err)
+	}
+	if err := canary.ValidateCandidates(s.candidates); err != nil {
+		return fmt.Errorf("invalid candidates: %w", err)
+	}
+
+	featureFlags, err := featureflag.New(&cfg.FeatureFlagsConfig)
+	if err != nil {
+		return fmt.Errorf("invalid feature flags config: %w", err)
+	}
+
+	// the outbound requests, including those of the secret store, are sent as the egress configuration specifies
+	if err := egress.Install(&cfg.EgressConfig); err != nil {
+		return fmt.Errorf("invalid egress config: %w", err)
+	}
+	provider, err := secrets.New(ctx, &cfg.SecretsConfig)
+	if err != nil {
+		return fmt.Errorf("could not create secret provider: %w", err)
+	}
+	resolver := secrets.NewResolver(provider, cfg.SecretsConfig.RefreshInterval)
+	// the database credentials are resolved again for new connections, so that they can be rotated
+	beforeConnect := resolver.BeforeConnect(cfg.PostgresConfig.Username, cfg.PostgresConfig.Password)
+	if err := resolver.ResolveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("could not resolve secrets: %w", err)
+	}
+
+	tokenSource, err := postgres.NewIAMTokenSource(ctx, &cfg.PostgresConfig)
+	if err != nil {
+		return fmt.Errorf("could not create database auth token source: %w", err)
+	}
+
+	poolOpts := []postgres.Option{postgres.WithBeforeConnect(beforeConnect), postgres.WithTokenAuth(tokenSource)}
+	if cfg.YHSConfig.ReadOnly {
+		poolOpts = append(poolOpts, postgres.WithReadOnly())
+	}
+	pool, err := postgres.NewConnectionPool(ctx, &cfg.PostgresConfig, poolOpts...)
+	if err != nil {
+		return fmt.Errorf("cannot parse Postgres connection config: %w", err)
+	}
+	// fail fast instead of failing queries on columns which do not exist or moved
+	if err := migrations.CheckSchemaVersion(ctx, pool); err != nil {
+		return err
+	}
+	cipher, err := encryption.New(&cfg.EncryptionConfig)
+	if err != nil {
+		return fmt.Errorf("invalid encryption config: %w", err)
+	}
+	linkRenderer, err := links.New(&cfg.LinksConfig)
+	if err != nil {
+		return fmt.Errorf("invalid links config: %w", err)
+	}
+	sparkHistoryServer, err := spark.NewHistoryServer(&cfg.SparkConfig)
+	if err != nil {
+		return fmt.Errorf("invalid spark config: %w", err)
+	}
+	transformer, err := transform.New(&cfg.TransformConfig)
+	if err != nil {
+		return fmt.Errorf("invalid transform config: %w", err)
+	}
+	postgresRepository, err := repository.NewPostgresRepository(
+		pool,
+		repository.WithCipher(cipher),
+		repository.WithSparkApplicationIDTag(cfg.SparkConfig.ApplicationIDTag),
+		repository.WithWorkflowIDTags(cfg.WorkflowsConfig.IDTags),
+		repository.WithRetryKeyTags(cfg.RetriesConfig.KeyTags),
+		repository.WithPriorityClassTag(cfg.PriorityConfig.ClassTag),
+		repository.WithTopologyAttributes(cfg.TopologyConfig.ZoneAttribute, cfg.TopologyConfig.RackAttribute),
+		repository.WithColdTier(cfg.StorageConfig.ColdAfter),
+	)
+	if err != nil {
+		log.Logger.Error("could not create db repository")
+		panic(err)
+	}
+	var faults *faultinject.Injector
+	if faultinject.Enabled {
+		log.Logger.Warn("fault injection is enabled, this build must not be used in production")
+		faults = faultinject.New()
+	}
+	// the duration of the operations of the database is exposed as Prometheus metrics, without the injected faults
+	// and the operations rejected by the circuit breaker
+	mainRepository := faultinject.NewRepository(metrics.NewRepository(postgresRepository), faults)
+	// the circuit breaker rejects the operations while the database is unavailable, so that they fail fast
+	mainRepository = breaker.NewRepository(mainRepository, breaker.New(&cfg.CircuitBreakerConfig))
+	var eventRepository repository.EventRepository = repository.NewInMemoryEventRepository()
+
+	g := newGroup(ctx)
+	defer g.cancel()
+
+	// in read-only mode, another server syncs the data and prunes applications
+	readOnly := cfg.YHSConfig.ReadOnly
+	if readOnly {
+		log.Logger.Info("starting in read-only mode")
+	}
+
+	// the background jobs of the server writing the data run on one replica only, which holds their locks
+	locker := singleton.NewLocker(pool, cfg.PostgresConfig.DbName+"/"+cfg.PostgresConfig.Schema)
+	if !readOnly {
+		g.add(locker.Run)
+	}
+
+	// the ingestion and the periodic jobs can be paused by the admin API, and the pauses apply to all replicas
+	pauses := pause.NewController(mainRepository)
+	if err := pauses.Load(ctx); err != nil {
+		return fmt.Errorf("could not load paused operations: %w", err)
+	}
+	g.add(pauses.Run)
+
+	// while the ingestion catches up, the configured jobs are paused in addition to those paused by the admin API
+	var jobPauses scheduler.Pauses = pauses
+	catchUp := catchup.NewMonitor(&cfg.CatchUpConfig, catchup.WithPauses(pauses))
+	if catchUp != nil {
+		jobPauses = catchUp
+	}
+
+	// the periodic jobs run on their configured schedules, or at the intervals of their sections by default
+	jobScheduler := scheduler.New(scheduler.WithLocker(locker), scheduler.WithPauses(jobPauses))
+	registerJob := func(
+		name string, interval time.Duration, run func(context.Context) error, opts ...scheduler.JobOption,
+	) error {
+		schedule, ok := cfg.JobsConfig.Schedules[name]
+		if !ok {
+			schedule = "@every " + interval.String()
+		}
+		if err := jobScheduler.Register(name, schedule, run, opts...); err != nil {
+			return fmt.Errorf("invalid jobs config: %w", err)
+		}
+		return nil
+	}
+
+	// the server writing the data notifies its feed of the changes and publishes them to the read-only servers
+	feed := changes.NewFeed()
+	if readOnly {
+		g.add(func(ctx context.Context) error {
+			return changes.Listen(ctx, pool, feed)
+		})
+	} else {
+		mainRepository = changes.NewRepository(mainRepository, feed)
+		publisher := changes.NewPublisher(pool, feed)
+		g.add(publisher.Run)
+	}
+
+	// the applications are replicated to ClickHouse by the server writing the data, and all servers query it
+	if cfg.ClickHouseConfig.Enabled() {
+		clickHouse := clickhouse.NewClient(&cfg.ClickHouseConfig)
+		if !readOnly {
+			sink := clickhouse.NewSink(mainRepository, clickHouse, clickhouse.WithBatchSize(cfg.ClickHouseConfig.BatchSize))
+			err := registerJob(
+				"clickhouse-sink",
+				cfg.ClickHouseConfig.Interval,
+				sink.Sync,
+				scheduler.RunOnStart(),
+				scheduler.Exclusive(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		mainRepository = clickhouse.NewRepository(mainRepository, clickHouse, cipher)
+	} else if !readOnly {
+		// the changes are not recorded anymore once ClickHouse is not configured
+		if err := mainRepository.StopAnalyticsSink(ctx, clickhouse.SinkName); err != nil {
+			log.Logger.Errorf("could not stop ClickHouse sink: %v", err)
+		}
+	}
+
+	// the server writing the data stores the traces of the applications, which are served by all servers
+	if cfg.TraceConfig.Enabled && !readOnly {
+		tracer := trace.NewRecorder(eventRepository, mainRepository, &cfg.TraceConfig)
+		eventRepository = tracer
+		g.add(tracer.Run)
+	}
+
+	// the server writing the data records the terminations of the nodes, with the allocations they disrupted
+	if cfg.SpotConfig.Enabled && !readOnly {
+		eventRepository = spot.NewRecorder(eventRepository, mainRepository, &cfg.SpotConfig)
+	}
+
+	// the server writing the data writes the raw events to the lake, which offline analytics read
+	if cfg.LakeConfig.Enabled() && !readOnly {
+		store, err := objectstore.New(ctx, &cfg.LakeConfig.ObjectStorageConfig)
+		if err != nil {
+			return fmt.Errorf("invalid lake config: %w", err)
+		}
+		opts := []lake.Option{
+			lake.WithFlushInterval(cfg.LakeConfig.FlushInterval),
+			lake.WithMaxFileEvents(cfg.LakeConfig.MaxFileEvents),
+			lake.WithMaxBufferedEvents(cfg.LakeConfig.MaxBufferedEvents),
+		}
+		if cfg.LakeConfig.Format == config.LakeFormatDelta {
+			opts = append(opts, lake.WithDeltaTable())
+		}
+		lakeWriter := lake.NewWriter(eventRepository, store, opts...)
+		eventRepository = lakeWriter
+		g.add(lakeWriter.Run)
+	}
+
+	responseCache := cache.New(&cfg.CacheConfig)
+	if responseCache != nil {
+		// a shared cache is invalidated for all servers by the server writing the data
+		if !readOnly || cfg.CacheConfig.Redis.Address == "" {
+			feed.Subscribe(func([]changes.Topic) {
+				responseCache.Invalidate()
+			})
+		}
+		g.add(responseCache.Run)
+	}
+
+	// past results change only when applications are erased or bulk deleted, recent results expire soon
+	resultCache := cache.NewResults(&cfg.CacheConfig)
+	if resultCache != nil {
+		if !readOnly || cfg.CacheConfig.Redis.Address == "" {
+			feed.Subscribe(func(topics []changes.Topic) {
+				if slices.Contains(topics, changes.TopicErasures) {
+					resultCache.Invalidate()
+				}
+			})
+		}
+		g.add(resultCache.Run)
+	}
+
+	// the scheduler at the configured host and the schedulers of the other clusters are synced alike
+	schedulers := cfg.YunikornConfig.Schedulers()
+	if len(schedulers) == 0 {
+		// without a host, the client of the default cluster connects to the default address of Yunikorn
+		schedulers = []config.YunikornConfig{cfg.YunikornConfig}
+	}
+	clients := make(yunikorn.ClusterApplications, len(schedulers))
+	var healthComponents []health.Component
+	// a server ingesting the data forwarded by collectors does not need to sync a Yunikorn of its own
+	syncYunikorn := !readOnly && (cfg.YunikornConfig.Host != "" || len(cfg.YunikornConfig.Clusters) > 0 ||
+		!cfg.IngestConfig.Enabled)
+	if cfg.IngestConfig.Enabled {
+		log.Logger.Infow("ingesting data forwarded by collectors", "sync_yunikorn", syncYunikorn)
+	}
+	// the server syncing Yunikorn pushes the events it ingests to the clients of its live event stream
+	var eventStream *broadcast.Hub
+	if syncYunikorn {
+		eventStream = broadcast.NewHub(broadcast.DefaultBufferSize)
+	}
+	for i, yunikornConfig := range schedulers {
+		client := yunikorn.NewRESTClient(&yunikornConfig)
+		clients[yunikornConfig.ClusterID] = client
+		if !syncYunikorn {
+			continue
+		}
+		syncTracker := yunikorn.NewSyncTracker(yunikornConfig.ClusterID)
+		opts := []yunikorn.Option{
+			yunikorn.WithSyncInterval(cfg.YHSConfig.DataSyncInterval),
+			yunikorn.WithFaultInjector(faults),
+			yunikorn.WithTransformer(transformer),
+			yunikorn.WithSyncTracker(syncTracker),
+			yunikorn.WithEpochRecorder(mainRepository),
+			yunikorn.WithPublisher(eventStream),
+			yunikorn.WithCluster(yunikornConfig.ClusterID),
+		}
+		// the lag of a single event stream drives the catch-up mode, which would flap between the lags of several
+		if i == 0 {
+			opts = append(opts, yunikorn.WithCatchUp(catchUp))
+		}
+		service := yunikorn.NewService(mainRepository, eventRepository, client, opts...)
+		lockName := "yunikorn-sync"
+		if yunikornConfig.ClusterID != "" {
+			lockName += "-" + yunikornConfig.ClusterID
+		}
+		g.add(func(ctx context.Context) error {
+			return locker.RunExclusive(ctx, lockName, func(ctx context.Context) error {
+				return pauses.RunUnlessPaused(ctx, pause.Ingestion, service.Run)
+			})
+		})
+		healthComponents = append(
+			healthComponents,
+			health.NewYunikornComponent(client, yunikornConfig.ClusterID),
+			health.NewYunikornSyncComponent(syncTracker, cfg.YunikornConfig.SyncFailureBudget),
+		)
+	}
+
+	policies := policy.NewStore()
+	if err := policies.ApplyRetentionConfig(&cfg.RetentionConfig); err != nil {
+		return fmt.Errorf("invalid retention config: %w", err)
+	}
+	pruner := retention.NewPruner(
+		mainRepository,
+		policies,
+		retention.WithHistoryTTL(cfg.RetentionConfig.HistoryTTL),
+		retention.WithBatchSize(cfg.RetentionConfig.BatchSize),
+	)
+	if !readOnly {
+		if err := registerJob("retention", cfg.RetentionConfig.Interval, pruner.Prune, scheduler.Exclusive()); err != nil {
+			return err
+		}
+	}
+
+	// the server writing the data moves the finished applications to the cold tier, which all servers query
+	if cfg.StorageConfig.ColdAfter > 0 && !readOnly {
+		mover := tiering.NewMover(
+			mainRepository,
+			cfg.StorageConfig.ColdAfter,
+			tiering.WithBatchSize(cfg.StorageConfig.BatchSize),
+		)
+		if err := registerJob("cold-tier", cfg.StorageConfig.Interval, mover.Move, scheduler.Exclusive()); err != nil {
+			return err
+		}
+	}
+
+	// the alert rules are evaluated by every server, each serving the statuses of the rules
+	alertingService := alerting.NewService(mainRepository, policies, alerting.WithWebhook(cfg.AlertingConfig.WebhookURL))
+	if err := registerJob("alerting", cfg.AlertingConfig.Interval, alertingService.Evaluate); err != nil {
+		return err
+	}
+
+	if !readOnly {
+		// the checks run on start too, since their interval is long and would be postponed by every restart otherwise
+		dataQualityChecker := dataquality.NewChecker(mainRepository)
+		err := registerJob(
+			"data-quality",
+			cfg.DataQualityConfig.Interval,
+			dataQualityChecker.Check,
+			scheduler.RunOnStart(),
+			scheduler.Exclusive(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !readOnly {
+		// the SLOs of the configuration file replace those of the previous configuration, while those defined via
+		// the API are kept
+		if err := mainRepository.SyncConfigSLOs(ctx, slo.ConfigSLOs(&cfg.SLOConfig)); err != nil {
+			return fmt.Errorf("could not store the SLOs of the configuration file: %w", err)
+		}
+		evaluator := slo.NewEvaluator(mainRepository, cfg.SLOConfig.HistoryTTL)
+		if err := registerJob("slo", cfg.SLOConfig.Interval, evaluator.Evaluate, scheduler.Exclusive()); err != nil {
+			return err
+		}
+	}
+
+	g.add(jobScheduler.Run)
+
+	if cfg.ControllerConfig.Enabled {
+		policyController, err := controller.New(&cfg.ControllerConfig, policies)
+		if err != nil {
+			return err
+		}
+		g.add(policyController.Run)
+	}
+
+	healthComponents = append(healthComponents, health.NewPostgresComponent(pool), health.NewSchemaComponent(pool))
+	healthService := health.New(s.version, healthComponents...)
+
+	wsOpts := []webservice.Option{
+		webservice.WithFeatureFlags(featureFlags),
+		webservice.WithAPIKeys(cfg.AuthConfig.APIKeys),
+		webservice.WithAdminListener(cfg.AdminConfig.Listen),
+		webservice.WithAdminAPIKeys(cfg.AdminConfig.APIKeys),
+		webservice.WithPolicies(policies, alertingService),
+		webservice.WithRetention(pruner),
+		webservice.WithFaultInjector(faults),
+		webservice.WithLinks(linkRenderer),
+		webservice.WithSparkHistoryServer(sparkHistoryServer),
+		webservice.WithCache(responseCache),
+		webservice.WithResultCache(resultCache, cfg.CacheConfig.Results),
+		webservice.WithNodeGroups(cfg.NodeGroupsConfig.Groups),
+		webservice.WithEventCategories(cfg.EventStatisticsConfig.Categories),
+		webservice.WithChanges(feed),
+		webservice.WithScheduler(jobScheduler),
+		webservice.WithPauses(pauses),
+		webservice.WithQuotas(quota.New(&cfg.QuotasConfig)),
+		webservice.WithRoutes(s.routes...),
+	}
+	if cfg.IngestConfig.Enabled {
+		wsOpts = append(wsOpts, webservice.WithIngest())
+	}
+	// the live tails of the queues merge the applications of the scheduler with the history
+	if cfg.YunikornConfig.Host != "" || len(cfg.YunikornConfig.Clusters) > 0 {
+		wsOpts = append(wsOpts, webservice.WithLiveApplications(clients))
+	}
+	if eventStream != nil {
+		wsOpts = append(wsOpts, webservice.WithEventStream(eventStream))
+	}
+	if cfg.AutoscalerConfig.Enabled {
+		converter := autoscaler.NewConverter(cfg.AutoscalerConfig.SourceComponents)
+		wsOpts = append(wsOpts, webservice.WithAutoscalerEvents(converter))
+	}
+	if cfg.TraceConfig.Enabled {
+		wsOpts = append(wsOpts, webservice.WithTraces())
+	}
+	if cfg.FallbackConfig.Enabled {
+		stale := cache.NewStale(cfg.FallbackConfig.MaxEntries, cfg.FallbackConfig.MaxAge)
+		wsOpts = append(wsOpts, webservice.WithFallback(stale, health.NewPostgresComponent(pool)))
+	}
+	if cfg.ExportsConfig.Enabled() {
+		exports, err := export.NewStore(ctx, &cfg.ExportsConfig)
+		if err != nil {
+			return fmt.Errorf("invalid exports config: %w", err)
+		}
+		wsOpts = append(wsOpts, webservice.WithExports(exports))
+	}
+	if cfg.SnapshotsConfig.Enabled() {
+		snapshots := snapshot.NewManager(pool, &cfg.SnapshotsConfig)
+		g.add(snapshots.Run)
+		wsOpts = append(wsOpts, webservice.WithSnapshots(snapshots))
+	}
+	// a sample of the read requests is mirrored to a secondary deployment, e.g. to validate a new release
+	if requestMirror := mirror.New(&cfg.MirrorConfig); requestMirror != nil {
+		g.add(requestMirror.Run)
+		wsOpts = append(wsOpts, webservice.WithMirror(requestMirror))
+	}
+	// the candidates of the repository methods only run for the calls of the API, not those of the background jobs
+	canaries := canary.New(mainRepository, s.candidates)
+	wsOpts = append(wsOpts, webservice.WithCanary(canaries))
+	apiRepository := canary.NewRepository(mainRepository, canaries)
+	ws := webservice.NewWebService(&cfg.YHSConfig, apiRepository, eventRepository, healthService, wsOpts...)
+	if s.mount != nil {
+		s.mount(ws.Handler(ctx))
+	} else {
+		// the web service serves the requests until it is shut down, as the context only scopes its setup
+		g.addStoppable(ws.Start, func() {
+			shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+			defer cancel()
+			_ = ws.Shutdown(shutdownCtx)
+		})
+	}
+
+	return g.run(ctx)
+}
+
+// group runs the components of a server until the context of the server is canceled or one of them stops, e.g.
+// because it failed, which cancels the context of the others.
+type group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	run.Group
+}
+
+// newGroup returns a group of components which run with a context derived from ctx.
+func newGroup(ctx context.Context) *group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &group{ctx: ctx, cancel: cancel}
+}
+
+// add adds a component, which must return once its context is canceled.
+func (g *group) add(component func(context.Context) error) {
+	g.addStoppable(component, func() {})
+}
+
+// addStoppable adds a component which is stopped by stop, in addition to the cancellation of its context.
+func (g *group) addStoppable(component func(context.Context) error, stop func()) {
+	g.Add(
+		func() error {
+			return component(g.ctx)
+		},
+		func(error) {
+			g.cancel()
+			stop()
+		},
+	)
+}
+
+// run runs the components until ctx is canceled or one of them stops. It returns the error of the component which
+// stopped first, or nil if ctx was canceled.
+func (g *group) run(ctx context.Context) error {
+	err := g.Run()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("server stopped: %w", err)
+	}
+	return nil
+}
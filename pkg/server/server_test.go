@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNew(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	mount := func(http.Handler) {}
+	s := New(&Config{}, WithLogger(logger), WithVersion("1.2.3"), WithHandler(mount))
+	assert.Same(t, logger, s.logger)
+	assert.Equal(t, "1.2.3", s.version)
+	assert.NotNil(t, s.mount)
+
+	assert.Equal(t, "unknown", New(&Config{}).version)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	cfg := &Config{}
+	cfg.FeatureFlagsConfig.Flags = map[string]bool{"unknown-feature": true}
+
+	err := New(cfg, WithLogger(zap.NewNop().Sugar())).Run(context.Background())
+	assert.ErrorContains(t, err, "invalid feature flags config")
+}
+
+func TestRunInvalidRoutes(t *testing.T) {
+	route := Route{Method: http.MethodGet, Path: "/ws/v1/teams"}
+	err := New(&Config{}, WithLogger(zap.NewNop().Sugar()), WithRoutes(route)).Run(context.Background())
+	assert.ErrorContains(t, err, "invalid routes")
+}
+
+func TestRunInvalidCandidates(t *testing.T) {
+	candidate := Candidate{Method: "UpsertApplications", New: func(repo Repository) Repository { return repo }}
+	err := New(&Config{}, WithLogger(zap.NewNop().Sugar()), WithCandidates(candidate)).Run(context.Background())
+	assert.ErrorContains(t, err, "invalid candidates")
+}
+
+func TestGroupStopsWhenComponentFails(t *testing.T) {
+	g := newGroup(context.Background())
+	defer g.cancel()
+	stopped := false
+	g.add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.addStoppable(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, func() {
+		stopped = true
+	})
+	g.add(func(context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	err := g.run(context.Background())
+	assert.ErrorContains(t, err, "connection refused")
+	assert.True(t, stopped)
+	assert.Error(t, g.ctx.Err())
+}
+
+func TestGroupStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := newGroup(ctx)
+	defer g.cancel()
+	g.add(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cancel()
+
+	assert.NoError(t, g.run(ctx))
+}
@@ -10,17 +10,6 @@ func GetTestLogConfig() *config.LogConfig {
 	}
 }
 
-func GetTestPostgresConfig() *config.PostgresConfig {
-	return &config.PostgresConfig{
-		Host:     "localhost",
-		Port:     30002,
-		Username: "postgres",
-		Password: "psw",
-		DbName:   "postgres",
-		SSLMode:  "disable",
-	}
-}
-
 func GetTestYunikornConfig() *config.YunikornConfig {
 	return &config.YunikornConfig{
 		Host:   "localhost",
@@ -0,0 +1,305 @@
+// Package harness provisions the dependencies of integration tests in containers using testcontainers, so that
+// integration tests only require a Docker compatible container runtime.
+//
+// A test creates its own schema with NewSchema, which applies the migrations, seeds the fixtures and drops the schema
+// when the test has finished:
+//
+//	h := harness.Shared(ctx, t)
+//	pool := h.NewSchema(ctx, t, "testdata/applications.sql")
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/G-Research/yunikorn-history-server/internal/config"
+	"github.com/G-Research/yunikorn-history-server/internal/database/postgres"
+	"github.com/G-Research/yunikorn-history-server/test/util"
+)
+
+const (
+	DefaultPostgresImage = "postgres:16-alpine"
+
+	postgresUsername = "postgres"
+	postgresPassword = "psw"
+	postgresDatabase = "postgres"
+	postgresPort     = nat.Port("5432/tcp")
+	yunikornPort     = nat.Port("9080/tcp")
+
+	startupTimeout = 2 * time.Minute
+)
+
+// Harness holds the configuration of the started containers.
+type Harness struct {
+	postgres      *config.PostgresConfig
+	yunikorn      *config.YunikornConfig
+	migrationsDir string
+}
+
+type options struct {
+	postgresImage string
+	yunikornImage string
+	migrationsDir string
+}
+
+type Option func(*options)
+
+// WithPostgresImage sets the image of the Postgres container, DefaultPostgresImage by default.
+func WithPostgresImage(image string) Option {
+	return func(o *options) {
+		o.postgresImage = image
+	}
+}
+
+// WithYunikorn starts a container of the given image, which serves the YuniKorn REST API on port 9080.
+// By default, YuniKorn is not started.
+func WithYunikorn(image string) Option {
+	return func(o *options) {
+		o.yunikornImage = image
+	}
+}
+
+// WithMigrationsDir sets the directory of the migrations applied by NewSchema.
+// By default, it is the migrations directory at the root of the module.
+func WithMigrationsDir(dir string) Option {
+	return func(o *options) {
+		o.migrationsDir = dir
+	}
+}
+
+// New starts the containers and registers their termination when the test has finished.
+func New(ctx context.Context, t *testing.T, opts ...Option) *Harness {
+	t.Helper()
+	h, containers, err := start(ctx, opts...)
+	for _, c := range containers {
+		testcontainers.CleanupContainer(t, c)
+	}
+	if err != nil {
+		t.Fatalf("could not start test harness: %v", err)
+	}
+	return h
+}
+
+var (
+	sharedOnce    sync.Once
+	sharedHarness *Harness
+	sharedErr     error
+)
+
+// Shared returns a harness with the default options which is shared by all tests of the package. Its containers
+// are started by the first call and removed by the testcontainers reaper when the test binary exits.
+func Shared(ctx context.Context, t *testing.T) *Harness {
+	t.Helper()
+	sharedOnce.Do(func() {
+		// the containers outlive the context of the first test
+		sharedHarness, _, sharedErr = start(context.WithoutCancel(ctx))
+	})
+	if sharedErr != nil {
+		t.Fatalf("could not start shared test harness: %v", sharedErr)
+	}
+	return sharedHarness
+}
+
+func start(ctx context.Context, opts ...Option) (*Harness, []testcontainers.Container, error) {
+	o := options{postgresImage: DefaultPostgresImage}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.migrationsDir == "" {
+		dir, err := moduleMigrationsDir()
+		if err != nil {
+			return nil, nil, err
+		}
+		o.migrationsDir = dir
+	}
+	h := &Harness{migrationsDir: o.migrationsDir}
+
+	var containers []testcontainers.Container
+	pg, err := tcpostgres.Run(ctx, o.postgresImage,
+		tcpostgres.WithUsername(postgresUsername),
+		tcpostgres.WithPassword(postgresPassword),
+		tcpostgres.WithDatabase(postgresDatabase),
+		testcontainers.WithWaitStrategy(
+			// Postgres restarts once after running the init scripts
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(startupTimeout),
+		),
+	)
+	if pg != nil {
+		containers = append(containers, pg)
+	}
+	if err != nil {
+		return nil, containers, fmt.Errorf("could not start postgres container: %v", err)
+	}
+	host, port, err := endpoint(ctx, pg, postgresPort)
+	if err != nil {
+		return nil, containers, err
+	}
+	h.postgres = &config.PostgresConfig{
+		Host:     host,
+		Port:     port,
+		Username: postgresUsername,
+		Password: postgresPassword,
+		DbName:   postgresDatabase,
+		SSLMode:  "disable",
+	}
+
+	if o.yunikornImage != "" {
+		yk, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        o.yunikornImage,
+				ExposedPorts: []string{string(yunikornPort)},
+				WaitingFor:   wait.ForHTTP("/ws/v1/scheduler/healthcheck").WithPort(yunikornPort).WithStartupTimeout(startupTimeout),
+			},
+			Started: true,
+		})
+		if yk != nil {
+			containers = append(containers, yk)
+		}
+		if err != nil {
+			return nil, containers, fmt.Errorf("could not start yunikorn container: %v", err)
+		}
+		host, port, err := endpoint(ctx, yk, yunikornPort)
+		if err != nil {
+			return nil, containers, err
+		}
+		h.yunikorn = &config.YunikornConfig{Host: host, Port: port}
+	}
+	return h, containers, nil
+}
+
+func endpoint(ctx context.Context, c testcontainers.Container, port nat.Port) (string, int, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", 0, fmt.Errorf("could not get container host: %v", err)
+	}
+	mapped, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", 0, fmt.Errorf("could not get mapped port %s: %v", port, err)
+	}
+	return host, mapped.Int(), nil
+}
+
+// moduleMigrationsDir returns the migrations directory at the root of the module, which is found from the working
+// directory of the test, the directory of the tested package.
+func moduleMigrationsDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return filepath.Join(dir, "migrations"), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("could not find the module root from %s", dir)
+		}
+		dir = parent
+	}
+}
+
+// PostgresConfig returns the configuration of the Postgres container. The configuration is a copy,
+// so that the caller may set the schema.
+func (h *Harness) PostgresConfig() *config.PostgresConfig {
+	cfg := *h.postgres
+	return &cfg
+}
+
+// YunikornConfig returns the configuration of the YuniKorn container, or nil if YuniKorn was not started.
+func (h *Harness) YunikornConfig() *config.YunikornConfig {
+	if h.yunikorn == nil {
+		return nil
+	}
+	cfg := *h.yunikorn
+	return &cfg
+}
+
+// MigrationsDir returns the directory of the migrations applied by NewSchema.
+func (h *Harness) MigrationsDir() string {
+	return h.migrationsDir
+}
+
+// NewSchema creates a new schema, applies the migrations, seeds the fixtures and returns a connection pool
+// using the schema. The schema is dropped when the test has finished.
+func (h *Harness) NewSchema(ctx context.Context, t *testing.T, fixtures ...string) *pgxpool.Pool {
+	t.Helper()
+	cfg := h.CreateSchema(ctx, t)
+	ApplyMigrations(t, cfg, h.migrationsDir)
+	pool := h.pool(ctx, t, cfg)
+	Seed(ctx, t, pool, fixtures...)
+	return pool
+}
+
+// ApplyMigrations applies the migrations of the directory to the database of the configuration.
+func ApplyMigrations(t *testing.T, cfg *config.PostgresConfig, migrationsDir string) {
+	t.Helper()
+	// golang-migrate is used directly, so that the migrations package can use the harness in its tests
+	m, err := migrate.New("file://"+migrationsDir, postgres.BuildConnectionStringFromConfig(cfg))
+	if err != nil {
+		t.Fatalf("could not create migrator: %v", err)
+	}
+	defer m.Close()
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("could not apply migrations: %v", err)
+	}
+}
+
+// CreateSchema creates a new empty schema, which is dropped when the test has finished,
+// and returns the configuration using it.
+func (h *Harness) CreateSchema(ctx context.Context, t *testing.T) *config.PostgresConfig {
+	t.Helper()
+	cfg := h.PostgresConfig()
+	cfg.Schema = "test_" + util.GenerateRandomAlphanum(t, 8)
+	pool := h.pool(ctx, t, h.PostgresConfig())
+	if _, err := pool.Exec(ctx, "CREATE SCHEMA "+cfg.Schema); err != nil {
+		t.Fatalf("could not create schema %s: %v", cfg.Schema, err)
+	}
+	t.Cleanup(func() {
+		// the context of the test may be cancelled already
+		if _, err := pool.Exec(context.WithoutCancel(ctx), "DROP SCHEMA "+cfg.Schema+" CASCADE"); err != nil {
+			t.Errorf("could not drop schema %s: %v", cfg.Schema, err)
+		}
+	})
+	return cfg
+}
+
+func (h *Harness) pool(ctx context.Context, t *testing.T, cfg *config.PostgresConfig) *pgxpool.Pool {
+	t.Helper()
+	pool, err := postgres.NewConnectionPool(ctx, cfg)
+	if err != nil {
+		t.Fatalf("could not create connection pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// Seed runs the SQL fixture files in order.
+func Seed(ctx context.Context, t *testing.T, pool *pgxpool.Pool, fixtures ...string) {
+	t.Helper()
+	for _, fixture := range fixtures {
+		content, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatalf("could not read fixture: %v", err)
+		}
+		if _, err := pool.Exec(ctx, string(content)); err != nil {
+			t.Fatalf("could not seed fixture %s: %v", fixture, err)
+		}
+	}
+}
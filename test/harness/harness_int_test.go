@@ -0,0 +1,29 @@
+package harness
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarness_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	h := New(ctx, t)
+	assert.Nil(t, h.YunikornConfig())
+
+	pool := h.NewSchema(ctx, t, "testdata/legal_holds.sql")
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT COUNT(*) FROM legal_holds").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// schemas are isolated from each other
+	other := h.NewSchema(ctx, t)
+	require.NoError(t, other.QueryRow(ctx, "SELECT COUNT(*) FROM legal_holds").Scan(&count))
+	assert.Equal(t, 0, count)
+}
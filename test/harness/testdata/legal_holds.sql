@@ -0,0 +1,2 @@
+INSERT INTO legal_holds (partition, queue_name, reason, created_by, created_at)
+VALUES ('default', 'root.default', 'audit', 'ops', 1719792000);